@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGMFactoryName(t *testing.T) {
+	f := &GMFactory{}
+	assert.Equal(t, f.Name(), GuomiBasedFactoryName)
+}
+
+func TestGMFactoryGetInvalidArgs(t *testing.T) {
+	f := &GMFactory{}
+
+	_, err := f.Get(nil)
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.Error(t, err, "Invalid config. It must not be nil.")
+
+	opts := &FactoryOpts{
+		SwOpts: &SwOpts{},
+	}
+	_, err = f.Get(opts)
+	assert.Error(t, err, "Failed initializing configuration at [0,GMSM3]")
+}
+
+func TestGMFactoryGet(t *testing.T) {
+	f := &GMFactory{}
+
+	opts := &FactoryOpts{
+		SwOpts: &SwOpts{
+			SecLevel:   256,
+			HashFamily: "GMSM3",
+			Ephemeral:  true,
+		},
+	}
+	csp, err := f.Get(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+
+	// SM3 is available, and SM2 keys are generated
+	_, err = csp.Hash([]byte("abc"), &bccsp.GMSM3Opts{})
+	assert.NoError(t, err)
+	_, err = csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "gmfactory")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts = &FactoryOpts{
+		SwOpts: &SwOpts{
+			SecLevel:     256,
+			HashFamily:   "GMSM3",
+			FileKeystore: &FileKeystoreOpts{KeyStorePath: dir},
+		},
+	}
+	csp, err = f.Get(opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+}
+
+func TestGetBCCSPFromOptsGM(t *testing.T) {
+	csp, err := GetBCCSPFromOpts(&FactoryOpts{
+		ProviderName: "GM",
+		SwOpts:       GetDefaultOpts().SwOpts,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package factory
 
 import (
+	"strings"
+
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/pkg/errors"
 )
@@ -51,9 +53,14 @@ func InitFactories(config *FactoryOpts) error {
 		// Initialize factories map
 		bccspMap = make(map[string]bccsp.BCCSP)
 
-		// Software-Based BCCSP
+		// Software-Based BCCSP, implementing either the standard or the national crypto algorithms
 		if config.SwOpts != nil {
-			f := &SWFactory{}
+			var f BCCSPFactory
+			if strings.ToUpper(config.ProviderName) == GuomiBasedFactoryName {
+				f = &GMFactory{}
+			} else {
+				f = &SWFactory{}
+			}
 			err := initBCCSP(f, config)
 			if err != nil {
 				factoriesInitError = errors.Wrapf(err, "Failed initializing BCCSP.")
@@ -87,6 +94,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 		f = &SWFactory{}
 	case "PLUGIN":
 		f = &PluginFactory{}
+	case "GM":
+		f = &GMFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.ProviderName)
 	}
@@ -19,12 +19,11 @@ package factory
 // returns a new instance every time
 func GetDefaultOpts() *FactoryOpts {
 	return &FactoryOpts{
-		ProviderName: "GM",
+		ProviderName: "SW",
 		SwOpts: &SwOpts{
-			HashFamily: "GMSM3",
+			HashFamily: "SHA2",
 			SecLevel:   256,
 			Ephemeral:  true,
-
 		},
 	}
 }
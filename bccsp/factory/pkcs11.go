@@ -53,7 +53,6 @@ func setFactories(config *FactoryOpts) error {
 	if config.ProviderName == "" {
 		config.ProviderName = "SW"
 	}
-	config.ProviderName = "GM"
 
 	if config.SwOpts == nil {
 		config.SwOpts = GetDefaultOpts().SwOpts
@@ -62,10 +61,10 @@ func setFactories(config *FactoryOpts) error {
 	// Initialize factories map
 	bccspMap = make(map[string]bccsp.BCCSP)
 
-	// Software-Based BCCSP
+	// Software-Based BCCSP, implementing either the standard or the national crypto algorithms
 	if config.SwOpts != nil {
 		var f BCCSPFactory
-		if strings.ToUpper(config.ProviderName) == "GM" {
+		if strings.ToUpper(config.ProviderName) == GuomiBasedFactoryName {
 			f = &GMFactory{}
 		} else {
 			f = &SWFactory{}
@@ -23,8 +23,8 @@ import (
 	"io"
 	"math/big"
 
-	"crypto/sm2"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 // //调用SM2接口生成SM2证书
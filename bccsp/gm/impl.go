@@ -21,10 +21,10 @@ import (
 	"hash"
 	"reflect"
 
-	"crypto/sm3"
 	"github.com/hyperledger/fabric/bccsp"
-	"github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"github.com/tjfoc/gmsm/sm3"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -39,12 +39,12 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	conf := &config{}
 	err := conf.setSecurityLevel(securityLevel, hashFamily)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed initializing configuration at [%v,%v]", securityLevel, hashFamily).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed initializing configuration at [%v,%v]", securityLevel, hashFamily)
 	}
 
 	// Check KeyStore
 	if keyStore == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid bccsp.KeyStore instance. It must be different from nil.")
+		return nil, errors.New("Invalid bccsp.KeyStore instance. It must be different from nil.")
 	}
 
 	// Set the encryptors
@@ -88,7 +88,7 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	// Set the key generators
 	keyGenerators := make(map[reflect.Type]KeyGenerator)
 	keyGenerators[reflect.TypeOf(&bccsp.GMSM2KeyGenOpts{})] = &gmsm2KeyGenerator{}
-	keyGenerators[reflect.TypeOf(&bccsp.GMSM4KeyGenOpts{})] = &gmsm4KeyGenerator{length: 32}
+	keyGenerators[reflect.TypeOf(&bccsp.GMSM4KeyGenOpts{})] = &gmsm4KeyGenerator{length: 16}
 	impl.keyGenerators = keyGenerators
 
 	// Set the key derivers
@@ -128,17 +128,17 @@ type impl struct {
 func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 	// Validate arguments
 	if opts == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Opts parameter. It must not be nil.")
+		return nil, errors.New("Invalid Opts parameter. It must not be nil.")
 	}
 
 	keyGenerator, found := csp.keyGenerators[reflect.TypeOf(opts)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'KeyGenOpts' provided [%v]", opts)
+		return nil, errors.Errorf("Unsupported 'KeyGenOpts' provided [%v]", opts)
 	}
 
 	k, err = keyGenerator.KeyGen(opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed generating key with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed generating key with opts [%v]", opts)
 	}
 
 	// If the key is not Ephemeral, store it.
@@ -146,7 +146,7 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 		// Store the key
 		err = csp.ks.StoreKey(k)
 		if err != nil {
-			return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed storing key [%s]. [%s]", opts.Algorithm(), err)
+			return nil, errors.Wrapf(err, "Failed storing key [%s]", opts.Algorithm())
 		}
 	}
 
@@ -157,19 +157,19 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
 	// Validate arguments
 	if k == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Key. It must not be nil.")
+		return nil, errors.New("Invalid Key. It must not be nil.")
 	}
 	if opts == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid opts. It must not be nil.")
+		return nil, errors.New("Invalid opts. It must not be nil.")
 	}
 	keyDeriver, found := csp.keyDerivers[reflect.TypeOf(k)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'Key' provided [%v]", k)
+		return nil, errors.Errorf("Unsupported 'Key' provided [%v]", k)
 	}
 
 	k, err = keyDeriver.KeyDeriv(k, opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed deriving key with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed deriving key with opts [%v]", opts)
 	}
 
 	// If the key is not Ephemeral, store it.
@@ -177,7 +177,7 @@ func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, e
 		// Store the key
 		err = csp.ks.StoreKey(k)
 		if err != nil {
-			return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed storing key [%s]. [%s]", opts.Algorithm(), err)
+			return nil, errors.Wrapf(err, "Failed storing key [%s]", opts.Algorithm())
 		}
 	}
 
@@ -188,20 +188,20 @@ func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, e
 func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
 	// Validate arguments
 	if raw == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid raw. It must not be nil.")
+		return nil, errors.New("Invalid raw. It must not be nil.")
 	}
 	if opts == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid opts. It must not be nil.")
+		return nil, errors.New("Invalid opts. It must not be nil.")
 	}
 
 	keyImporter, found := csp.keyImporters[reflect.TypeOf(opts)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'KeyImportOpts' provided [%v]", opts)
+		return nil, errors.Errorf("Unsupported 'KeyImportOpts' provided [%v]", opts)
 	}
 
 	k, err = keyImporter.KeyImport(raw, opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed importing key with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed importing key with opts [%v]", opts)
 	}
 
 	// If the key is not Ephemeral, store it.
@@ -209,7 +209,7 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		// Store the key
 		err = csp.ks.StoreKey(k)
 		if err != nil {
-			return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed storing imported key with opts [%v]", opts).WrapError(err)
+			return nil, errors.Wrapf(err, "Failed storing imported key with opts [%v]", opts)
 		}
 	}
 
@@ -220,7 +220,7 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
 	k, err = csp.ks.GetKey(ski)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed getting key for SKI [%v]", ski).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed getting key for SKI [%v]", ski)
 	}
 
 	return
@@ -230,17 +230,17 @@ func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
 func (csp *impl) Hash(msg []byte, opts bccsp.HashOpts) (digest []byte, err error) {
 	// Validate arguments
 	if opts == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid opts. It must not be nil.")
+		return nil, errors.New("Invalid opts. It must not be nil.")
 	}
 
 	hasher, found := csp.hashers[reflect.TypeOf(opts)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'HashOpt' provided [%v]", opts)
+		return nil, errors.Errorf("Unsupported 'HashOpt' provided [%v]", opts)
 	}
 
 	digest, err = hasher.Hash(msg, opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed hashing with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed hashing with opts [%v]", opts)
 	}
 
 	return
@@ -250,17 +250,17 @@ func (csp *impl) Hash(msg []byte, opts bccsp.HashOpts) (digest []byte, err error
 func (csp *impl) GetHash(opts bccsp.HashOpts) (h hash.Hash, err error) {
 	// Validate arguments
 	if opts == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid opts. It must not be nil.")
+		return nil, errors.New("Invalid opts. It must not be nil.")
 	}
 
 	hasher, found := csp.hashers[reflect.TypeOf(opts)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'HashOpt' provided [%v]", opts)
+		return nil, errors.Errorf("Unsupported 'HashOpt' provided [%v]", opts)
 	}
 
 	h, err = hasher.GetHash(opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed getting hash function with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed getting hash function with opts [%v]", opts)
 	}
 
 	return
@@ -271,20 +271,20 @@ func (csp *impl) GetHash(opts bccsp.HashOpts) (h hash.Hash, err error) {
 func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
 	// Validate arguments
 	if k == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Key. It must not be nil.")
+		return nil, errors.New("Invalid Key. It must not be nil.")
 	}
 	if len(digest) == 0 {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid digest. Cannot be empty.")
+		return nil, errors.New("Invalid digest. Cannot be empty.")
 	}
 
 	signer, found := csp.signers[reflect.TypeOf(k)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'SignKey' provided [%v]", k)
+		return nil, errors.Errorf("Unsupported 'SignKey' provided [%v]", k)
 	}
 
 	signature, err = signer.Sign(k, digest, opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed signing with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed signing with opts [%v]", opts)
 	}
 
 	return
@@ -294,23 +294,23 @@ func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signat
 func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
 	// Validate arguments
 	if k == nil {
-		return false, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Key. It must not be nil.")
+		return false, errors.New("Invalid Key. It must not be nil.")
 	}
 	if len(signature) == 0 {
-		return false, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid signature. Cannot be empty.")
+		return false, errors.New("Invalid signature. Cannot be empty.")
 	}
 	if len(digest) == 0 {
-		return false, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid digest. Cannot be empty.")
+		return false, errors.New("Invalid digest. Cannot be empty.")
 	}
 
 	verifier, found := csp.verifiers[reflect.TypeOf(k)]
 	if !found {
-		return false, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'VerifyKey' provided [%T]", k)
+		return false, errors.Errorf("Unsupported 'VerifyKey' provided [%T]", k)
 	}
 
 	valid, err = verifier.Verify(k, signature, digest, opts)
 	if err != nil {
-		return false, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed verifing with opts [%v]", opts).WrapError(err)
+		return false, errors.Wrapf(err, "Failed verifing with opts [%v]", opts)
 	}
 
 	return
@@ -320,12 +320,12 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
 	// Validate arguments
 	if k == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Key. It must not be nil.")
+		return nil, errors.New("Invalid Key. It must not be nil.")
 	}
 
 	encryptor, found := csp.encryptors[reflect.TypeOf(k)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'EncryptKey' provided [%v]", k)
+		return nil, errors.Errorf("Unsupported 'EncryptKey' provided [%v]", k)
 	}
 
 	return encryptor.Encrypt(k, plaintext, opts)
@@ -335,17 +335,17 @@ func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts
 func (csp *impl) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
 	// Validate arguments
 	if k == nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.BadRequest, "Invalid Key. It must not be nil.")
+		return nil, errors.New("Invalid Key. It must not be nil.")
 	}
 
 	decryptor, found := csp.decryptors[reflect.TypeOf(k)]
 	if !found {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.NotFound, "Unsupported 'DecryptKey' provided [%v]", k)
+		return nil, errors.Errorf("Unsupported 'DecryptKey' provided [%v]", k)
 	}
 
 	plaintext, err = decryptor.Decrypt(k, ciphertext, opts)
 	if err != nil {
-		return nil, errors.ErrorWithCallstack(errors.BCCSP, errors.Internal, "Failed decrypting with opts [%v]", opts).WrapError(err)
+		return nil, errors.Wrapf(err, "Failed decrypting with opts [%v]", opts)
 	}
 
 	return
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm4"
+)
+
+func newTestCSP(t *testing.T) bccsp.BCCSP {
+	csp, err := New(256, "GMSM3", NewDummyKeyStore())
+	assert.NoError(t, err)
+	return csp
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(256, "GMSM3", nil)
+	assert.EqualError(t, err, "Invalid bccsp.KeyStore instance. It must be different from nil.")
+
+	_, err = New(128, "GMSM3", NewDummyKeyStore())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed initializing configuration at [128,GMSM3]")
+}
+
+func TestSM3StandardVectors(t *testing.T) {
+	csp := newTestCSP(t)
+
+	// Standard test vectors from GM/T 0004-2012, Appendix A
+	for msg, expected := range map[string]string{
+		"abc": "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0",
+		"abcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcdabcd": "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732",
+	} {
+		digest, err := csp.Hash([]byte(msg), &bccsp.GMSM3Opts{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, hex.EncodeToString(digest))
+
+		h, err := csp.GetHash(&bccsp.GMSM3Opts{})
+		assert.NoError(t, err)
+		h.Write([]byte(msg))
+		assert.Equal(t, expected, hex.EncodeToString(h.Sum(nil)))
+	}
+
+	_, err := csp.Hash([]byte("abc"), nil)
+	assert.EqualError(t, err, "Invalid opts. It must not be nil.")
+}
+
+func TestSM2SignVerify(t *testing.T) {
+	csp := newTestCSP(t)
+
+	k, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+
+	msg := []byte("Hello World")
+	signature, err := csp.Sign(k, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := csp.Verify(k, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+	valid, err = csp.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(pk, signature, []byte("Hello World!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = csp.Sign(k, nil, nil)
+	assert.EqualError(t, err, "Invalid digest. Cannot be empty.")
+	_, err = csp.Verify(pk, nil, msg, nil)
+	assert.EqualError(t, err, "Invalid signature. Cannot be empty.")
+}
+
+func TestSM2Interop(t *testing.T) {
+	csp := newTestCSP(t)
+
+	// Keys and signatures produced outside of the BCCSP are understood by it, and vice versa
+	sk, err := sm2.GenerateKey(sm2.P256Sm2(), rand.Reader)
+	assert.NoError(t, err)
+	skDER, err := sm2.MarshalSm2UnecryptedPrivateKey(sk)
+	assert.NoError(t, err)
+	pkDER, err := sm2.MarshalSm2PublicKey(&sk.PublicKey)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyImport(skDER, &bccsp.GMSM2PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	pk, err := csp.KeyImport(pkDER, &bccsp.GMSM2PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, pkDER, raw)
+
+	msg := []byte("Hello World")
+	signature, err := sk.Sign(rand.Reader, msg, nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	signature, err = csp.Sign(k, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, sk.PublicKey.Verify(msg, signature))
+
+	// The public key of a certificate is imported as well
+	certPK, err := csp.KeyImport(&sm2.Certificate{PublicKey: &sk.PublicKey}, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), certPK.SKI())
+	valid, err = csp.Verify(certPK, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = csp.KeyImport(&sm2.Certificate{PublicKey: "not a key"}, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+}
+
+func TestSM4KeyEncryptDecrypt(t *testing.T) {
+	csp := newTestCSP(t)
+
+	k, err := csp.KeyGen(&bccsp.GMSM4KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, k.Symmetric())
+
+	msg := []byte("private data at rest")
+	ct, err := csp.Encrypt(k, msg, nil)
+	assert.NoError(t, err)
+	pt, err := csp.Decrypt(k, ct, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	// An imported key with the standard vector key decrypts what the generated key cannot
+	ik, err := csp.KeyImport(sm4VectorKey, &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.NoError(t, err)
+	ct, err = csp.Encrypt(ik, sm4VectorPlaintext, nil)
+	assert.NoError(t, err)
+	pt, err = csp.Decrypt(ik, ct, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sm4VectorPlaintext, pt)
+
+	_, err = csp.KeyImport([]byte("short key"), &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid key length [9]")
+}
+
+func TestSM4KeyDeriv(t *testing.T) {
+	csp := newTestCSP(t)
+
+	k, err := csp.KeyImport(sm4VectorKey, &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	dk, err := csp.KeyDeriv(k, &bccsp.HMACDeriveKeyOpts{Temporary: true, Arg: []byte("argument")})
+	assert.NoError(t, err)
+	raw, err := dk.Bytes()
+	assert.NoError(t, err)
+	assert.Len(t, raw, sm4.BlockSize)
+	assert.NotEqual(t, sm4VectorKey, raw)
+
+	// The derived key is a usable SM4 key
+	ct, err := csp.Encrypt(dk, []byte("Hello World"), nil)
+	assert.NoError(t, err)
+	pt, err := csp.Decrypt(dk, ct, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("Hello World"), pt)
+
+	_, err = csp.KeyDeriv(k, &bccsp.HMACTruncated256AESDeriveKeyOpts{Temporary: true, Arg: []byte("argument")})
+	assert.Error(t, err)
+}
@@ -1,5 +1,6 @@
 /*
 	密钥派生
+
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
@@ -15,12 +16,12 @@ limitations under the License.
 package gm
 
 import (
+	"crypto/hmac"
 	"errors"
-
 	"fmt"
+
 	"github.com/hyperledger/fabric/bccsp"
-	"crypto/hmac"
-	"reflect"
+	"github.com/tjfoc/gmsm/sm4"
 )
 
 type gmsm4PrivateKeyKeyDeriver struct {
@@ -35,13 +36,13 @@ func (kd *gmsm4PrivateKeyKeyDeriver) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOp
 	}
 
 	aesK := k.(*gmsm4PrivateKey)
-	fmt.Println(reflect.TypeOf(opts)) //*bccsp.HMACDeriveKeyOpts
 	switch opts.(type) {
 	case *bccsp.HMACDeriveKeyOpts:
 		hmacOpts := opts.(*bccsp.HMACDeriveKeyOpts)
 		mac := hmac.New(kd.bccsp.conf.hashFunction, aesK.privKey)
 		mac.Write(hmacOpts.Argument())
-		return &gmsm4PrivateKey{mac.Sum(nil), true}, nil
+		// SM4 keys are one block long
+		return &gmsm4PrivateKey{mac.Sum(nil)[:sm4.BlockSize], true}, nil
 	default:
 		return nil, fmt.Errorf("Unsupported 'KeyDerivOpts' provided [%v]", opts)
 	}
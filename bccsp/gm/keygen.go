@@ -16,11 +16,11 @@ limitations under the License.
 package gm
 
 import (
+	"crypto/rand"
 	"fmt"
 
-	"crypto/sm2"
 	"github.com/hyperledger/fabric/bccsp"
-	"crypto/rand"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 //定义国密SM2 keygen 结构体，实现 KeyGenerator 接口
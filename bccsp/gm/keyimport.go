@@ -23,7 +23,8 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/utils"
-	"crypto/sm2"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm4"
 )
 
 //实现内部的 KeyImporter 接口
@@ -39,6 +40,10 @@ func (*gmsm4ImportKeyOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyI
 		return nil, errors.New("Invalid raw material. It must not be nil.")
 	}
 
+	if len(sm4Raw) != sm4.BlockSize {
+		return nil, fmt.Errorf("Invalid key length [%d]. Must be %d bytes", len(sm4Raw), sm4.BlockSize)
+	}
+
 	return &gmsm4PrivateKey{utils.Clone(sm4Raw), false}, nil
 }
 
@@ -178,26 +183,11 @@ func (ki *x509PublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bc
 	}
 
 	pk := sm2Cert.PublicKey
-	switch pk.(type) {
+	switch pk := pk.(type) {
 	case sm2.PublicKey:
-		fmt.Printf("bccsp gm keyimport pk is sm2.PublicKey")
-		sm2PublickKey, ok := pk.(sm2.PublicKey)
-		if !ok {
-			return nil, errors.New("Parse interface []  to sm2 pk error")
-		}
-		der, err := sm2.MarshalSm2PublicKey(&sm2PublickKey)
-		if err != nil {
-			return nil, errors.New("MarshalSm2PublicKey error")
-		}
-
-		return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
-			der,
-			&bccsp.GMSM2PublicKeyImportOpts{Temporary: opts.Ephemeral()})
+		return ki.importSM2PublicKey(&pk, opts)
 	case *sm2.PublicKey:
-		fmt.Printf("bccsp gm keyimport pk is *sm2.PublicKey")
-		return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
-			pk,
-			&bccsp.GMSM2PublicKeyImportOpts{Temporary: opts.Ephemeral()})
+		return ki.importSM2PublicKey(pk, opts)
 	case *ecdsa.PublicKey:
 		return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.ECDSAGoPublicKeyImportOpts{})].KeyImport(
 			pk,
@@ -205,26 +195,16 @@ func (ki *x509PublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bc
 	default:
 		return nil, errors.New("Certificate's public key type not recognized. Supported keys: [GMSM2]")
 	}
+}
 
-	// return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
-	// 	pk,
-	// 	&bccsp.GMSM2PublicKeyImportOpts{Temporary:opts.Ephemeral()})
-
-	// switch pk.(type) {
-	// case *sm2.PublicKey:
-
-	// 	ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
-	// 		pk,
-	// 		&bccsp.GMSM2PublicKeyImportOpts{Temporary:opts.Ephemeral()})
+// importSM2PublicKey imports the given public key through its PKIX DER encoding
+func (ki *x509PublicKeyImportOptsKeyImporter) importSM2PublicKey(pk *sm2.PublicKey, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, err := sm2.MarshalSm2PublicKey(pk)
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling GMSM2 public key [%s]", err)
+	}
 
-	// 	// return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
-	// 	// 	pk,
-	// 	// 	&bccsp.GMSM2PublicKeyImportOpts{Temporary: opts.Ephemeral()})
-	// // case *rsa.PublicKey:
-	// // 	return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.RSAGoPublicKeyImportOpts{})].KeyImport(
-	// // 		pk,
-	// // 		&bccsp.RSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
-	// default:
-	// 	return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA]")
-	// }
+	return ki.bccsp.keyImporters[reflect.TypeOf(&bccsp.GMSM2PublicKeyImportOpts{})].KeyImport(
+		der,
+		&bccsp.GMSM2PublicKeyImportOpts{Temporary: opts.Ephemeral()})
 }
@@ -25,7 +25,7 @@ import (
 	"math/big"
 
 	"github.com/hyperledger/fabric/bccsp"
-	"crypto/sm2"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 type SM2Signature struct {
@@ -22,7 +22,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/bccsp"
-	"crypto/sm2"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 type gmsm2PrivateKey struct {
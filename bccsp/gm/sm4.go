@@ -16,12 +16,15 @@ limitations under the License.
 package gm
 
 import (
+	"bytes"
+	"crypto/cipher"
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/hyperledger/fabric/bccsp"
-	"crypto/sm4"
+	"github.com/tjfoc/gmsm/sm4"
 )
 
 // GetRandomBytes returns len random looking bytes
@@ -43,53 +46,115 @@ func GetRandomBytes(len int) ([]byte, error) {
 	return buffer, nil
 }
 
-// AESCBCPKCS7Encrypt combines CBC encryption and PKCS7 padding
+func pkcs7Padding(src []byte) []byte {
+	padding := sm4.BlockSize - len(src)%sm4.BlockSize
+	padtext := bytes.Repeat([]byte{byte(padding)}, padding)
+	return append(src, padtext...)
+}
+
+func pkcs7UnPadding(src []byte) ([]byte, error) {
+	length := len(src)
+	if length == 0 {
+		return nil, errors.New("Invalid pkcs7 padding (empty plaintext)")
+	}
+	unpadding := int(src[length-1])
+
+	if unpadding > sm4.BlockSize || unpadding == 0 {
+		return nil, errors.New("Invalid pkcs7 padding (unpadding > sm4.BlockSize || unpadding == 0)")
+	}
+
+	pad := src[len(src)-unpadding:]
+	for i := 0; i < unpadding; i++ {
+		if pad[i] != byte(unpadding) {
+			return nil, errors.New("Invalid pkcs7 padding (pad[i] != unpadding)")
+		}
+	}
+
+	return src[:(length - unpadding)], nil
+}
+
+func sm4CBCEncryptWithRand(prng io.Reader, key, s []byte) ([]byte, error) {
+	if len(s)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid plaintext. It must be a multiple of the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, sm4.BlockSize+len(s))
+	iv := ciphertext[:sm4.BlockSize]
+	if _, err := io.ReadFull(prng, iv); err != nil {
+		return nil, err
+	}
+
+	mode := cipher.NewCBCEncrypter(block, iv)
+	mode.CryptBlocks(ciphertext[sm4.BlockSize:], s)
+
+	return ciphertext, nil
+}
+
+func sm4CBCDecrypt(key, src []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(src) < sm4.BlockSize {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+	iv := src[:sm4.BlockSize]
+	src = src[sm4.BlockSize:]
+
+	if len(src)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+
+	pt := make([]byte, len(src))
+	mode := cipher.NewCBCDecrypter(block, iv)
+	mode.CryptBlocks(pt, src)
+
+	return pt, nil
+}
+
+// SM4Encrypt combines SM4 CBC encryption and PKCS7 padding.
+// The random IV is prepended to the returned ciphertext
 func SM4Encrypt(key, src []byte) ([]byte, error) {
-	// // First pad
-	// tmp := pkcs7Padding(src)
-
-	// // Then encrypt
-	// return aesCBCEncrypt(key, tmp)
-	dst := make([]byte, len(src))
-	sm4.EncryptBlock(key, dst, src)
-	return dst, nil
+	return SM4EncryptWithRand(rand.Reader, key, src)
 }
 
-// AESCBCPKCS7Decrypt combines CBC decryption and PKCS7 unpadding
+// SM4EncryptWithRand combines SM4 CBC encryption and PKCS7 padding using as prng the passed to the function
+func SM4EncryptWithRand(prng io.Reader, key, src []byte) ([]byte, error) {
+	// First pad
+	tmp := pkcs7Padding(src)
+
+	// Then encrypt
+	return sm4CBCEncryptWithRand(prng, key, tmp)
+}
+
+// SM4Decrypt combines SM4 CBC decryption and PKCS7 unpadding
 func SM4Decrypt(key, src []byte) ([]byte, error) {
 	// First decrypt
-	// pt, err := aesCBCDecrypt(key, src)
-	// if err == nil {
-	// 	return pkcs7UnPadding(pt)
-	// }
-
-	dst := make([]byte, len(src))
-	sm4.DecryptBlock(key, dst, src)
-	return dst, nil
+	pt, err := sm4CBCDecrypt(key, src)
+	if err != nil {
+		return nil, err
+	}
+
+	// Then remove padding
+	return pkcs7UnPadding(pt)
 }
 
 type gmsm4Encryptor struct{}
 
 //实现 Encryptor 接口
 func (*gmsm4Encryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
-
 	return SM4Encrypt(k.(*gmsm4PrivateKey).privKey, plaintext)
-	//return AESCBCPKCS7Encrypt(k.(*sm4PrivateKey).privKey, plaintext)
-
-	// key := k.(*gmsm4PrivateKey).privKey
-	// var en = make([]byte, 16)
-	// sms4(plaintext, 16, key, en, 1)
-	// return en, nil
 }
 
 type gmsm4Decryptor struct{}
 
 //实现 Decryptor 接口
 func (*gmsm4Decryptor) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
-
 	return SM4Decrypt(k.(*gmsm4PrivateKey).privKey, ciphertext)
-	// var dc = make([]byte, 16)
-	// key := k.(*gmsm4PrivateKey).privKey
-	// sms4(ciphertext, 16, key, dc, 0)
-	// return dc, nil
 }
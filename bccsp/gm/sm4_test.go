@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm4"
+)
+
+// Standard test vector from GM/T 0002-2012, Appendix A.1
+var (
+	sm4VectorKey        = mustDecodeHex("0123456789abcdeffedcba9876543210")
+	sm4VectorPlaintext  = mustDecodeHex("0123456789abcdeffedcba9876543210")
+	sm4VectorCiphertext = mustDecodeHex("681edf34d206965e86b3e94f536e4246")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestSM4StandardVector(t *testing.T) {
+	// With an all-zero IV, the first CBC block is the plain block encryption
+	ct, err := SM4EncryptWithRand(bytes.NewReader(make([]byte, sm4.BlockSize)), sm4VectorKey, sm4VectorPlaintext)
+	assert.NoError(t, err)
+	assert.Len(t, ct, 3*sm4.BlockSize, "IV, one block of data and one block of padding expected")
+	assert.Equal(t, make([]byte, sm4.BlockSize), ct[:sm4.BlockSize])
+	assert.Equal(t, sm4VectorCiphertext, ct[sm4.BlockSize:2*sm4.BlockSize])
+
+	pt, err := SM4Decrypt(sm4VectorKey, ct)
+	assert.NoError(t, err)
+	assert.Equal(t, sm4VectorPlaintext, pt)
+}
+
+func TestSM4EncryptDecrypt(t *testing.T) {
+	key, err := GetRandomBytes(sm4.BlockSize)
+	assert.NoError(t, err)
+
+	for _, l := range []int{0, 1, sm4.BlockSize - 1, sm4.BlockSize, sm4.BlockSize + 1, 10 * sm4.BlockSize} {
+		msg := bytes.Repeat([]byte{0xa5}, l)
+
+		ct, err := SM4Encrypt(key, msg)
+		assert.NoError(t, err)
+		assert.Len(t, ct, sm4.BlockSize+(l/sm4.BlockSize+1)*sm4.BlockSize)
+
+		pt, err := SM4Decrypt(key, ct)
+		assert.NoError(t, err)
+		assert.Equal(t, msg, pt)
+	}
+
+	// A random IV is used for every encryption
+	ct1, err := SM4Encrypt(key, []byte("Hello World"))
+	assert.NoError(t, err)
+	ct2, err := SM4Encrypt(key, []byte("Hello World"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, ct1, ct2)
+}
+
+func TestSM4EncryptDecryptErrors(t *testing.T) {
+	_, err := SM4Encrypt([]byte("short key"), []byte("Hello World"))
+	assert.Error(t, err)
+
+	_, err = SM4Decrypt(sm4VectorKey, make([]byte, sm4.BlockSize-1))
+	assert.EqualError(t, err, "Invalid ciphertext. It must be a multiple of the block size")
+
+	_, err = SM4Decrypt(sm4VectorKey, make([]byte, sm4.BlockSize+1))
+	assert.EqualError(t, err, "Invalid ciphertext. It must be a multiple of the block size")
+
+	// Decrypting with the wrong key yields broken padding
+	ct, err := SM4Encrypt(sm4VectorKey, []byte("Hello World"))
+	assert.NoError(t, err)
+	otherKey := append([]byte{}, sm4VectorKey...)
+	otherKey[0] ^= 0xff
+	pt, err := SM4Decrypt(otherKey, ct)
+	assert.False(t, err == nil && bytes.Equal(pt, []byte("Hello World")))
+}
+
+func TestPKCS7Padding(t *testing.T) {
+	for l := 0; l <= 2*sm4.BlockSize; l++ {
+		src := bytes.Repeat([]byte{'a'}, l)
+		padded := pkcs7Padding(append([]byte{}, src...))
+		assert.Equal(t, 0, len(padded)%sm4.BlockSize)
+
+		unpadded, err := pkcs7UnPadding(padded)
+		assert.NoError(t, err)
+		assert.Equal(t, src, unpadded)
+	}
+
+	_, err := pkcs7UnPadding([]byte{})
+	assert.Error(t, err)
+	_, err = pkcs7UnPadding(append(bytes.Repeat([]byte{'a'}, sm4.BlockSize-1), 0))
+	assert.Error(t, err)
+	_, err = pkcs7UnPadding(append(bytes.Repeat([]byte{'a'}, sm4.BlockSize-1), sm4.BlockSize+1))
+	assert.Error(t, err)
+	_, err = pkcs7UnPadding(append(bytes.Repeat([]byte{'a'}, sm4.BlockSize-2), 1, 2))
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ import (
 	"crypto/x509"
 	"math/big"
 	"os"
+	"sync"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
@@ -66,8 +67,19 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 			lib, label)
 	}
 
-	sessions := make(chan pkcs11.SessionHandle, sessionCacheSize)
-	csp := &impl{swCSP, conf, keyStore, ctx, sessions, slot, lib, opts.Sensitive, opts.SoftVerify}
+	csp := &impl{
+		BCCSP:        swCSP,
+		conf:         conf,
+		ks:           keyStore,
+		ctx:          ctx,
+		sessions:     make(chan pkcs11.SessionHandle, sessionCacheSize),
+		slot:         slot,
+		pin:          pin,
+		handleCache:  make(map[string]pkcs11.ObjectHandle),
+		lib:          lib,
+		noPrivImport: opts.Sensitive,
+		softVerify:   opts.SoftVerify,
+	}
 	csp.returnSession(*session)
 	return csp, nil
 }
@@ -81,6 +93,11 @@ type impl struct {
 	ctx      *pkcs11.Ctx
 	sessions chan pkcs11.SessionHandle
 	slot     uint
+	pin      string
+
+	// handleCache holds the handles of the keys found on the token, by key type and SKI
+	handleLock  sync.RWMutex
+	handleCache map[string]pkcs11.ObjectHandle
 
 	lib          string
 	noPrivImport bool
@@ -14,8 +14,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/miekg/pkcs11"
 	"github.com/op/go-logging"
 )
@@ -81,30 +84,84 @@ func loadLib(lib, pin, label string) (*pkcs11.Ctx, uint, *pkcs11.SessionHandle,
 	return ctx, slot, &session, nil
 }
 
-func (csp *impl) getSession() (session pkcs11.SessionHandle) {
+const (
+	openSessionRetries    = 10
+	openSessionRetryDelay = 100 * time.Millisecond
+)
+
+var (
+	// tokenFailures are the errors after which the sessions with the token can't be used anymore,
+	// such as when the token was reset or reconnected. New sessions need to be opened and logged in.
+	tokenFailures = []pkcs11.Error{
+		pkcs11.CKR_DEVICE_ERROR,
+		pkcs11.CKR_DEVICE_REMOVED,
+		pkcs11.CKR_TOKEN_NOT_PRESENT,
+		pkcs11.CKR_SESSION_CLOSED,
+		pkcs11.CKR_SESSION_HANDLE_INVALID,
+		pkcs11.CKR_USER_NOT_LOGGED_IN,
+	}
+
+	// handleFailures are the errors after which the cached key handles can't be used anymore
+	handleFailures = []pkcs11.Error{
+		pkcs11.CKR_OBJECT_HANDLE_INVALID,
+		pkcs11.CKR_KEY_HANDLE_INVALID,
+	}
+
+	// operationDurationBuckets are the upper bounds (in seconds) of the buckets of the HSM operation duration histogram
+	operationDurationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+)
+
+// isError returns whether the given error is, or was caused by, one of the given pkcs11 errors.
+// The errors of the pkcs11 library are formatted into the errors returned by the operations,
+// hence they are looked up in the error message.
+func isError(err error, p11Errors ...pkcs11.Error) bool {
+	if err == nil {
+		return false
+	}
+	for _, p11Err := range p11Errors {
+		if strings.Contains(err.Error(), p11Err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (csp *impl) getSession() (pkcs11.SessionHandle, error) {
 	select {
-	case session = <-csp.sessions:
+	case session := <-csp.sessions:
 		logger.Debugf("Reusing existing pkcs11 session %+v on slot %d\n", session, csp.slot)
-
+		return session, nil
 	default:
 		// cache is empty (or completely in use), create a new session
-		var s pkcs11.SessionHandle
-		var err error = nil
-		for i := 0; i < 10; i++ {
-			s, err = csp.ctx.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
-			if err != nil {
-				logger.Warningf("OpenSession failed, retrying [%s]\n", err)
-			} else {
-				break
-			}
-		}
-		if err != nil {
-			panic(fmt.Errorf("OpenSession failed [%s]\n", err))
+		return csp.openSession()
+	}
+}
+
+// openSession opens a new session with the token and logs into it
+func (csp *impl) openSession() (pkcs11.SessionHandle, error) {
+	var session pkcs11.SessionHandle
+	var err error
+	for i := 0; i < openSessionRetries; i++ {
+		session, err = csp.ctx.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err == nil {
+			break
 		}
-		logger.Debugf("Created new pkcs11 session %+v on slot %d\n", s, csp.slot)
-		session = s
+		logger.Warningf("OpenSession failed, retrying [%s]\n", err)
+		time.Sleep(openSessionRetryDelay)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("OpenSession failed [%s]", err)
+	}
+	logger.Debugf("Created new pkcs11 session %+v on slot %d\n", session, csp.slot)
+
+	// The login state is shared by all the sessions with the token,
+	// but it's lost once the token resets or all of them are closed
+	err = csp.ctx.Login(session, pkcs11.CKU_USER, csp.pin)
+	if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		csp.ctx.CloseSession(session)
+		return 0, fmt.Errorf("Login failed [%s]", err)
 	}
-	return session
+	return session, nil
 }
 
 func (csp *impl) returnSession(session pkcs11.SessionHandle) {
@@ -117,27 +174,136 @@ func (csp *impl) returnSession(session pkcs11.SessionHandle) {
 	}
 }
 
-// Look for an EC key by SKI, stored in CKA_ID
-// This function can probably be adapted for both EC and RSA keys.
-func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
-	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
-	isPriv = true
-	_, err = findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+// discardSessions closes the sessions in the session cache
+func (csp *impl) discardSessions() {
+	for {
+		select {
+		case session := <-csp.sessions:
+			csp.ctx.CloseSession(session)
+		default:
+			return
+		}
+	}
+}
+
+// withSession runs the given operation with a session from the session cache.
+// If the operation fails because the token failed, the sessions with the token are discarded,
+// and if it fails because of a token failure or a stale key handle, the key handle cache is cleared.
+// Idempotent operations are then retried once, with a new session.
+func (csp *impl) withSession(op string, idempotent bool, f func(session pkcs11.SessionHandle) error) error {
+	start := time.Now()
+	err := csp.trySession(f)
+	if idempotent && isError(err, append(tokenFailures, handleFailures...)...) {
+		logger.Warningf("Retrying %s after failure [%s]", op, err)
+		err = csp.trySession(f)
+	}
+	reportOperation(op, time.Since(start), err)
+	return err
+}
+
+func (csp *impl) trySession(f func(session pkcs11.SessionHandle) error) error {
+	session, err := csp.getSession()
 	if err != nil {
-		isPriv = false
-		logger.Debugf("Private key not found [%s] for SKI [%s], looking for Public key", err, hex.EncodeToString(ski))
+		return err
 	}
 
-	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
+	err = f(session)
+	switch {
+	case isError(err, tokenFailures...):
+		logger.Warningf("Discarding the pkcs11 sessions on slot %d after token failure [%s]", csp.slot, err)
+		reportTokenFailure()
+		csp.ctx.CloseSession(session)
+		csp.discardSessions()
+		csp.clearHandleCache()
+	case isError(err, handleFailures...):
+		csp.clearHandleCache()
+		csp.returnSession(session)
+	default:
+		csp.returnSession(session)
+	}
+	return err
+}
+
+// findKey looks for a key by SKI, first in the key handle cache and then on the token.
+// The handles of token objects are cached, while the handles of session objects aren't,
+// as they are destroyed once the session which created them is closed.
+func (csp *impl) findKey(session pkcs11.SessionHandle, ski []byte, keyType bool) (*pkcs11.ObjectHandle, error) {
+	key := fmt.Sprintf("%t:%x", keyType, ski)
+	csp.handleLock.RLock()
+	handle, cached := csp.handleCache[key]
+	csp.handleLock.RUnlock()
+	if cached {
+		return &handle, nil
+	}
+
+	found, err := findKeyPairFromSKI(csp.ctx, session, ski, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := csp.ctx.GetAttributeValue(session, *found, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_TOKEN, nil)})
 	if err != nil {
-		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		return nil, err
 	}
+	if len(attrs) == 1 && len(attrs[0].Value) == 1 && attrs[0].Value[0] != 0 {
+		csp.handleLock.Lock()
+		csp.handleCache[key] = *found
+		csp.handleLock.Unlock()
+	}
+	return found, nil
+}
+
+func (csp *impl) clearHandleCache() {
+	csp.handleLock.Lock()
+	csp.handleCache = make(map[string]pkcs11.ObjectHandle)
+	csp.handleLock.Unlock()
+}
+
+// reportOperation reports the duration and the outcome of an operation with the token, if metrics are initialized
+func reportOperation(op string, elapsed time.Duration, err error) {
+	if metrics.RootScope == nil {
+		return
+	}
+	scope := metrics.RootScope.SubScope("bccsp_p11").Tagged(map[string]string{"operation": op})
+	scope.Histogram("operation_duration_seconds", operationDurationBuckets).RecordValue(elapsed.Seconds())
+	if err != nil {
+		scope.Counter("operations_failed").Inc(1)
+	}
+}
+
+// reportTokenFailure reports a token failure which the sessions with the token were discarded after
+func reportTokenFailure() {
+	if metrics.RootScope == nil {
+		return
+	}
+	metrics.RootScope.SubScope("bccsp_p11").Counter("token_failures").Inc(1)
+}
+
+// Look for an EC key by SKI, stored in CKA_ID
+// This function can probably be adapted for both EC and RSA keys.
+func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
+	var ecpt, marshaledOid []byte
+	err = csp.withSession("get_key", true, func(session pkcs11.SessionHandle) error {
+		isPriv = true
+		_, err := csp.findKey(session, ski, privateKeyFlag)
+		if err != nil {
+			isPriv = false
+			logger.Debugf("Private key not found [%s] for SKI [%s], looking for Public key", err, hex.EncodeToString(ski))
+		}
+
+		publicKey, err := csp.findKey(session, ski, publicKeyFlag)
+		if err != nil {
+			return fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		}
 
-	ecpt, marshaledOid, err := ecPoint(p11lib, session, *publicKey)
+		ecpt, marshaledOid, err = ecPoint(csp.ctx, session, *publicKey)
+		if err != nil {
+			return fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+		return nil, false, err
 	}
 
 	curveOid := new(asn1.ObjectIdentifier)
@@ -211,9 +377,16 @@ func oidFromNamedCurve(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
 }
 
 func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
+	err = csp.withSession("generate_key", false, func(session pkcs11.SessionHandle) error {
+		var err error
+		ski, pubKey, err = csp.generateECKeyWithSession(session, curve, ephemeral)
+		return err
+	})
+	return ski, pubKey, err
+}
+
+func (csp *impl) generateECKeyWithSession(session pkcs11.SessionHandle, curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
 
 	id := nextIDCtr()
 	publabel := fmt.Sprintf("BCPUB%s", id.Text(16))
@@ -298,11 +471,18 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 }
 
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
+	err = csp.withSession("sign", true, func(session pkcs11.SessionHandle) error {
+		var err error
+		R, S, err = csp.signP11ECDSAWithSession(session, ski, msg)
+		return err
+	})
+	return R, S, err
+}
+
+func (csp *impl) signP11ECDSAWithSession(session pkcs11.SessionHandle, ski []byte, msg []byte) (R, S *big.Int, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
 
-	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+	privateKey, err := csp.findKey(session, ski, privateKeyFlag)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Private key not found [%s]\n", err)
 	}
@@ -328,13 +508,20 @@ func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error)
 }
 
 func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize int) (valid bool, err error) {
+	err = csp.withSession("verify", true, func(session pkcs11.SessionHandle) error {
+		var err error
+		valid, err = csp.verifyP11ECDSAWithSession(session, ski, msg, R, S, byteSize)
+		return err
+	})
+	return valid, err
+}
+
+func (csp *impl) verifyP11ECDSAWithSession(session pkcs11.SessionHandle, ski []byte, msg []byte, R, S *big.Int, byteSize int) (valid bool, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
 
 	logger.Debugf("Verify ECDSA\n")
 
-	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyFlag)
+	publicKey, err := csp.findKey(session, ski, publicKeyFlag)
 	if err != nil {
 		return false, fmt.Errorf("Public key not found [%s]\n", err)
 	}
@@ -364,9 +551,16 @@ func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize
 }
 
 func (csp *impl) importECKey(curve asn1.ObjectIdentifier, privKey, ecPt []byte, ephemeral bool, keyType bool) (ski []byte, err error) {
+	err = csp.withSession("import_key", false, func(session pkcs11.SessionHandle) error {
+		var err error
+		ski, err = csp.importECKeyWithSession(session, curve, privKey, ecPt, ephemeral, keyType)
+		return err
+	})
+	return ski, err
+}
+
+func (csp *impl) importECKeyWithSession(session pkcs11.SessionHandle, curve asn1.ObjectIdentifier, privKey, ecPt []byte, ephemeral bool, keyType bool) (ski []byte, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
 
 	marshaledOID, err := asn1.Marshal(curve)
 	if err != nil {
@@ -577,11 +771,23 @@ func listAttrs(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, obj pkcs11.Obje
 }
 
 func (csp *impl) getSecretValue(ski []byte) []byte {
+	var value []byte
+	csp.withSession("get_secret_value", true, func(session pkcs11.SessionHandle) error {
+		var err error
+		value, err = csp.getSecretValueWithSession(session, ski)
+		return err
+	})
+	return value
+}
+
+func (csp *impl) getSecretValueWithSession(session pkcs11.SessionHandle, ski []byte) ([]byte, error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
 
-	keyHandle, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+	keyHandle, err := csp.findKey(session, ski, privateKeyFlag)
+	if err != nil {
+		logger.Warningf("P11: key not found [%s]\n", err)
+		return nil, err
+	}
 
 	var privKey []byte
 	template := []*pkcs11.Attribute{
@@ -592,15 +798,18 @@ func (csp *impl) getSecretValue(ski []byte) []byte {
 	attr, err := p11lib.GetAttributeValue(session, *keyHandle, template)
 	if err != nil {
 		logger.Warningf("P11: get(attrlist) [%s]\n", err)
+		if isError(err, append(tokenFailures, handleFailures...)...) {
+			return nil, err
+		}
 	}
 
 	for _, a := range attr {
 		// Would be friendlier if the bindings provided a way convert Attribute hex to string
 		logger.Debugf("ListAttr: type %d/0x%x, length %d\n%s", a.Type, a.Type, len(a.Value), hex.Dump(a.Value))
-		return a.Value
+		return a.Value, nil
 	}
 	logger.Warningf("No Key Value found!", err)
-	return nil
+	return nil, nil
 }
 
 var (
@@ -23,6 +23,8 @@ import (
 	"crypto/rand"
 	"encoding/asn1"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -133,7 +135,9 @@ func TestPKCS11GetSession(t *testing.T) {
 	}
 	var sessions []pkcs11.SessionHandle
 	for i := 0; i < 3*sessionCacheSize; i++ {
-		sessions = append(sessions, currentBCCSP.(*impl).getSession())
+		session, err := currentBCCSP.(*impl).getSession()
+		assert.NoError(t, err)
+		sessions = append(sessions, session)
 	}
 
 	// Return all sessions, should leave sessionCacheSize cached
@@ -148,13 +152,15 @@ func TestPKCS11GetSession(t *testing.T) {
 
 	// Should be able to get sessionCacheSize cached sessions
 	for i := 0; i < sessionCacheSize; i++ {
-		sessions = append(sessions, currentBCCSP.(*impl).getSession())
+		session, err := currentBCCSP.(*impl).getSession()
+		assert.NoError(t, err)
+		sessions = append(sessions, session)
 	}
 
 	// This one should fail
-	assert.Panics(t, func() {
-		currentBCCSP.(*impl).getSession()
-	}, "Should not been able to create another session")
+	_, err := currentBCCSP.(*impl).getSession()
+	assert.Error(t, err, "Should not been able to create another session")
+	assert.Contains(t, err.Error(), "OpenSession failed")
 
 	// Cleanup
 	for _, session := range sessions {
@@ -163,6 +169,70 @@ func TestPKCS11GetSession(t *testing.T) {
 	currentBCCSP.(*impl).slot = oldSlot
 }
 
+func TestPKCS11IsError(t *testing.T) {
+	assert.False(t, isError(nil, tokenFailures...))
+	assert.False(t, isError(errors.New("Private key not found"), tokenFailures...))
+	assert.True(t, isError(pkcs11.Error(pkcs11.CKR_DEVICE_ERROR), tokenFailures...))
+	err := fmt.Errorf("Sign-initialize  failed [%s]\n", pkcs11.Error(pkcs11.CKR_SESSION_HANDLE_INVALID))
+	assert.True(t, isError(err, tokenFailures...))
+	assert.False(t, isError(err, handleFailures...))
+	assert.True(t, isError(fmt.Errorf("P11: sign failed [%s]", pkcs11.Error(pkcs11.CKR_KEY_HANDLE_INVALID)), handleFailures...))
+}
+
+func TestPKCS11KeyHandleCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping TestPKCS11KeyHandleCache")
+	}
+	csp := currentBCCSP.(*impl)
+	csp.clearHandleCache()
+
+	// Handles of token objects are cached
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	_, isPriv, err := csp.getECKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, isPriv)
+	csp.handleLock.RLock()
+	assert.Len(t, csp.handleCache, 2)
+	csp.handleLock.RUnlock()
+
+	// Handles of session objects aren't
+	k, err = csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	_, _, err = csp.getECKey(k.SKI())
+	assert.NoError(t, err)
+	csp.handleLock.RLock()
+	assert.Len(t, csp.handleCache, 2)
+	csp.handleLock.RUnlock()
+
+	csp.clearHandleCache()
+	assert.Empty(t, csp.handleCache)
+}
+
+func TestPKCS11TokenFailureRecovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping TestPKCS11TokenFailureRecovery")
+	}
+	csp := currentBCCSP.(*impl)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	_, _, err = csp.signP11ECDSA(k.SKI(), digest)
+	assert.NoError(t, err)
+
+	// Closing all the sessions invalidates the cached sessions and logs out of the token,
+	// as if the token was reset
+	assert.NoError(t, csp.ctx.CloseAllSessions(csp.slot))
+
+	R, S, err := csp.signP11ECDSA(k.SKI(), digest)
+	assert.NoError(t, err, "Should have recovered from the invalidated sessions")
+	valid, err := csp.verifyP11ECDSA(k.SKI(), digest, R, S, currentTestConfig.securityLevel/8)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
 func TestPKCS11ECKeySignVerify(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping TestPKCS11ECKeySignVerify")
@@ -26,7 +26,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"crypto/sm2"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 // struct to hold info required for PKCS#8
@@ -232,22 +232,34 @@ func PEMtoPrivateKey(raw []byte, pwd []byte) (interface{}, error) {
 		if err != nil {
 			return nil, fmt.Errorf("Failed PEM decryption [%s]", err)
 		}
-		//key, err := DERToPrivateKey(decrypted)
-		key, err := sm2.ParsePKCS8UnecryptedPrivateKey(decrypted)
+		key, err := derToPrivateKeyOrSM2(decrypted)
 		if err != nil {
 			return nil, err
 		}
 		return key, err
 	}
-	//cert, err := DERToPrivateKey(block.Bytes)
-	key, err := sm2.ParsePKCS8UnecryptedPrivateKey(block.Bytes)
-
+	key, err := derToPrivateKeyOrSM2(block.Bytes)
 	if err != nil {
 		return nil, err
 	}
 	return key, err
 }
 
+// derToPrivateKeyOrSM2 unmarshals a der to an rsa or ecdsa private key,
+// and only if the der contains neither, to an sm2 private key.
+// SM2 keys are on a curve unknown to crypto/x509, hence they never parse as ecdsa keys.
+func derToPrivateKeyOrSM2(der []byte) (interface{}, error) {
+	key, err := DERToPrivateKey(der)
+	if err == nil {
+		return key, nil
+	}
+	sm2Key, sm2Err := sm2.ParsePKCS8UnecryptedPrivateKey(der)
+	if sm2Err != nil {
+		return nil, err
+	}
+	return sm2Key, nil
+}
+
 // PEMtoAES extracts from the PEM an AES key
 func PEMtoAES(raw []byte, pwd []byte) ([]byte, error) {
 	if len(raw) == 0 {
@@ -30,6 +30,11 @@ const (
 	// during development.  This string will hopefully be removed prior to the release of v1.2 and
 	// Will be enabled along with ApplicationV1_2
 	ApplicationChaincodeLifecycleExperimental = "V1_2_CHAINCODE_LIFECYCLE_EXPERIMENTAL"
+
+	// ApplicationFabTokenExperimental is the capabilties string for token transactions, which are
+	// validated and committed by the peers natively, without invoking chaincodes. It is a prototype
+	// which is only supported by experimental builds.
+	ApplicationFabTokenExperimental = "V1_2_FABTOKEN_EXPERIMENTAL"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -40,6 +45,7 @@ type ApplicationProvider struct {
 	v11PvtDataExperimental       bool
 	v11ResourcesTreeExperimental bool
 	v12LifecycleExperimental     bool
+	v12FabTokenExperimental      bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -51,6 +57,7 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
 	_, ap.v11ResourcesTreeExperimental = capabilities[ApplicationResourcesTreeExperimental]
 	_, ap.v12LifecycleExperimental = capabilities[ApplicationChaincodeLifecycleExperimental]
+	_, ap.v12FabTokenExperimental = capabilities[ApplicationFabTokenExperimental]
 	return ap
 }
 
@@ -107,3 +114,9 @@ func (ap *ApplicationProvider) MetadataLifecycle() bool {
 func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
 	return ap.v12
 }
+
+// FabToken returns true if this channel supports the experimental token transactions,
+// which are validated and committed without invoking chaincodes
+func (ap *ApplicationProvider) FabToken() bool {
+	return ap.v12FabTokenExperimental
+}
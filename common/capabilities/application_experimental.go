@@ -22,6 +22,8 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationChaincodeLifecycleExperimental:
 		return true
+	case ApplicationFabTokenExperimental:
+		return true
 	default:
 		return false
 	}
@@ -65,3 +65,15 @@ func TestChaincodeLifecycleExperimental(t *testing.T) {
 	})
 	assert.True(t, op.MetadataLifecycle())
 }
+
+func TestFabTokenExperimental(t *testing.T) {
+	op := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationFabTokenExperimental: {},
+	})
+	assert.True(t, op.FabToken())
+
+	op = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2: {},
+	})
+	assert.False(t, op.FabToken())
+}
@@ -16,12 +16,22 @@ const (
 
 	// ChannelV1_1 is the capabilties string for standard new non-backwards compatible fabric v1.1 channel capabilities.
 	ChannelV1_1 = "V1_1"
+
+	// ChannelV1_3 is the capabilities string for channels whose MSPs classify admins and
+	// orderers by OU, in addition to clients and peers. It implies ChannelV1_1.
+	ChannelV1_3 = "V1_3"
+
+	// ChannelGMSM3 is the capabilities string for channels which hash their blocks and
+	// config with the SM3 national hashing algorithm, and require the GM BCCSP provider.
+	ChannelGMSM3 = "GMSM3"
 )
 
 // ChannelProvider provides capabilities information for channel level config.
 type ChannelProvider struct {
 	*registry
-	v11 bool
+	v11   bool
+	v13   bool
+	gmsm3 bool
 }
 
 // NewChannelProvider creates a channel capabilities provider.
@@ -29,6 +39,8 @@ func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider
 	cp := &ChannelProvider{}
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11 = capabilities[ChannelV1_1]
+	_, cp.v13 = capabilities[ChannelV1_3]
+	_, cp.gmsm3 = capabilities[ChannelGMSM3]
 	return cp
 }
 
@@ -43,6 +55,10 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 	// Add new capability names here
 	case ChannelV1_1:
 		return true
+	case ChannelV1_3:
+		return true
+	case ChannelGMSM3:
+		return true
 	default:
 		return false
 	}
@@ -51,9 +67,16 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 // MSPVersion returns the level of MSP support required by this channel.
 func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 	switch {
+	case cp.v13:
+		return msp.MSPv1_3
 	case cp.v11:
 		return msp.MSPv1_1
 	default:
 		return msp.MSPv1_0
 	}
 }
+
+// SM3Hashing returns true if the channel may use the SM3 hashing algorithm.
+func (cp *ChannelProvider) SM3Hashing() bool {
+	return cp.gmsm3
+}
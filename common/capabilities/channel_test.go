@@ -27,4 +27,30 @@ func TestChannelV11(t *testing.T) {
 	})
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.MSPVersion() == msp.MSPv1_1)
+	assert.False(t, op.SM3Hashing())
+}
+
+func TestChannelV13(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_3)
+
+	op = NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_1: {},
+		ChannelV1_3: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_3)
+}
+
+func TestChannelGMSM3(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_1:  {},
+		ChannelGMSM3: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_1)
+	assert.True(t, op.SM3Hashing())
 }
@@ -8,14 +8,18 @@ package cauthdsl
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
+	"github.com/pkg/errors"
 )
 
 var cauthdslLogger = flogging.MustGetLogger("cauthdsl")
@@ -42,32 +46,39 @@ func deduplicate(sds []*cb.SignedData, deserializer msp.IdentityDeserializer) []
 	return result
 }
 
+// evaluator evaluates a set of signed data against a compiled policy, recording the evaluation into trace unless it is nil
+type evaluator func(signedData []*cb.SignedData, used []bool, trace *policies.PolicyTrace) bool
+
 // compile recursively builds a go evaluatable function corresponding to the policy specified, remember to call deduplicate on identities before
 // passing them to this function for evaluation
-func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer) (func([]*cb.SignedData, []bool) bool, error) {
+func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer) (evaluator, error) {
 	if policy == nil {
 		return nil, fmt.Errorf("Empty policy element")
 	}
 
 	switch t := policy.Type.(type) {
 	case *cb.SignaturePolicy_NOutOf_:
-		policies := make([]func([]*cb.SignedData, []bool) bool, len(t.NOutOf.Rules))
+		rules := make([]evaluator, len(t.NOutOf.Rules))
 		for i, policy := range t.NOutOf.Rules {
 			compiledPolicy, err := compile(policy, identities, deserializer)
 			if err != nil {
 				return nil, err
 			}
-			policies[i] = compiledPolicy
+			rules[i] = compiledPolicy
 
 		}
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		return func(signedData []*cb.SignedData, used []bool, trace *policies.PolicyTrace) bool {
 			grepKey := time.Now().UnixNano()
 			cauthdslLogger.Debugf("%p gate %d evaluation starts", signedData, grepKey)
 			verified := int32(0)
 			_used := make([]bool, len(used))
-			for _, policy := range policies {
+			for _, policy := range rules {
 				copy(_used, used)
-				if policy(signedData, _used) {
+				var subTrace *policies.PolicyTrace
+				if trace != nil {
+					subTrace = trace.NewSubPolicy("")
+				}
+				if policy(signedData, _used, subTrace) {
 					verified++
 					copy(used, _used)
 				}
@@ -78,6 +89,13 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 			} else {
 				cauthdslLogger.Debugf("%p gate %d evaluation fails", signedData, grepKey)
 			}
+			if trace != nil {
+				trace.Policy = fmt.Sprintf("OutOf(%d, %d policies)", t.NOutOf.N, len(rules))
+				trace.Satisfied = verified >= t.NOutOf.N
+				if !trace.Satisfied {
+					trace.Reason = fmt.Sprintf("%d of %d required policies satisfied", verified, t.NOutOf.N)
+				}
+			}
 
 			return verified >= t.NOutOf.N
 		}, nil
@@ -86,11 +104,15 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 			return nil, fmt.Errorf("identity index out of range, requested %v, but identies length is %d", t.SignedBy, len(identities))
 		}
 		signedByID := identities[t.SignedBy]
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		return func(signedData []*cb.SignedData, used []bool, trace *policies.PolicyTrace) bool {
 			cauthdslLogger.Debugf("%p signed by %d principal evaluation starts (used %v)", signedData, t.SignedBy, used)
+			if trace != nil {
+				trace.Policy = principalString(signedByID)
+			}
 			for i, sd := range signedData {
 				if used[i] {
 					cauthdslLogger.Debugf("%p skipping identity %d because it has already been used", signedData, i)
+					traceSignature(trace, i, nil, errors.New("already used by another principal"))
 					continue
 				}
 				if cauthdslLogger.IsEnabledFor(logging.DEBUG) {
@@ -100,27 +122,79 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 				identity, err := deserializer.DeserializeIdentity(sd.Identity)
 				if err != nil {
 					cauthdslLogger.Errorf("Principal deserialization failure (%s) for identity %x", err, sd.Identity)
+					traceSignature(trace, i, nil, err)
 					continue
 				}
 				err = identity.SatisfiesPrincipal(signedByID)
 				if err != nil {
 					cauthdslLogger.Debugf("%p identity %d does not satisfy principal: %s", signedData, i, err)
+					traceSignature(trace, i, identity, err)
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal matched by identity %d", signedData, i)
 				err = identity.Verify(sd.Data, sd.Signature)
 				if err != nil {
 					cauthdslLogger.Debugf("%p signature for identity %d is invalid: %s", signedData, i, err)
+					traceSignature(trace, i, identity, errors.Errorf("invalid signature: %s", err))
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal evaluation succeeds for identity %d", signedData, i)
+				traceSignature(trace, i, identity, nil)
+				if trace != nil {
+					trace.Satisfied = true
+				}
 				used[i] = true
 				return true
 			}
 			cauthdslLogger.Debugf("%p principal evaluation fails", signedData)
+			if trace != nil {
+				trace.Reason = "no signature matched the principal"
+			}
 			return false
 		}, nil
 	default:
 		return nil, fmt.Errorf("Unknown type: %T:%v", t, t)
 	}
 }
+
+// traceSignature records into trace, unless it is nil, whether the signature
+// at index i satisfied the principal being evaluated
+func traceSignature(trace *policies.PolicyTrace, i int, identity msp.Identity, err error) {
+	if trace == nil {
+		return
+	}
+	sig := &policies.SignatureTrace{Index: i, Matched: err == nil}
+	if identity != nil {
+		sig.Identity = fmt.Sprintf("%s (%s)", identity.GetIdentifier().Mspid, identity.GetIdentifier().Id)
+	}
+	if err != nil {
+		sig.Reason = err.Error()
+	}
+	trace.Signatures = append(trace.Signatures, sig)
+}
+
+// principalString returns a description of the principal, using the policy
+// language notation for roles
+func principalString(principal *mb.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			break
+		}
+		return fmt.Sprintf("'%s.%s'", role.MspIdentifier, strings.ToLower(role.Role.String()))
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			break
+		}
+		return fmt.Sprintf("member of %s in OU %s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+	case mb.MSPPrincipal_IDENTITY:
+		sid := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, sid); err != nil {
+			break
+		}
+		return fmt.Sprintf("identity of %s", sid.Mspid)
+	}
+	return fmt.Sprintf("%s principal", principal.PrincipalClassification)
+}
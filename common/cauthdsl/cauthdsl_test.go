@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
@@ -86,6 +87,10 @@ func toSignedData(data [][]byte, identities [][]byte, signatures [][]byte) ([]*c
 	return signedData, make([]bool, len(signedData))
 }
 
+func untraced(signedData []*cb.SignedData, used []bool) ([]*cb.SignedData, []bool, *policies.PolicyTrace) {
+	return signedData, used, nil
+}
+
 type mockDeserializer struct {
 	fail error
 }
@@ -114,13 +119,13 @@ func TestSimpleSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{validSignature})) {
+	if !spe(untraced(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{validSignature}))) {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if spe(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{invalidSignature})) {
+	if spe(untraced(toSignedData([][]byte{nil}, [][]byte{signers[0]}, [][]byte{invalidSignature}))) {
 		t.Errorf("Expected authentication to fail given the invalid signature")
 	}
-	if spe(toSignedData([][]byte{nil}, [][]byte{signers[1]}, [][]byte{validSignature})) {
+	if spe(untraced(toSignedData([][]byte{nil}, [][]byte{signers[1]}, [][]byte{validSignature}))) {
 		t.Errorf("Expected authentication to fail because signers[1] is not authorized in the policy, despite his valid signature")
 	}
 }
@@ -133,13 +138,13 @@ func TestMultipleSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})) {
+	if !spe(untraced(toSignedData(msgs, signers, [][]byte{validSignature, validSignature}))) {
 		t.Errorf("Expected authentication to succeed with  valid signatures")
 	}
-	if spe(toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature})) {
+	if spe(untraced(toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature}))) {
 		t.Errorf("Expected authentication to fail given one of two invalid signatures")
 	}
-	if spe(toSignedData(msgs, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature})) {
+	if spe(untraced(toSignedData(msgs, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature}))) {
 		t.Errorf("Expected authentication to fail because although there were two valid signatures, one was duplicated")
 	}
 }
@@ -152,19 +157,19 @@ func TestComplexNestedSignature(t *testing.T) {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
 
-	if !spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, validSignature, validSignature})) {
+	if !spe(untraced(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, validSignature, validSignature}))) {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if !spe(toSignedData(moreMsgs, [][]byte{[]byte("signer0"), []byte("signer0"), []byte("signer0")}, [][]byte{validSignature, validSignature, validSignature})) {
+	if !spe(untraced(toSignedData(moreMsgs, [][]byte{[]byte("signer0"), []byte("signer0"), []byte("signer0")}, [][]byte{validSignature, validSignature, validSignature}))) {
 		t.Errorf("Expected authentication to succeed with valid signatures")
 	}
-	if spe(toSignedData(msgs, signers, [][]byte{validSignature, validSignature})) {
+	if spe(untraced(toSignedData(msgs, signers, [][]byte{validSignature, validSignature}))) {
 		t.Errorf("Expected authentication to fail with too few signatures")
 	}
-	if spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, invalidSignature, validSignature})) {
+	if spe(untraced(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer0")}...), [][]byte{validSignature, invalidSignature, validSignature}))) {
 		t.Errorf("Expected authentication failure as the signature of signer[1] was invalid")
 	}
-	if spe(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer1")}...), [][]byte{validSignature, validSignature, validSignature})) {
+	if spe(untraced(toSignedData(moreMsgs, append(signers, [][]byte{[]byte("signer1")}...), [][]byte{validSignature, validSignature, validSignature}))) {
 		t.Errorf("Expected authentication failure as there was a signature from signer[0] missing")
 	}
 }
@@ -273,3 +278,25 @@ func TestSignedByMspPeer(t *testing.T) {
 	assert.Equal(t, role.MspIdentifier, "A")
 	assert.Equal(t, role.Role, mb.MSPRole_PEER)
 }
+
+func TestSignedByMspOrderer(t *testing.T) {
+	e := SignedByMspOrderer("A")
+	assert.Equal(t, 1, len(e.Identities))
+
+	role := &mb.MSPRole{}
+	err := proto.Unmarshal(e.Identities[0].Principal, role)
+	assert.NoError(t, err)
+
+	assert.Equal(t, role.MspIdentifier, "A")
+	assert.Equal(t, role.Role, mb.MSPRole_ORDERER)
+
+	e = SignedByAnyOrderer([]string{"A"})
+	assert.Equal(t, 1, len(e.Identities))
+
+	role = &mb.MSPRole{}
+	err = proto.Unmarshal(e.Identities[0].Principal, role)
+	assert.NoError(t, err)
+
+	assert.Equal(t, role.MspIdentifier, "A")
+	assert.Equal(t, role.Role, mb.MSPRole_ORDERER)
+}
@@ -52,7 +52,7 @@ func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, erro
 }
 
 type policy struct {
-	evaluator    func([]*cb.SignedData, []bool) bool
+	evaluator    evaluator
 	deserializer msp.IdentityDeserializer
 }
 
@@ -62,9 +62,22 @@ func (p *policy) Evaluate(signatureSet []*cb.SignedData) error {
 		return fmt.Errorf("No such policy")
 	}
 
-	ok := p.evaluator(deduplicate(signatureSet, p.deserializer), make([]bool, len(signatureSet)))
+	ok := p.evaluator(deduplicate(signatureSet, p.deserializer), make([]bool, len(signatureSet)), nil)
 	if !ok {
 		return errors.New("signature set did not satisfy policy")
 	}
 	return nil
 }
+
+// EvaluateWithTrace evaluates the signature set like Evaluate, recording which signatures matched which principals
+func (p *policy) EvaluateWithTrace(signatureSet []*cb.SignedData, trace *policies.PolicyTrace) error {
+	if p == nil {
+		return trace.Record(fmt.Errorf("No such policy"))
+	}
+
+	ok := p.evaluator(deduplicate(signatureSet, p.deserializer), make([]bool, len(signatureSet)), trace.NewSubPolicy(""))
+	if !ok {
+		return trace.Record(errors.New("signature set did not satisfy policy"))
+	}
+	return trace.Record(nil)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -103,3 +104,48 @@ func TestRejectOnUnknown(t *testing.T) {
 	err = policy.Evaluate([]*cb.SignedData{})
 	assert.Error(t, err, "Should have errored evaluating the default policy")
 }
+
+func TestEvaluateWithTrace(t *testing.T) {
+	policy, _, err := NewPolicyProvider(&mockDeserializer{}).NewPolicy(marshalOrPanic(Envelope(And(SignedBy(0), SignedBy(1)), signers)))
+	assert.NoError(t, err)
+
+	signedData, _ := toSignedData(msgs, signers, [][]byte{validSignature, invalidSignature})
+	err = policies.EvaluateWithTrace(policy, signedData)
+	assert.Error(t, err)
+	traceErr, ok := err.(*policies.TraceError)
+	assert.True(t, ok, "Should have returned a trace")
+	assert.Contains(t, err.Error(), "signature set did not satisfy policy, evaluation trace:")
+
+	trace := traceErr.Trace
+	assert.False(t, trace.Satisfied)
+	assert.Len(t, trace.SubPolicies, 1)
+	gate := trace.SubPolicies[0]
+	assert.Equal(t, "OutOf(2, 2 policies)", gate.Policy)
+	assert.Equal(t, "1 of 2 required policies satisfied", gate.Reason)
+	assert.Len(t, gate.SubPolicies, 2)
+
+	first := gate.SubPolicies[0]
+	assert.True(t, first.Satisfied)
+	assert.Len(t, first.Signatures, 1)
+	assert.True(t, first.Signatures[0].Matched)
+	assert.Equal(t, "Mock (signer0)", first.Signatures[0].Identity)
+
+	second := gate.SubPolicies[1]
+	assert.False(t, second.Satisfied)
+	assert.Equal(t, "no signature matched the principal", second.Reason)
+	assert.Len(t, second.Signatures, 2)
+	assert.Equal(t, "already used by another principal", second.Signatures[0].Reason)
+	assert.Equal(t, "invalid signature: Invalid signature", second.Signatures[1].Reason)
+
+	signedData, _ = toSignedData(msgs, signers, [][]byte{validSignature, validSignature})
+	assert.NoError(t, policies.EvaluateWithTrace(policy, signedData))
+}
+
+func TestPrincipalString(t *testing.T) {
+	envelope := SignedByMspPeer("Org1MSP")
+	assert.Equal(t, "'Org1MSP.peer'", principalString(envelope.Identities[0]))
+	assert.Equal(t, "IDENTITY principal", principalString(&mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_IDENTITY,
+		Principal:               []byte("garbage"),
+	}))
+}
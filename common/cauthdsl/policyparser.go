@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 // Gate values
@@ -38,17 +39,17 @@ const (
 
 // Role values for principals
 const (
-	RoleAdmin  = "admin"
-	RoleMember = "member"
-	RoleClient = "client"
-	RolePeer   = "peer"
-	// RoleOrderer = "orderer" TODO
+	RoleAdmin   = "admin"
+	RoleMember  = "member"
+	RoleClient  = "client"
+	RolePeer    = "peer"
+	RoleOrderer = "orderer"
 )
 
 var (
 	regex = regexp.MustCompile(
-		fmt.Sprintf("^([[:alnum:].-]+)([.])(%s|%s|%s|%s)$",
-			RoleAdmin, RoleMember, RoleClient, RolePeer),
+		fmt.Sprintf("^([[:alnum:].-]+)([.])(%s|%s|%s|%s|%s)$",
+			RoleAdmin, RoleMember, RoleClient, RolePeer, RoleOrderer),
 	)
 	regexErr = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
 )
@@ -147,7 +148,7 @@ func secondPass(args ...interface{}) (interface{}, error) {
 	}
 
 	/* get the n in the t out of n */
-	var n int = len(args) - 1
+	var n int = len(args) - 2
 
 	/* sanity check - t better be <= n */
 	if t > n {
@@ -180,6 +181,8 @@ func secondPass(args ...interface{}) (interface{}, error) {
 				r = msp.MSPRole_CLIENT
 			case RolePeer:
 				r = msp.MSPRole_PEER
+			case RoleOrderer:
+				r = msp.MSPRole_ORDERER
 			default:
 				return nil, fmt.Errorf("Error parsing role %s", t)
 			}
@@ -219,6 +222,19 @@ type context struct {
 	principals []*msp.MSPPrincipal
 }
 
+// ParserOptions configures how policies are parsed by FromStringWithOptions
+type ParserOptions struct {
+	// MaxDepth is the maximum nesting depth of the gates of a policy,
+	// sub-policies included. Zero means no limit
+	MaxDepth int
+	// MaxPrincipals is the maximum number of principals of a policy,
+	// sub-policies included. Zero means no limit
+	MaxPrincipals int
+	// SubPolicies maps the names that a policy may reference in
+	// place of a principal or a gate to the policies they stand for
+	SubPolicies map[string]string
+}
+
 func newContext() *context {
 	return &context{IDNum: 0, principals: make([]*msp.MSPPrincipal, 0)}
 }
@@ -241,8 +257,61 @@ func newContext() *context {
 //	- ORG is a string (representing the MSP identifier)
 //	- ROLE takes the value of any of the RoleXXX constants representing
 //    the required role
+//
+// The "outof" gate takes the number of its arguments that must be
+// satisfied as first argument, e.g. OutOf(2, P, P, P)
 func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
-	// first we translate the and/or business into outof gates
+	return FromStringWithOptions(policy, ParserOptions{})
+}
+
+// FromStringWithOptions parses a policy like FromString does, additionally
+// resolving the names of the sub-policies it references, and rejecting
+// policies that exceed the limits of the given options.
+// For example, given the sub-policy Endorsers = "OR('A.peer', 'B.peer')",
+// the policy "AND('C.admin', Endorsers)" requires a signature from an
+// admin of C and one from a peer of either A or B
+func FromStringWithOptions(policy string, opts ParserOptions) (*common.SignaturePolicyEnvelope, error) {
+	// validate the policy first, in order to point out
+	// where exactly the policy is malformed or too large
+	err := newPolicyChecker(opts).check(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// then we translate the sub-policies into outof gates, so that
+	// they can be passed as parameters to the policy referencing them
+	parameters := make(map[string]interface{}, len(opts.SubPolicies))
+	for name, subPolicy := range opts.SubPolicies {
+		intermediateRes, err := toOutOf(subPolicy, nil)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid sub-policy '%s'", name))
+		}
+		parameters[name] = intermediateRes
+	}
+	// sub-policies may in turn reference other sub-policies,
+	// so we resolve references until none is left
+	for i := 0; i < len(opts.SubPolicies); i++ {
+		for name, intermediateRes := range parameters {
+			resolved, err := toOutOf(intermediateRes.(string), parameters)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid sub-policy '%s'", name))
+			}
+			parameters[name] = resolved
+		}
+	}
+
+	intermediateRes, err := toOutOf(policy, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromOutOf(intermediateRes)
+}
+
+// toOutOf translates the and/or business of the given policy into outof gates,
+// substituting the given parameters to the names the policy references.
+// Names without parameters are left in place if parameters are nil
+func toOutOf(policy string, parameters map[string]interface{}) (string, error) {
 	intermediate, err := govaluate.NewEvaluableExpressionWithFunctions(
 		policy, map[string]govaluate.ExpressionFunction{
 			GateAnd:                  and,
@@ -257,29 +326,52 @@ func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 		},
 	)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	if parameters == nil {
+		// leave the references in place, to be resolved later
+		parameters = make(map[string]interface{})
+		for _, name := range intermediate.Vars() {
+			parameters[name] = name
+		}
 	}
 
-	intermediateRes, err := intermediate.Evaluate(map[string]interface{}{})
+	intermediateRes, err := intermediate.Evaluate(parameters)
 	if err != nil {
 		// attempt to produce a meaningful error
 		if regexErr.MatchString(err.Error()) {
 			sm := regexErr.FindStringSubmatch(err.Error())
 			if len(sm) == 2 {
-				return nil, fmt.Errorf("unrecognized token '%s' in policy string", sm[1])
+				return "", fmt.Errorf("unrecognized token '%s' in policy string", sm[1])
 			}
 		}
 
-		return nil, err
+		return "", err
 	}
 
+	res, ok := intermediateRes.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected policy of type %s", reflect.TypeOf(intermediateRes))
+	}
+	if regex.MatchString(res) {
+		// the policy is a principal, which must stay quoted
+		// when referenced by other policies
+		res = "'" + res + "'"
+	}
+
+	return res, nil
+}
+
+// fromOutOf builds the SignaturePolicyEnvelope of a policy made of outof gates only
+func fromOutOf(intermediateRes string) (*common.SignaturePolicyEnvelope, error) {
 	// we still need two passes. The first pass just adds an extra
 	// argument ID to each of the outof calls. This is
 	// required because govaluate has no means of giving context
 	// to user-implemented functions other than via arguments.
 	// We need this argument because we need a global place where
 	// we put the identities that the policy requires
-	exp, err := govaluate.NewEvaluableExpressionWithFunctions(intermediateRes.(string), map[string]govaluate.ExpressionFunction{"outof": firstPass})
+	exp, err := govaluate.NewEvaluableExpressionWithFunctions(intermediateRes, map[string]govaluate.ExpressionFunction{"outof": firstPass})
 	if err != nil {
 		return nil, err
 	}
@@ -327,3 +419,215 @@ func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 
 	return p, nil
 }
+
+// gates maps the names of the gates accepted by the parser
+// to their canonical name
+var gates = map[string]string{
+	GateAnd:                    GateAnd,
+	strings.ToLower(GateAnd):   GateAnd,
+	strings.ToUpper(GateAnd):   GateAnd,
+	GateOr:                     GateOr,
+	strings.ToLower(GateOr):    GateOr,
+	strings.ToUpper(GateOr):    GateOr,
+	GateOutOf:                  GateOutOf,
+	strings.ToLower(GateOutOf): GateOutOf,
+	strings.ToUpper(GateOutOf): GateOutOf,
+}
+
+// policyChecker validates the syntax of policies and enforces the limits
+// of ParserOptions, reporting the position of the first problem it finds.
+// Positions are 1-based byte offsets in the policy string
+type policyChecker struct {
+	opts ParserOptions
+	// principals counts the principals found so far, sub-policies included
+	principals int
+	// resolving holds the sub-policies being checked, to detect cycles
+	resolving map[string]bool
+}
+
+// gateFrame tracks a gate whose arguments are being checked
+type gateFrame struct {
+	gate string
+	pos  int
+	// args counts the arguments of the gate that were completed
+	args int
+	// threshold is the first argument of an outof gate
+	threshold int
+}
+
+func newPolicyChecker(opts ParserOptions) *policyChecker {
+	return &policyChecker{opts: opts, resolving: make(map[string]bool)}
+}
+
+func (pc *policyChecker) check(policy string) error {
+	return pc.checkAtDepth(policy, 0)
+}
+
+// checkAtDepth checks a policy nested in the given number of gates
+func (pc *policyChecker) checkAtDepth(policy string, depth int) error {
+	var stack []*gateFrame
+	// expectArg tells whether an argument must come next,
+	// done whether the whole policy has been read
+	expectArg, done := true, false
+
+	// startArg checks that an argument may start at the given position
+	startArg := func(pos int) error {
+		if done {
+			return fmt.Errorf("unexpected token after the end of the policy at position %d", pos)
+		}
+		if !expectArg {
+			return fmt.Errorf("missing ',' before position %d", pos)
+		}
+		if len(stack) != 0 {
+			top := stack[len(stack)-1]
+			if top.gate == GateOutOf && top.args == 0 {
+				return fmt.Errorf("expected the threshold of the %s gate at position %d", GateOutOf, pos)
+			}
+		}
+		return nil
+	}
+	// endArg records the end of an argument
+	endArg := func() {
+		expectArg = false
+		done = len(stack) == 0
+	}
+
+	for i := 0; i < len(policy); {
+		c := policy[i]
+		pos := i + 1
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '\'' || c == '"':
+			if err := startArg(pos); err != nil {
+				return err
+			}
+			end := strings.IndexByte(policy[i+1:], c)
+			if end < 0 {
+				return fmt.Errorf("unterminated principal at position %d", pos)
+			}
+			principal := policy[i+1 : i+1+end]
+			if !regex.MatchString(principal) {
+				return fmt.Errorf("invalid principal '%s' at position %d", principal, pos)
+			}
+			pc.principals++
+			if pc.opts.MaxPrincipals > 0 && pc.principals > pc.opts.MaxPrincipals {
+				return fmt.Errorf("policy exceeds the maximum of %d principals at position %d", pc.opts.MaxPrincipals, pos)
+			}
+			i += end + 2
+			endArg()
+
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(policy) && (policy[j] >= '0' && policy[j] <= '9' || policy[j] == '.') {
+				j++
+			}
+			number := policy[i:j]
+			if done || !expectArg || len(stack) == 0 || stack[len(stack)-1].gate != GateOutOf || stack[len(stack)-1].args != 0 {
+				return fmt.Errorf("unexpected number %s at position %d", number, pos)
+			}
+			threshold, err := strconv.Atoi(number)
+			if err != nil {
+				return fmt.Errorf("invalid threshold %s at position %d", number, pos)
+			}
+			stack[len(stack)-1].threshold = threshold
+			i = j
+			expectArg = false
+
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			j := i
+			for j < len(policy) && (policy[j] >= 'a' && policy[j] <= 'z' || policy[j] >= 'A' && policy[j] <= 'Z' || policy[j] >= '0' && policy[j] <= '9' || policy[j] == '_') {
+				j++
+			}
+			name := policy[i:j]
+			if err := startArg(pos); err != nil {
+				return err
+			}
+
+			k := j
+			for k < len(policy) && (policy[k] == ' ' || policy[k] == '\t' || policy[k] == '\n' || policy[k] == '\r') {
+				k++
+			}
+			if k < len(policy) && policy[k] == '(' {
+				// this is a gate
+				gate, ok := gates[name]
+				if !ok {
+					return fmt.Errorf("unrecognized gate '%s' at position %d", name, pos)
+				}
+				stack = append(stack, &gateFrame{gate: gate, pos: pos})
+				if pc.opts.MaxDepth > 0 && depth+len(stack) > pc.opts.MaxDepth {
+					return fmt.Errorf("policy exceeds the maximum depth of %d at position %d", pc.opts.MaxDepth, pos)
+				}
+				i = k + 1
+				expectArg = true
+				continue
+			}
+
+			// this is a reference to a sub-policy
+			subPolicy, ok := pc.opts.SubPolicies[name]
+			if !ok {
+				return fmt.Errorf("unrecognized token '%s' at position %d", name, pos)
+			}
+			if pc.resolving[name] {
+				return fmt.Errorf("sub-policy '%s' references itself at position %d", name, pos)
+			}
+			pc.resolving[name] = true
+			err := pc.checkAtDepth(subPolicy, depth+len(stack))
+			delete(pc.resolving, name)
+			if err != nil {
+				return fmt.Errorf("invalid sub-policy '%s' referenced at position %d: %s", name, pos, err)
+			}
+			i = j
+			endArg()
+
+		case c == ',':
+			if len(stack) == 0 || expectArg {
+				return fmt.Errorf("unexpected ',' at position %d", pos)
+			}
+			stack[len(stack)-1].args++
+			i++
+			expectArg = true
+
+		case c == ')':
+			if len(stack) == 0 {
+				return fmt.Errorf("unexpected ')' at position %d", pos)
+			}
+			top := stack[len(stack)-1]
+			if expectArg {
+				if top.args == 0 {
+					return fmt.Errorf("%s gate at position %d has no arguments", top.gate, top.pos)
+				}
+				return fmt.Errorf("unexpected ')' at position %d", pos)
+			}
+			top.args++
+			if top.gate == GateOutOf {
+				// the first argument is the threshold
+				n := top.args - 1
+				if n == 0 {
+					return fmt.Errorf("%s gate at position %d has no policies", top.gate, top.pos)
+				}
+				if top.threshold < 1 || top.threshold > n {
+					return fmt.Errorf("%s gate at position %d requires %d out of %d policies", top.gate, top.pos, top.threshold, n)
+				}
+			}
+			stack = stack[:len(stack)-1]
+			i++
+			endArg()
+
+		default:
+			return fmt.Errorf("unexpected character '%c' at position %d", c, pos)
+		}
+	}
+
+	if len(stack) != 0 {
+		top := stack[len(stack)-1]
+		return fmt.Errorf("missing ')' for the %s gate at position %d", top.gate, top.pos)
+	}
+	if !done {
+		return errors.New("empty policy")
+	}
+
+	return nil
+}
@@ -96,7 +96,7 @@ func TestAnd(t *testing.T) {
 }
 
 func TestAndClientPeerOrderer(t *testing.T) {
-	p1, err := FromString("AND('A.client', 'B.peer')")
+	p1, err := FromString("AND('A.client', 'B.peer', 'C.orderer')")
 	assert.NoError(t, err)
 
 	principals := make([]*msp.MSPPrincipal, 0)
@@ -109,9 +109,13 @@ func TestAndClientPeerOrderer(t *testing.T) {
 		PrincipalClassification: msp.MSPPrincipal_ROLE,
 		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_PEER, MspIdentifier: "B"})})
 
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_ORDERER, MspIdentifier: "C"})})
+
 	p2 := &common.SignaturePolicyEnvelope{
 		Version:    0,
-		Rule:       And(SignedBy(0), SignedBy(1)),
+		Rule:       NOutOf(3, []*common.SignaturePolicy{SignedBy(0), SignedBy(1), SignedBy(2)}),
 		Identities: principals,
 	}
 
@@ -233,3 +237,100 @@ func TestBadStringsNoPanic(t *testing.T) {
 	_, err = FromString("OR('A.member', Bmember)")
 	assert.Error(t, err)
 }
+
+func TestOutOfThreshold(t *testing.T) {
+	_, err := FromString("OutOf(3, 'A.member', 'B.member')")
+	assert.EqualError(t, err, "OutOf gate at position 1 requires 3 out of 2 policies")
+
+	_, err = FromString("OR('A.member', OutOf(0, 'B.member'))")
+	assert.EqualError(t, err, "OutOf gate at position 16 requires 0 out of 1 policies")
+
+	_, err = FromString("OutOf(1)")
+	assert.EqualError(t, err, "OutOf gate at position 1 has no policies")
+
+	_, err = FromString("OutOf('A.member', 'B.member')")
+	assert.EqualError(t, err, "expected the threshold of the OutOf gate at position 7")
+}
+
+func TestSyntaxErrorPositions(t *testing.T) {
+	_, err := FromString("OR('A.member' 'B.member')")
+	assert.EqualError(t, err, "missing ',' before position 15")
+
+	_, err = FromString("OR('A.member', 'B.member'")
+	assert.EqualError(t, err, "missing ')' for the Or gate at position 1")
+
+	_, err = FromString("OR('A.member', 'Bmember')")
+	assert.EqualError(t, err, "invalid principal 'Bmember' at position 16")
+
+	_, err = FromString("OR('A.member', Unknown)")
+	assert.EqualError(t, err, "unrecognized token 'Unknown' at position 16")
+
+	_, err = FromString("NOR('A.member')")
+	assert.EqualError(t, err, "unrecognized gate 'NOR' at position 1")
+
+	_, err = FromString("OR()")
+	assert.EqualError(t, err, "Or gate at position 1 has no arguments")
+
+	_, err = FromString("OR('A.member') OR('B.member')")
+	assert.EqualError(t, err, "unexpected token after the end of the policy at position 16")
+
+	_, err = FromString("")
+	assert.EqualError(t, err, "empty policy")
+}
+
+func TestSubPolicies(t *testing.T) {
+	opts := ParserOptions{
+		SubPolicies: map[string]string{
+			"Endorsers": "OR('A.peer', Others)",
+			"Others":    "'B.peer'",
+		},
+	}
+
+	p1, err := FromStringWithOptions("AND('C.admin', Endorsers)", opts)
+	assert.NoError(t, err)
+	p2, err := FromString("AND('C.admin', OR('A.peer', 'B.peer'))")
+	assert.NoError(t, err)
+	assert.Equal(t, p2, p1)
+
+	// a policy can be a reference only
+	p1, err = FromStringWithOptions("Endorsers", opts)
+	assert.NoError(t, err)
+	p2, err = FromString("OR('A.peer', 'B.peer')")
+	assert.NoError(t, err)
+	assert.Equal(t, p2, p1)
+
+	// sub-policies cannot reference themselves
+	opts.SubPolicies["Others"] = "OR('B.peer', Endorsers)"
+	_, err = FromStringWithOptions("AND('C.admin', Endorsers)", opts)
+	assert.EqualError(t, err, "invalid sub-policy 'Endorsers' referenced at position 16: invalid sub-policy 'Others' referenced at position 14: sub-policy 'Endorsers' references itself at position 14")
+
+	// references are checked
+	opts.SubPolicies["Others"] = "OR('B.peer', Unknown)"
+	_, err = FromStringWithOptions("AND('C.admin', Endorsers)", opts)
+	assert.EqualError(t, err, "invalid sub-policy 'Endorsers' referenced at position 16: invalid sub-policy 'Others' referenced at position 14: unrecognized token 'Unknown' at position 14")
+}
+
+func TestPolicyLimits(t *testing.T) {
+	_, err := FromStringWithOptions("OR('A.member', AND('B.member', OR('C.member')))", ParserOptions{MaxDepth: 3})
+	assert.NoError(t, err)
+	_, err = FromStringWithOptions("OR('A.member', AND('B.member', OR('C.member')))", ParserOptions{MaxDepth: 2})
+	assert.EqualError(t, err, "policy exceeds the maximum depth of 2 at position 32")
+
+	_, err = FromStringWithOptions("OR('A.member', 'B.member', 'C.member')", ParserOptions{MaxPrincipals: 3})
+	assert.NoError(t, err)
+	_, err = FromStringWithOptions("OR('A.member', 'B.member', 'C.member')", ParserOptions{MaxPrincipals: 2})
+	assert.EqualError(t, err, "policy exceeds the maximum of 2 principals at position 28")
+
+	// sub-policies count towards the limits
+	opts := ParserOptions{
+		MaxDepth:      2,
+		MaxPrincipals: 3,
+		SubPolicies:   map[string]string{"Endorsers": "OR('A.peer', 'B.peer')"},
+	}
+	_, err = FromStringWithOptions("AND('C.admin', Endorsers)", opts)
+	assert.NoError(t, err)
+	_, err = FromStringWithOptions("AND('C.admin', OR(Endorsers))", opts)
+	assert.EqualError(t, err, "invalid sub-policy 'Endorsers' referenced at position 19: policy exceeds the maximum depth of 2 at position 1")
+	_, err = FromStringWithOptions("AND(Endorsers, Endorsers)", opts)
+	assert.EqualError(t, err, "invalid sub-policy 'Endorsers' referenced at position 16: policy exceeds the maximum of 3 principals at position 14")
+}
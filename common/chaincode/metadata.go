@@ -26,6 +26,10 @@ type Metadata struct {
 	Policy            []byte
 	Id                []byte
 	CollectionsConfig []byte
+	// SubPolicies maps names, such as the functions of the chaincode,
+	// to serialized signature policies that endorse invocations which
+	// select them instead of Policy
+	SubPolicies map[string][]byte
 }
 
 // MetadataSet defines an aggregation of Metadata
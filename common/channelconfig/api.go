@@ -26,6 +26,14 @@ type Org interface {
 	MSPID() string
 }
 
+// OrdererOrg stores the per org orderer config
+type OrdererOrg interface {
+	Org
+
+	// Endpoints returns the endpoints of orderer nodes
+	Endpoints() []string
+}
+
 // ApplicationOrg stores the per org application config
 type ApplicationOrg interface {
 	Org
@@ -98,7 +106,7 @@ type Orderer interface {
 	KafkaBrokers() []string
 
 	// Organizations returns the organizations for the ordering service
-	Organizations() map[string]Org
+	Organizations() map[string]OrdererOrg
 
 	// Capabilities defines the capabilities for the orderer portion of a channel
 	Capabilities() OrdererCapabilities
@@ -112,6 +120,9 @@ type ChannelCapabilities interface {
 	// MSPVersion specifies the version of the MSP this channel must understand, including the MSP types
 	// and MSP principal types.
 	MSPVersion() msp.MSPVersion
+
+	// SM3Hashing specifies whether the channel may use the SM3 national hashing algorithm
+	SM3Hashing() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
@@ -151,6 +162,10 @@ type ApplicationCapabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// FabToken returns true if this channel supports the experimental token transactions,
+	// which are validated and committed without invoking chaincodes
+	FabToken() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
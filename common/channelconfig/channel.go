@@ -160,7 +160,7 @@ func (cc *ChannelConfig) ConsortiumName() string {
 
 // Capabilities returns information about the available capabilities for this channel
 func (cc *ChannelConfig) Capabilities() ChannelCapabilities {
-	return capabilities.NewChannelProvider(cc.protos.Capabilities.Capabilities)
+	return capabilities.NewChannelProvider(cc.protos.Capabilities.GetCapabilities())
 }
 
 // Validate inspects the generated configuration protos and ensures that the values are correct
@@ -184,6 +184,11 @@ func (cc *ChannelConfig) validateHashingAlgorithm() error {
 		cc.hashingAlgorithm = util.ComputeSHA256
 	case bccsp.SHA3_256:
 		cc.hashingAlgorithm = util.ComputeSHA3256
+	case bccsp.GMSM3:
+		if !cc.Capabilities().SM3Hashing() {
+			return fmt.Errorf("Hashing algorithm %s requires the %s channel capability", bccsp.GMSM3, capabilities.ChannelGMSM3)
+		}
+		cc.hashingAlgorithm = util.ComputeSM3
 	default:
 		return fmt.Errorf("Unknown hashing algorithm type: %s", cc.protos.HashingAlgorithm.Name)
 	}
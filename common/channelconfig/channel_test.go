@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 
@@ -55,6 +56,18 @@ func TestHashingAlgorithm(t *testing.T) {
 
 	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3256).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
 		"Unexpected hashing algorithm returned")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.GMSM3}}}
+	assert.Error(t, cc.validateHashingAlgorithm(), "SM3 hashing algorithm supplied without the channel capability")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{
+		HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.GMSM3},
+		Capabilities:     &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.ChannelGMSM3: {}}},
+	}}
+	assert.NoError(t, cc.validateHashingAlgorithm(), "Allowed hashing algorith GMSM3 supplied")
+
+	assert.Equal(t, reflect.ValueOf(util.ComputeSM3).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
+		"Unexpected hashing algorithm returned")
 }
 
 func TestBlockDataHashingStructure(t *testing.T) {
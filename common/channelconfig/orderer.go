@@ -8,6 +8,7 @@ package channelconfig
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -40,6 +41,9 @@ const (
 
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message
 	KafkaBrokersKey = "KafkaBrokers"
+
+	// EndpointsKey is the cb.ConfigValue key name for the Endpoints message in the OrdererOrgGroup
+	EndpointsKey = "Endpoints"
 )
 
 // OrdererProtos is used as the source of the OrdererConfig
@@ -52,10 +56,74 @@ type OrdererProtos struct {
 	Capabilities        *cb.Capabilities
 }
 
+// OrdererOrgProtos are deserialized from the Orderer org config values
+type OrdererOrgProtos struct {
+	Endpoints *cb.OrdererAddresses
+}
+
+// OrdererOrgConfig defines the configuration for an orderer org
+type OrdererOrgConfig struct {
+	*OrganizationConfig
+	protos *OrdererOrgProtos
+	name   string
+}
+
+// Endpoints returns the set of addresses this ordering org exposes as orderers
+func (oc *OrdererOrgConfig) Endpoints() []string {
+	return oc.protos.Endpoints.GetAddresses()
+}
+
+// NewOrdererOrgConfig returns an orderer org config built from the given ConfigGroup
+func NewOrdererOrgConfig(orgName string, orgGroup *cb.ConfigGroup, mspConfigHandler *MSPConfigHandler) (*OrdererOrgConfig, error) {
+	if len(orgGroup.Groups) > 0 {
+		return nil, fmt.Errorf("OrdererOrg config does not allow sub-groups")
+	}
+
+	protos := &OrdererOrgProtos{}
+	orgProtos := &OrganizationProtos{}
+
+	if err := DeserializeProtoValuesFromGroup(orgGroup, protos, orgProtos); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize values")
+	}
+
+	ooc := &OrdererOrgConfig{
+		name:   orgName,
+		protos: protos,
+		OrganizationConfig: &OrganizationConfig{
+			name:             orgName,
+			protos:           orgProtos,
+			mspConfigHandler: mspConfigHandler,
+		},
+	}
+
+	if err := ooc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ooc, nil
+}
+
+// Validate returns whether the orderer org config is valid
+func (ooc *OrdererOrgConfig) Validate() error {
+	if err := ooc.validateEndpoints(); err != nil {
+		return err
+	}
+	return ooc.OrganizationConfig.Validate()
+}
+
+func (ooc *OrdererOrgConfig) validateEndpoints() error {
+	for _, endpoint := range ooc.Endpoints() {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return errors.Errorf("invalid orderer endpoint %s of org %s", endpoint, ooc.name)
+		}
+	}
+	return nil
+}
+
 // OrdererConfig holds the orderer configuration information
 type OrdererConfig struct {
 	protos *OrdererProtos
-	orgs   map[string]Org
+	orgs   map[string]OrdererOrg
 
 	batchTimeout time.Duration
 }
@@ -64,7 +132,7 @@ type OrdererConfig struct {
 func NewOrdererConfig(ordererGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler) (*OrdererConfig, error) {
 	oc := &OrdererConfig{
 		protos: &OrdererProtos{},
-		orgs:   make(map[string]Org),
+		orgs:   make(map[string]OrdererOrg),
 	}
 
 	if err := DeserializeProtoValuesFromGroup(ordererGroup, oc.protos); err != nil {
@@ -77,7 +145,7 @@ func NewOrdererConfig(ordererGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 
 	for orgName, orgGroup := range ordererGroup.Groups {
 		var err error
-		if oc.orgs[orgName], err = NewOrganizationConfig(orgName, orgGroup, mspConfig); err != nil {
+		if oc.orgs[orgName], err = NewOrdererOrgConfig(orgName, orgGroup, mspConfig); err != nil {
 			return nil, err
 		}
 	}
@@ -112,7 +180,7 @@ func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 }
 
 // Organizations returns a map of the orgs in the channel
-func (oc *OrdererConfig) Organizations() map[string]Org {
+func (oc *OrdererConfig) Organizations() map[string]OrdererOrg {
 	return oc.orgs
 }
 
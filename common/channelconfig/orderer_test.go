@@ -9,6 +9,7 @@ package channelconfig
 import (
 	"testing"
 
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	logging "github.com/op/go-logging"
@@ -55,3 +56,20 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+func TestOrdererOrgInterface(t *testing.T) {
+	_ = OrdererOrg(&OrdererOrgConfig{})
+}
+
+func TestOrdererOrgEndpoints(t *testing.T) {
+	ooc := &OrdererOrgConfig{name: "OrdererOrg", protos: &OrdererOrgProtos{}}
+	assert.Empty(t, ooc.Endpoints(), "No endpoints defined")
+	assert.NoError(t, ooc.validateEndpoints())
+
+	ooc.protos.Endpoints = &cb.OrdererAddresses{Addresses: []string{"orderer0:7050", "127.0.0.1:7050"}}
+	assert.Equal(t, []string{"orderer0:7050", "127.0.0.1:7050"}, ooc.Endpoints())
+	assert.NoError(t, ooc.validateEndpoints(), "Valid orderer endpoints")
+
+	ooc.protos.Endpoints.Addresses = append(ooc.protos.Endpoints.Addresses, "orderer1")
+	assert.EqualError(t, ooc.validateEndpoints(), "invalid orderer endpoint orderer1 of org OrdererOrg")
+}
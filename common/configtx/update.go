@@ -67,6 +67,32 @@ func validateModPolicy(modPolicy string) error {
 }
 
 func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedData []*cb.SignedData) error {
+	if err := vi.verifyDeltaSetVersions(deltaSet); err != nil {
+		return err
+	}
+
+	for key := range deltaSet {
+		existing, ok := vi.configMap[key]
+		if !ok {
+			continue
+		}
+
+		policy, ok := vi.policyForItem(existing)
+		if !ok {
+			return errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
+		}
+
+		// Ensure the policy is satisfied
+		if err := policy.Evaluate(signedData); err != nil {
+			return errors.Wrapf(err, "policy for %s not satisfied", key)
+		}
+	}
+	return nil
+}
+
+// verifyDeltaSetVersions validates the mod_policy and version of each element
+// of the delta set, regardless of whether its modification is authorized
+func (vi *ValidatorImpl) verifyDeltaSetVersions(deltaSet map[string]comparable) error {
 	if len(deltaSet) == 0 {
 		return errors.Errorf("delta set was empty -- update would have no effect")
 	}
@@ -88,16 +114,6 @@ func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedDa
 		if value.version() != existing.version()+1 {
 			return errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
 		}
-
-		policy, ok := vi.policyForItem(existing)
-		if !ok {
-			return errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
-		}
-
-		// Ensure the policy is satisfied
-		if err := policy.Evaluate(signedData); err != nil {
-			return errors.Wrapf(err, "policy for %s not satisfied", key)
-		}
 	}
 	return nil
 }
@@ -123,6 +139,22 @@ func (vi *ValidatorImpl) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelop
 		return nil, err
 	}
 
+	return vi.applyUpdate(configUpdate, func(deltaSet map[string]comparable) error {
+		signedData, err := configUpdateEnv.AsSignedData()
+		if err != nil {
+			return err
+		}
+
+		if err = vi.verifyDeltaSet(deltaSet, signedData); err != nil {
+			return errors.Wrapf(err, "error validating DeltaSet")
+		}
+		return nil
+	})
+}
+
+// applyUpdate validates the read set of the config update, lets verify validate the
+// delta set of the update and returns the map of the config resulting from the update
+func (vi *ValidatorImpl) applyUpdate(configUpdate *cb.ConfigUpdate, verify func(deltaSet map[string]comparable) error) (map[string]comparable, error) {
 	if configUpdate.ChannelId != vi.channelID {
 		return nil, errors.Errorf("Update not for correct channel: %s for %s", configUpdate.ChannelId, vi.channelID)
 	}
@@ -142,15 +174,10 @@ func (vi *ValidatorImpl) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelop
 	}
 
 	deltaSet := computeDeltaSet(readSet, writeSet)
-	signedData, err := configUpdateEnv.AsSignedData()
-	if err != nil {
+	if err := verify(deltaSet); err != nil {
 		return nil, err
 	}
 
-	if err = vi.verifyDeltaSet(deltaSet, signedData); err != nil {
-		return nil, errors.Wrapf(err, "error validating DeltaSet")
-	}
-
 	fullProposedConfig := vi.computeUpdateResult(deltaSet)
 	if err := verifyFullProposedConfig(writeSet, fullProposedConfig); err != nil {
 		return nil, errors.Wrapf(err, "full config did not verify")
@@ -159,6 +186,37 @@ func (vi *ValidatorImpl) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelop
 	return fullProposedConfig, nil
 }
 
+// ApplyConfigUpdate returns the config which results from applying the given config
+// update to the given config of the channel. The modification policies of the modified
+// elements aren't evaluated, so that the impact of an update can be assessed before it is signed.
+func ApplyConfigUpdate(channelID string, config *cb.Config, namespace string, configUpdate *cb.ConfigUpdate) (*cb.Config, error) {
+	if configUpdate == nil {
+		return nil, errors.Errorf("cannot process nil ConfigUpdate")
+	}
+
+	vi, err := NewValidatorImpl(channelID, config, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := vi.applyUpdate(configUpdate, func(deltaSet map[string]comparable) error {
+		return errors.Wrapf(vi.verifyDeltaSetVersions(deltaSet), "error validating DeltaSet")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	channelGroup, err := configMapToConfig(configMap, namespace)
+	if err != nil {
+		return nil, errors.Errorf("could not turn configMap back to channelGroup: %s", err)
+	}
+
+	return &cb.Config{
+		Sequence:     config.Sequence + 1,
+		ChannelGroup: channelGroup,
+	}, nil
+}
+
 func (vi *ValidatorImpl) policyForItem(item comparable) (policies.Policy, bool) {
 	manager := vi.pm
 
@@ -209,3 +209,58 @@ func TestValidateModPolicy(t *testing.T) {
 		assert.Regexp(t, "path element at 1 is invalid", validateModPolicy("foo//bar"))
 	})
 }
+
+func TestApplyConfigUpdate(t *testing.T) {
+	config := makeConfig(makeConfigPair("foo", "foo", 0, []byte("foo")), makeConfigPair("bar", "bar", 0, []byte("bar")))
+	config.Sequence = 3
+
+	t.Run("Green path", func(t *testing.T) {
+		configUpdate := &cb.ConfigUpdate{
+			ChannelId: defaultChain,
+			ReadSet:   makeConfigSet(),
+			WriteSet:  makeConfigSet(makeConfigPair("foo", "foo", 1, []byte("baz"))),
+		}
+		updated, err := ApplyConfigUpdate(defaultChain, config, "foonamespace", configUpdate)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(4), updated.Sequence)
+		assert.Equal(t, []byte("baz"), updated.ChannelGroup.Values["foo"].Value)
+		assert.Equal(t, []byte("bar"), updated.ChannelGroup.Values["bar"].Value)
+		// The config the update was applied to is left intact
+		assert.Equal(t, []byte("foo"), config.ChannelGroup.Values["foo"].Value)
+	})
+
+	t.Run("Nil config update", func(t *testing.T) {
+		_, err := ApplyConfigUpdate(defaultChain, config, "foonamespace", nil)
+		assert.EqualError(t, err, "cannot process nil ConfigUpdate")
+	})
+
+	t.Run("Wrong channel", func(t *testing.T) {
+		configUpdate := &cb.ConfigUpdate{
+			ChannelId: "wrongChain",
+			ReadSet:   makeConfigSet(),
+			WriteSet:  makeConfigSet(makeConfigPair("foo", "foo", 1, []byte("baz"))),
+		}
+		_, err := ApplyConfigUpdate(defaultChain, config, "foonamespace", configUpdate)
+		assert.EqualError(t, err, "Update not for correct channel: wrongChain for "+defaultChain)
+	})
+
+	t.Run("Version skip", func(t *testing.T) {
+		configUpdate := &cb.ConfigUpdate{
+			ChannelId: defaultChain,
+			ReadSet:   makeConfigSet(),
+			WriteSet:  makeConfigSet(makeConfigPair("foo", "foo", 2, []byte("baz"))),
+		}
+		_, err := ApplyConfigUpdate(defaultChain, config, "foonamespace", configUpdate)
+		assert.EqualError(t, err, "error validating DeltaSet: attempt to set key [Value]  /foonamespace/foo to version 2, but key is at version 0")
+	})
+
+	t.Run("Empty update", func(t *testing.T) {
+		configUpdate := &cb.ConfigUpdate{
+			ChannelId: defaultChain,
+			ReadSet:   makeConfigSet(),
+			WriteSet:  makeConfigSet(),
+		}
+		_, err := ApplyConfigUpdate(defaultChain, config, "foonamespace", configUpdate)
+		assert.EqualError(t, err, "error validating DeltaSet: delta set was empty -- update would have no effect")
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// maxBlocksPerBatch bounds the number of blocks buffered for a single batch
+// regardless of what the client asked for
+const maxBlocksPerBatch = 1000
+
+//go:generate counterfeiter -o mock/block_batch_response_sender.go -fake-name BlockBatchResponseSender . BlockBatchResponseSender
+
+// BlockBatchResponseSender is implemented by response senders which are able to
+// deliver several, possibly compressed, blocks in a single response.
+type BlockBatchResponseSender interface {
+	SendBlockBatchResponse(batch *cb.BlockBatch) error
+}
+
+// blockBatcher accumulates consecutive blocks to be sent as a single batch
+type blockBatcher struct {
+	sender      BlockBatchResponseSender
+	compression cb.Compression
+	maxBlocks   int
+	maxBytes    int
+	blocks      []*cb.Block
+	size        int
+}
+
+// newBlockBatcher returns a blockBatcher if the stream options ask for batching
+// or compression and the response sender supports it, or nil otherwise
+func newBlockBatcher(rs ResponseSender, options *ab.BlockStreamOptions) *blockBatcher {
+	if options == nil || (options.MaxBatchBlocks <= 1 && options.Compression == cb.Compression_UNCOMPRESSED) {
+		return nil
+	}
+	sender, ok := rs.(BlockBatchResponseSender)
+	if !ok {
+		return nil
+	}
+	maxBlocks := int(options.MaxBatchBlocks)
+	if maxBlocks < 1 {
+		maxBlocks = 1
+	}
+	if maxBlocks > maxBlocksPerBatch {
+		maxBlocks = maxBlocksPerBatch
+	}
+	return &blockBatcher{
+		sender:      sender,
+		compression: options.Compression,
+		maxBlocks:   maxBlocks,
+		maxBytes:    int(options.MaxBatchBytes),
+	}
+}
+
+// deliver adds the block to the pending batch, and sends the batch once it is
+// full, the stop block was reached or the next block is not available yet
+func (b *blockBatcher) deliver(block *cb.Block, stopNum, height uint64) error {
+	if err := b.add(block); err != nil {
+		return err
+	}
+	if b.full() || stopNum == block.Header.Number || block.Header.Number+1 >= height {
+		return b.flush()
+	}
+	return nil
+}
+
+// add appends the block to the pending batch, sending the pending blocks first
+// if the block would not fit into the same batch
+func (b *blockBatcher) add(block *cb.Block) error {
+	size := proto.Size(block)
+	if len(b.blocks) > 0 && b.maxBytes > 0 && b.size+size > b.maxBytes {
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+	b.blocks = append(b.blocks, block)
+	b.size += size
+	return nil
+}
+
+// full returns whether the pending batch reached its size limits
+func (b *blockBatcher) full() bool {
+	return len(b.blocks) >= b.maxBlocks || (b.maxBytes > 0 && b.size >= b.maxBytes)
+}
+
+// flush sends the pending blocks, if any
+func (b *blockBatcher) flush() error {
+	if len(b.blocks) == 0 {
+		return nil
+	}
+	batch, err := utils.NewBlockBatch(b.blocks, b.compression)
+	if err != nil {
+		return err
+	}
+	b.blocks = nil
+	b.size = 0
+	return b.sender.SendBlockBatchResponse(batch)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// CheckpointToken returns the token of the checkpoint right after the transaction
+// with the given index and ID of the block with the given number
+func CheckpointToken(blockNumber uint64, txIndex int, txID string) []byte {
+	return utils.MarshalOrPanic(&ab.DeliverCheckpoint{
+		BlockNumber: blockNumber,
+		TxIndex:     uint32(txIndex),
+		TxId:        txID,
+	})
+}
+
+// BlockCheckpointToken returns the token of the checkpoint right after the last transaction
+// of the given block, or nil if the ID of the transaction can't be extracted
+func BlockCheckpointToken(block *cb.Block) []byte {
+	if block == nil || block.Header == nil || block.Data == nil || len(block.Data.Data) == 0 {
+		return nil
+	}
+	txIndex := len(block.Data.Data) - 1
+	txID, err := txIDAt(block, txIndex)
+	if err != nil {
+		logger.Debugf("Not issuing a checkpoint for block %d: %s", block.Header.Number, err)
+		return nil
+	}
+	return CheckpointToken(block.Header.Number, txIndex, txID)
+}
+
+// unmarshalCheckpoint unmarshals the given checkpoint token
+func unmarshalCheckpoint(token []byte) (*ab.DeliverCheckpoint, error) {
+	checkpoint := &ab.DeliverCheckpoint{}
+	if err := proto.Unmarshal(token, checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// resumeAfter returns a copy of the given block of the given checkpoint without the data of the transactions
+// up to the transaction of the checkpoint, which were delivered already, or nil if all the transactions of
+// the block were delivered. It fails if the transaction of the checkpoint isn't the transaction of the block
+func resumeAfter(block *cb.Block, checkpoint *ab.DeliverCheckpoint) (*cb.Block, error) {
+	txIndex := int(checkpoint.TxIndex)
+	if block.Data == nil || txIndex >= len(block.Data.Data) {
+		return nil, errors.Errorf("block %d has no transaction %d", block.Header.Number, txIndex)
+	}
+	txID, err := txIDAt(block, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	if txID != checkpoint.TxId {
+		return nil, errors.Errorf("transaction %d of block %d is %s, not %s", txIndex, block.Header.Number, txID, checkpoint.TxId)
+	}
+	if txIndex == len(block.Data.Data)-1 {
+		return nil, nil
+	}
+	data := make([][]byte, len(block.Data.Data))
+	copy(data[txIndex+1:], block.Data.Data[txIndex+1:])
+	return &cb.Block{
+		Header:   block.Header,
+		Data:     &cb.BlockData{Data: data},
+		Metadata: block.Metadata,
+	}, nil
+}
+
+// txIDAt returns the ID of the transaction with the given index of the given block
+func txIDAt(block *cb.Block, txIndex int) (string, error) {
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[txIndex])
+	if err != nil {
+		return "", errors.WithMessage(err, "failed unmarshaling transaction")
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed unmarshaling channel header of transaction")
+	}
+	return chdr.TxId, nil
+}
@@ -217,6 +217,17 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
 	}
 
+	var resumeFrom *ab.DeliverCheckpoint
+	if len(seekInfo.Checkpoint) > 0 {
+		if resumeFrom, err = unmarshalCheckpoint(seekInfo.Checkpoint); err != nil {
+			logger.Warningf("[channel: %s] Received seekInfo message from %s with malformed checkpoint: %s", chdr.ChannelId, addr, err)
+			return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+		}
+		seekInfo.Start = &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: resumeFrom.BlockNumber}},
+		}
+	}
+
 	if seekInfo.Start == nil || seekInfo.Stop == nil {
 		logger.Warningf("[channel: %s] Received seekInfo message from %s with missing start or stop %v, %v", chdr.ChannelId, addr, seekInfo.Start, seekInfo.Stop)
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
@@ -240,6 +251,8 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		}
 	}
 
+	batcher := newBlockBatcher(srv.ResponseSender, seekInfo.StreamOptions)
+
 	for {
 		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
 			if number > chain.Reader().Height()-1 {
@@ -262,14 +275,30 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			return srv.SendStatusResponse(cb.Status_FORBIDDEN)
 		}
 
-		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
+		blockNum := block.Header.Number
+		if resumeFrom != nil && blockNum == resumeFrom.BlockNumber {
+			// the transactions up to the checkpoint were delivered already
+			if block, err = resumeAfter(block, resumeFrom); err != nil {
+				logger.Warningf("[channel: %s] Received seekInfo message from %s with a checkpoint that doesn't match the ledger: %s", chdr.ChannelId, addr, err)
+				return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
+			}
+		}
 
-		if err := srv.SendBlockResponse(block); err != nil {
-			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
-			return err
+		if block != nil {
+			logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
+
+			if batcher == nil {
+				if err := srv.SendBlockResponse(block); err != nil {
+					logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
+					return err
+				}
+			} else if err := batcher.deliver(block, stopNum, chain.Reader().Height()); err != nil {
+				logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
+				return err
+			}
 		}
 
-		if stopNum == block.Header.Number {
+		if stopNum == blockNum {
 			break
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/deliver/mock"
@@ -35,6 +36,11 @@ var (
 	}
 )
 
+type batchingResponseSender struct {
+	*mock.ResponseSender
+	*mock.BlockBatchResponseSender
+}
+
 var _ = Describe("Deliver", func() {
 	Describe("NewHandler", func() {
 		var fakeChainManager *mock.ChainManager
@@ -271,6 +277,104 @@ var _ = Describe("Deliver", func() {
 					}))
 				}
 			})
+
+			Context("when the client asks for block batches", func() {
+				var fakeBatchSender *mock.BlockBatchResponseSender
+
+				BeforeEach(func() {
+					fakeBatchSender = &mock.BlockBatchResponseSender{}
+					server.ResponseSender = &batchingResponseSender{
+						ResponseSender:           fakeResponseSender,
+						BlockBatchResponseSender: fakeBatchSender,
+					}
+					seekInfo.StreamOptions = &ab.BlockStreamOptions{MaxBatchBlocks: 2}
+				})
+
+				It("sends the blocks in batches up to the stop block", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeBatchSender.SendBlockBatchResponseCallCount()).To(Equal(3))
+					var numbers [][]uint64
+					for i := 0; i < 3; i++ {
+						batch := fakeBatchSender.SendBlockBatchResponseArgsForCall(i)
+						Expect(batch.Compression).To(Equal(cb.Compression_UNCOMPRESSED))
+						var batchNumbers []uint64
+						for _, b := range batch.Blocks {
+							batchNumbers = append(batchNumbers, b.Header.Number)
+						}
+						numbers = append(numbers, batchNumbers)
+					}
+					Expect(numbers).To(Equal([][]uint64{{995, 996}, {997, 998}, {999}}))
+				})
+
+				Context("when the batch would exceed the byte limit", func() {
+					BeforeEach(func() {
+						blockSize := proto.Size(&cb.Block{Header: &cb.BlockHeader{Number: 995}})
+						seekInfo.StreamOptions.MaxBatchBlocks = 10
+						seekInfo.StreamOptions.MaxBatchBytes = uint32(blockSize + 1)
+					})
+
+					It("sends each block in its own batch", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBatchSender.SendBlockBatchResponseCallCount()).To(Equal(5))
+						for i := 0; i < 5; i++ {
+							batch := fakeBatchSender.SendBlockBatchResponseArgsForCall(i)
+							Expect(batch.Blocks).To(HaveLen(1))
+							Expect(batch.Blocks[0].Header.Number).To(Equal(995 + uint64(i)))
+						}
+					})
+				})
+
+				Context("when the client asks for compression", func() {
+					BeforeEach(func() {
+						seekInfo.StreamOptions = &ab.BlockStreamOptions{Compression: cb.Compression_GZIP}
+					})
+
+					It("sends every block compressed", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBatchSender.SendBlockBatchResponseCallCount()).To(Equal(5))
+						for i := 0; i < 5; i++ {
+							batch := fakeBatchSender.SendBlockBatchResponseArgsForCall(i)
+							Expect(batch.Compression).To(Equal(cb.Compression_GZIP))
+							blocks, err := utils.GetBlocksFromBlockBatch(batch)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(blocks).To(HaveLen(1))
+							Expect(proto.Equal(blocks[0], &cb.Block{Header: &cb.BlockHeader{Number: 995 + uint64(i)}})).To(BeTrue())
+						}
+					})
+				})
+
+				Context("when sending the batch fails", func() {
+					BeforeEach(func() {
+						fakeBatchSender.SendBlockBatchResponseReturns(errors.New("send-batch-fails"))
+					})
+
+					It("returns the error", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).To(MatchError("send-batch-fails"))
+					})
+				})
+
+				Context("when the response sender does not support batches", func() {
+					BeforeEach(func() {
+						server.ResponseSender = fakeResponseSender
+					})
+
+					It("sends the blocks one by one", func() {
+						err := handler.Handle(context.Background(), server)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+						Expect(fakeBatchSender.SendBlockBatchResponseCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 
 		Context("when seek info is configured to stop at the oldest block", func() {
@@ -565,6 +669,95 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when seek info has a checkpoint", func() {
+			BeforeEach(func() {
+				block := &cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data:   &cb.BlockData{Data: [][]byte{transaction("tx0"), transaction("tx1"), transaction("tx2")}},
+				}
+				fakeBlockIterator.NextReturns(block, cb.Status_SUCCESS)
+				seekInfo.Start = nil
+				seekInfo.Checkpoint = deliver.CheckpointToken(100, 1, "tx1")
+			})
+
+			It("resumes the deliver right after the transaction of the checkpoint", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBlockReader.IteratorCallCount()).To(Equal(1))
+				startPosition := fakeBlockReader.IteratorArgsForCall(0)
+				Expect(startPosition).To(Equal(&ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 100}},
+				}))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b.Data.Data).To(Equal([][]byte{nil, nil, transaction("tx2")}))
+			})
+
+			Context("when all the transactions of the block were delivered", func() {
+				BeforeEach(func() {
+					seekInfo.Checkpoint = deliver.CheckpointToken(100, 2, "tx2")
+				})
+
+				It("doesn't deliver the block again", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+
+			Context("when the transaction of the checkpoint doesn't match the ledger", func() {
+				BeforeEach(func() {
+					seekInfo.Checkpoint = deliver.CheckpointToken(100, 1, "tx2")
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the block of the checkpoint has no such transaction", func() {
+				BeforeEach(func() {
+					seekInfo.Checkpoint = deliver.CheckpointToken(100, 3, "tx3")
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the checkpoint is malformed", func() {
+				BeforeEach(func() {
+					seekInfo.Checkpoint = []byte("complete-nonsense")
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
+
 		Context("when seek info start number is greater than stop number", func() {
 			BeforeEach(func() {
 				seekInfo = &ab.SeekInfo{
@@ -627,4 +820,42 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 	})
+
+	Describe("BlockCheckpointToken", func() {
+		It("returns the checkpoint token of the last transaction of the block", func() {
+			block := &cb.Block{
+				Header: &cb.BlockHeader{Number: 100},
+				Data:   &cb.BlockData{Data: [][]byte{transaction("tx0"), transaction("tx1")}},
+			}
+			Expect(deliver.BlockCheckpointToken(block)).To(Equal(deliver.CheckpointToken(100, 1, "tx1")))
+		})
+
+		Context("when the block has no transactions", func() {
+			It("returns nil", func() {
+				block := &cb.Block{Header: &cb.BlockHeader{Number: 100}}
+				Expect(deliver.BlockCheckpointToken(block)).To(BeNil())
+			})
+		})
+
+		Context("when the last transaction of the block is malformed", func() {
+			It("returns nil", func() {
+				block := &cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data:   &cb.BlockData{Data: [][]byte{[]byte("complete-nonsense")}},
+				}
+				Expect(deliver.BlockCheckpointToken(block)).To(BeNil())
+			})
+		})
+	})
 })
+
+// transaction returns a marshaled transaction envelope with the given ID
+func transaction(txID string) []byte {
+	return utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+			},
+		}),
+	})
+}
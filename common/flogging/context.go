@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/op/go-logging"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+// Fields are the fields of a log entry that correlate it with the
+// transaction being processed, across the logs of the endorser,
+// the orderer and the committer
+type Fields struct {
+	Channel   string `json:"channel,omitempty"`
+	TxID      string `json:"txid,omitempty"`
+	Chaincode string `json:"chaincode,omitempty"`
+	GRPCPeer  string `json:"grpc_peer,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+	SpanID    string `json:"span_id,omitempty"`
+}
+
+// merge returns the fields, overridden by the other fields that are set
+func (f Fields) merge(other Fields) Fields {
+	if other.Channel != "" {
+		f.Channel = other.Channel
+	}
+	if other.TxID != "" {
+		f.TxID = other.TxID
+	}
+	if other.Chaincode != "" {
+		f.Chaincode = other.Chaincode
+	}
+	if other.GRPCPeer != "" {
+		f.GRPCPeer = other.GRPCPeer
+	}
+	if other.TraceID != "" {
+		f.TraceID = other.TraceID
+	}
+	if other.SpanID != "" {
+		f.SpanID = other.SpanID
+	}
+	return f
+}
+
+type fieldsKey struct{}
+
+// WithFields returns a copy of the context carrying the fields, which
+// override the fields already carried by the context if they are set
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	existing, _ := ctx.Value(fieldsKey{}).(Fields)
+	return context.WithValue(ctx, fieldsKey{}, existing.merge(fields))
+}
+
+// FieldsFromContext returns the fields carried by the context.
+// Unless they are set explicitly, the gRPC peer is the remote address of
+// the gRPC call of the context, and the trace ID is derived from the
+// transaction ID the same way the tracing package derives it.
+func FieldsFromContext(ctx context.Context) Fields {
+	if ctx == nil {
+		return Fields{}
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(Fields)
+	if fields.GRPCPeer == "" {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			fields.GRPCPeer = p.Addr.String()
+		}
+	}
+	if fields.TraceID == "" && fields.TxID != "" {
+		fields.TraceID = otlp.TraceID(fields.TxID)
+	}
+	return fields
+}
+
+// fieldsMessage is the argument a ContextLogger logs its entries with,
+// from which the JSON format takes the fields of the entry.
+// The other formats only print the message.
+type fieldsMessage struct {
+	fields  Fields
+	message string
+}
+
+func (m *fieldsMessage) String() string {
+	return m.message
+}
+
+// ContextLogger logs entries that carry the correlation fields of a context
+type ContextLogger struct {
+	logger *logging.Logger
+	fields Fields
+}
+
+// FromContext returns a logger of the module of the given logger,
+// whose entries carry the correlation fields of the context
+func FromContext(ctx context.Context, l *logging.Logger) *ContextLogger {
+	cl := logging.MustGetLogger(l.Module)
+	cl.ExtraCalldepth = l.ExtraCalldepth + 1
+	return &ContextLogger{logger: cl, fields: FieldsFromContext(ctx)}
+}
+
+// Fields returns the correlation fields of the entries of the logger
+func (c *ContextLogger) Fields() Fields {
+	return c.fields
+}
+
+// IsEnabledFor returns true if the logger is enabled for the given level
+func (c *ContextLogger) IsEnabledFor(level logging.Level) bool {
+	return c.logger.IsEnabledFor(level)
+}
+
+func (c *ContextLogger) message(args []interface{}) *fieldsMessage {
+	// use Fprintln to make sure we always get space between arguments,
+	// like go-logging does
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, args...)
+	buf.Truncate(buf.Len() - 1)
+	return &fieldsMessage{fields: c.fields, message: buf.String()}
+}
+
+func (c *ContextLogger) messagef(format string, args []interface{}) *fieldsMessage {
+	return &fieldsMessage{fields: c.fields, message: fmt.Sprintf(format, args...)}
+}
+
+// Debug logs a message at level DEBUG
+func (c *ContextLogger) Debug(args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.DEBUG) {
+		c.logger.Debug(c.message(args))
+	}
+}
+
+// Debugf logs a message at level DEBUG
+func (c *ContextLogger) Debugf(format string, args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.DEBUG) {
+		c.logger.Debug(c.messagef(format, args))
+	}
+}
+
+// Info logs a message at level INFO
+func (c *ContextLogger) Info(args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.INFO) {
+		c.logger.Info(c.message(args))
+	}
+}
+
+// Infof logs a message at level INFO
+func (c *ContextLogger) Infof(format string, args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.INFO) {
+		c.logger.Info(c.messagef(format, args))
+	}
+}
+
+// Warning logs a message at level WARNING
+func (c *ContextLogger) Warning(args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.WARNING) {
+		c.logger.Warning(c.message(args))
+	}
+}
+
+// Warningf logs a message at level WARNING
+func (c *ContextLogger) Warningf(format string, args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.WARNING) {
+		c.logger.Warning(c.messagef(format, args))
+	}
+}
+
+// Error logs a message at level ERROR
+func (c *ContextLogger) Error(args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.ERROR) {
+		c.logger.Error(c.message(args))
+	}
+}
+
+// Errorf logs a message at level ERROR
+func (c *ContextLogger) Errorf(format string, args ...interface{}) {
+	if c.logger.IsEnabledFor(logging.ERROR) {
+		c.logger.Error(c.messagef(format, args))
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/op/go-logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+)
+
+func TestFieldsFromContext(t *testing.T) {
+	assert.Equal(t, flogging.Fields{}, flogging.FieldsFromContext(context.Background()))
+
+	ctx := flogging.WithFields(context.Background(), flogging.Fields{Channel: "mychannel", Chaincode: "mycc"})
+	ctx = flogging.WithFields(ctx, flogging.Fields{TxID: "tx1", Chaincode: "lscc"})
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7051}})
+	assert.Equal(t, flogging.Fields{
+		Channel:   "mychannel",
+		TxID:      "tx1",
+		Chaincode: "lscc",
+		GRPCPeer:  "127.0.0.1:7051",
+		TraceID:   otlp.TraceID("tx1"),
+	}, flogging.FieldsFromContext(ctx))
+
+	ctx = flogging.WithFields(ctx, flogging.Fields{GRPCPeer: "orderer:7050", SpanID: "0102030405060708"})
+	fields := flogging.FieldsFromContext(ctx)
+	assert.Equal(t, "orderer:7050", fields.GRPCPeer)
+	assert.Equal(t, "0102030405060708", fields.SpanID)
+}
+
+func TestJSONFormat(t *testing.T) {
+	defer flogging.Reset()
+	buf := &bytes.Buffer{}
+	flogging.InitBackend(flogging.SetFormat(flogging.JSONFormat), buf)
+
+	entries := func() []map[string]interface{} {
+		var entries []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			entry := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+			entries = append(entries, entry)
+		}
+		buf.Reset()
+		return entries
+	}
+
+	l := flogging.MustGetLogger("json-test")
+	l.Infof("plain %s", "entry")
+	entry := entries()[0]
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "json-test", entry["module"])
+	assert.Equal(t, "plain entry", entry["msg"])
+	assert.Regexp(t, `^context_test\.go:\d+$`, entry["caller"])
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}`, entry["ts"])
+	assert.NotContains(t, entry, "txid")
+
+	ctx := flogging.WithFields(context.Background(), flogging.Fields{Channel: "mychannel", TxID: "tx1", Chaincode: "mycc"})
+	cl := flogging.FromContext(ctx, l)
+	cl.Warningf("endorsement of %s failed", "tx1")
+	cl.Error("invalid", "transaction")
+	cl.Debug("not logged at level INFO")
+	logged := entries()
+	require.Len(t, logged, 2)
+	entry = logged[0]
+	assert.Equal(t, "WARNING", entry["level"])
+	assert.Equal(t, "endorsement of tx1 failed", entry["msg"])
+	assert.Equal(t, "mychannel", entry["channel"])
+	assert.Equal(t, "tx1", entry["txid"])
+	assert.Equal(t, "mycc", entry["chaincode"])
+	assert.Equal(t, otlp.TraceID("tx1"), entry["trace_id"])
+	assert.NotContains(t, entry, "grpc_peer")
+	// The caller is the caller of the context logger
+	assert.Regexp(t, `^context_test\.go:\d+$`, entry["caller"])
+	assert.Equal(t, "invalid transaction", logged[1]["msg"])
+	assert.Equal(t, "ERROR", logged[1]["level"])
+}
+
+func TestContextLoggerTextFormat(t *testing.T) {
+	defer flogging.Reset()
+	buf := &bytes.Buffer{}
+	flogging.InitBackend(flogging.SetFormat("%{shortfile} %{message}"), buf)
+
+	ctx := flogging.WithFields(context.Background(), flogging.Fields{TxID: "tx1"})
+	cl := flogging.FromContext(ctx, flogging.MustGetLogger("text-test"))
+	assert.True(t, cl.IsEnabledFor(logging.INFO))
+	assert.Equal(t, "tx1", cl.Fields().TxID)
+	cl.Infof("committed %d transactions", 3)
+	// Fields are only emitted by the JSON format
+	assert.Regexp(t, `^context_test\.go:\d+ committed 3 transactions\n$`, buf.String())
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+
+	"github.com/op/go-logging"
+)
+
+// JSONFormat is the logging format that emits each log entry as a JSON
+// object on a line of its own, carrying the correlation fields of the
+// entry, such as the channel and transaction ID, as separate keys
+const JSONFormat = "json"
+
+const jsonTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+type jsonEntry struct {
+	Time    string `json:"ts"`
+	Level   string `json:"level"`
+	Module  string `json:"module"`
+	Caller  string `json:"caller,omitempty"`
+	Message string `json:"msg"`
+	Fields
+}
+
+// jsonFormatter formats log records as JSON objects
+type jsonFormatter struct{}
+
+// Format writes the record as a JSON object
+func (f *jsonFormatter) Format(calldepth int, r *logging.Record, w io.Writer) error {
+	entry := jsonEntry{
+		Time:   r.Time.Format(jsonTimeFormat),
+		Level:  r.Level.String(),
+		Module: r.Module,
+	}
+	if _, file, line, ok := runtime.Caller(calldepth + 1); ok {
+		entry.Caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	if m, ok := fieldsMessageOf(r); ok {
+		entry.Message = m.message
+		entry.Fields = m.fields
+	} else {
+		entry.Message = r.Message()
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// fieldsMessageOf returns the message logged through a ContextLogger
+func fieldsMessageOf(r *logging.Record) (*fieldsMessage, bool) {
+	if len(r.Args) != 1 {
+		return nil, false
+	}
+	m, ok := r.Args[0].(*fieldsMessage)
+	return m, ok
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/op/go-logging"
+)
+
+// levelRevert restores the level a logger had before
+// it was temporarily changed, once the change expires
+type levelRevert struct {
+	level string
+	timer *time.Timer
+}
+
+// pendingReverts holds the reverts of temporarily changed loggers
+// by logger name, and is guarded by lock
+var pendingReverts = make(map[string]*levelRevert)
+
+// GetLoggerLevels returns the levels of the loggers whose name matches the
+// supplied pattern, in which '*' matches any sequence of characters.
+func GetLoggerLevels(pattern string) (map[string]string, error) {
+	re, err := wildcardRegExp(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+	levels := make(map[string]string)
+	for module := range modules {
+		if re.MatchString(module) {
+			levels[module] = GetModuleLevel(module)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no logger matches '%s'", pattern)
+	}
+	return levels, nil
+}
+
+// SetLoggerLevels sets the level of the loggers whose name matches the
+// supplied pattern, in which '*' matches any sequence of characters, and
+// returns their new levels. If expiry is positive, the loggers revert to
+// the level they had before once it elapses, otherwise the level is kept
+// until it is changed again.
+func SetLoggerLevels(pattern string, level string, expiry time.Duration) (map[string]string, error) {
+	logLevel, err := logging.LogLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level '%s'", level)
+	}
+	re, err := wildcardRegExp(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	levels := make(map[string]string)
+	for module := range modules {
+		if !re.MatchString(module) {
+			continue
+		}
+		previous := GetModuleLevel(module)
+		if revert, exists := pendingReverts[module]; exists {
+			// Keep reverting to the level the logger had
+			// before it was first changed temporarily
+			revert.timer.Stop()
+			delete(pendingReverts, module)
+			previous = revert.level
+		}
+		logging.SetLevel(logLevel, module)
+		modules[module] = logLevel.String()
+		levels[module] = logLevel.String()
+		if expiry > 0 {
+			scheduleRevert(module, previous, expiry)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no logger matches '%s'", pattern)
+	}
+
+	if expiry > 0 {
+		logger.Infof("Log level of %d loggers matching '%s' set to %s for %s", len(levels), pattern, logLevel, expiry)
+	} else {
+		logger.Infof("Log level of %d loggers matching '%s' set to %s", len(levels), pattern, logLevel)
+	}
+	return levels, nil
+}
+
+// scheduleRevert reverts the level of the logger after the expiry.
+// It must be called while holding the lock.
+func scheduleRevert(module string, level string, expiry time.Duration) {
+	revert := &levelRevert{level: level}
+	revert.timer = time.AfterFunc(expiry, func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if pendingReverts[module] != revert {
+			// The level of the logger was changed again in the meantime
+			return
+		}
+		delete(pendingReverts, module)
+		logLevel, err := logging.LogLevel(level)
+		if err != nil {
+			logger.Warningf("Failed reverting log level of logger '%s' to '%s': %s", module, level, err)
+			return
+		}
+		logging.SetLevel(logLevel, module)
+		modules[module] = logLevel.String()
+		logger.Infof("Log level of logger '%s' reverted to %s", module, logLevel)
+	})
+	pendingReverts[module] = revert
+}
+
+// cancelPendingReverts stops all pending reverts.
+// It must be called while holding the lock.
+func cancelPendingReverts() {
+	for module, revert := range pendingReverts {
+		revert.timer.Stop()
+		delete(pendingReverts, module)
+	}
+}
+
+// wildcardRegExp compiles a logger name pattern, in which '*' matches any
+// sequence of characters, into a regular expression matching whole names
+func wildcardRegExp(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, errors.New("empty logger name pattern")
+	}
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	return regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLoggerLevels(t *testing.T) {
+	defer flogging.Reset()
+	flogging.MustGetLogger("gossip/comm")
+	flogging.MustGetLogger("gossip/election")
+	flogging.MustGetLogger("ledger")
+
+	levels, err := flogging.GetLoggerLevels("gossip/*")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"gossip/comm": "INFO", "gossip/election": "INFO"}, levels)
+
+	levels, err = flogging.GetLoggerLevels("ledger")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ledger": "INFO"}, levels)
+
+	// Patterns match whole logger names
+	_, err = flogging.GetLoggerLevels("gossip")
+	assert.EqualError(t, err, "no logger matches 'gossip'")
+
+	// Characters other than '*' have no special meaning
+	_, err = flogging.GetLoggerLevels("gossip/.*")
+	assert.EqualError(t, err, "no logger matches 'gossip/.*'")
+
+	_, err = flogging.GetLoggerLevels("")
+	assert.EqualError(t, err, "empty logger name pattern")
+}
+
+func TestSetLoggerLevels(t *testing.T) {
+	defer flogging.Reset()
+	flogging.MustGetLogger("gossip/comm")
+	flogging.MustGetLogger("gossip/election")
+	flogging.MustGetLogger("ledger")
+
+	levels, err := flogging.SetLoggerLevels("*/comm", "debug", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"gossip/comm": "DEBUG"}, levels)
+	assert.Equal(t, "DEBUG", flogging.GetModuleLevel("gossip/comm"))
+	assert.Equal(t, "INFO", flogging.GetModuleLevel("gossip/election"))
+
+	_, err = flogging.SetLoggerLevels("gossip/*", "foo", 0)
+	assert.EqualError(t, err, "invalid log level 'foo'")
+
+	_, err = flogging.SetLoggerLevels("chaincode", "debug", 0)
+	assert.EqualError(t, err, "no logger matches 'chaincode'")
+	assert.Equal(t, "DEBUG", flogging.GetModuleLevel("gossip/comm"))
+}
+
+func TestSetLoggerLevelsExpiry(t *testing.T) {
+	defer flogging.Reset()
+	flogging.MustGetLogger("gossip/comm")
+	flogging.MustGetLogger("ledger")
+	_, err := flogging.SetLoggerLevels("ledger", "warning", 0)
+	assert.NoError(t, err)
+
+	_, err = flogging.SetLoggerLevels("*", "debug", 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "DEBUG", flogging.GetModuleLevel("gossip/comm"))
+	assert.Equal(t, "DEBUG", flogging.GetModuleLevel("ledger"))
+
+	// Extending the temporary change of a logger keeps
+	// reverting it to the level it had before
+	_, err = flogging.SetLoggerLevels("ledger", "error", 500*time.Millisecond)
+	assert.NoError(t, err)
+
+	waitForLevel(t, "gossip/comm", "INFO")
+	assert.Equal(t, "ERROR", flogging.GetModuleLevel("ledger"))
+	waitForLevel(t, "ledger", "WARNING")
+
+	// A permanent change cancels the pending revert
+	_, err = flogging.SetLoggerLevels("gossip/comm", "debug", 100*time.Millisecond)
+	assert.NoError(t, err)
+	_, err = flogging.SetLoggerLevels("gossip/comm", "error", 0)
+	assert.NoError(t, err)
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, "ERROR", flogging.GetModuleLevel("gossip/comm"))
+}
+
+func waitForLevel(t *testing.T, module string, level string) {
+	for i := 0; i < 50; i++ {
+		if flogging.GetModuleLevel(module) == level {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Log level of logger '%s' is %s, expected %s", module, flogging.GetModuleLevel(module), level)
+}
@@ -52,6 +52,7 @@ func init() {
 
 // Reset sets to logging to the defaults defined in this package.
 func Reset() {
+	cancelPendingReverts()
 	modules = make(map[string]string)
 	lock = sync.RWMutex{}
 
@@ -60,11 +61,15 @@ func Reset() {
 	InitFromSpec("")
 }
 
-// SetFormat sets the logging format.
+// SetFormat sets the logging format. The format is either JSONFormat,
+// or a go-logging format string.
 func SetFormat(formatSpec string) logging.Formatter {
 	if formatSpec == "" {
 		formatSpec = defaultFormat
 	}
+	if formatSpec == JSONFormat {
+		return &jsonFormatter{}
+	}
 	return logging.MustStringFormatter(formatSpec)
 }
 
@@ -218,10 +223,12 @@ func GetPeerStartupLevel(module string) string {
 }
 
 // RevertToPeerStartupLevels reverts the log levels for all modules to the level
-// defined at the end of peer startup.
+// defined at the end of peer startup, and cancels the pending reverts of log
+// levels that were changed temporarily.
 func RevertToPeerStartupLevels() error {
-	lock.RLock()
-	defer lock.RUnlock()
+	lock.Lock()
+	defer lock.Unlock()
+	cancelPendingReverts()
 	for key := range peerStartModules {
 		_, err := setModuleLevel(key, peerStartModules[key], false, true)
 		if err != nil {
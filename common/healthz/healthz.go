@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package healthz
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("healthz")
+
+const (
+	// StatusOK is the status of a healthy component
+	StatusOK = "OK"
+	// StatusUnavailable is the status of a component whose health check failed
+	StatusUnavailable = "Service Unavailable"
+)
+
+// HealthChecker checks the health of a component
+type HealthChecker interface {
+	// HealthCheck returns an error if the component is unhealthy.
+	// It must return once the context is done.
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheckerFunc is a function that implements HealthChecker
+type HealthCheckerFunc func(ctx context.Context) error
+
+// HealthCheck invokes the function
+func (f HealthCheckerFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentStatus is the outcome of the health check of a component
+type ComponentStatus struct {
+	Component string `json:"component"`
+	Status    string `json:"status"`
+	// Reason is the error of the health check, if it failed
+	Reason string `json:"reason,omitempty"`
+	// LastError is the error of the latest health check that failed,
+	// which is kept after the component recovers
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorTime *time.Time `json:"last_error_time,omitempty"`
+}
+
+// HealthStatus is the outcome of the health checks of all components
+type HealthStatus struct {
+	Status     string            `json:"status"`
+	Time       time.Time         `json:"time"`
+	Components []ComponentStatus `json:"components"`
+}
+
+type component struct {
+	checker       HealthChecker
+	lastError     string
+	lastErrorTime time.Time
+}
+
+// HealthHandler runs the health checks of the registered components,
+// and serves their outcome over HTTP
+type HealthHandler struct {
+	mutex      sync.Mutex
+	components map[string]*component
+	timeout    time.Duration
+	now        func() time.Time
+}
+
+// NewHealthHandler creates a HealthHandler that fails
+// health checks that take longer than the timeout
+func NewHealthHandler(timeout time.Duration) *HealthHandler {
+	return &HealthHandler{
+		components: make(map[string]*component),
+		timeout:    timeout,
+		now:        time.Now,
+	}
+}
+
+// RegisterChecker registers the health checker of a component
+func (h *HealthHandler) RegisterChecker(name string, checker HealthChecker) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, exists := h.components[name]; exists {
+		return errors.Errorf("health checker of component %s is already registered", name)
+	}
+	h.components[name] = &component{checker: checker}
+	return nil
+}
+
+// DeregisterChecker deregisters the health checker of a component
+func (h *HealthHandler) DeregisterChecker(name string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.components, name)
+}
+
+// RunChecks runs the health checks of all registered components concurrently,
+// and returns their outcome sorted by component name
+func (h *HealthHandler) RunChecks(ctx context.Context) HealthStatus {
+	h.mutex.Lock()
+	checkers := make(map[string]HealthChecker, len(h.components))
+	for name, c := range h.components {
+		checkers[name] = c.checker
+	}
+	h.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	errs := make(map[string]error, len(checkers))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(len(checkers))
+	for name, checker := range checkers {
+		go func(name string, checker HealthChecker) {
+			defer wg.Done()
+			err := runCheck(ctx, checker)
+			lock.Lock()
+			errs[name] = err
+			lock.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+
+	now := h.now()
+	status := HealthStatus{Status: StatusOK, Time: now}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for name, err := range errs {
+		componentStatus := ComponentStatus{Component: name, Status: StatusOK}
+		c, exists := h.components[name]
+		if !exists {
+			// Deregistered while its health check was running
+			continue
+		}
+		if err != nil {
+			logger.Warningf("Health check of component %s failed: %s", name, err)
+			c.lastError = err.Error()
+			c.lastErrorTime = now
+			componentStatus.Status = StatusUnavailable
+			componentStatus.Reason = err.Error()
+			status.Status = StatusUnavailable
+		}
+		if c.lastError != "" {
+			lastErrorTime := c.lastErrorTime
+			componentStatus.LastError = c.lastError
+			componentStatus.LastErrorTime = &lastErrorTime
+		}
+		status.Components = append(status.Components, componentStatus)
+	}
+	sort.Slice(status.Components, func(i, j int) bool {
+		return status.Components[i].Component < status.Components[j].Component
+	})
+	return status
+}
+
+// runCheck runs a health check, and fails it once the context is done
+// in case the checker doesn't return by itself
+func runCheck(ctx context.Context, checker HealthChecker) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- checker.HealthCheck(ctx)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "health check did not complete")
+	}
+}
+
+// ServeHTTP runs the health checks and responds with their outcome,
+// with status 200 if all components are healthy, and 503 otherwise
+func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := h.RunChecks(r.Context())
+	body, err := json.Marshal(status)
+	if err != nil {
+		logger.Errorf("Failed marshaling health status: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if status.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(body)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package healthz
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestRegisterChecker(t *testing.T) {
+	h := NewHealthHandler(time.Second)
+	ok := HealthCheckerFunc(func(context.Context) error { return nil })
+	assert.NoError(t, h.RegisterChecker("couchdb", ok))
+	assert.EqualError(t, h.RegisterChecker("couchdb", ok), "health checker of component couchdb is already registered")
+
+	h.DeregisterChecker("couchdb")
+	assert.NoError(t, h.RegisterChecker("couchdb", ok))
+}
+
+func TestRunChecks(t *testing.T) {
+	now := time.Unix(1000, 0)
+	h := NewHealthHandler(100 * time.Millisecond)
+	h.now = func() time.Time { return now }
+
+	var dockerErr error
+	h.RegisterChecker("docker", HealthCheckerFunc(func(context.Context) error { return dockerErr }))
+	h.RegisterChecker("couchdb", HealthCheckerFunc(func(context.Context) error { return nil }))
+
+	status := h.RunChecks(context.Background())
+	assert.Equal(t, HealthStatus{
+		Status: StatusOK,
+		Time:   now,
+		Components: []ComponentStatus{
+			{Component: "couchdb", Status: StatusOK},
+			{Component: "docker", Status: StatusOK},
+		},
+	}, status)
+
+	dockerErr = errors.New("cannot connect to the Docker daemon")
+	failureTime := now
+	status = h.RunChecks(context.Background())
+	assert.Equal(t, StatusUnavailable, status.Status)
+	assert.Equal(t, ComponentStatus{Component: "couchdb", Status: StatusOK}, status.Components[0])
+	assert.Equal(t, ComponentStatus{
+		Component:     "docker",
+		Status:        StatusUnavailable,
+		Reason:        "cannot connect to the Docker daemon",
+		LastError:     "cannot connect to the Docker daemon",
+		LastErrorTime: &failureTime,
+	}, status.Components[1])
+
+	// The last error is kept after the component recovers
+	dockerErr = nil
+	now = now.Add(time.Minute)
+	status = h.RunChecks(context.Background())
+	assert.Equal(t, StatusOK, status.Status)
+	assert.Equal(t, ComponentStatus{
+		Component:     "docker",
+		Status:        StatusOK,
+		LastError:     "cannot connect to the Docker daemon",
+		LastErrorTime: &failureTime,
+	}, status.Components[1])
+}
+
+func TestRunChecksTimeout(t *testing.T) {
+	h := NewHealthHandler(100 * time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	h.RegisterChecker("gossip", HealthCheckerFunc(func(context.Context) error {
+		<-block
+		return nil
+	}))
+
+	status := h.RunChecks(context.Background())
+	assert.Equal(t, StatusUnavailable, status.Status)
+	assert.Equal(t, "health check did not complete: context deadline exceeded", status.Components[0].Reason)
+}
+
+func TestServeHTTP(t *testing.T) {
+	h := NewHealthHandler(time.Second)
+	var checkErr error
+	h.RegisterChecker("couchdb", HealthCheckerFunc(func(context.Context) error { return checkErr }))
+
+	serve := func(method string) (*httptest.ResponseRecorder, HealthStatus) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/healthz", nil))
+		var status HealthStatus
+		if rec.Code != http.StatusMethodNotAllowed {
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec, status
+	}
+
+	rec, status := serve(http.MethodGet)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, StatusOK, status.Status)
+
+	checkErr = errors.New("connection refused")
+	rec, status = serve(http.MethodGet)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, StatusUnavailable, status.Status)
+	assert.Equal(t, "connection refused", status.Components[0].Reason)
+
+	rec, _ = serve(http.MethodPost)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -49,12 +49,31 @@ var (
 	ErrAttrNotIndexed = errors.New("Attribute not indexed")
 )
 
+// BootstrapInfo is the information about the last block of a snapshot that a block store is bootstrapped from.
+// Such a block store doesn't contain the blocks up to that block, and its first block is the one following it.
+type BootstrapInfo struct {
+	LastBlockNum      uint64
+	LastBlockHash     []byte
+	PreviousBlockHash []byte
+	// ConfigBlock is the config block in effect as of the last block of the snapshot, if it is known.
+	// It is kept along with the bootstrap info since it is not among the blocks of the block store
+	ConfigBlock *common.Block
+}
+
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	// BootstrapBlockStore records that the block store for the given ledgerid starts after the last block of a snapshot.
+	// It is expected to be invoked before the block store is opened for the first time
+	BootstrapBlockStore(ledgerid string, info *BootstrapInfo) error
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// Remove removes the block store for the given ledgerid, which is expected to be shut down
+	Remove(ledgerid string) error
+	// Rollback removes the blocks after the given block number from the block store for the given ledgerid,
+	// which is expected to be shut down
+	Rollback(ledgerid string, blockNum uint64) error
 	Close()
 }
 
@@ -71,5 +90,10 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// GetBootstrapInfo returns the information about the snapshot the block store was bootstrapped from,
+	// or nil if the block store contains all the blocks since the genesis block
+	GetBootstrapInfo() (*BootstrapInfo, error)
+	// ApproximateSize returns the approximate number of bytes that the block store occupies in the local storage
+	ApproximateSize() (int64, error)
 	Shutdown()
 }
@@ -38,6 +38,7 @@ type blockfileStream struct {
 	file          *os.File
 	reader        *bufio.Reader
 	currentOffset int64
+	release       func()
 }
 
 // blockStream reads blocks sequentially from multiple files.
@@ -48,6 +49,7 @@ type blockStream struct {
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
+	archiver          *blockfileArchiver
 }
 
 // blockPlacementInfo captures the information related
@@ -77,7 +79,7 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 		panic(fmt.Sprintf("Could not seek file [%s] to given startOffset [%d]. New position = [%d]",
 			filePath, startOffset, newPosition))
 	}
-	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
+	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset, nil}
 	return s, nil
 }
 
@@ -149,7 +151,11 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 }
 
 func (s *blockfileStream) close() error {
-	return s.file.Close()
+	err := s.file.Close()
+	if s.release != nil {
+		s.release()
+	}
+	return err
 }
 
 ///////////////////////////////////
@@ -160,7 +166,7 @@ func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFile
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream, nil}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -169,7 +175,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = s.archiver.newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,352 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+const fetchingFilePrefix = "fetching_"
+
+var archiveInfoKey = []byte("archivedBlockfiles")
+
+// ArchiveStore is an object store, such as an S3 or a GCS bucket, that block files are archived to
+type ArchiveStore interface {
+	// Upload stores the contents of the local file at filePath as the object with the given name
+	Upload(name string, filePath string) error
+	// Download writes the contents of the object with the given name to the local file at filePath
+	Download(name string, filePath string) error
+}
+
+// blockfileArchiver moves the complete block files that only contain blocks older than the most recent
+// ones to an object store, in the order of the files. The index keeps pointing to the archived block files,
+// which are fetched back when they are read and kept locally until they are evicted from a small cache.
+//
+// The methods of a nil blockfileArchiver behave as if no block file was archived
+type blockfileArchiver struct {
+	ledgerID string
+	mgr      *blockfileMgr
+	conf     *ArchiveConf
+
+	lock                sync.Mutex
+	lastArchivedFileNum int
+	// cached maps the archived block files that have a local copy to the order in which they were last used
+	cached   map[int]uint64
+	useCount uint64
+	inUse    map[int]int
+
+	archiveLock sync.Mutex
+	fetchLock   sync.Mutex
+	trigger     chan struct{}
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+func newBlockfileArchiver(ledgerID string, mgr *blockfileMgr, conf *ArchiveConf) (*blockfileArchiver, error) {
+	a := &blockfileArchiver{
+		ledgerID: ledgerID,
+		mgr:      mgr,
+		conf:     conf,
+		cached:   make(map[int]uint64),
+		inUse:    make(map[int]int),
+		trigger:  make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	var err error
+	if a.lastArchivedFileNum, err = retrieveLastArchivedFileNum(mgr.db); err != nil {
+		return nil, err
+	}
+
+	// the local copies of archived block files that are left from a previous run are cached,
+	// and the partially fetched block files are removed
+	files, err := ioutil.ReadDir(mgr.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), fetchingFilePrefix) {
+			if err := os.Remove(filepath.Join(mgr.rootDir, file.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for fileNum := 0; fileNum <= a.lastArchivedFileNum; fileNum++ {
+		exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			a.useCount++
+			a.cached[fileNum] = a.useCount
+		}
+	}
+	a.evict()
+	logger.Infof("Block files of ledger [%s] up to [%d] are archived, [%d] of them are cached",
+		ledgerID, a.lastArchivedFileNum, len(a.cached))
+	return a, nil
+}
+
+// retrieveLastArchivedFileNum returns the number of the last archived block file, or -1 if none is archived
+func retrieveLastArchivedFileNum(db *leveldbhelper.DBHandle) (int, error) {
+	b, err := db.Get(archiveInfoKey)
+	if err != nil {
+		return -1, err
+	}
+	if b == nil {
+		return -1, nil
+	}
+	numArchivedFiles, n := proto.DecodeVarint(b)
+	if n == 0 {
+		return -1, fmt.Errorf("invalid archive info [%#v]", b)
+	}
+	return int(numArchivedFiles) - 1, nil
+}
+
+// start starts archiving the block files in the background
+func (a *blockfileArchiver) start() {
+	if a == nil {
+		return
+	}
+	go a.run()
+	a.notify()
+}
+
+func (a *blockfileArchiver) run() {
+	defer close(a.done)
+	for {
+		select {
+		case <-a.trigger:
+			if err := a.archiveBlockfiles(); err != nil {
+				logger.Errorf("Failed archiving block files of ledger [%s]: %s", a.ledgerID, err)
+			}
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// notify signals that blocks were added, which may make more block files eligible for archival
+func (a *blockfileArchiver) notify() {
+	if a == nil {
+		return
+	}
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// close stops archiving the block files and waits for the archival in progress to complete
+func (a *blockfileArchiver) close() {
+	if a == nil {
+		return
+	}
+	close(a.stop)
+	<-a.done
+}
+
+// archiveBlockfiles archives the complete block files whose blocks are all older than the retained blocks
+func (a *blockfileArchiver) archiveBlockfiles() error {
+	a.archiveLock.Lock()
+	defer a.archiveLock.Unlock()
+
+	a.mgr.cpInfoCond.L.Lock()
+	cpInfo := a.mgr.cpInfo
+	a.mgr.cpInfoCond.L.Unlock()
+	height := cpInfo.lastBlockNumber + 1
+	if cpInfo.isChainEmpty || height <= a.conf.RetainBlocks {
+		return nil
+	}
+	firstRetainedBlockNum := height - a.conf.RetainBlocks
+
+	a.lock.Lock()
+	fileNum := a.lastArchivedFileNum + 1
+	a.lock.Unlock()
+	// the blocks of a block file precede the first block of the next one
+	for ; fileNum < cpInfo.latestFileChunkSuffixNum; fileNum++ {
+		nextFirstBlockNum, found, err := firstBlockNumInFile(a.mgr.rootDir, fileNum+1)
+		if err != nil {
+			return err
+		}
+		if !found || nextFirstBlockNum > firstRetainedBlockNum {
+			return nil
+		}
+		if err := a.archive(fileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archive uploads a block file to the archive, after which its local copy is only kept as a cached copy
+func (a *blockfileArchiver) archive(fileNum int) error {
+	logger.Infof("Archiving block file [%d] of ledger [%s]", fileNum, a.ledgerID)
+	if err := a.conf.Store.Upload(a.objectName(fileNum), deriveBlockfilePath(a.mgr.rootDir, fileNum)); err != nil {
+		return fmt.Errorf("failed uploading block file [%d]: %s", fileNum, err)
+	}
+	if err := a.mgr.db.Put(archiveInfoKey, proto.EncodeVarint(uint64(fileNum)+1), true); err != nil {
+		return err
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.lastArchivedFileNum = fileNum
+	a.useCount++
+	a.cached[fileNum] = a.useCount
+	a.evict()
+	return nil
+}
+
+// acquire makes sure that a block file is available locally, fetching it from the archive if needed.
+// The block file isn't evicted until the returned release function is called
+func (a *blockfileArchiver) acquire(fileNum int) (func(), error) {
+	if a == nil {
+		return func() {}, nil
+	}
+	a.lock.Lock()
+	if fileNum > a.lastArchivedFileNum {
+		a.lock.Unlock()
+		return func() {}, nil
+	}
+	a.inUse[fileNum]++
+	a.lock.Unlock()
+
+	release := func() {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		if a.inUse[fileNum]--; a.inUse[fileNum] == 0 {
+			delete(a.inUse, fileNum)
+		}
+		a.evict()
+	}
+	if err := a.fetch(fileNum); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// fetch downloads an archived block file, unless it is cached
+func (a *blockfileArchiver) fetch(fileNum int) error {
+	a.fetchLock.Lock()
+	defer a.fetchLock.Unlock()
+	a.lock.Lock()
+	_, cached := a.cached[fileNum]
+	if cached {
+		a.useCount++
+		a.cached[fileNum] = a.useCount
+	}
+	a.lock.Unlock()
+	if cached {
+		return nil
+	}
+
+	logger.Infof("Fetching archived block file [%d] of ledger [%s]", fileNum, a.ledgerID)
+	filePath := deriveBlockfilePath(a.mgr.rootDir, fileNum)
+	fetchingFilePath := filepath.Join(a.mgr.rootDir, fetchingFilePrefix+filepath.Base(filePath))
+	if err := a.conf.Store.Download(a.objectName(fileNum), fetchingFilePath); err != nil {
+		os.Remove(fetchingFilePath)
+		return fmt.Errorf("failed fetching archived block file [%d]: %s", fileNum, err)
+	}
+	if err := os.Rename(fetchingFilePath, filePath); err != nil {
+		os.Remove(fetchingFilePath)
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.useCount++
+	a.cached[fileNum] = a.useCount
+	a.evict()
+	return nil
+}
+
+// evict removes the least recently used cached block files that aren't in use,
+// until no more than the configured number of them are cached. The lock must be held
+func (a *blockfileArchiver) evict() {
+	for len(a.cached) > a.conf.MaxCachedFiles {
+		evictee, evicteeLastUse := -1, uint64(0)
+		for fileNum, lastUse := range a.cached {
+			if a.inUse[fileNum] > 0 {
+				continue
+			}
+			if evictee == -1 || lastUse < evicteeLastUse {
+				evictee, evicteeLastUse = fileNum, lastUse
+			}
+		}
+		if evictee == -1 {
+			return
+		}
+		if err := os.Remove(deriveBlockfilePath(a.mgr.rootDir, evictee)); err != nil && !os.IsNotExist(err) {
+			logger.Warningf("Could not remove the local copy of archived block file [%d] of ledger [%s]: %s",
+				evictee, a.ledgerID, err)
+			return
+		}
+		logger.Debugf("Evicted archived block file [%d] of ledger [%s]", evictee, a.ledgerID)
+		delete(a.cached, evictee)
+	}
+}
+
+func (a *blockfileArchiver) objectName(fileNum int) string {
+	return a.ledgerID + "/" + blockfilePrefix + fmt.Sprintf("%06d", fileNum)
+}
+
+// newBlockfileStream opens a blockfileStream on a block file that may be archived,
+// which isn't evicted until the stream is closed
+func (a *blockfileArchiver) newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
+	release, err := a.acquire(fileNum)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newBlockfileStream(rootDir, fileNum, startOffset)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	s.release = release
+	return s, nil
+}
+
+// newBlockStream opens a blockStream on block files that may be archived
+func (a *blockfileArchiver) newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := a.newBlockfileStream(rootDir, startFileNum, startOffset)
+	if err != nil {
+		return nil, err
+	}
+	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream, a}, nil
+}
+
+// firstBlockNumInFile returns the number of the first block of a local block file,
+// and false if the block file doesn't contain a complete block yet
+func firstBlockNumInFile(rootDir string, fileNum int) (uint64, bool, error) {
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err == ErrUnexpectedEndOfBlockfile || (err == nil && blockBytes == nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, false, err
+	}
+	return info.blockHeader.Number, true, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+)
+
+// mockArchiveStore is an ArchiveStore that keeps the objects in memory
+type mockArchiveStore struct {
+	lock      sync.Mutex
+	objects   map[string][]byte
+	downloads int
+}
+
+func newMockArchiveStore() *mockArchiveStore {
+	return &mockArchiveStore{objects: make(map[string][]byte)}
+}
+
+func (s *mockArchiveStore) Upload(name string, filePath string) error {
+	b, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.objects[name] = b
+	return nil
+}
+
+func (s *mockArchiveStore) Download(name string, filePath string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	b, ok := s.objects[name]
+	if !ok {
+		return fmt.Errorf("object [%s] not found", name)
+	}
+	s.downloads++
+	return ioutil.WriteFile(filePath, b, 0660)
+}
+
+func TestBlockfileArchiver(t *testing.T) {
+	store := newMockArchiveStore()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := []*common.Block{gb}
+	for i := 1; i < 20; i++ {
+		blocks = append(blocks, bg.NextTestBlock(10, 1000))
+	}
+	// each block file holds a single block, since no block is smaller than half the size of the largest one
+	maxFileSize := 0
+	for _, block := range blocks {
+		b, _, err := serializeBlock(block)
+		testutil.AssertNoError(t, err, "")
+		if size := len(b) + len(proto.EncodeVarint(uint64(len(b)))); size > maxFileSize {
+			maxFileSize = size
+		}
+	}
+	archiveConf := &ArchiveConf{Store: store, RetainBlocks: 5, MaxCachedFiles: 1}
+	env := newTestEnv(t, NewConfWithArchive(testPath(), maxFileSize, archiveConf))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	w.addBlocks(blocks)
+	mgr := w.blockfileMgr
+	testutil.AssertEquals(t, mgr.cpInfo.latestFileChunkSuffixNum, 19)
+
+	// the block files of all the blocks but the 5 most recent ones are archived,
+	// and only the last archived block file is kept locally
+	testutil.AssertNoError(t, mgr.archiver.archiveBlockfiles(), "")
+	testutil.AssertEquals(t, mgr.archiver.lastArchivedFileNum, 14)
+	testutil.AssertEquals(t, len(store.objects), 15)
+	assertLocalBlockfiles(t, mgr.rootDir, 14, 15, 16, 17, 18, 19)
+
+	// the archived blocks are fetched on demand, and only the last fetched block file is kept locally
+	w.testGetBlockByNumber(blocks, 0)
+	w.testGetBlockByHash(blocks)
+	assertLocalBlockfiles(t, mgr.rootDir, 14, 15, 16, 17, 18, 19)
+	tx, err := mgr.retrieveTransactionByBlockNumTranNum(0, 0)
+	testutil.AssertNoError(t, err, "")
+	expectedTx, err := putil.GetEnvelopeFromBlock(blocks[0].Data.Data[0])
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, tx, expectedTx)
+	assertLocalBlockfiles(t, mgr.rootDir, 0, 15, 16, 17, 18, 19)
+
+	// a cached block file isn't fetched again
+	downloads := store.downloads
+	_, err = mgr.retrieveBlockByNumber(0)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, store.downloads, downloads)
+
+	// the block files are fetched in turn while iterating over the blocks
+	itr, err := mgr.retrieveBlocks(0)
+	testutil.AssertNoError(t, err, "")
+	for _, block := range blocks {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, result, block)
+	}
+	itr.Close()
+	assertLocalBlockfiles(t, mgr.rootDir, 14, 15, 16, 17, 18, 19)
+
+	// an archived block file can't be read without the archive
+	delete(store.objects, "testLedger/blockfile_000003")
+	_, err = mgr.retrieveBlockByNumber(3)
+	testutil.AssertError(t, err, "Expected an error when an archived block file is missing in the archive")
+
+	// the archived block files are known after a restart
+	w.close()
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	testutil.AssertEquals(t, w.blockfileMgr.archiver.lastArchivedFileNum, 14)
+	_, err = w.blockfileMgr.retrieveBlockByNumber(0)
+	testutil.AssertNoError(t, err, "")
+}
+
+func TestBlockfileArchiverRetainsRecentBlocks(t *testing.T) {
+	store := newMockArchiveStore()
+	archiveConf := &ArchiveConf{Store: store, RetainBlocks: 100, MaxCachedFiles: 1}
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 1, archiveConf))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	w.addBlocks(testutil.ConstructTestBlocks(t, 10))
+
+	testutil.AssertNoError(t, w.blockfileMgr.archiver.archiveBlockfiles(), "")
+	testutil.AssertEquals(t, w.blockfileMgr.archiver.lastArchivedFileNum, -1)
+	testutil.AssertEquals(t, len(store.objects), 0)
+}
+
+func assertLocalBlockfiles(t *testing.T, rootDir string, expectedFileNums ...int) {
+	var fileNums []int
+	for fileNum := 0; fileNum <= expectedFileNums[len(expectedFileNums)-1]; fileNum++ {
+		exists, _, err := util.FileExists(deriveBlockfilePath(rootDir, fileNum))
+		testutil.AssertNoError(t, err, "")
+		if exists {
+			fileNums = append(fileNums, fileNum)
+		}
+	}
+	testutil.AssertEquals(t, fileNums, expectedFileNums)
+	files, err := filepath.Glob(filepath.Join(rootDir, fetchingFilePrefix+"*"))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(files), 0)
+}
@@ -103,6 +103,22 @@ func retrieveLastFileSuffix(rootDir string) (int, error) {
 	return biggestFileNum, err
 }
 
+// blockfilesSize returns the total size of the block files that are present in the rootDir
+func blockfilesSize(rootDir string) (int64, error) {
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, fileInfo := range filesInfo {
+		if fileInfo.IsDir() || !isBlockFileName(fileInfo.Name()) {
+			continue
+		}
+		size += fileInfo.Size()
+	}
+	return size, nil
+}
+
 func isBlockFileName(name string) bool {
 	return strings.HasPrefix(name, blockfilePrefix)
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sync"
@@ -41,7 +42,9 @@ const (
 )
 
 var (
-	blkMgrInfoKey = []byte("blkMgrInfo")
+	blkMgrInfoKey           = []byte("blkMgrInfo")
+	bootstrapInfoKey        = []byte("bootstrappingSnapshotInfo")
+	bootstrapConfigBlockKey = []byte("bootstrappingConfigBlock")
 )
 
 type blockfileMgr struct {
@@ -53,6 +56,8 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	bootstrapInfo     *blkstorage.BootstrapInfo
+	archiver          *blockfileArchiver
 }
 
 /*
@@ -107,6 +112,11 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// Instantiate the manager, i.e. blockFileMgr structure
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
 
+	// A block store bootstrapped from a snapshot starts after the last block of the snapshot
+	if mgr.bootstrapInfo, err = mgr.loadBootstrapInfo(); err != nil {
+		panic(fmt.Sprintf("Could not get bootstrap info from db: %s", err))
+	}
+
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
 	// At init checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
@@ -119,6 +129,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		if cpInfo, err = constructCheckpointInfoFromBlockFiles(rootDir); err != nil {
 			panic(fmt.Sprintf("Could not build checkpoint info from block files: %s", err))
 		}
+		if cpInfo.isChainEmpty && mgr.bootstrapInfo != nil {
+			cpInfo.lastBlockNumber = mgr.bootstrapInfo.LastBlockNum
+		}
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(cpInfo))
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
@@ -151,12 +164,25 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// or announcing the occurrence of an event.
 	mgr.cpInfoCond = sync.NewCond(&sync.Mutex{})
 
+	// The block files that were archived are fetched from the archive when they are read
+	if conf.archiveConf != nil {
+		if mgr.archiver, err = newBlockfileArchiver(id, mgr, conf.archiveConf); err != nil {
+			panic(fmt.Sprintf("Could not initialize the archival of block files: %s", err))
+		}
+	}
+
 	// init BlockchainInfo for external API's
 	bcInfo := &common.BlockchainInfo{
 		Height:            0,
 		CurrentBlockHash:  nil,
 		PreviousBlockHash: nil}
 
+	if cpInfo.isChainEmpty && mgr.bootstrapInfo != nil {
+		bcInfo = &common.BlockchainInfo{
+			Height:            mgr.bootstrapInfo.LastBlockNum + 1,
+			CurrentBlockHash:  mgr.bootstrapInfo.LastBlockHash,
+			PreviousBlockHash: mgr.bootstrapInfo.PreviousBlockHash}
+	}
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
@@ -172,6 +198,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	mgr.archiver.start()
 	return mgr
 }
 
@@ -196,7 +223,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 		return
 	}
 	//Scan the file system to verify that the checkpoint info stored in db is correct
-	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
 		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize))
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
@@ -207,7 +234,13 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		// The first block isn't the genesis block if the block store was bootstrapped from a snapshot,
+		// hence the number of the last block is taken from the block itself
+		lastBlockInfo, err := extractSerializedBlockInfo(lastBlockBytes)
+		if err != nil {
+			panic(fmt.Sprintf("Could not extract the last block in the current file: %s", err))
+		}
+		cpInfo.lastBlockNumber = lastBlockInfo.blockHeader.Number
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -220,6 +253,7 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
+	mgr.archiver.close()
 	mgr.currentFileWriter.close()
 }
 
@@ -248,6 +282,12 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	if block.Header.Number != mgr.getBlockchainInfo().Height {
 		return fmt.Errorf("Block number should have been %d but was %d", mgr.getBlockchainInfo().Height, block.Header.Number)
 	}
+	// the first block following a snapshot ties the snapshot to the chain, whose blocks are signed by the orderers
+	if mgr.cpInfo.isChainEmpty && mgr.bootstrapInfo != nil &&
+		!bytes.Equal(block.Header.PreviousHash, mgr.bootstrapInfo.LastBlockHash) {
+		return fmt.Errorf("Previous hash of block %d does not match the hash of the last block of the snapshot the block store was bootstrapped from",
+			block.Header.Number)
+	}
 	blockBytes, info, err := serializeBlock(block)
 	if err != nil {
 		return fmt.Errorf("Error while serializing block: %s", err)
@@ -314,6 +354,7 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	mgr.archiver.notify()
 	return nil
 }
 
@@ -360,7 +401,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = mgr.archiver.newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -419,6 +460,20 @@ func (mgr *blockfileMgr) syncIndex() error {
 	return nil
 }
 
+// approximateSize returns the approximate number of bytes that the local block files
+// and the index of the ledger occupy in the file system
+func (mgr *blockfileMgr) approximateSize() (int64, error) {
+	filesSize, err := blockfilesSize(mgr.rootDir)
+	if err != nil {
+		return 0, err
+	}
+	indexSize, err := mgr.db.ApproximateSize()
+	if err != nil {
+		return 0, err
+	}
+	return filesSize + indexSize, nil
+}
+
 func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
@@ -499,6 +554,10 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if mgr.bootstrapInfo != nil && startNum <= mgr.bootstrapInfo.LastBlockNum {
+		return nil, fmt.Errorf("block number [%d] is not available, as the block store starts after block [%d] of the snapshot it was bootstrapped from",
+			startNum, mgr.bootstrapInfo.LastBlockNum)
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -544,7 +603,7 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	stream, err := mgr.archiver.newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -557,6 +616,11 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	release, err := mgr.archiver.acquire(lp.fileSuffixNum)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -596,6 +660,27 @@ func (mgr *blockfileMgr) saveCurrentInfo(i *checkpointInfo, sync bool) error {
 	return nil
 }
 
+//Get the information about the snapshot the block store was bootstrapped from, if any
+func (mgr *blockfileMgr) loadBootstrapInfo() (*blkstorage.BootstrapInfo, error) {
+	b, err := mgr.db.Get(bootstrapInfoKey)
+	if b == nil || err != nil {
+		return nil, err
+	}
+	info, err := unmarshalBootstrapInfo(b)
+	if err != nil {
+		return nil, err
+	}
+	// the config block is stored separately, since the block stores bootstrapped before it was kept don't have it
+	if b, err = mgr.db.Get(bootstrapConfigBlockKey); b == nil || err != nil {
+		return info, err
+	}
+	info.ConfigBlock = &common.Block{}
+	if err = proto.Unmarshal(b, info.ConfigBlock); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
 func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
@@ -688,3 +773,34 @@ func (i *checkpointInfo) String() string {
 	return fmt.Sprintf("latestFileChunkSuffixNum=[%d], latestFileChunksize=[%d], isChainEmpty=[%t], lastBlockNumber=[%d]",
 		i.latestFileChunkSuffixNum, i.latestFileChunksize, i.isChainEmpty, i.lastBlockNumber)
 }
+
+func marshalBootstrapInfo(info *blkstorage.BootstrapInfo) ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	var err error
+	if err = buffer.EncodeVarint(info.LastBlockNum); err != nil {
+		return nil, err
+	}
+	if err = buffer.EncodeRawBytes(info.LastBlockHash); err != nil {
+		return nil, err
+	}
+	if err = buffer.EncodeRawBytes(info.PreviousBlockHash); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalBootstrapInfo(b []byte) (*blkstorage.BootstrapInfo, error) {
+	buffer := proto.NewBuffer(b)
+	info := &blkstorage.BootstrapInfo{}
+	var err error
+	if info.LastBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return nil, err
+	}
+	if info.LastBlockHash, err = buffer.DecodeRawBytes(false); err != nil {
+		return nil, err
+	}
+	if info.PreviousBlockHash, err = buffer.DecodeRawBytes(false); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -30,6 +30,17 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+}
+
+// ArchiveConf encapsulates the configuration of the archival of block files to an object store
+type ArchiveConf struct {
+	// Store is the object store the block files are archived to
+	Store ArchiveStore
+	// RetainBlocks is the number of most recent blocks that are never archived
+	RetainBlocks uint64
+	// MaxCachedFiles is the number of archived block files that are kept locally once fetched
+	MaxCachedFiles int
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +49,15 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, nil}
+}
+
+// NewConfWithArchive constructs new `Conf` that archives the complete block files
+// to an object store according to the given archiveConf
+func NewConfWithArchive(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.archiveConf = archiveConf
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -86,6 +86,16 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// GetBootstrapInfo returns the information about the snapshot the block store was bootstrapped from, if any
+func (store *fsBlockStore) GetBootstrapInfo() (*blkstorage.BootstrapInfo, error) {
+	return store.fileMgr.bootstrapInfo, nil
+}
+
+// ApproximateSize returns the approximate number of bytes that the block files and the index occupy locally
+func (store *fsBlockStore) ApproximateSize() (int64, error) {
+	return store.fileMgr.approximateSize()
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
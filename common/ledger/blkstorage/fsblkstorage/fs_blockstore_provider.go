@@ -17,6 +17,10 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -48,6 +52,32 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle), nil
 }
 
+// BootstrapBlockStore records that the block store for given ledgerid starts after the last block of a snapshot.
+// This method should be invoked before the block store is opened for the first time
+func (p *FsBlockstoreProvider) BootstrapBlockStore(ledgerid string, info *blkstorage.BootstrapInfo) error {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("block store for ledger [%s] already exists", ledgerid)
+	}
+	b, err := marshalBootstrapInfo(info)
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(bootstrapInfoKey, b)
+	if info.ConfigBlock != nil {
+		configBlockBytes, err := proto.Marshal(info.ConfigBlock)
+		if err != nil {
+			return err
+		}
+		batch.Put(bootstrapConfigBlockKey, configBlockBytes)
+	}
+	return p.leveldbProvider.GetDBHandle(ledgerid).WriteBatch(batch, true)
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -59,6 +89,25 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove removes the blocks and the index of the BlockStore with given id.
+// The BlockStore is expected to be shut down
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	dbHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	batch := leveldbhelper.NewUpdateBatch()
+	itr := dbHandle.GetIterator(nil, nil)
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return fmt.Errorf("error iterating over the index of ledger [%s]: %s", ledgerid, err)
+	}
+	if err := dbHandle.WriteBatch(batch, true); err != nil {
+		return fmt.Errorf("error removing the index of ledger [%s]: %s", ledgerid, err)
+	}
+	return os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid))
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
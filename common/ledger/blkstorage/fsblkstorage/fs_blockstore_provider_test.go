@@ -21,6 +21,7 @@ import (
 
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
@@ -147,6 +148,116 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestBootstrappedBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	info := &blkstorage.BootstrapInfo{
+		LastBlockNum:      4,
+		LastBlockHash:     blocks[4].Header.Hash(),
+		PreviousBlockHash: blocks[4].Header.PreviousHash,
+		ConfigBlock:       blocks[2],
+	}
+	testutil.AssertNoError(t, provider.BootstrapBlockStore("ledger1", info), "")
+
+	store, err := provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	bootstrapInfo, err := store.GetBootstrapInfo()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, bootstrapInfo.LastBlockNum, info.LastBlockNum)
+	testutil.AssertEquals(t, bootstrapInfo.LastBlockHash, info.LastBlockHash)
+	testutil.AssertEquals(t, bootstrapInfo.PreviousBlockHash, info.PreviousBlockHash)
+	testutil.AssertEquals(t, proto.Equal(bootstrapInfo.ConfigBlock, info.ConfigBlock), true)
+	bcInfo, _ := store.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
+		Height:            5,
+		CurrentBlockHash:  info.LastBlockHash,
+		PreviousBlockHash: info.PreviousBlockHash,
+	})
+
+	// The blocks of the snapshot are neither accepted nor available
+	testutil.AssertError(t, store.AddBlock(blocks[0]), "")
+	_, err = store.RetrieveBlocks(4)
+	testutil.AssertError(t, err, "")
+
+	// The first block must follow the last block of the snapshot
+	forkedBlock := proto.Clone(blocks[5]).(*common.Block)
+	forkedBlock.Header.PreviousHash = []byte("another-hash")
+	testutil.AssertError(t, store.AddBlock(forkedBlock), "")
+
+	for _, b := range blocks[5:] {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	itr, err := store.RetrieveBlocks(5)
+	testutil.AssertNoError(t, err, "")
+	for _, b := range blocks[5:] {
+		block, _ := itr.Next()
+		testutil.AssertEquals(t, block, b)
+	}
+	itr.Close()
+	store.Shutdown()
+
+	// The block store keeps its blocks after the snapshot when it's reopened
+	store, err = provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	defer store.Shutdown()
+	bootstrapInfo, _ = store.GetBootstrapInfo()
+	testutil.AssertEquals(t, proto.Equal(bootstrapInfo.ConfigBlock, info.ConfigBlock), true)
+	bcInfo, _ = store.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(10))
+	testutil.AssertEquals(t, bcInfo.CurrentBlockHash, blocks[9].Header.Hash())
+	block, err := store.RetrieveBlockByNumber(7)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, block, blocks[7])
+
+	// An existing block store can't be bootstrapped
+	testutil.AssertError(t, provider.BootstrapBlockStore("ledger1", info), "")
+}
+
+func TestRemoveBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	for _, ledgerid := range []string{"ledger1", "ledger2"} {
+		store, err := provider.OpenBlockStore(ledgerid)
+		testutil.AssertNoError(t, err, "")
+		for _, b := range blocks {
+			testutil.AssertNoError(t, store.AddBlock(b), "")
+		}
+		store.Shutdown()
+	}
+
+	testutil.AssertNoError(t, provider.Remove("ledger1"), "")
+	exists, err := provider.Exists("ledger1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exists, false)
+	storeNames, _ := provider.List()
+	testutil.AssertEquals(t, storeNames, []string{"ledger2"})
+
+	// A block store created with the id of a removed one starts empty
+	store, err := provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	bcInfo, _ := store.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(0))
+	_, err = store.RetrieveBlockByHash(blocks[2].Header.Hash())
+	testutil.AssertError(t, err, "")
+	store.Shutdown()
+
+	// Other block stores are left intact
+	store, err = provider.OpenBlockStore("ledger2")
+	testutil.AssertNoError(t, err, "")
+	defer store.Shutdown()
+	bcInfo, _ = store.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(5))
+	block, err := store.RetrieveBlockByNumber(3)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, block, blocks[3])
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
@@ -38,3 +38,30 @@ func TestWrongBlockNumber(t *testing.T) {
 	err := store.AddBlock(blocks[4])
 	testutil.AssertError(t, err, "Error shold have been thrown when adding block number 4 while block number 3 is expected")
 }
+
+func TestApproximateSize(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store, _ := provider.OpenBlockStore("testLedger")
+	defer store.Shutdown()
+
+	size, err := store.ApproximateSize()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, size, int64(0))
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	var blocksSize int64
+	for _, block := range blocks {
+		testutil.AssertNoError(t, store.AddBlock(block), "")
+		blockBytes, _, err := serializeBlock(block)
+		testutil.AssertNoError(t, err, "")
+		blocksSize += int64(len(blockBytes))
+	}
+	size, err = store.ApproximateSize()
+	testutil.AssertNoError(t, err, "")
+	if size < blocksSize {
+		t.Fatalf("Expected the approximate size of the block store to be at least %d, got %d", blocksSize, size)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+// Rollback removes the blocks after the given block number from the block store for given ledgerid,
+// along with their index entries. The block store is expected to be shut down.
+//
+// The index is rolled back before the block files, and the checkpoint info is removed in between,
+// so that it is reconstructed from the block files when the block store is opened. Hence a crash
+// during the rollback leaves a block store whose blocks are a prefix of the original ones
+func (p *FsBlockstoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("block store of ledger [%s] does not exist", ledgerid)
+	}
+	indexStore := p.leveldbProvider.GetDBHandle(ledgerid)
+	rootDir := p.conf.getLedgerBlockDir(ledgerid)
+
+	// the block files are scanned without fetching the archived ones, the target block is expected to be local
+	mgr := newBlockfileMgr(ledgerid, NewConf(p.conf.blockStorageDir, p.conf.maxBlockfileSize), p.indexConfig, indexStore)
+	cpInfo := mgr.cpInfo
+	bootstrapInfo := mgr.bootstrapInfo
+	targetLoc, locErr := mgr.index.getBlockLocByBlockNum(blockNum)
+	mgr.close()
+
+	if bootstrapInfo != nil {
+		return fmt.Errorf("block store of ledger [%s] was bootstrapped from a snapshot, it can't be rolled back", ledgerid)
+	}
+	if cpInfo.isChainEmpty || blockNum >= cpInfo.lastBlockNumber {
+		return fmt.Errorf("target block number [%d] should be less than the biggest block number [%d] of ledger [%s]",
+			blockNum, cpInfo.lastBlockNumber, ledgerid)
+	}
+	if locErr != nil {
+		return fmt.Errorf("error locating block [%d] of ledger [%s]: %s", blockNum, ledgerid, locErr)
+	}
+	lastArchivedFileNum, err := retrieveLastArchivedFileNum(indexStore)
+	if err != nil {
+		return err
+	}
+	if targetLoc.fileSuffixNum <= lastArchivedFileNum {
+		return fmt.Errorf("block [%d] of ledger [%s] is in block file [%d], which is archived, it can't be rolled back to",
+			blockNum, ledgerid, targetLoc.fileSuffixNum)
+	}
+
+	logger.Infof("Rolling back block store of ledger [%s] from block [%d] to block [%d]", ledgerid, cpInfo.lastBlockNumber, blockNum)
+	stream, err := newBlockStream(rootDir, targetLoc.fileSuffixNum, int64(targetLoc.offset), cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+	if err != nil {
+		return err
+	}
+	if blockBytes == nil {
+		return fmt.Errorf("block [%d] of ledger [%s] is missing from block file [%d]", blockNum, ledgerid, targetLoc.fileSuffixNum)
+	}
+	targetEndOffset := placementInfo.blockBytesOffset + int64(len(blockBytes))
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		if blockBytes, _, err = stream.nextBlockBytesAndPlacementInfo(); err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		addIndexDeletions(batch, info)
+	}
+	batch.Put(indexCheckpointKey, encodeBlockNum(blockNum))
+	if err := indexStore.WriteBatch(batch, true); err != nil {
+		return fmt.Errorf("error rolling back the index of ledger [%s]: %s", ledgerid, err)
+	}
+	if err := indexStore.Delete(blkMgrInfoKey, true); err != nil {
+		return fmt.Errorf("error removing the checkpoint info of ledger [%s]: %s", ledgerid, err)
+	}
+
+	for fileNum := cpInfo.latestFileChunkSuffixNum; fileNum > targetLoc.fileSuffixNum; fileNum-- {
+		if err := os.Remove(deriveBlockfilePath(rootDir, fileNum)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing block file [%d] of ledger [%s]: %s", fileNum, ledgerid, err)
+		}
+	}
+	if err := os.Truncate(deriveBlockfilePath(rootDir, targetLoc.fileSuffixNum), targetEndOffset); err != nil {
+		return fmt.Errorf("error truncating block file [%d] of ledger [%s]: %s", targetLoc.fileSuffixNum, ledgerid, err)
+	}
+	logger.Infof("Rolled back block store of ledger [%s] to block [%d]", ledgerid, blockNum)
+	return nil
+}
+
+// addIndexDeletions adds to the batch the deletion of the index entries of a block
+func addIndexDeletions(batch *leveldbhelper.UpdateBatch, info *serializedBlockInfo) {
+	batch.Delete(constructBlockNumKey(info.blockHeader.Number))
+	batch.Delete(constructBlockHashKey(info.blockHeader.Hash()))
+	for txNum, txOffset := range info.txOffsets {
+		batch.Delete(constructTxIDKey(txOffset.txID))
+		batch.Delete(constructBlockNumTranNumKey(info.blockHeader.Number, uint64(txNum)))
+		batch.Delete(constructBlockTxIDKey(txOffset.txID))
+		batch.Delete(constructTxValidationCodeIDKey(txOffset.txID))
+	}
+}
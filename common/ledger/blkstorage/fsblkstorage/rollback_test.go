@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+func TestRollbackBlockStore(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	size := 0
+	for _, block := range blocks[:10] {
+		by, _, err := serializeBlock(block)
+		testutil.AssertNoError(t, err, "")
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+	env := newTestEnv(t, NewConf(testPath(), size))
+	defer env.Cleanup()
+	provider := env.provider
+
+	store, err := provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	for _, b := range blocks {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	testutil.AssertEquals(t, store.(*fsBlockStore).fileMgr.cpInfo.latestFileChunkSuffixNum > 1, true)
+	store.Shutdown()
+
+	testutil.AssertNoError(t, provider.Rollback("ledger1", 12), "")
+
+	store, err = provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	checkBlocks(t, blocks[:13], store)
+	// the removed blocks are gone from the index
+	_, err = store.RetrieveBlockByHash(blocks[20].Header.Hash())
+	testutil.AssertEquals(t, err, blkstorage.ErrNotFoundInIndex)
+	txID, err := extractTxID(blocks[20].Data.Data[0])
+	testutil.AssertNoError(t, err, "")
+	_, err = store.RetrieveTxByID(txID)
+	testutil.AssertEquals(t, err, blkstorage.ErrNotFoundInIndex)
+	_, err = store.RetrieveTxValidationCodeByTxID(txID)
+	testutil.AssertEquals(t, err, blkstorage.ErrNotFoundInIndex)
+
+	// the removed blocks can be added again
+	for _, b := range blocks[13:] {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	checkBlocks(t, blocks, store)
+	store.Shutdown()
+
+	// the rollback is persistent
+	testutil.AssertNoError(t, provider.Rollback("ledger1", 0), "")
+	store, err = provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	checkBlocks(t, blocks[:1], store)
+	store.Shutdown()
+	store, err = provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	defer store.Shutdown()
+	checkBlocks(t, blocks[:1], store)
+}
+
+func TestRollbackBlockStoreErrors(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	provider := env.provider
+	blocks := testutil.ConstructTestBlocks(t, 10)
+
+	err := provider.Rollback("ledger1", 2)
+	testutil.AssertEquals(t, err.Error(), "block store of ledger [ledger1] does not exist")
+
+	store, err := provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	for _, b := range blocks {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	store.Shutdown()
+
+	err = provider.Rollback("ledger1", 9)
+	testutil.AssertEquals(t, err.Error(), "target block number [9] should be less than the biggest block number [9] of ledger [ledger1]")
+
+	// the first block file is archived
+	testutil.AssertNoError(t, provider.leveldbProvider.GetDBHandle("ledger1").Put(archiveInfoKey, proto.EncodeVarint(1), true), "")
+	err = provider.Rollback("ledger1", 2)
+	testutil.AssertEquals(t, err.Error(), "block [2] of ledger [ledger1] is in block file [0], which is archived, it can't be rolled back to")
+
+	info := &blkstorage.BootstrapInfo{
+		LastBlockNum:      4,
+		LastBlockHash:     blocks[4].Header.Hash(),
+		PreviousBlockHash: blocks[4].Header.PreviousHash,
+	}
+	testutil.AssertNoError(t, provider.BootstrapBlockStore("ledger2", info), "")
+	store, err = provider.OpenBlockStore("ledger2")
+	testutil.AssertNoError(t, err, "")
+	for _, b := range blocks[5:] {
+		testutil.AssertNoError(t, store.AddBlock(b), "")
+	}
+	store.Shutdown()
+	err = provider.Rollback("ledger2", 6)
+	testutil.AssertEquals(t, err.Error(), "block store of ledger [ledger2] was bootstrapped from a snapshot, it can't be rolled back")
+
+	// the failed rollbacks leave the block stores intact
+	store, err = provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "")
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, bcInfo, &common.BlockchainInfo{
+		Height:            10,
+		CurrentBlockHash:  blocks[9].Header.Hash(),
+		PreviousBlockHash: blocks[9].Header.PreviousHash,
+	})
+}
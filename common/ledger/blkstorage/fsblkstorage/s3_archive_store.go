@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region  = "us-east-1"
+	defaultS3Timeout = 5 * time.Minute
+	// emptyPayloadHash is the SHA-256 hash of an empty request body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Conf encapsulates the configuration of an object store that is compatible with the S3 API,
+// such as Amazon S3, Google Cloud Storage with HMAC keys or MinIO
+type S3Conf struct {
+	// Endpoint is the URL of the object store, e.g. https://storage.googleapis.com
+	Endpoint string
+	// Bucket is the bucket the objects are stored in
+	Bucket string
+	// Region is the region of the bucket, us-east-1 by default
+	Region string
+	// AccessKeyID and SecretAccessKey sign the requests. The requests are anonymous without them
+	AccessKeyID     string
+	SecretAccessKey string
+	// Prefix is prepended to the names of the objects
+	Prefix string
+	// Timeout bounds the duration of an upload or a download, 5 minutes by default
+	Timeout time.Duration
+}
+
+// s3ArchiveStore is an ArchiveStore that stores the block files in a bucket of an S3-compatible object store,
+// using path-style requests signed with AWS Signature Version 4
+type s3ArchiveStore struct {
+	conf   S3Conf
+	client *http.Client
+}
+
+// NewS3ArchiveStore constructs an ArchiveStore that stores the block files in an S3-compatible object store
+func NewS3ArchiveStore(conf *S3Conf) ArchiveStore {
+	s := &s3ArchiveStore{conf: *conf}
+	if s.conf.Region == "" {
+		s.conf.Region = defaultS3Region
+	}
+	if s.conf.Timeout <= 0 {
+		s.conf.Timeout = defaultS3Timeout
+	}
+	s.client = &http.Client{Timeout: s.conf.Timeout}
+	return s
+}
+
+// Upload implements method in ArchiveStore interface
+func (s *s3ArchiveStore) Upload(name string, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(name), ioutil.NopCloser(file))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	s.sign(req, hex.EncodeToString(hash.Sum(nil)), time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkS3Response(resp, http.StatusOK)
+}
+
+// Download implements method in ArchiveStore interface
+func (s *s3ArchiveStore) Download(name string, filePath string) error {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(name), nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, emptyPayloadHash, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkS3Response(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (s *s3ArchiveStore) objectURL(name string) string {
+	u := &url.URL{Path: "/" + s.conf.Bucket + "/" + s.conf.Prefix + name}
+	return strings.TrimRight(s.conf.Endpoint, "/") + u.EscapedPath()
+}
+
+// sign signs a request with AWS Signature Version 4, given the SHA-256 hash of its body
+func (s *s3ArchiveStore) sign(req *http.Request, payloadHash string, now time.Time) {
+	if s.conf.AccessKeyID == "" {
+		return
+	}
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders, signedHeaders, payloadHash}, "\n")
+	scope := date + "/" + s.conf.Region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.conf.SecretAccessKey), date)
+	key = hmacSHA256(key, s.conf.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.conf.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func checkS3Response(resp *http.Response, expectedStatus int) error {
+	if resp.StatusCode == expectedStatus {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("object store returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
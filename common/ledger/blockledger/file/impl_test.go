@@ -25,6 +25,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -117,6 +118,14 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) GetBootstrapInfo() (*blkstorage.BootstrapInfo, error) {
+	return nil, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) ApproximateSize() (int64, error) {
+	return 0, mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -111,6 +111,25 @@ func TestIgnoreInvalidBlockFileName(t *testing.T) {
 	assert.Zero(t, chain.Height(), "Expected chain to be empty")
 }
 
+func TestRemove(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	jlf := New(name)
+	_, err = jlf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	_, err = jlf.GetOrCreate("bar")
+	assert.NoError(t, err)
+
+	assert.NoError(t, jlf.Remove("foo"))
+	assert.Equal(t, []string{"bar"}, jlf.ChainIDs())
+
+	// The removed chain is not recovered
+	jlf = New(name)
+	assert.Equal(t, []string{"bar"}, jlf.ChainIDs())
+}
+
 func TestClose(t *testing.T) {
 	name, err := ioutil.TempDir("", "hyperledger_fabric")
 	assert.Nil(t, err, "Error creating temp dir: %s", err)
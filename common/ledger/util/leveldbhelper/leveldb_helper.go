@@ -152,6 +152,16 @@ func (dbInst *DB) GetIterator(startKey []byte, endKey []byte) iterator.Iterator
 	return dbInst.db.NewIterator(&goleveldbutil.Range{Start: startKey, Limit: endKey}, dbInst.readOpts)
 }
 
+// ApproximateSize returns the approximate number of bytes that the keys between the startKey (inclusive)
+// and the endKey (exclusive) occupy in the file system. Recent writes that aren't compacted yet aren't accounted for
+func (dbInst *DB) ApproximateSize(startKey []byte, endKey []byte) (int64, error) {
+	sizes, err := dbInst.db.SizeOf([]goleveldbutil.Range{{Start: startKey, Limit: endKey}})
+	if err != nil {
+		return 0, err
+	}
+	return sizes.Sum(), nil
+}
+
 // WriteBatch writes a batch
 func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	wo := dbInst.writeOptsNoSync
@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// FileLock is an exclusive lock on a directory, which is held by at most one process at a time.
+// It is expected to be used by a single goroutine
+type FileLock struct {
+	db       *leveldb.DB
+	filePath string
+}
+
+// NewFileLock constructs a FileLock on the given directory
+func NewFileLock(filePath string) *FileLock {
+	return &FileLock{filePath: filePath}
+}
+
+// Lock acquires the lock. This is achieved by opening a leveldb in the directory, as leveldb
+// holds a lock on the files of an open db, which is released when the db is closed or when
+// the process that opened it exits. Opening the db again, from the same or from another process,
+// fails until then
+func (f *FileLock) Lock() error {
+	if f.db != nil {
+		return nil
+	}
+	dirEmpty, err := util.CreateDirIfMissing(f.filePath)
+	if err != nil {
+		return fmt.Errorf("error creating dir [%s]: %s", f.filePath, err)
+	}
+	db, err := leveldb.OpenFile(f.filePath, &opt.Options{ErrorIfMissing: !dirEmpty})
+	if err == syscall.EAGAIN {
+		return fmt.Errorf("lock is already acquired on file [%s]", f.filePath)
+	}
+	if err != nil {
+		return fmt.Errorf("error acquiring lock on file [%s]: %s", f.filePath, err)
+	}
+	f.db = db
+	return nil
+}
+
+// Unlock releases the lock, if it is held
+func (f *FileLock) Unlock() {
+	if f.db == nil {
+		return
+	}
+	if err := f.db.Close(); err != nil {
+		logger.Warningf("Error while releasing the lock on file [%s]: %s", f.filePath, err)
+		return
+	}
+	f.db = nil
+}
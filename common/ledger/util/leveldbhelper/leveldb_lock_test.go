@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLock(t *testing.T) {
+	lockPath := filepath.Join(testDBPath, "fileLock")
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	defer os.RemoveAll(testDBPath)
+
+	fileLock := NewFileLock(lockPath)
+	assert.NoError(t, fileLock.Lock())
+	// acquiring a held lock again is a no-op
+	assert.NoError(t, fileLock.Lock())
+
+	otherLock := NewFileLock(lockPath)
+	assert.EqualError(t, otherLock.Lock(), "lock is already acquired on file ["+lockPath+"]")
+
+	fileLock.Unlock()
+	// releasing a released lock is a no-op
+	fileLock.Unlock()
+
+	assert.NoError(t, otherLock.Lock())
+	otherLock.Unlock()
+}
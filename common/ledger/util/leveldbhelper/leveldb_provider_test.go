@@ -90,6 +90,30 @@ func TestBatchedUpdates(t *testing.T) {
 	}
 }
 
+func TestApproximateSize(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+
+	db2 := env.provider.GetDBHandle("db2")
+	for i := 0; i < 100; i++ {
+		db2.Put([]byte(createTestKey(i)), testutil.ConstructRandomBytes(t, 1000), false)
+	}
+	// the writes are flushed to the files of the db when it is reopened
+	env.provider.Close()
+	env.provider = NewProvider(&Conf{testDBPath})
+
+	size, err := env.provider.GetDBHandle("db2").ApproximateSize()
+	testutil.AssertNoError(t, err, "")
+	if size < 100*1000 {
+		t.Fatalf("Expected the approximate size of db2 to be at least %d, got %d", 100*1000, size)
+	}
+	for _, dbName := range []string{"db1", "db3"} {
+		size, err := env.provider.GetDBHandle(dbName).ApproximateSize()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, size, int64(0))
+	}
+}
+
 func testDBBasicWriteAndReads(t *testing.T, dbNames ...string) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
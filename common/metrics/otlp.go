@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/uber-go/tally"
+)
+
+type OTLPReporterOpts struct {
+	// Endpoint is the URL of the OpenTelemetry collector, e.g. http://localhost:4318
+	Endpoint string
+	// Timeout bounds each export request to the collector
+	Timeout time.Duration
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string
+}
+
+// otlpReporter buffers the values tally reports during an interval,
+// and exports them to an OpenTelemetry collector when tally flushes.
+// Counters and histograms are exported as deltas over the interval.
+type otlpReporter struct {
+	exporter *otlp.Exporter
+	resource otlp.Resource
+	now      func() time.Time
+
+	mutex      sync.Mutex
+	start      time.Time
+	counters   map[string]*otlpSeries
+	gauges     map[string]*otlpSeries
+	histograms map[string]*otlpHistogramSeries
+}
+
+type otlpSeries struct {
+	name  string
+	tags  map[string]string
+	value float64
+}
+
+type otlpHistogramSeries struct {
+	name   string
+	tags   map[string]string
+	bounds []float64
+	counts []uint64
+}
+
+func newOTLPReporter(otlpReporterOpts OTLPReporterOpts) (*otlpReporter, error) {
+	if otlpReporterOpts.Timeout <= 0 {
+		return nil, errors.New("missing otlp Timeout option")
+	}
+	exporter, err := otlp.NewExporter(otlpReporterOpts.Endpoint, otlp.MetricsPath, otlpReporterOpts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	serviceName := otlpReporterOpts.ServiceName
+	if serviceName == "" {
+		serviceName = namespace
+	}
+	r := &otlpReporter{
+		exporter: exporter,
+		resource: otlp.NewResource(serviceName),
+		now:      time.Now,
+	}
+	r.reset(r.now())
+	return r, nil
+}
+
+func (r *otlpReporter) reset(start time.Time) {
+	r.start = start
+	r.counters = make(map[string]*otlpSeries)
+	r.gauges = make(map[string]*otlpSeries)
+	r.histograms = make(map[string]*otlpHistogramSeries)
+}
+
+func (r *otlpReporter) ReportCounter(name string, tags map[string]string, value int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := scopeRegistryKey(name, tags)
+	s, ok := r.counters[key]
+	if !ok {
+		s = &otlpSeries{name: name, tags: tags}
+		r.counters[key] = s
+	}
+	s.value += float64(value)
+}
+
+func (r *otlpReporter) ReportGauge(name string, tags map[string]string, value float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.gauges[scopeRegistryKey(name, tags)] = &otlpSeries{name: name, tags: tags, value: value}
+}
+
+// ReportTimer reports the interval as a gauge in seconds
+func (r *otlpReporter) ReportTimer(name string, tags map[string]string, interval time.Duration) {
+	r.ReportGauge(name, tags, interval.Seconds())
+}
+
+func (r *otlpReporter) ReportHistogramValueSamples(
+	name string,
+	tags map[string]string,
+	buckets tally.Buckets,
+	bucketLowerBound,
+	bucketUpperBound float64,
+	samples int64,
+) {
+	r.reportHistogramSamples(name, tags, buckets.AsValues(), bucketUpperBound, samples)
+}
+
+// ReportHistogramDurationSamples reports the samples in seconds
+func (r *otlpReporter) ReportHistogramDurationSamples(
+	name string,
+	tags map[string]string,
+	buckets tally.Buckets,
+	bucketLowerBound,
+	bucketUpperBound time.Duration,
+	samples int64,
+) {
+	var bounds []float64
+	for _, d := range buckets.AsDurations() {
+		bounds = append(bounds, d.Seconds())
+	}
+	r.reportHistogramSamples(name, tags, bounds, bucketUpperBound.Seconds(), samples)
+}
+
+func (r *otlpReporter) reportHistogramSamples(name string, tags map[string]string, bounds []float64, upperBound float64, samples int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := scopeRegistryKey(name, tags)
+	s, ok := r.histograms[key]
+	if !ok {
+		bounds = append([]float64(nil), bounds...)
+		sort.Float64s(bounds)
+		s = &otlpHistogramSeries{
+			name:   name,
+			tags:   tags,
+			bounds: bounds,
+			counts: make([]uint64, len(bounds)+1),
+		}
+		r.histograms[key] = s
+	}
+	// tally buckets hold the values up to and including their upper bound,
+	// and the last one, bounded by math.MaxFloat64, the values above all bounds
+	s.counts[sort.SearchFloat64s(s.bounds, upperBound)] += uint64(samples)
+}
+
+func (r *otlpReporter) Capabilities() tally.Capabilities {
+	return r
+}
+
+func (r *otlpReporter) Reporting() bool {
+	return true
+}
+
+func (r *otlpReporter) Tagging() bool {
+	return true
+}
+
+// Flush exports the values reported since the previous flush
+func (r *otlpReporter) Flush() {
+	request := r.collect()
+	if request == nil {
+		return
+	}
+	if err := r.exporter.Export(request); err != nil {
+		logger.Warningf("Failed exporting metrics: %s", err)
+	}
+}
+
+func (r *otlpReporter) collect() *otlp.ExportMetricsServiceRequest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.counters) == 0 && len(r.gauges) == 0 && len(r.histograms) == 0 {
+		return nil
+	}
+
+	flushTime := r.now()
+	start, now := otlp.UnixNano(r.start), otlp.UnixNano(flushTime)
+	var metrics []*otlp.Metric
+	for _, s := range r.counters {
+		value := int64(s.value)
+		metrics = append(metrics, &otlp.Metric{
+			Name: s.name,
+			Sum: &otlp.Sum{
+				DataPoints: []otlp.NumberDataPoint{{
+					Attributes:        otlp.Attributes(s.tags),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					AsInt:             &value,
+				}},
+				AggregationTemporality: otlp.AggregationTemporalityDelta,
+				IsMonotonic:            true,
+			},
+		})
+	}
+	for _, s := range r.gauges {
+		value := s.value
+		metrics = append(metrics, &otlp.Metric{
+			Name: s.name,
+			Gauge: &otlp.Gauge{
+				DataPoints: []otlp.NumberDataPoint{{
+					Attributes:   otlp.Attributes(s.tags),
+					TimeUnixNano: now,
+					AsDouble:     &value,
+				}},
+			},
+		})
+	}
+	for _, s := range r.histograms {
+		var count uint64
+		for _, c := range s.counts {
+			count += c
+		}
+		metrics = append(metrics, &otlp.Metric{
+			Name: s.name,
+			Histogram: &otlp.Histogram{
+				DataPoints: []*otlp.HistogramDataPoint{{
+					Attributes:        otlp.Attributes(s.tags),
+					StartTimeUnixNano: start,
+					TimeUnixNano:      now,
+					Count:             count,
+					BucketCounts:      s.counts,
+					ExplicitBounds:    s.bounds,
+				}},
+				AggregationTemporality: otlp.AggregationTemporalityDelta,
+			},
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Name < metrics[j].Name
+	})
+	r.reset(flushTime)
+
+	return &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []otlp.ResourceMetrics{{
+			Resource: r.resource,
+			ScopeMetrics: []otlp.ScopeMetrics{{
+				Scope:   otlp.InstrumentationScope{Name: namespace},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+// Close exports the values reported since the last flush
+func (r *otlpReporter) Close() error {
+	r.Flush()
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type otlpCollector struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	requests []*otlp.ExportMetricsServiceRequest
+}
+
+func newOTLPCollector() *otlpCollector {
+	c := &otlpCollector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlp.MetricsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		request := &otlp.ExportMetricsServiceRequest{}
+		if err := json.Unmarshal(b, request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.mutex.Lock()
+		c.requests = append(c.requests, request)
+		c.mutex.Unlock()
+	}))
+	return c
+}
+
+func (c *otlpCollector) received() []*otlp.ExportMetricsServiceRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.requests
+}
+
+func TestNewOTLPReporter(t *testing.T) {
+	_, err := newOTLPReporter(OTLPReporterOpts{Endpoint: "http://localhost:4318"})
+	assert.EqualError(t, err, "missing otlp Timeout option")
+
+	_, err = newOTLPReporter(OTLPReporterOpts{Timeout: time.Second})
+	assert.EqualError(t, err, "missing OTLP collector endpoint")
+
+	r, err := newOTLPReporter(OTLPReporterOpts{Endpoint: "http://localhost:4318", Timeout: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, otlp.NewResource(namespace), r.resource)
+}
+
+func TestOTLPReporterFlush(t *testing.T) {
+	collector := newOTLPCollector()
+	defer collector.server.Close()
+
+	r, err := newOTLPReporter(OTLPReporterOpts{Endpoint: collector.server.URL, Timeout: time.Second, ServiceName: "peer"})
+	require.NoError(t, err)
+	start := time.Unix(100, 0)
+	now := start
+	r.now = func() time.Time { return now }
+	r.reset(start)
+
+	// Nothing is exported when nothing was reported
+	r.Flush()
+	assert.Empty(t, collector.received())
+
+	tags := map[string]string{"channel": "mychannel"}
+	buckets := tally.ValueBuckets{1, 5}
+	r.ReportCounter("hyperledger_fabric.proposals", tags, 2)
+	r.ReportCounter("hyperledger_fabric.proposals", tags, 3)
+	r.ReportGauge("hyperledger_fabric.height", tags, 10)
+	r.ReportGauge("hyperledger_fabric.height", tags, 12)
+	r.ReportHistogramValueSamples("hyperledger_fabric.latency", tags, buckets, 1, 5, 4)
+	r.ReportHistogramValueSamples("hyperledger_fabric.latency", tags, buckets, 5, 1.7976931348623157e+308, 1)
+	now = start.Add(time.Second)
+	r.Flush()
+
+	requests := collector.received()
+	require.Len(t, requests, 1)
+	resourceMetrics := requests[0].ResourceMetrics[0]
+	assert.Equal(t, otlp.NewResource("peer"), resourceMetrics.Resource)
+	assert.Equal(t, namespace, resourceMetrics.ScopeMetrics[0].Scope.Name)
+	metrics := resourceMetrics.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 3)
+	attributes := []otlp.KeyValue{otlp.StringAttribute("channel", "mychannel")}
+
+	height := metrics[0]
+	assert.Equal(t, "hyperledger_fabric.height", height.Name)
+	require.NotNil(t, height.Gauge)
+	assert.Equal(t, attributes, height.Gauge.DataPoints[0].Attributes)
+	assert.Equal(t, 12.0, *height.Gauge.DataPoints[0].AsDouble)
+	assert.Equal(t, otlp.UnixNano(now), height.Gauge.DataPoints[0].TimeUnixNano)
+
+	latency := metrics[1]
+	assert.Equal(t, "hyperledger_fabric.latency", latency.Name)
+	require.NotNil(t, latency.Histogram)
+	assert.Equal(t, otlp.AggregationTemporalityDelta, latency.Histogram.AggregationTemporality)
+	dataPoint := latency.Histogram.DataPoints[0]
+	assert.Equal(t, uint64(5), dataPoint.Count)
+	assert.Equal(t, otlp.Uint64s{0, 4, 1}, dataPoint.BucketCounts)
+	assert.Equal(t, []float64{1, 5}, dataPoint.ExplicitBounds)
+	assert.Equal(t, otlp.UnixNano(start), dataPoint.StartTimeUnixNano)
+
+	proposals := metrics[2]
+	assert.Equal(t, "hyperledger_fabric.proposals", proposals.Name)
+	require.NotNil(t, proposals.Sum)
+	assert.True(t, proposals.Sum.IsMonotonic)
+	assert.Equal(t, otlp.AggregationTemporalityDelta, proposals.Sum.AggregationTemporality)
+	assert.Equal(t, int64(5), *proposals.Sum.DataPoints[0].AsInt)
+	assert.Equal(t, otlp.UnixNano(start), proposals.Sum.DataPoints[0].StartTimeUnixNano)
+
+	// Values are exported as deltas since the previous flush
+	r.ReportCounter("hyperledger_fabric.proposals", tags, 1)
+	now = now.Add(time.Second)
+	assert.NoError(t, r.Close())
+	requests = collector.received()
+	require.Len(t, requests, 2)
+	metrics = requests[1].ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 1)
+	assert.Equal(t, int64(1), *metrics[0].Sum.DataPoints[0].AsInt)
+	assert.Equal(t, otlp.UnixNano(start.Add(time.Second)), metrics[0].Sum.DataPoints[0].StartTimeUnixNano)
+}
+
+func TestMetricsByOTLPReporter(t *testing.T) {
+	collector := newOTLPCollector()
+	defer collector.server.Close()
+
+	opts := Opts{
+		Enabled:  true,
+		Reporter: otlpReporterType,
+		Interval: time.Hour,
+		OTLPReporterOpts: OTLPReporterOpts{
+			Endpoint: collector.server.URL,
+			Timeout:  time.Second,
+		},
+	}
+	s, err := create(opts)
+	require.NoError(t, err)
+	s.SubScope("peer").Tagged(map[string]string{"channel": "mychannel"}).Counter("proposals").Inc(1)
+
+	// Closing the scope reports and exports the pending values
+	assert.NoError(t, s.Close())
+	requests := collector.received()
+	require.Len(t, requests, 1)
+	metrics := requests[0].ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "hyperledger_fabric.peer.proposals", metrics[0].Name)
+	assert.Equal(t, int64(1), *metrics[0].Sum.DataPoints[0].AsInt)
+}
@@ -8,9 +8,12 @@ package metrics
 
 import (
 	"fmt"
+	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
 	"github.com/uber-go/tally"
 )
@@ -20,12 +23,15 @@ const (
 
 	statsdReporterType = "statsd"
 	promReporterType   = "prom"
+	otlpReporterType   = "otlp"
 
 	defaultReporterType = statsdReporterType
 	defaultInterval     = 1 * time.Second
 
 	defaultStatsdReporterFlushInterval = 2 * time.Second
 	defaultStatsdReporterFlushBytes    = 1432
+
+	defaultOTLPReporterTimeout = 10 * time.Second
 )
 
 var RootScope Scope
@@ -67,12 +73,39 @@ func NewOpts() Opts {
 	if opts.Reporter == promReporterType {
 		promOpts := PromReporterOpts{}
 		promOpts.ListenAddress = viper.GetString("metrics.promReporter.listenAddress")
+		if viper.GetBool("metrics.promReporter.tls.enabled") {
+			promOpts.TLS = newPromReporterTLSOpts()
+		}
 		opts.PromReporterOpts = promOpts
 	}
 
+	if opts.Reporter == otlpReporterType {
+		otlpOpts := OTLPReporterOpts{}
+		otlpOpts.Endpoint = viper.GetString("metrics.otlpReporter.endpoint")
+		if timeout := viper.GetDuration("metrics.otlpReporter.timeout"); timeout > 0 {
+			otlpOpts.Timeout = timeout
+		} else {
+			otlpOpts.Timeout = defaultOTLPReporterTimeout
+		}
+		otlpOpts.ServiceName = viper.GetString("metrics.otlpReporter.serviceName")
+		opts.OTLPReporterOpts = otlpOpts
+	}
+
 	return opts
 }
 
+func newPromReporterTLSOpts() PromReporterTLSOpts {
+	tlsOpts := PromReporterTLSOpts{Enabled: true}
+	tlsOpts.CertFile = config.GetPath("metrics.promReporter.tls.cert.file")
+	tlsOpts.KeyFile = config.GetPath("metrics.promReporter.tls.key.file")
+	for _, file := range viper.GetStringSlice("metrics.promReporter.tls.clientRootCAs.files") {
+		tlsOpts.ClientRootCAs = append(tlsOpts.ClientRootCAs,
+			config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
+	}
+	tlsOpts.Authorization = viper.GetStringMapStringSlice("metrics.promReporter.tls.authorization")
+	return tlsOpts
+}
+
 //Init initializes global root metrics scope instance, all callers can only use it to extend sub scope
 func Init(opts Opts) (err error) {
 	once.Do(func() {
@@ -121,6 +154,24 @@ type StatsdReporterOpts struct {
 
 type PromReporterOpts struct {
 	ListenAddress string
+	TLS           PromReporterTLSOpts
+	// Handlers are served by the HTTP server alongside the metrics, by path
+	Handlers map[string]http.Handler
+}
+
+// PromReporterTLSOpts configures mutual TLS for the HTTP server
+// Prometheus pulls metrics from
+type PromReporterTLSOpts struct {
+	Enabled       bool
+	CertFile      string
+	KeyFile       string
+	ClientRootCAs []string
+	// Authorization maps an endpoint path to the organizational units
+	// allowed to access it. A client is authorized if its certificate
+	// carries any of the listed OUs. Endpoints without an entry are
+	// accessible to every client that passes TLS client authentication.
+	// The entry of an endpoint applies to the paths beneath it as well.
+	Authorization map[string][]string
 }
 
 type Opts struct {
@@ -129,6 +180,7 @@ type Opts struct {
 	Enabled            bool
 	StatsdReporterOpts StatsdReporterOpts
 	PromReporterOpts   PromReporterOpts
+	OTLPReporterOpts   OTLPReporterOpts
 }
 
 type noOpCounter struct {
@@ -145,9 +197,17 @@ func (g *noOpGauge) Update(v float64) {
 
 }
 
+type noOpHistogram struct {
+}
+
+func (h *noOpHistogram) RecordValue(v float64) {
+
+}
+
 type noOpScope struct {
-	counter *noOpCounter
-	gauge   *noOpGauge
+	counter   *noOpCounter
+	gauge     *noOpGauge
+	histogram *noOpHistogram
 }
 
 func (s *noOpScope) Counter(name string) Counter {
@@ -158,6 +218,10 @@ func (s *noOpScope) Gauge(name string) Gauge {
 	return s.gauge
 }
 
+func (s *noOpScope) Histogram(name string, buckets []float64) Histogram {
+	return s.histogram
+}
+
 func (s *noOpScope) Tagged(tags map[string]string) Scope {
 	return s
 }
@@ -176,8 +240,9 @@ func (s *noOpScope) Start() error {
 
 func newNoOpScope() Scope {
 	return &noOpScope{
-		counter: &noOpCounter{},
-		gauge:   &noOpGauge{},
+		counter:   &noOpCounter{},
+		gauge:     &noOpGauge{},
+		histogram: &noOpHistogram{},
 	}
 }
 
@@ -191,7 +256,8 @@ func create(opts Opts) (rootScope Scope, e error) {
 			return
 		}
 
-		if opts.Reporter != statsdReporterType && opts.Reporter != promReporterType {
+		if opts.Reporter != statsdReporterType && opts.Reporter != promReporterType &&
+			opts.Reporter != otlpReporterType {
 			e = fmt.Errorf("not supported Reporter type %s", opts.Reporter)
 			return
 		}
@@ -206,6 +272,10 @@ func create(opts Opts) (rootScope Scope, e error) {
 			cachedReporter, e = newPromReporter(opts.PromReporterOpts)
 		}
 
+		if opts.Reporter == otlpReporterType {
+			reporter, e = newOTLPReporter(opts.OTLPReporterOpts)
+		}
+
 		if e != nil {
 			return
 		}
@@ -8,6 +8,7 @@ package metrics
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -212,6 +213,30 @@ func TestNewOpts(t *testing.T) {
 	assert.Equal(t, 1*time.Second, opts1.Interval)
 	assert.Equal(t, promReporterType, opts1.Reporter)
 	assert.Equal(t, "0.0.0.0:8080", opts1.PromReporterOpts.ListenAddress)
+	assert.False(t, opts1.PromReporterOpts.TLS.Enabled)
+
+	viper.Set("metrics.promReporter.tls.enabled", true)
+	viper.Set("metrics.promReporter.tls.authorization", map[string][]string{
+		"/metrics": {"admin", "monitoring"},
+	})
+	opts2 := NewOpts()
+	tlsOpts := opts2.PromReporterOpts.TLS
+	assert.True(t, tlsOpts.Enabled)
+	assert.True(t, filepath.IsAbs(tlsOpts.CertFile))
+	assert.Equal(t, "server.crt", filepath.Base(tlsOpts.CertFile))
+	assert.Equal(t, "server.key", filepath.Base(tlsOpts.KeyFile))
+	assert.Len(t, tlsOpts.ClientRootCAs, 1)
+	assert.True(t, filepath.IsAbs(tlsOpts.ClientRootCAs[0]))
+	assert.Equal(t, []string{"admin", "monitoring"}, tlsOpts.Authorization["/metrics"])
+	viper.Reset()
+
+	setupTestConfig()
+	viper.Set("metrics.Reporter", otlpReporterType)
+	opts3 := NewOpts()
+	assert.Equal(t, otlpReporterType, opts3.Reporter)
+	assert.Equal(t, "http://localhost:4318", opts3.OTLPReporterOpts.Endpoint)
+	assert.Equal(t, 10*time.Second, opts3.OTLPReporterOpts.Timeout)
+	assert.Equal(t, "peer", opts3.OTLPReporterOpts.ServiceName)
 }
 
 func TestNewOptsDefaultVar(t *testing.T) {
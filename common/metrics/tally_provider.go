@@ -20,6 +20,7 @@ import (
 	"github.com/op/go-logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	tls "github.com/tjfoc/gmtls"
 	"github.com/uber-go/tally"
 	promreporter "github.com/uber-go/tally/prometheus"
 	statsdreporter "github.com/uber-go/tally/statsd"
@@ -53,6 +54,18 @@ func (g *gauge) Update(v float64) {
 	g.tallyGauge.Update(v)
 }
 
+type histogram struct {
+	tallyHistogram tally.Histogram
+}
+
+func newHistogram(tallyHistogram tally.Histogram) *histogram {
+	return &histogram{tallyHistogram: tallyHistogram}
+}
+
+func (h *histogram) RecordValue(v float64) {
+	h.tallyHistogram.RecordValue(v)
+}
+
 type scopeRegistry struct {
 	sync.RWMutex
 	subScopes map[string]*scope
@@ -68,9 +81,11 @@ type scope struct {
 
 	cm sync.RWMutex
 	gm sync.RWMutex
+	hm sync.RWMutex
 
-	counters map[string]*counter
-	gauges   map[string]*gauge
+	counters   map[string]*counter
+	gauges     map[string]*gauge
+	histograms map[string]*histogram
 }
 
 func newRootScope(opts tally.ScopeOptions, interval time.Duration) Scope {
@@ -92,7 +107,8 @@ func newRootScope(opts tally.ScopeOptions, interval time.Duration) Scope {
 		},
 		baseReporter: baseReporter,
 		counters:     make(map[string]*counter),
-		gauges:       make(map[string]*gauge)}
+		gauges:       make(map[string]*gauge),
+		histograms:   make(map[string]*histogram)}
 }
 
 func newStatsdReporter(statsdReporterOpts StatsdReporterOpts) (tally.StatsReporter, error) {
@@ -129,11 +145,26 @@ func newPromReporter(promReporterOpts PromReporterOpts) (promreporter.Reporter,
 	mux := http.NewServeMux()
 	handler := promReporterHttpHandler(opts.Registerer.(*prometheus.Registry))
 	mux.Handle("/metrics", handler)
+	for path, h := range promReporterOpts.Handlers {
+		mux.Handle(path, h)
+	}
 	server := &http.Server{Addr: promReporterOpts.ListenAddress, Handler: mux}
 	promReporter := &promReporter{
 		reporter: reporter,
 		server:   server,
 		registry: opts.Registerer.(*prometheus.Registry)}
+	if promReporterOpts.TLS.Enabled {
+		tlsConfig, err := newServerTLSConfig(promReporterOpts.TLS)
+		if err != nil {
+			return nil, err
+		}
+		conns := newTLSConnections()
+		server.ConnState = conns.track
+		server.Handler = newAuthorizationHandler(mux, promReporterOpts.TLS.Authorization, conns.peerCertificates)
+		promReporter.tlsConfig = tlsConfig
+	} else if len(promReporterOpts.TLS.Authorization) != 0 {
+		return nil, errors.New("prometheus authorization rules require TLS to be enabled")
+	}
 	return promReporter, nil
 }
 
@@ -171,6 +202,23 @@ func (s *scope) Gauge(name string) Gauge {
 	return val
 }
 
+func (s *scope) Histogram(name string, buckets []float64) Histogram {
+	s.hm.RLock()
+	val, ok := s.histograms[name]
+	s.hm.RUnlock()
+	if !ok {
+		s.hm.Lock()
+		val, ok = s.histograms[name]
+		if !ok {
+			histogram := s.tallyScope.Histogram(name, tally.ValueBuckets(buckets))
+			val = newHistogram(histogram)
+			s.histograms[name] = val
+		}
+		s.hm.Unlock()
+	}
+	return val
+}
+
 func (s *scope) Tagged(tags map[string]string) Scope {
 	originTags := tags
 	tags = mergeRightTags(s.tags, tags)
@@ -201,8 +249,9 @@ func (s *scope) Tagged(tags map[string]string) Scope {
 		tallyScope: s.tallyScope.Tagged(originTags),
 		registry:   s.registry,
 
-		counters: make(map[string]*counter),
-		gauges:   make(map[string]*gauge),
+		counters:   make(map[string]*counter),
+		gauges:     make(map[string]*gauge),
+		histograms: make(map[string]*histogram),
 	}
 
 	s.registry.subScopes[key] = subScope
@@ -237,8 +286,9 @@ func (s *scope) SubScope(prefix string) Scope {
 		tallyScope: s.tallyScope.SubScope(prefix),
 		registry:   s.registry,
 
-		counters: make(map[string]*counter),
-		gauges:   make(map[string]*gauge),
+		counters:   make(map[string]*counter),
+		gauges:     make(map[string]*gauge),
+		histograms: make(map[string]*histogram),
 	}
 
 	s.registry.subScopes[key] = subScope
@@ -265,9 +315,10 @@ type statsdReporter struct {
 }
 
 type promReporter struct {
-	reporter promreporter.Reporter
-	server   *http.Server
-	registry *prometheus.Registry
+	reporter  promreporter.Reporter
+	server    *http.Server
+	registry  *prometheus.Registry
+	tlsConfig *tls.Config
 }
 
 func (r *statsdReporter) ReportCounter(name string, tags map[string]string, value int64) {
@@ -395,6 +446,13 @@ func (r *promReporter) Close() error {
 }
 
 func (r *promReporter) Start() error {
+	if r.tlsConfig != nil {
+		listener, err := tls.Listen("tcp", r.server.Addr, r.tlsConfig)
+		if err != nil {
+			return err
+		}
+		return r.server.Serve(listener)
+	}
 	return r.server.ListenAndServe()
 }
 
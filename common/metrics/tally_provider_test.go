@@ -53,11 +53,13 @@ func (m *testFloatValue) ReportGauge(value float64) {
 type testStatsReporter struct {
 	cg sync.WaitGroup
 	gg sync.WaitGroup
+	hg sync.WaitGroup
 
 	scope Scope
 
-	counters map[string]*testIntValue
-	gauges   map[string]*testFloatValue
+	counters   map[string]*testIntValue
+	gauges     map[string]*testFloatValue
+	histograms map[string]map[float64]int64
 
 	flushes int32
 }
@@ -65,8 +67,9 @@ type testStatsReporter struct {
 // newTestStatsReporter returns a new TestStatsReporter
 func newTestStatsReporter() *testStatsReporter {
 	return &testStatsReporter{
-		counters: make(map[string]*testIntValue),
-		gauges:   make(map[string]*testFloatValue)}
+		counters:   make(map[string]*testIntValue),
+		gauges:     make(map[string]*testFloatValue),
+		histograms: make(map[string]map[float64]int64)}
 }
 
 func (r *testStatsReporter) WaitAll() {
@@ -140,7 +143,11 @@ func (r *testStatsReporter) ReportHistogramValueSamples(
 	bucketUpperBound float64,
 	samples int64,
 ) {
-
+	if r.histograms[name] == nil {
+		r.histograms[name] = make(map[float64]int64)
+	}
+	r.histograms[name][bucketUpperBound] = samples
+	r.hg.Done()
 }
 
 func (r *testStatsReporter) ReportHistogramDurationSamples(
@@ -245,6 +252,31 @@ func TestMultiGaugeReport(t *testing.T) {
 	assert.Equal(t, float64(3.33), r.gauges[namespace+".foo"].val)
 }
 
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	r := newTestStatsReporter()
+	opts := tally.ScopeOptions{
+		Prefix:    namespace,
+		Separator: tally.DefaultSeparator,
+		Reporter:  r}
+
+	s := newRootScope(opts, 1*time.Second)
+	go s.Start()
+	defer s.Close()
+
+	// Two samples fall into the bucket bounded by 1, and one into the bucket bounded by 10
+	r.hg.Add(2)
+	h := s.Histogram("foo", []float64{1, 10})
+	assert.True(t, h == s.Histogram("foo", []float64{1, 10}))
+	h.RecordValue(0.5)
+	h.RecordValue(1)
+	h.RecordValue(5)
+	r.hg.Wait()
+
+	assert.Equal(t, int64(2), r.histograms[namespace+".foo"][1])
+	assert.Equal(t, int64(1), r.histograms[namespace+".foo"][10])
+}
+
 func TestSubScope(t *testing.T) {
 	t.Parallel()
 	r := newTestStatsReporter()
@@ -445,6 +477,38 @@ func TestMetricsByPrometheusReporter(t *testing.T) {
 	}
 }
 
+func TestPrometheusReporterHandlers(t *testing.T) {
+	t.Parallel()
+	address := "127.0.0.1:8084"
+	r, err := newPromReporter(PromReporterOpts{
+		ListenAddress: address,
+		Handlers: map[string]http.Handler{
+			"/healthz": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("OK"))
+			}),
+		},
+	})
+	assert.NoError(t, err)
+	reporter := r.(*promReporter)
+	go reporter.Start()
+	defer reporter.Close()
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		if resp, err = http.Get(fmt.Sprintf("http://%s/healthz", address)); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "OK", string(body))
+}
+
 func newTestStatsdReporter() (tally.StatsReporter, error) {
 	opts := StatsdReporterOpts{
 		Address:       statsdAddress,
@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/tjfoc/gmsm/sm2"
+	tls "github.com/tjfoc/gmtls"
+)
+
+// newServerTLSConfig creates a TLS configuration that requires clients
+// to present a certificate issued by one of the configured client root CAs
+func newServerTLSConfig(opts PromReporterTLSOpts) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading TLS key pair: %s", err)
+	}
+
+	if len(opts.ClientRootCAs) == 0 {
+		return nil, errors.New("missing client root CAs, required for TLS client authentication")
+	}
+	clientCAs := sm2.NewCertPool()
+	for _, file := range opts.ClientRootCAs {
+		pem, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading client root CA: %s", err)
+		}
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client root CA file %s", file)
+		}
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// tlsConnections keeps track of the TLS connections of an HTTP server by
+// their remote address. The HTTP server only exposes the connection state
+// of crypto/tls connections to the handlers, hence the client certificates
+// of gmtls connections are looked up through it.
+type tlsConnections struct {
+	sync.Mutex
+	conns map[string]*tls.Conn
+}
+
+func newTLSConnections() *tlsConnections {
+	return &tlsConnections{conns: make(map[string]*tls.Conn)}
+}
+
+// track is meant to be set as the ConnState hook of the HTTP server
+func (c *tlsConnections) track(conn net.Conn, state http.ConnState) {
+	tlsConn, isTLS := conn.(*tls.Conn)
+	if !isTLS {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	switch state {
+	case http.StateNew:
+		c.conns[conn.RemoteAddr().String()] = tlsConn
+	case http.StateHijacked, http.StateClosed:
+		delete(c.conns, conn.RemoteAddr().String())
+	}
+}
+
+// peerCertificates returns the certificate chain the client of the given request presented
+func (c *tlsConnections) peerCertificates(r *http.Request) []*sm2.Certificate {
+	c.Lock()
+	tlsConn, exists := c.conns[r.RemoteAddr]
+	c.Unlock()
+	if !exists {
+		return nil
+	}
+	return tlsConn.ConnectionState().PeerCertificates
+}
+
+// authorizationHandler restricts access to endpoints to clients whose
+// certificate carries one of the organizational units configured for them.
+// The rule of an endpoint applies to every path beneath it as well.
+type authorizationHandler struct {
+	next             http.Handler
+	rules            map[string][]string
+	peerCertificates func(*http.Request) []*sm2.Certificate
+}
+
+func newAuthorizationHandler(next http.Handler, rules map[string][]string, peerCertificates func(*http.Request) []*sm2.Certificate) http.Handler {
+	if len(rules) == 0 {
+		return next
+	}
+	cleanRules := make(map[string][]string, len(rules))
+	for p, ous := range rules {
+		cleanRules[path.Clean("/"+p)] = ous
+	}
+	return &authorizationHandler{next: next, rules: cleanRules, peerCertificates: peerCertificates}
+}
+
+// allowedOUs returns the organizational units allowed to access the given path,
+// according to the rule of the longest endpoint the path is beneath of
+func (h *authorizationHandler) allowedOUs(urlPath string) ([]string, bool) {
+	urlPath = path.Clean("/" + urlPath)
+	var matched string
+	var allowedOUs []string
+	found := false
+	for endpoint, ous := range h.rules {
+		if urlPath != endpoint && !strings.HasPrefix(urlPath, strings.TrimSuffix(endpoint, "/")+"/") {
+			continue
+		}
+		if found && len(endpoint) <= len(matched) {
+			continue
+		}
+		matched, allowedOUs, found = endpoint, ous, true
+	}
+	return allowedOUs, found
+}
+
+func (h *authorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	allowedOUs, exists := h.allowedOUs(r.URL.Path)
+	if !exists {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	peerCertificates := h.peerCertificates(r)
+	if len(peerCertificates) == 0 {
+		logger.Warningf("Denied access to %s for %s: no client certificate", r.URL.Path, r.RemoteAddr)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	clientCert := peerCertificates[0]
+	for _, ou := range clientCert.Subject.OrganizationalUnit {
+		for _, allowed := range allowedOUs {
+			if ou == allowed {
+				h.next.ServeHTTP(w, r)
+				return
+			}
+		}
+	}
+
+	logger.Warningf("Denied access to %s for %s: client certificate OUs %v are not any of %v",
+		r.URL.Path, clientCert.Subject.CommonName, clientCert.Subject.OrganizationalUnit, allowedOUs)
+	http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+}
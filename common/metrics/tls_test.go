@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmtls"
+)
+
+const promTLSAddress = "127.0.0.1:8083"
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns the PEM encoded certificate and key of a new leaf
+// certificate with the given organizational units
+func (ca *testCA) issue(t *testing.T, cn string, ous []string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn, OrganizationalUnit: ous},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	// gmtls only loads PKCS#1 and PKCS#8 private keys
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *testCA) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func writeFile(t *testing.T, dir, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, content, 0600))
+	return path
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "metrics-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	cert, key := ca.issue(t, "server", nil)
+	opts := PromReporterTLSOpts{
+		Enabled:       true,
+		CertFile:      writeFile(t, dir, "server.crt", cert),
+		KeyFile:       writeFile(t, dir, "server.key", key),
+		ClientRootCAs: []string{writeFile(t, dir, "ca.crt", ca.certPEM())},
+	}
+	tlsConfig, err := newServerTLSConfig(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, gmtls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	badOpts := opts
+	badOpts.KeyFile = filepath.Join(dir, "missing.key")
+	_, err = newServerTLSConfig(badOpts)
+	assert.Contains(t, err.Error(), "failed loading TLS key pair")
+
+	badOpts = opts
+	badOpts.ClientRootCAs = nil
+	_, err = newServerTLSConfig(badOpts)
+	assert.EqualError(t, err, "missing client root CAs, required for TLS client authentication")
+
+	badOpts = opts
+	badOpts.ClientRootCAs = []string{filepath.Join(dir, "missing.crt")}
+	_, err = newServerTLSConfig(badOpts)
+	assert.Contains(t, err.Error(), "failed loading client root CA")
+
+	badOpts = opts
+	badOpts.ClientRootCAs = []string{opts.KeyFile}
+	_, err = newServerTLSConfig(badOpts)
+	assert.Contains(t, err.Error(), "no certificates found in client root CA file")
+}
+
+func TestAuthorizationHandler(t *testing.T) {
+	t.Parallel()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	var peerCertificates []*sm2.Certificate
+	handler := newAuthorizationHandler(next, map[string][]string{
+		"/metrics":        {"admin", "monitoring"},
+		"/admin/":         {"admin"},
+		"/admin/loglevel": {"operator"},
+	}, func(*http.Request) []*sm2.Certificate {
+		return peerCertificates
+	})
+
+	serve := func(path string, ous ...[]string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		peerCertificates = nil
+		for _, ou := range ous {
+			peerCertificates = append(peerCertificates, &sm2.Certificate{
+				Subject: pkix.Name{OrganizationalUnit: ou},
+			})
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/metrics", []string{"monitoring"}))
+	assert.Equal(t, http.StatusOK, serve("/metrics", []string{"peer", "admin"}))
+	assert.Equal(t, http.StatusForbidden, serve("/metrics", []string{"client"}))
+	assert.Equal(t, http.StatusForbidden, serve("/metrics"))
+	// Only the leaf certificate is considered
+	assert.Equal(t, http.StatusForbidden, serve("/metrics", []string{"client"}, []string{"admin"}))
+	// Endpoints without rules are open to every authenticated client
+	assert.Equal(t, http.StatusOK, serve("/healthz", []string{"client"}))
+	assert.Equal(t, http.StatusOK, serve("/metricsfoo", []string{"client"}))
+
+	// Trailing slashes and unclean paths don't circumvent the rules
+	assert.Equal(t, http.StatusForbidden, serve("/metrics/", []string{"client"}))
+	assert.Equal(t, http.StatusOK, serve("/metrics/", []string{"admin"}))
+	assert.Equal(t, http.StatusForbidden, serve("//metrics", []string{"client"}))
+	assert.Equal(t, http.StatusForbidden, serve("/healthz/../metrics", []string{"client"}))
+
+	// The rule of an endpoint applies to the paths beneath it,
+	// unless a more specific rule exists
+	assert.Equal(t, http.StatusForbidden, serve("/metrics/foo", []string{"client"}))
+	assert.Equal(t, http.StatusOK, serve("/admin", []string{"admin"}))
+	assert.Equal(t, http.StatusOK, serve("/admin/peers", []string{"admin"}))
+	assert.Equal(t, http.StatusForbidden, serve("/admin/peers", []string{"operator"}))
+	assert.Equal(t, http.StatusOK, serve("/admin/loglevel/", []string{"operator"}))
+	assert.Equal(t, http.StatusForbidden, serve("/admin/loglevel", []string{"admin"}))
+
+	// Without rules the handler is not wrapped at all
+	assert.IsType(t, next, newAuthorizationHandler(next, nil, nil))
+}
+
+func TestPromReporterAuthorizationWithoutTLS(t *testing.T) {
+	t.Parallel()
+	_, err := newPromReporter(PromReporterOpts{
+		ListenAddress: promTLSAddress,
+		TLS: PromReporterTLSOpts{
+			Authorization: map[string][]string{"/metrics": {"admin"}},
+		},
+	})
+	assert.EqualError(t, err, "prometheus authorization rules require TLS to be enabled")
+}
+
+func TestPromReporterMutualTLS(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "metrics-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, "server", nil)
+	r, err := newPromReporter(PromReporterOpts{
+		ListenAddress: promTLSAddress,
+		TLS: PromReporterTLSOpts{
+			Enabled:       true,
+			CertFile:      writeFile(t, dir, "server.crt", serverCert),
+			KeyFile:       writeFile(t, dir, "server.key", serverKey),
+			ClientRootCAs: []string{writeFile(t, dir, "ca.crt", ca.certPEM())},
+			Authorization: map[string][]string{"/metrics": {"admin"}},
+		},
+	})
+	require.NoError(t, err)
+	reporter := r.(*promReporter)
+	go reporter.Start()
+	defer reporter.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	get := func(clientCerts ...tls.Certificate) (int, error) {
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      rootCAs,
+					Certificates: clientCerts,
+				},
+			},
+		}
+		resp, err := client.Get(fmt.Sprintf("https://%s/metrics", promTLSAddress))
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	keyPair := func(ous ...string) tls.Certificate {
+		cert, key := ca.issue(t, "client", ous)
+		pair, err := tls.X509KeyPair(cert, key)
+		require.NoError(t, err)
+		return pair
+	}
+
+	// Wait for the server to come up
+	var code int
+	for i := 0; i < 50; i++ {
+		if code, err = get(keyPair("admin")); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, err = get(keyPair("client"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Clients without a certificate fail TLS client authentication
+	_, err = get()
+	assert.Error(t, err)
+
+	// Certificates issued by an unknown CA are rejected as well
+	otherCA := newTestCA(t)
+	cert, key := otherCA.issue(t, "client", []string{"admin"})
+	pair, err := tls.X509KeyPair(cert, key)
+	require.NoError(t, err)
+	_, err = get(pair)
+	assert.Error(t, err)
+}
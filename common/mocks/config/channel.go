@@ -68,6 +68,9 @@ type ChannelCapabilities struct {
 
 	// MSPVersionVal is returned by MSPVersion()
 	MSPVersionVal msp.MSPVersion
+
+	// SM3HashingVal is returned by SM3Hashing()
+	SM3HashingVal bool
 }
 
 // Supported returns SupportedErr
@@ -79,3 +82,8 @@ func (cc *ChannelCapabilities) Supported() error {
 func (cc *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 	return cc.MSPVersionVal
 }
+
+// SM3Hashing returns SM3HashingVal
+func (cc *ChannelCapabilities) SM3Hashing() bool {
+	return cc.SM3HashingVal
+}
@@ -26,7 +26,7 @@ type Orderer struct {
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
 	MaxChannelsCountVal uint64
 	// OrganizationsVal is returned as the result of Organizations()
-	OrganizationsVal map[string]channelconfig.Org
+	OrganizationsVal map[string]channelconfig.OrdererOrg
 	// CapabilitiesVal is returned as the result of Capabilities()
 	CapabilitiesVal channelconfig.OrdererCapabilities
 }
@@ -57,7 +57,7 @@ func (scm *Orderer) MaxChannelsCount() uint64 {
 }
 
 // Organizations returns OrganizationsVal
-func (scm *Orderer) Organizations() map[string]channelconfig.Org {
+func (scm *Orderer) Organizations() map[string]channelconfig.OrdererOrg {
 	return scm.OrganizationsVal
 }
 
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package otlp
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// AggregationTemporalityDelta indicates that the value of a data point
+// covers the interval since the previous data point was reported
+const AggregationTemporalityDelta = 1
+
+// ExportMetricsServiceRequest is the request of the metrics export service
+type ExportMetricsServiceRequest struct {
+	ResourceMetrics []ResourceMetrics `json:"resourceMetrics"`
+}
+
+// ResourceMetrics are the metrics of a resource
+type ResourceMetrics struct {
+	Resource     Resource       `json:"resource"`
+	ScopeMetrics []ScopeMetrics `json:"scopeMetrics"`
+}
+
+// ScopeMetrics are the metrics produced by an instrumentation scope
+type ScopeMetrics struct {
+	Scope   InstrumentationScope `json:"scope"`
+	Metrics []*Metric            `json:"metrics"`
+}
+
+// Metric is a named metric with the data points of one of its kinds
+type Metric struct {
+	Name      string     `json:"name"`
+	Sum       *Sum       `json:"sum,omitempty"`
+	Gauge     *Gauge     `json:"gauge,omitempty"`
+	Histogram *Histogram `json:"histogram,omitempty"`
+}
+
+// Sum is a metric whose data points are sums of measurements
+type Sum struct {
+	DataPoints             []NumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+// Gauge is a metric whose data points are sampled values
+type Gauge struct {
+	DataPoints []NumberDataPoint `json:"dataPoints"`
+}
+
+// NumberDataPoint is a data point of a sum or a gauge.
+// Exactly one of AsInt and AsDouble is set
+type NumberDataPoint struct {
+	Attributes        []KeyValue `json:"attributes"`
+	StartTimeUnixNano uint64     `json:"startTimeUnixNano,string,omitempty"`
+	TimeUnixNano      uint64     `json:"timeUnixNano,string"`
+	AsInt             *int64     `json:"asInt,string,omitempty"`
+	AsDouble          *float64   `json:"asDouble,omitempty"`
+}
+
+// Histogram is a metric whose data points are distributions of measurements
+type Histogram struct {
+	DataPoints             []*HistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+}
+
+// HistogramDataPoint counts the measurements that fall into each bucket.
+// Bucket i counts the measurements in (ExplicitBounds[i-1], ExplicitBounds[i]],
+// and the last bucket those above the last bound
+type HistogramDataPoint struct {
+	Attributes        []KeyValue `json:"attributes"`
+	StartTimeUnixNano uint64     `json:"startTimeUnixNano,string,omitempty"`
+	TimeUnixNano      uint64     `json:"timeUnixNano,string"`
+	Count             uint64     `json:"count,string"`
+	BucketCounts      Uint64s    `json:"bucketCounts"`
+	ExplicitBounds    []float64  `json:"explicitBounds"`
+}
+
+// Uint64s is a list of integers, which are encoded
+// as strings in JSON like all 64 bit integers of OTLP
+type Uint64s []uint64
+
+// MarshalJSON encodes the integers as strings
+func (u Uint64s) MarshalJSON() ([]byte, error) {
+	strs := make([]string, len(u))
+	for i, v := range u {
+		strs[i] = strconv.FormatUint(v, 10)
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalJSON decodes the integers from strings
+func (u *Uint64s) UnmarshalJSON(b []byte) error {
+	var strs []string
+	if err := json.Unmarshal(b, &strs); err != nil {
+		return err
+	}
+	values := make(Uint64s, len(strs))
+	for i, s := range strs {
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	*u = values
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package otlp exports telemetry to an OpenTelemetry collector
+// over OTLP/HTTP, using the JSON encoding of the protocol.
+package otlp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MetricsPath is the path of the metrics export service of a collector
+	MetricsPath = "/v1/metrics"
+	// TracesPath is the path of the trace export service of a collector
+	TracesPath = "/v1/traces"
+)
+
+// Exporter posts export requests to a service of an OpenTelemetry collector
+type Exporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewExporter creates an Exporter that posts to the service at
+// the given path of the collector listening at the endpoint,
+// for instance http://localhost:4318
+func NewExporter(endpoint string, path string, timeout time.Duration) (*Exporter, error) {
+	if endpoint == "" {
+		return nil, errors.New("missing OTLP collector endpoint")
+	}
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, errors.Errorf("OTLP collector endpoint %s is not an http or https URL", endpoint)
+	}
+	return &Exporter{
+		url:    strings.TrimSuffix(endpoint, "/") + path,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Export posts the export request to the collector
+func (e *Exporter) Export(request interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling OTLP export request")
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed exporting to %s", e.url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.Errorf("failed exporting to %s: %s: %s", e.url, resp.Status, msg)
+	}
+	return nil
+}
+
+// Resource describes the entity that produces the telemetry
+type Resource struct {
+	Attributes []KeyValue `json:"attributes"`
+}
+
+// NewResource creates the resource of the service with the given name
+func NewResource(serviceName string) Resource {
+	return Resource{Attributes: []KeyValue{StringAttribute("service.name", serviceName)}}
+}
+
+// InstrumentationScope identifies the library that produces the telemetry
+type InstrumentationScope struct {
+	Name string `json:"name"`
+}
+
+// KeyValue is an attribute of a resource, data point or span
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// AnyValue is the value of an attribute
+type AnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// StringAttribute creates an attribute with a string value
+func StringAttribute(key, value string) KeyValue {
+	return KeyValue{Key: key, Value: AnyValue{StringValue: value}}
+}
+
+// Attributes converts a map to attributes sorted by key
+func Attributes(m map[string]string) []KeyValue {
+	attributes := make([]KeyValue, 0, len(m))
+	for k, v := range m {
+		attributes = append(attributes, StringAttribute(k, v))
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+	return attributes
+}
+
+// TraceID derives the hex encoded ID of a trace from a key identifying it,
+// such as a transaction ID, so that every process handling the key
+// records its spans in the same trace
+func TraceID(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:16])
+}
+
+// UnixNano returns the time as nanoseconds since the epoch,
+// or zero if the time is the zero time
+func UnixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
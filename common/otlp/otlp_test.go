@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package otlp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExporter(t *testing.T) {
+	_, err := NewExporter("", MetricsPath, time.Second)
+	assert.EqualError(t, err, "missing OTLP collector endpoint")
+
+	_, err = NewExporter("localhost:4318", MetricsPath, time.Second)
+	assert.EqualError(t, err, "OTLP collector endpoint localhost:4318 is not an http or https URL")
+
+	e, err := NewExporter("http://localhost:4318/", TracesPath, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318/v1/traces", e.url)
+}
+
+func TestExport(t *testing.T) {
+	var path, contentType string
+	var body map[string]interface{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		b, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(b, &body)
+		w.WriteHeader(status)
+		w.Write([]byte("rejected"))
+	}))
+	defer server.Close()
+
+	e, err := NewExporter(server.URL, MetricsPath, time.Second)
+	require.NoError(t, err)
+
+	value := int64(3)
+	request := &ExportMetricsServiceRequest{
+		ResourceMetrics: []ResourceMetrics{{
+			Resource: NewResource("peer"),
+			ScopeMetrics: []ScopeMetrics{{
+				Scope: InstrumentationScope{Name: "hyperledger_fabric"},
+				Metrics: []*Metric{{
+					Name: "proposals",
+					Sum: &Sum{
+						DataPoints: []NumberDataPoint{{
+							Attributes:   Attributes(map[string]string{"channel": "mychannel"}),
+							TimeUnixNano: 1000,
+							AsInt:        &value,
+						}},
+						AggregationTemporality: AggregationTemporalityDelta,
+						IsMonotonic:            true,
+					},
+				}},
+			}},
+		}},
+	}
+	assert.NoError(t, e.Export(request))
+	assert.Equal(t, MetricsPath, path)
+	assert.Equal(t, "application/json", contentType)
+	dataPoint := body["resourceMetrics"].([]interface{})[0].(map[string]interface{})["scopeMetrics"].([]interface{})[0].(map[string]interface{})["metrics"].([]interface{})[0].(map[string]interface{})["sum"].(map[string]interface{})["dataPoints"].([]interface{})[0].(map[string]interface{})
+	// 64 bit integers are encoded as strings
+	assert.Equal(t, "3", dataPoint["asInt"])
+	assert.Equal(t, "1000", dataPoint["timeUnixNano"])
+	assert.NotContains(t, dataPoint, "startTimeUnixNano")
+
+	status = http.StatusBadRequest
+	err = e.Export(request)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "400 Bad Request: rejected")
+}
+
+func TestAttributes(t *testing.T) {
+	assert.Empty(t, Attributes(nil))
+	assert.Equal(t, []KeyValue{
+		StringAttribute("chaincode", "mycc"),
+		StringAttribute("channel", "mychannel"),
+	}, Attributes(map[string]string{"channel": "mychannel", "chaincode": "mycc"}))
+}
+
+func TestUint64sJSON(t *testing.T) {
+	b, err := json.Marshal(Uint64s{0, 18446744073709551615})
+	assert.NoError(t, err)
+	assert.Equal(t, `["0","18446744073709551615"]`, string(b))
+
+	var u Uint64s
+	assert.NoError(t, json.Unmarshal(b, &u))
+	assert.Equal(t, Uint64s{0, 18446744073709551615}, u)
+	assert.Error(t, json.Unmarshal([]byte(`["-1"]`), &u))
+}
+
+func TestTraceID(t *testing.T) {
+	id := TraceID("tx1")
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, TraceID("tx1"))
+	assert.NotEqual(t, id, TraceID("tx2"))
+}
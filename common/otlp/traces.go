@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package otlp
+
+const (
+	// SpanKindInternal is the kind of spans of operations within a process
+	SpanKindInternal = 1
+	// SpanKindServer is the kind of spans of requests served by a process
+	SpanKindServer = 2
+
+	// StatusCodeOK indicates that the operation of a span succeeded
+	StatusCodeOK = 1
+	// StatusCodeError indicates that the operation of a span failed
+	StatusCodeError = 2
+)
+
+// ExportTraceServiceRequest is the request of the trace export service
+type ExportTraceServiceRequest struct {
+	ResourceSpans []ResourceSpans `json:"resourceSpans"`
+}
+
+// ResourceSpans are the spans of a resource
+type ResourceSpans struct {
+	Resource   Resource     `json:"resource"`
+	ScopeSpans []ScopeSpans `json:"scopeSpans"`
+}
+
+// ScopeSpans are the spans produced by an instrumentation scope
+type ScopeSpans struct {
+	Scope InstrumentationScope `json:"scope"`
+	Spans []*Span              `json:"spans"`
+}
+
+// Span is an operation of a trace. The trace and span IDs are hex encoded
+type Span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano uint64     `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64     `json:"endTimeUnixNano,string"`
+	Attributes        []KeyValue `json:"attributes"`
+	Status            Status     `json:"status"`
+}
+
+// Status is the outcome of the operation of a span
+type Status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
@@ -66,6 +66,15 @@ func newImplicitMetaPolicy(data []byte, managers map[string]*ManagerImpl) (*impl
 
 // Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy
 func (imp *implicitMetaPolicy) Evaluate(signatureSet []*cb.SignedData) error {
+	return imp.evaluate(signatureSet, nil)
+}
+
+// EvaluateWithTrace evaluates the signature set like Evaluate, recording which sub-policies were satisfied
+func (imp *implicitMetaPolicy) EvaluateWithTrace(signatureSet []*cb.SignedData, trace *PolicyTrace) error {
+	return trace.Record(imp.evaluate(signatureSet, trace))
+}
+
+func (imp *implicitMetaPolicy) evaluate(signatureSet []*cb.SignedData, trace *PolicyTrace) error {
 	logger.Debugf("This is an implicit meta policy, it will trigger other policy evaluations, whose failures may be benign")
 	remaining := imp.threshold
 
@@ -88,7 +97,13 @@ func (imp *implicitMetaPolicy) Evaluate(signatureSet []*cb.SignedData) error {
 	}()
 
 	for _, policy := range imp.subPolicies {
-		if policy.Evaluate(signatureSet) == nil {
+		var err error
+		if trace != nil {
+			err = evaluateWithTrace(policy, signatureSet, trace.NewSubPolicy(""))
+		} else {
+			err = policy.Evaluate(signatureSet)
+		}
+		if err == nil {
 			remaining--
 			if remaining == 0 {
 				return nil
@@ -91,7 +91,8 @@ func (cp *ComparablePrincipal) IsA(other *ComparablePrincipal) bool {
 		return sameOU && sameIssuer
 	}
 
-	// Check if we're both the same MSP Role
+	// Check if we're both the same MSP Role. Peers, orderers and the other roles
+	// are told apart by distinct NodeOUs, so different roles aren't considered to overlap
 	if this.role != nil && other.role != nil {
 		return this.role.Role == other.role.Role
 	}
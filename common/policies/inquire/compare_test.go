@@ -145,6 +145,18 @@ func TestIsA(t *testing.T) {
 	t.Run("OUs and Peers aren't the same", func(t *testing.T) {
 		assert.False(t, ou1.IsA(peer1))
 	})
+
+	t.Run("Orderers and admins are members, but not peers", func(t *testing.T) {
+		orderer1 := NewComparablePrincipal(role("Org1MSP", msp.MSPRole_ORDERER))
+		admin1 := NewComparablePrincipal(role("Org1MSP", msp.MSPRole_ADMIN))
+		assert.True(t, orderer1.IsA(member1))
+		assert.True(t, admin1.IsA(member1))
+		assert.True(t, orderer1.IsA(NewComparablePrincipal(role("Org1MSP", msp.MSPRole_ORDERER))))
+		assert.False(t, orderer1.IsA(peer1))
+		assert.False(t, admin1.IsA(peer1))
+		assert.False(t, orderer1.IsA(admin1))
+		assert.False(t, peer1.IsA(orderer1))
+	})
 }
 
 func TestIsFound(t *testing.T) {
@@ -206,6 +218,12 @@ func member(orgName string) *msp.MSPPrincipal {
 		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: orgName})}
 }
 
+func role(orgName string, roleType msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: roleType, MspIdentifier: orgName})}
+}
+
 func peer(orgName string) *msp.MSPPrincipal {
 	return &msp.MSPPrincipal{
 		PrincipalClassification: msp.MSPPrincipal_ROLE,
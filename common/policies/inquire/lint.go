@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inquire
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/graph"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// FindingType is the kind of mistake a Finding reports
+type FindingType string
+
+const (
+	// UnknownMSP means a principal refers to an MSP that isn't one of the channel's organizations
+	UnknownMSP FindingType = "UnknownMSP"
+	// InvalidPrincipal means a principal can't be parsed, or a rule refers to a principal that doesn't exist
+	InvalidPrincipal FindingType = "InvalidPrincipal"
+	// UnsatisfiableNOutOf means an NOutOf rule requires more sub-rules to be satisfied than can be satisfied
+	UnsatisfiableNOutOf FindingType = "UnsatisfiableNOutOf"
+	// RedundantBranch means a sub-rule of an OR rule doesn't affect the outcome of the policy,
+	// because some other sub-rule of the same OR rule is satisfied whenever it is satisfied
+	RedundantBranch FindingType = "RedundantBranch"
+)
+
+// Finding is a probable authoring mistake found in a policy
+type Finding struct {
+	Type FindingType
+	// Rule is the identifier of the rule the finding refers to.
+	// The root rule is identified by "0", and the i'th sub-rule
+	// of a rule identified by x is identified by x.i
+	Rule string
+	// Description describes the finding in a human readable manner
+	Description string
+}
+
+// String returns a string representation of this Finding
+func (f Finding) String() string {
+	return fmt.Sprintf("%s at rule %s: %s", f.Type, f.Rule, f.Description)
+}
+
+// LintPolicy inspects the given serialized SignaturePolicyEnvelope and returns
+// the authoring mistakes found in it, given the MSP IDs of the organizations of the channel.
+// If channelOrgs is empty, the MSP IDs the policy refers to aren't checked.
+// An error is returned only if the policy can't be parsed.
+func LintPolicy(policyBytes []byte, channelOrgs []string) ([]Finding, error) {
+	sigPol := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policyBytes, sigPol); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling policy")
+	}
+	if sigPol.Rule == nil {
+		return nil, errors.New("policy has no rule")
+	}
+
+	l := &linter{
+		principals: make([]*ComparablePrincipal, len(sigPol.Identities)),
+		orgs:       make(map[string]struct{}),
+	}
+	for i, principal := range sigPol.Identities {
+		l.principals[i] = NewComparablePrincipal(principal)
+	}
+	for _, org := range channelOrgs {
+		l.orgs[org] = struct{}{}
+	}
+
+	root := graph.NewTreeVertex(fmt.Sprintf("%d", 0), sigPol.Rule)
+	computePolicyTree(root)
+	l.lint(root)
+	return l.findings, nil
+}
+
+type linter struct {
+	principals []*ComparablePrincipal
+	orgs       map[string]struct{}
+	findings   []Finding
+}
+
+func (l *linter) report(findingType FindingType, v *graph.TreeVertex, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{
+		Type:        findingType,
+		Rule:        v.Id,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// lint reports the findings of the rule of the given vertex and all its sub-rules,
+// and returns whether the rule can be satisfied
+func (l *linter) lint(v *graph.TreeVertex) bool {
+	sigPol := v.Data.(*common.SignaturePolicy)
+	switch rule := sigPol.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		return l.lintSignedBy(v, rule.SignedBy)
+	case *common.SignaturePolicy_NOutOf_:
+		return l.lintNOutOf(v, rule.NOutOf)
+	default:
+		l.report(InvalidPrincipal, v, "rule is of unknown type %T", sigPol.Type)
+		return false
+	}
+}
+
+func (l *linter) lintSignedBy(v *graph.TreeVertex, index int32) bool {
+	if index < 0 || int(index) >= len(l.principals) {
+		l.report(InvalidPrincipal, v, "refers to principal %d but the policy has only %d principals", index, len(l.principals))
+		return false
+	}
+	principal := l.principals[index]
+	if principal == nil {
+		l.report(InvalidPrincipal, v, "principal %d is malformed or of an unsupported type", index)
+		return false
+	}
+	if len(l.orgs) == 0 {
+		return true
+	}
+	if _, exists := l.orgs[principal.mspID]; !exists {
+		l.report(UnknownMSP, v, "MSP %s isn't an organization of the channel", principal.mspID)
+		return false
+	}
+	return true
+}
+
+func (l *linter) lintNOutOf(v *graph.TreeVertex, rule *common.SignaturePolicy_NOutOf) bool {
+	satisfiable := 0
+	for _, u := range v.Descendants {
+		if l.lint(u) {
+			satisfiable++
+		}
+	}
+	if int(rule.N) > len(rule.Rules) {
+		l.report(UnsatisfiableNOutOf, v, "requires %d sub-rules to be satisfied but has only %d", rule.N, len(rule.Rules))
+		return false
+	}
+	if int(rule.N) > satisfiable {
+		l.report(UnsatisfiableNOutOf, v, "requires %d sub-rules to be satisfied but only %d can be satisfied", rule.N, satisfiable)
+		return false
+	}
+	// Sub-rules can only be redundant to each other if satisfying any one of them is enough
+	if rule.N == 1 {
+		l.lintRedundantBranches(v)
+	}
+	return true
+}
+
+// lintRedundantBranches reports sub-rules of the given OR rule that are either
+// identical to previous sub-rules, or are signatures of principals that are
+// special cases of principals of other sub-rules, such as a peer of an MSP alongside a member of the same MSP
+func (l *linter) lintRedundantBranches(v *graph.TreeVertex) {
+	for i, u := range v.Descendants {
+		for j, w := range v.Descendants {
+			if i == j {
+				continue
+			}
+			if j < i && l.equivalent(u, w) {
+				l.report(RedundantBranch, u, "is identical to rule %s", w.Id)
+				break
+			}
+			p1, p2 := l.signerOf(u), l.signerOf(w)
+			if p1 == nil || p2 == nil || (p1.IsA(p2) && p2.IsA(p1)) {
+				continue
+			}
+			if p1.IsA(p2) {
+				l.report(RedundantBranch, u, "every signer that satisfies it also satisfies rule %s", w.Id)
+				break
+			}
+		}
+	}
+}
+
+// signerOf returns the principal the rule of the given vertex requires a signature of,
+// or nil if the rule isn't a signature of a valid principal
+func (l *linter) signerOf(v *graph.TreeVertex) *ComparablePrincipal {
+	signedBy, isSignedBy := v.Data.(*common.SignaturePolicy).Type.(*common.SignaturePolicy_SignedBy)
+	if !isSignedBy || signedBy.SignedBy < 0 || int(signedBy.SignedBy) >= len(l.principals) {
+		return nil
+	}
+	return l.principals[signedBy.SignedBy]
+}
+
+// equivalent returns whether the rules of the given vertices are structurally identical,
+// and refer to identical principals
+func (l *linter) equivalent(v, u *graph.TreeVertex) bool {
+	if len(v.Descendants) != len(u.Descendants) || v.Threshold != u.Threshold {
+		return false
+	}
+	if v.IsLeaf() {
+		p1, p2 := l.signerOf(v), l.signerOf(u)
+		if p1 == nil || p2 == nil {
+			return false
+		}
+		return bytes.Equal(p1.principal.Principal, p2.principal.Principal) &&
+			p1.principal.PrincipalClassification == p2.principal.PrincipalClassification
+	}
+	for i := range v.Descendants {
+		if !l.equivalent(v.Descendants[i], u.Descendants[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inquire
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintPolicy(t *testing.T) {
+	channelOrgs := []string{"A", "B", "C"}
+
+	policyFromString := func(policy string) []byte {
+		sigPol, err := cauthdsl.FromString(policy)
+		assert.NoError(t, err)
+		return utils.MarshalOrPanic(sigPol)
+	}
+
+	tests := []struct {
+		name     string
+		policy   []byte
+		expected []Finding
+	}{
+		{
+			name:   "valid policy",
+			policy: policyFromString("OR(AND('A.member', 'B.member'), 'C.peer', AND('A.member', 'C.member'))"),
+		},
+		{
+			name:   "unknown MSP",
+			policy: policyFromString("AND('A.member', 'D.member')"),
+			expected: []Finding{
+				{Type: UnknownMSP, Rule: "0.1", Description: "MSP D isn't an organization of the channel"},
+				{Type: UnsatisfiableNOutOf, Rule: "0", Description: "requires 2 sub-rules to be satisfied but only 1 can be satisfied"},
+			},
+		},
+		{
+			name:   "unknown MSP in a satisfiable branch",
+			policy: policyFromString("OR('A.member', 'D.member')"),
+			expected: []Finding{
+				{Type: UnknownMSP, Rule: "0.1", Description: "MSP D isn't an organization of the channel"},
+			},
+		},
+		{
+			name: "N bigger than sub-rules",
+			policy: func() []byte {
+				// the parser rejects such policies, so raise the threshold after parsing
+				sigPol, err := cauthdsl.FromString("OutOf(2, 'A.member', 'B.member')")
+				assert.NoError(t, err)
+				sigPol.Rule.GetNOutOf().N = 3
+				return utils.MarshalOrPanic(sigPol)
+			}(),
+			expected: []Finding{
+				{Type: UnsatisfiableNOutOf, Rule: "0", Description: "requires 3 sub-rules to be satisfied but has only 2"},
+			},
+		},
+		{
+			name:   "identical branches",
+			policy: policyFromString("OR(AND('A.member', 'B.member'), 'C.member', AND('A.member', 'B.member'))"),
+			expected: []Finding{
+				{Type: RedundantBranch, Rule: "0.2", Description: "is identical to rule 0.0"},
+			},
+		},
+		{
+			name:   "role subsumed by member",
+			policy: policyFromString("OR('A.peer', 'A.member', 'B.admin')"),
+			expected: []Finding{
+				{Type: RedundantBranch, Rule: "0.0", Description: "every signer that satisfies it also satisfies rule 0.1"},
+			},
+		},
+		{
+			name:   "identical branches of an AND aren't redundant",
+			policy: policyFromString("AND('A.member', 'A.member')"),
+		},
+		{
+			name: "principal index out of bounds",
+			policy: utils.MarshalOrPanic(&common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.SignedBy(1),
+				Identities: []*msp.MSPPrincipal{{PrincipalClassification: msp.MSPPrincipal_ROLE}},
+			}),
+			expected: []Finding{
+				{Type: InvalidPrincipal, Rule: "0", Description: "refers to principal 1 but the policy has only 1 principals"},
+			},
+		},
+		{
+			name: "unsupported principal",
+			policy: utils.MarshalOrPanic(&common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.SignedBy(0),
+				Identities: []*msp.MSPPrincipal{{PrincipalClassification: msp.MSPPrincipal_IDENTITY}},
+			}),
+			expected: []Finding{
+				{Type: InvalidPrincipal, Rule: "0", Description: "principal 0 is malformed or of an unsupported type"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			findings, err := LintPolicy(test.policy, channelOrgs)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, findings)
+		})
+	}
+}
+
+func TestLintPolicyWithoutChannelOrgs(t *testing.T) {
+	sigPol, err := cauthdsl.FromString("AND('A.member', 'D.member')")
+	assert.NoError(t, err)
+	findings, err := LintPolicy(utils.MarshalOrPanic(sigPol), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestLintPolicyBadInput(t *testing.T) {
+	_, err := LintPolicy([]byte{1, 2, 3}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshaling policy")
+
+	_, err = LintPolicy(utils.MarshalOrPanic(&common.SignaturePolicyEnvelope{}), nil)
+	assert.EqualError(t, err, "policy has no rule")
+}
@@ -254,12 +254,28 @@ func (pl *policyLogger) Evaluate(signatureSet []*cb.SignedData) error {
 	}
 
 	err := pl.policy.Evaluate(signatureSet)
+	pl.logResult(err)
+	return err
+}
+
+func (pl *policyLogger) EvaluateWithTrace(signatureSet []*cb.SignedData, trace *PolicyTrace) error {
+	if logger.IsEnabledFor(logging.DEBUG) {
+		logger.Debugf("== Evaluating %T Policy %s with tracing ==", pl.policy, pl.policyName)
+		defer logger.Debugf("== Done Evaluating %T Policy %s", pl.policy, pl.policyName)
+	}
+
+	trace.Policy = pl.policyName
+	err := evaluateWithTrace(pl.policy, signatureSet, trace)
+	pl.logResult(err)
+	return err
+}
+
+func (pl *policyLogger) logResult(err error) {
 	if err != nil {
 		logger.Debugf("Signature set did not satisfy policy %s", pl.policyName)
 	} else {
 		logger.Debugf("Signature set satisfies policy %s", pl.policyName)
 	}
-	return err
 }
 
 // GetPolicy returns a policy and true if it was the policy requested, or false if it is the default reject policy
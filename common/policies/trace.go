@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+// TraceablePolicy is a Policy able to record how it was evaluated
+type TraceablePolicy interface {
+	Policy
+
+	// EvaluateWithTrace behaves like Evaluate, and additionally records
+	// the outcome of the evaluation into the given trace
+	EvaluateWithTrace(signatureSet []*cb.SignedData, trace *PolicyTrace) error
+}
+
+// PolicyTrace records how a policy, and the policies it is made of,
+// were evaluated against a signature set
+type PolicyTrace struct {
+	// Policy describes the evaluated policy, e.g. its path, a gate or a principal
+	Policy string
+	// Satisfied is true if the signature set satisfied the policy
+	Satisfied bool
+	// Reason explains why the policy was not satisfied
+	Reason string
+	// Signatures records how the signatures were matched against a principal
+	Signatures []*SignatureTrace
+	// SubPolicies holds the traces of the policies this policy evaluated
+	SubPolicies []*PolicyTrace
+}
+
+// SignatureTrace records how a signature was matched against a principal
+type SignatureTrace struct {
+	// Index is the position of the signature in the (de-duplicated) signature set
+	Index int
+	// Identity identifies the signer, if its identity could be deserialized
+	Identity string
+	// Matched is true if the signature satisfied the principal
+	Matched bool
+	// Reason explains why the signature did not satisfy the principal
+	Reason string
+}
+
+// NewSubPolicy appends the trace of a sub-policy to this trace and returns it
+func (pt *PolicyTrace) NewSubPolicy(policy string) *PolicyTrace {
+	sub := &PolicyTrace{Policy: policy}
+	pt.SubPolicies = append(pt.SubPolicies, sub)
+	return sub
+}
+
+// Record sets the outcome of the evaluation of the policy, and returns err
+func (pt *PolicyTrace) Record(err error) error {
+	pt.Satisfied = err == nil
+	if err != nil {
+		pt.Reason = err.Error()
+	}
+	return err
+}
+
+// String renders the trace as an indented tree
+func (pt *PolicyTrace) String() string {
+	var b bytes.Buffer
+	pt.write(&b, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (pt *PolicyTrace) write(b *bytes.Buffer, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if pt.Satisfied {
+		fmt.Fprintf(b, "%s%s: satisfied\n", indent, pt.Policy)
+	} else if pt.Reason != "" {
+		fmt.Fprintf(b, "%s%s: not satisfied (%s)\n", indent, pt.Policy, pt.Reason)
+	} else {
+		fmt.Fprintf(b, "%s%s: not satisfied\n", indent, pt.Policy)
+	}
+	for _, sig := range pt.Signatures {
+		signer := sig.Identity
+		if signer == "" {
+			signer = "unknown identity"
+		}
+		if sig.Matched {
+			fmt.Fprintf(b, "%s  signature %d by %s: matched\n", indent, sig.Index, signer)
+		} else {
+			fmt.Fprintf(b, "%s  signature %d by %s: %s\n", indent, sig.Index, signer, sig.Reason)
+		}
+	}
+	for _, sub := range pt.SubPolicies {
+		sub.write(b, depth+1)
+	}
+}
+
+// TraceError is returned by EvaluateWithTrace when the signature set does
+// not satisfy the policy, it carries the trace of the failed evaluation
+type TraceError struct {
+	Err   error
+	Trace *PolicyTrace
+}
+
+// Error returns the evaluation error followed by its trace
+func (e *TraceError) Error() string {
+	return fmt.Sprintf("%s, evaluation trace:\n%s", e.Err, e.Trace)
+}
+
+// EvaluateWithTrace evaluates the signature set against the policy like
+// Evaluate does, tracing the evaluation. If the policy is not satisfied,
+// the returned error is a *TraceError holding the trace.
+func EvaluateWithTrace(policy Policy, signatureSet []*cb.SignedData) error {
+	trace := &PolicyTrace{}
+	err := evaluateWithTrace(policy, signatureSet, trace)
+	if err != nil {
+		return &TraceError{Err: err, Trace: trace}
+	}
+	return nil
+}
+
+// evaluateWithTrace records the evaluation of the policy into the trace,
+// policies that are not traceable only record their outcome
+func evaluateWithTrace(policy Policy, signatureSet []*cb.SignedData, trace *PolicyTrace) error {
+	if trace.Policy == "" {
+		trace.Policy = fmt.Sprintf("%T", policy)
+	}
+	if tp, ok := policy.(TraceablePolicy); ok {
+		return tp.EvaluateWithTrace(signatureSet, trace)
+	}
+	return trace.Record(policy.Evaluate(signatureSet))
+}
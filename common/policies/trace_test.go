@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"errors"
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failPolicy struct{}
+
+func (fp failPolicy) Evaluate(signedData []*cb.SignedData) error {
+	return errors.New("fail")
+}
+
+func TestEvaluateWithTrace(t *testing.T) {
+	managers := map[string]*ManagerImpl{
+		"Org1": {path: "Org1", policies: map[string]Policy{TestPolicyName: acceptPolicy{}}},
+		"Org2": {path: "Org2", policies: map[string]Policy{TestPolicyName: failPolicy{}}},
+		"Org3": {path: "Org3", policies: map[string]Policy{}},
+	}
+	imp, err := newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ALL,
+		SubPolicy: TestPolicyName,
+	}), managers)
+	assert.NoError(t, err)
+
+	err = EvaluateWithTrace(imp, nil)
+	assert.Error(t, err)
+	traceErr, ok := err.(*TraceError)
+	assert.True(t, ok, "Should have returned a trace")
+	assert.EqualError(t, traceErr.Err, "Failed to reach implicit threshold of 3 sub-policies, required 2 remaining")
+
+	trace := traceErr.Trace
+	assert.Equal(t, "*policies.implicitMetaPolicy", trace.Policy)
+	assert.False(t, trace.Satisfied)
+	assert.Len(t, trace.SubPolicies, 3)
+	outcomes := map[string]*PolicyTrace{}
+	for _, sub := range trace.SubPolicies {
+		outcomes[sub.Policy] = sub
+	}
+	assert.True(t, outcomes["/Org1/"+TestPolicyName].Satisfied)
+	assert.Equal(t, "fail", outcomes["/Org2/"+TestPolicyName].Reason)
+	assert.Equal(t, "No such policy: 'TestPolicyName'", outcomes["policies.rejectPolicy"].Reason)
+
+	assert.Contains(t, err.Error(), "evaluation trace:\n*policies.implicitMetaPolicy: not satisfied (Failed to reach implicit threshold")
+	assert.Contains(t, err.Error(), "\n  /Org1/TestPolicyName: satisfied")
+	assert.Contains(t, err.Error(), "\n  /Org2/TestPolicyName: not satisfied (fail)")
+
+	delete(managers, "Org2")
+	delete(managers, "Org3")
+	imp, err = newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ALL,
+		SubPolicy: TestPolicyName,
+	}), managers)
+	assert.NoError(t, err)
+	assert.NoError(t, EvaluateWithTrace(imp, nil))
+}
+
+func TestPolicyTraceString(t *testing.T) {
+	trace := &PolicyTrace{Policy: "OutOf(1, 1 policies)", Reason: "0 of 1 required policies satisfied"}
+	principal := trace.NewSubPolicy("'Org1MSP.peer'")
+	principal.Reason = "no signature matched the principal"
+	principal.Signatures = []*SignatureTrace{
+		{Index: 0, Identity: "Org1MSP (id)", Reason: "The identity is not a peer"},
+		{Index: 1, Reason: "deserialization failure"},
+	}
+
+	assert.Equal(t, `OutOf(1, 1 policies): not satisfied (0 of 1 required policies satisfied)
+  'Org1MSP.peer': not satisfied (no signature matched the principal)
+    signature 0 by Org1MSP (id): The identity is not a peer
+    signature 1 by unknown identity: deserialization failure`, trace.String())
+}
@@ -227,6 +227,9 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create orderer org")
 		}
+		if len(org.OrdererEndpoints) > 0 {
+			addValue(ordererGroup.Groups[org.Name], channelconfig.EndpointsValue(org.OrdererEndpoints), channelconfig.AdminsPolicyKey)
+		}
 	}
 
 	ordererGroup.ModPolicy = channelconfig.AdminsPolicyKey
@@ -284,6 +284,18 @@ func TestNewOrdererGroup(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, group)
 	})
+
+	t.Run("Orderer org endpoints", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+		org := config.Orderer.Organizations[0]
+		org.OrdererEndpoints = []string{"orderer0:7050", "orderer1:7050"}
+		group, err := NewOrdererGroup(config.Orderer)
+		assert.NoError(t, err)
+		endpoints := &cb.OrdererAddresses{}
+		err = proto.Unmarshal(group.Groups[org.Name].Values[channelconfig.EndpointsKey].Value, endpoints)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orderer0:7050", "orderer1:7050"}, endpoints.Addresses)
+	})
 }
 
 func TestBootstrapper(t *testing.T) {
@@ -152,6 +152,10 @@ type Organization struct {
 	// for both orderers and applications.
 	AnchorPeers []*AnchorPeer `yaml:"AnchorPeers"`
 
+	// OrdererEndpoints are the endpoints of the orderers of an orderer organization,
+	// in the form of host:port
+	OrdererEndpoints []string `yaml:"OrdererEndpoints"`
+
 	// AdminPrincipal is deprecated and may be removed in a future release
 	// it was used for modifying the default policy generation, but policies
 	// may now be specified explicitly so it is redundant and unnecessary
@@ -213,6 +217,12 @@ var genesisDefaults = TopLevel{
 // Note, for environment overrides to work properly within a profile, Load
 // should be used instead.
 func LoadTopLevel(configPaths ...string) *TopLevel {
+	return LoadTopLevelWithOverrides(Overrides{}, configPaths...)
+}
+
+// LoadTopLevelWithOverrides behaves like LoadTopLevel, additionally applying
+// the given templates and overlays to configtx.yaml.
+func LoadTopLevelWithOverrides(overrides Overrides, configPaths ...string) *TopLevel {
 	config := viper.New()
 	if len(configPaths) > 0 {
 		for _, p := range configPaths {
@@ -230,7 +240,7 @@ func LoadTopLevel(configPaths ...string) *TopLevel {
 	replacer := strings.NewReplacer(".", "_")
 	config.SetEnvKeyReplacer(replacer)
 
-	err := config.ReadInConfig()
+	err := readConfig(config, overrides)
 	if err != nil {
 		logger.Panic("Error reading configuration: ", err)
 	}
@@ -253,6 +263,12 @@ func LoadTopLevel(configPaths ...string) *TopLevel {
 // a given profile. Config paths may optionally be provided and will be used
 // in place of the FABRIC_CFG_PATH env variable.
 func Load(profile string, configPaths ...string) *Profile {
+	return LoadWithOverrides(profile, Overrides{}, configPaths...)
+}
+
+// LoadWithOverrides behaves like Load, additionally applying the given
+// templates and overlays to configtx.yaml.
+func LoadWithOverrides(profile string, overrides Overrides, configPaths ...string) *Profile {
 	config := viper.New()
 	if len(configPaths) > 0 {
 		for _, p := range configPaths {
@@ -272,7 +288,7 @@ func Load(profile string, configPaths ...string) *Profile {
 	replacer := strings.NewReplacer(strings.ToUpper(fmt.Sprintf("profiles.%s.", profile)), "", ".", "_")
 	config.SetEnvKeyReplacer(replacer)
 
-	err := config.ReadInConfig()
+	err := readConfig(config, overrides)
 	if err != nil {
 		logger.Panic("Error reading configuration: ", err)
 	}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localconfig
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// templatesKey is the key under which the templates are nested while
+// parsing, it never reaches the configuration structs
+const templatesKey = "Templates"
+
+// Overrides lists the files configtx.yaml is assembled from, in addition to
+// configtx.yaml itself.
+type Overrides struct {
+	// Templates are files, or directories of .yaml files, defining YAML
+	// anchors (typically organization definitions) which configtx.yaml and
+	// the overlays may reference, e.g. a template containing
+	//
+	//   Org3: &Org3
+	//       Name: Org3MSP
+	//       ...
+	//
+	// allows configtx.yaml to list *Org3 among the organizations of a profile.
+	// Relative paths within templates, such as MSPDir, are relative to the
+	// directory of configtx.yaml.
+	Templates []string
+
+	// Overlays are files which are merged, in order, on top of configtx.yaml.
+	// Mappings are merged key by key while any other value, including lists,
+	// replaces the value it overlays, e.g. an overlay containing
+	//
+	//   Profiles:
+	//       SampleChannel:
+	//           Application:
+	//               Organizations:
+	//                   - *Org3
+	//
+	// only replaces the application organizations of the SampleChannel profile.
+	Overlays []string
+}
+
+func (o Overrides) empty() bool {
+	return len(o.Templates) == 0 && len(o.Overlays) == 0
+}
+
+// readConfig reads the configuration file found by config, applying the
+// templates and overlays
+func readConfig(config *viper.Viper, overrides Overrides) error {
+	if overrides.empty() {
+		return config.ReadInConfig()
+	}
+
+	// ReadInConfig locates the configuration file before parsing it, the
+	// file might reference template anchors, so it is parsed below instead
+	config.ReadInConfig()
+	configFile := config.ConfigFileUsed()
+	if configFile == "" {
+		return errors.New("could not find the configuration file")
+	}
+
+	templates, err := readTemplates(overrides.Templates)
+	if err != nil {
+		return err
+	}
+
+	merged, err := parseWithTemplates(configFile, templates)
+	if err != nil {
+		return err
+	}
+	for _, overlay := range overrides.Overlays {
+		overlayed, err := parseWithTemplates(overlay, templates)
+		if err != nil {
+			return err
+		}
+		merged = mergeYAML(merged, overlayed)
+	}
+	delete(merged.(map[interface{}]interface{}), templatesKey)
+
+	raw, err := yaml.Marshal(merged)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the merged configuration")
+	}
+	return config.ReadConfig(bytes.NewReader(raw))
+}
+
+// readTemplates returns the templates nested under the templates key, so
+// that the anchors they define can be referenced by the document they are
+// prepended to
+func readTemplates(paths []string) ([]byte, error) {
+	var files []string
+	for _, path := range paths {
+		matches, err := filepath.Glob(filepath.Join(path, "*.yaml"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list templates in %s", path)
+		}
+		if len(matches) == 0 {
+			matches = []string{path}
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	var buf bytes.Buffer
+	if len(files) > 0 {
+		buf.WriteString(templatesKey + ":\n")
+	}
+	for i, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read template %s", file)
+		}
+		buf.WriteString("    \"" + strconv.Itoa(i) + "\":\n")
+		for _, line := range strings.Split(string(raw), "\n") {
+			if strings.TrimSpace(line) == "---" {
+				continue
+			}
+			buf.WriteString("        " + line + "\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func parseWithTemplates(file string, templates []byte) (interface{}, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", file)
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(append(append([]byte{}, templates...), raw...), &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", file)
+	}
+	if _, ok := doc.(map[interface{}]interface{}); !ok {
+		return nil, errors.Errorf("%s does not contain a mapping", file)
+	}
+	return doc, nil
+}
+
+// mergeYAML merges overlay on top of base, mappings are merged key by key
+// while any other overlay value replaces the base value
+func mergeYAML(base, overlay interface{}) interface{} {
+	baseMap, ok := base.(map[interface{}]interface{})
+	if !ok {
+		return overlay
+	}
+	overlayMap, ok := overlay.(map[interface{}]interface{})
+	if !ok {
+		return overlay
+	}
+
+	merged := make(map[interface{}]interface{}, len(baseMap))
+	for key, value := range baseMap {
+		merged[key] = value
+	}
+	for key, value := range overlayMap {
+		if key == templatesKey {
+			continue
+		}
+		if baseValue, ok := merged[key]; ok {
+			merged[key] = mergeYAML(baseValue, value)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigtx = `
+Organizations:
+    - *Org1
+    - *Org2
+
+Profiles:
+    TwoOrgsChannel:
+        Consortium: SampleConsortium
+        Application:
+            Organizations:
+                - *Org1
+            Policies:
+                Readers:
+                    Type: ImplicitMeta
+                    Rule: "ANY Readers"
+`
+
+const testOrg1Template = `---
+Org1: &Org1
+    Name: Org1
+    ID: Org1MSP
+    MSPDir: msp/org1
+`
+
+const testOrg2Template = `Org2: &Org2
+    Name: Org2
+    ID: Org2MSP
+    MSPDir: msp/org2
+    AnchorPeers:
+        - Host: peer0.org2.example.com
+          Port: 7051
+`
+
+const testOverlay = `
+Profiles:
+    TwoOrgsChannel:
+        Application:
+            Organizations:
+                - *Org1
+                - *Org2
+`
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestLoadWithOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configtxgen-overrides")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestFiles(t, dir, map[string]string{
+		"configtx.yaml":       testConfigtx,
+		"templates/org1.yaml": testOrg1Template,
+		"templates/org2.yaml": testOrg2Template,
+		"overlay.yaml":        testOverlay,
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		overrides := Overrides{Templates: []string{filepath.Join(dir, "templates")}}
+		profile := LoadWithOverrides("TwoOrgsChannel", overrides, dir)
+		require.Len(t, profile.Application.Organizations, 1)
+		org := profile.Application.Organizations[0]
+		assert.Equal(t, "Org1MSP", org.ID)
+		assert.Equal(t, filepath.Join(dir, "msp/org1"), org.MSPDir)
+		assert.Equal(t, "ImplicitMeta", profile.Application.Policies["Readers"].Type)
+
+		topLevel := LoadTopLevelWithOverrides(overrides, dir)
+		require.Len(t, topLevel.Organizations, 2)
+		assert.Equal(t, "Org2MSP", topLevel.Organizations[1].ID)
+		assert.Equal(t, 7051, topLevel.Organizations[1].AnchorPeers[0].Port)
+	})
+
+	t.Run("overlays", func(t *testing.T) {
+		overrides := Overrides{
+			Templates: []string{filepath.Join(dir, "templates/org1.yaml"), filepath.Join(dir, "templates/org2.yaml")},
+			Overlays:  []string{filepath.Join(dir, "overlay.yaml")},
+		}
+		profile := LoadWithOverrides("TwoOrgsChannel", overrides, dir)
+		require.Len(t, profile.Application.Organizations, 2)
+		assert.Equal(t, "Org2MSP", profile.Application.Organizations[1].ID)
+		// values the overlay does not mention are preserved
+		assert.Equal(t, "SampleConsortium", profile.Consortium)
+		assert.Equal(t, "ANY Readers", profile.Application.Policies["Readers"].Rule)
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		overrides := Overrides{Templates: []string{filepath.Join(dir, "templates/org1.yaml")}}
+		assert.Panics(t, func() { LoadWithOverrides("TwoOrgsChannel", overrides, dir) })
+		overrides = Overrides{Templates: []string{filepath.Join(dir, "missing.yaml")}}
+		assert.Panics(t, func() { LoadTopLevelWithOverrides(overrides, dir) })
+	})
+}
+
+func TestMergeYAML(t *testing.T) {
+	base := map[interface{}]interface{}{
+		"a": map[interface{}]interface{}{"b": 1, "c": []interface{}{1, 2}},
+		"d": "e",
+	}
+	overlay := map[interface{}]interface{}{
+		"a":          map[interface{}]interface{}{"c": []interface{}{3}, "f": true},
+		templatesKey: "ignored",
+	}
+
+	assert.Equal(t, map[interface{}]interface{}{
+		"a": map[interface{}]interface{}{"b": 1, "c": []interface{}{3}, "f": true},
+		"d": "e",
+	}, mergeYAML(base, overlay))
+}
@@ -206,7 +206,8 @@ func doPrintOrg(t *genesisconfig.TopLevel, printOrg string) error {
 }
 
 func main() {
-	var outputBlock, outputChannelCreateTx, profile, configPath, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg, printOrg string
+	var outputBlock, outputChannelCreateTx, profile, configPath, channelID, inspectBlock, inspectChannelCreateTx, outputAnchorPeersUpdate, asOrg, printOrg, templates, overlays string
+	var validateOnly bool
 
 	flag.StringVar(&outputBlock, "outputBlock", "", "The path to write the genesis block to (if set)")
 	flag.StringVar(&channelID, "channelID", "", "The channel ID to use in the configtx")
@@ -218,6 +219,9 @@ func main() {
 	flag.StringVar(&outputAnchorPeersUpdate, "outputAnchorPeersUpdate", "", "Creates an config update to update an anchor peer (works only with the default channel creation, and only for the first update)")
 	flag.StringVar(&asOrg, "asOrg", "", "Performs the config generation as a particular organization (by name), only including values in the write set that org (likely) has privilege to set")
 	flag.StringVar(&printOrg, "printOrg", "", "Prints the definition of an organization as JSON. (useful for adding an org to a channel manually)")
+	flag.StringVar(&templates, "templates", "", "Comma separated list of template files, or directories of .yaml template files, whose YAML anchors (e.g. organization definitions) configtx.yaml and the overlays may reference")
+	flag.StringVar(&overlays, "overlays", "", "Comma separated list of files merged, in order, on top of configtx.yaml (e.g. to adjust a profile)")
+	flag.BoolVar(&validateOnly, "validateOnly", false, "Checks that the policies of the profile reference existing definitions, without generating any output")
 
 	if channelID == "" {
 		channelID = genesisconfig.TestChainID
@@ -239,7 +243,8 @@ func main() {
 	// don't need to panic when running via command line
 	defer func() {
 		if err := recover(); err != nil {
-			if strings.Contains(fmt.Sprint(err), "Error reading configuration: Unsupported Config Type") {
+			if strings.Contains(fmt.Sprint(err), "Error reading configuration: Unsupported Config Type") ||
+				strings.Contains(fmt.Sprint(err), "Error reading configuration: could not find the configuration file") {
 				logger.Error("Could not find configtx.yaml. " +
 					"Please make sure that FABRIC_CFG_PATH or --configPath is set to a path " +
 					"which contains configtx.yaml")
@@ -257,19 +262,31 @@ func main() {
 
 	logger.Info("Loading configuration")
 	factory.InitFactories(nil)
+	overrides := genesisconfig.Overrides{
+		Templates: splitList(templates),
+		Overlays:  splitList(overlays),
+	}
 	var profileConfig *genesisconfig.Profile
-	if outputBlock != "" || outputChannelCreateTx != "" || outputAnchorPeersUpdate != "" {
+	if outputBlock != "" || outputChannelCreateTx != "" || outputAnchorPeersUpdate != "" || validateOnly {
 		if configPath != "" {
-			profileConfig = genesisconfig.Load(profile, configPath)
+			profileConfig = genesisconfig.LoadWithOverrides(profile, overrides, configPath)
 		} else {
-			profileConfig = genesisconfig.Load(profile)
+			profileConfig = genesisconfig.LoadWithOverrides(profile, overrides)
 		}
 	}
+
+	if validateOnly {
+		if err := doValidateOnly(profileConfig); err != nil {
+			logger.Fatalf("Error on validateOnly: %s", err)
+		}
+		return
+	}
+
 	var topLevelConfig *genesisconfig.TopLevel
 	if configPath != "" {
-		topLevelConfig = genesisconfig.LoadTopLevel(configPath)
+		topLevelConfig = genesisconfig.LoadTopLevelWithOverrides(overrides, configPath)
 	} else {
-		topLevelConfig = genesisconfig.LoadTopLevel()
+		topLevelConfig = genesisconfig.LoadTopLevelWithOverrides(overrides)
 	}
 
 	if outputBlock != "" {
@@ -309,6 +326,17 @@ func main() {
 	}
 }
 
+// splitList splits a comma separated list, ignoring empty elements
+func splitList(list string) []string {
+	var elements []string
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
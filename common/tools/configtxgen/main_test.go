@@ -25,6 +25,7 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/core/config/configtest"
 
@@ -201,3 +202,45 @@ func TestPrintOrg(t *testing.T) {
 	assert.Error(t, err, "Fake org")
 	assert.Regexp(t, "bad org definition", err.Error())
 }
+
+func TestValidateOnly(t *testing.T) {
+	factory.InitFactories(nil)
+
+	config := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+	assert.NoError(t, doValidateOnly(config), "Good application channel profile")
+	config = configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	assert.NoError(t, doValidateOnly(config), "Good orderer system channel profile")
+
+	v := func(config *genesisconfig.Profile) []string {
+		group, err := encoder.NewChannelGroup(config)
+		assert.NoError(t, err)
+		return (&referenceValidator{root: group, mspIDs: map[string]struct{}{}}).validate()
+	}
+
+	config = configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	config.Orderer.Policies["Readers"] = &genesisconfig.Policy{Type: "ImplicitMeta", Rule: "ANY Observers"}
+	config.Orderer.Organizations[0].Policies["Writers"] = &genesisconfig.Policy{Type: "Signature", Rule: "OR('SampleOrg.member', 'UnknownOrg.peer')"}
+	assert.Equal(t, []string{
+		"policy /Channel/Orderer/Readers references the sub-policy Observers, which /Channel/Orderer/SampleOrg does not define",
+		"policy /Channel/Orderer/SampleOrg/Writers references the MSP UnknownOrg, which no organization defines",
+	}, v(config))
+
+	config = configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+	config.Application.ACLs["peer/Propose"] = "/Channel/Application/Missing"
+	config.Application.ACLs["qscc/GetChainInfo"] = "Readers"
+	config.Application.ACLs["qscc/GetBlockByNumber"] = "/Channel/Readers"
+	config.Application.ACLs["cscc/GetConfigBlock"] = "Peers"
+	err := doValidateOnly(config)
+	assert.Error(t, err)
+	assert.Equal(t, "found 2 invalid references in the profile", err.Error())
+
+	config.Orderer = configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile).Orderer
+	assert.Equal(t, []string{
+		"ACL for cscc/GetConfigBlock references the policy /Channel/Application/Peers, which does not exist",
+		"ACL for peer/Propose references the policy /Channel/Application/Missing, which does not exist",
+	}, v(config))
+
+	config.Orderer = nil
+	config.Application = nil
+	assert.Error(t, doValidateOnly(config), "Profile without orderer or application")
+}
@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+func doValidateOnly(conf *genesisconfig.Profile) error {
+	logger.Info("Validating the profile")
+
+	var channelGroup *cb.ConfigGroup
+	// application channel profiles only define the application group, the
+	// rest of the channel configuration comes from the ordering system channel
+	partial := conf.Orderer == nil
+	if partial {
+		if conf.Application == nil {
+			return errors.New("profile defines neither an Orderer nor an Application section")
+		}
+		ag, err := encoder.NewApplicationGroup(conf.Application)
+		if err != nil {
+			return errors.WithMessage(err, "could not create application group")
+		}
+		channelGroup = &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{channelconfig.ApplicationGroupKey: ag},
+		}
+	} else {
+		var err error
+		channelGroup, err = encoder.NewChannelGroup(conf)
+		if err != nil {
+			return errors.WithMessage(err, "could not create channel group")
+		}
+	}
+
+	v := &referenceValidator{
+		root:    channelGroup,
+		partial: partial,
+		mspIDs:  map[string]struct{}{},
+	}
+	problems := v.validate()
+	for _, problem := range problems {
+		logger.Error(problem)
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("found %d invalid references in the profile", len(problems))
+	}
+	logger.Info("The profile is valid")
+	return nil
+}
+
+// referenceValidator checks that the policies of a channel configuration
+// reference existing definitions
+type referenceValidator struct {
+	root     *cb.ConfigGroup
+	partial  bool
+	mspIDs   map[string]struct{}
+	problems []string
+}
+
+func (v *referenceValidator) validate() []string {
+	v.collectMSPIDs(v.root)
+	v.validateGroup(v.root, policies.PathSeparator+channelconfig.ChannelGroupKey)
+	sort.Strings(v.problems)
+	return v.problems
+}
+
+func (v *referenceValidator) report(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *referenceValidator) collectMSPIDs(group *cb.ConfigGroup) {
+	if value, ok := group.Values[channelconfig.MSPKey]; ok {
+		mspConfig := &mspprotos.MSPConfig{}
+		if err := proto.Unmarshal(value.Value, mspConfig); err == nil {
+			switch msp.ProviderType(mspConfig.Type) {
+			case msp.FABRIC:
+				fabricConfig := &mspprotos.FabricMSPConfig{}
+				if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err == nil {
+					v.mspIDs[fabricConfig.Name] = struct{}{}
+				}
+			case msp.IDEMIX:
+				idemixConfig := &mspprotos.IdemixMSPConfig{}
+				if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err == nil {
+					v.mspIDs[idemixConfig.Name] = struct{}{}
+				}
+			}
+		}
+	}
+	for _, subGroup := range group.Groups {
+		v.collectMSPIDs(subGroup)
+	}
+}
+
+func (v *referenceValidator) validateGroup(group *cb.ConfigGroup, path string) {
+	for name, configPolicy := range group.Policies {
+		v.validatePolicy(group, path, name, configPolicy.Policy)
+	}
+
+	if value, ok := group.Values[channelconfig.ACLsKey]; ok {
+		acls := &pb.ACLs{}
+		if err := proto.Unmarshal(value.Value, acls); err != nil {
+			v.report("%s/%s is malformed: %s", path, channelconfig.ACLsKey, err)
+		} else {
+			for resource, acl := range acls.Acls {
+				v.validateACL(path, resource, acl.PolicyRef)
+			}
+		}
+	}
+
+	for name, subGroup := range group.Groups {
+		v.validateGroup(subGroup, path+policies.PathSeparator+name)
+	}
+}
+
+func (v *referenceValidator) validatePolicy(group *cb.ConfigGroup, groupPath, name string, policy *cb.Policy) {
+	path := groupPath + policies.PathSeparator + name
+	if policy == nil {
+		v.report("policy %s is empty", path)
+		return
+	}
+
+	switch cb.Policy_PolicyType(policy.Type) {
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(policy.Value, imp); err != nil {
+			v.report("policy %s is malformed: %s", path, err)
+			return
+		}
+		for subGroupName, subGroup := range group.Groups {
+			if group == v.root && subGroupName == channelconfig.ConsortiumsGroupKey {
+				// the consortiums group of the ordering system channel only
+				// defines its Admins policy, the orderer organizations are
+				// relied upon to satisfy the other channel policies
+				continue
+			}
+			if _, ok := subGroup.Policies[imp.SubPolicy]; !ok {
+				v.report("policy %s references the sub-policy %s, which %s/%s does not define", path, imp.SubPolicy, groupPath, subGroupName)
+			}
+		}
+	case cb.Policy_SIGNATURE:
+		envelope := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy.Value, envelope); err != nil {
+			v.report("policy %s is malformed: %s", path, err)
+			return
+		}
+		for _, principal := range envelope.Identities {
+			mspID := principalMSPID(principal)
+			if mspID == "" {
+				continue
+			}
+			if _, ok := v.mspIDs[mspID]; !ok {
+				v.report("policy %s references the MSP %s, which no organization defines", path, mspID)
+			}
+		}
+	}
+}
+
+func (v *referenceValidator) validateACL(path, resource, policyRef string) {
+	if !strings.HasPrefix(policyRef, policies.PathSeparator) {
+		policyRef = path + policies.PathSeparator + policyRef
+	}
+
+	elements := strings.Split(strings.TrimPrefix(policyRef, policies.PathSeparator), policies.PathSeparator)
+	if len(elements) < 2 || elements[0] != channelconfig.ChannelGroupKey {
+		v.report("ACL for %s references the policy %s, which is not a channel policy", resource, policyRef)
+		return
+	}
+
+	group := v.root
+	for _, name := range elements[1 : len(elements)-1] {
+		subGroup, ok := group.Groups[name]
+		if !ok {
+			if v.partial {
+				// defined by the ordering system channel
+				return
+			}
+			v.report("ACL for %s references the policy %s, which does not exist", resource, policyRef)
+			return
+		}
+		group = subGroup
+	}
+	if _, ok := group.Policies[elements[len(elements)-1]]; !ok {
+		if v.partial && group == v.root {
+			return
+		}
+		v.report("ACL for %s references the policy %s, which does not exist", resource, policyRef)
+	}
+}
+
+// principalMSPID returns the identifier of the MSP the principal refers to,
+// or the empty string if it does not refer to one
+func principalMSPID(principal *mspprotos.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mspprotos.MSPPrincipal_ROLE:
+		role := &mspprotos.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err == nil {
+			return role.MspIdentifier
+		}
+	case mspprotos.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspprotos.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err == nil {
+			return ou.MspIdentifier
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BlockType designates marshaled config blocks as inputs
+	BlockType = "common.Block"
+	// ConfigType designates marshaled configs as inputs
+	ConfigType = "common.Config"
+)
+
+// UnmarshalConfig unmarshals a config, or extracts it from a config block,
+// depending on the given input type
+func UnmarshalConfig(inputType string, raw []byte) (*cb.Config, error) {
+	switch inputType {
+	case ConfigType:
+		config := &cb.Config{}
+		if err := proto.Unmarshal(raw, config); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling config")
+		}
+		return config, nil
+	case BlockType:
+		block := &cb.Block{}
+		if err := proto.Unmarshal(raw, block); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling block")
+		}
+		return ConfigFromBlock(block)
+	default:
+		return nil, errors.Errorf("unknown input type %s, expected %s or %s", inputType, BlockType, ConfigType)
+	}
+}
+
+// ConfigFromBlock extracts the config from a config block
+func ConfigFromBlock(block *cb.Block) (*cb.Config, error) {
+	envelope, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling payload")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("block payload has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling channel header")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block is not a config block, its transaction is of type %d", chdr.Type)
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling config envelope")
+	}
+	if configEnvelope.Config == nil {
+		return nil, errors.New("config envelope has no config")
+	}
+	return configEnvelope.Config, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// Change is a difference between two configurations, at the level of the
+// fields of their JSON representation
+type Change struct {
+	// Path locates the field, groups are designated by their names only,
+	// e.g. /Channel/Application/Org1MSP/values/AnchorPeers
+	Path string
+	// Original is the original value of the field, nil if it was added
+	Original interface{}
+	// Updated is the updated value of the field, nil if it was removed
+	Updated interface{}
+}
+
+// String returns the change in a human readable form, prefixed with
+// '+' for additions, '-' for removals and '~' for modifications
+func (c *Change) String() string {
+	switch {
+	case c.Original == nil:
+		return fmt.Sprintf("+ %s: %s", c.Path, compactJSON(c.Updated))
+	case c.Updated == nil:
+		return fmt.Sprintf("- %s: %s", c.Path, compactJSON(c.Original))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, compactJSON(c.Original), compactJSON(c.Updated))
+	}
+}
+
+// Compute returns the changes which transition the original configuration
+// into the updated one, sorted by path
+func Compute(original, updated *cb.Config) ([]*Change, error) {
+	originalDoc, err := toJSON(original)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not decode original config")
+	}
+	updatedDoc, err := toJSON(updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not decode updated config")
+	}
+
+	var changes []*Change
+	compare(nil, originalDoc, updatedDoc, &changes)
+	return changes, nil
+}
+
+// Format returns the changes in a human readable form, one per line
+func Format(changes []*Change) string {
+	var b bytes.Buffer
+	for _, change := range changes {
+		b.WriteString(change.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func toJSON(config *cb.Config) (interface{}, error) {
+	var buf bytes.Buffer
+	if err := protolator.DeepMarshalJSON(&buf, config); err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func compare(path []string, original, updated interface{}, changes *[]*Change) {
+	originalMap, originalIsMap := original.(map[string]interface{})
+	updatedMap, updatedIsMap := updated.(map[string]interface{})
+	if originalIsMap && updatedIsMap {
+		keys := map[string]struct{}{}
+		for key := range originalMap {
+			keys[key] = struct{}{}
+		}
+		for key := range updatedMap {
+			keys[key] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, key := range sortedKeys {
+			compare(append(path, key), originalMap[key], updatedMap[key], changes)
+		}
+		return
+	}
+
+	originalSlice, originalIsSlice := original.([]interface{})
+	updatedSlice, updatedIsSlice := updated.([]interface{})
+	if originalIsSlice && updatedIsSlice {
+		for i := 0; i < len(originalSlice) || i < len(updatedSlice); i++ {
+			var originalElement, updatedElement interface{}
+			if i < len(originalSlice) {
+				originalElement = originalSlice[i]
+			}
+			if i < len(updatedSlice) {
+				updatedElement = updatedSlice[i]
+			}
+			compare(append(path, strconv.Itoa(i)), originalElement, updatedElement, changes)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(original, updated) {
+		return
+	}
+	*changes = append(*changes, &Change{
+		Path:     pathString(path),
+		Original: original,
+		Updated:  updated,
+	})
+}
+
+// pathString renders the path of a field, eliding the 'groups' elements and
+// naming the channel group after its configuration path
+func pathString(path []string) string {
+	var elements []string
+	for i, element := range path {
+		switch {
+		case i == 0 && element == "channel_group":
+			elements = append(elements, "Channel")
+		case element == "groups" && i+1 < len(path):
+			continue
+		default:
+			elements = append(elements, element)
+		}
+	}
+	return "/" + strings.Join(elements, "/")
+}
+
+func compactJSON(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig(modPolicy string, maxMessageCount uint32) *cb.Config {
+	return &cb.Config{
+		Sequence: 3,
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: modPolicy,
+			Values: map[string]*cb.ConfigValue{
+				"HashingAlgorithm": {
+					ModPolicy: "Admins",
+					Value:     utils.MarshalOrPanic(&cb.HashingAlgorithm{Name: "SHA256"}),
+				},
+			},
+			Groups: map[string]*cb.ConfigGroup{
+				"Orderer": {
+					Version:   1,
+					ModPolicy: "Admins",
+					Values: map[string]*cb.ConfigValue{
+						"BatchSize": {
+							ModPolicy: "Admins",
+							Value:     utils.MarshalOrPanic(&ab.BatchSize{MaxMessageCount: maxMessageCount}),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	original := testConfig("Admins", 10)
+	updated := testConfig("Writers", 20)
+	delete(updated.ChannelGroup.Values, "HashingAlgorithm")
+	updated.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"] = &cb.ConfigValue{
+		ModPolicy: "Admins",
+		Value:     utils.MarshalOrPanic(&ab.BatchTimeout{Timeout: "2s"}),
+	}
+
+	changes, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, `~ /Channel/Orderer/values/BatchSize/value/max_message_count: 10 -> 20
++ /Channel/Orderer/values/BatchTimeout: {"mod_policy":"Admins","value":{"timeout":"2s"},"version":"0"}
+~ /Channel/mod_policy: "Admins" -> "Writers"
+- /Channel/values/HashingAlgorithm: {"mod_policy":"Admins","value":{"name":"SHA256"},"version":"0"}
+`, Format(changes))
+
+	changes, err = Compute(original, testConfig("Admins", 10))
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	original.ChannelGroup.Values["HashingAlgorithm"].Value = []byte("garbage")
+	_, err = Compute(original, updated)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not decode original config")
+}
+
+func TestPathString(t *testing.T) {
+	assert.Equal(t, "/Channel/Application/Org1MSP/policies/Admins", pathString([]string{"channel_group", "groups", "Application", "groups", "Org1MSP", "policies", "Admins"}))
+	assert.Equal(t, "/Channel/Application/groups", pathString([]string{"channel_group", "groups", "Application", "groups"}))
+	assert.Equal(t, "/sequence", pathString([]string{"sequence"}))
+}
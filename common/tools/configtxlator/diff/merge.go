@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ConflictError is returned by Merge when both configurations modified the
+// same element differently
+type ConflictError struct {
+	// Paths locates the conflicting elements
+	Paths []string
+}
+
+// Error lists the conflicting elements
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflicting modifications of %s", strings.Join(e.Paths, ", "))
+}
+
+// Merge three-way merges the configurations ours and theirs, which were both
+// derived from base, e.g. by concurrently prepared config updates. Elements
+// modified by only one of them are taken from it, elements modified by both
+// must have been modified identically, otherwise a *ConflictError is returned.
+// Values and policies are merged as a whole, groups member by member.
+func Merge(base, ours, theirs *cb.Config) (*cb.Config, error) {
+	if base.ChannelGroup == nil || ours.ChannelGroup == nil || theirs.ChannelGroup == nil {
+		return nil, errors.New("no channel group included for one of the configs")
+	}
+
+	m := &merger{}
+	merged := m.mergeGroup("/Channel", base.ChannelGroup, ours.ChannelGroup, theirs.ChannelGroup)
+	if len(m.conflicts) > 0 {
+		sort.Strings(m.conflicts)
+		return nil, &ConflictError{Paths: m.conflicts}
+	}
+
+	return &cb.Config{
+		Sequence:     base.Sequence,
+		ChannelGroup: merged,
+	}, nil
+}
+
+type merger struct {
+	conflicts []string
+}
+
+func (m *merger) mergeGroup(path string, base, ours, theirs *cb.ConfigGroup) *cb.ConfigGroup {
+	merged := &cb.ConfigGroup{
+		Version:  base.Version,
+		Groups:   map[string]*cb.ConfigGroup{},
+		Values:   map[string]*cb.ConfigValue{},
+		Policies: map[string]*cb.ConfigPolicy{},
+	}
+
+	switch {
+	case ours.ModPolicy == theirs.ModPolicy || theirs.ModPolicy == base.ModPolicy:
+		merged.ModPolicy = ours.ModPolicy
+	case ours.ModPolicy == base.ModPolicy:
+		merged.ModPolicy = theirs.ModPolicy
+	default:
+		m.conflicts = append(m.conflicts, path+"/mod_policy")
+	}
+
+	for _, name := range unionKeys(base.Groups, ours.Groups, theirs.Groups) {
+		b, o, t := base.Groups[name], ours.Groups[name], theirs.Groups[name]
+		if o != nil && t != nil {
+			if b == nil {
+				b = cb.NewConfigGroup()
+			}
+			merged.Groups[name] = m.mergeGroup(path+"/"+name, b, o, t)
+			continue
+		}
+		if element, ok := m.mergeElement(path+"/"+name, b, o, t); ok && !isNil(element) {
+			merged.Groups[name] = element.(*cb.ConfigGroup)
+		}
+	}
+
+	for _, name := range unionKeys(base.Values, ours.Values, theirs.Values) {
+		b, o, t := base.Values[name], ours.Values[name], theirs.Values[name]
+		if element, ok := m.mergeElement(path+"/values/"+name, b, o, t); ok && !isNil(element) {
+			merged.Values[name] = element.(*cb.ConfigValue)
+		}
+	}
+
+	for _, name := range unionKeys(base.Policies, ours.Policies, theirs.Policies) {
+		b, o, t := base.Policies[name], ours.Policies[name], theirs.Policies[name]
+		if element, ok := m.mergeElement(path+"/policies/"+name, b, o, t); ok && !isNil(element) {
+			merged.Policies[name] = element.(*cb.ConfigPolicy)
+		}
+	}
+
+	return merged
+}
+
+// mergeElement merges a config element as a whole, it returns the merged
+// element, which is nil if the element was removed, and whether the merge
+// succeeded
+func (m *merger) mergeElement(path string, base, ours, theirs proto.Message) (proto.Message, bool) {
+	switch {
+	case equal(ours, theirs), equal(base, theirs):
+		return ours, true
+	case equal(base, ours):
+		return theirs, true
+	default:
+		m.conflicts = append(m.conflicts, path)
+		return nil, false
+	}
+}
+
+// equal compares config elements, ignoring their versions which do not
+// matter when computing a config update
+func equal(a, b proto.Message) bool {
+	if isNil(a) || isNil(b) {
+		return isNil(a) && isNil(b)
+	}
+	a, b = proto.Clone(a), proto.Clone(b)
+	clearVersions(a)
+	clearVersions(b)
+	return proto.Equal(a, b)
+}
+
+func isNil(msg proto.Message) bool {
+	switch m := msg.(type) {
+	case *cb.ConfigGroup:
+		return m == nil
+	case *cb.ConfigValue:
+		return m == nil
+	case *cb.ConfigPolicy:
+		return m == nil
+	}
+	return msg == nil
+}
+
+func clearVersions(msg proto.Message) {
+	switch m := msg.(type) {
+	case *cb.ConfigGroup:
+		m.Version = 0
+		for _, group := range m.Groups {
+			clearVersions(group)
+		}
+		for _, value := range m.Values {
+			value.Version = 0
+		}
+		for _, policy := range m.Policies {
+			policy.Version = 0
+		}
+	case *cb.ConfigValue:
+		m.Version = 0
+	case *cb.ConfigPolicy:
+		m.Version = 0
+	}
+}
+
+// unionKeys returns the sorted names of the elements of the given maps
+func unionKeys(maps ...interface{}) []string {
+	names := map[string]struct{}{}
+	for _, elements := range maps {
+		switch e := elements.(type) {
+		case map[string]*cb.ConfigGroup:
+			for name := range e {
+				names[name] = struct{}{}
+			}
+		case map[string]*cb.ConfigValue:
+			for name := range e {
+				names[name] = struct{}{}
+			}
+		case map[string]*cb.ConfigPolicy:
+			for name := range e {
+				names[name] = struct{}{}
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	base := testConfig("Admins", 10)
+
+	// ours raises the batch size, theirs adds a batch timeout and removes
+	// the hashing algorithm, both add the same policy
+	ours := proto.Clone(base).(*cb.Config)
+	ours.ChannelGroup.Groups["Orderer"].Values["BatchSize"].Value = utils.MarshalOrPanic(&ab.BatchSize{MaxMessageCount: 20})
+	ours.ChannelGroup.Policies = map[string]*cb.ConfigPolicy{"Readers": {ModPolicy: "Admins"}}
+	theirs := proto.Clone(base).(*cb.Config)
+	theirs.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"] = &cb.ConfigValue{
+		ModPolicy: "Admins",
+		Value:     utils.MarshalOrPanic(&ab.BatchTimeout{Timeout: "2s"}),
+	}
+	delete(theirs.ChannelGroup.Values, "HashingAlgorithm")
+	theirs.ChannelGroup.Policies = map[string]*cb.ConfigPolicy{"Readers": {ModPolicy: "Admins", Version: 2}}
+	theirs.ChannelGroup.Groups["Consortiums"] = &cb.ConfigGroup{ModPolicy: "Admins"}
+
+	merged, err := Merge(base, ours, theirs)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), merged.Sequence)
+	orderer := merged.ChannelGroup.Groups["Orderer"]
+	assert.Equal(t, uint64(1), orderer.Version)
+	assert.Equal(t, ours.ChannelGroup.Groups["Orderer"].Values["BatchSize"], orderer.Values["BatchSize"])
+	assert.Equal(t, theirs.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"], orderer.Values["BatchTimeout"])
+	assert.NotContains(t, merged.ChannelGroup.Values, "HashingAlgorithm")
+	assert.Contains(t, merged.ChannelGroup.Policies, "Readers")
+	assert.Contains(t, merged.ChannelGroup.Groups, "Consortiums")
+
+	// the update carries both modifications
+	configUpdate, err := update.Compute(base, merged)
+	assert.NoError(t, err)
+	writeSet := configUpdate.WriteSet
+	assert.Equal(t, uint64(1), writeSet.Groups["Orderer"].Values["BatchSize"].Version)
+	assert.Contains(t, writeSet.Groups["Orderer"].Values, "BatchTimeout")
+	assert.Contains(t, writeSet.Groups, "Consortiums")
+	assert.NotContains(t, writeSet.Values, "HashingAlgorithm")
+}
+
+func TestMergeConflicts(t *testing.T) {
+	base := testConfig("Admins", 10)
+
+	ours := testConfig("Writers", 20)
+	theirs := testConfig("Readers", 30)
+	theirs.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"] = &cb.ConfigValue{ModPolicy: "Admins"}
+	ours.ChannelGroup.Groups["Orderer"].Values["BatchTimeout"] = &cb.ConfigValue{ModPolicy: "Writers"}
+	// removed by one side, modified by the other
+	delete(ours.ChannelGroup.Values, "HashingAlgorithm")
+	theirs.ChannelGroup.Values["HashingAlgorithm"].ModPolicy = "Writers"
+
+	_, err := Merge(base, ours, theirs)
+	assert.Error(t, err)
+	conflictErr, ok := err.(*ConflictError)
+	assert.True(t, ok, "Should have returned a ConflictError")
+	assert.Equal(t, []string{
+		"/Channel/Orderer/values/BatchSize",
+		"/Channel/Orderer/values/BatchTimeout",
+		"/Channel/mod_policy",
+		"/Channel/values/HashingAlgorithm",
+	}, conflictErr.Paths)
+	assert.Equal(t, "conflicting modifications of /Channel/Orderer/values/BatchSize, /Channel/Orderer/values/BatchTimeout, /Channel/mod_policy, /Channel/values/HashingAlgorithm", err.Error())
+
+	_, err = Merge(base, ours, &cb.Config{})
+	assert.EqualError(t, err, "no channel group included for one of the configs")
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	config := testConfig("Admins", 10)
+	block := &cb.Block{
+		Data: &cb.BlockData{
+			Data: [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+				Payload: utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG)}),
+					},
+					Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{Config: config}),
+				}),
+			})},
+		},
+	}
+
+	unmarshaled, err := UnmarshalConfig(BlockType, utils.MarshalOrPanic(block))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(config, unmarshaled))
+
+	unmarshaled, err = UnmarshalConfig(ConfigType, utils.MarshalOrPanic(config))
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(config, unmarshaled))
+
+	_, err = UnmarshalConfig("common.Envelope", nil)
+	assert.EqualError(t, err, "unknown input type common.Envelope, expected common.Block or common.Config")
+
+	_, err = UnmarshalConfig(BlockType, []byte("garbage"))
+	assert.Error(t, err)
+
+	block.Data.Data[0] = utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_ENDORSER_TRANSACTION)}),
+			},
+		}),
+	})
+	_, err = ConfigFromBlock(block)
+	assert.EqualError(t, err, "block is not a config block, its transaction is of type 3")
+}
@@ -23,6 +23,7 @@ import (
 	"os"
 	"reflect"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
@@ -61,6 +62,20 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	computeDiff          = app.Command("compute_diff", "Takes two marshaled config blocks, or common.Config messages, and prints the differences between them.")
+	computeDiffOriginal  = computeDiff.Flag("original", "The original config block or message.").Required().File()
+	computeDiffUpdated   = computeDiff.Flag("updated", "The updated config block or message.").Required().File()
+	computeDiffInputType = computeDiff.Flag("input_type", "The type of the inputs, either 'common.Block' or 'common.Config'.").Default(diff.BlockType).String()
+	computeDiffDest      = computeDiff.Flag("output", "A file to write the differences to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	mergeUpdates          = app.Command("merge_updates", "Takes a base config and two configs concurrently updated from it, three-way merges them and computes the config update which transitions from the base config to the merged one.")
+	mergeUpdatesBase      = mergeUpdates.Flag("base", "The config block or message both updated configs are derived from.").Required().File()
+	mergeUpdatesOurs      = mergeUpdates.Flag("ours", "The first updated config block or message.").Required().File()
+	mergeUpdatesTheirs    = mergeUpdates.Flag("theirs", "The second updated config block or message.").Required().File()
+	mergeUpdatesInputType = mergeUpdates.Flag("input_type", "The type of the inputs, either 'common.Block' or 'common.Config'.").Default(diff.ConfigType).String()
+	mergeUpdatesChannelID = mergeUpdates.Flag("channel_id", "The name of the channel for this update.").Required().String()
+	mergeUpdatesDest      = mergeUpdates.Flag("output", "A file to write the config update to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -93,6 +108,23 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case computeDiff.FullCommand():
+		defer (*computeDiffOriginal).Close()
+		defer (*computeDiffUpdated).Close()
+		defer (*computeDiffDest).Close()
+		err := computeDiffs(*computeDiffInputType, *computeDiffOriginal, *computeDiffUpdated, *computeDiffDest)
+		if err != nil {
+			app.Fatalf("Error computing differences: %s", err)
+		}
+	case mergeUpdates.FullCommand():
+		defer (*mergeUpdatesBase).Close()
+		defer (*mergeUpdatesOurs).Close()
+		defer (*mergeUpdatesTheirs).Close()
+		defer (*mergeUpdatesDest).Close()
+		err := mergeUpdts(*mergeUpdatesInputType, *mergeUpdatesBase, *mergeUpdatesOurs, *mergeUpdatesTheirs, *mergeUpdatesDest, *mergeUpdatesChannelID)
+		if err != nil {
+			app.Fatalf("Error merging updates: %s", err)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -203,3 +235,81 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func readConfig(inputType string, input *os.File) (*cb.Config, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", input.Name())
+	}
+
+	config, err := diff.UnmarshalConfig(inputType, in)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error decoding %s", input.Name()))
+	}
+	return config, nil
+}
+
+func computeDiffs(inputType string, original, updated, output *os.File) error {
+	origConf, err := readConfig(inputType, original)
+	if err != nil {
+		return err
+	}
+
+	updtConf, err := readConfig(inputType, updated)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diff.Compute(origConf, updtConf)
+	if err != nil {
+		return errors.WithMessage(err, "error computing differences")
+	}
+
+	_, err = output.WriteString(diff.Format(changes))
+	if err != nil {
+		return errors.Wrapf(err, "error writing differences to output")
+	}
+
+	return nil
+}
+
+func mergeUpdts(inputType string, base, ours, theirs, output *os.File, channelID string) error {
+	baseConf, err := readConfig(inputType, base)
+	if err != nil {
+		return err
+	}
+
+	ourConf, err := readConfig(inputType, ours)
+	if err != nil {
+		return err
+	}
+
+	theirConf, err := readConfig(inputType, theirs)
+	if err != nil {
+		return err
+	}
+
+	merged, err := diff.Merge(baseConf, ourConf, theirConf)
+	if err != nil {
+		return errors.WithMessage(err, "error merging configs")
+	}
+
+	cu, err := update.Compute(baseConf, merged)
+	if err != nil {
+		return errors.Wrapf(err, "error computing config update")
+	}
+
+	cu.ChannelId = channelID
+
+	outBytes, err := proto.Marshal(cu)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling computed config update")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update to output")
+	}
+
+	return nil
+}
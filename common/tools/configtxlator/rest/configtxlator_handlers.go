@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/diff"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -54,6 +55,22 @@ func fieldConfigProto(fieldName string, r *http.Request) (*cb.Config, error) {
 	return config, nil
 }
 
+// fieldConfig reads the config, or config block, of the given field, the
+// type of the fields is given by the 'input_type' field and defaults to
+// common.Config
+func fieldConfig(fieldName string, r *http.Request) (*cb.Config, error) {
+	fieldBytes, err := fieldBytes(fieldName, r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading field bytes: %s", err)
+	}
+
+	inputType := r.FormValue("input_type")
+	if inputType == "" {
+		inputType = diff.ConfigType
+	}
+	return diff.UnmarshalConfig(inputType, fieldBytes)
+}
+
 func ComputeUpdateFromConfigs(w http.ResponseWriter, r *http.Request) {
 	originalConfig, err := fieldConfigProto("original", r)
 	if err != nil {
@@ -90,6 +107,73 @@ func ComputeUpdateFromConfigs(w http.ResponseWriter, r *http.Request) {
 	w.Write(encoded)
 }
 
+func ComputeDiffFromConfigs(w http.ResponseWriter, r *http.Request) {
+	originalConfig, err := fieldConfig("original", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'original': %s\n", err)
+		return
+	}
+
+	updatedConfig, err := fieldConfig("updated", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'updated': %s\n", err)
+		return
+	}
+
+	changes, err := diff.Compute(originalConfig, updatedConfig)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing differences: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, diff.Format(changes))
+}
+
+func ComputeUpdateFromMerge(w http.ResponseWriter, r *http.Request) {
+	configs := map[string]*cb.Config{}
+	for _, field := range []string{"base", "ours", "theirs"} {
+		config, err := fieldConfig(field, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field '%s': %s\n", field, err)
+			return
+		}
+		configs[field] = config
+	}
+
+	merged, err := diff.Merge(configs["base"], configs["ours"], configs["theirs"])
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "Error merging configs: %s\n", err)
+		return
+	}
+
+	configUpdate, err := update.Compute(configs["base"], merged)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing update: %s\n", err)
+		return
+	}
+
+	configUpdate.ChannelId = r.FormValue("channel")
+
+	encoded, err := proto.Marshal(configUpdate)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling config update: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
+
 func SanityCheckConfig(w http.ResponseWriter, r *http.Request) {
 	buf, err := ioutil.ReadAll(r.Body)
 	if err != nil {
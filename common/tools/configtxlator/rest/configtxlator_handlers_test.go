@@ -24,6 +24,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -182,3 +183,87 @@ func TestConfigtxlatorSanityCheckMalformedConfig(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func postConfigs(t *testing.T, url string, configs map[string]*cb.Config, values map[string]string) *httptest.ResponseRecorder {
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+	for field, config := range configs {
+		ffw, err := mpw.CreateFormFile(field, field)
+		assert.NoError(t, err)
+		_, err = bytes.NewReader(utils.MarshalOrPanic(config)).WriteTo(ffw)
+		assert.NoError(t, err)
+	}
+	for field, value := range values {
+		assert.NoError(t, mpw.WriteField(field, value))
+	}
+	assert.NoError(t, mpw.Close())
+
+	req, err := http.NewRequest("POST", url, buffer)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	NewRouter().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConfigtxlatorComputeDiff(t *testing.T) {
+	rec := postConfigs(t, "/configtxlator/compute/diff-from-configs", map[string]*cb.Config{
+		"original": {ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}},
+		"updated":  {ChannelGroup: &cb.ConfigGroup{ModPolicy: "bar"}},
+	}, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "~ /Channel/mod_policy: \"foo\" -> \"bar\"\n", rec.Body.String())
+
+	rec = postConfigs(t, "/configtxlator/compute/diff-from-configs", map[string]*cb.Config{
+		"original": {ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}},
+	}, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error with field 'updated'")
+
+	rec = postConfigs(t, "/configtxlator/compute/diff-from-configs", map[string]*cb.Config{
+		"original": {ChannelGroup: &cb.ConfigGroup{ModPolicy: "foo"}},
+		"updated":  {ChannelGroup: &cb.ConfigGroup{ModPolicy: "bar"}},
+	}, map[string]string{"input_type": "common.Block"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestConfigtxlatorComputeUpdateFromMerge(t *testing.T) {
+	base := &cb.Config{ChannelGroup: &cb.ConfigGroup{
+		ModPolicy: "foo",
+		Groups: map[string]*cb.ConfigGroup{
+			"Orderer": {ModPolicy: "foo"},
+		},
+	}}
+	ours := proto.Clone(base).(*cb.Config)
+	ours.ChannelGroup.ModPolicy = "bar"
+	theirs := proto.Clone(base).(*cb.Config)
+	theirs.ChannelGroup.Groups["Orderer"].ModPolicy = "baz"
+
+	rec := postConfigs(t, "/configtxlator/compute/update-from-merge", map[string]*cb.Config{
+		"base":   base,
+		"ours":   ours,
+		"theirs": theirs,
+	}, map[string]string{"channel": "foo"})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	configUpdate := &cb.ConfigUpdate{}
+	assert.NoError(t, proto.Unmarshal(rec.Body.Bytes(), configUpdate))
+	assert.Equal(t, "foo", configUpdate.ChannelId)
+	assert.Equal(t, "bar", configUpdate.WriteSet.ModPolicy)
+	assert.Equal(t, "baz", configUpdate.WriteSet.Groups["Orderer"].ModPolicy)
+
+	theirs.ChannelGroup.ModPolicy = "qux"
+	rec = postConfigs(t, "/configtxlator/compute/update-from-merge", map[string]*cb.Config{
+		"base":   base,
+		"ours":   ours,
+		"theirs": theirs,
+	}, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "Error merging configs: conflicting modifications of /Channel/mod_policy\n", rec.Body.String())
+
+	rec = postConfigs(t, "/configtxlator/compute/update-from-merge", map[string]*cb.Config{
+		"base": base,
+		"ours": ours,
+	}, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error with field 'theirs'")
+}
@@ -32,6 +32,12 @@ func NewRouter() *mux.Router {
 	router.
 		HandleFunc("/configtxlator/compute/update-from-configs", ComputeUpdateFromConfigs).
 		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/compute/diff-from-configs", ComputeDiffFromConfigs).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/compute/update-from-merge", ComputeUpdateFromMerge).
+		Methods("POST")
 	router.
 		HandleFunc("/configtxlator/config/verify", SanityCheckConfig).
 		Methods("POST")
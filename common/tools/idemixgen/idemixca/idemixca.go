@@ -102,3 +102,25 @@ func GenerateSignerConfig(isAdmin bool, ouString string, enrollmentId string, re
 
 	return proto.Marshal(signer)
 }
+
+// GenerateCRI creates the serialized Credential Revocation Information of an epoch,
+// in which only the signers with the given revocation handles are not revoked.
+// The CRI is signed with the long term revocation key and can be distributed to
+// the signers and verifiers of the MSP, for instance through the channel config.
+func GenerateCRI(unrevokedHandles []int, epoch int, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+
+	handles := make([]*FP256BN.BIG, len(unrevokedHandles))
+	for i, rh := range unrevokedHandles {
+		handles[i] = FP256BN.NewBIGint(rh)
+	}
+
+	cri, err := idemix.CreateCRI(revKey, handles, epoch, idemix.ALG_PLAIN_SIGNATURE, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create CRI")
+	}
+	return proto.Marshal(cri)
+}
@@ -64,6 +64,48 @@ func TestIdemixCa(t *testing.T) {
 	assert.EqualError(t, err, "the enrollment id value is empty")
 }
 
+func TestIdemixCaCRI(t *testing.T) {
+	cleanup()
+
+	isk, ipkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(ipkBytes, ipk))
+	key := &idemix.IssuerKey{isk, ipk}
+
+	revocationkey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	writeVerifierToFile(ipkBytes, elliptic.Marshal(elliptic.P384(), revocationkey.X, revocationkey.Y))
+
+	conf, err := GenerateSignerConfig(false, "OU1", "enrollmentid1", 1, key, revocationkey)
+	assert.NoError(t, err)
+	assert.NoError(t, writeSignerToFile(conf))
+
+	// The signer can setup in an epoch in which it isn't revoked
+	cri, err := GenerateCRI([]int{1, 2}, 1, revocationkey)
+	assert.NoError(t, err)
+	assert.NoError(t, writeCRIToFile(cri))
+	assert.NoError(t, setupMSP())
+
+	// but not in an epoch in which it is revoked
+	cri, err = GenerateCRI([]int{2}, 2, revocationkey)
+	assert.NoError(t, err)
+	assert.NoError(t, writeCRIToFile(cri))
+	err = setupMSP()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is revoked in epoch 2")
+
+	// nor with a CRI signed with another revocation key
+	otherRevocationKey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	cri, err = GenerateCRI([]int{1}, 3, otherRevocationKey)
+	assert.NoError(t, err)
+	assert.NoError(t, writeCRIToFile(cri))
+	err = setupMSP()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "EpochPKSig invalid")
+}
+
 func cleanup() error {
 	// clean up any previous files
 	err := os.RemoveAll(testDir)
@@ -94,6 +136,10 @@ func writeVerifierToFile(ipkBytes []byte, revpkBytes []byte) error {
 	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileRevocationPublicKey), revpkBytes, 0644)
 }
 
+func writeCRIToFile(criBytes []byte) error {
+	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileCRI), criBytes, 0644)
+}
+
 func writeSignerToFile(signerBytes []byte) error {
 	err := os.Mkdir(filepath.Join(testDir, m.IdemixConfigDirUser), os.ModePerm)
 	if err != nil {
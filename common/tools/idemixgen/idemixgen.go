@@ -49,6 +49,9 @@ var (
 	genCredIsAdmin          = genSignerConfig.Flag("admin", "Make the default signer admin").Short('a').Bool()
 	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
 	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The handle used to revoke this signer").Short('r').Int()
+	genCRI                  = app.Command("cri", "Generate the credential revocation information of an epoch for this Idemix MSP")
+	genCRIEpoch             = genCRI.Flag("epoch", "The epoch of the credential revocation information").Short('e').Required().Int()
+	genCRIUnrevokedHandles  = genCRI.Flag("unrevokedHandle", "The handle of a signer that is not revoked in the epoch, can be repeated").Short('r').Ints()
 
 	version = app.Command("version", "Show version information")
 )
@@ -95,6 +98,13 @@ func main() {
 		handleError(os.Mkdir(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
 		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
 
+	case genCRI.FullCommand():
+		cri, err := idemixca.GenerateCRI(*genCRIUnrevokedHandles, *genCRIEpoch, readRevocationKey())
+		handleError(err)
+
+		// Write the CRI to the verifier config, replacing the one of the previous epoch
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileCRI), cri)
+
 	case version.FullCommand():
 		printVersion()
 	}
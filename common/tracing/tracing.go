@@ -0,0 +1,303 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing records spans of the stages a transaction goes through,
+// and exports them to an OpenTelemetry collector.
+//
+// The trace ID of a transaction is derived from its transaction ID, so the
+// spans recorded by every peer that endorses or commits the transaction,
+// from the proposal to the commit, belong to the same trace without the
+// trace context having to travel in the transaction itself.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+var logger = flogging.MustGetLogger("tracing")
+
+const (
+	scopeName = "hyperledger_fabric"
+
+	defaultTimeout       = 10 * time.Second
+	defaultFlushInterval = 5 * time.Second
+	defaultServiceName   = "peer"
+
+	// maxQueuedSpans bounds the spans kept between exports,
+	// spans recorded beyond it are dropped
+	maxQueuedSpans = 4096
+)
+
+// Opts configures the tracer
+type Opts struct {
+	Enabled bool
+	// Endpoint is the URL of the OpenTelemetry collector, e.g. http://localhost:4318
+	Endpoint string
+	// Timeout bounds each export request to the collector
+	Timeout time.Duration
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string
+	// FlushInterval is the frequency spans are exported at
+	FlushInterval time.Duration
+}
+
+// NewOpts creates tracing options based on the config file
+func NewOpts() Opts {
+	opts := Opts{}
+	opts.Enabled = viper.GetBool("tracing.enabled")
+	opts.Endpoint = viper.GetString("tracing.endpoint")
+	if timeout := viper.GetDuration("tracing.timeout"); timeout > 0 {
+		opts.Timeout = timeout
+	} else {
+		opts.Timeout = defaultTimeout
+	}
+	if serviceName := viper.GetString("tracing.serviceName"); serviceName != "" {
+		opts.ServiceName = serviceName
+	} else {
+		opts.ServiceName = defaultServiceName
+	}
+	if flushInterval := viper.GetDuration("tracing.flushInterval"); flushInterval > 0 {
+		opts.FlushInterval = flushInterval
+	} else {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	return opts
+}
+
+var (
+	tracerLock sync.RWMutex
+	tracer     *Tracer
+)
+
+// Init starts the tracer that spans are recorded with.
+// Spans are not recorded if tracing isn't enabled.
+func Init(opts Opts) error {
+	if !opts.Enabled {
+		return nil
+	}
+	t, err := NewTracer(opts)
+	if err != nil {
+		return err
+	}
+
+	tracerLock.Lock()
+	defer tracerLock.Unlock()
+	if tracer != nil {
+		tracer.Stop()
+	}
+	tracer = t
+	return nil
+}
+
+// Shutdown stops the tracer, after exporting the spans it still holds
+func Shutdown() {
+	tracerLock.Lock()
+	defer tracerLock.Unlock()
+	if tracer != nil {
+		tracer.Stop()
+		tracer = nil
+	}
+}
+
+// Enabled returns whether spans are recorded
+func Enabled() bool {
+	return currentTracer() != nil
+}
+
+func currentTracer() *Tracer {
+	tracerLock.RLock()
+	defer tracerLock.RUnlock()
+	return tracer
+}
+
+// StartSpan starts a span of the operation with the given name in the trace
+// of the transaction. It returns nil, on which all Span methods are no-ops,
+// if tracing isn't enabled.
+func StartSpan(txID, name string) *Span {
+	t := currentTracer()
+	if t == nil {
+		return nil
+	}
+	return &Span{
+		tracer:     t,
+		txID:       txID,
+		spanID:     newSpanID(),
+		name:       name,
+		start:      time.Now(),
+		attributes: make(map[string]string),
+	}
+}
+
+// RecordSpan records a span of an operation of the transaction that
+// has already completed, failing with the given error if it isn't nil
+func RecordSpan(txID, name string, start, end time.Time, attributes map[string]string, err error) {
+	t := currentTracer()
+	if t == nil {
+		return
+	}
+	t.record(txID, newSpanID(), name, start, end, attributes, err)
+}
+
+// TraceID returns the hex encoded ID of the trace of the transaction
+func TraceID(txID string) string {
+	return otlp.TraceID(txID)
+}
+
+// Span is an operation of a transaction that is being timed
+type Span struct {
+	tracer     *Tracer
+	txID       string
+	spanID     string
+	name       string
+	start      time.Time
+	attributes map[string]string
+}
+
+// SpanID returns the hex encoded ID of the span,
+// or an empty string if tracing isn't enabled
+func (s *Span) SpanID() string {
+	if s == nil {
+		return ""
+	}
+	return s.spanID
+}
+
+// SetAttribute sets an attribute of the span
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.attributes[key] = value
+}
+
+// End completes the span, which failed if the error isn't nil
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.tracer.record(s.txID, s.spanID, s.name, s.start, time.Now(), s.attributes, err)
+}
+
+// Tracer batches the recorded spans and exports them periodically
+type Tracer struct {
+	exporter      *otlp.Exporter
+	resource      otlp.Resource
+	flushInterval time.Duration
+
+	mutex sync.Mutex
+	spans []*otlp.Span
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewTracer creates a Tracer and starts exporting the spans it records
+func NewTracer(opts Opts) (*Tracer, error) {
+	if opts.FlushInterval <= 0 {
+		return nil, errors.Errorf("invalid tracing flush interval %s", opts.FlushInterval)
+	}
+	exporter, err := otlp.NewExporter(opts.Endpoint, otlp.TracesPath, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tracer{
+		exporter:      exporter,
+		resource:      otlp.NewResource(opts.ServiceName),
+		flushInterval: opts.FlushInterval,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go t.run()
+	return t, nil
+}
+
+func (t *Tracer) record(txID, spanID, name string, start, end time.Time, attributes map[string]string, err error) {
+	span := &otlp.Span{
+		TraceID:           TraceID(txID),
+		SpanID:            spanID,
+		Name:              name,
+		Kind:              otlp.SpanKindServer,
+		StartTimeUnixNano: otlp.UnixNano(start),
+		EndTimeUnixNano:   otlp.UnixNano(end),
+		Attributes:        append(otlp.Attributes(attributes), otlp.StringAttribute("tx_id", txID)),
+		Status:            otlp.Status{Code: otlp.StatusCodeOK},
+	}
+	if err != nil {
+		span.Status = otlp.Status{Code: otlp.StatusCodeError, Message: err.Error()}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.spans) >= maxQueuedSpans {
+		logger.Debugf("Dropping span %s of transaction %s, %d spans are already queued", name, txID, len(t.spans))
+		return
+	}
+	t.spans = append(t.spans, span)
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.Flush()
+		case <-t.stop:
+			t.Flush()
+			return
+		}
+	}
+}
+
+// Flush exports the spans recorded since the previous flush
+func (t *Tracer) Flush() {
+	t.mutex.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mutex.Unlock()
+	if len(spans) == 0 {
+		return
+	}
+
+	request := &otlp.ExportTraceServiceRequest{
+		ResourceSpans: []otlp.ResourceSpans{{
+			Resource: t.resource,
+			ScopeSpans: []otlp.ScopeSpans{{
+				Scope: otlp.InstrumentationScope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	}
+	if err := t.exporter.Export(request); err != nil {
+		logger.Warningf("Failed exporting %d spans: %s", len(spans), err)
+	}
+}
+
+// Stop stops exporting spans periodically, after exporting the spans it still holds
+func (t *Tracer) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+	})
+	<-t.done
+}
+
+func newSpanID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		logger.Panicf("Failed generating span ID: %s", err)
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/otlp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type collector struct {
+	server *httptest.Server
+	mutex  sync.Mutex
+	spans  []*otlp.Span
+}
+
+func newCollector() *collector {
+	c := &collector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlp.TracesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		request := &otlp.ExportTraceServiceRequest{}
+		if err := json.Unmarshal(b, request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				c.spans = append(c.spans, ss.Spans...)
+			}
+		}
+	}))
+	return c
+}
+
+func (c *collector) received() []*otlp.Span {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.spans
+}
+
+func TestNewOpts(t *testing.T) {
+	defer viper.Reset()
+	opts := NewOpts()
+	assert.Equal(t, Opts{
+		Timeout:       defaultTimeout,
+		ServiceName:   defaultServiceName,
+		FlushInterval: defaultFlushInterval,
+	}, opts)
+
+	viper.Set("tracing.enabled", true)
+	viper.Set("tracing.endpoint", "http://collector:4318")
+	viper.Set("tracing.timeout", "3s")
+	viper.Set("tracing.serviceName", "peer0")
+	viper.Set("tracing.flushInterval", "1s")
+	opts = NewOpts()
+	assert.Equal(t, Opts{
+		Enabled:       true,
+		Endpoint:      "http://collector:4318",
+		Timeout:       3 * time.Second,
+		ServiceName:   "peer0",
+		FlushInterval: time.Second,
+	}, opts)
+}
+
+func TestNewTracer(t *testing.T) {
+	_, err := NewTracer(Opts{Endpoint: "http://localhost:4318"})
+	assert.EqualError(t, err, "invalid tracing flush interval 0s")
+
+	_, err = NewTracer(Opts{Endpoint: "localhost:4318", FlushInterval: time.Second})
+	assert.EqualError(t, err, "OTLP collector endpoint localhost:4318 is not an http or https URL")
+}
+
+func TestDisabled(t *testing.T) {
+	assert.NoError(t, Init(Opts{Enabled: false, Endpoint: "not a URL"}))
+	assert.False(t, Enabled())
+
+	span := StartSpan("tx1", "endorse")
+	assert.Nil(t, span)
+	assert.Empty(t, span.SpanID())
+	// Spans are no-ops when tracing is disabled
+	span.SetAttribute("channel", "mychannel")
+	span.End(nil)
+	RecordSpan("tx1", "commit", time.Now(), time.Now(), nil, nil)
+}
+
+func TestSpans(t *testing.T) {
+	c := newCollector()
+	defer c.server.Close()
+
+	err := Init(Opts{
+		Enabled:       true,
+		Endpoint:      c.server.URL,
+		Timeout:       time.Second,
+		ServiceName:   "peer0",
+		FlushInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	assert.True(t, Enabled())
+
+	span := StartSpan("tx1", "endorse")
+	span.SetAttribute("channel", "mychannel")
+	spanID := span.SpanID()
+	span.End(nil)
+	start := time.Unix(100, 0)
+	RecordSpan("tx1", "commit", start, start.Add(time.Second), map[string]string{"validation_code": "MVCC_READ_CONFLICT"}, errors.New("transaction is invalid"))
+
+	// Shutting down exports the spans that are still queued
+	Shutdown()
+	assert.False(t, Enabled())
+
+	spans := c.received()
+	require.Len(t, spans, 2)
+	endorse, commit := spans[0], spans[1]
+	assert.Equal(t, TraceID("tx1"), endorse.TraceID)
+	assert.Equal(t, TraceID("tx1"), commit.TraceID)
+	assert.Equal(t, spanID, endorse.SpanID)
+	assert.NotEqual(t, endorse.SpanID, commit.SpanID)
+	assert.Len(t, endorse.SpanID, 16)
+
+	assert.Equal(t, "endorse", endorse.Name)
+	assert.Equal(t, []otlp.KeyValue{
+		otlp.StringAttribute("channel", "mychannel"),
+		otlp.StringAttribute("tx_id", "tx1"),
+	}, endorse.Attributes)
+	assert.Equal(t, otlp.Status{Code: otlp.StatusCodeOK}, endorse.Status)
+	assert.True(t, endorse.EndTimeUnixNano >= endorse.StartTimeUnixNano)
+
+	assert.Equal(t, "commit", commit.Name)
+	assert.Equal(t, otlp.UnixNano(start), commit.StartTimeUnixNano)
+	assert.Equal(t, otlp.UnixNano(start.Add(time.Second)), commit.EndTimeUnixNano)
+	assert.Equal(t, []otlp.KeyValue{
+		otlp.StringAttribute("validation_code", "MVCC_READ_CONFLICT"),
+		otlp.StringAttribute("tx_id", "tx1"),
+	}, commit.Attributes)
+	assert.Equal(t, otlp.Status{Code: otlp.StatusCodeError, Message: "transaction is invalid"}, commit.Status)
+}
+
+func TestDroppedSpans(t *testing.T) {
+	c := newCollector()
+	defer c.server.Close()
+
+	tracer, err := NewTracer(Opts{Endpoint: c.server.URL, Timeout: time.Second, FlushInterval: time.Hour})
+	require.NoError(t, err)
+	for i := 0; i < maxQueuedSpans+1; i++ {
+		tracer.record("tx1", newSpanID(), "endorse", time.Now(), time.Now(), nil, nil)
+	}
+	tracer.Stop()
+	assert.Len(t, c.received(), maxQueuedSpans)
+}
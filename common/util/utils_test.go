@@ -45,6 +45,12 @@ func TestComputeSHA3256(t *testing.T) {
 	}
 }
 
+func TestComputeSM3(t *testing.T) {
+	// Standard test vector from GM/T 0004-2012
+	expected := "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"
+	assert.Equal(t, expected, fmt.Sprintf("%x", ComputeSM3([]byte("abc"))))
+}
+
 func TestUUIDGeneration(t *testing.T) {
 	uuid := GenerateUUID()
 	if len(uuid) != 36 {
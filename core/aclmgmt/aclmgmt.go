@@ -18,3 +18,13 @@ type ACLProvider interface {
 	//id can be extracted for testing against a policy
 	CheckACL(resName string, channelID string, idinfo interface{}) error
 }
+
+//CustomACLProvider is implemented by ACLProviders which check the ACLs of
+//custom resources, such as the functions of chaincodes, that fabric knows
+//nothing about. A custom resource is only subject to an ACL if a policy is
+//mapped to it in the ACLs of the channel config
+type CustomACLProvider interface {
+	//CheckCustomACL checks the ACL for the custom resource for the channel
+	//using the idinfo, if a policy is mapped to the resource
+	CheckCustomACL(resName string, channelID string, idinfo interface{}) error
+}
@@ -34,6 +34,16 @@ func (am *aclMgmtImpl) CheckACL(resName string, channelID string, idinfo interfa
 	return am.rescfgProvider.CheckACL(resName, channelID, idinfo)
 }
 
+//CheckCustomACL checks the ACL for the custom resource for the channel using
+//the idinfo, if the resource based config provider maps it to a policy
+func (am *aclMgmtImpl) CheckCustomACL(resName string, channelID string, idinfo interface{}) error {
+	cp, ok := am.rescfgProvider.(CustomACLProvider)
+	if !ok {
+		return nil
+	}
+	return cp.CheckCustomACL(resName, channelID, idinfo)
+}
+
 //ACLProvider consists of two providers, supplied one and a default one (1.0 ACL management
 //using ChannelReaders and ChannelWriters). If supplied provider is nil, a resource based
 //ACL provider is created.
@@ -125,6 +125,8 @@ func (rp *aclmgmtPolicyProviderImpl) CheckACL(polName string, idinfo interface{}
 		if err != nil {
 			return err
 		}
+	case []*common.SignedData:
+		sd = idinfo.([]*common.SignedData)
 	default:
 		return InvalidIdInfo(polName)
 	}
@@ -172,3 +174,22 @@ func (rp *resourceProvider) CheckACL(resName string, channelID string, idinfo in
 
 	return rp.defaultProvider.CheckACL(resName, channelID, idinfo)
 }
+
+//CheckCustomACL implements CustomACLProvider. Custom resources that aren't
+//mapped to a policy in the config aren't subject to an ACL, as the default
+//provider knows nothing about them
+func (rp *resourceProvider) CheckCustomACL(resName string, channelID string, idinfo interface{}) error {
+	resCfg := rp.resGetter(channelID)
+	if resCfg == nil {
+		return nil
+	}
+
+	pp := &aclmgmtPolicyProviderImpl{&policyEvaluatorImpl{resCfg}}
+	policyName := pp.GetPolicyName(resName)
+	if policyName == "" {
+		aclLogger.Debugf("acl policy not found in config for custom resource %s", resName)
+		return nil
+	}
+	aclLogger.Debugf("acl policy %s found in config for custom resource %s", policyName, resName)
+	return pp.CheckACL(policyName, idinfo)
+}
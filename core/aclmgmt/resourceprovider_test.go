@@ -16,12 +16,17 @@ limitations under the License.
 package aclmgmt
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/localmsp"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -66,6 +71,11 @@ func TestPolicyBase(t *testing.T) {
 	assert.NoError(t, err)
 	err = pprov.CheckACL("pol", env)
 	assert.NoError(t, err)
+
+	sd, err := env.AsSignedData()
+	assert.NoError(t, err)
+	err = pprov.CheckACL("pol", sd)
+	assert.NoError(t, err)
 }
 
 func TestPolicyBad(t *testing.T) {
@@ -106,3 +116,38 @@ func init() {
 		return
 	}
 }
+
+func TestCheckCustomACL(t *testing.T) {
+	resources := &mockconfig.Resources{
+		ApplicationConfigVal: &mockconfig.MockApplication{Acls: map[string]string{"mycc/transfer": "/Channel/Application/Admins"}},
+		PolicyManagerVal: &mockpolicies.Manager{PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Admins": &mockpolicies.Policy{Err: errors.New("signature set did not satisfy policy")},
+		}},
+	}
+	rp := newResourceProvider(func(channelID string) channelconfig.Resources {
+		if channelID != "myc" {
+			return nil
+		}
+		return resources
+	}, newDefaultACLProvider())
+	am := newACLMgmt(rp).(CustomACLProvider)
+	sProp, _ := utils.MockSignedEndorserProposalOrPanic("myc", &peer.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+
+	err := am.CheckCustomACL("mycc/transfer", "myc", sProp)
+	assert.EqualError(t, err, "failed evaluating policy on signed data during check policy [/Channel/Application/Admins]: [signature set did not satisfy policy]")
+
+	// resources that aren't mapped to a policy aren't subject to an ACL
+	err = am.CheckCustomACL("mycc/query", "myc", sProp)
+	assert.NoError(t, err)
+
+	err = am.CheckCustomACL("mycc/transfer", "otherc", sProp)
+	assert.NoError(t, err)
+
+	resources.PolicyManagerVal.(*mockpolicies.Manager).PolicyMap["/Channel/Application/Admins"] = &mockpolicies.Policy{}
+	err = am.CheckCustomACL("mycc/transfer", "myc", sProp)
+	assert.NoError(t, err)
+
+	// providers that don't check custom resources allow them
+	err = newACLMgmt(newDefaultACLProvider()).(CustomACLProvider).CheckCustomACL("mycc/transfer", "myc", sProp)
+	assert.NoError(t, err)
+}
@@ -27,6 +27,7 @@ const (
 	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
 	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
 	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChaincodeEvents = "qscc/GetChaincodeEvents"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -40,6 +41,22 @@ const (
 	Peer_ChaincodeToChaincode = "peer/ChaincodeToChaincode"
 
 	//Events
-	Event_Block         = "event/Block"
-	Event_FilteredBlock = "event/FilteredBlock"
+	Event_Block                    = "event/Block"
+	Event_FilteredBlock            = "event/FilteredBlock"
+	Event_FilteredBlockPvtDataHash = "event/FilteredBlockPvtDataHash"
+
+	//Gateway resources
+	Gateway_CommitStatus = "gateway/CommitStatus"
+
+	//Token resources
+	Token_Issue          = "token/Issue"
+	Token_ProcessCommand = "token/ProcessCommand"
 )
+
+//ChaincodeFunction returns the resource of a function of an application
+//chaincode, such as "mycc/transfer". Unlike the resources above, the
+//functions of chaincodes are only subject to an ACL if operators map
+//them to a policy in the ACLs of the channel config
+func ChaincodeFunction(chaincode, function string) string {
+	return chaincode + "/" + function
+}
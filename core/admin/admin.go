@@ -7,10 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package admin
 
 import (
+	"encoding/json"
+	"sort"
+	"time"
+
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/discovery"
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
@@ -29,19 +39,104 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// GossipSupport provides the admin service with access to the gossip layer
+type GossipSupport interface {
+	// MembershipSnapshot returns a snapshot of the alive and dead membership views
+	MembershipSnapshot() (gossip.MembershipSnapshot, error)
+
+	// LeaderElectionStatus returns the status of the leader election
+	// of each channel the peer uses leader election in
+	LeaderElectionStatus() (map[string]election.Status, error)
+
+	// OverrideLeaderElection overrides the outcome of the leader election of the given channel
+	OverrideLeaderElection(chainID string, override election.Override) error
+
+	// ReloadExternalEndpoint reloads the peer's configuration and publishes
+	// the external endpoint found in it to other peers, and returns it
+	ReloadExternalEndpoint() (string, error)
+
+	// PvtDataDisseminations returns the records of the recent disseminations of the private data of the given channel,
+	// of transactions simulated at or above the given block height, and only of the given transaction if txID isn't empty
+	PvtDataDisseminations(chainID string, startBlock uint64, txID string) ([]privdata.DisseminationRecord, error)
+
+	// MissingPvtDataStats returns the statistics of the private data that was missing
+	// when the blocks of the given channel were committed
+	MissingPvtDataStats(chainID string) (privdata.MissingPvtDataStats, error)
+}
+
+// DiscoverySupport provides the admin service with access to the discovery service
+type DiscoverySupport interface {
+	// DiscoveryStats returns the statistics of the discovery service since it was started,
+	// or an error if the discovery service isn't enabled
+	DiscoveryStats() (discovery.Stats, error)
+}
+
+// LedgerSupport provides the admin service with access to the ledgers of the channels
+type LedgerSupport interface {
+	// PruneHistory starts pruning the history database of the given channel in the background
+	PruneHistory(channelID string) error
+
+	// HistoryPruningStatus returns the status of the pruning of the history database of the given channel
+	HistoryPruningStatus(channelID string) (*ledger.HistoryPruningStatus, error)
+
+	// DryRunCommit validates the given block of the given channel like the committer does,
+	// without committing it, and returns the validation code of each of its transactions
+	DryRunCommit(channelID string, block *common.Block) ([]pb.TxValidationCode, error)
+
+	// PurgePrivateData purges the private values of the given keys of a collection of the given channel
+	// right away if blockToLive is zero, or otherwise once blockToLive more blocks are committed
+	PurgePrivateData(channelID, ccName, collName string, keys []string, blockToLive uint64) error
+}
+
+// TLSSupport provides the admin service with access to the TLS credentials of the peer
+type TLSSupport interface {
+	// ReloadTLSCredentials reloads the TLS credentials of the gRPC servers of the peer
+	// from their files, and returns the PEM encoded certificate of the peer and the
+	// number of certificate authorities client certificates are verified with
+	ReloadTLSCredentials() (certificate []byte, clientRootCAs int, err error)
+}
+
+var leaderElectionOverrides = map[pb.LeaderElectionOverrideRequest_Override]election.Override{
+	pb.LeaderElectionOverrideRequest_NONE:       election.NoOverride,
+	pb.LeaderElectionOverrideRequest_CLAIM:      election.ClaimLeadership,
+	pb.LeaderElectionOverrideRequest_RELINQUISH: election.RelinquishLeadership,
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, gossipSupport GossipSupport) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
+		gossip: gossipSupport,
 	}
 	return s
 }
 
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
-	v requestValidator
+	v         requestValidator
+	gossip    GossipSupport
+	discovery DiscoverySupport
+	ledgers   LedgerSupport
+	installer ChaincodeInstaller
+	uploads   *uploadStore
+	tls       TLSSupport
+}
+
+// SetDiscoverySupport sets the access of the admin service to the discovery service
+func (s *ServerAdmin) SetDiscoverySupport(discoverySupport DiscoverySupport) {
+	s.discovery = discoverySupport
+}
+
+// SetLedgerSupport sets the access of the admin service to the ledgers
+func (s *ServerAdmin) SetLedgerSupport(ledgerSupport LedgerSupport) {
+	s.ledgers = ledgerSupport
+}
+
+// SetTLSSupport sets the access of the admin service to the TLS credentials
+func (s *ServerAdmin) SetTLSSupport(tlsSupport TLSSupport) {
+	s.tls = tlsSupport
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -97,3 +192,326 @@ func (s *ServerAdmin) RevertLogLevels(ctx context.Context, env *common.Envelope)
 	err := flogging.RevertToPeerStartupLevels()
 	return &empty.Empty{}, err
 }
+
+func (s *ServerAdmin) GetLoggerLevels(ctx context.Context, env *common.Envelope) (*pb.LoggerLevelsResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetLoggerLevelsReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	levels, err := flogging.GetLoggerLevels(request.LoggerPattern)
+	if err != nil {
+		return nil, err
+	}
+	return loggerLevelsResponse(levels), nil
+}
+
+func (s *ServerAdmin) SetLoggerLevels(ctx context.Context, env *common.Envelope) (*pb.LoggerLevelsResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetLoggerLevelsReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	expiry := time.Duration(request.ExpiryMinutes) * time.Minute
+	levels, err := flogging.SetLoggerLevels(request.LoggerPattern, request.LogLevel, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return loggerLevelsResponse(levels), nil
+}
+
+// loggerLevelsResponse lists the levels of the loggers sorted by logger name
+func loggerLevelsResponse(levels map[string]string) *pb.LoggerLevelsResponse {
+	response := &pb.LoggerLevelsResponse{}
+	for logger, level := range levels {
+		response.Loggers = append(response.Loggers, &pb.LoggerLevel{Logger: logger, Level: level})
+	}
+	sort.Slice(response.Loggers, func(i, j int) bool {
+		return response.Loggers[i].Logger < response.Loggers[j].Logger
+	})
+	return response
+}
+
+func (s *ServerAdmin) GetGossipMembership(ctx context.Context, env *common.Envelope) (*pb.GossipMembershipResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	snapshot, err := s.gossip.MembershipSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	membership, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling membership snapshot")
+	}
+	return &pb.GossipMembershipResponse{Membership: membership}, nil
+}
+
+func (s *ServerAdmin) GetLeaderElectionStatus(ctx context.Context, env *common.Envelope) (*pb.LeaderElectionStatusResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	electionStatus, err := s.gossip.LeaderElectionStatus()
+	if err != nil {
+		return nil, err
+	}
+	status, err := json.Marshal(electionStatus)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling leader election status")
+	}
+	return &pb.LeaderElectionStatusResponse{Status: status}, nil
+}
+
+func (s *ServerAdmin) OverrideLeaderElection(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetLeaderElectionOverrideReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	override, exists := leaderElectionOverrides[request.Override]
+	if !exists {
+		return nil, errors.Errorf("unknown override %v", request.Override)
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	logger.Infof("Overriding leader election of channel %s with %s", request.ChannelId, override)
+	if err := s.gossip.OverrideLeaderElection(request.ChannelId, override); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerAdmin) ReloadGossipEndpoint(ctx context.Context, env *common.Envelope) (*pb.GossipEndpointResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	endpoint, err := s.gossip.ReloadExternalEndpoint()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reloading gossip endpoint")
+	}
+	logger.Infof("Reloaded gossip external endpoint: %s", endpoint)
+	return &pb.GossipEndpointResponse{Endpoint: endpoint}, nil
+}
+
+func (s *ServerAdmin) ReloadTLSCredentials(ctx context.Context, env *common.Envelope) (*pb.TLSCredentialsResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.tls == nil {
+		return nil, errors.New("TLS is not enabled")
+	}
+	certificate, clientRootCAs, err := s.tls.ReloadTLSCredentials()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reloading TLS credentials")
+	}
+	return &pb.TLSCredentialsResponse{Certificate: certificate, ClientRootCas: uint32(clientRootCAs)}, nil
+}
+
+func (s *ServerAdmin) GetDiscoveryStats(ctx context.Context, env *common.Envelope) (*pb.DiscoveryStatsResponse, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.discovery == nil {
+		return nil, errors.New("discovery service is not available")
+	}
+	stats, err := s.discovery.DiscoveryStats()
+	if err != nil {
+		return nil, err
+	}
+	rawStats, err := json.Marshal(stats)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling discovery stats")
+	}
+	return &pb.DiscoveryStatsResponse{Stats: rawStats}, nil
+}
+
+func (s *ServerAdmin) PruneHistory(ctx context.Context, env *common.Envelope) (*pb.HistoryPruningStatusResponse, error) {
+	request, err := s.validateHistoryPruningRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Pruning history database of channel %s", request.ChannelId)
+	if err := s.ledgers.PruneHistory(request.ChannelId); err != nil {
+		return nil, errors.WithMessage(err, "failed pruning history database")
+	}
+	return s.historyPruningStatus(request.ChannelId)
+}
+
+func (s *ServerAdmin) GetHistoryPruningStatus(ctx context.Context, env *common.Envelope) (*pb.HistoryPruningStatusResponse, error) {
+	request, err := s.validateHistoryPruningRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	return s.historyPruningStatus(request.ChannelId)
+}
+
+func (s *ServerAdmin) validateHistoryPruningRequest(ctx context.Context, env *common.Envelope) (*pb.HistoryPruningRequest, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetHistoryPruningReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if s.ledgers == nil {
+		return nil, errors.New("ledgers are not available")
+	}
+	return request, nil
+}
+
+func (s *ServerAdmin) historyPruningStatus(channelID string) (*pb.HistoryPruningStatusResponse, error) {
+	status, err := s.ledgers.HistoryPruningStatus(channelID)
+	if err != nil {
+		return nil, err
+	}
+	rawStatus, err := json.Marshal(status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling history pruning status")
+	}
+	return &pb.HistoryPruningStatusResponse{Status: rawStatus}, nil
+}
+
+func (s *ServerAdmin) DryRunCommit(ctx context.Context, env *common.Envelope) (*pb.DryRunCommitResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetDryRunCommitReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if request.Block == nil {
+		return nil, errors.New("block is nil")
+	}
+	if s.ledgers == nil {
+		return nil, errors.New("ledgers are not available")
+	}
+	logger.Infof("Dry running the commit of block %d of channel %s", request.Block.GetHeader().GetNumber(), request.ChannelId)
+	codes, err := s.ledgers.DryRunCommit(request.ChannelId, request.Block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed dry running commit")
+	}
+	resp := &pb.DryRunCommitResponse{}
+	for i, code := range codes {
+		resp.Transactions = append(resp.Transactions, &pb.DryRunTransactionResult{
+			TxId:           txID(request.Block, i),
+			ValidationCode: code,
+		})
+	}
+	return resp, nil
+}
+
+func (s *ServerAdmin) PurgePrivateData(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetPrivateDataPurgeReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if request.ChaincodeName == "" || request.CollectionName == "" {
+		return nil, errors.New("chaincode name and collection name must be specified")
+	}
+	if len(request.Keys) == 0 {
+		return nil, errors.New("no keys to purge")
+	}
+	if s.ledgers == nil {
+		return nil, errors.New("ledgers are not available")
+	}
+	if request.BlockToLive == 0 {
+		logger.Infof("Purging %d private data keys of collection %s of chaincode %s on channel %s",
+			len(request.Keys), request.CollectionName, request.ChaincodeName, request.ChannelId)
+	} else {
+		logger.Infof("Purging %d private data keys of collection %s of chaincode %s on channel %s in %d blocks",
+			len(request.Keys), request.CollectionName, request.ChaincodeName, request.ChannelId, request.BlockToLive)
+	}
+	err = s.ledgers.PurgePrivateData(request.ChannelId, request.ChaincodeName, request.CollectionName, request.Keys, request.BlockToLive)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed purging private data")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerAdmin) GetPvtDataDisseminations(ctx context.Context, env *common.Envelope) (*pb.PvtDataDisseminationsResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetPvtDataDisseminationsReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	records, err := s.gossip.PvtDataDisseminations(request.ChannelId, request.StartBlock, request.TxId)
+	if err != nil {
+		return nil, err
+	}
+	disseminations, err := json.Marshal(records)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling private data disseminations")
+	}
+	return &pb.PvtDataDisseminationsResponse{Disseminations: disseminations}, nil
+}
+
+func (s *ServerAdmin) GetMissingPvtDataStats(ctx context.Context, env *common.Envelope) (*pb.MissingPvtDataStatsResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetMissingPvtDataStatsReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if s.gossip == nil {
+		return nil, errors.New("gossip service is not available")
+	}
+	stats, err := s.gossip.MissingPvtDataStats(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	rawStats, err := json.Marshal(stats)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling missing private data statistics")
+	}
+	return &pb.MissingPvtDataStatsResponse{Stats: rawStats}, nil
+}
+
+// txID returns the ID of the transaction at the given index of the block,
+// or an empty string if the transaction is malformed
+func txID(block *common.Block, index int) string {
+	if index >= len(block.GetData().GetData()) {
+		return ""
+	}
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[index])
+	if err != nil {
+		return ""
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return ""
+	}
+	return chdr.TxId
+}
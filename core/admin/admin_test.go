@@ -8,12 +8,21 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/discovery"
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	context2 "golang.org/x/net/context"
@@ -36,7 +45,7 @@ func (v *mockValidator) validate(ctx context2.Context, env *common.Envelope) (*p
 }
 
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -46,7 +55,7 @@ func TestGetStatus(t *testing.T) {
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -56,10 +65,10 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
-	mv.On("validate").Return(nil, accessDenied).Times(5)
+	mv.On("validate").Return(nil, accessDenied).Times(8)
 
 	ctx := context.Background()
 	status, err := adminServer.GetStatus(ctx, nil)
@@ -79,10 +88,19 @@ func TestForbidden(t *testing.T) {
 
 	_, err = adminServer.StartServer(ctx, nil)
 	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.GetGossipMembership(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.GetLeaderElectionStatus(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.OverrideLeaderElection(ctx, nil)
+	assert.Equal(t, accessDenied, err)
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -132,3 +150,673 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "logger level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+func TestLoggerLevels(t *testing.T) {
+	defer flogging.Reset()
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+	flogging.MustGetLogger("test/a")
+	flogging.MustGetLogger("test/b")
+
+	wrapLoggerLevelsRequest := func(llr *pb.LoggerLevelsRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_LoggerLevelsReq{
+				LoggerLevelsReq: llr,
+			},
+		}
+	}
+
+	mv.On("validate").Return(wrapLoggerLevelsRequest(&pb.LoggerLevelsRequest{
+		LoggerPattern: "test/*",
+		LogLevel:      "debug",
+		ExpiryMinutes: 10,
+	}), nil).Once()
+	response, err := adminServer.SetLoggerLevels(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.LoggerLevel{
+		{Logger: "test/a", Level: "DEBUG"},
+		{Logger: "test/b", Level: "DEBUG"},
+	}, response.Loggers)
+
+	mv.On("validate").Return(wrapLoggerLevelsRequest(&pb.LoggerLevelsRequest{LoggerPattern: "test/b"}), nil).Once()
+	response, err = adminServer.GetLoggerLevels(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.LoggerLevel{{Logger: "test/b", Level: "DEBUG"}}, response.Loggers)
+
+	mv.On("validate").Return(wrapLoggerLevelsRequest(&pb.LoggerLevelsRequest{
+		LoggerPattern: "test/*",
+		LogLevel:      "foo",
+	}), nil).Once()
+	_, err = adminServer.SetLoggerLevels(context.Background(), nil)
+	assert.EqualError(t, err, "invalid log level 'foo'")
+
+	mv.On("validate").Return(wrapLoggerLevelsRequest(&pb.LoggerLevelsRequest{LoggerPattern: "nope"}), nil).Once()
+	_, err = adminServer.GetLoggerLevels(context.Background(), nil)
+	assert.EqualError(t, err, "no logger matches 'nope'")
+
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Twice()
+	_, err = adminServer.GetLoggerLevels(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+	_, err = adminServer.SetLoggerLevels(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	mv.On("validate").Return(nil, accessDenied).Twice()
+	_, err = adminServer.GetLoggerLevels(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.SetLoggerLevels(context.Background(), nil)
+	assert.Equal(t, accessDenied, err)
+}
+
+type mockDiscoverySupport struct {
+	mock.Mock
+}
+
+func (ds *mockDiscoverySupport) DiscoveryStats() (discovery.Stats, error) {
+	args := ds.Called()
+	return args.Get(0).(discovery.Stats), args.Error(1)
+}
+
+type mockGossipSupport struct {
+	mock.Mock
+	snapshot gossip.MembershipSnapshot
+	err      error
+}
+
+func (gs *mockGossipSupport) MembershipSnapshot() (gossip.MembershipSnapshot, error) {
+	return gs.snapshot, gs.err
+}
+
+func (gs *mockGossipSupport) LeaderElectionStatus() (map[string]election.Status, error) {
+	args := gs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]election.Status), args.Error(1)
+}
+
+func (gs *mockGossipSupport) OverrideLeaderElection(chainID string, override election.Override) error {
+	return gs.Called(chainID, override).Error(0)
+}
+
+func (gs *mockGossipSupport) ReloadExternalEndpoint() (string, error) {
+	args := gs.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (gs *mockGossipSupport) PvtDataDisseminations(chainID string, startBlock uint64, txID string) ([]privdata.DisseminationRecord, error) {
+	args := gs.Called(chainID, startBlock, txID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]privdata.DisseminationRecord), args.Error(1)
+}
+
+func (gs *mockGossipSupport) MissingPvtDataStats(chainID string) (privdata.MissingPvtDataStats, error) {
+	args := gs.Called(chainID)
+	return args.Get(0).(privdata.MissingPvtDataStats), args.Error(1)
+}
+
+func TestGetGossipMembership(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	// No gossip support
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err := adminServer.GetGossipMembership(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	// The gossip service isn't initialized yet
+	adminServer.gossip = &mockGossipSupport{err: errors.New("gossip service isn't initialized yet")}
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetGossipMembership(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "gossip service isn't initialized yet")
+
+	snapshot := gossip.MembershipSnapshot{
+		Self: gossip.PeerSnapshot{
+			PKIid:         "0a0b",
+			Endpoint:      "p0:7051",
+			LedgerHeights: map[string]uint64{"mychannel": 10},
+		},
+		Alive: []gossip.PeerSnapshot{{PKIid: "0c0d", Endpoint: "p1:7051"}},
+		Dead:  []gossip.PeerSnapshot{{PKIid: "0e0f", Endpoint: "p2:7051"}},
+	}
+	adminServer.gossip = &mockGossipSupport{snapshot: snapshot}
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetGossipMembership(context.Background(), nil)
+	assert.NoError(t, err)
+	received := gossip.MembershipSnapshot{}
+	assert.NoError(t, json.Unmarshal(resp.Membership, &received))
+	assert.Equal(t, snapshot, received)
+}
+
+func TestGetLeaderElectionStatus(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	// No gossip support
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err := adminServer.GetLeaderElectionStatus(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	lastTransition := time.Now().UTC().Round(time.Second)
+	status := map[string]election.Status{
+		"mychannel": {
+			IsLeader:       true,
+			Leader:         "0a0b",
+			Override:       election.ClaimLeadership,
+			Transitions:    3,
+			LastTransition: &lastTransition,
+		},
+		"yourchannel": {
+			Leader: "0c0d",
+		},
+	}
+	gs := &mockGossipSupport{}
+	adminServer.gossip = gs
+
+	// The gossip service isn't initialized yet
+	gs.On("LeaderElectionStatus").Return(nil, errors.New("gossip service isn't initialized yet")).Once()
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetLeaderElectionStatus(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "gossip service isn't initialized yet")
+
+	gs.On("LeaderElectionStatus").Return(status, nil).Once()
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetLeaderElectionStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	received := map[string]election.Status{}
+	assert.NoError(t, json.Unmarshal(resp.Status, &received))
+	assert.Equal(t, status, received)
+}
+
+func TestOverrideLeaderElection(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapOverrideRequest := func(req *pb.LeaderElectionOverrideRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_LeaderElectionOverrideReq{
+				LeaderElectionOverrideReq: req,
+			},
+		}
+	}
+
+	// Nil request
+	mv.On("validate").Return(wrapOverrideRequest(nil), nil).Once()
+	_, err := adminServer.OverrideLeaderElection(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	// Unknown override
+	mv.On("validate").Return(wrapOverrideRequest(&pb.LeaderElectionOverrideRequest{
+		ChannelId: "mychannel",
+		Override:  pb.LeaderElectionOverrideRequest_Override(10),
+	}), nil).Once()
+	_, err = adminServer.OverrideLeaderElection(context.Background(), nil)
+	assert.EqualError(t, err, "unknown override 10")
+
+	// No gossip support
+	claimRequest := wrapOverrideRequest(&pb.LeaderElectionOverrideRequest{
+		ChannelId: "mychannel",
+		Override:  pb.LeaderElectionOverrideRequest_CLAIM,
+	})
+	mv.On("validate").Return(claimRequest, nil).Once()
+	_, err = adminServer.OverrideLeaderElection(context.Background(), nil)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	gs := &mockGossipSupport{}
+	gs.On("OverrideLeaderElection", "mychannel", election.ClaimLeadership).Return(nil).Once()
+	gs.On("OverrideLeaderElection", "yourchannel", election.RelinquishLeadership).Return(errors.New("leader election isn't used in channel yourchannel")).Once()
+	adminServer.gossip = gs
+
+	mv.On("validate").Return(claimRequest, nil).Once()
+	resp, err := adminServer.OverrideLeaderElection(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+
+	mv.On("validate").Return(wrapOverrideRequest(&pb.LeaderElectionOverrideRequest{
+		ChannelId: "yourchannel",
+		Override:  pb.LeaderElectionOverrideRequest_RELINQUISH,
+	}), nil).Once()
+	_, err = adminServer.OverrideLeaderElection(context.Background(), nil)
+	assert.EqualError(t, err, "leader election isn't used in channel yourchannel")
+	gs.AssertExpectations(t)
+}
+
+func TestReloadGossipEndpoint(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	// No gossip support
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err := adminServer.ReloadGossipEndpoint(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	gs := &mockGossipSupport{}
+	gs.On("ReloadExternalEndpoint").Return("peer0.org1.example.com:7051", nil).Once()
+	gs.On("ReloadExternalEndpoint").Return("", errors.New("Config File \"core\" Not Found")).Once()
+	adminServer.gossip = gs
+
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.ReloadGossipEndpoint(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "peer0.org1.example.com:7051", resp.Endpoint)
+
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.ReloadGossipEndpoint(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed reloading gossip endpoint: Config File \"core\" Not Found")
+	gs.AssertExpectations(t)
+}
+
+func TestGetPvtDataDisseminations(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapRequest := func(req *pb.PvtDataDisseminationsRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_PvtDataDisseminationsReq{
+				PvtDataDisseminationsReq: req,
+			},
+		}
+	}
+
+	// Nil request
+	mv.On("validate").Return(wrapRequest(nil), nil).Once()
+	_, err := adminServer.GetPvtDataDisseminations(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	// No gossip support
+	request := wrapRequest(&pb.PvtDataDisseminationsRequest{ChannelId: "mychannel", StartBlock: 5, TxId: "tx1"})
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.GetPvtDataDisseminations(context.Background(), nil)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	records := []privdata.DisseminationRecord{
+		{
+			TxID:          "tx1",
+			BlockHeight:   5,
+			Namespace:     "mycc",
+			Collection:    "collection1",
+			RequiredPeers: 1,
+			MaxPeers:      2,
+			EligiblePeers: 3,
+			Peers: []gossip.PeerSendResult{
+				{PKIid: "0a0b", Endpoint: "p1:7051"},
+				{PKIid: "0c0d", Endpoint: "p2:7051", Error: "timed out"},
+			},
+			Acks: 1,
+		},
+	}
+	gs := &mockGossipSupport{}
+	gs.On("PvtDataDisseminations", "mychannel", uint64(5), "tx1").Return(records, nil).Once()
+	gs.On("PvtDataDisseminations", "yourchannel", uint64(0), "").Return(nil, errors.New("No private data handler for yourchannel")).Once()
+	adminServer.gossip = gs
+
+	mv.On("validate").Return(request, nil).Once()
+	resp, err := adminServer.GetPvtDataDisseminations(context.Background(), nil)
+	assert.NoError(t, err)
+	var received []privdata.DisseminationRecord
+	assert.NoError(t, json.Unmarshal(resp.Disseminations, &received))
+	assert.Equal(t, records, received)
+
+	mv.On("validate").Return(wrapRequest(&pb.PvtDataDisseminationsRequest{ChannelId: "yourchannel"}), nil).Once()
+	resp, err = adminServer.GetPvtDataDisseminations(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "No private data handler for yourchannel")
+	gs.AssertExpectations(t)
+}
+
+func TestGetMissingPvtDataStats(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapRequest := func(req *pb.MissingPvtDataStatsRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_MissingPvtDataStatsReq{
+				MissingPvtDataStatsReq: req,
+			},
+		}
+	}
+
+	// Nil request
+	mv.On("validate").Return(wrapRequest(nil), nil).Once()
+	_, err := adminServer.GetMissingPvtDataStats(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	// No gossip support
+	request := wrapRequest(&pb.MissingPvtDataStatsRequest{ChannelId: "mychannel"})
+	mv.On("validate").Return(request, nil).Once()
+	_, err = adminServer.GetMissingPvtDataStats(context.Background(), nil)
+	assert.EqualError(t, err, "gossip service is not available")
+
+	stats := privdata.MissingPvtDataStats{
+		ByChaincode: map[string]map[string]int{"mycc": {"collection1": 2}},
+		BlockRanges: []privdata.BlockRange{{From: 3, To: 4}},
+	}
+	gs := &mockGossipSupport{}
+	gs.On("MissingPvtDataStats", "mychannel").Return(stats, nil).Once()
+	gs.On("MissingPvtDataStats", "yourchannel").Return(privdata.MissingPvtDataStats{}, errors.New("No private data handler for yourchannel")).Once()
+	adminServer.gossip = gs
+
+	mv.On("validate").Return(request, nil).Once()
+	resp, err := adminServer.GetMissingPvtDataStats(context.Background(), nil)
+	assert.NoError(t, err)
+	var received privdata.MissingPvtDataStats
+	assert.NoError(t, json.Unmarshal(resp.Stats, &received))
+	assert.Equal(t, stats, received)
+
+	mv.On("validate").Return(wrapRequest(&pb.MissingPvtDataStatsRequest{ChannelId: "yourchannel"}), nil).Once()
+	resp, err = adminServer.GetMissingPvtDataStats(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "No private data handler for yourchannel")
+	gs.AssertExpectations(t)
+}
+
+func TestGetDiscoveryStats(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	// Scenario I: The discovery service isn't available
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err := adminServer.GetDiscoveryStats(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "discovery service is not available")
+
+	// Scenario II: The discovery service isn't enabled
+	ds := &mockDiscoverySupport{}
+	ds.On("DiscoveryStats").Return(discovery.Stats{}, errors.New("discovery service isn't enabled")).Once()
+	adminServer.SetDiscoverySupport(ds)
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetDiscoveryStats(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "discovery service isn't enabled")
+
+	// Scenario III: The stats are returned in JSON
+	ds.On("DiscoveryStats").Return(discovery.Stats{
+		Queries: map[string]discovery.QueryStats{
+			"endorsers": {Count: 3, Failures: 1},
+		},
+		AuthFailures: 2,
+	}, nil).Once()
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.GetDiscoveryStats(context.Background(), nil)
+	assert.NoError(t, err)
+	stats := discovery.Stats{}
+	assert.NoError(t, json.Unmarshal(resp.Stats, &stats))
+	assert.Equal(t, uint64(3), stats.Queries["endorsers"].Count)
+	assert.Equal(t, uint64(1), stats.Queries["endorsers"].Failures)
+	assert.Equal(t, uint64(2), stats.AuthFailures)
+	ds.AssertExpectations(t)
+}
+
+type mockLedgerSupport struct {
+	mock.Mock
+}
+
+func (ls *mockLedgerSupport) PruneHistory(channelID string) error {
+	return ls.Called(channelID).Error(0)
+}
+
+func (ls *mockLedgerSupport) HistoryPruningStatus(channelID string) (*ledger.HistoryPruningStatus, error) {
+	args := ls.Called(channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.HistoryPruningStatus), args.Error(1)
+}
+
+func (ls *mockLedgerSupport) DryRunCommit(channelID string, block *common.Block) ([]pb.TxValidationCode, error) {
+	args := ls.Called(channelID, block)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pb.TxValidationCode), args.Error(1)
+}
+
+func (ls *mockLedgerSupport) PurgePrivateData(channelID, ccName, collName string, keys []string, blockToLive uint64) error {
+	return ls.Called(channelID, ccName, collName, keys, blockToLive).Error(0)
+}
+
+func TestHistoryPruning(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_HistoryPruningReq{
+			HistoryPruningReq: &pb.HistoryPruningRequest{ChannelId: "mychannel"},
+		},
+	}
+
+	// Scenario I: The request is nil
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	resp, err := adminServer.PruneHistory(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "request is nil")
+
+	// Scenario II: The ledgers aren't available
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.GetHistoryPruningStatus(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "ledgers are not available")
+
+	// Scenario III: Pruning fails to start
+	ls := &mockLedgerSupport{}
+	adminServer.SetLedgerSupport(ls)
+	ls.On("PruneHistory", "mychannel").Return(errors.New("history database is not enabled")).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.PruneHistory(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed pruning history database: history database is not enabled")
+
+	// Scenario IV: Pruning starts, and its status is returned in JSON
+	started := time.Now()
+	ls.On("PruneHistory", "mychannel").Return(nil).Once()
+	ls.On("HistoryPruningStatus", "mychannel").Return(&ledger.HistoryPruningStatus{Running: true, LastStarted: started}, nil).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.PruneHistory(context.Background(), nil)
+	assert.NoError(t, err)
+	status := &ledger.HistoryPruningStatus{}
+	assert.NoError(t, json.Unmarshal(resp.Status, status))
+	assert.True(t, status.Running)
+	assert.True(t, started.Equal(status.LastStarted))
+
+	// Scenario V: The status of the last pruning is returned in JSON
+	ls.On("HistoryPruningStatus", "mychannel").Return(&ledger.HistoryPruningStatus{LastPrunedRecords: 10, TotalPrunedRecords: 20}, nil).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.GetHistoryPruningStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	status = &ledger.HistoryPruningStatus{}
+	assert.NoError(t, json.Unmarshal(resp.Status, status))
+	assert.False(t, status.Running)
+	assert.Equal(t, uint64(10), status.LastPrunedRecords)
+	assert.Equal(t, uint64(20), status.TotalPrunedRecords)
+
+	// Scenario VI: The status can't be retrieved
+	ls.On("HistoryPruningStatus", "mychannel").Return(nil, errors.New("ledger is not opened")).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.GetHistoryPruningStatus(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "ledger is not opened")
+	ls.AssertExpectations(t)
+}
+
+func TestDryRunCommit(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 5},
+		Data: &common.BlockData{
+			Data: [][]byte{
+				utils.MarshalOrPanic(&common.Envelope{
+					Payload: utils.MarshalOrPanic(&common.Payload{
+						Header: &common.Header{
+							ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{TxId: "tx1"}),
+						},
+					}),
+				}),
+				[]byte("malformed transaction"),
+			},
+		},
+	}
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_DryRunCommitReq{
+			DryRunCommitReq: &pb.DryRunCommitRequest{ChannelId: "mychannel", Block: block},
+		},
+	}
+
+	// Scenario I: The request is nil
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	resp, err := adminServer.DryRunCommit(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "request is nil")
+
+	// Scenario II: The request doesn't contain a block
+	mv.On("validate").Return(&pb.AdminOperation{
+		Content: &pb.AdminOperation_DryRunCommitReq{
+			DryRunCommitReq: &pb.DryRunCommitRequest{ChannelId: "mychannel"},
+		},
+	}, nil).Once()
+	resp, err = adminServer.DryRunCommit(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "block is nil")
+
+	// Scenario III: The ledgers aren't available
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.DryRunCommit(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "ledgers are not available")
+
+	// Scenario IV: The block fails validation
+	ls := &mockLedgerSupport{}
+	adminServer.SetLedgerSupport(ls)
+	ls.On("DryRunCommit", "mychannel", block).Return(nil, errors.New("channel mychannel not found")).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.DryRunCommit(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed dry running commit: channel mychannel not found")
+
+	// Scenario V: The validation code of each transaction is returned
+	codes := []pb.TxValidationCode{pb.TxValidationCode_MVCC_READ_CONFLICT, pb.TxValidationCode_INVALID_OTHER_REASON}
+	ls.On("DryRunCommit", "mychannel", block).Return(codes, nil).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.DryRunCommit(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.DryRunTransactionResult{
+		{TxId: "tx1", ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT},
+		{TxId: "", ValidationCode: pb.TxValidationCode_INVALID_OTHER_REASON},
+	}, resp.Transactions)
+	ls.AssertExpectations(t)
+}
+
+func TestPurgePrivateData(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+	purgeOp := func(request *pb.PrivateDataPurgeRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_PrivateDataPurgeReq{PrivateDataPurgeReq: request},
+		}
+	}
+	keys := []string{"key1", "key2"}
+	op := purgeOp(&pb.PrivateDataPurgeRequest{ChannelId: "mychannel", ChaincodeName: "mycc", CollectionName: "coll1", Keys: keys})
+
+	// Scenario I: The request is nil
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	resp, err := adminServer.PurgePrivateData(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "request is nil")
+
+	// Scenario II: The request doesn't specify the collection or the keys
+	mv.On("validate").Return(purgeOp(&pb.PrivateDataPurgeRequest{ChannelId: "mychannel", ChaincodeName: "mycc", Keys: keys}), nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "chaincode name and collection name must be specified")
+	mv.On("validate").Return(purgeOp(&pb.PrivateDataPurgeRequest{ChannelId: "mychannel", ChaincodeName: "mycc", CollectionName: "coll1"}), nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "no keys to purge")
+
+	// Scenario III: The ledgers aren't available
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "ledgers are not available")
+
+	// Scenario IV: Purging fails
+	ls := &mockLedgerSupport{}
+	adminServer.SetLedgerSupport(ls)
+	ls.On("PurgePrivateData", "mychannel", "mycc", "coll1", keys, uint64(0)).Return(errors.New("ledger is not opened")).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed purging private data: ledger is not opened")
+
+	// Scenario V: The keys are purged right away, or scheduled for purging
+	ls.On("PurgePrivateData", "mychannel", "mycc", "coll1", keys, uint64(0)).Return(nil).Once()
+	mv.On("validate").Return(op, nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	ls.On("PurgePrivateData", "mychannel", "mycc", "coll1", keys, uint64(10)).Return(nil).Once()
+	mv.On("validate").Return(purgeOp(&pb.PrivateDataPurgeRequest{ChannelId: "mychannel", ChaincodeName: "mycc", CollectionName: "coll1", Keys: keys, BlockToLive: 10}), nil).Once()
+	resp, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	ls.AssertExpectations(t)
+}
+
+type mockTLSSupport struct {
+	mock.Mock
+}
+
+func (ts *mockTLSSupport) ReloadTLSCredentials() ([]byte, int, error) {
+	args := ts.Called()
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]byte), args.Int(1), args.Error(2)
+}
+
+func TestReloadTLSCredentials(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	// Scenario I: TLS isn't enabled
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err := adminServer.ReloadTLSCredentials(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "TLS is not enabled")
+
+	// Scenario II: The credentials are reloaded
+	ts := &mockTLSSupport{}
+	ts.On("ReloadTLSCredentials").Return([]byte("certificate"), 3, nil).Once()
+	adminServer.SetTLSSupport(ts)
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.ReloadTLSCredentials(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("certificate"), resp.Certificate)
+	assert.Equal(t, uint32(3), resp.ClientRootCas)
+
+	// Scenario III: The credentials can't be reloaded
+	ts.On("ReloadTLSCredentials").Return(nil, 0, errors.New("invalid TLS certificate or key")).Once()
+	mv.On("validate").Return(nil, nil).Once()
+	resp, err = adminServer.ReloadTLSCredentials(context.Background(), nil)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed reloading TLS credentials: invalid TLS certificate or key")
+	ts.AssertExpectations(t)
+}
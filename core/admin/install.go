@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ChaincodeInstaller installs chaincode packages on the peer
+type ChaincodeInstaller interface {
+	// InstallPackage installs the given chaincode package,
+	// and returns the name and version of the installed chaincode
+	InstallPackage(ccPackage []byte) (string, string, error)
+}
+
+// SetChaincodeInstaller sets the installer the admin service installs chaincode packages with,
+// and the directory that packages are uploaded to until they are fully received
+func (s *ServerAdmin) SetChaincodeInstaller(installer ChaincodeInstaller, uploadDir string) {
+	s.installer = installer
+	s.uploads = newUploadStore(uploadDir)
+}
+
+// InstallChaincode receives a chaincode package in chunks, acknowledges the bytes received
+// after each chunk, and installs the chaincode once the whole package is received.
+// If the stream breaks before the whole package is received, the bytes received so far
+// are kept, and a later upload of the same package resumes from where this one stopped.
+func (s *ServerAdmin) InstallChaincode(stream pb.Admin_InstallChaincodeServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	op, err := s.v.validate(stream.Context(), msg.GetRequest())
+	if err != nil {
+		return err
+	}
+	request := op.GetInstallChaincodeReq()
+	if request == nil {
+		return errors.New("request is nil")
+	}
+	if s.installer == nil {
+		return errors.New("chaincode installation is not available")
+	}
+	upload, err := s.uploads.start(request.PackageHash, request.PackageSize)
+	if err != nil {
+		return err
+	}
+	defer upload.close()
+
+	if err := stream.Send(upload.progress()); err != nil {
+		return err
+	}
+	for !upload.complete() {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return errors.Errorf("stream closed after %d out of %d bytes of the package were received", upload.received, upload.total)
+		}
+		if err != nil {
+			return err
+		}
+		if msg.GetRequest() != nil {
+			return errors.New("expected a chunk of the package, but got a request")
+		}
+		if err := upload.write(msg.GetChunk()); err != nil {
+			return err
+		}
+		if err := stream.Send(upload.progress()); err != nil {
+			return err
+		}
+	}
+
+	ccPackage, err := upload.read()
+	if err != nil {
+		return err
+	}
+	name, version, err := s.installer.InstallPackage(ccPackage)
+	if err != nil {
+		return errors.WithMessage(err, "failed installing chaincode")
+	}
+	logger.Infof("Installed chaincode %s:%s from an uploaded package of %d bytes", name, version, upload.total)
+	progress := upload.progress()
+	progress.Installed = true
+	progress.ChaincodeName = name
+	progress.ChaincodeVersion = version
+	return stream.Send(progress)
+}
+
+// uploadStore keeps the chaincode packages that are being uploaded in a directory,
+// each in a file named after the hash of the package
+type uploadStore struct {
+	dir        string
+	lock       sync.Mutex
+	inProgress map[string]struct{}
+}
+
+func newUploadStore(dir string) *uploadStore {
+	return &uploadStore{
+		dir:        dir,
+		inProgress: make(map[string]struct{}),
+	}
+}
+
+// start starts the upload of the package with the given hash and size,
+// or resumes a previous upload of it that was interrupted
+func (us *uploadStore) start(hash []byte, size uint64) (*upload, error) {
+	if len(hash) != sha256.Size {
+		return nil, errors.Errorf("invalid package hash of %d bytes, expected a SHA256 hash", len(hash))
+	}
+	if size == 0 {
+		return nil, errors.New("package size must be positive")
+	}
+	key := hex.EncodeToString(hash)
+
+	us.lock.Lock()
+	defer us.lock.Unlock()
+	if _, exists := us.inProgress[key]; exists {
+		return nil, errors.Errorf("package %s is already being uploaded", key)
+	}
+
+	if err := os.MkdirAll(us.dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed creating upload directory")
+	}
+	path := filepath.Join(us.dir, key)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed opening upload file")
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed reading upload file")
+	}
+	received := uint64(fi.Size())
+	// A previous upload of a different size can't be resumed
+	if received > size {
+		received = 0
+		if err := file.Truncate(0); err != nil {
+			file.Close()
+			return nil, errors.Wrap(err, "failed truncating upload file")
+		}
+	}
+	if _, err := file.Seek(int64(received), io.SeekStart); err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed seeking upload file")
+	}
+	if received > 0 {
+		logger.Infof("Resuming upload of package %s at %d out of %d bytes", key, received, size)
+	}
+	us.inProgress[key] = struct{}{}
+	return &upload{
+		store:    us,
+		key:      key,
+		hash:     hash,
+		file:     file,
+		received: received,
+		total:    size,
+	}, nil
+}
+
+func (us *uploadStore) release(key string) {
+	us.lock.Lock()
+	defer us.lock.Unlock()
+	delete(us.inProgress, key)
+}
+
+// upload is a chaincode package being uploaded
+type upload struct {
+	store    *uploadStore
+	key      string
+	hash     []byte
+	file     *os.File
+	received uint64
+	total    uint64
+}
+
+func (u *upload) progress() *pb.InstallChaincodeProgress {
+	return &pb.InstallChaincodeProgress{
+		Received: u.received,
+		Total:    u.total,
+	}
+}
+
+func (u *upload) complete() bool {
+	return u.received == u.total
+}
+
+// write appends the given chunk to the package
+func (u *upload) write(chunk []byte) error {
+	if len(chunk) == 0 {
+		return errors.New("empty chunk")
+	}
+	if u.received+uint64(len(chunk)) > u.total {
+		return errors.Errorf("chunk of %d bytes exceeds the package size of %d bytes, of which %d were already received", len(chunk), u.total, u.received)
+	}
+	n, err := u.file.Write(chunk)
+	u.received += uint64(n)
+	if err != nil {
+		return errors.Wrap(err, "failed writing chunk")
+	}
+	return nil
+}
+
+// read returns the whole package, after verifying it matches the hash it was uploaded with
+func (u *upload) read() ([]byte, error) {
+	ccPackage, err := ioutil.ReadFile(u.file.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading uploaded package")
+	}
+	if hash := sha256.Sum256(ccPackage); !bytes.Equal(hash[:], u.hash) {
+		return nil, errors.Errorf("hash of the uploaded package is %s, but expected %s", hex.EncodeToString(hash[:]), u.key)
+	}
+	return ccPackage, nil
+}
+
+// close ends the upload. Packages that were fully received are removed,
+// while the received bytes of the rest are kept for the upload to be resumed.
+func (u *upload) close() {
+	u.file.Close()
+	if u.complete() {
+		os.Remove(u.file.Name())
+	}
+	u.store.release(u.key)
+}
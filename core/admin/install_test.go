@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	context2 "golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type mockInstaller struct {
+	mock.Mock
+}
+
+func (mi *mockInstaller) InstallPackage(ccPackage []byte) (string, string, error) {
+	args := mi.Called(ccPackage)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+type mockInstallStream struct {
+	grpc.ServerStream
+	msgs     []*pb.InstallChaincodeMessage
+	progress []*pb.InstallChaincodeProgress
+}
+
+func (s *mockInstallStream) Context() context2.Context {
+	return context.Background()
+}
+
+func (s *mockInstallStream) Send(progress *pb.InstallChaincodeProgress) error {
+	s.progress = append(s.progress, progress)
+	return nil
+}
+
+func (s *mockInstallStream) Recv() (*pb.InstallChaincodeMessage, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+func newInstallStream(chunks ...[]byte) *mockInstallStream {
+	stream := &mockInstallStream{
+		msgs: []*pb.InstallChaincodeMessage{
+			{Content: &pb.InstallChaincodeMessage_Request{Request: &common.Envelope{}}},
+		},
+	}
+	for _, chunk := range chunks {
+		stream.msgs = append(stream.msgs, &pb.InstallChaincodeMessage{
+			Content: &pb.InstallChaincodeMessage_Chunk{Chunk: chunk},
+		})
+	}
+	return stream
+}
+
+func installOperation(ccPackage []byte) *pb.AdminOperation {
+	hash := sha256.Sum256(ccPackage)
+	return &pb.AdminOperation{
+		Content: &pb.AdminOperation_InstallChaincodeReq{
+			InstallChaincodeReq: &pb.InstallChaincodeRequest{
+				PackageHash: hash[:],
+				PackageSize: uint64(len(ccPackage)),
+			},
+		},
+	}
+}
+
+func TestInstallChaincode(t *testing.T) {
+	uploadDir, err := ioutil.TempDir("", "uploads")
+	assert.NoError(t, err)
+	defer os.RemoveAll(uploadDir)
+
+	adminServer := NewAdminServer(nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+	installer := &mockInstaller{}
+	ccPackage := []byte("a chaincode package of 40 bytes in total")
+	uploadedPackage := filepath.Join(uploadDir, hex.EncodeToString(installOperation(ccPackage).GetInstallChaincodeReq().PackageHash))
+
+	// Scenario I: Chaincode installation isn't available
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	err = adminServer.InstallChaincode(newInstallStream(ccPackage))
+	assert.EqualError(t, err, "chaincode installation is not available")
+	adminServer.SetChaincodeInstaller(installer, uploadDir)
+
+	// Scenario II: The request isn't an install request
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	err = adminServer.InstallChaincode(newInstallStream(ccPackage))
+	assert.EqualError(t, err, "request is nil")
+
+	// Scenario III: The package is uploaded in chunks, and a progress
+	// acknowledgement is sent for each chunk
+	installer.On("InstallPackage", ccPackage).Return("mycc", "1.0", nil).Once()
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	stream := newInstallStream(ccPackage[:15], ccPackage[15:30], ccPackage[30:])
+	assert.NoError(t, adminServer.InstallChaincode(stream))
+	assert.Equal(t, []*pb.InstallChaincodeProgress{
+		{Received: 0, Total: 40},
+		{Received: 15, Total: 40},
+		{Received: 30, Total: 40},
+		{Received: 40, Total: 40},
+		{Received: 40, Total: 40, Installed: true, ChaincodeName: "mycc", ChaincodeVersion: "1.0"},
+	}, stream.progress)
+	_, err = os.Stat(uploadedPackage)
+	assert.True(t, os.IsNotExist(err), "uploaded package should have been removed")
+
+	// Scenario IV: The stream breaks in the middle of the upload,
+	// and the upload is resumed from where it stopped
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	stream = newInstallStream(ccPackage[:15], ccPackage[15:30])
+	err = adminServer.InstallChaincode(stream)
+	assert.EqualError(t, err, "stream closed after 30 out of 40 bytes of the package were received")
+	fi, err := os.Stat(uploadedPackage)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), fi.Size())
+
+	installer.On("InstallPackage", ccPackage).Return("mycc", "1.0", nil).Once()
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	stream = newInstallStream(ccPackage[30:])
+	assert.NoError(t, adminServer.InstallChaincode(stream))
+	assert.Equal(t, []*pb.InstallChaincodeProgress{
+		{Received: 30, Total: 40},
+		{Received: 40, Total: 40},
+		{Received: 40, Total: 40, Installed: true, ChaincodeName: "mycc", ChaincodeVersion: "1.0"},
+	}, stream.progress)
+
+	// Scenario V: A chunk exceeds the size of the package
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	err = adminServer.InstallChaincode(newInstallStream(ccPackage[:30], ccPackage))
+	assert.EqualError(t, err, "chunk of 40 bytes exceeds the package size of 40 bytes, of which 30 were already received")
+	os.Remove(uploadedPackage)
+
+	// Scenario VI: The uploaded package doesn't match its hash,
+	// hence it isn't installed, and is removed
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	tamperedPackage := []byte("a chaincode package of 40 bytes in tot@l")
+	err = adminServer.InstallChaincode(newInstallStream(tamperedPackage))
+	assert.Contains(t, err.Error(), "hash of the uploaded package is")
+	_, err = os.Stat(uploadedPackage)
+	assert.True(t, os.IsNotExist(err), "uploaded package should have been removed")
+
+	// Scenario VII: The installation fails
+	installer.On("InstallPackage", ccPackage).Return("", "", errors.New("chaincode mycc:1.0 already exists")).Once()
+	mv.On("validate").Return(installOperation(ccPackage), nil).Once()
+	err = adminServer.InstallChaincode(newInstallStream(ccPackage))
+	assert.EqualError(t, err, "failed installing chaincode: chaincode mycc:1.0 already exists")
+
+	// Scenario VIII: The request is forbidden
+	mv.On("validate").Return(nil, accessDenied).Once()
+	err = adminServer.InstallChaincode(newInstallStream(ccPackage))
+	assert.Equal(t, accessDenied, err)
+
+	installer.AssertExpectations(t)
+}
+
+func TestUploadStore(t *testing.T) {
+	uploadDir, err := ioutil.TempDir("", "uploads")
+	assert.NoError(t, err)
+	defer os.RemoveAll(uploadDir)
+	us := newUploadStore(uploadDir)
+	hash := sha256.Sum256([]byte("package"))
+
+	// Scenario I: The hash isn't a SHA256 hash
+	_, err = us.start([]byte{1, 2, 3}, 7)
+	assert.EqualError(t, err, "invalid package hash of 3 bytes, expected a SHA256 hash")
+
+	// Scenario II: The package is empty
+	_, err = us.start(hash[:], 0)
+	assert.EqualError(t, err, "package size must be positive")
+
+	// Scenario III: The same package is uploaded twice at the same time
+	u, err := us.start(hash[:], 7)
+	assert.NoError(t, err)
+	_, err = us.start(hash[:], 7)
+	assert.EqualError(t, err, "package "+hex.EncodeToString(hash[:])+" is already being uploaded")
+	assert.NoError(t, u.write([]byte("pack")))
+	assert.EqualError(t, u.write(nil), "empty chunk")
+	u.close()
+
+	// Scenario IV: An upload of a smaller package with the same hash
+	// can't be resumed, hence it starts over
+	u, err = us.start(hash[:], 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), u.received)
+	u.close()
+}
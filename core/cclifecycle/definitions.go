@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cc
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LifecycleNamespace is the namespace the new chaincode lifecycle
+	// stores the definitions of chaincodes in
+	LifecycleNamespace = "_lifecycle"
+
+	// chaincodeDefinitionType is the datatype of chaincode definitions
+	// in the new chaincode lifecycle namespace
+	chaincodeDefinitionType = "ChaincodeDefinition"
+
+	endorsementInfoField = "EndorsementInfo"
+	validationInfoField  = "ValidationInfo"
+	collectionsField     = "Collections"
+)
+
+func metadataKey(cc string) string {
+	return fmt.Sprintf("namespaces/metadata/%s", cc)
+}
+
+func fieldKey(cc string, field string) string {
+	return fmt.Sprintf("namespaces/fields/%s/%s", cc, field)
+}
+
+// DefinedChaincodes retrieves the metadata of the given chaincodes
+// from their definitions in the new chaincode lifecycle namespace.
+// Chaincodes that aren't defined in the namespace are skipped.
+func DefinedChaincodes(q Query, loadCollections bool, chaincodes ...string) (chaincode.MetadataSet, error) {
+	defer q.Done()
+
+	var res chaincode.MetadataSet
+	for _, cc := range chaincodes {
+		md, err := definedChaincode(q, cc, loadCollections)
+		if err != nil {
+			return nil, err
+		}
+		if md == nil {
+			Logger.Debug("Chaincode", cc, "isn't defined in", LifecycleNamespace)
+			continue
+		}
+		res = append(res, *md)
+	}
+	Logger.Debug("Returning", res)
+	return res, nil
+}
+
+// ResolvedChaincodes retrieves the metadata of the given deployed chaincodes like DeployedChaincodes does,
+// but the metadata of chaincodes that are also defined in the new chaincode lifecycle namespace
+// is taken from their definitions, which supersede their LSCC instantiation data.
+func ResolvedChaincodes(q Query, filter ChaincodePredicate, loadCollections bool, chaincodes ...string) (chaincode.MetadataSet, error) {
+	defer q.Done()
+
+	res, err := deployedChaincodes(q, filter, loadCollections, chaincodes...)
+	if err != nil {
+		return nil, err
+	}
+	for i, md := range res {
+		definition, err := definedChaincode(q, md.Name, loadCollections)
+		if err != nil {
+			return nil, err
+		}
+		if definition == nil {
+			continue
+		}
+		// Definitions don't reference chaincode packages, so the chaincode
+		// is still identified by its LSCC instantiation data
+		definition.Id = md.Id
+		Logger.Debug("Chaincode", md.Name, "is defined in", LifecycleNamespace, "with version", definition.Version)
+		res[i] = *definition
+	}
+	return res, nil
+}
+
+// definedChaincode returns the metadata of the given chaincode from its definition
+// in the new chaincode lifecycle namespace, or nil if it isn't defined in it
+func definedChaincode(q Query, cc string, loadCollections bool) (*chaincode.Metadata, error) {
+	data, err := q.GetState(LifecycleNamespace, metadataKey(cc))
+	if err != nil {
+		Logger.Errorf("Failed querying %s namespace: %v", LifecycleNamespace, err)
+		return nil, errors.WithStack(err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	metadata := &lifecycle.StateMetadata{}
+	if err := proto.Unmarshal(data, metadata); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling metadata of chaincode %s", cc)
+	}
+	if metadata.Datatype != chaincodeDefinitionType {
+		return nil, errors.Errorf("%s is of type %s and not a chaincode definition", cc, metadata.Datatype)
+	}
+
+	endorsementInfo := &lifecycle.ChaincodeEndorsementInfo{}
+	if err := readField(q, cc, endorsementInfoField, endorsementInfo); err != nil {
+		return nil, err
+	}
+	validationInfo := &lifecycle.ChaincodeValidationInfo{}
+	if err := readField(q, cc, validationInfoField, validationInfo); err != nil {
+		return nil, err
+	}
+	md := &chaincode.Metadata{
+		Name:    cc,
+		Version: endorsementInfo.Version,
+	}
+	md.Policy, err = signaturePolicyOf(validationInfo.ValidationParameter)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed extracting endorsement policy of chaincode %s", cc))
+	}
+	for name, subPolicy := range validationInfo.SubPolicies {
+		policy, err := signaturePolicyOf(subPolicy)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed extracting sub-policy %s of chaincode %s", name, cc))
+		}
+		if policy == nil {
+			continue
+		}
+		if md.SubPolicies == nil {
+			md.SubPolicies = make(map[string][]byte)
+		}
+		md.SubPolicies[name] = policy
+	}
+
+	if loadCollections {
+		md.CollectionsConfig, err = readBytesField(q, cc, collectionsField)
+		if err != nil {
+			return nil, err
+		}
+		Logger.Debug("Retrieved collection config for", cc, "from", LifecycleNamespace)
+	}
+	return md, nil
+}
+
+// signaturePolicyOf returns the serialized signature policy that the given marshaled application policy
+// specifies, or nil if the chaincode is endorsed according to a policy of the channel configuration
+func signaturePolicyOf(applicationPolicy []byte) ([]byte, error) {
+	appPolicy := &lifecycle.ApplicationPolicy{}
+	if err := proto.Unmarshal(applicationPolicy, appPolicy); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling application policy")
+	}
+	if ref := appPolicy.GetChannelConfigPolicyReference(); ref != "" {
+		Logger.Warning("Chaincode is endorsed according to channel config policy", ref, "which isn't a signature policy")
+		return nil, nil
+	}
+	if appPolicy.GetSignaturePolicy() == nil {
+		return nil, errors.New("application policy is empty")
+	}
+	return proto.Marshal(appPolicy.GetSignaturePolicy())
+}
+
+// readBytesField returns the bytes that the given field of the definition of the given chaincode encodes
+func readBytesField(q Query, cc string, field string) ([]byte, error) {
+	key := fieldKey(cc, field)
+	data, err := q.GetState(LifecycleNamespace, key)
+	if err != nil {
+		Logger.Errorf("Failed querying %s namespace for %s: %v", LifecycleNamespace, key, err)
+		return nil, errors.WithStack(err)
+	}
+	stateData := &lifecycle.StateData{}
+	if err := proto.Unmarshal(data, stateData); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling %s", key)
+	}
+	return stateData.GetBytes(), nil
+}
+
+// readField unmarshals the given field of the definition of the given chaincode into the given message
+func readField(q Query, cc string, field string, msg proto.Message) error {
+	data, err := readBytesField(q, cc, field)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrapf(err, "failed unmarshaling %s of chaincode %s", field, cc)
+	}
+	return nil
+}
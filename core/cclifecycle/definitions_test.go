@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cc_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/cclifecycle"
+	"github.com/hyperledger/fabric/core/cclifecycle/mocks"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// defineChaincode makes the given query return a definition of the given chaincode
+// from the new chaincode lifecycle namespace
+func defineChaincode(query *mocks.Query, name, version string, policy *common.SignaturePolicyEnvelope, collections []byte) {
+	appPolicy := &lifecycle.ApplicationPolicy{
+		Type: &lifecycle.ApplicationPolicy_SignaturePolicy{
+			SignaturePolicy: policy,
+		},
+	}
+	defineChaincodeWithPolicy(query, name, version, appPolicy, collections)
+}
+
+func defineChaincodeWithPolicy(query *mocks.Query, name, version string, policy *lifecycle.ApplicationPolicy, collections []byte) {
+	defineChaincodeWithValidationInfo(query, name, version, &lifecycle.ChaincodeValidationInfo{
+		ValidationPlugin:    "vscc",
+		ValidationParameter: utils.MarshalOrPanic(policy),
+	}, collections)
+}
+
+func defineChaincodeWithValidationInfo(query *mocks.Query, name, version string, validationInfo *lifecycle.ChaincodeValidationInfo, collections []byte) {
+	field := func(field string, value []byte) {
+		key := fmt.Sprintf("namespaces/fields/%s/%s", name, field)
+		query.On("GetState", cc.LifecycleNamespace, key).Return(utils.MarshalOrPanic(&lifecycle.StateData{
+			Type: &lifecycle.StateData_Bytes{Bytes: value},
+		}), nil)
+	}
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/"+name).Return(utils.MarshalOrPanic(&lifecycle.StateMetadata{
+		Datatype: "ChaincodeDefinition",
+		Fields:   []string{"EndorsementInfo", "ValidationInfo", "Collections"},
+	}), nil)
+	field("EndorsementInfo", utils.MarshalOrPanic(&lifecycle.ChaincodeEndorsementInfo{
+		Version: version,
+	}))
+	field("ValidationInfo", utils.MarshalOrPanic(validationInfo))
+	field("Collections", collections)
+}
+
+func TestDefinedChaincodes(t *testing.T) {
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	query := &mocks.Query{}
+	query.On("Done")
+	defineChaincode(query, "cc1", "1.0", policy, []byte{10, 10, 10})
+	defineChaincodeWithPolicy(query, "cc2", "2.0", &lifecycle.ApplicationPolicy{
+		Type: &lifecycle.ApplicationPolicy_ChannelConfigPolicyReference{
+			ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+		},
+	}, nil)
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/cc3").Return(nil, nil)
+
+	// Scenario I: Chaincodes that aren't defined are skipped, and collections are loaded only on demand
+	md, err := cc.DefinedChaincodes(query, false, "cc1", "cc2", "cc3")
+	assert.NoError(t, err)
+	assert.Equal(t, chaincode.MetadataSet{
+		{
+			Name:    "cc1",
+			Version: "1.0",
+			Policy:  utils.MarshalOrPanic(policy),
+		},
+		{
+			// Policies that reference the channel configuration aren't signature policies
+			Name:    "cc2",
+			Version: "2.0",
+		},
+	}, md)
+
+	md, err = cc.DefinedChaincodes(query, true, "cc1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{10, 10, 10}, md[0].CollectionsConfig)
+
+	// Scenario II: The state contains something other than a chaincode definition
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/cc4").Return(utils.MarshalOrPanic(&lifecycle.StateMetadata{
+		Datatype: "ChaincodeParameters",
+	}), nil)
+	_, err = cc.DefinedChaincodes(query, false, "cc4")
+	assert.EqualError(t, err, "cc4 is of type ChaincodeParameters and not a chaincode definition")
+
+	// Scenario III: The definition of the chaincode has no endorsement policy
+	defineChaincodeWithPolicy(query, "cc5", "1.0", &lifecycle.ApplicationPolicy{}, nil)
+	_, err = cc.DefinedChaincodes(query, false, "cc5")
+	assert.EqualError(t, err, "failed extracting endorsement policy of chaincode cc5: application policy is empty")
+
+	// Scenario IV: Querying the state fails
+	query.On("GetState", cc.LifecycleNamespace, mock.Anything).Return(nil, errors.New("failed accessing DB"))
+	_, err = cc.DefinedChaincodes(query, false, "cc6")
+	assert.EqualError(t, err, "failed accessing DB")
+}
+
+func TestDefinedChaincodesSubPolicies(t *testing.T) {
+	signaturePolicy := func(policy *common.SignaturePolicyEnvelope) []byte {
+		return utils.MarshalOrPanic(&lifecycle.ApplicationPolicy{
+			Type: &lifecycle.ApplicationPolicy_SignaturePolicy{
+				SignaturePolicy: policy,
+			},
+		})
+	}
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	transferPolicy := cauthdsl.SignedByMspPeer("Org2MSP")
+	query := &mocks.Query{}
+	query.On("Done")
+	defineChaincodeWithValidationInfo(query, "cc1", "1.0", &lifecycle.ChaincodeValidationInfo{
+		ValidationPlugin:    "vscc",
+		ValidationParameter: signaturePolicy(policy),
+		SubPolicies: map[string][]byte{
+			"transfer": signaturePolicy(transferPolicy),
+			"read": utils.MarshalOrPanic(&lifecycle.ApplicationPolicy{
+				Type: &lifecycle.ApplicationPolicy_ChannelConfigPolicyReference{
+					ChannelConfigPolicyReference: "/Channel/Application/Readers",
+				},
+			}),
+		},
+	}, nil)
+	defineChaincodeWithValidationInfo(query, "cc2", "1.0", &lifecycle.ChaincodeValidationInfo{
+		ValidationPlugin:    "vscc",
+		ValidationParameter: signaturePolicy(policy),
+		SubPolicies: map[string][]byte{
+			"transfer": utils.MarshalOrPanic(&lifecycle.ApplicationPolicy{}),
+		},
+	}, nil)
+
+	// Scenario I: Sub-policies that reference the channel configuration aren't signature policies
+	md, err := cc.DefinedChaincodes(query, false, "cc1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"transfer": utils.MarshalOrPanic(transferPolicy),
+	}, md[0].SubPolicies)
+
+	// Scenario II: A sub-policy is empty
+	_, err = cc.DefinedChaincodes(query, false, "cc2")
+	assert.EqualError(t, err, "failed extracting sub-policy transfer of chaincode cc2: application policy is empty")
+}
+
+func TestResolvedChaincodes(t *testing.T) {
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	query := &mocks.Query{}
+	query.On("Done")
+	for _, name := range []string{"cc1", "cc2"} {
+		query.On("GetState", "lscc", name).Return(utils.MarshalOrPanic(&ccprovider.ChaincodeData{
+			Name:    name,
+			Version: "1.0",
+			Id:      []byte{42},
+			Policy:  []byte{1, 2, 3},
+		}), nil)
+	}
+	// cc1 was migrated to the new chaincode lifecycle, but cc2 wasn't
+	defineChaincode(query, "cc1", "2.0", policy, nil)
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/cc2").Return(nil, nil).Once()
+
+	md, err := cc.ResolvedChaincodes(query, cc.AcceptAll, false, "cc1", "cc2")
+	assert.NoError(t, err)
+	assert.Equal(t, chaincode.MetadataSet{
+		{
+			Name:    "cc1",
+			Version: "2.0",
+			Id:      []byte{42},
+			Policy:  utils.MarshalOrPanic(policy),
+		},
+		{
+			Name:    "cc2",
+			Version: "1.0",
+			Id:      []byte{42},
+			Policy:  []byte{1, 2, 3},
+		},
+	}, md)
+
+	// Failures to read definitions fail the resolution
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/cc2").Return(nil, errors.New("failed accessing DB"))
+	_, err = cc.ResolvedChaincodes(query, cc.AcceptAll, false, "cc2")
+	assert.EqualError(t, err, "failed accessing DB")
+}
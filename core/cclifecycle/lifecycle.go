@@ -107,24 +107,38 @@ func NewLifeCycle(installedChaincodes Enumerator) (*Lifecycle, error) {
 }
 
 // Metadata returns the metadata of the chaincode on the given channel,
-// or nil if not found or an error occurred at retrieving it
+// or nil if not found or an error occurred at retrieving it.
+// If the chaincode is defined in the new chaincode lifecycle, its definition
+// is preferred over its LSCC instantiation data.
 func (lc *Lifecycle) Metadata(channel string, cc string, collections bool) *chaincode.Metadata {
 	queryCreator := lc.queryCreatorsByChannel[channel]
 	if queryCreator == nil {
 		Logger.Warning("Requested Metadata for non-existent channel", channel)
 		return nil
 	}
+	query, err := queryCreator.NewQuery()
+	if err != nil {
+		Logger.Error("Failed obtaining new query for channel", channel, ":", err)
+		return nil
+	}
+	definition, err := definedChaincode(query, cc, collections)
+	if err != nil {
+		query.Done()
+		Logger.Error("Failed querying", LifecycleNamespace, "for channel", channel, ":", err)
+		return nil
+	}
+	if definition != nil {
+		query.Done()
+		Logger.Debug("Returning definition for channel", channel, ", chaincode", cc, ":", definition)
+		return definition
+	}
 	// Search the metadata in our local cache, and if it exists - return it, but only if
 	// no collections were specified in the invocation.
 	if md, found := lc.deployedCCsByChannel[channel].Lookup(cc); found && !collections {
+		query.Done()
 		Logger.Debug("Returning metadata for channel", channel, ", chaincode", cc, ":", md)
 		return &md
 	}
-	query, err := queryCreator.NewQuery()
-	if err != nil {
-		Logger.Error("Failed obtaining new query for channel", channel, ":", err)
-		return nil
-	}
 	md, err := DeployedChaincodes(query, AcceptAll, collections, cc)
 	if err != nil {
 		Logger.Error("Failed querying LSCC for channel", channel, ":", err)
@@ -147,7 +161,7 @@ func (lc *Lifecycle) initMetadataForChannel(channel string, queryCreator QueryCr
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	ccs, err := queryChaincodeDefinitions(query, lc.installedCCs, DeployedChaincodes)
+	ccs, err := queryChaincodeDefinitions(query, lc.installedCCs, ResolvedChaincodes)
 	if err != nil {
 		return errors.WithStack(err)
 	}
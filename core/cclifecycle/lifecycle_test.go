@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/cclifecycle"
@@ -96,6 +97,7 @@ func TestHandleChaincodeDeployGreenPath(t *testing.T) {
 	query.On("GetState", "lscc", "cc1").Return(cc1Bytes, nil)
 	query.On("GetState", "lscc", "cc2").Return(cc2Bytes, nil)
 	query.On("GetState", "lscc", "cc3").Return(cc3Bytes, nil).Once()
+	query.On("GetState", cc.LifecycleNamespace, mock.Anything).Return(nil, nil)
 	query.On("Done")
 	queryCreator := &mocks.QueryCreator{}
 	queryCreator.On("NewQuery").Return(query, nil)
@@ -204,6 +206,7 @@ func TestHandleChaincodeDeployFailures(t *testing.T) {
 	})
 
 	query := &mocks.Query{}
+	query.On("GetState", cc.LifecycleNamespace, mock.Anything).Return(nil, nil)
 	query.On("Done")
 	queryCreator := &mocks.QueryCreator{}
 	enum := &mocks.Enumerator{}
@@ -289,6 +292,8 @@ func TestMetadata(t *testing.T) {
 
 	query := &mocks.Query{}
 	query.On("GetState", "lscc", "cc3").Return(cc1Bytes, nil)
+	// None of the chaincodes are defined in the new chaincode lifecycle
+	query.On("GetState", cc.LifecycleNamespace, mock.Anything).Return(nil, nil)
 	query.On("Done")
 	queryCreator := &mocks.QueryCreator{}
 
@@ -317,6 +322,7 @@ func TestMetadata(t *testing.T) {
 	defer sub.ChaincodeDeployDone(true)
 	assert.NoError(t, err)
 	assert.NotNil(t, sub)
+	queryCreator.On("NewQuery").Return(query, nil).Once()
 	md = lc.Metadata("mychannel", "cc1", false)
 	assert.Equal(t, &chaincode.Metadata{
 		Name:    "cc1",
@@ -387,6 +393,59 @@ func TestMetadata(t *testing.T) {
 	logger.AssertLogged("Failed querying lscc namespace for cc1~collection: foo")
 }
 
+func TestMetadataPrefersLifecycleDefinitions(t *testing.T) {
+	logger, restoreLogger := newLogAsserter(t)
+	defer restoreLogger()
+
+	legacyBytes := utils.MarshalOrPanic(&ccprovider.ChaincodeData{
+		Name:    "cc1",
+		Version: "1.0",
+		Id:      []byte{42},
+		Policy:  []byte{1, 2, 3, 4, 5},
+	})
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+
+	query := &mocks.Query{}
+	query.On("GetState", "lscc", "cc1").Return(legacyBytes, nil)
+	query.On("Done")
+	queryCreator := &mocks.QueryCreator{}
+	queryCreator.On("NewQuery").Return(query, nil)
+
+	enum := &mocks.Enumerator{}
+	enum.On("Enumerate").Return([]chaincode.InstalledChaincode{
+		{
+			Name:    "cc1",
+			Version: "1.0",
+			Id:      []byte{42},
+		},
+	}, nil)
+	lc, err := cc.NewLifeCycle(enum)
+	assert.NoError(t, err)
+
+	// Scenario I: The chaincode was migrated to the new chaincode lifecycle,
+	// so its definition is preferred over its LSCC instantiation data, even though the latter is cached
+	defineChaincode(query, "cc1", "2.0", policy, []byte{10, 10, 10})
+	sub, err := lc.NewChannelSubscription("mychannel", queryCreator)
+	defer sub.ChaincodeDeployDone(true)
+	assert.NoError(t, err)
+	md := lc.Metadata("mychannel", "cc1", false)
+	assert.Equal(t, &chaincode.Metadata{
+		Name:    "cc1",
+		Version: "2.0",
+		Policy:  utils.MarshalOrPanic(policy),
+	}, md)
+	logger.AssertLogged("Returning definition for channel mychannel , chaincode cc1")
+
+	md = lc.Metadata("mychannel", "cc1", true)
+	assert.Equal(t, []byte{10, 10, 10}, md.CollectionsConfig)
+
+	// Scenario II: The definition of the chaincode is corrupt
+	query.On("GetState", cc.LifecycleNamespace, "namespaces/metadata/cc2").Return([]byte{1, 2, 3}, nil)
+	md = lc.Metadata("mychannel", "cc2", false)
+	assert.Nil(t, md)
+	logger.AssertLogged("Failed querying _lifecycle for channel mychannel : failed unmarshaling metadata of chaincode cc2")
+}
+
 type logAsserter struct {
 	logEntries chan string
 	t          *testing.T
@@ -45,7 +45,7 @@ func (sub *Subscription) processPendingUpdate(ccDef *cceventmgmt.ChaincodeDefini
 		Version: ccDef.Version,
 		Id:      ccDef.Hash,
 	}}
-	ccs, err := queryChaincodeDefinitions(query, installedCC, DeployedChaincodes)
+	ccs, err := queryChaincodeDefinitions(query, installedCC, ResolvedChaincodes)
 	if err != nil {
 		Logger.Errorf("Query for channel %s for %v failed with error %v", sub.channel, ccDef, err)
 		return
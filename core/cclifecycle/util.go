@@ -79,7 +79,10 @@ type ChaincodePredicate func(cc chaincode.Metadata) bool
 // DeployedChaincodes retrieves the metadata of the given deployed chaincodes
 func DeployedChaincodes(q Query, filter ChaincodePredicate, loadCollections bool, chaincodes ...string) (chaincode.MetadataSet, error) {
 	defer q.Done()
+	return deployedChaincodes(q, filter, loadCollections, chaincodes...)
+}
 
+func deployedChaincodes(q Query, filter ChaincodePredicate, loadCollections bool, chaincodes ...string) (chaincode.MetadataSet, error) {
 	var res chaincode.MetadataSet
 	for _, cc := range chaincodes {
 		data, err := q.GetState("lscc", cc)
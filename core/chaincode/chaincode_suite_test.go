@@ -38,11 +38,26 @@ type resultsIterator interface {
 	commonledger.ResultsIterator
 }
 
+//go:generate counterfeiter -o mock/query_results_iterator.go --fake-name QueryResultsIterator . queryResultsIterator
+type queryResultsIterator interface {
+	ledger.QueryResultsIterator
+}
+
 //go:generate counterfeiter -o mock/runtime.go --fake-name Runtime . chaincodeRuntime
 type chaincodeRuntime interface {
 	chaincode.Runtime
 }
 
+//go:generate counterfeiter -o mock/image_builder.go --fake-name ImageBuilder . imageBuilder
+type imageBuilder interface {
+	chaincode.ImageBuilder
+}
+
+//go:generate counterfeiter -o mock/launcher.go --fake-name Launcher . launcher
+type launcher interface {
+	chaincode.Launcher
+}
+
 //go:generate counterfeiter -o mock/cert_generator.go --fake-name CertGenerator . certGenerator
 type certGenerator interface {
 	chaincode.CertGenerator
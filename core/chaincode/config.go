@@ -29,6 +29,13 @@ type Config struct {
 	LogFormat      string
 	LogLevel       string
 	ShimLogLevel   string
+
+	// WarmUpChaincodes are the names of the chaincodes that are warmed up
+	// when the peer starts, all of them if it holds "*"
+	WarmUpChaincodes []string
+	// WarmUpLaunch launches the chaincodes that are warmed up, besides
+	// building their images
+	WarmUpLaunch bool
 }
 
 func GlobalConfig() *Config {
@@ -58,6 +65,9 @@ func (c *Config) load() {
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
+
+	c.WarmUpChaincodes = viper.GetStringSlice("chaincode.warmup.chaincodes")
+	c.WarmUpLaunch = viper.GetBool("chaincode.warmup.launch")
 }
 
 func toSeconds(s string, def int) time.Duration {
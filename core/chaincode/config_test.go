@@ -36,6 +36,8 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "WARNING")
 			viper.Set("chaincode.logging.shim", "WARNING")
+			viper.Set("chaincode.warmup.chaincodes", []string{"mycc", "othercc"})
+			viper.Set("chaincode.warmup.launch", true)
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
@@ -45,6 +47,8 @@ var _ = Describe("Config", func() {
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("WARNING"))
 			Expect(config.ShimLogLevel).To(Equal("WARNING"))
+			Expect(config.WarmUpChaincodes).To(Equal([]string{"mycc", "othercc"}))
+			Expect(config.WarmUpLaunch).To(BeTrue())
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -113,13 +117,15 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":            viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":         viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":    viper.GetString("chaincode.executetimeout"),
+		"chaincode.startuptimeout":    viper.GetString("chaincode.startuptimeout"),
+		"chaincode.logging.format":    viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":     viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":      viper.GetString("chaincode.logging.shim"),
+		"chaincode.warmup.chaincodes": viper.GetString("chaincode.warmup.chaincodes"),
+		"chaincode.warmup.launch":     viper.GetString("chaincode.warmup.launch"),
 	}
 
 	return func() {
@@ -97,6 +97,25 @@ func (c *ContainerRuntime) Stop(ctxt context.Context, cccid *ccprovider.CCContex
 	return nil
 }
 
+// Build builds the image chaincode is launched from, unless it already exists.
+func (c *ContainerRuntime) Build(ctxt context.Context, cccid *ccprovider.CCContext, cds *pb.ChaincodeDeploymentSpec) error {
+	bir := container.BuildImageReq{
+		Builder: &container.PlatformBuilder{
+			DeploymentSpec: cds,
+		},
+		CCID: ccintf.CCID{
+			Name:    cds.ChaincodeSpec.ChaincodeId.Name,
+			Version: cccid.Version,
+		},
+	}
+
+	if err := c.Processor.Process(ctxt, getVMType(cds), bir); err != nil {
+		return errors.WithMessage(err, "error building image")
+	}
+
+	return nil
+}
+
 func getVMType(cds *pb.ChaincodeDeploymentSpec) string {
 	if cds.ExecEnv == pb.ChaincodeDeploymentSpec_SYSTEM {
 		return inproccontroller.ContainerType
@@ -320,3 +320,39 @@ func TestContainerRuntimeStopErrors(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestContainerRuntimeBuild(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor: fakeProcessor,
+	}
+
+	ccctx := ccprovider.NewCCContext("context-chain-id", "context-name", "context-version", "", false, nil, nil)
+	cds := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type: pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{
+				Name: "chaincode-id-name",
+			},
+		},
+	}
+
+	err := cr.Build(context.Background(), ccctx, cds)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	ctx, vmType, req := fakeProcessor.ProcessArgsForCall(0)
+	assert.Equal(t, context.Background(), ctx)
+	assert.Equal(t, dockercontroller.ContainerType, vmType)
+	buildReq, ok := req.(container.BuildImageReq)
+	assert.True(t, ok)
+	assert.Equal(t, &container.PlatformBuilder{DeploymentSpec: cds}, buildReq.Builder)
+	assert.Equal(t, ccintf.CCID{
+		Name:    "chaincode-id-name",
+		Version: "context-version",
+	}, buildReq.CCID)
+
+	fakeProcessor.ProcessReturns(errors.New("process-failed"))
+	err = cr.Build(context.Background(), ccctx, cds)
+	assert.EqualError(t, err, "error building image: process-failed")
+}
@@ -557,6 +557,10 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
+	if len(getStateByRange.Metadata) > 0 {
+		return h.handleGetStateByRangeWithPagination(msg, getStateByRange, txContext)
+	}
+
 	iterID := h.UUIDGenerator.New()
 	chaincodeName := h.ChaincodeName()
 
@@ -587,6 +591,72 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// handleGetStateByRangeWithPagination returns a page of the results of a range query,
+// starting at the bookmark of the query metadata, along with the bookmark of the next page
+func (h *Handler) handleGetStateByRangeWithPagination(msg *pb.ChaincodeMessage, getStateByRange *pb.GetStateByRange, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	queryMetadata := &pb.QueryMetadata{}
+	if err := proto.Unmarshal(getStateByRange.Metadata, queryMetadata); err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if queryMetadata.PageSize <= 0 {
+		return nil, errors.Errorf("invalid page size %d, it must be positive", queryMetadata.PageSize)
+	}
+	if isCollectionSet(getStateByRange.Collection) {
+		return nil, errors.New("paginated range queries are not supported for private data")
+	}
+
+	startKey := getStateByRange.StartKey
+	if bookmark := queryMetadata.Bookmark; bookmark != "" {
+		if bookmark < startKey || (getStateByRange.EndKey != "" && bookmark >= getStateByRange.EndKey) {
+			return nil, errors.Errorf("bookmark %s is out of the range [%s, %s)", bookmark, startKey, getStateByRange.EndKey)
+		}
+		startKey = bookmark
+	}
+
+	rangeIter, err := txContext.TXSimulator.GetStateRangeScanIteratorWithMetadata(h.ChaincodeName(), startKey, getStateByRange.EndKey,
+		map[string]interface{}{"limit": queryMetadata.PageSize})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var pendingResult PendingQueryResult
+	for {
+		queryResult, err := rangeIter.Next()
+		if err != nil {
+			rangeIter.Close()
+			return nil, errors.WithStack(err)
+		}
+		if queryResult == nil {
+			break
+		}
+		if err := pendingResult.Add(queryResult); err != nil {
+			rangeIter.Close()
+			return nil, errors.WithStack(err)
+		}
+	}
+	results := pendingResult.Cut()
+
+	responseMetadata, err := proto.Marshal(&pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            rangeIter.GetBookmarkAndClose(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+	payloadBytes, err := proto.Marshal(&pb.QueryResponse{
+		Results:  results,
+		HasMore:  false,
+		Id:       h.UUIDGenerator.New(),
+		Metadata: responseMetadata,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	chaincodeLogger.Debugf("Got a page of %d keys and values. Sending %s", len(results), pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles query to ledger for query state next
 func (h *Handler) HandleQueryStateNext(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	queryStateNext := &pb.QueryStateNext{}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -822,6 +823,118 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the query is paginated", func() {
+			var (
+				fakeQueryResultsIterator *mock.QueryResultsIterator
+				queryMetadata            *pb.QueryMetadata
+			)
+
+			marshalRequest := func() {
+				metadata, err := proto.Marshal(queryMetadata)
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			}
+
+			BeforeEach(func() {
+				queryMetadata = &pb.QueryMetadata{PageSize: 2, Bookmark: "get-state-mid-key"}
+				marshalRequest()
+
+				fakeQueryResultsIterator = &mock.QueryResultsIterator{}
+				fakeQueryResultsIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "key1", Value: []byte("value1")}, nil)
+				fakeQueryResultsIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "key2", Value: []byte("value2")}, nil)
+				fakeQueryResultsIterator.NextReturnsOnCall(2, nil, nil)
+				fakeQueryResultsIterator.GetBookmarkAndCloseReturns("key3")
+				fakeTxSimulator.GetStateRangeScanIteratorWithMetadataReturns(fakeQueryResultsIterator, nil)
+			})
+
+			It("calls GetStateRangeScanIteratorWithMetadata starting at the bookmark", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.GetStateRangeScanIteratorCallCount()).To(Equal(0))
+				Expect(fakeTxSimulator.GetStateRangeScanIteratorWithMetadataCallCount()).To(Equal(1))
+				ccname, startKey, endKey, metadata := fakeTxSimulator.GetStateRangeScanIteratorWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(startKey).To(Equal("get-state-mid-key"))
+				Expect(endKey).To(Equal("get-state-end-key"))
+				Expect(metadata).To(Equal(map[string]interface{}{"limit": int32(2)}))
+			})
+
+			It("returns the page of results along with the bookmark of the next page", func() {
+				resp, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeQueryResultsIterator.GetBookmarkAndCloseCallCount()).To(Equal(1))
+
+				queryResponse := &pb.QueryResponse{}
+				err = proto.Unmarshal(resp.Payload, queryResponse)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(queryResponse.HasMore).To(BeFalse())
+				Expect(queryResponse.Results).To(HaveLen(2))
+
+				responseMetadata := &pb.QueryResponseMetadata{}
+				err = proto.Unmarshal(queryResponse.Metadata, responseMetadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(responseMetadata).To(Equal(&pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "key3"}))
+			})
+
+			It("doesn't initialize a query context", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txContext.GetQueryIterator("generated-query-id")).To(BeNil())
+			})
+
+			Context("and the page size isn't positive", func() {
+				BeforeEach(func() {
+					queryMetadata.PageSize = 0
+					marshalRequest()
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("invalid page size 0, it must be positive"))
+				})
+			})
+
+			Context("and the bookmark is out of the range", func() {
+				BeforeEach(func() {
+					queryMetadata.Bookmark = "get-state-z-key"
+					marshalRequest()
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("bookmark get-state-z-key is out of the range [get-state-start-key, get-state-end-key)"))
+				})
+			})
+
+			Context("and collection is set", func() {
+				BeforeEach(func() {
+					request.Collection = "collection-name"
+					marshalRequest()
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("paginated range queries are not supported for private data"))
+				})
+			})
+
+			Context("and the iterator fails", func() {
+				BeforeEach(func() {
+					fakeQueryResultsIterator.NextReturnsOnCall(1, nil, errors.New("mushrooms"))
+				})
+
+				It("closes the iterator and returns the error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("mushrooms"))
+					Expect(fakeQueryResultsIterator.CloseCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
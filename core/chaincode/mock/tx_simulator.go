@@ -310,6 +310,22 @@ type TxSimulator struct {
 		result1 *ledger.TxSimulationResults
 		result2 error
 	}
+	GetStateRangeScanIteratorWithMetadataStub        func(string, string, string, map[string]interface{}) (ledger.QueryResultsIterator, error)
+	getStateRangeScanIteratorWithMetadataMutex       sync.RWMutex
+	getStateRangeScanIteratorWithMetadataArgsForCall []struct {
+		namespace string
+		startKey  string
+		endKey    string
+		metadata  map[string]interface{}
+	}
+	getStateRangeScanIteratorWithMetadataReturns struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorWithMetadataReturnsOnCall map[int]struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1467,6 +1483,60 @@ func (fake *TxSimulator) GetTxSimulationResultsReturnsOnCall(i int, result1 *led
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	fake.getStateRangeScanIteratorWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorWithMetadataReturnsOnCall[len(fake.getStateRangeScanIteratorWithMetadataArgsForCall)]
+	fake.getStateRangeScanIteratorWithMetadataArgsForCall = append(fake.getStateRangeScanIteratorWithMetadataArgsForCall, struct {
+		namespace string
+		startKey  string
+		endKey    string
+		metadata  map[string]interface{}
+	}{namespace, startKey, endKey, metadata})
+	fake.recordInvocation("GetStateRangeScanIteratorWithMetadata", []interface{}{namespace, startKey, endKey, metadata})
+	fake.getStateRangeScanIteratorWithMetadataMutex.Unlock()
+	if fake.GetStateRangeScanIteratorWithMetadataStub != nil {
+		return fake.GetStateRangeScanIteratorWithMetadataStub(namespace, startKey, endKey, metadata)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateRangeScanIteratorWithMetadataReturns.result1, fake.getStateRangeScanIteratorWithMetadataReturns.result2
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorWithMetadataCallCount() int {
+	fake.getStateRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getStateRangeScanIteratorWithMetadataMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorWithMetadataArgsForCall)
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorWithMetadataArgsForCall(i int) (string, string, string, map[string]interface{}) {
+	fake.getStateRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getStateRangeScanIteratorWithMetadataMutex.RUnlock()
+	return fake.getStateRangeScanIteratorWithMetadataArgsForCall[i].namespace, fake.getStateRangeScanIteratorWithMetadataArgsForCall[i].startKey, fake.getStateRangeScanIteratorWithMetadataArgsForCall[i].endKey, fake.getStateRangeScanIteratorWithMetadataArgsForCall[i].metadata
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorWithMetadataReturns(result1 ledger.QueryResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorWithMetadataStub = nil
+	fake.getStateRangeScanIteratorWithMetadataReturns = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorWithMetadataReturnsOnCall(i int, result1 ledger.QueryResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorWithMetadataStub = nil
+	if fake.getStateRangeScanIteratorWithMetadataReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorWithMetadataReturnsOnCall[i] = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1516,6 +1586,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.deletePrivateDataMetadataMutex.RUnlock()
 	fake.getTxSimulationResultsMutex.RLock()
 	defer fake.getTxSimulationResultsMutex.RUnlock()
+	fake.getStateRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getStateRangeScanIteratorWithMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
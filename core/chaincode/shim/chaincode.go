@@ -481,13 +481,32 @@ const (
 )
 
 func (stub *ChaincodeStub) handleGetStateByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetStateByRange(collection, startKey, endKey, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetStateByRange(collection, startKey, endKey, nil, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &StateQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+func (stub *ChaincodeStub) handleGetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if pageSize <= 0 {
+		return nil, nil, errors.Errorf("page size must be positive, got %d", pageSize)
+	}
+	//we constructed a valid object. No need to check for error
+	metadata, _ := proto.Marshal(&pb.QueryMetadata{PageSize: pageSize, Bookmark: bookmark})
+	// Paginated queries access public data only, by setting the collection to empty string
+	collection := ""
+	response, err := stub.handler.handleGetStateByRange(collection, startKey, endKey, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseMetadata := &pb.QueryResponseMetadata{}
+	if err := proto.Unmarshal(response.Metadata, responseMetadata); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal query response metadata")
+	}
+	return &StateQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, responseMetadata, nil
+}
+
 // GetStateByRange documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if startKey == "" {
@@ -500,6 +519,17 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 	return stub.handleGetStateByRange(collection, startKey, endKey)
 }
 
+// GetStateByRangeWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetStateByRangeWithPagination(startKey, endKey, pageSize, bookmark)
+}
+
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
 	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
@@ -586,6 +616,15 @@ func (stub *ChaincodeStub) GetStateByPartialCompositeKey(objectType string, attr
 	}
 }
 
+// GetStateByPartialCompositeKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetStateByRangeWithPagination(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue), pageSize, bookmark)
+}
+
 func (iter *StateQueryIterator) Next() (*queryresult.KV, error) {
 	if result, err := iter.nextResult(STATE_QUERY_RESULT); err == nil {
 		return result.(*queryresult.KV), err
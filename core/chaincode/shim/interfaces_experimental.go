@@ -110,6 +110,18 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error)
 
+	// GetStateByRangeWithPagination returns a range iterator over a page of the
+	// keys between the startKey (inclusive) and endKey (exclusive), in lexical
+	// order. At most pageSize keys are returned, starting at the bookmark, or at
+	// the startKey if the bookmark is empty. The returned QueryResponseMetadata
+	// holds the number of keys fetched and the bookmark of the next page, which is
+	// empty once all the keys in the range have been returned.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// Paginated queries are supported only in read-only transactions, since
+	// the query is not re-executed during validation phase.
+	GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
+		bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetStateByPartialCompositeKey queries the state in the ledger based on
 	// a given partial composite key. This function returns an iterator
 	// which can be used to iterate over all composite keys whose prefix matches
@@ -122,6 +134,19 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetStateByPartialCompositeKey(objectType string, keys []string) (StateQueryIteratorInterface, error)
 
+	// GetStateByPartialCompositeKeyWithPagination queries a page of the composite
+	// keys whose prefix matches the given partial composite key, like
+	// GetStateByPartialCompositeKey. At most pageSize keys are returned, starting
+	// at the bookmark, or at the first matching key if the bookmark is empty. The
+	// returned QueryResponseMetadata holds the number of keys fetched and the
+	// bookmark of the next page, which is empty once all the matching keys have
+	// been returned.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// Paginated queries are supported only in read-only transactions, since
+	// the query is not re-executed during validation phase.
+	GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
+		pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// CreateCompositeKey combines the given `attributes` to form a composite
 	// key. The objectType and attributes are expected to have only valid utf8
 	// strings and should not contain U+0000 (nil byte) and U+10FFFF
@@ -255,6 +255,8 @@ func TestMockMock(t *testing.T) {
 	stub.DelState("dummy")
 	stub.GetStateByRange("start", "end")
 	stub.GetQueryResult("q")
+	stub.GetStateByRangeWithPagination("start", "end", 10, "")
+	stub.GetStateByPartialCompositeKeyWithPagination("obj", nil, 10, "")
 	stub2 := NewMockStub("othercc", &shimTestCC{})
 	stub.MockPeerChaincode("othercc/mychan", stub2)
 	stub.InvokeChaincode("othercc", nil, "mychan")
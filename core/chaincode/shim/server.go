@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a chaincode server
+type TLSProperties struct {
+	// Disabled disables TLS, which is enabled by default
+	Disabled bool
+	// Key is the PEM encoded private key of the server
+	Key []byte
+	// Cert is the PEM encoded certificate of the server
+	Cert []byte
+	// ClientCACerts are the PEM encoded certificates of the CAs of the peers.
+	// If any are set, peers must authenticate with a certificate issued by one of them.
+	ClientCACerts [][]byte
+}
+
+// ChaincodeServer runs the chaincode as a server that peers connect to, instead of
+// the chaincode connecting to the peer that launched it. This allows chaincode to
+// be deployed and managed independently of the peers, e.g. as a Kubernetes service.
+type ChaincodeServer struct {
+	// CCID is the name and version the chaincode registers with at the peers, as name:version
+	CCID string
+	// Address is the address the server listens on
+	Address string
+	// CC is the chaincode the server serves
+	CC Chaincode
+	// TLSProps are the TLS settings of the server
+	TLSProps TLSProperties
+}
+
+// Start starts serving the chaincode, and blocks until the server stops
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+	if err := factory.InitFactories(factory.GetDefaultOpts()); err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	secOpts := &comm.SecureOptions{UseTLS: !cs.TLSProps.Disabled}
+	if secOpts.UseTLS {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return errors.New("key and certificate must be specified when TLS is enabled")
+		}
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		secOpts.ClientRootCAs = cs.TLSProps.ClientCACerts
+		secOpts.RequireClientCert = len(cs.TLSProps.ClientCACerts) > 0
+	}
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		// Peers ping the chaincode to check its health, hence they are allowed to ping frequently
+		KaOpts: &comm.KeepaliveOptions{
+			ServerInterval:    time.Minute,
+			ServerTimeout:     20 * time.Second,
+			ServerMinInterval: 15 * time.Second,
+		},
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating chaincode server")
+	}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	chaincodeLogger.Infof("Chaincode %s is serving at %s", cs.CCID, cs.Address)
+	return server.Start()
+}
+
+// Connect registers the chaincode on the stream of a peer that connected to the server,
+// and handles the messages of the peer until the stream breaks
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	chaincodeLogger.Infof("Peer connected to chaincode %s", cs.CCID)
+	return chatWithPeer(cs.CCID, &serverStream{Chaincode_ConnectServer: stream}, cs.CC)
+}
+
+// serverStream is the stream of a peer that connected to the chaincode server. The
+// stream is closed by the server once Connect returns, hence closing it is a no-op.
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+func (ss *serverStream) CloseSend() error {
+	return nil
+}
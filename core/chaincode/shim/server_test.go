@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaincodeServerStart(t *testing.T) {
+	// Scenario I: The ccid isn't specified
+	cs := &ChaincodeServer{}
+	assert.EqualError(t, cs.Start(), "ccid must be specified")
+
+	// Scenario II: The address isn't specified
+	cs.CCID = "mycc:1.0"
+	assert.EqualError(t, cs.Start(), "address must be specified")
+
+	// Scenario III: The chaincode isn't specified
+	cs.Address = "127.0.0.1:0"
+	assert.EqualError(t, cs.Start(), "chaincode must be specified")
+
+	// Scenario IV: TLS is enabled without a key and a certificate
+	cs.CC = &shimTestCC{}
+	assert.EqualError(t, cs.Start(), "key and certificate must be specified when TLS is enabled")
+
+	// Scenario V: The server can't listen on the address
+	cs.TLSProps.Disabled = true
+	cs.Address = "nonexistent:-1"
+	assert.Contains(t, cs.Start().Error(), "failed creating chaincode server")
+}
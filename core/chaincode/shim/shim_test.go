@@ -90,6 +90,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.cc2cc(stub, args)
 	} else if function == "rangeq" {
 		return t.rangeq(stub, args)
+	} else if function == "rangeqpaged" {
+		return t.rangeqpaged(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
 	} else if function == "richq" {
@@ -297,6 +299,26 @@ func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Respon
 	return Success(buffer.Bytes())
 }
 
+// rangeqpaged calls paginated range query and returns the bookmark of the next page
+func (t *shimTestCC) rangeqpaged(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 3 {
+		return Error("Incorrect number of arguments. Expecting keys for range query and a bookmark")
+	}
+
+	resultsIterator, metadata, err := stub.GetStateByRangeWithPagination(args[0], args[1], 2, args[2])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	for resultsIterator.HasNext() {
+		if _, err := resultsIterator.Next(); err != nil {
+			return Error(err.Error())
+		}
+	}
+	return Success([]byte(metadata.Bookmark))
+}
+
 // rangeq calls range query
 func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) < 1 {
@@ -755,6 +777,25 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//paginated range query
+
+	//create the response
+	pagedQueryResponse := &pb.QueryResponse{Results: rangeQueryResponse.Results, HasMore: false,
+		Metadata: utils.MarshalOrPanic(&pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "C"})}
+
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE, Txid: "6d", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(pagedQueryResponse), Txid: "6d", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "6d", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "6d", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "6d", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("rangeqpaged"), []byte("A"), []byte("D"), []byte("")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "6d", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//history query
 
 	//create the response
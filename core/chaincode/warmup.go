@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// AllChaincodes allows all chaincodes to be warmed up
+const AllChaincodes = "*"
+
+// ImageBuilder builds the images chaincode is launched from.
+type ImageBuilder interface {
+	Build(ctxt context.Context, cccid *ccprovider.CCContext, cds *pb.ChaincodeDeploymentSpec) error
+}
+
+// QueryExecutorCreator creates query executors on the state of a channel.
+type QueryExecutorCreator func(channel string) (ledger.QueryExecutor, error)
+
+// WarmUp builds the images of the chaincodes instantiated on the channels of
+// the peer when it starts, and optionally launches them, so that the first
+// invocations of the chaincodes after a restart don't wait for their images
+// to be built and for them to be launched.
+type WarmUp struct {
+	// Chaincodes are the names of the chaincodes that are warmed up, all of
+	// them if it holds AllChaincodes
+	Chaincodes []string
+	// Launch launches the chaincodes, besides building their images
+	Launch          bool
+	Builder         ImageBuilder
+	Launcher        Launcher
+	PackageProvider PackageProvider
+}
+
+// NewWarmUp creates a WarmUp of the chaincodes allowed by the configuration,
+// which are built and launched by the given ChaincodeSupport.
+func NewWarmUp(config *Config, cs *ChaincodeSupport, packageProvider PackageProvider) *WarmUp {
+	builder, _ := cs.Runtime.(ImageBuilder)
+	return &WarmUp{
+		Chaincodes:      config.WarmUpChaincodes,
+		Launch:          config.WarmUpLaunch,
+		Builder:         builder,
+		Launcher:        cs,
+		PackageProvider: packageProvider,
+	}
+}
+
+// Run warms up the allowed chaincodes that are instantiated on the given
+// channels and installed on the peer. Chaincodes that fail to warm up are
+// built and launched by their first invocation, as usual.
+func (w *WarmUp) Run(channels []string, newQueryExecutor QueryExecutorCreator) {
+	if len(w.Chaincodes) == 0 {
+		return
+	}
+
+	warmedUp := map[string]struct{}{}
+	for _, channel := range channels {
+		chaincodes, err := instantiatedChaincodes(channel, newQueryExecutor)
+		if err != nil {
+			chaincodeLogger.Warningf("Failed retrieving the chaincodes instantiated on channel %s: %+v", channel, err)
+			continue
+		}
+		for _, cd := range chaincodes {
+			cname := cd.Name + ":" + cd.Version
+			if _, exists := warmedUp[cname]; exists || !w.allowed(cd.Name) {
+				continue
+			}
+			warmedUp[cname] = struct{}{}
+
+			ccpack, err := w.PackageProvider.GetChaincode(cd.Name, cd.Version)
+			if err != nil {
+				chaincodeLogger.Debugf("Chaincode %s isn't warmed up, as it isn't installed: %s", cname, err)
+				continue
+			}
+			if err := w.warmUp(channel, cd, ccpack.GetDepSpec()); err != nil {
+				chaincodeLogger.Warningf("Failed warming up chaincode %s: %+v", cname, err)
+				continue
+			}
+			chaincodeLogger.Infof("Warmed up chaincode %s", cname)
+		}
+	}
+}
+
+func (w *WarmUp) allowed(name string) bool {
+	for _, allowed := range w.Chaincodes {
+		if allowed == name || allowed == AllChaincodes {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *WarmUp) warmUp(channel string, cd *ccprovider.ChaincodeData, cds *pb.ChaincodeDeploymentSpec) error {
+	cccid := ccprovider.NewCCContext(channel, cd.Name, cd.Version, "", false, nil, nil)
+	// Launching the chaincode builds its image if it doesn't exist
+	if w.Launch {
+		return w.Launcher.Launch(context.Background(), cccid, cds)
+	}
+	if w.Builder == nil {
+		return nil
+	}
+	return w.Builder.Build(context.Background(), cccid, cds)
+}
+
+// instantiatedChaincodes returns the definitions of the chaincodes that are
+// instantiated on the channel, as recorded in the state of lscc.
+func instantiatedChaincodes(channel string, newQueryExecutor QueryExecutorCreator) ([]*ccprovider.ChaincodeData, error) {
+	qe, err := newQueryExecutor(channel)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating query executor")
+	}
+	defer qe.Done()
+
+	itr, err := qe.GetStateRangeScanIterator("lscc", "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed querying lscc namespace")
+	}
+	defer itr.Close()
+
+	var chaincodes []*ccprovider.ChaincodeData
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed querying lscc namespace")
+		}
+		if res == nil {
+			return chaincodes, nil
+		}
+		kv := res.(*queryresult.KV)
+		// The collection configs of chaincodes are stored in lscc along their definitions
+		if privdata.IsCollectionConfigKey(kv.Key) {
+			continue
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(kv.Value, cd); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling definition of chaincode %s", kv.Key)
+		}
+		chaincodes = append(chaincodes, cd)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("WarmUp", func() {
+	var (
+		fakeBuilder         *mock.ImageBuilder
+		fakeLauncher        *mock.Launcher
+		fakePackageProvider *mock.PackageProvider
+		queryExecutors      map[string]*mock.TxSimulator
+		deploymentSpec      *pb.ChaincodeDeploymentSpec
+
+		warmUp *chaincode.WarmUp
+	)
+
+	definition := func(name, version string) *queryresult.KV {
+		value, err := proto.Marshal(&ccprovider.ChaincodeData{Name: name, Version: version})
+		Expect(err).NotTo(HaveOccurred())
+		return &queryresult.KV{Namespace: "lscc", Key: name, Value: value}
+	}
+
+	lsccState := func(kvs ...*queryresult.KV) *mock.ResultsIterator {
+		itr := &mock.ResultsIterator{}
+		for i, kv := range kvs {
+			itr.NextReturnsOnCall(i, kv, nil)
+		}
+		return itr
+	}
+
+	newQueryExecutor := func(channel string) (ledger.QueryExecutor, error) {
+		qe, ok := queryExecutors[channel]
+		if !ok {
+			return nil, errors.Errorf("channel %s doesn't exist", channel)
+		}
+		return qe, nil
+	}
+
+	BeforeEach(func() {
+		fakeBuilder = &mock.ImageBuilder{}
+		fakeLauncher = &mock.Launcher{}
+		deploymentSpec = &pb.ChaincodeDeploymentSpec{
+			CodePackage:   []byte("code-package"),
+			ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "chaincode-name"}},
+		}
+		fakePackage := &mock.CCPackage{}
+		fakePackage.GetDepSpecReturns(deploymentSpec)
+		fakePackageProvider = &mock.PackageProvider{}
+		fakePackageProvider.GetChaincodeReturns(fakePackage, nil)
+
+		channel1 := &mock.TxSimulator{}
+		channel1.GetStateRangeScanIteratorReturns(lsccState(
+			definition("mycc", "1.0"),
+			&queryresult.KV{Namespace: "lscc", Key: "mycc~collection", Value: []byte("collections")},
+			definition("othercc", "2.0"),
+		), nil)
+		channel2 := &mock.TxSimulator{}
+		channel2.GetStateRangeScanIteratorReturns(lsccState(definition("mycc", "1.0")), nil)
+		queryExecutors = map[string]*mock.TxSimulator{
+			"channel1": channel1,
+			"channel2": channel2,
+		}
+
+		warmUp = &chaincode.WarmUp{
+			Chaincodes:      []string{"mycc"},
+			Builder:         fakeBuilder,
+			Launcher:        fakeLauncher,
+			PackageProvider: fakePackageProvider,
+		}
+	})
+
+	It("builds the images of the allowed chaincodes once", func() {
+		warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+		Expect(fakeBuilder.BuildCallCount()).To(Equal(1))
+		_, cccid, cds := fakeBuilder.BuildArgsForCall(0)
+		Expect(cccid.ChainID).To(Equal("channel1"))
+		Expect(cccid.Name).To(Equal("mycc"))
+		Expect(cccid.Version).To(Equal("1.0"))
+		Expect(cds).To(Equal(deploymentSpec))
+		Expect(fakeLauncher.LaunchCallCount()).To(Equal(0))
+
+		Expect(fakePackageProvider.GetChaincodeCallCount()).To(Equal(1))
+		name, version := fakePackageProvider.GetChaincodeArgsForCall(0)
+		Expect(name).To(Equal("mycc"))
+		Expect(version).To(Equal("1.0"))
+
+		for _, qe := range queryExecutors {
+			Expect(qe.DoneCallCount()).To(Equal(1))
+			namespace, _, _ := qe.GetStateRangeScanIteratorArgsForCall(0)
+			Expect(namespace).To(Equal("lscc"))
+		}
+	})
+
+	Context("when all chaincodes are allowed", func() {
+		BeforeEach(func() {
+			warmUp.Chaincodes = []string{chaincode.AllChaincodes}
+		})
+
+		It("builds the images of all chaincodes, skipping collection configs", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(2))
+			_, cccid, _ := fakeBuilder.BuildArgsForCall(1)
+			Expect(cccid.Name).To(Equal("othercc"))
+			Expect(cccid.Version).To(Equal("2.0"))
+		})
+	})
+
+	Context("when the chaincodes are launched", func() {
+		BeforeEach(func() {
+			warmUp.Launch = true
+		})
+
+		It("launches them", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeLauncher.LaunchCallCount()).To(Equal(1))
+			_, cccid, spec := fakeLauncher.LaunchArgsForCall(0)
+			Expect(cccid.GetCanonicalName()).To(Equal("mycc:1.0"))
+			Expect(spec).To(Equal(deploymentSpec))
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no chaincodes are allowed", func() {
+		BeforeEach(func() {
+			warmUp.Chaincodes = nil
+		})
+
+		It("does nothing", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(queryExecutors["channel1"].GetStateRangeScanIteratorCallCount()).To(Equal(0))
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the chaincode isn't installed", func() {
+		BeforeEach(func() {
+			fakePackageProvider.GetChaincodeReturns(nil, errors.New("not installed"))
+		})
+
+		It("skips it", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the state of a channel can't be queried", func() {
+		BeforeEach(func() {
+			queryExecutors["channel1"].GetStateRangeScanIteratorReturns(nil, errors.New("boom"))
+		})
+
+		It("warms up the chaincodes of the other channels", func() {
+			warmUp.Run([]string{"missing", "channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(1))
+			_, cccid, _ := fakeBuilder.BuildArgsForCall(0)
+			Expect(cccid.ChainID).To(Equal("channel2"))
+		})
+	})
+
+	Context("when a definition is corrupted", func() {
+		BeforeEach(func() {
+			queryExecutors["channel1"].GetStateRangeScanIteratorReturns(lsccState(
+				&queryresult.KV{Namespace: "lscc", Key: "mycc", Value: []byte("garbage")},
+			), nil)
+		})
+
+		It("skips the channel", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(1))
+			_, cccid, _ := fakeBuilder.BuildArgsForCall(0)
+			Expect(cccid.ChainID).To(Equal("channel2"))
+		})
+	})
+
+	Context("when the build fails", func() {
+		BeforeEach(func() {
+			warmUp.Chaincodes = []string{chaincode.AllChaincodes}
+			fakeBuilder.BuildReturnsOnCall(0, errors.New("boom"))
+		})
+
+		It("warms up the other chaincodes", func() {
+			warmUp.Run([]string{"channel1", "channel2"}, newQueryExecutor)
+
+			Expect(fakeBuilder.BuildCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -49,6 +49,8 @@ type ServerConfig struct {
 	SecOpts *SecureOptions
 	// KaOpts defines the keepalive parameters
 	KaOpts *KeepaliveOptions
+	// Limits defines the limits on the connections and requests of clients
+	Limits ConnectionLimits
 }
 
 // ClientConfig defines the parameters for configuring a GRPCClient instance
@@ -81,6 +83,9 @@ type SecureOptions struct {
 	RequireClientCert bool
 	// CipherSuites is a list of supported cipher suites for TLS
 	CipherSuites []uint16
+	// VirtualHosts are the key pairs presented by servers instead of
+	// Certificate and Key to clients that request their hostnames through SNI
+	VirtualHosts []VirtualHost
 }
 
 // KeepaliveOptions is used to set the gRPC keepalive settings for both
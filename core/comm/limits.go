@@ -0,0 +1,287 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	rejectedDenied          = "denied"
+	rejectedConnectionLimit = "connection_limit"
+	rejectedStreamLimit     = "stream_limit"
+)
+
+// ConnectionLimits defines the limits a GRPCServer enforces on its clients,
+// which are identified by the IP address they connect from
+type ConnectionLimits struct {
+	// MaxConcurrentStreams is the maximum number of concurrent streams on
+	// each connection. Zero leaves the gRPC default in place.
+	MaxConcurrentStreams uint32
+	// MaxConnectionsPerClient is the maximum number of concurrent connections
+	// of each client. Zero means no limit.
+	MaxConnectionsPerClient int
+	// MaxStreamsPerClient is the maximum number of concurrent requests, unary
+	// and streaming, of each client across all its connections.
+	// Zero means no limit.
+	MaxStreamsPerClient int
+	// DeniedClients are the IP addresses and CIDR ranges of the clients
+	// whose connections are refused
+	DeniedClients []string
+}
+
+// clientLimiter keeps track of the connections and streams of each client
+// to enforce the connection limits
+type clientLimiter struct {
+	address string
+	limits  ConnectionLimits
+	denied  []*net.IPNet
+	lock    sync.Mutex
+	total   int
+	conns   map[string]int
+	streams map[string]int
+}
+
+func newClientLimiter(address string, limits ConnectionLimits) (*clientLimiter, error) {
+	if limits.MaxConnectionsPerClient < 0 {
+		return nil, errors.Errorf("invalid maximum number of connections per client %d", limits.MaxConnectionsPerClient)
+	}
+	if limits.MaxStreamsPerClient < 0 {
+		return nil, errors.Errorf("invalid maximum number of streams per client %d", limits.MaxStreamsPerClient)
+	}
+	l := &clientLimiter{
+		address: address,
+		limits:  limits,
+		conns:   make(map[string]int),
+		streams: make(map[string]int),
+	}
+	for _, client := range limits.DeniedClients {
+		ipNet, err := parseIPNet(client)
+		if err != nil {
+			return nil, err
+		}
+		l.denied = append(l.denied, ipNet)
+	}
+	return l, nil
+}
+
+// parseIPNet parses a CIDR range, or an IP address as the range of itself
+func parseIPNet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, errors.Errorf("invalid denied client %s: not an IP address or CIDR range", s)
+		}
+		return ipNet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errors.Errorf("invalid denied client %s: not an IP address or CIDR range", s)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// clientOf returns the client a remote address belongs to
+func clientOf(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+func (l *clientLimiter) isDenied(client string) bool {
+	ip := net.ParseIP(client)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range l.denied {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// acquireConn accounts for a new connection of the client, and returns
+// the reason it is rejected for, or an empty string if it is accepted
+func (l *clientLimiter) acquireConn(client string) string {
+	if l.isDenied(client) {
+		return rejectedDenied
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.limits.MaxConnectionsPerClient > 0 && l.conns[client] >= l.limits.MaxConnectionsPerClient {
+		return rejectedConnectionLimit
+	}
+	l.conns[client]++
+	l.total++
+	l.reportConnections()
+	return ""
+}
+
+func (l *clientLimiter) releaseConn(client string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	release(l.conns, client)
+	l.total--
+	l.reportConnections()
+}
+
+// acquireStream accounts for a new stream of the client, and returns
+// false if the client reached its limit
+func (l *clientLimiter) acquireStream(client string) bool {
+	if l.limits.MaxStreamsPerClient == 0 {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.streams[client] >= l.limits.MaxStreamsPerClient {
+		return false
+	}
+	l.streams[client]++
+	return true
+}
+
+func (l *clientLimiter) releaseStream(client string) {
+	if l.limits.MaxStreamsPerClient == 0 {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	release(l.streams, client)
+}
+
+func release(counts map[string]int, client string) {
+	if counts[client] <= 1 {
+		delete(counts, client)
+		return
+	}
+	counts[client]--
+}
+
+// serverOptions returns the gRPC server options that enforce the limits
+func (l *clientLimiter) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if l.limits.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(l.limits.MaxConcurrentStreams))
+	}
+	if l.limits.MaxStreamsPerClient > 0 {
+		opts = append(opts,
+			grpc.UnaryInterceptor(l.unaryInterceptor),
+			grpc.StreamInterceptor(l.streamInterceptor))
+	}
+	return opts
+}
+
+func (l *clientLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	client, err := l.admitStream(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer l.releaseStream(client)
+	return handler(ctx, req)
+}
+
+func (l *clientLimiter) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	client, err := l.admitStream(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	defer l.releaseStream(client)
+	return handler(srv, ss)
+}
+
+func (l *clientLimiter) admitStream(ctx context.Context, method string) (string, error) {
+	var client string
+	if p, ok := peer.FromContext(ctx); ok {
+		client = clientOf(p.Addr)
+	}
+	if !l.acquireStream(client) {
+		commLogger.Warningf("Rejecting %s from %s: the client reached the limit of %d concurrent requests", method, client, l.limits.MaxStreamsPerClient)
+		l.reportRejected(rejectedStreamLimit)
+		return "", status.Errorf(codes.ResourceExhausted, "too many concurrent requests from %s", client)
+	}
+	return client, nil
+}
+
+// limitListener closes the connections of clients that are denied
+// or that reached their connection limit as soon as they are accepted
+type limitListener struct {
+	net.Listener
+	limiter *clientLimiter
+}
+
+// Accept waits for and returns the next connection that is within the limits
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		client := clientOf(conn.RemoteAddr())
+		if reason := l.limiter.acquireConn(client); reason != "" {
+			commLogger.Warningf("Rejecting connection from %s: %s", conn.RemoteAddr(), reason)
+			l.limiter.reportRejected(reason)
+			conn.Close()
+			continue
+		}
+		return &limitConn{Conn: conn, limiter: l.limiter, client: client}, nil
+	}
+}
+
+// limitConn releases the connection of its client once closed
+type limitConn struct {
+	net.Conn
+	limiter *clientLimiter
+	client  string
+	once    sync.Once
+}
+
+func (c *limitConn) Close() error {
+	c.once.Do(func() {
+		c.limiter.releaseConn(c.client)
+	})
+	return c.Conn.Close()
+}
+
+// reportConnections reports the number of open connections of the server,
+// if metrics are initialized
+func (l *clientLimiter) reportConnections() {
+	if metrics.RootScope == nil {
+		return
+	}
+	metrics.RootScope.SubScope("grpc_server").Tagged(map[string]string{"server": l.address}).
+		Gauge("connections").Update(float64(l.total))
+}
+
+// reportRejected counts the connections and requests rejected by the
+// connection limits, if metrics are initialized
+func (l *clientLimiter) reportRejected(reason string) {
+	if metrics.RootScope == nil {
+		return
+	}
+	metrics.RootScope.SubScope("grpc_server").Tagged(map[string]string{"server": l.address, "reason": reason}).
+		Counter("rejected").Inc(1)
+}
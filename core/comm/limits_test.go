@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewClientLimiter(t *testing.T) {
+	_, err := newClientLimiter("", ConnectionLimits{MaxConnectionsPerClient: -1})
+	assert.EqualError(t, err, "invalid maximum number of connections per client -1")
+
+	_, err = newClientLimiter("", ConnectionLimits{MaxStreamsPerClient: -1})
+	assert.EqualError(t, err, "invalid maximum number of streams per client -1")
+
+	_, err = newClientLimiter("", ConnectionLimits{DeniedClients: []string{"peer0.org1"}})
+	assert.EqualError(t, err, "invalid denied client peer0.org1: not an IP address or CIDR range")
+
+	_, err = newClientLimiter("", ConnectionLimits{DeniedClients: []string{"10.0.0.0/33"}})
+	assert.EqualError(t, err, "invalid denied client 10.0.0.0/33: not an IP address or CIDR range")
+}
+
+func TestDeniedClients(t *testing.T) {
+	l, err := newClientLimiter("", ConnectionLimits{DeniedClients: []string{"10.0.0.1", "192.168.0.0/16", "::1"}})
+	require.NoError(t, err)
+
+	assert.True(t, l.isDenied("10.0.0.1"))
+	assert.False(t, l.isDenied("10.0.0.2"))
+	assert.True(t, l.isDenied("192.168.3.4"))
+	assert.True(t, l.isDenied("::1"))
+	assert.False(t, l.isDenied("not an address"))
+	assert.Equal(t, rejectedDenied, l.acquireConn("192.168.3.4"))
+	assert.Equal(t, "", l.acquireConn("10.0.0.2"))
+}
+
+func TestConnectionLimit(t *testing.T) {
+	l, err := newClientLimiter("", ConnectionLimits{MaxConnectionsPerClient: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, "", l.acquireConn("10.0.0.1"))
+	assert.Equal(t, "", l.acquireConn("10.0.0.1"))
+	assert.Equal(t, rejectedConnectionLimit, l.acquireConn("10.0.0.1"))
+	// Other clients have their own limit
+	assert.Equal(t, "", l.acquireConn("10.0.0.2"))
+
+	l.releaseConn("10.0.0.1")
+	assert.Equal(t, "", l.acquireConn("10.0.0.1"))
+	l.releaseConn("10.0.0.1")
+	l.releaseConn("10.0.0.1")
+	l.releaseConn("10.0.0.2")
+	assert.Empty(t, l.conns)
+	assert.Equal(t, 0, l.total)
+}
+
+func TestLimitListener(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l, err := newClientLimiter(lis.Addr().String(), ConnectionLimits{MaxConnectionsPerClient: 1})
+	require.NoError(t, err)
+	limitLis := &limitListener{Listener: lis, limiter: l}
+	defer limitLis.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := limitLis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	first, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer first.Close()
+	var conn net.Conn
+	select {
+	case conn = <-accepted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first connection was not accepted")
+	}
+
+	// The second connection of the client is closed right away
+	second, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer second.Close()
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = second.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.Len(t, accepted, 0)
+
+	// Closing a connection twice releases it once
+	conn.Close()
+	conn.Close()
+	third, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer third.Close()
+	select {
+	case <-accepted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection was not accepted after the first one was closed")
+	}
+}
+
+func TestStreamLimit(t *testing.T) {
+	l, err := newClientLimiter("", ConnectionLimits{MaxStreamsPerClient: 1})
+	require.NoError(t, err)
+	assert.Len(t, l.serverOptions(), 2)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 7051}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/protos.Endorser/ProcessProposal"}
+	handled := 0
+	var nested error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled++
+		if handled == 1 {
+			// The client is at its limit while the request is being handled
+			_, nested = l.unaryInterceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		}
+		return "response", nil
+	}
+
+	resp, err := l.unaryInterceptor(ctx, "request", info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(nested))
+	assert.Equal(t, "too many concurrent requests from 10.0.0.1", status.Convert(nested).Message())
+
+	// The stream was released once handled
+	_, err = l.unaryInterceptor(ctx, "request", info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, handled)
+	assert.Empty(t, l.streams)
+}
+
+func TestNoStreamLimit(t *testing.T) {
+	l, err := newClientLimiter("", ConnectionLimits{MaxConcurrentStreams: 10})
+	require.NoError(t, err)
+	assert.Len(t, l.serverOptions(), 1)
+	assert.True(t, l.acquireStream("10.0.0.1"))
+	assert.True(t, l.acquireStream("10.0.0.1"))
+	assert.Empty(t, l.streams)
+}
@@ -9,6 +9,7 @@ package comm
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -44,6 +45,9 @@ type connProducer struct {
 	endpoints         []string
 	disabledEndpoints map[string]time.Time
 	connect           ConnectionFactory
+	orgEndpoints      func() map[string][]string
+	orgWeights        map[string]int
+	currentWeights    map[string]int
 }
 
 // NewConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory.
@@ -55,10 +59,36 @@ func NewConnectionProducer(factory ConnectionFactory, endpoints []string) Connec
 	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time)}
 }
 
+// NewWeightedConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory,
+// which fails over among the ordering organizations returned by orgEndpoints, a map of MSP IDs to endpoints.
+// Organizations are picked by a smooth weighted round-robin, according to the given weights of their MSP IDs,
+// organizations without a weight have a weight of 1.
+// It returns nil, if the given endpoints slice is empty.
+func NewWeightedConnectionProducer(factory ConnectionFactory, endpoints []string, orgEndpoints func() map[string][]string, orgWeights map[string]int) ConnectionProducer {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &connProducer{
+		endpoints:         endpoints,
+		connect:           factory,
+		disabledEndpoints: make(map[string]time.Time),
+		orgEndpoints:      orgEndpoints,
+		orgWeights:        orgWeights,
+		currentWeights:    make(map[string]int),
+	}
+}
+
 // NewConnection creates a new connection.
 // Returns the connection, the endpoint selected, nil on success.
 // Returns nil, "", error on failure
 func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
+	// Organizations are looked up before locking, as the lookup may
+	// block on the updates of the endpoints
+	var orgEndpoints map[string][]string
+	if cp.orgEndpoints != nil {
+		orgEndpoints = cp.orgEndpoints()
+	}
+
 	cp.Lock()
 	defer cp.Unlock()
 
@@ -68,7 +98,7 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 		}
 	}
 
-	endpoints := shuffle(cp.endpoints)
+	endpoints := cp.orderEndpoints(orgEndpoints)
 	checkedEndpoints := make([]string, 0)
 	for _, endpoint := range endpoints {
 		if _, ok := cp.disabledEndpoints[endpoint]; !ok {
@@ -104,6 +134,83 @@ func (cp *connProducer) UpdateEndpoints(endpoints []string) {
 	cp.disabledEndpoints = newDisabled
 }
 
+// orderEndpoints returns the endpoints in the order connecting to them is attempted.
+// The endpoints of the organization picked by the weighted round-robin come first,
+// followed by the endpoints of the rest of the organizations by descending weight,
+// and the endpoints that belong to no organization last.
+func (cp *connProducer) orderEndpoints(orgEndpoints map[string][]string) []string {
+	orgs, byOrg, ungrouped := groupEndpoints(cp.endpoints, orgEndpoints)
+	if len(orgs) == 0 {
+		return shuffle(cp.endpoints)
+	}
+
+	// Forget organizations that are no longer among the endpoints
+	for org := range cp.currentWeights {
+		if _, exists := byOrg[org]; !exists {
+			delete(cp.currentWeights, org)
+		}
+	}
+	totalWeight := 0
+	picked := ""
+	for _, org := range orgs {
+		weight := cp.weight(org)
+		totalWeight += weight
+		cp.currentWeights[org] += weight
+		if picked == "" || cp.currentWeights[org] > cp.currentWeights[picked] {
+			picked = org
+		}
+	}
+	cp.currentWeights[picked] -= totalWeight
+
+	sort.SliceStable(orgs, func(i, j int) bool {
+		if orgs[i] == picked || orgs[j] == picked {
+			return orgs[i] == picked
+		}
+		return cp.weight(orgs[i]) > cp.weight(orgs[j])
+	})
+	var endpoints []string
+	for _, org := range orgs {
+		endpoints = append(endpoints, shuffle(byOrg[org])...)
+	}
+	return append(endpoints, shuffle(ungrouped)...)
+}
+
+func (cp *connProducer) weight(org string) int {
+	if weight, exists := cp.orgWeights[org]; exists && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// groupEndpoints groups the given endpoints by the organizations they belong to, and returns
+// the sorted organizations, their endpoints and the endpoints that belong to no organization
+func groupEndpoints(endpoints []string, orgEndpoints map[string][]string) ([]string, map[string][]string, []string) {
+	orgOf := make(map[string]string)
+	for org, endpoints := range orgEndpoints {
+		for _, endpoint := range endpoints {
+			if _, exists := orgOf[endpoint]; !exists || org < orgOf[endpoint] {
+				orgOf[endpoint] = org
+			}
+		}
+	}
+	var orgs []string
+	var ungrouped []string
+	byOrg := make(map[string][]string)
+	for _, endpoint := range endpoints {
+		org, exists := orgOf[endpoint]
+		if !exists {
+			ungrouped = append(ungrouped, endpoint)
+			continue
+		}
+		if _, exists := byOrg[org]; !exists {
+			orgs = append(orgs, org)
+		}
+		byOrg[org] = append(byOrg[org], endpoint)
+	}
+	sort.Strings(orgs)
+	return orgs, byOrg, ungrouped
+}
+
 func (cp *connProducer) DisableEndpoint(endpoint string) {
 	cp.Lock()
 	defer cp.Unlock()
@@ -133,3 +133,54 @@ func TestDisableEndpoint(t *testing.T) {
 	assert.Equal(t, "b", a)
 
 }
+
+func TestWeightedOrgFailover(t *testing.T) {
+	t.Parallel()
+	shouldConnFail := map[string]bool{}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if shouldConnFail[endpoint] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	orgEndpoints := map[string][]string{
+		"OrgA": {"a1", "a2"},
+		"OrgB": {"b1"},
+	}
+	producer := NewWeightedConnectionProducer(connFactory, []string{"a1", "a2", "b1", "c"}, func() map[string][]string {
+		return orgEndpoints
+	}, map[string]int{"OrgA": 2})
+
+	// Scenario I: Organizations are picked according to their weights,
+	// and the endpoints that belong to no organization aren't picked
+	selected := make(map[string]int)
+	for i := 0; i < 30; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		selected[endpoint]++
+	}
+	assert.Equal(t, 20, selected["a1"]+selected["a2"])
+	assert.Equal(t, 10, selected["b1"])
+	assert.Zero(t, selected["c"])
+
+	// Scenario II: When the endpoints of an organization fail, the endpoints of the next organization are picked
+	shouldConnFail["a1"] = true
+	shouldConnFail["a2"] = true
+	for i := 0; i < 3; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		assert.Equal(t, "b1", endpoint)
+	}
+
+	// Scenario III: When the endpoints of all organizations fail, the endpoints that belong to no organization are picked
+	shouldConnFail["b1"] = true
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", endpoint)
+
+	// Scenario IV: Organizations that aren't among the endpoints are ignored
+	orgEndpoints["OrgB"] = []string{"b2"}
+	shouldConnFail["c"] = true
+	_, _, err = producer.NewConnection()
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	tls "github.com/tjfoc/gmtls"
+)
+
+// CredentialsLoader loads the TLS credentials of servers from their files.
+// The client root CAs it returns replace all the certificate authorities the
+// servers verify client certificates with.
+type CredentialsLoader func() (*SecureOptions, error)
+
+// TLSServer is a server whose TLS credentials can be replaced while it is serving
+type TLSServer interface {
+	// Address returns the listen address of the server
+	Address() string
+	// TLSEnabled is a flag indicating whether or not TLS is enabled
+	TLSEnabled() bool
+	// MutualTLSRequired is a flag indicating whether or not client
+	// certificates are required
+	MutualTLSRequired() bool
+	// SetClientRootCAs sets the list of authorities used to verify client
+	// certificates based on a list of PEM-encoded X509 certificate authorities
+	SetClientRootCAs(clientRoots [][]byte) error
+	// SetServerCertificate assigns the TLS certificate presented by the server
+	SetServerCertificate(tls.Certificate)
+	// SetVirtualHosts replaces the key pairs presented to the clients that
+	// request the hostnames of virtual hosts through SNI
+	SetVirtualHosts(hosts []VirtualHost) error
+}
+
+// CredentialsReloader reloads the TLS certificates and client root CAs of
+// servers without restarting them, whenever the files they are read
+// from change, and on demand.
+// Changes are detected by polling the modification time and size of the
+// files, which also catches files replaced through symbolic links.
+type CredentialsReloader struct {
+	load  CredentialsLoader
+	files []string
+
+	lock     sync.Mutex
+	servers  []TLSServer
+	watching bool
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCredentialsReloader creates a CredentialsReloader of the given servers,
+// whose credentials are loaded from the given files
+func NewCredentialsReloader(load CredentialsLoader, files []string, servers ...TLSServer) *CredentialsReloader {
+	return &CredentialsReloader{
+		load:    load,
+		files:   files,
+		servers: servers,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// AddServer adds a server whose credentials are reloaded
+func (r *CredentialsReloader) AddServer(server TLSServer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.servers = append(r.servers, server)
+}
+
+// Reload loads the credentials and applies them to the servers, and returns
+// the credentials loaded. The servers keep their current credentials if the
+// new ones can't be loaded, or if the certificate doesn't match the key.
+func (r *CredentialsReloader) Reload() (*SecureOptions, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	opts, err := r.load()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed loading TLS credentials")
+	}
+	cert, err := tls.X509KeyPair(opts.Certificate, opts.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid TLS certificate or key")
+	}
+	// validate the virtual hosts before applying anything
+	if _, err := newVirtualHosts(opts.VirtualHosts); err != nil {
+		return nil, err
+	}
+	for _, server := range r.servers {
+		if !server.TLSEnabled() {
+			continue
+		}
+		if server.MutualTLSRequired() {
+			// set the client root CAs first, since it is the only step that can fail
+			if err := server.SetClientRootCAs(opts.ClientRootCAs); err != nil {
+				return nil, errors.WithMessage(err, "failed setting client root CAs of "+server.Address())
+			}
+		}
+		// the virtual hosts were validated above
+		server.SetVirtualHosts(opts.VirtualHosts)
+		server.SetServerCertificate(cert)
+	}
+	commLogger.Infof("Reloaded TLS credentials of %d gRPC servers", len(r.servers))
+	return opts, nil
+}
+
+// fileState identifies a version of a file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func (r *CredentialsReloader) fileStates() map[string]fileState {
+	states := make(map[string]fileState)
+	for _, file := range r.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			// a missing file is a state of its own, the credentials are
+			// reloaded once it shows up again
+			continue
+		}
+		states[file] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return states
+}
+
+// Watch starts reloading the credentials whenever their files change,
+// checking the files at the given interval
+func (r *CredentialsReloader) Watch(interval time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.watching {
+		return
+	}
+	r.watching = true
+	go r.watch(interval)
+}
+
+func (r *CredentialsReloader) watch(interval time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	states := r.fileStates()
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+		current := r.fileStates()
+		if sameFileStates(states, current) {
+			continue
+		}
+		states = current
+		commLogger.Infof("TLS credential files changed, reloading them")
+		if _, err := r.Reload(); err != nil {
+			commLogger.Errorf("Failed reloading TLS credentials: %+v", err)
+		}
+	}
+}
+
+func sameFileStates(s1, s2 map[string]fileState) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for file, state := range s1 {
+		other, exists := s2[file]
+		if !exists || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
+
+// Stop stops watching the files of the credentials
+func (r *CredentialsReloader) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+	r.lock.Lock()
+	watching := r.watching
+	r.lock.Unlock()
+	if watching {
+		<-r.done
+	}
+}
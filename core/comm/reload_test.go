@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tls "github.com/tjfoc/gmtls"
+)
+
+type mockTLSServer struct {
+	sync.Mutex
+	mutualTLS     bool
+	cert          tls.Certificate
+	clientRoots   [][]byte
+	clientRootErr error
+	virtualHosts  []VirtualHost
+	updates       int
+}
+
+func (s *mockTLSServer) Address() string {
+	return "127.0.0.1:7051"
+}
+
+func (s *mockTLSServer) TLSEnabled() bool {
+	return true
+}
+
+func (s *mockTLSServer) MutualTLSRequired() bool {
+	return s.mutualTLS
+}
+
+func (s *mockTLSServer) SetClientRootCAs(clientRoots [][]byte) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.clientRootErr != nil {
+		return s.clientRootErr
+	}
+	s.clientRoots = clientRoots
+	return nil
+}
+
+func (s *mockTLSServer) SetServerCertificate(cert tls.Certificate) {
+	s.Lock()
+	defer s.Unlock()
+	s.cert = cert
+	s.updates++
+}
+
+func (s *mockTLSServer) SetVirtualHosts(hosts []VirtualHost) error {
+	s.Lock()
+	defer s.Unlock()
+	s.virtualHosts = hosts
+	return nil
+}
+
+func (s *mockTLSServer) certificateUpdates() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.updates
+}
+
+// copyCredentials copies the key pair of the given host to the files
+func copyCredentials(t *testing.T, host string, certFile, keyFile string) {
+	for src, dst := range map[string]string{
+		"server.crt": certFile,
+		"server.key": keyFile,
+	} {
+		pem, err := ioutil.ReadFile(filepath.Join("testdata", "dynamic_cert_update", host, src))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(dst, pem, 0600))
+	}
+}
+
+func fileLoader(certFile, keyFile string, clientRoots [][]byte) CredentialsLoader {
+	return func() (*SecureOptions, error) {
+		cert, err := ioutil.ReadFile(certFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &SecureOptions{UseTLS: true, Certificate: cert, Key: key, ClientRootCAs: clientRoots}, nil
+	}
+}
+
+func TestCredentialsReloaderReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	copyCredentials(t, "localhost", certFile, keyFile)
+
+	clientRoots := [][]byte{[]byte("root1"), []byte("root2")}
+	server := &mockTLSServer{mutualTLS: true}
+	reloader := NewCredentialsReloader(fileLoader(certFile, keyFile, clientRoots), []string{certFile, keyFile}, server)
+
+	opts, err := reloader.Reload()
+	require.NoError(t, err)
+	cert1, _ := ioutil.ReadFile(certFile)
+	assert.Equal(t, cert1, opts.Certificate)
+	assert.Equal(t, clientRoots, server.clientRoots)
+	assert.Equal(t, 1, server.updates)
+
+	// A certificate that doesn't match the key isn't applied
+	cert2, err := ioutil.ReadFile(filepath.Join("testdata", "dynamic_cert_update", "notlocalhost", "server.crt"))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, cert2, 0600))
+	_, err = reloader.Reload()
+	assert.Contains(t, err.Error(), "invalid TLS certificate or key")
+	assert.Equal(t, 1, server.updates)
+
+	// Missing files aren't applied either
+	os.Remove(keyFile)
+	_, err = reloader.Reload()
+	assert.Contains(t, err.Error(), "failed loading TLS credentials")
+	assert.Equal(t, 1, server.updates)
+
+	// The certificate is kept if the client root CAs can't be set
+	copyCredentials(t, "notlocalhost", certFile, keyFile)
+	server.clientRootErr = errors.New("asn1: syntax error")
+	_, err = reloader.Reload()
+	assert.EqualError(t, err, "failed setting client root CAs of 127.0.0.1:7051: asn1: syntax error")
+	assert.Equal(t, 1, server.updates)
+
+	// Servers added later are reloaded too
+	server.clientRootErr = nil
+	other := &mockTLSServer{}
+	reloader.AddServer(other)
+	_, err = reloader.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.updates)
+	assert.Equal(t, 1, other.updates)
+	assert.Nil(t, other.clientRoots)
+
+	// Invalid virtual hosts aren't applied
+	load := reloader.load
+	reloader.load = func() (*SecureOptions, error) {
+		opts, err := load()
+		if err != nil {
+			return nil, err
+		}
+		opts.VirtualHosts = []VirtualHost{{Certificate: opts.Certificate}}
+		return opts, nil
+	}
+	_, err = reloader.Reload()
+	assert.Contains(t, err.Error(), "invalid key pair of virtual host 0")
+	assert.Equal(t, 2, server.updates)
+}
+
+func TestCredentialsReloaderWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	copyCredentials(t, "localhost", certFile, keyFile)
+
+	server := &mockTLSServer{}
+	reloader := NewCredentialsReloader(fileLoader(certFile, keyFile, nil), []string{certFile, keyFile}, server)
+	reloader.Watch(10 * time.Millisecond)
+	defer reloader.Stop()
+
+	// Nothing is reloaded while the files don't change
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, server.certificateUpdates())
+
+	copyCredentials(t, "notlocalhost", certFile, keyFile)
+	// make sure the modification time changes on file systems with a coarse resolution
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	deadline := time.Now().Add(5 * time.Second)
+	for server.certificateUpdates() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 1, server.certificateUpdates())
+}
+
+func TestCredentialsReloaderStop(t *testing.T) {
+	reloader := NewCredentialsReloader(nil, nil)
+	// Stopping a reloader that isn't watching returns right away
+	reloader.Stop()
+	reloader.Stop()
+}
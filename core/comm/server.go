@@ -49,6 +49,9 @@ type GRPCServer interface {
 	SetClientRootCAs(clientRoots [][]byte) error
 	// SetServerCertificate assigns the current TLS certificate to be the peer's server certificate
 	SetServerCertificate(tls.Certificate)
+	// SetVirtualHosts replaces the key pairs presented to the clients that
+	// request the hostnames of virtual hosts through SNI
+	SetVirtualHosts(hosts []VirtualHost) error
 }
 
 type grpcServerImpl struct {
@@ -61,6 +64,9 @@ type grpcServerImpl struct {
 	// Certificate presented by the server for TLS communication
 	// stored as an atomic reference
 	serverCertificate atomic.Value
+	// Key pairs presented to clients that request their hostnames through SNI
+	// stored as an atomic reference
+	virtualHosts atomic.Value
 	// Key used by the server for TLS communication
 	serverKeyPEM []byte
 	// List of certificate authorities to optionally pass to the client during
@@ -97,6 +103,15 @@ func NewGRPCServer(address string, serverConfig ServerConfig) (GRPCServer, error
 // NewGRPCServerFromListener creates a new implementation of a GRPCServer given
 // an existing net.Listener instance using default keepalive
 func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig) (GRPCServer, error) {
+	limiter, err := newClientLimiter(listener.Addr().String(), serverConfig.Limits)
+	if err != nil {
+		return nil, err
+	}
+	// only connections within the limits are handed to the grpc.Server
+	if serverConfig.Limits.MaxConnectionsPerClient > 0 || len(serverConfig.Limits.DeniedClients) > 0 {
+		listener = &limitListener{Listener: listener, limiter: limiter}
+	}
+
 	grpcServer := &grpcServerImpl{
 		address:  listener.Addr().String(),
 		listener: listener,
@@ -122,12 +137,18 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 				return nil, err
 			}
 			grpcServer.serverCertificate.Store(cert)
+			if err := grpcServer.SetVirtualHosts(secureConfig.VirtualHosts); err != nil {
+				return nil, err
+			}
 
 			//set up our TLS config
 			if len(secureConfig.CipherSuites) == 0 {
 				secureConfig.CipherSuites = tlsCipherSuites
 			}
-			getCert := func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			getCert := func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				if cert := grpcServer.virtualHosts.Load().(virtualHosts).certificate(hello.ServerName); cert != nil {
+					return cert, nil
+				}
 				cert := grpcServer.serverCertificate.Load().(tls.Certificate)
 				return &cert, nil
 			}
@@ -169,6 +190,8 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(MaxRecvMsgSize()))
 	// set the keepalive options
 	serverOpts = append(serverOpts, ServerKeepaliveOptions(serverConfig.KaOpts)...)
+	// set the limits on concurrent streams
+	serverOpts = append(serverOpts, limiter.serverOptions()...)
 
 	grpcServer.server = grpc.NewServer(serverOpts...)
 
@@ -180,6 +203,17 @@ func (gServer *grpcServerImpl) SetServerCertificate(cert tls.Certificate) {
 	gServer.serverCertificate.Store(cert)
 }
 
+// SetVirtualHosts replaces the key pairs presented to the clients that
+// request the hostnames of virtual hosts through SNI
+func (gServer *grpcServerImpl) SetVirtualHosts(hosts []VirtualHost) error {
+	vhosts, err := newVirtualHosts(hosts)
+	if err != nil {
+		return err
+	}
+	gServer.virtualHosts.Store(vhosts)
+	return nil
+}
+
 // Address returns the listen address for this GRPCServer instance
 func (gServer *grpcServerImpl) Address() string {
 	return gServer.address
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tjfoc/gmsm/sm2"
+	tls "github.com/tjfoc/gmtls"
+)
+
+// VirtualHost is a TLS key pair a GRPCServer presents to the clients that
+// request one of its hostnames through SNI, instead of its default key pair.
+// This allows a server reached through several DNS names, such as an internal
+// and an external one, to present a certificate of a different trust domain
+// for each of them.
+type VirtualHost struct {
+	// Hostnames the key pair is presented for. A hostname starting with "*."
+	// matches any single label in its place. The DNS names of the certificate
+	// are used if it is empty.
+	Hostnames []string
+	// PEM-encoded X509 certificate
+	Certificate []byte
+	// PEM-encoded private key
+	Key []byte
+}
+
+// virtualHosts maps the hostnames of virtual hosts to their key pairs
+type virtualHosts map[string]*tls.Certificate
+
+// newVirtualHosts loads the key pairs of the virtual hosts
+func newVirtualHosts(hosts []VirtualHost) (virtualHosts, error) {
+	vhosts := make(virtualHosts)
+	for i, host := range hosts {
+		cert, err := tls.X509KeyPair(host.Certificate, host.Key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key pair of virtual host %d", i)
+		}
+		hostnames := host.Hostnames
+		if len(hostnames) == 0 {
+			leaf, err := sm2.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid certificate of virtual host %d", i)
+			}
+			hostnames = leaf.DNSNames
+		}
+		if len(hostnames) == 0 {
+			return nil, errors.Errorf("virtual host %d has no hostnames, and its certificate has no DNS names", i)
+		}
+		for _, hostname := range hostnames {
+			hostname = normalizeHostname(hostname)
+			if _, exists := vhosts[hostname]; exists {
+				return nil, errors.Errorf("hostname %s is used by more than one virtual host", hostname)
+			}
+			vhosts[hostname] = &cert
+		}
+	}
+	return vhosts, nil
+}
+
+// certificate returns the key pair of the virtual host of the server name
+// requested by a client, or nil if none matches it
+func (v virtualHosts) certificate(serverName string) *tls.Certificate {
+	if len(v) == 0 || serverName == "" {
+		return nil
+	}
+	serverName = normalizeHostname(serverName)
+	if cert, exists := v[serverName]; exists {
+		return cert
+	}
+	// replace the first label with a wildcard
+	if i := strings.Index(serverName, "."); i > 0 {
+		return v["*"+serverName[i:]]
+	}
+	return nil
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(hostname), ".")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadVirtualHost(t *testing.T, host string, hostnames ...string) VirtualHost {
+	dir := filepath.Join("testdata", "dynamic_cert_update", host)
+	cert, err := ioutil.ReadFile(filepath.Join(dir, "server.crt"))
+	require.NoError(t, err)
+	key, err := ioutil.ReadFile(filepath.Join(dir, "server.key"))
+	require.NoError(t, err)
+	return VirtualHost{Hostnames: hostnames, Certificate: cert, Key: key}
+}
+
+func TestVirtualHosts(t *testing.T) {
+	internal := loadVirtualHost(t, "localhost")
+	external := loadVirtualHost(t, "notlocalhost", "peer0.example.com", "*.external.example.com")
+	vhosts, err := newVirtualHosts([]VirtualHost{internal, external})
+	require.NoError(t, err)
+
+	internalCert := vhosts["localhost"]
+	externalCert := vhosts["peer0.example.com"]
+	require.NotNil(t, internalCert)
+	require.NotNil(t, externalCert)
+	assert.NotEqual(t, internalCert.Certificate, externalCert.Certificate)
+
+	tests := []struct {
+		serverName string
+		expected   interface{}
+	}{
+		// the DNS names of the certificate are used without hostnames
+		{"localhost", internalCert},
+		{"localhost.org1.example.com", internalCert},
+		{"LOCALHOST.", internalCert},
+		{"peer0.example.com", externalCert},
+		{"peer1.external.example.com", externalCert},
+		// wildcards only match a single label
+		{"peer1.org.external.example.com", nil},
+		{"external.example.com", nil},
+		{"peer1.example.com", nil},
+		{"", nil},
+	}
+	for _, test := range tests {
+		cert := vhosts.certificate(test.serverName)
+		if test.expected == nil {
+			assert.Nil(t, cert, test.serverName)
+			continue
+		}
+		assert.Equal(t, test.expected, cert, test.serverName)
+	}
+
+	// no virtual hosts
+	vhosts, err = newVirtualHosts(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, vhosts.certificate("localhost"))
+}
+
+func TestVirtualHostsErrors(t *testing.T) {
+	internal := loadVirtualHost(t, "localhost")
+	external := loadVirtualHost(t, "notlocalhost", "localhost")
+
+	_, err := newVirtualHosts([]VirtualHost{internal, external})
+	assert.EqualError(t, err, "hostname localhost is used by more than one virtual host")
+
+	mismatched := VirtualHost{Certificate: internal.Certificate, Key: external.Key}
+	_, err = newVirtualHosts([]VirtualHost{internal, mismatched})
+	assert.Contains(t, err.Error(), "invalid key pair of virtual host 1")
+}
@@ -17,8 +17,13 @@ limitations under the License.
 package committer
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/ledger"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -102,9 +107,11 @@ func (lc *LedgerCommitter) CommitWithPvtData(blockAndPvtData *ledger.BlockAndPvt
 	}
 
 	// Committing new block
+	start := time.Now()
 	if err := lc.PeerLedgerSupport.CommitWithPvtData(blockAndPvtData); err != nil {
 		return err
 	}
+	traceCommit(blockAndPvtData.Block, start, time.Now())
 
 	// post commit actions, such as event publishing
 	lc.postCommit(blockAndPvtData.Block)
@@ -133,6 +140,40 @@ func (lc *LedgerCommitter) postCommit(block *common.Block) {
 	}
 }
 
+// traceCommit records the commit of each transaction of the block in its trace,
+// along with the validation code the transaction was committed with
+func traceCommit(block *common.Block, start, end time.Time) {
+	if !tracing.Enabled() {
+		return
+	}
+	var txsFilter ledgerUtil.TxValidationFlags
+	if len(block.Metadata.GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFilter = ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for i, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil || chdr.TxId == "" {
+			continue
+		}
+		attributes := map[string]string{
+			"channel":      chdr.ChannelId,
+			"block_number": strconv.FormatUint(block.Header.GetNumber(), 10),
+		}
+		var txErr error
+		if i < len(txsFilter) {
+			attributes["validation_code"] = txsFilter.Flag(i).String()
+			if !txsFilter.IsValid(i) {
+				txErr = errors.Errorf("transaction is invalid: %s", txsFilter.Flag(i))
+			}
+		}
+		tracing.RecordSpan(chdr.TxId, "commit", start, end, attributes, txErr)
+	}
+}
+
 // LedgerHeight returns recently committed block sequence number
 func (lc *LedgerCommitter) LedgerHeight() (uint64, error) {
 	var info *common.BlockchainInfo
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/capabilities"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/identities"
@@ -96,7 +97,7 @@ func (pv *PluginValidator) ValidateWithPlugin(ctx *Context) error {
 			Reason: fmt.Sprintf("plugin with name %s couldn't be used: %v", ctx.VSCCName, err),
 		}
 	}
-	err = plugin.Validate(ctx.Block, ctx.Namespace, ctx.Seq, 0, SerializedPolicy(ctx.Policy))
+	err = validateWithRecovery(plugin, ctx)
 	validityStatus := "valid"
 	if err != nil {
 		validityStatus = fmt.Sprintf("invalid: %v", err)
@@ -105,6 +106,20 @@ func (pv *PluginValidator) ValidateWithPlugin(ctx *Context) error {
 	return err
 }
 
+// validateWithRecovery validates the transaction with the plugin. A panic of the plugin is converted into
+// an execution failure, such that a faulty plugin halts the validation of the block instead of crashing the
+// peer, and the transaction isn't marked invalid, which other peers might not do
+func validateWithRecovery(plugin validation.Plugin, ctx *Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &validation.ExecutionFailureError{
+				Reason: fmt.Sprintf("plugin with name %s panicked while validating: %v", ctx.VSCCName, r),
+			}
+		}
+	}()
+	return plugin.Validate(ctx.Block, ctx.Namespace, ctx.Seq, 0, SerializedPolicy(ctx.Policy))
+}
+
 func (pv *PluginValidator) getOrCreatePlugin(ctx *Context) (validation.Plugin, error) {
 	pluginFactory := pv.PluginFactoryByName(PluginName(ctx.VSCCName))
 	if pluginFactory == nil {
@@ -168,24 +183,43 @@ func (pbc *pluginsByChannel) createPluginIfAbsent(channel string) (validation.Pl
 func (pbc *pluginsByChannel) initPlugin(plugin validation.Plugin, channel string) (validation.Plugin, error) {
 	pe := &PolicyEvaluator{IdentityDeserializer: pbc.pv.IdentityDeserializer}
 	sf := &StateFetcherImpl{QueryExecutorCreator: pbc.pv}
-	if err := plugin.Init(pe, sf, pbc.pv.capabilities); err != nil {
+	if err := initWithRecovery(plugin, pe, sf, pbc.pv.capabilities); err != nil {
 		return nil, errors.Wrap(err, "failed initializing plugin")
 	}
 	return plugin, nil
 }
 
+// initWithRecovery initializes the plugin, and converts a panic of the plugin into an error
+func initWithRecovery(plugin validation.Plugin, dependencies ...validation.Dependency) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("plugin panicked: %v", r)
+		}
+	}()
+	return plugin.Init(dependencies...)
+}
+
 type PolicyEvaluator struct {
 	msp.IdentityDeserializer
 }
 
-// Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy
+// Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy.
+// If the policy is not satisfied, it is evaluated again with tracing, so that the returned error
+// tells which signatures matched which principals.
 func (id *PolicyEvaluator) Evaluate(policyBytes []byte, signatureSet []*common.SignedData) error {
 	pp := cauthdsl.NewPolicyProvider(id.IdentityDeserializer)
 	policy, _, err := pp.NewPolicy(policyBytes)
 	if err != nil {
 		return err
 	}
-	return policy.Evaluate(signatureSet)
+	err = policy.Evaluate(signatureSet)
+	if err == nil {
+		return nil
+	}
+	if traceErr := policies.EvaluateWithTrace(policy, signatureSet); traceErr != nil {
+		return traceErr
+	}
+	return err
 }
 
 // DeserializeIdentity unmarshals the given identity to msp.Identity
@@ -65,6 +65,34 @@ func TestValidateWithPlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateWithPanickingPlugin(t *testing.T) {
+	pm := make(txvalidator.MapBasedPluginMapper)
+	v := txvalidator.NewPluginValidator(pm, &mocks.QueryExecutorCreator{}, &mocks.IdentityDeserializer{}, &mocks.Capabilities{})
+	ctx := &txvalidator.Context{
+		Namespace: "mycc",
+		VSCCName:  "vscc",
+	}
+	factory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	factory.On("New").Return(plugin)
+	pm["vscc"] = factory
+
+	// Scenario I: The plugin panics while initializing
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		panic("foo")
+	}).Return(nil).Once()
+	err := v.ValidateWithPlugin(ctx)
+	assert.EqualError(t, err, "plugin with name vscc couldn't be used: failed initializing plugin: plugin panicked: foo")
+
+	// Scenario II: The plugin panics while validating, which is an execution failure
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		panic("bar")
+	}).Return(nil).Once()
+	err = v.ValidateWithPlugin(ctx)
+	assert.Equal(t, &validation.ExecutionFailureError{Reason: "plugin with name vscc panicked while validating: bar"}, err)
+}
+
 func TestSamplePlugin(t *testing.T) {
 	pm := make(txvalidator.MapBasedPluginMapper)
 	qec := &mocks.QueryExecutorCreator{}
@@ -7,11 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package txvalidator
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx/test"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -26,7 +30,9 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sync/semaphore"
@@ -156,6 +162,42 @@ func TestBlockValidationDuplicateTXId(t *testing.T) {
 	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
 }
 
+func TestBlockValidationTokenTransactions(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	acv := &config.MockApplicationCapabilities{ForbidDuplicateTXIdInBlockRv: true}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{vcs, &validator.MockVsccValidator{}}
+
+	env, _, err := utils.CreateSignedTokenTxEnvelope(util2.GetTestChainID(), &token.TokenTransaction{}, localmsp.NewSigner())
+	assert.NoError(t, err)
+	block := testutil.NewBlock([]*common.Envelope{env, env}, 1, gbHash)
+
+	tValidator.Validate(block)
+
+	// token transactions are only valid on the channels which enable them
+	txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD))
+	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD))
+
+	acv.FabTokenRv = true
+	tValidator.Validate(block)
+
+	txsfltr = util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsSetTo(0, peer.TxValidationCode_VALID))
+	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
+}
+
 func TestBlockValidation(t *testing.T) {
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
 	ledgermgmt.InitializeTestEnv()
@@ -200,6 +242,60 @@ func TestVeryLargeParallelBlockValidation(t *testing.T) {
 	testValidationWithNTXes(t, ledger, gbHash, 4096)
 }
 
+// failingVsccValidator fails the validation of certain transactions with
+// a terminal error, and records the transactions it validated
+type failingVsccValidator struct {
+	lock      sync.Mutex
+	failing   map[int]bool
+	validated []int
+}
+
+func (v *failingVsccValidator) VSCCValidateTx(seq int, payload *common.Payload, envBytes []byte, block *common.Block) (error, peer.TxValidationCode) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.validated = append(v.validated, seq)
+	if v.failing[seq] {
+		return &commonerrors.VSCCExecutionFailureError{Err: fmt.Errorf("failed executing VSCC of tx %d", seq)}, peer.TxValidationCode(0)
+	}
+	return nil, peer.TxValidationCode_VALID
+}
+
+func TestBlockValidationTerminalError(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	sr := [][]byte{}
+	for i := 0; i < 10; i++ {
+		sr = append(sr, []byte("simulation results"))
+	}
+	newValidator := func(workers int64, vscc vsccValidator) *TxValidator {
+		vcs := struct {
+			*mocktxvalidator.Support
+			*semaphore.Weighted
+		}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(workers)}
+		return &TxValidator{vcs, vscc}
+	}
+
+	// Scenario I: A single worker stops validating the
+	// transactions that follow the one that failed
+	vscc := &failingVsccValidator{failing: map[int]bool{2: true}}
+	err := newValidator(1, vscc).Validate(testutil.ConstructBlock(t, 1, gbHash, sr, true))
+	assert.EqualError(t, err, "failed executing VSCC of tx 2")
+	assert.Equal(t, []int{0, 1, 2}, vscc.validated)
+
+	// Scenario II: Several transactions fail while validated in parallel,
+	// and the error of the first of them in the block is returned
+	vscc = &failingVsccValidator{failing: map[int]bool{0: true, 5: true}}
+	err = newValidator(10, vscc).Validate(testutil.ConstructBlock(t, 1, gbHash, sr, true))
+	assert.EqualError(t, err, "failed executing VSCC of tx 0")
+}
+
 func TestNewTxValidator_DuplicateTransactions(t *testing.T) {
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
 	ledgermgmt.InitializeTestEnv()
@@ -388,3 +484,62 @@ func TestInvalidTXsForUpgradeCC(t *testing.T) {
 
 	assert.EqualValues(t, expectTxsFltr, txsfltr)
 }
+
+type mockDryRunSupport struct {
+	*mocktxvalidator.Support
+	*semaphore.Weighted
+	validateConfigVal error
+	validated         []*common.ConfigEnvelope
+}
+
+func (ms *mockDryRunSupport) ValidateConfig(configtx *common.ConfigEnvelope) error {
+	ms.validated = append(ms.validated, configtx)
+	return ms.validateConfigVal
+}
+
+func TestDryRun(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+
+	configEnv := &common.ConfigEnvelope{
+		Config: &common.Config{Sequence: 1, ChannelGroup: &common.ConfigGroup{}},
+	}
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, util2.GetTestChainID(), localmsp.NewSigner(), configEnv, 0, 0)
+	assert.NoError(t, err)
+	block := testutil.NewBlock([]*common.Envelope{env}, 1, []byte("previous hash"))
+
+	newSupport := func() *mockDryRunSupport {
+		return &mockDryRunSupport{
+			Support: &mocktxvalidator.Support{
+				ACVal:    &config.MockApplicationCapabilities{},
+				ApplyVal: errors.New("config transactions are applied"),
+			},
+			Weighted: semaphore.NewWeighted(10),
+		}
+	}
+
+	// Config transactions are validated without being applied
+	support := newSupport()
+	tValidator := &TxValidator{support, &validator.MockVsccValidator{}}
+	dryRunBlock := proto.Clone(block).(*common.Block)
+	assert.NoError(t, tValidator.DryRun(dryRunBlock))
+	txsfltr := util.TxValidationFlags(dryRunBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsfltr.IsValid(0))
+	assert.Len(t, support.validated, 1)
+	assert.True(t, proto.Equal(configEnv, support.validated[0]))
+
+	// Validation failures of config transactions fail the validation of the block
+	support = newSupport()
+	support.validateConfigVal = errors.New("invalid config")
+	tValidator = &TxValidator{support, &validator.MockVsccValidator{}}
+	err = tValidator.DryRun(proto.Clone(block).(*common.Block))
+	assert.EqualError(t, err, "error validating config which passed initial validity checks: invalid config")
+
+	// Dry runs require a support which validates config transactions without applying them
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
+	tValidator = &TxValidator{vcs, &validator.MockVsccValidator{}}
+	err = tValidator.DryRun(proto.Clone(block).(*common.Block))
+	assert.EqualError(t, err, "config transactions can't be validated without being applied")
+}
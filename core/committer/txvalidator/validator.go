@@ -8,6 +8,8 @@ package txvalidator
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -53,6 +55,15 @@ type Support interface {
 	Capabilities() channelconfig.ApplicationCapabilities
 }
 
+// DryRunSupport is implemented by the Support of the channels whose blocks can
+// be validated without applying the config transactions they contain
+type DryRunSupport interface {
+	Support
+
+	// ValidateConfig validates a configtx without applying it
+	ValidateConfig(configtx *common.ConfigEnvelope) error
+}
+
 //Validator interface which defines API to validate block transactions
 // and return the bit array mask indicating invalid transactions which
 // didn't pass validation.
@@ -113,16 +124,18 @@ func (v *TxValidator) chainExists(chain string) bool {
 
 // Validate performs the validation of a block. The validation
 // of each transaction in the block is performed in parallel.
-// The approach is as follows: the committer thread starts the
-// tx validation function in a goroutine (using a semaphore to cap
-// the number of concurrent validating goroutines). The committer
-// thread then reads results of validation (in orderer of completion
-// of the goroutines) from the results channel. The goroutines
-// perform the validation of the txs in the block and enqueue the
-// validation result in the results channel. A few note-worthy facts:
-// 1) to keep the approach simple, the committer thread enqueues
-//    all transactions in the block and then moves on to reading the
-//    results.
+// The approach is as follows: the committer thread starts validation
+// workers as long as it can acquire slots of the semaphore that caps
+// the number of concurrent validating goroutines of all channels, and
+// until all transactions are picked up. Each worker validates the
+// transactions it picks up one after another, in the order of the block,
+// and stores the result of each transaction at its index, so that the
+// committer thread goes over the results in the order of the block once
+// all workers are done. A few note-worthy facts:
+// 1) the number of goroutines is bounded by the size of the semaphore
+//    rather than by the number of transactions in the block, and a
+//    terminal error of one transaction stops the workers from picking
+//    up the remaining transactions, as the block is rejected anyway.
 // 2) for parallel validation to work, it is important that the
 //    validation function does not change the state of the system.
 //    Otherwise the order in which validation is perform matters
@@ -131,10 +144,10 @@ func (v *TxValidator) chainExists(chain string) bool {
 //    state is when a config transaction is received, but they are
 //    guaranteed to be alone in the block. If/when this assumption
 //    is violated, this code must be changed.
+// 3) validation doesn't depend on the order in which the transactions
+//    are validated, since the MVCC checks that do depend on the order
+//    of the transactions are performed by the ledger at commit time.
 func (v *TxValidator) Validate(block *common.Block) error {
-	var err error
-	var errPos int
-
 	logger.Debug("START Block Validation")
 	defer logger.Debug("END Block Validation")
 	// Initialize trans as valid here, then set invalidation reason code upon invalidation below
@@ -146,66 +159,36 @@ func (v *TxValidator) Validate(block *common.Block) error {
 	// array of txids
 	txidArray := make([]string, len(block.Data.Data))
 
-	results := make(chan *blockValidationResult)
-	go func() {
-		for tIdx, d := range block.Data.Data {
-			// ensure that we don't have too many concurrent validation workers
-			v.Support.Acquire(context.Background(), 1)
-
-			go func(index int, data []byte) {
-				defer v.Support.Release(1)
-
-				v.validateTx(&blockValidationRequest{
-					d:     data,
-					block: block,
-					tIdx:  index,
-				}, results)
-			}(tIdx, d)
-		}
-	}()
-
 	logger.Debugf("expecting %d block validation responses", len(block.Data.Data))
+	results := v.validateTxs(block)
 
-	// now we read responses in the order in which they come back
-	for i := 0; i < len(block.Data.Data); i++ {
-		res := <-results
-
-		if res.err != nil {
-			// if there is an error, we buffer its value, wait for
-			// all workers to complete validation and then return
-			// the error from the first tx in this block that returned an error
+	// if there is an error, we return the error from
+	// the first tx in this block that returned an error
+	for _, res := range results {
+		if res != nil && res.err != nil {
 			logger.Debugf("got terminal error %s for idx %d", res.err, res.tIdx)
+			return res.err
+		}
+	}
 
-			if err == nil || res.tIdx < errPos {
-				err = res.err
-				errPos = res.tIdx
-			}
-		} else {
-			// if there was no error, we set the txsfltr and we set the
-			// txsChaincodeNames and txsUpgradedChaincodes maps
-			logger.Debugf("got result for idx %d, code %d", res.tIdx, res.validationCode)
+	// if there was no error, we set the txsfltr and we set the
+	// txsChaincodeNames and txsUpgradedChaincodes maps
+	for _, res := range results {
+		logger.Debugf("got result for idx %d, code %d", res.tIdx, res.validationCode)
 
-			txsfltr.SetFlag(res.tIdx, res.validationCode)
+		txsfltr.SetFlag(res.tIdx, res.validationCode)
 
-			if res.validationCode == peer.TxValidationCode_VALID {
-				if res.txsChaincodeName != nil {
-					txsChaincodeNames[res.tIdx] = res.txsChaincodeName
-				}
-				if res.txsUpgradedChaincode != nil {
-					txsUpgradedChaincodes[res.tIdx] = res.txsUpgradedChaincode
-				}
-				txidArray[res.tIdx] = res.txid
+		if res.validationCode == peer.TxValidationCode_VALID {
+			if res.txsChaincodeName != nil {
+				txsChaincodeNames[res.tIdx] = res.txsChaincodeName
 			}
+			if res.txsUpgradedChaincode != nil {
+				txsUpgradedChaincodes[res.tIdx] = res.txsUpgradedChaincode
+			}
+			txidArray[res.tIdx] = res.txid
 		}
 	}
 
-	// if we're here, all workers have completed the validation.
-	// If there was an error we return the error from the first
-	// tx in this block that returned an error
-	if err != nil {
-		return err
-	}
-
 	// if we operate with this capability, we mark invalid any transaction that has a txid
 	// which is equal to that of a previous tx in this block
 	if v.Support.Capabilities().ForbidDuplicateTXIdInBlock() {
@@ -218,8 +201,7 @@ func (v *TxValidator) Validate(block *common.Block) error {
 	v.invalidTXsForUpgradeCC(txsChaincodeNames, txsUpgradedChaincodes, txsfltr)
 
 	// make sure no transaction has skipped validation
-	err = v.allValidated(txsfltr, block)
-	if err != nil {
+	if err := v.allValidated(txsfltr, block); err != nil {
 		return err
 	}
 
@@ -231,6 +213,72 @@ func (v *TxValidator) Validate(block *common.Block) error {
 	return nil
 }
 
+// DryRun performs the validation of a block like Validate does, except that
+// the config transactions of the block are validated without being applied,
+// which requires the Support of the validator to implement DryRunSupport
+func (v *TxValidator) DryRun(block *common.Block) error {
+	support, ok := v.Support.(DryRunSupport)
+	if !ok {
+		return errors.New("config transactions can't be validated without being applied")
+	}
+	dryRun := &TxValidator{
+		Support: &dryRunSupport{DryRunSupport: support},
+		Vscc:    v.Vscc,
+	}
+	return dryRun.Validate(block)
+}
+
+// dryRunSupport validates config transactions instead of applying them
+type dryRunSupport struct {
+	DryRunSupport
+}
+
+func (s *dryRunSupport) Apply(configtx *common.ConfigEnvelope) error {
+	return s.ValidateConfig(configtx)
+}
+
+// validateTxs validates the transactions of the block with a pool of workers, and returns the
+// results by transaction index. The results of transactions that weren't validated because
+// the validation of another transaction failed with a terminal error are nil.
+func (v *TxValidator) validateTxs(block *common.Block) []*blockValidationResult {
+	results := make([]*blockValidationResult, len(block.Data.Data))
+	// next is the index of the last transaction picked up by a worker
+	next := int64(-1)
+	// aborted is set once a transaction failed with a terminal error
+	var aborted int32
+
+	var wg sync.WaitGroup
+	work := func() {
+		defer wg.Done()
+		defer v.Support.Release(1)
+		for atomic.LoadInt32(&aborted) == 0 {
+			tIdx := int(atomic.AddInt64(&next, 1))
+			if tIdx >= len(results) {
+				return
+			}
+			res := v.validateTx(&blockValidationRequest{
+				d:     block.Data.Data[tIdx],
+				block: block,
+				tIdx:  tIdx,
+			})
+			results[tIdx] = res
+			if res.err != nil {
+				atomic.StoreInt32(&aborted, 1)
+			}
+		}
+	}
+
+	// start workers as long as there are transactions that weren't picked up,
+	// while ensuring that we don't have too many concurrent validation workers
+	for workers := 0; workers < len(results) && atomic.LoadInt64(&next)+1 < int64(len(results)) && atomic.LoadInt32(&aborted) == 0; workers++ {
+		v.Support.Acquire(context.Background(), 1)
+		wg.Add(1)
+		go work()
+	}
+	wg.Wait()
+	return results
+}
+
 // allValidated returns error if some of the validation flags have not been set
 // during validation
 func (v *TxValidator) allValidated(txsfltr ledgerUtil.TxValidationFlags, block *common.Block) error {
@@ -261,26 +309,24 @@ func markTXIdDuplicates(txids []string, txsfltr ledgerUtil.TxValidationFlags) {
 	}
 }
 
-func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *blockValidationResult) {
+func (v *TxValidator) validateTx(req *blockValidationRequest) *blockValidationResult {
 	block := req.block
 	d := req.d
 	tIdx := req.tIdx
 	txID := ""
 
 	if d == nil {
-		results <- &blockValidationResult{
+		return &blockValidationResult{
 			tIdx: tIdx,
 		}
-		return
 	}
 
 	if env, err := utils.GetEnvelopeFromBlock(d); err != nil {
 		logger.Warningf("Error getting tx from block: %+v", err)
-		results <- &blockValidationResult{
+		return &blockValidationResult{
 			tIdx:           tIdx,
 			validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
 		}
-		return
 	} else if env != nil {
 		// validate the transaction: here we check that the transaction
 		// is properly formed, properly signed and that the security
@@ -297,21 +343,19 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 
 		if payload, txResult = validation.ValidateTransaction(env, v.Support.Capabilities()); txResult != peer.TxValidationCode_VALID {
 			logger.Errorf("Invalid transaction with index %d", tIdx)
-			results <- &blockValidationResult{
+			return &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: txResult,
 			}
-			return
 		}
 
 		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 		if err != nil {
 			logger.Warningf("Could not unmarshal channel header, err %s, skipping", err)
-			results <- &blockValidationResult{
+			return &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
 			}
-			return
 		}
 
 		channel := chdr.ChannelId
@@ -319,122 +363,122 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 
 		if !v.chainExists(channel) {
 			logger.Errorf("Dropping transaction for non-existent channel %s", channel)
-			results <- &blockValidationResult{
+			return &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND,
 			}
-			return
 		}
 
 		if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
 			// Check duplicate transactions
 			txID = chdr.TxId
+			txLogger := flogging.FromContext(flogging.WithFields(context.Background(), flogging.Fields{Channel: channel, TxID: txID}), logger)
 			if _, err := v.Support.Ledger().GetTransactionByID(txID); err == nil {
-				logger.Error("Duplicate transaction found, ", txID, ", skipping")
-				results <- &blockValidationResult{
+				txLogger.Error("Duplicate transaction found, ", txID, ", skipping")
+				return &blockValidationResult{
 					tIdx:           tIdx,
 					validationCode: peer.TxValidationCode_DUPLICATE_TXID,
 				}
-				return
 			}
 
 			// Validate tx with vscc and policy
-			logger.Debug("Validating transaction vscc tx validate")
+			txLogger.Debug("Validating transaction vscc tx validate")
 			err, cde := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
 			if err != nil {
-				logger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
+				txLogger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
 				switch err.(type) {
 				case *commonerrors.VSCCExecutionFailureError:
-					results <- &blockValidationResult{
+					return &blockValidationResult{
 						tIdx: tIdx,
 						err:  err,
 					}
-					return
 				case *commonerrors.VSCCInfoLookupFailureError:
-					results <- &blockValidationResult{
+					return &blockValidationResult{
 						tIdx: tIdx,
 						err:  err,
 					}
-					return
 				default:
-					results <- &blockValidationResult{
+					return &blockValidationResult{
 						tIdx:           tIdx,
 						validationCode: cde,
 					}
-					return
 				}
 			}
 
 			invokeCC, upgradeCC, err := v.getTxCCInstance(payload)
 			if err != nil {
-				logger.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
-				results <- &blockValidationResult{
+				txLogger.Errorf("Get chaincode instance from transaction txId = %s returned error: %+v", txID, err)
+				return &blockValidationResult{
 					tIdx:           tIdx,
 					validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
 				}
-				return
 			}
 			txsChaincodeName = invokeCC
 			if upgradeCC != nil {
-				logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
+				txLogger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
 				txsUpgradedChaincode = upgradeCC
 			}
+		} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
+			// Token transactions are validated by their processor when they are committed,
+			// so only check duplicate transactions
+			txID = chdr.TxId
+			if _, err := v.Support.Ledger().GetTransactionByID(txID); err == nil {
+				logger.Errorf("Duplicate token transaction found, %s, skipping", txID)
+				return &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_DUPLICATE_TXID,
+				}
+			}
 		} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
 			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
 			if err != nil {
 				err = errors.WithMessage(err, "error unmarshalling config which passed initial validity checks")
 				logger.Criticalf("%+v", err)
-				results <- &blockValidationResult{
+				return &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
 				}
-				return
 			}
 
 			if err := v.Support.Apply(configEnvelope); err != nil {
 				err = errors.WithMessage(err, "error validating config which passed initial validity checks")
 				logger.Criticalf("%+v", err)
-				results <- &blockValidationResult{
+				return &blockValidationResult{
 					tIdx: tIdx,
 					err:  err,
 				}
-				return
 			}
 			logger.Debugf("config transaction received for chain %s", channel)
 		} else {
 			logger.Warningf("Unknown transaction type [%s] in block number [%d] transaction index [%d]",
 				common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-			results <- &blockValidationResult{
+			return &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_UNKNOWN_TX_TYPE,
 			}
-			return
 		}
 
 		if _, err := proto.Marshal(env); err != nil {
 			logger.Warningf("Cannot marshal transaction: %s", err)
-			results <- &blockValidationResult{
+			return &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_MARSHAL_TX_ERROR,
 			}
-			return
 		}
 		// Succeeded to pass down here, transaction is valid
-		results <- &blockValidationResult{
+		return &blockValidationResult{
 			tIdx:                 tIdx,
 			txsChaincodeName:     txsChaincodeName,
 			txsUpgradedChaincode: txsUpgradedChaincode,
 			validationCode:       peer.TxValidationCode_VALID,
 			txid:                 txID,
 		}
-		return
 	} else {
 		logger.Warning("Nil tx from block")
-		results <- &blockValidationResult{
+		return &blockValidationResult{
 			tIdx:           tIdx,
 			validationCode: peer.TxValidationCode_NIL_ENVELOPE,
 		}
-		return
 	}
 }
 
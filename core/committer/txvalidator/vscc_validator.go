@@ -264,7 +264,10 @@ func (v *VsccValidatorImpl) VSCCValidateTxForCC(ctx *Context) error {
 		return nil
 	}
 	// If the error is a pluggable validation execution error, cast it to the common errors ExecutionFailureError.
-	if e, isExecutionError := err.(validation.ExecutionFailureError); isExecutionError {
+	switch e := err.(type) {
+	case *validation.ExecutionFailureError:
+		return &commonerrors.VSCCExecutionFailureError{Err: e}
+	case validation.ExecutionFailureError:
 		return &commonerrors.VSCCExecutionFailureError{Err: e}
 	}
 	// Else, treat it as an endorsement error.
@@ -318,8 +321,8 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 	}
 	vscc := &sysccprovider.ChaincodeInstance{
 		ChainID:          chdr.ChannelId,
-		ChaincodeName:    "vscc",                     // default vscc for system chaincodes
-		ChaincodeVersion: coreUtil.GetSysCCVersion(), // Get vscc version
+		ChaincodeName:    validation.DefaultPluginName, // default vscc for system chaincodes
+		ChaincodeVersion: coreUtil.GetSysCCVersion(),   // Get vscc version
 	}
 	var policy []byte
 	var err error
@@ -35,6 +35,10 @@ type CollectionAccessPolicy interface {
 	// AccessFilter returns a member filter function for a collection
 	AccessFilter() Filter
 
+	// MemberAccessFilter returns a member filter function for the peers
+	// that pull the private data of the collection from other peers
+	MemberAccessFilter() Filter
+
 	// The minimum number of peers private data will be sent to upon
 	// endorsement. The endorsement would fail if dissemination to at least
 	// this number of peers is not achieved.
@@ -21,10 +21,11 @@ import (
 // SimpleCollection implements a collection with static properties
 // and a public member set
 type SimpleCollection struct {
-	name         string
-	accessPolicy policies.Policy
-	memberOrgs   []string
-	conf         common.StaticCollectionConfig
+	name               string
+	accessPolicy       policies.Policy
+	memberAccessPolicy policies.Policy
+	memberOrgs         []string
+	conf               common.StaticCollectionConfig
 }
 
 type SimpleCollectionPersistenceConfigs struct {
@@ -62,6 +63,25 @@ func (sc *SimpleCollection) AccessFilter() Filter {
 	}
 }
 
+// MemberAccessFilter returns the member filter function that evaluates signed data
+// of a peer that pulls private data against the member access policy of this collection,
+// in addition to its member orgs policy
+func (sc *SimpleCollection) MemberAccessFilter() Filter {
+	accessFilter := sc.AccessFilter()
+	if sc.memberAccessPolicy == nil {
+		return accessFilter
+	}
+	return func(sd common.SignedData) bool {
+		if !accessFilter(sd) {
+			return false
+		}
+		if err := sc.memberAccessPolicy.Evaluate([]*common.SignedData{&sd}); err != nil {
+			return false
+		}
+		return true
+	}
+}
+
 // Setup configures a simple collection object based on a given
 // StaticCollectionConfig proto that has all the necessary information
 func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfig, deserializer msp.IdentityDeserializer) error {
@@ -92,6 +112,22 @@ func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfi
 		return err
 	}
 
+	// the member access policy is optional
+	if memberAccessPolicyConfig := collectionConfig.GetMemberAccessPolicy(); memberAccessPolicyConfig != nil {
+		memberAccessPolicyEnvelope := memberAccessPolicyConfig.GetSignaturePolicy()
+		if memberAccessPolicyEnvelope == nil {
+			return errors.New("Collection config member access policy is nil")
+		}
+		polBytes, err := proto.Marshal(memberAccessPolicyEnvelope)
+		if err != nil {
+			return err
+		}
+		sc.memberAccessPolicy, _, err = npp.NewPolicy(polBytes)
+		if err != nil {
+			return errors.WithMessage(err, "invalid collection config member access policy")
+		}
+	}
+
 	// get member org MSP IDs from the envelope
 	for _, principal := range accessPolicyEnvelope.Identities {
 		switch principal.PrincipalClassification {
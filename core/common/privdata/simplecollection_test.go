@@ -166,3 +166,49 @@ func TestSimpleCollectionFilter(t *testing.T) {
 	}
 	assert.True(t, accessFilter(member))
 }
+
+func TestSimpleCollectionMemberAccessFilter(t *testing.T) {
+	// create member orgs policy
+	var signers = [][]byte{[]byte("signer0"), []byte("signer1")}
+	policyEnvelope := cauthdsl.Envelope(cauthdsl.Or(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), signers)
+	accessPolicy := createCollectionPolicyConfig(policyEnvelope)
+
+	signer0 := pb.SignedData{Identity: signers[0], Signature: []byte{}, Data: []byte{}}
+	signer1 := pb.SignedData{Identity: signers[1], Signature: []byte{}, Data: []byte{}}
+	notMember := pb.SignedData{Identity: []byte{1, 2, 3}, Signature: []byte{}, Data: []byte{}}
+
+	// without a member access policy, the members of the orgs may pull private data
+	var sc SimpleCollection
+	err := sc.Setup(&pb.StaticCollectionConfig{
+		Name:             "test collection",
+		MemberOrgsPolicy: accessPolicy,
+	}, &mockDeserializer{})
+	assert.NoError(t, err)
+	memberAccessFilter := sc.MemberAccessFilter()
+	assert.True(t, memberAccessFilter(signer0))
+	assert.True(t, memberAccessFilter(signer1))
+	assert.False(t, memberAccessFilter(notMember))
+
+	// with a member access policy, only the members of the orgs that satisfy it may pull private data
+	sc = SimpleCollection{}
+	err = sc.Setup(&pb.StaticCollectionConfig{
+		Name:               "test collection",
+		MemberOrgsPolicy:   accessPolicy,
+		MemberAccessPolicy: createCollectionPolicyConfig(cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{signers[1]})),
+	}, &mockDeserializer{})
+	assert.NoError(t, err)
+	assert.True(t, sc.AccessFilter()(signer0))
+	memberAccessFilter = sc.MemberAccessFilter()
+	assert.False(t, memberAccessFilter(signer0))
+	assert.True(t, memberAccessFilter(signer1))
+	assert.False(t, memberAccessFilter(notMember))
+
+	// the member access policy has to be a signature policy
+	sc = SimpleCollection{}
+	err = sc.Setup(&pb.StaticCollectionConfig{
+		Name:               "test collection",
+		MemberOrgsPolicy:   accessPolicy,
+		MemberAccessPolicy: &pb.CollectionPolicyConfig{},
+	}, &mockDeserializer{})
+	assert.EqualError(t, err, "Collection config member access policy is nil")
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	mmsp "github.com/hyperledger/fabric/common/mocks/msp"
 	"github.com/hyperledger/fabric/common/util"
@@ -31,6 +32,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -438,6 +440,32 @@ func Test2EndorsersDisagree(t *testing.T) {
 	}
 }
 
+func TestTokenTransaction(t *testing.T) {
+	env, _, err := utils.CreateSignedTokenTxEnvelope(util.GetTestChainID(), &token.TokenTransaction{}, localmsp.NewSigner())
+	assert.NoError(t, err)
+
+	payl, txResult := ValidateTransaction(env, &config.MockApplicationCapabilities{FabTokenRv: true})
+	assert.Equal(t, peer.TxValidationCode_VALID, txResult)
+	assert.NotNil(t, payl)
+
+	// token transactions are only supported by the channels which enable them
+	_, txResult = ValidateTransaction(env, &config.MockApplicationCapabilities{})
+	assert.Equal(t, peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD, txResult)
+
+	// the transaction ID must be computed from the nonce and the creator
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	chdr.TxId = "bogus"
+	payload.Header.ChannelHeader = utils.MarshalOrPanic(chdr)
+	env.Payload = utils.MarshalOrPanic(payload)
+	env.Signature, err = signer.Sign(env.Payload)
+	assert.NoError(t, err)
+	_, txResult = ValidateTransaction(env, &config.MockApplicationCapabilities{FabTokenRv: true})
+	assert.Equal(t, peer.TxValidationCode_BAD_PROPOSAL_TXID, txResult)
+}
+
 func TestInvocationsBadArgs(t *testing.T) {
 	_, code := ValidateTransaction(nil, &config.MockApplicationCapabilities{})
 	assert.Equal(t, code, peer.TxValidationCode_NIL_ENVELOPE)
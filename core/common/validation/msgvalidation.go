@@ -220,7 +220,8 @@ func validateChannelHeader(cHdr *common.ChannelHeader) error {
 	// validate the header type
 	if common.HeaderType(cHdr.Type) != common.HeaderType_ENDORSER_TRANSACTION &&
 		common.HeaderType(cHdr.Type) != common.HeaderType_CONFIG_UPDATE &&
-		common.HeaderType(cHdr.Type) != common.HeaderType_CONFIG {
+		common.HeaderType(cHdr.Type) != common.HeaderType_CONFIG &&
+		common.HeaderType(cHdr.Type) != common.HeaderType_TOKEN_TRANSACTION {
 		return errors.Errorf("invalid header type %s", common.HeaderType(cHdr.Type))
 	}
 
@@ -441,6 +442,26 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		} else {
 			return payload, pb.TxValidationCode_VALID
 		}
+	case common.HeaderType_TOKEN_TRANSACTION:
+		// Token transactions are only supported by the channels which enable them
+		if !c.FabToken() {
+			return nil, pb.TxValidationCode_UNSUPPORTED_TX_PAYLOAD
+		}
+
+		// Verify that the transaction ID has been computed properly,
+		// as for endorser transactions
+		err = utils.CheckProposalTxID(
+			chdr.TxId,
+			shdr.Nonce,
+			shdr.Creator)
+
+		if err != nil {
+			putilsLogger.Errorf("CheckProposalTxID returns err %s", err)
+			return nil, pb.TxValidationCode_BAD_PROPOSAL_TXID
+		}
+
+		// The token transaction itself is validated when it is committed
+		return payload, pb.TxValidationCode_VALID
 	default:
 		return nil, pb.TxValidationCode_UNSUPPORTED_TX_PAYLOAD
 	}
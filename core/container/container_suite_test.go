@@ -33,6 +33,11 @@ type builder interface {
 	container.Builder
 }
 
+//go:generate counterfeiter -o mock/image_builder.go --fake-name ImageBuilder . imageBuilder
+type imageBuilder interface {
+	container.ImageBuilder
+}
+
 func TestContainer(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Container Suite")
@@ -120,6 +120,61 @@ var _ = Describe("Container", func() {
 				})
 			})
 		})
+
+		Describe("BuildImageReq", func() {
+			var (
+				buildReq         *container.BuildImageReq
+				fakeImageBuilder *mock.ImageBuilder
+				imageBuilderVM   container.VM
+			)
+
+			BeforeEach(func() {
+				buildReq = &container.BuildImageReq{
+					CCID:    ccintf.CCID{Name: "build-name"},
+					Builder: &mock.Builder{},
+				}
+				fakeImageBuilder = &mock.ImageBuilder{}
+				imageBuilderVM = &struct {
+					*mock.VM
+					*mock.ImageBuilder
+				}{fakeVM, fakeImageBuilder}
+			})
+
+			Describe("Do", func() {
+				It("builds the image", func() {
+					err := buildReq.Do(ctxt, imageBuilderVM)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeImageBuilder.BuildImageCallCount()).To(Equal(1))
+					rctxt, ccid, builder := fakeImageBuilder.BuildImageArgsForCall(0)
+					Expect(rctxt).To(Equal(ctxt))
+					Expect(ccid).To(Equal(ccintf.CCID{Name: "build-name"}))
+					Expect(builder).To(Equal(&mock.Builder{}))
+					Expect(fakeVM.StartCallCount()).To(Equal(0))
+				})
+
+				Context("when the vm doesn't build images", func() {
+					It("does nothing", func() {
+						err := buildReq.Do(ctxt, fakeVM)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeVM.StartCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the build fails", func() {
+					It("returns the error", func() {
+						fakeImageBuilder.BuildImageReturns(errors.New("Boo"))
+						err := buildReq.Do(ctxt, imageBuilderVM)
+						Expect(err).To(MatchError("Boo"))
+					})
+				})
+			})
+
+			Describe("GetCCID", func() {
+				It("Returns the CCID embedded in the structure", func() {
+					Expect(buildReq.GetCCID()).To(Equal(ccintf.CCID{Name: "build-name"}))
+				})
+			})
+		})
 	})
 
 	Describe("VMController", func() {
@@ -33,6 +33,12 @@ type VM interface {
 	Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error
 }
 
+// ImageBuilder is implemented by the VMs that launch chaincode from an image,
+// which can be built ahead of the launch of the chaincode
+type ImageBuilder interface {
+	BuildImage(ctxt context.Context, ccid ccintf.CCID, builder Builder) error
+}
+
 type refCountedLock struct {
 	refCount int
 	lock     *sync.RWMutex
@@ -161,6 +167,26 @@ func (si StopContainerReq) GetCCID() ccintf.CCID {
 	return si.CCID
 }
 
+//BuildImageReq - properties for building the image of a chaincode without starting it.
+type BuildImageReq struct {
+	ccintf.CCID
+	Builder Builder
+}
+
+// Do builds the image of the chaincode, unless the VM doesn't launch chaincode from images
+func (bi BuildImageReq) Do(ctxt context.Context, v VM) error {
+	imageBuilder, ok := v.(ImageBuilder)
+	if !ok {
+		vmLogger.Debugf("VM of chaincode %s doesn't build images", bi.CCID.GetName())
+		return nil
+	}
+	return imageBuilder.BuildImage(ctxt, bi.CCID, bi.Builder)
+}
+
+func (bi BuildImageReq) GetCCID() ccintf.CCID {
+	return bi.CCID
+}
+
 //Process should be used as follows
 //   . construct a context
 //   . construct req of the right type (e.g., CreateImageReq)
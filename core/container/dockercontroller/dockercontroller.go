@@ -67,6 +67,9 @@ type dockerClient interface {
 	// BuildImage builds an image from a tarball's url or a Dockerfile in the input
 	// stream, returns an error in case of failure
 	BuildImage(opts docker.BuildImageOptions) error
+	// InspectImage returns an image by its name or ID, returns ErrNoSuchImage
+	// if the image doesn't exist
+	InspectImage(name string) (*docker.Image, error)
 	// RemoveImageExtended removes a docker image by its name or ID, returns an
 	// error in case of failure
 	RemoveImageExtended(id string, opts docker.RemoveImageOptions) error
@@ -78,6 +81,8 @@ type dockerClient interface {
 	KillContainer(opts docker.KillContainerOptions) error
 	// RemoveContainer removes a docker container, returns an error in case of failure
 	RemoveContainer(opts docker.RemoveContainerOptions) error
+	// PingWithContext pings the docker daemon, returns an error in case of failure
+	PingWithContext(ctx context.Context) error
 }
 
 // Controller implements container.VMProvider
@@ -380,6 +385,50 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 	return nil
 }
 
+// BuildImage builds the image of the chaincode, unless it already exists
+func (vm *DockerVM) BuildImage(ctxt context.Context, ccid ccintf.CCID, builder container.Builder) error {
+	imageName, err := vm.GetVMNameForDocker(ccid)
+	if err != nil {
+		return err
+	}
+
+	client, err := vm.getClientFnc()
+	if err != nil {
+		dockerLogger.Debugf("build - cannot create client %s", err)
+		return err
+	}
+
+	_, err = client.InspectImage(imageName)
+	if err == nil {
+		dockerLogger.Debugf("Image %s already exists", imageName)
+		return nil
+	}
+	if err != docker.ErrNoSuchImage {
+		return err
+	}
+	if builder == nil {
+		return fmt.Errorf("image %s doesn't exist, and there is no builder for it", imageName)
+	}
+
+	reader, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("failed generating the build of image %s: %s", imageName, err)
+	}
+	return vm.deployImage(client, ccid, nil, nil, reader)
+}
+
+// HealthCheck checks that the docker daemon is reachable
+func (vm *DockerVM) HealthCheck(ctx context.Context) error {
+	client, err := vm.getClientFnc()
+	if err != nil {
+		return fmt.Errorf("failed creating docker client: %s", err)
+	}
+	if err := client.PingWithContext(ctx); err != nil {
+		return fmt.Errorf("failed pinging docker daemon: %s", err)
+	}
+	return nil
+}
+
 //Stop stops a running chaincode
 func (vm *DockerVM) Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
 	id := vm.GetVMName(ccid)
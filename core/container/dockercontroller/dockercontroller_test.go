@@ -181,6 +181,60 @@ func Test_Stop(t *testing.T) {
 	testerr(t, err, true)
 }
 
+func TestHealthCheck(t *testing.T) {
+	dvm := DockerVM{getClientFnc: getMockClient}
+	ctx := context.Background()
+
+	assert.NoError(t, dvm.HealthCheck(ctx))
+
+	getClientErr = true
+	assert.EqualError(t, dvm.HealthCheck(ctx), "failed creating docker client: Failed to get client")
+	getClientErr = false
+
+	pingErr = true
+	assert.EqualError(t, dvm.HealthCheck(ctx), "failed pinging docker daemon: Cannot connect to the Docker daemon")
+	pingErr = false
+}
+
+func TestBuildImage(t *testing.T) {
+	dvm := DockerVM{getClientFnc: getMockClient}
+	ccid := ccintf.CCID{Name: "simple"}
+	ctx := context.Background()
+	built := false
+	builder := &mockBuilder{
+		buildFunc: func() (io.Reader, error) {
+			built = true
+			return &bytes.Buffer{}, nil
+		},
+	}
+
+	// the image already exists
+	assert.NoError(t, dvm.BuildImage(ctx, ccid, builder))
+	assert.False(t, built)
+
+	noSuchImgErr = true
+	defer func() { noSuchImgErr = false }()
+
+	assert.NoError(t, dvm.BuildImage(ctx, ccid, builder))
+	assert.True(t, built)
+
+	err := dvm.BuildImage(ctx, ccid, nil)
+	assert.Contains(t, err.Error(), "doesn't exist, and there is no builder for it")
+
+	builder.buildFunc = func() (io.Reader, error) { return nil, errors.New("bad platform") }
+	err = dvm.BuildImage(ctx, ccid, builder)
+	assert.Contains(t, err.Error(), "bad platform")
+
+	buildErr = true
+	defer func() { buildErr = false }()
+	builder.buildFunc = func() (io.Reader, error) { return &bytes.Buffer{}, nil }
+	assert.EqualError(t, dvm.BuildImage(ctx, ccid, builder), "Error building image")
+
+	getClientErr = true
+	defer func() { getClientErr = false }()
+	assert.EqualError(t, dvm.BuildImage(ctx, ccid, builder), "Failed to get client")
+}
+
 type testCase struct {
 	name           string
 	vm             *DockerVM
@@ -304,7 +358,7 @@ type mockClient struct {
 }
 
 var getClientErr, createErr, uploadErr, noSuchImgErr, buildErr, removeImgErr,
-	startErr, stopErr, killErr, removeErr bool
+	startErr, stopErr, killErr, removeErr, pingErr bool
 
 func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*docker.Container, error) {
 	if createErr {
@@ -344,6 +398,13 @@ func (c *mockClient) BuildImage(opts docker.BuildImageOptions) error {
 	return nil
 }
 
+func (c *mockClient) InspectImage(name string) (*docker.Image, error) {
+	if noSuchImgErr {
+		return nil, docker.ErrNoSuchImage
+	}
+	return &docker.Image{}, nil
+}
+
 func (c *mockClient) RemoveImageExtended(id string, opts docker.RemoveImageOptions) error {
 	if removeImgErr {
 		return errors.New("Error removing extended image")
@@ -371,3 +432,10 @@ func (c *mockClient) RemoveContainer(opts docker.RemoveContainerOptions) error {
 	}
 	return nil
 }
+
+func (c *mockClient) PingWithContext(ctx context.Context) error {
+	if pingErr {
+		return errors.New("Cannot connect to the Docker daemon")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Config is the configuration of an external builder
+type Config struct {
+	// Name identifies the builder in logs
+	Name string `mapstructure:"name" yaml:"name"`
+	// Path is the directory of the builder. The bin directory in it contains the
+	// detect and build executables, and optionally the release and run executables.
+	Path string `mapstructure:"path" yaml:"path"`
+	// EnvironmentWhitelist are the environment variables of the peer
+	// that are passed to the executables of the builder
+	EnvironmentWhitelist []string `mapstructure:"environmentWhitelist" yaml:"environmentWhitelist"`
+}
+
+// Builder builds and launches chaincode by running the executables of an external builder:
+//   - bin/detect <source dir> <metadata dir> exits with 0 if the builder builds the chaincode
+//   - bin/build <source dir> <metadata dir> <build output dir> builds the chaincode
+//   - bin/release <build output dir> <release output dir> provides the chaincode with
+//     artifacts such as CouchDB indexes, and is optional. For chaincode that runs as a
+//     server, it provides the chaincode/server/connection.json file the peer connects with.
+//   - bin/run <build output dir> <run metadata dir> runs the chaincode until it is stopped,
+//     and is optional. Builders without it build chaincode that is launched externally,
+//     and that connects to the peer by itself.
+type Builder struct {
+	Config
+}
+
+// NewBuilder creates a builder out of the given configuration
+func NewBuilder(config Config) *Builder {
+	if config.Name == "" {
+		config.Name = filepath.Base(config.Path)
+	}
+	return &Builder{Config: config}
+}
+
+// detect returns whether the builder builds the chaincode in the given source directory
+func (b *Builder) detect(sourceDir, metadataDir string) bool {
+	detect := b.executable("detect")
+	if _, err := os.Stat(detect); err != nil {
+		logger.Warningf("Builder %s has no detect executable: %v", b.Name, err)
+		return false
+	}
+	if err := b.command(detect, sourceDir, metadataDir).Run(); err != nil {
+		logger.Debugf("Builder %s didn't detect the chaincode: %v", b.Name, err)
+		return false
+	}
+	return true
+}
+
+// build builds the chaincode in the given source directory into the given output directory
+func (b *Builder) build(sourceDir, metadataDir, outputDir string) error {
+	return b.runToCompletion("build", sourceDir, metadataDir, outputDir)
+}
+
+// release provides the artifacts of the built chaincode in the given release directory,
+// if the builder has a release executable
+func (b *Builder) release(outputDir, releaseDir string) error {
+	if !b.has("release") {
+		return nil
+	}
+	return b.runToCompletion("release", outputDir, releaseDir)
+}
+
+// run starts running the built chaincode, and returns the started command,
+// or nil if the builder has no run executable
+func (b *Builder) run(outputDir, runMetadataDir string) (*exec.Cmd, error) {
+	if !b.has("run") {
+		return nil, nil
+	}
+	cmd := b.command(b.executable("run"), outputDir, runMetadataDir)
+	cmd.Stdout = &logWriter{prefix: b.Name}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "builder %s failed starting chaincode", b.Name)
+	}
+	return cmd, nil
+}
+
+func (b *Builder) runToCompletion(name string, args ...string) error {
+	out, err := b.command(b.executable(name), args...).CombinedOutput()
+	logger.Debugf("Output of %s of builder %s: %s", name, b.Name, out)
+	if err != nil {
+		return errors.Wrapf(err, "%s of builder %s failed: %s", name, b.Name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (b *Builder) command(path string, args ...string) *exec.Cmd {
+	cmd := exec.Command(path, args...)
+	cmd.Env = []string{}
+	for _, name := range b.EnvironmentWhitelist {
+		if value, exists := os.LookupEnv(name); exists {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+	return cmd
+}
+
+func (b *Builder) has(name string) bool {
+	_, err := os.Stat(b.executable(name))
+	return err == nil
+}
+
+func (b *Builder) executable(name string) string {
+	return filepath.Join(b.Path, "bin", name)
+}
+
+// logWriter logs the output of running chaincode
+type logWriter struct {
+	prefix string
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(bytes.TrimRight(p, "\n"), []byte("\n")) {
+		logger.Infof("[%s] %s", w.prefix, line)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,444 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("externalbuilder")
+
+const chaincodeIDEnv = "CORE_CHAINCODE_ID_NAME="
+
+// Provider creates VMs that build and launch chaincode with the first of the external builders
+// that detects it, and with VMs of the fallback provider if none of them does
+type Provider struct {
+	PeerAddress string
+	WorkDir     string
+	Builders    []*Builder
+	Fallback    container.VMProvider
+
+	lock      sync.Mutex
+	instances map[string]*instance
+}
+
+// NewProvider creates a provider that builds chaincode with the given external builders in
+// the given work directory, and launches chaincode that connects to the given peer address.
+// Chaincode that none of the builders detects is built and launched by VMs of the given
+// fallback provider, if it isn't nil.
+func NewProvider(peerAddress, workDir string, configs []Config, fallback container.VMProvider) *Provider {
+	p := &Provider{
+		PeerAddress: peerAddress,
+		WorkDir:     workDir,
+		Fallback:    fallback,
+		instances:   make(map[string]*instance),
+	}
+	for _, config := range configs {
+		p.Builders = append(p.Builders, NewBuilder(config))
+	}
+	return p
+}
+
+// NewVM creates a new ExternalVM
+func (p *Provider) NewVM() container.VM {
+	vm := &ExternalVM{provider: p}
+	if p.Fallback != nil {
+		vm.fallback = p.Fallback.NewVM()
+	}
+	return vm
+}
+
+func (p *Provider) addInstance(name string, inst *instance) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.instances[name] = inst
+}
+
+func (p *Provider) instance(name string) *instance {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.instances[name]
+}
+
+func (p *Provider) removeInstance(name string) *instance {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	inst := p.instances[name]
+	delete(p.instances, name)
+	return inst
+}
+
+// instance is chaincode built by an external builder
+type instance struct {
+	builder string
+	dir     string
+	// cmd is the running chaincode, or nil if the chaincode is launched externally
+	cmd  *exec.Cmd
+	done chan struct{}
+	// server is the connection to the chaincode, if it runs as a server
+	server *serverConnection
+}
+
+func (inst *instance) wait() {
+	err := inst.cmd.Wait()
+	logger.Infof("Chaincode run by builder %s exited: %v", inst.builder, err)
+	close(inst.done)
+}
+
+// stop stops the running chaincode, and waits up to the given timeout for it to exit
+// before killing it, unless told not to kill it
+func (inst *instance) stop(timeout time.Duration, dontkill bool) {
+	if inst.server != nil {
+		inst.server.close()
+	}
+	if inst.cmd == nil {
+		return
+	}
+	inst.cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-inst.done:
+		return
+	case <-time.After(timeout):
+	}
+	if dontkill {
+		return
+	}
+	inst.cmd.Process.Kill()
+	<-inst.done
+}
+
+// ExternalVM is a VM that builds and launches chaincode with external builders
+type ExternalVM struct {
+	provider *Provider
+	fallback container.VM
+}
+
+// chaincodeConfig is the configuration the run executable of a builder
+// is given in the chaincode.json file of the run metadata directory
+type chaincodeConfig struct {
+	ChaincodeID string `json:"chaincode_id"`
+	PeerAddress string `json:"peer_address"`
+	ClientCert  string `json:"client_cert,omitempty"`
+	ClientKey   string `json:"client_key,omitempty"`
+	RootCert    string `json:"root_cert,omitempty"`
+}
+
+// chaincodeMetadata is the metadata of the chaincode the executables of
+// a builder are given in the metadata.json file of the metadata directory
+type chaincodeMetadata struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// Start builds the chaincode with the first external builder that detects it and launches it
+func (vm *ExternalVM) Start(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	name := ccid.GetName()
+	var cds *pb.ChaincodeDeploymentSpec
+	if platformBuilder, isPlatformBuilder := builder.(*container.PlatformBuilder); isPlatformBuilder {
+		cds = platformBuilder.DeploymentSpec
+	}
+	if cds == nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+		return vm.startFallback(ctxt, ccid, args, env, filesToUpload, builder)
+	}
+
+	// Chaincode that runs as a server is connected to again without being rebuilt
+	if inst := vm.provider.instance(name); inst != nil && inst.server != nil {
+		ccSupport, err := ccSupportOf(ctxt)
+		if err != nil {
+			return err
+		}
+		return inst.server.connect(ccSupport)
+	}
+
+	// Stop the chaincode if it's already running, as it's about to be rebuilt
+	if err := vm.Stop(ctxt, ccid, 0, false, false); err != nil {
+		logger.Warningf("Failed stopping chaincode %s before starting it: %v", name, err)
+	}
+
+	dir := filepath.Join(vm.provider.WorkDir, name)
+	dirs, err := createDirs(dir, "source", "metadata", "build", "release", "run")
+	if err != nil {
+		return err
+	}
+	sourceDir, metadataDir, buildDir, releaseDir, runDir := dirs[0], dirs[1], dirs[2], dirs[3], dirs[4]
+	if err := writeSource(cds, sourceDir, metadataDir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+
+	detected := vm.detect(sourceDir, metadataDir)
+	if detected == nil {
+		os.RemoveAll(dir)
+		return vm.startFallback(ctxt, ccid, args, env, filesToUpload, builder)
+	}
+
+	logger.Infof("Building chaincode %s with builder %s", name, detected.Name)
+	if err := detected.build(sourceDir, metadataDir, buildDir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	if err := detected.release(buildDir, releaseDir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	config := vm.chaincodeConfig(env, filesToUpload)
+	info, err := readConnectionInfo(releaseDir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	if info != nil {
+		err := vm.connect(ctxt, name, dir, detected, info, config)
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+		return err
+	}
+	if err := writeJSON(filepath.Join(runDir, "chaincode.json"), config); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	cmd, err := detected.run(buildDir, runDir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	inst := &instance{
+		builder: detected.Name,
+		dir:     dir,
+		cmd:     cmd,
+		done:    make(chan struct{}),
+	}
+	if cmd == nil {
+		logger.Infof("Builder %s doesn't run chaincode, chaincode %s is expected to be launched externally", detected.Name, name)
+	} else {
+		go inst.wait()
+	}
+	vm.provider.addInstance(name, inst)
+	return nil
+}
+
+// BuildImage builds the image of chaincode that no external builder detects with the fallback VM.
+// Chaincode that an external builder detects is built when it's launched.
+func (vm *ExternalVM) BuildImage(ctxt context.Context, ccid ccintf.CCID, builder container.Builder) error {
+	fallback, ok := vm.fallback.(container.ImageBuilder)
+	if !ok {
+		return nil
+	}
+	var cds *pb.ChaincodeDeploymentSpec
+	if platformBuilder, isPlatformBuilder := builder.(*container.PlatformBuilder); isPlatformBuilder {
+		cds = platformBuilder.DeploymentSpec
+	}
+	if cds == nil || cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+		return fallback.BuildImage(ctxt, ccid, builder)
+	}
+
+	dir, err := ioutil.TempDir("", "detect")
+	if err != nil {
+		return errors.Wrap(err, "failed creating temporary directory")
+	}
+	defer os.RemoveAll(dir)
+	dirs, err := createDirs(dir, "source", "metadata")
+	if err != nil {
+		return err
+	}
+	if err := writeSource(cds, dirs[0], dirs[1]); err != nil {
+		return err
+	}
+	if detected := vm.detect(dirs[0], dirs[1]); detected != nil {
+		logger.Debugf("Chaincode %s is built by builder %s when it's launched", ccid.GetName(), detected.Name)
+		return nil
+	}
+	return fallback.BuildImage(ctxt, ccid, builder)
+}
+
+// detect returns the first external builder that detects the chaincode, if any
+func (vm *ExternalVM) detect(sourceDir, metadataDir string) *Builder {
+	for _, b := range vm.provider.Builders {
+		if b.detect(sourceDir, metadataDir) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Stop stops the chaincode if it was built by an external builder
+func (vm *ExternalVM) Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
+	inst := vm.provider.removeInstance(ccid.GetName())
+	if inst == nil {
+		if vm.fallback == nil {
+			return nil
+		}
+		return vm.fallback.Stop(ctxt, ccid, timeout, dontkill, dontremove)
+	}
+	inst.stop(time.Duration(timeout)*time.Second, dontkill)
+	if !dontremove {
+		os.RemoveAll(inst.dir)
+	}
+	return nil
+}
+
+// connect connects to chaincode that runs as a server, instead of running the chaincode
+func (vm *ExternalVM) connect(ctxt context.Context, name, dir string, builder *Builder, info *connectionInfo, config *chaincodeConfig) error {
+	ccSupport, err := ccSupportOf(ctxt)
+	if err != nil {
+		return err
+	}
+	server, err := newServerConnection(name, info, config)
+	if err != nil {
+		return err
+	}
+	if err := server.connect(ccSupport); err != nil {
+		return err
+	}
+	vm.provider.addInstance(name, &instance{
+		builder: builder.Name,
+		dir:     dir,
+		done:    make(chan struct{}),
+		server:  server,
+	})
+	return nil
+}
+
+func (vm *ExternalVM) startFallback(ctxt context.Context, ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+	if vm.fallback == nil {
+		return errors.Errorf("no external builder detected chaincode %s", ccid.GetName())
+	}
+	return vm.fallback.Start(ctxt, ccid, args, env, filesToUpload, builder)
+}
+
+// chaincodeConfig returns the configuration of the chaincode out of the environment and
+// the TLS files it would have been launched with in a container
+func (vm *ExternalVM) chaincodeConfig(env []string, filesToUpload map[string][]byte) *chaincodeConfig {
+	config := &chaincodeConfig{PeerAddress: vm.provider.PeerAddress}
+	for _, e := range env {
+		if strings.HasPrefix(e, chaincodeIDEnv) {
+			config.ChaincodeID = strings.TrimPrefix(e, chaincodeIDEnv)
+		}
+	}
+	for path, content := range filesToUpload {
+		switch filepath.Base(path) {
+		case "client.crt":
+			config.ClientCert = string(content)
+		case "client.key":
+			config.ClientKey = string(content)
+		case "peer.crt":
+			config.RootCert = string(content)
+		}
+	}
+	return config
+}
+
+func ccSupportOf(ctxt context.Context) (ccintf.CCSupport, error) {
+	ccSupport, ok := ctxt.Value(ccintf.GetCCHandlerKey()).(ccintf.CCSupport)
+	if !ok {
+		return nil, errors.New("chaincode support is not available to connect to chaincode servers")
+	}
+	return ccSupport, nil
+}
+
+// writeSource extracts the chaincode package to the source directory and
+// writes its metadata to the metadata directory
+func writeSource(cds *pb.ChaincodeDeploymentSpec, sourceDir, metadataDir string) error {
+	if err := untar(cds.CodePackage, sourceDir); err != nil {
+		return errors.WithMessage(err, "failed extracting chaincode package")
+	}
+	return writeJSON(filepath.Join(metadataDir, "metadata.json"), &chaincodeMetadata{
+		Path:  cds.ChaincodeSpec.ChaincodeId.Path,
+		Type:  strings.ToLower(cds.ChaincodeSpec.Type.String()),
+		Label: cds.ChaincodeSpec.ChaincodeId.Name + ":" + cds.ChaincodeSpec.ChaincodeId.Version,
+	})
+}
+
+func createDirs(dir string, names ...string) ([]string, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "failed removing %s", dir)
+	}
+	var dirs []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed creating %s", path)
+		}
+		dirs = append(dirs, path)
+	}
+	return dirs, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshaling %s", filepath.Base(path))
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed writing %s", path)
+	}
+	return nil
+}
+
+// untar extracts the given gzipped tar into the given directory
+func untar(gzippedTar []byte, dir string) error {
+	gr, err := gzip.NewReader(bytes.NewReader(gzippedTar))
+	if err != nil {
+		return errors.Wrap(err, "failed reading gzip")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed reading tar")
+		}
+		path := filepath.Join(dir, header.Name)
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return errors.Errorf("illegal file path %s", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return errors.Wrapf(err, "failed creating %s", path)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return errors.Wrapf(err, "failed creating %s", filepath.Dir(path))
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode)|0600)
+			if err != nil {
+				return errors.Wrapf(err, "failed creating %s", path)
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return errors.Wrapf(err, "failed writing %s", path)
+			}
+		default:
+			logger.Debugf("Skipping %s of type %c", header.Name, header.Typeflag)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/mock"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func platformBuilder(t *testing.T, ccType pb.ChaincodeSpec_Type, files map[string]string) *container.PlatformBuilder {
+	return &container.PlatformBuilder{
+		DeploymentSpec: &pb.ChaincodeDeploymentSpec{
+			ChaincodeSpec: &pb.ChaincodeSpec{
+				Type:        ccType,
+				ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0", Path: "github.com/mycc"},
+			},
+			CodePackage: codePackage(t, files),
+		},
+	}
+}
+
+func TestExternalVM(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "externalbuilds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(workDir)
+
+	fallbackVM := &mock.VM{}
+	fallback := &mock.VMProvider{}
+	fallback.NewVMReturns(fallbackVM)
+	provider := NewProvider("peer0:7052", workDir, []Config{
+		{Path: "testdata/nobuild"},
+		{Name: "go", Path: "testdata/goonly"},
+	}, fallback)
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	dir := filepath.Join(workDir, ccid.GetName())
+	env := []string{"CORE_CHAINCODE_ID_NAME=mycc:1.0", "CORE_PEER_TLS_ENABLED=true"}
+	filesToUpload := map[string][]byte{
+		"/etc/hyperledger/fabric/client.crt": []byte("cert"),
+		"/etc/hyperledger/fabric/client.key": []byte("key"),
+		"/etc/hyperledger/fabric/peer.crt":   []byte("root"),
+	}
+
+	// Scenario I: The chaincode is detected by the second builder, which builds,
+	// releases and runs it with the configuration of the chaincode
+	builder := platformBuilder(t, pb.ChaincodeSpec_GOLANG, map[string]string{"src/github.com/mycc/main.go": "package main"})
+	err = vm.Start(context.Background(), ccid, nil, env, filesToUpload, builder)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fallbackVM.StartCallCount())
+	built, err := ioutil.ReadFile(filepath.Join(dir, "build", "src", "github.com", "mycc", "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main", string(built))
+	_, err = os.Stat(filepath.Join(dir, "release", "released"))
+	assert.NoError(t, err)
+
+	// The run executable copies the configuration of the chaincode once it's running
+	var data []byte
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if data, err = ioutil.ReadFile(filepath.Join(dir, "chaincode.json")); err == nil && len(data) > 0 {
+			break
+		}
+	}
+	config := &chaincodeConfig{}
+	assert.NoError(t, json.Unmarshal(data, config))
+	assert.Equal(t, &chaincodeConfig{
+		ChaincodeID: "mycc:1.0",
+		PeerAddress: "peer0:7052",
+		ClientCert:  "cert",
+		ClientKey:   "key",
+		RootCert:    "root",
+	}, config)
+
+	// Scenario II: The chaincode is stopped, and its directory is removed
+	err = vm.Stop(context.Background(), ccid, 5, false, false)
+	assert.NoError(t, err)
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "directory of the chaincode should have been removed")
+	assert.Equal(t, 0, fallbackVM.StopCallCount())
+
+	// Scenario III: No builder detects the chaincode, hence it's started by the fallback VM
+	builder = platformBuilder(t, pb.ChaincodeSpec_NODE, map[string]string{"src/package.json": "{}"})
+	err = vm.Start(context.Background(), ccid, nil, env, filesToUpload, builder)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fallbackVM.StartCallCount())
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "directory of the chaincode should have been removed")
+
+	// Scenario IV: Chaincode that wasn't built by an external builder is stopped by the fallback VM
+	err = vm.Stop(context.Background(), ccid, 5, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fallbackVM.StopCallCount())
+
+	// Scenario V: The builder isn't a platform builder, hence the chaincode is started by the fallback VM
+	err = vm.Start(context.Background(), ccid, nil, env, filesToUpload, &mock.Builder{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fallbackVM.StartCallCount())
+}
+
+func TestExternalVMWithoutFallback(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "externalbuilds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(workDir)
+
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	builder := platformBuilder(t, pb.ChaincodeSpec_NODE, map[string]string{"src/package.json": "{}"})
+
+	// Scenario I: No builder detects the chaincode
+	vm := NewProvider("peer0:7052", workDir, []Config{{Path: "testdata/goonly"}}, nil).NewVM()
+	err = vm.Start(context.Background(), ccid, nil, nil, nil, builder)
+	assert.EqualError(t, err, "no external builder detected chaincode mycc-1.0")
+
+	// Scenario II: The build fails
+	vm = NewProvider("peer0:7052", workDir, []Config{{Path: "testdata/failbuild"}}, nil).NewVM()
+	err = vm.Start(context.Background(), ccid, nil, nil, nil, builder)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "build of builder failbuild failed: compilation failed")
+	_, err = os.Stat(filepath.Join(workDir, ccid.GetName()))
+	assert.True(t, os.IsNotExist(err), "directory of the chaincode should have been removed")
+
+	// Scenario III: The builder doesn't run chaincode, hence stopping it only removes its directory
+	vm = NewProvider("peer0:7052", workDir, []Config{{Path: "testdata/failbuild"}}, nil).NewVM()
+	provider := vm.(*ExternalVM).provider
+	provider.addInstance(ccid.GetName(), &instance{dir: filepath.Join(workDir, ccid.GetName())})
+	assert.NoError(t, os.MkdirAll(filepath.Join(workDir, ccid.GetName()), 0755))
+	assert.NoError(t, vm.Stop(context.Background(), ccid, 5, false, false))
+	_, err = os.Stat(filepath.Join(workDir, ccid.GetName()))
+	assert.True(t, os.IsNotExist(err), "directory of the chaincode should have been removed")
+}
+
+func TestExternalVMBuildImage(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "externalbuilds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(workDir)
+
+	fallbackImageBuilder := &mock.ImageBuilder{}
+	fallback := &mock.VMProvider{}
+	fallback.NewVMReturns(&struct {
+		*mock.VM
+		*mock.ImageBuilder
+	}{&mock.VM{}, fallbackImageBuilder})
+	vm := NewProvider("peer0:7052", workDir, []Config{{Path: "testdata/goonly"}}, fallback).NewVM()
+	imageBuilder, ok := vm.(container.ImageBuilder)
+	assert.True(t, ok)
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+
+	// Scenario I: Chaincode detected by a builder is built when it's launched
+	builder := platformBuilder(t, pb.ChaincodeSpec_GOLANG, map[string]string{"src/github.com/mycc/main.go": "package main"})
+	assert.NoError(t, imageBuilder.BuildImage(context.Background(), ccid, builder))
+	assert.Equal(t, 0, fallbackImageBuilder.BuildImageCallCount())
+	_, err = os.Stat(filepath.Join(workDir, ccid.GetName()))
+	assert.True(t, os.IsNotExist(err), "chaincode shouldn't have been built")
+
+	// Scenario II: The image of chaincode that no builder detects is built by the fallback VM
+	builder = platformBuilder(t, pb.ChaincodeSpec_NODE, map[string]string{"src/package.json": "{}"})
+	assert.NoError(t, imageBuilder.BuildImage(context.Background(), ccid, builder))
+	assert.Equal(t, 1, fallbackImageBuilder.BuildImageCallCount())
+	_, buildCCID, fallbackBuilder := fallbackImageBuilder.BuildImageArgsForCall(0)
+	assert.Equal(t, ccid, buildCCID)
+	assert.Equal(t, builder, fallbackBuilder)
+
+	// Scenario III: The fallback VM doesn't build images
+	fallback.NewVMReturns(&mock.VM{})
+	vm = NewProvider("peer0:7052", workDir, []Config{{Path: "testdata/goonly"}}, fallback).NewVM()
+	assert.NoError(t, vm.(container.ImageBuilder).BuildImage(context.Background(), ccid, builder))
+	assert.Equal(t, 1, fallbackImageBuilder.BuildImageCallCount())
+}
+
+func TestUntar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "untar")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Scenario I: The package isn't gzipped
+	err = untar([]byte("not gzipped"), dir)
+	assert.Error(t, err)
+
+	// Scenario II: A file in the package escapes the directory
+	err = untar(codePackage(t, map[string]string{"../escaped": "content"}), dir)
+	assert.EqualError(t, err, "illegal file path ../escaped")
+}
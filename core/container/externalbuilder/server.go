@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// connectionFile is the file in the release directory that the release executable of a
+// builder provides for chaincode that runs as a server, instead of the run executable running it
+const connectionFile = "chaincode/server/connection.json"
+
+// connectionInfo is the information the peer connects to chaincode that runs as a server with
+type connectionInfo struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	// ClientKey and ClientCert are the PEM encoded key and certificate the peer authenticates with.
+	// If they aren't set, the peer authenticates with the client certificate it generated for the chaincode.
+	ClientKey  string `json:"client_key"`
+	ClientCert string `json:"client_cert"`
+	// RootCert is the PEM encoded certificate of the CA of the chaincode server
+	RootCert string `json:"root_cert"`
+}
+
+// readConnectionInfo reads the connection information in the given release directory,
+// and returns nil if there is none, as the chaincode doesn't run as a server
+func readConnectionInfo(releaseDir string) (*connectionInfo, error) {
+	data, err := ioutil.ReadFile(filepath.Join(releaseDir, connectionFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading connection information")
+	}
+	info := &connectionInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling connection information")
+	}
+	if info.Address == "" {
+		return nil, errors.New("connection information doesn't contain an address")
+	}
+	return info, nil
+}
+
+// clientConfig returns the configuration of the client that connects to the chaincode server,
+// which authenticates with the client certificate of the given chaincode configuration unless
+// the connection information specifies another one
+func (info *connectionInfo) clientConfig(config *chaincodeConfig) (comm.ClientConfig, error) {
+	clientConfig := comm.ClientConfig{
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: comm.DefaultConnectionTimeout,
+		SecOpts: &comm.SecureOptions{},
+	}
+	if info.DialTimeout != "" {
+		timeout, err := time.ParseDuration(info.DialTimeout)
+		if err != nil {
+			return comm.ClientConfig{}, errors.Wrapf(err, "invalid dial timeout %s", info.DialTimeout)
+		}
+		clientConfig.Timeout = timeout
+	}
+	if !info.TLSRequired {
+		return clientConfig, nil
+	}
+	if info.RootCert == "" {
+		return comm.ClientConfig{}, errors.New("root certificate is required when TLS is required")
+	}
+	clientConfig.SecOpts.UseTLS = true
+	clientConfig.SecOpts.ServerRootCAs = [][]byte{[]byte(info.RootCert)}
+	if !info.ClientAuthRequired {
+		return clientConfig, nil
+	}
+	key, cert := info.ClientKey, info.ClientCert
+	if key == "" && cert == "" {
+		key, cert = config.ClientKey, config.ClientCert
+	}
+	if key == "" || cert == "" {
+		return comm.ClientConfig{}, errors.New("client key and certificate are required when client authentication is required")
+	}
+	clientConfig.SecOpts.RequireClientCert = true
+	clientConfig.SecOpts.Key = []byte(key)
+	clientConfig.SecOpts.Certificate = []byte(cert)
+	return clientConfig, nil
+}
+
+// serverConnection is the connection of the peer to chaincode that runs as a server
+type serverConnection struct {
+	name         string
+	address      string
+	clientConfig comm.ClientConfig
+
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+}
+
+func newServerConnection(name string, info *connectionInfo, config *chaincodeConfig) (*serverConnection, error) {
+	clientConfig, err := info.clientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &serverConnection{
+		name:         name,
+		address:      info.Address,
+		clientConfig: clientConfig,
+	}, nil
+}
+
+// connect connects to the chaincode server, and hands the stream the chaincode registers on
+// over to the given chaincode support. Keepalive pings detect chaincode servers that stopped
+// responding, which breaks the stream, and the chaincode is connected to again when it's next launched.
+func (sc *serverConnection) connect(ccSupport ccintf.CCSupport) error {
+	sc.close()
+	client, err := comm.NewGRPCClient(sc.clientConfig)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating chaincode server client")
+	}
+	conn, err := client.NewConnection(sc.address, "")
+	if err != nil {
+		return errors.WithMessage(err, "failed connecting to chaincode server at "+sc.address)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return errors.Wrapf(err, "failed opening stream to chaincode server at %s", sc.address)
+	}
+	sc.conn, sc.cancel = conn, cancel
+
+	logger.Infof("Connected to chaincode %s at %s", sc.name, sc.address)
+	go func() {
+		err := ccSupport.HandleChaincodeStream(ctx, stream)
+		logger.Warningf("Connection to chaincode %s at %s ended: %v", sc.name, sc.address, err)
+		cancel()
+		conn.Close()
+	}()
+	return nil
+}
+
+// close closes the connection to the chaincode server, if there is one
+func (sc *serverConnection) close() {
+	if sc.cancel == nil {
+		return
+	}
+	sc.cancel()
+	sc.conn.Close()
+	sc.cancel, sc.conn = nil, nil
+}
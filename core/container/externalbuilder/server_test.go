@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type chaincodeServer struct {
+	connections chan struct{}
+}
+
+func (cs *chaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	cs.connections <- struct{}{}
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+type ccSupport struct {
+	registered chan *pb.ChaincodeMessage
+}
+
+func (cs *ccSupport) HandleChaincodeStream(ctxt context.Context, stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	cs.registered <- msg
+	_, err = stream.Recv()
+	return err
+}
+
+func TestChaincodeServer(t *testing.T) {
+	workDir, err := ioutil.TempDir("", "externalbuilds")
+	assert.NoError(t, err)
+	defer os.RemoveAll(workDir)
+
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{SecOpts: &comm.SecureOptions{}})
+	assert.NoError(t, err)
+	cs := &chaincodeServer{connections: make(chan struct{}, 10)}
+	pb.RegisterChaincodeServer(server.Server(), cs)
+	go server.Start()
+	defer server.Stop()
+
+	os.Setenv("CHAINCODE_SERVER_ADDRESS", server.Address())
+	defer os.Unsetenv("CHAINCODE_SERVER_ADDRESS")
+	provider := NewProvider("peer0:7052", workDir, []Config{
+		{Path: "testdata/ccaas", EnvironmentWhitelist: []string{"CHAINCODE_SERVER_ADDRESS"}},
+	}, nil)
+	vm := provider.NewVM()
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	support := &ccSupport{registered: make(chan *pb.ChaincodeMessage, 10)}
+	ctxt := context.WithValue(context.Background(), ccintf.GetCCHandlerKey(), support)
+	builder := platformBuilder(t, pb.ChaincodeSpec_GOLANG, map[string]string{"src/main.go": "package main"})
+
+	assertRegistered := func() {
+		select {
+		case <-cs.connections:
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "peer didn't connect to the chaincode server")
+		}
+		select {
+		case msg := <-support.registered:
+			assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "chaincode didn't register on the stream")
+		}
+	}
+
+	// Scenario I: The chaincode isn't launched without the chaincode support to connect it to
+	err = vm.Start(context.Background(), ccid, nil, nil, nil, builder)
+	assert.EqualError(t, err, "chaincode support is not available to connect to chaincode servers")
+
+	// Scenario II: The peer connects to the chaincode server provided by the release
+	// executable, and the stream is handed over to the chaincode support
+	err = vm.Start(ctxt, ccid, nil, nil, nil, builder)
+	assert.NoError(t, err)
+	assertRegistered()
+
+	// Scenario III: The chaincode is launched again, and the peer connects
+	// to the chaincode server again without rebuilding the chaincode
+	assert.NoError(t, os.Unsetenv("CHAINCODE_SERVER_ADDRESS"))
+	err = vm.Start(ctxt, ccid, nil, nil, nil, builder)
+	assert.NoError(t, err)
+	assertRegistered()
+
+	// Scenario IV: The chaincode is stopped, which closes the connection
+	inst := provider.instance(ccid.GetName())
+	assert.NotNil(t, inst)
+	assert.NoError(t, vm.Stop(ctxt, ccid, 5, false, false))
+	assert.Nil(t, inst.server.conn)
+	_, err = os.Stat(filepath.Join(workDir, ccid.GetName()))
+	assert.True(t, os.IsNotExist(err), "directory of the chaincode should have been removed")
+}
+
+func TestReadConnectionInfo(t *testing.T) {
+	releaseDir, err := ioutil.TempDir("", "release")
+	assert.NoError(t, err)
+	defer os.RemoveAll(releaseDir)
+	path := filepath.Join(releaseDir, connectionFile)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+
+	// Scenario I: The chaincode doesn't run as a server
+	info, err := readConnectionInfo(filepath.Join(releaseDir, "nonexistent"))
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Scenario II: The connection information isn't valid JSON
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = readConnectionInfo(releaseDir)
+	assert.Contains(t, err.Error(), "failed unmarshaling connection information")
+
+	// Scenario III: The connection information has no address
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0600))
+	_, err = readConnectionInfo(releaseDir)
+	assert.EqualError(t, err, "connection information doesn't contain an address")
+
+	// Scenario IV: The connection information is valid
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"address":"mycc:9999","tls_required":true}`), 0600))
+	info, err = readConnectionInfo(releaseDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &connectionInfo{Address: "mycc:9999", TLSRequired: true}, info)
+}
+
+func TestConnectionInfoClientConfig(t *testing.T) {
+	generated := &chaincodeConfig{ClientKey: "generated key", ClientCert: "generated cert"}
+
+	// Scenario I: TLS isn't required
+	config, err := (&connectionInfo{DialTimeout: "10s"}).clientConfig(generated)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, config.Timeout)
+	assert.False(t, config.SecOpts.UseTLS)
+
+	// Scenario II: The dial timeout is invalid
+	_, err = (&connectionInfo{DialTimeout: "soon"}).clientConfig(generated)
+	assert.Contains(t, err.Error(), "invalid dial timeout soon")
+
+	// Scenario III: TLS is required without a root certificate
+	_, err = (&connectionInfo{TLSRequired: true}).clientConfig(generated)
+	assert.EqualError(t, err, "root certificate is required when TLS is required")
+
+	// Scenario IV: Client authentication is required, and the peer
+	// authenticates with the client certificate it generated
+	info := &connectionInfo{TLSRequired: true, ClientAuthRequired: true, RootCert: "root"}
+	config, err = info.clientConfig(generated)
+	assert.NoError(t, err)
+	assert.Equal(t, comm.DefaultConnectionTimeout, config.Timeout)
+	assert.Equal(t, &comm.SecureOptions{
+		UseTLS:            true,
+		RequireClientCert: true,
+		ServerRootCAs:     [][]byte{[]byte("root")},
+		Key:               []byte("generated key"),
+		Certificate:       []byte("generated cert"),
+	}, config.SecOpts)
+
+	// Scenario V: Client authentication is required, and the peer authenticates
+	// with the client certificate of the connection information
+	info.ClientKey, info.ClientCert = "key", "cert"
+	config, err = info.clientConfig(generated)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("key"), config.SecOpts.Key)
+	assert.Equal(t, []byte("cert"), config.SecOpts.Certificate)
+
+	// Scenario VI: Client authentication is required, but there is no client certificate
+	info.ClientKey, info.ClientCert = "", ""
+	_, err = info.clientConfig(&chaincodeConfig{})
+	assert.EqualError(t, err, "client key and certificate are required when client authentication is required")
+}
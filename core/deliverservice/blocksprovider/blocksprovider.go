@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	gossip_proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 )
 
@@ -165,34 +166,17 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 		case *orderer.DeliverResponse_Block:
 			errorStatusCounter = 0
 			statusCounter = 0
-			seqNum := t.Block.Header.Number
-
-			marshaledBlock, err := proto.Marshal(t.Block)
+			b.processBlock(t.Block)
+		case *orderer.DeliverResponse_BlockBatch:
+			errorStatusCounter = 0
+			statusCounter = 0
+			blocks, err := utils.GetBlocksFromBlockBatch(t.BlockBatch)
 			if err != nil {
-				logger.Errorf("[%s] Error serializing block with sequence number %d, due to %s", b.chainID, seqNum, err)
+				logger.Errorf("[%s] Error extracting blocks from batch, due to %s", b.chainID, err)
 				continue
 			}
-			if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), seqNum, marshaledBlock); err != nil {
-				logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
-				continue
-			}
-
-			numberOfPeers := len(b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)))
-			// Create payload with a block received
-			payload := createPayload(seqNum, marshaledBlock)
-			// Use payload to create gossip message
-			gossipMsg := createGossipMsg(b.chainID, payload)
-
-			logger.Debugf("[%s] Adding payload locally, buffer seqNum = [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
-			// Add payload to local state payloads buffer
-			if err := b.gossip.AddPayload(b.chainID, payload); err != nil {
-				logger.Warning("Failed adding payload of", seqNum, "because:", err)
-			}
-
-			// Gossip messages with other nodes
-			logger.Debugf("[%s] Gossiping block [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
-			if !b.isDone() {
-				b.gossip.Gossip(gossipMsg)
+			for _, block := range blocks {
+				b.processBlock(block)
 			}
 		default:
 			logger.Warningf("[%s] Received unknown: ", b.chainID, t)
@@ -201,6 +185,40 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	}
 }
 
+// processBlock verifies the block received from the ordering service, adds it
+// to the local state and gossips it to the other peers of the channel
+func (b *blocksProviderImpl) processBlock(block *common.Block) {
+	seqNum := block.Header.Number
+
+	marshaledBlock, err := proto.Marshal(block)
+	if err != nil {
+		logger.Errorf("[%s] Error serializing block with sequence number %d, due to %s", b.chainID, seqNum, err)
+		return
+	}
+	if err := b.mcs.VerifyBlock(gossipcommon.ChainID(b.chainID), seqNum, marshaledBlock); err != nil {
+		logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
+		return
+	}
+
+	numberOfPeers := len(b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)))
+	// Create payload with a block received
+	payload := createPayload(seqNum, marshaledBlock)
+	// Use payload to create gossip message
+	gossipMsg := createGossipMsg(b.chainID, payload)
+
+	logger.Debugf("[%s] Adding payload locally, buffer seqNum = [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
+	// Add payload to local state payloads buffer
+	if err := b.gossip.AddPayload(b.chainID, payload); err != nil {
+		logger.Warning("Failed adding payload of", seqNum, "because:", err)
+	}
+
+	// Gossip messages with other nodes
+	logger.Debugf("[%s] Gossiping block [%d], peers number [%d]", b.chainID, seqNum, numberOfPeers)
+	if !b.isDone() {
+		b.gossip.Gossip(gossipMsg)
+	}
+}
+
 // Stop stops blocks delivery provider
 func (b *blocksProviderImpl) Stop() {
 	atomic.StoreInt32(&b.done, 1)
@@ -18,6 +18,7 @@ import (
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -443,3 +444,47 @@ func TestBlockVerificationFailure(t *testing.T) {
 	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
 	makeTestCase(uint64(0), mcs, false, rcvr)(t)
 }
+
+func TestBlockBatchDelivery(t *testing.T) {
+	var blocks []*common.Block
+	for i := uint64(5); i < 8; i++ {
+		blocks = append(blocks, &common.Block{
+			Header: &common.BlockHeader{Number: i},
+			Data:   &common.BlockData{Data: [][]byte{}},
+		})
+	}
+	batch, err := utils.NewBlockBatch(blocks, common.Compression_GZIP)
+	assert.NoError(t, err)
+
+	sent := int32(0)
+	rcvr := func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		if atomic.CompareAndSwapInt32(&sent, 0, 1) {
+			return &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_BlockBatch{BlockBatch: batch},
+			}, nil
+		}
+		time.Sleep(time.Second)
+		return nil, errors.New("no more blocks")
+	}
+
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, len(blocks))}
+	deliverer := &mocks.MockBlocksDeliverer{Pos: 5}
+	deliverer.MockRecv = rcvr
+	provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs)
+	defer provider.Stop()
+	go provider.DeliverBlocks()
+
+	// All blocks of the batch are committed and gossiped in order
+	for i := uint64(5); i < 8; i++ {
+		select {
+		case seqNum := <-gossipServiceAdapter.GossipBlockDisseminations:
+			assert.Equal(t, i, seqNum)
+		case <-time.After(time.Second * 5):
+			assert.Fail(t, "Didn't gossip a block within a timely manner")
+			return
+		}
+	}
+	assert.Equal(t, int32(len(blocks)), atomic.LoadInt32(&gossipServiceAdapter.AddPayloadsCnt))
+}
@@ -46,6 +46,7 @@ type broadcastClient struct {
 	blocksprovider.BlocksDeliverer
 	conn     *connection
 	endpoint string
+	metrics  *deliverMetrics
 }
 
 // NewBroadcastClient returns a broadcastClient with the given params
@@ -159,6 +160,7 @@ func (bc *broadcastClient) afterConnect(conn *grpc.ClientConn, abc orderer.Atomi
 	defer logger.Debug("Exiting")
 	bc.Lock()
 	bc.endpoint = endpoint
+	bc.metrics.reportConnected(endpoint, true)
 	bc.conn = &connection{ClientConn: conn, cancel: cf}
 	bc.BlocksDeliverer = abc
 	if bc.shouldStop() {
@@ -207,6 +209,7 @@ func (bc *broadcastClient) Close() {
 	if bc.conn == nil {
 		return
 	}
+	bc.metrics.reportConnected(bc.endpoint, false)
 	bc.endpoint = ""
 	bc.conn.Close()
 }
@@ -220,6 +223,7 @@ func (bc *broadcastClient) Disconnect(disableEndpoint bool) {
 	if disableEndpoint && bc.endpoint != "" {
 		bc.prod.DisableEndpoint(bc.endpoint)
 	}
+	bc.metrics.reportConnected(bc.endpoint, false)
 	bc.endpoint = ""
 	if bc.conn == nil {
 		return
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverclient
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// deliverMetrics reports the orderer the blocks of a channel are delivered from
+type deliverMetrics struct {
+	scope metrics.Scope
+}
+
+// newDeliverMetrics creates the delivery metrics of the given channel,
+// or returns nil if metrics aren't initialized
+func newDeliverMetrics(chainID string) *deliverMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	return &deliverMetrics{
+		scope: metrics.RootScope.SubScope("deliver").Tagged(map[string]string{"channel": chainID}),
+	}
+}
+
+// reportConnected reports whether the channel is connected to the given orderer endpoint
+func (m *deliverMetrics) reportConnected(endpoint string, connected bool) {
+	if m == nil || endpoint == "" {
+		return
+	}
+	gauge := m.scope.Tagged(map[string]string{"orderer": endpoint}).Gauge("connected")
+	if connected {
+		gauge.Update(1)
+	} else {
+		gauge.Update(0)
+	}
+}
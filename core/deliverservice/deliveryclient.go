@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -49,6 +52,46 @@ func getReConnectBackoffThreshold() float64 {
 	return util.GetFloat64OrDefault("peer.deliveryclient.reConnectBackoffThreshold", defaultReConnectBackoffThreshold)
 }
 
+// getOrdererOrgWeights returns the weights of the ordering organizations,
+// configured as a list of <MSP ID>:<weight> entries
+func getOrdererOrgWeights() map[string]int {
+	weights := make(map[string]int)
+	for _, entry := range viper.GetStringSlice("peer.deliveryclient.ordererOrgWeights") {
+		sep := strings.LastIndex(entry, ":")
+		if sep <= 0 {
+			logger.Warningf("Ignoring orderer organization weight %s, expected <MSP ID>:<weight>", entry)
+			continue
+		}
+		weight, err := strconv.Atoi(entry[sep+1:])
+		if err != nil || weight <= 0 {
+			logger.Warningf("Ignoring orderer organization weight %s, the weight must be a positive integer", entry)
+			continue
+		}
+		weights[entry[:sep]] = weight
+	}
+	return weights
+}
+
+// getBlockStreamOptions returns the compression and batching the delivery
+// service asks the ordering service for, or nil if neither is configured
+func getBlockStreamOptions() *orderer.BlockStreamOptions {
+	options := &orderer.BlockStreamOptions{
+		MaxBatchBlocks: uint32(viper.GetInt("peer.deliveryclient.blockStream.maxBatchBlocks")),
+		MaxBatchBytes:  uint32(viper.GetInt("peer.deliveryclient.blockStream.maxBatchBytes")),
+	}
+	if compression := viper.GetString("peer.deliveryclient.blockStream.compression"); compression != "" {
+		value, exists := common.Compression_value[strings.ToUpper(compression)]
+		if !exists {
+			logger.Warningf("Ignoring unsupported block stream compression %s", compression)
+		}
+		options.Compression = common.Compression(value)
+	}
+	if options.MaxBatchBlocks <= 1 && options.Compression == common.Compression_UNCOMPRESSED {
+		return nil
+	}
+	return options
+}
+
 // DeliverService used to communicate with orderers to obtain
 // new blocks and send them to the committer service
 type DeliverService interface {
@@ -61,9 +104,13 @@ type DeliverService interface {
 	// to channel peers.
 	StopDeliverForChannel(chainID string) error
 
-	// UpdateEndpoints
+	// UpdateEndpoints updates the ordering service endpoints of the channel
 	UpdateEndpoints(chainID string, endpoints []string) error
 
+	// UpdateOrdererOrgs updates the endpoints of each ordering organization of the channel,
+	// which are failed over among according to the organizations' weights
+	UpdateOrdererOrgs(chainID string, orgEndpoints map[string][]string)
+
 	// Stop terminates delivery service and closes the connection
 	Stop()
 }
@@ -76,6 +123,12 @@ type deliverServiceImpl struct {
 	blockProviders map[string]blocksprovider.BlocksProvider
 	lock           sync.RWMutex
 	stopping       bool
+	// endpoints are the latest ordering service endpoints of each channel
+	endpoints map[string][]string
+	// ordererOrgs are the latest endpoints of each ordering organization of each channel,
+	// they are guarded by a lock of their own as connections look them up
+	ordererOrgs     map[string]map[string][]string
+	ordererOrgsLock sync.RWMutex
 }
 
 // Config dictates the DeliveryService's properties,
@@ -105,6 +158,8 @@ func NewDeliverService(conf *Config) (DeliverService, error) {
 	ds := &deliverServiceImpl{
 		conf:           conf,
 		blockProviders: make(map[string]blocksprovider.BlocksProvider),
+		endpoints:      make(map[string][]string),
+		ordererOrgs:    make(map[string]map[string][]string),
 	}
 	if err := ds.validateConfiguration(); err != nil {
 		return nil, err
@@ -113,6 +168,13 @@ func NewDeliverService(conf *Config) (DeliverService, error) {
 }
 
 func (d *deliverServiceImpl) UpdateEndpoints(chainID string, endpoints []string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	// Remember the endpoints, so that delivery restarted for the channel
+	// connects to them rather than to the ones it was created with
+	if len(endpoints) != 0 {
+		d.endpoints[chainID] = endpoints
+	}
 	// Use chainID to obtain blocks provider and pass endpoints
 	// for update
 	if bp, ok := d.blockProviders[chainID]; ok {
@@ -123,6 +185,18 @@ func (d *deliverServiceImpl) UpdateEndpoints(chainID string, endpoints []string)
 	return errors.New(fmt.Sprintf("Channel with %s id was not found", chainID))
 }
 
+func (d *deliverServiceImpl) UpdateOrdererOrgs(chainID string, orgEndpoints map[string][]string) {
+	d.ordererOrgsLock.Lock()
+	defer d.ordererOrgsLock.Unlock()
+	d.ordererOrgs[chainID] = orgEndpoints
+}
+
+func (d *deliverServiceImpl) ordererOrgsOf(chainID string) map[string][]string {
+	d.ordererOrgsLock.RLock()
+	defer d.ordererOrgsLock.RUnlock()
+	return d.ordererOrgs[chainID]
+}
+
 func (d *deliverServiceImpl) validateConfiguration() error {
 	conf := d.conf
 	if len(conf.Endpoints) == 0 {
@@ -215,8 +289,9 @@ func (d *deliverServiceImpl) Stop() {
 
 func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
 	requester := &blocksRequester{
-		tls:     viper.GetBool("peer.tls.enabled"),
-		chainID: chainID,
+		tls:           viper.GetBool("peer.tls.enabled"),
+		chainID:       chainID,
+		streamOptions: getBlockStreamOptions(),
 	}
 	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
 		return requester.RequestBlocks(ledgerInfoProvider)
@@ -229,8 +304,15 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, getReConnectBackoffThreshold())), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	endpoints := d.conf.Endpoints
+	if updated, exists := d.endpoints[chainID]; exists {
+		endpoints = updated
+	}
+	connProd := comm.NewWeightedConnectionProducer(d.conf.ConnFactory(chainID), endpoints, func() map[string][]string {
+		return d.ordererOrgsOf(chainID)
+	}, getOrdererOrgWeights())
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
+	bClient.metrics = newDeliverMetrics(chainID)
 	requester.client = bClient
 	return bClient
 }
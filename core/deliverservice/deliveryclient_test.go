@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -617,6 +618,65 @@ func TestRetryPolicyOverflow(t *testing.T) {
 	}
 }
 
+func TestDeliverServiceRestartWithUpdatedEndpoints(t *testing.T) {
+	// Scenario: The endpoints of a channel are updated while delivery isn't running for it,
+	// and delivery that is started afterwards should connect to the updated endpoints
+	connFactory := func(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
+		return func(_ string) (*grpc.ClientConn, error) {
+			return nil, errors.New("")
+		}
+	}
+	ds, err := NewDeliverService(&Config{
+		Endpoints:   []string{"localhost:5611"},
+		Gossip:      &mocks.MockGossipServiceAdapter{},
+		CryptoSvc:   &mockMCS{},
+		ABCFactory:  DefaultABCFactory,
+		ConnFactory: connFactory,
+	})
+	assert.NoError(t, err)
+	d := ds.(*deliverServiceImpl)
+
+	assert.Error(t, d.UpdateEndpoints("TEST", []string{"localhost:5612", "localhost:5613"}))
+	d.UpdateOrdererOrgs("TEST", map[string][]string{"OrdererMSP": {"localhost:5612"}})
+	client := d.newClient("TEST", &mocks.MockLedgerInfo{Height: uint64(100)})
+	assert.Equal(t, []string{"localhost:5612", "localhost:5613"}, client.GetEndpoints())
+	assert.Equal(t, map[string][]string{"OrdererMSP": {"localhost:5612"}}, d.ordererOrgsOf("TEST"))
+
+	// Other channels still connect to the endpoints the delivery service was created with
+	client = d.newClient("OTHER", &mocks.MockLedgerInfo{Height: uint64(100)})
+	assert.Equal(t, []string{"localhost:5611"}, client.GetEndpoints())
+}
+
+func TestOrdererOrgWeights(t *testing.T) {
+	defer viper.Reset()
+
+	// Scenario I: No weights are configured
+	assert.Empty(t, getOrdererOrgWeights())
+
+	// Scenario II: Malformed weights and weights that aren't positive are ignored
+	viper.Set("peer.deliveryclient.ordererOrgWeights", []string{"OrdererMSP:3", "Orderer:Org2MSP:2", "NoWeightMSP", ":4", "ZeroMSP:0", "BadMSP:x"})
+	assert.Equal(t, map[string]int{"OrdererMSP": 3, "Orderer:Org2MSP": 2}, getOrdererOrgWeights())
+}
+
+func TestBlockStreamOptions(t *testing.T) {
+	defer viper.Reset()
+
+	// Scenario I: Neither compression nor batching is configured
+	assert.Nil(t, getBlockStreamOptions())
+	viper.Set("peer.deliveryclient.blockStream.maxBatchBlocks", 1)
+	assert.Nil(t, getBlockStreamOptions())
+
+	// Scenario II: Compression only
+	viper.Set("peer.deliveryclient.blockStream.compression", "gzip")
+	assert.Equal(t, &orderer.BlockStreamOptions{Compression: cb.Compression_GZIP, MaxBatchBlocks: 1}, getBlockStreamOptions())
+
+	// Scenario III: Batching with an unsupported compression
+	viper.Set("peer.deliveryclient.blockStream.compression", "lz4")
+	viper.Set("peer.deliveryclient.blockStream.maxBatchBlocks", 10)
+	viper.Set("peer.deliveryclient.blockStream.maxBatchBytes", 1024)
+	assert.Equal(t, &orderer.BlockStreamOptions{MaxBatchBlocks: 10, MaxBatchBytes: 1024}, getBlockStreamOptions())
+}
+
 func assertBlockDissemination(expectedSeq uint64, ch chan uint64, t *testing.T) {
 	select {
 	case seq := <-ch:
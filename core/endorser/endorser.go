@@ -8,15 +8,18 @@ package endorser
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -66,6 +69,10 @@ type Support interface {
 	// SignedProposal from which an id can be extracted for testing against a policy
 	CheckACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, shdr *common.SignatureHeader, hdrext *pb.ChaincodeHeaderExtension) error
 
+	// CheckChaincodeFunctionACL checks the ACL for the function of the chaincode the
+	// SignedProposal invokes, if operators mapped the function to a policy of the Channel
+	CheckChaincodeFunctionACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, hdrext *pb.ChaincodeHeaderExtension, function string) error
+
 	// IsJavaCC returns true if the CDS package bytes describe a chaincode
 	// that requires the java runtime environment to execute
 	IsJavaCC(buf []byte) (bool, error)
@@ -90,6 +97,8 @@ type Endorser struct {
 	distributePrivateData privateDataDistributor
 	s                     Support
 	PvtRWSetAssembler
+	// Limits bound the sizes of the results of simulating proposals
+	Limits SimulationLimits
 }
 
 // validateResult provides the result of endorseProposal verification
@@ -259,6 +268,7 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 	var pubSimResBytes []byte
 	var res *pb.Response
 	var ccevent *pb.ChaincodeEvent
+	startTime := time.Now()
 	res, ccevent, err = e.callChaincode(ctx, chainID, version, txid, signedProp, prop, cis, cid, txsim)
 	if err != nil {
 		endorserLogger.Errorf("[%s][%s] failed to invoke chaincode %s, error: %+v", chainID, shorttxid(txid), cid, err)
@@ -270,6 +280,20 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 			txsim.Done()
 			return nil, nil, nil, nil, err
 		}
+		if pubSimResBytes, err = simResult.GetPubSimulationBytes(); err != nil {
+			txsim.Done()
+			return nil, nil, nil, nil, err
+		}
+
+		// Reject the proposal before its private data is distributed if its results are too large
+		sizes := sizesOf(pubSimResBytes, simResult)
+		err = e.Limits.check(cid.Name, sizes)
+		reportSimulation(cid.Name, time.Since(startTime), sizes, err != nil)
+		if err != nil {
+			txsim.Done()
+			endorserLogger.Warningf("[%s][%s] %s", chainID, shorttxid(txid), err)
+			return nil, nil, nil, nil, err
+		}
 
 		if simResult.PvtSimulationResults != nil {
 			if cid.Name == "lscc" {
@@ -291,9 +315,6 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 		}
 
 		txsim.Done()
-		if pubSimResBytes, err = simResult.GetPubSimulationBytes(); err != nil {
-			return nil, nil, nil, nil, err
-		}
 	}
 	return cdLedger, res, pubSimResBytes, ccevent, nil
 }
@@ -308,7 +329,7 @@ func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid strin
 	var escc string
 	// ie, "lscc" or system chaincodes
 	if isSysCC {
-		escc = "escc"
+		escc = endorsement.DefaultPluginName
 	} else {
 		escc = cd.Endorsement()
 	}
@@ -409,6 +430,17 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
 				return vr, err
 			}
+
+			// check that the proposal complies with the policy the function it invokes is mapped to
+			var function string
+			if function, err = invokedFunction(prop); err != nil {
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, err
+			}
+			if err = e.s.CheckChaincodeFunctionACL(signedProp, chdr, hdrExt, function); err != nil {
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, err
+			}
 		}
 	} else {
 		// chainless proposals do not/cannot affect ledger and cannot be submitted as transactions
@@ -422,7 +454,7 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 }
 
 // ProcessProposal process the Proposal
-func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (resp *pb.ProposalResponse, err error) {
 	addr := util.ExtractRemoteAddress(ctx)
 	endorserLogger.Debug("Entering: request from", addr)
 	defer endorserLogger.Debug("Exit: request from", addr)
@@ -436,6 +468,20 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 
 	prop, hdrExt, chainID, txid := vr.prop, vr.hdrExt, vr.chainID, vr.txid
 
+	span := tracing.StartSpan(txid, "endorse")
+	span.SetAttribute("channel", chainID)
+	span.SetAttribute("chaincode", hdrExt.ChaincodeId.GetName())
+	defer func() {
+		span.End(endorsementError(resp, err))
+	}()
+	ctx = flogging.WithFields(ctx, flogging.Fields{
+		Channel:   chainID,
+		TxID:      txid,
+		Chaincode: hdrExt.ChaincodeId.GetName(),
+		SpanID:    span.SpanID(),
+	})
+	txLogger := flogging.FromContext(ctx, endorserLogger)
+
 	// obtaining once the tx simulator for this proposal. This will be nil
 	// for chainless proposals
 	// Also obtain a history query executor for history queries, since tx simulator does not cover history
@@ -476,7 +522,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	}
 	if res != nil {
 		if res.Status >= shim.ERROR {
-			endorserLogger.Errorf("[%s][%s] simulateProposal() resulted in chaincode %s response status %d for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, res.Status, txid)
+			txLogger.Errorf("[%s][%s] simulateProposal() resulted in chaincode %s response status %d for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, res.Status, txid)
 			var cceventBytes []byte
 			if ccevent != nil {
 				cceventBytes, err = putils.GetBytesChaincodeEvent(ccevent)
@@ -507,7 +553,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 		}
 		if pResp.Response.Status >= shim.ERRORTHRESHOLD {
-			endorserLogger.Debugf("[%s][%s] endorseProposal() resulted in chaincode %s error for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, txid)
+			txLogger.Debugf("[%s][%s] endorseProposal() resulted in chaincode %s error for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, txid)
 			return pResp, nil
 		}
 	}
@@ -520,6 +566,31 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	return pResp, nil
 }
 
+// endorsementError returns the error a proposal failed to be endorsed with, if any
+func endorsementError(resp *pb.ProposalResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp.GetResponse().GetStatus() >= shim.ERRORTHRESHOLD {
+		return errors.Errorf("proposal response status %d: %s", resp.Response.Status, resp.Response.Message)
+	}
+	return nil
+}
+
+// invokedFunction returns the function of the chaincode the proposal invokes,
+// which chaincodes take as the first of their arguments by convention
+func invokedFunction(prop *pb.Proposal) (string, error) {
+	cis, err := putils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract the chaincode invocation spec from the proposal")
+	}
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) == 0 {
+		return "", nil
+	}
+	return string(args[0]), nil
+}
+
 // shorttxid replicates the chaincode package function to shorten txids.
 // ~~TODO utilize a common shorttxid utility across packages.~~
 // TODO use a formal type for transaction ID and make it a stringer
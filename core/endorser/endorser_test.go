@@ -289,6 +289,31 @@ func TestEndorserBadACL(t *testing.T) {
 	assert.EqualValues(t, 500, pResp.Response.Status)
 }
 
+func TestEndorserBadChaincodeFunctionACL(t *testing.T) {
+	support := &em.MockSupport{
+		GetApplicationConfigBoolRv:   true,
+		GetApplicationConfigRv:       &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		CheckChaincodeFunctionACLErr: errors.New("failed evaluating policy"),
+		GetTransactionByIDErr:        errors.New(""),
+		ChaincodeDefinitionRv:        &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:                  &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		GetTxSimulatorRv: &mockccprovider.MockTxSim{
+			GetTxSimulationResultsRv: &ledger.TxSimulationResults{
+				PubSimulationResults: &rwset.TxReadWriteSet{},
+			},
+		},
+	}
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support)
+
+	signedProp := getSignedPropWithCHIdAndArgs(util.GetTestChainID(), "ccid", "0", [][]byte{[]byte("transfer"), []byte("a")}, t)
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "failed evaluating policy", pResp.Response.Message)
+	assert.Equal(t, "transfer", support.CheckedChaincodeFunction)
+}
+
 func TestEndorserGoodPathEmptyChannel(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
@@ -520,6 +545,56 @@ func TestEndorserGoodPath(t *testing.T) {
 	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
+func TestEndorserSimulationLimits(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	simResults := &ledger.TxSimulationResults{
+		PubSimulationResults: &rwset.TxReadWriteSet{
+			NsRwset: []*rwset.NsReadWriteSet{{Namespace: "ccid", Rwset: make([]byte, 100)}},
+		},
+	}
+	support := &em.MockSupport{
+		Mock: m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		GetTxSimulatorRv:           &mockccprovider.MockTxSim{GetTxSimulationResultsRv: simResults},
+	}
+	attachPluginEndorser(support)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support)
+	rwsetSize := len(utils.MarshalOrPanic(simResults.PubSimulationResults))
+
+	// Scenario I: The read/write set is within the limit
+	es.Limits = endorser.SimulationLimits{MaxRWSetSize: rwsetSize}
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	// Scenario II: The read/write set exceeds the limit
+	es.Limits = endorser.SimulationLimits{MaxRWSetSize: rwsetSize - 1}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, fmt.Sprintf("simulation of chaincode ccid produced a read/write set of %d bytes, which exceeds the limit of %d bytes", rwsetSize, rwsetSize-1), pResp.Response.Message)
+
+	// Scenario III: The private data exceeds the limit, hence it isn't distributed
+	simResults.PvtSimulationResults = &rwset.TxPvtReadWriteSet{
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+			Namespace:          "ccid",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "col", Rwset: make([]byte, 100)}},
+		}},
+	}
+	pvtDataSize := len(utils.MarshalOrPanic(simResults.PvtSimulationResults))
+	es.Limits = endorser.SimulationLimits{MaxPrivateDataSize: 100}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, fmt.Sprintf("simulation of chaincode ccid produced private data of %d bytes, which exceeds the limit of 100 bytes", pvtDataSize), pResp.Response.Message)
+}
+
 func TestEndorserLSCC(t *testing.T) {
 	m := &mock.Mock{}
 	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+var (
+	// simulationDurationBuckets are the upper bounds (in seconds) of the buckets of the simulation duration histogram
+	simulationDurationBuckets = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+	// simulationSizeBuckets are the upper bounds (in bytes) of the buckets of the read/write set size histograms
+	simulationSizeBuckets = []float64{1 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+)
+
+// SimulationLimits bound the sizes of the results of simulating proposals.
+// Proposals whose simulation exceeds them are rejected. Zero means no limit.
+type SimulationLimits struct {
+	// MaxRWSetSize is the maximum size in bytes of the public read/write set
+	MaxRWSetSize int
+	// MaxPrivateDataSize is the maximum size in bytes of the private read/write set
+	MaxPrivateDataSize int
+}
+
+// simulationSizes are the sizes in bytes of the results of a simulation
+type simulationSizes struct {
+	rwset   int
+	pvtData int
+}
+
+func sizesOf(pubSimResBytes []byte, simResult *ledger.TxSimulationResults) simulationSizes {
+	sizes := simulationSizes{rwset: len(pubSimResBytes)}
+	if simResult.PvtSimulationResults != nil {
+		sizes.pvtData = proto.Size(simResult.PvtSimulationResults)
+	}
+	return sizes
+}
+
+// check returns an error if the given sizes of the simulation results of the given chaincode exceed the limits
+func (l SimulationLimits) check(chaincode string, sizes simulationSizes) error {
+	if l.MaxRWSetSize > 0 && sizes.rwset > l.MaxRWSetSize {
+		return errors.Errorf("simulation of chaincode %s produced a read/write set of %d bytes, which exceeds the limit of %d bytes", chaincode, sizes.rwset, l.MaxRWSetSize)
+	}
+	if l.MaxPrivateDataSize > 0 && sizes.pvtData > l.MaxPrivateDataSize {
+		return errors.Errorf("simulation of chaincode %s produced private data of %d bytes, which exceeds the limit of %d bytes", chaincode, sizes.pvtData, l.MaxPrivateDataSize)
+	}
+	return nil
+}
+
+// reportSimulation reports the duration and the result sizes of a simulation
+// of the given chaincode, if metrics are initialized
+func reportSimulation(chaincode string, elapsed time.Duration, sizes simulationSizes, limitExceeded bool) {
+	if metrics.RootScope == nil {
+		return
+	}
+	scope := metrics.RootScope.SubScope("endorser").Tagged(map[string]string{"chaincode": chaincode})
+	scope.Histogram("simulation_duration_seconds", simulationDurationBuckets).RecordValue(elapsed.Seconds())
+	scope.Histogram("rwset_size_bytes", simulationSizeBuckets).RecordValue(float64(sizes.rwset))
+	if sizes.pvtData > 0 {
+		scope.Histogram("private_data_size_bytes", simulationSizeBuckets).RecordValue(float64(sizes.pvtData))
+	}
+	if limitExceeded {
+		scope.Counter("simulation_limit_exceeded").Inc(1)
+	}
+}
@@ -138,13 +138,34 @@ func (pbc *pluginsByChannel) initPlugin(plugin endorsement.Plugin, channel strin
 	}
 	// Add the SigningIdentityFetcher as a dependency
 	dependencies = append(dependencies, pbc.pe.SigningIdentityFetcher)
-	err = plugin.Init(dependencies...)
+	err = initWithRecovery(plugin, dependencies...)
 	if err != nil {
 		return nil, err
 	}
 	return plugin, nil
 }
 
+// initWithRecovery initializes the plugin, and converts a panic of the plugin into an error,
+// such that a faulty plugin fails the endorsement instead of crashing the peer
+func initWithRecovery(plugin endorsement.Plugin, dependencies ...endorsement.Dependency) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("plugin panicked while initializing: %v", r)
+		}
+	}()
+	return plugin.Init(dependencies...)
+}
+
+// endorseWithRecovery endorses the payload with the plugin, and converts a panic of the plugin into an error
+func endorseWithRecovery(plugin endorsement.Plugin, payload []byte, sp *pb.SignedProposal) (e *pb.Endorsement, prp []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, prp, err = nil, nil, errors.Errorf("plugin panicked while endorsing: %v", r)
+		}
+	}()
+	return plugin.Endorse(payload, sp)
+}
+
 // PluginEndorser endorsers proposal responses using plugins
 type PluginEndorser struct {
 	sync.Mutex
@@ -179,7 +200,7 @@ func (pe *PluginEndorser) EndorseWithPlugin(ctx Context) (*pb.ProposalResponse,
 		return nil, errors.Wrap(err, "failed assembling proposal response payload")
 	}
 
-	endorsement, prpBytes, err := plugin.Endorse(prpBytes, ctx.SignedProposal)
+	endorsement, prpBytes, err := endorseWithRecovery(plugin, prpBytes, ctx.SignedProposal)
 	if err != nil {
 		endorserLogger.Warning("Endorsement with plugin for", ctx, " failed:", err)
 		return nil, errors.WithStack(err)
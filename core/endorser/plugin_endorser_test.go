@@ -118,6 +118,54 @@ func TestPluginEndorserGreenPath(t *testing.T) {
 	plugin.AssertCalled(t, "Init", sif)
 }
 
+func TestPluginEndorserPanics(t *testing.T) {
+	proposal, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		},
+	}, []byte{1, 2, 3})
+	assert.NoError(t, err)
+	pluginMapper := &mocks.PluginMapper{}
+	pluginFactory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	pluginMapper.On("PluginFactoryByName", endorser.PluginName("plugin")).Return(pluginFactory)
+	pluginFactory.On("New").Return(plugin)
+	cs := &mocks.ChannelStateRetriever{}
+	cs.On("NewQueryCreator", "mychannel").Return(&mocks.QueryCreator{}, nil)
+	pluginEndorser := endorser.NewPluginEndorser(&endorser.PluginSupport{
+		ChannelStateRetriever:   cs,
+		SigningIdentityFetcher:  &mocks.SigningIdentityFetcher{},
+		PluginMapper:            pluginMapper,
+		TransientStoreRetriever: mockTransientStoreRetriever,
+	})
+	ctx := endorser.Context{
+		Response:   &peer.Response{},
+		PluginName: "plugin",
+		Proposal:   proposal,
+		ChaincodeID: &peer.ChaincodeID{
+			Name: "mycc",
+		},
+		Channel: "mychannel",
+	}
+
+	// Scenario I: The plugin panics while initializing
+	plugin.On("Init", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		panic("foo")
+	}).Return(nil).Once()
+	resp, err := pluginEndorser.EndorseWithPlugin(ctx)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "plugin panicked while initializing: foo")
+
+	// Scenario II: The plugin panics while endorsing
+	plugin.On("Init", mock.Anything, mock.Anything).Return(nil).Once()
+	plugin.On("Endorse", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		panic("bar")
+	}).Return(nil, nil, nil).Once()
+	resp, err = pluginEndorser.EndorseWithPlugin(ctx)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "plugin panicked while endorsing: bar")
+}
+
 func TestPluginEndorserErrors(t *testing.T) {
 	pluginMapper := &mocks.PluginMapper{}
 	pluginFactory := &mocks.PluginFactory{}
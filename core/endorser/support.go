@@ -135,6 +135,16 @@ func (s *SupportImpl) CheckACL(signedProp *pb.SignedProposal, chdr *common.Chann
 	return s.ACLProvider.CheckACL(resources.Peer_Propose, chdr.ChannelId, signedProp)
 }
 
+// CheckChaincodeFunctionACL checks the ACL for the function of the chaincode the
+// SignedProposal invokes, if operators mapped the function to a policy of the Channel
+func (s *SupportImpl) CheckChaincodeFunctionACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, hdrext *pb.ChaincodeHeaderExtension, function string) error {
+	customACLProvider, ok := s.ACLProvider.(aclmgmt.CustomACLProvider)
+	if !ok || function == "" {
+		return nil
+	}
+	return customACLProvider.CheckCustomACL(resources.ChaincodeFunction(hdrext.ChaincodeId.Name, function), chdr.ChannelId, signedProp)
+}
+
 // IsJavaCC returns true if the CDS package bytes describe a chaincode
 // that requires the java runtime environment to execute
 func (s *SupportImpl) IsJavaCC(buf []byte) (bool, error) {
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// Support provides the gateway with the services of the peer it runs on
+type Support interface {
+	// PeersForEndorsement returns the endorsement descriptor of the given chaincode interest
+	// in the given channel, as the discovery service computes it
+	PeersForEndorsement(channel gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error)
+
+	// OrdererEndpoints returns the endpoints of the orderers of the given channel
+	OrdererEndpoints(channel string) ([]string, error)
+
+	// Ledger returns the ledger of the given channel, or nil if the peer hasn't joined the channel
+	Ledger(channel string) Ledger
+
+	// CheckACL checks that the given identity, which is given as signed data, can access the given resource of the given channel
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// Ledger is the part of the ledger of a channel that the gateway uses
+// to wait for the commit of the transactions it submits
+type Ledger interface {
+	// GetBlockchainInfo returns basic info about blockchain
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	// GetBlocksIterator returns an iterator that starts from `startBlockNumber`(inclusive),
+	// and blocks until the next block is committed when the last one was retrieved
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+	// GetBlockByTxID returns a block which contains a transaction
+	GetBlockByTxID(txID string) (*common.Block, error)
+}
+
+// EndorserClientFactory returns a client of the endorser of the peer with the given endpoint
+type EndorserClientFactory func(endpoint string) (peer.EndorserClient, error)
+
+// BroadcastClientFactory returns a client of the orderer with the given endpoint, which orders the given channel
+type BroadcastClientFactory func(channel, endpoint string) (ab.AtomicBroadcastClient, error)
@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"sync"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// commitTracker tracks the commit of the transactions of a channel by the peer. It retains the statuses
+// of the transactions of the latest blocks, and notifies the waiters registered for transactions
+// once they are committed. The statuses of the transactions of older blocks are looked up in the ledger
+type commitTracker struct {
+	sync.Mutex
+	channel   string
+	ledger    Ledger
+	retention uint64
+	itr       commonledger.ResultsIterator
+	statuses  map[string]*gp.CommitStatusResponse
+	blocks    []retainedBlock
+	waiters   map[string][]chan *gp.CommitStatusResponse
+	stopped   bool
+}
+
+// retainedBlock is a block the statuses of the transactions of which are retained
+type retainedBlock struct {
+	number uint64
+	txIDs  []string
+}
+
+// newCommitTracker creates a tracker of the commit of the transactions of the given channel
+// to the given ledger, which retains the statuses of the transactions of the given number of
+// latest blocks. The tracker tracks the blocks committed from now on, once it runs
+func newCommitTracker(channel string, ledger Ledger, retention uint64) (*commitTracker, error) {
+	info, err := ledger.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed retrieving blockchain info")
+	}
+	itr, err := ledger.GetBlocksIterator(info.Height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating blocks iterator")
+	}
+	return &commitTracker{
+		channel:   channel,
+		ledger:    ledger,
+		retention: retention,
+		itr:       itr,
+		statuses:  make(map[string]*gp.CommitStatusResponse),
+		waiters:   make(map[string][]chan *gp.CommitStatusResponse),
+	}, nil
+}
+
+// run processes the blocks committed to the ledger, until the tracker is stopped
+// or the blocks can no longer be retrieved
+func (ct *commitTracker) run() {
+	defer ct.halt()
+	for {
+		res, err := ct.itr.Next()
+		if err != nil {
+			logger.Errorf("Stopped tracking the commit of transactions of channel %s: failed retrieving block: %v", ct.channel, err)
+			return
+		}
+		if res == nil {
+			logger.Debugf("Stopped tracking the commit of transactions of channel %s", ct.channel)
+			return
+		}
+		block, isBlock := res.(*common.Block)
+		if !isBlock {
+			logger.Errorf("Stopped tracking the commit of transactions of channel %s: expected a block, got %T", ct.channel, res)
+			return
+		}
+		ct.process(block)
+	}
+}
+
+// stop stops the tracking of the commit of transactions
+func (ct *commitTracker) stop() {
+	ct.itr.Close()
+}
+
+// halt marks the tracker as stopped, and notifies the waiters that their transactions are no longer tracked
+func (ct *commitTracker) halt() {
+	ct.Lock()
+	defer ct.Unlock()
+	ct.stopped = true
+	for txID, waiters := range ct.waiters {
+		for _, waiter := range waiters {
+			close(waiter)
+		}
+		delete(ct.waiters, txID)
+	}
+}
+
+// process notifies the waiters of the transactions of the given block, retains the statuses
+// of its transactions, and discards the statuses of the blocks that fall out of the retention window
+func (ct *commitTracker) process(block *common.Block) {
+	statuses := statusesOf(block)
+	ct.Lock()
+	defer ct.Unlock()
+	rb := retainedBlock{number: block.Header.Number}
+	for txID, status := range statuses {
+		for _, waiter := range ct.waiters[txID] {
+			waiter <- status
+		}
+		delete(ct.waiters, txID)
+		// the status of a transaction is the status of its first occurrence
+		if _, exists := ct.statuses[txID]; !exists {
+			ct.statuses[txID] = status
+			rb.txIDs = append(rb.txIDs, txID)
+		}
+	}
+	ct.blocks = append(ct.blocks, rb)
+	for uint64(len(ct.blocks)) > ct.retention {
+		for _, txID := range ct.blocks[0].txIDs {
+			delete(ct.statuses, txID)
+		}
+		ct.blocks = ct.blocks[1:]
+	}
+}
+
+// status returns the status of the given transaction, or nil if it isn't committed yet
+func (ct *commitTracker) status(txID string) (*gp.CommitStatusResponse, error) {
+	ct.Lock()
+	defer ct.Unlock()
+	return ct.lookup(txID)
+}
+
+// register returns the status of the given transaction if it is already committed. Otherwise, it returns a channel
+// that receives the status of the transaction once it is committed. The returned function cancels the registration
+func (ct *commitTracker) register(txID string) (*gp.CommitStatusResponse, <-chan *gp.CommitStatusResponse, func(), error) {
+	ct.Lock()
+	defer ct.Unlock()
+	status, err := ct.lookup(txID)
+	if err != nil || status != nil {
+		return status, nil, func() {}, err
+	}
+	waiter := make(chan *gp.CommitStatusResponse, 1)
+	ct.waiters[txID] = append(ct.waiters[txID], waiter)
+	cancel := func() {
+		ct.Lock()
+		defer ct.Unlock()
+		waiters := ct.waiters[txID]
+		for i, w := range waiters {
+			if w == waiter {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(ct.waiters, txID)
+		} else {
+			ct.waiters[txID] = waiters
+		}
+	}
+	return nil, waiter, cancel, nil
+}
+
+// lookup returns the status of the given transaction, or nil if it isn't committed yet.
+// It is called with the lock held, such that no block is processed while the ledger is looked up
+func (ct *commitTracker) lookup(txID string) (*gp.CommitStatusResponse, error) {
+	if ct.stopped {
+		return nil, errors.Errorf("the commit of transactions of channel %s is no longer tracked", ct.channel)
+	}
+	if status, exists := ct.statuses[txID]; exists {
+		return status, nil
+	}
+	// the transaction is either committed in a block that isn't retained, or isn't committed yet
+	block, err := ct.ledger.GetBlockByTxID(txID)
+	if err != nil {
+		logger.Debugf("Transaction %s isn't found in the ledger of channel %s: %v", txID, ct.channel, err)
+		return nil, nil
+	}
+	return statusesOf(block)[txID], nil
+}
+
+// waitForStatus waits until the given channel receives the status of the given transaction,
+// or until the given context is done
+func waitForStatus(ctx context.Context, txID string, committed <-chan *gp.CommitStatusResponse) (*gp.CommitStatusResponse, error) {
+	select {
+	case status, isOpen := <-committed:
+		if !isOpen {
+			return nil, errors.Errorf("stopped tracking the commit of transaction %s", txID)
+		}
+		return status, nil
+	case <-ctx.Done():
+		return nil, errors.Errorf("gave up waiting for the commit of transaction %s: %v", txID, ctx.Err())
+	}
+}
+
+// statusesOf returns the statuses of the transactions of the given block by their IDs.
+// The status of a transaction that occurs several times in the block is the status of its first occurrence
+func statusesOf(block *common.Block) map[string]*gp.CommitStatusResponse {
+	statuses := make(map[string]*gp.CommitStatusResponse)
+	if block == nil || block.Data == nil || block.Header == nil {
+		return statuses
+	}
+	var flags ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for i, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			logger.Warningf("Failed unmarshaling transaction %d of block %d: %v", i, block.Header.Number, err)
+			continue
+		}
+		chdr, err := channelHeaderOf(env)
+		if err != nil {
+			logger.Warningf("Failed unmarshaling transaction %d of block %d: %v", i, block.Header.Number, err)
+			continue
+		}
+		if _, exists := statuses[chdr.TxId]; exists {
+			continue
+		}
+		if i >= len(flags) {
+			logger.Warningf("Block %d has no validation flag for transaction %s", block.Header.Number, chdr.TxId)
+			continue
+		}
+		statuses[chdr.TxId] = &gp.CommitStatusResponse{
+			Committed:      true,
+			ValidationCode: flags.Flag(i),
+			BlockNumber:    block.Header.Number,
+		}
+	}
+	return statuses
+}
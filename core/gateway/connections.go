@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"sync"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"google.golang.org/grpc"
+)
+
+// Dialer creates a connection to the given endpoint
+type Dialer func(endpoint string) (*grpc.ClientConn, error)
+
+// connections holds the connections to the peers and orderers, such that they are reused
+// across the requests to the gateway
+type connections struct {
+	sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newConnections() *connections {
+	return &connections{conns: make(map[string]*grpc.ClientConn)}
+}
+
+// get returns the connection with the given key, and creates it with the given dialer if it doesn't exist
+func (c *connections) get(key, endpoint string, dial Dialer) (*grpc.ClientConn, error) {
+	c.Lock()
+	defer c.Unlock()
+	if conn, exists := c.conns[key]; exists {
+		return conn, nil
+	}
+	conn, err := dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[key] = conn
+	return conn, nil
+}
+
+// EndorserClients returns an EndorserClientFactory that connects to the peers with the given dialer,
+// and reuses the connections
+func EndorserClients(dial Dialer) EndorserClientFactory {
+	conns := newConnections()
+	return func(endpoint string) (peer.EndorserClient, error) {
+		conn, err := conns.get(endpoint, endpoint, dial)
+		if err != nil {
+			return nil, err
+		}
+		return peer.NewEndorserClient(conn), nil
+	}
+}
+
+// BroadcastClients returns a BroadcastClientFactory that connects to the orderers of a channel with
+// the dialer returned by the given function for the channel, and reuses the connections
+func BroadcastClients(dialerOfChannel func(channel string) Dialer) BroadcastClientFactory {
+	conns := newConnections()
+	return func(channel, endpoint string) (ab.AtomicBroadcastClient, error) {
+		// the connections are keyed by channels too, because the root CAs
+		// trusted to connect to the orderers depend on the channel
+		conn, err := conns.get(channel+"/"+endpoint, endpoint, dialerOfChannel(channel))
+		if err != nil {
+			return nil, err
+		}
+		return ab.NewAtomicBroadcastClient(conn), nil
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// endorsement collects the endorsements of a proposal. The endorsement of the proposal is requested
+// at most once from every peer, even if the peer belongs to several groups or layouts
+type endorsement struct {
+	ctx            context.Context
+	server         *Server
+	signedProposal *peer.SignedProposal
+	results        map[string]*endorsementResult
+}
+
+// endorsementResult is the outcome of the request of an endorsement from a peer
+type endorsementResult struct {
+	resp *peer.ProposalResponse
+	err  error
+}
+
+func newEndorsement(ctx context.Context, server *Server, sp *peer.SignedProposal) *endorsement {
+	return &endorsement{
+		ctx:            ctx,
+		server:         server,
+		signedProposal: sp,
+		results:        make(map[string]*endorsementResult),
+	}
+}
+
+// byLayouts returns the endorsements of the peers that satisfy the first layout that can be satisfied,
+// in the order of the given layouts
+func (e *endorsement) byLayouts(groups map[string][]*endorser, layouts []*discovery.Layout) ([]*peer.ProposalResponse, error) {
+	if len(layouts) == 0 {
+		return nil, errors.New("the endorsement descriptor has no layouts")
+	}
+	var errs []string
+	for _, layout := range layouts {
+		responses, err := e.forLayout(groups, layout.QuantitiesByGroup)
+		if err == nil {
+			return responses, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, errors.Errorf("no layout of the endorsement policy could be satisfied: %s", e.describeFailures(errs))
+}
+
+// byOrgs returns the endorsements of a peer of each of the given organizations
+func (e *endorsement) byOrgs(groups map[string][]*endorser, orgs []string) ([]*peer.ProposalResponse, error) {
+	endorsersByOrgs := make(map[string][]*endorser)
+	quantities := make(map[string]uint32)
+	for _, org := range orgs {
+		endorsersByOrgs[org] = endorsersOfOrgs(groups, []string{org}, e.server.conf.Endpoint)
+		quantities[org] = 1
+	}
+	responses, err := e.forLayout(endorsersByOrgs, quantities)
+	if err != nil {
+		return nil, errors.New(e.describeFailures([]string{err.Error()}))
+	}
+	return responses, nil
+}
+
+// forLayout returns the endorsements of the given quantities of distinct peers of the given groups.
+// The endorsements are requested from the preferred peers of all the groups in parallel, and the peers
+// that fail are replaced by the next preferred peers of their groups, until the layout is satisfied or
+// a group runs out of peers
+func (e *endorsement) forLayout(groups map[string][]*endorser, quantities map[string]uint32) ([]*peer.ProposalResponse, error) {
+	var groupNames []string
+	for group := range quantities {
+		groupNames = append(groupNames, group)
+	}
+	sort.Strings(groupNames)
+
+	for {
+		used := make(map[string]struct{})
+		var picked []*endorser
+		for _, group := range groupNames {
+			var pickedOfGroup uint32
+			for _, en := range groups[group] {
+				if pickedOfGroup == quantities[group] {
+					break
+				}
+				if _, isUsed := used[en.endpoint]; isUsed {
+					continue
+				}
+				if res, requested := e.results[en.endpoint]; requested && res.err != nil {
+					continue
+				}
+				used[en.endpoint] = struct{}{}
+				picked = append(picked, en)
+				pickedOfGroup++
+			}
+			if pickedOfGroup < quantities[group] {
+				return nil, errors.Errorf("only %d out of %d peers of group %s could endorse the proposal",
+					pickedOfGroup, quantities[group], group)
+			}
+		}
+
+		e.request(picked)
+
+		var responses []*peer.ProposalResponse
+		for _, en := range picked {
+			if res := e.results[en.endpoint]; res.err == nil {
+				responses = append(responses, res.resp)
+			}
+		}
+		if len(responses) == len(picked) {
+			return responses, nil
+		}
+	}
+}
+
+// request requests in parallel the endorsements of the given peers the endorsements of which
+// weren't requested yet
+func (e *endorsement) request(endorsers []*endorser) {
+	var toRequest []*endorser
+	for _, en := range endorsers {
+		if _, requested := e.results[en.endpoint]; !requested {
+			toRequest = append(toRequest, en)
+		}
+	}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, en := range toRequest {
+		wg.Add(1)
+		go func(en *endorser) {
+			defer wg.Done()
+			resp, err := e.server.processProposal(e.ctx, en, e.signedProposal)
+			if err == nil && resp.Response.Status >= shim.ERRORTHRESHOLD {
+				err = errors.Errorf("endorsement failed with status %d: %s", resp.Response.Status, resp.Response.Message)
+			}
+			if err != nil {
+				logger.Warningf("Failed requesting endorsement from peer %s: %v", en.endpoint, err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			e.results[en.endpoint] = &endorsementResult{resp: resp, err: err}
+		}(en)
+	}
+	wg.Wait()
+}
+
+// describeFailures describes the given errors, along with the errors of the peers that failed endorsing
+func (e *endorsement) describeFailures(errs []string) string {
+	var endpoints []string
+	for endpoint, res := range e.results {
+		if res.err != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		errs = append(errs, fmt.Sprintf("peer %s: %v", endpoint, e.results[endpoint].err))
+	}
+	return strings.Join(errs, "; ")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// endorser is a peer that the endorsement of a proposal can be requested from
+type endorser struct {
+	endpoint     string
+	mspID        string
+	ledgerHeight uint64
+}
+
+// endorsersByGroups parses the peers of the groups of the given endorsement descriptor, and sorts the
+// peers of each group by decreasing ledger heights, such that the most up to date peers are preferred.
+// Among peers of the same ledger height, the local peer with the given endpoint is preferred
+func endorsersByGroups(desc *discovery.EndorsementDescriptor, localEndpoint string) (map[string][]*endorser, error) {
+	res := make(map[string][]*endorser)
+	for group, peers := range desc.EndorsersByGroups {
+		var endorsers []*endorser
+		for _, p := range peers.Peers {
+			e, err := parseEndorser(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed parsing peer of group %s", group)
+			}
+			endorsers = append(endorsers, e)
+		}
+		sortEndorsers(endorsers, localEndpoint)
+		res[group] = endorsers
+	}
+	return res, nil
+}
+
+// endorsersOfOrgs returns the distinct peers of the given groups that belong to any of the given organizations,
+// sorted as in function 'endorsersByGroups'. If no organization is given, the peers of all the organizations are returned
+func endorsersOfOrgs(groups map[string][]*endorser, orgs []string, localEndpoint string) []*endorser {
+	wantedOrgs := make(map[string]struct{})
+	for _, org := range orgs {
+		wantedOrgs[org] = struct{}{}
+	}
+	seen := make(map[string]struct{})
+	var res []*endorser
+	for _, endorsers := range groups {
+		for _, e := range endorsers {
+			if _, wanted := wantedOrgs[e.mspID]; len(orgs) > 0 && !wanted {
+				continue
+			}
+			if _, exists := seen[e.endpoint]; exists {
+				continue
+			}
+			seen[e.endpoint] = struct{}{}
+			res = append(res, e)
+		}
+	}
+	sortEndorsers(res, localEndpoint)
+	return res
+}
+
+func sortEndorsers(endorsers []*endorser, localEndpoint string) {
+	sort.SliceStable(endorsers, func(i, j int) bool {
+		if endorsers[i].ledgerHeight != endorsers[j].ledgerHeight {
+			return endorsers[i].ledgerHeight > endorsers[j].ledgerHeight
+		}
+		return endorsers[i].endpoint == localEndpoint && endorsers[j].endpoint != localEndpoint
+	})
+}
+
+func parseEndorser(p *discovery.Peer) (*endorser, error) {
+	if p.MembershipInfo == nil {
+		return nil, errors.New("membership info is missing")
+	}
+	aliveMsg, err := p.MembershipInfo.ToGossipMessage()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling gossip envelope to alive message")
+	}
+	if aliveMsg.GetAliveMsg().GetMembership() == nil {
+		return nil, errors.New("message isn't an alive message with a membership")
+	}
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(p.Identity, sID); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling peer's identity")
+	}
+	ledgerHeight := p.LedgerHeight
+	if ledgerHeight == 0 && p.StateInfo != nil {
+		stateInfoMsg, err := p.StateInfo.ToGossipMessage()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed unmarshaling gossip envelope to state info message")
+		}
+		ledgerHeight = stateInfoMsg.GetStateInfo().GetProperties().GetLedgerHeight()
+	}
+	return &endorser{
+		endpoint:     aliveMsg.GetAliveMsg().Membership.Endpoint,
+		mspID:        sID.Mspid,
+		ledgerHeight: ledgerHeight,
+	}, nil
+}
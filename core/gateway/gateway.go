@@ -0,0 +1,400 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("gateway")
+
+// Config defines the parameters of the gateway
+type Config struct {
+	// Endpoint is the endpoint of the peer the gateway runs on,
+	// whose endorser is invoked without a connection
+	Endpoint string
+	// EndorsementTimeout bounds the duration of the endorsement of a proposal by a peer
+	EndorsementTimeout time.Duration
+	// BroadcastTimeout bounds the duration of sending a transaction to an orderer
+	BroadcastTimeout time.Duration
+	// CommitTimeout bounds the duration of waiting for the commit of a transaction
+	CommitTimeout time.Duration
+	// CommitStatusRetention is the number of latest blocks of a channel the statuses
+	// of the transactions of which are kept in memory
+	CommitStatusRetention uint64
+}
+
+// Server implements the Gateway service. It selects the endorsers of proposals according to the
+// layouts of the endorsement descriptors the discovery service computes, collects the endorsements,
+// submits the transactions to the orderers and waits for their commit by the peer it runs on
+type Server struct {
+	conf             Config
+	support          Support
+	localEndorser    peer.EndorserServer
+	endorserClients  EndorserClientFactory
+	broadcastClients BroadcastClientFactory
+
+	trackersLock sync.Mutex
+	trackers     map[string]*commitTracker
+}
+
+// NewServer creates a new gateway server, which invokes the given endorser for the endorsements
+// of the peer it runs on, and creates clients with the given factories for the other peers and the orderers
+func NewServer(conf Config, localEndorser peer.EndorserServer, support Support, endorserClients EndorserClientFactory, broadcastClients BroadcastClientFactory) *Server {
+	return &Server{
+		conf:             conf,
+		support:          support,
+		localEndorser:    localEndorser,
+		endorserClients:  endorserClients,
+		broadcastClients: broadcastClients,
+		trackers:         make(map[string]*commitTracker),
+	}
+}
+
+// Stop stops tracking the commit of transactions
+func (s *Server) Stop() {
+	s.trackersLock.Lock()
+	defer s.trackersLock.Unlock()
+	for channel, ct := range s.trackers {
+		ct.stop()
+		delete(s.trackers, channel)
+	}
+}
+
+// Evaluate invokes a chaincode on a single peer, and returns the result without submitting it to the orderers
+func (s *Server) Evaluate(ctx context.Context, req *gp.EvaluateRequest) (*gp.EvaluateResponse, error) {
+	chaincode, err := checkProposal(req.ProposedTransaction, req.ChannelId, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*endorser
+	if len(req.TargetOrganizations) == 0 && s.support.Ledger(req.ChannelId) != nil {
+		candidates = []*endorser{{endpoint: s.conf.Endpoint}}
+	} else {
+		groups, _, err := s.endorsersOf(req.ChannelId, chaincode)
+		if err != nil {
+			return nil, err
+		}
+		candidates = endorsersOfOrgs(groups, req.TargetOrganizations, s.conf.Endpoint)
+		if len(candidates) == 0 {
+			return nil, errors.Errorf("no peer of organizations %v can evaluate transaction %s of chaincode %s", req.TargetOrganizations, req.TransactionId, chaincode)
+		}
+	}
+
+	var errs []string
+	for _, e := range candidates {
+		resp, err := s.processProposal(ctx, e, req.ProposedTransaction)
+		if err != nil {
+			logger.Warningf("Failed evaluating transaction %s on peer %s: %v", req.TransactionId, e.endpoint, err)
+			errs = append(errs, err.Error())
+			continue
+		}
+		if resp.Response.Status >= shim.ERRORTHRESHOLD {
+			return nil, errors.Errorf("evaluation of transaction %s failed with status %d: %s", req.TransactionId, resp.Response.Status, resp.Response.Message)
+		}
+		return &gp.EvaluateResponse{Result: resp.Response}, nil
+	}
+	return nil, errors.Errorf("failed evaluating transaction %s: %s", req.TransactionId, strings.Join(errs, "; "))
+}
+
+// Endorse collects the endorsements of a proposal that satisfy the endorsement policy of the chaincode,
+// and returns the transaction that is assembled from them, to be signed by the client
+func (s *Server) Endorse(ctx context.Context, req *gp.EndorseRequest) (*gp.EndorseResponse, error) {
+	chaincode, err := checkProposal(req.ProposedTransaction, req.ChannelId, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	groups, layouts, err := s.endorsersOf(req.ChannelId, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	e := newEndorsement(ctx, s, req.ProposedTransaction)
+	var responses []*peer.ProposalResponse
+	if len(req.EndorsingOrganizations) > 0 {
+		responses, err = e.byOrgs(groups, req.EndorsingOrganizations)
+	} else {
+		responses, err = e.byLayouts(groups, layouts)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed collecting endorsements of transaction "+req.TransactionId)
+	}
+
+	proposal, err := utils.GetProposal(req.ProposedTransaction.ProposalBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling proposal")
+	}
+	env, err := utils.CreateUnsignedTx(proposal, responses...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed assembling transaction from endorsements")
+	}
+	return &gp.EndorseResponse{PreparedTransaction: env, Result: responses[0].Response}, nil
+}
+
+// Submit sends a signed transaction to the orderers of its channel, and waits until the transaction
+// is committed by the peer the gateway runs on
+func (s *Server) Submit(ctx context.Context, req *gp.SubmitRequest) (*gp.SubmitResponse, error) {
+	env := req.PreparedTransaction
+	if env == nil || len(env.Signature) == 0 {
+		return nil, errors.New("the prepared transaction isn't signed")
+	}
+	chdr, err := channelHeaderOf(env)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.ChannelId != req.ChannelId || chdr.TxId != req.TransactionId {
+		return nil, errors.Errorf("the prepared transaction is transaction %s of channel %s, not transaction %s of channel %s",
+			chdr.TxId, chdr.ChannelId, req.TransactionId, req.ChannelId)
+	}
+	ct, err := s.commitTracker(req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	// The waiter is registered before the transaction is sent, such that its commit isn't missed
+	status, committed, unregister, err := ct.register(req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	defer unregister()
+	if status != nil {
+		logger.Debugf("Transaction %s was already committed in block %d", req.TransactionId, status.BlockNumber)
+		return &gp.SubmitResponse{ValidationCode: status.ValidationCode, BlockNumber: status.BlockNumber}, nil
+	}
+
+	if err := s.broadcast(ctx, req.ChannelId, env); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.conf.CommitTimeout)
+	defer cancel()
+	status, err = waitForStatus(ctx, req.TransactionId, committed)
+	if err != nil {
+		return nil, err
+	}
+	return &gp.SubmitResponse{ValidationCode: status.ValidationCode, BlockNumber: status.BlockNumber}, nil
+}
+
+// CommitStatus returns the outcome of the commit of a transaction by the peer the gateway runs on.
+// If the transaction isn't committed yet, it returns right away, unless the request asks to wait for the commit
+func (s *Server) CommitStatus(ctx context.Context, signedReq *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
+	req := &gp.CommitStatusRequest{}
+	if err := proto.Unmarshal(signedReq.Request, req); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling commit status request")
+	}
+	sd := []*common.SignedData{{
+		Data:      signedReq.Request,
+		Identity:  req.Identity,
+		Signature: signedReq.Signature,
+	}}
+	if err := s.support.CheckACL(resources.Gateway_CommitStatus, req.ChannelId, sd); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("access denied to the commit status of transaction %s of channel %s", req.TransactionId, req.ChannelId))
+	}
+	ct, err := s.commitTracker(req.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Wait {
+		status, err := ct.status(req.TransactionId)
+		if err != nil || status != nil {
+			return status, err
+		}
+		return &gp.CommitStatusResponse{}, nil
+	}
+
+	status, committed, unregister, err := ct.register(req.TransactionId)
+	if err != nil || status != nil {
+		return status, err
+	}
+	defer unregister()
+	ctx, cancel := context.WithTimeout(ctx, s.conf.CommitTimeout)
+	defer cancel()
+	return waitForStatus(ctx, req.TransactionId, committed)
+}
+
+// commitTracker returns the tracker of the commit of the transactions of the given channel,
+// and starts it if it doesn't run yet
+func (s *Server) commitTracker(channel string) (*commitTracker, error) {
+	s.trackersLock.Lock()
+	defer s.trackersLock.Unlock()
+	if ct, exists := s.trackers[channel]; exists {
+		return ct, nil
+	}
+	ledger := s.support.Ledger(channel)
+	if ledger == nil {
+		return nil, errors.Errorf("the peer hasn't joined channel %s", channel)
+	}
+	ct, err := newCommitTracker(channel, ledger, s.conf.CommitStatusRetention)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed tracking the commit of transactions of channel "+channel)
+	}
+	s.trackers[channel] = ct
+	go func() {
+		ct.run()
+		// a tracker that stopped is replaced by a new one upon the next request
+		s.trackersLock.Lock()
+		defer s.trackersLock.Unlock()
+		if s.trackers[channel] == ct {
+			delete(s.trackers, channel)
+		}
+	}()
+	return ct, nil
+}
+
+// endorsersOf returns the endorsers of the groups and the layouts of the endorsement descriptor of the given chaincode
+func (s *Server) endorsersOf(channel, chaincode string) (map[string][]*endorser, []*discovery.Layout, error) {
+	desc, err := s.support.PeersForEndorsement(gossipcommon.ChainID(channel), &discovery.ChaincodeInterest{
+		Chaincodes: []*discovery.ChaincodeCall{{Name: chaincode}},
+	})
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed computing the endorsers of chaincode "+chaincode)
+	}
+	groups, err := endorsersByGroups(desc, s.conf.Endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return groups, desc.Layouts, nil
+}
+
+// processProposal requests the endorsement of the given proposal from the given peer
+func (s *Server) processProposal(ctx context.Context, e *endorser, sp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.conf.EndorsementTimeout)
+	defer cancel()
+	var resp *peer.ProposalResponse
+	var err error
+	if e.endpoint == s.conf.Endpoint {
+		resp, err = s.localEndorser.ProcessProposal(ctx, sp)
+	} else {
+		var client peer.EndorserClient
+		if client, err = s.endorserClients(e.endpoint); err != nil {
+			return nil, errors.Wrapf(err, "failed connecting to %s", e.endpoint)
+		}
+		resp, err = client.ProcessProposal(ctx, sp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Response == nil {
+		return nil, errors.Errorf("peer %s returned an empty proposal response", e.endpoint)
+	}
+	return resp, nil
+}
+
+// broadcast sends the given transaction to the orderers of the given channel, in random
+// order, until one of them accepts it
+func (s *Server) broadcast(ctx context.Context, channel string, env *common.Envelope) error {
+	endpoints, err := s.support.OrdererEndpoints(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving the orderer endpoints of channel "+channel)
+	}
+	if len(endpoints) == 0 {
+		return errors.Errorf("no orderer endpoints are known for channel %s", channel)
+	}
+	var errs []string
+	for _, i := range rand.Perm(len(endpoints)) {
+		status, err := s.broadcastTo(ctx, channel, endpoints[i], env)
+		if err == nil {
+			return nil
+		}
+		logger.Warningf("Failed sending transaction to orderer %s: %v", endpoints[i], err)
+		// the other orderers would reject the transaction too
+		if status == common.Status_BAD_REQUEST || status == common.Status_FORBIDDEN {
+			return err
+		}
+		errs = append(errs, err.Error())
+	}
+	return errors.Errorf("failed sending transaction to the orderers: %s", strings.Join(errs, "; "))
+}
+
+func (s *Server) broadcastTo(ctx context.Context, channel, endpoint string, env *common.Envelope) (common.Status, error) {
+	client, err := s.broadcastClients(channel, endpoint)
+	if err != nil {
+		return common.Status_UNKNOWN, errors.Wrapf(err, "failed connecting to %s", endpoint)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.conf.BroadcastTimeout)
+	defer cancel()
+	stream, err := client.Broadcast(ctx)
+	if err != nil {
+		return common.Status_UNKNOWN, err
+	}
+	if err := stream.Send(env); err != nil {
+		return common.Status_UNKNOWN, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return common.Status_UNKNOWN, err
+	}
+	if resp.Status != common.Status_SUCCESS {
+		return resp.Status, errors.Errorf("orderer %s rejected the transaction with status %s: %s", endpoint, resp.Status, resp.Info)
+	}
+	return resp.Status, stream.CloseSend()
+}
+
+// checkProposal checks that the given proposal is the proposal of the given transaction of the given channel,
+// and returns the name of the chaincode it invokes
+func checkProposal(sp *peer.SignedProposal, channel, txID string) (string, error) {
+	if sp == nil {
+		return "", errors.New("the proposed transaction is missing")
+	}
+	proposal, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling proposal")
+	}
+	hdr, err := utils.GetHeader(proposal.Header)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling proposal header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling channel header")
+	}
+	if chdr.ChannelId != channel || chdr.TxId != txID {
+		return "", errors.Errorf("the proposal is the proposal of transaction %s of channel %s, not transaction %s of channel %s",
+			chdr.TxId, chdr.ChannelId, txID, channel)
+	}
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling chaincode header extension")
+	}
+	if hdrExt.ChaincodeId == nil || hdrExt.ChaincodeId.Name == "" {
+		return "", errors.New("the proposal doesn't invoke any chaincode")
+	}
+	return hdrExt.ChaincodeId.Name, nil
+}
+
+func channelHeaderOf(env *common.Envelope) (*common.ChannelHeader, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling payload")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("the payload header is missing")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling channel header")
+	}
+	return chdr, nil
+}
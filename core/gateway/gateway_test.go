@@ -0,0 +1,634 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const localEndpoint = "p0.org1:7051"
+
+func TestEvaluate(t *testing.T) {
+	sp, txID := signedProposal(t, "mychannel")
+	endorsers := newFakeEndorsers()
+	support := &fakeSupport{
+		desc: descriptor(map[string][]*discovery.Peer{
+			"G0": {discoveryPeer(t, localEndpoint, "Org1MSP", 10)},
+			"G1": {discoveryPeer(t, "p0.org2:7051", "Org2MSP", 9), discoveryPeer(t, "p1.org2:7051", "Org2MSP", 10)},
+		}),
+		ledger: newFakeLedger(),
+	}
+	s := newTestServer(support, endorsers, nil)
+
+	// Scenario I: the transaction is evaluated by the local peer
+	resp, err := s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(localEndpoint), resp.Result.Payload)
+
+	// Scenario II: the transaction is evaluated by the most up to date peer of the target organization
+	resp, err = s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp,
+		TargetOrganizations: []string{"Org2MSP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("p1.org2:7051"), resp.Result.Payload)
+
+	// Scenario III: the other peer of the target organization is used if the first one can't be reached
+	endorsers.fail("p1.org2:7051", errors.New("connection refused"))
+	resp, err = s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp,
+		TargetOrganizations: []string{"Org2MSP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("p0.org2:7051"), resp.Result.Payload)
+
+	// Scenario IV: the chaincode returns an error
+	endorsers.respond(localEndpoint, &peer.Response{Status: 500, Message: "key not found"}, nil)
+	_, err = s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp})
+	assert.EqualError(t, err, "evaluation of transaction "+txID+" failed with status 500: key not found")
+
+	// Scenario V: no peer of the target organization
+	_, err = s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp,
+		TargetOrganizations: []string{"Org3MSP"}})
+	assert.EqualError(t, err, "no peer of organizations [Org3MSP] can evaluate transaction "+txID+" of chaincode mycc")
+
+	// Scenario VI: the request doesn't match the proposal
+	_, err = s.Evaluate(context.Background(), &gp.EvaluateRequest{TransactionId: txID, ChannelId: "yourchannel", ProposedTransaction: sp})
+	assert.EqualError(t, err, "the proposal is the proposal of transaction "+txID+" of channel mychannel, not transaction "+txID+" of channel yourchannel")
+}
+
+func TestEndorse(t *testing.T) {
+	sp, txID := signedProposal(t, "mychannel")
+	desc := descriptor(map[string][]*discovery.Peer{
+		"G0": {discoveryPeer(t, localEndpoint, "Org1MSP", 10), discoveryPeer(t, "p1.org1:7051", "Org1MSP", 10)},
+		"G1": {discoveryPeer(t, "p0.org2:7051", "Org2MSP", 10)},
+	}, map[string]uint32{"G0": 1, "G1": 1}, map[string]uint32{"G0": 2})
+	support := &fakeSupport{desc: desc}
+	req := &gp.EndorseRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp}
+
+	// Scenario I: the first layout is satisfied
+	endorsers := newFakeEndorsers()
+	for _, endpoint := range []string{localEndpoint, "p1.org1:7051", "p0.org2:7051"} {
+		endorsers.respond(endpoint, &peer.Response{Status: 200, Payload: []byte("result")}, []byte("payload"))
+	}
+	s := newTestServer(support, endorsers, nil)
+	resp, err := s.Endorse(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("result"), resp.Result.Payload)
+	assert.Empty(t, resp.PreparedTransaction.Signature)
+	assert.Equal(t, []string{localEndpoint, "p0.org2:7051"}, endorsersOfTransaction(t, resp.PreparedTransaction))
+	assert.Equal(t, map[string]int{localEndpoint: 1, "p0.org2:7051": 1}, endorsers.calls())
+
+	// Scenario II: the peer of the second group fails, so the second layout is satisfied.
+	// The local peer isn't requested to endorse the proposal again
+	endorsers.reset()
+	endorsers.fail("p0.org2:7051", errors.New("connection refused"))
+	resp, err = s.Endorse(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{localEndpoint, "p1.org1:7051"}, endorsersOfTransaction(t, resp.PreparedTransaction))
+	assert.Equal(t, map[string]int{localEndpoint: 1, "p1.org1:7051": 1, "p0.org2:7051": 1}, endorsers.calls())
+
+	// Scenario III: no layout can be satisfied
+	endorsers.reset()
+	endorsers.respond("p1.org1:7051", &peer.Response{Status: 500, Message: "chaincode panicked"}, nil)
+	_, err = s.Endorse(context.Background(), req)
+	assert.EqualError(t, err, "failed collecting endorsements of transaction "+txID+": no layout of the endorsement policy could be satisfied: "+
+		"only 0 out of 1 peers of group G1 could endorse the proposal; only 1 out of 2 peers of group G0 could endorse the proposal; "+
+		"peer p0.org2:7051: connection refused; peer p1.org1:7051: endorsement failed with status 500: chaincode panicked")
+
+	// Scenario IV: the endorsing organizations are given
+	endorsers.reset()
+	endorsers.respond("p0.org2:7051", &peer.Response{Status: 200, Payload: []byte("result")}, []byte("payload"))
+	resp, err = s.Endorse(context.Background(), &gp.EndorseRequest{TransactionId: txID, ChannelId: "mychannel", ProposedTransaction: sp,
+		EndorsingOrganizations: []string{"Org2MSP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p0.org2:7051"}, endorsersOfTransaction(t, resp.PreparedTransaction))
+
+	// Scenario V: the endorsers disagree on the result of the proposal
+	endorsers.reset()
+	endorsers.respond("p0.org2:7051", &peer.Response{Status: 200, Payload: []byte("result")}, []byte("other payload"))
+	_, err = s.Endorse(context.Background(), req)
+	assert.EqualError(t, err, "failed assembling transaction from endorsements: ProposalResponsePayloads do not match")
+
+	// Scenario VI: the endorsers can't be computed
+	support.descErr = errors.New("chaincode isn't installed")
+	_, err = s.Endorse(context.Background(), req)
+	assert.EqualError(t, err, "failed computing the endorsers of chaincode mycc: chaincode isn't installed")
+}
+
+func TestSubmit(t *testing.T) {
+	env, txID := signedTransaction(t, "mychannel")
+	ledger := newFakeLedger()
+	orderers := newFakeOrderers(ledger)
+	support := &fakeSupport{ledger: ledger, ordererEndpoints: []string{"o0:7050", "o1:7050"}}
+	s := newTestServer(support, newFakeEndorsers(), orderers)
+	req := &gp.SubmitRequest{TransactionId: txID, ChannelId: "mychannel", PreparedTransaction: env}
+
+	// Scenario I: the transaction is ordered and committed
+	resp, err := s.Submit(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &gp.SubmitResponse{ValidationCode: peer.TxValidationCode_VALID, BlockNumber: 5}, resp)
+	assert.Equal(t, 1, orderers.broadcasts())
+
+	// Scenario II: the transaction was already committed, so it isn't sent again
+	resp, err = s.Submit(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &gp.SubmitResponse{ValidationCode: peer.TxValidationCode_VALID, BlockNumber: 5}, resp)
+	assert.Equal(t, 1, orderers.broadcasts())
+
+	// Scenario III: an orderer is unavailable, so the transaction is sent to the other one
+	env, txID = signedTransaction(t, "mychannel")
+	req = &gp.SubmitRequest{TransactionId: txID, ChannelId: "mychannel", PreparedTransaction: env}
+	orderers.reset()
+	orderers.setStatus("o0:7050", common.Status_SERVICE_UNAVAILABLE)
+	resp, err = s.Submit(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &gp.SubmitResponse{ValidationCode: peer.TxValidationCode_VALID, BlockNumber: 6}, resp)
+
+	// Scenario IV: the transaction is rejected, so it isn't sent to the other orderer
+	env, txID = signedTransaction(t, "mychannel")
+	req = &gp.SubmitRequest{TransactionId: txID, ChannelId: "mychannel", PreparedTransaction: env}
+	orderers.reset()
+	orderers.setStatus("o0:7050", common.Status_BAD_REQUEST)
+	orderers.setStatus("o1:7050", common.Status_BAD_REQUEST)
+	_, err = s.Submit(context.Background(), req)
+	assert.Contains(t, err.Error(), "rejected the transaction with status BAD_REQUEST")
+	assert.Equal(t, 1, orderers.broadcasts())
+
+	// Scenario V: the transaction isn't committed in time
+	orderers.reset()
+	orderers.commit = false
+	s.conf.CommitTimeout = 100 * time.Millisecond
+	_, err = s.Submit(context.Background(), req)
+	assert.EqualError(t, err, "gave up waiting for the commit of transaction "+txID+": context deadline exceeded")
+
+	// Scenario VI: the transaction isn't signed
+	env.Signature = nil
+	_, err = s.Submit(context.Background(), req)
+	assert.EqualError(t, err, "the prepared transaction isn't signed")
+
+	// Scenario VII: the peer hasn't joined the channel
+	env, txID = signedTransaction(t, "yourchannel")
+	_, err = s.Submit(context.Background(), &gp.SubmitRequest{TransactionId: txID, ChannelId: "yourchannel", PreparedTransaction: env})
+	assert.EqualError(t, err, "the peer hasn't joined channel yourchannel")
+
+	// Scenario VIII: the request doesn't match the transaction
+	_, err = s.Submit(context.Background(), &gp.SubmitRequest{TransactionId: "foo", ChannelId: "yourchannel", PreparedTransaction: env})
+	assert.EqualError(t, err, "the prepared transaction is transaction "+txID+" of channel yourchannel, not transaction foo of channel yourchannel")
+}
+
+func TestCommitStatus(t *testing.T) {
+	env, txID := signedTransaction(t, "mychannel")
+	ledger := newFakeLedger()
+	orderers := newFakeOrderers(ledger)
+	support := &fakeSupport{ledger: ledger, ordererEndpoints: []string{"o0:7050"}}
+	s := newTestServer(support, newFakeEndorsers(), orderers)
+	defer s.Stop()
+
+	// Scenario I: the transaction isn't committed yet
+	resp, err := s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", txID, false))
+	assert.NoError(t, err)
+	assert.Equal(t, &gp.CommitStatusResponse{}, resp)
+
+	// Scenario II: the client waits for the commit of the transaction
+	res := make(chan *gp.CommitStatusResponse, 1)
+	go func() {
+		resp, err := s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", txID, true))
+		assert.NoError(t, err)
+		res <- resp
+	}()
+	ledger.commit(env)
+	committed := &gp.CommitStatusResponse{Committed: true, ValidationCode: peer.TxValidationCode_VALID, BlockNumber: 5}
+	select {
+	case resp := <-res:
+		assert.Equal(t, committed, resp)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timed out waiting for the commit status")
+	}
+
+	// Scenario III: the status of the transaction is retained, so the ledger isn't looked up
+	lookups := ledger.lookups()
+	resp, err = s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", txID, false))
+	assert.NoError(t, err)
+	assert.Equal(t, committed, resp)
+	assert.Equal(t, lookups, ledger.lookups())
+
+	// Scenario IV: the block of the transaction falls out of the retention window,
+	// so the status of the transaction is looked up in the ledger
+	env2, txID2 := signedTransaction(t, "mychannel")
+	_, err = s.Submit(context.Background(), &gp.SubmitRequest{TransactionId: txID2, ChannelId: "mychannel", PreparedTransaction: env2})
+	assert.NoError(t, err)
+	lookups = ledger.lookups()
+	resp, err = s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", txID, false))
+	assert.NoError(t, err)
+	assert.Equal(t, committed, resp)
+	assert.Equal(t, lookups+1, ledger.lookups())
+
+	// Scenario V: the transaction isn't committed in time
+	s.conf.CommitTimeout = 100 * time.Millisecond
+	_, err = s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", "foo", true))
+	assert.EqualError(t, err, "gave up waiting for the commit of transaction foo: context deadline exceeded")
+
+	// Scenario VI: the peer hasn't joined the channel
+	_, err = s.CommitStatus(context.Background(), commitStatusRequest(t, "yourchannel", txID, false))
+	assert.EqualError(t, err, "the peer hasn't joined channel yourchannel")
+
+	// Scenario VII: the client isn't allowed to read the ledger of the channel
+	support.aclErr = errors.New("signature set did not satisfy policy")
+	_, err = s.CommitStatus(context.Background(), commitStatusRequest(t, "mychannel", txID, false))
+	assert.EqualError(t, err, "access denied to the commit status of transaction "+txID+" of channel mychannel: signature set did not satisfy policy")
+
+	// Scenario VIII: the request is malformed
+	_, err = s.CommitStatus(context.Background(), &gp.SignedCommitStatusRequest{Request: []byte{0}})
+	assert.Contains(t, err.Error(), "failed unmarshaling commit status request")
+}
+
+func TestCommitTrackerStopped(t *testing.T) {
+	ct, err := newCommitTracker("mychannel", newFakeLedger(), 10)
+	assert.NoError(t, err)
+	_, committed, _, err := ct.register("foo")
+	assert.NoError(t, err)
+	go ct.run()
+	ct.stop()
+
+	_, err = waitForStatus(context.Background(), "foo", committed)
+	assert.EqualError(t, err, "stopped tracking the commit of transaction foo")
+	_, err = ct.status("foo")
+	assert.EqualError(t, err, "the commit of transactions of channel mychannel is no longer tracked")
+}
+
+func newTestServer(support *fakeSupport, endorsers *fakeEndorsers, orderers *fakeOrderers) *Server {
+	conf := Config{
+		Endpoint:           localEndpoint,
+		EndorsementTimeout: time.Second,
+		BroadcastTimeout:   time.Second,
+		CommitTimeout:      5 * time.Second,
+		// the statuses of the transactions of the latest block are retained
+		CommitStatusRetention: 1,
+	}
+	var broadcastClients BroadcastClientFactory
+	if orderers != nil {
+		broadcastClients = orderers.client
+	}
+	return NewServer(conf, &localEndorser{endorsers}, support, endorsers.client, broadcastClients)
+}
+
+func signedProposal(t *testing.T, channel string) (*peer.SignedProposal, string) {
+	creator := utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("client")})
+	proposal, txID, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, channel, &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: &peer.ChaincodeID{Name: "mycc"}},
+	}, creator)
+	assert.NoError(t, err)
+	return &peer.SignedProposal{ProposalBytes: utils.MarshalOrPanic(proposal), Signature: []byte("signature")}, txID
+}
+
+func commitStatusRequest(t *testing.T, channel, txID string, wait bool) *gp.SignedCommitStatusRequest {
+	req := &gp.CommitStatusRequest{
+		TransactionId: txID,
+		ChannelId:     channel,
+		Identity:      utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("client")}),
+		Wait:          wait,
+	}
+	return &gp.SignedCommitStatusRequest{Request: utils.MarshalOrPanic(req), Signature: []byte("signature")}
+}
+
+func signedTransaction(t *testing.T, channel string) (*common.Envelope, string) {
+	sp, txID := signedProposal(t, channel)
+	proposal, err := utils.GetProposal(sp.ProposalBytes)
+	assert.NoError(t, err)
+	env, err := utils.CreateUnsignedTx(proposal, &peer.ProposalResponse{
+		Response:    &peer.Response{Status: 200},
+		Payload:     []byte("payload"),
+		Endorsement: &peer.Endorsement{},
+	})
+	assert.NoError(t, err)
+	env.Signature = []byte("signature")
+	return env, txID
+}
+
+// endorsersOfTransaction returns the endpoints of the peers that endorsed the given transaction,
+// which are set as the endorsers by the fake endorsers
+func endorsersOfTransaction(t *testing.T, env *common.Envelope) []string {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	tx, err := utils.GetTransaction(payload.Data)
+	assert.NoError(t, err)
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	assert.NoError(t, err)
+	var endorsers []string
+	for _, endorsement := range cap.Action.Endorsements {
+		endorsers = append(endorsers, string(endorsement.Endorser))
+	}
+	return endorsers
+}
+
+func descriptor(groups map[string][]*discovery.Peer, layouts ...map[string]uint32) *discovery.EndorsementDescriptor {
+	desc := &discovery.EndorsementDescriptor{Chaincode: "mycc", EndorsersByGroups: make(map[string]*discovery.Peers)}
+	for group, peers := range groups {
+		desc.EndorsersByGroups[group] = &discovery.Peers{Peers: peers}
+	}
+	for _, layout := range layouts {
+		desc.Layouts = append(desc.Layouts, &discovery.Layout{QuantitiesByGroup: layout})
+	}
+	return desc
+}
+
+func discoveryPeer(t *testing.T, endpoint, mspID string, ledgerHeight uint64) *discovery.Peer {
+	aliveMsg, err := (&gossip.GossipMessage{
+		Content: &gossip.GossipMessage_AliveMsg{
+			AliveMsg: &gossip.AliveMessage{Membership: &gossip.Member{Endpoint: endpoint}},
+		},
+	}).NoopSign()
+	assert.NoError(t, err)
+	return &discovery.Peer{
+		MembershipInfo: aliveMsg.Envelope,
+		Identity:       utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(endpoint)}),
+		LedgerHeight:   ledgerHeight,
+	}
+}
+
+type fakeSupport struct {
+	desc             *discovery.EndorsementDescriptor
+	descErr          error
+	ordererEndpoints []string
+	ledger           *fakeLedger
+	aclErr           error
+}
+
+func (fs *fakeSupport) PeersForEndorsement(channel gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
+	return fs.desc, fs.descErr
+}
+
+func (fs *fakeSupport) OrdererEndpoints(channel string) ([]string, error) {
+	return fs.ordererEndpoints, nil
+}
+
+func (fs *fakeSupport) Ledger(channel string) Ledger {
+	if channel != "mychannel" || fs.ledger == nil {
+		return nil
+	}
+	return fs.ledger
+}
+
+func (fs *fakeSupport) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	return fs.aclErr
+}
+
+// fakeEndorsers simulates the endorsers of peers, which endorse proposals with their endpoints as identities
+type fakeEndorsers struct {
+	sync.Mutex
+	responses map[string]*peer.ProposalResponse
+	errors    map[string]error
+	callCount map[string]int
+}
+
+func newFakeEndorsers() *fakeEndorsers {
+	fe := &fakeEndorsers{}
+	fe.reset()
+	fe.responses = make(map[string]*peer.ProposalResponse)
+	fe.errors = make(map[string]error)
+	return fe
+}
+
+func (fe *fakeEndorsers) reset() {
+	fe.Lock()
+	defer fe.Unlock()
+	fe.callCount = make(map[string]int)
+}
+
+func (fe *fakeEndorsers) respond(endpoint string, resp *peer.Response, payload []byte) {
+	fe.Lock()
+	defer fe.Unlock()
+	delete(fe.errors, endpoint)
+	fe.responses[endpoint] = &peer.ProposalResponse{
+		Response:    resp,
+		Payload:     payload,
+		Endorsement: &peer.Endorsement{Endorser: []byte(endpoint)},
+	}
+}
+
+func (fe *fakeEndorsers) fail(endpoint string, err error) {
+	fe.Lock()
+	defer fe.Unlock()
+	fe.errors[endpoint] = err
+}
+
+func (fe *fakeEndorsers) calls() map[string]int {
+	fe.Lock()
+	defer fe.Unlock()
+	return fe.callCount
+}
+
+func (fe *fakeEndorsers) process(endpoint string) (*peer.ProposalResponse, error) {
+	fe.Lock()
+	defer fe.Unlock()
+	fe.callCount[endpoint]++
+	if err, exists := fe.errors[endpoint]; exists {
+		return nil, err
+	}
+	if resp, exists := fe.responses[endpoint]; exists {
+		return resp, nil
+	}
+	return &peer.ProposalResponse{Response: &peer.Response{Status: 200, Payload: []byte(endpoint)}}, nil
+}
+
+func (fe *fakeEndorsers) client(endpoint string) (peer.EndorserClient, error) {
+	return &fakeEndorserClient{fe: fe, endpoint: endpoint}, nil
+}
+
+type fakeEndorserClient struct {
+	fe       *fakeEndorsers
+	endpoint string
+}
+
+func (fec *fakeEndorserClient) ProcessProposal(ctx context.Context, sp *peer.SignedProposal, opts ...grpc.CallOption) (*peer.ProposalResponse, error) {
+	return fec.fe.process(fec.endpoint)
+}
+
+type localEndorser struct {
+	fe *fakeEndorsers
+}
+
+func (le *localEndorser) ProcessProposal(ctx context.Context, sp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	return le.fe.process(localEndpoint)
+}
+
+// fakeOrderers simulates orderers, which commit the transactions they accept to the given ledger
+type fakeOrderers struct {
+	sync.Mutex
+	ledger         *fakeLedger
+	statuses       map[string]common.Status
+	broadcastCount int
+	commit         bool
+}
+
+func newFakeOrderers(ledger *fakeLedger) *fakeOrderers {
+	fo := &fakeOrderers{ledger: ledger}
+	fo.reset()
+	return fo
+}
+
+func (fo *fakeOrderers) reset() {
+	fo.Lock()
+	defer fo.Unlock()
+	fo.statuses = make(map[string]common.Status)
+	fo.broadcastCount = 0
+	fo.commit = true
+}
+
+func (fo *fakeOrderers) setStatus(endpoint string, status common.Status) {
+	fo.Lock()
+	defer fo.Unlock()
+	fo.statuses[endpoint] = status
+}
+
+func (fo *fakeOrderers) broadcasts() int {
+	fo.Lock()
+	defer fo.Unlock()
+	return fo.broadcastCount
+}
+
+func (fo *fakeOrderers) client(channel, endpoint string) (ab.AtomicBroadcastClient, error) {
+	return &fakeBroadcastClient{fo: fo, endpoint: endpoint}, nil
+}
+
+type fakeBroadcastClient struct {
+	fo       *fakeOrderers
+	endpoint string
+}
+
+func (fbc *fakeBroadcastClient) Broadcast(ctx context.Context, opts ...grpc.CallOption) (ab.AtomicBroadcast_BroadcastClient, error) {
+	return &fakeBroadcastStream{fbc: fbc}, nil
+}
+
+func (fbc *fakeBroadcastClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (ab.AtomicBroadcast_DeliverClient, error) {
+	panic("not implemented")
+}
+
+type fakeBroadcastStream struct {
+	grpc.ClientStream
+	fbc    *fakeBroadcastClient
+	status common.Status
+}
+
+func (fbs *fakeBroadcastStream) Send(env *common.Envelope) error {
+	fo := fbs.fbc.fo
+	fo.Lock()
+	defer fo.Unlock()
+	fo.broadcastCount++
+	fbs.status = common.Status_SUCCESS
+	if status, exists := fo.statuses[fbs.fbc.endpoint]; exists {
+		fbs.status = status
+	}
+	if fbs.status == common.Status_SUCCESS && fo.commit {
+		fo.ledger.commit(env)
+	}
+	return nil
+}
+
+func (fbs *fakeBroadcastStream) Recv() (*ab.BroadcastResponse, error) {
+	return &ab.BroadcastResponse{Status: fbs.status}, nil
+}
+
+func (fbs *fakeBroadcastStream) CloseSend() error {
+	return nil
+}
+
+// fakeLedger holds blocks of a single transaction, starting from block 5
+type fakeLedger struct {
+	sync.Mutex
+	blocks      []*common.Block
+	cond        *sync.Cond
+	lookupCount int
+}
+
+func newFakeLedger() *fakeLedger {
+	fl := &fakeLedger{blocks: make([]*common.Block, 5)}
+	fl.cond = sync.NewCond(&fl.Mutex)
+	return fl
+}
+
+func (fl *fakeLedger) commit(env *common.Envelope) {
+	// the block is committed asynchronously, as the orderers reply before the peer commits the block
+	go func() {
+		fl.Lock()
+		defer fl.Unlock()
+		block := common.NewBlock(uint64(len(fl.blocks)), nil)
+		block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+		fl.blocks = append(fl.blocks, block)
+		fl.cond.Broadcast()
+	}()
+}
+
+func (fl *fakeLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	fl.Lock()
+	defer fl.Unlock()
+	return &common.BlockchainInfo{Height: uint64(len(fl.blocks))}, nil
+}
+
+func (fl *fakeLedger) GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error) {
+	return &fakeBlocksIterator{fl: fl, next: startBlockNumber}, nil
+}
+
+func (fl *fakeLedger) GetBlockByTxID(txID string) (*common.Block, error) {
+	fl.Lock()
+	defer fl.Unlock()
+	fl.lookupCount++
+	for _, block := range fl.blocks {
+		if _, found := statusesOf(block)[txID]; found {
+			return block, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (fl *fakeLedger) lookups() int {
+	fl.Lock()
+	defer fl.Unlock()
+	return fl.lookupCount
+}
+
+type fakeBlocksIterator struct {
+	fl     *fakeLedger
+	next   uint64
+	closed bool
+}
+
+func (itr *fakeBlocksIterator) Next() (commonledger.QueryResult, error) {
+	itr.fl.Lock()
+	defer itr.fl.Unlock()
+	for uint64(len(itr.fl.blocks)) <= itr.next && !itr.closed {
+		itr.fl.cond.Wait()
+	}
+	if itr.closed {
+		return nil, nil
+	}
+	itr.next++
+	return itr.fl.blocks[itr.next-1], nil
+}
+
+func (itr *fakeBlocksIterator) Close() {
+	itr.fl.Lock()
+	defer itr.fl.Unlock()
+	itr.closed = true
+	itr.fl.cond.Broadcast()
+}
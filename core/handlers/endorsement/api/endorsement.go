@@ -10,6 +10,17 @@ import (
 	"github.com/hyperledger/fabric/protos/peer"
 )
 
+const (
+	// InterfaceVersion is the version of the endorsement plugin interface. A plugin that is loaded
+	// from a shared object may declare the version it implements in an exported string variable
+	// named InterfaceVersion, which the peer checks to be of the same major version
+	InterfaceVersion = "1.0"
+
+	// DefaultPluginName is the name of the endorsement plugin that endorses the proposals
+	// of system chaincodes, and of chaincodes that are instantiated without an endorsement plugin
+	DefaultPluginName = "escc"
+)
+
 // Argument defines the argument for endorsement
 type Argument interface {
 	Dependency
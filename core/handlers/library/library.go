@@ -13,6 +13,8 @@ import (
 	"github.com/hyperledger/fabric/core/handlers/decoration/decorator"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
+	"github.com/hyperledger/fabric/core/handlers/principal/api"
+	principalbuiltin "github.com/hyperledger/fabric/core/handlers/principal/builtin"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/builtin"
 )
@@ -51,3 +53,9 @@ func (r *HandlerLibrary) DefaultEndorsement() endorsement.PluginFactory {
 func (r *HandlerLibrary) DefaultValidation() validation.PluginFactory {
 	return &DefaultValidationFactory{}
 }
+
+// DefaultPrincipalEvaluation creates a principal evaluation plugin factory
+// that keeps the default principal evaluation of the peer
+func (r *HandlerLibrary) DefaultPrincipalEvaluation() principal.PluginFactory {
+	return &principalbuiltin.DefaultPrincipalEvaluationFactory{}
+}
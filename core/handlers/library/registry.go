@@ -11,12 +11,14 @@ import (
 	"os"
 	"plugin"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/principal/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 )
 
@@ -42,10 +44,17 @@ const (
 	Decoration
 	Endorsement
 	Validation
+	// PrincipalEvaluation handler - evaluate which identities satisfy
+	// which principals when the discovery service analyzes endorsement policies
+	PrincipalEvaluation
 
 	authPluginFactory      = "NewFilter"
 	decoratorPluginFactory = "NewDecorator"
 	pluginFactory          = "NewPluginFactory"
+	interfaceVersion       = "InterfaceVersion"
+
+	defaultEndorsementFactory = "DefaultEndorsement"
+	defaultValidationFactory  = "DefaultValidation"
 )
 
 type registry struct {
@@ -53,6 +62,7 @@ type registry struct {
 	decorators []decoration.Decorator
 	endorsers  map[string]endorsement2.PluginFactory
 	validators map[string]validation.PluginFactory
+	evaluator  principal.PluginFactory
 }
 
 var once sync.Once
@@ -65,6 +75,8 @@ type Config struct {
 	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
 	Endorsers   PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
 	Validators  PluginMapping    `mapstructure:"validators" yaml:"validators"`
+	// PrincipalEvaluator is optional, and if omitted, the default principal evaluation is used
+	PrincipalEvaluator *HandlerConfig `mapstructure:"principalEvaluator" yaml:"principalEvaluator"`
 }
 
 type PluginMapping map[string]*HandlerConfig
@@ -79,10 +91,7 @@ type HandlerConfig struct {
 // of the registry
 func InitRegistry(c Config) Registry {
 	once.Do(func() {
-		reg = registry{
-			endorsers:  make(map[string]endorsement2.PluginFactory),
-			validators: make(map[string]validation.PluginFactory),
-		}
+		reg = registry{}
 		reg.loadHandlers(c)
 	})
 	return &reg
@@ -90,6 +99,8 @@ func InitRegistry(c Config) Registry {
 
 // loadHandlers loads the configured handlers
 func (r *registry) loadHandlers(c Config) {
+	r.endorsers = make(map[string]endorsement2.PluginFactory)
+	r.validators = make(map[string]validation.PluginFactory)
 	for _, config := range c.AuthFilters {
 		r.evaluateModeAndLoad(config, Auth)
 	}
@@ -104,6 +115,19 @@ func (r *registry) loadHandlers(c Config) {
 	for chaincodeID, config := range c.Validators {
 		r.evaluateModeAndLoad(config, Validation, chaincodeID)
 	}
+
+	if c.PrincipalEvaluator != nil && (c.PrincipalEvaluator.Name != "" || c.PrincipalEvaluator.Library != "") {
+		r.evaluateModeAndLoad(c.PrincipalEvaluator, PrincipalEvaluation)
+	}
+
+	// The default plugins are always available, as system chaincodes and chaincodes
+	// instantiated without specifying plugins are endorsed and validated with them
+	if _, exists := r.endorsers[endorsement2.DefaultPluginName]; !exists {
+		r.loadCompiled(defaultEndorsementFactory, Endorsement, endorsement2.DefaultPluginName)
+	}
+	if _, exists := r.validators[validation.DefaultPluginName]; !exists {
+		r.loadCompiled(defaultValidationFactory, Validation, validation.DefaultPluginName)
+	}
 }
 
 // evaluateModeAndLoad if a library path is provided, load the shared object
@@ -140,6 +164,8 @@ func (r *registry) loadCompiled(handlerFactory string, handlerType HandlerType,
 			logger.Panicf("expected 1 argument in extraArgs")
 		}
 		r.validators[extraArgs[0]] = inst.(validation.PluginFactory)
+	} else if handlerType == PrincipalEvaluation {
+		r.evaluator = inst.(principal.PluginFactory)
 	}
 }
 
@@ -158,12 +184,44 @@ func (r *registry) loadPlugin(pluginPath string, handlerType HandlerType, extraA
 	} else if handlerType == Decoration {
 		r.initDecoratorPlugin(p)
 	} else if handlerType == Endorsement {
+		checkInterfaceVersion(p, pluginPath, endorsement2.InterfaceVersion)
 		r.initEndorsementPlugin(p, extraArgs...)
 	} else if handlerType == Validation {
+		checkInterfaceVersion(p, pluginPath, validation.InterfaceVersion)
 		r.initValidationPlugin(p, extraArgs...)
+	} else if handlerType == PrincipalEvaluation {
+		r.initPrincipalEvaluationPlugin(p)
+	}
+}
+
+// symbolLookup looks up the exported symbols of a plugin
+type symbolLookup interface {
+	Lookup(symName string) (plugin.Symbol, error)
+}
+
+// checkInterfaceVersion panics when the plugin declares that it implements a version of the plugin
+// interface with another major version than the supported one. A plugin that doesn't declare
+// the version is assumed to implement the supported version
+func checkInterfaceVersion(p symbolLookup, pluginPath string, supportedVersion string) {
+	versionSymbol, err := p.Lookup(interfaceVersion)
+	if err != nil {
+		logger.Debugf("Plugin at path %s doesn't declare the version of the plugin interface it implements", pluginPath)
+		return
+	}
+	version, ok := versionSymbol.(*string)
+	if !ok {
+		logger.Panicf("Variable %s of plugin at path %s isn't a string", interfaceVersion, pluginPath)
+	}
+	if majorVersion(*version) != majorVersion(supportedVersion) {
+		logger.Panicf("Plugin at path %s implements version %s of the plugin interface, but version %s is supported",
+			pluginPath, *version, supportedVersion)
 	}
 }
 
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
+
 // initAuthPlugin constructs an auth filter from the given plugin
 func (r *registry) initAuthPlugin(p *plugin.Plugin) {
 	constructorSymbol, err := p.Lookup(authPluginFactory)
@@ -237,6 +295,23 @@ func (r *registry) initValidationPlugin(p *plugin.Plugin, extraArgs ...string) {
 	r.validators[extraArgs[0]] = factory
 }
 
+func (r *registry) initPrincipalEvaluationPlugin(p *plugin.Plugin) {
+	factorySymbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		panicWithLookupError(pluginFactory, err)
+	}
+
+	constructor, ok := factorySymbol.(func() principal.PluginFactory)
+	if !ok {
+		panicWithDefinitionError(pluginFactory)
+	}
+	factory := constructor()
+	if factory == nil {
+		logger.Panicf("factory instance returned nil")
+	}
+	r.evaluator = factory
+}
+
 // panicWithLookupError panics when a handler constructor lookup fails
 func panicWithLookupError(factory string, err error) {
 	logger.Panicf(fmt.Sprintf("Plugin must contain constructor with name %s. Error from lookup: %s",
@@ -261,6 +336,8 @@ func (r *registry) Lookup(handlerType HandlerType) interface{} {
 		return r.endorsers
 	} else if handlerType == Validation {
 		return r.validators
+	} else if handlerType == PrincipalEvaluation {
+		return r.evaluator
 	}
 
 	return nil
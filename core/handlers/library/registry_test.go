@@ -7,10 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package library
 
 import (
+	"plugin"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
+	"github.com/hyperledger/fabric/core/handlers/principal/api"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	validationbuiltin "github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +40,18 @@ func TestInitRegistry(t *testing.T) {
 	assert.Len(t, decorators, 1)
 }
 
+func TestLoadCompiledPrincipalEvaluation(t *testing.T) {
+	testReg := registry{}
+	assert.Nil(t, testReg.Lookup(PrincipalEvaluation))
+
+	testReg.loadHandlers(Config{
+		PrincipalEvaluator: &HandlerConfig{Name: "DefaultPrincipalEvaluation"},
+	})
+	factory, isFactory := testReg.Lookup(PrincipalEvaluation).(principal.PluginFactory)
+	assert.True(t, isFactory)
+	assert.NotNil(t, factory)
+}
+
 func TestLoadCompiledInvalid(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -43,3 +62,51 @@ func TestLoadCompiledInvalid(t *testing.T) {
 	testReg := registry{}
 	testReg.loadCompiled("InvalidFactory", Auth)
 }
+
+func TestLoadDefaultEndorsementAndValidation(t *testing.T) {
+	testReg := registry{}
+	testReg.loadHandlers(Config{})
+	endorsers := testReg.Lookup(Endorsement).(map[string]endorsement.PluginFactory)
+	assert.Len(t, endorsers, 1)
+	assert.IsType(t, &builtin.DefaultEndorsementFactory{}, endorsers[endorsement.DefaultPluginName])
+	validators := testReg.Lookup(Validation).(map[string]validation.PluginFactory)
+	assert.Len(t, validators, 1)
+	assert.IsType(t, &validationbuiltin.DefaultValidationFactory{}, validators[validation.DefaultPluginName])
+
+	// configured plugins take precedence over the default ones
+	testReg = registry{}
+	testReg.loadHandlers(Config{
+		Endorsers:  PluginMapping{"escc": {Name: "DefaultEndorsement"}, "custom": {Name: "DefaultEndorsement"}},
+		Validators: PluginMapping{"custom": {Name: "DefaultValidation"}},
+	})
+	assert.Len(t, testReg.Lookup(Endorsement), 2)
+	assert.Len(t, testReg.Lookup(Validation), 2)
+}
+
+type mockSymbolLookup map[string]plugin.Symbol
+
+func (m mockSymbolLookup) Lookup(symName string) (plugin.Symbol, error) {
+	if symbol, exists := m[symName]; exists {
+		return symbol, nil
+	}
+	return nil, errors.Errorf("symbol %s not found", symName)
+}
+
+func TestCheckInterfaceVersion(t *testing.T) {
+	compatibleVersion := "1.3"
+	incompatibleVersion := "2.0"
+	notString := 1
+
+	assert.NotPanics(t, func() {
+		checkInterfaceVersion(mockSymbolLookup{}, "plugin.so", "1.0")
+	})
+	assert.NotPanics(t, func() {
+		checkInterfaceVersion(mockSymbolLookup{"InterfaceVersion": &compatibleVersion}, "plugin.so", "1.0")
+	})
+	assert.Panics(t, func() {
+		checkInterfaceVersion(mockSymbolLookup{"InterfaceVersion": &incompatibleVersion}, "plugin.so", "1.0")
+	})
+	assert.Panics(t, func() {
+		checkInterfaceVersion(mockSymbolLookup{"InterfaceVersion": &notString}, "plugin.so", "1.0")
+	})
+}
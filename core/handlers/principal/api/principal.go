@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package principal
+
+import (
+	"github.com/hyperledger/fabric/protos/msp"
+)
+
+// Evaluator evaluates which identities satisfy which principals.
+// It is used by the discovery service to compute the peers that
+// can satisfy endorsement policies
+type Evaluator interface {
+	// SatisfiesPrincipal returns whether a given peer identity satisfies a certain principal
+	// on a given channel
+	SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) error
+
+	// MSPOfPrincipal returns the MSP ID of the given principal,
+	// or an empty string if the principal isn't associated with an MSP
+	MSPOfPrincipal(principal *msp.MSPPrincipal) string
+}
+
+// PluginFactory creates an Evaluator out of the default Evaluator of the peer.
+// The created Evaluator may delegate to the default Evaluator the evaluation
+// of identities and principals it doesn't handle by itself
+type PluginFactory interface {
+	New(defaultEvaluator Evaluator) Evaluator
+}
@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package builtin
+
+import (
+	. "github.com/hyperledger/fabric/core/handlers/principal/api"
+)
+
+// DefaultPrincipalEvaluationFactory returns a principal evaluation plugin factory
+// which returns the default Evaluator of the peer as is
+type DefaultPrincipalEvaluationFactory struct {
+}
+
+// New returns the given default Evaluator
+func (*DefaultPrincipalEvaluationFactory) New(defaultEvaluator Evaluator) Evaluator {
+	return defaultEvaluator
+}
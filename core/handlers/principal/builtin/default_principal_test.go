@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package builtin
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+type evaluator struct {
+}
+
+func (*evaluator) SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) error {
+	return nil
+}
+
+func (*evaluator) MSPOfPrincipal(principal *msp.MSPPrincipal) string {
+	return ""
+}
+
+func TestDefaultPrincipalEvaluation(t *testing.T) {
+	defaultEvaluator := &evaluator{}
+	factory := &DefaultPrincipalEvaluationFactory{}
+	assert.True(t, defaultEvaluator == factory.New(defaultEvaluator))
+}
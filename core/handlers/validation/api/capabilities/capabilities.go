@@ -45,4 +45,8 @@ type Capabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// FabToken returns true if this channel supports the experimental token transactions,
+	// which are validated and committed without invoking chaincodes
+	FabToken() bool
 }
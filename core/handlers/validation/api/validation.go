@@ -8,6 +8,17 @@ package validation
 
 import "github.com/hyperledger/fabric/protos/common"
 
+const (
+	// InterfaceVersion is the version of the validation plugin interface. A plugin that is loaded
+	// from a shared object may declare the version it implements in an exported string variable
+	// named InterfaceVersion, which the peer checks to be of the same major version
+	InterfaceVersion = "1.0"
+
+	// DefaultPluginName is the name of the validation plugin that validates the transactions
+	// of system chaincodes, and of chaincodes that are instantiated without a validation plugin
+	DefaultPluginName = "vscc"
+)
+
 // Argument defines the argument for validation
 type Argument interface {
 	Dependency
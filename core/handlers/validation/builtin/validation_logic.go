@@ -193,6 +193,11 @@ func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfi
 				collectionName, maximumPeerCount, requiredPeerCount))
 
 		}
+
+		// The member access policy is optional, but has to be a signature policy if present
+		if memberAccessPolicy := newCollection.GetMemberAccessPolicy(); memberAccessPolicy != nil && memberAccessPolicy.GetSignaturePolicy() == nil {
+			return policyErr(fmt.Errorf("collection-name: %s -- member access policy must be a signature policy", collectionName))
+		}
 	}
 	return nil
 }
@@ -1830,6 +1830,22 @@ func TestValidateRWSetAndCollectionForDeploy(t *testing.T) {
 	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
 	assert.Errorf(t, err, "collection-name: %s -- maximum peer count (%d) cannot be greater than the required peer count (%d)",
 		collName3, maximumPeerCount, requiredPeerCount)
+
+	// Test 12: a collection with a member access policy -> success
+	coll3 = createCollectionConfig(collName3, policyEnvelope, 1, 2, blockToLive)
+	coll3.GetStaticCollectionConfig().MemberAccessPolicy = &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+			SignaturePolicy: cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{signers[0]}),
+		},
+	}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.NoError(t, err)
+
+	// Test 13: a member access policy that isn't a signature policy -> error
+	coll3.GetStaticCollectionConfig().MemberAccessPolicy = &common.CollectionPolicyConfig{}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "collection-name: mycollection3 -- member access policy must be a signature policy")
 }
 
 func TestValidateRWSetAndCollectionForUpgrade(t *testing.T) {
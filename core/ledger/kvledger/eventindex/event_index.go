@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventindex
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+var logger = flogging.MustGetLogger("eventindex")
+
+var savePointKey = []byte{0x00}
+
+// Provider provides the indexes of the chaincode events of the ledgers, which share a single leveldb
+type Provider struct {
+	dbProvider *leveldbhelper.Provider
+}
+
+// NewProvider instantiates Provider
+func NewProvider() *Provider {
+	dbPath := ledgerconfig.GetEventIndexLevelDBPath()
+	logger.Debugf("constructing event index Provider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	return &Provider{dbProvider}
+}
+
+// GetIndex returns the index of the chaincode events of the given ledger
+func (provider *Provider) GetIndex(ledgerID string) *Index {
+	return &Index{provider.dbProvider.GetDBHandle(ledgerID), ledgerID}
+}
+
+// Close closes the underlying db
+func (provider *Provider) Close() {
+	provider.dbProvider.Close()
+}
+
+// Index indexes the chaincode events emitted by the valid transactions of the blocks of a ledger
+// by the name of the chaincode, the height of the transaction and the ID of the transaction
+type Index struct {
+	db       *leveldbhelper.DBHandle
+	ledgerID string
+}
+
+// Commit indexes the chaincode events of the given block
+func (idx *Index) Commit(block *common.Block) error {
+	blockNo := block.Header.Number
+	var tranNo uint64
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	for _, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(int(tranNo)) {
+			tranNo++
+			continue
+		}
+		env, err := putils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return err
+		}
+		payload, err := putils.GetPayload(env)
+		if err != nil {
+			return err
+		}
+		chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return err
+		}
+		if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+			ccAction, err := putils.GetActionFromEnvelope(envBytes)
+			if err != nil {
+				return err
+			}
+			if len(ccAction.Events) > 0 {
+				ccEvent, err := putils.GetChaincodeEvents(ccAction.Events)
+				if err != nil {
+					return err
+				}
+				if ccEvent.ChaincodeId != "" {
+					eventKey := constructEventKey(ccEvent.ChaincodeId, blockNo, tranNo)
+					dbBatch.Put(eventKey, ccAction.Events)
+					dbBatch.Put(constructTxIDKey(chdr.TxId), eventKey)
+				}
+			}
+		}
+		tranNo++
+	}
+
+	// add savepoint for recovery purpose
+	dbBatch.Put(savePointKey, version.NewHeight(blockNo, tranNo).ToBytes())
+	if err := idx.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	logger.Debugf("Channel [%s]: Chaincode events of block [%d] indexed", idx.ledgerID, blockNo)
+	return nil
+}
+
+// GetLastSavepoint returns the height of the last block that is indexed, or nil if no block is indexed
+func (idx *Index) GetLastSavepoint() (*version.Height, error) {
+	versionBytes, err := idx.db.Get(savePointKey)
+	if err != nil || versionBytes == nil {
+		return nil, err
+	}
+	height, _ := version.NewHeightFromBytes(versionBytes)
+	return height, nil
+}
+
+// InitSavepoint sets the savepoint of an empty index of a ledger that starts at a snapshot, rather than
+// the genesis block. The events emitted before the snapshot are not indexed
+func (idx *Index) InitSavepoint(height *version.Height) error {
+	savepoint, err := idx.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint != nil {
+		return fmt.Errorf("event index for channel [%s] is not empty", idx.ledgerID)
+	}
+	return idx.db.Put(savePointKey, height.ToBytes(), true)
+}
+
+// ShouldRecover implements method in interface kvledger.Recoverer
+func (idx *Index) ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error) {
+	if !ledgerconfig.IsEventIndexEnabled() {
+		return false, 0, nil
+	}
+	savepoint, err := idx.GetLastSavepoint()
+	if err != nil {
+		return false, 0, err
+	}
+	if savepoint == nil {
+		return true, 0, nil
+	}
+	return savepoint.BlockNum != lastAvailableBlock, savepoint.BlockNum + 1, nil
+}
+
+// CommitLostBlock implements method in interface kvledger.Recoverer
+func (idx *Index) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	return idx.Commit(blockAndPvtdata.Block)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventindex
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	ptestutils "github.com/hyperledger/fabric/protos/testutils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnv struct {
+	t        *testing.T
+	path     string
+	provider *Provider
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	path, err := ioutil.TempDir("", "eventindex-")
+	assert.NoError(t, err)
+	viper.Set("peer.fileSystemPath", path)
+	viper.Set("ledger.eventIndex.enabled", true)
+	return &testEnv{t, path, NewProvider()}
+}
+
+func (env *testEnv) cleanup() {
+	env.provider.Close()
+	os.RemoveAll(env.path)
+	viper.Set("ledger.eventIndex.enabled", false)
+}
+
+// tx is a transaction of a test block, which emits the given event unless its name is empty
+type tx struct {
+	txID      string
+	ccName    string
+	eventName string
+	invalid   bool
+}
+
+func constructBlock(t *testing.T, blockNum uint64, txs ...tx) *common.Block {
+	var envs []*common.Envelope
+	for _, tx := range txs {
+		var events []byte
+		if tx.eventName != "" {
+			var err error
+			events, err = proto.Marshal(&peer.ChaincodeEvent{
+				ChaincodeId: tx.ccName,
+				TxId:        tx.txID,
+				EventName:   tx.eventName,
+				Payload:     []byte("payload of " + tx.txID),
+			})
+			assert.NoError(t, err)
+		}
+		env, _, err := ptestutils.ConstructUnsignedTxEnv(util.GetTestChainID(), &peer.ChaincodeID{Name: tx.ccName, Version: "v1"},
+			nil, []byte("results"), tx.txID, events, nil)
+		assert.NoError(t, err)
+		envs = append(envs, env)
+	}
+	block := testutil.NewBlock(envs, blockNum, []byte("previous hash"))
+	flags := lutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i, tx := range txs {
+		if tx.invalid {
+			flags.SetFlag(i, peer.TxValidationCode_MVCC_READ_CONFLICT)
+		}
+	}
+	return block
+}
+
+func queryEvents(t *testing.T, idx *Index, query *ledger.ChaincodeEventsQuery) []*peer.ChaincodeEventRecord {
+	itr, err := idx.Query(query)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var records []*peer.ChaincodeEventRecord
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			return records
+		}
+		records = append(records, res.(*peer.ChaincodeEventRecord))
+	}
+}
+
+func txIDsOf(records []*peer.ChaincodeEventRecord) []string {
+	var txIDs []string
+	for _, r := range records {
+		txIDs = append(txIDs, r.Event.TxId)
+	}
+	return txIDs
+}
+
+func TestSavepoint(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	idx := env.provider.GetIndex("ledger1")
+
+	savepoint, err := idx.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	recover, firstBlockNum, err := idx.ShouldRecover(0)
+	assert.NoError(t, err)
+	assert.True(t, recover)
+	assert.Equal(t, uint64(0), firstBlockNum)
+
+	_, genesisBlock := testutil.NewBlockGenerator(t, "ledger1", false)
+	assert.NoError(t, idx.Commit(genesisBlock))
+	assert.NoError(t, idx.CommitLostBlock(&ledger.BlockAndPvtData{Block: constructBlock(t, 1, tx{"tx1", "cc1", "", false})}))
+	savepoint, err = idx.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), savepoint)
+
+	recover, _, err = idx.ShouldRecover(1)
+	assert.NoError(t, err)
+	assert.False(t, recover)
+	recover, firstBlockNum, err = idx.ShouldRecover(5)
+	assert.NoError(t, err)
+	assert.True(t, recover)
+	assert.Equal(t, uint64(2), firstBlockNum)
+
+	// the index isn't recovered while it isn't enabled
+	viper.Set("ledger.eventIndex.enabled", false)
+	recover, _, err = idx.ShouldRecover(5)
+	assert.NoError(t, err)
+	assert.False(t, recover)
+}
+
+func TestInitSavepoint(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	idx := env.provider.GetIndex("ledger1")
+
+	assert.NoError(t, idx.InitSavepoint(version.NewHeight(10, 2)))
+	recover, _, err := idx.ShouldRecover(10)
+	assert.NoError(t, err)
+	assert.False(t, recover)
+	assert.EqualError(t, idx.InitSavepoint(version.NewHeight(20, 0)), "event index for channel [ledger1] is not empty")
+}
+
+func TestQuery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	idx := env.provider.GetIndex("ledger1")
+	// the events of the other ledgers are indexed separately
+	assert.NoError(t, env.provider.GetIndex("ledger2").Commit(constructBlock(t, 1, tx{"tx0", "cc1", "transfer", false})))
+
+	assert.NoError(t, idx.Commit(constructBlock(t, 1,
+		tx{"tx1", "cc1", "transfer", false},
+		tx{"tx2", "cc2", "transfer", false},
+		tx{"tx3", "cc1", "mint", true},
+		tx{"tx4", "cc1", "", false},
+	)))
+	assert.NoError(t, idx.Commit(constructBlock(t, 2,
+		tx{"tx5", "cc1", "mint", false},
+		tx{"tx6", "cc1", "transferFrom", false},
+	)))
+	assert.NoError(t, idx.Commit(constructBlock(t, 3,
+		tx{"tx7", "cc11", "transfer", false},
+		tx{"tx8", "cc1", "burn", false},
+	)))
+
+	all := &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EndBlock: math.MaxUint64}
+	records := queryEvents(t, idx, all)
+	assert.Equal(t, []string{"tx1", "tx5", "tx6", "tx8"}, txIDsOf(records))
+	assert.Equal(t, uint64(2), records[2].BlockNumber)
+	assert.Equal(t, uint64(1), records[2].TxNumber)
+	assert.Equal(t, "transferFrom", records[2].Event.EventName)
+	assert.Equal(t, []byte("payload of tx6"), records[2].Event.Payload)
+
+	t.Run("EventNamePattern", func(t *testing.T) {
+		records := queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EventNamePattern: "transfer", EndBlock: math.MaxUint64})
+		assert.Equal(t, []string{"tx1"}, txIDsOf(records))
+		records = queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EventNamePattern: "transfer.*|burn", EndBlock: math.MaxUint64})
+		assert.Equal(t, []string{"tx1", "tx6", "tx8"}, txIDsOf(records))
+	})
+
+	t.Run("BlockRange", func(t *testing.T) {
+		records := queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", StartBlock: 2, EndBlock: 2})
+		assert.Equal(t, []string{"tx5", "tx6"}, txIDsOf(records))
+		records = queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", StartBlock: 3, EndBlock: math.MaxUint64})
+		assert.Equal(t, []string{"tx8"}, txIDsOf(records))
+		records = queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", StartBlock: 4, EndBlock: 10})
+		assert.Empty(t, records)
+	})
+
+	t.Run("TxID", func(t *testing.T) {
+		records := queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EndBlock: math.MaxUint64, TxID: "tx6"})
+		assert.Equal(t, []string{"tx6"}, txIDsOf(records))
+		// the event of the transaction has to match the rest of the query too
+		assert.Empty(t, queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc2", EndBlock: math.MaxUint64, TxID: "tx6"}))
+		assert.Empty(t, queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EndBlock: 1, TxID: "tx6"}))
+		assert.Empty(t, queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EventNamePattern: "mint", EndBlock: math.MaxUint64, TxID: "tx6"}))
+		// invalid transactions and transactions without events aren't indexed
+		assert.Empty(t, queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EndBlock: math.MaxUint64, TxID: "tx3"}))
+		assert.Empty(t, queryEvents(t, idx, &ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EndBlock: math.MaxUint64, TxID: "tx4"}))
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		_, err := idx.Query(&ledger.ChaincodeEventsQuery{EndBlock: math.MaxUint64})
+		assert.EqualError(t, err, "the chaincode name of the query must not be empty")
+		_, err = idx.Query(&ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", StartBlock: 3, EndBlock: 2})
+		assert.EqualError(t, err, "the start block [3] of the query is after its end block [2]")
+		_, err = idx.Query(&ledger.ChaincodeEventsQuery{ChaincodeName: "cc1", EventNamePattern: "(", EndBlock: math.MaxUint64})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid event name pattern [(]")
+	})
+}
+
+func TestSplitEventKey(t *testing.T) {
+	ccName, blockNum, tranNum, err := splitEventKey(constructEventKey("cc1", 300, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, "cc1", ccName)
+	assert.Equal(t, uint64(300), blockNum)
+	assert.Equal(t, uint64(7), tranNum)
+
+	_, _, _, err = splitEventKey(constructPartialEventKey("cc1", 300))
+	assert.Error(t, err)
+	_, _, _, err = splitEventKey([]byte("ecc1"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventindex
+
+import (
+	"regexp"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+var eventKeyPrefix = []byte{'e'}
+var txIDKeyPrefix = []byte{'t'}
+var compositeKeySep = []byte{0x00}
+
+// Query returns an iterator over the indexed events that match the given query, in the order they were committed.
+// The iterator contains items of type *peer.ChaincodeEventRecord
+func (idx *Index) Query(query *ledger.ChaincodeEventsQuery) (commonledger.ResultsIterator, error) {
+	if query.ChaincodeName == "" {
+		return nil, errors.New("the chaincode name of the query must not be empty")
+	}
+	if query.StartBlock > query.EndBlock {
+		return nil, errors.Errorf("the start block [%d] of the query is after its end block [%d]", query.StartBlock, query.EndBlock)
+	}
+	scanner := &eventScanner{query: query}
+	if query.EventNamePattern != "" {
+		// the names of the events have to match the pattern entirely
+		namePattern, err := regexp.Compile("^(?:" + query.EventNamePattern + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event name pattern [%s]", query.EventNamePattern)
+		}
+		scanner.namePattern = namePattern
+	}
+	if query.TxID != "" {
+		// a transaction emits a single event, hence there is nothing to scan
+		eventKey, err := idx.db.Get(constructTxIDKey(query.TxID))
+		if err != nil {
+			return nil, err
+		}
+		if eventKey == nil {
+			return scanner, nil
+		}
+		eventBytes, err := idx.db.Get(eventKey)
+		if err != nil {
+			return nil, err
+		}
+		scanner.pending = []kv{{eventKey, eventBytes}}
+		return scanner, nil
+	}
+	startKey := constructEventKey(query.ChaincodeName, query.StartBlock, 0)
+	endKey := append(constructPartialEventKey(query.ChaincodeName, query.EndBlock), 0xff)
+	scanner.dbItr = idx.db.GetIterator(startKey, endKey)
+	return scanner, nil
+}
+
+type kv struct {
+	key, value []byte
+}
+
+// eventScanner implements ResultsIterator for iterating through the indexed events that match a query,
+// either from a range scan of the index, or from the pending events that were looked up already
+type eventScanner struct {
+	query       *ledger.ChaincodeEventsQuery
+	namePattern *regexp.Regexp // nil matches all the names
+	dbItr       iterator.Iterator
+	pending     []kv
+}
+
+// Next returns the next event that matches the query, or nil when there are no more
+func (scanner *eventScanner) Next() (commonledger.QueryResult, error) {
+	for {
+		eventKV, ok := scanner.next()
+		if !ok {
+			return nil, nil
+		}
+		ccName, blockNum, tranNum, err := splitEventKey(eventKV.key)
+		if err != nil {
+			return nil, err
+		}
+		if ccName != scanner.query.ChaincodeName || blockNum < scanner.query.StartBlock || blockNum > scanner.query.EndBlock {
+			continue
+		}
+		ccEvent, err := putils.GetChaincodeEvents(eventKV.value)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshalling indexed chaincode event")
+		}
+		if scanner.namePattern != nil && !scanner.namePattern.MatchString(ccEvent.EventName) {
+			continue
+		}
+		return &peer.ChaincodeEventRecord{BlockNumber: blockNum, TxNumber: tranNum, Event: ccEvent}, nil
+	}
+}
+
+func (scanner *eventScanner) next() (kv, bool) {
+	if scanner.dbItr != nil {
+		if !scanner.dbItr.Next() {
+			return kv{}, false
+		}
+		return kv{scanner.dbItr.Key(), scanner.dbItr.Value()}, true
+	}
+	if len(scanner.pending) == 0 {
+		return kv{}, false
+	}
+	eventKV := scanner.pending[0]
+	scanner.pending = scanner.pending[1:]
+	return eventKV, true
+}
+
+// Close releases the resources held by the scanner
+func (scanner *eventScanner) Close() {
+	if scanner.dbItr != nil {
+		scanner.dbItr.Release()
+	}
+}
+
+// constructEventKey builds the key of an event e~ccName~blockNum~tranNum using an order preserving
+// encoding, so that the events of a chaincode are ordered by height. The names of chaincodes can't
+// contain the separator
+func constructEventKey(ccName string, blockNum uint64, tranNum uint64) []byte {
+	eventKey := constructPartialEventKey(ccName, blockNum)
+	return append(eventKey, util.EncodeOrderPreservingVarUint64(tranNum)...)
+}
+
+// constructPartialEventKey builds the prefix e~ccName~blockNum of the keys of the events of a block
+func constructPartialEventKey(ccName string, blockNum uint64) []byte {
+	var eventKey []byte
+	eventKey = append(eventKey, eventKeyPrefix...)
+	eventKey = append(eventKey, []byte(ccName)...)
+	eventKey = append(eventKey, compositeKeySep...)
+	return append(eventKey, util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
+
+// splitEventKey splits the key of an event into the name of the chaincode, the block number and the transaction number
+func splitEventKey(eventKey []byte) (string, uint64, uint64, error) {
+	for i := len(eventKeyPrefix); i < len(eventKey); i++ {
+		if eventKey[i] != compositeKeySep[0] {
+			continue
+		}
+		height := eventKey[i+1:]
+		if !isEncodedNumber(height) {
+			break
+		}
+		blockNum, consumed := util.DecodeOrderPreservingVarUint64(height)
+		if !isEncodedNumber(height[consumed:]) {
+			break
+		}
+		tranNum, _ := util.DecodeOrderPreservingVarUint64(height[consumed:])
+		return string(eventKey[len(eventKeyPrefix):i]), blockNum, tranNum, nil
+	}
+	return "", 0, 0, errors.Errorf("malformed event key %x", eventKey)
+}
+
+// isEncodedNumber tells whether the bytes start with a number encoded by EncodeOrderPreservingVarUint64
+func isEncodedNumber(b []byte) bool {
+	return len(b) > 0 && b[0] <= 8 && int(b[0]) < len(b)
+}
+
+// constructTxIDKey builds the key t~txID, the value of which is the key of the event of the transaction
+func constructTxIDKey(txID string) []byte {
+	return append(append([]byte{}, txIDKeyPrefix...), []byte(txID)...)
+}
@@ -53,6 +53,48 @@ func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []b
 	return compositeKey
 }
 
+// SplitHeightFromCompositeHistoryKey splits the History Key namespace~key~blocknum~trannum into the partial
+// History Key namespace~key~ and the block number. Since the key may contain the separator, the height is
+// taken from the longest suffix that decodes into a block number and a transaction number.
+// It returns false if the History Key doesn't end with a height
+func SplitHeightFromCompositeHistoryKey(historyKey []byte) ([]byte, uint64, bool) {
+	nsEnd := bytes.Index(historyKey, compositeKeySep)
+	if nsEnd <= 0 {
+		return nil, 0, false
+	}
+	// an encoded number takes up to 9 bytes, so the height takes up to 18 bytes
+	start := len(historyKey) - 19
+	if start <= nsEnd {
+		start = nsEnd + 1
+	}
+	for sepIndex := start; sepIndex < len(historyKey); sepIndex++ {
+		if historyKey[sepIndex] != compositeKeySep[0] || !isEncodedHeight(historyKey[sepIndex+1:]) {
+			continue
+		}
+		blockNum, _ := util.DecodeOrderPreservingVarUint64(historyKey[sepIndex+1:])
+		return historyKey[:sepIndex+1], blockNum, true
+	}
+	return nil, 0, false
+}
+
+// isEncodedHeight tells whether the bytes are exactly a block number followed by a transaction number,
+// both encoded by EncodeOrderPreservingVarUint64
+func isEncodedHeight(b []byte) bool {
+	consumed := 0
+	for i := 0; i < 2; i++ {
+		if consumed >= len(b) {
+			return false
+		}
+		size := int(b[consumed])
+		// the encoding has no leading zeros
+		if size > 8 || consumed+size >= len(b) || (size > 0 && b[consumed+1] == 0x00) {
+			return false
+		}
+		consumed += size + 1
+	}
+	return consumed == len(b)
+}
+
 //SplitCompositeHistoryKey splits the key bytes using a separator
 func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
 	split := bytes.SplitN(bytesToSplit, separator, 2)
@@ -46,3 +46,21 @@ func TestSplitCompositeKey(t *testing.T) {
 	// second position should hold the extra bytes that were split off
 	testutil.AssertEquals(t, extraBytes, []byte("extra bytes to split"))
 }
+
+func TestSplitHeightFromCompositeHistoryKey(t *testing.T) {
+	compositeKey := "\x00objectType\x00attr1\x00attr2\x00"
+	for _, key := range []string{"key1", compositeKey, "key\x00with\x00separators"} {
+		for _, height := range [][2]uint64{{0, 0}, {1, 0}, {0x01000000, 0}, {0x0100, 0x0100}, {^uint64(0), 5}} {
+			historyKey := ConstructCompositeHistoryKey("ns1", key, height[0], height[1])
+			partialKey, blockNum, ok := SplitHeightFromCompositeHistoryKey(historyKey)
+			testutil.AssertEquals(t, ok, true)
+			testutil.AssertEquals(t, partialKey, ConstructPartialCompositeHistoryKey("ns1", key, false))
+			testutil.AssertEquals(t, blockNum, height[0])
+		}
+	}
+
+	_, _, ok := SplitHeightFromCompositeHistoryKey([]byte{0x00})
+	testutil.AssertEquals(t, ok, false)
+	_, _, ok = SplitHeightFromCompositeHistoryKey([]byte("ns1" + strKeySep + "key1" + strKeySep + "no height"))
+	testutil.AssertEquals(t, ok, false)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package historydb
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -36,6 +38,28 @@ type HistoryDB interface {
 	NewHistoryQueryExecutor(blockStore blkstorage.BlockStore) (ledger.HistoryQueryExecutor, error)
 	Commit(block *common.Block) error
 	GetLastSavepoint() (*version.Height, error)
+	// InitSavepoint sets the savepoint of an empty history db that starts at a snapshot, rather than the genesis block
+	InitSavepoint(height *version.Height) error
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// Prune deletes the history records that are not retained by the given retention policy, and returns
+	// the number of deleted records. The block store is used to find out when the records were committed.
+	// Prune can be invoked while blocks are committed
+	Prune(policy RetentionPolicy, blockStore blkstorage.BlockStore) (uint64, error)
+}
+
+// RetentionPolicy determines the history records that are retained when a history database is pruned.
+// A record is deleted only if none of the criteria that are set retains it
+type RetentionPolicy struct {
+	// MaxVersions is the number of most recent versions of each key that are retained.
+	// Zero unsets this criterion
+	MaxVersions int
+	// MaxAge is how long the versions of each key are retained after the block they were committed in was created.
+	// Zero unsets this criterion
+	MaxAge time.Duration
+}
+
+// IsEnabled tells whether the policy sets any criterion, without which no history record is ever deleted
+func (p RetentionPolicy) IsEnabled() bool {
+	return p.MaxVersions > 0 || p.MaxAge > 0
 }
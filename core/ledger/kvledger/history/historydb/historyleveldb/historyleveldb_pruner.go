@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyleveldb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+// pruneBatchSize is the number of history records that are deleted in a single write to the history database
+const pruneBatchSize = 1000
+
+// historyRecord is a history record of a key at a given block
+type historyRecord struct {
+	historyKey []byte
+	blockNum   uint64
+}
+
+// Prune implements method in HistoryDB interface
+func (historyDB *historyDB) Prune(policy historydb.RetentionPolicy, blockStore blkstorage.BlockStore) (uint64, error) {
+	if !policy.IsEnabled() {
+		return 0, nil
+	}
+
+	// the records committed in the blocks up to ageCutoff are older than the maximum age.
+	// Without a maximum age, all the records are considered old
+	ageCutoff, anyOld := ^uint64(0), true
+	if policy.MaxAge > 0 {
+		var err error
+		ageCutoff, anyOld, err = lastBlockCreatedBefore(blockStore, time.Now().Add(-policy.MaxAge))
+		if err != nil {
+			return 0, err
+		}
+		if !anyOld {
+			logger.Debugf("Channel [%s]: No history record is older than %s", historyDB.dbName, policy.MaxAge)
+			return 0, nil
+		}
+	}
+
+	logger.Infof("Channel [%s]: Pruning history database with retention policy %+v", historyDB.dbName, policy)
+
+	var pruned uint64
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	// deleteRecords deletes the records of a key that are not retained, which are ordered by height
+	deleteRecords := func(records []historyRecord) error {
+		for i, record := range records {
+			newerVersions := len(records) - 1 - i
+			if newerVersions < policy.MaxVersions || record.blockNum > ageCutoff {
+				continue
+			}
+			dbBatch.Delete(record.historyKey)
+			pruned++
+			if len(dbBatch.KVs) < pruneBatchSize {
+				continue
+			}
+			if err := historyDB.db.WriteBatch(dbBatch, true); err != nil {
+				return err
+			}
+			dbBatch = leveldbhelper.NewUpdateBatch()
+		}
+		return nil
+	}
+
+	// the records of a key are adjacent in the history database, and they are ordered by height
+	dbItr := historyDB.db.GetIterator(nil, nil)
+	defer dbItr.Release()
+	var partialKey []byte
+	var records []historyRecord
+	for dbItr.Next() {
+		historyKey := append([]byte{}, dbItr.Key()...)
+		if bytes.Equal(historyKey, savePointKey) {
+			continue
+		}
+		recordPartialKey, blockNum, ok := historydb.SplitHeightFromCompositeHistoryKey(historyKey)
+		if !ok {
+			logger.Warningf("Channel [%s]: Skipping malformed history key [%#v]", historyDB.dbName, historyKey)
+			continue
+		}
+		if !bytes.Equal(recordPartialKey, partialKey) {
+			if err := deleteRecords(records); err != nil {
+				return pruned, err
+			}
+			partialKey, records = recordPartialKey, records[:0]
+		}
+		records = append(records, historyRecord{historyKey, blockNum})
+	}
+	if err := dbItr.Error(); err != nil {
+		return pruned, err
+	}
+	if err := deleteRecords(records); err != nil {
+		return pruned, err
+	}
+	if err := historyDB.db.WriteBatch(dbBatch, true); err != nil {
+		return pruned, err
+	}
+
+	logger.Infof("Channel [%s]: Pruned %d records from history database", historyDB.dbName, pruned)
+	return pruned, nil
+}
+
+// lastBlockCreatedBefore returns the number of the last block that was created before the given time,
+// according to the timestamp of its first transaction, and false if there is no such block.
+// The blocks are assumed to be created in the order of their numbers
+func lastBlockCreatedBefore(blockStore blkstorage.BlockStore, t time.Time) (uint64, bool, error) {
+	info, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return 0, false, err
+	}
+	var firstBlockNum uint64
+	bootstrapInfo, err := blockStore.GetBootstrapInfo()
+	if err != nil {
+		return 0, false, err
+	}
+	if bootstrapInfo != nil {
+		firstBlockNum = bootstrapInfo.LastBlockNum + 1
+	}
+
+	// search for the first block that was created at or after the given time
+	low, high := firstBlockNum, info.Height
+	for low < high {
+		mid := low + (high-low)/2
+		created, err := blockCreationTime(blockStore, mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if created.Before(t) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	if low == firstBlockNum {
+		return 0, false, nil
+	}
+	return low - 1, true, nil
+}
+
+// blockCreationTime returns the timestamp of the first transaction of the given block
+func blockCreationTime(blockStore blkstorage.BlockStore, blockNum uint64) (time.Time, error) {
+	tranEnvelope, err := blockStore.RetrieveTxByBlockNumTranNum(blockNum, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	payload, err := putils.GetPayload(tranEnvelope)
+	if err != nil {
+		return time.Time{}, err
+	}
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if chdr.Timestamp == nil {
+		return time.Time{}, nil
+	}
+	return time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos)), nil
+}
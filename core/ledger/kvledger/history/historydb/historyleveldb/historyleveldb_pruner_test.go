@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package historyleveldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+
+func TestPrune(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	testutil.AssertNoError(t, store1.AddBlock(gb), "")
+	testutil.AssertNoError(t, env.testHistoryDB.Commit(gb), "")
+
+	compositeKey := "\x00objectType\x00attr1\x00"
+	// blocks 1 to 4 update key1 and the composite key, and block 1 updates key2 as well
+	for i := 1; i <= 4; i++ {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte("value1"))
+		simulator.SetState("ns1", compositeKey, []byte("value1"))
+		if i == 1 {
+			simulator.SetState("ns1", "key2", []byte("value2"))
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		testutil.AssertNoError(t, store1.AddBlock(block), "")
+		testutil.AssertNoError(t, env.testHistoryDB.Commit(block), "")
+	}
+
+	// a policy without any criterion doesn't prune any record
+	pruned, err := env.testHistoryDB.Prune(historydb.RetentionPolicy{}, store1)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pruned, uint64(0))
+
+	// all the records were committed within the maximum age
+	pruned, err = env.testHistoryDB.Prune(historydb.RetentionPolicy{MaxAge: time.Hour}, store1)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pruned, uint64(0))
+
+	// the 2 oldest versions of key1 and of the composite key are pruned
+	pruned, err = env.testHistoryDB.Prune(historydb.RetentionPolicy{MaxVersions: 2}, store1)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pruned, uint64(4))
+	assertHistoryBlocks(t, env.testHistoryDB, store1, "key1", []uint64{3, 4})
+	assertHistoryBlocks(t, env.testHistoryDB, store1, compositeKey, []uint64{3, 4})
+	assertHistoryBlocks(t, env.testHistoryDB, store1, "key2", []uint64{1})
+
+	// the versions within the maximum age are retained even if they are not among the most recent ones
+	pruned, err = env.testHistoryDB.Prune(historydb.RetentionPolicy{MaxVersions: 1, MaxAge: time.Hour}, store1)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pruned, uint64(0))
+
+	// once the versions are older than the maximum age, only the most recent one is retained
+	time.Sleep(10 * time.Millisecond)
+	pruned, err = env.testHistoryDB.Prune(historydb.RetentionPolicy{MaxVersions: 1, MaxAge: time.Millisecond}, store1)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pruned, uint64(2))
+	assertHistoryBlocks(t, env.testHistoryDB, store1, "key1", []uint64{4})
+	assertHistoryBlocks(t, env.testHistoryDB, store1, compositeKey, []uint64{4})
+	assertHistoryBlocks(t, env.testHistoryDB, store1, "key2", []uint64{1})
+
+	// the savepoint isn't pruned
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, savepoint.BlockNum, uint64(4))
+}
+
+func TestLastBlockCreatedBefore(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	store1, err := env.testBlockStorageEnv.provider.OpenBlockStore("ledger1")
+	testutil.AssertNoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	_, found, err := lastBlockCreatedBefore(store1, time.Now())
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, found, false)
+
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	for _, block := range blocks {
+		testutil.AssertNoError(t, store1.AddBlock(block), "")
+	}
+	_, found, err = lastBlockCreatedBefore(store1, time.Now().Add(-time.Hour))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, found, false)
+
+	blockNum, found, err := lastBlockCreatedBefore(store1, time.Now().Add(time.Hour))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, found, true)
+	testutil.AssertEquals(t, blockNum, uint64(2))
+}
+
+// assertHistoryBlocks asserts that the history of the given key consists of the updates in the given blocks
+func assertHistoryBlocks(t *testing.T, historyDB historydb.HistoryDB, blockStore blkstorage.BlockStore, key string, expectedBlockNums []uint64) {
+	qhistory, err := historyDB.NewHistoryQueryExecutor(blockStore)
+	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
+	itr, err := qhistory.GetHistoryForKey("ns1", key)
+	testutil.AssertNoError(t, err, "Error upon GetHistoryForKey()")
+	defer itr.Close()
+
+	var blockNums []uint64
+	for {
+		kmod, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if kmod == nil {
+			break
+		}
+		block, err := blockStore.RetrieveBlockByTxID(kmod.(*queryresult.KeyModification).TxId)
+		testutil.AssertNoError(t, err, "")
+		blockNums = append(blockNums, block.Header.Number)
+	}
+	testutil.AssertEquals(t, blockNums, expectedBlockNums)
+}
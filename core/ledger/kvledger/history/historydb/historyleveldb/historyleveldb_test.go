@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -101,6 +102,24 @@ func TestSavepoint(t *testing.T) {
 	testutil.AssertEquals(t, blockNum, uint64(3))
 }
 
+//TestInitSavepoint tests that the savepoint of a history db that starts at a snapshot can only be set while it's empty
+func TestInitSavepoint(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	testutil.AssertNoError(t, env.testHistoryDB.InitSavepoint(version.NewHeight(10, 2)), "")
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	testutil.AssertNoError(t, err, "Error upon historyDatabase.GetLastSavepoint()")
+	testutil.AssertEquals(t, savepoint, version.NewHeight(10, 2))
+
+	// ShouldRecover should return false as the history db is in sync with the snapshot
+	status, _, err := env.testHistoryDB.ShouldRecover(10)
+	testutil.AssertNoError(t, err, "Error upon historyDatabase.ShouldRecover()")
+	testutil.AssertEquals(t, status, false)
+
+	testutil.AssertError(t, env.testHistoryDB.InitSavepoint(version.NewHeight(20, 0)), "")
+}
+
 func TestHistory(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
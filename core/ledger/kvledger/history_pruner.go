@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+)
+
+// historyPruner prunes the history database of a ledger according to a retention policy,
+// periodically in the background and on demand. Only one pruning runs at a time
+type historyPruner struct {
+	ledgerID   string
+	historyDB  historydb.HistoryDB
+	blockStore blkstorage.BlockStore
+	policy     historydb.RetentionPolicy
+
+	lock    sync.Mutex
+	status  ledger.HistoryPruningStatus
+	running sync.WaitGroup
+	stop    chan struct{}
+}
+
+// newHistoryPruner constructs a historyPruner, which prunes the history database at
+// the given interval if the retention policy is enabled and the interval isn't zero
+func newHistoryPruner(ledgerID string, historyDB historydb.HistoryDB, blockStore blkstorage.BlockStore,
+	policy historydb.RetentionPolicy, interval time.Duration) *historyPruner {
+	p := &historyPruner{
+		ledgerID:   ledgerID,
+		historyDB:  historyDB,
+		blockStore: blockStore,
+		policy:     policy,
+		stop:       make(chan struct{}),
+	}
+	if policy.IsEnabled() && interval > 0 {
+		logger.Infof("Pruning history database of ledger [%s] every %s with retention policy %+v", ledgerID, interval, policy)
+		go p.pruneEvery(interval)
+	}
+	return p
+}
+
+func (p *historyPruner) pruneEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.start(); err != nil {
+				logger.Warningf("Skipping periodic pruning: %s", err)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// start starts pruning the history database in the background,
+// unless it is already being pruned or the retention policy isn't enabled
+func (p *historyPruner) start() error {
+	if !p.policy.IsEnabled() {
+		return fmt.Errorf("no retention policy is configured for the history database of ledger [%s]", p.ledgerID)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	select {
+	case <-p.stop:
+		return fmt.Errorf("ledger [%s] is closed", p.ledgerID)
+	default:
+	}
+	if p.status.Running {
+		return fmt.Errorf("history database of ledger [%s] is already being pruned", p.ledgerID)
+	}
+	p.status.Running = true
+	p.status.LastStarted = time.Now()
+	p.running.Add(1)
+	go p.prune()
+	return nil
+}
+
+func (p *historyPruner) prune() {
+	defer p.running.Done()
+	pruned, err := p.historyDB.Prune(p.policy, p.blockStore)
+	if err != nil {
+		logger.Errorf("Failed pruning history database of ledger [%s]: %s", p.ledgerID, err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.status.Running = false
+	p.status.LastCompleted = time.Now()
+	p.status.LastPrunedRecords = pruned
+	p.status.TotalPrunedRecords += pruned
+	p.status.LastError = ""
+	if err != nil {
+		p.status.LastError = err.Error()
+	}
+}
+
+// getStatus returns the status of the pruning of the history database
+func (p *historyPruner) getStatus() *ledger.HistoryPruningStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	status := p.status
+	return &status
+}
+
+// close stops the periodic pruning and waits for a pruning that is running to complete.
+// Closing a closed historyPruner has no effect
+func (p *historyPruner) close() {
+	p.lock.Lock()
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+	p.lock.Unlock()
+	p.running.Wait()
+}
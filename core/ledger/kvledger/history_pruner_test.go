@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPrunedHistoryDB struct {
+	historydb.HistoryDB
+	proceed chan struct{}
+	pruned  uint64
+	err     error
+}
+
+func (db *mockPrunedHistoryDB) Prune(policy historydb.RetentionPolicy, blockStore blkstorage.BlockStore) (uint64, error) {
+	<-db.proceed
+	return db.pruned, db.err
+}
+
+func waitForPruning(t *testing.T, p *historyPruner) {
+	for i := 0; i < 500 && p.getStatus().Running; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, p.getStatus().Running, "pruning should have completed")
+}
+
+func TestHistoryPruner(t *testing.T) {
+	db := &mockPrunedHistoryDB{proceed: make(chan struct{}), pruned: 5}
+
+	// Scenario I: The history database can't be pruned without a retention policy
+	p := newHistoryPruner("ledger1", db, nil, historydb.RetentionPolicy{}, 0)
+	assert.EqualError(t, p.start(), "no retention policy is configured for the history database of ledger [ledger1]")
+	p.close()
+
+	// Scenario II: Only one pruning runs at a time, and its outcome is reported in the status
+	p = newHistoryPruner("ledger1", db, nil, historydb.RetentionPolicy{MaxVersions: 1}, 0)
+	defer p.close()
+	assert.NoError(t, p.start())
+	assert.True(t, p.getStatus().Running)
+	assert.EqualError(t, p.start(), "history database of ledger [ledger1] is already being pruned")
+	db.proceed <- struct{}{}
+	waitForPruning(t, p)
+	status := p.getStatus()
+	assert.Equal(t, uint64(5), status.LastPrunedRecords)
+	assert.Equal(t, uint64(5), status.TotalPrunedRecords)
+	assert.Empty(t, status.LastError)
+	assert.False(t, status.LastCompleted.Before(status.LastStarted))
+
+	// Scenario III: A failed pruning is reported in the status
+	db.pruned, db.err = 2, errors.New("leveldb: closed")
+	assert.NoError(t, p.start())
+	db.proceed <- struct{}{}
+	waitForPruning(t, p)
+	status = p.getStatus()
+	assert.Equal(t, uint64(2), status.LastPrunedRecords)
+	assert.Equal(t, uint64(7), status.TotalPrunedRecords)
+	assert.Equal(t, "leveldb: closed", status.LastError)
+}
+
+func TestHistoryPrunerPeriodic(t *testing.T) {
+	db := &mockPrunedHistoryDB{proceed: make(chan struct{})}
+	p := newHistoryPruner("ledger1", db, nil, historydb.RetentionPolicy{MaxAge: time.Hour}, 10*time.Millisecond)
+
+	// the history database is pruned without being requested
+	select {
+	case db.proceed <- struct{}{}:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the history database should have been pruned periodically")
+	}
+	waitForPruning(t, p)
+
+	// closing waits for the pruning that is running
+	for i := 0; i < 500 && !p.getStatus().Running; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	closed := make(chan struct{})
+	go func() {
+		p.close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		assert.Fail(t, "close should wait for the pruning that is running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	db.proceed <- struct{}{}
+	<-closed
+	assert.EqualError(t, p.start(), "ledger [ledger1] is closed")
+}
@@ -9,7 +9,9 @@ package kvledger
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 
@@ -20,14 +22,17 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/eventindex"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	leveldberrors "github.com/syndtr/goleveldb/leveldb/errors"
 )
 
 var logger = flogging.MustGetLogger("kvledger")
@@ -37,10 +42,17 @@ var logger = flogging.MustGetLogger("kvledger")
 type kvLedger struct {
 	ledgerID               string
 	blockStore             *ledgerstorage.Store
+	stateDB                privacyenabledstate.DB
 	txtmgmt                txmgr.TxMgr
 	historyDB              historydb.HistoryDB
+	historyPruner          *historyPruner
+	eventIndex             *eventindex.Index
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
+	// commitLock serializes commits and dry runs, which share
+	// the versions the state database caches while validating
+	commitLock sync.Mutex
+	metrics    *ledgerMetrics
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -49,6 +61,7 @@ func newKVLedger(
 	blockStore *ledgerstorage.Store,
 	versionedDB privacyenabledstate.DB,
 	historyDB historydb.HistoryDB,
+	eventIndex *eventindex.Index,
 	configHistoryMgr confighistory.Mgr,
 	stateListeners []ledger.StateListener,
 	bookkeeperProvider bookkeeping.Provider) (*kvLedger, error) {
@@ -56,8 +69,9 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	stateListeners = append(stateListeners, configHistoryMgr)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
-	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	// id store, blockstore, txmgr (state database), history database, event index
+	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, stateDB: versionedDB, historyDB: historyDB, eventIndex: eventIndex,
+		blockAPIsRWLock: &sync.RWMutex{}, metrics: newLedgerMetrics(ledgerID)}
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
@@ -72,11 +86,21 @@ func newKVLedger(
 		return nil, err
 	}
 	l.initBlockStore(btlPolicy)
-	//Recover both state DB and history DB if they are out of sync with block storage
+	//Recover the state DB, history DB and event index if they are out of sync with block storage
 	if err := l.recoverDBs(); err != nil {
+		if _, ok := err.(*dbInconsistencyError); ok {
+			return nil, err
+		}
 		panic(fmt.Errorf(`Error during state DB recovery:%s`, err))
 	}
 	l.configHistoryRetriever = configHistoryMgr.GetRetriever(ledgerID, l)
+	if ledgerconfig.IsHistoryDBEnabled() {
+		policy := historydb.RetentionPolicy{
+			MaxVersions: ledgerconfig.GetHistoryRetentionMaxVersions(),
+			MaxAge:      ledgerconfig.GetHistoryRetentionMaxAge(),
+		}
+		l.historyPruner = newHistoryPruner(ledgerID, historyDB, blockStore, policy, ledgerconfig.GetHistoryPruneInterval())
+	}
 	return l, nil
 }
 
@@ -91,7 +115,7 @@ func (l *kvLedger) initBlockStore(btlPolicy pvtdatapolicy.BTLPolicy) {
 	l.blockStore.Init(btlPolicy)
 }
 
-//Recover the state database and history database (if exist)
+//Recover the state database, history database and event index (if exist)
 //by recommitting last valid blocks
 func (l *kvLedger) recoverDBs() error {
 	logger.Debugf("Entering recoverDB()")
@@ -102,39 +126,46 @@ func (l *kvLedger) recoverDBs() error {
 		return nil
 	}
 	lastAvailableBlockNum := info.Height - 1
-	recoverables := []recoverable{l.txtmgmt, l.historyDB}
+	recoverables := []recoverable{l.txtmgmt, l.historyDB, l.eventIndex}
+	dbNames := []string{"state", "history", "event index"}
 	recoverers := []*recoverer{}
-	for _, recoverable := range recoverables {
+	for i, recoverable := range recoverables {
 		recoverFlag, firstBlockNum, err := recoverable.ShouldRecover(lastAvailableBlockNum)
 		if err != nil {
+			// leveldb verifies the checksums of the data it reads
+			if leveldberrors.IsCorrupted(err) {
+				return &dbInconsistencyError{l.ledgerID, dbNames[i], fmt.Sprintf("the database is corrupted: %s", err)}
+			}
 			return err
 		}
+		if recoverFlag && firstBlockNum > lastAvailableBlockNum+1 {
+			return &dbInconsistencyError{l.ledgerID, dbNames[i], fmt.Sprintf(
+				"the database is at block [%d], which is ahead of the block store at block [%d]", firstBlockNum-1, lastAvailableBlockNum)}
+		}
 		if recoverFlag {
 			recoverers = append(recoverers, &recoverer{firstBlockNum, recoverable})
 		}
 	}
-	if len(recoverers) == 0 {
-		return nil
-	}
-	if len(recoverers) == 1 {
-		return l.recommitLostBlocks(recoverers[0].firstBlockNum, lastAvailableBlockNum, recoverers[0].recoverable)
-	}
-
-	// both dbs need to be recovered
-	if recoverers[0].firstBlockNum > recoverers[1].firstBlockNum {
-		// swap (put the lagger db at 0 index)
-		recoverers[0], recoverers[1] = recoverers[1], recoverers[0]
-	}
-	if recoverers[0].firstBlockNum != recoverers[1].firstBlockNum {
-		// bring the lagger db equal to the other db
-		if err := l.recommitLostBlocks(recoverers[0].firstBlockNum, recoverers[1].firstBlockNum-1,
-			recoverers[0].recoverable); err != nil {
+	// put the most lagging db first, then bring the lagging dbs up to the next db one after
+	// the other, such that every block is retrieved once, and finally get all the dbs upto block storage
+	sort.SliceStable(recoverers, func(i, j int) bool {
+		return recoverers[i].firstBlockNum < recoverers[j].firstBlockNum
+	})
+	var lagging []recoverable
+	for i, r := range recoverers {
+		lagging = append(lagging, r.recoverable)
+		lastBlockNum := lastAvailableBlockNum
+		if i+1 < len(recoverers) {
+			if recoverers[i+1].firstBlockNum == r.firstBlockNum {
+				continue
+			}
+			lastBlockNum = recoverers[i+1].firstBlockNum - 1
+		}
+		if err := l.recommitLostBlocks(r.firstBlockNum, lastBlockNum, lagging...); err != nil {
 			return err
 		}
 	}
-	// get both the db upto block storage
-	return l.recommitLostBlocks(recoverers[1].firstBlockNum, lastAvailableBlockNum,
-		recoverers[0].recoverable, recoverers[1].recoverable)
+	return nil
 }
 
 //recommitLostBlocks retrieves blocks in specified range and commit the write set to either
@@ -142,6 +173,8 @@ func (l *kvLedger) recoverDBs() error {
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	logger.Infof("Recovering ledger [%s]: recommitting blocks [%d] to [%d]", l.ledgerID, firstBlockNum, lastBlockNum)
+	progressLogger := newRecoveryProgressLogger(l.ledgerID, firstBlockNum, lastBlockNum)
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
@@ -151,6 +184,7 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		progressLogger.blockRecommitted(blockNumber)
 	}
 	return nil
 }
@@ -247,42 +281,153 @@ func (l *kvLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error
 	return l.historyDB.NewHistoryQueryExecutor(l.blockStore)
 }
 
+// PruneHistory implements method in interface `ledger.HistoryPruner`
+func (l *kvLedger) PruneHistory() error {
+	if l.historyPruner == nil {
+		return errors.New("history database is not enabled")
+	}
+	return l.historyPruner.start()
+}
+
+// HistoryPruningStatus implements method in interface `ledger.HistoryPruner`
+func (l *kvLedger) HistoryPruningStatus() (*ledger.HistoryPruningStatus, error) {
+	if l.historyPruner == nil {
+		return nil, errors.New("history database is not enabled")
+	}
+	return l.historyPruner.getStatus(), nil
+}
+
+// QueryChaincodeEvents implements method in interface `ledger.ChaincodeEventsQuerier`
+func (l *kvLedger) QueryChaincodeEvents(query *ledger.ChaincodeEventsQuery) (commonledger.ResultsIterator, error) {
+	if !ledgerconfig.IsEventIndexEnabled() {
+		return nil, errors.New("chaincode event index is not enabled")
+	}
+	return l.eventIndex.Query(query)
+}
+
 // CommitWithPvtData commits the block and the corresponding pvt data in an atomic operation
 func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) error {
 	var err error
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
+	var phases commitPhases
+
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
 
 	logger.Debugf("Channel [%s]: Validating state for block [%d]", l.ledgerID, blockNo)
+	startTime := time.Now()
 	err = l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
 	if err != nil {
 		return err
 	}
+	phases.validation = time.Since(startTime)
 
 	logger.Debugf("Channel [%s]: Committing block [%d] to storage", l.ledgerID, blockNo)
 
 	l.blockAPIsRWLock.Lock()
 	defer l.blockAPIsRWLock.Unlock()
+	startTime = time.Now()
 	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
 		return err
 	}
+	phases.blockWrite = time.Since(startTime)
 	logger.Infof("Channel [%s]: Committed block [%d] with %d transaction(s)", l.ledgerID, block.Header.Number, len(block.Data.Data))
 
 	logger.Debugf("Channel [%s]: Committing block [%d] transactions to state database", l.ledgerID, blockNo)
+	startTime = time.Now()
 	if err = l.txtmgmt.Commit(); err != nil {
 		panic(fmt.Errorf(`Error during commit to txmgr:%s`, err))
 	}
+	phases.stateUpdate = time.Since(startTime)
 
 	// History database could be written in parallel with state and/or async as a future optimization
 	if ledgerconfig.IsHistoryDBEnabled() {
 		logger.Debugf("Channel [%s]: Committing block [%d] transactions to history database", l.ledgerID, blockNo)
+		startTime = time.Now()
 		if err := l.historyDB.Commit(block); err != nil {
 			panic(fmt.Errorf(`Error during commit to history db:%s`, err))
 		}
+		phases.history = time.Since(startTime)
+	}
+	if ledgerconfig.IsEventIndexEnabled() {
+		logger.Debugf("Channel [%s]: Indexing chaincode events of block [%d]", l.ledgerID, blockNo)
+		if err := l.eventIndex.Commit(block); err != nil {
+			panic(fmt.Errorf(`Error during commit to event index:%s`, err))
+		}
 	}
+	// the block is committed already, hence the keys that fail to be purged are purged with the next block instead
+	if err := l.purgeScheduledPvtData(blockNo); err != nil {
+		logger.Errorf("Channel [%s]: Error purging the private data keys scheduled for purging with block [%d]: %s", l.ledgerID, blockNo, err)
+	}
+	l.metrics.reportCommit(phases)
+	l.metrics.reportStorage(l.blockStore, l.stateDB)
 	return nil
 }
 
+// DryRunCommit implements method in interface `ledger.DryRunCommitter`
+func (l *kvLedger) DryRunCommit(pvtdataAndBlock *ledger.BlockAndPvtData) error {
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	logger.Debugf("Channel [%s]: Validating state for block [%d] without committing it", l.ledgerID, pvtdataAndBlock.Block.Header.Number)
+	return l.txtmgmt.ValidateOnly(pvtdataAndBlock)
+}
+
+// PurgePrivateDataKeys implements method in interface `ledger.PrivateDataPurger`
+func (l *kvLedger) PurgePrivateDataKeys(ns, coll string, keys []string) error {
+	if len(keys) == 0 {
+		return errors.New("no private data keys to purge")
+	}
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	logger.Infof("Channel [%s]: Purging [%d] private data keys of collection [%s:%s]", l.ledgerID, len(keys), ns, coll)
+	return l.purgePvtDataKeys(&pvtdatastorage.KeysToPurge{Ns: ns, Coll: coll, Keys: keys})
+}
+
+// SchedulePrivateDataKeysPurge implements method in interface `ledger.PrivateDataPurger`
+func (l *kvLedger) SchedulePrivateDataKeysPurge(ns, coll string, keys []string, blockToLive uint64) error {
+	if len(keys) == 0 {
+		return errors.New("no private data keys to purge")
+	}
+	if blockToLive == 0 {
+		return errors.New("the blockToLive of the private data keys must be greater than zero")
+	}
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	purgeAtBlk := bcInfo.Height - 1 + blockToLive
+	logger.Infof("Channel [%s]: Scheduling [%d] private data keys of collection [%s:%s] for purging with block [%d]", l.ledgerID, len(keys), ns, coll, purgeAtBlk)
+	return l.blockStore.SchedulePvtDataKeysPurge(&pvtdatastorage.KeysToPurge{Ns: ns, Coll: coll, Keys: keys}, purgeAtBlk)
+}
+
+// purgeScheduledPvtData purges the private data keys that are scheduled for purging with the commit of the given block,
+// including the ones scheduled for earlier blocks that failed to be purged
+func (l *kvLedger) purgeScheduledPvtData(blockNum uint64) error {
+	scheduledPurges, err := l.blockStore.GetScheduledPvtDataKeysPurges(blockNum)
+	if err != nil || len(scheduledPurges) == 0 {
+		return err
+	}
+	for _, toPurge := range scheduledPurges {
+		logger.Infof("Channel [%s]: Purging [%d] private data keys of collection [%s:%s] as scheduled", l.ledgerID, len(toPurge.Keys), toPurge.Ns, toPurge.Coll)
+		if err := l.purgePvtDataKeys(toPurge); err != nil {
+			return err
+		}
+	}
+	return l.blockStore.ClearScheduledPvtDataKeysPurges(blockNum)
+}
+
+// purgePvtDataKeys purges the keys from the state and then from the private data store.
+// Purging keys that are purged already is harmless, hence a failed purge can be retried
+func (l *kvLedger) purgePvtDataKeys(toPurge *pvtdatastorage.KeysToPurge) error {
+	if err := l.txtmgmt.PurgePvtData(toPurge.Ns, toPurge.Coll, toPurge.Keys); err != nil {
+		return err
+	}
+	return l.blockStore.PurgePvtDataKeys(toPurge)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (l *kvLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -319,6 +464,9 @@ func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, e
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	if l.historyPruner != nil {
+		l.historyPruner.close()
+	}
 	l.blockStore.Shutdown()
 	l.txtmgmt.Shutdown()
 }
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/eventindex"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
@@ -45,6 +47,7 @@ type Provider struct {
 	ledgerStoreProvider *ledgerstorage.Provider
 	vdbProvider         privacyenabledstate.DBProvider
 	historydbProvider   historydb.HistoryDBProvider
+	eventIndexProvider  *eventindex.Provider
 	configHistoryMgr    confighistory.Mgr
 	stateListeners      []ledger.StateListener
 	bookkeepingProvider bookkeeping.Provider
@@ -69,11 +72,13 @@ func NewProvider() (ledger.PeerLedgerProvider, error) {
 
 	// Initialize the history database (index for history of values by key)
 	historydbProvider := historyleveldb.NewHistoryDBProvider()
+	// Initialize the index of chaincode events
+	eventIndexProvider := eventindex.NewProvider()
 	bookkeepingProvider := bookkeeping.NewProvider()
 	// Initialize config history mgr
 	configHistoryMgr := confighistory.NewMgr()
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider, configHistoryMgr, nil, bookkeepingProvider}
+	provider := &Provider{idStore, ledgerStoreProvider, vdbProvider, historydbProvider, eventIndexProvider, configHistoryMgr, nil, bookkeepingProvider}
 	provider.recoverUnderConstructionLedger()
 	return provider, nil
 }
@@ -120,6 +125,51 @@ func (provider *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger,
 	return lgr, nil
 }
 
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// Like function 'Create', this function sets the under construction flag before importing the snapshot.
+// Bootstrapping the block store is the last step of the import, hence if a crash happens after that step,
+// the 'recoverUnderConstructionLedger' function adds the ledger to the created ledgers list
+func (provider *Provider) CreateFromSnapshot(configBlock *common.Block, snapshot io.Reader) (ledger.PeerLedger, error) {
+	ledgerID, err := utils.GetChainIDFromBlock(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	sr, header, err := newSnapshotReader(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if header.info.LedgerID != ledgerID {
+		return nil, fmt.Errorf("snapshot is of ledger [%s], not [%s]", header.info.LedgerID, ledgerID)
+	}
+	if configBlock.Header.Number > header.info.LastBlockNum {
+		return nil, fmt.Errorf("config block [%d] is after the last block [%d] of the snapshot",
+			configBlock.Header.Number, header.info.LastBlockNum)
+	}
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, err
+	}
+	if err = provider.importSnapshot(sr, header, configBlock); err != nil {
+		logger.Errorf("Error in importing snapshot of ledger [%s]. Unsetting under construction flag. Err: %s", ledgerID, err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, nil), "Error while marking ledger as created")
+	logger.Infof("Created ledger [%s] from snapshot as of block [%d]", ledgerID, header.info.LastBlockNum)
+	return lgr, nil
+}
+
 // Open implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	logger.Debugf("Open() opening kvledger: %s", ledgerID)
@@ -154,8 +204,9 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	}
 
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying data stores
-	// (id store, blockstore, state database, history database)
-	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, provider.configHistoryMgr, provider.stateListeners, provider.bookkeepingProvider)
+	// (id store, blockstore, state database, history database, event index)
+	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, provider.eventIndexProvider.GetIndex(ledgerID),
+		provider.configHistoryMgr, provider.stateListeners, provider.bookkeepingProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -178,14 +229,16 @@ func (provider *Provider) Close() {
 	provider.ledgerStoreProvider.Close()
 	provider.vdbProvider.Close()
 	provider.historydbProvider.Close()
+	provider.eventIndexProvider.Close()
 	provider.bookkeepingProvider.Close()
 	provider.configHistoryMgr.Close()
 }
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
-// state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// state. Recovery checks if the ledger was created and the genesis block was committed successfully (or the block store
+// was bootstrapped from a snapshot) then it completes the last step of adding the ledger id to the list of created ledgers.
+// Else, it clears the under construction flag
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -199,14 +252,19 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
+	bootstrapInfo, err := ledger.(*kvLedger).blockStore.GetBootstrapInfo()
+	panicOnErr(err, "Error while getting bootstrap info for the under construction ledger [%s]", ledgerID)
 	ledger.Close()
 
-	switch bcInfo.Height {
-	case 0:
+	switch {
+	case bcInfo.Height == 0:
 		logger.Infof("Genesis block was not committed. Hence, the peer ledger not created. unsetting the under construction flag")
 		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
 		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
-	case 1:
+	case bootstrapInfo != nil:
+		logger.Infof("Block store was bootstrapped from a snapshot. Hence, marking the peer ledger as created")
+		panicOnErr(provider.idStore.createLedgerID(ledgerID, nil), "Error while adding ledgerID [%s] to created list", ledgerID)
+	case bcInfo.Height == 1:
 		logger.Infof("Genesis block was committed. Hence, marking the peer ledger as created")
 		genesisBlock, err := ledger.GetBlockByNumber(0)
 		panicOnErr(err, "Error while retrieving genesis block from blockchain for ledger [%s]", ledgerID)
@@ -267,12 +325,16 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 	return string(val), nil
 }
 
+// createLedgerID adds the ledger to the created ledgers list. The genesis block is nil
+// for a ledger created from a snapshot
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	key := s.encodeLedgerKey(ledgerID)
 	var val []byte
 	var err error
-	if val, err = proto.Marshal(gb); err != nil {
-		return err
+	if gb != nil {
+		if val, err = proto.Marshal(gb); err != nil {
+			return err
+		}
 	}
 	if val, err = s.db.Get(key); err != nil {
 		return err
@@ -303,6 +365,7 @@ func (s *idStore) getAllLedgerIds() ([]string, error) {
 	itr.First()
 	for itr.Valid() {
 		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
+			itr.Next()
 			continue
 		}
 		id := string(s.decodeLedgerID(itr.Key()))
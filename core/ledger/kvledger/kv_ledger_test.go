@@ -8,6 +8,7 @@ package kvledger
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"testing"
 
@@ -17,11 +18,15 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
+	ptestutils "github.com/hyperledger/fabric/protos/testutils"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -120,6 +125,183 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	testutil.AssertEquals(t, validCode, peer.TxValidationCode_VALID)
 }
 
+func TestKVLedgerDryRunCommit(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimBytes, _ := simRes.GetPubSimulationBytes()
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}), "")
+
+	// both transactions read key1, so the second one conflicts with the first one
+	var simResults [][]byte
+	for _, value := range []string{"value2", "value3"} {
+		simulator, _ = ledger.NewTxSimulator(util.GenerateUUID())
+		simulator.GetState("ns1", "key1")
+		simulator.SetState("ns1", "key1", []byte(value))
+		simulator.Done()
+		simRes, _ = simulator.GetTxSimulationResults()
+		pubSimBytes, _ = simRes.GetPubSimulationBytes()
+		simResults = append(simResults, pubSimBytes)
+	}
+	block2 := bg.NextBlock(simResults)
+
+	dryRunCommitter := ledger.(lgr.DryRunCommitter)
+	for i := 0; i < 2; i++ {
+		dryRunBlock := proto.Clone(block2).(*common.Block)
+		testutil.AssertNoError(t, dryRunCommitter.DryRunCommit(&lgr.BlockAndPvtData{Block: dryRunBlock}), "")
+		flags := lutils.TxValidationFlags(dryRunBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		testutil.AssertEquals(t, flags.Flag(0), peer.TxValidationCode_VALID)
+		testutil.AssertEquals(t, flags.Flag(1), peer.TxValidationCode_MVCC_READ_CONFLICT)
+	}
+
+	// the dry runs committed neither the block nor the state
+	bcInfo, _ := ledger.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(2))
+	qe, _ := ledger.NewQueryExecutor()
+	value, _ := qe.GetState("ns1", "key1")
+	qe.Done()
+	testutil.AssertEquals(t, value, []byte("value1"))
+
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block2}), "")
+	qe, _ = ledger.NewQueryExecutor()
+	value, _ = qe.GetState("ns1", "key1")
+	qe.Done()
+	testutil.AssertEquals(t, value, []byte("value2"))
+}
+
+func TestKVLedgerChaincodeEvents(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.eventIndex.enabled", true)
+	defer viper.Set("ledger.eventIndex.enabled", false)
+	provider, _ := NewProvider()
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+
+	nextBlockWithEvent := func(eventName string) *common.Block {
+		txid := util.GenerateUUID()
+		simulator, _ := ledger.NewTxSimulator(txid)
+		simulator.SetState("ns1", "key1", []byte(eventName))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		events, _ := proto.Marshal(&peer.ChaincodeEvent{ChaincodeId: "ns1", TxId: txid, EventName: eventName})
+		env, _, err := ptestutils.ConstructUnsignedTxEnv(util.GetTestChainID(), &peer.ChaincodeID{Name: "ns1", Version: "v1"},
+			nil, pubSimBytes, txid, events, nil)
+		testutil.AssertNoError(t, err, "")
+		bcInfo, _ := ledger.GetBlockchainInfo()
+		return testutil.NewBlock([]*common.Envelope{env}, bcInfo.Height, bcInfo.CurrentBlockHash)
+	}
+	queryEventNames := func(l lgr.PeerLedger) []string {
+		itr, err := l.(lgr.ChaincodeEventsQuerier).QueryChaincodeEvents(&lgr.ChaincodeEventsQuery{ChaincodeName: "ns1", EndBlock: math.MaxUint64})
+		testutil.AssertNoError(t, err, "")
+		defer itr.Close()
+		var names []string
+		for {
+			res, err := itr.Next()
+			testutil.AssertNoError(t, err, "")
+			if res == nil {
+				return names
+			}
+			names = append(names, res.(*peer.ChaincodeEventRecord).Event.EventName)
+		}
+	}
+
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: nextBlockWithEvent("event1")}), "")
+	assert.Equal(t, []string{"event1"}, queryEventNames(ledger))
+
+	// the blocks committed while the index is disabled are indexed when the ledger is opened with the index enabled
+	viper.Set("ledger.eventIndex.enabled", false)
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: nextBlockWithEvent("event2")}), "")
+	_, err := ledger.(lgr.ChaincodeEventsQuerier).QueryChaincodeEvents(&lgr.ChaincodeEventsQuery{ChaincodeName: "ns1"})
+	assert.EqualError(t, err, "chaincode event index is not enabled")
+	ledger.Close()
+
+	viper.Set("ledger.eventIndex.enabled", true)
+	ledger, _ = provider.Open("testLedger")
+	defer ledger.Close()
+	assert.Equal(t, []string{"event1", "event2"}, queryEventNames(ledger))
+}
+
+func TestKVLedgerPurgePrivateDataKeys(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+	purger := ledger.(lgr.PrivateDataPurger)
+
+	collectionConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "simulationForCollConfig", "ns", map[string]uint64{"coll": 0})
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(collectionConfigBlk), "")
+	testutil.AssertNoError(t, ledger.CommitWithPvtData(prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1"},
+		map[string]string{"key1": "pvtValue1", "key2": "pvtValue2", "key3": "pvtValue3"})), "")
+
+	pvtDataAvailable := func(key string) bool {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		defer simulator.Done()
+		val, err := simulator.GetPrivateData("ns", "coll", key)
+		if _, ok := err.(*txmgr.ErrPvtdataNotAvailable); ok {
+			return false
+		}
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertNotNil(t, val)
+		return true
+	}
+	storedPvtKeys := func() []string {
+		pvtdata, err := ledger.GetPvtDataByNum(2, nil)
+		testutil.AssertNoError(t, err, "")
+		kvRWSet := &kvrwset.KVRWSet{}
+		testutil.AssertNoError(t, proto.Unmarshal(pvtdata[0].WriteSet.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, kvRWSet), "")
+		var keys []string
+		for _, write := range kvRWSet.Writes {
+			keys = append(keys, write.Key)
+		}
+		return keys
+	}
+
+	// the hash of a purged key remains, hence reading the key fails rather than finding no value
+	testutil.AssertNoError(t, purger.PurgePrivateDataKeys("ns", "coll", []string{"key1"}), "")
+	assert.False(t, pvtDataAvailable("key1"))
+	assert.True(t, pvtDataAvailable("key2"))
+	assert.Equal(t, []string{"key2", "key3"}, storedPvtKeys())
+
+	// key2 is purged with the commit of block 4
+	testutil.AssertNoError(t, purger.SchedulePrivateDataKeysPurge("ns", "coll", []string{"key2"}, 2), "")
+	commitPublicBlock := func(txid string) {
+		simulator, _ := ledger.NewTxSimulator(txid)
+		simulator.SetState("ns", "key1", []byte(txid))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		testutil.AssertNoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}), "")
+	}
+	commitPublicBlock("SimulateForBlk3")
+	assert.True(t, pvtDataAvailable("key2"))
+	commitPublicBlock("SimulateForBlk4")
+	assert.False(t, pvtDataAvailable("key2"))
+	assert.True(t, pvtDataAvailable("key3"))
+	assert.Equal(t, []string{"key3"}, storedPvtKeys())
+
+	assert.EqualError(t, purger.PurgePrivateDataKeys("ns", "coll", nil), "no private data keys to purge")
+	assert.EqualError(t, purger.SchedulePrivateDataKeysPurge("ns", "coll", []string{"key3"}, 0),
+		"the blockToLive of the private data keys must be greater than zero")
+}
+
 func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	t.Skip()
 	env := newTestEnv(t)
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// commitDurationBuckets are the upper bounds (in seconds) of the buckets of the commit duration histograms
+var commitDurationBuckets = []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+
+// storageReportInterval is the minimum interval between two reports of the storage occupied by a ledger,
+// which is estimated after the commit of a block
+const storageReportInterval = 10 * time.Second
+
+// commitPhases are the durations of the phases of the commit of a block
+type commitPhases struct {
+	validation  time.Duration
+	blockWrite  time.Duration
+	stateUpdate time.Duration
+	history     time.Duration
+}
+
+// sizeEstimator estimates the number of bytes a store occupies in the file system
+type sizeEstimator interface {
+	ApproximateSize() (int64, error)
+}
+
+// ledgerMetrics reports the durations of the commits of the blocks of a channel
+// and the storage that its block store and state database occupy
+type ledgerMetrics struct {
+	ledgerID                string
+	commitDuration          metrics.Histogram
+	validationDuration      metrics.Histogram
+	blockWriteDuration      metrics.Histogram
+	stateUpdateDuration     metrics.Histogram
+	historyDuration         metrics.Histogram
+	blockStorageBytes       metrics.Gauge
+	stateStorageBytes       metrics.Gauge
+	lock                    sync.Mutex
+	lastStorageReport       time.Time
+	stateSizeNotEstimatable bool
+}
+
+// newLedgerMetrics creates the metrics of the ledger of the given channel,
+// or returns nil if metrics aren't initialized
+func newLedgerMetrics(ledgerID string) *ledgerMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("ledger").Tagged(map[string]string{"channel": ledgerID})
+	phaseDuration := func(phase string) metrics.Histogram {
+		return scope.Tagged(map[string]string{"phase": phase}).Histogram("commit_phase_duration_seconds", commitDurationBuckets)
+	}
+	return &ledgerMetrics{
+		ledgerID:            ledgerID,
+		commitDuration:      scope.Histogram("commit_duration_seconds", commitDurationBuckets),
+		validationDuration:  phaseDuration("validation"),
+		blockWriteDuration:  phaseDuration("block_write"),
+		stateUpdateDuration: phaseDuration("state_update"),
+		historyDuration:     phaseDuration("history"),
+		blockStorageBytes:   scope.Gauge("block_storage_bytes"),
+		stateStorageBytes:   scope.Gauge("state_storage_bytes"),
+	}
+}
+
+// reportCommit reports the durations of the phases of the commit of a block
+func (m *ledgerMetrics) reportCommit(phases commitPhases) {
+	if m == nil {
+		return
+	}
+	m.validationDuration.RecordValue(phases.validation.Seconds())
+	m.blockWriteDuration.RecordValue(phases.blockWrite.Seconds())
+	m.stateUpdateDuration.RecordValue(phases.stateUpdate.Seconds())
+	if phases.history > 0 {
+		m.historyDuration.RecordValue(phases.history.Seconds())
+	}
+	total := phases.validation + phases.blockWrite + phases.stateUpdate + phases.history
+	m.commitDuration.RecordValue(total.Seconds())
+}
+
+// reportStorage reports the storage occupied by the block store and the state database,
+// unless it was reported less than storageReportInterval ago
+func (m *ledgerMetrics) reportStorage(blockStore sizeEstimator, stateDB sizeEstimator) {
+	if m == nil {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if time.Since(m.lastStorageReport) < storageReportInterval {
+		return
+	}
+	m.lastStorageReport = time.Now()
+
+	if size, err := blockStore.ApproximateSize(); err != nil {
+		logger.Warningf("Channel [%s]: Failed estimating the size of the block store: %s", m.ledgerID, err)
+	} else {
+		m.blockStorageBytes.Update(float64(size))
+	}
+	if m.stateSizeNotEstimatable {
+		return
+	}
+	if size, err := stateDB.ApproximateSize(); err != nil {
+		// The size of a state database that doesn't support it isn't estimated again
+		logger.Infof("Channel [%s]: Not reporting the size of the state database: %s", m.ledgerID, err)
+		m.stateSizeNotEstimatable = true
+	} else {
+		m.stateStorageBytes.Update(float64(size))
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHistogram struct {
+	values []float64
+}
+
+func (h *recordingHistogram) RecordValue(value float64) {
+	h.values = append(h.values, value)
+}
+
+type recordingGauge struct {
+	values []float64
+}
+
+func (g *recordingGauge) Update(value float64) {
+	g.values = append(g.values, value)
+}
+
+type mockSizeEstimator struct {
+	size int64
+	err  error
+}
+
+func (e *mockSizeEstimator) ApproximateSize() (int64, error) {
+	return e.size, e.err
+}
+
+func newRecordingLedgerMetrics() *ledgerMetrics {
+	return &ledgerMetrics{
+		ledgerID:            "testLedger",
+		commitDuration:      &recordingHistogram{},
+		validationDuration:  &recordingHistogram{},
+		blockWriteDuration:  &recordingHistogram{},
+		stateUpdateDuration: &recordingHistogram{},
+		historyDuration:     &recordingHistogram{},
+		blockStorageBytes:   &recordingGauge{},
+		stateStorageBytes:   &recordingGauge{},
+	}
+}
+
+func TestLedgerMetricsReportCommit(t *testing.T) {
+	m := newRecordingLedgerMetrics()
+
+	// Scenario I: The durations of all the phases and their sum are reported
+	m.reportCommit(commitPhases{
+		validation:  time.Second,
+		blockWrite:  2 * time.Second,
+		stateUpdate: 3 * time.Second,
+		history:     4 * time.Second,
+	})
+	assert.Equal(t, []float64{1}, m.validationDuration.(*recordingHistogram).values)
+	assert.Equal(t, []float64{2}, m.blockWriteDuration.(*recordingHistogram).values)
+	assert.Equal(t, []float64{3}, m.stateUpdateDuration.(*recordingHistogram).values)
+	assert.Equal(t, []float64{4}, m.historyDuration.(*recordingHistogram).values)
+	assert.Equal(t, []float64{10}, m.commitDuration.(*recordingHistogram).values)
+
+	// Scenario II: The duration of the history phase isn't reported when the history database is disabled
+	m.reportCommit(commitPhases{validation: time.Second, blockWrite: time.Second, stateUpdate: time.Second})
+	assert.Equal(t, []float64{4}, m.historyDuration.(*recordingHistogram).values)
+	assert.Equal(t, []float64{10, 3}, m.commitDuration.(*recordingHistogram).values)
+
+	// Scenario III: Nothing is reported when metrics aren't initialized
+	var noMetrics *ledgerMetrics
+	noMetrics.reportCommit(commitPhases{validation: time.Second})
+	noMetrics.reportStorage(&mockSizeEstimator{}, &mockSizeEstimator{})
+}
+
+func TestLedgerMetricsReportStorage(t *testing.T) {
+	m := newRecordingLedgerMetrics()
+	blockStore := &mockSizeEstimator{size: 100}
+	stateDB := &mockSizeEstimator{size: 200}
+
+	// Scenario I: The sizes of the block store and the state database are reported
+	m.reportStorage(blockStore, stateDB)
+	assert.Equal(t, []float64{100}, m.blockStorageBytes.(*recordingGauge).values)
+	assert.Equal(t, []float64{200}, m.stateStorageBytes.(*recordingGauge).values)
+
+	// Scenario II: The sizes aren't estimated again within the report interval
+	m.reportStorage(blockStore, stateDB)
+	assert.Equal(t, []float64{100}, m.blockStorageBytes.(*recordingGauge).values)
+
+	// Scenario III: The size of a state database that can't estimate it is no longer reported,
+	// while the size of the block store still is
+	m.lastStorageReport = time.Time{}
+	stateDB.err = errors.New("Estimating the size is not supported by the state database")
+	m.reportStorage(blockStore, stateDB)
+	assert.True(t, m.stateSizeNotEstimatable)
+	m.lastStorageReport = time.Time{}
+	stateDB.err = nil
+	blockStore.size = 150
+	m.reportStorage(blockStore, stateDB)
+	assert.Equal(t, []float64{100, 100, 150}, m.blockStorageBytes.(*recordingGauge).values)
+	assert.Equal(t, []float64{200}, m.stateStorageBytes.(*recordingGauge).values)
+}
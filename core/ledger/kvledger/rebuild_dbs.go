@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+)
+
+// RebuildDBs drops the state, history, event index, config history and bookkeeping dbs of all the ledgers,
+// which are rebuilt from the block stores when the peer starts next time. This recovers the ledgers whose dbs are
+// found to be inconsistent with their block stores at the start of the peer.
+// As function 'RollbackKVLedger', this function is expected to be invoked while the peer is offline
+func RebuildDBs() error {
+	fileLock, err := acquireFileLock()
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	idStore.close()
+	if err != nil {
+		return err
+	}
+
+	ledgerStoreProvider := ledgerstorage.NewProvider()
+	defer ledgerStoreProvider.Close()
+	if _, err := retrieveRebuildableLedgerHeights(ledgerStoreProvider, ledgerIDs); err != nil {
+		return err
+	}
+	logger.Infof("Dropping the dbs of ledgers %s, which are rebuilt from the block stores when the peer starts", ledgerIDs)
+	return dropDBs()
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildDBs(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	populateTestLedger(t, "testLedger", 5)
+
+	// the block store is rolled back behind the dbs
+	ledgerStoreProvider := ledgerstorage.NewProvider()
+	assert.NoError(t, ledgerStoreProvider.Rollback("testLedger", 2))
+	ledgerStoreProvider.Close()
+
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	_, err = provider.Open("testLedger")
+	assert.Error(t, err)
+	assert.IsType(t, &dbInconsistencyError{}, err)
+	assert.Contains(t, err.Error(), "the state database of ledger [testLedger] is inconsistent with the block store, "+
+		"the database is at block [5], which is ahead of the block store at block [2]")
+	assert.Contains(t, err.Error(), "peer node rebuild-dbs")
+	provider.Close()
+
+	assert.NoError(t, RebuildDBs())
+
+	provider, err = NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	ledger, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+	assertKey1Value(t, ledger, "value2")
+	assertKey1HistoryLen(t, ledger, 2)
+}
+
+func TestRecoveryProgressLogger(t *testing.T) {
+	p := newRecoveryProgressLogger("testLedger", 10, 29)
+	var loggedPercents []uint64
+	for blockNum := uint64(10); blockNum <= 29; blockNum++ {
+		loggedPercent := p.loggedPercent
+		p.blockRecommitted(blockNum)
+		if p.loggedPercent != loggedPercent {
+			loggedPercents = append(loggedPercents, p.loggedPercent)
+		}
+	}
+	assert.Equal(t, []uint64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, loggedPercents)
+
+	p = newRecoveryProgressLogger("testLedger", 0, 2)
+	loggedPercents = nil
+	for blockNum := uint64(0); blockNum <= 2; blockNum++ {
+		p.blockRecommitted(blockNum)
+		loggedPercents = append(loggedPercents, p.loggedPercent)
+	}
+	assert.Equal(t, []uint64{33, 66, 100}, loggedPercents)
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package kvledger
 
-import "github.com/hyperledger/fabric/core/ledger"
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger"
+)
 
 type recoverable interface {
 	// ShouldRecover return whether recovery is need.
@@ -31,3 +35,39 @@ type recoverer struct {
 	firstBlockNum uint64
 	recoverable   recoverable
 }
+
+// dbInconsistencyError is returned when a db that is derived from the block store can't be recovered by
+// recommitting blocks, because it is ahead of the block store or it is corrupted
+type dbInconsistencyError struct {
+	ledgerID string
+	dbName   string
+	reason   string
+}
+
+func (e *dbInconsistencyError) Error() string {
+	return fmt.Sprintf("the %s database of ledger [%s] is inconsistent with the block store, %s. "+
+		"Stop the peer node and rebuild the databases from the block stores with the command 'peer node rebuild-dbs'",
+		e.dbName, e.ledgerID, e.reason)
+}
+
+// recoveryProgressLogger logs the progress of recommitting a range of blocks, whenever another
+// ten percent of the blocks is recommitted
+type recoveryProgressLogger struct {
+	ledgerID      string
+	firstBlockNum uint64
+	numBlocks     uint64
+	loggedPercent uint64
+}
+
+func newRecoveryProgressLogger(ledgerID string, firstBlockNum, lastBlockNum uint64) *recoveryProgressLogger {
+	return &recoveryProgressLogger{ledgerID: ledgerID, firstBlockNum: firstBlockNum, numBlocks: lastBlockNum - firstBlockNum + 1}
+}
+
+func (p *recoveryProgressLogger) blockRecommitted(blockNum uint64) {
+	percent := (blockNum - p.firstBlockNum + 1) * 100 / p.numBlocks
+	if percent/10 == p.loggedPercent/10 {
+		return
+	}
+	p.loggedPercent = percent
+	logger.Infof("Recovering ledger [%s]: recommitted block [%d], %d%% done", p.ledgerID, blockNum, percent)
+}
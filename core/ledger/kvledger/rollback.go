@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+)
+
+// RollbackKVLedger removes the blocks after the given block number from the ledger with given ledgerID,
+// along with their pvt data. The state, history, event index, config history and bookkeeping dbs of all the
+// ledgers are dropped, and are rebuilt from the block stores when the peer starts next time.
+// This function is expected to be invoked while the peer is offline, which is enforced via the ledger file lock
+func RollbackKVLedger(ledgerID string, blockNum uint64) error {
+	fileLock, err := acquireFileLock()
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	if err != nil {
+		idStore.close()
+		return err
+	}
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	idStore.close()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("ledger [%s] does not exist", ledgerID)
+	}
+
+	ledgerStoreProvider := ledgerstorage.NewProvider()
+	defer ledgerStoreProvider.Close()
+	heights, err := retrieveRebuildableLedgerHeights(ledgerStoreProvider, ledgerIDs)
+	if err != nil {
+		return err
+	}
+	if blockNum+1 >= heights[ledgerID] {
+		return fmt.Errorf("target block number [%d] should be less than the biggest block number [%d] of ledger [%s]",
+			blockNum, heights[ledgerID]-1, ledgerID)
+	}
+
+	if err := dropDBs(); err != nil {
+		return err
+	}
+	logger.Infof("Rolling back ledger [%s] to block [%d]", ledgerID, blockNum)
+	return ledgerStoreProvider.Rollback(ledgerID, blockNum)
+}
+
+// ResetAllKVLedgers rolls back all the ledgers to their genesis blocks. As with function 'RollbackKVLedger',
+// the other dbs are rebuilt from the block stores when the peer starts next time
+func ResetAllKVLedgers() error {
+	fileLock, err := acquireFileLock()
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	idStore.close()
+	if err != nil {
+		return err
+	}
+
+	ledgerStoreProvider := ledgerstorage.NewProvider()
+	defer ledgerStoreProvider.Close()
+	heights, err := retrieveRebuildableLedgerHeights(ledgerStoreProvider, ledgerIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := dropDBs(); err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		if heights[ledgerID] <= 1 {
+			continue
+		}
+		logger.Infof("Resetting ledger [%s] to the genesis block", ledgerID)
+		if err := ledgerStoreProvider.Rollback(ledgerID, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// acquireFileLock acquires the lock on the ledger data, which a running peer holds
+func acquireFileLock() (*leveldbhelper.FileLock, error) {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, fmt.Errorf("the ledger data is in use, stop the peer node or wait for the running command to complete: %s", err)
+	}
+	return fileLock, nil
+}
+
+// retrieveRebuildableLedgerHeights returns the heights of the block stores of given ledgers. As the dbs are dropped
+// for all the ledgers at once, this function returns an error if any of the ledgers was created from a snapshot,
+// because the dbs of such a ledger can't be rebuilt from its block store
+func retrieveRebuildableLedgerHeights(ledgerStoreProvider *ledgerstorage.Provider, ledgerIDs []string) (map[string]uint64, error) {
+	heights := make(map[string]uint64)
+	for _, ledgerID := range ledgerIDs {
+		store, err := ledgerStoreProvider.Open(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		bootstrapInfo, err := store.GetBootstrapInfo()
+		if err != nil {
+			store.Shutdown()
+			return nil, err
+		}
+		bcInfo, err := store.GetBlockchainInfo()
+		store.Shutdown()
+		if err != nil {
+			return nil, err
+		}
+		if bootstrapInfo != nil {
+			return nil, fmt.Errorf("ledger [%s] was created from a snapshot, its dbs can't be rebuilt from its blocks", ledgerID)
+		}
+		heights[ledgerID] = bcInfo.Height
+	}
+	return heights, nil
+}
+
+// dropDBs drops the dbs that are derived from the block stores. The state db is dropped first, as the config
+// history and bookkeeping dbs are rebuilt only along with the state db
+func dropDBs() error {
+	if ledgerconfig.IsCouchDBEnabled() {
+		if err := statecouchdb.DropApplicationDBs(); err != nil {
+			return err
+		}
+	} else if err := removeDBDir(ledgerconfig.GetStateLevelDBPath()); err != nil {
+		return err
+	}
+	for _, path := range []string{
+		ledgerconfig.GetHistoryLevelDBPath(),
+		ledgerconfig.GetEventIndexLevelDBPath(),
+		ledgerconfig.GetConfigHistoryPath(),
+		ledgerconfig.GetInternalBookkeeperPath(),
+	} {
+		if err := removeDBDir(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeDBDir(path string) error {
+	logger.Infof("Dropping db at [%s]", path)
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("error dropping db at [%s]: %s", path, err)
+	}
+	return nil
+}
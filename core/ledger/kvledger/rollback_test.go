@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackKVLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	blocks := populateTestLedger(t, "testLedger", 5)
+
+	assert.NoError(t, RollbackKVLedger("testLedger", 2))
+
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	ledger, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+	assert.Equal(t, blocks[2].Header.Hash(), bcInfo.CurrentBlockHash)
+	// the state and the history are rebuilt from the remaining blocks
+	assertKey1Value(t, ledger, "value2")
+	assertKey1HistoryLen(t, ledger, 2)
+
+	// the removed blocks can be committed again
+	for _, block := range blocks[3:] {
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+	}
+	assertKey1Value(t, ledger, "value5")
+	assertKey1HistoryLen(t, ledger, 5)
+}
+
+func TestResetAllKVLedgers(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	populateTestLedger(t, "testLedger1", 3)
+	populateTestLedger(t, "testLedger2", 0)
+
+	assert.NoError(t, ResetAllKVLedgers())
+
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	for _, ledgerID := range []string{"testLedger1", "testLedger2"} {
+		ledger, err := provider.Open(ledgerID)
+		assert.NoError(t, err)
+		bcInfo, err := ledger.GetBlockchainInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), bcInfo.Height)
+		assertKey1Value(t, ledger, "")
+		ledger.Close()
+	}
+}
+
+func TestRollbackKVLedgerErrors(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	populateTestLedger(t, "testLedger", 3)
+
+	err := RollbackKVLedger("nonExistingLedger", 1)
+	assert.EqualError(t, err, "ledger [nonExistingLedger] does not exist")
+
+	err = RollbackKVLedger("testLedger", 3)
+	assert.EqualError(t, err, "target block number [3] should be less than the biggest block number [3] of ledger [testLedger]")
+
+	// a running peer holds the file lock
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	assert.NoError(t, fileLock.Lock())
+	err = RollbackKVLedger("testLedger", 1)
+	assert.Contains(t, err.Error(), "the ledger data is in use, stop the peer node or wait for the running command to complete")
+	err = ResetAllKVLedgers()
+	assert.Contains(t, err.Error(), "the ledger data is in use, stop the peer node or wait for the running command to complete")
+	fileLock.Unlock()
+
+	// the failed rollbacks leave the ledger intact
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	ledger, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+	assertKey1Value(t, ledger, "value3")
+}
+
+// populateTestLedger creates a ledger with the genesis block and the given number of blocks, the block
+// with block number i setting the key 'key1' to 'value<i>'. It returns the blocks, which are all committed
+func populateTestLedger(t *testing.T, ledgerID string, numBlocks int) []*common.Block {
+	provider, err := NewProvider()
+	assert.NoError(t, err)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	blocks := []*common.Block{gb}
+	for i := 1; i <= numBlocks; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		assert.NoError(t, err)
+		assert.NoError(t, simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i))))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func assertKey1Value(t *testing.T, ledger lgr.PeerLedger, expectedValue string) {
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	value, err := qe.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedValue, string(value))
+}
+
+func assertKey1HistoryLen(t *testing.T, ledger lgr.PeerLedger, expectedLen int) {
+	hqe, err := ledger.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	itr, err := hqe.GetHistoryForKey("ns1", "key1")
+	assert.NoError(t, err)
+	defer itr.Close()
+	count := 0
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			break
+		}
+		count++
+	}
+	assert.Equal(t, expectedLen, count)
+}
@@ -0,0 +1,419 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// A snapshot is a gzip compressed sequence of records, each of which is prefixed with its length.
+// The first record is the header of the snapshot, which is followed by a record for each key-value
+// of the state, and an empty record that marks the end of the key-values. Starting with version 2,
+// the last record is the state hash, i.e., the SHA-256 hash of the records of the key-values.
+const snapshotFormatVersion = 2
+
+// snapshotImportBatchSize is the number of key-values of a snapshot that are imported into the state db at once
+var snapshotImportBatchSize = 1000
+
+// snapshotHeader is the first record of a snapshot
+type snapshotHeader struct {
+	formatVersion uint64
+	info          *ledger.SnapshotInfo
+	// savepoint is the savepoint of the state db the snapshot was exported from
+	savepoint *version.Height
+}
+
+// ExportSnapshot implements method in interface `ledger.SnapshotExporter`.
+// Blocks are not committed to the ledger while the snapshot is being exported
+func (l *kvLedger) ExportSnapshot(w io.Writer) (*ledger.SnapshotInfo, error) {
+	// the query executor holds the commit lock of the state db until it's done
+	qe, err := l.txtmgmt.NewQueryExecutor(util.GenerateUUID())
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	savepoint, err := l.stateDB.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, fmt.Errorf("no block is committed to the state db of ledger [%s]", l.ledgerID)
+	}
+	info, err := l.snapshotInfo(savepoint.BlockNum)
+	if err != nil {
+		return nil, err
+	}
+	itr, err := l.stateDB.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	sw := newSnapshotWriter(w)
+	if err := sw.writeHeader(&snapshotHeader{snapshotFormatVersion, info, savepoint}); err != nil {
+		return nil, err
+	}
+	numKVs := 0
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			break
+		}
+		if err := sw.writeKV(result.(*statedb.VersionedKV)); err != nil {
+			return nil, err
+		}
+		numKVs++
+	}
+	if info.StateHash, err = sw.close(); err != nil {
+		return nil, err
+	}
+	logger.Infof("Channel [%s]: Exported snapshot of the state as of block [%d] with %d key(s) and state hash [%x]",
+		l.ledgerID, info.LastBlockNum, numKVs, info.StateHash)
+	return info, nil
+}
+
+// ReadSnapshot reads a whole snapshot exported by a `SnapshotExporter`, verifying its key-values against the
+// state hash the snapshot ends with, and returns the info of the snapshot. The given function, if not nil, is
+// invoked with each key-value of the snapshot
+func ReadSnapshot(snapshot io.Reader, visit func(namespace, key string, value []byte) error) (*ledger.SnapshotInfo, error) {
+	sr, header, err := newSnapshotReader(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		kv, err := sr.nextKV()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		if visit == nil {
+			continue
+		}
+		if err := visit(kv.Namespace, kv.Key, kv.Value); err != nil {
+			return nil, err
+		}
+	}
+	header.info.StateHash = sr.stateHash
+	return header.info, nil
+}
+
+// GetBootstrapInfo implements method in interface `ledger.BootstrapInfoRetriever`
+func (l *kvLedger) GetBootstrapInfo() (*ledger.SnapshotInfo, *common.Block, error) {
+	bootstrapInfo, err := l.blockStore.GetBootstrapInfo()
+	if bootstrapInfo == nil || err != nil {
+		return nil, nil, err
+	}
+	info := &ledger.SnapshotInfo{
+		LedgerID:          l.ledgerID,
+		LastBlockNum:      bootstrapInfo.LastBlockNum,
+		LastBlockHash:     bootstrapInfo.LastBlockHash,
+		PreviousBlockHash: bootstrapInfo.PreviousBlockHash,
+	}
+	return info, bootstrapInfo.ConfigBlock, nil
+}
+
+// snapshotInfo returns the info of a snapshot of the state as of the given block
+func (l *kvLedger) snapshotInfo(lastBlockNum uint64) (*ledger.SnapshotInfo, error) {
+	info := &ledger.SnapshotInfo{LedgerID: l.ledgerID, LastBlockNum: lastBlockNum}
+	bootstrapInfo, err := l.blockStore.GetBootstrapInfo()
+	if err != nil {
+		return nil, err
+	}
+	// the block isn't in the block store if no block was committed since the ledger was created from a snapshot
+	if bootstrapInfo != nil && bootstrapInfo.LastBlockNum == lastBlockNum {
+		info.LastBlockHash = bootstrapInfo.LastBlockHash
+		info.PreviousBlockHash = bootstrapInfo.PreviousBlockHash
+		return info, nil
+	}
+	block, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	info.LastBlockHash = block.Header.Hash()
+	info.PreviousBlockHash = block.Header.PreviousHash
+	return info, nil
+}
+
+// importSnapshot imports the state of the snapshot into the state db, the history db and the event index of the
+// ledger, and bootstraps its block store with the given config block, so that the ledger starts after the last
+// block of the snapshot
+func (provider *Provider) importSnapshot(sr *snapshotReader, header *snapshotHeader, configBlock *common.Block) error {
+	ledgerID := header.info.LedgerID
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	savepoint, err := vDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint != nil {
+		return fmt.Errorf("state db of ledger [%s] is not empty", ledgerID)
+	}
+	numKVs := 0
+	batch := privacyenabledstate.NewUpdateBatch()
+	for {
+		kv, err := sr.nextKV()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			break
+		}
+		// the namespaces of the hashed data are exported as they are stored in the state db, hence
+		// the hashed data is imported as public data of these namespaces
+		batch.PubUpdates.Put(kv.Namespace, kv.Key, kv.Value, kv.Version)
+		numKVs++
+		if numKVs%snapshotImportBatchSize == 0 {
+			if err := vDB.ApplyPrivacyAwareUpdates(batch, header.savepoint); err != nil {
+				return err
+			}
+			batch = privacyenabledstate.NewUpdateBatch()
+		}
+	}
+	// the last batch is applied even if it's empty, so that the savepoint is set for empty snapshots
+	if err := vDB.ApplyPrivacyAwareUpdates(batch, header.savepoint); err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Imported snapshot of the state as of block [%d] with %d key(s) and state hash [%x]",
+		ledgerID, header.info.LastBlockNum, numKVs, sr.stateHash)
+
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := historyDB.InitSavepoint(header.savepoint); err != nil {
+		return err
+	}
+	if err := provider.eventIndexProvider.GetIndex(ledgerID).InitSavepoint(header.savepoint); err != nil {
+		return err
+	}
+	return provider.ledgerStoreProvider.Bootstrap(ledgerID, &blkstorage.BootstrapInfo{
+		LastBlockNum:      header.info.LastBlockNum,
+		LastBlockHash:     header.info.LastBlockHash,
+		PreviousBlockHash: header.info.PreviousBlockHash,
+		ConfigBlock:       configBlock,
+	})
+}
+
+type snapshotWriter struct {
+	gzipWriter *gzip.Writer
+	hasher     hash.Hash
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	return &snapshotWriter{gzip.NewWriter(w), sha256.New()}
+}
+
+func (sw *snapshotWriter) writeHeader(header *snapshotHeader) error {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(header.formatVersion); err != nil {
+		return err
+	}
+	if err := buffer.EncodeStringBytes(header.info.LedgerID); err != nil {
+		return err
+	}
+	if err := buffer.EncodeVarint(header.info.LastBlockNum); err != nil {
+		return err
+	}
+	if err := buffer.EncodeRawBytes(header.info.LastBlockHash); err != nil {
+		return err
+	}
+	if err := buffer.EncodeRawBytes(header.info.PreviousBlockHash); err != nil {
+		return err
+	}
+	if err := buffer.EncodeRawBytes(header.savepoint.ToBytes()); err != nil {
+		return err
+	}
+	return sw.writeRecord(buffer.Bytes())
+}
+
+func (sw *snapshotWriter) writeKV(kv *statedb.VersionedKV) error {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeStringBytes(kv.Namespace); err != nil {
+		return err
+	}
+	if err := buffer.EncodeStringBytes(kv.Key); err != nil {
+		return err
+	}
+	if err := buffer.EncodeRawBytes(kv.Value); err != nil {
+		return err
+	}
+	if err := buffer.EncodeRawBytes(kv.Version.ToBytes()); err != nil {
+		return err
+	}
+	sw.hasher.Write(buffer.Bytes())
+	return sw.writeRecord(buffer.Bytes())
+}
+
+func (sw *snapshotWriter) writeRecord(record []byte) error {
+	if _, err := sw.gzipWriter.Write(proto.EncodeVarint(uint64(len(record)))); err != nil {
+		return err
+	}
+	_, err := sw.gzipWriter.Write(record)
+	return err
+}
+
+// close marks the end of the key-values, writes the state hash and flushes the snapshot. It returns the state hash
+func (sw *snapshotWriter) close() ([]byte, error) {
+	if err := sw.writeRecord(nil); err != nil {
+		return nil, err
+	}
+	stateHash := sw.hasher.Sum(nil)
+	if err := sw.writeRecord(stateHash); err != nil {
+		return nil, err
+	}
+	return stateHash, sw.gzipWriter.Close()
+}
+
+type snapshotReader struct {
+	reader        *bufio.Reader
+	formatVersion uint64
+	hasher        hash.Hash
+	// stateHash is the hash of the key-values, once they are all read
+	stateHash []byte
+}
+
+// newSnapshotReader returns a reader of the given snapshot, along with the header of the snapshot
+func newSnapshotReader(r io.Reader) (*snapshotReader, *snapshotHeader, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error while reading snapshot: %s", err)
+	}
+	sr := &snapshotReader{reader: bufio.NewReader(gzipReader), hasher: sha256.New()}
+	record, err := sr.readRecord()
+	if err != nil {
+		return nil, nil, err
+	}
+	header, err := decodeSnapshotHeader(record)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error while decoding snapshot header: %s", err)
+	}
+	sr.formatVersion = header.formatVersion
+	return sr, header, nil
+}
+
+func decodeSnapshotHeader(record []byte) (*snapshotHeader, error) {
+	buffer := proto.NewBuffer(record)
+	formatVersion, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, err
+	}
+	if formatVersion == 0 || formatVersion > snapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version [%d]", formatVersion)
+	}
+	info := &ledger.SnapshotInfo{}
+	if info.LedgerID, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if info.LastBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return nil, err
+	}
+	if info.LastBlockHash, err = buffer.DecodeRawBytes(false); err != nil {
+		return nil, err
+	}
+	if info.PreviousBlockHash, err = buffer.DecodeRawBytes(false); err != nil {
+		return nil, err
+	}
+	savepointBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return nil, err
+	}
+	savepoint, _ := version.NewHeightFromBytes(savepointBytes)
+	return &snapshotHeader{formatVersion, info, savepoint}, nil
+}
+
+// nextKV returns the next key-value of the snapshot, or nil at the end of the snapshot, once the key-values
+// are verified against the state hash of the snapshot
+func (sr *snapshotReader) nextKV() (*statedb.VersionedKV, error) {
+	record, err := sr.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	if len(record) == 0 {
+		return nil, sr.verifyStateHash()
+	}
+	sr.hasher.Write(record)
+	buffer := proto.NewBuffer(record)
+	kv := &statedb.VersionedKV{}
+	if kv.Namespace, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if kv.Key, err = buffer.DecodeStringBytes(); err != nil {
+		return nil, err
+	}
+	if kv.Value, err = buffer.DecodeRawBytes(true); err != nil {
+		return nil, err
+	}
+	versionBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return nil, err
+	}
+	kv.Version, _ = version.NewHeightFromBytes(versionBytes)
+	return kv, nil
+}
+
+// verifyStateHash checks the hash of the key-values read against the state hash the snapshot ends with.
+// The snapshots of the first format version have no state hash
+func (sr *snapshotReader) verifyStateHash() error {
+	sr.stateHash = sr.hasher.Sum(nil)
+	if sr.formatVersion < 2 {
+		return nil
+	}
+	stateHash, err := sr.readRecord()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stateHash, sr.stateHash) {
+		return fmt.Errorf("state hash [%x] of snapshot does not match the hash [%x] of its key-values, the snapshot is corrupted",
+			stateHash, sr.stateHash)
+	}
+	return nil
+}
+
+func (sr *snapshotReader) readRecord() ([]byte, error) {
+	length, err := binary.ReadUvarint(sr.reader)
+	if err != nil {
+		return nil, fmt.Errorf("Error while reading snapshot: %s", errOrTruncated(err))
+	}
+	record := make([]byte, length)
+	if _, err := io.ReadFull(sr.reader, record); err != nil {
+		return nil, fmt.Errorf("Error while reading snapshot: %s", errOrTruncated(err))
+	}
+	return record, nil
+}
+
+// errOrTruncated reports the end of the input before the end of the snapshot as a truncated snapshot
+func errOrTruncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("snapshot is truncated")
+	}
+	return err
+}
@@ -0,0 +1,228 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+func TestSnapshotExportAndImport(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	ledgerID := constructTestLedgerID(1)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	testutil.AssertNoError(t, err, "")
+	for i := 1; i <= 2; i++ {
+		commitTestBlock(t, l, bg.NextBlock(simulateTestTx(t, l, fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))))
+	}
+	bcInfo, _ := l.GetBlockchainInfo()
+
+	snapshot := &bytes.Buffer{}
+	info, err := l.(ledger.SnapshotExporter).ExportSnapshot(snapshot)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(info.StateHash), 32)
+	testutil.AssertEquals(t, info, &ledger.SnapshotInfo{
+		LedgerID:          ledgerID,
+		LastBlockNum:      2,
+		LastBlockHash:     bcInfo.CurrentBlockHash,
+		PreviousBlockHash: bcInfo.PreviousBlockHash,
+		StateHash:         info.StateHash,
+	})
+	l.Close()
+	provider.Close()
+
+	// The whole snapshot is read and verified without importing it
+	var keys []string
+	readInfo, err := ReadSnapshot(bytes.NewReader(snapshot.Bytes()), func(namespace, key string, value []byte) error {
+		keys = append(keys, namespace+"/"+key)
+		return nil
+	})
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, readInfo, info)
+	testutil.AssertContains(t, keys, "ns1/key1")
+	testutil.AssertContains(t, keys, "ns1/key2")
+
+	// A new peer creates the ledger from the snapshot, importing one key-value at a time
+	defer func(batchSize int) { snapshotImportBatchSize = batchSize }(snapshotImportBatchSize)
+	snapshotImportBatchSize = 1
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider, _ = NewProvider()
+	defer provider.Close()
+
+	_, otherGB := testutil.NewBlockGenerator(t, constructTestLedgerID(2), false)
+	_, err = provider.CreateFromSnapshot(otherGB, bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertError(t, err, "The snapshot is of another ledger")
+	laterConfigBlock := proto.Clone(gb).(*common.Block)
+	laterConfigBlock.Header.Number = 3
+	_, err = provider.CreateFromSnapshot(laterConfigBlock, bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertError(t, err, "The config block is after the last block of the snapshot")
+	l, err = provider.CreateFromSnapshot(gb, bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertNoError(t, err, "")
+	defer l.Close()
+	importedBCInfo, _ := l.GetBlockchainInfo()
+	testutil.AssertEquals(t, importedBCInfo, bcInfo)
+	bootstrapInfo, configBlock, err := l.(ledger.BootstrapInfoRetriever).GetBootstrapInfo()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, bootstrapInfo.LastBlockNum, uint64(2))
+	testutil.AssertEquals(t, bootstrapInfo.LastBlockHash, info.LastBlockHash)
+	testutil.AssertEquals(t, proto.Equal(configBlock, gb), true)
+	qe, _ := l.NewQueryExecutor()
+	value, err := qe.GetState("ns1", "key1")
+	qe.Done()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, value, []byte("value1"))
+	_, err = l.GetBlockByNumber(1)
+	testutil.AssertError(t, err, "The blocks of the snapshot are not in the block store")
+	_, err = provider.CreateFromSnapshot(gb, bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertEquals(t, err, ErrLedgerIDExists)
+
+	// The ledger exports the same snapshot before a block is committed to it
+	exportedSnapshot := &bytes.Buffer{}
+	exportedInfo, err := l.(ledger.SnapshotExporter).ExportSnapshot(exportedSnapshot)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, exportedInfo, info)
+
+	// The ledger receives the blocks following the snapshot
+	commitTestBlock(t, l, bg.NextBlock(simulateTestTx(t, l, "key1", "value3")))
+	bcInfo, _ = l.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(4))
+	qe, _ = l.NewQueryExecutor()
+	value, err = qe.GetState("ns1", "key1")
+	qe.Done()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, value, []byte("value3"))
+}
+
+func TestRecoveryOfLedgerCreatedFromSnapshot(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	ledgerID := constructTestLedgerID(1)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, _ := provider.Create(gb)
+	commitTestBlock(t, l, bg.NextBlock(simulateTestTx(t, l, "key1", "value1")))
+	snapshot := &bytes.Buffer{}
+	_, err := l.(ledger.SnapshotExporter).ExportSnapshot(snapshot)
+	testutil.AssertNoError(t, err, "")
+	l.Close()
+	provider.Close()
+
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider, _ = NewProvider()
+	l, err = provider.CreateFromSnapshot(gb, bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertNoError(t, err, "")
+	l.Close()
+
+	// assume a crash happens after the block store is bootstrapped, before the ledger is marked as created
+	idStore := provider.(*Provider).idStore
+	testutil.AssertNoError(t, idStore.db.Delete(idStore.encodeLedgerKey(ledgerID), true), "")
+	testutil.AssertNoError(t, idStore.setUnderConstructionFlag(ledgerID), "")
+	provider.Close()
+
+	// construct a new provider to invoke recovery
+	provider, err = NewProvider()
+	testutil.AssertNoError(t, err, "Provider failed to recover an underConstructionLedger")
+	defer provider.Close()
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	testutil.AssertNoError(t, err, "Failed to read the underconstruction flag")
+	testutil.AssertEquals(t, flag, "")
+	l, err = provider.Open(ledgerID)
+	testutil.AssertNoError(t, err, "Failed to open the ledger")
+	bcInfo, _ := l.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(2))
+	l.Close()
+}
+
+func TestSnapshotReader(t *testing.T) {
+	snapshot := &bytes.Buffer{}
+	sw := newSnapshotWriter(snapshot)
+	header := &snapshotHeader{snapshotFormatVersion, &ledger.SnapshotInfo{LedgerID: "ledger1", LastBlockNum: 5}, version.NewHeight(5, 2)}
+	testutil.AssertNoError(t, sw.writeHeader(header), "")
+	kv := &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+		VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(3, 1)},
+	}
+	testutil.AssertNoError(t, sw.writeKV(kv), "")
+	stateHash, err := sw.close()
+	testutil.AssertNoError(t, err, "")
+
+	sr, readHeader, err := newSnapshotReader(bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, readHeader.info.LedgerID, "ledger1")
+	testutil.AssertEquals(t, readHeader.info.LastBlockNum, uint64(5))
+	testutil.AssertEquals(t, readHeader.savepoint, version.NewHeight(5, 2))
+	readKV, err := sr.nextKV()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, readKV, kv)
+	readKV, err = sr.nextKV()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, readKV)
+	testutil.AssertEquals(t, sr.stateHash, stateHash)
+
+	// A snapshot whose key-values don't match its state hash is corrupted
+	snapshot.Reset()
+	sw = newSnapshotWriter(snapshot)
+	testutil.AssertNoError(t, sw.writeHeader(header), "")
+	testutil.AssertNoError(t, sw.writeKV(kv), "")
+	testutil.AssertNoError(t, sw.writeRecord(nil), "")
+	testutil.AssertNoError(t, sw.writeRecord([]byte("another-hash")), "")
+	testutil.AssertNoError(t, sw.gzipWriter.Close(), "")
+	_, err = ReadSnapshot(bytes.NewReader(snapshot.Bytes()), nil)
+	testutil.AssertError(t, err, "The state hash doesn't match")
+
+	// A snapshot of the first format version has no state hash
+	snapshot.Reset()
+	sw = newSnapshotWriter(snapshot)
+	testutil.AssertNoError(t, sw.writeHeader(&snapshotHeader{1, header.info, header.savepoint}), "")
+	testutil.AssertNoError(t, sw.writeKV(kv), "")
+	testutil.AssertNoError(t, sw.writeRecord(nil), "")
+	testutil.AssertNoError(t, sw.gzipWriter.Close(), "")
+	info, err := ReadSnapshot(bytes.NewReader(snapshot.Bytes()), nil)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, info.StateHash, stateHash)
+
+	// A snapshot without the record that marks its end is truncated
+	snapshot.Reset()
+	sw = newSnapshotWriter(snapshot)
+	testutil.AssertNoError(t, sw.writeHeader(header), "")
+	testutil.AssertNoError(t, sw.gzipWriter.Close(), "")
+	sr, _, err = newSnapshotReader(bytes.NewReader(snapshot.Bytes()))
+	testutil.AssertNoError(t, err, "")
+	_, err = sr.nextKV()
+	testutil.AssertEquals(t, err.Error(), "Error while reading snapshot: snapshot is truncated")
+
+	// The input isn't a snapshot
+	_, _, err = newSnapshotReader(bytes.NewReader([]byte("not a snapshot")))
+	testutil.AssertError(t, err, "The input is not gzip compressed")
+}
+
+func simulateTestTx(t *testing.T, l ledger.PeerLedger, key, value string) [][]byte {
+	s, _ := l.NewTxSimulator(util.GenerateUUID())
+	testutil.AssertNoError(t, s.SetState("ns1", key, []byte(value)), "")
+	s.Done()
+	res, _ := s.GetTxSimulationResults()
+	pubSimBytes, _ := res.GetPubSimulationBytes()
+	return [][]byte{pubSimBytes}
+}
+
+func commitTestBlock(t *testing.T, l ledger.PeerLedger, block *common.Block) {
+	testutil.AssertNoError(t, l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block}), "")
+}
@@ -9,6 +9,7 @@ package privacyenabledstate
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 
@@ -190,6 +191,50 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	return s.VersionedDB.ApplyUpdates(updates.PubUpdates.UpdateBatch, height)
 }
 
+// GetFullScanIterator implements corresponding function in interface DB
+func (s *CommonStorageDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, fmt.Errorf("Full scans are not supported by the state database")
+	}
+	itr, err := fullScannable.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	return &pvtDataSkippingIterator{itr}, nil
+}
+
+// ApproximateSize implements corresponding function in interface DB
+func (s *CommonStorageDB) ApproximateSize() (int64, error) {
+	sizeEstimator, ok := s.VersionedDB.(statedb.SizeEstimator)
+	if !ok {
+		return 0, fmt.Errorf("Estimating the size is not supported by the state database")
+	}
+	return sizeEstimator.ApproximateSize()
+}
+
+// pvtDataSkippingIterator skips the key-values of the namespaces that hold private data
+type pvtDataSkippingIterator struct {
+	statedb.ResultsIterator
+}
+
+func (itr *pvtDataSkippingIterator) Next() (statedb.QueryResult, error) {
+	for {
+		result, err := itr.ResultsIterator.Next()
+		if result == nil || err != nil {
+			return result, err
+		}
+		if !isPvtDataNs(result.(*statedb.VersionedKV).Namespace) {
+			return result, nil
+		}
+	}
+}
+
+func isPvtDataNs(namespace string) bool {
+	i := strings.Index(namespace, nsJoiner)
+	return i >= 0 && strings.HasPrefix(namespace[i+len(nsJoiner):], pvtDataPrefix)
+}
+
 func derivePvtDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + pvtDataPrefix + collection
 }
@@ -37,6 +37,12 @@ type DB interface {
 	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (statedb.ResultsIterator, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	// GetFullScanIterator returns an iterator over the public and hashed data of all the namespaces, as stored
+	// in the underlying db, which skips the private data. The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator() (statedb.ResultsIterator, error)
+	// ApproximateSize returns the approximate number of bytes that the public, hashed and private data
+	// occupy in the underlying db
+	ApproximateSize() (int64, error)
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
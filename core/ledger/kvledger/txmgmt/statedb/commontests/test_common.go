@@ -17,6 +17,7 @@ limitations under the License.
 package commontests
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -211,6 +212,60 @@ func testItr(t *testing.T, itr statedb.ResultsIterator, expectedKeys []string) {
 	testutil.AssertNil(t, last)
 }
 
+// TestPaginatedRangeQuery tests range queries whose results are limited, and which are resumed at the bookmark
+func TestPaginatedRangeQuery(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testpaginatedrangequery")
+	testutil.AssertNoError(t, err, "")
+	db.Open()
+	defer db.Close()
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 5; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns2", "key6", []byte("value6"), version.NewHeight(1, 6))
+	db.ApplyUpdates(batch, version.NewHeight(2, 5))
+
+	// The first page ends before the end of the range
+	itr, err := db.GetStateRangeScanIteratorWithMetadata("ns1", "", "", map[string]interface{}{"limit": int32(2)})
+	testutil.AssertNoError(t, err, "")
+	testItrWithoutClose(t, itr, []string{"key1", "key2"})
+	testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "key3")
+
+	// The next page starts at the bookmark, and is the last page of the range
+	itr, err = db.GetStateRangeScanIteratorWithMetadata("ns1", "key3", "key5", map[string]interface{}{"limit": int32(2)})
+	testutil.AssertNoError(t, err, "")
+	testItrWithoutClose(t, itr, []string{"key3", "key4"})
+	testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "")
+
+	// The bookmark is the key following the last one returned, if the caller stops early
+	itr, err = db.GetStateRangeScanIteratorWithMetadata("ns1", "key2", "", map[string]interface{}{"limit": int32(3)})
+	testutil.AssertNoError(t, err, "")
+	queryResult, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, queryResult.(*statedb.VersionedKV).Key, "key2")
+	testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "key3")
+
+	// The results are not limited without a limit
+	itr, err = db.GetStateRangeScanIteratorWithMetadata("ns1", "", "", nil)
+	testutil.AssertNoError(t, err, "")
+	testItrWithoutClose(t, itr, []string{"key1", "key2", "key3", "key4", "key5"})
+	testutil.AssertEquals(t, itr.GetBookmarkAndClose(), "")
+
+	_, err = db.GetStateRangeScanIteratorWithMetadata("ns1", "", "", map[string]interface{}{"limit": "2"})
+	testutil.AssertError(t, err, "The limit must be an int32")
+}
+
+func testItrWithoutClose(t *testing.T, itr statedb.ResultsIterator, expectedKeys []string) {
+	for _, expectedKey := range expectedKeys {
+		queryResult, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		testutil.AssertEquals(t, queryResult.(*statedb.VersionedKV).Key, expectedKey)
+	}
+	last, err := itr.Next()
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, last)
+}
+
 // TestQuery tests queries
 func TestQuery(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	db, err := dbProvider.GetDBHandle("testquery")
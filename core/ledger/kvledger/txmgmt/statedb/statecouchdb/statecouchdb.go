@@ -41,8 +41,8 @@ type VersionedDBProvider struct {
 func NewVersionedDBProvider() (*VersionedDBProvider, error) {
 	logger.Debugf("constructing CouchDB VersionedDBProvider")
 	couchDBDef := couchdb.GetCouchDBDefinition()
-	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
-		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout)
+	couchInstance, err := couchdb.CreateCouchInstanceWithConnectionPool(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.ConnectionPool)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +70,30 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return vdb, nil
 }
 
+// DropApplicationDBs drops all the application databases of the CouchDB instance that the state is kept in.
+// This is expected to be invoked while the peer is offline, such that the state is rebuilt from the block
+// store when the peer starts
+func DropApplicationDBs() error {
+	couchDBDef := couchdb.GetCouchDBDefinition()
+	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout)
+	if err != nil {
+		return err
+	}
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	for _, dbName := range dbNames {
+		db := &couchdb.CouchDatabase{CouchInstance: couchInstance, DBName: dbName}
+		if _, err := db.DropDatabase(); err != nil {
+			return fmt.Errorf("error dropping state database [%s]: %s", dbName, err)
+		}
+		logger.Infof("Dropped state database [%s]", dbName)
+	}
+	return nil
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
@@ -259,19 +283,32 @@ func (vdb *VersionedDB) GetStateMultipleKeys(namespace string, keys []string) ([
 // startKey is inclusive
 // endKey is exclusive
 func (vdb *VersionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
-	// Get the querylimit from core.yaml
-	queryLimit := ledgerconfig.GetQueryLimit()
-	db, err := vdb.getNamespaceDBHandle(namespace)
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
+// The key-values are read from CouchDB in pages of at most queryLimit documents, as they are iterated over
+func (vdb *VersionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit, err := statedb.RangeQueryLimit(metadata)
 	if err != nil {
 		return nil, err
 	}
-	queryResult, err := db.ReadDocRange(startKey, endKey, queryLimit, querySkip)
+	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
-		logger.Debugf("Error calling ReadDocRange(): %s\n", err.Error())
 		return nil, err
 	}
-	logger.Debugf("Exiting GetStateRangeScanIterator")
-	return newQueryScanner(namespace, *queryResult), nil
+	scanner := &rangeScanner{
+		db:             db,
+		namespace:      namespace,
+		endKey:         endKey,
+		pageSize:       ledgerconfig.GetQueryLimit(),
+		requestedLimit: requestedLimit,
+	}
+	if err := scanner.fetchPage(startKey); err != nil {
+		return nil, err
+	}
+	logger.Debugf("Exiting GetStateRangeScanIteratorWithMetadata")
+	return scanner, nil
 }
 
 // ExecuteQuery implements method in VersionedDB interface
@@ -470,3 +507,81 @@ func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
 func (scanner *queryScanner) Close() {
 	scanner = nil
 }
+
+// rangeScanner iterates over the key-values of a range query, reading them from CouchDB a page at a time
+type rangeScanner struct {
+	db        *couchdb.CouchDatabase
+	namespace string
+	endKey    string
+	// pageSize is the maximum number of documents read from CouchDB at once
+	pageSize int
+	// requestedLimit is the maximum number of results to return, 0 means no limit
+	requestedLimit       int32
+	totalRecordsReturned int32
+
+	page *queryScanner
+	// nextPageStartKey is the key the next page starts at, if there is a next page
+	nextPageStartKey string
+	hasNextPage      bool
+}
+
+// fetchPage reads the page of key-values that starts at the given key. One more document than
+// the size of the page is read, which is the first document of the next page, if there is one
+func (scanner *rangeScanner) fetchPage(startKey string) error {
+	limit := scanner.pageSize
+	if scanner.requestedLimit > 0 {
+		if remaining := int(scanner.requestedLimit - scanner.totalRecordsReturned); remaining < limit {
+			limit = remaining
+		}
+	}
+	queryResult, err := scanner.db.ReadDocRange(startKey, scanner.endKey, limit+1, querySkip)
+	if err != nil {
+		logger.Debugf("Error calling ReadDocRange(): %s\n", err.Error())
+		return err
+	}
+	results := *queryResult
+	scanner.hasNextPage = len(results) > limit
+	if scanner.hasNextPage {
+		scanner.nextPageStartKey = results[limit].ID
+		results = results[:limit]
+	}
+	scanner.page = newQueryScanner(scanner.namespace, results)
+	return nil
+}
+
+func (scanner *rangeScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	if scanner.page.cursor+1 >= len(scanner.page.results) {
+		if !scanner.hasNextPage {
+			return nil, nil
+		}
+		if err := scanner.fetchPage(scanner.nextPageStartKey); err != nil {
+			return nil, err
+		}
+	}
+	result, err := scanner.page.Next()
+	if err != nil || result == nil {
+		return result, err
+	}
+	scanner.totalRecordsReturned++
+	return result, nil
+}
+
+func (scanner *rangeScanner) Close() {
+	scanner.page.Close()
+}
+
+// GetBookmarkAndClose implements method in QueryResultsIterator interface.
+// The bookmark is the key following the last key returned, if there is one
+func (scanner *rangeScanner) GetBookmarkAndClose() string {
+	bookmark := ""
+	if next := scanner.page.cursor + 1; next < len(scanner.page.results) {
+		bookmark = scanner.page.results[next].ID
+	} else if scanner.hasNextPage {
+		bookmark = scanner.nextPageStartKey
+	}
+	scanner.Close()
+	return bookmark
+}
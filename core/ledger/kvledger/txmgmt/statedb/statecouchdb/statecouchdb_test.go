@@ -118,6 +118,20 @@ func TestIterator(t *testing.T) {
 	commontests.TestIterator(t, env.DBProvider)
 }
 
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	env.Cleanup("testpaginatedrangequery_")
+	env.Cleanup("testpaginatedrangequery_ns1")
+	env.Cleanup("testpaginatedrangequery_ns2")
+	defer env.Cleanup("testpaginatedrangequery_")
+	defer env.Cleanup("testpaginatedrangequery_ns1")
+	defer env.Cleanup("testpaginatedrangequery_ns2")
+	// read a single document from CouchDB at once, so that the pages of the queries span several reads
+	defer viper.Set("ledger.state.couchDBConfig.queryLimit", viper.GetInt("ledger.state.couchDBConfig.queryLimit"))
+	viper.Set("ledger.state.couchDBConfig.queryLimit", 1)
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
 // The following tests are unique to couchdb, they are not used in leveldb
 //  query test
 func TestQuery(t *testing.T) {
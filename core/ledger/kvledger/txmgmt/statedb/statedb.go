@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package statedb
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -33,6 +34,10 @@ type VersionedDB interface {
 	// endKey is exclusive
 	// The returned ResultsIterator contains results of type *VersionedKV
 	GetStateRangeScanIterator(namespace string, startKey string, endKey string) (ResultsIterator, error)
+	// GetStateRangeScanIteratorWithMetadata returns an iterator that contains the key-values between given key ranges,
+	// as bounded by the metadata of the query (see RangeQueryLimit). startKey is inclusive, endKey is exclusive.
+	// The returned QueryResultsIterator contains results of type *VersionedKV
+	GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type *VersionedKV.
 	ExecuteQuery(namespace, query string) (ResultsIterator, error)
 	// ApplyUpdates applies the batch to the underlying db.
@@ -62,6 +67,21 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+// FullScannable interface provides an additional function for
+// databases capable of iterating over the state of all the namespaces
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator that contains all the key-values of all the namespaces.
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator() (ResultsIterator, error)
+}
+
+// SizeEstimator interface provides an additional function for
+// databases capable of estimating the storage they occupy
+type SizeEstimator interface {
+	// ApproximateSize returns the approximate number of bytes that the db occupies in the storage
+	ApproximateSize() (int64, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -86,9 +106,37 @@ type ResultsIterator interface {
 	Close()
 }
 
+// QueryResultsIterator adds GetBookmarkAndClose method to ResultsIterator
+type QueryResultsIterator interface {
+	ResultsIterator
+	// GetBookmarkAndClose returns the key the next page of the query starts at, which is empty
+	// if there are no more results, and releases the resources occupied by the iterator
+	GetBookmarkAndClose() string
+}
+
 // QueryResult - a general interface for supporting different types of query results. Actual types differ for different queries
 type QueryResult interface{}
 
+// RangeQueryLimit returns the maximum number of results of a range query with the given metadata,
+// which is the int32 value of the "limit" entry, or 0 if the number of results is not limited.
+// It returns an error if the metadata contains other entries
+func RangeQueryLimit(metadata map[string]interface{}) (int32, error) {
+	limit := int32(0)
+	for key, value := range metadata {
+		switch key {
+		case "limit":
+			l, ok := value.(int32)
+			if !ok || l < 0 {
+				return 0, fmt.Errorf("invalid entry, \"limit\" must be a non-negative int32")
+			}
+			limit = l
+		default:
+			return 0, fmt.Errorf("invalid entry, option %s not recognized", key)
+		}
+	}
+	return limit, nil
+}
+
 type nsUpdates struct {
 	m map[string]*VersionedValue
 }
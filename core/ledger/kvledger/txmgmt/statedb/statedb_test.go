@@ -123,6 +123,23 @@ func TestUpdateBatchIterator(t *testing.T) {
 	checkItrResults(t, batch.GetRangeScanIterator("non-existing-ns", "", ""), nil)
 }
 
+func TestRangeQueryLimit(t *testing.T) {
+	limit, err := RangeQueryLimit(nil)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, limit, int32(0))
+
+	limit, err = RangeQueryLimit(map[string]interface{}{"limit": int32(10)})
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, limit, int32(10))
+
+	_, err = RangeQueryLimit(map[string]interface{}{"limit": 10})
+	testutil.AssertError(t, err, "The limit must be an int32")
+	_, err = RangeQueryLimit(map[string]interface{}{"limit": int32(-1)})
+	testutil.AssertError(t, err, "The limit must not be negative")
+	_, err = RangeQueryLimit(map[string]interface{}{"bookmark": "key1"})
+	testutil.AssertError(t, err, "Only the limit is a valid entry")
+}
+
 func checkItrResults(t *testing.T, itr ResultsIterator, expectedResults []*VersionedKV) {
 	for i := 0; i < len(expectedResults); i++ {
 		res, _ := itr.Next()
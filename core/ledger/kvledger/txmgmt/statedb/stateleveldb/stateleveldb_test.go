@@ -56,6 +56,12 @@ func TestIterator(t *testing.T) {
 	commontests.TestIterator(t, env.DBProvider)
 }
 
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
 func TestEncodeDecodeValueAndVersion(t *testing.T) {
 	testValueAndVersionEncoding(t, []byte("value1"), version.NewHeight(1, 2))
 	testValueAndVersionEncoding(t, []byte{}, version.NewHeight(50, 50))
@@ -104,6 +110,40 @@ func TestQueryOnLevelDB(t *testing.T) {
 	testutil.AssertNil(t, itr)
 }
 
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscan")
+	testutil.AssertNoError(t, err, "")
+	batch := statedb.NewUpdateBatch()
+	batch.Put("", "config", []byte("value0"), version.NewHeight(1, 0))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	db.ApplyUpdates(batch, version.NewHeight(1, 2))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+	testutil.AssertNoError(t, err, "")
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		result, err := itr.Next()
+		testutil.AssertNoError(t, err, "")
+		if result == nil {
+			break
+		}
+		results = append(results, result.(*statedb.VersionedKV))
+	}
+	// The savepoint isn't part of the state
+	testutil.AssertEquals(t, results, []*statedb.VersionedKV{
+		{CompositeKey: statedb.CompositeKey{Namespace: "", Key: "config"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value0"), Version: version.NewHeight(1, 0)}},
+		{CompositeKey: statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}},
+		{CompositeKey: statedb.CompositeKey{Namespace: "ns2", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}},
+	})
+}
+
 func TestGetStateMultipleKeys(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -86,7 +87,20 @@ func (h *queryHelper) getStateRangeScanIterator(namespace string, startKey strin
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, nil, h.txmgr.db, h.rwsetBuilder,
+		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
+	if err != nil {
+		return nil, err
+	}
+	h.itrs = append(h.itrs, itr)
+	return itr, nil
+}
+
+func (h *queryHelper) getStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	itr, err := newResultsItr(namespace, startKey, endKey, metadata, h.txmgr.db, h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
@@ -238,19 +252,26 @@ func (h *queryHelper) validateCollName(ns, coll string) error {
 type resultsItr struct {
 	ns                      string
 	endKey                  string
-	dbItr                   statedb.ResultsIterator
+	dbItr                   statedb.QueryResultsIterator
 	rwSetBuilder            *rwsetutil.RWSetBuilder
 	rangeQueryInfo          *kvrwset.RangeQueryInfo
 	rangeQueryResultsHelper *rwsetutil.RangeQueryResultsHelper
+	// limited is set if the results of the query are limited by its metadata, in which case
+	// the db iterator may stop before the end of the range
+	limited bool
 }
 
-func newResultsItr(ns string, startKey string, endKey string,
+func newResultsItr(ns string, startKey string, endKey string, metadata map[string]interface{},
 	db statedb.VersionedDB, rwsetBuilder *rwsetutil.RWSetBuilder, enableHashing bool, maxDegree uint32) (*resultsItr, error) {
-	dbItr, err := db.GetStateRangeScanIterator(ns, startKey, endKey)
+	limit, err := statedb.RangeQueryLimit(metadata)
+	if err != nil {
+		return nil, err
+	}
+	dbItr, err := db.GetStateRangeScanIteratorWithMetadata(ns, startKey, endKey, metadata)
 	if err != nil {
 		return nil, err
 	}
-	itr := &resultsItr{ns: ns, dbItr: dbItr}
+	itr := &resultsItr{ns: ns, dbItr: dbItr, limited: limit > 0}
 	// it's a simulation request so, enable capture of range query info
 	if rwsetBuilder != nil {
 		itr.rwSetBuilder = rwsetBuilder
@@ -297,6 +318,11 @@ func (itr *resultsItr) updateRangeQueryInfo(queryResult statedb.QueryResult) {
 	}
 
 	if queryResult == nil {
+		// the db iterator of a limited query may stop before the end of the range, in which
+		// case the end key remains the latest key retrieved by the caller
+		if itr.limited {
+			return
+		}
 		// caller scanned till the iterator got exhausted.
 		// So, set the endKey to the actual endKey supplied in the query
 		itr.rangeQueryInfo.ItrExhausted = true
@@ -315,6 +341,11 @@ func (itr *resultsItr) Close() {
 	itr.dbItr.Close()
 }
 
+// GetBookmarkAndClose implements method in interface ledger.QueryResultsIterator
+func (itr *resultsItr) GetBookmarkAndClose() string {
+	return itr.dbItr.GetBookmarkAndClose()
+}
+
 type queryResultsItr struct {
 	DBItr        statedb.ResultsIterator
 	RWSetBuilder *rwsetutil.RWSetBuilder
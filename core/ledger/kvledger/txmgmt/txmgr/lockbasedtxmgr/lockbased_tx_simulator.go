@@ -29,16 +29,17 @@ import (
 // LockBasedTxSimulator is a transaction simulator used in `LockBasedTxMgr`
 type lockBasedTxSimulator struct {
 	lockBasedQueryExecutor
-	rwsetBuilder            *rwsetutil.RWSetBuilder
-	writePerformed          bool
-	pvtdataQueriesPerformed bool
+	rwsetBuilder              *rwsetutil.RWSetBuilder
+	writePerformed            bool
+	pvtdataQueriesPerformed   bool
+	paginatedQueriesPerformed bool
 }
 
 func newLockBasedTxSimulator(txmgr *LockBasedTxMgr, txid string) (*lockBasedTxSimulator, error) {
 	rwsetBuilder := rwsetutil.NewRWSetBuilder()
 	helper := newQueryHelper(txmgr, rwsetBuilder)
 	logger.Debugf("constructing new tx simulator txid = [%s]", txid)
-	return &lockBasedTxSimulator{lockBasedQueryExecutor{helper, txid}, rwsetBuilder, false, false}, nil
+	return &lockBasedTxSimulator{lockBasedQueryExecutor{helper, txid}, rwsetBuilder, false, false, false}, nil
 }
 
 // SetState implements method in interface `ledger.TxSimulator`
@@ -123,6 +124,14 @@ func (s *lockBasedTxSimulator) GetPrivateDataRangeScanIterator(namespace, collec
 	return s.lockBasedQueryExecutor.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
 }
 
+// GetStateRangeScanIteratorWithMetadata implements method in interface `ledger.QueryExecutor`
+func (s *lockBasedTxSimulator) GetStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := s.checkBeforePaginatedQueries(); err != nil {
+		return nil, err
+	}
+	return s.lockBasedQueryExecutor.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, metadata)
+}
+
 // SetPrivateDataMetadata implements method in interface `ledger.TxSimulator`
 func (s *lockBasedTxSimulator) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
 	return errors.New("not implemented")
@@ -161,10 +170,25 @@ func (s *lockBasedTxSimulator) checkBeforeWrite() error {
 			Msg: fmt.Sprintf("Tx [%s]: Transaction has already performed queries on pvt data. Writes are not allowed", s.txid),
 		}
 	}
+	if s.paginatedQueriesPerformed {
+		return &txmgr.ErrUnsupportedTransaction{
+			Msg: fmt.Sprintf("Tx [%s]: Transaction has already performed a paginated query. Writes are not allowed", s.txid),
+		}
+	}
 	s.writePerformed = true
 	return nil
 }
 
+func (s *lockBasedTxSimulator) checkBeforePaginatedQueries() error {
+	if s.writePerformed {
+		return &txmgr.ErrUnsupportedTransaction{
+			Msg: fmt.Sprintf("Tx [%s]: Paginated queries are supported only in a read-only transaction", s.txid),
+		}
+	}
+	s.paginatedQueriesPerformed = true
+	return nil
+}
+
 func (s *lockBasedTxSimulator) checkBeforePvtdataQueries() error {
 	if s.writePerformed {
 		return &txmgr.ErrUnsupportedTransaction{
@@ -105,6 +105,17 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 	return nil
 }
 
+// ValidateOnly implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) ValidateOnly(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	block := blockAndPvtdata.Block
+	logger.Debugf("Validating block [%d] without preparing it for commit", block.Header.Number)
+	// the versions cached by the statedb while validating are cleared, as they
+	// are not cleared by a commit
+	defer txmgr.clearCache()
+	_, err := txmgr.validator.ValidateAndPrepareBatch(blockAndPvtdata, true)
+	return err
+}
+
 func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 	for _, listener := range txmgr.stateListeners {
 		stateUpdatesForListener := extractStateUpdates(txmgr.current.batch, listener.InterestedInNamespaces())
@@ -165,6 +176,24 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	return nil
 }
 
+// PurgePvtData implements method in interface `txmgmt.TxMgr`. It deletes the private values of the
+// given keys from the state database, while their hashes remain. Hence, the simulations that read these
+// keys afterwards fail, as the private data matching the hashed versions is not available
+func (txmgr *LockBasedTxMgr) PurgePvtData(ns, coll string, keys []string) error {
+	savepoint, err := txmgr.GetLastSavepoint()
+	if err != nil || savepoint == nil {
+		return err
+	}
+	batch := privacyenabledstate.NewUpdateBatch()
+	for _, key := range keys {
+		batch.PvtUpdates.Delete(ns, coll, key, savepoint)
+	}
+	txmgr.commitRWLock.Lock()
+	defer txmgr.commitRWLock.Unlock()
+	logger.Debugf("Purging [%d] private data keys of collection [%s:%s] from state database", len(keys), ns, coll)
+	return txmgr.db.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
 // Rollback implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Rollback() {
 	txmgr.reset()
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -443,6 +444,67 @@ func testIterator(t *testing.T, env testEnv, numKeys int, startKeyNum int, endKe
 	testutil.AssertEquals(t, count, expectedCount)
 }
 
+func TestPaginatedIterator(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testpaginatediterator"
+		testEnv.init(t, testLedgerID, nil)
+		testPaginatedIterator(t, testEnv)
+		testEnv.cleanup()
+	}
+}
+
+func testPaginatedIterator(t *testing.T, env testEnv) {
+	cID := "cid"
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	s, _ := txMgr.NewTxSimulator("test_tx1")
+	for i := 1; i <= 10; i++ {
+		s.SetState(cID, createTestKey(i), createTestValue(i))
+	}
+	s.Done()
+	txRWSet, _ := s.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet.PubSimulationResults)
+
+	readPage := func(qe ledger.QueryExecutor, startKey string) ([]string, string) {
+		itr, err := qe.GetStateRangeScanIteratorWithMetadata(cID, startKey, createTestKey(9), map[string]interface{}{"limit": int32(4)})
+		testutil.AssertNoError(t, err, "")
+		var keys []string
+		for {
+			kv, err := itr.Next()
+			testutil.AssertNoError(t, err, "")
+			if kv == nil {
+				break
+			}
+			keys = append(keys, kv.(*queryresult.KV).Key)
+		}
+		return keys, itr.GetBookmarkAndClose()
+	}
+
+	// The pages of the query are read in the order of the keys, each one starting at the bookmark of the previous one
+	qe, _ := txMgr.NewQueryExecutor("test_tx2")
+	keys, bookmark := readPage(qe, "")
+	testutil.AssertEquals(t, keys, []string{createTestKey(1), createTestKey(2), createTestKey(3), createTestKey(4)})
+	testutil.AssertEquals(t, bookmark, createTestKey(5))
+	keys, bookmark = readPage(qe, bookmark)
+	testutil.AssertEquals(t, keys, []string{createTestKey(5), createTestKey(6), createTestKey(7), createTestKey(8)})
+	testutil.AssertEquals(t, bookmark, "")
+	qe.Done()
+
+	// The range query info of a simulation ends at the last key read, as the page doesn't reach the end of the range
+	s, _ = txMgr.NewTxSimulator("test_tx3")
+	keys, _ = readPage(s, createTestKey(3))
+	testutil.AssertEquals(t, len(keys), 4)
+	s.Done()
+	txRWSet, _ = s.GetTxSimulationResults()
+	txRwSet, err := rwsetutil.TxRwSetFromProtoMsg(txRWSet.PubSimulationResults)
+	testutil.AssertNoError(t, err, "")
+	rangeQueryInfo := txRwSet.NsRwSets[0].KvRwSet.RangeQueriesInfo[0]
+	testutil.AssertEquals(t, rangeQueryInfo.StartKey, createTestKey(3))
+	testutil.AssertEquals(t, rangeQueryInfo.EndKey, createTestKey(6))
+	testutil.AssertEquals(t, rangeQueryInfo.ItrExhausted, false)
+}
+
 func TestIteratorWithDeletes(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Logf("Running test for TestEnv = %s", testEnv.getName())
@@ -731,6 +793,20 @@ func TestTxSimulatorUnsupportedTx(t *testing.T) {
 	err = simulator.SetState("ns", "key", []byte("value"))
 	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
 	testutil.AssertEquals(t, ok, true)
+
+	simulator, _ = txMgr.NewTxSimulator("txid3")
+	err = simulator.SetState("ns", "key", []byte("value"))
+	testutil.AssertNoError(t, err, "")
+	_, err = simulator.GetStateRangeScanIteratorWithMetadata("ns1", "startKey", "endKey", map[string]interface{}{"limit": int32(2)})
+	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
+	testutil.AssertEquals(t, ok, true)
+
+	simulator, _ = txMgr.NewTxSimulator("txid4")
+	_, err = simulator.GetStateRangeScanIteratorWithMetadata("ns1", "startKey", "endKey", map[string]interface{}{"limit": int32(2)})
+	testutil.AssertNoError(t, err, "")
+	err = simulator.SetState("ns", "key", []byte("value"))
+	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
+	testutil.AssertEquals(t, ok, true)
 }
 
 func TestTxSimulatorMissingPvtdata(t *testing.T) {
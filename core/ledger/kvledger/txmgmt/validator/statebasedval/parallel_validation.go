@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+)
+
+// blockWrites holds the keys written by the transactions of a block that have been scanned so far,
+// irrespective of whether these transactions turn out to be valid
+type blockWrites struct {
+	pubKeys    map[statedb.CompositeKey]struct{}
+	pubKeysNs  map[string][]string
+	hashedKeys map[privacyenabledstate.HashedCompositeKey]struct{}
+}
+
+func newBlockWrites() *blockWrites {
+	return &blockWrites{
+		pubKeys:    make(map[statedb.CompositeKey]struct{}),
+		pubKeysNs:  make(map[string][]string),
+		hashedKeys: make(map[privacyenabledstate.HashedCompositeKey]struct{}),
+	}
+}
+
+// computeDependentTxs returns, for each transaction of the block, whether the transaction reads, or range
+// queries, a key that is written by a preceding transaction of the block. The validation of a transaction
+// that is not dependent does not need the updates of the preceding valid transactions of the block, because
+// none of them can cause an mvcc or a phantom read conflict for this transaction
+func computeDependentTxs(block *valinternal.Block) []bool {
+	dependentTxs := make([]bool, len(block.Txs))
+	writes := newBlockWrites()
+	for i, tx := range block.Txs {
+		dependentTxs[i] = writes.conflictWith(tx)
+		writes.add(tx)
+	}
+	return dependentTxs
+}
+
+// conflictWith returns whether any of the reads of the given transaction is affected by the writes
+func (w *blockWrites) conflictWith(tx *valinternal.Transaction) bool {
+	for _, nsRWSet := range tx.RWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			if _, ok := w.pubKeys[statedb.CompositeKey{Namespace: ns, Key: kvRead.Key}]; ok {
+				return true
+			}
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			// the end key is treated as inclusive, which may only make a transaction dependent needlessly
+			for _, key := range w.pubKeysNs[ns] {
+				if key >= rqi.StartKey && (rqi.EndKey == "" || key <= rqi.EndKey) {
+					return true
+				}
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, kvReadHash := range collHashedRWSet.HashedRwSet.HashedReads {
+				hashedCompositeKey := privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(kvReadHash.KeyHash),
+				}
+				if _, ok := w.hashedKeys[hashedCompositeKey]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// add adds the writes of the given transaction
+func (w *blockWrites) add(tx *valinternal.Transaction) {
+	for _, nsRWSet := range tx.RWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			compositeKey := statedb.CompositeKey{Namespace: ns, Key: kvWrite.Key}
+			if _, ok := w.pubKeys[compositeKey]; !ok {
+				w.pubKeys[compositeKey] = struct{}{}
+				w.pubKeysNs[ns] = append(w.pubKeysNs[ns], kvWrite.Key)
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, kvWriteHash := range collHashedRWSet.HashedRwSet.HashedWrites {
+				w.hashedKeys[privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(kvWriteHash.KeyHash),
+				}] = struct{}{}
+			}
+		}
+	}
+}
+
+// validateIndependentTxs validates the transactions of the block that are not dependent on the preceding
+// transactions of the block against the committed state only, using up to 'v.parallelism' goroutines, and
+// sets their validation codes
+func (v *Validator) validateIndependentTxs(block *valinternal.Block, dependentTxs []bool) error {
+	// the updates are only read by the goroutines, and stay empty
+	noUpdates := valinternal.NewPubAndHashUpdates()
+	txIndexes := make(chan int)
+	errs := make(chan error, v.parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < v.parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for txIndex := range txIndexes {
+				tx := block.Txs[txIndex]
+				validationCode, err := v.validateTx(tx.RWSet, noUpdates)
+				if err != nil {
+					errs <- err
+					// drain the remaining transactions, such that the dispatch is not blocked
+					for range txIndexes {
+					}
+					return
+				}
+				tx.ValidationCode = validationCode
+			}
+		}()
+	}
+	for txIndex, dependent := range dependentTxs {
+		if !dependent {
+			txIndexes <- txIndex
+		}
+	}
+	close(txIndexes)
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDependentTxs(t *testing.T) {
+	// tx0 writes key1 and a private key
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToWriteSet("ns1", "key1", []byte("value1"))
+	rwsetBuilder0.AddToPvtAndHashedWriteSet("ns1", "coll1", "pvtKey1", []byte("pvtValue1"))
+	// tx1 reads key1 in another namespace and key2
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns2", "key1", version.NewHeight(1, 0))
+	rwsetBuilder1.AddToReadSet("ns1", "key2", version.NewHeight(1, 0))
+	// tx2 reads key1
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	// tx3 reads the hash of the private key
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToHashedReadSet("ns1", "coll1", "pvtKey1", version.NewHeight(1, 0))
+	// tx4 range queries a range that does not contain key1
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key2", EndKey: "key4", ItrExhausted: true})
+	// tx5 range queries a range that contains key1
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key0", EndKey: "key1", ItrExhausted: false})
+	// tx6 reads a key written by a preceding transaction that is not the first one
+	rwsetBuilder6 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder6.AddToReadSet("ns2", "key2", version.NewHeight(1, 0))
+	rwsetBuilder4.AddToWriteSet("ns2", "key2", []byte("value2"))
+
+	block := testBlock(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4, rwsetBuilder5, rwsetBuilder6)
+	assert.Equal(t, []bool{false, false, true, true, false, true, true}, computeDependentTxs(block))
+}
+
+func TestParallelValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 0; i < 10; i++ {
+		batch.PubUpdates.Put("ns1", fmt.Sprintf("key%d", i), []byte("value"), version.NewHeight(1, uint64(i)))
+	}
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 9))
+
+	var builders []*rwsetutil.RWSetBuilder
+	for i := 0; i < 10; i++ {
+		b := rwsetutil.NewRWSetBuilder()
+		b.AddToReadSet("ns1", fmt.Sprintf("key%d", i), version.NewHeight(1, uint64(i)))
+		b.AddToWriteSet("ns1", fmt.Sprintf("key%d", i), []byte("newValue"))
+		builders = append(builders, b)
+	}
+	// a transaction with a stale read, which is invalid
+	staleRead := rwsetutil.NewRWSetBuilder()
+	staleRead.AddToReadSet("ns1", "key9", version.NewHeight(1, 1))
+	staleRead.AddToWriteSet("ns1", "key10", []byte("value"))
+	// a transaction that reads a key written by a preceding invalid transaction, which is valid
+	readAfterInvalid := rwsetutil.NewRWSetBuilder()
+	readAfterInvalid.AddToReadSet("ns1", "key10", nil)
+	// a transaction that reads a key written by a preceding valid transaction
+	readAfterValid := rwsetutil.NewRWSetBuilder()
+	readAfterValid.AddToReadSet("ns1", "key5", version.NewHeight(1, 5))
+	builders = append(builders, staleRead, readAfterInvalid, readAfterValid)
+
+	validator := NewValidator(db)
+	validator.parallelism = 3
+	block := testBlock(t, builders...)
+	updates, err := validator.ValidateAndPrepareBatch(block, true)
+	assert.NoError(t, err)
+	var invalidTxs []int
+	for _, tx := range block.Txs {
+		if tx.ValidationCode != peer.TxValidationCode_VALID {
+			invalidTxs = append(invalidTxs, tx.IndexInBlock)
+		}
+	}
+	assert.Equal(t, []int{10, 12}, invalidTxs)
+	for i := 0; i < 10; i++ {
+		vv := updates.PubUpdates.Get("ns1", fmt.Sprintf("key%d", i))
+		assert.Equal(t, version.NewHeight(1, uint64(i)), vv.Version)
+	}
+	assert.False(t, updates.PubUpdates.Exists("ns1", "key10"))
+}
+
+func testBlock(t *testing.T, builders ...*rwsetutil.RWSetBuilder) *valinternal.Block {
+	var trans []*valinternal.Transaction
+	for i, txRWSet := range getTestPubSimulationRWSet(t, builders...) {
+		trans = append(trans, &valinternal.Transaction{
+			ID:             fmt.Sprintf("txid-%d", i),
+			IndexInBlock:   i,
+			ValidationCode: peer.TxValidationCode_VALID,
+			RWSet:          txRWSet,
+		})
+	}
+	return &valinternal.Block{Num: 1, Txs: trans}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -22,11 +23,18 @@ var logger = flogging.MustGetLogger("statebasedval")
 // and preceding valid transactions with in the same block
 type Validator struct {
 	db privacyenabledstate.DB
+	// parallelism is the number of goroutines that validate the transactions that do not depend
+	// on the preceding transactions of the block, which are validated serially if it is 0
+	parallelism int
 }
 
 // NewValidator constructs StateValidator
 func NewValidator(db privacyenabledstate.DB) *Validator {
-	return &Validator{db}
+	v := &Validator{db: db}
+	if ledgerconfig.IsParallelValidationEnabled() {
+		v.parallelism = ledgerconfig.GetParallelValidationWorkers()
+	}
+	return v
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -97,11 +105,24 @@ func (v *Validator) ValidateAndPrepareBatch(block *valinternal.Block, doMVCCVali
 		}
 	}
 
+	// The transactions that do not depend on the preceding transactions of the block are validated
+	// in parallel first. The loop below then validates the dependent ones in the order of the block,
+	// against the updates of all the preceding valid transactions, as if all were validated serially
+	var dependentTxs []bool
+	if doMVCCValidation && v.parallelism > 0 {
+		dependentTxs = computeDependentTxs(block)
+		if err := v.validateIndependentTxs(block, dependentTxs); err != nil {
+			return nil, err
+		}
+	}
+
 	updates := valinternal.NewPubAndHashUpdates()
-	for _, tx := range block.Txs {
+	for i, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if dependentTxs != nil && !dependentTxs[i] {
+			validationCode = tx.ValidationCode
+		} else if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
 			return nil, err
 		}
 
@@ -347,6 +347,15 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 }
 
 func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+	// the transactions are expected to be validated the same, serially and in parallel
+	for _, parallelism := range []int{0, 4} {
+		v := *val
+		v.parallelism = parallelism
+		checkValidationWithValidator(t, &v, transRWSets, expectedInvalidTxIndexes)
+	}
+}
+
+func checkValidationWithValidator(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
 	var trans []*valinternal.Transaction
 	for i, tranRWSet := range transRWSets {
 		tx := &valinternal.Transaction{
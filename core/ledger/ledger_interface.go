@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package ledger
 
 import (
+	"io"
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -21,6 +24,12 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot exported by a `SnapshotExporter` of the ledger
+	// of the channel of the given config block, which is expected to be the config block in effect as of the
+	// last block of the snapshot. The ledger starts with the state of the snapshot and receives the blocks
+	// following the last block of the snapshot, without the blocks up to that block being replayed.
+	// The first block the ledger receives must follow the last block of the snapshot, as recorded in the snapshot
+	CreateFromSnapshot(configBlock *common.Block, snapshot io.Reader) (PeerLedger, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -77,6 +86,101 @@ type PeerLedger interface {
 	GetConfigHistoryRetriever() (ConfigHistoryRetriever, error)
 }
 
+// SnapshotInfo describes a snapshot of the state of a ledger
+type SnapshotInfo struct {
+	LedgerID string
+	// LastBlockNum is the number of the last block whose transactions are reflected in the snapshot
+	LastBlockNum      uint64
+	LastBlockHash     []byte
+	PreviousBlockHash []byte
+	// StateHash is the SHA-256 hash of the key-values of the snapshot, which the snapshot ends with
+	StateHash []byte
+}
+
+// SnapshotExporter is implemented by the ledgers that can export a snapshot of their state, which
+// consists of the public state and the hashes of the private data, but not the private data itself
+type SnapshotExporter interface {
+	// ExportSnapshot writes a snapshot of the state of the ledger as of its last committed block
+	ExportSnapshot(w io.Writer) (*SnapshotInfo, error)
+}
+
+// HistoryPruningStatus describes the pruning of the history database of a ledger
+type HistoryPruningStatus struct {
+	// Running tells whether the history database is being pruned
+	Running bool
+	// LastStarted is when the last pruning started
+	LastStarted time.Time
+	// LastCompleted is when the last pruning completed
+	LastCompleted time.Time
+	// LastPrunedRecords is the number of history records deleted by the last completed pruning
+	LastPrunedRecords uint64
+	// TotalPrunedRecords is the number of history records deleted since the ledger was opened
+	TotalPrunedRecords uint64
+	// LastError is the error the last completed pruning failed with, if it failed
+	LastError string
+}
+
+// HistoryPruner is implemented by the ledgers whose history database can be pruned according
+// to a retention policy, which deletes the history records that are not retained by the policy
+type HistoryPruner interface {
+	// PruneHistory starts pruning the history database in the background
+	PruneHistory() error
+	// HistoryPruningStatus returns the status of the pruning of the history database
+	HistoryPruningStatus() (*HistoryPruningStatus, error)
+}
+
+// BootstrapInfoRetriever is implemented by the ledgers that can be created from a snapshot
+type BootstrapInfoRetriever interface {
+	// GetBootstrapInfo returns the info of the snapshot the ledger was created from, without its state hash, along
+	// with the config block the ledger was created with, which is not among the blocks of the ledger.
+	// It returns nils if the ledger was not created from a snapshot
+	GetBootstrapInfo() (*SnapshotInfo, *common.Block, error)
+}
+
+// DryRunCommitter is implemented by the ledgers which can validate a block against their
+// current state without committing it
+type DryRunCommitter interface {
+	// DryRunCommit performs the MVCC validation of the given block against the current state
+	// and sets the resulting validation flags in the metadata of the block, like CommitWithPvtData
+	// does, but neither commits the block nor changes the state
+	DryRunCommit(blockAndPvtdata *BlockAndPvtData) error
+}
+
+// ChaincodeEventsQuery selects the chaincode events indexed by a ledger
+type ChaincodeEventsQuery struct {
+	// ChaincodeName is the name of the chaincode that emitted the events
+	ChaincodeName string
+	// EventNamePattern is a regular expression the names of the events match. Empty matches all the names
+	EventNamePattern string
+	// StartBlock is the number of the first block the events are selected from
+	StartBlock uint64
+	// EndBlock is the number of the last block the events are selected from
+	EndBlock uint64
+	// TxID is the ID of the transaction that emitted the events. Empty selects the events of all the transactions
+	TxID string
+}
+
+// ChaincodeEventsQuerier is implemented by the ledgers that can index the events emitted by chaincodes,
+// such that historical events can be queried without replaying the blocks
+type ChaincodeEventsQuerier interface {
+	// QueryChaincodeEvents returns an iterator over the indexed events that match the given query, in the
+	// order they were committed. The iterator contains items of type *peer.ChaincodeEventRecord
+	QueryChaincodeEvents(query *ChaincodeEventsQuery) (commonledger.ResultsIterator, error)
+}
+
+// PrivateDataPurger is implemented by the ledgers that can purge the private values of individual keys on demand,
+// e.g., to honour a request for erasure, rather than only once the blockToLive of their collection is reached.
+// The hashes of the purged keys and values remain, as they are part of the state the peers of the channel agree on
+type PrivateDataPurger interface {
+	// PurgePrivateDataKeys purges the private values of the given keys of a collection from the state and from
+	// the private data of all the blocks right away
+	PurgePrivateDataKeys(ns, coll string, keys []string) error
+	// SchedulePrivateDataKeysPurge overrides the blockToLive of a collection for the given keys, such that their
+	// private values are purged with the commit of the block that is blockToLive blocks after the last committed
+	// block. The blockToLive of the collection still applies if it is reached earlier
+	SchedulePrivateDataKeysPurge(ns, coll string, keys []string, blockToLive uint64) error
+}
+
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
 // Post-v1
 type ValidatedLedger interface {
@@ -101,6 +205,12 @@ type QueryExecutor interface {
 	// can be supplied as empty strings. However, a full scan should be used judiciously for performance reasons.
 	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
 	GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error)
+	// GetStateRangeScanIteratorWithMetadata returns an iterator that contains the key-values between given key ranges,
+	// in the order of the keys, as bounded by the metadata of the query. The "limit" entry (int32) of the metadata is
+	// the maximum number of results to return. The bookmark of the returned iterator is the key the next page starts at.
+	// Transactions that perform such queries are read-only. startKey and endKey are as in GetStateRangeScanIterator.
+	// The returned QueryResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type specific to the underlying data store.
 	// Only used for state databases that support query
 	// For a chaincode, the namespace corresponds to the chaincodeId
@@ -127,6 +237,14 @@ type QueryExecutor interface {
 	Done()
 }
 
+// QueryResultsIterator is the iterator of a query whose results are limited, which can be resumed at its bookmark
+type QueryResultsIterator interface {
+	commonledger.ResultsIterator
+	// GetBookmarkAndClose returns the key the next page of the query starts at, which is empty
+	// if there are no more results, and releases the resources occupied by the iterator
+	GetBookmarkAndClose() string
+}
+
 // HistoryQueryExecutor executes the history queries
 type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
@@ -8,6 +8,8 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"runtime"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
@@ -27,12 +29,24 @@ const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
 const confHistoryLeveldb = "historyLeveldb"
+const confEventIndexLeveldb = "eventIndexLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
+const confFileLock = "fileLock"
 const confQueryLimit = "ledger.state.couchDBConfig.queryLimit"
+const confEnableBlockArchive = "ledger.blockchain.archive.enabled"
+const confBlockArchiveRetainBlocks = "ledger.blockchain.archive.retainBlocks"
+const confBlockArchiveMaxCachedFiles = "ledger.blockchain.archive.maxCachedFiles"
+const confBlockArchiveObjectStore = "ledger.blockchain.archive.objectStore"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
+const confHistoryRetentionMaxVersions = "ledger.history.retention.maxVersions"
+const confHistoryRetentionMaxAge = "ledger.history.retention.maxAge"
+const confHistoryPruneInterval = "ledger.history.retention.pruneInterval"
+const confEnableEventIndex = "ledger.eventIndex.enabled"
+const confEnableParallelValidation = "ledger.state.parallelValidation.enabled"
+const confParallelValidationWorkers = "ledger.state.parallelValidation.workers"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
@@ -59,6 +73,11 @@ func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
 }
 
+// GetEventIndexLevelDBPath returns the filesystem path that is used to maintain the index of chaincode events
+func GetEventIndexLevelDBPath() string {
+	return filepath.Join(GetRootPath(), confEventIndexLeveldb)
+}
+
 // GetBlockStorePath returns the filesystem path that is used for the chain block stores
 func GetBlockStorePath() string {
 	return filepath.Join(GetRootPath(), confChains)
@@ -79,11 +98,72 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
+// GetFileLockPath returns the filesystem path that is used to lock the ledger data, such that a peer node
+// doesn't start while a command that modifies the ledger data offline runs, and the other way around
+func GetFileLockPath() string {
+	return filepath.Join(GetRootPath(), confFileLock)
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+// IsBlockArchiveEnabled returns whether the block files are archived to an object store
+func IsBlockArchiveEnabled() bool {
+	return viper.GetBool(confEnableBlockArchive)
+}
+
+// GetBlockArchiveRetainBlocks returns the number of most recent blocks that are never archived
+func GetBlockArchiveRetainBlocks() uint64 {
+	// if retainBlocks was unset, default to 10000
+	if !viper.IsSet(confBlockArchiveRetainBlocks) {
+		return 10000
+	}
+	retainBlocks := viper.GetInt(confBlockArchiveRetainBlocks)
+	if retainBlocks < 0 {
+		retainBlocks = 0
+	}
+	return uint64(retainBlocks)
+}
+
+// GetBlockArchiveMaxCachedFiles returns the number of archived block files that are kept locally once fetched
+func GetBlockArchiveMaxCachedFiles() int {
+	// if maxCachedFiles was unset, default to 2
+	if !viper.IsSet(confBlockArchiveMaxCachedFiles) {
+		return 2
+	}
+	maxCachedFiles := viper.GetInt(confBlockArchiveMaxCachedFiles)
+	if maxCachedFiles < 0 {
+		maxCachedFiles = 0
+	}
+	return maxCachedFiles
+}
+
+// ObjectStoreConfig is the configuration of the S3-compatible object store the block files are archived to
+type ObjectStoreConfig struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Prefix          string
+	Timeout         time.Duration
+}
+
+// GetBlockArchiveObjectStoreConfig returns the configuration of the object store the block files are archived to
+func GetBlockArchiveObjectStoreConfig() *ObjectStoreConfig {
+	return &ObjectStoreConfig{
+		Endpoint:        viper.GetString(confBlockArchiveObjectStore + ".endpoint"),
+		Bucket:          viper.GetString(confBlockArchiveObjectStore + ".bucket"),
+		Region:          viper.GetString(confBlockArchiveObjectStore + ".region"),
+		AccessKeyID:     viper.GetString(confBlockArchiveObjectStore + ".accessKeyID"),
+		SecretAccessKey: viper.GetString(confBlockArchiveObjectStore + ".secretAccessKey"),
+		Prefix:          viper.GetString(confBlockArchiveObjectStore + ".prefix"),
+		Timeout:         viper.GetDuration(confBlockArchiveObjectStore + ".timeout"),
+	}
+}
+
 //GetQueryLimit exposes the queryLimit variable
 func GetQueryLimit() int {
 	queryLimit := viper.GetInt(confQueryLimit)
@@ -104,6 +184,23 @@ func GetMaxBatchUpdateSize() int {
 	return maxBatchUpdateSize
 }
 
+// IsParallelValidationEnabled returns whether the transactions of a block that do not depend on the
+// preceding transactions of the block are validated in parallel during the commit of the block
+func IsParallelValidationEnabled() bool {
+	return viper.GetBool(confEnableParallelValidation)
+}
+
+// GetParallelValidationWorkers returns the number of goroutines that validate the transactions of a block
+// in parallel, when the parallel validation is enabled
+func GetParallelValidationWorkers() int {
+	workers := viper.GetInt(confParallelValidationWorkers)
+	// if workers was unset or invalid, default to the number of CPUs
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return workers
+}
+
 // GetPvtdataStorePurgeInterval returns the interval in the terms of number of blocks
 // when the purge for the expired data would be performed
 func GetPvtdataStorePurgeInterval() uint64 {
@@ -119,6 +216,45 @@ func IsHistoryDBEnabled() bool {
 	return viper.GetBool(confEnableHistoryDatabase)
 }
 
+// IsEventIndexEnabled tells whether the events emitted by chaincodes are indexed
+func IsEventIndexEnabled() bool {
+	return viper.GetBool(confEnableEventIndex)
+}
+
+// GetHistoryRetentionMaxVersions returns the number of most recent versions of each key
+// that are retained when the history database is pruned. Zero means unset
+func GetHistoryRetentionMaxVersions() int {
+	maxVersions := viper.GetInt(confHistoryRetentionMaxVersions)
+	if maxVersions < 0 {
+		maxVersions = 0
+	}
+	return maxVersions
+}
+
+// GetHistoryRetentionMaxAge returns how long the versions of each key are retained
+// when the history database is pruned. Zero means unset
+func GetHistoryRetentionMaxAge() time.Duration {
+	maxAge := viper.GetDuration(confHistoryRetentionMaxAge)
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	return maxAge
+}
+
+// GetHistoryPruneInterval returns the interval at which the history database is pruned
+// according to the retention policy. Zero means that it's only pruned on demand
+func GetHistoryPruneInterval() time.Duration {
+	// if pruneInterval was unset, default to 24 hours
+	if !viper.IsSet(confHistoryPruneInterval) {
+		return 24 * time.Hour
+	}
+	pruneInterval := viper.GetDuration(confHistoryPruneInterval)
+	if pruneInterval < 0 {
+		pruneInterval = 0
+	}
+	return pruneInterval
+}
+
 // IsQueryReadsHashingEnabled enables or disables computing of hash
 // of range query results for phantom item validation
 func IsQueryReadsHashingEnabled() bool {
@@ -17,7 +17,9 @@ limitations under the License.
 package ledgerconfig
 
 import (
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -60,6 +62,9 @@ func TestLedgerConfigPathDefault(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetHistoryLevelDBPath(),
 		"/var/hyperledger/production/ledgersData/historyLeveldb")
+	testutil.AssertEquals(t,
+		GetEventIndexLevelDBPath(),
+		"/var/hyperledger/production/ledgersData/eventIndexLeveldb")
 	testutil.AssertEquals(t,
 		GetBlockStorePath(),
 		"/var/hyperledger/production/ledgersData/chains")
@@ -69,6 +74,9 @@ func TestLedgerConfigPathDefault(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetInternalBookkeeperPath(),
 		"/var/hyperledger/production/ledgersData/bookkeeper")
+	testutil.AssertEquals(t,
+		GetFileLockPath(),
+		"/var/hyperledger/production/ledgersData/fileLock")
 
 }
 
@@ -88,6 +96,9 @@ func TestLedgerConfigPath(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetHistoryLevelDBPath(),
 		"/tmp/hyperledger/production/ledgersData/historyLeveldb")
+	testutil.AssertEquals(t,
+		GetEventIndexLevelDBPath(),
+		"/tmp/hyperledger/production/ledgersData/eventIndexLeveldb")
 	testutil.AssertEquals(t,
 		GetBlockStorePath(),
 		"/tmp/hyperledger/production/ledgersData/chains")
@@ -97,6 +108,9 @@ func TestLedgerConfigPath(t *testing.T) {
 	testutil.AssertEquals(t,
 		GetInternalBookkeeperPath(),
 		"/tmp/hyperledger/production/ledgersData/bookkeeper")
+	testutil.AssertEquals(t,
+		GetFileLockPath(),
+		"/tmp/hyperledger/production/ledgersData/fileLock")
 }
 
 func TestGetQueryLimitDefault(t *testing.T) {
@@ -189,6 +203,103 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, false) //test config returns false
 }
 
+func TestIsEventIndexEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	testutil.AssertEquals(t, IsEventIndexEnabled(), false) //test default config is false
+	viper.Set("ledger.eventIndex.enabled", true)
+	testutil.AssertEquals(t, IsEventIndexEnabled(), true)
+}
+
+func TestHistoryRetentionDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	testutil.AssertEquals(t, GetHistoryRetentionMaxVersions(), 0)
+	testutil.AssertEquals(t, GetHistoryRetentionMaxAge(), time.Duration(0))
+	testutil.AssertEquals(t, GetHistoryPruneInterval(), 24*time.Hour)
+}
+
+func TestHistoryRetentionUnset(t *testing.T) {
+	viper.Reset()
+	testutil.AssertEquals(t, GetHistoryRetentionMaxVersions(), 0)
+	testutil.AssertEquals(t, GetHistoryRetentionMaxAge(), time.Duration(0))
+	testutil.AssertEquals(t, GetHistoryPruneInterval(), 24*time.Hour) // 24 hours if pruneInterval is not set
+}
+
+func TestHistoryRetention(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.history.retention.maxVersions", 10)
+	viper.Set("ledger.history.retention.maxAge", "720h")
+	viper.Set("ledger.history.retention.pruneInterval", "1h")
+	testutil.AssertEquals(t, GetHistoryRetentionMaxVersions(), 10)
+	testutil.AssertEquals(t, GetHistoryRetentionMaxAge(), 720*time.Hour)
+	testutil.AssertEquals(t, GetHistoryPruneInterval(), time.Hour)
+
+	// negative values are treated as unset
+	viper.Set("ledger.history.retention.maxVersions", -1)
+	viper.Set("ledger.history.retention.maxAge", "-1h")
+	viper.Set("ledger.history.retention.pruneInterval", "-1h")
+	testutil.AssertEquals(t, GetHistoryRetentionMaxVersions(), 0)
+	testutil.AssertEquals(t, GetHistoryRetentionMaxAge(), time.Duration(0))
+	testutil.AssertEquals(t, GetHistoryPruneInterval(), time.Duration(0))
+}
+
+func TestBlockArchiveDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	testutil.AssertEquals(t, IsBlockArchiveEnabled(), false)
+	testutil.AssertEquals(t, GetBlockArchiveRetainBlocks(), uint64(10000))
+	testutil.AssertEquals(t, GetBlockArchiveMaxCachedFiles(), 2)
+	testutil.AssertEquals(t, GetBlockArchiveObjectStoreConfig().Timeout, 5*time.Minute)
+}
+
+func TestBlockArchiveUnset(t *testing.T) {
+	viper.Reset()
+	testutil.AssertEquals(t, IsBlockArchiveEnabled(), false)
+	testutil.AssertEquals(t, GetBlockArchiveRetainBlocks(), uint64(10000))
+	testutil.AssertEquals(t, GetBlockArchiveMaxCachedFiles(), 2)
+}
+
+func TestBlockArchive(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.blockchain.archive.enabled", true)
+	viper.Set("ledger.blockchain.archive.retainBlocks", 100)
+	viper.Set("ledger.blockchain.archive.maxCachedFiles", 1)
+	viper.Set("ledger.blockchain.archive.objectStore.endpoint", "https://storage.googleapis.com")
+	viper.Set("ledger.blockchain.archive.objectStore.bucket", "ledgers")
+	testutil.AssertEquals(t, IsBlockArchiveEnabled(), true)
+	testutil.AssertEquals(t, GetBlockArchiveRetainBlocks(), uint64(100))
+	testutil.AssertEquals(t, GetBlockArchiveMaxCachedFiles(), 1)
+	objectStoreConfig := GetBlockArchiveObjectStoreConfig()
+	testutil.AssertEquals(t, objectStoreConfig.Endpoint, "https://storage.googleapis.com")
+	testutil.AssertEquals(t, objectStoreConfig.Bucket, "ledgers")
+
+	// negative values are treated as 0
+	viper.Set("ledger.blockchain.archive.retainBlocks", -1)
+	viper.Set("ledger.blockchain.archive.maxCachedFiles", -1)
+	testutil.AssertEquals(t, GetBlockArchiveRetainBlocks(), uint64(0))
+	testutil.AssertEquals(t, GetBlockArchiveMaxCachedFiles(), 0)
+}
+
+func TestParallelValidationDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	testutil.AssertEquals(t, IsParallelValidationEnabled(), false)
+	testutil.AssertEquals(t, GetParallelValidationWorkers(), runtime.NumCPU())
+}
+
+func TestParallelValidation(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.parallelValidation.enabled", true)
+	viper.Set("ledger.state.parallelValidation.workers", 3)
+	testutil.AssertEquals(t, IsParallelValidationEnabled(), true)
+	testutil.AssertEquals(t, GetParallelValidationWorkers(), 3)
+
+	// negative values are treated as unset
+	viper.Set("ledger.state.parallelValidation.workers", -1)
+	testutil.AssertEquals(t, GetParallelValidationWorkers(), runtime.NumCPU())
+}
+
 func TestIsAutoWarmIndexesEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsAutoWarmIndexesEnabled()
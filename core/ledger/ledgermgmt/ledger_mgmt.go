@@ -18,6 +18,7 @@ package ledgermgmt
 
 import (
 	"errors"
+	"io"
 	"sync"
 
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -25,6 +26,7 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
@@ -96,6 +98,181 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from a snapshot, which was exported by function `ExportSnapshot`,
+// of the ledger of the channel of the given config block, instead of committing all of its blocks. The config
+// block is expected to be the config block in effect as of the last block of the snapshot
+func CreateLedgerFromSnapshot(configBlock *common.Block, snapshot io.Reader) (ledger.PeerLedger, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+
+	id, err := utils.GetChainIDFromBlock(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Creating ledger [%s] from snapshot with config block [%d]", id, configBlock.Header.Number)
+	l, err := ledgerProvider.CreateFromSnapshot(configBlock, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	l = wrapLedger(id, l)
+	openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot", id)
+	return l, nil
+}
+
+// ExportSnapshot writes a snapshot of the state of the opened ledger with the given id.
+// Blocks are not committed to the ledger while the snapshot is being exported
+func ExportSnapshot(id string, w io.Writer) (*ledger.SnapshotInfo, error) {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return nil, kvledger.ErrLedgerNotOpened
+	}
+	exporter, ok := l.(*closableLedger).PeerLedger.(ledger.SnapshotExporter)
+	if !ok {
+		return nil, fmt.Errorf("ledger [%s] does not support exporting snapshots", id)
+	}
+	logger.Infof("Exporting snapshot of ledger [%s]", id)
+	return exporter.ExportSnapshot(w)
+}
+
+// ReadSnapshot reads a whole snapshot exported by function `ExportSnapshot`, verifying it against its state hash,
+// and returns the info of the snapshot. The given function, if not nil, is invoked with each key-value of the snapshot
+func ReadSnapshot(snapshot io.Reader, visit func(namespace, key string, value []byte) error) (*ledger.SnapshotInfo, error) {
+	return kvledger.ReadSnapshot(snapshot, visit)
+}
+
+// GetBootstrapInfo returns the info of the snapshot the opened ledger with the given id was created from, along
+// with the config block it was created with. It returns nils if the ledger was not created from a snapshot
+func GetBootstrapInfo(id string) (*ledger.SnapshotInfo, *common.Block, error) {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return nil, nil, ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return nil, nil, kvledger.ErrLedgerNotOpened
+	}
+	retriever, ok := l.(*closableLedger).PeerLedger.(ledger.BootstrapInfoRetriever)
+	if !ok {
+		return nil, nil, nil
+	}
+	return retriever.GetBootstrapInfo()
+}
+
+// PruneHistory starts pruning the history database of the opened ledger with the given id in the
+// background, according to the retention policy of the history database
+func PruneHistory(id string) error {
+	pruner, err := historyPrunerOf(id)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Pruning history database of ledger [%s]", id)
+	return pruner.PruneHistory()
+}
+
+// GetHistoryPruningStatus returns the status of the pruning of the history database of the opened ledger with the given id
+func GetHistoryPruningStatus(id string) (*ledger.HistoryPruningStatus, error) {
+	pruner, err := historyPrunerOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return pruner.HistoryPruningStatus()
+}
+
+func historyPrunerOf(id string) (ledger.HistoryPruner, error) {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return nil, kvledger.ErrLedgerNotOpened
+	}
+	pruner, ok := l.(*closableLedger).PeerLedger.(ledger.HistoryPruner)
+	if !ok {
+		return nil, fmt.Errorf("ledger [%s] does not support pruning its history database", id)
+	}
+	return pruner, nil
+}
+
+// DryRunCommit performs the MVCC validation of the given block against the current state of the
+// opened ledger with the given id, and sets the resulting validation flags in the metadata of the
+// block, without committing the block
+func DryRunCommit(id string, blockAndPvtdata *ledger.BlockAndPvtData) error {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return kvledger.ErrLedgerNotOpened
+	}
+	committer, ok := l.(*closableLedger).PeerLedger.(ledger.DryRunCommitter)
+	if !ok {
+		return fmt.Errorf("ledger [%s] does not support dry runs of commits", id)
+	}
+	return committer.DryRunCommit(blockAndPvtdata)
+}
+
+// QueryChaincodeEvents returns an iterator over the chaincode events indexed by the opened ledger with
+// the given id that match the given query
+func QueryChaincodeEvents(id string, query *ledger.ChaincodeEventsQuery) (commonledger.ResultsIterator, error) {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return nil, kvledger.ErrLedgerNotOpened
+	}
+	querier, ok := l.(*closableLedger).PeerLedger.(ledger.ChaincodeEventsQuerier)
+	if !ok {
+		return nil, fmt.Errorf("ledger [%s] does not support querying chaincode events", id)
+	}
+	return querier.QueryChaincodeEvents(query)
+}
+
+// PurgePrivateDataKeys purges the private values of the given keys of a collection of the opened ledger with the
+// given id right away if blockToLive is zero, or otherwise with the commit of the block that is blockToLive blocks
+// after the last committed block
+func PurgePrivateDataKeys(id, ns, coll string, keys []string, blockToLive uint64) error {
+	lock.Lock()
+	if !initialized {
+		lock.Unlock()
+		return ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	lock.Unlock()
+	if !ok {
+		return kvledger.ErrLedgerNotOpened
+	}
+	purger, ok := l.(*closableLedger).PeerLedger.(ledger.PrivateDataPurger)
+	if !ok {
+		return fmt.Errorf("ledger [%s] does not support purging private data keys", id)
+	}
+	if blockToLive == 0 {
+		return purger.PurgePrivateDataKeys(ns, coll, keys)
+	}
+	return purger.SchedulePrivateDataKeysPurge(ns, coll, keys, blockToLive)
+}
+
 // OpenLedger returns a ledger for the given id
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
@@ -128,6 +305,29 @@ func GetLedgerIDs() ([]string, error) {
 	return ledgerProvider.List()
 }
 
+// RollbackLedger rolls back the ledger with the given id to the given block number. Unlike the other
+// functions of this package, this function is expected to be invoked while the ledger management is not
+// initialized, i.e. while the peer is offline
+func RollbackLedger(id string, blockNum uint64) error {
+	logger.Infof("Rolling back ledger [%s] to block number [%d]", id, blockNum)
+	return kvledger.RollbackKVLedger(id, blockNum)
+}
+
+// ResetLedgers rolls back all the ledgers to their genesis blocks. As function 'RollbackLedger',
+// this function is expected to be invoked while the peer is offline
+func ResetLedgers() error {
+	logger.Info("Resetting all the ledgers to their genesis blocks")
+	return kvledger.ResetAllKVLedgers()
+}
+
+// RebuildDBs drops the databases of all the ledgers that are derived from the block stores, such that
+// they are rebuilt when the peer starts. As function 'RollbackLedger', this function is expected to be
+// invoked while the peer is offline
+func RebuildDBs() error {
+	logger.Info("Dropping the databases of all the ledgers to rebuild them from the block stores")
+	return kvledger.RebuildDBs()
+}
+
 // Close closes all the opened ledgers and any resources held for ledger management
 func Close() {
 	logger.Infof("Closing ledger mgmt")
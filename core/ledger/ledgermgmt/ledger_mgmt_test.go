@@ -21,10 +21,12 @@ import (
 	"testing"
 
 	"os"
+	"time"
 
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/spf13/viper"
 )
 
@@ -91,6 +93,69 @@ func TestLedgerMgmt(t *testing.T) {
 	Close()
 }
 
+func TestHistoryPruning(t *testing.T) {
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	viper.Set("ledger.history.retention.maxVersions", 1)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+	defer viper.Set("ledger.history.retention.maxVersions", 0)
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+
+	ledgerID := constructTestLedgerID(0)
+	testutil.AssertEquals(t, PruneHistory(ledgerID), kvledger.ErrLedgerNotOpened)
+
+	gb, _ := test.MakeGenesisBlock(ledgerID)
+	_, err := CreateLedger(gb)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNoError(t, PruneHistory(ledgerID), "")
+	status, err := GetHistoryPruningStatus(ledgerID)
+	testutil.AssertNoError(t, err, "")
+	for i := 0; i < 500 && status.Running; i++ {
+		time.Sleep(10 * time.Millisecond)
+		status, err = GetHistoryPruningStatus(ledgerID)
+		testutil.AssertNoError(t, err, "")
+	}
+	testutil.AssertEquals(t, status.Running, false)
+	testutil.AssertEquals(t, status.LastError, "")
+	testutil.AssertEquals(t, status.LastPrunedRecords, uint64(0))
+}
+
+func TestDryRunCommit(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+
+	ledgerID := constructTestLedgerID(0)
+	testutil.AssertEquals(t, DryRunCommit(ledgerID, &ledger.BlockAndPvtData{}), kvledger.ErrLedgerNotOpened)
+
+	gb, _ := test.MakeGenesisBlock(ledgerID)
+	l, err := CreateLedger(gb)
+	testutil.AssertNoError(t, err, "")
+	simulator, _ := l.NewTxSimulator("txid")
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimBytes, _ := simRes.GetPubSimulationBytes()
+	block := testutil.ConstructBlock(t, 1, gb.Header.Hash(), [][]byte{pubSimBytes}, false)
+	testutil.AssertNoError(t, DryRunCommit(ledgerID, &ledger.BlockAndPvtData{Block: block}), "")
+	bcInfo, _ := l.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(1))
+}
+
+func TestPurgePrivateDataKeys(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+
+	ledgerID := constructTestLedgerID(0)
+	testutil.AssertEquals(t, PurgePrivateDataKeys(ledgerID, "ns1", "coll1", []string{"key1"}, 0), kvledger.ErrLedgerNotOpened)
+
+	gb, _ := test.MakeGenesisBlock(ledgerID)
+	_, err := CreateLedger(gb)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNoError(t, PurgePrivateDataKeys(ledgerID, "ns1", "coll1", []string{"key1"}, 0), "")
+	testutil.AssertNoError(t, PurgePrivateDataKeys(ledgerID, "ns1", "coll1", []string{"key1"}, 5), "")
+	testutil.AssertError(t, PurgePrivateDataKeys(ledgerID, "ns1", "coll1", nil, 5), "")
+}
+
 func constructTestLedgerID(i int) string {
 	return fmt.Sprintf("ledger_%06d", i)
 }
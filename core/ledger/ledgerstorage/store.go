@@ -55,9 +55,26 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrTxValidationCode,
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig)
+	blockStoreConf := fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+	if ledgerconfig.IsBlockArchiveEnabled() {
+		objectStoreConfig := ledgerconfig.GetBlockArchiveObjectStoreConfig()
+		archiveStore := fsblkstorage.NewS3ArchiveStore(&fsblkstorage.S3Conf{
+			Endpoint:        objectStoreConfig.Endpoint,
+			Bucket:          objectStoreConfig.Bucket,
+			Region:          objectStoreConfig.Region,
+			AccessKeyID:     objectStoreConfig.AccessKeyID,
+			SecretAccessKey: objectStoreConfig.SecretAccessKey,
+			Prefix:          objectStoreConfig.Prefix,
+			Timeout:         objectStoreConfig.Timeout,
+		})
+		blockStoreConf = fsblkstorage.NewConfWithArchive(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(),
+			&fsblkstorage.ArchiveConf{
+				Store:          archiveStore,
+				RetainBlocks:   ledgerconfig.GetBlockArchiveRetainBlocks(),
+				MaxCachedFiles: ledgerconfig.GetBlockArchiveMaxCachedFiles(),
+			})
+	}
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf, indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
 	return &Provider{blockStoreProvider, pvtStoreProvider}
@@ -82,6 +99,24 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// Bootstrap records that the store for given ledgerid starts after the last block of a snapshot.
+// This method should be invoked before the store is opened for the first time, which brings the
+// pvtdata store upto the last block of the snapshot
+func (p *Provider) Bootstrap(ledgerid string, info *blkstorage.BootstrapInfo) error {
+	return p.blkStoreProvider.BootstrapBlockStore(ledgerid, info)
+}
+
+// Rollback removes the blocks after the given block number, along with their pvt data, from the store
+// for given ledgerid. The store is expected to be closed.
+// The pvt data store is rolled back first, as rolling it back again is a no-op, so that a rollback
+// that fails midway can be retried
+func (p *Provider) Rollback(ledgerid string, blockNum uint64) error {
+	if err := p.pvtdataStoreProvider.Rollback(ledgerid, blockNum); err != nil {
+		return err
+	}
+	return p.blkStoreProvider.Rollback(ledgerid, blockNum)
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -150,6 +185,30 @@ func (s *Store) getPvtDataByNumWithoutLock(blockNum uint64, filter ledger.PvtNsC
 	return pvtdata, nil
 }
 
+// PurgePvtDataKeys removes the writes of the given keys from the pvt data of all the blocks
+func (s *Store) PurgePvtDataKeys(toPurge *pvtdatastorage.KeysToPurge) error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.PurgeKeys(toPurge)
+}
+
+// SchedulePvtDataKeysPurge records that the given keys are to be purged with the commit of the block `purgeAtBlk`
+func (s *Store) SchedulePvtDataKeysPurge(toPurge *pvtdatastorage.KeysToPurge, purgeAtBlk uint64) error {
+	return s.pvtdataStore.SchedulePurge(toPurge, purgeAtBlk)
+}
+
+// GetScheduledPvtDataKeysPurges returns the keys that are scheduled to be purged with the commit of the blocks
+// up to the block `maxBlkNum`
+func (s *Store) GetScheduledPvtDataKeysPurges(maxBlkNum uint64) ([]*pvtdatastorage.KeysToPurge, error) {
+	return s.pvtdataStore.GetScheduledPurges(maxBlkNum)
+}
+
+// ClearScheduledPvtDataKeysPurges removes the schedules of the keys to be purged with the commit of the blocks
+// up to the block `maxBlkNum`
+func (s *Store) ClearScheduledPvtDataKeysPurges(maxBlkNum uint64) error {
+	return s.pvtdataStore.ClearScheduledPurges(maxBlkNum)
+}
+
 // init first invokes function `initFromExistingBlockchain`
 // in order to check whether the pvtdata store is present because of an upgrade
 // of peer from 1.0 and need to be updated with the existing blockchain. If, this is
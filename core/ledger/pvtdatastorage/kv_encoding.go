@@ -10,15 +10,17 @@ import (
 	"bytes"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 )
 
 var (
-	pendingCommitKey    = []byte{0}
-	lastCommittedBlkkey = []byte{1}
-	pvtDataKeyPrefix    = []byte{2}
-	expiryKeyPrefix     = []byte{3}
+	pendingCommitKey       = []byte{0}
+	lastCommittedBlkkey    = []byte{1}
+	pvtDataKeyPrefix       = []byte{2}
+	expiryKeyPrefix        = []byte{3}
+	purgeScheduleKeyPrefix = []byte{4}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -36,6 +38,28 @@ func getExpiryKeysForRangeScan(minBlkNum, maxBlkNum uint64) (startKey, endKey []
 	return
 }
 
+// getDataKeysForRangeScanFromBlockNum returns the range of the data keys of the given block and of the later ones
+func getDataKeysForRangeScanFromBlockNum(blockNum uint64) (startKey, endKey []byte) {
+	startKey = append(pvtDataKeyPrefix, version.NewHeight(blockNum, 0).ToBytes()...)
+	endKey = append(pvtDataKeyPrefix, 0xff)
+	return
+}
+
+// getAllExpiryKeysForRangeScan returns the range of all the expiry keys
+func getAllExpiryKeysForRangeScan() (startKey, endKey []byte) {
+	startKey = expiryKeyPrefix
+	endKey = append(expiryKeyPrefix, 0xff)
+	return
+}
+
+// getPurgeScheduleKeysForRangeScan returns the range of the keys scheduled to be purged with the commit
+// of the blocks up to the given block number
+func getPurgeScheduleKeysForRangeScan(maxBlkNum uint64) (startKey, endKey []byte) {
+	startKey = purgeScheduleKeyPrefix
+	endKey = append(purgeScheduleKeyPrefix, util.EncodeOrderPreservingVarUint64(maxBlkNum+1)...)
+	return
+}
+
 func encodeLastCommittedBlockVal(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
@@ -87,6 +111,29 @@ func decodeDatakey(datakeyBytes []byte) *dataKey {
 	return &dataKey{blkNum: blkNum, txNum: tranNum, ns: ns, coll: coll}
 }
 
+// encodePurgeScheduleKey encodes the key of a schedule entry as purgeAtBlk~ns~coll~key, so that the entries
+// are ordered by the block they are purged with. The key comes last as it may contain nil bytes
+func encodePurgeScheduleKey(purgeAtBlk uint64, ns, coll, key string) []byte {
+	scheduleKeyBytes := append(purgeScheduleKeyPrefix, util.EncodeOrderPreservingVarUint64(purgeAtBlk)...)
+	scheduleKeyBytes = append(scheduleKeyBytes, []byte(ns)...)
+	scheduleKeyBytes = append(scheduleKeyBytes, nilByte)
+	scheduleKeyBytes = append(scheduleKeyBytes, []byte(coll)...)
+	scheduleKeyBytes = append(scheduleKeyBytes, nilByte)
+	return append(scheduleKeyBytes, []byte(key)...)
+}
+
+func decodePurgeScheduleKey(scheduleKeyBytes []byte) (purgeAtBlk uint64, ns, coll, key string) {
+	purgeAtBlk, n := util.DecodeOrderPreservingVarUint64(scheduleKeyBytes[1:])
+	remainingBytes := scheduleKeyBytes[n+1:]
+	nilByteIndex := bytes.IndexByte(remainingBytes, nilByte)
+	ns = string(remainingBytes[:nilByteIndex])
+	remainingBytes = remainingBytes[nilByteIndex+1:]
+	nilByteIndex = bytes.IndexByte(remainingBytes, nilByte)
+	coll = string(remainingBytes[:nilByteIndex])
+	key = string(remainingBytes[nilByteIndex+1:])
+	return
+}
+
 func decodeDataValue(datavalueBytes []byte) (*rwset.CollectionPvtReadWriteSet, error) {
 	collPvtdata := &rwset.CollectionPvtReadWriteSet{}
 	err := proto.Unmarshal(datavalueBytes, collPvtdata)
@@ -17,3 +17,11 @@ func TestDataKeyEncoding(t *testing.T) {
 	datakey2 := decodeDatakey(encodeDataKey(dataKey1))
 	assert.Equal(t, dataKey1, datakey2)
 }
+
+func TestPurgeScheduleKeyEncoding(t *testing.T) {
+	purgeAtBlk, ns, coll, key := decodePurgeScheduleKey(encodePurgeScheduleKey(300, "ns1", "coll1", "\x00composite\x00key\x00"))
+	assert.Equal(t, uint64(300), purgeAtBlk)
+	assert.Equal(t, "ns1", ns)
+	assert.Equal(t, "coll1", coll)
+	assert.Equal(t, "\x00composite\x00key\x00", key)
+}
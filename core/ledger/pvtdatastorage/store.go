@@ -15,6 +15,9 @@ import (
 // private write sets for a ledger
 type Provider interface {
 	OpenStore(id string) (Store, error)
+	// Rollback removes the pvt data of the blocks after the given block number from the store
+	// for the given ledger id, which is expected to be shut down
+	Rollback(id string, blockNum uint64) error
 	Close()
 }
 
@@ -59,10 +62,28 @@ type Store interface {
 	LastCommittedBlockHeight() (uint64, error)
 	// HasPendingBatch returns if the store has a pending batch
 	HasPendingBatch() (bool, error)
+	// PurgeKeys removes the writes of the given keys from the stored pvt data of all the blocks, ahead of
+	// the expiry of the pvt data. The rest of the pvt data of the transactions that wrote these keys is retained
+	PurgeKeys(toPurge *KeysToPurge) error
+	// SchedulePurge records that the given keys are to be purged with the commit of the block `purgeAtBlk`.
+	// The store keeps the schedule, while purging the keys is left to the caller
+	SchedulePurge(toPurge *KeysToPurge, purgeAtBlk uint64) error
+	// GetScheduledPurges returns the keys that are scheduled to be purged with the commit of the blocks
+	// up to the block `maxBlkNum`
+	GetScheduledPurges(maxBlkNum uint64) ([]*KeysToPurge, error)
+	// ClearScheduledPurges removes the schedules of the keys to be purged with the commit of the blocks
+	// up to the block `maxBlkNum`, once these keys are purged
+	ClearScheduledPurges(maxBlkNum uint64) error
 	// Shutdown stops the store
 	Shutdown()
 }
 
+// KeysToPurge are keys of a collection whose pvt data is purged on demand
+type KeysToPurge struct {
+	Ns, Coll string
+	Keys     []string
+}
+
 // ErrIllegalCall is to be thrown by a store impl if the store does not expect a call to Prepare/Commit/Rollback/InitLastCommittedBlock
 type ErrIllegalCall struct {
 	msg string
@@ -10,12 +10,14 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 )
 
 var logger = flogging.MustGetLogger("pvtdatastorage")
@@ -78,6 +80,36 @@ func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	return s, nil
 }
 
+// Rollback implements the function in the interface `Provider`
+func (p *provider) Rollback(ledgerid string, blockNum uint64) error {
+	db := p.dbProvider.GetDBHandle(ledgerid)
+	s := &store{db: db, ledgerid: ledgerid}
+	if err := s.initState(); err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Delete(pendingCommitKey)
+	if !s.isEmpty && s.lastCommittedBlock > blockNum {
+		logger.Infof("Rolling back private data of ledger [%s] from block [%d] to block [%d]", ledgerid, s.lastCommittedBlock, blockNum)
+		startKey, endKey := getDataKeysForRangeScanFromBlockNum(blockNum + 1)
+		itr := db.GetIterator(startKey, endKey)
+		for itr.Next() {
+			batch.Delete(itr.Key())
+		}
+		itr.Release()
+		startKey, endKey = getAllExpiryKeysForRangeScan()
+		itr = db.GetIterator(startKey, endKey)
+		for itr.Next() {
+			if decodeExpiryKey(itr.Key()).committingBlk > blockNum {
+				batch.Delete(itr.Key())
+			}
+		}
+		itr.Release()
+		batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(blockNum))
+	}
+	return db.WriteBatch(batch, true)
+}
+
 // Close closes the store
 func (p *provider) Close() {
 	p.dbProvider.Close()
@@ -301,6 +333,100 @@ func (s *store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEnt
 	return expiryEntries, nil
 }
 
+// PurgeKeys implements the function in the interface `Store`.
+// As the store is not indexed by the keys, the pvt data of all the blocks is scanned for the writes of the keys
+func (s *store) PurgeKeys(toPurge *KeysToPurge) error {
+	// the purger routine is excluded, so that it does not remove data entries that are written back below
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+	keys := make(map[string]struct{})
+	for _, key := range toPurge.Keys {
+		keys[key] = struct{}{}
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	startKey, endKey := getDataKeysForRangeScanFromBlockNum(0)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		dataKey := decodeDatakey(itr.Key())
+		if dataKey.ns != toPurge.Ns || dataKey.coll != toPurge.Coll {
+			continue
+		}
+		dataValue, err := decodeDataValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(dataValue.Rwset, kvRWSet); err != nil {
+			return err
+		}
+		var retainedWrites []*kvrwset.KVWrite
+		for _, write := range kvRWSet.Writes {
+			if _, ok := keys[write.Key]; !ok {
+				retainedWrites = append(retainedWrites, write)
+			}
+		}
+		if len(retainedWrites) == len(kvRWSet.Writes) {
+			continue
+		}
+		kvRWSet.Writes = retainedWrites
+		if dataValue.Rwset, err = proto.Marshal(kvRWSet); err != nil {
+			return err
+		}
+		valBytes, err := encodeDataValue(dataValue)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("Purging keys from private data of block [%d], transaction [%d], collection [%s:%s]", dataKey.blkNum, dataKey.txNum, dataKey.ns, dataKey.coll)
+		batch.Put(encodeDataKey(dataKey), valBytes)
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
+// SchedulePurge implements the function in the interface `Store`
+func (s *store) SchedulePurge(toPurge *KeysToPurge, purgeAtBlk uint64) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, key := range toPurge.Keys {
+		batch.Put(encodePurgeScheduleKey(purgeAtBlk, toPurge.Ns, toPurge.Coll, key), emptyValue)
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
+// GetScheduledPurges implements the function in the interface `Store`
+func (s *store) GetScheduledPurges(maxBlkNum uint64) ([]*KeysToPurge, error) {
+	startKey, endKey := getPurgeScheduleKeysForRangeScan(maxBlkNum)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	var scheduledPurges []*KeysToPurge
+	byNsColl := make(map[[2]string]*KeysToPurge)
+	for itr.Next() {
+		_, ns, coll, key := decodePurgeScheduleKey(itr.Key())
+		toPurge, ok := byNsColl[[2]string{ns, coll}]
+		if !ok {
+			toPurge = &KeysToPurge{Ns: ns, Coll: coll}
+			byNsColl[[2]string{ns, coll}] = toPurge
+			scheduledPurges = append(scheduledPurges, toPurge)
+		}
+		toPurge.Keys = append(toPurge.Keys, key)
+	}
+	return scheduledPurges, nil
+}
+
+// ClearScheduledPurges implements the function in the interface `Store`
+func (s *store) ClearScheduledPurges(maxBlkNum uint64) error {
+	startKey, endKey := getPurgeScheduleKeysForRangeScan(maxBlkNum)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		purgeAtBlk, ns, coll, key := decodePurgeScheduleKey(itr.Key())
+		batch.Delete(encodePurgeScheduleKey(purgeAtBlk, ns, coll, key))
+	}
+	return s.db.WriteBatch(batch, true)
+}
+
 // LastCommittedBlockHeight implements the function in the interface `Store`
 func (s *store) LastCommittedBlockHeight() (uint64, error) {
 	if s.isEmpty {
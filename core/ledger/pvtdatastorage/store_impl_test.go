@@ -15,11 +15,13 @@ import (
 
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -285,6 +287,125 @@ func TestInitLastCommittedBlock(t *testing.T) {
 	assert.True(ok)
 }
 
+func TestRollback(t *testing.T) {
+	ledgerid := "TestRollback"
+	cs := btltestutil.NewMockCollectionStore()
+	cs.SetBTL("ns-1", "coll-1", 10)
+	cs.SetBTL("ns-1", "coll-2", 0)
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(cs)
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	assert.NoError(s.Prepare(0, nil))
+	assert.NoError(s.Commit())
+	for blkNum := uint64(1); blkNum <= 5; blkNum++ {
+		assert.NoError(s.Prepare(blkNum, []*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+		}))
+		assert.NoError(s.Commit())
+	}
+	// a pending batch of the next block is discarded too
+	assert.NoError(s.Prepare(6, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
+	}))
+
+	env.TestStoreProvider.Close()
+	env.TestStoreProvider = NewProvider()
+	assert.NoError(env.TestStoreProvider.Rollback(ledgerid, 3))
+	env.CloseAndReopen()
+	s = env.TestStore
+
+	testEmpty(false, assert, s)
+	testPendingBatch(false, assert, s)
+	testLastCommittedBlockHeight(4, assert, s)
+	for blkNum := uint64(1); blkNum <= 3; blkNum++ {
+		assert.True(testDataKeyExists(t, s, &dataKey{blkNum: blkNum, txNum: 2, ns: "ns-1", coll: "coll-1"}))
+		assert.True(testDataKeyExists(t, s, &dataKey{blkNum: blkNum, txNum: 2, ns: "ns-1", coll: "coll-2"}))
+	}
+	for blkNum := uint64(4); blkNum <= 6; blkNum++ {
+		assert.False(testDataKeyExists(t, s, &dataKey{blkNum: blkNum, txNum: 2, ns: "ns-1", coll: "coll-1"}))
+		assert.False(testDataKeyExists(t, s, &dataKey{blkNum: blkNum, txNum: 2, ns: "ns-1", coll: "coll-2"}))
+	}
+	expiryEntries, err := s.(*store).retrieveExpiryEntries(0, 100)
+	assert.NoError(err)
+	assert.Len(expiryEntries, 3)
+	for _, expiryEntry := range expiryEntries {
+		assert.True(expiryEntry.key.committingBlk <= 3)
+	}
+
+	// the store accepts the pvt data of the removed blocks again
+	assert.NoError(s.Prepare(4, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
+	}))
+	assert.NoError(s.Commit())
+	testLastCommittedBlockHeight(5, assert, s)
+}
+
+func TestPurgeKeys(t *testing.T) {
+	cs := btltestutil.NewMockCollectionStore()
+	cs.SetBTL("ns-1", "coll-1", 0)
+	cs.SetBTL("ns-1", "coll-2", 0)
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(cs)
+	env := NewTestStoreEnv(t, "TestPurgeKeys", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	assert.NoError(s.Prepare(0, nil))
+	assert.NoError(s.Commit())
+	for blkNum := uint64(1); blkNum <= 2; blkNum++ {
+		assert.NoError(s.Prepare(blkNum, []*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+		}))
+		assert.NoError(s.Commit())
+	}
+
+	assert.NoError(s.PurgeKeys(&KeysToPurge{Ns: "ns-1", Coll: "coll-1", Keys: []string{"key-ns-1-coll-1"}}))
+	for blkNum := uint64(1); blkNum <= 2; blkNum++ {
+		retrievedData, err := s.GetPvtDataByBlockNum(blkNum, nil)
+		assert.NoError(err)
+		assert.Len(retrievedData, 1)
+		// the rest of the pvt data of the transaction is retained
+		expectedData := produceSamplePvtdata(t, 2, []string{"ns-1:coll-2"})
+		assert.True(retrievedData[0].Has("ns-1", "coll-1"))
+		assert.Equal(expectedData.WriteSet.NsPvtRwset[0].CollectionPvtRwset[0], retrievedData[0].WriteSet.NsPvtRwset[0].CollectionPvtRwset[1])
+		kvRWSet := &kvrwset.KVRWSet{}
+		assert.NoError(proto.Unmarshal(retrievedData[0].WriteSet.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, kvRWSet))
+		assert.Empty(kvRWSet.Writes)
+	}
+}
+
+func TestPurgeSchedule(t *testing.T) {
+	env := NewTestStoreEnv(t, "TestPurgeSchedule", nil)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	assert.NoError(s.SchedulePurge(&KeysToPurge{Ns: "ns-1", Coll: "coll-1", Keys: []string{"key1", "key2"}}, 5))
+	assert.NoError(s.SchedulePurge(&KeysToPurge{Ns: "ns-1", Coll: "coll-2", Keys: []string{"key1"}}, 5))
+	assert.NoError(s.SchedulePurge(&KeysToPurge{Ns: "ns-1", Coll: "coll-1", Keys: []string{"key3"}}, 7))
+
+	scheduledPurges, err := s.GetScheduledPurges(4)
+	assert.NoError(err)
+	assert.Empty(scheduledPurges)
+	scheduledPurges, err = s.GetScheduledPurges(6)
+	assert.NoError(err)
+	assert.Equal([]*KeysToPurge{
+		{Ns: "ns-1", Coll: "coll-1", Keys: []string{"key1", "key2"}},
+		{Ns: "ns-1", Coll: "coll-2", Keys: []string{"key1"}},
+	}, scheduledPurges)
+
+	// the schedule survives a restart, until it is cleared
+	env.CloseAndReopen()
+	s = env.TestStore
+	assert.NoError(s.ClearScheduledPurges(6))
+	scheduledPurges, err = s.GetScheduledPurges(10)
+	assert.NoError(err)
+	assert.Equal([]*KeysToPurge{{Ns: "ns-1", Coll: "coll-1", Keys: []string{"key3"}}}, scheduledPurges)
+}
+
 // TODO Add tests for simulating a crash between calls `Prepare` and `Commit`/`Rollback`
 
 func testEmpty(expectedEmpty bool, assert *assert.Assertions, store Store) {
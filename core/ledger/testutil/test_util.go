@@ -82,6 +82,13 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.state.couchDBConfig.queryLimit", 10000)
 	viper.Set("ledger.state.stateDatabase", "goleveldb")
 	viper.Set("ledger.history.enableHistoryDatabase", false)
+	viper.Set("ledger.history.retention.maxVersions", 0)
+	viper.Set("ledger.history.retention.maxAge", "0s")
+	viper.Set("ledger.history.retention.pruneInterval", "24h")
+	viper.Set("ledger.eventIndex.enabled", false)
+	viper.Set("ledger.blockchain.archive.enabled", false)
+	viper.Set("ledger.blockchain.archive.retainBlocks", 10000)
+	viper.Set("ledger.blockchain.archive.maxCachedFiles", 2)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
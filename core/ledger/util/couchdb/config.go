@@ -30,6 +30,7 @@ type CouchDBDef struct {
 	MaxRetries          int
 	MaxRetriesOnStartup int
 	RequestTimeout      time.Duration
+	ConnectionPool      ConnectionPoolConfig
 }
 
 //GetCouchDBDefinition exposes the useCouchDB variable
@@ -41,6 +42,14 @@ func GetCouchDBDefinition() *CouchDBDef {
 	maxRetries := viper.GetInt("ledger.state.couchDBConfig.maxRetries")
 	maxRetriesOnStartup := viper.GetInt("ledger.state.couchDBConfig.maxRetriesOnStartup")
 	requestTimeout := viper.GetDuration("ledger.state.couchDBConfig.requestTimeout")
-
-	return &CouchDBDef{couchDBAddress, username, password, maxRetries, maxRetriesOnStartup, requestTimeout}
+	connectionPool := ConnectionPoolConfig{
+		MaxIdleConns:               viper.GetInt("ledger.state.couchDBConfig.maxIdleConns"),
+		MaxIdleConnsPerHost:        viper.GetInt("ledger.state.couchDBConfig.maxIdleConnsPerHost"),
+		IdleConnTimeout:            viper.GetDuration("ledger.state.couchDBConfig.idleConnTimeout"),
+		MaxConcurrentRequests:      viper.GetInt("ledger.state.couchDBConfig.maxConcurrentRequests"),
+		CircuitBreakerThreshold:    viper.GetInt("ledger.state.couchDBConfig.circuitBreakerThreshold"),
+		CircuitBreakerResetTimeout: viper.GetDuration("ledger.state.couchDBConfig.circuitBreakerResetTimeout"),
+	}
+
+	return &CouchDBDef{couchDBAddress, username, password, maxRetries, maxRetriesOnStartup, requestTimeout, connectionPool}
 }
@@ -24,4 +24,12 @@ func TestGetCouchDBDefinition(t *testing.T) {
 	testutil.AssertEquals(t, couchDBDef.MaxRetries, 3)
 	testutil.AssertEquals(t, couchDBDef.MaxRetriesOnStartup, 10)
 	testutil.AssertEquals(t, couchDBDef.RequestTimeout, time.Second*35)
+	testutil.AssertEquals(t, couchDBDef.ConnectionPool, ConnectionPoolConfig{
+		MaxIdleConns:               100,
+		MaxIdleConnsPerHost:        100,
+		IdleConnTimeout:            90 * time.Second,
+		MaxConcurrentRequests:      64,
+		CircuitBreakerThreshold:    0,
+		CircuitBreakerResetTimeout: 30 * time.Second,
+	})
 }
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdb
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// requestDurationBuckets are the upper bounds (in seconds) of the buckets of the request duration histogram
+var requestDurationBuckets = []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+
+// ConnectionPoolConfig bounds the connections and the requests of the client of a CouchDB instance.
+// Zero means no limit, except for the idle connections, whose limits default to those of net/http.
+type ConnectionPoolConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept open to CouchDB
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept open to a CouchDB host
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout time.Duration
+	// MaxConcurrentRequests is the maximum number of requests in flight to CouchDB.
+	// Further requests wait until one of them completes.
+	MaxConcurrentRequests int
+	// CircuitBreakerThreshold is the number of consecutive requests that fail after all their retries,
+	// after which requests fail without being sent to CouchDB. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+	// CircuitBreakerResetTimeout is how long requests fail without being sent once the circuit
+	// breaker opens, before a request is sent again to check whether CouchDB has recovered
+	CircuitBreakerResetTimeout time.Duration
+}
+
+// newTransport returns the transport of the http client of a CouchDB instance, which pools connections as configured
+func newTransport(pool ConnectionPoolConfig) *http.Transport {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	transport.DisableCompression = false
+	if pool.MaxIdleConns > 0 {
+		transport.MaxIdleConns = pool.MaxIdleConns
+	}
+	if pool.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	}
+	if pool.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	return transport
+}
+
+// requestLimiter bounds the number of requests in flight. A nil limiter doesn't bound them.
+type requestLimiter chan struct{}
+
+func newRequestLimiter(maxConcurrentRequests int) requestLimiter {
+	if maxConcurrentRequests <= 0 {
+		return nil
+	}
+	return make(requestLimiter, maxConcurrentRequests)
+}
+
+func (l requestLimiter) acquire() {
+	if l != nil {
+		l <- struct{}{}
+	}
+}
+
+func (l requestLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// circuitBreaker stops requests from being sent to a CouchDB instance that keeps failing them.
+// It opens after the configured number of consecutive failures, and lets a request through
+// once the reset timeout elapses. It closes again when a request succeeds.
+// A nil circuit breaker never opens.
+type circuitBreaker struct {
+	threshold    int
+	resetTimeout time.Duration
+
+	lock     sync.Mutex
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, resetTimeout time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, resetTimeout: resetTimeout}
+}
+
+// allow returns an error if the circuit breaker is open
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if cb.failures < cb.threshold {
+		return nil
+	}
+	if remaining := cb.resetTimeout - time.Since(cb.openedAt); remaining > 0 {
+		return fmt.Errorf("CouchDB circuit breaker is open after %d consecutive failed requests, retrying CouchDB in %s",
+			cb.failures, remaining)
+	}
+	// let this request through, and open the circuit breaker again for the reset
+	// timeout, so that the other requests wait for the outcome of this one
+	cb.openedAt = time.Now()
+	return nil
+}
+
+// record records the outcome of a request that was allowed
+func (cb *circuitBreaker) record(succeeded bool) {
+	if cb == nil {
+		return
+	}
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if succeeded {
+		if cb.failures >= cb.threshold {
+			logger.Infof("CouchDB circuit breaker closed after a request succeeded")
+		}
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures == cb.threshold {
+		logger.Warningf("CouchDB circuit breaker opened after %d consecutive failed requests", cb.failures)
+		cb.openedAt = time.Now()
+	}
+}
+
+// operationOf returns the type of operation of a request to CouchDB, which is the method of the request
+// followed by the CouchDB endpoint it's sent to, such as post_bulk_docs or get_all_docs, if there is one
+func operationOf(method, connectURL string) string {
+	operation := strings.ToLower(method)
+	u, err := url.Parse(connectURL)
+	if err != nil {
+		return operation
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.HasPrefix(segments[i], "_") {
+			return operation + segments[i]
+		}
+	}
+	return operation
+}
+
+// requestOutcome is the outcome of a request to CouchDB, as reported in the metrics
+type requestOutcome struct {
+	retries  int
+	failed   bool
+	rejected bool
+}
+
+// reportRequest reports the duration and the outcome of a request of the given type of operation, if metrics are initialized
+func reportRequest(operation string, elapsed time.Duration, outcome requestOutcome) {
+	if metrics.RootScope == nil {
+		return
+	}
+	scope := metrics.RootScope.SubScope("couchdb").Tagged(map[string]string{"operation": operation})
+	if outcome.rejected {
+		scope.Counter("circuit_breaker_rejections").Inc(1)
+		return
+	}
+	scope.Histogram("request_duration_seconds", requestDurationBuckets).RecordValue(elapsed.Seconds())
+	if outcome.retries > 0 {
+		scope.Counter("request_retries").Inc(int64(outcome.retries))
+	}
+	if outcome.failed {
+		scope.Counter("requests_failed").Inc(1)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package couchdb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	// Scenario I: The idle connections default to those of net/http
+	transport := newTransport(ConnectionPoolConfig{})
+	assert.Equal(t, 0, transport.MaxIdleConns)
+	assert.Equal(t, 0, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Duration(0), transport.IdleConnTimeout)
+
+	// Scenario II: The idle connections are bounded as configured
+	transport = newTransport(ConnectionPoolConfig{MaxIdleConns: 10, MaxIdleConnsPerHost: 5, IdleConnTimeout: time.Minute})
+	assert.Equal(t, 10, transport.MaxIdleConns)
+	assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	// Scenario I: A circuit breaker without a threshold never opens
+	cb := newCircuitBreaker(0, time.Minute)
+	assert.Nil(t, cb)
+	cb.record(false)
+	assert.NoError(t, cb.allow())
+
+	// Scenario II: The circuit breaker opens after consecutive failures only
+	cb = newCircuitBreaker(2, time.Minute)
+	cb.record(false)
+	cb.record(true)
+	cb.record(false)
+	assert.NoError(t, cb.allow())
+	cb.record(false)
+	assert.Contains(t, cb.allow().Error(), "CouchDB circuit breaker is open after 2 consecutive failed requests")
+
+	// Scenario III: A request is let through once the reset timeout elapses,
+	// and the circuit breaker opens again if it fails
+	cb.openedAt = time.Now().Add(-2 * time.Minute)
+	assert.NoError(t, cb.allow())
+	assert.Error(t, cb.allow(), "Only one request should be let through")
+	cb.openedAt = time.Now().Add(-2 * time.Minute)
+	assert.NoError(t, cb.allow())
+	cb.record(false)
+	assert.Error(t, cb.allow())
+
+	// Scenario IV: The circuit breaker closes when the request that is let through succeeds
+	cb.openedAt = time.Now().Add(-2 * time.Minute)
+	assert.NoError(t, cb.allow())
+	cb.record(true)
+	assert.NoError(t, cb.allow())
+	assert.NoError(t, cb.allow())
+}
+
+func TestRequestLimiter(t *testing.T) {
+	// Scenario I: The requests aren't bounded without a maximum
+	l := newRequestLimiter(0)
+	assert.Nil(t, l)
+	l.acquire()
+	l.release()
+
+	// Scenario II: A request waits until one of the requests in flight completes
+	l = newRequestLimiter(1)
+	l.acquire()
+	acquired := make(chan struct{})
+	go func() {
+		l.acquire()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "the request shouldn't be sent while another one is in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+	l.release()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the request should be sent once the request in flight completed")
+	}
+}
+
+func TestOperationOf(t *testing.T) {
+	assert.Equal(t, "post_bulk_docs", operationOf(http.MethodPost, "http://127.0.0.1:5984/mychannel_/_bulk_docs"))
+	assert.Equal(t, "get_all_docs", operationOf(http.MethodGet, "http://127.0.0.1:5984/mychannel_/_all_docs?limit=10"))
+	assert.Equal(t, "post_find", operationOf(http.MethodPost, "http://127.0.0.1:5984/mychannel_mycc/_find"))
+	assert.Equal(t, "get", operationOf(http.MethodGet, "http://127.0.0.1:5984/mychannel_mycc/key1"))
+	assert.Equal(t, "put", operationOf(http.MethodPut, "http://127.0.0.1:5984/mychannel_mycc"))
+	assert.Equal(t, "delete", operationOf(http.MethodDelete, "%zz"))
+}
+
+func TestHandleRequestWithCircuitBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"internal_server_error","reason":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	couchInstance := &CouchInstance{
+		conf:    CouchConnectionDef{URL: server.URL, RequestTimeout: 5 * time.Second},
+		client:  &http.Client{Transport: newTransport(ConnectionPoolConfig{})},
+		limiter: newRequestLimiter(1),
+		breaker: newCircuitBreaker(2, time.Minute),
+	}
+
+	// Scenario I: The requests are sent to CouchDB until the circuit breaker opens
+	for i := 0; i < 2; i++ {
+		_, couchDBReturn, err := couchInstance.handleRequest(http.MethodGet, server.URL+"/db/key1", nil, "", "", 0, true)
+		assert.Error(t, err)
+		assert.Equal(t, 500, couchDBReturn.StatusCode)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// Scenario II: The requests fail without being sent once the circuit breaker is open
+	_, _, err := couchInstance.handleRequest(http.MethodGet, server.URL+"/db/key1", nil, "", "", 0, true)
+	assert.Contains(t, err.Error(), "CouchDB circuit breaker is open")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	logging "github.com/op/go-logging"
+	"golang.org/x/net/context"
 )
 
 var logger = flogging.MustGetLogger("couchdb")
@@ -123,12 +124,15 @@ type CouchConnectionDef struct {
 	MaxRetries          int
 	MaxRetriesOnStartup int
 	RequestTimeout      time.Duration
+	ConnectionPool      ConnectionPoolConfig
 }
 
 //CouchInstance represents a CouchDB instance
 type CouchInstance struct {
-	conf   CouchConnectionDef //connection configuration
-	client *http.Client       // a client to connect to this instance
+	conf    CouchConnectionDef //connection configuration
+	client  *http.Client       // a client to connect to this instance
+	limiter requestLimiter     // bounds the requests in flight to this instance
+	breaker *circuitBreaker    // stops requests to this instance while it keeps failing them
 }
 
 //CouchDatabase represents a database within a CouchDB instance
@@ -239,7 +243,7 @@ func CreateConnectionDefinition(couchDBAddress, username, password string, maxRe
 
 	//return an object containing the connection information
 	return &CouchConnectionDef{finalURL.String(), username, password, maxRetries,
-		maxRetriesOnStartup, requestTimeout}, nil
+		maxRetriesOnStartup, requestTimeout, ConnectionPoolConfig{}}, nil
 
 }
 
@@ -394,6 +398,57 @@ func (couchInstance *CouchInstance) VerifyCouchConfig() (*ConnectionInfo, *DBRet
 	return dbResponse, couchDBReturn, nil
 }
 
+// HealthCheck checks that CouchDB is reachable, without retrying
+func (couchInstance *CouchInstance) HealthCheck(ctx context.Context) error {
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		return err
+	}
+	connectURL.Path = "/"
+	resp, _, err := couchInstance.handleRequest(http.MethodGet, connectURL.String(), nil, "", "", 0, true)
+	if err != nil {
+		return fmt.Errorf("CouchDB at %s is unreachable: %s", couchInstance.conf.URL, err)
+	}
+	closeResponseBody(resp)
+	return nil
+}
+
+//RetrieveApplicationDBNames method provides function to retrieve the names of all the databases
+//of the CouchDB instance, except for the system databases, whose names start with an underscore
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err.Error())
+		return nil, err
+	}
+	connectURL.Path = "/_all_dbs"
+
+	//get the number of retries
+	maxRetries := couchInstance.conf.MaxRetries
+
+	resp, _, err := couchInstance.handleRequest(http.MethodGet, connectURL.String(), nil,
+		couchInstance.conf.Username, couchInstance.conf.Password, maxRetries, true)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	decodeErr := json.NewDecoder(resp.Body).Decode(&dbNames)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	return applicationDBNames, nil
+}
+
 //DropDatabase provides method to drop an existing database
 func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 
@@ -1520,12 +1575,21 @@ func (couchInstance *CouchInstance) handleRequest(method, connectURL string, dat
 		return nil, nil, fmt.Errorf("Number of retries must be zero or greater.")
 	}
 
+	operation := operationOf(method, connectURL)
+	startTime := time.Now()
+	if err := couchInstance.breaker.allow(); err != nil {
+		reportRequest(operation, time.Since(startTime), requestOutcome{rejected: true})
+		return nil, nil, err
+	}
+	retries := 0
+
 	//attempt the http request for the max number of retries
 	// if maxRetries is 0, the database creation will be attempted once and will
 	//    return an error if unsuccessful
 	// if maxRetries is 3 (default), a maximum of 4 attempts (one attempt with 3 retries)
 	//    will be made with warning entries for unsuccessful attempts
 	for attempts := 0; attempts <= maxRetries; attempts++ {
+		retries = attempts
 
 		//Set up a buffer for the payload data
 		payloadData := new(bytes.Buffer)
@@ -1583,8 +1647,10 @@ func (couchInstance *CouchInstance) handleRequest(method, connectURL string, dat
 			logger.Debugf("HTTP Request: %s", bytes.Replace(dump, []byte{0x0d, 0x0a}, []byte{0x20, 0x7c, 0x20}, -1))
 		}
 
-		//Execute http request
+		//Execute http request, waiting for one of the requests in flight to complete if there are too many
+		couchInstance.limiter.acquire()
 		resp, errResp = couchInstance.client.Do(req)
+		couchInstance.limiter.release()
 
 		//check to see if the return from CouchDB is valid
 		if invalidCouchDBReturn(resp, errResp) {
@@ -1637,6 +1703,11 @@ func (couchInstance *CouchInstance) handleRequest(method, connectURL string, dat
 
 	} // end retry loop
 
+	//the request failed if CouchDB couldn't be reached or returned a 500 error after retries are exhausted
+	failed := invalidCouchDBReturn(resp, errResp) || errResp != nil || resp.StatusCode >= 500
+	couchInstance.breaker.record(!failed)
+	reportRequest(operation, time.Since(startTime), requestOutcome{retries: retries, failed: failed})
+
 	//if a golang http error is still present after retries are exhausted, return the error
 	if errResp != nil {
 		return nil, nil, errResp
@@ -21,6 +21,7 @@ import (
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/integration/runner"
 	"github.com/spf13/viper"
+	"golang.org/x/net/context"
 )
 
 const badConnectURL = "couchdb:5990"
@@ -151,7 +152,7 @@ func TestBadCouchDBInstance(t *testing.T) {
 	client := &http.Client{}
 
 	//Create a bad couchdb instance
-	badCouchDBInstance := CouchInstance{badConnectDef, client}
+	badCouchDBInstance := CouchInstance{conf: badConnectDef, client: client}
 
 	//Create a bad CouchDatabase
 	badDB := CouchDatabase{&badCouchDBInstance, "baddb", 1}
@@ -176,6 +177,10 @@ func TestBadCouchDBInstance(t *testing.T) {
 	_, _, err = badCouchDBInstance.VerifyCouchConfig()
 	testutil.AssertError(t, err, "Error should have been thrown with VerifyCouchConfig and invalid connection")
 
+	//Test HealthCheck with bad connection
+	err = badCouchDBInstance.HealthCheck(context.Background())
+	testutil.AssertError(t, err, "Error should have been thrown with HealthCheck and invalid connection")
+
 	//Test EnsureFullCommit with bad connection
 	_, err = badDB.EnsureFullCommit()
 	testutil.AssertError(t, err, "Error should have been thrown with EnsureFullCommit and invalid connection")
@@ -319,6 +324,20 @@ func TestDBBadConnection(t *testing.T) {
 	testutil.AssertError(t, err, fmt.Sprintf("Error should have been thrown for a bad connection"))
 }
 
+func TestHealthCheck(t *testing.T) {
+
+	couchInstance, err := CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout)
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when trying to create couch instance"))
+	err = couchInstance.HealthCheck(context.Background())
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when checking the health of CouchDB"))
+
+	//Point the instance to an address nothing listens on
+	couchInstance.conf.URL = "http://" + badConnectURL
+	err = couchInstance.HealthCheck(context.Background())
+	testutil.AssertError(t, err, fmt.Sprintf("Error should have been thrown for an unreachable CouchDB"))
+}
+
 func TestBadDBCredentials(t *testing.T) {
 
 	database := "testdbbadcredentials"
@@ -804,6 +823,36 @@ func TestDBDeleteDocument(t *testing.T) {
 
 }
 
+func TestRetrieveApplicationDBNames(t *testing.T) {
+
+	database := "testretrieveapplicationdbnames"
+	err := cleanup(database)
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when trying to cleanup  Error: %s", err))
+	defer cleanup(database)
+
+	//create a new instance and database object
+	couchInstance, err := CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout)
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when trying to create couch instance"))
+	db := CouchDatabase{CouchInstance: couchInstance, DBName: database}
+
+	//create a new database
+	errdb := db.CreateDatabaseIfNotExist()
+	testutil.AssertNoError(t, errdb, fmt.Sprintf("Error when trying to create database"))
+
+	//the system databases are created when the couch config is verified
+	_, _, err = couchInstance.VerifyCouchConfig()
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when trying to verify couch config"))
+
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	testutil.AssertNoError(t, err, fmt.Sprintf("Error when trying to retrieve application database names"))
+	testutil.AssertContains(t, dbNames, database)
+	for _, dbName := range dbNames {
+		testutil.AssertEquals(t, strings.HasPrefix(dbName, "_"), false)
+	}
+
+}
+
 func TestDBDeleteNonExistingDocument(t *testing.T) {
 
 	database := "testdbdeletenonexistingdocument"
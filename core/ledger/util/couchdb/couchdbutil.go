@@ -33,24 +33,37 @@ var collectionNameAllowedLength = 50
 func CreateCouchInstance(couchDBConnectURL, id, pw string, maxRetries,
 	maxRetriesOnStartup int, connectionTimeout time.Duration) (*CouchInstance, error) {
 
+	return CreateCouchInstanceWithConnectionPool(couchDBConnectURL, id, pw, maxRetries,
+		maxRetriesOnStartup, connectionTimeout, ConnectionPoolConfig{})
+}
+
+//CreateCouchInstanceWithConnectionPool creates a CouchDB instance whose client
+//pools connections and bounds requests as configured
+func CreateCouchInstanceWithConnectionPool(couchDBConnectURL, id, pw string, maxRetries,
+	maxRetriesOnStartup int, connectionTimeout time.Duration, pool ConnectionPoolConfig) (*CouchInstance, error) {
+
 	couchConf, err := CreateConnectionDefinition(couchDBConnectURL,
 		id, pw, maxRetries, maxRetriesOnStartup, connectionTimeout)
 	if err != nil {
 		logger.Errorf("Error during CouchDB CreateConnectionDefinition(): %s\n", err.Error())
 		return nil, err
 	}
+	couchConf.ConnectionPool = pool
 
 	// Create the http client once
 	// Clients and Transports are safe for concurrent use by multiple goroutines
 	// and for efficiency should only be created once and re-used.
 	client := &http.Client{Timeout: couchConf.RequestTimeout}
 
-	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
-	transport.DisableCompression = false
-	client.Transport = transport
+	client.Transport = newTransport(pool)
 
 	//Create the CouchDB instance
-	couchInstance := &CouchInstance{conf: *couchConf, client: client}
+	couchInstance := &CouchInstance{
+		conf:    *couchConf,
+		client:  client,
+		limiter: newRequestLimiter(pool.MaxConcurrentRequests),
+		breaker: newCircuitBreaker(pool.CircuitBreakerThreshold, pool.CircuitBreakerResetTimeout),
+	}
 
 	connectInfo, retVal, verifyErr := couchInstance.VerifyCouchConfig()
 	if verifyErr != nil {
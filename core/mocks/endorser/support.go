@@ -36,6 +36,8 @@ type MockSupport struct {
 	CheckInstantiationPolicyError    error
 	GetTransactionByIDErr            error
 	CheckACLErr                      error
+	CheckChaincodeFunctionACLErr     error
+	CheckedChaincodeFunction         string
 	SysCCMap                         map[string]struct{}
 	IsJavaRV                         bool
 	IsJavaErr                        error
@@ -103,6 +105,11 @@ func (s *MockSupport) CheckACL(signedProp *pb.SignedProposal, chdr *common.Chann
 	return s.CheckACLErr
 }
 
+func (s *MockSupport) CheckChaincodeFunctionACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, hdrext *pb.ChaincodeHeaderExtension, function string) error {
+	s.CheckedChaincodeFunction = function
+	return s.CheckChaincodeFunctionACLErr
+}
+
 func (s *MockSupport) IsJavaCC(buf []byte) (bool, error) {
 	return s.IsJavaRV, s.IsJavaErr
 }
@@ -166,6 +166,10 @@ func GetServerConfig() (comm.ServerConfig, error) {
 			}
 			secureOptions.ServerRootCAs = [][]byte{rootCert}
 		}
+		secureOptions.VirtualHosts, err = getVirtualHosts()
+		if err != nil {
+			return serverConfig, err
+		}
 	}
 	// get the default keepalive options
 	serverConfig.KaOpts = comm.DefaultKeepaliveOptions()
@@ -173,9 +177,94 @@ func GetServerConfig() (comm.ServerConfig, error) {
 	if viper.IsSet("peer.keepalive.minInterval") {
 		serverConfig.KaOpts.ServerMinInterval = viper.GetDuration("peer.keepalive.minInterval")
 	}
+	if viper.IsSet("peer.keepalive.interval") {
+		serverConfig.KaOpts.ServerInterval = viper.GetDuration("peer.keepalive.interval")
+	}
+	if viper.IsSet("peer.keepalive.timeout") {
+		serverConfig.KaOpts.ServerTimeout = viper.GetDuration("peer.keepalive.timeout")
+	}
+	// limits on the connections and requests of clients
+	serverConfig.Limits = comm.ConnectionLimits{
+		MaxConcurrentStreams:    uint32(viper.GetInt("peer.limits.maxConcurrentStreams")),
+		MaxConnectionsPerClient: viper.GetInt("peer.limits.maxConnectionsPerClient"),
+		MaxStreamsPerClient:     viper.GetInt("peer.limits.maxStreamsPerClient"),
+		DeniedClients:           viper.GetStringSlice("peer.limits.deniedClients"),
+	}
 	return serverConfig, nil
 }
 
+// GetTLSCredentialFiles returns the files the TLS credentials
+// of the peer server are read from
+func GetTLSCredentialFiles() []string {
+	files := []string{config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file")}
+	if viper.GetBool("peer.tls.clientAuthRequired") {
+		for _, file := range viper.GetStringSlice("peer.tls.clientRootCAs.files") {
+			files = append(files, config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
+		}
+	}
+	if rootCert := config.GetPath("peer.tls.rootcert.file"); rootCert != "" {
+		files = append(files, rootCert)
+	}
+	// the virtual hosts are validated when the peer server is created
+	hosts, _ := getVirtualHostConfigs()
+	for _, host := range hosts {
+		files = append(files, host.certFile(), host.keyFile())
+	}
+	return files
+}
+
+// virtualHostConfig is the configuration of a virtual host of the peer
+// server, as found under peer.tls.virtualHosts
+type virtualHostConfig struct {
+	Hostnames []string
+	Cert      struct{ File string }
+	Key       struct{ File string }
+}
+
+func (c virtualHostConfig) certFile() string {
+	return config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), c.Cert.File)
+}
+
+func (c virtualHostConfig) keyFile() string {
+	return config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), c.Key.File)
+}
+
+func getVirtualHostConfigs() ([]virtualHostConfig, error) {
+	if !viper.IsSet("peer.tls.virtualHosts") {
+		return nil, nil
+	}
+	var hosts []virtualHostConfig
+	if err := viper.UnmarshalKey("peer.tls.virtualHosts", &hosts); err != nil {
+		return nil, fmt.Errorf("error parsing peer.tls.virtualHosts (%s)", err)
+	}
+	return hosts, nil
+}
+
+// getVirtualHosts loads the key pairs the peer server presents to the clients
+// that request other hostnames through SNI
+func getVirtualHosts() ([]comm.VirtualHost, error) {
+	configs, err := getVirtualHostConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var hosts []comm.VirtualHost
+	for i, c := range configs {
+		if c.Cert.File == "" || c.Key.File == "" {
+			return nil, fmt.Errorf("peer.tls.virtualHosts[%d] must set both cert.file and key.file", i)
+		}
+		cert, err := ioutil.ReadFile(c.certFile())
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS certificate of virtual host %d (%s)", i, err)
+		}
+		key, err := ioutil.ReadFile(c.keyFile())
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS key of virtual host %d (%s)", i, err)
+		}
+		hosts = append(hosts, comm.VirtualHost{Hostnames: c.Hostnames, Certificate: cert, Key: key})
+	}
+	return hosts, nil
+}
+
 // GetClientCertificate returns the TLS certificate to use for gRPC client
 // connections
 func GetClientCertificate() (tls.Certificate, error) {
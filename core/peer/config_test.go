@@ -144,6 +144,32 @@ func TestGetServerConfig(t *testing.T) {
 	sc, _ = GetServerConfig()
 	assert.Equal(t, time.Duration(2)*time.Minute, sc.KaOpts.ServerMinInterval,
 		"ServerConfig.KaOpts.ServerMinInterval should be set to 2 min")
+	viper.Set("peer.keepalive.interval", "1h")
+	viper.Set("peer.keepalive.timeout", "10s")
+	sc, _ = GetServerConfig()
+	assert.Equal(t, time.Hour, sc.KaOpts.ServerInterval,
+		"ServerConfig.KaOpts.ServerInterval should be set to 1 hour")
+	assert.Equal(t, 10*time.Second, sc.KaOpts.ServerTimeout,
+		"ServerConfig.KaOpts.ServerTimeout should be set to 10 sec")
+
+	// connection limits
+	assert.Equal(t, comm.ConnectionLimits{}, sc.Limits,
+		"ServerConfig.Limits should not limit clients by default")
+	viper.Set("peer.limits.maxConcurrentStreams", 100)
+	viper.Set("peer.limits.maxConnectionsPerClient", 5)
+	viper.Set("peer.limits.maxStreamsPerClient", 50)
+	viper.Set("peer.limits.deniedClients", []string{"10.0.0.1", "192.168.0.0/16"})
+	sc, _ = GetServerConfig()
+	assert.Equal(t, comm.ConnectionLimits{
+		MaxConcurrentStreams:    100,
+		MaxConnectionsPerClient: 5,
+		MaxStreamsPerClient:     50,
+		DeniedClients:           []string{"10.0.0.1", "192.168.0.0/16"},
+	}, sc.Limits)
+	viper.Set("peer.limits.maxConcurrentStreams", 0)
+	viper.Set("peer.limits.maxConnectionsPerClient", 0)
+	viper.Set("peer.limits.maxStreamsPerClient", 0)
+	viper.Set("peer.limits.deniedClients", []string{})
 
 	// good config with TLS
 	viper.Set("peer.tls.enabled", true)
@@ -164,9 +190,41 @@ func TestGetServerConfig(t *testing.T) {
 	assert.Equal(t, 2, len(sc.SecOpts.ClientRootCAs),
 		"ServerConfig.SecOpts.ClientRootCAs should contain 2 entries")
 
+	// virtual hosts
+	assert.Empty(t, sc.SecOpts.VirtualHosts)
+	viper.Set("peer.tls.virtualHosts", []map[string]interface{}{
+		{
+			"hostnames": []string{"peer0.org2.example.com"},
+			"cert":      map[string]string{"file": filepath.Join("testdata", "Org2-server1-cert.pem")},
+			"key":       map[string]string{"file": filepath.Join("testdata", "Org2-server1-key.pem")},
+		},
+	})
+	sc, err := GetServerConfig()
+	assert.NoError(t, err)
+	if assert.Len(t, sc.SecOpts.VirtualHosts, 1) {
+		assert.Equal(t, []string{"peer0.org2.example.com"}, sc.SecOpts.VirtualHosts[0].Hostnames)
+		assert.NotEmpty(t, sc.SecOpts.VirtualHosts[0].Certificate)
+		assert.NotEmpty(t, sc.SecOpts.VirtualHosts[0].Key)
+	}
+	assert.Contains(t, GetTLSCredentialFiles(), filepath.Join("testdata", "Org2-server1-key.pem"))
+	viper.Set("peer.tls.virtualHosts", []map[string]interface{}{
+		{"cert": map[string]string{"file": filepath.Join("testdata", "Org2-server1-cert.pem")}},
+	})
+	_, err = GetServerConfig()
+	assert.EqualError(t, err, "peer.tls.virtualHosts[0] must set both cert.file and key.file")
+	viper.Set("peer.tls.virtualHosts", []map[string]interface{}{
+		{
+			"cert": map[string]string{"file": filepath.Join("testdata", "Org2-server1-cert.pem")},
+			"key":  map[string]string{"file": filepath.Join("testdata", "Org22-server1-key.pem")},
+		},
+	})
+	_, err = GetServerConfig()
+	assert.Contains(t, err.Error(), "error loading TLS key of virtual host 0")
+	viper.Set("peer.tls.virtualHosts", nil)
+
 	// bad config with TLS
 	viper.Set("peer.tls.rootcert.file", filepath.Join("testdata", "Org11-cert.pem"))
-	_, err := GetServerConfig()
+	_, err = GetServerConfig()
 	assert.Error(t, err, "GetServerConfig should return error with bad root cert path")
 	viper.Set("peer.tls.cert.file", filepath.Join("testdata", "Org11-cert.pem"))
 	_, err = GetServerConfig()
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -66,14 +67,25 @@ func (brs *blockResponseSender) SendStatusResponse(status common.Status) error {
 // SendBlockResponse generates deliver response with block message
 func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
 	response := &peer.DeliverResponse{
-		Type: &peer.DeliverResponse_Block{Block: block},
+		Type:       &peer.DeliverResponse_Block{Block: block},
+		Checkpoint: deliver.BlockCheckpointToken(block),
 	}
 	return brs.Send(response)
 }
 
-// filteredBlockResponseSender structure used to send filtered block responses
+// SendBlockBatchResponse generates deliver response with a batch of blocks
+func (brs *blockResponseSender) SendBlockBatchResponse(batch *common.BlockBatch) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_BlockBatch{BlockBatch: batch},
+	}
+	return brs.Send(response)
+}
+
+// filteredBlockResponseSender structure used to send filtered block responses,
+// whose transactions carry the hashes of the private data they wrote if withPvtDataHashes is set
 type filteredBlockResponseSender struct {
 	peer.Deliver_DeliverFilteredServer
+	withPvtDataHashes bool
 }
 
 func (fbrs *filteredBlockResponseSender) SendStatusResponse(status common.Status) error {
@@ -87,13 +99,14 @@ func (fbrs *filteredBlockResponseSender) SendStatusResponse(status common.Status
 func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
-	filteredBlock, err := b.toFilteredBlock()
+	filteredBlock, err := b.toFilteredBlock(fbrs.withPvtDataHashes)
 	if err != nil {
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
 	}
 	response := &peer.DeliverResponse{
-		Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+		Type:       &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+		Checkpoint: deliver.BlockCheckpointToken(block),
 	}
 	return fbrs.Send(response)
 }
@@ -120,6 +133,23 @@ func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
 
+// DeliverFilteredWithPvtDataHashes sends a stream of filtered blocks, whose transactions
+// carry the hashes of the private data they wrote, to a client after commitment
+func (s *server) DeliverFilteredWithPvtDataHashes(srv peer.Deliver_DeliverFilteredWithPvtDataHashesServer) error {
+	logger.Debugf("Starting new DeliverFilteredWithPvtDataHashes handler")
+	defer dumpStacktraceOnPanic()
+	// getting policy checker based on resources.Event_FilteredBlockPvtDataHash resource name
+	deliverServer := &deliver.Server{
+		Receiver:      srv,
+		PolicyChecker: s.policyCheckerProvider(resources.Event_FilteredBlockPvtDataHash),
+		ResponseSender: &filteredBlockResponseSender{
+			Deliver_DeliverFilteredServer: srv,
+			withPvtDataHashes:             true,
+		},
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
 // Deliver sends a stream of blocks to a client after commitment
 func (s *server) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	logger.Debugf("Starting new Deliver handler")
@@ -161,7 +191,7 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 	}
 }
 
-func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
+func (block *blockEvent) toFilteredBlock(withPvtDataHashes bool) (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
 	}
@@ -205,6 +235,7 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 			Txid:             chdr.TxId,
 			Type:             common.HeaderType(chdr.Type),
 			TxValidationCode: txsFltr.Flag(txIndex),
+			Checkpoint:       deliver.CheckpointToken(block.Header.Number, txIndex, chdr.TxId),
 		}
 
 		if filteredTransaction.Type == common.HeaderType_ENDORSER_TRANSACTION {
@@ -218,6 +249,14 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 				logger.Errorf(err.Error())
 				return nil, err
 			}
+
+			if withPvtDataHashes {
+				filteredTransaction.PvtDataHashes, err = transactionActions(tx.Actions).toPvtDataHashes()
+				if err != nil {
+					logger.Errorf(err.Error())
+					return nil, err
+				}
+			}
 		}
 
 		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
@@ -229,24 +268,13 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
 	for _, action := range ta {
-		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+		caPayload, err := toChaincodeAction(action)
 		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+			return nil, err
 		}
-
-		if chaincodeActionPayload.Action == nil {
-			logger.Debugf("chaincode action, the payload action is nil, skipping")
+		if caPayload == nil {
 			continue
 		}
-		propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
-		}
-
-		caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
-		}
 
 		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
 		if err != nil {
@@ -269,6 +297,59 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 	}, nil
 }
 
+// toPvtDataHashes returns the hashes of the private data written by the actions to each collection
+func (ta transactionActions) toPvtDataHashes() ([]*peer.CollectionPvtDataHash, error) {
+	var pvtDataHashes []*peer.CollectionPvtDataHash
+	for _, action := range ta {
+		caPayload, err := toChaincodeAction(action)
+		if err != nil {
+			return nil, err
+		}
+		if caPayload == nil {
+			continue
+		}
+
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(caPayload.Results); err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal read-write set for block event")
+		}
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				pvtDataHashes = append(pvtDataHashes, &peer.CollectionPvtDataHash{
+					Namespace:      nsRWSet.NameSpace,
+					CollectionName: collHashedRWSet.CollectionName,
+					PvtRwsetHash:   collHashedRWSet.PvtRwSetHash,
+				})
+			}
+		}
+	}
+	return pvtDataHashes, nil
+}
+
+// toChaincodeAction extracts the chaincode action of the transaction action,
+// or returns nil if the transaction action has none
+func toChaincodeAction(action *peer.TransactionAction) (*peer.ChaincodeAction, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+	}
+
+	if chaincodeActionPayload.Action == nil {
+		logger.Debugf("chaincode action, the payload action is nil, skipping")
+		return nil, nil
+	}
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
+	}
+
+	caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
+	}
+	return caPayload, nil
+}
+
 func dumpStacktraceOnPanic() {
 	func() {
 		if r := recover(); r != nil {
@@ -16,7 +16,9 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -208,6 +210,9 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 								config.Equal(config.eventName, chaincodeActions[0].ChaincodeEvent.EventName)
 								config.Equal(config.txID, chaincodeActions[0].ChaincodeEvent.TxId)
 								config.Equal(config.chaincodeName, chaincodeActions[0].ChaincodeEvent.ChaincodeId)
+								config.Empty(tx.PvtDataHashes)
+								config.Equal(deliver.CheckpointToken(0, 0, config.txID), tx.Checkpoint)
+								config.Equal(tx.Checkpoint, response.Checkpoint)
 							default:
 								config.FailNow("Unexpected response type")
 							}
@@ -379,6 +384,96 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsServer_DeliverFilteredWithPvtDataHashes(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	config := testConfig{
+		channelID:     "testChainID",
+		eventName:     "testEvent",
+		chaincodeName: "mycc",
+		txID:          "testID",
+		payload: &common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					ChannelId: "testChainID",
+					Timestamp: util.CreateUtcTimestamp(),
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{}),
+			},
+			Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+				Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+				Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+				Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+			}),
+		},
+		Assertions: assert.New(t),
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "mycc",
+				KvRwSet:   &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{
+					{CollectionName: "coll1", HashedRwSet: &kvrwset.HashedRWSet{}, PvtRwSetHash: []byte("hash1")},
+					{CollectionName: "coll2", HashedRwSet: &kvrwset.HashedRWSet{}, PvtRwSetHash: []byte("hash2")},
+				},
+			},
+		},
+	}
+	results, err := txRWSet.ToProtoBytes()
+	assert.NoError(t, err)
+	chaincodeActionPayload, err := createChaincodeActionWithResults(config.chaincodeName, config.eventName, config.txID, results)
+	assert.NoError(t, err)
+	chainManager := createDefaultSupportMamangerMock(config, chaincodeActionPayload)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	p := &peer2.Peer{}
+	// setup mock deliver server
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+	deliverServer.On("Recv").Return(&common.Envelope{
+		Payload: utils.MarshalOrPanic(config.payload),
+	}, nil).Run(func(_ mock.Arguments) {
+		// once we are getting new message we need to mock
+		// Recv call to get io.EOF to stop the looping for
+		// next message and we can assert for the DeliverResponse
+		// value we are getting from the deliver server
+		deliverServer.Mock = mock.Mock{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+		deliverServer.On("Recv").Return(&common.Envelope{}, io.EOF)
+		deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			defer wg.Done()
+			response := args.Get(0).(*peer.DeliverResponse)
+			switch response.Type.(type) {
+			case *peer.DeliverResponse_Status:
+				config.Equal(common.Status_SUCCESS, response.GetStatus())
+			case *peer.DeliverResponse_FilteredBlock:
+				block := response.GetFilteredBlock()
+				config.Equal(1, len(block.FilteredTransactions))
+				tx := block.FilteredTransactions[0]
+				config.Equal(config.txID, tx.Txid)
+				chaincodeActions := tx.GetTransactionActions().ChaincodeActions
+				config.Equal(1, len(chaincodeActions))
+				config.Equal(config.eventName, chaincodeActions[0].ChaincodeEvent.EventName)
+				config.Equal([]*peer.CollectionPvtDataHash{
+					{Namespace: "mycc", CollectionName: "coll1", PvtRwsetHash: []byte("hash1")},
+					{Namespace: "mycc", CollectionName: "coll2", PvtRwsetHash: []byte("hash2")},
+				}, tx.PvtDataHashes)
+			default:
+				config.FailNow("Unexpected response type")
+			}
+		}).Return(nil)
+	})
+
+	server := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chainManager)
+	err = server.DeliverFilteredWithPvtDataHashes(deliverServer)
+	wg.Wait()
+	// no error expected
+	assert.NoError(t, err)
+}
+
 func createDefaultSupportMamangerMock(config testConfig, chaincodeActionPayload *peer.ChaincodeActionPayload) *mockChainManager {
 	chainManager := &mockChainManager{}
 	iter := &mockIterator{}
@@ -448,6 +543,10 @@ func createEndorsement(channelID string, txID string, chaincodeActionPayload *pe
 }
 
 func createChaincodeAction(chaincodeName string, eventName string, txID string) (*peer.ChaincodeActionPayload, error) {
+	return createChaincodeActionWithResults(chaincodeName, eventName, txID, nil)
+}
+
+func createChaincodeActionWithResults(chaincodeName string, eventName string, txID string, results []byte) (*peer.ChaincodeActionPayload, error) {
 	// chaincode events
 	eventsBytes, err := proto.Marshal(&peer.ChaincodeEvent{
 		ChaincodeId: chaincodeName,
@@ -463,7 +562,8 @@ func createChaincodeAction(chaincodeName string, eventName string, txID string)
 		ChaincodeId: &peer.ChaincodeID{
 			Name: chaincodeName,
 		},
-		Events: eventsBytes,
+		Results: results,
+		Events:  eventsBytes,
 	})
 	if err != nil {
 		return nil, err
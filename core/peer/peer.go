@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	cc "github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -33,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/service"
@@ -63,6 +65,28 @@ type gossipSupport struct {
 	channelconfig.Application
 	configtx.Validator
 	channelconfig.Channel
+	ordererOrgs map[string][]string
+}
+
+// OrdererOrgEndpoints returns a map of the MSP IDs of the ordering organizations to the endpoints of their orderers
+func (gs *gossipSupport) OrdererOrgEndpoints() map[string][]string {
+	return gs.ordererOrgs
+}
+
+// ordererOrgEndpoints returns the endpoints of the ordering organizations
+// of the given channel configuration, by their MSP IDs
+func ordererOrgEndpoints(cc channelconfig.Resources) map[string][]string {
+	orgEndpoints := make(map[string][]string)
+	oc, ok := cc.OrdererConfig()
+	if !ok {
+		return orgEndpoints
+	}
+	for _, org := range oc.Organizations() {
+		if endpoints := org.Endpoints(); len(endpoints) != 0 {
+			orgEndpoints[org.MSPID()] = endpoints
+		}
+	}
+	return orgEndpoints
 }
 
 type chainSupport struct {
@@ -90,25 +114,15 @@ func (sp *storeProvider) OpenStore(ledgerID string) (transientstore.Store, error
 }
 
 func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
-	err := cs.ConfigtxValidator().Validate(configtx)
+	bundle, err := cs.validateConfig(configtx)
 	if err != nil {
 		return err
 	}
 
-	// If the chainSupport is being mocked, this field will be nil
-	if cs.bundleSource != nil {
-		bundle, err := channelconfig.NewBundle(cs.ConfigtxValidator().ChainID(), configtx.Config)
-		if err != nil {
-			return err
-		}
-
+	// If the chainSupport is being mocked, there is no bundle
+	if bundle != nil {
 		channelconfig.LogSanityChecks(bundle)
 
-		err = cs.bundleSource.ChannelConfig().ValidateNew(bundle)
-		if err != nil {
-			return err
-		}
-
 		capabilitiesSupportedOrPanic(bundle)
 
 		rBundle, err := cs.bundleSource.NewFromChannelConfig(bundle)
@@ -121,6 +135,38 @@ func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
 	return nil
 }
 
+// ValidateConfig validates the configtx against the current config of the channel, without applying it
+func (cs *chainSupport) ValidateConfig(configtx *common.ConfigEnvelope) error {
+	_, err := cs.validateConfig(configtx)
+	return err
+}
+
+// validateConfig validates the configtx against the current config of the channel, and returns
+// the bundle of the resulting config, or nil if the chainSupport is being mocked
+func (cs *chainSupport) validateConfig(configtx *common.ConfigEnvelope) (*channelconfig.Bundle, error) {
+	err := cs.ConfigtxValidator().Validate(configtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the chainSupport is being mocked, this field will be nil
+	if cs.bundleSource == nil {
+		return nil, nil
+	}
+
+	bundle, err := channelconfig.NewBundle(cs.ConfigtxValidator().ChainID(), configtx.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.bundleSource.ChannelConfig().ValidateNew(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
 func capabilitiesSupportedOrPanic(res channelconfig.Resources) {
 	ac, ok := res.ApplicationConfig()
 	if !ok {
@@ -161,6 +207,7 @@ type chain struct {
 	cs        *chainSupport
 	cb        *common.Block
 	committer committer.Committer
+	validator *txvalidator.TxValidator
 }
 
 // chains is a local map of chainID->chainObject
@@ -215,7 +262,7 @@ func Initialize(init func(string)) {
 			peerLogger.Debugf("Error while loading ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
 			continue
 		}
-		if cb, err = getCurrConfigBlockFromLedger(ledger); err != nil {
+		if cb, err = getCurrConfigBlock(cid, ledger); err != nil {
 			peerLogger.Warningf("Failed to find config block on ledger %s(%s)", cid, err)
 			peerLogger.Debugf("Error while looking for config block on ledger %s with message %s. We continue to the next ledger rather than abort.", cid, err)
 			continue
@@ -313,6 +360,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 			Validator:   bundle.ChannelConfig().ConfigtxValidator(),
 			Application: ac,
 			Channel:     bundle.ChannelConfig().ChannelConfig(),
+			ordererOrgs: ordererOrgEndpoints(bundle.ChannelConfig()),
 		})
 		service.GetGossipService().SuspectPeers(func(identity api.PeerIdentityType) bool {
 			// TODO: this is a place-holder that would somehow make the MSP layer suspect
@@ -417,6 +465,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		cs:        cs,
 		cb:        cb,
 		committer: c,
+		validator: validator,
 	}
 
 	return nil
@@ -478,6 +527,45 @@ func GetLedger(cid string) ledger.PeerLedger {
 	return nil
 }
 
+// DryRunCommit validates the given block of the chain with chain ID like the committer does, including
+// the MVCC validation against the current state of the ledger, without committing the block or applying
+// the config it contains, and returns the validation code of each of its transactions
+func DryRunCommit(cid string, block *common.Block) ([]pb.TxValidationCode, error) {
+	chains.RLock()
+	c, ok := chains.list[cid]
+	chains.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("channel %s not found", cid)
+	}
+
+	if block.GetHeader() == nil || block.GetData() == nil {
+		return nil, errors.New("block is malformed")
+	}
+	blockChainID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed extracting the channel of the block")
+	}
+	if blockChainID != cid {
+		return nil, errors.Errorf("block is of channel %s", blockChainID)
+	}
+
+	// validation sets the validation flags in the metadata of the block
+	block = proto.Clone(block).(*common.Block)
+	if err := c.validator.DryRun(block); err != nil {
+		return nil, errors.WithMessage(err, "failed validating block")
+	}
+	if err := ledgermgmt.DryRunCommit(cid, &ledger.BlockAndPvtData{Block: block}); err != nil {
+		return nil, errors.WithMessage(err, "failed validating block against the state")
+	}
+
+	txsFilter := ledgerUtil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	codes := make([]pb.TxValidationCode, len(txsFilter))
+	for i := range txsFilter {
+		codes[i] = txsFilter.Flag(i)
+	}
+	return codes, nil
+}
+
 // GetResourcesConfig returns the resources configuration of the chain with channel ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetResourcesConfig(cid string) resourcesconfig.Resources {
@@ -532,21 +620,7 @@ func updateTrustedRoots(cm channelconfig.Resources) {
 	serverConfig, err = GetServerConfig()
 	if err == nil && serverConfig.SecOpts.UseTLS {
 		buildTrustedRootsForChain(cm)
-
-		// now iterate over all roots for all app and orderer chains
-		trustedRoots := [][]byte{}
-		credSupport.RLock()
-		defer credSupport.RUnlock()
-		for _, roots := range credSupport.AppRootCAsByChain {
-			trustedRoots = append(trustedRoots, roots...)
-		}
-		// also need to append statically configured root certs
-		if len(serverConfig.SecOpts.ClientRootCAs) > 0 {
-			trustedRoots = append(trustedRoots, serverConfig.SecOpts.ClientRootCAs...)
-		}
-		if len(serverConfig.SecOpts.ServerRootCAs) > 0 {
-			trustedRoots = append(trustedRoots, serverConfig.SecOpts.ServerRootCAs...)
-		}
+		trustedRoots := trustedClientRoots(serverConfig.SecOpts)
 
 		server := GetPeerServer()
 		// now update the client roots for the peerServer
@@ -562,6 +636,41 @@ func updateTrustedRoots(cm channelconfig.Resources) {
 	}
 }
 
+// trustedClientRoots returns the root CAs of the application organizations
+// of all the channels, along with the statically configured root CAs
+func trustedClientRoots(secOpts *comm.SecureOptions) [][]byte {
+	// now iterate over all roots for all app and orderer chains
+	trustedRoots := [][]byte{}
+	credSupport.RLock()
+	defer credSupport.RUnlock()
+	for _, roots := range credSupport.AppRootCAsByChain {
+		trustedRoots = append(trustedRoots, roots...)
+	}
+	// also need to append statically configured root certs
+	if len(secOpts.ClientRootCAs) > 0 {
+		trustedRoots = append(trustedRoots, secOpts.ClientRootCAs...)
+	}
+	if len(secOpts.ServerRootCAs) > 0 {
+		trustedRoots = append(trustedRoots, secOpts.ServerRootCAs...)
+	}
+	return trustedRoots
+}
+
+// LoadTLSCredentials loads the TLS credentials of the peer server from their
+// files. The client root CAs returned include those of the channels.
+func LoadTLSCredentials() (*comm.SecureOptions, error) {
+	serverConfig, err := GetServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	if !serverConfig.SecOpts.UseTLS {
+		return nil, errors.New("TLS is not enabled")
+	}
+	secOpts := *serverConfig.SecOpts
+	secOpts.ClientRootCAs = trustedClientRoots(serverConfig.SecOpts)
+	return &secOpts, nil
+}
+
 // populates the appRootCAs and orderRootCAs maps by getting the
 // root and intermediate certs for all msps associated with the MSPManager
 func buildTrustedRootsForChain(cm channelconfig.Resources) {
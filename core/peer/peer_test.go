@@ -38,6 +38,9 @@ func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, endpoints []string
 	return nil
 }
 
+func (ds *mockDeliveryClient) UpdateOrdererOrgs(chainID string, orgEndpoints map[string][]string) {
+}
+
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, f func()) error {
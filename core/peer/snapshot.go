@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// CreateChainFromSnapshot creates a new chain from a config block and a snapshot of the ledger of its
+// channel, which was exported by a peer of the channel, e.g., a peer of another organization. This lets
+// a peer join a channel that has a long history without committing all of its blocks. The config block
+// must be the config block in effect as of the last block of the snapshot, and the snapshot must have
+// the given state hash, as published by the peer that exported it. The last block of the snapshot is
+// verified once the peer receives the following block, whose previous hash must match it.
+func CreateChainFromSnapshot(cb *common.Block, snapshotPath string, stateHash []byte) error {
+	cid, err := utils.GetChainIDFromBlock(cb)
+	if err != nil {
+		return err
+	}
+	if len(stateHash) == 0 {
+		return errors.New("the state hash of the snapshot must be provided")
+	}
+	if err := verifySnapshot(cid, cb, snapshotPath, stateHash); err != nil {
+		return errors.WithMessage(err, "invalid snapshot "+snapshotPath)
+	}
+
+	snapshot, err := os.Open(snapshotPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening snapshot %s", snapshotPath)
+	}
+	defer snapshot.Close()
+	l, err := ledgermgmt.CreateLedgerFromSnapshot(cb, snapshot)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+	return createChain(cid, l, cb)
+}
+
+// verifySnapshot reads the whole snapshot before it is imported, to check that it has the given state hash,
+// that it is a snapshot of the ledger of the given channel, and that its channel config is the one of the
+// config block
+func verifySnapshot(cid string, cb *common.Block, snapshotPath string, stateHash []byte) error {
+	envelopeConfig, err := utils.ExtractEnvelope(cb, 0)
+	if err != nil {
+		return err
+	}
+	configEnvelope := &common.ConfigEnvelope{}
+	if _, err := utils.UnmarshalEnvelopeOfType(envelopeConfig, common.HeaderType_CONFIG, configEnvelope); err != nil {
+		return err
+	}
+
+	snapshot, err := os.Open(snapshotPath)
+	if err != nil {
+		return errors.Wrap(err, "failed opening snapshot")
+	}
+	defer snapshot.Close()
+	var chanConf *common.Config
+	info, err := ledgermgmt.ReadSnapshot(snapshot, func(namespace, key string, value []byte) error {
+		if namespace != peerNamespace || key != channelConfigKey {
+			return nil
+		}
+		conf, err := deserialize(value)
+		if err != nil {
+			return errors.Wrap(err, "failed unmarshaling channel config")
+		}
+		chanConf = conf
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if info.LedgerID != cid {
+		return errors.Errorf("snapshot is of channel %s, not %s", info.LedgerID, cid)
+	}
+	if !bytes.Equal(info.StateHash, stateHash) {
+		return errors.Errorf("state hash of snapshot is %x, not %x", info.StateHash, stateHash)
+	}
+	if cb.Header.Number > info.LastBlockNum {
+		return errors.Errorf("config block %d is after the last block %d of the snapshot", cb.Header.Number, info.LastBlockNum)
+	}
+	// the channel config in the state of the snapshot is the one in effect as of its last block
+	if chanConf == nil {
+		return errors.New("snapshot does not hold the channel config")
+	}
+	if !proto.Equal(chanConf, configEnvelope.Config) {
+		return errors.Errorf("config block %d is not the config block in effect as of the last block %d of the snapshot",
+			cb.Header.Number, info.LastBlockNum)
+	}
+	return nil
+}
+
+// getCurrConfigBlock returns the current config block of the ledger of the given channel. The config block
+// a ledger was created with from a snapshot is not among the blocks of the ledger, and it is current until
+// a config block following the snapshot is committed
+func getCurrConfigBlock(cid string, l ledger.PeerLedger) (*common.Block, error) {
+	info, cb, err := ledgermgmt.GetBootstrapInfo(cid)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return getCurrConfigBlockFromLedger(l)
+	}
+	if cb == nil {
+		return nil, errors.Errorf("ledger %s was created from a snapshot without a config block", cid)
+	}
+
+	blockchainInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if blockchainInfo.Height-1 == info.LastBlockNum {
+		return cb, nil
+	}
+	lastBlock, err := l.GetBlockByNumber(blockchainInfo.Height - 1)
+	if err != nil {
+		return nil, err
+	}
+	configBlockIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if configBlockIndex <= info.LastBlockNum {
+		return cb, nil
+	}
+	return l.GetBlockByNumber(configBlockIndex)
+}
+
+// exportedSnapshot is a snapshot of the ledger of a channel that the peer exported
+type exportedSnapshot struct {
+	Path   string `json:"path"`
+	Height uint64 `json:"height"`
+}
+
+// snapshotListPath returns the path of the file that lists the snapshots
+// the peer exported of the ledger of the given channel
+func snapshotListPath(cid string) string {
+	return filepath.Join(ledgerconfig.GetRootPath(), "snapshots", cid+".json")
+}
+
+// RecordSnapshot lists the snapshot of the ledger of the given channel that was exported to the given path,
+// and whose last block has the given number, and publishes the heights of the listed snapshots to the other
+// peers of the channel
+func RecordSnapshot(cid string, snapshotPath string, lastBlockNum uint64) error {
+	snapshots, err := listSnapshots(cid)
+	if err != nil {
+		return err
+	}
+	snapshots = append(snapshots, exportedSnapshot{Path: snapshotPath, Height: lastBlockNum + 1})
+	rawSnapshots, err := json.Marshal(snapshots)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling snapshot list")
+	}
+	listPath := snapshotListPath(cid)
+	if err := os.MkdirAll(filepath.Dir(listPath), 0755); err != nil {
+		return errors.Wrapf(err, "failed creating directory of %s", listPath)
+	}
+	if err := ioutil.WriteFile(listPath, rawSnapshots, 0600); err != nil {
+		return errors.Wrapf(err, "failed writing %s", listPath)
+	}
+	PublishSnapshotHeights(cid)
+	return nil
+}
+
+// listSnapshots returns the snapshots the peer exported of the ledger of
+// the given channel that still exist on the file system of the peer
+func listSnapshots(cid string) ([]exportedSnapshot, error) {
+	listPath := snapshotListPath(cid)
+	rawSnapshots, err := ioutil.ReadFile(listPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading %s", listPath)
+	}
+	var snapshots []exportedSnapshot
+	if err := json.Unmarshal(rawSnapshots, &snapshots); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling %s", listPath)
+	}
+	var res []exportedSnapshot
+	for _, snapshot := range snapshots {
+		if _, err := os.Stat(snapshot.Path); err != nil {
+			peerLogger.Debugf("Snapshot %s of channel %s is no longer listed: %v", snapshot.Path, cid, err)
+			continue
+		}
+		res = append(res, snapshot)
+	}
+	return res, nil
+}
+
+// SnapshotHeights returns the distinct heights, in ascending order, of the snapshots the peer
+// exported of the ledger of the given channel that still exist on the file system of the peer
+func SnapshotHeights(cid string) ([]uint64, error) {
+	snapshots, err := listSnapshots(cid)
+	if err != nil {
+		return nil, err
+	}
+	var heights []uint64
+	listed := make(map[uint64]struct{})
+	for _, snapshot := range snapshots {
+		if _, exists := listed[snapshot.Height]; exists {
+			continue
+		}
+		listed[snapshot.Height] = struct{}{}
+		heights = append(heights, snapshot.Height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights, nil
+}
+
+// PublishSnapshotHeights publishes the heights of the snapshots the peer lists for the given channel
+// to the other peers of the channel, so that peers joining the channel can discover them
+func PublishSnapshotHeights(cid string) {
+	heights, err := SnapshotHeights(cid)
+	if err != nil {
+		peerLogger.Warningf("Failed listing snapshots of channel %s: %+v", cid, err)
+		return
+	}
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return
+	}
+	gossipService.UpdateLedgerSnapshots(heights, gossipcommon.ChainID(cid))
+}
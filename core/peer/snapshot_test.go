@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotJoin(t *testing.T) {
+	helper := &testHelper{t: t}
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	chainid := "testchain1"
+	ledgermgmt.InitializeTestEnvWithCustomProcessors(ConfigTxProcessors)
+	defer ledgermgmt.CleanupTestEnv()
+	chanConf := helper.sampleChannelConfig(1, true)
+	genesisBlock := helper.constructBlock(helper.constructGenesisTx(t, chainid, chanConf), 0, nil)
+	l, err := ledgermgmt.CreateLedger(genesisBlock)
+	require.NoError(t, err)
+
+	// the config block of a ledger created with a genesis block is among its blocks
+	cb, err := getCurrConfigBlock(chainid, l)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(cb, genesisBlock))
+
+	snapshotPath := filepath.Join(snapshotDir, "snapshot")
+	snapshot, err := os.Create(snapshotPath)
+	require.NoError(t, err)
+	info, err := ledgermgmt.ExportSnapshot(chainid, snapshot)
+	require.NoError(t, err)
+	snapshot.Close()
+
+	assert.NoError(t, verifySnapshot(chainid, genesisBlock, snapshotPath, info.StateHash))
+
+	err = verifySnapshot(chainid, genesisBlock, snapshotPath, []byte("another-hash"))
+	assert.Contains(t, err.Error(), "state hash of snapshot is")
+
+	otherChannelBlock := helper.constructBlock(helper.constructGenesisTx(t, "testchain2", helper.sampleChannelConfig(1, true)), 0, nil)
+	err = verifySnapshot("testchain2", otherChannelBlock, snapshotPath, info.StateHash)
+	assert.EqualError(t, err, "snapshot is of channel testchain1, not testchain2")
+
+	otherConfigBlock := helper.constructBlock(helper.constructGenesisTx(t, chainid, helper.sampleChannelConfig(2, true)), 0, nil)
+	err = verifySnapshot(chainid, otherConfigBlock, snapshotPath, info.StateHash)
+	assert.EqualError(t, err, "config block 0 is not the config block in effect as of the last block 0 of the snapshot")
+
+	err = verifySnapshot(chainid, genesisBlock, filepath.Join(snapshotDir, "missing"), info.StateHash)
+	assert.Contains(t, err.Error(), "failed opening snapshot")
+
+	err = CreateChainFromSnapshot(genesisBlock, snapshotPath, nil)
+	assert.EqualError(t, err, "the state hash of the snapshot must be provided")
+
+	// a peer of another organization creates the ledger from the snapshot, and keeps the config block
+	ledgermgmt.CleanupTestEnv()
+	ledgermgmt.InitializeTestEnvWithCustomProcessors(ConfigTxProcessors)
+	snapshot, err = os.Open(snapshotPath)
+	require.NoError(t, err)
+	defer snapshot.Close()
+	l, err = ledgermgmt.CreateLedgerFromSnapshot(genesisBlock, snapshot)
+	require.NoError(t, err)
+	_, err = l.GetBlockByNumber(0)
+	assert.Error(t, err)
+	cb, err = getCurrConfigBlock(chainid, l)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(cb, genesisBlock))
+	importedChanConf, err := retrievePersistedChannelConfig(l)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(importedChanConf, chanConf))
+}
+
+func TestSnapshotHeights(t *testing.T) {
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	// No snapshots are listed before any is exported
+	heights, err := SnapshotHeights("testchain1")
+	assert.NoError(t, err)
+	assert.Empty(t, heights)
+
+	for i, lastBlockNum := range []uint64{9, 4, 9, 14} {
+		snapshotPath := filepath.Join(snapshotDir, fmt.Sprintf("snapshot%d", i))
+		require.NoError(t, ioutil.WriteFile(snapshotPath, []byte("snapshot"), 0600))
+		require.NoError(t, RecordSnapshot("testchain1", snapshotPath, lastBlockNum))
+	}
+	heights, err = SnapshotHeights("testchain1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{5, 10, 15}, heights)
+
+	// Snapshots that were removed from the file system are no longer listed
+	require.NoError(t, os.Remove(filepath.Join(snapshotDir, "snapshot3")))
+	heights, err = SnapshotHeights("testchain1")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{5, 10}, heights)
+
+	// Snapshots are listed per channel
+	heights, err = SnapshotHeights("testchain2")
+	assert.NoError(t, err)
+	assert.Empty(t, heights)
+}
@@ -12,7 +12,11 @@ SPDX-License-Identifier: Apache-2.0
 package cscc
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -23,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -70,8 +75,19 @@ const (
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
 	SimulateConfigTreeUpdate string = "SimulateConfigTreeUpdate"
+	JoinChainBySnapshot      string = "JoinChainBySnapshot"
+	ExportSnapshot           string = "ExportSnapshot"
 )
 
+// SnapshotInfo is the response of ExportSnapshot, with hex encoded hashes. The state hash is
+// provided to JoinChainBySnapshot along with the snapshot by the peers joining from it
+type SnapshotInfo struct {
+	ChannelID     string `json:"channel_id"`
+	LastBlockNum  uint64 `json:"last_block_number"`
+	LastBlockHash string `json:"last_block_hash"`
+	StateHash     string `json:"state_hash"`
+}
+
 // Init is mostly useless from an SCC perspective
 func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	cnflogger.Info("Init CSCC")
@@ -85,8 +101,11 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // Peer calls this function with 2 arguments:
 // # args[0] is the function name, which must be JoinChain, GetConfigBlock or
 // UpdateConfigBlock
-// # args[1] is a configuration Block if args[0] is JoinChain or
-// UpdateConfigBlock; otherwise it is the chain id
+// # args[1] is a configuration Block if args[0] is JoinChain,
+// JoinChainBySnapshot or UpdateConfigBlock; otherwise it is the chain id
+// JoinChainBySnapshot takes the path of a snapshot on the file system of the
+// peer and its state hash as args[2] and args[3], and ExportSnapshot takes the
+// path of the snapshot to write as args[2]
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
 func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
@@ -149,6 +168,47 @@ func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		}
 
 		return joinChain(cid, block, e.ccp, e.sccp)
+	case JoinChainBySnapshot:
+		if len(args) < 4 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+
+		block, err := utils.GetBlockFromBlockBytes(args[1])
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to reconstruct the config block, %s", err))
+		}
+
+		cid, err := utils.GetChainIDFromBlock(block)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("\"JoinChainBySnapshot\" request failed to extract "+
+				"channel id from the block due to [%s]", err))
+		}
+
+		if err := validateConfigBlock(block); err != nil {
+			return shim.Error(fmt.Sprintf("\"JoinChainBySnapshot\" for chainID = %s failed because of validation "+
+				"of configuration block, because of %s", cid, err))
+		}
+
+		// check local MSP Admins policy
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("\"JoinChainBySnapshot\" request failed authorization check "+
+				"for channel [%s]: [%s]", cid, err))
+		}
+
+		return joinChainBySnapshot(cid, block, string(args[2]), args[3])
+	case ExportSnapshot:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+
+		// check local MSP Admins policy, since the snapshot holds the whole state of the channel
+		// and is written to the file system of the peer
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("\"ExportSnapshot\" request failed authorization check "+
+				"for channel [%s]: [%s]", args[1], err))
+		}
+
+		return exportSnapshot(string(args[1]), string(args[2]))
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -238,6 +298,57 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshot joins the chain of the configuration block from a snapshot
+// of its ledger, instead of committing all of its blocks
+func joinChainBySnapshot(chainID string, block *common.Block, snapshotPath string, stateHash []byte) pb.Response {
+	if err := peer.CreateChainFromSnapshot(block, snapshotPath, stateHash); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	peer.InitChain(chainID)
+
+	return shim.Success(nil)
+}
+
+// exportSnapshot writes a snapshot of the ledger of the specified chainID to a
+// new file on the file system of the peer, and returns the info of the snapshot
+func exportSnapshot(chainID string, snapshotPath string) pb.Response {
+	if peer.GetLedger(chainID) == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", chainID))
+	}
+	if !filepath.IsAbs(snapshotPath) {
+		return shim.Error(fmt.Sprintf("The path of the snapshot must be absolute, got %s", snapshotPath))
+	}
+	file, err := os.OpenFile(snapshotPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to create snapshot file: %s", err))
+	}
+	info, err := ledgermgmt.ExportSnapshot(chainID, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(snapshotPath)
+		return shim.Error(fmt.Sprintf("Failed to export snapshot of channel %s: %s", chainID, err))
+	}
+	// A snapshot that can't be listed is still exported, it just isn't advertised to other peers
+	if err := peer.RecordSnapshot(chainID, snapshotPath, info.LastBlockNum); err != nil {
+		cnflogger.Warningf("Failed listing snapshot %s of channel %s: %+v", snapshotPath, chainID, err)
+	}
+
+	infoBytes, err := json.Marshal(&SnapshotInfo{
+		ChannelID:     info.LedgerID,
+		LastBlockNum:  info.LastBlockNum,
+		LastBlockHash: hex.EncodeToString(info.LastBlockHash),
+		StateHash:     hex.EncodeToString(info.StateHash),
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(infoBytes)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
@@ -57,6 +57,9 @@ func (ds *mockDeliveryClient) UpdateEndpoints(chainID string, endpoints []string
 	return nil
 }
 
+func (ds *mockDeliveryClient) UpdateOrdererOrgs(chainID string, orgEndpoints map[string][]string) {
+}
+
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
 func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, f func()) error {
@@ -173,6 +176,44 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	}
 }
 
+func TestConfigerInvokeSnapshotWrongParams(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+
+	if res := stub.MockInit("1", nil); res.Status != shim.OK {
+		fmt.Println("Init failed", string(res.Message))
+		t.FailNow()
+	}
+
+	// Failed path: the path and the state hash of the snapshot are missing
+	args := [][]byte{[]byte("JoinChainBySnapshot"), []byte("action")}
+	res := stub.MockInvoke("2", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+
+	// Failed path: wrong config block
+	args = [][]byte{[]byte("JoinChainBySnapshot"), []byte("action"), []byte("/tmp/snapshot"), []byte("hash")}
+	res = stub.MockInvoke("3", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Failed to reconstruct the config block")
+
+	// Failed path: the path of the snapshot to export is missing
+	args = [][]byte{[]byte("ExportSnapshot"), []byte("mytestchainid")}
+	res = stub.MockInvoke("4", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+
+	// Failed path: no signed proposal provided
+	args = [][]byte{[]byte("ExportSnapshot"), []byte("mytestchainid"), []byte("/tmp/snapshot")}
+	res = stub.MockInvokeWithSignedProposal("5", args, nil)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "failed authorization check")
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	ccp := &ccprovidermocks.MockCcProviderImpl{}
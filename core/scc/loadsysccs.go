@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package scc
 
 import (
-	"fmt"
 	"os"
 	"plugin"
 	"sync"
@@ -19,6 +18,7 @@ import (
 
 const (
 	sccFactoryMethod = "New"
+	sccVersionSymbol = "Version"
 )
 
 // PluginConfig SCC plugin configuration
@@ -28,10 +28,15 @@ type PluginConfig struct {
 	Path              string `mapstructure:"path" yaml:"path"`
 	InvokableExternal bool   `mapstructure:"invokableExternal" yaml:"invokableExternal"`
 	InvokableCC2CC    bool   `mapstructure:"invokableCC2CC" yaml:"invokableCC2CC"`
+	// Version is the version of the plugin. The plugin isn't loaded if it
+	// exports a Version variable that doesn't match it.
+	Version string `mapstructure:"version" yaml:"version"`
+	// Channels are the channels the plugin is enabled on, all channels if it's empty
+	Channels []string `mapstructure:"channels" yaml:"channels"`
 }
 
 var once sync.Once
-var sccPlugins []*SystemChaincode
+var sccPlugins = NewPluginRegistry()
 
 // loadSysCCs reads system chaincode plugin configuration and loads them
 func loadSysCCs(p *Provider) []*SystemChaincode {
@@ -43,48 +48,70 @@ func loadSysCCs(p *Provider) []*SystemChaincode {
 		}
 		loadSysCCsWithConfig(config)
 	})
-	return sccPlugins
+	return sccPlugins.SystemChaincodes()
 }
 
+// loadSysCCsWithConfig loads the enabled and whitelisted plugins, and registers
+// them with sccPlugins. Plugins that fail to load aren't registered, rather than
+// preventing the peer from starting.
 func loadSysCCsWithConfig(configs []*PluginConfig) {
 	for _, conf := range configs {
-		plugin := loadPlugin(conf.Path)
-		chaincode := &SystemChaincode{
-			Enabled:           conf.Enabled,
-			Name:              conf.Name,
-			Path:              conf.Path,
-			Chaincode:         *plugin,
-			InvokableExternal: conf.InvokableExternal,
-			InvokableCC2CC:    conf.InvokableCC2CC,
+		// plugins run code as soon as they are opened
+		if !conf.Enabled || !isWhitelisted(conf.Name) {
+			sysccLogger.Infof("SCC plugin %s at path %s isn't loaded, as it's disabled", conf.Name, conf.Path)
+			continue
 		}
-		sccPlugins = append(sccPlugins, chaincode)
-		sysccLogger.Infof("Successfully loaded SCC %s from path %s", chaincode.Name, chaincode.Path)
+
+		cc, version, err := loadPlugin(conf.Path)
+		if err != nil {
+			sysccLogger.Errorf("Failed loading SCC plugin %s: %s", conf.Name, err)
+			continue
+		}
+		if err := sccPlugins.Register(conf, version, cc); err != nil {
+			sysccLogger.Errorf("Failed registering SCC plugin %s: %s", conf.Name, err)
+			continue
+		}
+		sysccLogger.Infof("Successfully loaded SCC %s version %s from path %s", conf.Name, version, conf.Path)
 	}
 }
 
-func loadPlugin(path string) *shim.Chaincode {
+// loadPlugin opens the plugin at the given path, and returns the chaincode it
+// creates and the version it exports, if any
+func loadPlugin(path string) (cc shim.Chaincode, version string, err error) {
+	// a faulty plugin may panic while it's opened or creates its chaincode
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("plugin at path %s panicked: %v", path, r)
+		}
+	}()
+
 	if _, err := os.Stat(path); err != nil {
-		panic(fmt.Errorf("Could not find plugin at path %s: %s", path, err))
+		return nil, "", errors.Wrapf(err, "could not find plugin at path %s", path)
 	}
 
 	p, err := plugin.Open(path)
 	if err != nil {
-		panic(fmt.Errorf("Error opening plugin at path %s: %s", path, err))
+		return nil, "", errors.Wrapf(err, "error opening plugin at path %s", path)
 	}
 
 	sccFactorySymbol, err := p.Lookup(sccFactoryMethod)
 	if err != nil {
-		panic(fmt.Errorf(
-			"Could not find symbol %s. Plugin must export this method", sccFactoryMethod))
+		return nil, "", errors.Errorf("could not find symbol %s. Plugin must export this method", sccFactoryMethod)
 	}
 
 	sccFactory, ok := sccFactorySymbol.(func() shim.Chaincode)
 	if !ok {
-		panic(fmt.Errorf("Function %s does not match expected definition func() shim.Chaincode",
-			sccFactoryMethod))
+		return nil, "", errors.Errorf("function %s does not match expected definition func() shim.Chaincode", sccFactoryMethod)
 	}
 
-	scc := sccFactory()
+	// the version is optional
+	if versionSymbol, err := p.Lookup(sccVersionSymbol); err == nil {
+		v, ok := versionSymbol.(*string)
+		if !ok {
+			return nil, "", errors.Errorf("symbol %s does not match expected definition string", sccVersionSymbol)
+		}
+		version = *v
+	}
 
-	return &scc
+	return sccFactory(), version, nil
 }
@@ -48,15 +48,25 @@ func TestLoadSCCPlugin(t *testing.T) {
   `, pluginName, pluginPath)
 	viper.SetConfigType("yaml")
 	viper.ReadConfig(bytes.NewBuffer([]byte(testConfig)))
+	viper.Set("chaincode.system", map[string]string{pluginName: "enable"})
+	defer viper.Set("chaincode.system", map[string]string{"lscc": "enable", "a": "enable"})
 
 	sccs := loadSysCCs(&Provider{})
 	assert.Len(t, sccs, 1, "expected one SCC to be loaded")
-	resp := sccs[0].Chaincode.Invoke(nil)
+	stub := shim.NewMockStub(pluginName, sccs[0].Chaincode)
+	resp := stub.MockInvoke("txid", nil)
 	assert.Equal(t, int32(shim.OK), resp.Status, "expected success response from scc")
 }
 
 func TestLoadSCCPluginInvalid(t *testing.T) {
-	assert.Panics(t, func() { loadPlugin("missing.so") }, "expected panic with invalid path")
+	_, _, err := loadPlugin("missing.so")
+	assert.Error(t, err, "expected error with invalid path")
+}
+
+func TestLoadSCCPluginNotWhitelisted(t *testing.T) {
+	loadSysCCsWithConfig([]*PluginConfig{{Enabled: true, Name: "notwhitelisted", Path: "missing.so"}})
+	_, registered := sccPlugins.Version("notwhitelisted")
+	assert.False(t, registered)
 }
 
 func buildExamplePlugin(t *testing.T, path, pluginPackage string) {
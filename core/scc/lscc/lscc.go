@@ -21,6 +21,8 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -373,37 +375,54 @@ func isValidStatedbArtifactsTar(statedbArtifactsTar []byte) error {
 
 // executeInstall implements the "install" Invoke transaction
 func (lscc *lifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccbytes []byte) error {
+	_, err := lscc.install(ccbytes)
+	return err
+}
+
+// InstallPackage installs the given chaincode package on the peer just like the "install"
+// Invoke transaction does, and returns the name and version of the installed chaincode.
+// Unlike the Invoke transaction, it doesn't authorize the installation, hence the caller
+// is expected to do so.
+func (lscc *lifeCycleSysCC) InstallPackage(ccbytes []byte) (string, string, error) {
+	ccpack, err := lscc.install(ccbytes)
+	if err != nil {
+		return "", "", err
+	}
+	return ccpack.GetChaincodeData().Name, ccpack.GetChaincodeData().Version, nil
+}
+
+func (lscc *lifeCycleSysCC) install(ccbytes []byte) (ccprovider.CCPackage, error) {
 	ccpack, err := ccprovider.GetCCPackage(ccbytes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	cds := ccpack.GetDepSpec()
 
 	if cds == nil {
-		return fmt.Errorf("nil deployment spec from from the CC package")
+		return nil, fmt.Errorf("nil deployment spec from from the CC package")
 	}
 
 	if err = lscc.isValidChaincodeName(cds.ChaincodeSpec.ChaincodeId.Name); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = lscc.isValidChaincodeVersion(cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version); err != nil {
-		return err
+		return nil, err
 	}
 
 	if lscc.sccprovider.IsSysCC(cds.ChaincodeSpec.ChaincodeId.Name) {
-		return errors.Errorf("cannot install: %s is the name of a system chaincode", cds.ChaincodeSpec.ChaincodeId.Name)
+		return nil, errors.Errorf("cannot install: %s is the name of a system chaincode", cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
 	// Get any statedb artifacts from the chaincode package, e.g. couchdb index definitions
 	statedbArtifactsTar, err := ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = isValidStatedbArtifactsTar(statedbArtifactsTar); err != nil {
-		return InvalidStatedbArtifactsErr(err.Error())
+		return nil, InvalidStatedbArtifactsErr(err.Error())
 	}
 
 	chaincodeDefinition := &cceventmgmt.ChaincodeDefinition{
@@ -420,17 +439,17 @@ func (lscc *lifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccb
 		cceventmgmt.GetMgr().ChaincodeInstallDone(err == nil)
 	}()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Finally, if everything is good above, install the chaincode to local peer file system so that endorsements can start
 	if err = lscc.support.PutChaincodeToLocalStorage(ccpack); err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Infof("Installed Chaincode [%s] Version [%s] to peer", ccpack.GetChaincodeData().Name, ccpack.GetChaincodeData().Version)
 
-	return nil
+	return ccpack, nil
 }
 
 // executeDeployOrUpgrade routes the code path either to executeDeploy or executeUpgrade
@@ -698,14 +717,14 @@ func (lscc *lifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		if len(args) > 4 && len(args[4]) > 0 {
 			escc = args[4]
 		} else {
-			escc = []byte("escc")
+			escc = []byte(endorsement.DefaultPluginName)
 		}
 
 		var vscc []byte
 		if len(args) > 5 && len(args[5]) > 0 {
 			vscc = args[5]
 		} else {
-			vscc = []byte("vscc")
+			vscc = []byte(validation.DefaultPluginName)
 		}
 
 		var collectionsConfig []byte
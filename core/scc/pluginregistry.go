@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scc
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// PluginRegistry keeps track of the system chaincode plugins loaded by the peer,
+// along with their versions. The chaincodes of the registered plugins are only
+// invoked on the channels the plugins are enabled on, and their panics are
+// recovered, so that a faulty plugin can't crash the peer.
+type PluginRegistry struct {
+	mutex   sync.Mutex
+	plugins []*registeredPlugin
+}
+
+type registeredPlugin struct {
+	version string
+	sysCC   *SystemChaincode
+}
+
+// NewPluginRegistry creates an empty PluginRegistry
+func NewPluginRegistry() *PluginRegistry {
+	return &PluginRegistry{}
+}
+
+// Register registers the given chaincode of the plugin with the given configuration,
+// which exports the given version, if any. The version exported by the plugin must
+// match the configured version, if both are set. A plugin can't be registered twice,
+// unless with the same version, in which case the registration has no effect.
+func (r *PluginRegistry) Register(conf *PluginConfig, version string, cc shim.Chaincode) error {
+	if version == "" {
+		version = conf.Version
+	} else if conf.Version != "" && conf.Version != version {
+		return errors.Errorf("plugin %s has version %s, while version %s is configured", conf.Name, version, conf.Version)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, plugin := range r.plugins {
+		if plugin.sysCC.Name != conf.Name {
+			continue
+		}
+		if plugin.version != version {
+			return errors.Errorf("plugin %s is already registered with version %s", conf.Name, plugin.version)
+		}
+		sysccLogger.Warningf("SCC plugin %s version %s is already registered", conf.Name, version)
+		return nil
+	}
+
+	r.plugins = append(r.plugins, &registeredPlugin{
+		version: version,
+		sysCC: &SystemChaincode{
+			Enabled:           conf.Enabled,
+			Name:              conf.Name,
+			Path:              conf.Path,
+			Chaincode:         &pluginChaincode{name: conf.Name, channels: conf.Channels, cc: cc},
+			InvokableExternal: conf.InvokableExternal,
+			InvokableCC2CC:    conf.InvokableCC2CC,
+			Channels:          conf.Channels,
+		},
+	})
+	return nil
+}
+
+// Version returns the version of the registered plugin with the given name,
+// and whether such a plugin is registered
+func (r *PluginRegistry) Version(name string) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, plugin := range r.plugins {
+		if plugin.sysCC.Name == name {
+			return plugin.version, true
+		}
+	}
+	return "", false
+}
+
+// SystemChaincodes returns the system chaincodes of the registered plugins,
+// in the order of their registration
+func (r *PluginRegistry) SystemChaincodes() []*SystemChaincode {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var sysCCs []*SystemChaincode
+	for _, plugin := range r.plugins {
+		sysCCs = append(sysCCs, plugin.sysCC)
+	}
+	return sysCCs
+}
+
+// pluginChaincode is the chaincode of a system chaincode plugin. It refuses to be
+// invoked on the channels the plugin isn't enabled on, and turns the panics of the
+// plugin into error responses. Panics in goroutines started by the plugin can't be
+// recovered, though.
+type pluginChaincode struct {
+	name     string
+	channels []string
+	cc       shim.Chaincode
+}
+
+// Init implements shim.Chaincode
+func (p *pluginChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return p.call("Init", stub, p.cc.Init)
+}
+
+// Invoke implements shim.Chaincode
+func (p *pluginChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return p.call("Invoke", stub, p.cc.Invoke)
+}
+
+func (p *pluginChaincode) call(method string, stub shim.ChaincodeStubInterface, f func(shim.ChaincodeStubInterface) pb.Response) (resp pb.Response) {
+	if !enabledOnChannel(p.channels, stub.GetChannelID()) {
+		return shim.Error(fmt.Sprintf("system chaincode %s is disabled on channel %s", p.name, stub.GetChannelID()))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			sysccLogger.Errorf("System chaincode %s panicked in %s: %v\n%s", p.name, method, r, debug.Stack())
+			resp = shim.Error(fmt.Sprintf("system chaincode %s panicked: %v", p.name, r))
+		}
+	}()
+	return f(stub)
+}
+
+// enabledOnChannel returns whether a system chaincode that is enabled on the given
+// channels, or on all channels if there are none, is enabled on the given channel
+func enabledOnChannel(channels []string, chainID string) bool {
+	if len(channels) == 0 {
+		return true
+	}
+	for _, channel := range channels {
+		if channel == chainID {
+			return true
+		}
+	}
+	return false
+}
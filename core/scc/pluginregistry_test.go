@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type panickingChaincode struct{}
+
+func (*panickingChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (*panickingChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	panic("boom")
+}
+
+func TestPluginRegistryRegister(t *testing.T) {
+	r := NewPluginRegistry()
+
+	err := r.Register(&PluginConfig{Enabled: true, Name: "myscc", Path: "myscc.so", Version: "1.0"}, "", &panickingChaincode{})
+	assert.NoError(t, err)
+	version, registered := r.Version("myscc")
+	assert.True(t, registered)
+	assert.Equal(t, "1.0", version)
+
+	// registering the same version again has no effect
+	err = r.Register(&PluginConfig{Enabled: true, Name: "myscc", Path: "other.so"}, "1.0", &panickingChaincode{})
+	assert.NoError(t, err)
+	assert.Len(t, r.SystemChaincodes(), 1)
+	assert.Equal(t, "myscc.so", r.SystemChaincodes()[0].Path)
+
+	err = r.Register(&PluginConfig{Enabled: true, Name: "myscc", Version: "2.0"}, "", &panickingChaincode{})
+	assert.EqualError(t, err, "plugin myscc is already registered with version 1.0")
+
+	err = r.Register(&PluginConfig{Enabled: true, Name: "otherscc", Version: "2.0"}, "1.0", &panickingChaincode{})
+	assert.EqualError(t, err, "plugin otherscc has version 1.0, while version 2.0 is configured")
+	_, registered = r.Version("otherscc")
+	assert.False(t, registered)
+}
+
+func TestPluginChaincodeIsolation(t *testing.T) {
+	r := NewPluginRegistry()
+	err := r.Register(&PluginConfig{
+		Enabled:           true,
+		Name:              "myscc",
+		InvokableExternal: true,
+		Channels:          []string{"ch1"},
+	}, "", &panickingChaincode{})
+	assert.NoError(t, err)
+	sysCC := r.SystemChaincodes()[0]
+	assert.True(t, sysCC.InvokableExternal)
+	assert.Equal(t, []string{"ch1"}, sysCC.Channels)
+
+	stub := shim.NewMockStub("myscc", sysCC.Chaincode)
+	stub.ChannelID = "ch1"
+	resp := stub.MockInit("txid1", nil)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+
+	// the panic of the plugin doesn't crash the peer
+	resp = stub.MockInvoke("txid2", nil)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode myscc panicked: boom", resp.Message)
+
+	stub.ChannelID = "ch2"
+	resp = stub.MockInit("txid3", nil)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode myscc is disabled on channel ch2", resp.Message)
+}
+
+func TestEnabledOnChannel(t *testing.T) {
+	assert.True(t, enabledOnChannel(nil, "ch1"))
+	assert.True(t, enabledOnChannel(nil, ""))
+	assert.True(t, enabledOnChannel([]string{"ch1", "ch2"}, "ch2"))
+	assert.False(t, enabledOnChannel([]string{"ch1"}, "ch2"))
+	assert.False(t, enabledOnChannel([]string{"ch1"}, ""))
+}
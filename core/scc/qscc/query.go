@@ -8,6 +8,7 @@ package qscc
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -15,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -33,6 +35,7 @@ func New(aclProvider aclmgmt.ACLProvider) *LedgerQuerier {
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetChaincodeEvents returns the indexed events of a chaincode
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -46,6 +49,7 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChaincodeEvents string = "GetChaincodeEvents"
 )
 
 // Init is called once per chain when the chain is created.
@@ -64,6 +68,8 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetChaincodeEvents: Return the indexed events of the chaincode named in args[2], optionally matching
+// the event name pattern in args[3], the block range from args[4] to args[5] and the transaction ID in args[6]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -108,6 +114,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetChaincodeEvents:
+		return getChaincodeEvents(cid, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -207,6 +215,51 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getChaincodeEvents(cid string, args [][]byte) pb.Response {
+	query := &ledger.ChaincodeEventsQuery{ChaincodeName: string(args[0]), EndBlock: math.MaxUint64}
+	if len(args) > 1 {
+		query.EventNamePattern = string(args[1])
+	}
+	var err error
+	if len(args) > 2 && len(args[2]) > 0 {
+		if query.StartBlock, err = strconv.ParseUint(string(args[2]), 10, 64); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+		}
+	}
+	if len(args) > 3 && len(args[3]) > 0 {
+		if query.EndBlock, err = strconv.ParseUint(string(args[3]), 10, 64); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+		}
+	}
+	if len(args) > 4 {
+		query.TxID = string(args[4])
+	}
+
+	itr, err := ledgermgmt.QueryChaincodeEvents(cid, query)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query events of chaincode %s, error %s", query.ChaincodeName, err))
+	}
+	defer itr.Close()
+	records := &pb.ChaincodeEventRecords{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to query events of chaincode %s, error %s", query.ChaincodeName, err))
+		}
+		if res == nil {
+			break
+		}
+		records.Records = append(records.Records, res.(*pb.ChaincodeEventRecord))
+	}
+
+	bytes, err := utils.Marshal(records)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
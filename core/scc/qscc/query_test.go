@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -30,6 +31,7 @@ import (
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
+	ptestutils "github.com/hyperledger/fabric/protos/testutils"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -179,6 +181,59 @@ func TestQueryGetBlockByTxID(t *testing.T) {
 	assert.Equal(t, int32(shim.ERROR), res.Status, "GetBlockByTxID should have failed with blank txId.")
 }
 
+func TestQueryGetChaincodeEvents(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.eventIndex.enabled", true)
+	defer viper.Set("ledger.eventIndex.enabled", false)
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ledger := peer.GetLedger(chainid)
+	defer ledger.Close()
+	txid := util.GenerateUUID()
+	simulator, _ := ledger.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	events := utils.MarshalOrPanic(&peer2.ChaincodeEvent{ChaincodeId: "ns1", TxId: txid, EventName: "transfer"})
+	env, _, err := ptestutils.ConstructUnsignedTxEnv(chainid, &peer2.ChaincodeID{Name: "ns1"}, nil, pubSimResBytes, txid, events, nil)
+	require.NoError(t, err)
+	bcInfo, _ := ledger.GetBlockchainInfo()
+	block1 := testutil.NewBlock([]*common.Envelope{env}, bcInfo.Height, bcInfo.CurrentBlockHash)
+	require.NoError(t, ledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block1}))
+
+	args := [][]byte{[]byte(GetChaincodeEvents), []byte(chainid), []byte("ns1"), []byte("trans.*"), []byte("1"), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetChaincodeEvents, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetChaincodeEvents should have succeeded: %s", res.Message)
+	records := &peer2.ChaincodeEventRecords{}
+	require.NoError(t, proto.Unmarshal(res.Payload, records))
+	require.Len(t, records.Records, 1)
+	assert.Equal(t, uint64(1), records.Records[0].BlockNumber)
+	assert.Equal(t, txid, records.Records[0].Event.TxId)
+
+	args = [][]byte{[]byte(GetChaincodeEvents), []byte(chainid), []byte("ns1"), []byte("mint")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetChaincodeEvents should have succeeded: %s", res.Message)
+	records = &peer2.ChaincodeEventRecords{}
+	require.NoError(t, proto.Unmarshal(res.Payload, records))
+	assert.Empty(t, records.Records)
+
+	args = [][]byte{[]byte(GetChaincodeEvents), []byte(chainid), []byte("ns1"), []byte(""), []byte("first")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetChaincodeEvents should have failed with invalid start block")
+
+	args = [][]byte{[]byte(GetChaincodeEvents), []byte(chainid), []byte("")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetChaincodeEvents should have failed with blank chaincode name")
+}
+
 func TestFailingAccessControl(t *testing.T) {
 	chainid := "mytestchainid6"
 	path := tempDir(t, "test6")
@@ -63,6 +63,10 @@ type SystemChaincode struct {
 	// Enabled a convenient switch to enable/disable system chaincode without
 	// having to remove entry from importsysccs.go
 	Enabled bool
+
+	// Channels are the channels the system chaincode
+	// is deployed on, all of them if it's empty
+	Channels []string
 }
 
 // registerSysCC registers the given system chaincode with the peer
@@ -99,6 +103,10 @@ func (syscc *SystemChaincode) deploySysCC(chainID string, ccprov ccprovider.Chai
 		sysccLogger.Info(fmt.Sprintf("system chaincode (%s,%s) disabled", syscc.Name, syscc.Path))
 		return nil
 	}
+	if !enabledOnChannel(syscc.Channels, chainID) {
+		sysccLogger.Infof("system chaincode %s(%s) disabled on channel %s", syscc.Name, syscc.Path, chainID)
+		return nil
+	}
 
 	txid := util.GenerateUUID()
 
@@ -161,8 +169,12 @@ func (syscc *SystemChaincode) deDeploySysCC(chainID string, ccprov ccprovider.Ch
 }
 
 func (syscc *SystemChaincode) isWhitelisted() bool {
+	return isWhitelisted(syscc.Name)
+}
+
+func isWhitelisted(name string) bool {
 	chaincodes := viper.GetStringMapString("chaincode.system")
-	val, ok := chaincodes[syscc.Name]
+	val, ok := chaincodes[name]
 	enabled := val == "enable" || val == "true" || val == "yes"
 	return ok && enabled
 }
@@ -49,12 +49,38 @@ type GossipSupport interface {
 type EndorsementSupport interface {
 	// PeersForEndorsement returns an EndorsementDescriptor for a given set of peers, channel, and chaincode
 	PeersForEndorsement(channel common.ChainID, interest *discovery2.ChaincodeInterest) (*discovery2.EndorsementDescriptor, error)
+
+	// SimulatePolicy reports whether the given endorsement policy can be satisfied
+	// by the peers of the given channel, regardless of the policies of chaincodes
+	SimulatePolicy(channel common.ChainID, policy *common2.SignaturePolicyEnvelope) (*discovery2.PolicySimulationResult, error)
+
+	// ChaincodeVersions reports, for each of the given chaincodes, the versions installed
+	// on the alive peers of the given channel and the version of its definition in the channel
+	ChaincodeVersions(channel common.ChainID, chaincodes []string) (*discovery2.ChaincodeVersionsResult, error)
+
+	// OrgCombinations returns the combinations of organizations whose endorsements satisfy
+	// the endorsement policies of the given chaincode interest in the given channel,
+	// without resolving the peers of the organizations
+	OrgCombinations(channel common.ChainID, interest *discovery2.ChaincodeInterest) (*discovery2.OrgCombinations, error)
 }
 
 // ConfigSupport provides access to channel configuration
 type ConfigSupport interface {
 	// Config returns the channel's configuration
 	Config(channel string) (*discovery2.ConfigResult, error)
+
+	// ConfigUpdateImpact reports how the given config update would change the configuration
+	// of the given channel, and which of the given peers of the channel, by MSP ID, it would eject
+	ConfigUpdateImpact(channel string, configUpdate *common2.ConfigUpdate, peersByOrg map[string]*discovery2.Peers) (*discovery2.ConfigUpdateImpactResult, error)
+}
+
+// ResponseSigner signs responses of the discovery service
+type ResponseSigner interface {
+	// Sign signs the given message and returns the signature, or error on failure
+	Sign(msg []byte) ([]byte, error)
+
+	// Serialize returns the serialized identity the signatures can be verified with
+	Serialize() ([]byte, error)
 }
 
 // Support defines an interface that allows the discovery service
@@ -52,6 +52,8 @@ type authCache struct {
 	acSupport
 	sync.RWMutex
 	conf authCacheConfig
+	// stats records the lookups in the cache, if not nil
+	stats *serviceStats
 }
 
 func newAuthCache(s acSupport, conf authCacheConfig) *authCache {
@@ -108,10 +110,12 @@ func (cache *accessCache) EligibleForService(data common.SignedData) error {
 	currSeq := cache.ac.acSupport.ConfigSequence(cache.channel)
 	if cache.isValid(currSeq) {
 		foundInCache, isEligibleErr := cache.lookup(key)
+		cache.ac.stats.authCacheLookup(foundInCache)
 		if foundInCache {
 			return isEligibleErr
 		}
 	} else {
+		cache.ac.stats.authCacheLookup(false)
 		cache.configChange(currSeq)
 	}
 
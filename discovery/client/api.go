@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package discovery
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/pkg/errors"
@@ -22,6 +24,11 @@ var (
 // or nil and error on failure
 type Signer func(msg []byte) ([]byte, error)
 
+// ResponseVerifier verifies that the given signature over the given message
+// was created by the given identity, and that the identity is trusted to serve responses.
+// Returns nil on success, or error on failure
+type ResponseVerifier func(identity, signature, msg []byte) error
+
 // Dialer connects to the server
 type Dialer func() (*grpc.ClientConn, error)
 
@@ -42,6 +49,9 @@ type ChannelResponse interface {
 	// Peers returns a response for a peer membership query, or error if something went wrong
 	Peers() ([]*Peer, error)
 
+	// SnapshotPeers returns a response for a snapshot peers query, or error if something went wrong
+	SnapshotPeers() ([]*Peer, error)
+
 	// Endorsers returns the response for an endorser query for a given
 	// chaincode in a given channel context, or error if something went wrong.
 	// The method returns a random set of endorsers, such that signatures from all of them
@@ -50,6 +60,18 @@ type ChannelResponse interface {
 	// PrioritySelector: Determines which endorsers are selected over others
 	// ExclusionFilter: Determines which endorsers are not selected
 	Endorsers(cc string, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error)
+
+	// PolicySimulation returns the response for a policy simulation query, or error if something went wrong
+	PolicySimulation() (*PolicySimulation, error)
+
+	// ChaincodeVersions returns the response for a chaincode versions query, or error if something went wrong
+	ChaincodeVersions() ([]*ChaincodeVersions, error)
+
+	// ConfigUpdateImpact returns the response for a config update impact query, or error if something went wrong
+	ConfigUpdateImpact() (*ConfigUpdateImpact, error)
+
+	// OrgCombinations returns the response for an org combinations query, or error if something went wrong
+	OrgCombinations() ([]*OrgCombinations, error)
 }
 
 // LocalResponse aggregates responses for a channel-less scope
@@ -62,6 +84,50 @@ type LocalResponse interface {
 // for satisfying some chaincode's endorsement policy
 type Endorsers []*Peer
 
+// PolicySimulation describes whether an endorsement policy can be satisfied
+// by the peers of a channel, which organizations it requires,
+// and minimal sets of peers that satisfy it
+type PolicySimulation struct {
+	Satisfiable     bool
+	RequiredOrgs    []string
+	MinimalPeerSets []Endorsers
+}
+
+// ChaincodeVersions describes the versions of a chaincode installed on the alive peers
+// of a channel, and the version of the chaincode definition in the channel
+type ChaincodeVersions struct {
+	Chaincode string
+	// DefinedVersion is empty if the chaincode isn't defined in the channel
+	DefinedVersion string
+	// PeersByInstalledVersion maps installed versions to the peers that have them installed.
+	// Peers that don't have the chaincode installed are mapped to the empty version.
+	PeersByInstalledVersion map[string][]*Peer
+}
+
+// OrgCombinations describes the combinations of organizations whose endorsements
+// satisfy the endorsement policies of a chaincode, from the fewest endorsements required
+type OrgCombinations struct {
+	Chaincode string
+	// Combinations map MSP IDs to the quantity of endorsements required from peers of each organization
+	Combinations []map[string]uint32
+}
+
+// ConfigUpdateImpact describes how a config update would change the configuration
+// of a channel, and which alive peers of the channel it would eject
+type ConfigUpdateImpact struct {
+	PolicyChanges []*discovery.PolicyChange
+	AddedMSPs     []string
+	RemovedMSPs   []string
+	EjectedPeers  []*EjectedPeer
+}
+
+// EjectedPeer is an alive peer of a channel that a config update would eject,
+// along with the reason it would be ejected
+type EjectedPeer struct {
+	*Peer
+	Reason string
+}
+
 // Peer aggregates identity, membership and channel-scoped information
 // of a certain peer.
 type Peer struct {
@@ -69,4 +135,8 @@ type Peer struct {
 	AliveMessage     *gossip.SignedGossipMessage
 	StateInfoMessage *gossip.SignedGossipMessage
 	Identity         []byte
+	LedgerHeight     uint64
+	// RTT is the round trip time from the peer that returned this peer as an endorser.
+	// It is 0 if it wasn't measured, or if this peer is the one that returned it.
+	RTT time.Duration
 }
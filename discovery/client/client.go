@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/msp"
@@ -20,7 +22,7 @@ import (
 )
 
 var (
-	configTypes = []discovery.QueryType{discovery.ConfigQueryType, discovery.PeerMembershipQueryType, discovery.ChaincodeQueryType, discovery.LocalMembershipQueryType}
+	configTypes = []discovery.QueryType{discovery.ConfigQueryType, discovery.PeerMembershipQueryType, discovery.ChaincodeQueryType, discovery.LocalMembershipQueryType, discovery.SnapshotPeersQueryType, discovery.PolicySimulationQueryType, discovery.ChaincodeVersionsQueryType, discovery.ConfigUpdateImpactQueryType, discovery.OrgCombinationsQueryType}
 )
 
 // Client interacts with the discovery server
@@ -29,6 +31,7 @@ type Client struct {
 	lastSignature    []byte
 	createConnection Dialer
 	signRequest      Signer
+	verifyResponse   ResponseVerifier
 }
 
 // NewRequest creates a new request
@@ -46,8 +49,12 @@ func NewRequest() *Request {
 
 // Request aggregates several queries inside it
 type Request struct {
-	lastChannel string
-	lastIndex   int
+	lastChannel        string
+	lastIndex          int
+	maxLedgerHeightLag uint64
+	preferredOrgs      []string
+	maxPreferredRTT    time.Duration
+	descriptorVersion  discovery.DescriptorVersion
 	// map from query type to channel (or channel + chaincode) to expected index in response
 	queryMapping map[discovery.QueryType]map[string]int
 	*discovery.Request
@@ -69,13 +76,28 @@ func (req *Request) AddConfigQuery() *Request {
 
 // AddEndorsersQuery adds to the request a query for given chaincodes
 func (req *Request) AddEndorsersQuery(chaincodes ...string) *Request {
+	var calls []*discovery.ChaincodeCall
+	for _, cc := range chaincodes {
+		calls = append(calls, &discovery.ChaincodeCall{Name: cc})
+	}
+	return req.AddEndorsersQueryForCalls(calls...)
+}
+
+// AddEndorsersQueryForCalls adds to the request a query for given chaincode calls,
+// which may also specify the collections each chaincode accesses
+func (req *Request) AddEndorsersQueryForCalls(calls ...*discovery.ChaincodeCall) *Request {
 	ch := req.lastChannel
 	q := &discovery.Query_CcQuery{
-		CcQuery: &discovery.ChaincodeQuery{},
+		CcQuery: &discovery.ChaincodeQuery{
+			DescriptorVersion: req.descriptorVersion,
+		},
 	}
-	for _, cc := range chaincodes {
+	for _, call := range calls {
 		q.CcQuery.Interests = append(q.CcQuery.Interests, &discovery.ChaincodeInterest{
-			Chaincodes: []*discovery.ChaincodeCall{{Name: cc}},
+			Chaincodes:            []*discovery.ChaincodeCall{call},
+			MaxLedgerHeightLag:    req.maxLedgerHeightLag,
+			PreferredOrgs:         req.preferredOrgs,
+			MaxPreferredRttMicros: uint64(req.maxPreferredRTT / time.Microsecond),
 		})
 	}
 	req.Queries = append(req.Queries, &discovery.Query{
@@ -86,6 +108,58 @@ func (req *Request) AddEndorsersQuery(chaincodes ...string) *Request {
 	return req
 }
 
+// SetMaxLedgerHeightLag sets, for all endorsers queries of the request, the maximum number
+// of blocks the ledger height of endorsers may lag behind the highest ledger height in the channel.
+// 0 means the maximum lag the peer is configured with applies.
+func (req *Request) SetMaxLedgerHeightLag(lag uint64) *Request {
+	req.maxLedgerHeightLag = lag
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().GetInterests() {
+			interest.MaxLedgerHeightLag = lag
+		}
+	}
+	return req
+}
+
+// SetPreferredOrgs sets, for all endorsers queries of the request, the MSP IDs of the
+// organizations whose peers are preferred as endorsers. Layouts that can be satisfied
+// by their peers are listed first in the descriptors, and so are their peers in groups.
+func (req *Request) SetPreferredOrgs(mspIDs ...string) *Request {
+	req.preferredOrgs = mspIDs
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().GetInterests() {
+			interest.PreferredOrgs = mspIDs
+		}
+	}
+	return req
+}
+
+// SetMaxPreferredRTT sets, for all endorsers queries of the request, the maximum round trip time
+// from the peer the request is sent to, of peers that are preferred as endorsers along with the
+// peers of the preferred organizations. 0 means peers aren't preferred by their round trip times.
+func (req *Request) SetMaxPreferredRTT(rtt time.Duration) *Request {
+	req.maxPreferredRTT = rtt
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().GetInterests() {
+			interest.MaxPreferredRttMicros = uint64(rtt / time.Microsecond)
+		}
+	}
+	return req
+}
+
+// SetDescriptorVersion sets, for all endorsers queries of the request, the format of
+// the endorsement descriptors returned. In V2 descriptors, groups are keyed by MSP IDs.
+// Peers that don't support descriptor versions return V1 descriptors.
+func (req *Request) SetDescriptorVersion(version discovery.DescriptorVersion) *Request {
+	req.descriptorVersion = version
+	for _, q := range req.Queries {
+		if ccQuery := q.GetCcQuery(); ccQuery != nil {
+			ccQuery.DescriptorVersion = version
+		}
+	}
+	return req
+}
+
 // AddLocalPeersQuery adds to the request a local peer query
 func (req *Request) AddLocalPeersQuery() *Request {
 	q := &discovery.Query_LocalPeers{
@@ -112,6 +186,95 @@ func (req *Request) AddPeersQuery() *Request {
 	return req
 }
 
+// AddSnapshotPeersQuery adds to the request a query for peers that can serve
+// a ledger snapshot at a height of at least the given minimum height
+func (req *Request) AddSnapshotPeersQuery(minHeight uint64) *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_SnapshotPeers{
+		SnapshotPeers: &discovery.SnapshotPeersQuery{
+			MinHeight: minHeight,
+		},
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.SnapshotPeersQueryType, ch)
+	return req
+}
+
+// AddPolicySimulationQuery adds to the request a query that simulates
+// the given endorsement policy against the peers of the channel
+func (req *Request) AddPolicySimulationQuery(policy *common.SignaturePolicyEnvelope) *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_PolicySimulation{
+		PolicySimulation: &discovery.PolicySimulationQuery{
+			Policy: policy,
+		},
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.PolicySimulationQueryType, ch)
+	return req
+}
+
+// AddChaincodeVersionsQuery adds to the request a query for the versions
+// of the given chaincodes installed on the peers of the channel
+func (req *Request) AddChaincodeVersionsQuery(chaincodes ...string) *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_ChaincodeVersions{
+		ChaincodeVersions: &discovery.ChaincodeVersionsQuery{
+			Chaincodes: chaincodes,
+		},
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.ChaincodeVersionsQueryType, ch)
+	return req
+}
+
+// AddOrgCombinationsQuery adds to the request a query for the combinations of organizations
+// whose endorsements satisfy the endorsement policies of the given chaincode calls.
+// Unlike an endorsers query, the peers of the organizations aren't resolved.
+func (req *Request) AddOrgCombinationsQuery(calls ...*discovery.ChaincodeCall) *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_OrgCombinations{
+		OrgCombinations: &discovery.OrgCombinationsQuery{},
+	}
+	for _, call := range calls {
+		q.OrgCombinations.Interests = append(q.OrgCombinations.Interests, &discovery.ChaincodeInterest{
+			Chaincodes: []*discovery.ChaincodeCall{call},
+		})
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.OrgCombinationsQueryType, ch)
+	return req
+}
+
+// AddConfigUpdateImpactQuery adds to the request a query for the impact
+// the given marshaled common.ConfigUpdate would have on the channel
+func (req *Request) AddConfigUpdateImpactQuery(configUpdate []byte) *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_ConfigUpdateImpact{
+		ConfigUpdateImpact: &discovery.ConfigUpdateImpactQuery{
+			ConfigUpdate: configUpdate,
+		},
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.ConfigUpdateImpactQueryType, ch)
+	return req
+}
+
 // OfChannel sets the next queries added to be in the given channel's context
 func (req *Request) OfChannel(ch string) *Request {
 	req.lastChannel = ch
@@ -125,8 +288,73 @@ func (req *Request) addQueryMapping(queryType discovery.QueryType, key string) {
 
 // Send sends the request and returns the response, or error on failure
 func (c *Client) Send(ctx context.Context, req *Request, auth *discovery.AuthInfo) (Response, error) {
+	nonce, err := c.newNonce()
+	if err != nil {
+		return nil, err
+	}
+	signedReq, err := c.signedRequest(req, auth, nonce)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendRequest(ctx, c.createConnection, signedReq, req, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return computeResponse(req.queryMapping, resp)
+}
+
+// newNonce returns a nonce that binds signatures of responses to a request,
+// or nil if responses aren't verified
+func (c *Client) newNonce() ([]byte, error) {
+	if c.verifyResponse == nil {
+		return nil, nil
+	}
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+	return nonce, nil
+}
+
+// sendRequest sends the given SignedRequest to the discovery service the given Dialer connects to,
+// and returns the raw response after verifying its signature, if responses are verified
+func (c *Client) sendRequest(ctx context.Context, dial Dialer, signedReq *discovery.SignedRequest, req *Request, nonce []byte) (*discovery.Response, error) {
+	resp, err := sendRequest(ctx, dial, signedReq, req)
+	if err != nil {
+		return nil, err
+	}
+	if c.verifyResponse == nil {
+		return resp, nil
+	}
+	if err := VerifyResponse(resp, nonce, c.verifyResponse); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyResponse verifies the signature of the given response, which was received
+// for a request with the given nonce, using the given ResponseVerifier.
+// Returns nil if the response is properly signed, or error otherwise
+func VerifyResponse(resp *discovery.Response, nonce []byte, verify ResponseVerifier) error {
+	sig := resp.Signature
+	if sig == nil || len(sig.Signature) == 0 {
+		return errors.New("response isn't signed")
+	}
+	msg, err := resp.SignedContent(nonce)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling response")
+	}
+	if err := verify(sig.Identity, sig.Signature, msg); err != nil {
+		return errors.Wrap(err, "failed verifying response signature")
+	}
+	return nil
+}
+
+// signedRequest marshals the given Request with the given AuthInfo and nonce, and signs it
+func (c *Client) signedRequest(req *Request, auth *discovery.AuthInfo, nonce []byte) (*discovery.SignedRequest, error) {
 	reqToBeSent := *req.Request
 	reqToBeSent.Authentication = auth
+	reqToBeSent.Nonce = nonce
 	payload, err := proto.Marshal(&reqToBeSent)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed marshaling Request to bytes")
@@ -149,23 +377,29 @@ func (c *Client) Send(ctx context.Context, req *Request, auth *discovery.AuthInf
 	c.lastRequest = payload
 	c.lastSignature = sig
 
-	conn, err := c.createConnection()
+	return &discovery.SignedRequest{
+		Payload:   payload,
+		Signature: sig,
+	}, nil
+}
+
+// sendRequest sends the given SignedRequest to the discovery service the given Dialer connects to,
+// and returns the raw response
+func sendRequest(ctx context.Context, dial Dialer, signedReq *discovery.SignedRequest, req *Request) (*discovery.Response, error) {
+	conn, err := dial()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed connecting to discovery service")
 	}
 
 	cl := discovery.NewDiscoveryClient(conn)
-	resp, err := cl.Discover(ctx, &discovery.SignedRequest{
-		Payload:   payload,
-		Signature: sig,
-	})
+	resp, err := cl.Discover(ctx, signedReq)
 	if err != nil {
 		return nil, errors.Wrap(err, "discovery service refused our Request")
 	}
 	if n := len(resp.Results); n != req.lastIndex {
 		return nil, errors.Errorf("Sent %d queries but received %d responses back", req.lastIndex, n)
 	}
-	return computeResponse(req.queryMapping, resp)
+	return resp, nil
 }
 
 type resultOrError interface {
@@ -203,6 +437,74 @@ func (cr *channelResponse) Config() (*discovery.ConfigResult, error) {
 	return nil, res.(error)
 }
 
+func (cr *channelResponse) PolicySimulation() (*PolicySimulation, error) {
+	res, exists := cr.response[key{
+		queryType: discovery.PolicySimulationQueryType,
+		channel:   cr.channel,
+	}]
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if simulation, isSimulation := res.(*PolicySimulation); isSimulation {
+		return simulation, nil
+	}
+
+	return nil, res.(error)
+}
+
+func (cr *channelResponse) ChaincodeVersions() ([]*ChaincodeVersions, error) {
+	res, exists := cr.response[key{
+		queryType: discovery.ChaincodeVersionsQueryType,
+		channel:   cr.channel,
+	}]
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if versions, isVersions := res.([]*ChaincodeVersions); isVersions {
+		return versions, nil
+	}
+
+	return nil, res.(error)
+}
+
+func (cr *channelResponse) ConfigUpdateImpact() (*ConfigUpdateImpact, error) {
+	res, exists := cr.response[key{
+		queryType: discovery.ConfigUpdateImpactQueryType,
+		channel:   cr.channel,
+	}]
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if impact, isImpact := res.(*ConfigUpdateImpact); isImpact {
+		return impact, nil
+	}
+
+	return nil, res.(error)
+}
+
+func (cr *channelResponse) OrgCombinations() ([]*OrgCombinations, error) {
+	res, exists := cr.response[key{
+		queryType: discovery.OrgCombinationsQueryType,
+		channel:   cr.channel,
+	}]
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if combinations, isCombinations := res.([]*OrgCombinations); isCombinations {
+		return combinations, nil
+	}
+
+	return nil, res.(error)
+}
+
 func parsePeers(queryType discovery.QueryType, r response, channel string) ([]*Peer, error) {
 	res, exists := r[key{
 		queryType: queryType,
@@ -224,6 +526,10 @@ func (cr *channelResponse) Peers() ([]*Peer, error) {
 	return parsePeers(discovery.PeerMembershipQueryType, cr.response, cr.channel)
 }
 
+func (cr *channelResponse) SnapshotPeers() ([]*Peer, error) {
+	return parsePeers(discovery.SnapshotPeersQueryType, cr.response, cr.channel)
+}
+
 func (cr *channelResponse) Endorsers(cc string, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error) {
 	// If we have a key that has no chaincode field,
 	// it means it's an error returned from the service
@@ -308,6 +614,16 @@ func computeResponse(queryMapping map[discovery.QueryType]map[string]int, r *dis
 			err = resp.mapPeerMembership(channel2index, r, discovery.PeerMembershipQueryType)
 		case discovery.LocalMembershipQueryType:
 			err = resp.mapPeerMembership(channel2index, r, discovery.LocalMembershipQueryType)
+		case discovery.SnapshotPeersQueryType:
+			err = resp.mapPeerMembership(channel2index, r, discovery.SnapshotPeersQueryType)
+		case discovery.PolicySimulationQueryType:
+			err = resp.mapPolicySimulation(channel2index, r)
+		case discovery.ChaincodeVersionsQueryType:
+			err = resp.mapChaincodeVersions(channel2index, r)
+		case discovery.ConfigUpdateImpactQueryType:
+			err = resp.mapConfigUpdateImpact(channel2index, r)
+		case discovery.OrgCombinationsQueryType:
+			err = resp.mapOrgCombinations(channel2index, r)
 		}
 		if err != nil {
 			return nil, err
@@ -338,6 +654,154 @@ func (resp response) mapConfig(channel2index map[string]int, r *discovery.Respon
 	return nil
 }
 
+func (resp response) mapPolicySimulation(channel2index map[string]int, r *discovery.Response) error {
+	for ch, index := range channel2index {
+		simulationRes, err := r.PolicySimulationAt(index)
+		if simulationRes == nil && err == nil {
+			return errors.Errorf("expected QueryResult of either PolicySimulationResult or Error but got %v instead", r.Results[index])
+		}
+		key := key{
+			queryType: discovery.PolicySimulationQueryType,
+			channel:   ch,
+		}
+
+		if err != nil {
+			resp[key] = errors.New(err.Content)
+			continue
+		}
+
+		simulation := &PolicySimulation{
+			Satisfiable:  simulationRes.Satisfiable,
+			RequiredOrgs: simulationRes.RequiredOrgs,
+		}
+		for _, peers := range simulationRes.MinimalPeerSets {
+			var endorsers Endorsers
+			for _, p := range peers.Peers {
+				peer, err := endorser(p, "", ch)
+				if err != nil {
+					return errors.Wrap(err, "failed constructing peer set out of policy simulation")
+				}
+				endorsers = append(endorsers, peer)
+			}
+			simulation.MinimalPeerSets = append(simulation.MinimalPeerSets, endorsers)
+		}
+		resp[key] = simulation
+	}
+	return nil
+}
+
+func (resp response) mapChaincodeVersions(channel2index map[string]int, r *discovery.Response) error {
+	for ch, index := range channel2index {
+		versionsRes, err := r.ChaincodeVersionsAt(index)
+		if versionsRes == nil && err == nil {
+			return errors.Errorf("expected QueryResult of either ChaincodeVersionsResult or Error but got %v instead", r.Results[index])
+		}
+		key := key{
+			queryType: discovery.ChaincodeVersionsQueryType,
+			channel:   ch,
+		}
+
+		if err != nil {
+			resp[key] = errors.New(err.Content)
+			continue
+		}
+
+		var res []*ChaincodeVersions
+		for _, ccVersions := range versionsRes.Chaincodes {
+			versions := &ChaincodeVersions{
+				Chaincode:               ccVersions.Chaincode,
+				DefinedVersion:          ccVersions.DefinedVersion,
+				PeersByInstalledVersion: make(map[string][]*Peer),
+			}
+			for version, peers := range ccVersions.PeersByInstalledVersion {
+				for _, p := range peers.Peers {
+					peer, err := endorser(p, ccVersions.Chaincode, ch)
+					if err != nil {
+						return errors.Wrap(err, "failed constructing peers out of chaincode versions")
+					}
+					versions.PeersByInstalledVersion[version] = append(versions.PeersByInstalledVersion[version], peer)
+				}
+			}
+			res = append(res, versions)
+		}
+		resp[key] = res
+	}
+	return nil
+}
+
+func (resp response) mapConfigUpdateImpact(channel2index map[string]int, r *discovery.Response) error {
+	for ch, index := range channel2index {
+		impactRes, err := r.ConfigUpdateImpactAt(index)
+		if impactRes == nil && err == nil {
+			return errors.Errorf("expected QueryResult of either ConfigUpdateImpactResult or Error but got %v instead", r.Results[index])
+		}
+		key := key{
+			queryType: discovery.ConfigUpdateImpactQueryType,
+			channel:   ch,
+		}
+
+		if err != nil {
+			resp[key] = errors.New(err.Content)
+			continue
+		}
+
+		impact := &ConfigUpdateImpact{
+			PolicyChanges: impactRes.PolicyChanges,
+			AddedMSPs:     impactRes.AddedMsps,
+			RemovedMSPs:   impactRes.RemovedMsps,
+		}
+		for _, ejected := range impactRes.EjectedPeers {
+			if ejected.Peer == nil {
+				return errors.New("received an empty ejected peer")
+			}
+			peer, err := endorser(ejected.Peer, "", ch)
+			if err != nil {
+				return errors.Wrap(err, "failed constructing ejected peers out of config update impact")
+			}
+			impact.EjectedPeers = append(impact.EjectedPeers, &EjectedPeer{
+				Peer:   peer,
+				Reason: ejected.Reason,
+			})
+		}
+		resp[key] = impact
+	}
+	return nil
+}
+
+func (resp response) mapOrgCombinations(channel2index map[string]int, r *discovery.Response) error {
+	for ch, index := range channel2index {
+		combinationsRes, err := r.OrgCombinationsAt(index)
+		if combinationsRes == nil && err == nil {
+			return errors.Errorf("expected QueryResult of either OrgCombinationsResult or Error but got %v instead", r.Results[index])
+		}
+		key := key{
+			queryType: discovery.OrgCombinationsQueryType,
+			channel:   ch,
+		}
+
+		if err != nil {
+			resp[key] = errors.New(err.Content)
+			continue
+		}
+
+		var res []*OrgCombinations
+		for _, ccCombinations := range combinationsRes.Content {
+			combinations := &OrgCombinations{
+				Chaincode: ccCombinations.Chaincode,
+			}
+			for _, combination := range ccCombinations.Combinations {
+				if len(combination.QuantitiesByOrg) == 0 {
+					return errors.Errorf("received an empty org combination for chaincode %s", ccCombinations.Chaincode)
+				}
+				combinations.Combinations = append(combinations.Combinations, combination.QuantitiesByOrg)
+			}
+			res = append(res, combinations)
+		}
+		resp[key] = res
+	}
+	return nil
+}
+
 func (resp response) mapPeerMembership(channel2index map[string]int, r *discovery.Response, qt discovery.QueryType) error {
 	for ch, index := range channel2index {
 		membersRes, err := r.MembershipAt(index)
@@ -495,11 +959,18 @@ func endorser(peer *discovery.Peer, chaincode, channel string) (*Peer, error) {
 	if err := proto.Unmarshal(peer.Identity, sID); err != nil {
 		return nil, errors.Wrap(err, "failed unmarshaling peer's identity")
 	}
+	ledgerHeight := peer.LedgerHeight
+	if ledgerHeight == 0 {
+		// Servers that don't return the ledger height only advertise it in the StateInfo message
+		ledgerHeight = stateInfMsg.GetStateInfo().GetProperties().GetLedgerHeight()
+	}
 	return &Peer{
 		Identity:         peer.Identity,
 		StateInfoMessage: stateInfMsg,
 		AliveMessage:     aliveMsg,
 		MSPID:            sID.Mspid,
+		LedgerHeight:     ledgerHeight,
+		RTT:              time.Duration(peer.RttMicros) * time.Microsecond,
 	}, nil
 }
 
@@ -516,6 +987,16 @@ func NewClient(createConnection Dialer, s Signer) *Client {
 	}
 }
 
+// NewVerifyingClient creates a new Client instance that requires responses
+// to be signed, and verifies their signatures with the given ResponseVerifier
+func NewVerifyingClient(createConnection Dialer, s Signer, v ResponseVerifier) *Client {
+	return &Client{
+		createConnection: createConnection,
+		signRequest:      s,
+		verifyResponse:   v,
+	}
+}
+
 func validateAliveMessage(message *gossip.SignedGossipMessage) error {
 	am := message.GetAliveMsg()
 	if am == nil {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package discovery
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -106,6 +107,21 @@ var (
 		newPeer(7, stateInfoMessage(), nil).NetworkMember,
 	}
 
+	propertiesWithSnapshots = &gossip.Properties{
+		SnapshotHeights: []uint64{10},
+	}
+
+	channelPeersWithSnapshots = discovery3.Members{
+		newPeer(0, stateInfoMessageWithSnapshots(10), propertiesWithSnapshots).NetworkMember,
+		newPeer(1, stateInfoMessage(), nil).NetworkMember,
+		newPeer(2, stateInfoMessageWithSnapshots(10), propertiesWithSnapshots).NetworkMember,
+		newPeer(3, stateInfoMessage(), nil).NetworkMember,
+		newPeer(4, stateInfoMessage(), nil).NetworkMember,
+		newPeer(5, stateInfoMessage(), nil).NetworkMember,
+		newPeer(6, stateInfoMessage(), nil).NetworkMember,
+		newPeer(7, stateInfoMessage(), nil).NetworkMember,
+	}
+
 	membershipPeers = discovery3.Members{
 		newPeer(0, aliveMessage(0), nil).NetworkMember,
 		newPeer(1, aliveMessage(1), nil).NetworkMember,
@@ -355,6 +371,25 @@ func TestClient(t *testing.T) {
 	assert.NoError(t, err)
 	// The combinations of endorsers should be in the expected combinations
 	assert.Contains(t, expectedOrgCombinations, getMSPs(endorsers))
+
+	// Lastly, we check the case when some of the peers advertise ledger snapshots.
+	sup.On("PeersOfChannel").Return(channelPeersWithSnapshots).Once()
+	req = NewRequest()
+	req.OfChannel("mychannel").AddSnapshotPeersQuery(10)
+	r, err = cl.Send(ctx, req, authInfo)
+	assert.NoError(t, err)
+
+	peers, err = r.ForChannel("fakeChannel").SnapshotPeers()
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, peers)
+
+	// Only peers that advertise a snapshot height of at least 10 should be returned
+	peers, err = r.ForChannel("mychannel").SnapshotPeers()
+	assert.NoError(t, err)
+	assert.Len(t, peers, 2)
+	for _, p := range peers {
+		assert.Contains(t, p.StateInfoMessage.GetStateInfo().Properties.SnapshotHeights, uint64(10))
+	}
 }
 
 func TestUnableToSign(t *testing.T) {
@@ -498,6 +533,374 @@ func TestBadResponses(t *testing.T) {
 	assert.Empty(t, r)
 }
 
+func TestResponseVerification(t *testing.T) {
+	signer := func(msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+	// The peer "signs" responses by returning the message as its signature
+	peerSigner := &responseSigner{identity: []byte("p0")}
+	verifier := func(identity, signature, msg []byte) error {
+		if !bytes.Equal(identity, []byte("p0")) {
+			return errors.Errorf("%s isn't a trusted peer", string(identity))
+		}
+		if !bytes.Equal(signature, msg) {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+	startService := func(conf fabricdisc.Config) (*grpc.Server, Dialer) {
+		l, err := net.Listen("tcp", "localhost:0")
+		assert.NoError(t, err)
+		sup := &mockSupport{}
+		sup.On("Config", "mychannel").Return(expectedConf)
+		s := grpc.NewServer()
+		discovery.RegisterDiscoveryServer(s, fabricdisc.NewService(conf, sup))
+		go s.Serve(l)
+		return s, func() (*grpc.ClientConn, error) {
+			return grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+		}
+	}
+	auth := &discovery.AuthInfo{
+		ClientIdentity: []byte{1, 2, 3},
+	}
+	req := NewRequest().OfChannel("mychannel").AddConfigQuery()
+
+	// Scenario I: The peer signs its responses, and the client verifies them
+	server, connect := startService(fabricdisc.Config{ResponseSigner: peerSigner})
+	defer server.Stop()
+	r, err := NewVerifyingClient(connect, signer, verifier).Send(ctx, req, auth)
+	assert.NoError(t, err)
+	conf, err := r.ForChannel("mychannel").Config()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedConf.Msps, conf.Msps)
+
+	// Scenario II: The peer signs its responses, but with an identity the client doesn't trust
+	peerSigner.identity = []byte("p1")
+	r, err = NewVerifyingClient(connect, signer, verifier).Send(ctx, req, auth)
+	assert.EqualError(t, err, "failed verifying response signature: p1 isn't a trusted peer")
+	assert.Nil(t, r)
+
+	// Scenario III: Clients that don't verify responses ignore the signatures
+	r, err = NewClient(connect, signer).Send(ctx, req, auth)
+	assert.NoError(t, err)
+	assert.NotNil(t, r)
+
+	// Scenario IV: The peer doesn't sign its responses
+	unsignedServer, connect := startService(fabricdisc.Config{})
+	defer unsignedServer.Stop()
+	r, err = NewVerifyingClient(connect, signer, verifier).Send(ctx, req, auth)
+	assert.EqualError(t, err, "response isn't signed")
+	assert.Nil(t, r)
+}
+
+func TestVerifyResponse(t *testing.T) {
+	verifier := func(identity, signature, msg []byte) error {
+		if !bytes.Equal(signature, msg) {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+	resp := &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_ConfigResult{
+					ConfigResult: expectedConf,
+				},
+			},
+		},
+	}
+	msg, _ := resp.SignedContent([]byte("nonce"))
+	resp.Signature = &discovery.ResponseSignature{Identity: []byte("p0"), Signature: msg}
+
+	// Scenario I: A properly signed response
+	assert.NoError(t, VerifyResponse(resp, []byte("nonce"), verifier))
+
+	// Scenario II: The response was signed for another request
+	err := VerifyResponse(resp, []byte("another nonce"), verifier)
+	assert.EqualError(t, err, "failed verifying response signature: bad signature")
+
+	// Scenario III: The response was tampered with after it was signed
+	resp.Results = append(resp.Results, resp.Results[0])
+	err = VerifyResponse(resp, []byte("nonce"), verifier)
+	assert.EqualError(t, err, "failed verifying response signature: bad signature")
+
+	// Scenario IV: The response isn't signed
+	resp.Signature = nil
+	assert.EqualError(t, VerifyResponse(resp, []byte("nonce"), verifier), "response isn't signed")
+}
+
+func TestAddEndorsersQueryForCalls(t *testing.T) {
+	req := NewRequest().OfChannel("mychannel").AddEndorsersQueryForCalls(
+		&discovery.ChaincodeCall{Name: "cc1", CollectionNames: []string{"col1", "col2"}},
+		&discovery.ChaincodeCall{Name: "cc2"},
+	)
+	assert.Len(t, req.Queries, 1)
+	assert.Equal(t, "mychannel", req.Queries[0].Channel)
+	assert.Equal(t, []*discovery.ChaincodeInterest{
+		{Chaincodes: []*discovery.ChaincodeCall{{Name: "cc1", CollectionNames: []string{"col1", "col2"}}}},
+		{Chaincodes: []*discovery.ChaincodeCall{{Name: "cc2"}}},
+	}, req.Queries[0].GetCcQuery().Interests)
+	assert.Equal(t, 0, req.queryMapping[discovery.ChaincodeQueryType]["mychannel"])
+}
+
+func TestPolicySimulationResponse(t *testing.T) {
+	identity := peerIdentity("A", 0).Identity
+	req := NewRequest().OfChannel("mychannel").AddPolicySimulationQuery(&common.SignaturePolicyEnvelope{})
+	req.OfChannel("yourchannel").AddPolicySimulationQuery(&common.SignaturePolicyEnvelope{})
+	r := &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_PolicySimulationRes{
+					PolicySimulationRes: &discovery.PolicySimulationResult{
+						Satisfiable:  true,
+						RequiredOrgs: []string{"A"},
+						MinimalPeerSets: []*discovery.Peers{
+							{
+								Peers: []*discovery.Peer{
+									{
+										Identity:       identity,
+										MembershipInfo: aliveMessage(0),
+										StateInfo:      stateInfoMessage(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Result: &discovery.QueryResult_Error{
+					Error: &discovery.Error{Content: "policy is empty"},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The results are mapped to their channels
+	resp, err := computeResponse(req.queryMapping, r)
+	assert.NoError(t, err)
+	simulation, err := resp.ForChannel("mychannel").PolicySimulation()
+	assert.NoError(t, err)
+	assert.True(t, simulation.Satisfiable)
+	assert.Equal(t, []string{"A"}, simulation.RequiredOrgs)
+	assert.Len(t, simulation.MinimalPeerSets, 1)
+	assert.Equal(t, "A", simulation.MinimalPeerSets[0][0].MSPID)
+	assert.Equal(t, []byte(identity), simulation.MinimalPeerSets[0][0].Identity)
+
+	simulation, err = resp.ForChannel("yourchannel").PolicySimulation()
+	assert.Nil(t, simulation)
+	assert.EqualError(t, err, "policy is empty")
+
+	_, err = resp.ForChannel("ourchannel").PolicySimulation()
+	assert.Equal(t, ErrNotFound, err)
+
+	// Scenario II: A peer in a minimal peer set has no state info
+	r.Results[0].GetPolicySimulationRes().MinimalPeerSets[0].Peers[0].StateInfo = nil
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "failed constructing peer set out of policy simulation")
+
+	// Scenario III: The result is of the wrong type
+	r.Results[0].Result = &discovery.QueryResult_ConfigResult{ConfigResult: &discovery.ConfigResult{}}
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "expected QueryResult of either PolicySimulationResult or Error")
+}
+
+func TestChaincodeVersionsResponse(t *testing.T) {
+	identity := peerIdentity("A", 0).Identity
+	req := NewRequest().OfChannel("mychannel").AddChaincodeVersionsQuery("mycc")
+	req.OfChannel("yourchannel").AddChaincodeVersionsQuery()
+	assert.Equal(t, []string{"mycc"}, req.Queries[0].GetChaincodeVersions().Chaincodes)
+	r := &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_ChaincodeVersionsRes{
+					ChaincodeVersionsRes: &discovery.ChaincodeVersionsResult{
+						Chaincodes: []*discovery.ChaincodeVersions{
+							{
+								Chaincode:      "mycc",
+								DefinedVersion: "1.0",
+								PeersByInstalledVersion: map[string]*discovery.Peers{
+									"0.9": {
+										Peers: []*discovery.Peer{
+											{
+												Identity:       identity,
+												MembershipInfo: aliveMessage(0),
+												StateInfo:      stateInfoMessage(),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Result: &discovery.QueryResult_Error{
+					Error: &discovery.Error{Content: "no chaincodes specified"},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The results are mapped to their channels
+	resp, err := computeResponse(req.queryMapping, r)
+	assert.NoError(t, err)
+	versions, err := resp.ForChannel("mychannel").ChaincodeVersions()
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+	assert.Equal(t, "mycc", versions[0].Chaincode)
+	assert.Equal(t, "1.0", versions[0].DefinedVersion)
+	assert.Len(t, versions[0].PeersByInstalledVersion["0.9"], 1)
+	assert.Equal(t, "A", versions[0].PeersByInstalledVersion["0.9"][0].MSPID)
+
+	versions, err = resp.ForChannel("yourchannel").ChaincodeVersions()
+	assert.Nil(t, versions)
+	assert.EqualError(t, err, "no chaincodes specified")
+
+	_, err = resp.ForChannel("ourchannel").ChaincodeVersions()
+	assert.Equal(t, ErrNotFound, err)
+
+	// Scenario II: A peer has no state info
+	r.Results[0].GetChaincodeVersionsRes().Chaincodes[0].PeersByInstalledVersion["0.9"].Peers[0].StateInfo = nil
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "failed constructing peers out of chaincode versions")
+
+	// Scenario III: The result is of the wrong type
+	r.Results[0].Result = &discovery.QueryResult_ConfigResult{ConfigResult: &discovery.ConfigResult{}}
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "expected QueryResult of either ChaincodeVersionsResult or Error")
+}
+
+func TestOrgCombinationsResponse(t *testing.T) {
+	req := NewRequest().OfChannel("mychannel").AddOrgCombinationsQuery(&discovery.ChaincodeCall{Name: "mycc"})
+	req.OfChannel("yourchannel").AddOrgCombinationsQuery(&discovery.ChaincodeCall{Name: "mycc"})
+	assert.Equal(t, "mycc", req.Queries[0].GetOrgCombinations().Interests[0].Chaincodes[0].Name)
+	r := &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_OrgCombinationsRes{
+					OrgCombinationsRes: &discovery.OrgCombinationsResult{
+						Content: []*discovery.OrgCombinations{
+							{
+								Chaincode: "mycc",
+								Combinations: []*discovery.OrgCombination{
+									{QuantitiesByOrg: map[string]uint32{"A": 1, "B": 1}},
+									{QuantitiesByOrg: map[string]uint32{"C": 2}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Result: &discovery.QueryResult_Error{
+					Error: &discovery.Error{Content: "policy not found"},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The results are mapped to their channels
+	resp, err := computeResponse(req.queryMapping, r)
+	assert.NoError(t, err)
+	combinations, err := resp.ForChannel("mychannel").OrgCombinations()
+	assert.NoError(t, err)
+	assert.Equal(t, []*OrgCombinations{
+		{
+			Chaincode:    "mycc",
+			Combinations: []map[string]uint32{{"A": 1, "B": 1}, {"C": 2}},
+		},
+	}, combinations)
+
+	combinations, err = resp.ForChannel("yourchannel").OrgCombinations()
+	assert.Nil(t, combinations)
+	assert.EqualError(t, err, "policy not found")
+
+	_, err = resp.ForChannel("ourchannel").OrgCombinations()
+	assert.Equal(t, ErrNotFound, err)
+
+	// Scenario II: A combination is empty
+	r.Results[0].GetOrgCombinationsRes().Content[0].Combinations[1].QuantitiesByOrg = nil
+	_, err = computeResponse(req.queryMapping, r)
+	assert.EqualError(t, err, "received an empty org combination for chaincode mycc")
+
+	// Scenario III: The result is of the wrong type
+	r.Results[0].Result = &discovery.QueryResult_ConfigResult{ConfigResult: &discovery.ConfigResult{}}
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "expected QueryResult of either OrgCombinationsResult or Error")
+}
+
+func TestConfigUpdateImpactResponse(t *testing.T) {
+	identity := peerIdentity("A", 0).Identity
+	req := NewRequest().OfChannel("mychannel").AddConfigUpdateImpactQuery([]byte{1, 2, 3})
+	req.OfChannel("yourchannel").AddConfigUpdateImpactQuery(nil)
+	assert.Equal(t, []byte{1, 2, 3}, req.Queries[0].GetConfigUpdateImpact().ConfigUpdate)
+	r := &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_ConfigUpdateImpactRes{
+					ConfigUpdateImpactRes: &discovery.ConfigUpdateImpactResult{
+						PolicyChanges: []*discovery.PolicyChange{
+							{Path: "/Channel/Application/A/Admins", Type: discovery.PolicyChange_REMOVED},
+						},
+						RemovedMsps: []string{"A"},
+						EjectedPeers: []*discovery.EjectedPeer{
+							{
+								Peer: &discovery.Peer{
+									Identity:       identity,
+									MembershipInfo: aliveMessage(0),
+									StateInfo:      stateInfoMessage(),
+								},
+								Reason: "A is not an application organization of the channel",
+							},
+						},
+					},
+				},
+			},
+			{
+				Result: &discovery.QueryResult_Error{
+					Error: &discovery.Error{Content: "failed parsing config update"},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The results are mapped to their channels
+	resp, err := computeResponse(req.queryMapping, r)
+	assert.NoError(t, err)
+	impact, err := resp.ForChannel("mychannel").ConfigUpdateImpact()
+	assert.NoError(t, err)
+	assert.Equal(t, "/Channel/Application/A/Admins", impact.PolicyChanges[0].Path)
+	assert.Equal(t, []string{"A"}, impact.RemovedMSPs)
+	assert.Empty(t, impact.AddedMSPs)
+	assert.Len(t, impact.EjectedPeers, 1)
+	assert.Equal(t, "A", impact.EjectedPeers[0].MSPID)
+	assert.Equal(t, "A is not an application organization of the channel", impact.EjectedPeers[0].Reason)
+
+	impact, err = resp.ForChannel("yourchannel").ConfigUpdateImpact()
+	assert.Nil(t, impact)
+	assert.EqualError(t, err, "failed parsing config update")
+
+	_, err = resp.ForChannel("ourchannel").ConfigUpdateImpact()
+	assert.Equal(t, ErrNotFound, err)
+
+	// Scenario II: An ejected peer has no state info
+	r.Results[0].GetConfigUpdateImpactRes().EjectedPeers[0].Peer.StateInfo = nil
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "failed constructing ejected peers out of config update impact")
+
+	// Scenario III: An ejected peer is empty
+	r.Results[0].GetConfigUpdateImpactRes().EjectedPeers[0].Peer = nil
+	_, err = computeResponse(req.queryMapping, r)
+	assert.EqualError(t, err, "received an empty ejected peer")
+
+	// Scenario IV: The result is of the wrong type
+	r.Results[0].Result = &discovery.QueryResult_ConfigResult{ConfigResult: &discovery.ConfigResult{}}
+	_, err = computeResponse(req.queryMapping, r)
+	assert.Contains(t, err.Error(), "expected QueryResult of either ConfigUpdateImpactResult or Error")
+}
+
 func TestValidateAliveMessage(t *testing.T) {
 	am := aliveMessage(1)
 	msg, _ := am.ToGossipMessage()
@@ -543,6 +946,75 @@ func TestValidateStateInfoMessage(t *testing.T) {
 	assert.Equal(t, "message isn't a stateInfo message", err.Error())
 }
 
+func TestEndorserLedgerHeight(t *testing.T) {
+	peer := &discovery.Peer{
+		MembershipInfo: aliveMessage(1),
+		StateInfo:      stateInfoWithHeight(100).Envelope,
+		Identity: utils.MarshalOrPanic(&msp.SerializedIdentity{
+			Mspid:   "A",
+			IdBytes: []byte("p1"),
+		}),
+	}
+
+	// Scenario I: The server doesn't return the ledger height,
+	// so it is taken from the StateInfo message
+	p, err := endorser(peer, "mycc", "mychannel")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), p.LedgerHeight)
+
+	// Scenario II: The server returns the ledger height
+	peer.LedgerHeight = 101
+	p, err = endorser(peer, "mycc", "mychannel")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), p.LedgerHeight)
+}
+
+func TestRequestMaxLedgerHeightLag(t *testing.T) {
+	// The lag applies to chaincode interests added both before and after it is set
+	req := NewRequest().OfChannel("mychannel").AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc1"})
+	req = req.SetMaxLedgerHeightLag(5).AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc2"})
+	assert.Len(t, req.Queries, 2)
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().Interests {
+			assert.Equal(t, uint64(5), interest.MaxLedgerHeightLag)
+		}
+	}
+}
+
+func TestRequestPreferredOrgs(t *testing.T) {
+	// The preferred organizations apply to chaincode interests added both before and after they are set
+	req := NewRequest().OfChannel("mychannel").AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc1"})
+	req = req.SetPreferredOrgs("Org1MSP", "Org2MSP").AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc2"})
+	assert.Len(t, req.Queries, 2)
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().Interests {
+			assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, interest.PreferredOrgs)
+		}
+	}
+}
+
+func TestRequestMaxPreferredRTT(t *testing.T) {
+	// The maximum round trip time applies to chaincode interests added both before and after it is set
+	req := NewRequest().OfChannel("mychannel").AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc1"})
+	req = req.SetMaxPreferredRTT(10 * time.Millisecond).AddEndorsersQueryForCalls(&discovery.ChaincodeCall{Name: "cc2"})
+	assert.Len(t, req.Queries, 2)
+	for _, q := range req.Queries {
+		for _, interest := range q.GetCcQuery().Interests {
+			assert.Equal(t, uint64(10000), interest.MaxPreferredRttMicros)
+		}
+	}
+}
+
+func TestRequestDescriptorVersion(t *testing.T) {
+	// The version applies to endorsers queries added both before and after it is set
+	req := NewRequest().OfChannel("mychannel").AddEndorsersQuery("cc1").AddConfigQuery()
+	req = req.SetDescriptorVersion(discovery.DescriptorVersion_V2).AddEndorsersQuery("cc2")
+	assert.Len(t, req.Queries, 3)
+	assert.Equal(t, discovery.DescriptorVersion_V2, req.Queries[0].GetCcQuery().DescriptorVersion)
+	assert.Nil(t, req.Queries[1].GetCcQuery())
+	assert.Equal(t, discovery.DescriptorVersion_V2, req.Queries[2].GetCcQuery().DescriptorVersion)
+}
+
 func getMSP(peer *Peer) string {
 	endpoint := peer.AliveMessage.GetAliveMsg().Membership.Endpoint
 	id, _ := strconv.ParseInt(endpoint[1:], 10, 64)
@@ -602,8 +1074,22 @@ func (pf *policyFetcher) PolicyByChaincode(channel string, cc string) policies.I
 	return pf.Called().Get(0).(policies.InquireablePolicy)
 }
 
+func (pf *policyFetcher) SubPolicyByChaincode(channel string, cc string, name string) policies.InquireablePolicy {
+	return nil
+}
+
+func (pf *policyFetcher) PolicyOfKey(channel string, cc string, key string) (*common.SignaturePolicyEnvelope, error) {
+	return nil, nil
+}
+
 type endorsementAnalyzer interface {
 	PeersForEndorsement(chainID gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error)
+
+	SimulatePolicy(chainID gossipcommon.ChainID, policy *common.SignaturePolicyEnvelope) (*discovery.PolicySimulationResult, error)
+
+	ChaincodeVersions(chainID gossipcommon.ChainID, chaincodes []string) (*discovery.ChaincodeVersionsResult, error)
+
+	OrgCombinations(chainID gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.OrgCombinations, error)
 }
 
 type inquireablePolicy struct {
@@ -685,6 +1171,24 @@ func stateInfoMessage(chaincodes ...*gossip.Chaincode) *gossip.Envelope {
 	return sMsg.Envelope
 }
 
+func stateInfoMessageWithSnapshots(heights ...uint64) *gossip.Envelope {
+	g := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{
+				Timestamp: &gossip.PeerTime{
+					SeqNum: 5,
+					IncNum: uint64(time.Now().UnixNano()),
+				},
+				Properties: &gossip.Properties{
+					SnapshotHeights: heights,
+				},
+			},
+		},
+	}
+	sMsg, _ := g.NoopSign()
+	return sMsg.Envelope
+}
+
 func newPeer(i int, env *gossip.Envelope, properties *gossip.Properties) *peerInfo {
 	p := fmt.Sprintf("p%d", i)
 	return &peerInfo{
@@ -700,6 +1204,18 @@ func newPeer(i int, env *gossip.Envelope, properties *gossip.Properties) *peerIn
 	}
 }
 
+type responseSigner struct {
+	identity []byte
+}
+
+func (rs *responseSigner) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func (rs *responseSigner) Serialize() ([]byte, error) {
+	return rs.identity, nil
+}
+
 type mockSupport struct {
 	seq uint64
 	mock.Mock
@@ -728,10 +1244,26 @@ func (ms *mockSupport) Peers() discovery3.Members {
 	return ms.Called().Get(0).(discovery3.Members)
 }
 
+func (ms *mockSupport) PeerLatencies(gossipcommon.ChainID) map[string]time.Duration {
+	return nil
+}
+
 func (ms *mockSupport) PeersForEndorsement(channel gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
 	return ms.endorsementAnalyzer.PeersForEndorsement(channel, interest)
 }
 
+func (ms *mockSupport) SimulatePolicy(channel gossipcommon.ChainID, policy *common.SignaturePolicyEnvelope) (*discovery.PolicySimulationResult, error) {
+	return ms.endorsementAnalyzer.SimulatePolicy(channel, policy)
+}
+
+func (ms *mockSupport) ChaincodeVersions(channel gossipcommon.ChainID, chaincodes []string) (*discovery.ChaincodeVersionsResult, error) {
+	return ms.endorsementAnalyzer.ChaincodeVersions(channel, chaincodes)
+}
+
+func (ms *mockSupport) OrgCombinations(channel gossipcommon.ChainID, interest *discovery.ChaincodeInterest) (*discovery.OrgCombinations, error) {
+	return ms.endorsementAnalyzer.OrgCombinations(channel, interest)
+}
+
 func (*mockSupport) EligibleForService(channel string, data common.SignedData) error {
 	return nil
 }
@@ -740,6 +1272,10 @@ func (ms *mockSupport) Config(channel string) (*discovery.ConfigResult, error) {
 	return ms.Called(channel).Get(0).(*discovery.ConfigResult), nil
 }
 
+func (ms *mockSupport) ConfigUpdateImpact(channel string, configUpdate *common.ConfigUpdate, peersByOrg map[string]*discovery.Peers) (*discovery.ConfigUpdateImpactResult, error) {
+	panic("implement me")
+}
+
 type mockDiscoveryServer struct {
 	mock.Mock
 	*grpc.Server
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+// QuorumPeer is a peer that a Request is sent to in quorum mode
+type QuorumPeer struct {
+	// Endpoint identifies the peer in the QuorumReport
+	Endpoint string
+	// Dialer connects to the discovery service of the peer
+	Dialer Dialer
+}
+
+// QuorumReport describes how the peers queried in quorum mode responded
+type QuorumReport struct {
+	// Agreeing are the endpoints of the peers that returned
+	// the response that was accepted, or that is the most common one
+	// if no response was accepted
+	Agreeing []string
+	// Disagreeing are the endpoints of the peers that returned
+	// responses that are semantically different than the one of the Agreeing peers
+	Disagreeing []string
+	// Failed maps endpoints of peers that couldn't be queried, to the reason
+	Failed map[string]error
+}
+
+// QuorumError is returned when not enough peers returned semantically equivalent responses
+type QuorumError struct {
+	Threshold int
+	Report    QuorumReport
+}
+
+// Error returns a string representation of this QuorumError
+func (qe *QuorumError) Error() string {
+	return fmt.Sprintf("quorum of %d not reached: %d peers agree, %d peers disagree, %d peers failed",
+		qe.Threshold, len(qe.Report.Agreeing), len(qe.Report.Disagreeing), len(qe.Report.Failed))
+}
+
+// SendWithQuorum sends the request to all given peers, and accepts the response only if at least
+// threshold of them returned semantically equivalent responses.
+// Responses are considered equivalent if they contain the same configuration, the same peers
+// and the same ways of satisfying endorsement policies, regardless of ledger heights,
+// ordering of peers and names of endorsement groups.
+// The returned QuorumReport lists the peers that agree with the accepted response,
+// and the ones that disagree with it or failed. If no quorum is reached, a *QuorumError
+// that contains the QuorumReport is returned.
+func (c *Client) SendWithQuorum(ctx context.Context, req *Request, auth *discovery.AuthInfo, threshold int, peers ...QuorumPeer) (Response, QuorumReport, error) {
+	report := QuorumReport{Failed: make(map[string]error)}
+	if threshold < 1 || threshold > len(peers) {
+		return nil, report, errors.Errorf("threshold must be between 1 and the number of peers (%d), but is %d", len(peers), threshold)
+	}
+	nonce, err := c.newNonce()
+	if err != nil {
+		return nil, report, err
+	}
+	signedReq, err := c.signedRequest(req, auth, nonce)
+	if err != nil {
+		return nil, report, err
+	}
+
+	responses := make([]*discovery.Response, len(peers))
+	errs := make([]error, len(peers))
+	var wg sync.WaitGroup
+	wg.Add(len(peers))
+	for i, p := range peers {
+		go func(i int, p QuorumPeer) {
+			defer wg.Done()
+			responses[i], errs[i] = c.sendRequest(ctx, p.Dialer, signedReq, req, nonce)
+		}(i, p)
+	}
+	wg.Wait()
+
+	// Group the peers by the fingerprints of their responses
+	var fingerprints []string
+	peersByFingerprint := make(map[string][]int)
+	for i, p := range peers {
+		if errs[i] != nil {
+			report.Failed[p.Endpoint] = errs[i]
+			continue
+		}
+		fp := fingerprintResponse(responses[i])
+		if _, exists := peersByFingerprint[fp]; !exists {
+			fingerprints = append(fingerprints, fp)
+		}
+		peersByFingerprint[fp] = append(peersByFingerprint[fp], i)
+	}
+
+	var majority string
+	var majoritySize int
+	for _, fp := range fingerprints {
+		if len(peersByFingerprint[fp]) > majoritySize {
+			majority = fp
+			majoritySize = len(peersByFingerprint[fp])
+		}
+	}
+	for _, fp := range fingerprints {
+		for _, i := range peersByFingerprint[fp] {
+			if fp == majority {
+				report.Agreeing = append(report.Agreeing, peers[i].Endpoint)
+			} else {
+				report.Disagreeing = append(report.Disagreeing, peers[i].Endpoint)
+			}
+		}
+	}
+
+	if len(report.Agreeing) < threshold {
+		return nil, report, &QuorumError{Threshold: threshold, Report: report}
+	}
+	resp, err := computeResponse(req.queryMapping, responses[peersByFingerprint[majority][0]])
+	if err != nil {
+		return nil, report, err
+	}
+	return resp, report, nil
+}
+
+// fingerprintResponse returns a string that is identical for semantically equivalent responses
+func fingerprintResponse(resp *discovery.Response) string {
+	buff := &bytes.Buffer{}
+	for i, res := range resp.Results {
+		fmt.Fprintf(buff, "%d:", i)
+		switch r := res.Result.(type) {
+		case *discovery.QueryResult_Error:
+			fmt.Fprintf(buff, "error(%s)", r.Error.GetContent())
+		case *discovery.QueryResult_ConfigResult:
+			fmt.Fprintf(buff, "config(%s)", proto.CompactTextString(r.ConfigResult))
+		case *discovery.QueryResult_Members:
+			fmt.Fprintf(buff, "members(%s)", fingerprintMembers(r.Members))
+		case *discovery.QueryResult_CcQueryRes:
+			fmt.Fprintf(buff, "endorsers(%s)", fingerprintEndorsers(r.CcQueryRes))
+		case *discovery.QueryResult_PolicySimulationRes:
+			fmt.Fprintf(buff, "simulation(%s)", fingerprintPolicySimulation(r.PolicySimulationRes))
+		case *discovery.QueryResult_OrgCombinationsRes:
+			fmt.Fprintf(buff, "orgs(%s)", proto.CompactTextString(r.OrgCombinationsRes))
+		default:
+			fmt.Fprintf(buff, "unknown(%T)", res.Result)
+		}
+		buff.WriteString(";")
+	}
+	return buff.String()
+}
+
+func fingerprintPolicySimulation(res *discovery.PolicySimulationResult) string {
+	var sets []string
+	for _, peers := range res.GetMinimalPeerSets() {
+		sets = append(sets, "["+fingerprintPeers(peers)+"]")
+	}
+	sort.Strings(sets)
+	return fmt.Sprintf("%t|%s|%s", res.Satisfiable, strings.Join(res.RequiredOrgs, ","), strings.Join(sets, ","))
+}
+
+// fingerprintPeers returns the sorted identities of the given peers,
+// disregarding their ledger heights and membership timestamps
+func fingerprintPeers(peers *discovery.Peers) string {
+	var identities []string
+	for _, p := range peers.GetPeers() {
+		identities = append(identities, hex.EncodeToString(p.Identity))
+	}
+	sort.Strings(identities)
+	return strings.Join(identities, ",")
+}
+
+func fingerprintMembers(members *discovery.PeerMembershipResult) string {
+	var orgs []string
+	for org, peers := range members.GetPeersByOrg() {
+		orgs = append(orgs, fmt.Sprintf("%s[%s]", org, fingerprintPeers(peers)))
+	}
+	sort.Strings(orgs)
+	return strings.Join(orgs, ",")
+}
+
+func fingerprintEndorsers(res *discovery.ChaincodeQueryResult) string {
+	var descriptors []string
+	for _, desc := range res.GetContent() {
+		// Group names are chosen arbitrarily by each peer, so layouts are
+		// represented by the contents of their groups instead of their names
+		var layouts []string
+		for _, layout := range desc.Layouts {
+			var groups []string
+			for grp, quantity := range layout.QuantitiesByGroup {
+				groups = append(groups, fmt.Sprintf("%d*[%s]", quantity, fingerprintPeers(desc.EndorsersByGroups[grp])))
+			}
+			sort.Strings(groups)
+			layouts = append(layouts, strings.Join(groups, "+"))
+		}
+		sort.Strings(layouts)
+		descriptors = append(descriptors, fmt.Sprintf("%s{%s}", desc.Chaincode, strings.Join(layouts, "|")))
+	}
+	sort.Strings(descriptors)
+	return strings.Join(descriptors, ",")
+}
@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func membershipResponse(identities ...string) *discovery.Response {
+	peers := &discovery.Peers{}
+	for i, id := range identities {
+		peers.Peers = append(peers.Peers, &discovery.Peer{
+			Identity:       []byte(id),
+			MembershipInfo: aliveMessage(i),
+			StateInfo:      stateInfoMessage(),
+		})
+	}
+	return &discovery.Response{
+		Results: []*discovery.QueryResult{
+			{
+				Result: &discovery.QueryResult_Members{
+					Members: &discovery.PeerMembershipResult{
+						PeersByOrg: map[string]*discovery.Peers{
+							"A": peers,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSendWithQuorum(t *testing.T) {
+	signer := func(msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+	auth := &discovery.AuthInfo{
+		ClientIdentity: []byte{1, 2, 3},
+	}
+
+	var services []*mockDiscoveryServer
+	var peers []QuorumPeer
+	for i := 0; i < 4; i++ {
+		svc := newMockDiscoveryService()
+		defer svc.shutdown()
+		services = append(services, svc)
+		port := svc.port
+		peers = append(peers, QuorumPeer{
+			Endpoint: fmt.Sprintf("p%d", i),
+			Dialer: func() (*grpc.ClientConn, error) {
+				return grpc.Dial(fmt.Sprintf("localhost:%d", port), grpc.WithInsecure())
+			},
+		})
+	}
+	// The first 2 peers see the same peers in a different order, the third peer has a skewed view,
+	// and the fourth peer fails
+	services[0].On("Discover").Return(membershipResponse("p1", "p2"), nil)
+	services[1].On("Discover").Return(membershipResponse("p2", "p1"), nil)
+	services[2].On("Discover").Return(membershipResponse("p1"), nil)
+	services[3].On("Discover").Return(nil, errors.New("foo"))
+
+	cl := NewClient(nil, signer)
+	req := NewRequest().OfChannel("mychannel").AddPeersQuery()
+
+	// Scenario I: Quorum is reached
+	resp, report, err := cl.SendWithQuorum(ctx, req, auth, 2, peers...)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p0", "p1"}, report.Agreeing)
+	assert.Equal(t, []string{"p2"}, report.Disagreeing)
+	assert.Len(t, report.Failed, 1)
+	assert.Contains(t, report.Failed["p3"].Error(), "foo")
+	channelPeers, err := resp.ForChannel("mychannel").Peers()
+	assert.NoError(t, err)
+	assert.Len(t, channelPeers, 2)
+
+	// Scenario II: Quorum isn't reached
+	resp, report, err = cl.SendWithQuorum(ctx, req, auth, 3, peers...)
+	assert.Nil(t, resp)
+	assert.IsType(t, &QuorumError{}, err)
+	assert.Equal(t, "quorum of 3 not reached: 2 peers agree, 1 peers disagree, 1 peers failed", err.Error())
+	assert.Equal(t, report, err.(*QuorumError).Report)
+
+	// Scenario III: Invalid threshold
+	_, _, err = cl.SendWithQuorum(ctx, req, auth, 5, peers...)
+	assert.EqualError(t, err, "threshold must be between 1 and the number of peers (4), but is 5")
+}
+
+func TestFingerprintEndorsers(t *testing.T) {
+	descriptor := func(groupNames ...string) *discovery.Response {
+		peersOf := func(ids ...string) *discovery.Peers {
+			peers := &discovery.Peers{}
+			for _, id := range ids {
+				peers.Peers = append(peers.Peers, &discovery.Peer{Identity: []byte(id), StateInfo: stateInfoMessage(&gossip.Chaincode{Name: "mycc"})})
+			}
+			return peers
+		}
+		return &discovery.Response{
+			Results: []*discovery.QueryResult{
+				{
+					Result: &discovery.QueryResult_CcQueryRes{
+						CcQueryRes: &discovery.ChaincodeQueryResult{
+							Content: []*discovery.EndorsementDescriptor{
+								{
+									Chaincode: "mycc",
+									EndorsersByGroups: map[string]*discovery.Peers{
+										groupNames[0]: peersOf("p1", "p2"),
+										groupNames[1]: peersOf("p3"),
+									},
+									Layouts: []*discovery.Layout{
+										{QuantitiesByGroup: map[string]uint32{groupNames[0]: 1}},
+										{QuantitiesByGroup: map[string]uint32{groupNames[0]: 1, groupNames[1]: 1}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Group names don't matter
+	assert.Equal(t, fingerprintResponse(descriptor("G0", "G1")), fingerprintResponse(descriptor("X", "Y")))
+	// Contents of groups do
+	assert.NotEqual(t, fingerprintResponse(descriptor("G0", "G1")), fingerprintResponse(descriptor("G1", "G0")))
+}
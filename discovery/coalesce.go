@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/protos/discovery"
+)
+
+// coalescingMetrics reports how effective the coalescing of endorsement queries is
+type coalescingMetrics struct {
+	computations metrics.Counter
+	coalesced    metrics.Counter
+}
+
+// newCoalescingMetrics creates the coalescing metrics,
+// or returns nil if metrics aren't initialized
+func newCoalescingMetrics() *coalescingMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("discovery")
+	return &coalescingMetrics{
+		computations: scope.Counter("endorsement_computations"),
+		coalesced:    scope.Counter("endorsement_queries_coalesced"),
+	}
+}
+
+func (m *coalescingMetrics) report(coalesced bool) {
+	if m == nil {
+		return
+	}
+	if coalesced {
+		m.coalesced.Inc(1)
+	} else {
+		m.computations.Inc(1)
+	}
+}
+
+// endorsementComputation is a computation of an endorsement descriptor
+// that concurrent identical queries wait for
+type endorsementComputation struct {
+	done    chan struct{}
+	waiters int
+	desc    *discovery.EndorsementDescriptor
+	err     error
+}
+
+// endorsementCoalescer coalesces identical endorsement queries that are processed concurrently
+// into a single computation, whose result is shared by all of them
+type endorsementCoalescer struct {
+	lock     sync.Mutex
+	inFlight map[string]*endorsementComputation
+	metrics  *coalescingMetrics
+}
+
+func newEndorsementCoalescer() *endorsementCoalescer {
+	return &endorsementCoalescer{
+		inFlight: make(map[string]*endorsementComputation),
+		metrics:  newCoalescingMetrics(),
+	}
+}
+
+// peersForEndorsement returns the result of compute for the given channel and interest.
+// If an identical computation is already in progress, its result is awaited instead.
+func (c *endorsementCoalescer) peersForEndorsement(channel string, interest *discovery.ChaincodeInterest, compute func() (*discovery.EndorsementDescriptor, error)) (*discovery.EndorsementDescriptor, error) {
+	rawInterest, err := proto.Marshal(interest)
+	if err != nil {
+		return compute()
+	}
+	key := channel + "\x00" + string(rawInterest)
+
+	c.lock.Lock()
+	if inProgress, exists := c.inFlight[key]; exists {
+		inProgress.waiters++
+		c.lock.Unlock()
+		c.metrics.report(true)
+		<-inProgress.done
+		return inProgress.desc, inProgress.err
+	}
+	computation := &endorsementComputation{done: make(chan struct{})}
+	c.inFlight[key] = computation
+	c.lock.Unlock()
+	c.metrics.report(false)
+
+	computation.desc, computation.err = compute()
+
+	c.lock.Lock()
+	delete(c.inFlight, key)
+	c.lock.Unlock()
+	close(computation.done)
+	return computation.desc, computation.err
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndorsementCoalescer(t *testing.T) {
+	c := newEndorsementCoalescer()
+	interest := func(cc string) *discovery.ChaincodeInterest {
+		return &discovery.ChaincodeInterest{Chaincodes: []*discovery.ChaincodeCall{{Name: cc}}}
+	}
+	rawInterest := func(cc string) []byte {
+		b, _ := proto.Marshal(interest(cc))
+		return b
+	}
+	waitFor := func(condition func() bool) {
+		for i := 0; i < 500; i++ {
+			c.lock.Lock()
+			satisfied := condition()
+			c.lock.Unlock()
+			if satisfied {
+				return
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+		t.Fatal("condition wasn't satisfied in time")
+	}
+
+	// Scenario I: 5 identical concurrent queries are computed once
+	release := make(chan struct{})
+	var computations int
+	var lock sync.Mutex
+	compute := func() (*discovery.EndorsementDescriptor, error) {
+		lock.Lock()
+		computations++
+		lock.Unlock()
+		<-release
+		return &discovery.EndorsementDescriptor{Chaincode: "cc1"}, nil
+	}
+	results := make(chan *discovery.EndorsementDescriptor, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			desc, err := c.peersForEndorsement("mychannel", interest("cc1"), compute)
+			assert.NoError(t, err)
+			results <- desc
+		}()
+	}
+	waitFor(func() bool {
+		return len(c.inFlight) == 1 && c.inFlight["mychannel\x00"+string(rawInterest("cc1"))].waiters == 4
+	})
+	close(release)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, "cc1", (<-results).Chaincode)
+	}
+	assert.Equal(t, 1, computations)
+	assert.Empty(t, c.inFlight)
+
+	// Scenario II: Once the computation is over, identical queries are computed again
+	desc, err := c.peersForEndorsement("mychannel", interest("cc1"), compute)
+	assert.NoError(t, err)
+	assert.Equal(t, "cc1", desc.Chaincode)
+	assert.Equal(t, 2, computations)
+
+	// Scenario III: Queries of different channels or chaincodes aren't coalesced, and errors are shared
+	release = make(chan struct{})
+	failing := func() (*discovery.EndorsementDescriptor, error) {
+		<-release
+		return nil, errors.New("foo")
+	}
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for _, channel := range []string{"mychannel", "yourchannel"} {
+		go func(channel string) {
+			defer wg.Done()
+			_, err := c.peersForEndorsement(channel, interest("cc2"), failing)
+			assert.EqualError(t, err, "foo")
+		}(channel)
+	}
+	go func() {
+		defer wg.Done()
+		_, err := c.peersForEndorsement("mychannel", interest("cc3"), failing)
+		assert.EqualError(t, err, "foo")
+	}()
+	waitFor(func() bool {
+		return len(c.inFlight) == 3
+	})
+	close(release)
+	wg.Wait()
+}
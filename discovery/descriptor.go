@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// descriptorOfVersion returns the given endorsement descriptor in the given format.
+// The given descriptor is expected to be a V1 descriptor, and isn't modified.
+func descriptorOfVersion(desc *discovery.EndorsementDescriptor, version discovery.DescriptorVersion) (*discovery.EndorsementDescriptor, error) {
+	switch version {
+	case discovery.DescriptorVersion_V1:
+		return desc, nil
+	case discovery.DescriptorVersion_V2:
+		return toDescriptorV2(desc)
+	default:
+		return nil, errors.Errorf("unknown descriptor version %d", version)
+	}
+}
+
+// toDescriptorV2 converts a V1 endorsement descriptor to a V2 endorsement descriptor,
+// in which the groups are keyed by the MSP IDs of the peers in them.
+// Groups of the same MSP are merged, and the quantities of the layouts are summed accordingly,
+// since the principals of the groups are satisfied by distinct signatures.
+func toDescriptorV2(desc *discovery.EndorsementDescriptor) (*discovery.EndorsementDescriptor, error) {
+	mspIDsByGroups := make(map[string]string, len(desc.EndorsersByGroups))
+	groups := make([]string, 0, len(desc.EndorsersByGroups))
+	for grp, peers := range desc.EndorsersByGroups {
+		mspID, err := mspIDOfPeers(peers.Peers)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed determining the MSP ID of group "+grp)
+		}
+		mspIDsByGroups[grp] = mspID
+		groups = append(groups, grp)
+	}
+	sort.Strings(groups)
+
+	res := &discovery.EndorsementDescriptor{
+		Chaincode:         desc.Chaincode,
+		EndorsersByGroups: make(map[string]*discovery.Peers),
+		Version:           discovery.DescriptorVersion_V2,
+	}
+	// Merge the peers of groups of the same MSP, as the same peer may satisfy several principals
+	peersSeen := make(map[string]struct{})
+	for _, grp := range groups {
+		mspID := mspIDsByGroups[grp]
+		peers, exists := res.EndorsersByGroups[mspID]
+		if !exists {
+			peers = &discovery.Peers{}
+			res.EndorsersByGroups[mspID] = peers
+		}
+		for _, p := range desc.EndorsersByGroups[grp].Peers {
+			if _, seen := peersSeen[string(p.Identity)]; seen {
+				continue
+			}
+			peersSeen[string(p.Identity)] = struct{}{}
+			peers.Peers = append(peers.Peers, p)
+		}
+	}
+
+	for _, layout := range desc.Layouts {
+		quantities := make(map[string]uint32, len(layout.QuantitiesByGroup))
+		for grp, quantity := range layout.QuantitiesByGroup {
+			mspID, exists := mspIDsByGroups[grp]
+			if !exists {
+				return nil, errors.Errorf("group %s of layout isn't found in the descriptor", grp)
+			}
+			quantities[mspID] += quantity
+		}
+		// Layouts of different principal combinations may become identical once grouped by MSP ID
+		if !containsLayout(res.Layouts, quantities) {
+			res.Layouts = append(res.Layouts, &discovery.Layout{QuantitiesByGroup: quantities})
+		}
+	}
+	return res, nil
+}
+
+// mspIDOfPeers returns the MSP ID of the given peers,
+// or an error if they aren't all of the same MSP
+func mspIDOfPeers(peers []*discovery.Peer) (string, error) {
+	var mspID string
+	for _, p := range peers {
+		sID := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(p.Identity, sID); err != nil {
+			return "", errors.Wrap(err, "failed unmarshaling identity of peer")
+		}
+		if mspID != "" && sID.Mspid != mspID {
+			return "", errors.Errorf("peers are of different MSPs: %s and %s", mspID, sID.Mspid)
+		}
+		mspID = sID.Mspid
+	}
+	if mspID == "" {
+		return "", errors.New("no peers with an MSP ID")
+	}
+	return mspID, nil
+}
+
+func containsLayout(layouts []*discovery.Layout, quantities map[string]uint32) bool {
+	for _, l := range layouts {
+		if reflect.DeepEqual(l.QuantitiesByGroup, quantities) {
+			return true
+		}
+	}
+	return false
+}
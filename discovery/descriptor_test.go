@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func peerOfMSP(mspID string, id string) *discovery.Peer {
+	return &discovery.Peer{
+		Identity: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(id)}),
+	}
+}
+
+func TestToDescriptorV2(t *testing.T) {
+	p1, p2, p3 := peerOfMSP("Org1MSP", "p1"), peerOfMSP("Org1MSP", "p2"), peerOfMSP("Org2MSP", "p3")
+	// G0 and G1 are principals of Org1MSP, such as its members and its peers,
+	// and G2 is a principal of Org2MSP
+	desc := &discovery.EndorsementDescriptor{
+		Chaincode: "mycc",
+		EndorsersByGroups: map[string]*discovery.Peers{
+			"G0": {Peers: []*discovery.Peer{p1, p2}},
+			"G1": {Peers: []*discovery.Peer{p2}},
+			"G2": {Peers: []*discovery.Peer{p3}},
+		},
+		Layouts: []*discovery.Layout{
+			{QuantitiesByGroup: map[string]uint32{"G0": 1, "G2": 1}},
+			{QuantitiesByGroup: map[string]uint32{"G1": 1, "G2": 1}},
+			{QuantitiesByGroup: map[string]uint32{"G0": 1, "G1": 1}},
+		},
+	}
+	v2, err := toDescriptorV2(desc)
+	assert.NoError(t, err)
+	assert.Equal(t, &discovery.EndorsementDescriptor{
+		Chaincode: "mycc",
+		Version:   discovery.DescriptorVersion_V2,
+		EndorsersByGroups: map[string]*discovery.Peers{
+			"Org1MSP": {Peers: []*discovery.Peer{p1, p2}},
+			"Org2MSP": {Peers: []*discovery.Peer{p3}},
+		},
+		// The first two layouts become identical, and the principals
+		// of the third layout are satisfied by distinct Org1MSP peers
+		Layouts: []*discovery.Layout{
+			{QuantitiesByGroup: map[string]uint32{"Org1MSP": 1, "Org2MSP": 1}},
+			{QuantitiesByGroup: map[string]uint32{"Org1MSP": 2}},
+		},
+	}, v2)
+	// The original descriptor isn't modified
+	assert.Len(t, desc.EndorsersByGroups, 3)
+	assert.Equal(t, discovery.DescriptorVersion_V1, desc.Version)
+
+	// A group with peers of several MSPs, such as a group of an anonymity principal, can't be converted
+	desc.EndorsersByGroups["G2"].Peers = append(desc.EndorsersByGroups["G2"].Peers, p1)
+	_, err = toDescriptorV2(desc)
+	assert.Contains(t, err.Error(), "failed determining the MSP ID of group G2: peers are of different MSPs")
+
+	// A peer with a malformed identity
+	desc.EndorsersByGroups["G2"].Peers = []*discovery.Peer{{Identity: []byte{1, 2, 3}}}
+	_, err = toDescriptorV2(desc)
+	assert.Contains(t, err.Error(), "failed unmarshaling identity of peer")
+
+	_, err = descriptorOfVersion(desc, discovery.DescriptorVersion(5))
+	assert.EqualError(t, err, "unknown descriptor version 5")
+}
+
+func TestChaincodeQueryDescriptorVersion(t *testing.T) {
+	desc := &discovery.EndorsementDescriptor{
+		Chaincode: "mycc",
+		EndorsersByGroups: map[string]*discovery.Peers{
+			"G0": {Peers: []*discovery.Peer{peerOfMSP("Org1MSP", "p1")}},
+		},
+		Layouts: []*discovery.Layout{{QuantitiesByGroup: map[string]uint32{"G0": 1}}},
+	}
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(nil)
+	mockSup.On("PeersForEndorsement", "mycc").Return(desc, nil)
+	service := NewService(Config{}, mockSup)
+
+	query := func(version discovery.DescriptorVersion) *discovery.EndorsementDescriptor {
+		req := &discovery.Request{
+			Authentication: &discovery.AuthInfo{
+				ClientIdentity: []byte{1, 2, 3},
+			},
+			Queries: []*discovery.Query{
+				{
+					Channel: "mychannel",
+					Query: &discovery.Query_CcQuery{
+						CcQuery: &discovery.ChaincodeQuery{
+							Interests:         []*discovery.ChaincodeInterest{{Chaincodes: []*discovery.ChaincodeCall{{Name: "mycc"}}}},
+							DescriptorVersion: version,
+						},
+					},
+				},
+			},
+		}
+		resp, err := service.Discover(context.Background(), toSignedRequest(req))
+		assert.NoError(t, err)
+		return resp.Results[0].GetCcQueryRes().Content[0]
+	}
+
+	assert.Equal(t, desc, query(discovery.DescriptorVersion_V1))
+	v2 := query(discovery.DescriptorVersion_V2)
+	assert.Equal(t, discovery.DescriptorVersion_V2, v2.Version)
+	assert.Contains(t, v2.EndorsersByGroups, "Org1MSP")
+	assert.Equal(t, map[string]uint32{"Org1MSP": 1}, v2.Layouts[0].QuantitiesByGroup)
+}
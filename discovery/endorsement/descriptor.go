@@ -0,0 +1,373 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/graph"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// PeerPrincipalEvaluator returns whether the given peer satisfies the given principal
+type PeerPrincipalEvaluator func(member discovery2.NetworkMember, principal *msp.MSPPrincipal) bool
+
+// DescriptorSpec specifies a policy that is expressed by principal sets,
+// and the peers that may be selected to satisfy it
+type DescriptorSpec struct {
+	// PrincipalSets are the principal combinations that satisfy the policy
+	PrincipalSets []policies.PrincipalSet
+	// Members are the peers that are candidates for satisfying the policy
+	Members discovery2.Members
+	// Include selects which of the Members may be selected.
+	// If nil, all Members may be selected.
+	Include func(member discovery2.NetworkMember) bool
+	// SatisfiesPrincipal returns whether a peer satisfies a principal
+	SatisfiesPrincipal PeerPrincipalEvaluator
+	// ToPeer converts a member to the Peer that represents it in the descriptor
+	ToPeer func(member discovery2.NetworkMember) *discovery.Peer
+	// Prefer selects which of the Members are preferred. Layouts that can be
+	// satisfied by preferred members are ranked first, and so are preferred
+	// members in the groups. If nil, no members are preferred.
+	Prefer func(member discovery2.NetworkMember) bool
+}
+
+// ServiceDescriptor describes the ways in which peers can satisfy a policy.
+// It generalizes the EndorsementDescriptor, and can be used by any service
+// that selects peers according to a policy, such as private data dissemination
+// or validator selection.
+type ServiceDescriptor struct {
+	// Layouts are the principal combinations that the peers can satisfy,
+	// each mapping groups to the number of peers that need to be selected from them
+	Layouts []*discovery.Layout
+	// PeersByGroups maps the groups of the Layouts to the peers that satisfy their principals
+	PeersByGroups map[string]*discovery.Peers
+}
+
+// ComputeDescriptor computes a ServiceDescriptor out of the given DescriptorSpec,
+// or returns an error if the peers cannot satisfy any of the principal combinations
+func ComputeDescriptor(spec DescriptorSpec) (*ServiceDescriptor, error) {
+	members := spec.Members
+	if spec.Include != nil {
+		members = members.Filter(spec.Include)
+	}
+	// mapPrincipalsToGroups returns a mapping from principals to their corresponding groups.
+	// groups are just human readable representations that mask the principals behind them
+	principalGroups := mapPrincipalsToGroups(spec.PrincipalSets)
+	// principalsToPeersGraph computes a bipartite graph (V1 U V2 , E)
+	// such that V1 is the peers, V2 are the principals,
+	// and each e=(peer,principal) is in E if the peer satisfies the principal
+	satGraph := principalsToPeersGraph(principalAndPeerData{
+		members: members,
+		pGrps:   principalGroups,
+	}, spec.SatisfiesPrincipal)
+
+	layouts := computeLayouts(spec.PrincipalSets, principalGroups, satGraph)
+	if len(layouts) == 0 {
+		return nil, errors.New("cannot satisfy any principal combination")
+	}
+	if spec.Prefer != nil {
+		rankLayouts(layouts, satGraph, spec.Prefer)
+	}
+
+	criteria := &peerMembershipCriteria{
+		possibleLayouts: layouts,
+		satGraph:        satGraph,
+		toPeer:          spec.ToPeer,
+		prefer:          spec.Prefer,
+	}
+
+	return &ServiceDescriptor{
+		Layouts:       layouts,
+		PeersByGroups: peersByGroup(criteria),
+	}, nil
+}
+
+type peerMembershipCriteria struct {
+	satGraph        *principalPeerGraph
+	toPeer          func(member discovery2.NetworkMember) *discovery.Peer
+	prefer          func(member discovery2.NetworkMember) bool
+	possibleLayouts layouts
+}
+
+// peersByGroup computes a map from groups to peers.
+// Each group included, is found in some layout, which means
+// that there is some principal combination that includes the corresponding
+// group.
+// This means that if a group isn't included in the result, there is no
+// principal combination (that includes the principal corresponding to the group),
+// such that there are enough peers to satisfy the principal combination.
+func peersByGroup(criteria *peerMembershipCriteria) map[string]*discovery.Peers {
+	satGraph := criteria.satGraph
+	includedGroups := criteria.possibleLayouts.groupsSet()
+
+	res := make(map[string]*discovery.Peers)
+	// Map peers to their corresponding groups.
+	// Iterate the principals, and put the peers into each group that corresponds with a principal vertex
+	for grp, principalVertex := range satGraph.principalVertices {
+		if _, exists := includedGroups[grp]; !exists {
+			// If the current group is not found in any layout, skip the corresponding principal
+			continue
+		}
+		peerList := &discovery.Peers{}
+		res[grp] = peerList
+		var others []*discovery.Peer
+		for _, peerVertex := range principalVertex.Neighbors() {
+			member := peerVertex.Data.(discovery2.NetworkMember)
+			// Preferred peers are listed before the others
+			if criteria.prefer == nil || criteria.prefer(member) {
+				peerList.Peers = append(peerList.Peers, criteria.toPeer(member))
+			} else {
+				others = append(others, criteria.toPeer(member))
+			}
+		}
+		peerList.Peers = append(peerList.Peers, others...)
+	}
+	return res
+}
+
+// rankLayouts sorts the given layouts such that the layouts whose groups can all be
+// satisfied by preferred peers come first, followed by the layouts with the most groups
+// that can be satisfied by preferred peers. Layouts that rank the same keep their order.
+func rankLayouts(layouts []*discovery.Layout, satGraph *principalPeerGraph, prefer func(member discovery2.NetworkMember) bool) {
+	preferredPeersByGroup := make(map[string]int)
+	for grp, principalVertex := range satGraph.principalVertices {
+		for _, peerVertex := range principalVertex.Neighbors() {
+			if prefer(peerVertex.Data.(discovery2.NetworkMember)) {
+				preferredPeersByGroup[grp]++
+			}
+		}
+	}
+
+	type rankedLayout struct {
+		layout          *discovery.Layout
+		preferredGroups int
+	}
+	ranked := make([]rankedLayout, len(layouts))
+	for i, layout := range layouts {
+		ranked[i].layout = layout
+		for grp, quantity := range layout.QuantitiesByGroup {
+			if preferredPeersByGroup[grp] >= int(quantity) {
+				ranked[i].preferredGroups++
+			}
+		}
+	}
+	allPreferred := func(l rankedLayout) bool {
+		return l.preferredGroups == len(l.layout.QuantitiesByGroup)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if allPreferred(ranked[i]) != allPreferred(ranked[j]) {
+			return allPreferred(ranked[i])
+		}
+		return ranked[i].preferredGroups > ranked[j].preferredGroups
+	})
+	for i := range ranked {
+		layouts[i] = ranked[i].layout
+	}
+}
+
+// computeLayouts computes all possible principal combinations
+// that can be used to satisfy the policy, given a graph
+// of available peers that maps each peer to a principal it satisfies.
+// Each such a combination is called a layout, because it maps
+// a group (alias for a principal) to a threshold of peers that need to be selected,
+// and that satisfy the corresponding principal.
+func computeLayouts(principalsSets []policies.PrincipalSet, principalGroups principalGroupMapper, satGraph *principalPeerGraph) []*discovery.Layout {
+	var layouts []*discovery.Layout
+	// principalsSets is a collection of combinations of principals,
+	// such that each combination (given enough peers) satisfies the policy.
+	for _, principalSet := range principalsSets {
+		layout := &discovery.Layout{
+			QuantitiesByGroup: make(map[string]uint32),
+		}
+		// Since principalsSet has repetitions, we first
+		// compute a mapping from the principal to repetitions in the set.
+		for principal, plurality := range principalSet.UniqueSet() {
+			key := principalKey{
+				cls:       int32(principal.PrincipalClassification),
+				principal: string(principal.Principal),
+			}
+			// We map the principal to a group, which is an alias for the principal.
+			layout.QuantitiesByGroup[principalGroups.group(key)] = uint32(plurality)
+		}
+		// Check that the layout can be satisfied with the current known peers
+		// This is done by iterating the current layout, and ensuring that
+		// each principal vertex is connected to at least <plurality> peer vertices.
+		if isLayoutSatisfied(layout.QuantitiesByGroup, satGraph) {
+			// If so, then add the layout to the layouts, since we have enough peers to satisfy the
+			// principal combination
+			layouts = append(layouts, layout)
+		}
+	}
+	return layouts
+}
+
+func isLayoutSatisfied(layout map[string]uint32, satGraph *principalPeerGraph) bool {
+	for grp, plurality := range layout {
+		// Do we have more than <plurality> peers connected to the principal?
+		if len(satGraph.principalVertices[grp].Neighbors()) < int(plurality) {
+			return false
+		}
+	}
+	return true
+}
+
+type principalPeerGraph struct {
+	peerVertices      []*graph.Vertex
+	principalVertices map[string]*graph.Vertex
+}
+
+type principalAndPeerData struct {
+	members discovery2.Members
+	pGrps   principalGroupMapper
+}
+
+func principalsToPeersGraph(data principalAndPeerData, satisfiesPrincipal PeerPrincipalEvaluator) *principalPeerGraph {
+	// Create the peer vertices
+	peerVertices := make([]*graph.Vertex, len(data.members))
+	for i, member := range data.members {
+		peerVertices[i] = graph.NewVertex(string(member.PKIid), member)
+	}
+
+	// Create the principal vertices
+	principalVertices := make(map[string]*graph.Vertex)
+	for pKey, grp := range data.pGrps {
+		principalVertices[grp] = graph.NewVertex(grp, pKey.toPrincipal())
+	}
+
+	// Connect principals and peers
+	for _, principalVertex := range principalVertices {
+		for _, peerVertex := range peerVertices {
+			// If the current peer satisfies the principal, connect their corresponding vertices with an edge
+			principal := principalVertex.Data.(*msp.MSPPrincipal)
+			member := peerVertex.Data.(discovery2.NetworkMember)
+			if satisfiesPrincipal(member, principal) {
+				peerVertex.AddNeighbor(principalVertex)
+			}
+		}
+	}
+	return &principalPeerGraph{
+		peerVertices:      peerVertices,
+		principalVertices: principalVertices,
+	}
+}
+
+func mapPrincipalsToGroups(principalsSets []policies.PrincipalSet) principalGroupMapper {
+	groupMapper := make(principalGroupMapper)
+	totalPrincipals := make(map[principalKey]struct{})
+	for _, principalSet := range principalsSets {
+		for _, principal := range principalSet {
+			totalPrincipals[principalKey{
+				principal: string(principal.Principal),
+				cls:       int32(principal.PrincipalClassification),
+			}] = struct{}{}
+		}
+	}
+	for principal := range totalPrincipals {
+		groupMapper.group(principal)
+	}
+	return groupMapper
+}
+
+// principalGroupMapper maps principals to names of groups
+type principalGroupMapper map[principalKey]string
+
+func (mapper principalGroupMapper) group(principal principalKey) string {
+	if grp, exists := mapper[principal]; exists {
+		return grp
+	}
+	grp := principal.groupName()
+	mapper[principal] = grp
+	return grp
+}
+
+type principalKey struct {
+	cls       int32
+	principal string
+}
+
+// groupName returns a name derived from the content of the principal,
+// which is the same across queries and peers.
+// Role principals are named after the MSP ID and the role, i.e Org1MSP.member,
+// organizational unit principals are named after the MSP ID and the OU, i.e Org1MSP.ou.audit,
+// and other principals are named after their classification and a hash of their content.
+func (pk principalKey) groupName() string {
+	switch msp.MSPPrincipal_Classification(pk.cls) {
+	case msp.MSPPrincipal_ROLE:
+		role := &msp.MSPRole{}
+		if unmarshalCanonical(pk.principal, role) {
+			return fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+		}
+	case msp.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &msp.OrganizationUnit{}
+		if unmarshalCanonical(pk.principal, ou) {
+			name := fmt.Sprintf("%s.ou.%s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+			// OUs of the same name might be certified by different CAs
+			if len(ou.CertifiersIdentifier) > 0 {
+				name = fmt.Sprintf("%s#%s", name, shortHash(ou.CertifiersIdentifier))
+			}
+			return name
+		}
+	}
+	cls := strings.ToLower(msp.MSPPrincipal_Classification(pk.cls).String())
+	return fmt.Sprintf("%s#%s", cls, shortHash([]byte(pk.principal)))
+}
+
+// unmarshalCanonical unmarshals the given bytes into the given message,
+// and returns whether they are the canonical encoding of the message.
+// Readable group names are used only for canonically encoded principals,
+// otherwise different encodings of the same principal would be mapped to the same group.
+func unmarshalCanonical(raw string, msg proto.Message) bool {
+	if err := proto.Unmarshal([]byte(raw), msg); err != nil {
+		return false
+	}
+	canonical, err := proto.Marshal(msg)
+	return err == nil && bytes.Equal(canonical, []byte(raw))
+}
+
+func shortHash(b []byte) string {
+	return hex.EncodeToString(util.ComputeSHA256(b)[:8])
+}
+
+// principalName returns a human readable representation of the given principal
+func principalName(principal *msp.MSPPrincipal) string {
+	return principalKey{
+		cls:       int32(principal.PrincipalClassification),
+		principal: string(principal.Principal),
+	}.groupName()
+}
+
+func (pk principalKey) toPrincipal() *msp.MSPPrincipal {
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_Classification(pk.cls),
+		Principal:               []byte(pk.principal),
+	}
+}
+
+// layouts is an aggregation of several layouts
+type layouts []*discovery.Layout
+
+// groupsSet returns a set of groups that the layouts contain
+func (l layouts) groupsSet() map[string]struct{} {
+	m := make(map[string]struct{})
+	for _, layout := range l {
+		for grp := range layout.QuantitiesByGroup {
+			m[grp] = struct{}{}
+		}
+	}
+	return m
+}
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/graph"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	discoveryprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDescriptor(t *testing.T) {
+	memberRole := func(mspID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: mspID,
+				Role:          msp.MSPRole_MEMBER,
+			}),
+		}
+	}
+	// The policy requires either Org1 and Org2, or 2 peers of Org3
+	principalSets := []policies.PrincipalSet{
+		{memberRole("Org1MSP"), memberRole("Org2MSP")},
+		{memberRole("Org3MSP"), memberRole("Org3MSP")},
+	}
+	// p1 and p2 are of Org1, p3 is of Org2, and p4 and p5 are of Org3
+	mspIDs := map[string]string{"p1": "Org1MSP", "p2": "Org1MSP", "p3": "Org2MSP", "p4": "Org3MSP", "p5": "Org3MSP"}
+	var members discovery.Members
+	for _, p := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		members = append(members, discovery.NetworkMember{PKIid: []byte(p), Endpoint: p})
+	}
+	satisfiesPrincipal := func(member discovery.NetworkMember, principal *msp.MSPPrincipal) bool {
+		role := &msp.MSPRole{}
+		assert.NoError(t, proto.Unmarshal(principal.Principal, role))
+		return mspIDs[member.Endpoint] == role.MspIdentifier
+	}
+	toPeer := func(member discovery.NetworkMember) *discoveryprotos.Peer {
+		return &discoveryprotos.Peer{Identity: []byte(member.Endpoint)}
+	}
+	endpointsByGroup := func(desc *ServiceDescriptor) map[string][]string {
+		res := make(map[string][]string)
+		for grp, peers := range desc.PeersByGroups {
+			for _, p := range peers.Peers {
+				res[grp] = append(res[grp], string(p.Identity))
+			}
+		}
+		return res
+	}
+
+	// Scenario I: All peers may be selected, so both principal combinations can be satisfied
+	desc, err := ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalSets,
+		Members:            members,
+		SatisfiesPrincipal: satisfiesPrincipal,
+		ToPeer:             toPeer,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, desc.Layouts, 2)
+	groups := endpointsByGroup(desc)
+	assert.Len(t, groups, 3)
+	assert.ElementsMatch(t, []string{"p1", "p2"}, groups["Org1MSP.member"])
+	assert.ElementsMatch(t, []string{"p3"}, groups["Org2MSP.member"])
+	assert.ElementsMatch(t, []string{"p4", "p5"}, groups["Org3MSP.member"])
+
+	// Scenario II: p5 is excluded by the predicate, so only the first combination can be satisfied
+	desc, err = ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalSets,
+		Members:            members,
+		Include:            func(member discovery.NetworkMember) bool { return member.Endpoint != "p5" },
+		SatisfiesPrincipal: satisfiesPrincipal,
+		ToPeer:             toPeer,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*discoveryprotos.Layout{
+		{QuantitiesByGroup: map[string]uint32{"Org1MSP.member": 1, "Org2MSP.member": 1}},
+	}, desc.Layouts)
+	groups = endpointsByGroup(desc)
+	assert.Len(t, groups, 2)
+
+	// Scenario III: No principal combination can be satisfied
+	_, err = ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalSets,
+		Members:            members,
+		Include:            func(member discovery.NetworkMember) bool { return member.Endpoint == "p1" },
+		SatisfiesPrincipal: satisfiesPrincipal,
+		ToPeer:             toPeer,
+	})
+	assert.EqualError(t, err, "cannot satisfy any principal combination")
+
+	// Scenario IV: The peers of Org3 are preferred, so the combination they satisfy is ranked first
+	org3Layout := &discoveryprotos.Layout{QuantitiesByGroup: map[string]uint32{"Org3MSP.member": 2}}
+	desc, err = ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalSets,
+		Members:            members,
+		SatisfiesPrincipal: satisfiesPrincipal,
+		ToPeer:             toPeer,
+		Prefer:             func(member discovery.NetworkMember) bool { return mspIDs[member.Endpoint] == "Org3MSP" },
+	})
+	assert.NoError(t, err)
+	assert.Len(t, desc.Layouts, 2)
+	assert.Equal(t, org3Layout, desc.Layouts[0])
+
+	// Scenario V: Only p2 is preferred, so it's listed first in its group, and the combination
+	// that includes its group is ranked first
+	desc, err = ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalSets,
+		Members:            members,
+		SatisfiesPrincipal: satisfiesPrincipal,
+		ToPeer:             toPeer,
+		Prefer:             func(member discovery.NetworkMember) bool { return member.Endpoint == "p2" },
+	})
+	assert.NoError(t, err)
+	assert.Len(t, desc.Layouts, 2)
+	assert.Equal(t, org3Layout, desc.Layouts[1])
+	assert.Equal(t, []string{"p2", "p1"}, endpointsByGroup(desc)["Org1MSP.member"])
+}
+
+func TestRankLayouts(t *testing.T) {
+	// g1 has 2 preferred peers, g2 has 1, and g3 has none
+	satGraph := &principalPeerGraph{principalVertices: make(map[string]*graph.Vertex)}
+	preferred := map[string]bool{"p1": true, "p2": true, "p3": true}
+	for grp, peers := range map[string][]string{"g1": {"p1", "p2"}, "g2": {"p3", "p4"}, "g3": {"p5"}} {
+		principalVertex := graph.NewVertex(grp, nil)
+		for _, p := range peers {
+			graph.NewVertex(p, discovery.NetworkMember{Endpoint: p}).AddNeighbor(principalVertex)
+		}
+		satGraph.principalVertices[grp] = principalVertex
+	}
+	layout := func(quantities map[string]uint32) *discoveryprotos.Layout {
+		return &discoveryprotos.Layout{QuantitiesByGroup: quantities}
+	}
+	noPreferred := layout(map[string]uint32{"g3": 1})
+	partlyPreferred := layout(map[string]uint32{"g1": 1, "g3": 1})
+	twoPreferredGroups := layout(map[string]uint32{"g1": 1, "g2": 1, "g3": 1})
+	notEnoughPreferred := layout(map[string]uint32{"g2": 2})
+	allPreferred := layout(map[string]uint32{"g1": 2, "g2": 1})
+
+	layouts := []*discoveryprotos.Layout{noPreferred, partlyPreferred, notEnoughPreferred, twoPreferredGroups, allPreferred}
+	rankLayouts(layouts, satGraph, func(member discovery.NetworkMember) bool { return preferred[member.Endpoint] })
+	assert.Equal(t, []*discoveryprotos.Layout{allPreferred, twoPreferredGroups, partlyPreferred, noPreferred, notEnoughPreferred}, layouts)
+}
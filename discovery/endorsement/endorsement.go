@@ -7,18 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package endorsement
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/common/graph"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
+	common2 "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/op/go-logging"
 	"github.com/pkg/errors"
 )
 
@@ -45,6 +46,14 @@ type policyFetcher interface {
 	// PolicyByChaincode returns a policy that can be inquired which identities
 	// satisfy it
 	PolicyByChaincode(channel string, cc string) policies.InquireablePolicy
+
+	// SubPolicyByChaincode returns the sub-policy of the given name of the given chaincode,
+	// or nil if the chaincode has no such sub-policy
+	SubPolicyByChaincode(channel string, cc string, name string) policies.InquireablePolicy
+
+	// PolicyOfKey returns the key-level endorsement policy of the given key
+	// of the given chaincode, or nil if the key has no key-level endorsement policy
+	PolicyOfKey(channel string, cc string, key string) (*common2.SignaturePolicyEnvelope, error)
 }
 
 type gossipSupport interface {
@@ -57,6 +66,10 @@ type gossipSupport interface {
 
 	// Peers returns the NetworkMembers considered alive
 	Peers() discovery2.Members
+
+	// PeerLatencies returns the round trip times measured to the alive peers
+	// of the given channel by their PKI-IDs
+	PeerLatencies(common.ChainID) map[string]time.Duration
 }
 
 type endorsementAnalyzer struct {
@@ -64,6 +77,7 @@ type endorsementAnalyzer struct {
 	principalEvaluator
 	policyFetcher
 	chaincodeMetadataFetcher
+	maxLedgerHeightLag uint64
 }
 
 // NewEndorsementAnalyzer constructs an NewEndorsementAnalyzer out of the given support
@@ -76,16 +90,85 @@ func NewEndorsementAnalyzer(gs gossipSupport, pf policyFetcher, pe principalEval
 	}
 }
 
-type peerPrincipalEvaluator func(member discovery2.NetworkMember, principal *msp.MSPPrincipal) bool
+// SetMaxLedgerHeightLag sets the maximum number of blocks the ledger height of a peer
+// may lag behind the highest ledger height in the channel, for the peer to be included
+// in endorsement descriptors. 0 means peers aren't excluded by their ledger height,
+// unless a chaincode interest specifies otherwise.
+func (ea *endorsementAnalyzer) SetMaxLedgerHeightLag(lag uint64) {
+	ea.maxLedgerHeightLag = lag
+}
 
 // PeersForEndorsement returns an EndorsementDescriptor for a given set of peers, channel, and chaincode
 func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
+	ctx, err := ea.computeContext(chainID, interest)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ea.computeEndorsementResponse(ctx)
+}
+
+// CanSatisfy returns whether the endorsement policies of the given chaincode interest can currently
+// be satisfied by the alive peers of the given channel.
+// Unlike PeersForEndorsement, it stops as soon as it finds a principal combination that
+// can be satisfied, and doesn't compute the groups and the peers of an EndorsementDescriptor.
+func (ea *endorsementAnalyzer) CanSatisfy(chainID common.ChainID, interest *discovery.ChaincodeInterest) (bool, error) {
+	ctx, err := ea.computeContext(chainID, interest)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	satisfiesPrincipal := ea.satisfiesPrincipal(ctx.channel, ctx.identitiesOfMembers)
+	// Count the peers that satisfy each principal lazily, and only up to the needed amount
+	satisfyingPeers := make(map[principalKey]int)
+	enoughPeersFor := func(principal *msp.MSPPrincipal, plurality int) bool {
+		key := principalKey{
+			cls:       int32(principal.PrincipalClassification),
+			principal: string(principal.Principal),
+		}
+		if count, exists := satisfyingPeers[key]; exists && count >= plurality {
+			return true
+		}
+		count := 0
+		for _, member := range ctx.aliveMembership {
+			if satisfiesPrincipal(member, principal) {
+				count++
+			}
+			if count >= plurality {
+				break
+			}
+		}
+		satisfyingPeers[key] = count
+		return count >= plurality
+	}
+
+	for _, principalSet := range ctx.principalsSets {
+		satisfied := true
+		for principal, plurality := range principalSet.UniqueSet() {
+			if !enoughPeersFor(principal, plurality) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// computeContext computes the principal combinations that satisfy the endorsement policies
+// of the given chaincode interest, and the peers of the channel that may endorse
+func (ea *endorsementAnalyzer) computeContext(chainID common.ChainID, interest *discovery.ChaincodeInterest) (*context, error) {
 	metadataAndCollectionFilters, err := loadMetadataAndFilters(chainID, interest, ea)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	chanMembership := ea.PeersOfChannel(chainID)
+	// Filter out peers that lag behind, as endorsements of stale peers would likely be invalidated
+	if lag := ea.ledgerHeightLag(interest); lag > 0 {
+		chanMembership = chanMembership.Filter(peersWithinLedgerHeightLag(chanMembership, lag))
+	}
 	// Filter out peers that don't have the chaincode installed on them
-	chanMembership := ea.PeersOfChannel(chainID).Filter(peersWithChaincode(metadataAndCollectionFilters.md...))
+	chanMembership = chanMembership.Filter(peersWithChaincode(metadataAndCollectionFilters.md...))
 	channelMembersById := chanMembership.ByID()
 	// Choose only the alive messages of those that have joined the channel
 	aliveMembership := ea.Peers().Intersect(chanMembership)
@@ -93,6 +176,7 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 	// Compute a mapping between the PKI-IDs of members to their identities
 	identities := ea.IdentityInfo()
 	identitiesOfMembers := computeIdentitiesOfMembers(identities, membersById)
+	latencies := ea.PeerLatencies(chainID)
 	filter := ea.excludeIfCCNotInstalled(membersById, identities.ByID())
 	principalsSets, err := ea.computePrincipalSets(chainID, interest, filter)
 	if err != nil {
@@ -106,14 +190,19 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 		return nil, errors.WithStack(err)
 	}
 
-	return ea.computeEndorsementResponse(&context{
+	return &context{
 		chaincode:           interest.Chaincodes[0].Name,
 		channel:             string(chainID),
 		principalsSets:      principalsSets,
 		channelMembersById:  channelMembersById,
 		aliveMembership:     aliveMembership,
 		identitiesOfMembers: identitiesOfMembers,
-	})
+		latencies:           latencies,
+		preferred: anyOf(
+			peersOfOrgs(interest.PreferredOrgs, identities.ByID()),
+			peersWithinRTT(latencies, interest.MaxPreferredRttMicros),
+		),
+	}, nil
 }
 
 type context struct {
@@ -123,36 +212,35 @@ type context struct {
 	principalsSets      []policies.PrincipalSet
 	channelMembersById  map[string]discovery2.NetworkMember
 	identitiesOfMembers memberIdentities
+	latencies           map[string]time.Duration
+	preferred           func(member discovery2.NetworkMember) bool
 }
 
 func (ea *endorsementAnalyzer) computeEndorsementResponse(ctx *context) (*discovery.EndorsementDescriptor, error) {
-	// mapPrincipalsToGroups returns a mapping from principals to their corresponding groups.
-	// groups are just human readable representations that mask the principals behind them
-	principalGroups := mapPrincipalsToGroups(ctx.principalsSets)
-	// principalsToPeersGraph computes a bipartite graph (V1 U V2 , E)
-	// such that V1 is the peers, V2 are the principals,
-	// and each e=(peer,principal) is in E if the peer satisfies the principal
-	satGraph := principalsToPeersGraph(principalAndPeerData{
-		members: ctx.aliveMembership,
-		pGrps:   principalGroups,
-	}, ea.satisfiesPrincipal(ctx.channel, ctx.identitiesOfMembers))
-
-	layouts := computeLayouts(ctx.principalsSets, principalGroups, satGraph)
-	if len(layouts) == 0 {
-		return nil, errors.New("cannot satisfy any principal combination")
-	}
-
-	criteria := &peerMembershipCriteria{
-		possibleLayouts: layouts,
-		satGraph:        satGraph,
-		chanMemberById:  ctx.channelMembersById,
-		idOfMembers:     ctx.identitiesOfMembers,
+	desc, err := ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      ctx.principalsSets,
+		Members:            ctx.aliveMembership,
+		SatisfiesPrincipal: ea.satisfiesPrincipal(ctx.channel, ctx.identitiesOfMembers),
+		ToPeer: func(member discovery2.NetworkMember) *discovery.Peer {
+			stateInfo := ctx.channelMembersById[string(member.PKIid)]
+			return &discovery.Peer{
+				Identity:       ctx.identitiesOfMembers.identityByPKIID(member.PKIid),
+				StateInfo:      stateInfo.Envelope,
+				MembershipInfo: member.Envelope,
+				LedgerHeight:   stateInfo.Properties.GetLedgerHeight(),
+				RttMicros:      uint64(ctx.latencies[string(member.PKIid)] / time.Microsecond),
+			}
+		},
+		Prefer: ctx.preferred,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &discovery.EndorsementDescriptor{
 		Chaincode:         ctx.chaincode,
-		Layouts:           layouts,
-		EndorsersByGroups: endorsersByGroup(criteria),
+		Layouts:           desc.Layouts,
+		EndorsersByGroups: desc.PeersByGroups,
 	}, nil
 }
 
@@ -175,12 +263,28 @@ func (ea *endorsementAnalyzer) excludeIfCCNotInstalled(membersById map[string]di
 func (ea *endorsementAnalyzer) computePrincipalSets(chainID common.ChainID, interest *discovery.ChaincodeInterest, filter principalFilter) (policies.PrincipalSets, error) {
 	var inquireablePolicies []policies.InquireablePolicy
 	for _, chaincode := range interest.Chaincodes {
-		pol := ea.PolicyByChaincode(string(chainID), chaincode.Name)
+		pol := ea.policyOfCall(string(chainID), chaincode)
 		if pol == nil {
 			logger.Debug("Policy for chaincode '", chaincode, "'doesn't exist")
 			return nil, errors.New("policy not found")
 		}
 		inquireablePolicies = append(inquireablePolicies, pol)
+		// The key-level endorsement policies need to be satisfied
+		// in addition to the endorsement policy of the chaincode
+		for _, keyPolicy := range chaincode.KeyPolicies {
+			inquireablePolicies = append(inquireablePolicies, inquire.NewInquireableSignaturePolicy(keyPolicy))
+		}
+		for _, key := range chaincode.KeyNames {
+			keyPolicy, err := ea.PolicyOfKey(string(chainID), chaincode.Name, key)
+			if err != nil {
+				logger.Warningf("Failed retrieving the key-level endorsement policy of key %s of chaincode %s: %v", key, chaincode.Name, err)
+				return nil, errors.Errorf("failed retrieving the key-level endorsement policy of key %s", key)
+			}
+			if keyPolicy == nil {
+				continue
+			}
+			inquireablePolicies = append(inquireablePolicies, inquire.NewInquireableSignaturePolicy(keyPolicy))
+		}
 	}
 
 	var cpss []inquire.ComparablePrincipalSets
@@ -212,6 +316,18 @@ func (ea *endorsementAnalyzer) computePrincipalSets(chainID common.ChainID, inte
 	return cps.ToPrincipalSets(), nil
 }
 
+// policyOfCall returns the sub-policy the given chaincode call selects, or the endorsement
+// policy of the chaincode if the call doesn't select a sub-policy the chaincode has
+func (ea *endorsementAnalyzer) policyOfCall(channel string, call *discovery.ChaincodeCall) policies.InquireablePolicy {
+	if call.SubPolicy != "" {
+		if pol := ea.SubPolicyByChaincode(channel, call.Name, call.SubPolicy); pol != nil {
+			return pol
+		}
+		logger.Debugf("Chaincode %s has no sub-policy %s, using its endorsement policy", call.Name, call.SubPolicy)
+	}
+	return ea.PolicyByChaincode(channel, call.Name)
+}
+
 type filterFunc func(policies.PrincipalSets) (policies.PrincipalSets, error)
 
 type filterFunctions []filterFunc
@@ -226,12 +342,15 @@ func loadMetadataAndFilters(chainID common.ChainID, interest *discovery.Chaincod
 	var filters filterFunctions
 
 	for _, chaincode := range interest.Chaincodes {
-		ccMD := fetch.Metadata(string(chainID), chaincode.Name, len(chaincode.CollectionNames) > 0)
+		// Collections that are only written to don't restrict the endorsers,
+		// since only members of a collection need to read its private data
+		filterByCollections := len(chaincode.CollectionNames) > 0 && !chaincode.NoPrivateReads
+		ccMD := fetch.Metadata(string(chainID), chaincode.Name, filterByCollections)
 		if ccMD == nil {
 			return nil, errors.Errorf("No metadata was found for chaincode %s in channel %s", chaincode.Name, string(chainID))
 		}
 		metadata = append(metadata, ccMD)
-		if len(chaincode.CollectionNames) == 0 {
+		if !filterByCollections {
 			continue
 		}
 		f, err := newCollectionFilter(ccMD.CollectionsConfig)
@@ -276,162 +395,18 @@ func (filters filterFunctions) combine() filterFunc {
 	}
 }
 
-func (ea *endorsementAnalyzer) satisfiesPrincipal(channel string, identitiesOfMembers memberIdentities) peerPrincipalEvaluator {
+func (ea *endorsementAnalyzer) satisfiesPrincipal(channel string, identitiesOfMembers memberIdentities) PeerPrincipalEvaluator {
 	return func(member discovery2.NetworkMember, principal *msp.MSPPrincipal) bool {
 		err := ea.SatisfiesPrincipal(channel, identitiesOfMembers.identityByPKIID(member.PKIid), principal)
-		if err == nil {
-			// TODO: log the principals in a human readable form
-			logger.Debug(member, "satisfies principal", principal)
-			return true
-		}
-		logger.Debug(member, "doesn't satisfy principal", principal, ":", err)
-		return false
-	}
-}
-
-type peerMembershipCriteria struct {
-	satGraph        *principalPeerGraph
-	idOfMembers     memberIdentities
-	chanMemberById  map[string]discovery2.NetworkMember
-	possibleLayouts layouts
-}
-
-// endorsersByGroup computes a map from groups to peers.
-// Each group included, is found in some layout, which means
-// that there is some principal combination that includes the corresponding
-// group.
-// This means that if a group isn't included in the result, there is no
-// principal combination (that includes the principal corresponding to the group),
-// such that there are enough peers to satisfy the principal combination.
-func endorsersByGroup(criteria *peerMembershipCriteria) map[string]*discovery.Peers {
-	satGraph := criteria.satGraph
-	idOfMembers := criteria.idOfMembers
-	chanMemberById := criteria.chanMemberById
-	includedGroups := criteria.possibleLayouts.groupsSet()
-
-	res := make(map[string]*discovery.Peers)
-	// Map endorsers to their corresponding groups.
-	// Iterate the principals, and put the peers into each group that corresponds with a principal vertex
-	for grp, principalVertex := range satGraph.principalVertices {
-		if _, exists := includedGroups[grp]; !exists {
-			// If the current group is not found in any layout, skip the corresponding principal
-			continue
-		}
-		peerList := &discovery.Peers{}
-		res[grp] = peerList
-		for _, peerVertex := range principalVertex.Neighbors() {
-			member := peerVertex.Data.(discovery2.NetworkMember)
-			peerList.Peers = append(peerList.Peers, &discovery.Peer{
-				Identity:       idOfMembers.identityByPKIID(member.PKIid),
-				StateInfo:      chanMemberById[string(member.PKIid)].Envelope,
-				MembershipInfo: member.Envelope,
-			})
-		}
-	}
-	return res
-}
-
-// computeLayouts computes all possible principal combinations
-// that can be used to satisfy the endorsement policy, given a graph
-// of available peers that maps each peer to a principal it satisfies.
-// Each such a combination is called a layout, because it maps
-// a group (alias for a principal) to a threshold of peers that need to endorse,
-// and that satisfy the corresponding principal.
-func computeLayouts(principalsSets []policies.PrincipalSet, principalGroups principalGroupMapper, satGraph *principalPeerGraph) []*discovery.Layout {
-	var layouts []*discovery.Layout
-	// principalsSets is a collection of combinations of principals,
-	// such that each combination (given enough peers) satisfies the endorsement policy.
-	for _, principalSet := range principalsSets {
-		layout := &discovery.Layout{
-			QuantitiesByGroup: make(map[string]uint32),
-		}
-		// Since principalsSet has repetitions, we first
-		// compute a mapping from the principal to repetitions in the set.
-		for principal, plurality := range principalSet.UniqueSet() {
-			key := principalKey{
-				cls:       int32(principal.PrincipalClassification),
-				principal: string(principal.Principal),
+		if logger.IsEnabledFor(logging.DEBUG) {
+			if err == nil {
+				logger.Debug(member, "satisfies principal", principalName(principal))
+			} else {
+				logger.Debug(member, "doesn't satisfy principal", principalName(principal), ":", err)
 			}
-			// We map the principal to a group, which is an alias for the principal.
-			layout.QuantitiesByGroup[principalGroups.group(key)] = uint32(plurality)
-		}
-		// Check that the layout can be satisfied with the current known peers
-		// This is done by iterating the current layout, and ensuring that
-		// each principal vertex is connected to at least <plurality> peer vertices.
-		if isLayoutSatisfied(layout.QuantitiesByGroup, satGraph) {
-			// If so, then add the layout to the layouts, since we have enough peers to satisfy the
-			// principal combination
-			layouts = append(layouts, layout)
-		}
-	}
-	return layouts
-}
-
-func isLayoutSatisfied(layout map[string]uint32, satGraph *principalPeerGraph) bool {
-	for grp, plurality := range layout {
-		// Do we have more than <plurality> peers connected to the principal?
-		if len(satGraph.principalVertices[grp].Neighbors()) < int(plurality) {
-			return false
 		}
+		return err == nil
 	}
-	return true
-}
-
-type principalPeerGraph struct {
-	peerVertices      []*graph.Vertex
-	principalVertices map[string]*graph.Vertex
-}
-
-type principalAndPeerData struct {
-	members discovery2.Members
-	pGrps   principalGroupMapper
-}
-
-func principalsToPeersGraph(data principalAndPeerData, satisfiesPrincipal peerPrincipalEvaluator) *principalPeerGraph {
-	// Create the peer vertices
-	peerVertices := make([]*graph.Vertex, len(data.members))
-	for i, member := range data.members {
-		peerVertices[i] = graph.NewVertex(string(member.PKIid), member)
-	}
-
-	// Create the principal vertices
-	principalVertices := make(map[string]*graph.Vertex)
-	for pKey, grp := range data.pGrps {
-		principalVertices[grp] = graph.NewVertex(grp, pKey.toPrincipal())
-	}
-
-	// Connect principals and peers
-	for _, principalVertex := range principalVertices {
-		for _, peerVertex := range peerVertices {
-			// If the current peer satisfies the principal, connect their corresponding vertices with an edge
-			principal := principalVertex.Data.(*msp.MSPPrincipal)
-			member := peerVertex.Data.(discovery2.NetworkMember)
-			if satisfiesPrincipal(member, principal) {
-				peerVertex.AddNeighbor(principalVertex)
-			}
-		}
-	}
-	return &principalPeerGraph{
-		peerVertices:      peerVertices,
-		principalVertices: principalVertices,
-	}
-}
-
-func mapPrincipalsToGroups(principalsSets []policies.PrincipalSet) principalGroupMapper {
-	groupMapper := make(principalGroupMapper)
-	totalPrincipals := make(map[principalKey]struct{})
-	for _, principalSet := range principalsSets {
-		for _, principal := range principalSet {
-			totalPrincipals[principalKey{
-				principal: string(principal.Principal),
-				cls:       int32(principal.PrincipalClassification),
-			}] = struct{}{}
-		}
-	}
-	for principal := range totalPrincipals {
-		groupMapper.group(principal)
-	}
-	return groupMapper
 }
 
 type memberIdentities map[string]api.PeerIdentityType
@@ -454,42 +429,31 @@ func computeIdentitiesOfMembers(identitySet api.PeerIdentitySet, members map[str
 	return identitiesOfMembers
 }
 
-// principalGroupMapper maps principals to names of groups
-type principalGroupMapper map[principalKey]string
-
-func (mapper principalGroupMapper) group(principal principalKey) string {
-	if grp, exists := mapper[principal]; exists {
-		return grp
+// ledgerHeightLag returns the maximum ledger height lag that applies to the given chaincode interest
+func (ea *endorsementAnalyzer) ledgerHeightLag(interest *discovery.ChaincodeInterest) uint64 {
+	if interest.MaxLedgerHeightLag > 0 {
+		return interest.MaxLedgerHeightLag
 	}
-	grp := fmt.Sprintf("G%d", len(mapper))
-	mapper[principal] = grp
-	return grp
+	return ea.maxLedgerHeightLag
 }
 
-type principalKey struct {
-	cls       int32
-	principal string
-}
-
-func (pk principalKey) toPrincipal() *msp.MSPPrincipal {
-	return &msp.MSPPrincipal{
-		PrincipalClassification: msp.MSPPrincipal_Classification(pk.cls),
-		Principal:               []byte(pk.principal),
+// peersWithinLedgerHeightLag returns a filter that selects peers whose ledger height
+// doesn't lag behind the highest ledger height among the given members by more than lag blocks
+func peersWithinLedgerHeightLag(members discovery2.Members, lag uint64) func(member discovery2.NetworkMember) bool {
+	var maxHeight uint64
+	for _, member := range members {
+		if height := member.Properties.GetLedgerHeight(); height > maxHeight {
+			maxHeight = height
+		}
 	}
-}
-
-// layouts is an aggregation of several layouts
-type layouts []*discovery.Layout
-
-// groupsSet returns a set of groups that the layouts contain
-func (l layouts) groupsSet() map[string]struct{} {
-	m := make(map[string]struct{})
-	for _, layout := range l {
-		for grp := range layout.QuantitiesByGroup {
-			m[grp] = struct{}{}
+	return func(member discovery2.NetworkMember) bool {
+		height := member.Properties.GetLedgerHeight()
+		if height+lag < maxHeight {
+			logger.Debugf("Excluding %s since its ledger height %d lags behind %d by more than %d blocks", &member, height, maxHeight, lag)
+			return false
 		}
+		return true
 	}
-	return m
 }
 
 func peersWithChaincode(metadata ...*chaincode.Metadata) func(member discovery2.NetworkMember) bool {
@@ -512,6 +476,61 @@ func peersWithChaincode(metadata ...*chaincode.Metadata) func(member discovery2.
 	}
 }
 
+// peersOfOrgs returns a filter that selects the peers of the given organizations,
+// or nil if there are no organizations
+func peersOfOrgs(mspIDs []string, identitiesByID map[string]api.PeerIdentityInfo) func(member discovery2.NetworkMember) bool {
+	if len(mspIDs) == 0 {
+		return nil
+	}
+	orgs := make(map[string]struct{})
+	for _, mspID := range mspIDs {
+		orgs[mspID] = struct{}{}
+	}
+	return func(member discovery2.NetworkMember) bool {
+		identity, exists := identitiesByID[string(member.PKIid)]
+		if !exists {
+			return false
+		}
+		_, preferred := orgs[string(identity.Organization)]
+		return preferred
+	}
+}
+
+// peersWithinRTT returns a filter that selects the peers whose round trip times
+// were measured to be at most the given number of microseconds, or nil if it is 0
+func peersWithinRTT(latencies map[string]time.Duration, maxRTTMicros uint64) func(member discovery2.NetworkMember) bool {
+	if maxRTTMicros == 0 {
+		return nil
+	}
+	maxRTT := time.Duration(maxRTTMicros) * time.Microsecond
+	return func(member discovery2.NetworkMember) bool {
+		rtt, measured := latencies[string(member.PKIid)]
+		return measured && rtt <= maxRTT
+	}
+}
+
+// anyOf returns a filter that selects the peers that any of the given filters select,
+// ignoring nil filters, or nil if all of the filters are nil
+func anyOf(filters ...func(member discovery2.NetworkMember) bool) func(member discovery2.NetworkMember) bool {
+	var nonNilFilters []func(member discovery2.NetworkMember) bool
+	for _, filter := range filters {
+		if filter != nil {
+			nonNilFilters = append(nonNilFilters, filter)
+		}
+	}
+	if len(nonNilFilters) == 0 {
+		return nil
+	}
+	return func(member discovery2.NetworkMember) bool {
+		for _, filter := range nonNilFilters {
+			if filter(member) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func mspIDsOfMembers(membersById map[string]discovery2.NetworkMember, identitiesByID map[string]api.PeerIdentityInfo) map[string]struct{} {
 	res := make(map[string]struct{})
 	for pkiID := range membersById {
@@ -9,14 +9,17 @@ package endorsement
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	common2 "github.com/hyperledger/fabric/protos/common"
 	discoveryprotos "github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/msp"
@@ -26,6 +29,12 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+var pkiID2OU = map[string]string{
+	"p0":  "audit",
+	"p6":  "audit",
+	"p12": "sales",
+}
+
 var pkiID2MSPID = map[string]string{
 	"p0":  "Org0MSP",
 	"p1":  "Org1MSP",
@@ -59,6 +68,7 @@ func TestPeersForEndorsement(t *testing.T) {
 				res[string(p.Identity)] = struct{}{}
 				assert.Equal(t, string(p.Identity), string(p.MembershipInfo.Payload))
 				assert.Equal(t, string(p.Identity), string(p.StateInfo.Payload))
+				assert.Equal(t, uint64(100), p.LedgerHeight)
 			}
 		}
 		return res
@@ -83,12 +93,12 @@ func TestPeersForEndorsement(t *testing.T) {
 	identities := identitySet(pkiID2MSPID)
 
 	chanPeers := peerSet{
-		newPeer(0).withChaincode(cc, "1.0"),
-		newPeer(3).withChaincode(cc, "1.0"),
-		newPeer(6).withChaincode(cc, "1.0"),
-		newPeer(9).withChaincode(cc, "1.0"),
-		newPeer(11).withChaincode(cc, "1.0"),
-		newPeer(12).withChaincode(cc, "1.0"),
+		newPeer(0).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(3).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(6).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(9).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(11).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(12).withChaincode(cc, "1.0").withLedgerHeight(100),
 	}
 	g.On("Peers").Return(alivePeers.toMembers())
 	g.On("IdentityInfo").Return(identities)
@@ -188,11 +198,11 @@ func TestPeersForEndorsement(t *testing.T) {
 		// Scenario VI: Policy is found, there are enough peers to satisfy policy combinations,
 		// but some peers have the wrong chaincode version, and some don't even have it installed.
 		chanPeers := peerSet{
-			newPeer(0).withChaincode(cc, "1.0"),
-			newPeer(3).withChaincode(cc, "1.0"),
-			newPeer(6).withChaincode(cc, "1.0"),
-			newPeer(9).withChaincode(cc, "1.0"),
-			newPeer(12).withChaincode(cc, "1.0"),
+			newPeer(0).withChaincode(cc, "1.0").withLedgerHeight(100),
+			newPeer(3).withChaincode(cc, "1.0").withLedgerHeight(100),
+			newPeer(6).withChaincode(cc, "1.0").withLedgerHeight(100),
+			newPeer(9).withChaincode(cc, "1.0").withLedgerHeight(100),
+			newPeer(12).withChaincode(cc, "1.0").withLedgerHeight(100),
 		}
 		chanPeers[0].Properties.Chaincodes[0].Version = "0.6"
 		chanPeers[4].Properties = nil
@@ -268,7 +278,7 @@ func TestPeersForEndorsement(t *testing.T) {
 
 		chanPeers := peerSet{}
 		for _, id := range []int{0, 2, 4, 6, 10, 12} {
-			peer := newPeer(id).withChaincode("cc1", "1.0").withChaincode("cc2", "1.0").withChaincode("cc3", "1.0")
+			peer := newPeer(id).withChaincode("cc1", "1.0").withChaincode("cc2", "1.0").withChaincode("cc3", "1.0").withLedgerHeight(100)
 			chanPeers = append(chanPeers, peer)
 		}
 
@@ -323,6 +333,538 @@ func TestPeersForEndorsement(t *testing.T) {
 			peerIdentityString("p12"): {},
 		}, extractPeers(desc))
 	})
+
+	t.Run("CollectionsWithoutPrivateReads", func(t *testing.T) {
+		// Scenario X: Same as scenario VIII, but the collection is only written to,
+		// hence the collection policy doesn't restrict the endorsers
+		// and both p0 and p6, or p12 alone are returned.
+		collectionOrgs := []*msp.MSPPrincipal{
+			peerRole("p0"),
+			peerRole("p12"),
+		}
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name: cc, Version: "1.0", CollectionsConfig: buildCollectionConfig("collection", collectionOrgs...),
+		}).Once()
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).
+			addPrincipal(peerRole("p6")).newSet().
+			addPrincipal(peerRole("p12")).buildPolicy()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{
+				{
+					Name:            cc,
+					CollectionNames: []string{"collection"},
+					NoPrivateReads:  true,
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, desc)
+		assert.Len(t, desc.Layouts, 2)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p12"): {},
+		}, extractPeers(desc))
+	})
+
+	t.Run("KeyPolicies", func(t *testing.T) {
+		// Scenario XI: The endorsement policy of the chaincode is satisfied by p0 and p6, or by p12 alone,
+		// but the chaincode writes to a key with a key-level endorsement policy that requires p6.
+		// Therefore, the only layout is p0 and p6, as it already contains the principal of the key-level policy.
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name: cc, Version: "1.0",
+		}).Once()
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).
+			addPrincipal(peerRole("p6")).newSet().
+			addPrincipal(peerRole("p12")).buildPolicy()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{
+				{
+					Name:        cc,
+					KeyPolicies: []*common2.SignaturePolicyEnvelope{cauthdsl.SignedByMspPeer(pkiID2MSPID["p6"])},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, desc)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Len(t, desc.Layouts[0].QuantitiesByGroup, 2)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"): {},
+			peerIdentityString("p6"): {},
+		}, extractPeers(desc))
+	})
+
+	t.Run("KeyNames", func(t *testing.T) {
+		// Scenario XII: The endorsement policy of the chaincode is satisfied by p0 and p6, or by p12 alone,
+		// and the chaincode writes to key k1 which has a key-level endorsement policy that requires p6,
+		// and to key k2 which has no key-level endorsement policy.
+		// Therefore, the only layout is p0 and p6, as it already contains the principal of the key-level policy.
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name: cc, Version: "1.0",
+		}).Once()
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).
+			addPrincipal(peerRole("p6")).newSet().
+			addPrincipal(peerRole("p12")).buildPolicy()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		pf.On("PolicyOfKey", cc, "k1").Return(cauthdsl.SignedByMspPeer(pkiID2MSPID["p6"]), nil).Once()
+		pf.On("PolicyOfKey", cc, "k2").Return(nil, nil).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{
+				{
+					Name:     cc,
+					KeyNames: []string{"k1", "k2"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, desc)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Len(t, desc.Layouts[0].QuantitiesByGroup, 2)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"): {},
+			peerIdentityString("p6"): {},
+		}, extractPeers(desc))
+
+		// Scenario XIII: The key-level endorsement policy of a key can't be retrieved
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name: cc, Version: "1.0",
+		}).Once()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		pf.On("PolicyByChaincode", cc).Return(policy).Once()
+		pf.On("PolicyOfKey", cc, "k1").Return(nil, errors.New("ledger unavailable")).Once()
+		desc, err = analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{
+				{
+					Name:     cc,
+					KeyNames: []string{"k1"},
+				},
+			},
+		})
+		assert.Nil(t, desc)
+		assert.EqualError(t, err, "failed retrieving the key-level endorsement policy of key k1")
+	})
+}
+
+func TestCanSatisfy(t *testing.T) {
+	peerRole := func(pkiID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: pkiID2MSPID[pkiID],
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	interest := &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}}
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(10), newPeer(11), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0"),
+		newPeer(6).withChaincode(cc, "1.0"),
+		newPeer(11).withChaincode(cc, "1.0"),
+		newPeer(12).withChaincode(cc, "1.0"),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// Scenario I: The policy requires p1 and p6, or p11 twice, but only p6 and a single p11 are alive
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(peerRole("p1")).addPrincipal(peerRole("p6")).
+		newSet().addPrincipal(peerRole("p11")).addPrincipal(peerRole("p11")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy).Once()
+	canSatisfy, err := analyzer.CanSatisfy(channel, interest)
+	assert.NoError(t, err)
+	assert.False(t, canSatisfy)
+
+	// Scenario II: The policy requires p10 and p12, or p0 and p6.
+	// p10 isn't in the channel, but p0 and p6 are
+	pb = principalBuilder{}
+	policy = pb.newSet().addPrincipal(peerRole("p10")).addPrincipal(peerRole("p12")).
+		newSet().addPrincipal(peerRole("p0")).addPrincipal(peerRole("p6")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy).Once()
+	canSatisfy, err = analyzer.CanSatisfy(channel, interest)
+	assert.NoError(t, err)
+	assert.True(t, canSatisfy)
+
+	// Ensure CanSatisfy agrees with PeersForEndorsement
+	pf.On("PolicyByChaincode", cc).Return(policy).Once()
+	desc, err := analyzer.PeersForEndorsement(channel, interest)
+	assert.NoError(t, err)
+	assert.Len(t, desc.Layouts, 1)
+
+	// Scenario III: The policy isn't found
+	pf.On("PolicyByChaincode", cc).Return(nil).Once()
+	canSatisfy, err = analyzer.CanSatisfy(channel, interest)
+	assert.False(t, canSatisfy)
+	assert.EqualError(t, err, "policy not found")
+}
+
+func TestPeersForEndorsementOUPrincipals(t *testing.T) {
+	ouPrincipal := func(mspID, ou string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+			Principal: utils.MarshalOrPanic(&msp.OrganizationUnit{
+				MspIdentifier:                mspID,
+				OrganizationalUnitIdentifier: ou,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	interest := &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}}
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0"),
+		newPeer(6).withChaincode(cc, "1.0"),
+		newPeer(12).withChaincode(cc, "1.0"),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// The policy requires any peer from the audit OU of Org0 together with any peer from the audit OU of Org6,
+	// or any peer from the audit OU of Org12.
+	// p0 and p6 are in the audit OU, but p12 is in the sales OU
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(ouPrincipal("Org0MSP", "audit")).addPrincipal(ouPrincipal("Org6MSP", "audit")).
+		newSet().addPrincipal(ouPrincipal("Org12MSP", "audit")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy).Twice()
+	desc, err := analyzer.PeersForEndorsement(channel, interest)
+	assert.NoError(t, err)
+	assert.Equal(t, []*discoveryprotos.Layout{
+		{QuantitiesByGroup: map[string]uint32{"Org0MSP.ou.audit": 1, "Org6MSP.ou.audit": 1}},
+	}, desc.Layouts)
+	assert.Len(t, desc.EndorsersByGroups, 2)
+	assert.Len(t, desc.EndorsersByGroups["Org0MSP.ou.audit"].Peers, 1)
+	assert.Equal(t, peerIdentityString("p0"), string(desc.EndorsersByGroups["Org0MSP.ou.audit"].Peers[0].Identity))
+	assert.Len(t, desc.EndorsersByGroups["Org6MSP.ou.audit"].Peers, 1)
+	assert.Equal(t, peerIdentityString("p6"), string(desc.EndorsersByGroups["Org6MSP.ou.audit"].Peers[0].Identity))
+	canSatisfy, err := analyzer.CanSatisfy(channel, interest)
+	assert.NoError(t, err)
+	assert.True(t, canSatisfy)
+
+	// Only the sales OU of Org12 can satisfy the policy, but it requires the audit OU
+	pb = principalBuilder{}
+	policy = pb.newSet().addPrincipal(ouPrincipal("Org12MSP", "audit")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy).Twice()
+	_, err = analyzer.PeersForEndorsement(channel, interest)
+	assert.EqualError(t, err, "cannot satisfy any principal combination")
+	canSatisfy, err = analyzer.CanSatisfy(channel, interest)
+	assert.NoError(t, err)
+	assert.False(t, canSatisfy)
+}
+
+func TestPeersForEndorsementLedgerHeightLag(t *testing.T) {
+	peerRole := func(pkiID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: pkiID2MSPID[pkiID],
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0").withLedgerHeight(100),
+		newPeer(6).withChaincode(cc, "1.0").withLedgerHeight(95),
+		newPeer(12).withChaincode(cc, "1.0").withLedgerHeight(80),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// The policy requires any one of p0, p6 or p12
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(peerRole("p0")).
+		newSet().addPrincipal(peerRole("p6")).
+		newSet().addPrincipal(peerRole("p12")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy)
+
+	layoutsOf := func(desc *discoveryprotos.EndorsementDescriptor) []string {
+		var groups []string
+		for _, layout := range desc.Layouts {
+			for grp := range layout.QuantitiesByGroup {
+				groups = append(groups, grp)
+			}
+		}
+		return groups
+	}
+	interest := &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}}
+
+	// Scenario I: No lag is configured, hence all peers are returned
+	desc, err := analyzer.PeersForEndorsement(channel, interest)
+	assert.NoError(t, err)
+	assert.Len(t, layoutsOf(desc), 3)
+
+	// Scenario II: A lag of 10 blocks is configured, hence p12 is excluded
+	analyzer.SetMaxLedgerHeightLag(10)
+	desc, err = analyzer.PeersForEndorsement(channel, interest)
+	assert.NoError(t, err)
+	assert.Len(t, layoutsOf(desc), 2)
+	for _, peers := range desc.EndorsersByGroups {
+		for _, p := range peers.Peers {
+			assert.NotEqual(t, peerIdentityString("p12"), string(p.Identity))
+		}
+	}
+
+	// Scenario III: The interest overrides the configured lag with a stricter lag,
+	// hence only p0 is returned
+	interest = &discoveryprotos.ChaincodeInterest{
+		Chaincodes:         []*discoveryprotos.ChaincodeCall{{Name: cc}},
+		MaxLedgerHeightLag: 1,
+	}
+	desc, err = analyzer.PeersForEndorsement(channel, interest)
+	assert.NoError(t, err)
+	assert.Len(t, layoutsOf(desc), 1)
+	for _, peers := range desc.EndorsersByGroups {
+		for _, p := range peers.Peers {
+			assert.Equal(t, peerIdentityString("p0"), string(p.Identity))
+		}
+	}
+}
+
+func TestPeersForEndorsementPreferredOrgs(t *testing.T) {
+	peerRole := func(pkiID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: pkiID2MSPID[pkiID],
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0"),
+		newPeer(6).withChaincode(cc, "1.0"),
+		newPeer(12).withChaincode(cc, "1.0"),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// The policy requires either p0, or both p6 and p12
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(peerRole("p0")).
+		newSet().addPrincipal(peerRole("p6")).addPrincipal(peerRole("p12")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy)
+
+	org0Layout := map[string]uint32{"Org0MSP.peer": 1}
+	org6And12Layout := map[string]uint32{"Org6MSP.peer": 1, "Org12MSP.peer": 1}
+	for _, test := range []struct {
+		preferredOrgs []string
+		firstLayout   map[string]uint32
+	}{
+		{[]string{"Org0MSP"}, org0Layout},
+		{[]string{"Org6MSP", "Org12MSP"}, org6And12Layout},
+		// A layout that can be satisfied only partially by preferred peers is ranked first
+		// over a layout that can't be satisfied by preferred peers at all
+		{[]string{"Org12MSP"}, org6And12Layout},
+	} {
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes:    []*discoveryprotos.ChaincodeCall{{Name: cc}},
+			PreferredOrgs: test.preferredOrgs,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, desc.Layouts, 2)
+		assert.Equal(t, test.firstLayout, desc.Layouts[0].QuantitiesByGroup, "preferred orgs: %v", test.preferredOrgs)
+	}
+}
+
+func TestPeersForEndorsementSubPolicy(t *testing.T) {
+	peerRole := func(pkiID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: pkiID2MSPID[pkiID],
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0"),
+		newPeer(6).withChaincode(cc, "1.0"),
+		newPeer(12).withChaincode(cc, "1.0"),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// The endorsement policy requires p0, and the transfer sub-policy requires both p6 and p12
+	pb := principalBuilder{}
+	pf.On("PolicyByChaincode", cc).Return(pb.newSet().addPrincipal(peerRole("p0")).buildPolicy())
+	pf.On("SubPolicyByChaincode", cc, "transfer").Return(pb.newSet().addPrincipal(peerRole("p6")).addPrincipal(peerRole("p12")).buildPolicy())
+	pf.On("SubPolicyByChaincode", cc, "read").Return(nil)
+
+	for _, test := range []struct {
+		subPolicy string
+		layout    map[string]uint32
+	}{
+		{"", map[string]uint32{"Org0MSP.peer": 1}},
+		{"transfer", map[string]uint32{"Org6MSP.peer": 1, "Org12MSP.peer": 1}},
+		// The chaincode has no read sub-policy, so its endorsement policy applies
+		{"read", map[string]uint32{"Org0MSP.peer": 1}},
+	} {
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc, SubPolicy: test.subPolicy}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Equal(t, test.layout, desc.Layouts[0].QuantitiesByGroup, "sub-policy: %s", test.subPolicy)
+	}
+	pf.AssertNotCalled(t, "SubPolicyByChaincode", cc, "")
+}
+
+func TestPeersForEndorsementPreferredRTT(t *testing.T) {
+	peerRole := func(pkiID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: pkiID2MSPID[pkiID],
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"})
+	g := &gossipMock{
+		latencies: map[string]time.Duration{
+			"p0":  50 * time.Millisecond,
+			"p6":  5 * time.Millisecond,
+			"p12": 10 * time.Millisecond,
+		},
+	}
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(12)}.toMembers())
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode(cc, "1.0"),
+		newPeer(6).withChaincode(cc, "1.0"),
+		newPeer(12).withChaincode(cc, "1.0"),
+	}.toMembers())
+	pf := &policyFetcherMock{}
+	analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+
+	// The policy requires either p0, or both p6 and p12
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(peerRole("p0")).
+		newSet().addPrincipal(peerRole("p6")).addPrincipal(peerRole("p12")).buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy)
+
+	org0Layout := map[string]uint32{"Org0MSP.peer": 1}
+	org6And12Layout := map[string]uint32{"Org6MSP.peer": 1, "Org12MSP.peer": 1}
+	for _, test := range []struct {
+		maxRTT        time.Duration
+		preferredOrgs []string
+		firstLayout   map[string]uint32
+	}{
+		{time.Millisecond * 10, nil, org6And12Layout},
+		// Peers of preferred organizations are preferred regardless of their round trip times
+		{time.Millisecond * 5, []string{"Org0MSP"}, org0Layout},
+		{time.Millisecond * 5, []string{"Org12MSP"}, org6And12Layout},
+	} {
+		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes:            []*discoveryprotos.ChaincodeCall{{Name: cc}},
+			MaxPreferredRttMicros: uint64(test.maxRTT / time.Microsecond),
+			PreferredOrgs:         test.preferredOrgs,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, desc.Layouts, 2)
+		assert.Equal(t, test.firstLayout, desc.Layouts[0].QuantitiesByGroup, "max RTT: %v, preferred orgs: %v", test.maxRTT, test.preferredOrgs)
+		// The round trip times of the endorsers are returned
+		assert.Equal(t, uint64(5000), desc.EndorsersByGroups["Org6MSP.peer"].Peers[0].RttMicros)
+	}
+}
+
+func TestGroupNames(t *testing.T) {
+	role := func(mspID string, r msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal:               utils.MarshalOrPanic(&msp.MSPRole{MspIdentifier: mspID, Role: r}),
+		}
+	}
+	identity := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+		Principal:               []byte("p0"),
+	}
+	ou := func(mspID, ou string, certifiers []byte) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+			Principal: utils.MarshalOrPanic(&msp.OrganizationUnit{
+				MspIdentifier:                mspID,
+				OrganizationalUnitIdentifier: ou,
+				CertifiersIdentifier:         certifiers,
+			}),
+		}
+	}
+	// A role principal with an unknown field appended isn't encoded canonically
+	nonCanonical := role("Org1MSP", msp.MSPRole_PEER)
+	nonCanonical.Principal = append(nonCanonical.Principal, 0x78, 0x01)
+
+	sets := []policies.PrincipalSet{
+		{role("Org1MSP", msp.MSPRole_PEER), role("Org2MSP", msp.MSPRole_ADMIN)},
+		{identity, nonCanonical},
+		{ou("Org1MSP", "audit", nil), ou("Org1MSP", "audit", []byte{1, 2, 3})},
+	}
+	mapper := mapPrincipalsToGroups(sets)
+	assert.Len(t, mapper, 6)
+	groups := make(map[string]struct{})
+	for _, grp := range mapper {
+		groups[grp] = struct{}{}
+	}
+	assert.Len(t, groups, 6)
+
+	key := func(p *msp.MSPPrincipal) principalKey {
+		return principalKey{cls: int32(p.PrincipalClassification), principal: string(p.Principal)}
+	}
+	assert.Equal(t, "Org1MSP.peer", mapper[key(role("Org1MSP", msp.MSPRole_PEER))])
+	assert.Equal(t, "Org2MSP.admin", mapper[key(role("Org2MSP", msp.MSPRole_ADMIN))])
+	assert.Regexp(t, "^identity#[0-9a-f]{16}$", mapper[key(identity)])
+	assert.Regexp(t, "^role#[0-9a-f]{16}$", mapper[key(nonCanonical)])
+	assert.Equal(t, "Org1MSP.ou.audit", mapper[key(ou("Org1MSP", "audit", nil))])
+	assert.Regexp(t, "^Org1MSP\\.ou\\.audit#[0-9a-f]{16}$", mapper[key(ou("Org1MSP", "audit", []byte{1, 2, 3}))])
+
+	// Group names don't depend on the order of the principals
+	reversed := mapPrincipalsToGroups([]policies.PrincipalSet{sets[2], sets[1], sets[0]})
+	assert.Equal(t, mapper, reversed)
 }
 
 func TestPop(t *testing.T) {
@@ -450,8 +992,17 @@ func (pi *peerInfo) withChaincode(name, version string) *peerInfo {
 	return pi
 }
 
+func (pi *peerInfo) withLedgerHeight(height uint64) *peerInfo {
+	if pi.Properties == nil {
+		pi.Properties = &gossip.Properties{}
+	}
+	pi.Properties.LedgerHeight = height
+	return pi
+}
+
 type gossipMock struct {
 	mock.Mock
+	latencies map[string]time.Duration
 }
 
 func (g *gossipMock) IdentityInfo() api.PeerIdentitySet {
@@ -468,6 +1019,10 @@ func (g *gossipMock) Peers() discovery.Members {
 	return members.(discovery.Members)
 }
 
+func (g *gossipMock) PeerLatencies(_ common.ChainID) map[string]time.Duration {
+	return g.latencies
+}
+
 type policyFetcherMock struct {
 	mock.Mock
 }
@@ -480,6 +1035,22 @@ func (pf *policyFetcherMock) PolicyByChaincode(channel string, chaincode string)
 	return arg.Get(0).(policies.InquireablePolicy)
 }
 
+func (pf *policyFetcherMock) SubPolicyByChaincode(channel string, chaincode string, name string) policies.InquireablePolicy {
+	arg := pf.Called(chaincode, name)
+	if arg.Get(0) == nil {
+		return nil
+	}
+	return arg.Get(0).(policies.InquireablePolicy)
+}
+
+func (pf *policyFetcherMock) PolicyOfKey(channel string, chaincode string, key string) (*common2.SignaturePolicyEnvelope, error) {
+	arg := pf.Called(chaincode, key)
+	if arg.Get(0) == nil {
+		return nil, arg.Error(1)
+	}
+	return arg.Get(0).(*common2.SignaturePolicyEnvelope), arg.Error(1)
+}
+
 type principalBuilder struct {
 	ip inquireablePolicy
 }
@@ -511,20 +1082,35 @@ type principalEvaluatorMock struct {
 }
 
 func (pe *principalEvaluatorMock) MSPOfPrincipal(principal *msp.MSPPrincipal) string {
+	if principal.PrincipalClassification == msp.MSPPrincipal_ORGANIZATION_UNIT {
+		ou := &msp.OrganizationUnit{}
+		proto.Unmarshal(principal.Principal, ou)
+		return ou.MspIdentifier
+	}
 	role := &msp.MSPRole{}
 	proto.Unmarshal(principal.Principal, role)
 	return role.MspIdentifier
 }
 
 func (pe *principalEvaluatorMock) SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) error {
-	peerRole := &msp.MSPRole{}
-	if err := proto.Unmarshal(principal.Principal, peerRole); err != nil {
-		return err
-	}
 	sId := &msp.SerializedIdentity{}
 	if err := proto.Unmarshal(identity, sId); err != nil {
 		return err
 	}
+	if principal.PrincipalClassification == msp.MSPPrincipal_ORGANIZATION_UNIT {
+		ou := &msp.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return err
+		}
+		if ou.MspIdentifier == sId.Mspid && ou.OrganizationalUnitIdentifier == pkiID2OU[string(sId.IdBytes)] {
+			return nil
+		}
+		return errors.New("not satisfies")
+	}
+	peerRole := &msp.MSPRole{}
+	if err := proto.Unmarshal(principal.Principal, peerRole); err != nil {
+		return err
+	}
 	if peerRole.MspIdentifier == sId.Mspid {
 		return nil
 	}
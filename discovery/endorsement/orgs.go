@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+// OrgCombinations returns the minimal combinations of organizations whose endorsements satisfy
+// the endorsement policies of the given chaincode interest, along with the quantity of
+// endorsements each combination requires from each organization.
+// Unlike PeersForEndorsement, the peers of the channel aren't resolved, and therefore
+// organizations are considered regardless of whether they have alive peers with the chaincode installed.
+func (ea *endorsementAnalyzer) OrgCombinations(chainID common.ChainID, interest *discovery.ChaincodeInterest) (*discovery.OrgCombinations, error) {
+	metadataAndCollectionFilters, err := loadMetadataAndFilters(chainID, interest, ea)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	principalsSets, err := ea.computePrincipalSets(chainID, interest, func(policies.PrincipalSet) bool {
+		return true
+	})
+	if err != nil {
+		logger.Warningf("Principal set computation failed: %v", err)
+		return nil, errors.WithStack(err)
+	}
+	principalsSets, err = metadataAndCollectionFilters.filter(principalsSets)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var combinations []orgCombination
+	for _, principalSet := range principalsSets {
+		combination, err := ea.orgCombination(principalSet)
+		if err != nil {
+			logger.Debugf("Principal set %v is skipped: %v", principalSet, err)
+			continue
+		}
+		combinations = append(combinations, combination)
+	}
+	if len(combinations) == 0 {
+		return nil, errors.New("no combination of organizations satisfies the endorsement policies")
+	}
+
+	res := &discovery.OrgCombinations{
+		Chaincode: interest.Chaincodes[0].Name,
+	}
+	for _, combination := range minimalOrgCombinations(combinations) {
+		res.Combinations = append(res.Combinations, &discovery.OrgCombination{
+			QuantitiesByOrg: combination,
+		})
+	}
+	return res, nil
+}
+
+// orgCombination maps MSP IDs to quantities of endorsements
+type orgCombination map[string]uint32
+
+// orgCombination returns the quantity of endorsements the given principal set
+// requires from each organization
+func (ea *endorsementAnalyzer) orgCombination(principalSet policies.PrincipalSet) (orgCombination, error) {
+	combination := make(orgCombination)
+	for _, principal := range principalSet {
+		mspID := ea.MSPOfPrincipal(principal)
+		if mspID == "" {
+			return nil, errors.Errorf("principal %v doesn't belong to an organization", principal)
+		}
+		combination[mspID]++
+	}
+	return combination, nil
+}
+
+func (c orgCombination) total() uint32 {
+	var total uint32
+	for _, quantity := range c {
+		total += quantity
+	}
+	return total
+}
+
+// contains returns whether c requires at least the quantities of endorsements that o requires
+func (c orgCombination) contains(o orgCombination) bool {
+	for mspID, quantity := range o {
+		if c[mspID] < quantity {
+			return false
+		}
+	}
+	return true
+}
+
+func (c orgCombination) String() string {
+	var orgs []string
+	for mspID, quantity := range c {
+		orgs = append(orgs, fmt.Sprintf("%s:%d", mspID, quantity))
+	}
+	sort.Strings(orgs)
+	return strings.Join(orgs, ",")
+}
+
+// minimalOrgCombinations returns the given combinations without duplicates and without
+// combinations that contain other combinations, ordered by the total quantity of
+// endorsements they require, and then by the MSP IDs of their organizations
+func minimalOrgCombinations(combinations []orgCombination) []orgCombination {
+	sorted := append([]orgCombination(nil), combinations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].total() != sorted[j].total() {
+			return sorted[i].total() < sorted[j].total()
+		}
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	var res []orgCombination
+	for _, combination := range sorted {
+		minimal := true
+		for _, selected := range res {
+			if combination.contains(selected) {
+				minimal = false
+				break
+			}
+		}
+		if minimal {
+			res = append(res, combination)
+		}
+	}
+	return res
+}
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/gossip/common"
+	discoveryprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgCombinations(t *testing.T) {
+	peerRole := func(mspID string) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal: utils.MarshalOrPanic(&msp.MSPRole{
+				MspIdentifier: mspID,
+				Role:          msp.MSPRole_PEER,
+			}),
+		}
+	}
+	cc := "chaincode"
+	channel := common.ChainID("test")
+	interest := &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}}
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Once()
+	pf := &policyFetcherMock{}
+	// The peers of the channel aren't resolved, so no gossip expectations are set
+	analyzer := NewEndorsementAnalyzer(&gossipMock{}, pf, &principalEvaluatorMock{}, mf)
+
+	// Scenario I: The policy requires Org1 and Org2, or Org3 twice, or Org1, Org2 and Org3.
+	// The last combination contains the first one, so it isn't returned
+	pb := principalBuilder{}
+	policy := pb.newSet().addPrincipal(peerRole("Org3MSP")).addPrincipal(peerRole("Org3MSP")).
+		newSet().addPrincipal(peerRole("Org2MSP")).addPrincipal(peerRole("Org1MSP")).
+		newSet().addPrincipal(peerRole("Org1MSP")).addPrincipal(peerRole("Org2MSP")).addPrincipal(peerRole("Org3MSP")).
+		buildPolicy()
+	pf.On("PolicyByChaincode", cc).Return(policy).Once()
+	res, err := analyzer.OrgCombinations(channel, interest)
+	assert.NoError(t, err)
+	assert.Equal(t, cc, res.Chaincode)
+	assert.Equal(t, []*discoveryprotos.OrgCombination{
+		{QuantitiesByOrg: map[string]uint32{"Org1MSP": 1, "Org2MSP": 1}},
+		{QuantitiesByOrg: map[string]uint32{"Org3MSP": 2}},
+	}, res.Combinations)
+
+	// Scenario II: The chaincode isn't defined
+	mf.On("Metadata").Return(nil).Once()
+	res, err = analyzer.OrgCombinations(channel, interest)
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "No metadata was found for chaincode chaincode in channel test")
+
+	// Scenario III: The policy isn't found
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Once()
+	pf.On("PolicyByChaincode", cc).Return(nil).Once()
+	res, err = analyzer.OrgCombinations(channel, interest)
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "policy not found")
+}
+
+func TestMinimalOrgCombinations(t *testing.T) {
+	combinations := []orgCombination{
+		{"Org2MSP": 2},
+		{"Org1MSP": 1, "Org2MSP": 1},
+		{"Org2MSP": 3},
+		{"Org1MSP": 1},
+		{"Org1MSP": 1},
+		{"Org0MSP": 1},
+	}
+	assert.Equal(t, []orgCombination{
+		{"Org0MSP": 1},
+		{"Org1MSP": 1},
+		{"Org2MSP": 2},
+	}, minimalOrgCombinations(combinations))
+}
@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/gossip/common"
+	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+// SimulatePolicy reports whether the given endorsement policy can be satisfied
+// by the alive peers of the given channel, which organizations it requires,
+// and minimal sets of peers that satisfy it.
+// Unlike PeersForEndorsement, the policy doesn't need to belong to any chaincode,
+// and therefore peers are considered regardless of the chaincodes installed on them.
+func (ea *endorsementAnalyzer) SimulatePolicy(chainID common.ChainID, policy *common2.SignaturePolicyEnvelope) (*discovery.PolicySimulationResult, error) {
+	if policy == nil || policy.Rule == nil || len(policy.Identities) == 0 {
+		return nil, errors.New("policy is empty")
+	}
+	principalsSets := inquire.NewInquireableSignaturePolicy(policy).SatisfiedBy()
+	if len(principalsSets) == 0 {
+		return nil, errors.New("policy cannot be satisfied by any principal combination")
+	}
+	res := &discovery.PolicySimulationResult{
+		RequiredOrgs: ea.requiredOrgs(principalsSets),
+	}
+
+	chanMembership := ea.PeersOfChannel(chainID)
+	channelMembersById := chanMembership.ByID()
+	aliveMembership := ea.Peers().Intersect(chanMembership)
+	identitiesOfMembers := computeIdentitiesOfMembers(ea.IdentityInfo(), aliveMembership.ByID())
+	desc, err := ComputeDescriptor(DescriptorSpec{
+		PrincipalSets:      principalsSets,
+		Members:            aliveMembership,
+		SatisfiesPrincipal: ea.satisfiesPrincipal(string(chainID), identitiesOfMembers),
+		ToPeer: func(member discovery2.NetworkMember) *discovery.Peer {
+			return &discovery.Peer{
+				Identity:       identitiesOfMembers.identityByPKIID(member.PKIid),
+				StateInfo:      channelMembersById[string(member.PKIid)].Envelope,
+				MembershipInfo: member.Envelope,
+			}
+		},
+	})
+	if err != nil {
+		logger.Debugf("Policy cannot be satisfied in channel %s: %v", chainID, err)
+		return res, nil
+	}
+	res.Satisfiable = true
+	res.MinimalPeerSets = minimalPeerSets(desc)
+	return res, nil
+}
+
+// requiredOrgs returns the sorted MSP IDs that appear in all of the given principal sets
+func (ea *endorsementAnalyzer) requiredOrgs(principalsSets []policies.PrincipalSet) []string {
+	var required map[string]struct{}
+	for _, principalSet := range principalsSets {
+		orgs := make(map[string]struct{})
+		for _, principal := range principalSet {
+			if mspID := ea.MSPOfPrincipal(principal); mspID != "" {
+				orgs[mspID] = struct{}{}
+			}
+		}
+		if required == nil {
+			required = orgs
+			continue
+		}
+		for mspID := range required {
+			if _, exists := orgs[mspID]; !exists {
+				delete(required, mspID)
+			}
+		}
+	}
+	var res []string
+	for mspID := range required {
+		res = append(res, mspID)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// minimalPeerSets returns a set of peers for each layout of the given descriptor,
+// such that none of the returned sets contains another, ordered by their sizes
+func minimalPeerSets(desc *ServiceDescriptor) []*discovery.Peers {
+	var sets [][]*discovery.Peer
+	for _, layout := range desc.Layouts {
+		if set := peersForLayout(layout, desc.PeersByGroups); set != nil {
+			sets = append(sets, set)
+		}
+	}
+	sort.SliceStable(sets, func(i, j int) bool {
+		return len(sets[i]) < len(sets[j])
+	})
+
+	var res []*discovery.Peers
+	for _, set := range sets {
+		minimal := true
+		for _, selected := range res {
+			if containsAll(set, selected.Peers) {
+				minimal = false
+				break
+			}
+		}
+		if minimal {
+			res = append(res, &discovery.Peers{Peers: set})
+		}
+	}
+	return res
+}
+
+// peersForLayout selects distinct peers from the given groups according to the quantities of the given layout,
+// preferring peers with lower identities, or returns nil if not enough distinct peers are found
+func peersForLayout(layout *discovery.Layout, peersByGroups map[string]*discovery.Peers) []*discovery.Peer {
+	var groups []string
+	for grp := range layout.QuantitiesByGroup {
+		groups = append(groups, grp)
+	}
+	sort.Strings(groups)
+
+	var selected []*discovery.Peer
+	for _, grp := range groups {
+		candidates := append([]*discovery.Peer(nil), peersByGroups[grp].GetPeers()...)
+		sort.Slice(candidates, func(i, j int) bool {
+			return bytes.Compare(candidates[i].Identity, candidates[j].Identity) < 0
+		})
+		quantity := int(layout.QuantitiesByGroup[grp])
+		for _, p := range candidates {
+			if quantity == 0 {
+				break
+			}
+			if containsAll(selected, []*discovery.Peer{p}) {
+				continue
+			}
+			selected = append(selected, p)
+			quantity--
+		}
+		if quantity > 0 {
+			return nil
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return bytes.Compare(selected[i].Identity, selected[j].Identity) < 0
+	})
+	return selected
+}
+
+// containsAll returns whether all of the peers of subset are in set
+func containsAll(set []*discovery.Peer, subset []*discovery.Peer) bool {
+	for _, p := range subset {
+		found := false
+		for _, q := range set {
+			if bytes.Equal(p.Identity, q.Identity) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
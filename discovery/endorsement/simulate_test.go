@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/gossip/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatePolicy(t *testing.T) {
+	channel := common.ChainID("test")
+	policy, err := cauthdsl.FromString("AND('Org0MSP.peer', OR('Org6MSP.peer', 'Org11MSP.peer'))")
+	assert.NoError(t, err)
+
+	g := &gossipMock{}
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{newPeer(0), newPeer(6), newPeer(11)}.toMembers())
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(11)}.toMembers()).Once()
+	analyzer := NewEndorsementAnalyzer(g, &policyFetcherMock{}, &principalEvaluatorMock{}, &metadataFetcher{})
+
+	// Scenario I: The policy can be satisfied either by p0 and p6, or by p0 and p11.
+	// Only Org0MSP is required by all combinations
+	res, err := analyzer.SimulatePolicy(channel, policy)
+	assert.NoError(t, err)
+	assert.True(t, res.Satisfiable)
+	assert.Equal(t, []string{"Org0MSP"}, res.RequiredOrgs)
+	var peerSets [][]string
+	for _, peers := range res.MinimalPeerSets {
+		var identities []string
+		for _, p := range peers.Peers {
+			identities = append(identities, string(p.Identity))
+		}
+		peerSets = append(peerSets, identities)
+	}
+	assert.Len(t, peerSets, 2)
+	assert.Contains(t, peerSets, []string{peerIdentityString("p0"), peerIdentityString("p6")})
+	assert.Contains(t, peerSets, []string{peerIdentityString("p0"), peerIdentityString("p11")})
+
+	// Scenario II: Only p0 is alive, so the policy can't be satisfied,
+	// but the organizations it requires are still reported
+	g.On("Peers").Return(peerSet{newPeer(0)}.toMembers()).Once()
+	res, err = analyzer.SimulatePolicy(channel, policy)
+	assert.NoError(t, err)
+	assert.False(t, res.Satisfiable)
+	assert.Equal(t, []string{"Org0MSP"}, res.RequiredOrgs)
+	assert.Empty(t, res.MinimalPeerSets)
+
+	// Scenario III: The policy is empty
+	res, err = analyzer.SimulatePolicy(channel, &common2.SignaturePolicyEnvelope{})
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "policy is empty")
+}
+
+func TestMinimalPeerSets(t *testing.T) {
+	p := func(id string) *discovery.Peer {
+		return &discovery.Peer{Identity: []byte(id)}
+	}
+	desc := &ServiceDescriptor{
+		PeersByGroups: map[string]*discovery.Peers{
+			"G0": {Peers: []*discovery.Peer{p("b"), p("a")}},
+			"G1": {Peers: []*discovery.Peer{p("a")}},
+			"G2": {Peers: []*discovery.Peer{p("c")}},
+		},
+		Layouts: []*discovery.Layout{
+			// a and b are a superset of a
+			{QuantitiesByGroup: map[string]uint32{"G0": 2}},
+			{QuantitiesByGroup: map[string]uint32{"G1": 1}},
+			// G0 can't provide a peer other than a and b
+			{QuantitiesByGroup: map[string]uint32{"G0": 3}},
+			{QuantitiesByGroup: map[string]uint32{"G2": 1}},
+		},
+	}
+	sets := minimalPeerSets(desc)
+	assert.Equal(t, []*discovery.Peers{
+		{Peers: []*discovery.Peer{p("a")}},
+		{Peers: []*discovery.Peer{p("c")}},
+	}, sets)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+// ChaincodeVersions reports, for each of the given chaincodes, the versions installed on the
+// alive peers of the given channel, and the version of the chaincode definition in the channel.
+// This helps finding out why the endorsement policy of a chaincode can't be satisfied,
+// since only peers that have the defined version installed are considered endorsers.
+func (ea *endorsementAnalyzer) ChaincodeVersions(chainID common.ChainID, chaincodes []string) (*discovery.ChaincodeVersionsResult, error) {
+	if len(chaincodes) == 0 {
+		return nil, errors.New("no chaincodes specified")
+	}
+	chanMembership := ea.PeersOfChannel(chainID)
+	channelMembersById := chanMembership.ByID()
+	aliveMembership := ea.Peers().Intersect(chanMembership)
+	identitiesOfMembers := computeIdentitiesOfMembers(ea.IdentityInfo(), aliveMembership.ByID())
+
+	res := &discovery.ChaincodeVersionsResult{}
+	for _, cc := range chaincodes {
+		versions := &discovery.ChaincodeVersions{
+			Chaincode:               cc,
+			PeersByInstalledVersion: make(map[string]*discovery.Peers),
+		}
+		if md := ea.Metadata(string(chainID), cc, false); md != nil {
+			versions.DefinedVersion = md.Version
+		}
+		for _, member := range aliveMembership {
+			stateInfo := channelMembersById[string(member.PKIid)]
+			version := installedVersion(stateInfo.Properties, cc)
+			peers, exists := versions.PeersByInstalledVersion[version]
+			if !exists {
+				peers = &discovery.Peers{}
+				versions.PeersByInstalledVersion[version] = peers
+			}
+			peers.Peers = append(peers.Peers, &discovery.Peer{
+				Identity:       identitiesOfMembers.identityByPKIID(member.PKIid),
+				StateInfo:      stateInfo.Envelope,
+				MembershipInfo: member.Envelope,
+				LedgerHeight:   stateInfo.Properties.GetLedgerHeight(),
+			})
+		}
+		res.Chaincodes = append(res.Chaincodes, versions)
+	}
+	return res, nil
+}
+
+// installedVersion returns the version of the given chaincode that the peer with the
+// given properties has installed, or an empty string if it doesn't have it installed
+func installedVersion(properties *gossip.Properties, cc string) string {
+	for _, installed := range properties.GetChaincodes() {
+		if installed.Name == cc {
+			return installed.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaincodeVersions(t *testing.T) {
+	channel := common.ChainID("test")
+	g := &gossipMock{}
+	g.On("IdentityInfo").Return(identitySet(pkiID2MSPID))
+	g.On("PeersOfChannel").Return(peerSet{
+		newPeer(0).withChaincode("cc1", "1.0").withLedgerHeight(100),
+		newPeer(6).withChaincode("cc1", "0.9").withChaincode("cc2", "1.0"),
+		newPeer(11),
+		// p12 isn't alive, hence it isn't reported
+		newPeer(12).withChaincode("cc1", "1.0"),
+	}.toMembers())
+	g.On("Peers").Return(peerSet{newPeer(0), newPeer(6), newPeer(11)}.toMembers())
+	mf := &metadataFetcher{}
+	mf.On("Metadata").Return(&chaincode.Metadata{Name: "cc1", Version: "1.0"}).Once()
+	mf.On("Metadata").Return(nil).Once()
+	analyzer := NewEndorsementAnalyzer(g, &policyFetcherMock{}, &principalEvaluatorMock{}, mf)
+
+	identitiesByVersion := func(versions *discovery.ChaincodeVersions) map[string][]string {
+		res := make(map[string][]string)
+		for version, peers := range versions.PeersByInstalledVersion {
+			for _, p := range peers.Peers {
+				res[version] = append(res[version], string(p.Identity))
+			}
+		}
+		return res
+	}
+
+	// Scenario I: cc1 is defined with version 1.0, but p6 has version 0.9 installed,
+	// and p11 doesn't have it installed at all. cc2 isn't defined in the channel.
+	res, err := analyzer.ChaincodeVersions(channel, []string{"cc1", "cc2"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Chaincodes, 2)
+	assert.Equal(t, "cc1", res.Chaincodes[0].Chaincode)
+	assert.Equal(t, "1.0", res.Chaincodes[0].DefinedVersion)
+	assert.Equal(t, map[string][]string{
+		"1.0": {peerIdentityString("p0")},
+		"0.9": {peerIdentityString("p6")},
+		"":    {peerIdentityString("p11")},
+	}, identitiesByVersion(res.Chaincodes[0]))
+	assert.Equal(t, uint64(100), res.Chaincodes[0].PeersByInstalledVersion["1.0"].Peers[0].LedgerHeight)
+	assert.Equal(t, "cc2", res.Chaincodes[1].Chaincode)
+	assert.Empty(t, res.Chaincodes[1].DefinedVersion)
+	assert.Equal(t, map[string][]string{
+		"1.0": {peerIdentityString("p6")},
+		"":    {peerIdentityString("p0"), peerIdentityString("p11")},
+	}, identitiesByVersion(res.Chaincodes[1]))
+
+	// Scenario II: No chaincodes are specified
+	res, err = analyzer.ChaincodeVersions(channel, nil)
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "no chaincodes specified")
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader is the HTTP header that carries the base64 encoded signature
+	// of the client over the JSON body of a request sent to the gateway
+	SignatureHeader = "X-Discovery-Signature"
+
+	// maxGatewayRequestSize is the maximum size in bytes of the body of a request sent to the gateway
+	maxGatewayRequestSize = 1 << 20
+)
+
+// HTTPHandler returns an HTTP handler that serves the discovery service over HTTP/JSON.
+// Requests are POSTed as the JSON encoding of a discovery.Request, and are signed by the client
+// over the raw body, with the signature carried in the SignatureHeader.
+// Responses are the JSON encoding of a discovery.Response.
+func (s *service) HTTPHandler() http.Handler {
+	return http.HandlerFunc(s.serveHTTP)
+}
+
+func (s *service) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("method %s isn't supported", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxGatewayRequestSize))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed reading request").Error(), http.StatusBadRequest)
+		return
+	}
+	req := &discovery.Request{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(body), req); err != nil {
+		http.Error(w, errors.Wrap(err, "failed parsing request").Error(), http.StatusBadRequest)
+		return
+	}
+	signature, err := base64.StdEncoding.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed decoding signature").Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.validateGatewayAuth(r, req); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	res, err := s.discover(req, common.SignedData{
+		Data:      body,
+		Signature: signature,
+		Identity:  req.Authentication.ClientIdentity,
+	}, r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := (&jsonpb.Marshaler{}).Marshal(w, res); err != nil {
+		logger.Warningf("Failed writing response to %s: %v", r.RemoteAddr, err)
+	}
+}
+
+// validateGatewayAuth validates the authentication info of a request sent to the gateway,
+// and that the TLS certificate the client claims matches the one it sent, if TLS is enabled
+func (s *service) validateGatewayAuth(r *http.Request, req *discovery.Request) error {
+	if err := validateAuthInfo(req.Authentication); err != nil {
+		return err
+	}
+	logger.Debug("Received gateway request from", r.RemoteAddr)
+	if !s.config.TLS {
+		return nil
+	}
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return errors.New("client didn't send a TLS certificate")
+	}
+	computedHash := util.ComputeSHA256(r.TLS.PeerCertificates[0].Raw)
+	if !bytes.Equal(computedHash, req.Authentication.ClientTlsCertHash) {
+		claimed := hex.EncodeToString(req.Authentication.ClientTlsCertHash)
+		logger.Warningf("client claimed TLS hash %s doesn't match computed TLS hash from HTTP connection %s", claimed, hex.EncodeToString(computedHash))
+		return errors.New("client claimed TLS hash doesn't match computed TLS hash from HTTP connection")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateway(t *testing.T) {
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+			},
+		},
+	}
+	body, err := (&jsonpb.Marshaler{}).MarshalToString(req)
+	assert.NoError(t, err)
+	signature := []byte{4, 5, 6}
+
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	// The client is authenticated by its signature over the JSON body of the request
+	mockSup.On("EligibleForService", "mychannel", common.SignedData{
+		Data:      []byte(body),
+		Signature: signature,
+		Identity:  []byte{1, 2, 3},
+	}).Return(nil)
+	mockSup.On("Config", "mychannel").Return(&discovery.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{"Org1MSP": {Name: "Org1MSP"}},
+	}, nil)
+	handler := NewService(Config{}, mockSup).HTTPHandler()
+
+	newRequest := func(method, body string, signature []byte) *http.Request {
+		r := httptest.NewRequest(method, "/", bytes.NewBufferString(body))
+		r.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(signature))
+		return r
+	}
+
+	// Successful query
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodPost, body, signature))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	res := &discovery.Response{}
+	assert.NoError(t, jsonpb.Unmarshal(rec.Body, res))
+	assert.Len(t, res.Results, 1)
+	assert.Equal(t, "Org1MSP", res.Results[0].GetConfigResult().Msps["Org1MSP"].Name)
+
+	// Only POST is supported
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// Malformed request
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodPost, "{", signature))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed parsing request")
+
+	// Malformed signature
+	rec = httptest.NewRecorder()
+	r := newRequest(http.MethodPost, body, nil)
+	r.Header.Set(SignatureHeader, "not base64!")
+	handler.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed decoding signature")
+
+	// No client identity
+	noIdentity, err := (&jsonpb.Marshaler{}).MarshalToString(&discovery.Request{
+		Authentication: &discovery.AuthInfo{},
+	})
+	assert.NoError(t, err)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodPost, noIdentity, signature))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "access denied, client identity wasn't supplied")
+}
+
+func TestGatewayTLS(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte{7, 8, 9}}
+	newRequest := func(tlsCertHash []byte, state *tls.ConnectionState) *http.Request {
+		body, err := (&jsonpb.Marshaler{}).MarshalToString(&discovery.Request{
+			Authentication: &discovery.AuthInfo{
+				ClientIdentity:    []byte{1, 2, 3},
+				ClientTlsCertHash: tlsCertHash,
+			},
+		})
+		assert.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		r.TLS = state
+		return r
+	}
+	handler := NewService(Config{TLS: true}, &mockSupport{}).HTTPHandler()
+
+	// The client didn't send a TLS certificate
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(util.ComputeSHA256(cert.Raw), nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "client didn't send a TLS certificate")
+
+	// The client claims a TLS certificate other than the one it sent
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest([]byte{1}, &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "client claimed TLS hash doesn't match computed TLS hash from HTTP connection")
+
+	// The client claims the TLS certificate it sent
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(util.ComputeSHA256(cert.Raw), &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+var queryTypeNames = map[discovery.QueryType]string{
+	discovery.InvalidQueryType:            "invalid",
+	discovery.ConfigQueryType:             "config",
+	discovery.PeerMembershipQueryType:     "peers",
+	discovery.ChaincodeQueryType:          "endorsers",
+	discovery.LocalMembershipQueryType:    "local_peers",
+	discovery.SnapshotPeersQueryType:      "snapshot_peers",
+	discovery.PolicySimulationQueryType:   "policy_simulation",
+	discovery.ChaincodeVersionsQueryType:  "chaincode_versions",
+	discovery.ConfigUpdateImpactQueryType: "config_update_impact",
+	discovery.OrgCombinationsQueryType:    "org_combinations",
+}
+
+// JournalEntry is a query the discovery service processed, as recorded in the query journal.
+// Entries don't contain the identities of the clients, nor their signatures.
+type JournalEntry struct {
+	// Time is the time the query was processed
+	Time time.Time `json:"time"`
+	// Client is an anonymized identifier of the client that sent the query.
+	// It is the same for all queries of the same client.
+	Client string `json:"client"`
+	// Query is the serialized query
+	Query []byte `json:"query"`
+}
+
+// queryJournal records the queries the discovery service processes
+type queryJournal struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+}
+
+func newQueryJournal(w io.Writer) *queryJournal {
+	return &queryJournal{enc: json.NewEncoder(w)}
+}
+
+// openQueryJournal opens the query journal at the given path for appending
+func openQueryJournal(path string) (*queryJournal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening query journal %s", path)
+	}
+	return newQueryJournal(f), nil
+}
+
+// record appends the given query sent by the given client identity to the journal
+func (j *queryJournal) record(q *discovery.Query, identity []byte) {
+	rawQuery, err := proto.Marshal(q)
+	if err != nil {
+		logger.Warning("Failed marshaling query:", err)
+		return
+	}
+	entry := &JournalEntry{
+		Time:   time.Now(),
+		Client: hex.EncodeToString(util.ComputeSHA256(identity)[:8]),
+		Query:  rawQuery,
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if err := j.enc.Encode(entry); err != nil {
+		logger.Warning("Failed recording query in the query journal:", err)
+	}
+}
+
+// ReadJournal reads the entries of a query journal from the given reader
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	var entries []JournalEntry
+	dec := json.NewDecoder(r)
+	for {
+		var entry JournalEntry
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading entry %d of the query journal", len(entries))
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// ReplayStats aggregates the outcome of replaying queries
+type ReplayStats struct {
+	// Queries is the number of queries replayed
+	Queries int
+	// Failed is the number of queries that resulted in an error
+	Failed int
+	// Duration is the total time it took to process the queries
+	Duration time.Duration
+}
+
+func (rs *ReplayStats) add(failed bool, duration time.Duration) {
+	rs.Queries++
+	if failed {
+		rs.Failed++
+	}
+	rs.Duration += duration
+}
+
+// ReplayReport summarizes the replay of a query journal
+type ReplayReport struct {
+	ReplayStats
+	// ByType breaks down the replay by the type of the queries,
+	// such as "config", "peers" or "endorsers"
+	ByType map[string]*ReplayStats
+}
+
+// Replay processes the given journal entries with a discovery service that is created
+// with the given Config and Support, and reports how it performed.
+// This allows evaluating configurations and topologies against a real query mix.
+// Since journal entries are anonymized, the queries aren't subject to access control.
+func Replay(entries []JournalEntry, config Config, sup Support) (*ReplayReport, error) {
+	// Don't journal the replayed queries
+	config.QueryJournalPath = ""
+	s := NewService(config, sup)
+	report := &ReplayReport{
+		ByType: make(map[string]*ReplayStats),
+	}
+	for i, entry := range entries {
+		q := &discovery.Query{}
+		if err := proto.Unmarshal(entry.Query, q); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling query of entry %d", i)
+		}
+		start := time.Now()
+		res := s.dispatch(q, newRequestSnapshot(sup))
+		elapsed := time.Since(start)
+		failed := res.GetError() != nil
+
+		typeName := queryTypeNames[q.GetType()]
+		if _, exists := report.ByType[typeName]; !exists {
+			report.ByType[typeName] = &ReplayStats{}
+		}
+		report.ByType[typeName].add(failed, elapsed)
+		report.add(failed, elapsed)
+	}
+	return report, nil
+}
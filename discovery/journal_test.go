@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func TestQueryJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "discovery-journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	journalPath := filepath.Join(dir, "journal")
+
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(nil)
+	mockSup.On("ChannelExists", "channelWithAccessDenied").Return(true)
+	mockSup.On("EligibleForService", "channelWithAccessDenied", mock.Anything).Return(errors.New("foo"))
+	mockSup.On("Config", "mychannel").Return(&discovery.ConfigResult{}, nil)
+	mockSup.On("PeersForEndorsement", "cc1").Return(&discovery.EndorsementDescriptor{Chaincode: "cc1"}, nil)
+
+	configQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	}
+	ccQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{
+				Interests: []*discovery.ChaincodeInterest{
+					{Chaincodes: []*discovery.ChaincodeCall{{Name: "cc1"}}},
+				},
+			},
+		},
+	}
+	deniedQuery := &discovery.Query{
+		Channel: "channelWithAccessDenied",
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	}
+
+	service := NewService(Config{QueryJournalPath: journalPath}, mockSup)
+	_, err = service.Discover(context.Background(), toSignedRequest(&discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{configQuery, ccQuery, deniedQuery},
+	}))
+	assert.NoError(t, err)
+
+	rawJournal, err := ioutil.ReadFile(journalPath)
+	assert.NoError(t, err)
+	// Ensure the journal doesn't contain the identity of the client
+	assert.NotContains(t, string(rawJournal), base64.StdEncoding.EncodeToString([]byte{1, 2, 3}))
+
+	f, err := os.Open(journalPath)
+	assert.NoError(t, err)
+	defer f.Close()
+	entries, err := ReadJournal(f)
+	assert.NoError(t, err)
+	// The query that wasn't eligible for service isn't recorded
+	assert.Len(t, entries, 2)
+	assert.Equal(t, entries[0].Client, entries[1].Client)
+	assert.NotEmpty(t, entries[0].Client)
+	for i, expected := range []*discovery.Query{configQuery, ccQuery} {
+		q := &discovery.Query{}
+		assert.NoError(t, proto.Unmarshal(entries[i].Query, q))
+		assert.True(t, proto.Equal(expected, q))
+	}
+
+	// Replay the journal against a topology in which the chaincode isn't installed,
+	// and the config query is duplicated
+	entries = append(entries, entries[0])
+	replaySup := &mockSupport{}
+	replaySup.On("Config", "mychannel").Return(&discovery.ConfigResult{}, nil)
+	replaySup.On("PeersForEndorsement", "cc1").Return(nil, errors.New("not installed"))
+	report, err := Replay(entries, Config{QueryJournalPath: journalPath}, replaySup)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Queries)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.ByType, 2)
+	assert.Equal(t, 2, report.ByType["config"].Queries)
+	assert.Equal(t, 0, report.ByType["config"].Failed)
+	assert.Equal(t, 1, report.ByType["endorsers"].Queries)
+	assert.Equal(t, 1, report.ByType["endorsers"].Failed)
+
+	// Ensure replayed queries aren't recorded
+	f2, err := os.Open(journalPath)
+	assert.NoError(t, err)
+	defer f2.Close()
+	entries, err = ReadJournal(f2)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// Bad journals
+	_, err = ReadJournal(bytes.NewBufferString("{}\n{"))
+	assert.Contains(t, err.Error(), "failed reading entry 1 of the query journal")
+	_, err = Replay([]JournalEntry{{Query: []byte{1, 2, 3}}}, Config{}, replaySup)
+	assert.Contains(t, err.Error(), "failed unmarshaling query of entry 0")
+}
+
+func TestQueryJournalBadPath(t *testing.T) {
+	service := NewService(Config{QueryJournalPath: "/nonexistent/dir/journal"}, &mockSupport{})
+	assert.Nil(t, service.journal)
+}
@@ -10,14 +10,16 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"time"
 
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
-	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
@@ -33,13 +35,17 @@ var accessDenied = wrapError(errors.New("access denied"))
 type certHashExtractor func(ctx context.Context) []byte
 
 // dispatcher defines a function that dispatches a query
-type dispatcher func(q *discovery.Query) *discovery.QueryResult
+// against the snapshot of the request the query is part of
+type dispatcher func(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult
 
 type service struct {
 	config             Config
 	channelDispatchers map[discovery.QueryType]dispatcher
 	localDispatchers   map[discovery.QueryType]dispatcher
 	auth               *authCache
+	journal            *queryJournal
+	coalescer          *endorsementCoalescer
+	stats              *serviceStats
 	Support
 }
 
@@ -49,6 +55,16 @@ type Config struct {
 	AuthCacheEnabled             bool
 	AuthCacheMaxSize             int
 	AuthCachePurgeRetentionRatio float64
+	// QueryJournalPath is the path of the file queries are recorded in.
+	// If empty, queries aren't recorded.
+	QueryJournalPath string
+	// ResponseSigner signs responses of requests that contain a nonce.
+	// If nil, responses aren't signed.
+	ResponseSigner ResponseSigner
+	// QueryConcurrency is the maximum number of queries of a request
+	// that are processed in parallel. Values smaller than 2 mean
+	// the queries are processed sequentially.
+	QueryConcurrency int
 }
 
 // String returns a string representation of this Config
@@ -64,22 +80,40 @@ type peerMapping map[string]*discovery.Peer
 
 // NewService creates a new discovery service instance
 func NewService(config Config, sup Support) *service {
+	stats := newServiceStats()
 	s := &service{
+		config: config,
 		auth: newAuthCache(sup, authCacheConfig{
 			enabled:             config.AuthCacheEnabled,
 			maxCacheSize:        config.AuthCacheMaxSize,
 			purgeRetentionRatio: config.AuthCachePurgeRetentionRatio,
 		}),
-		Support: sup,
+		coalescer: newEndorsementCoalescer(),
+		stats:     stats,
+		Support:   sup,
 	}
+	s.auth.stats = stats
 	s.channelDispatchers = map[discovery.QueryType]dispatcher{
-		discovery.ConfigQueryType:         s.configQuery,
-		discovery.ChaincodeQueryType:      s.chaincodeQuery,
-		discovery.PeerMembershipQueryType: s.channelMembershipResponse,
+		discovery.ConfigQueryType:             s.configQuery,
+		discovery.ChaincodeQueryType:          s.chaincodeQuery,
+		discovery.PeerMembershipQueryType:     s.channelMembershipResponse,
+		discovery.SnapshotPeersQueryType:      s.snapshotPeersResponse,
+		discovery.PolicySimulationQueryType:   s.policySimulationQuery,
+		discovery.ChaincodeVersionsQueryType:  s.chaincodeVersionsQuery,
+		discovery.ConfigUpdateImpactQueryType: s.configUpdateImpactQuery,
+		discovery.OrgCombinationsQueryType:    s.orgCombinationsQuery,
 	}
 	s.localDispatchers = map[discovery.QueryType]dispatcher{
 		discovery.LocalMembershipQueryType: s.localMembershipResponse,
 	}
+	if config.QueryJournalPath != "" {
+		journal, err := openQueryJournal(config.QueryJournalPath)
+		if err != nil {
+			logger.Errorf("Query journal is disabled: %v", err)
+		} else {
+			s.journal = journal
+		}
+	}
 	logger.Info("Created with config", config)
 	return s
 }
@@ -90,34 +124,85 @@ func (s *service) Discover(ctx context.Context, request *discovery.SignedRequest
 	if err != nil {
 		return nil, err
 	}
+	return s.discover(req, common.SignedData{
+		Data:      request.Payload,
+		Signature: request.Signature,
+		Identity:  req.Authentication.ClientIdentity,
+	}, addr)
+}
 
-	var res []*discovery.QueryResult
-	for _, q := range req.Queries {
-		res = append(res, s.processQuery(q, request, req.Authentication.ClientIdentity, addr))
-	}
-	return &discovery.Response{
+// discover processes the queries of the given request, whose client
+// is authenticated by the given signed data
+func (s *service) discover(req *discovery.Request, signedData common.SignedData, addr string) (*discovery.Response, error) {
+	// All queries of the request share the same view of the membership and configuration
+	snapshot := newRequestSnapshot(s.Support)
+	res := make([]*discovery.QueryResult, len(req.Queries))
+	s.forEachQuery(len(req.Queries), func(i int) {
+		res[i] = s.processQuery(req.Queries[i], signedData, addr, snapshot)
+	})
+	resp := &discovery.Response{
 		Results: res,
-	}, nil
+	}
+	if len(req.Nonce) == 0 {
+		return resp, nil
+	}
+	if err := s.signResponse(resp, req.Nonce); err != nil {
+		logger.Errorf("Failed signing response to %s: %v", addr, err)
+		return nil, errors.New("failed signing response")
+	}
+	return resp, nil
+}
+
+// signResponse signs the given response in the context of a request with the given nonce
+func (s *service) signResponse(resp *discovery.Response, nonce []byte) error {
+	if s.config.ResponseSigner == nil {
+		logger.Debug("Response signing is disabled, not signing response")
+		return nil
+	}
+	msg, err := resp.SignedContent(nonce)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling response")
+	}
+	identity, err := s.config.ResponseSigner.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing identity")
+	}
+	sig, err := s.config.ResponseSigner.Sign(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed signing")
+	}
+	resp.Signature = &discovery.ResponseSignature{
+		Identity:  identity,
+		Signature: sig,
+	}
+	return nil
+}
+
+func (s *service) processQuery(query *discovery.Query, signedData common.SignedData, addr string, snapshot *requestSnapshot) *discovery.QueryResult {
+	start := time.Now()
+	res := s.authorizeAndDispatch(query, signedData, addr, snapshot)
+	s.stats.queryProcessed(query.GetType(), time.Since(start), res.GetError() != nil)
+	return res
 }
 
-func (s *service) processQuery(query *discovery.Query, request *discovery.SignedRequest, identity []byte, addr string) *discovery.QueryResult {
+func (s *service) authorizeAndDispatch(query *discovery.Query, signedData common.SignedData, addr string, snapshot *requestSnapshot) *discovery.QueryResult {
 	if query.Channel != "" && !s.ChannelExists(query.Channel) {
 		logger.Warning("got query for channel", query.Channel, "from", addr, "but it doesn't exist")
 		return accessDenied
 	}
-	if err := s.auth.EligibleForService(query.Channel, common.SignedData{
-		Data:      request.Payload,
-		Signature: request.Signature,
-		Identity:  identity,
-	}); err != nil {
+	if err := s.auth.EligibleForService(query.Channel, signedData); err != nil {
 		logger.Warning("got query for channel", query.Channel, "from", addr, "but it isn't eligible:", err)
+		s.stats.authFailed()
 		return accessDenied
 	}
-	return s.dispatch(query)
+	if s.journal != nil {
+		s.journal.record(query, signedData.Identity)
+	}
+	return s.dispatch(query, snapshot)
 
 }
 
-func (s *service) dispatch(q *discovery.Query) *discovery.QueryResult {
+func (s *service) dispatch(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
 	dispatchers := s.channelDispatchers
 	// Ensure local queries are routed only to channel-less dispatchers
 	if q.Channel == "" {
@@ -127,22 +212,30 @@ func (s *service) dispatch(q *discovery.Query) *discovery.QueryResult {
 	if !exists {
 		return wrapError(errors.New("unknown or missing request type"))
 	}
-	return dispatchQuery(q)
+	return dispatchQuery(q, snapshot)
 }
 
-func (s *service) chaincodeQuery(q *discovery.Query) *discovery.QueryResult {
+func (s *service) chaincodeQuery(q *discovery.Query, _ *requestSnapshot) *discovery.QueryResult {
 	if err := validateCCQuery(q.GetCcQuery()); err != nil {
 		return wrapError(err)
 	}
 	var descriptors []*discovery.EndorsementDescriptor
 	for _, interest := range q.GetCcQuery().Interests {
-		desc, err := s.PeersForEndorsement(common2.ChainID(q.Channel), interest)
+		desc, err := s.coalescer.peersForEndorsement(q.Channel, interest, func() (*discovery.EndorsementDescriptor, error) {
+			return s.PeersForEndorsement(common2.ChainID(q.Channel), interest)
+		})
 		if err != nil {
 			logger.Errorf("Failed constructing descriptor for chaincode %s,: %v", interest, err)
 			return wrapError(errors.Errorf("failed constructing descriptor for %v", interest))
 		}
+		desc, err = descriptorOfVersion(desc, q.GetCcQuery().DescriptorVersion)
+		if err != nil {
+			logger.Errorf("Failed converting descriptor for chaincode %s to version %s: %v", interest, q.GetCcQuery().DescriptorVersion, err)
+			return wrapError(errors.Errorf("failed constructing descriptor of version %s for %v", q.GetCcQuery().DescriptorVersion, interest))
+		}
 		descriptors = append(descriptors, desc)
 	}
+	s.stats.descriptorsReturned(descriptors)
 
 	return &discovery.QueryResult{
 		Result: &discovery.QueryResult_CcQueryRes{
@@ -153,8 +246,79 @@ func (s *service) chaincodeQuery(q *discovery.Query) *discovery.QueryResult {
 	}
 }
 
-func (s *service) configQuery(q *discovery.Query) *discovery.QueryResult {
-	conf, err := s.Config(q.Channel)
+func (s *service) policySimulationQuery(q *discovery.Query, _ *requestSnapshot) *discovery.QueryResult {
+	res, err := s.SimulatePolicy(common2.ChainID(q.Channel), q.GetPolicySimulation().Policy)
+	if err != nil {
+		logger.Warningf("Failed simulating policy in channel %s: %v", q.Channel, err)
+		return wrapError(errors.Errorf("failed simulating policy: %v", err))
+	}
+	return &discovery.QueryResult{
+		Result: &discovery.QueryResult_PolicySimulationRes{
+			PolicySimulationRes: res,
+		},
+	}
+}
+
+func (s *service) chaincodeVersionsQuery(q *discovery.Query, _ *requestSnapshot) *discovery.QueryResult {
+	res, err := s.ChaincodeVersions(common2.ChainID(q.Channel), q.GetChaincodeVersions().Chaincodes)
+	if err != nil {
+		logger.Warningf("Failed computing chaincode versions in channel %s: %v", q.Channel, err)
+		return wrapError(errors.Errorf("failed computing chaincode versions: %v", err))
+	}
+	return &discovery.QueryResult{
+		Result: &discovery.QueryResult_ChaincodeVersionsRes{
+			ChaincodeVersionsRes: res,
+		},
+	}
+}
+
+func (s *service) orgCombinationsQuery(q *discovery.Query, _ *requestSnapshot) *discovery.QueryResult {
+	interests := q.GetOrgCombinations().Interests
+	if len(interests) == 0 {
+		return wrapError(errors.New("org combinations query must have at least one chaincode interest"))
+	}
+	if err := validateInterests(interests); err != nil {
+		return wrapError(err)
+	}
+	var combinations []*discovery.OrgCombinations
+	for _, interest := range interests {
+		res, err := s.OrgCombinations(common2.ChainID(q.Channel), interest)
+		if err != nil {
+			logger.Warningf("Failed computing org combinations for chaincode %s in channel %s: %v", interest, q.Channel, err)
+			return wrapError(errors.Errorf("failed computing org combinations for %v", interest))
+		}
+		combinations = append(combinations, res)
+	}
+	return &discovery.QueryResult{
+		Result: &discovery.QueryResult_OrgCombinationsRes{
+			OrgCombinationsRes: &discovery.OrgCombinationsResult{
+				Content: combinations,
+			},
+		},
+	}
+}
+
+// configUpdateImpactQuery reports the impact of a config update on the channel,
+// including which of the peers in the channel view the update would eject
+func (s *service) configUpdateImpactQuery(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
+	configUpdate, err := configtx.UnmarshalConfigUpdate(q.GetConfigUpdateImpact().ConfigUpdate)
+	if err != nil {
+		return wrapError(errors.Errorf("failed parsing config update: %v", err))
+	}
+	res, err := s.ConfigUpdateImpact(q.Channel, configUpdate, channelMembership(q.Channel, snapshot))
+	if err != nil {
+		logger.Warningf("Failed computing config update impact in channel %s: %v", q.Channel, err)
+		return wrapError(errors.Errorf("failed computing config update impact: %v", err))
+	}
+	return &discovery.QueryResult{
+		Result: &discovery.QueryResult_ConfigUpdateImpactRes{
+			ConfigUpdateImpactRes: res,
+		},
+	}
+}
+
+func (s *service) configQuery(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
+	conf, err := snapshot.Config(q.Channel)
 	if err != nil {
 		logger.Errorf("Failed fetching config for channel %s: %v", q.Channel, err)
 		return wrapError(errors.Errorf("failed fetching config for channel %s", q.Channel))
@@ -176,11 +340,15 @@ func wrapPeerResponse(peersByOrg map[string]*discovery.Peers) *discovery.QueryRe
 	}
 }
 
-func (s *service) channelMembershipResponse(q *discovery.Query) *discovery.QueryResult {
+func (s *service) channelMembershipResponse(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
+	return wrapPeerResponse(channelMembership(q.Channel, snapshot))
+}
+
+// channelMembership returns the alive peers in the channel view, by organization
+func channelMembership(channel string, snapshot *requestSnapshot) map[string]*discovery.Peers {
 	membersByOrgs := make(map[string]*discovery.Peers)
-	chanPeers := s.PeersOfChannel(common2.ChainID(q.Channel))
-	chanPeerByID := discovery2.Members(chanPeers).ByID()
-	for org, ids2Peers := range s.computeMembership(q) {
+	chanPeerByID := snapshot.PeersOfChannel(channel).ByID()
+	for org, ids2Peers := range computeMembership(snapshot) {
 		membersByOrgs[org] = &discovery.Peers{}
 		for id, peer := range ids2Peers {
 			// Check if the peer is in the channel view
@@ -193,12 +361,46 @@ func (s *service) channelMembershipResponse(q *discovery.Query) *discovery.Query
 			membersByOrgs[org].Peers = append(membersByOrgs[org].Peers, peer)
 		}
 	}
+	return membersByOrgs
+}
+
+// snapshotPeersResponse returns the peers of the channel that advertise
+// a ledger snapshot at a height of at least the requested minimum height
+func (s *service) snapshotPeersResponse(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
+	minHeight := q.GetSnapshotPeers().MinHeight
+	membersByOrgs := make(map[string]*discovery.Peers)
+	chanPeerByID := snapshot.PeersOfChannel(q.Channel).ByID()
+	for org, ids2Peers := range computeMembership(snapshot) {
+		membersByOrgs[org] = &discovery.Peers{}
+		for id, peer := range ids2Peers {
+			stateInfoMsg, exists := chanPeerByID[string(id)]
+			if !exists || !servesSnapshot(stateInfoMsg.Properties, minHeight) {
+				continue
+			}
+			peer.StateInfo = stateInfoMsg.Envelope
+			membersByOrgs[org].Peers = append(membersByOrgs[org].Peers, peer)
+		}
+	}
 	return wrapPeerResponse(membersByOrgs)
 }
 
-func (s *service) localMembershipResponse(q *discovery.Query) *discovery.QueryResult {
+// servesSnapshot returns whether the given properties advertise
+// a ledger snapshot at a height of at least minHeight
+func servesSnapshot(props *gossip.Properties, minHeight uint64) bool {
+	if props == nil {
+		return false
+	}
+	for _, height := range props.SnapshotHeights {
+		if height >= minHeight {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) localMembershipResponse(q *discovery.Query, snapshot *requestSnapshot) *discovery.QueryResult {
 	membersByOrgs := make(map[string]*discovery.Peers)
-	for org, ids2Peers := range s.computeMembership(q) {
+	for org, ids2Peers := range computeMembership(snapshot) {
 		membersByOrgs[org] = &discovery.Peers{}
 		for _, peer := range ids2Peers {
 			membersByOrgs[org].Peers = append(membersByOrgs[org].Peers, peer)
@@ -207,10 +409,10 @@ func (s *service) localMembershipResponse(q *discovery.Query) *discovery.QueryRe
 	return wrapPeerResponse(membersByOrgs)
 }
 
-func (s *service) computeMembership(q *discovery.Query) map[string]peerMapping {
+func computeMembership(snapshot *requestSnapshot) map[string]peerMapping {
 	peersByOrg := make(map[string]peerMapping)
-	peerAliveInfo := discovery2.Members(s.Peers()).ByID()
-	for org, peerIdentities := range s.IdentityInfo().ByOrg() {
+	peerAliveInfo := snapshot.Peers().ByID()
+	for org, peerIdentities := range snapshot.IdentityInfo().ByOrg() {
 		peersForCurrentOrg := make(peerMapping)
 		peersByOrg[org] = peersForCurrentOrg
 		for _, id := range peerIdentities {
@@ -237,11 +439,8 @@ func validateStructure(ctx context.Context, request *discovery.SignedRequest, ad
 	if err != nil {
 		return nil, errors.Wrap(err, "failed parsing request")
 	}
-	if req.Authentication == nil {
-		return nil, errors.New("access denied, no authentication info in request")
-	}
-	if len(req.Authentication.ClientIdentity) == 0 {
-		return nil, errors.New("access denied, client identity wasn't supplied")
+	if err := validateAuthInfo(req.Authentication); err != nil {
+		return nil, err
 	}
 	logger.Debug("Received request from", addr)
 	if !tlsEnabled {
@@ -259,11 +458,25 @@ func validateStructure(ctx context.Context, request *discovery.SignedRequest, ad
 	return req, nil
 }
 
+func validateAuthInfo(authInfo *discovery.AuthInfo) error {
+	if authInfo == nil {
+		return errors.New("access denied, no authentication info in request")
+	}
+	if len(authInfo.ClientIdentity) == 0 {
+		return errors.New("access denied, client identity wasn't supplied")
+	}
+	return nil
+}
+
 func validateCCQuery(ccQuery *discovery.ChaincodeQuery) error {
 	if len(ccQuery.Interests) == 0 {
 		return errors.New("chaincode query must have at least one chaincode interest")
 	}
-	for _, interest := range ccQuery.Interests {
+	return validateInterests(ccQuery.Interests)
+}
+
+func validateInterests(interests []*discovery.ChaincodeInterest) error {
+	for _, interest := range interests {
 		if interest == nil {
 			return errors.New("chaincode interest is nil")
 		}
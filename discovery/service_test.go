@@ -13,12 +13,14 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/gossip/api"
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/discovery"
 	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/net/context"
@@ -316,6 +318,376 @@ func TestService(t *testing.T) {
 	assert.Contains(t, resp.Results[0].GetError().Content, "unknown or missing request type")
 }
 
+func TestSnapshotPeersQuery(t *testing.T) {
+	ctx := context.Background()
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(nil)
+	service := NewService(Config{}, mockSup)
+
+	// Peers in membership view: {p0, p1, p2, p3}
+	// Peers in channel view: {p1, p2, p3}
+	// p1 serves snapshots at heights 10 and 20, p2 serves a snapshot at height 5
+	// and p3 doesn't serve snapshots at all.
+	withSnapshots := func(member discovery2.NetworkMember, heights ...uint64) discovery2.NetworkMember {
+		member.Properties = &gossip.Properties{
+			SnapshotHeights: heights,
+		}
+		return member
+	}
+	mockSup.On("PeersOfChannel", common2.ChainID("mychannel")).Return(discovery2.Members{
+		withSnapshots(stateInfoMsg(1), 10, 20), withSnapshots(stateInfoMsg(2), 5), stateInfoMsg(3),
+	})
+	mockSup.On("Peers").Return(discovery2.Members{
+		aliveMsg(0), aliveMsg(1), aliveMsg(2), aliveMsg(3),
+	})
+	mockSup.On("IdentityInfo").Return(api.PeerIdentitySet{
+		idInfo(0, "O2"), idInfo(1, "O2"), idInfo(2, "O3"), idInfo(3, "O3"),
+	})
+
+	snapshotQuery := func(minHeight uint64) *discovery.Request {
+		return &discovery.Request{
+			Authentication: &discovery.AuthInfo{
+				ClientIdentity: []byte{1, 2, 3},
+			},
+			Queries: []*discovery.Query{
+				{
+					Channel: "mychannel",
+					Query: &discovery.Query_SnapshotPeers{
+						SnapshotPeers: &discovery.SnapshotPeersQuery{
+							MinHeight: minHeight,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Scenario I: Only p1 serves a snapshot at a height of at least 15
+	resp, err := service.Discover(ctx, toSignedRequest(snapshotQuery(15)))
+	assert.NoError(t, err)
+	expected := map[string][]*discovery.Peer{
+		"O2": {
+			{
+				Identity:       idInfo(1, "O2").Identity,
+				StateInfo:      stateInfoMsg(1).Envelope,
+				MembershipInfo: aliveMsg(1).Envelope,
+			},
+		},
+	}
+	for org, responsePeers := range resp.Results[0].GetMembers().PeersByOrg {
+		assert.NoError(t, peers(expected[org]).compare(peers(responsePeers.Peers)))
+	}
+
+	// Scenario II: Both p1 and p2 serve snapshots at a height of at least 5
+	resp, err = service.Discover(ctx, toSignedRequest(snapshotQuery(5)))
+	assert.NoError(t, err)
+	expected["O3"] = []*discovery.Peer{
+		{
+			Identity:       idInfo(2, "O3").Identity,
+			StateInfo:      stateInfoMsg(2).Envelope,
+			MembershipInfo: aliveMsg(2).Envelope,
+		},
+	}
+	for org, responsePeers := range resp.Results[0].GetMembers().PeersByOrg {
+		assert.NoError(t, peers(expected[org]).compare(peers(responsePeers.Peers)))
+	}
+
+	// Scenario III: No peer serves a snapshot at a height of at least 100
+	resp, err = service.Discover(ctx, toSignedRequest(snapshotQuery(100)))
+	assert.NoError(t, err)
+	for _, responsePeers := range resp.Results[0].GetMembers().PeersByOrg {
+		assert.Empty(t, responsePeers.Peers)
+	}
+}
+
+func TestPolicySimulationQuery(t *testing.T) {
+	ctx := context.Background()
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("ChannelExists", "yourchannel").Return(true)
+	mockSup.On("EligibleForService", mock.Anything, mock.Anything).Return(nil)
+	mockSup.On("SimulatePolicy", "mychannel", policy).Return(&discovery.PolicySimulationResult{
+		Satisfiable:  true,
+		RequiredOrgs: []string{"Org1MSP"},
+	}, nil)
+	mockSup.On("SimulatePolicy", "yourchannel", policy).Return(nil, errors.New("policy is empty"))
+	service := NewService(Config{}, mockSup)
+
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query: &discovery.Query_PolicySimulation{
+					PolicySimulation: &discovery.PolicySimulationQuery{
+						Policy: policy,
+					},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The policy is simulated successfully
+	resp, err := service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes := resp.PolicySimulationAt(0)
+	assert.Nil(t, errRes)
+	assert.True(t, res.Satisfiable)
+	assert.Equal(t, []string{"Org1MSP"}, res.RequiredOrgs)
+
+	// Scenario II: The simulation fails
+	req.Queries[0].Channel = "yourchannel"
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.PolicySimulationAt(0)
+	assert.Nil(t, res)
+	assert.Equal(t, "failed simulating policy: policy is empty", errRes.Content)
+}
+
+func TestChaincodeVersionsQuery(t *testing.T) {
+	ctx := context.Background()
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("ChannelExists", "yourchannel").Return(true)
+	mockSup.On("EligibleForService", mock.Anything, mock.Anything).Return(nil)
+	mockSup.On("ChaincodeVersions", "mychannel", []string{"mycc"}).Return(&discovery.ChaincodeVersionsResult{
+		Chaincodes: []*discovery.ChaincodeVersions{{Chaincode: "mycc", DefinedVersion: "1.0"}},
+	}, nil)
+	mockSup.On("ChaincodeVersions", "yourchannel", []string{"mycc"}).Return(nil, errors.New("no chaincodes specified"))
+	service := NewService(Config{}, mockSup)
+
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query: &discovery.Query_ChaincodeVersions{
+					ChaincodeVersions: &discovery.ChaincodeVersionsQuery{
+						Chaincodes: []string{"mycc"},
+					},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The chaincode versions are computed successfully
+	resp, err := service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes := resp.ChaincodeVersionsAt(0)
+	assert.Nil(t, errRes)
+	assert.Equal(t, "1.0", res.Chaincodes[0].DefinedVersion)
+
+	// Scenario II: The computation fails
+	req.Queries[0].Channel = "yourchannel"
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.ChaincodeVersionsAt(0)
+	assert.Nil(t, res)
+	assert.Equal(t, "failed computing chaincode versions: no chaincodes specified", errRes.Content)
+}
+
+func TestOrgCombinationsQuery(t *testing.T) {
+	ctx := context.Background()
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("ChannelExists", "yourchannel").Return(true)
+	mockSup.On("EligibleForService", mock.Anything, mock.Anything).Return(nil)
+	mockSup.On("OrgCombinations", "mychannel", "mycc").Return(&discovery.OrgCombinations{
+		Chaincode: "mycc",
+		Combinations: []*discovery.OrgCombination{
+			{QuantitiesByOrg: map[string]uint32{"Org1MSP": 1}},
+		},
+	}, nil)
+	mockSup.On("OrgCombinations", "yourchannel", "mycc").Return(nil, errors.New("policy not found"))
+	service := NewService(Config{}, mockSup)
+
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query: &discovery.Query_OrgCombinations{
+					OrgCombinations: &discovery.OrgCombinationsQuery{
+						Interests: []*discovery.ChaincodeInterest{
+							{Chaincodes: []*discovery.ChaincodeCall{{Name: "mycc"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The org combinations are computed successfully
+	resp, err := service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes := resp.OrgCombinationsAt(0)
+	assert.Nil(t, errRes)
+	assert.Len(t, res.Content, 1)
+	assert.Equal(t, map[string]uint32{"Org1MSP": 1}, res.Content[0].Combinations[0].QuantitiesByOrg)
+
+	// Scenario II: The computation fails
+	req.Queries[0].Channel = "yourchannel"
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.OrgCombinationsAt(0)
+	assert.Nil(t, res)
+	assert.Contains(t, errRes.Content, "failed computing org combinations for")
+
+	// Scenario III: The query has no interests
+	req.Queries[0].GetOrgCombinations().Interests = nil
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.OrgCombinationsAt(0)
+	assert.Nil(t, res)
+	assert.Equal(t, "org combinations query must have at least one chaincode interest", errRes.Content)
+
+	// Scenario IV: An interest has no chaincodes
+	req.Queries[0].GetOrgCombinations().Interests = []*discovery.ChaincodeInterest{{}}
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.OrgCombinationsAt(0)
+	assert.Nil(t, res)
+	assert.Equal(t, "chaincode interest must contain at least one chaincode", errRes.Content)
+}
+
+func TestConfigUpdateImpactQuery(t *testing.T) {
+	ctx := context.Background()
+	configUpdate := &common.ConfigUpdate{ChannelId: "mychannel"}
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", mock.Anything).Return(true)
+	mockSup.On("EligibleForService", mock.Anything, mock.Anything).Return(nil)
+	// Peers in membership view: {p0, p1, p2}
+	// Peers in channel view: {p1, p2}
+	mockSup.On("PeersOfChannel", common2.ChainID("mychannel")).Return(discovery2.Members{
+		stateInfoMsg(1), stateInfoMsg(2),
+	})
+	mockSup.On("PeersOfChannel", common2.ChainID("yourchannel")).Return(discovery2.Members{})
+	mockSup.On("Peers").Return(discovery2.Members{
+		aliveMsg(0), aliveMsg(1), aliveMsg(2),
+	})
+	mockSup.On("IdentityInfo").Return(api.PeerIdentitySet{
+		idInfo(0, "O2"), idInfo(1, "O2"), idInfo(2, "O3"),
+	})
+	ejected := &discovery.Peer{
+		Identity:       idInfo(2, "O3").Identity,
+		StateInfo:      stateInfoMsg(2).Envelope,
+		MembershipInfo: aliveMsg(2).Envelope,
+	}
+	channelPeers := mock.MatchedBy(func(peersByOrg map[string]*discovery.Peers) bool {
+		return len(peersByOrg["O2"].GetPeers()) == 1 && len(peersByOrg["O3"].GetPeers()) == 1 &&
+			proto.Equal(ejected, peersByOrg["O3"].Peers[0])
+	})
+	mockSup.On("ConfigUpdateImpact", "mychannel", mock.Anything, channelPeers).Return(&discovery.ConfigUpdateImpactResult{
+		RemovedMsps: []string{"O3"},
+		EjectedPeers: []*discovery.EjectedPeer{
+			{Peer: ejected, Reason: "O3 is not an application organization of the channel"},
+		},
+	}, nil)
+	mockSup.On("ConfigUpdateImpact", "yourchannel", mock.Anything, mock.Anything).Return(nil, errors.New("delta set was empty"))
+	service := NewService(Config{}, mockSup)
+
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query: &discovery.Query_ConfigUpdateImpact{
+					ConfigUpdateImpact: &discovery.ConfigUpdateImpactQuery{
+						ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+					},
+				},
+			},
+		},
+	}
+
+	// Scenario I: The impact is computed against the alive peers of the channel
+	resp, err := service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes := resp.ConfigUpdateImpactAt(0)
+	assert.Nil(t, errRes)
+	assert.Equal(t, []string{"O3"}, res.RemovedMsps)
+	assert.Len(t, res.EjectedPeers, 1)
+	mockSup.AssertCalled(t, "ConfigUpdateImpact", "mychannel", mock.MatchedBy(func(cu *common.ConfigUpdate) bool {
+		return proto.Equal(configUpdate, cu)
+	}), channelPeers)
+
+	// Scenario II: The computation fails
+	req.Queries[0].Channel = "yourchannel"
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.ConfigUpdateImpactAt(0)
+	assert.Nil(t, res)
+	assert.Equal(t, "failed computing config update impact: delta set was empty", errRes.Content)
+
+	// Scenario III: The config update is malformed
+	req.Queries[0].GetConfigUpdateImpact().ConfigUpdate = []byte{1, 2, 3}
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	res, errRes = resp.ConfigUpdateImpactAt(0)
+	assert.Nil(t, res)
+	assert.Contains(t, errRes.Content, "failed parsing config update")
+}
+
+func TestResponseSigning(t *testing.T) {
+	ctx := context.Background()
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(nil)
+	mockSup.On("Config", "mychannel").Return(&discovery.ConfigResult{}, nil)
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{
+			{
+				Channel: "mychannel",
+				Query: &discovery.Query_ConfigQuery{
+					ConfigQuery: &discovery.ConfigQuery{},
+				},
+			},
+		},
+	}
+
+	// Scenario I: Response signing is disabled, so responses aren't signed
+	service := NewService(Config{}, mockSup)
+	req.Nonce = []byte("nonce")
+	resp, err := service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Signature)
+
+	// Scenario II: Response signing is enabled, but the request contains no nonce
+	signer := &mockResponseSigner{identity: []byte("p1")}
+	service = NewService(Config{ResponseSigner: signer}, mockSup)
+	req.Nonce = nil
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Signature)
+
+	// Scenario III: The request contains a nonce, so the response is signed
+	req.Nonce = []byte("nonce")
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("p1"), resp.Signature.Identity)
+	expectedMsg, _ := resp.SignedContent([]byte("nonce"))
+	assert.Equal(t, expectedMsg, resp.Signature.Signature)
+
+	// Scenario IV: Signing fails
+	signer.err = errors.New("HSM unavailable")
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "failed signing response")
+}
+
 func TestValidateStructure(t *testing.T) {
 	extractHash := func(ctx context.Context) []byte {
 		return nil
@@ -468,6 +840,23 @@ func toSignedRequest(req *discovery.Request) *discovery.SignedRequest {
 	}
 }
 
+// mockResponseSigner "signs" messages by returning them as their signatures
+type mockResponseSigner struct {
+	identity []byte
+	err      error
+}
+
+func (s *mockResponseSigner) Sign(msg []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return msg, nil
+}
+
+func (s *mockResponseSigner) Serialize() ([]byte, error) {
+	return s.identity, nil
+}
+
 type mockSupport struct {
 	mock.Mock
 }
@@ -501,6 +890,30 @@ func (ms *mockSupport) PeersForEndorsement(channel common2.ChainID, interest *di
 	return args.Get(0).(*discovery.EndorsementDescriptor), args.Error(1)
 }
 
+func (ms *mockSupport) SimulatePolicy(channel common2.ChainID, policy *common.SignaturePolicyEnvelope) (*discovery.PolicySimulationResult, error) {
+	args := ms.Called(string(channel), policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.PolicySimulationResult), args.Error(1)
+}
+
+func (ms *mockSupport) ChaincodeVersions(channel common2.ChainID, chaincodes []string) (*discovery.ChaincodeVersionsResult, error) {
+	args := ms.Called(string(channel), chaincodes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.ChaincodeVersionsResult), args.Error(1)
+}
+
+func (ms *mockSupport) OrgCombinations(channel common2.ChainID, interest *discovery.ChaincodeInterest) (*discovery.OrgCombinations, error) {
+	args := ms.Called(string(channel), interest.Chaincodes[0].Name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.OrgCombinations), args.Error(1)
+}
+
 func (*mockSupport) Chaincodes(id common2.ChainID) []*gossip.Chaincode {
 	panic("implement me")
 }
@@ -517,6 +930,14 @@ func (ms *mockSupport) Config(channel string) (*discovery.ConfigResult, error) {
 	return args.Get(0).(*discovery.ConfigResult), args.Error(1)
 }
 
+func (ms *mockSupport) ConfigUpdateImpact(channel string, configUpdate *common.ConfigUpdate, peersByOrg map[string]*discovery.Peers) (*discovery.ConfigUpdateImpactResult, error) {
+	args := ms.Called(channel, configUpdate, peersByOrg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.ConfigUpdateImpactResult), args.Error(1)
+}
+
 func idInfo(id int, org string) api.PeerIdentityInfo {
 	endpoint := fmt.Sprintf("p%d", id)
 	return api.PeerIdentityInfo{
@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/protos/discovery"
+)
+
+// requestSnapshot is a view of the membership and the channel configurations
+// that is shared by all queries of a request, so that they are answered
+// consistently and the view isn't re-read for every query.
+// Each part of the view is read lazily, at most once per request.
+type requestSnapshot struct {
+	sup Support
+
+	peersOnce sync.Once
+	peers     discovery2.Members
+
+	identitiesOnce sync.Once
+	identities     api.PeerIdentitySet
+
+	lock     sync.Mutex
+	channels map[string]*channelSnapshot
+}
+
+// channelSnapshot is the part of a requestSnapshot that is channel scoped
+type channelSnapshot struct {
+	peersOnce sync.Once
+	peers     discovery2.Members
+
+	configOnce sync.Once
+	config     *discovery.ConfigResult
+	configErr  error
+}
+
+func newRequestSnapshot(sup Support) *requestSnapshot {
+	return &requestSnapshot{
+		sup:      sup,
+		channels: make(map[string]*channelSnapshot),
+	}
+}
+
+// Peers returns the alive membership
+func (rs *requestSnapshot) Peers() discovery2.Members {
+	rs.peersOnce.Do(func() {
+		rs.peers = rs.sup.Peers()
+	})
+	return rs.peers
+}
+
+// IdentityInfo returns the identities of the known peers
+func (rs *requestSnapshot) IdentityInfo() api.PeerIdentitySet {
+	rs.identitiesOnce.Do(func() {
+		rs.identities = rs.sup.IdentityInfo()
+	})
+	return rs.identities
+}
+
+// PeersOfChannel returns the peers of the given channel
+func (rs *requestSnapshot) PeersOfChannel(channel string) discovery2.Members {
+	cs := rs.channel(channel)
+	cs.peersOnce.Do(func() {
+		cs.peers = rs.sup.PeersOfChannel(common2.ChainID(channel))
+	})
+	return cs.peers
+}
+
+// Config returns the config of the given channel
+func (rs *requestSnapshot) Config(channel string) (*discovery.ConfigResult, error) {
+	cs := rs.channel(channel)
+	cs.configOnce.Do(func() {
+		cs.config, cs.configErr = rs.sup.Config(channel)
+	})
+	return cs.config, cs.configErr
+}
+
+func (rs *requestSnapshot) channel(channel string) *channelSnapshot {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	cs, exists := rs.channels[channel]
+	if !exists {
+		cs = &channelSnapshot{}
+		rs.channels[channel] = cs
+	}
+	return cs
+}
+
+// forEachQuery invokes f on the indices of n queries, with
+// at most the configured query concurrency invocations in parallel
+func (s *service) forEachQuery(n int, f func(i int)) {
+	if s.config.QueryConcurrency < 2 || n < 2 {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	semaphore := make(chan struct{}, s.config.QueryConcurrency)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	discovery2 "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func TestSharedSnapshotPerRequest(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		mockSup := &mockSupport{}
+		mockSup.On("ChannelExists", mock.Anything).Return(true)
+		mockSup.On("EligibleForService", mock.Anything, mock.Anything).Return(nil)
+		mockSup.On("Config", "mychannel").Return(&discovery.ConfigResult{
+			Msps: map[string]*msp.FabricMSPConfig{"Org1MSP": {Name: "Org1MSP"}},
+		}, nil)
+		mockSup.On("PeersOfChannel", common2.ChainID("mychannel")).Return(discovery2.Members{
+			stateInfoMsg(0), stateInfoMsg(1),
+		})
+		mockSup.On("PeersOfChannel", common2.ChainID("yourchannel")).Return(discovery2.Members{
+			stateInfoMsg(1),
+		})
+		mockSup.On("Peers").Return(discovery2.Members{
+			aliveMsg(0), aliveMsg(1),
+		})
+		mockSup.On("IdentityInfo").Return(api.PeerIdentitySet{
+			idInfo(0, "O1"), idInfo(1, "O2"),
+		})
+		service := NewService(Config{QueryConcurrency: concurrency}, mockSup)
+
+		configQuery := func(channel string) *discovery.Query {
+			return &discovery.Query{
+				Channel: channel,
+				Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+			}
+		}
+		peersQuery := func(channel string) *discovery.Query {
+			return &discovery.Query{
+				Channel: channel,
+				Query:   &discovery.Query_PeerQuery{PeerQuery: &discovery.PeerMembershipQuery{}},
+			}
+		}
+		req := &discovery.Request{
+			Authentication: &discovery.AuthInfo{
+				ClientIdentity: []byte{1, 2, 3},
+			},
+			Queries: []*discovery.Query{
+				configQuery("mychannel"), peersQuery("mychannel"), peersQuery("yourchannel"),
+				configQuery("mychannel"), peersQuery("mychannel"),
+			},
+		}
+		resp, err := service.Discover(context.Background(), toSignedRequest(req))
+		assert.NoError(t, err)
+		assert.Len(t, resp.Results, 5)
+
+		// The results are in the order of the queries
+		countPeers := func(res *discovery.QueryResult) int {
+			var n int
+			for _, peers := range res.GetMembers().PeersByOrg {
+				n += len(peers.Peers)
+			}
+			return n
+		}
+		assert.Contains(t, resp.Results[0].GetConfigResult().Msps, "Org1MSP")
+		assert.Equal(t, 2, countPeers(resp.Results[1]))
+		assert.Equal(t, 1, countPeers(resp.Results[2]))
+		assert.Contains(t, resp.Results[3].GetConfigResult().Msps, "Org1MSP")
+		assert.Equal(t, 2, countPeers(resp.Results[4]))
+
+		// The membership and configuration were read once per request
+		mockSup.AssertNumberOfCalls(t, "Config", 1)
+		mockSup.AssertNumberOfCalls(t, "Peers", 1)
+		mockSup.AssertNumberOfCalls(t, "IdentityInfo", 1)
+		mockSup.AssertNumberOfCalls(t, "PeersOfChannel", 2)
+
+		// A subsequent request reads them again
+		_, err = service.Discover(context.Background(), toSignedRequest(req))
+		assert.NoError(t, err)
+		mockSup.AssertNumberOfCalls(t, "Config", 2)
+		mockSup.AssertNumberOfCalls(t, "Peers", 2)
+	}
+}
+
+func TestForEachQueryBounded(t *testing.T) {
+	s := &service{config: Config{QueryConcurrency: 3}}
+	var inFlight, maxInFlight int32
+	block := make(chan struct{})
+	done := make(chan struct{})
+	visited := make([]bool, 10)
+	go func() {
+		s.forEachQuery(len(visited), func(i int) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			<-block
+			visited[i] = true
+			atomic.AddInt32(&inFlight, -1)
+		})
+		close(done)
+	}()
+	// Wait for the maximum number of queries to be in flight, and then release them one at a time
+	for atomic.LoadInt32(&inFlight) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	for range visited {
+		block <- struct{}{}
+	}
+	<-done
+	assert.Equal(t, int32(3), atomic.LoadInt32(&maxInFlight))
+	for i := range visited {
+		assert.True(t, visited[i])
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/protos/discovery"
+)
+
+var (
+	// queryDurationBuckets are the upper bounds (in seconds) of the buckets of the query duration histogram
+	queryDurationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+	// descriptorSizeBuckets are the upper bounds (in bytes) of the buckets of the descriptor size histogram
+	descriptorSizeBuckets = []float64{1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20}
+)
+
+// QueryStats are the statistics of queries of a certain type
+type QueryStats struct {
+	Count          uint64        `json:"count"`
+	Failures       uint64        `json:"failures"`
+	AverageLatency time.Duration `json:"average_latency"`
+	MaxLatency     time.Duration `json:"max_latency"`
+}
+
+// Stats are the statistics of the discovery service since it was started
+type Stats struct {
+	// Queries are the statistics of the queries, by query type
+	Queries map[string]QueryStats `json:"queries"`
+	// AuthFailures is the number of queries denied since their client isn't eligible for the service
+	AuthFailures uint64 `json:"auth_failures"`
+	// AuthCacheHits and AuthCacheMisses are the numbers of authentication cache lookups
+	// that found and didn't find the eligibility of a client
+	AuthCacheHits    uint64  `json:"auth_cache_hits"`
+	AuthCacheMisses  uint64  `json:"auth_cache_misses"`
+	AuthCacheHitRate float64 `json:"auth_cache_hit_rate"`
+	// Descriptors is the number of endorsement descriptors returned,
+	// and AverageDescriptorSize is their average size in bytes
+	Descriptors           uint64 `json:"descriptors"`
+	AverageDescriptorSize uint64 `json:"average_descriptor_size"`
+}
+
+// serviceMetrics reports the statistics of the discovery service to the metrics system
+type serviceMetrics struct {
+	scope           metrics.Scope
+	authFailures    metrics.Counter
+	authCacheHits   metrics.Counter
+	authCacheMisses metrics.Counter
+	descriptorSize  metrics.Histogram
+}
+
+// newServiceMetrics creates the metrics of the discovery service,
+// or returns nil if metrics aren't initialized
+func newServiceMetrics() *serviceMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("discovery")
+	return &serviceMetrics{
+		scope:           scope,
+		authFailures:    scope.Counter("auth_failures"),
+		authCacheHits:   scope.Counter("auth_cache_hits"),
+		authCacheMisses: scope.Counter("auth_cache_misses"),
+		descriptorSize:  scope.Histogram("endorsement_descriptor_size_bytes", descriptorSizeBuckets),
+	}
+}
+
+type queryStats struct {
+	count        uint64
+	failures     uint64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// serviceStats accumulates the statistics of the discovery service,
+// and reports them to the metrics system if metrics are initialized.
+// A nil serviceStats records nothing
+type serviceStats struct {
+	lock            sync.Mutex
+	queries         map[discovery.QueryType]*queryStats
+	authFailures    uint64
+	authCacheHits   uint64
+	authCacheMisses uint64
+	descriptors     uint64
+	descriptorBytes uint64
+	metrics         *serviceMetrics
+}
+
+func newServiceStats() *serviceStats {
+	return &serviceStats{
+		queries: make(map[discovery.QueryType]*queryStats),
+		metrics: newServiceMetrics(),
+	}
+}
+
+// queryProcessed records a query of the given type that was processed in the given time
+func (s *serviceStats) queryProcessed(queryType discovery.QueryType, elapsed time.Duration, failed bool) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	qs, exists := s.queries[queryType]
+	if !exists {
+		qs = &queryStats{}
+		s.queries[queryType] = qs
+	}
+	qs.count++
+	if failed {
+		qs.failures++
+	}
+	qs.totalLatency += elapsed
+	if elapsed > qs.maxLatency {
+		qs.maxLatency = elapsed
+	}
+	s.lock.Unlock()
+
+	if s.metrics == nil {
+		return
+	}
+	scope := s.metrics.scope.Tagged(map[string]string{"type": queryTypeNames[queryType]})
+	scope.Counter("queries").Inc(1)
+	if failed {
+		scope.Counter("query_failures").Inc(1)
+	}
+	scope.Histogram("query_duration_seconds", queryDurationBuckets).RecordValue(elapsed.Seconds())
+}
+
+// authFailed records a query that was denied
+func (s *serviceStats) authFailed() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	s.authFailures++
+	s.lock.Unlock()
+	if s.metrics != nil {
+		s.metrics.authFailures.Inc(1)
+	}
+}
+
+// authCacheLookup records a lookup in the authentication cache
+func (s *serviceStats) authCacheLookup(hit bool) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if hit {
+		s.authCacheHits++
+	} else {
+		s.authCacheMisses++
+	}
+	s.lock.Unlock()
+	if s.metrics == nil {
+		return
+	}
+	if hit {
+		s.metrics.authCacheHits.Inc(1)
+	} else {
+		s.metrics.authCacheMisses.Inc(1)
+	}
+}
+
+// descriptorsReturned records the endorsement descriptors returned for a query
+func (s *serviceStats) descriptorsReturned(descriptors []*discovery.EndorsementDescriptor) {
+	if s == nil {
+		return
+	}
+	sizes := make([]int, len(descriptors))
+	for i, desc := range descriptors {
+		sizes[i] = proto.Size(desc)
+	}
+	s.lock.Lock()
+	for _, size := range sizes {
+		s.descriptors++
+		s.descriptorBytes += uint64(size)
+	}
+	s.lock.Unlock()
+	if s.metrics == nil {
+		return
+	}
+	for _, size := range sizes {
+		s.metrics.descriptorSize.RecordValue(float64(size))
+	}
+}
+
+// snapshot returns the statistics accumulated so far
+func (s *serviceStats) snapshot() Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stats := Stats{
+		Queries:         make(map[string]QueryStats, len(s.queries)),
+		AuthFailures:    s.authFailures,
+		AuthCacheHits:   s.authCacheHits,
+		AuthCacheMisses: s.authCacheMisses,
+		Descriptors:     s.descriptors,
+	}
+	for queryType, qs := range s.queries {
+		stats.Queries[queryTypeNames[queryType]] = QueryStats{
+			Count:          qs.count,
+			Failures:       qs.failures,
+			AverageLatency: qs.totalLatency / time.Duration(qs.count),
+			MaxLatency:     qs.maxLatency,
+		}
+	}
+	if lookups := s.authCacheHits + s.authCacheMisses; lookups > 0 {
+		stats.AuthCacheHitRate = float64(s.authCacheHits) / float64(lookups)
+	}
+	if s.descriptors > 0 {
+		stats.AverageDescriptorSize = s.descriptorBytes / s.descriptors
+	}
+	return stats
+}
+
+// Stats returns the statistics of the discovery service since it was started
+func (s *service) Stats() Stats {
+	return s.stats.snapshot()
+}
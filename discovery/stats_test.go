@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func TestServiceStats(t *testing.T) {
+	desc := &discovery.EndorsementDescriptor{Chaincode: "mycc"}
+	mockSup := &mockSupport{}
+	mockSup.On("ChannelExists", "mychannel").Return(true)
+	mockSup.On("ChannelExists", "yourchannel").Return(false)
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(nil).Once()
+	mockSup.On("EligibleForService", "mychannel", mock.Anything).Return(errors.New("not eligible"))
+	mockSup.On("Config", "mychannel").Return(&discovery.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{"Org1MSP": {Name: "Org1MSP"}},
+	}, nil)
+	mockSup.On("PeersForEndorsement", "mycc").Return(desc, nil)
+	service := NewService(Config{AuthCacheEnabled: true}, mockSup)
+
+	ccQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{
+				Interests: []*discovery.ChaincodeInterest{{Chaincodes: []*discovery.ChaincodeCall{{Name: "mycc"}}}},
+			},
+		},
+	}
+	configQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	}
+	nonExistentChannelQuery := &discovery.Query{
+		Channel: "yourchannel",
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	}
+	req := &discovery.Request{
+		Authentication: &discovery.AuthInfo{
+			ClientIdentity: []byte{1, 2, 3},
+		},
+		Queries: []*discovery.Query{ccQuery, configQuery, nonExistentChannelQuery},
+	}
+	// The first request is authenticated, and the second request is answered from the auth cache
+	_, err := service.Discover(context.Background(), toSignedRequest(req))
+	assert.NoError(t, err)
+	_, err = service.Discover(context.Background(), toSignedRequest(req))
+	assert.NoError(t, err)
+
+	stats := service.Stats()
+	assert.Equal(t, uint64(2), stats.Queries["endorsers"].Count)
+	assert.Equal(t, uint64(0), stats.Queries["endorsers"].Failures)
+	assert.Equal(t, uint64(4), stats.Queries["config"].Count)
+	assert.Equal(t, uint64(2), stats.Queries["config"].Failures)
+	assert.True(t, stats.Queries["config"].MaxLatency >= stats.Queries["config"].AverageLatency)
+	assert.Equal(t, uint64(3), stats.AuthCacheHits)
+	assert.Equal(t, uint64(1), stats.AuthCacheMisses)
+	assert.Equal(t, 0.75, stats.AuthCacheHitRate)
+	assert.Equal(t, uint64(0), stats.AuthFailures)
+	assert.Equal(t, uint64(2), stats.Descriptors)
+	assert.Equal(t, uint64(proto.Size(desc)), stats.AverageDescriptorSize)
+
+	// Without the auth cache, each query of the channel is authenticated,
+	// and queries of clients that aren't eligible are counted as auth failures
+	service = NewService(Config{}, mockSup)
+	_, err = service.Discover(context.Background(), toSignedRequest(req))
+	assert.NoError(t, err)
+	stats = service.Stats()
+	assert.Equal(t, uint64(2), stats.AuthFailures)
+	assert.Equal(t, uint64(1), stats.Queries["endorsers"].Failures)
+	assert.Equal(t, uint64(2), stats.Queries["config"].Failures)
+	assert.Zero(t, stats.AuthCacheHitRate)
+}
+
+func TestServiceStatsLatency(t *testing.T) {
+	stats := newServiceStats()
+	stats.queryProcessed(discovery.PeerMembershipQueryType, time.Second, false)
+	stats.queryProcessed(discovery.PeerMembershipQueryType, 3*time.Second, true)
+	snapshot := stats.snapshot()
+	assert.Equal(t, QueryStats{
+		Count:          2,
+		Failures:       1,
+		AverageLatency: 2 * time.Second,
+		MaxLatency:     3 * time.Second,
+	}, snapshot.Queries["peers"])
+
+	// A nil serviceStats records nothing
+	var nilStats *serviceStats
+	nilStats.queryProcessed(discovery.PeerMembershipQueryType, time.Second, false)
+	nilStats.authFailed()
+	nilStats.authCacheLookup(true)
+	nilStats.descriptorsReturned([]*discovery.EndorsementDescriptor{{}})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package acl
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/msp"
+)
+
+const defaultPseudonymCacheSize = 1000
+
+// verifiedPseudonym is an anonymous identity that was found eligible
+// for service in a certain config sequence of a channel
+type verifiedPseudonym struct {
+	identity msp.Identity
+	sequence uint64
+}
+
+// pseudonymCache caches anonymous identities, such as idemix pseudonyms,
+// that were found eligible for service in a channel.
+// Since signatures of anonymous identities are randomized, requests of the same client
+// never have the same signed data, so the eligibility of the identity is cached instead,
+// and subsequent requests only need their signatures to be verified by the cached identity.
+type pseudonymCache struct {
+	sync.RWMutex
+	maxSize int
+	entries map[string]verifiedPseudonym
+}
+
+func newPseudonymCache(maxSize int) *pseudonymCache {
+	return &pseudonymCache{
+		maxSize: maxSize,
+		entries: make(map[string]verifiedPseudonym),
+	}
+}
+
+func pseudonymKey(channel string, identity []byte) string {
+	return channel + "\x00" + string(identity)
+}
+
+// lookup returns the cached identity of the given serialized identity in the given channel,
+// or nil if it isn't cached or was cached in a config sequence other than the given one
+func (c *pseudonymCache) lookup(channel string, identity []byte, sequence uint64) msp.Identity {
+	c.RLock()
+	defer c.RUnlock()
+	entry, exists := c.entries[pseudonymKey(channel, identity)]
+	if !exists || entry.sequence != sequence {
+		return nil
+	}
+	return entry.identity
+}
+
+// add caches the given identity as eligible in the given config sequence of the given channel
+func (c *pseudonymCache) add(channel string, identity []byte, sequence uint64, id msp.Identity) {
+	c.Lock()
+	defer c.Unlock()
+	key := pseudonymKey(channel, identity)
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxSize {
+		// Evict an arbitrary entry to make room for the new one
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = verifiedPseudonym{identity: id, sequence: sequence}
+}
+
+// size returns the number of cached identities
+func (c *pseudonymCache) size() int {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.entries)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package acl
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubIdentity is an identity that is told apart from other identities only by its name.
+// The mocks of the discovery support can't be used, as they import this package
+type stubIdentity struct {
+	msp.Identity
+	name string
+}
+
+func TestPseudonymCache(t *testing.T) {
+	c := newPseudonymCache(2)
+	id1, id2, id3 := &stubIdentity{name: "id1"}, &stubIdentity{name: "id2"}, &stubIdentity{name: "id3"}
+
+	assert.Nil(t, c.lookup("mychannel", []byte("id1"), 1))
+	c.add("mychannel", []byte("id1"), 1, id1)
+	assert.Equal(t, id1, c.lookup("mychannel", []byte("id1"), 1))
+	// Lookups of other sequences or channels miss
+	assert.Nil(t, c.lookup("mychannel", []byte("id1"), 2))
+	assert.Nil(t, c.lookup("yourchannel", []byte("id1"), 1))
+
+	// Re-adding an identity updates its sequence without growing the cache
+	c.add("mychannel", []byte("id1"), 2, id1)
+	assert.Equal(t, 1, c.size())
+	assert.Equal(t, id1, c.lookup("mychannel", []byte("id1"), 2))
+
+	// The cache never exceeds its maximum size
+	c.add("mychannel", []byte("id2"), 2, id2)
+	c.add("mychannel", []byte("id3"), 2, id3)
+	assert.Equal(t, 2, c.size())
+	assert.Equal(t, id3, c.lookup("mychannel", []byte("id3"), 2))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	msp2 "github.com/hyperledger/fabric/msp"
 	common2 "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
@@ -57,20 +58,63 @@ type DiscoverySupport struct {
 	ChannelConfigGetter
 	Verifier
 	Evaluator
+	pseudonyms *pseudonymCache
 }
 
 // NewDiscoverySupport creates a new DiscoverySupport
 func NewDiscoverySupport(v Verifier, e Evaluator, chanConf ChannelConfigGetter) *DiscoverySupport {
-	return &DiscoverySupport{Verifier: v, Evaluator: e, ChannelConfigGetter: chanConf}
+	return &DiscoverySupport{
+		Verifier:            v,
+		Evaluator:           e,
+		ChannelConfigGetter: chanConf,
+		pseudonyms:          newPseudonymCache(defaultPseudonymCacheSize),
+	}
 }
 
 // Eligible returns whether the given peer is eligible for receiving
-// service from the discovery service for a given channel
+// service from the discovery service for a given channel.
+// Anonymous identities, such as idemix pseudonyms, that were found eligible are cached,
+// so that subsequent requests signed by them only have their signatures verified.
 func (s *DiscoverySupport) EligibleForService(channel string, data common2.SignedData) error {
 	if channel == "" {
 		return s.Evaluate([]*common2.SignedData{&data})
 	}
-	return s.VerifyByChannel(common.ChainID(channel), api.PeerIdentityType(data.Identity), data.Signature, data.Data)
+	mspMgr, sequence, ok := s.channelMSPs(channel)
+	if ok {
+		if id := s.pseudonyms.lookup(channel, data.Identity, sequence); id != nil {
+			return errors.Wrap(id.Verify(data.Data, data.Signature), "failed verifying signature")
+		}
+	}
+	if err := s.VerifyByChannel(common.ChainID(channel), api.PeerIdentityType(data.Identity), data.Signature, data.Data); err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	id, err := mspMgr.DeserializeIdentity(data.Identity)
+	if err != nil {
+		logger.Warningf("Failed deserializing identity eligible for service in channel %s: %v", channel, err)
+		return nil
+	}
+	if id.Anonymous() {
+		s.pseudonyms.add(channel, data.Identity, sequence, id)
+	}
+	return nil
+}
+
+// channelMSPs returns the MSP manager and the config sequence of the given channel,
+// and whether they were found
+func (s *DiscoverySupport) channelMSPs(channel string) (msp2.MSPManager, uint64, bool) {
+	conf := s.GetChannelConfig(channel)
+	if conf == nil {
+		return nil, 0, false
+	}
+	mspMgr := conf.MSPManager()
+	validator := conf.ConfigtxValidator()
+	if mspMgr == nil || validator == nil {
+		return nil, 0, false
+	}
+	return mspMgr, validator.Sequence(), true
 }
 
 // ConfigSequence returns the configuration sequence of the given channel
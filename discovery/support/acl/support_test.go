@@ -182,6 +182,58 @@ func TestEligibleForService(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestEligibleForServiceAnonymousIdentity(t *testing.T) {
+	v := &mocks.Verifier{}
+	chConfig := &mocks.ChanConfig{}
+	resources := &mocks.Resources{}
+	validator := &mocks.ConfigtxValidator{}
+	mgr := &mocks.MSPManager{}
+	pseudonym := &mocks.Identity{}
+	x509Identity := &mocks.Identity{}
+	chConfig.GetChannelConfigReturns(resources)
+	resources.MSPManagerReturns(mgr)
+	resources.ConfigtxValidatorReturns(validator)
+	validator.SequenceReturns(1)
+	pseudonym.AnonymousReturns(true)
+	x509Identity.AnonymousReturns(false)
+	sup := acl.NewDiscoverySupport(v, &mocks.Evaluator{}, chConfig)
+
+	anonymousRequest := common2.SignedData{Identity: []byte("pseudonym"), Data: []byte("data"), Signature: []byte("sig")}
+	x509Request := common2.SignedData{Identity: []byte("x509"), Data: []byte("data"), Signature: []byte("sig")}
+
+	// Scenario I: The anonymous identity is verified by the channel once, and then cached
+	mgr.DeserializeIdentityReturns(pseudonym, nil)
+	assert.NoError(t, sup.EligibleForService("mychannel", anonymousRequest))
+	assert.NoError(t, sup.EligibleForService("mychannel", anonymousRequest))
+	assert.Equal(t, 1, v.VerifyByChannelCallCount())
+	assert.Equal(t, 1, pseudonym.VerifyCallCount())
+
+	// Scenario II: A bad signature of a cached identity is rejected
+	pseudonym.VerifyReturns(errors.New("bad signature"))
+	err := sup.EligibleForService("mychannel", anonymousRequest)
+	assert.EqualError(t, err, "failed verifying signature: bad signature")
+	assert.Equal(t, 1, v.VerifyByChannelCallCount())
+
+	// Scenario III: The identity is cached per channel
+	assert.NoError(t, sup.EligibleForService("yourchannel", anonymousRequest))
+	assert.Equal(t, 2, v.VerifyByChannelCallCount())
+
+	// Scenario IV: A config update invalidates the cached identity
+	validator.SequenceReturns(2)
+	v.VerifyByChannelReturns(errors.New("identity revoked"))
+	err = sup.EligibleForService("mychannel", anonymousRequest)
+	assert.EqualError(t, err, "identity revoked")
+	assert.Equal(t, 3, v.VerifyByChannelCallCount())
+
+	// Scenario V: Non anonymous identities are never cached
+	v.VerifyByChannelReturns(nil)
+	mgr.DeserializeIdentityReturns(x509Identity, nil)
+	assert.NoError(t, sup.EligibleForService("mychannel", x509Request))
+	assert.NoError(t, sup.EligibleForService("mychannel", x509Request))
+	assert.Equal(t, 5, v.VerifyByChannelCallCount())
+	assert.Equal(t, 0, x509Identity.VerifyCallCount())
+}
+
 func TestSatisfiesPrincipal(t *testing.T) {
 	var (
 		chConfig                      = &mocks.ChanConfig{}
@@ -13,24 +13,47 @@ import (
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
 	common2 "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 )
 
+// validationParameterKey is the metadata key under which the
+// key-level endorsement policy of a key is stored in the ledger
+const validationParameterKey = "VALIDATION_PARAMETER"
+
 var logger = flogging.MustGetLogger("discovery/DiscoverySupport")
 
 type MetadataRetriever interface {
 	Metadata(channel string, cc string, loadCollections bool) *chaincode.Metadata
 }
 
+// StateMetadataRetriever retrieves the metadata of keys from the ledger
+type StateMetadataRetriever interface {
+	// StateMetadata returns the metadata of the given key of the given chaincode
+	// in the ledger of the given channel
+	StateMetadata(channel string, cc string, key string) (map[string][]byte, error)
+}
+
+// StateMetadataRetrieverFunc retrieves the metadata of keys from the ledger
+type StateMetadataRetrieverFunc func(channel string, cc string, key string) (map[string][]byte, error)
+
+// StateMetadata returns the metadata of the given key of the given chaincode
+// in the ledger of the given channel
+func (f StateMetadataRetrieverFunc) StateMetadata(channel string, cc string, key string) (map[string][]byte, error) {
+	return f(channel, cc, key)
+}
+
 // DiscoverySupport implements support that is used for service discovery
 // that is related to chaincode
 type DiscoverySupport struct {
-	ci MetadataRetriever
+	ci  MetadataRetriever
+	smr StateMetadataRetriever
 }
 
 // NewDiscoverySupport creates a new DiscoverySupport
-func NewDiscoverySupport(ci MetadataRetriever) *DiscoverySupport {
+func NewDiscoverySupport(ci MetadataRetriever, smr StateMetadataRetriever) *DiscoverySupport {
 	s := &DiscoverySupport{
-		ci: ci,
+		ci:  ci,
+		smr: smr,
 	}
 	return s
 }
@@ -41,8 +64,28 @@ func (s *DiscoverySupport) PolicyByChaincode(channel string, cc string) policies
 		logger.Info("Chaincode", cc, "wasn't found")
 		return nil
 	}
+	return inquireablePolicy(cc, chaincodeData.Policy)
+}
+
+// SubPolicyByChaincode returns the sub-policy of the given name of the given chaincode,
+// or nil if the chaincode wasn't found or has no such sub-policy
+func (s *DiscoverySupport) SubPolicyByChaincode(channel string, cc string, name string) policies.InquireablePolicy {
+	chaincodeData := s.ci.Metadata(channel, cc, false)
+	if chaincodeData == nil {
+		logger.Info("Chaincode", cc, "wasn't found")
+		return nil
+	}
+	policy, exists := chaincodeData.SubPolicies[name]
+	if !exists {
+		logger.Debug("Chaincode", cc, "has no sub-policy", name)
+		return nil
+	}
+	return inquireablePolicy(cc, policy)
+}
+
+func inquireablePolicy(cc string, policy []byte) policies.InquireablePolicy {
 	pol := &common2.SignaturePolicyEnvelope{}
-	if err := proto.Unmarshal(chaincodeData.Policy, pol); err != nil {
+	if err := proto.Unmarshal(policy, pol); err != nil {
 		logger.Warning("Failed unmarshaling policy for chaincode", cc, ":", err)
 		return nil
 	}
@@ -52,3 +95,21 @@ func (s *DiscoverySupport) PolicyByChaincode(channel string, cc string) policies
 	}
 	return inquire.NewInquireableSignaturePolicy(pol)
 }
+
+// PolicyOfKey returns the key-level endorsement policy of the given key of the given chaincode,
+// or nil if the key has no key-level endorsement policy
+func (s *DiscoverySupport) PolicyOfKey(channel string, cc string, key string) (*common2.SignaturePolicyEnvelope, error) {
+	md, err := s.smr.StateMetadata(channel, cc, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed retrieving metadata of key %s", key)
+	}
+	vp := md[validationParameterKey]
+	if len(vp) == 0 {
+		return nil, nil
+	}
+	pol := &common2.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(vp, pol); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling key-level endorsement policy of key %s", key)
+	}
+	return pol, nil
+}
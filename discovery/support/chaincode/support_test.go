@@ -10,9 +10,12 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,7 +66,7 @@ func TestSupport(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			sup := NewDiscoverySupport(&mockMetadataRetriever{res: test.input})
+			sup := NewDiscoverySupport(&mockMetadataRetriever{res: test.input}, nil)
 			res := sup.PolicyByChaincode("", "")
 			if test.shouldBeNil {
 				assert.Nil(t, res)
@@ -73,3 +76,67 @@ func TestSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestSubPolicyByChaincode(t *testing.T) {
+	transferPolicy := cauthdsl.SignedByMspPeer("Org1MSP")
+	md := &chaincode.Metadata{
+		Policy: utils.MarshalOrPanic(cauthdsl.SignedByMspPeer("Org2MSP")),
+		SubPolicies: map[string][]byte{
+			"transfer": utils.MarshalOrPanic(transferPolicy),
+			"read":     {1, 2, 3},
+		},
+	}
+	sup := NewDiscoverySupport(&mockMetadataRetriever{res: md}, nil)
+
+	// Scenario I: The sub-policy exists
+	res := sup.SubPolicyByChaincode("mychannel", "cc", "transfer")
+	assert.NotNil(t, res)
+	principalSets := res.SatisfiedBy()
+	assert.Len(t, principalSets, 1)
+	assert.Equal(t, transferPolicy.Identities[0], principalSets[0][0])
+
+	// Scenario II: The sub-policy doesn't exist
+	assert.Nil(t, sup.SubPolicyByChaincode("mychannel", "cc", "issue"))
+
+	// Scenario III: The sub-policy is malformed
+	assert.Nil(t, sup.SubPolicyByChaincode("mychannel", "cc", "read"))
+
+	// Scenario IV: The chaincode wasn't found
+	sup = NewDiscoverySupport(&mockMetadataRetriever{}, nil)
+	assert.Nil(t, sup.SubPolicyByChaincode("mychannel", "cc", "transfer"))
+}
+
+func TestPolicyOfKey(t *testing.T) {
+	keyPolicy := cauthdsl.SignedByMspPeer("Org1MSP")
+	metadata := map[string]map[string][]byte{
+		"k1": {validationParameterKey: utils.MarshalOrPanic(keyPolicy)},
+		"k2": {},
+		"k3": {validationParameterKey: []byte{1, 2, 3}},
+	}
+	sup := NewDiscoverySupport(&mockMetadataRetriever{}, StateMetadataRetrieverFunc(func(channel string, cc string, key string) (map[string][]byte, error) {
+		if key == "k4" {
+			return nil, errors.New("ledger unavailable")
+		}
+		return metadata[key], nil
+	}))
+
+	// Scenario I: The key has a key-level endorsement policy
+	res, err := sup.PolicyOfKey("mychannel", "cc", "k1")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(keyPolicy, res))
+
+	// Scenario II: The key has no key-level endorsement policy
+	res, err = sup.PolicyOfKey("mychannel", "cc", "k2")
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	// Scenario III: The key-level endorsement policy is malformed
+	res, err = sup.PolicyOfKey("mychannel", "cc", "k3")
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "failed unmarshaling key-level endorsement policy of key k3")
+
+	// Scenario IV: The metadata of the key can't be retrieved
+	res, err = sup.PolicyOfKey("mychannel", "cc", "k4")
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "failed retrieving metadata of key k4: ledger unavailable")
+}
@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// ConfigUpdateImpact reports how the given config update would change the configuration
+// of the given channel, and which of the given peers of the channel it would eject.
+// The update doesn't need to be signed, as the modification policies aren't evaluated.
+func (s *DiscoverySupport) ConfigUpdateImpact(channel string, configUpdate *common.ConfigUpdate, peersByOrg map[string]*discovery.Peers) (*discovery.ConfigUpdateImpactResult, error) {
+	ce, err := s.configEnvelope(channel)
+	if err != nil {
+		return nil, err
+	}
+	current, err := channelconfig.NewBundle(channel, ce.Config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating bundle of the current config")
+	}
+
+	proposedConfig, err := configtx.ApplyConfigUpdate(channel, ce.Config, channelconfig.RootGroupKey, configUpdate)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed applying config update")
+	}
+	proposed, err := channelconfig.NewBundle(channel, proposedConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "config update results in an invalid config")
+	}
+	if err := current.ValidateNew(proposed); err != nil {
+		return nil, errors.WithMessage(err, "config update results in an invalid config")
+	}
+
+	res := &discovery.ConfigUpdateImpactResult{
+		PolicyChanges: policyChanges(policies.PathSeparator+channelconfig.RootGroupKey, ce.Config.ChannelGroup, proposedConfig.ChannelGroup, nil),
+	}
+	sort.Slice(res.PolicyChanges, func(i, j int) bool {
+		return res.PolicyChanges[i].Path < res.PolicyChanges[j].Path
+	})
+
+	currentMSPs, err := current.MSPManager().GetMSPs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed getting MSPs of the current config")
+	}
+	proposedMSPs, err := proposed.MSPManager().GetMSPs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed getting MSPs of the proposed config")
+	}
+	for mspID := range proposedMSPs {
+		if _, exists := currentMSPs[mspID]; !exists {
+			res.AddedMsps = append(res.AddedMsps, mspID)
+		}
+	}
+	for mspID := range currentMSPs {
+		if _, exists := proposedMSPs[mspID]; !exists {
+			res.RemovedMsps = append(res.RemovedMsps, mspID)
+		}
+	}
+	sort.Strings(res.AddedMsps)
+	sort.Strings(res.RemovedMsps)
+
+	res.EjectedPeers = ejectedPeers(proposed, peersByOrg)
+	return res, nil
+}
+
+// policyChanges appends to changes the policies of the group at the given path,
+// and of its sub-groups, that differ between the current and the proposed config.
+// A group that is absent from one of the configs is passed as nil.
+func policyChanges(path string, current, proposed *common.ConfigGroup, changes []*discovery.PolicyChange) []*discovery.PolicyChange {
+	for name, currentPolicy := range current.GetPolicies() {
+		proposedPolicy, exists := proposed.GetPolicies()[name]
+		if !exists {
+			changes = append(changes, &discovery.PolicyChange{
+				Path: path + policies.PathSeparator + name,
+				Type: discovery.PolicyChange_REMOVED,
+			})
+			continue
+		}
+		if currentPolicy.ModPolicy != proposedPolicy.ModPolicy || !proto.Equal(currentPolicy.Policy, proposedPolicy.Policy) {
+			changes = append(changes, &discovery.PolicyChange{
+				Path: path + policies.PathSeparator + name,
+				Type: discovery.PolicyChange_MODIFIED,
+			})
+		}
+	}
+	for name := range proposed.GetPolicies() {
+		if _, exists := current.GetPolicies()[name]; !exists {
+			changes = append(changes, &discovery.PolicyChange{
+				Path: path + policies.PathSeparator + name,
+				Type: discovery.PolicyChange_ADDED,
+			})
+		}
+	}
+
+	for name, currentGroup := range current.GetGroups() {
+		changes = policyChanges(path+policies.PathSeparator+name, currentGroup, proposed.GetGroups()[name], changes)
+	}
+	for name, proposedGroup := range proposed.GetGroups() {
+		if _, exists := current.GetGroups()[name]; !exists {
+			changes = policyChanges(path+policies.PathSeparator+name, nil, proposedGroup, changes)
+		}
+	}
+	return changes
+}
+
+// ejectedPeers returns the given peers which wouldn't be members
+// of the channel under the proposed config, ordered by organization
+func ejectedPeers(proposed channelconfig.Resources, peersByOrg map[string]*discovery.Peers) []*discovery.EjectedPeer {
+	appOrgs := make(map[string]struct{})
+	if ac, exists := proposed.ApplicationConfig(); exists {
+		for _, org := range ac.Organizations() {
+			appOrgs[org.MSPID()] = struct{}{}
+		}
+	}
+
+	var orgs []string
+	for org := range peersByOrg {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	var res []*discovery.EjectedPeer
+	for _, org := range orgs {
+		for _, peer := range peersByOrg[org].GetPeers() {
+			reason := ejectionReason(proposed, appOrgs, peer.Identity)
+			if reason == "" {
+				continue
+			}
+			res = append(res, &discovery.EjectedPeer{
+				Peer:   peer,
+				Reason: reason,
+			})
+		}
+	}
+	return res
+}
+
+// ejectionReason returns why the peer with the given identity wouldn't be a member of the
+// channel under the proposed config, or an empty string if it would remain a member
+func ejectionReason(proposed channelconfig.Resources, appOrgs map[string]struct{}, identity []byte) string {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sID); err != nil {
+		return fmt.Sprintf("identity is malformed: %v", err)
+	}
+	if _, exists := appOrgs[sID.Mspid]; !exists {
+		return fmt.Sprintf("%s is not an application organization of the channel", sID.Mspid)
+	}
+	id, err := proposed.MSPManager().DeserializeIdentity(identity)
+	if err != nil {
+		return fmt.Sprintf("identity can't be deserialized: %v", err)
+	}
+	if err := id.Validate(); err != nil {
+		return fmt.Sprintf("identity is invalid: %v", err)
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/discovery/support/config"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigUpdateImpact(t *testing.T) {
+	block, err := test.MakeGenesisBlock("mychannel")
+	assert.NoError(t, err)
+	original, err := configFromBlock(block)
+	assert.NoError(t, err)
+	cs := config.NewDiscoverySupport(config.CurrentConfigBlockGetterFunc(func(channel string) *common.Block {
+		if channel != "mychannel" {
+			return nil
+		}
+		return block
+	}))
+
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+	cert, err := ioutil.ReadFile(filepath.Join(mspDir, "signcerts", "peer.pem"))
+	assert.NoError(t, err)
+	samplePeer := &discovery.Peer{
+		Identity: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: cert}),
+	}
+	strangerPeer := &discovery.Peer{
+		Identity: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org3MSP", IdBytes: cert}),
+	}
+	peersByOrg := map[string]*discovery.Peers{
+		"SampleOrg": {Peers: []*discovery.Peer{samplePeer}},
+		"Org3MSP":   {Peers: []*discovery.Peer{strangerPeer}},
+	}
+
+	appOrgs := func(config *common.Config) map[string]*common.ConfigGroup {
+		return config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups
+	}
+
+	t.Run("Organization added", func(t *testing.T) {
+		updated := proto.Clone(original).(*common.Config)
+		org2 := proto.Clone(appOrgs(updated)["SampleOrg"]).(*common.ConfigGroup)
+		setMSPName(t, org2, "Org2MSP")
+		appOrgs(updated)["Org2"] = org2
+
+		res, err := cs.ConfigUpdateImpact("mychannel", computeUpdate(t, original, updated), peersByOrg)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Org2MSP"}, res.AddedMsps)
+		assert.Empty(t, res.RemovedMsps)
+		for _, change := range res.PolicyChanges {
+			assert.Equal(t, discovery.PolicyChange_ADDED, change.Type)
+			assert.Contains(t, change.Path, "/Channel/Application/Org2/")
+		}
+		assert.Len(t, res.PolicyChanges, len(org2.Policies))
+		// The peer of the organization which isn't in the channel is the only one ejected
+		assert.Len(t, res.EjectedPeers, 1)
+		assert.Equal(t, strangerPeer, res.EjectedPeers[0].Peer)
+		assert.Equal(t, "Org3MSP is not an application organization of the channel", res.EjectedPeers[0].Reason)
+	})
+
+	t.Run("Organization removed", func(t *testing.T) {
+		updated := proto.Clone(original).(*common.Config)
+		delete(appOrgs(updated), "SampleOrg")
+
+		res, err := cs.ConfigUpdateImpact("mychannel", computeUpdate(t, original, updated), peersByOrg)
+		assert.NoError(t, err)
+		assert.Empty(t, res.AddedMsps)
+		// SampleOrg remains an orderer organization
+		assert.Empty(t, res.RemovedMsps)
+		assert.Equal(t, "/Channel/Application/SampleOrg/Admins", res.PolicyChanges[0].Path)
+		for _, change := range res.PolicyChanges {
+			assert.Equal(t, discovery.PolicyChange_REMOVED, change.Type)
+		}
+		assert.Len(t, res.EjectedPeers, 2)
+		assert.Equal(t, strangerPeer, res.EjectedPeers[0].Peer)
+		assert.Equal(t, samplePeer, res.EjectedPeers[1].Peer)
+		assert.Equal(t, "SampleOrg is not an application organization of the channel", res.EjectedPeers[1].Reason)
+	})
+
+	t.Run("Policy modified", func(t *testing.T) {
+		updated := proto.Clone(original).(*common.Config)
+		appOrgs(updated)["SampleOrg"].Policies["Writers"].ModPolicy = "Writers"
+
+		res, err := cs.ConfigUpdateImpact("mychannel", computeUpdate(t, original, updated), peersByOrg)
+		assert.NoError(t, err)
+		assert.Equal(t, []*discovery.PolicyChange{
+			{Path: "/Channel/Application/SampleOrg/Writers", Type: discovery.PolicyChange_MODIFIED},
+		}, res.PolicyChanges)
+		assert.Empty(t, res.AddedMsps)
+		assert.Empty(t, res.RemovedMsps)
+		assert.Len(t, res.EjectedPeers, 1)
+	})
+
+	t.Run("Update without effect", func(t *testing.T) {
+		configUpdate := &common.ConfigUpdate{
+			ChannelId: "mychannel",
+			ReadSet:   &common.ConfigGroup{},
+			WriteSet:  &common.ConfigGroup{},
+		}
+		_, err := cs.ConfigUpdateImpact("mychannel", configUpdate, peersByOrg)
+		assert.EqualError(t, err, "failed applying config update: error validating DeltaSet: delta set was empty -- update would have no effect")
+	})
+
+	t.Run("Invalid resulting config", func(t *testing.T) {
+		updated := proto.Clone(original).(*common.Config)
+		setMSPName(t, appOrgs(updated)["SampleOrg"], "")
+
+		_, err := cs.ConfigUpdateImpact("mychannel", computeUpdate(t, original, updated), peersByOrg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "config update results in an invalid config")
+	})
+
+	t.Run("Unknown channel", func(t *testing.T) {
+		_, err := cs.ConfigUpdateImpact("yourchannel", &common.ConfigUpdate{ChannelId: "yourchannel"}, peersByOrg)
+		assert.EqualError(t, err, "could not get last config block for channel yourchannel")
+	})
+}
+
+func configFromBlock(block *common.Block) (*common.Config, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.ExtractPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, err
+	}
+	return configEnv.Config, nil
+}
+
+func computeUpdate(t *testing.T, original, updated *common.Config) *common.ConfigUpdate {
+	configUpdate, err := update.Compute(original, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = "mychannel"
+	return configUpdate
+}
+
+func setMSPName(t *testing.T, org *common.ConfigGroup, name string) {
+	mspConfig := &msp.MSPConfig{}
+	assert.NoError(t, proto.Unmarshal(org.Values[channelconfig.MSPKey].Value, mspConfig))
+	fabricConfig := &msp.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, fabricConfig))
+	fabricConfig.Name = name
+	mspConfig.Config = utils.MarshalOrPanic(fabricConfig)
+	org.Values[channelconfig.MSPKey].Value = utils.MarshalOrPanic(mspConfig)
+}
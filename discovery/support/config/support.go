@@ -33,10 +33,26 @@ func (f CurrentConfigBlockGetterFunc) GetCurrConfigBlock(channel string) *common
 	return f(channel)
 }
 
+// OrdererEndpointOverride overrides the orderer endpoints of an organization
+// that are returned in config results, with endpoints from the local configuration of the peer
+type OrdererEndpointOverride struct {
+	// Endpoints are the endpoints of the orderers of the organization, in the form of host:port
+	Endpoints []string
+	// Replace determines whether the endpoints replace the endpoints from the channel configuration,
+	// or are added to them
+	Replace bool
+}
+
 // DiscoverySupport implements support that is used for service discovery
 // that is related to configuration
 type DiscoverySupport struct {
 	CurrentConfigBlockGetter
+	ordererOverrides map[string]ordererOverride
+}
+
+type ordererOverride struct {
+	endpoints []*discovery.Endpoint
+	replace   bool
 }
 
 // NewDiscoverySupport creates a new DiscoverySupport
@@ -46,26 +62,36 @@ func NewDiscoverySupport(getLastConfigBlock CurrentConfigBlockGetter) *Discovery
 	}
 }
 
+// SetOrdererEndpointOverrides sets overrides to the orderer endpoints of organizations,
+// keyed by the MSP IDs of the organizations.
+// Overrides of organizations that aren't orderer organizations of a channel
+// don't apply to config results of the channel.
+func (s *DiscoverySupport) SetOrdererEndpointOverrides(overrides map[string]OrdererEndpointOverride) error {
+	ordererOverrides := make(map[string]ordererOverride)
+	for org, override := range overrides {
+		var endpoints []*discovery.Endpoint
+		for _, endpoint := range override.Endpoints {
+			ep, err := parseEndpoint(endpoint)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("invalid orderer endpoint override for %s", org))
+			}
+			ep.Overridden = true
+			endpoints = append(endpoints, ep)
+		}
+		ordererOverrides[org] = ordererOverride{
+			endpoints: endpoints,
+			replace:   override.Replace,
+		}
+	}
+	s.ordererOverrides = ordererOverrides
+	return nil
+}
+
 // Config returns the channel's configuration
 func (s *DiscoverySupport) Config(channel string) (*discovery.ConfigResult, error) {
-	block := s.GetCurrConfigBlock(channel)
-	if block == nil {
-		return nil, errors.Errorf("could not get last config block for channel %s", channel)
-	}
-	if block.Data == nil || len(block.Data.Data) == 0 {
-		return nil, errors.Errorf("no transactions in block")
-	}
-	env := &common.Envelope{}
-	if err := proto.Unmarshal(block.Data.Data[0], env); err != nil {
-		return nil, errors.Wrap(err, "failed unmarshaling envelope")
-	}
-	pl := &common.Payload{}
-	if err := proto.Unmarshal(env.Payload, pl); err != nil {
-		return nil, errors.Wrap(err, "failed unmarshaling payload")
-	}
-	ce := &common.ConfigEnvelope{}
-	if err := proto.Unmarshal(pl.Data, ce); err != nil {
-		return nil, errors.Wrap(err, "failed unmarshaling config envelope")
+	ce, err := s.configEnvelope(channel)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := ValidateConfigEnvelope(ce); err != nil {
@@ -89,6 +115,7 @@ func (s *DiscoverySupport) Config(channel string) (*discovery.ConfigResult, erro
 		return nil, errors.Wrap(err, "failed computing orderer addresses")
 	}
 	res.Orderers = ordererEndpoints
+	s.applyOrdererOverrides(res.Orderers)
 
 	if err := appendMSPConfigs(ordererGrp, appGrp, res.Msps); err != nil {
 		return nil, errors.WithStack(err)
@@ -97,38 +124,114 @@ func (s *DiscoverySupport) Config(channel string) (*discovery.ConfigResult, erro
 
 }
 
+// configEnvelope returns the config envelope of the last config block of the channel
+func (s *DiscoverySupport) configEnvelope(channel string) (*common.ConfigEnvelope, error) {
+	block := s.GetCurrConfigBlock(channel)
+	if block == nil {
+		return nil, errors.Errorf("could not get last config block for channel %s", channel)
+	}
+	if block.Data == nil || len(block.Data.Data) == 0 {
+		return nil, errors.Errorf("no transactions in block")
+	}
+	env := &common.Envelope{}
+	if err := proto.Unmarshal(block.Data.Data[0], env); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling envelope")
+	}
+	pl := &common.Payload{}
+	if err := proto.Unmarshal(env.Payload, pl); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling payload")
+	}
+	ce := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(pl.Data, ce); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling config envelope")
+	}
+	return ce, nil
+}
+
+// applyOrdererOverrides replaces or augments the given orderer endpoints
+// with the orderer endpoint overrides of the corresponding organizations
+func (s *DiscoverySupport) applyOrdererOverrides(orderers map[string]*discovery.Endpoints) {
+	for ordererOrg, endpoints := range orderers {
+		override, exists := s.ordererOverrides[ordererOrg]
+		if !exists {
+			continue
+		}
+		if override.replace {
+			endpoints.Endpoint = nil
+		}
+		for _, ep := range override.endpoints {
+			if !containsEndpoint(endpoints.Endpoint, ep) {
+				endpoints.Endpoint = append(endpoints.Endpoint, proto.Clone(ep).(*discovery.Endpoint))
+			}
+		}
+	}
+}
+
+func containsEndpoint(endpoints []*discovery.Endpoint, endpoint *discovery.Endpoint) bool {
+	for _, ep := range endpoints {
+		if ep.Host == endpoint.Host && ep.Port == endpoint.Port {
+			return true
+		}
+	}
+	return false
+}
+
+// computeOrdererEndpoints returns the endpoints of the orderers of each orderer organization,
+// along with the TLS CA certificates of the organization.
+// Organizations that don't define their own endpoints are assigned the channel-wide orderer addresses.
 func computeOrdererEndpoints(ordererGrp map[string]*common.ConfigGroup, ordererAddresses *common.OrdererAddresses) (map[string]*discovery.Endpoints, error) {
 	res := make(map[string]*discovery.Endpoints)
-	for ordererOrg := range ordererGrp {
-		res[ordererOrg] = &discovery.Endpoints{}
-		for _, endpoint := range ordererAddresses.Addresses {
-			host, portStr, err := net.SplitHostPort(endpoint)
-			if err != nil {
-				return nil, errors.Errorf("failed parsing orderer endpoint %s", endpoint)
+	for ordererOrg, grp := range ordererGrp {
+		addresses := ordererAddresses.Addresses
+		if value, exists := grp.Values[channelconfig.EndpointsKey]; exists {
+			orgAddresses := &common.OrdererAddresses{}
+			if err := proto.Unmarshal(value.Value, orgAddresses); err != nil {
+				return nil, errors.Wrapf(err, "failed unmarshaling endpoints of %s", ordererOrg)
+			}
+			if len(orgAddresses.Addresses) > 0 {
+				addresses = orgAddresses.Addresses
 			}
-			port, err := strconv.ParseInt(portStr, 10, 32)
+		}
+		fabricConfig, err := fabricMSPConfig(grp)
+		if err != nil {
+			return nil, err
+		}
+		res[ordererOrg] = &discovery.Endpoints{
+			TlsRootCerts:         fabricConfig.TlsRootCerts,
+			TlsIntermediateCerts: fabricConfig.TlsIntermediateCerts,
+		}
+		for _, endpoint := range addresses {
+			ep, err := parseEndpoint(endpoint)
 			if err != nil {
-				return nil, errors.Errorf("%s is not a valid port number", portStr)
+				return nil, err
 			}
-			res[ordererOrg].Endpoint = append(res[ordererOrg].Endpoint, &discovery.Endpoint{
-				Host: host,
-				Port: uint32(port),
-			})
+			res[ordererOrg].Endpoint = append(res[ordererOrg].Endpoint, ep)
 		}
 	}
 	return res, nil
 }
 
+func parseEndpoint(endpoint string) (*discovery.Endpoint, error) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, errors.Errorf("failed parsing orderer endpoint %s", endpoint)
+	}
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil {
+		return nil, errors.Errorf("%s is not a valid port number", portStr)
+	}
+	return &discovery.Endpoint{
+		Host: host,
+		Port: uint32(port),
+	}, nil
+}
+
 func appendMSPConfigs(ordererGrp, appGrp map[string]*common.ConfigGroup, output map[string]*msp.FabricMSPConfig) error {
 	for _, group := range []map[string]*common.ConfigGroup{ordererGrp, appGrp} {
 		for orgID, grp := range group {
-			mspConfig := &msp.MSPConfig{}
-			if err := proto.Unmarshal(grp.Values[channelconfig.MSPKey].Value, mspConfig); err != nil {
-				return errors.Wrap(err, "failed parsing MSPConfig")
-			}
-			fabricConfig := &msp.FabricMSPConfig{}
-			if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
-				return errors.Wrap(err, "failed marshaling FabricMSPConfig")
+			fabricConfig, err := fabricMSPConfig(grp)
+			if err != nil {
+				return err
 			}
 			if _, exists := output[orgID]; exists {
 				continue
@@ -140,6 +243,18 @@ func appendMSPConfigs(ordererGrp, appGrp map[string]*common.ConfigGroup, output
 	return nil
 }
 
+func fabricMSPConfig(grp *common.ConfigGroup) (*msp.FabricMSPConfig, error) {
+	mspConfig := &msp.MSPConfig{}
+	if err := proto.Unmarshal(grp.Values[channelconfig.MSPKey].GetValue(), mspConfig); err != nil {
+		return nil, errors.Wrap(err, "failed parsing MSPConfig")
+	}
+	fabricConfig := &msp.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return nil, errors.Wrap(err, "failed marshaling FabricMSPConfig")
+	}
+	return fabricConfig, nil
+}
+
 func ValidateConfigEnvelope(ce *common.ConfigEnvelope) error {
 	if ce.Config == nil {
 		return fmt.Errorf("field Config is nil")
@@ -15,6 +15,8 @@ import (
 	"github.com/hyperledger/fabric/discovery/support/config"
 	"github.com/hyperledger/fabric/discovery/support/mocks"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,6 +67,124 @@ func TestSupportGreenPath(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+func TestOrdererEndpointOverrides(t *testing.T) {
+	block, err := test.MakeGenesisBlock("test")
+	assert.NoError(t, err)
+	fakeBlockGetter := &mocks.ConfigBlockGetter{}
+	fakeBlockGetter.GetCurrConfigBlockReturns(block)
+	cs := config.NewDiscoverySupport(fakeBlockGetter)
+
+	res, err := cs.Config("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Orderers, 1)
+	var ordererOrg string
+	var originalEndpoints []*discovery.Endpoint
+	for org, endpoints := range res.Orderers {
+		ordererOrg = org
+		originalEndpoints = endpoints.Endpoint
+	}
+	assert.NotEmpty(t, originalEndpoints)
+
+	// Scenario I: Endpoints are added to the endpoints from the channel config
+	err = cs.SetOrdererEndpointOverrides(map[string]config.OrdererEndpointOverride{
+		ordererOrg:        {Endpoints: []string{"lb.example.com:443"}},
+		"NotAnOrdererOrg": {Endpoints: []string{"foo.example.com:443"}},
+	})
+	assert.NoError(t, err)
+	res, err = cs.Config("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Orderers, 1)
+	expected := append(originalEndpoints, &discovery.Endpoint{Host: "lb.example.com", Port: 443, Overridden: true})
+	assert.Equal(t, expected, res.Orderers[ordererOrg].Endpoint)
+
+	// Scenario II: Endpoints replace the endpoints from the channel config
+	err = cs.SetOrdererEndpointOverrides(map[string]config.OrdererEndpointOverride{
+		ordererOrg: {Endpoints: []string{"lb1.example.com:443", "lb2.example.com:443"}, Replace: true},
+	})
+	assert.NoError(t, err)
+	res, err = cs.Config("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []*discovery.Endpoint{
+		{Host: "lb1.example.com", Port: 443, Overridden: true},
+		{Host: "lb2.example.com", Port: 443, Overridden: true},
+	}, res.Orderers[ordererOrg].Endpoint)
+
+	// Scenario III: Invalid overrides are rejected, and the previous overrides remain in effect
+	err = cs.SetOrdererEndpointOverrides(map[string]config.OrdererEndpointOverride{
+		ordererOrg: {Endpoints: []string{"lb.example.com"}},
+	})
+	assert.EqualError(t, err, "invalid orderer endpoint override for "+ordererOrg+": failed parsing orderer endpoint lb.example.com")
+	err = cs.SetOrdererEndpointOverrides(map[string]config.OrdererEndpointOverride{
+		ordererOrg: {Endpoints: []string{"lb.example.com:foo"}},
+	})
+	assert.EqualError(t, err, "invalid orderer endpoint override for "+ordererOrg+": foo is not a valid port number")
+	res, err = cs.Config("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Orderers[ordererOrg].Endpoint, 2)
+}
+
+func TestOrdererEndpointsPerOrg(t *testing.T) {
+	block, err := test.MakeGenesisBlock("test")
+	assert.NoError(t, err)
+	fakeBlockGetter := &mocks.ConfigBlockGetter{}
+	fakeBlockGetter.GetCurrConfigBlockReturns(block)
+	cs := config.NewDiscoverySupport(fakeBlockGetter)
+
+	// Scenario I: The orderer org doesn't define its own endpoints,
+	// so it's assigned the channel-wide orderer addresses, along with its TLS CA certificates
+	res, err := cs.Config("test")
+	assert.NoError(t, err)
+	assert.Len(t, res.Orderers, 1)
+	var ordererOrg string
+	for org := range res.Orderers {
+		ordererOrg = org
+	}
+	assert.NotEmpty(t, res.Orderers[ordererOrg].Endpoint)
+	assert.NotEmpty(t, res.Orderers[ordererOrg].TlsRootCerts)
+	assert.Equal(t, res.Msps[ordererOrg].TlsRootCerts, res.Orderers[ordererOrg].TlsRootCerts)
+	assert.Equal(t, res.Msps[ordererOrg].TlsIntermediateCerts, res.Orderers[ordererOrg].TlsIntermediateCerts)
+
+	// Scenario II: The orderer org defines its own endpoints, which take precedence
+	setOrdererOrgValue(block, ordererOrg, channelconfig.EndpointsKey, utils.MarshalOrPanic(&common.OrdererAddresses{
+		Addresses: []string{"orderer0.example.com:7050", "orderer1.example.com:7050"},
+	}))
+	res, err = cs.Config("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []*discovery.Endpoint{
+		{Host: "orderer0.example.com", Port: 7050},
+		{Host: "orderer1.example.com", Port: 7050},
+	}, res.Orderers[ordererOrg].Endpoint)
+
+	// Scenario III: The endpoints of the orderer org are invalid
+	setOrdererOrgValue(block, ordererOrg, channelconfig.EndpointsKey, utils.MarshalOrPanic(&common.OrdererAddresses{
+		Addresses: []string{"orderer0.example.com"},
+	}))
+	res, err = cs.Config("test")
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "failed parsing orderer endpoint orderer0.example.com")
+
+	setOrdererOrgValue(block, ordererOrg, channelconfig.EndpointsKey, []byte{1, 2, 3})
+	res, err = cs.Config("test")
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "failed unmarshaling endpoints of "+ordererOrg)
+}
+
+// setOrdererOrgValue sets the given value of the given orderer org in the config of the given config block
+func setOrdererOrgValue(block *common.Block, org string, key string, value []byte) {
+	env := utils.ExtractEnvelopeOrPanic(block, 0)
+	payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+	ce := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, ce); err != nil {
+		panic(err)
+	}
+	ce.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups[org].Values[key] = &common.ConfigValue{
+		Value: value,
+	}
+	payload.Data = utils.MarshalOrPanic(ce)
+	env.Payload = utils.MarshalOrPanic(payload)
+	block.Data.Data[0] = utils.MarshalOrPanic(env)
+}
+
 func TestSupportBadConfig(t *testing.T) {
 	fakeBlockGetter := &mocks.ConfigBlockGetter{}
 	cs := config.NewDiscoverySupport(fakeBlockGetter)
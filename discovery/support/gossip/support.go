@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package gossip
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	gossip2 "github.com/hyperledger/fabric/gossip/gossip"
@@ -44,6 +46,17 @@ func (s *DiscoverySupport) PeersOfChannel(chain common.ChainID) discovery.Member
 	return append(s.Gossip.PeersOfChannel(chain), selfMember)
 }
 
+// PeerLatencies returns the round trip times measured to the alive peers
+// of the given channel by their PKI-IDs, including this peer, whose round trip time is zero
+func (s *DiscoverySupport) PeerLatencies(chain common.ChainID) map[string]time.Duration {
+	latencies := s.Gossip.PeerLatencies(chain)
+	if latencies == nil {
+		latencies = make(map[string]time.Duration)
+	}
+	latencies[string(s.SelfMembershipInfo().PKIid)] = 0
+	return latencies
+}
+
 // Peers returns the NetworkMembers considered alive
 func (s *DiscoverySupport) Peers() discovery.Members {
 	peers := s.Gossip.Peers()
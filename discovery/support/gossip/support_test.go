@@ -8,6 +8,7 @@ package gossip_test
 
 import (
 	"testing"
+	"time"
 
 	gossipSupport "github.com/hyperledger/fabric/discovery/support/gossip"
 	"github.com/hyperledger/fabric/discovery/support/mocks"
@@ -51,3 +52,15 @@ func TestPeersOfChannel(t *testing.T) {
 	expected := discovery.Members{{PKIid: common.PKIidType("p1")}, {PKIid: common.PKIidType("p2")}, {PKIid: common.PKIidType("px"), Envelope: sMsg.Envelope}}
 	assert.Equal(t, expected, sup.PeersOfChannel(common.ChainID("")))
 }
+
+func TestPeerLatencies(t *testing.T) {
+	g := &mocks.Gossip{}
+	g.SelfMembershipInfoReturns(discovery.NetworkMember{PKIid: common.PKIidType("p0")})
+	g.PeerLatenciesReturnsOnCall(0, nil)
+	g.PeerLatenciesReturnsOnCall(1, map[string]time.Duration{"p1": time.Millisecond})
+	sup := gossipSupport.NewDiscoverySupport(g)
+	// The peer itself is always included, with a zero round trip time
+	assert.Equal(t, map[string]time.Duration{"p0": 0}, sup.PeerLatencies(common.ChainID("A")))
+	assert.Equal(t, map[string]time.Duration{"p0": 0, "p1": time.Millisecond}, sup.PeerLatencies(common.ChainID("A")))
+	assert.Equal(t, common.ChainID("A"), g.PeerLatenciesArgsForCall(1))
+}
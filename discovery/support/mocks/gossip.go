@@ -3,6 +3,7 @@ package mocks
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -10,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 )
 
@@ -77,6 +79,11 @@ type Gossip struct {
 	updateMetadataArgsForCall []struct {
 		metadata []byte
 	}
+	UpdateExternalEndpointStub        func(endpoint string)
+	updateExternalEndpointMutex       sync.RWMutex
+	updateExternalEndpointArgsForCall []struct {
+		endpoint string
+	}
 	UpdateLedgerHeightStub        func(height uint64, chainID common.ChainID)
 	updateLedgerHeightMutex       sync.RWMutex
 	updateLedgerHeightArgsForCall []struct {
@@ -147,9 +154,54 @@ type Gossip struct {
 	identityInfoReturnsOnCall map[int]struct {
 		result1 api.PeerIdentitySet
 	}
-	StopStub         func()
-	stopMutex        sync.RWMutex
-	stopArgsForCall  []struct{}
+	StopStub                      func()
+	stopMutex                     sync.RWMutex
+	stopArgsForCall               []struct{}
+	MembershipSnapshotStub        func() gossip.MembershipSnapshot
+	membershipSnapshotMutex       sync.RWMutex
+	membershipSnapshotArgsForCall []struct{}
+	membershipSnapshotReturns     struct {
+		result1 gossip.MembershipSnapshot
+	}
+	membershipSnapshotReturnsOnCall map[int]struct {
+		result1 gossip.MembershipSnapshot
+	}
+	PeerLatenciesStub        func(channel common.ChainID) map[string]time.Duration
+	peerLatenciesMutex       sync.RWMutex
+	peerLatenciesArgsForCall []struct {
+		channel common.ChainID
+	}
+	peerLatenciesReturns struct {
+		result1 map[string]time.Duration
+	}
+	peerLatenciesReturnsOnCall map[int]struct {
+		result1 map[string]time.Duration
+	}
+	UpdateLedgerSnapshotsStub        func([]uint64, common.ChainID)
+	updateLedgerSnapshotsMutex       sync.RWMutex
+	updateLedgerSnapshotsArgsForCall []struct {
+		heights []uint64
+		chainID common.ChainID
+	}
+	OpenBlockStreamStub        func(*proto.GossipMessage, *comm.RemotePeer) (comm.BlockStream, error)
+	openBlockStreamMutex       sync.RWMutex
+	openBlockStreamArgsForCall []struct {
+		msg  *proto.GossipMessage
+		peer *comm.RemotePeer
+	}
+	openBlockStreamReturns struct {
+		result1 comm.BlockStream
+		result2 error
+	}
+	openBlockStreamReturnsOnCall map[int]struct {
+		result1 comm.BlockStream
+		result2 error
+	}
+	OnIdentityPurgeStub        func(func(identity.PurgeEvent))
+	onIdentityPurgeMutex       sync.RWMutex
+	onIdentityPurgeArgsForCall []struct {
+		listener func(identity.PurgeEvent)
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -433,6 +485,30 @@ func (fake *Gossip) UpdateMetadataArgsForCall(i int) []byte {
 	return fake.updateMetadataArgsForCall[i].metadata
 }
 
+func (fake *Gossip) UpdateExternalEndpoint(endpoint string) {
+	fake.updateExternalEndpointMutex.Lock()
+	fake.updateExternalEndpointArgsForCall = append(fake.updateExternalEndpointArgsForCall, struct {
+		endpoint string
+	}{endpoint})
+	fake.recordInvocation("UpdateExternalEndpoint", []interface{}{endpoint})
+	fake.updateExternalEndpointMutex.Unlock()
+	if fake.UpdateExternalEndpointStub != nil {
+		fake.UpdateExternalEndpointStub(endpoint)
+	}
+}
+
+func (fake *Gossip) UpdateExternalEndpointCallCount() int {
+	fake.updateExternalEndpointMutex.RLock()
+	defer fake.updateExternalEndpointMutex.RUnlock()
+	return len(fake.updateExternalEndpointArgsForCall)
+}
+
+func (fake *Gossip) UpdateExternalEndpointArgsForCall(i int) string {
+	fake.updateExternalEndpointMutex.RLock()
+	defer fake.updateExternalEndpointMutex.RUnlock()
+	return fake.updateExternalEndpointArgsForCall[i].endpoint
+}
+
 func (fake *Gossip) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
 	fake.updateLedgerHeightMutex.Lock()
 	fake.updateLedgerHeightArgsForCall = append(fake.updateLedgerHeightArgsForCall, struct {
@@ -745,6 +821,200 @@ func (fake *Gossip) StopCallCount() int {
 	return len(fake.stopArgsForCall)
 }
 
+func (fake *Gossip) MembershipSnapshot() gossip.MembershipSnapshot {
+	fake.membershipSnapshotMutex.Lock()
+	ret, specificReturn := fake.membershipSnapshotReturnsOnCall[len(fake.membershipSnapshotArgsForCall)]
+	fake.membershipSnapshotArgsForCall = append(fake.membershipSnapshotArgsForCall, struct{}{})
+	fake.recordInvocation("MembershipSnapshot", []interface{}{})
+	fake.membershipSnapshotMutex.Unlock()
+	if fake.MembershipSnapshotStub != nil {
+		return fake.MembershipSnapshotStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.membershipSnapshotReturns.result1
+}
+
+func (fake *Gossip) MembershipSnapshotCallCount() int {
+	fake.membershipSnapshotMutex.RLock()
+	defer fake.membershipSnapshotMutex.RUnlock()
+	return len(fake.membershipSnapshotArgsForCall)
+}
+
+func (fake *Gossip) MembershipSnapshotReturns(result1 gossip.MembershipSnapshot) {
+	fake.MembershipSnapshotStub = nil
+	fake.membershipSnapshotReturns = struct {
+		result1 gossip.MembershipSnapshot
+	}{result1}
+}
+
+func (fake *Gossip) MembershipSnapshotReturnsOnCall(i int, result1 gossip.MembershipSnapshot) {
+	fake.MembershipSnapshotStub = nil
+	if fake.membershipSnapshotReturnsOnCall == nil {
+		fake.membershipSnapshotReturnsOnCall = make(map[int]struct {
+			result1 gossip.MembershipSnapshot
+		})
+	}
+	fake.membershipSnapshotReturnsOnCall[i] = struct {
+		result1 gossip.MembershipSnapshot
+	}{result1}
+}
+
+func (fake *Gossip) PeerLatencies(channel common.ChainID) map[string]time.Duration {
+	fake.peerLatenciesMutex.Lock()
+	ret, specificReturn := fake.peerLatenciesReturnsOnCall[len(fake.peerLatenciesArgsForCall)]
+	fake.peerLatenciesArgsForCall = append(fake.peerLatenciesArgsForCall, struct {
+		channel common.ChainID
+	}{channel})
+	fake.recordInvocation("PeerLatencies", []interface{}{channel})
+	fake.peerLatenciesMutex.Unlock()
+	if fake.PeerLatenciesStub != nil {
+		return fake.PeerLatenciesStub(channel)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.peerLatenciesReturns.result1
+}
+
+func (fake *Gossip) PeerLatenciesCallCount() int {
+	fake.peerLatenciesMutex.RLock()
+	defer fake.peerLatenciesMutex.RUnlock()
+	return len(fake.peerLatenciesArgsForCall)
+}
+
+func (fake *Gossip) PeerLatenciesArgsForCall(i int) common.ChainID {
+	fake.peerLatenciesMutex.RLock()
+	defer fake.peerLatenciesMutex.RUnlock()
+	return fake.peerLatenciesArgsForCall[i].channel
+}
+
+func (fake *Gossip) PeerLatenciesReturns(result1 map[string]time.Duration) {
+	fake.PeerLatenciesStub = nil
+	fake.peerLatenciesReturns = struct {
+		result1 map[string]time.Duration
+	}{result1}
+}
+
+func (fake *Gossip) PeerLatenciesReturnsOnCall(i int, result1 map[string]time.Duration) {
+	fake.PeerLatenciesStub = nil
+	if fake.peerLatenciesReturnsOnCall == nil {
+		fake.peerLatenciesReturnsOnCall = make(map[int]struct {
+			result1 map[string]time.Duration
+		})
+	}
+	fake.peerLatenciesReturnsOnCall[i] = struct {
+		result1 map[string]time.Duration
+	}{result1}
+}
+
+func (fake *Gossip) UpdateLedgerSnapshots(heights []uint64, chainID common.ChainID) {
+	var heightsCopy []uint64
+	if heights != nil {
+		heightsCopy = make([]uint64, len(heights))
+		copy(heightsCopy, heights)
+	}
+	fake.updateLedgerSnapshotsMutex.Lock()
+	fake.updateLedgerSnapshotsArgsForCall = append(fake.updateLedgerSnapshotsArgsForCall, struct {
+		heights []uint64
+		chainID common.ChainID
+	}{heightsCopy, chainID})
+	fake.recordInvocation("UpdateLedgerSnapshots", []interface{}{heightsCopy, chainID})
+	fake.updateLedgerSnapshotsMutex.Unlock()
+	if fake.UpdateLedgerSnapshotsStub != nil {
+		fake.UpdateLedgerSnapshotsStub(heights, chainID)
+	}
+}
+
+func (fake *Gossip) UpdateLedgerSnapshotsCallCount() int {
+	fake.updateLedgerSnapshotsMutex.RLock()
+	defer fake.updateLedgerSnapshotsMutex.RUnlock()
+	return len(fake.updateLedgerSnapshotsArgsForCall)
+}
+
+func (fake *Gossip) UpdateLedgerSnapshotsArgsForCall(i int) ([]uint64, common.ChainID) {
+	fake.updateLedgerSnapshotsMutex.RLock()
+	defer fake.updateLedgerSnapshotsMutex.RUnlock()
+	return fake.updateLedgerSnapshotsArgsForCall[i].heights, fake.updateLedgerSnapshotsArgsForCall[i].chainID
+}
+
+func (fake *Gossip) OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error) {
+	fake.openBlockStreamMutex.Lock()
+	ret, specificReturn := fake.openBlockStreamReturnsOnCall[len(fake.openBlockStreamArgsForCall)]
+	fake.openBlockStreamArgsForCall = append(fake.openBlockStreamArgsForCall, struct {
+		msg  *proto.GossipMessage
+		peer *comm.RemotePeer
+	}{msg, peer})
+	fake.recordInvocation("OpenBlockStream", []interface{}{msg, peer})
+	fake.openBlockStreamMutex.Unlock()
+	if fake.OpenBlockStreamStub != nil {
+		return fake.OpenBlockStreamStub(msg, peer)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.openBlockStreamReturns.result1, fake.openBlockStreamReturns.result2
+}
+
+func (fake *Gossip) OpenBlockStreamCallCount() int {
+	fake.openBlockStreamMutex.RLock()
+	defer fake.openBlockStreamMutex.RUnlock()
+	return len(fake.openBlockStreamArgsForCall)
+}
+
+func (fake *Gossip) OpenBlockStreamArgsForCall(i int) (*proto.GossipMessage, *comm.RemotePeer) {
+	fake.openBlockStreamMutex.RLock()
+	defer fake.openBlockStreamMutex.RUnlock()
+	return fake.openBlockStreamArgsForCall[i].msg, fake.openBlockStreamArgsForCall[i].peer
+}
+
+func (fake *Gossip) OpenBlockStreamReturns(result1 comm.BlockStream, result2 error) {
+	fake.OpenBlockStreamStub = nil
+	fake.openBlockStreamReturns = struct {
+		result1 comm.BlockStream
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Gossip) OpenBlockStreamReturnsOnCall(i int, result1 comm.BlockStream, result2 error) {
+	fake.OpenBlockStreamStub = nil
+	if fake.openBlockStreamReturnsOnCall == nil {
+		fake.openBlockStreamReturnsOnCall = make(map[int]struct {
+			result1 comm.BlockStream
+			result2 error
+		})
+	}
+	fake.openBlockStreamReturnsOnCall[i] = struct {
+		result1 comm.BlockStream
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Gossip) OnIdentityPurge(listener func(identity.PurgeEvent)) {
+	fake.onIdentityPurgeMutex.Lock()
+	fake.onIdentityPurgeArgsForCall = append(fake.onIdentityPurgeArgsForCall, struct {
+		listener func(identity.PurgeEvent)
+	}{listener})
+	fake.recordInvocation("OnIdentityPurge", []interface{}{listener})
+	fake.onIdentityPurgeMutex.Unlock()
+	if fake.OnIdentityPurgeStub != nil {
+		fake.OnIdentityPurgeStub(listener)
+	}
+}
+
+func (fake *Gossip) OnIdentityPurgeCallCount() int {
+	fake.onIdentityPurgeMutex.RLock()
+	defer fake.onIdentityPurgeMutex.RUnlock()
+	return len(fake.onIdentityPurgeArgsForCall)
+}
+
+func (fake *Gossip) OnIdentityPurgeArgsForCall(i int) func(identity.PurgeEvent) {
+	fake.onIdentityPurgeMutex.RLock()
+	defer fake.onIdentityPurgeMutex.RUnlock()
+	return fake.onIdentityPurgeArgsForCall[i].listener
+}
+
 func (fake *Gossip) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -762,6 +1032,8 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.peersOfChannelMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.updateExternalEndpointMutex.RLock()
+	defer fake.updateExternalEndpointMutex.RUnlock()
 	fake.updateLedgerHeightMutex.RLock()
 	defer fake.updateLedgerHeightMutex.RUnlock()
 	fake.updateChaincodesMutex.RLock()
@@ -782,6 +1054,16 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.identityInfoMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.membershipSnapshotMutex.RLock()
+	defer fake.membershipSnapshotMutex.RUnlock()
+	fake.peerLatenciesMutex.RLock()
+	defer fake.peerLatenciesMutex.RUnlock()
+	fake.updateLedgerSnapshotsMutex.RLock()
+	defer fake.updateLedgerSnapshotsMutex.RUnlock()
+	fake.openBlockStreamMutex.RLock()
+	defer fake.openBlockStreamMutex.RUnlock()
+	fake.onIdentityPurgeMutex.RLock()
+	defer fake.onIdentityPurgeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+const (
+	bandwidthQuotaKey          = "peer.gossip.bandwidth.channelQuota"
+	bandwidthQuotaOverridesKey = "peer.gossip.bandwidth.channelQuotaOverrides"
+	maxWaitingMessagesKey      = "peer.gossip.bandwidth.maxWaitingMessages"
+
+	defMaxWaitingMessages = 100
+)
+
+// bandwidthQuota throttles the egress of channel-scoped gossip messages
+// according to a per-channel quota of bytes per second.
+// Every channel is given its own token bucket, so a channel that exhausts
+// its quota (i.e. due to heavy private data dissemination) only delays
+// its own messages. The buckets are independent of each other: there is
+// no overall egress budget that is shared fairly among the channels, and
+// bandwidth a channel doesn't use is not lent to other channels.
+// Messages that are not bound to any channel (membership, connection
+// establishment, etc.) are never throttled.
+// Each message that waits for bandwidth occupies a goroutine, hence once
+// too many messages of a channel wait, further messages of it are dropped.
+type bandwidthQuota struct {
+	sync.Mutex
+	defaultRate int
+	overrides   map[string]int
+	maxWaiting  int32
+	channels    map[string]*channelBandwidth
+	now         func() time.Time
+	sleep       func(time.Duration)
+}
+
+// channelBandwidth is the token bucket of a channel, along with
+// the number of messages that wait for it to be refilled
+type channelBandwidth struct {
+	*tokenBucket
+	waiting int32
+	metrics *bandwidthMetrics
+}
+
+// newBandwidthQuotaFromConfig creates a bandwidthQuota from the peer configuration.
+// A quota of zero bytes per second means the channel isn't throttled.
+func newBandwidthQuotaFromConfig() *bandwidthQuota {
+	overrides := make(map[string]int)
+	for channel, rate := range viper.GetStringMap(bandwidthQuotaOverridesKey) {
+		overrides[channel] = cast.ToInt(rate)
+	}
+	return newBandwidthQuota(util.GetIntOrDefault(bandwidthQuotaKey, 0), overrides,
+		util.GetIntOrDefault(maxWaitingMessagesKey, defMaxWaitingMessages))
+}
+
+func newBandwidthQuota(defaultRate int, overrides map[string]int, maxWaiting int) *bandwidthQuota {
+	return &bandwidthQuota{
+		defaultRate: defaultRate,
+		overrides:   overrides,
+		maxWaiting:  int32(maxWaiting),
+		channels:    make(map[string]*channelBandwidth),
+		now:         time.Now,
+		sleep:       time.Sleep,
+	}
+}
+
+// acquire blocks until the channel of the given message has enough
+// bandwidth left in its quota to send the message, and returns true.
+// Returns false without blocking if the message should be dropped,
+// as too many messages of its channel already wait for bandwidth.
+func (bq *bandwidthQuota) acquire(msg *proto.SignedGossipMessage) bool {
+	if bq == nil || msg == nil || msg.GossipMessage == nil || len(msg.Channel) == 0 {
+		return true
+	}
+	cb := bq.channelOf(string(msg.Channel))
+	if cb == nil {
+		return true
+	}
+	size := pb.Size(msg.Envelope)
+	waitTime := cb.take(size, bq.now())
+	if waitTime == 0 {
+		return true
+	}
+
+	waiting := atomic.AddInt32(&cb.waiting, 1)
+	defer func() {
+		cb.metrics.reportWaiting(atomic.AddInt32(&cb.waiting, -1))
+	}()
+	if waiting > bq.maxWaiting {
+		cb.metrics.reportDropped()
+		return false
+	}
+	cb.metrics.reportThrottled()
+	cb.metrics.reportWaiting(waiting)
+	for waitTime > 0 {
+		bq.sleep(waitTime)
+		waitTime = cb.take(size, bq.now())
+	}
+	return true
+}
+
+// channelOf returns the bandwidth of the given channel,
+// or nil if the channel isn't throttled
+func (bq *bandwidthQuota) channelOf(channel string) *channelBandwidth {
+	bq.Lock()
+	defer bq.Unlock()
+	if cb, exists := bq.channels[channel]; exists {
+		return cb
+	}
+	rate := bq.defaultRate
+	if override, exists := bq.overrides[channel]; exists {
+		rate = override
+	}
+	if rate <= 0 {
+		return nil
+	}
+	cb := &channelBandwidth{
+		tokenBucket: newTokenBucket(rate, bq.now()),
+		metrics:     newBandwidthMetrics(channel),
+	}
+	bq.channels[channel] = cb
+	return cb
+}
+
+// tokenBucket accumulates up to a second's worth of bytes.
+// A message is admitted as long as the bucket isn't in debt,
+// so messages bigger than the rate itself can still be sent.
+type tokenBucket struct {
+	sync.Mutex
+	rate       float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:       float64(rate),
+		tokens:     float64(rate),
+		lastRefill: now,
+	}
+}
+
+// take consumes the given amount of bytes from the bucket and returns 0,
+// or returns the time to wait until the bucket is refilled enough to be taken from
+func (tb *tokenBucket) take(size int, now time.Time) time.Duration {
+	tb.Lock()
+	defer tb.Unlock()
+	if elapsed := now.Sub(tb.lastRefill); elapsed > 0 {
+		tb.tokens += elapsed.Seconds() * tb.rate
+		if tb.tokens > tb.rate {
+			tb.tokens = tb.rate
+		}
+		tb.lastRefill = now
+	}
+	if tb.tokens > 0 {
+		tb.tokens -= float64(size)
+		return 0
+	}
+	waitTime := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+	if waitTime <= 0 {
+		waitTime = time.Millisecond
+	}
+	return waitTime
+}
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// bandwidthMetrics reports the messages of a channel that are throttled by its bandwidth quota
+type bandwidthMetrics struct {
+	waiting   metrics.Gauge
+	throttled metrics.Counter
+	dropped   metrics.Counter
+}
+
+// newBandwidthMetrics creates the bandwidth metrics of the given channel,
+// or returns nil if metrics aren't initialized
+func newBandwidthMetrics(channel string) *bandwidthMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("gossip_bandwidth").Tagged(map[string]string{"channel": channel})
+	return &bandwidthMetrics{
+		waiting:   scope.Gauge("waiting_messages"),
+		throttled: scope.Counter("throttled_messages"),
+		dropped:   scope.Counter("dropped_messages"),
+	}
+}
+
+// reportWaiting reports the number of messages that wait for bandwidth
+func (m *bandwidthMetrics) reportWaiting(waiting int32) {
+	if m == nil {
+		return
+	}
+	m.waiting.Update(float64(waiting))
+}
+
+// reportThrottled reports a message that waits for bandwidth
+func (m *bandwidthMetrics) reportThrottled() {
+	if m == nil {
+		return
+	}
+	m.throttled.Inc(1)
+}
+
+// reportDropped reports a message that is dropped because too many messages wait for bandwidth
+func (m *bandwidthMetrics) reportDropped() {
+	if m == nil {
+		return
+	}
+	m.dropped.Inc(1)
+}
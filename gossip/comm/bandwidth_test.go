@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func channelMsg(channel string, payloadSize int) *proto.SignedGossipMessage {
+	return &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Channel: []byte(channel),
+		},
+		Envelope: &proto.Envelope{
+			Payload: make([]byte, payloadSize),
+		},
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	tb := newTokenBucket(1000, now)
+	// A full bucket admits a message even if it's bigger than the bucket itself
+	assert.Zero(t, tb.take(1500, now))
+	// The bucket is in debt, so we should wait until it's refilled
+	waitTime := tb.take(1, now)
+	assert.True(t, waitTime > 500*time.Millisecond)
+	assert.True(t, waitTime <= 501*time.Millisecond)
+	// After the wait time passes, the bucket should admit messages again
+	assert.Zero(t, tb.take(1, now.Add(waitTime)))
+	// The bucket never accumulates more than a second's worth of bytes
+	now = now.Add(time.Hour)
+	assert.Zero(t, tb.take(1000, now))
+	assert.NotZero(t, tb.take(1, now))
+}
+
+func TestBandwidthQuota(t *testing.T) {
+	now := time.Now()
+	var slept time.Duration
+	bq := newBandwidthQuota(1000, map[string]int{"B": 0}, defMaxWaitingMessages)
+	bq.now = func() time.Time {
+		return now
+	}
+	bq.sleep = func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	}
+
+	// Messages without a channel aren't throttled
+	for i := 0; i < 10; i++ {
+		bq.acquire(channelMsg("", 1000))
+	}
+	assert.Zero(t, slept)
+
+	// Channel B has its quota overridden to be unlimited
+	for i := 0; i < 10; i++ {
+		bq.acquire(channelMsg("B", 1000))
+	}
+	assert.Zero(t, slept)
+
+	// Channel A is throttled once it exhausts its quota
+	bq.acquire(channelMsg("A", 1000))
+	assert.Zero(t, slept)
+	bq.acquire(channelMsg("A", 1000))
+	assert.True(t, slept > 0)
+
+	// Channel C isn't affected by channel A exhausting its quota
+	slept = 0
+	bq.acquire(channelMsg("C", 1000))
+	assert.Zero(t, slept)
+
+	// A nil quota never throttles
+	var nilQuota *bandwidthQuota
+	assert.True(t, nilQuota.acquire(channelMsg("A", 1000)))
+}
+
+func TestBandwidthQuotaMaxWaiting(t *testing.T) {
+	now := time.Now()
+	bq := newBandwidthQuota(1000, nil, 1)
+	bq.now = func() time.Time {
+		return now
+	}
+	var droppedWhileWaiting []bool
+	bq.sleep = func(d time.Duration) {
+		// While a message waits for bandwidth, another message of the channel
+		// exceeds the maximum amount of waiting messages, and is dropped
+		droppedWhileWaiting = append(droppedWhileWaiting, !bq.acquire(channelMsg("A", 1000)))
+		// Messages of other channels are still sent
+		assert.True(t, bq.acquire(channelMsg("B", 1000)))
+		now = now.Add(d)
+	}
+
+	assert.True(t, bq.acquire(channelMsg("A", 1000)))
+	assert.Empty(t, droppedWhileWaiting)
+	assert.True(t, bq.acquire(channelMsg("A", 1000)))
+	assert.Equal(t, []bool{true}, droppedWhileWaiting)
+	assert.Equal(t, int32(0), bq.channelOf("A").waiting)
+}
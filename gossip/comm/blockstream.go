@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const defBlockStreamIdleTimeout = 30 * time.Second
+
+// BlockStream is a dedicated stream that blocks are received from,
+// opened by sending a state request to a remote peer
+type BlockStream interface {
+	// Recv returns the next message sent by the remote peer,
+	// or io.EOF if the remote peer finished sending messages
+	Recv() (*proto.SignedGossipMessage, error)
+
+	// Close closes the stream
+	Close()
+}
+
+// BlockStreamRequest is a ReceivedMessage that was received over
+// a dedicated block stream instead of over the gossip stream.
+// Responses to it are sent over the block stream, which remains
+// open until Close is invoked.
+type BlockStreamRequest interface {
+	proto.ReceivedMessage
+
+	// Send sends a message to the remote peer over the block stream
+	Send(msg *proto.GossipMessage) error
+
+	// Close ends the block stream, and reports the given error
+	// to the remote peer if it isn't nil
+	Close(err error)
+}
+
+// OpenBlockStream authenticates the given remote peer, sends it the given
+// state request over a dedicated block stream and returns the stream
+func (c *commImpl) OpenBlockStream(peer *RemotePeer, msg *proto.SignedGossipMessage) (BlockStream, error) {
+	if c.isStopping() {
+		return nil, errors.New("Stopping")
+	}
+	if msg.GetStateRequest() == nil {
+		return nil, errors.New("block streams can only be opened with a state request")
+	}
+	var dialOpts []grpc.DialOption
+	dialOpts = append(dialOpts, c.secureDialOpts()...)
+	dialOpts = append(dialOpts, grpc.WithBlock())
+	dialOpts = append(dialOpts, c.opts...)
+	ctx, cancel := context.WithTimeout(context.Background(), c.dialTimeout)
+	defer cancel()
+	cc, err := grpc.DialContext(ctx, peer.Endpoint, dialOpts...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ctx, cf := context.WithCancel(context.Background())
+	stream, err := proto.NewGossipClient(cc).BlockStream(ctx)
+	if err != nil {
+		cf()
+		cc.Close()
+		return nil, errors.WithStack(err)
+	}
+	bs := &blockStreamClient{cc: cc, stream: stream, cancel: cf}
+
+	connInfo, err := c.authenticateRemotePeer(stream, true)
+	if err != nil {
+		bs.Close()
+		return nil, errors.WithStack(err)
+	}
+	if len(peer.PKIID) > 0 && !bytes.Equal(connInfo.ID, peer.PKIID) {
+		bs.Close()
+		return nil, errors.New("PKI-ID of remote peer doesn't match expected PKI-ID")
+	}
+	if err := stream.Send(msg.Envelope); err != nil {
+		bs.Close()
+		return nil, errors.WithStack(err)
+	}
+	return bs, nil
+}
+
+// BlockStream serves a state request that is sent over a dedicated block stream.
+// The request is dispatched like any other message, and the stream is kept open
+// until the subscriber that handles the request closes it.
+// If no subscriber accepts the request, the stream is closed right away.
+func (c *commImpl) BlockStream(stream proto.Gossip_BlockStreamServer) error {
+	if c.isStopping() {
+		return errors.New("Shutting down")
+	}
+	connInfo, err := c.authenticateRemotePeer(stream, false)
+	if err != nil {
+		c.logger.Errorf("Authentication failed: %v", err)
+		return err
+	}
+	remoteAddress := extractRemoteAddress(stream)
+	m, err := readWithTimeout(stream, util.GetDurationOrDefault("peer.gossip.connTimeout", defConnTimeout), remoteAddress)
+	if err != nil {
+		c.logger.Warningf("Failed reading state request from %s: %v", remoteAddress, err)
+		return err
+	}
+	if m.GetStateRequest() == nil {
+		c.logger.Warning(remoteAddress, "opened a block stream but sent", m, "instead of a state request")
+		return errors.New("expected a state request")
+	}
+
+	req := &blockStreamRequest{
+		SignedGossipMessage: m,
+		stream:              stream,
+		connInfo:            connInfo,
+		progress:            make(chan struct{}, 1),
+		done:                make(chan struct{}),
+	}
+	// Ensure the subscriber won't send over the stream once it's no longer serviced
+	defer req.Close(nil)
+	if !c.msgPublisher.publish(req) {
+		c.logger.Warning("No subscriber accepted the state request of", remoteAddress, ", closing its block stream")
+		return errors.New("state request wasn't accepted")
+	}
+
+	for {
+		select {
+		case <-req.done:
+			return req.err
+		case <-req.progress:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case s := <-c.exitChan:
+			c.exitChan <- s
+			return errors.New("Shutting down")
+		case <-time.After(defBlockStreamIdleTimeout):
+			c.logger.Warning("Block stream of", remoteAddress, "was idle for", defBlockStreamIdleTimeout, ", closing it")
+			return errors.New("block stream is idle")
+		}
+	}
+}
+
+type blockStreamClient struct {
+	cc     *grpc.ClientConn
+	stream proto.Gossip_BlockStreamClient
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+// Recv returns the next message sent by the remote peer,
+// or io.EOF if the remote peer finished sending messages
+func (bs *blockStreamClient) Recv() (*proto.SignedGossipMessage, error) {
+	env, err := bs.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return env.ToGossipMessage()
+}
+
+// Close closes the stream
+func (bs *blockStreamClient) Close() {
+	bs.once.Do(func() {
+		bs.cancel()
+		bs.cc.Close()
+	})
+}
+
+type blockStreamRequest struct {
+	*proto.SignedGossipMessage
+	// Mutex serializes sends over the stream
+	sync.Mutex
+	stream   proto.Gossip_BlockStreamServer
+	connInfo *proto.ConnectionInfo
+	progress chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	err      error
+}
+
+// GetSourceEnvelope returns the Envelope the blockStreamRequest was
+// constructed with
+func (r *blockStreamRequest) GetSourceEnvelope() *proto.Envelope {
+	return r.Envelope
+}
+
+// GetGossipMessage returns the inner GossipMessage
+func (r *blockStreamRequest) GetGossipMessage() *proto.SignedGossipMessage {
+	return r.SignedGossipMessage
+}
+
+// GetConnectionInfo returns information about the remote peer
+// that opened the block stream
+func (r *blockStreamRequest) GetConnectionInfo() *proto.ConnectionInfo {
+	return r.connInfo
+}
+
+// Respond sends a msg to the remote peer over the block stream
+func (r *blockStreamRequest) Respond(msg *proto.GossipMessage) {
+	r.Send(msg)
+}
+
+// Ack does nothing, as block streams aren't acknowledged
+func (r *blockStreamRequest) Ack(err error) {
+}
+
+// Send sends a message to the remote peer over the block stream
+func (r *blockStreamRequest) Send(msg *proto.GossipMessage) error {
+	sMsg, err := msg.NoopSign()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	r.Lock()
+	defer r.Unlock()
+	select {
+	case <-r.done:
+		return errors.New("block stream is closed")
+	default:
+	}
+	if err := r.stream.Send(sMsg.Envelope); err != nil {
+		return errors.WithStack(err)
+	}
+	select {
+	case r.progress <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close ends the block stream, and reports the given error
+// to the remote peer if it isn't nil
+func (r *blockStreamRequest) Close(err error) {
+	r.once.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
@@ -33,6 +33,9 @@ type Comm interface {
 	// and an error if it's not.
 	Probe(peer *RemotePeer) error
 
+	// MeasureRTT pings a remote node and returns the round trip time of the ping
+	MeasureRTT(peer *RemotePeer) (time.Duration, error)
+
 	// Handshake authenticates a remote peer and returns
 	// (its identity, nil) on success and (nil, error)
 	Handshake(peer *RemotePeer) (api.PeerIdentityType, error)
@@ -47,6 +50,10 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// OpenBlockStream sends the given state request to a remote peer over a dedicated
+	// block stream, and returns the stream the response messages are received from
+	OpenBlockStream(peer *RemotePeer, msg *proto.SignedGossipMessage) (BlockStream, error)
+
 	// Stop stops the module
 	Stop()
 }
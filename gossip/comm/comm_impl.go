@@ -83,6 +83,8 @@ func NewCommInstanceWithServer(port int, idMapper identity.Mapper, peerIdentity
 		subscriptions:  make([]chan proto.ReceivedMessage, 0),
 		dialTimeout:    util.GetDurationOrDefault("peer.gossip.dialTimeout", defDialTimeout),
 		tlsCerts:       certs,
+		bwQuota:        newBandwidthQuotaFromConfig(),
+		tlsCertPinning: viper.GetBool("peer.gossip.tlsCertPinning"),
 	}
 	commInst.connStore = newConnStore(commInst, commInst.logger)
 
@@ -136,6 +138,8 @@ type commImpl struct {
 	port           int
 	stopping       int32
 	dialTimeout    time.Duration
+	bwQuota        *bandwidthQuota
+	tlsCertPinning bool
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -227,6 +231,14 @@ func (c *commImpl) sendToEndpoint(peer *RemotePeer, msg *proto.SignedGossipMessa
 	defer c.logger.Debug("Exiting")
 	var err error
 
+	if !c.bwQuota.acquire(msg) {
+		c.logger.Debug("Too many messages of channel", string(msg.Channel), "wait for bandwidth, dropping message to", peer.Endpoint)
+		return
+	}
+	if c.isStopping() {
+		return
+	}
+
 	conn, err := c.connStore.getConnection(peer)
 	if err == nil {
 		disConnectOnErr := func(err error) {
@@ -271,6 +283,37 @@ func (c *commImpl) Probe(remotePeer *RemotePeer) error {
 	return err
 }
 
+// MeasureRTT pings a remote node and returns the round trip time of the ping.
+// The ping is sent over the existing connection to the remote node if this node
+// initiated it, and otherwise over a dedicated connection whose establishment isn't measured.
+func (c *commImpl) MeasureRTT(remotePeer *RemotePeer) (time.Duration, error) {
+	if c.isStopping() {
+		return 0, errors.New("Stopping")
+	}
+	cl := c.connStore.clientOf(remotePeer)
+	if cl == nil {
+		var dialOpts []grpc.DialOption
+		dialOpts = append(dialOpts, c.secureDialOpts()...)
+		dialOpts = append(dialOpts, grpc.WithBlock())
+		dialOpts = append(dialOpts, c.opts...)
+		ctx, cancel := context.WithTimeout(context.Background(), c.dialTimeout)
+		defer cancel()
+		cc, err := grpc.DialContext(ctx, remotePeer.Endpoint, dialOpts...)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		defer cc.Close()
+		cl = proto.NewGossipClient(cc)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defConnTimeout)
+	defer cancel()
+	start := time.Now()
+	if _, err := cl.Ping(ctx, &proto.Empty{}); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return time.Since(start), nil
+}
+
 func (c *commImpl) Handshake(remotePeer *RemotePeer) (api.PeerIdentityType, error) {
 	var dialOpts []grpc.DialOption
 	dialOpts = append(dialOpts, c.secureDialOpts()...)
@@ -399,6 +442,7 @@ func extractRemoteAddress(stream stream) string {
 func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto.ConnectionInfo, error) {
 	ctx := stream.Context()
 	remoteAddress := extractRemoteAddress(stream)
+	remoteCert := extractCertificateFromContext(ctx)
 	remoteCertHash := extractCertificateHashFromContext(ctx)
 	var err error
 	var cMsg *proto.SignedGossipMessage
@@ -470,6 +514,14 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 		if !bytes.Equal(remoteCertHash, receivedMsg.TlsCertHash) {
 			return nil, errors.Errorf("Expected %v in remote hash of TLS cert, but got %v", remoteCertHash, receivedMsg.TlsCertHash)
 		}
+		// If TLS certificate pinning is enabled, make sure the TLS certificate belongs to
+		// the identity the remote peer claims, and not to some other peer that hijacked its endpoint.
+		if c.tlsCertPinning {
+			if err := verifyTLSCertPinning(receivedMsg.Identity, remoteCert); err != nil {
+				c.logger.Warningf("TLS certificate of %s isn't pinned to its identity: %v", remoteAddress, err)
+				return nil, err
+			}
+		}
 	}
 	// Final step - verify the signature on the connection message itself
 	verifier := func(peerIdentity []byte, signature, message []byte) error {
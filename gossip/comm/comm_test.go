@@ -12,6 +12,7 @@ import (
 	"crypto/sha256"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"math/rand"
 	"net"
 	"os"
@@ -352,6 +353,97 @@ func TestBasic(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func TestBlockStream(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(2611, naiveSec)
+	comm2, _ := newCommInstance(2612, naiveSec)
+	comm1.(*commImpl).SetDialOpts()
+	comm2.(*commImpl).SetDialOpts()
+	defer comm1.Stop()
+	defer comm2.Stop()
+
+	// State requests of blocks from sequence 100 on are rejected, like unauthorized requests are
+	isStateRequest := func(o interface{}) bool {
+		stateReq := o.(proto.ReceivedMessage).GetGossipMessage().GetStateRequest()
+		return stateReq != nil && stateReq.StartSeqNum < 100
+	}
+	inMsgs := comm2.Accept(isStateRequest)
+	go func() {
+		for m := range inMsgs {
+			req, isBlockStream := m.(BlockStreamRequest)
+			if !isBlockStream {
+				continue
+			}
+			stateReq := req.GetGossipMessage().GetStateRequest()
+			for seq := stateReq.StartSeqNum; seq <= stateReq.EndSeqNum; seq++ {
+				req.Send(&proto.GossipMessage{
+					Nonce: req.GetGossipMessage().Nonce,
+					Tag:   proto.GossipMessage_CHAN_OR_ORG,
+					Content: &proto.GossipMessage_StateResponse{
+						StateResponse: &proto.RemoteStateResponse{
+							Payloads: []*proto.Payload{{SeqNum: seq}},
+						},
+					},
+				})
+			}
+			req.Close(nil)
+		}
+	}()
+
+	stateRequest, _ := (&proto.GossipMessage{
+		Tag:   proto.GossipMessage_CHAN_OR_ORG,
+		Nonce: uint64(rand.Int()),
+		Content: &proto.GossipMessage_StateRequest{
+			StateRequest: &proto.RemoteStateRequest{
+				StartSeqNum: 5,
+				EndSeqNum:   14,
+			},
+		},
+	}).NoopSign()
+
+	// Block streams can only be opened with state requests
+	_, err := comm1.OpenBlockStream(remotePeer(2612), createGossipMsg())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block streams can only be opened with a state request")
+
+	// Block streams fail to be opened to peers whose PKI-ID isn't as expected
+	_, err = comm1.OpenBlockStream(&RemotePeer{Endpoint: "localhost:2612", PKIID: []byte("bla")}, stateRequest)
+	assert.Error(t, err)
+
+	stream, err := comm1.OpenBlockStream(remotePeer(2612), stateRequest)
+	assert.NoError(t, err)
+	defer stream.Close()
+	for seq := uint64(5); seq <= 14; seq++ {
+		m, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, stateRequest.Nonce, m.Nonce)
+		assert.Equal(t, seq, m.GetStateResponse().Payloads[0].SeqNum)
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	// Block streams of state requests that aren't accepted are closed right away
+	rejectedRequest, _ := (&proto.GossipMessage{
+		Tag:   proto.GossipMessage_CHAN_OR_ORG,
+		Nonce: uint64(rand.Int()),
+		Content: &proto.GossipMessage_StateRequest{
+			StateRequest: &proto.RemoteStateRequest{
+				StartSeqNum: 100,
+				EndSeqNum:   109,
+			},
+		},
+	}).NoopSign()
+	rejectedStream, err := comm1.OpenBlockStream(remotePeer(2612), rejectedRequest)
+	assert.NoError(t, err)
+	defer rejectedStream.Close()
+	start := time.Now()
+	_, err = rejectedStream.Recv()
+	assert.Error(t, err)
+	assert.NotEqual(t, io.EOF, err)
+	assert.Contains(t, err.Error(), "state request wasn't accepted")
+	assert.True(t, time.Since(start) < defBlockStreamIdleTimeout)
+}
+
 func TestProdConstructor(t *testing.T) {
 	t.Parallel()
 	srv, lsnr, dialOpts, certs := createGRPCLayer(20000)
@@ -522,6 +614,10 @@ func (bp *nonResponsivePeer) GossipStream(stream proto.Gossip_GossipStreamServer
 	return nil
 }
 
+func (bp *nonResponsivePeer) BlockStream(stream proto.Gossip_BlockStreamServer) error {
+	return nil
+}
+
 func (bp *nonResponsivePeer) stop() {
 	bp.Server.Stop()
 	bp.Listener.Close()
@@ -682,6 +778,31 @@ func TestReConnections(t *testing.T) {
 	waitForMessages(t, out1, 1, "Comm1 didn't receive a message from comm2 in a timely manner")
 }
 
+func TestMeasureRTT(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(10611, naiveSec)
+	defer comm1.Stop()
+	comm2, _ := newCommInstance(10612, naiveSec)
+	defer comm2.Stop()
+	time.Sleep(time.Duration(1) * time.Second)
+	// No connection exists yet, so the RTT is measured over a dedicated connection
+	rtt, err := comm1.MeasureRTT(remotePeer(10612))
+	assert.NoError(t, err)
+	assert.True(t, rtt > 0)
+	// Once comm1 connects to comm2, the RTT is measured over the existing connection,
+	// while comm2 still measures it over a dedicated connection
+	comm1.Send(createGossipMsg(), remotePeer(10612))
+	time.Sleep(time.Second)
+	rtt, err = comm1.MeasureRTT(remotePeer(10612))
+	assert.NoError(t, err)
+	assert.True(t, rtt > 0)
+	rtt, err = comm2.MeasureRTT(remotePeer(10611))
+	assert.NoError(t, err)
+	assert.True(t, rtt > 0)
+	_, err = comm1.MeasureRTT(remotePeer(9012))
+	assert.Error(t, err)
+}
+
 func TestProbe(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(6611, naiveSec)
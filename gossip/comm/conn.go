@@ -129,6 +129,18 @@ func (cs *connectionStore) connNum() int {
 	return len(cs.pki2Conn)
 }
 
+// clientOf returns the gRPC stub of the connection to the given remote peer,
+// or nil if there is no connection to it, or if the remote peer initiated it
+func (cs *connectionStore) clientOf(peer *RemotePeer) proto.GossipClient {
+	cs.RLock()
+	defer cs.RUnlock()
+
+	if conn, exists := cs.pki2Conn[string(peer.PKIID)]; exists {
+		return conn.cl
+	}
+	return nil
+}
+
 func (cs *connectionStore) closeConn(peer *RemotePeer) {
 	cs.Lock()
 	defer cs.Unlock()
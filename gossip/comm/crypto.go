@@ -7,11 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/pem"
 	"math/big"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/peer"
 
@@ -67,6 +72,15 @@ func certHashFromRawCert(rawCert []byte) []byte {
 
 // ExtractCertificateHash extracts the hash of the certificate from the stream
 func extractCertificateHashFromContext(ctx context.Context) []byte {
+	cert := extractCertificateFromContext(ctx)
+	if cert == nil {
+		return nil
+	}
+	return certHashFromRawCert(cert.Raw)
+}
+
+// extractCertificateFromContext extracts the TLS certificate of the remote peer from the stream
+func extractCertificateFromContext(ctx context.Context) *sm2.Certificate {
 	pr, extracted := peer.FromContext(ctx)
 	if !extracted {
 		return nil
@@ -85,6 +99,32 @@ func extractCertificateHashFromContext(ctx context.Context) []byte {
 	if len(certs) == 0 {
 		return nil
 	}
-	raw := certs[0].Raw
-	return certHashFromRawCert(raw)
+	return certs[0]
+}
+
+// verifyTLSCertPinning verifies that the given TLS certificate is either the certificate
+// embedded in the given peer identity, or that it has the same public key.
+func verifyTLSCertPinning(peerIdentity api.PeerIdentityType, tlsCert *sm2.Certificate) error {
+	if tlsCert == nil {
+		return errors.New("no TLS certificate")
+	}
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(peerIdentity, sID); err != nil {
+		return errors.Wrap(err, "failed unmarshaling peer identity")
+	}
+	bl, _ := pem.Decode(sID.IdBytes)
+	if bl == nil {
+		return errors.New("peer identity doesn't contain a PEM encoded certificate")
+	}
+	identityCert, err := sm2.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing the certificate of the peer identity")
+	}
+	if bytes.Equal(identityCert.Raw, tlsCert.Raw) {
+		return nil
+	}
+	if !bytes.Equal(identityCert.RawSubjectPublicKeyInfo, tlsCert.RawSubjectPublicKeyInfo) {
+		return errors.Errorf("TLS certificate of %s doesn't match the identity of %s", tlsCert.Subject.CommonName, identityCert.Subject.CommonName)
+	}
+	return nil
 }
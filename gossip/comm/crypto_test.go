@@ -7,16 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -72,6 +79,10 @@ func (s *gossipTestServer) Ping(context.Context, *proto.Empty) (*proto.Empty, er
 	return &proto.Empty{}, nil
 }
 
+func (s *gossipTestServer) BlockStream(stream proto.Gossip_BlockStreamServer) error {
+	return nil
+}
+
 func TestCertificateExtraction(t *testing.T) {
 	cert := GenerateCertificatesOrPanic()
 	srv := createTestServer(t, &cert)
@@ -108,3 +119,50 @@ func TestCertificateExtraction(t *testing.T) {
 	assert.Equal(t, clientSideCertHash, srv.selfCertHash, "Server self hash isn't equal to client side hash")
 	assert.Equal(t, clientCertHash, srv.remoteCertHash, "Server side and client hash aren't equal")
 }
+
+func TestVerifyTLSCertPinning(t *testing.T) {
+	identityOf := func(rawCert []byte) api.PeerIdentityType {
+		return utils.MarshalOrPanic(&msp.SerializedIdentity{
+			Mspid:   "Org1MSP",
+			IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rawCert}),
+		})
+	}
+	parse := func(rawCert []byte) *sm2.Certificate {
+		cert, err := sm2.ParseCertificate(rawCert)
+		assert.NoError(t, err)
+		return cert
+	}
+
+	pair := GenerateCertificatesOrPanic()
+	cert := parse(pair.Certificate[0])
+	identity := identityOf(pair.Certificate[0])
+
+	// Scenario I: The TLS certificate is the certificate of the identity
+	assert.NoError(t, verifyTLSCertPinning(identity, cert))
+
+	// Scenario II: The TLS certificate is a different certificate with the same public key
+	privateKey := pair.PrivateKey.(*sm2.PrivateKey)
+	template := *cert
+	template.SerialNumber = new(big.Int).Add(cert.SerialNumber, big.NewInt(1))
+	rawCert, err := sm2.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	assert.NoError(t, err)
+	assert.NoError(t, verifyTLSCertPinning(identity, parse(rawCert)))
+
+	// Scenario III: The TLS certificate belongs to some other peer
+	otherPair := GenerateCertificatesOrPanic()
+	err = verifyTLSCertPinning(identity, parse(otherPair.Certificate[0]))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the identity")
+
+	// Scenario IV: No TLS certificate was presented
+	assert.EqualError(t, verifyTLSCertPinning(identity, nil), "no TLS certificate")
+
+	// Scenario V: The identity doesn't contain a certificate
+	err = verifyTLSCertPinning(utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("p1")}), cert)
+	assert.EqualError(t, err, "peer identity doesn't contain a PEM encoded certificate")
+
+	// Scenario VI: The identity is malformed
+	err = verifyTLSCertPinning(api.PeerIdentityType{1, 2, 3}, cert)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshaling peer identity")
+}
@@ -67,12 +67,18 @@ func (m *ChannelDeMultiplexer) AddChannel(predicate common.MessageAcceptor) chan
 // DeMultiplex broadcasts the message to all channels that were returned
 // by AddChannel calls and that hold the respected predicates.
 func (m *ChannelDeMultiplexer) DeMultiplex(msg interface{}) {
+	m.publish(msg)
+}
+
+// publish broadcasts the message like DeMultiplex does,
+// and returns whether the predicate of any channel held for it
+func (m *ChannelDeMultiplexer) publish(msg interface{}) (accepted bool) {
 	defer func() {
 		recover()
 	}() // recover from sending on a closed channel
 
 	if m.isClosed() {
-		return
+		return false
 	}
 
 	m.lock.RLock()
@@ -81,7 +87,9 @@ func (m *ChannelDeMultiplexer) DeMultiplex(msg interface{}) {
 
 	for _, ch := range channels {
 		if ch.pred(msg) {
+			accepted = true
 			ch.ch <- msg
 		}
 	}
+	return accepted
 }
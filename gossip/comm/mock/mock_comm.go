@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
 )
 
 // Mock which aims to simulate socket
@@ -185,6 +186,16 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// MeasureRTT always returns a zero round trip time
+func (mock *commMock) MeasureRTT(peer *comm.RemotePeer) (time.Duration, error) {
+	return 0, nil
+}
+
+// OpenBlockStream isn't supported by the mocked communication object
+func (mock *commMock) OpenBlockStream(peer *comm.RemotePeer, msg *proto.SignedGossipMessage) (comm.BlockStream, error) {
+	return nil, errors.New("block streams aren't supported")
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
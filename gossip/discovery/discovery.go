@@ -8,6 +8,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -115,7 +116,8 @@ type Discovery interface {
 	// UpdateMetadata updates this instance's metadata
 	UpdateMetadata([]byte)
 
-	// UpdateEndpoint updates this instance's endpoint
+	// UpdateEndpoint updates this instance's endpoint,
+	// and publishes it to the other peers if it was changed
 	UpdateEndpoint(string)
 
 	// Stops this instance
@@ -124,6 +126,9 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// MembershipView returns a snapshot of the alive and dead members in the view
+	MembershipView() MembershipView
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
@@ -135,6 +140,25 @@ type Discovery interface {
 	Connect(member NetworkMember, id identifier)
 }
 
+// MemberState is a NetworkMember along with the aliveness
+// information the discovery layer keeps about it
+type MemberState struct {
+	NetworkMember
+	// LastSeen is the last time the member was heard from
+	LastSeen time.Time
+	// StartTime is the incarnation time the member advertised
+	StartTime time.Time
+	// SeqNum is the sequence number of the last alive message of the member
+	SeqNum uint64
+}
+
+// MembershipView is a snapshot of the alive and dead members
+// known to the discovery layer
+type MembershipView struct {
+	Alive []MemberState
+	Dead  []MemberState
+}
+
 // Members represents an aggregation of NetworkMembers
 type Members []NetworkMember
 
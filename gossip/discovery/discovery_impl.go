@@ -484,12 +484,19 @@ func (d *gossipDiscoveryImpl) handleAliveMessage(m *proto.SignedGossipMessage) {
 	pkiID := m.GetAliveMsg().Membership.PkiId
 	if equalPKIid(pkiID, d.self.PKIid) {
 		d.logger.Debug("Got alive message about ourselves,", m)
+		if m.GetAliveMsg().Timestamp.IncNum == d.incTime {
+			// An echo of one of our own alive messages, possibly one that
+			// was sent before our endpoint was updated
+			return
+		}
+		d.lock.RLock()
 		diffExternalEndpoint := d.self.Endpoint != m.GetAliveMsg().Membership.Endpoint
 		var diffInternalEndpoint bool
 		secretEnvelope := m.GetSecretEnvelope()
 		if secretEnvelope != nil && secretEnvelope.InternalEndpoint() != "" {
 			diffInternalEndpoint = secretEnvelope.InternalEndpoint() != d.self.InternalEndpoint
 		}
+		d.lock.RUnlock()
 		if diffInternalEndpoint || diffExternalEndpoint {
 			d.logger.Error("Bad configuration detected: Received AliveMessage from a peer with the same PKI-ID as myself:", m.GossipMessage)
 		}
@@ -780,6 +787,16 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 	d.logger.Debugf("Entering: learnedMembers={%v}", aliveArr)
 	defer d.logger.Debug("Exiting")
 
+	var relocated []NetworkMember
+	defer func() {
+		// Connections to members that changed their endpoint are closed,
+		// so that they would be re-established with the new endpoint
+		for i := range relocated {
+			d.logger.Info("Closing connection to", relocated[i], "since it changed its endpoint")
+			d.comm.CloseConn(&relocated[i])
+		}
+	}()
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -801,9 +818,13 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 
 		// update member's data
 		member := d.id2Member[string(am.Membership.PkiId)]
+		prevMember := *member
 		member.Endpoint = am.Membership.Endpoint
 		member.Metadata = am.Membership.Metadata
 		member.InternalEndpoint = internalEndpoint
+		if prevMember.PreferredEndpoint() != "" && prevMember.PreferredEndpoint() != member.PreferredEndpoint() {
+			relocated = append(relocated, prevMember)
+		}
 
 		if _, isKnownAsDead := d.deadLastTS[string(am.Membership.PkiId)]; isKnownAsDead {
 			d.logger.Warning(am.Membership, "has already expired")
@@ -919,6 +940,41 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+func (d *gossipDiscoveryImpl) MembershipView() MembershipView {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return MembershipView{
+		Alive: d.memberStates(d.aliveMembership, d.aliveLastTS),
+		Dead:  d.memberStates(d.deadMembership, d.deadLastTS),
+	}
+}
+
+func (d *gossipDiscoveryImpl) memberStates(membership *util.MembershipStore, lastTS map[string]*timestamp) []MemberState {
+	res := []MemberState{}
+	for _, m := range membership.ToSlice() {
+		member := m.GetAliveMsg().Membership
+		state := MemberState{
+			NetworkMember: NetworkMember{
+				PKIid:    member.PkiId,
+				Endpoint: member.Endpoint,
+				Metadata: member.Metadata,
+				Envelope: m.Envelope,
+			},
+		}
+		if netMember := d.id2Member[string(member.PkiId)]; netMember != nil {
+			state.InternalEndpoint = netMember.InternalEndpoint
+		}
+		if ts := lastTS[string(member.PkiId)]; ts != nil {
+			state.LastSeen = ts.lastSeen
+			state.StartTime = ts.incTime
+			state.SeqNum = ts.seqNum
+		}
+		res = append(res, state)
+	}
+	return res
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -931,9 +987,21 @@ func (d *gossipDiscoveryImpl) UpdateMetadata(md []byte) {
 
 func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.lock.Lock()
-	defer d.lock.Unlock()
-
+	changed := d.self.Endpoint != endpoint
 	d.self.Endpoint = endpoint
+	d.lock.Unlock()
+
+	if !changed {
+		return
+	}
+	// Publish the new endpoint right away instead of waiting for the next alive message
+	d.logger.Info("Updated endpoint to", endpoint)
+	msg, err := d.createSignedAliveMessage(true)
+	if err != nil {
+		d.logger.Warningf("Failed creating alive message: %+v", errors.WithStack(err))
+		return
+	}
+	d.comm.Gossip(msg)
 }
 
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
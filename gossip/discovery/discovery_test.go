@@ -81,6 +81,7 @@ type dummyCommModule struct {
 	lastSeqs     map[string]uint64
 	shouldGossip bool
 	mock         *mock.Mock
+	closedConns  []string
 }
 
 type gossipInstance struct {
@@ -200,6 +201,7 @@ func (comm *dummyCommModule) CloseConn(peer *NetworkMember) {
 	comm.lock.Lock()
 	defer comm.lock.Unlock()
 
+	comm.closedConns = append(comm.closedConns, peer.Endpoint)
 	if _, exists := comm.streams[peer.Endpoint]; !exists {
 		return
 	}
@@ -311,6 +313,10 @@ func (g *gossipInstance) Ping(context.Context, *proto.Empty) (*proto.Empty, erro
 	return &proto.Empty{}, nil
 }
 
+func (g *gossipInstance) BlockStream(stream proto.Gossip_BlockStreamServer) error {
+	return nil
+}
+
 var noopPolicy = func(remotePeer *NetworkMember) (Sieve, EnvelopeFilter) {
 	return func(msg *proto.SignedGossipMessage) bool {
 			return true
@@ -526,6 +532,58 @@ func TestUpdate(t *testing.T) {
 	stopInstances(t, instances)
 }
 
+func TestEndpointRotation(t *testing.T) {
+	t.Parallel()
+	inst1 := createDiscoveryInstanceWithNoGossip(22670, "d1", []string{})
+	inst2 := createDiscoveryInstanceWithNoGossip(22671, "d2", []string{})
+	defer inst1.Stop()
+	defer inst2.Stop()
+
+	handleAlive := func(from *gossipInstance) {
+		aliveMsg, err := from.discoveryImpl().createSignedAliveMessage(true)
+		assert.NoError(t, err)
+		inst1.discoveryImpl().handleMsgFromComm(&dummyReceivedMessage{
+			msg: aliveMsg,
+			info: &proto.ConnectionInfo{
+				ID: common.PKIidType("d2"),
+			},
+		})
+	}
+
+	handleAlive(inst2)
+	assert.Equal(t, "localhost:22671", inst1.Lookup(common.PKIidType("localhost:22671")).Endpoint)
+
+	// Only the metadata changed, so the connection shouldn't be closed
+	inst2.UpdateMetadata([]byte("bla bla"))
+	handleAlive(inst2)
+	inst1.comm.lock.RLock()
+	assert.Empty(t, inst1.comm.closedConns)
+	inst1.comm.lock.RUnlock()
+
+	// The endpoint changed, so the connection to the old endpoint should be closed
+	inst2.discoveryImpl().lock.Lock()
+	inst2.discoveryImpl().self.InternalEndpoint = "localhost:22672"
+	inst2.discoveryImpl().lock.Unlock()
+	inst2.UpdateEndpoint("localhost:22672")
+	handleAlive(inst2)
+	assert.Equal(t, "localhost:22672", inst1.Lookup(common.PKIidType("localhost:22671")).PreferredEndpoint())
+	inst1.comm.lock.RLock()
+	assert.Equal(t, []string{"localhost:22671"}, inst1.comm.closedConns)
+	inst1.comm.lock.RUnlock()
+
+	// Echoes of our own alive messages with the old endpoint are ignored
+	staleMsg, err := inst1.discoveryImpl().createSignedAliveMessage(true)
+	assert.NoError(t, err)
+	inst1.UpdateEndpoint("localhost:22673")
+	inst1.discoveryImpl().handleMsgFromComm(&dummyReceivedMessage{
+		msg: staleMsg,
+		info: &proto.ConnectionInfo{
+			ID: common.PKIidType("d1"),
+		},
+	})
+	assert.Equal(t, "localhost:22673", inst1.Self().Endpoint)
+}
+
 func TestInitiateSync(t *testing.T) {
 	t.Parallel()
 	nodeNum := 10
@@ -608,6 +666,38 @@ func TestExpiration(t *testing.T) {
 	waitUntilOrFailBlocking(t, stopAction.Wait)
 }
 
+func TestMembershipView(t *testing.T) {
+	t.Parallel()
+	bootPeers := []string{bootPeer(14611)}
+	inst1 := createDiscoveryInstance(14611, "d1", bootPeers)
+	inst2 := createDiscoveryInstance(14612, "d2", bootPeers)
+	inst3 := createDiscoveryInstance(14613, "d3", bootPeers)
+	assertMembership(t, []*gossipInstance{inst1, inst2, inst3}, 2)
+
+	view := inst1.MembershipView()
+	assert.Len(t, view.Alive, 2)
+	assert.Empty(t, view.Dead)
+	for _, member := range view.Alive {
+		assert.NotEmpty(t, member.Endpoint)
+		assert.NotEmpty(t, member.InternalEndpoint)
+		assert.False(t, member.LastSeen.IsZero())
+		assert.False(t, member.StartTime.IsZero())
+		assert.NotZero(t, member.SeqNum)
+	}
+
+	waitUntilOrFailBlocking(t, inst3.Stop)
+	waitUntilOrFail(t, func() bool {
+		view := inst1.MembershipView()
+		return len(view.Alive) == 1 && len(view.Dead) == 1
+	})
+	view = inst1.MembershipView()
+	assert.Equal(t, inst3.Self().PKIid, view.Dead[0].PKIid)
+	assert.Equal(t, inst2.Self().PKIid, view.Alive[0].PKIid)
+	assert.True(t, view.Alive[0].LastSeen.After(view.Dead[0].LastSeen))
+
+	stopInstances(t, []*gossipInstance{inst1, inst2})
+}
+
 func TestGetFullMembership(t *testing.T) {
 	t.Parallel()
 	nodeNum := 15
@@ -8,12 +8,14 @@ package election
 
 import (
 	"bytes"
+	"encoding/hex"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/op/go-logging"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -97,6 +99,75 @@ type LeaderElectionService interface {
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Status returns the state of the leader election, as observed by this peer
+	Status() Status
+
+	// SetOverride overrides the outcome of the leader election until
+	// it is invoked with NoOverride
+	SetOverride(override Override)
+}
+
+// Override overrides the outcome of the leader election,
+// and is used by operators during maintenance
+type Override int32
+
+const (
+	// NoOverride means the peer takes part in the leader election
+	NoOverride Override = iota
+	// ClaimLeadership means the peer is a leader regardless of the leader election,
+	// and doesn't step down when other peers declare themselves as leaders
+	ClaimLeadership
+	// RelinquishLeadership means the peer is never a leader,
+	// and doesn't take part in the leader election
+	RelinquishLeadership
+)
+
+var overrideNames = map[Override]string{
+	NoOverride:           "none",
+	ClaimLeadership:      "claim",
+	RelinquishLeadership: "relinquish",
+}
+
+// String returns a string representation of this Override
+func (o Override) String() string {
+	if name, exists := overrideNames[o]; exists {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText marshals this Override to its string representation
+func (o Override) MarshalText() ([]byte, error) {
+	if _, exists := overrideNames[o]; !exists {
+		return nil, errors.Errorf("unknown override %d", o)
+	}
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText sets this Override from its string representation
+func (o *Override) UnmarshalText(text []byte) error {
+	for override, name := range overrideNames {
+		if name == string(text) {
+			*o = override
+			return nil
+		}
+	}
+	return errors.Errorf("unknown override %s", string(text))
+}
+
+// Status describes the state of the leader election, as observed by a peer
+type Status struct {
+	// IsLeader is whether the peer is a leader
+	IsLeader bool `json:"is_leader"`
+	// Leader is the hex encoded ID of the leader the peer knows of, if any
+	Leader string `json:"leader,omitempty"`
+	// Override is the override of the leader election outcome that is in effect
+	Override Override `json:"override"`
+	// Transitions is the number of times the peer either became a leader or stopped being one
+	Transitions uint64 `json:"transitions"`
+	// LastTransition is the time of the last transition, if any
+	LastTransition *time.Time `json:"last_transition,omitempty"`
 }
 
 type peerID []byte
@@ -148,18 +219,23 @@ type leaderElectionSvcImpl struct {
 	id        peerID
 	proposals *util.Set
 	sync.Mutex
-	stopChan      chan struct{}
-	interruptChan chan struct{}
-	stopWG        sync.WaitGroup
-	isLeader      int32
-	toDie         int32
-	leaderExists  int32
-	yield         int32
-	sleeping      bool
-	adapter       LeaderElectionAdapter
-	logger        *logging.Logger
-	callback      leadershipCallback
-	yieldTimer    *time.Timer
+	stopChan       chan struct{}
+	interruptChan  chan struct{}
+	stopWG         sync.WaitGroup
+	isLeader       int32
+	toDie          int32
+	leaderExists   int32
+	yield          int32
+	override       int32
+	sleeping       bool
+	adapter        LeaderElectionAdapter
+	logger         *logging.Logger
+	callback       leadershipCallback
+	yieldTimer     *time.Timer
+	leaderID       peerID
+	lastDeclared   time.Time
+	transitions    uint64
+	lastTransition time.Time
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -202,10 +278,12 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		le.proposals.Add(string(msg.SenderID()))
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
+		le.leaderID = msg.SenderID()
+		le.lastDeclared = time.Now()
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() && le.getOverride() != ClaimLeadership {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -264,9 +342,9 @@ func (le *leaderElectionSvcImpl) run() {
 func (le *leaderElectionSvcImpl) leaderElection() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
-	// If we're yielding to other peers, do not participate
-	// in leader election
-	if le.isYielding() {
+	// If we're yielding to other peers, or relinquished the leadership,
+	// do not participate in leader election
+	if le.isYielding() || le.getOverride() == RelinquishLeadership {
 		return
 	}
 	// Propose ourselves as a leader
@@ -280,7 +358,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		return
 	}
 
-	if le.isYielding() {
+	if le.isYielding() || le.getOverride() == RelinquishLeadership {
 		le.logger.Debug(le.id, ": Aborting leader election because yielding")
 		return
 	}
@@ -294,6 +372,12 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	}
 	// If we got here, there is no one that proposed being a leader
 	// that's a better candidate than us.
+	le.Lock()
+	defer le.Unlock()
+	// The leadership might have been claimed or relinquished in the meantime
+	if le.IsLeader() || le.getOverride() == RelinquishLeadership {
+		return
+	}
 	le.beLeader()
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
@@ -371,15 +455,25 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 	return isLeader
 }
 
+// beLeader makes this peer a leader, and should be invoked while holding the lock
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
 	atomic.StoreInt32(&le.isLeader, int32(1))
+	le.leaderID = le.id
+	le.transitions++
+	le.lastTransition = time.Now()
 	le.callback(true)
 }
 
+// stopBeingLeader makes this peer a follower, and should be invoked while holding the lock
 func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.logger.Info(le.id, "Stopped being a leader")
 	atomic.StoreInt32(&le.isLeader, int32(0))
+	if bytes.Equal(le.leaderID, le.id) {
+		le.leaderID = nil
+	}
+	le.transitions++
+	le.lastTransition = time.Now()
 	le.callback(false)
 }
 
@@ -387,6 +481,10 @@ func (le *leaderElectionSvcImpl) shouldStop() bool {
 	return atomic.LoadInt32(&le.toDie) == int32(1)
 }
 
+func (le *leaderElectionSvcImpl) getOverride() Override {
+	return Override(atomic.LoadInt32(&le.override))
+}
+
 func (le *leaderElectionSvcImpl) isYielding() bool {
 	return atomic.LoadInt32(&le.yield) == int32(1)
 }
@@ -407,6 +505,11 @@ func (le *leaderElectionSvcImpl) Yield() {
 	if !le.IsLeader() || le.isYielding() {
 		return
 	}
+	// A claimed leadership is only relinquished by the operator
+	if le.getOverride() == ClaimLeadership {
+		le.logger.Warning(le.id, ": Not yielding since the leadership was claimed")
+		return
+	}
 	// Turn on the yield flag
 	atomic.StoreInt32(&le.yield, int32(1))
 	// Stop being a leader
@@ -419,6 +522,53 @@ func (le *leaderElectionSvcImpl) Yield() {
 	})
 }
 
+// Status returns the state of the leader election, as observed by this peer
+func (le *leaderElectionSvcImpl) Status() Status {
+	le.Lock()
+	defer le.Unlock()
+	status := Status{
+		IsLeader:    le.IsLeader(),
+		Override:    le.getOverride(),
+		Transitions: le.transitions,
+	}
+	// A leader is considered known as long as its declarations are
+	// received, or if this peer is the leader
+	if status.IsLeader || (le.leaderID != nil && time.Since(le.lastDeclared) < getLeaderAliveThreshold()) {
+		status.Leader = hex.EncodeToString(le.leaderID)
+	}
+	if le.transitions > 0 {
+		lastTransition := le.lastTransition
+		status.LastTransition = &lastTransition
+	}
+	return status
+}
+
+// SetOverride overrides the outcome of the leader election until
+// it is invoked with NoOverride
+func (le *leaderElectionSvcImpl) SetOverride(override Override) {
+	le.Lock()
+	defer le.Unlock()
+	le.logger.Info(le.id, ": Setting leader election override to", override)
+	atomic.StoreInt32(&le.override, int32(override))
+	switch override {
+	case ClaimLeadership:
+		if !le.IsLeader() {
+			le.beLeader()
+		}
+		atomic.StoreInt32(&le.leaderExists, int32(1))
+	case RelinquishLeadership:
+		if le.IsLeader() {
+			le.stopBeingLeader()
+		}
+		// Clear the leader exists flag since it could be that we are the leader
+		atomic.StoreInt32(&le.leaderExists, int32(0))
+	}
+	// Wake up the election routine, so it would act upon the override
+	if le.sleeping && len(le.interruptChan) == 0 {
+		le.interruptChan <- struct{}{}
+	}
+}
+
 // Stop stops the LeaderElectionService
 func (le *leaderElectionSvcImpl) Stop() {
 	le.logger.Debug(le.id, ": Entering")
@@ -372,6 +372,83 @@ func TestYieldAllPeers(t *testing.T) {
 	assert.Equal(t, "p0", leaders[0])
 }
 
+func TestOverride(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers spawn and p0 is elected as the leader.
+	// Afterwards, p2 claims the leadership and p0 relinquishes it.
+	// Expected outcome:
+	// (1) p2 is the only leader, and remains the leader even though p1 has a lower ID
+	// (2) When the overrides are removed, p2 remains the leader until it yields,
+	// and then the leader election resumes and p0 becomes the leader
+	peers := createPeers(0, 0, 1, 2)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+
+	peers[2].SetOverride(ClaimLeadership)
+	assert.True(t, peers[2].IsLeader())
+	assert.True(t, peers[2].isLeaderFromCallback())
+	peers[0].SetOverride(RelinquishLeadership)
+	assert.False(t, peers[0].IsLeader())
+	assert.False(t, peers[0].isLeaderFromCallback())
+
+	onlyP2isLeader := func() bool {
+		return !peers[0].IsLeader() && !peers[1].IsLeader() && peers[2].IsLeader()
+	}
+	waitForBoolFunc(t, onlyP2isLeader, true)
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	waitForBoolFunc(t, onlyP2isLeader, true)
+
+	// The leader the other peers know of is p2
+	waitForBoolFunc(t, func() bool {
+		return peers[0].Status().Leader == fmt.Sprintf("%x", "p2") && peers[1].Status().Leader == fmt.Sprintf("%x", "p2")
+	}, true)
+	status := peers[2].Status()
+	assert.True(t, status.IsLeader)
+	assert.Equal(t, ClaimLeadership, status.Override)
+	assert.Equal(t, uint64(1), status.Transitions)
+	assert.NotNil(t, status.LastTransition)
+	// p0 became a leader and then relinquished the leadership
+	assert.Equal(t, uint64(2), peers[0].Status().Transitions)
+
+	peers[0].SetOverride(NoOverride)
+	peers[2].SetOverride(NoOverride)
+	time.Sleep(getLeaderAliveThreshold() * 2)
+	waitForBoolFunc(t, onlyP2isLeader, true)
+	peers[2].Yield()
+	waitForBoolFunc(t, func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p0"
+	}, true)
+}
+
+func TestOverrideYield(t *testing.T) {
+	t.Parallel()
+	// Scenario: a single peer claims the leadership and then yields.
+	// Expected outcome: the peer doesn't yield
+	peers := createPeers(0, 0)
+	peers[0].SetOverride(ClaimLeadership)
+	assert.True(t, peers[0].IsLeader())
+	peers[0].Yield()
+	assert.True(t, peers[0].IsLeader())
+	assert.Equal(t, uint64(1), peers[0].Status().Transitions)
+}
+
+func TestOverrideText(t *testing.T) {
+	for _, override := range []Override{NoOverride, ClaimLeadership, RelinquishLeadership} {
+		text, err := override.MarshalText()
+		assert.NoError(t, err)
+		var o Override
+		assert.NoError(t, o.UnmarshalText(text))
+		assert.Equal(t, override, o)
+	}
+	assert.Equal(t, "claim", ClaimLeadership.String())
+
+	_, err := Override(5).MarshalText()
+	assert.EqualError(t, err, "unknown override 5")
+	var o Override
+	assert.EqualError(t, o.UnmarshalText([]byte("bla")), "unknown override bla")
+}
+
 func TestPartition(t *testing.T) {
 	t.Parallel()
 	// Scenario: peers spawn together, and then after a while a network partition occurs
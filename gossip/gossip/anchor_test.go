@@ -73,6 +73,10 @@ func (p *peerMock) Ping(context.Context, *proto.Empty) (*proto.Empty, error) {
 	return &proto.Empty{}, nil
 }
 
+func (p *peerMock) BlockStream(stream proto.Gossip_BlockStreamServer) error {
+	return nil
+}
+
 func newPeerMock(port int, expectedMsgs2Receive int, t *testing.T, msgAssertions ...msgInspection) *peerMock {
 	listenAddress := fmt.Sprintf(":%d", port)
 	ll, err := net.Listen("tcp", listenAddress)
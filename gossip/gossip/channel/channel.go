@@ -66,6 +66,10 @@ type GossipChannel interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode)
 
+	// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+	// the peer publishes to other peers in the channel
+	UpdateLedgerSnapshots(heights []uint64)
+
 	// IsOrgInChannel returns whether the given organization is in the channel
 	IsOrgInChannel(membersOrg api.OrgIdentityType) bool
 
@@ -306,11 +310,13 @@ func (gc *gossipChannel) LeaveChannel() {
 
 	var chaincodes []*proto.Chaincode
 	var height uint64
+	var snapshotHeights []uint64
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 		height = prevMsg.GetStateInfo().Properties.LedgerHeight
+		snapshotHeights = prevMsg.GetStateInfo().Properties.SnapshotHeights
 	}
-	gc.updateProperties(height, chaincodes, true)
+	gc.updateProperties(height, chaincodes, snapshotHeights, true)
 }
 
 func (gc *gossipChannel) hasLeftChannel() bool {
@@ -808,12 +814,14 @@ func (gc *gossipChannel) UpdateLedgerHeight(height uint64) {
 	defer gc.Unlock()
 
 	var chaincodes []*proto.Chaincode
+	var snapshotHeights []uint64
 	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+		snapshotHeights = prevMsg.GetStateInfo().Properties.SnapshotHeights
 	}
-	gc.updateProperties(height, chaincodes, leftChannel)
+	gc.updateProperties(height, chaincodes, snapshotHeights, leftChannel)
 }
 
 // UpdateChaincodes updates the chaincodes the peer publishes
@@ -823,12 +831,31 @@ func (gc *gossipChannel) UpdateChaincodes(chaincodes []*proto.Chaincode) {
 	defer gc.Unlock()
 
 	var ledgerHeight uint64 = 1
+	var snapshotHeights []uint64
+	var leftChannel bool
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		snapshotHeights = prevMsg.GetStateInfo().Properties.SnapshotHeights
+	}
+	gc.updateProperties(ledgerHeight, chaincodes, snapshotHeights, leftChannel)
+}
+
+// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+// the peer publishes to other peers in the channel
+func (gc *gossipChannel) UpdateLedgerSnapshots(heights []uint64) {
+	gc.Lock()
+	defer gc.Unlock()
+
+	var ledgerHeight uint64 = 1
+	var chaincodes []*proto.Chaincode
 	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 	}
-	gc.updateProperties(ledgerHeight, chaincodes, leftChannel)
+	gc.updateProperties(ledgerHeight, chaincodes, heights, leftChannel)
 }
 
 // UpdateStateInfo updates this channel's StateInfo message
@@ -840,7 +867,7 @@ func (gc *gossipChannel) updateStateInfo(msg *proto.SignedGossipMessage) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
-func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool) {
+func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, snapshotHeights []uint64, leftChannel bool) {
 	stateInfMsg := &proto.StateInfo{
 		Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
 		PkiId:       gc.pkiID,
@@ -849,9 +876,10 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 			SeqNum: uint64(time.Now().UnixNano()),
 		},
 		Properties: &proto.Properties{
-			LeftChannel:  leftChannel,
-			LedgerHeight: ledgerHeight,
-			Chaincodes:   chaincodes,
+			LeftChannel:     leftChannel,
+			LedgerHeight:    ledgerHeight,
+			Chaincodes:      chaincodes,
+			SnapshotHeights: snapshotHeights,
 		},
 	}
 	m := &proto.GossipMessage{
@@ -305,6 +305,39 @@ func TestSelf(t *testing.T) {
 	assert.Equal(t, gMsg.GetStateInfo().PkiId, []byte("1"))
 }
 
+func TestUpdateLedgerSnapshots(t *testing.T) {
+	t.Parallel()
+
+	cs := &cryptoService{}
+	pkiID1 := common.PKIidType("1")
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			string(orgInChannelA): {},
+		},
+	}
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(pkiID1, orgInChannelA, cs, channelA, adapter, jcm)
+	gc.UpdateLedgerHeight(10)
+	gc.UpdateLedgerSnapshots([]uint64{5, 8})
+	props := gc.Self().GetStateInfo().Properties
+	assert.Equal(t, uint64(10), props.LedgerHeight)
+	assert.Equal(t, []uint64{5, 8}, props.SnapshotHeights)
+
+	// Updating the other properties preserves the advertised snapshot heights
+	gc.UpdateLedgerHeight(11)
+	gc.UpdateChaincodes([]*proto.Chaincode{{Name: "example", Version: "1.0"}})
+	props = gc.Self().GetStateInfo().Properties
+	assert.Equal(t, uint64(11), props.LedgerHeight)
+	assert.Equal(t, []uint64{5, 8}, props.SnapshotHeights)
+
+	gc.LeaveChannel()
+	props = gc.Self().GetStateInfo().Properties
+	assert.True(t, props.LeftChannel)
+	assert.Equal(t, []uint64{5, 8}, props.SnapshotHeights)
+}
+
 func TestMsgStoreNotExpire(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +93,19 @@ func (cs *channelState) getGossipChannelByChainID(chainID common.ChainID) channe
 	return cs.channels[string(chainID)]
 }
 
+func (cs *channelState) channelNames() []string {
+	if cs.isStopping() {
+		return nil
+	}
+	cs.RLock()
+	defer cs.RUnlock()
+	var names []string
+	for name := range cs.channels {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 	if cs.isStopping() {
 		return
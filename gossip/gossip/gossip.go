@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/filter"
+	"github.com/hyperledger/fabric/gossip/identity"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 )
 
@@ -33,6 +34,10 @@ type Gossip interface {
 	// SendByCriteria sends a given message to all peers that match the given SendCriteria
 	SendByCriteria(*proto.SignedGossipMessage, SendCriteria) error
 
+	// OpenBlockStream sends the given state request to a remote peer over a dedicated
+	// block stream, and returns the stream the response messages are received from
+	OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error)
+
 	// GetPeers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
 
@@ -44,6 +49,10 @@ type Gossip interface {
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
 
+	// UpdateExternalEndpoint updates the external endpoint the peer
+	// publishes to other peers, without restarting the gossip component
+	UpdateExternalEndpoint(endpoint string)
+
 	// UpdateLedgerHeight updates the ledger height the peer
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64, chainID common.ChainID)
@@ -52,6 +61,10 @@ type Gossip interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID)
 
+	// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+	// the peer publishes to other peers in the channel
+	UpdateLedgerSnapshots(heights []uint64, chainID common.ChainID)
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -81,6 +94,17 @@ type Gossip interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// OnIdentityPurge registers a listener that is invoked for every peer identity
+	// that is purged, such as when its certificate is revoked or expires
+	OnIdentityPurge(listener func(identity.PurgeEvent))
+
+	// MembershipSnapshot returns a snapshot of the alive and dead membership views
+	MembershipSnapshot() MembershipSnapshot
+
+	// PeerLatencies returns the smoothed round trip times measured to the alive peers
+	// of the given channel by their PKI-IDs, or to all alive peers if the channel is empty
+	PeerLatencies(channel common.ChainID) map[string]time.Duration
+
 	// Stop stops the gossip component
 	Stop()
 }
@@ -100,6 +124,21 @@ type SendCriteria struct {
 	IsEligible filter.RoutingFilter // IsEligible defines whether a specific peer is eligible of receiving the message
 	Channel    common.ChainID       // Channel specifies a channel to send this message on. \
 	// Only peers that joined the channel would receive this message
+	OnSent func(report SendReport) // OnSent, if set, is invoked with a report of the peers the message was sent to
+}
+
+// SendReport describes to which peers a message sent by criteria was sent, and how each of them responded
+type SendReport struct {
+	EligiblePeers int              // EligiblePeers is the number of known peers that were eligible of receiving the message
+	Peers         []PeerSendResult // Peers are the peers that were selected to receive the message
+}
+
+// PeerSendResult describes the outcome of sending a message to a peer
+type PeerSendResult struct {
+	PKIid        string `json:"pki_id"`
+	Organization string `json:"organization,omitempty"`
+	Endpoint     string `json:"endpoint"`
+	Error        string `json:"error,omitempty"`
 }
 
 // String returns a string representation of this SendCriteria
@@ -133,4 +172,13 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	IdentitySweepInterval time.Duration // Determines frequency of re-validating all known identities, 0 disables it
+
+	MembershipFile            string        // File the alive membership is persisted to, and replayed from at startup
+	MembershipPersistInterval time.Duration // Determines frequency of persisting the alive membership, 0 disables it
+
+	VerificationWorkers int // Number of goroutines that verify and handle incoming messages, messages of the same sender are handled in order
+
+	LatencyProbeInterval time.Duration // Determines frequency of measuring the round trip times to alive peers, 0 disables it
 }
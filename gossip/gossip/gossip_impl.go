@@ -8,6 +8,7 @@ package gossip
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"sync"
@@ -61,6 +62,7 @@ type gossipServiceImpl struct {
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
+	latencies         *latencyMap
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -85,6 +87,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		latencies:             newLatencyMap(),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -92,6 +95,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 		g.certPuller.Remove(string(pkiID))
 	}, secAdvisor)
+	g.idMapper.OnPurge(g.handleIdentityPurge)
 
 	if s == nil {
 		g.comm, err = createCommWithServer(conf.BindPort, g.idMapper, selfIdentity, secureDialOpts)
@@ -123,6 +127,18 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 
 	go g.start()
 	go g.connect2BootstrapPeers()
+	if g.persistsMembership() {
+		go g.connect2PersistedPeers()
+		go g.periodicalMembershipPersistence()
+	}
+	if conf.IdentitySweepInterval > 0 {
+		go g.periodicalIdentityValidation(func(_ api.PeerIdentityType) bool {
+			return true
+		}, conf.IdentitySweepInterval)
+	}
+	if conf.LatencyProbeInterval > 0 {
+		go g.periodicalLatencyProbing()
+	}
 
 	return g
 }
@@ -145,7 +161,9 @@ func (g *gossipServiceImpl) selfNetworkMember() discovery.NetworkMember {
 		InternalEndpoint: g.conf.InternalEndpoint,
 	}
 	if g.disc != nil {
-		self.Metadata = g.disc.Self().Metadata
+		discSelf := g.disc.Self()
+		self.Metadata = discSelf.Metadata
+		self.Endpoint = discSelf.Endpoint
 	}
 	return self
 }
@@ -202,6 +220,26 @@ func (g *gossipServiceImpl) SuspectPeers(isSuspected api.PeerSuspector) {
 	g.certStore.suspectPeers(isSuspected)
 }
 
+// OnIdentityPurge registers a listener that is invoked for every peer identity
+// that is purged, such as when its certificate is revoked or expires
+func (g *gossipServiceImpl) OnIdentityPurge(listener func(identity.PurgeEvent)) {
+	g.idMapper.OnPurge(listener)
+}
+
+// handleIdentityPurge expires peers whose identities were found invalid from the membership,
+// instead of waiting for their alive messages to time out
+func (g *gossipServiceImpl) handleIdentityPurge(event identity.PurgeEvent) {
+	if event.Reason == identity.Unused {
+		return
+	}
+	g.logger.Warning("Purged", event.Reason, "identity of", event.PKIID)
+	select {
+	case g.presumedDead <- event.PKIID:
+	default:
+		g.logger.Warning("Failed expiring", event.PKIID, "from the membership, too many dead peers are pending")
+	}
+}
+
 func (g *gossipServiceImpl) periodicalIdentityValidation(suspectFunc api.PeerSuspector, interval time.Duration) {
 	for {
 		select {
@@ -297,6 +335,11 @@ func (g *gossipServiceImpl) start() {
 		if !isGossipMsg {
 			return false
 		}
+		// State requests sent over block streams are served only by the state transfer,
+		// as a block stream that no one serves is closed right away
+		if _, isBlockStream := msg.(comm.BlockStreamRequest); isBlockStream {
+			return false
+		}
 
 		isConn := gMsg.GetGossipMessage().GetConn() != nil
 		isEmpty := gMsg.GetGossipMessage().GetEmpty() != nil
@@ -316,13 +359,16 @@ func (g *gossipServiceImpl) acceptMessages(incMsgs <-chan proto.ReceivedMessage)
 	defer g.logger.Debug("Exiting")
 	g.stopSignal.Add(1)
 	defer g.stopSignal.Done()
+	// Messages are verified and handled in parallel, but messages of each sender are handled in order
+	workers := newMsgWorkers(g.conf.VerificationWorkers, g.handleMessage)
+	defer workers.stop()
 	for {
 		select {
 		case s := <-g.toDieChan:
 			g.toDieChan <- s
 			return
 		case msg := <-incMsgs:
-			g.handleMessage(msg)
+			workers.dispatch(msg)
 		}
 	}
 }
@@ -632,12 +678,19 @@ func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, crite
 		membership = gc.GetPeers()
 	}
 
+	var results comm.AggregatedSendResult
+	if criteria.OnSent != nil {
+		defer func() {
+			criteria.OnSent(g.sendReport(membership, criteria.IsEligible, results))
+		}()
+	}
+
 	peers2send := filter.SelectPeers(criteria.MaxPeers, membership, criteria.IsEligible)
 	if len(peers2send) < criteria.MinAck {
 		return fmt.Errorf("Requested to send to at least %d peers, but know only of %d suitable peers", criteria.MinAck, len(peers2send))
 	}
 
-	results := g.comm.SendWithAck(msg, criteria.Timeout, criteria.MinAck, peers2send...)
+	results = g.comm.SendWithAck(msg, criteria.Timeout, criteria.MinAck, peers2send...)
 
 	for _, res := range results {
 		if res.Error() == "" {
@@ -652,6 +705,26 @@ func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, crite
 	return nil
 }
 
+// sendReport reports how many of the given members are eligible of receiving a message,
+// and the outcome of sending the message to the peers it was sent to
+func (g *gossipServiceImpl) sendReport(membership []discovery.NetworkMember, isEligible filter.RoutingFilter, results comm.AggregatedSendResult) SendReport {
+	report := SendReport{}
+	for _, member := range membership {
+		if isEligible(member) {
+			report.EligiblePeers++
+		}
+	}
+	for _, res := range results {
+		report.Peers = append(report.Peers, PeerSendResult{
+			PKIid:        hex.EncodeToString(res.PKIID),
+			Organization: string(g.getOrgOfPeer(res.PKIID)),
+			Endpoint:     res.Endpoint,
+			Error:        res.Error(),
+		})
+	}
+	return report
+}
+
 // Gossip sends a message to other peers to the network
 func (g *gossipServiceImpl) Gossip(msg *proto.GossipMessage) {
 	// Educate developers to Gossip messages with the right tags.
@@ -710,6 +783,16 @@ func (g *gossipServiceImpl) Send(msg *proto.GossipMessage, peers ...*comm.Remote
 	g.comm.Send(m, peers...)
 }
 
+// OpenBlockStream sends the given state request to a remote peer over a dedicated
+// block stream, and returns the stream the response messages are received from
+func (g *gossipServiceImpl) OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error) {
+	m, err := msg.NoopSign()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return g.comm.OpenBlockStream(peer, m)
+}
+
 // GetPeers returns a mapping of endpoint --> []discovery.NetworkMember
 func (g *gossipServiceImpl) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
@@ -758,6 +841,11 @@ func (g *gossipServiceImpl) Stop() {
 	}
 	atomic.StoreInt32(&g.stopFlag, int32(1))
 	g.logger.Info("Stopping gossip")
+	if g.persistsMembership() {
+		if err := g.persistMembership(); err != nil {
+			g.logger.Warningf("Failed persisting membership: %+v", err)
+		}
+	}
 	comWG := sync.WaitGroup{}
 	comWG.Add(1)
 	go func() {
@@ -780,6 +868,15 @@ func (g *gossipServiceImpl) UpdateMetadata(md []byte) {
 	g.disc.UpdateMetadata(md)
 }
 
+// UpdateExternalEndpoint updates the external endpoint the peer
+// publishes to other peers, without restarting the gossip component
+func (g *gossipServiceImpl) UpdateExternalEndpoint(endpoint string) {
+	if endpoint == "" {
+		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
+	}
+	g.disc.UpdateEndpoint(endpoint)
+}
+
 // UpdateLedgerHeight updates the ledger height the peer
 // publishes to other peers in the channel
 func (g *gossipServiceImpl) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
@@ -802,6 +899,17 @@ func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chai
 	gc.UpdateChaincodes(chaincodes)
 }
 
+// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+// the peer publishes to other peers in the channel
+func (g *gossipServiceImpl) UpdateLedgerSnapshots(heights []uint64, chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Warning("No such channel", chainID)
+		return
+	}
+	gc.UpdateLedgerSnapshots(heights)
+}
+
 // Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
 // If passThrough is false, the messages are processed by the gossip layer beforehand.
 // If passThrough is true, the gossip layer doesn't intervene and the messages
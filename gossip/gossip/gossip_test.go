@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/stretchr/testify/assert"
@@ -1223,9 +1224,26 @@ func TestSendByCriteria(t *testing.T) {
 	go ack(ackChan2)
 	go nack(ackChan3)
 	go nack(ackChan4)
+	var report SendReport
+	criteria.OnSent = func(r SendReport) {
+		report = r
+	}
 	err = g1.SendByCriteria(msg, criteria)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "uh oh")
+	// The report lists the peers the message was sent to, along with their errors
+	assert.Equal(t, 3, report.EligiblePeers)
+	assert.Len(t, report.Peers, 3)
+	var nacks int
+	for _, peer := range report.Peers {
+		assert.NotEmpty(t, peer.Endpoint)
+		if peer.Error != "" {
+			assert.Contains(t, peer.Error, "uh oh")
+			nacks++
+		}
+	}
+	assert.Equal(t, 2, nacks)
+	criteria.OnSent = nil
 
 	// We try to send to either g2 or g3, but neither would ack us, so we would fail.
 	// However - what we actually check in this test is that we send to peers according to the
@@ -1322,6 +1340,16 @@ func TestIdentityExpiration(t *testing.T) {
 	// Now revoke some peer
 	revokedPeerIndex := rand.Intn(4)
 	revokedPkiID := common.PKIidType(fmt.Sprintf("localhost:%d", portPrefix+int(revokedPeerIndex)))
+	purgeEvents := make(chan identity.PurgeEvent, 1)
+	peers[(revokedPeerIndex+1)%4].OnIdentityPurge(func(event identity.PurgeEvent) {
+		if !bytes.Equal(event.PKIID, revokedPkiID) {
+			return
+		}
+		select {
+		case purgeEvents <- event:
+		default:
+		}
+	})
 	for i, p := range peers {
 		if i == revokedPeerIndex {
 			continue
@@ -1355,6 +1383,13 @@ func TestIdentityExpiration(t *testing.T) {
 		return true
 	}
 	waitUntilOrFail(t, ensureRevokedPeerIsIgnored)
+	// Ensure the purge of the revoked peer was reported
+	select {
+	case event := <-purgeEvents:
+		assert.Equal(t, identity.Revoked, event.Reason)
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "Didn't get a purge event for the revoked peer")
+	}
 	stopPeers(peers)
 	g5.Stop()
 }
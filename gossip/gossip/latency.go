@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+)
+
+// rttSmoothingFactor is the weight of a new round trip time measurement
+// in the smoothed round trip time, as in TCP (RFC 6298)
+const rttSmoothingFactor = 0.125
+
+// latencyMap maps PKI-IDs of peers to the smoothed
+// round trip times measured to them
+type latencyMap struct {
+	sync.RWMutex
+	rtts map[string]time.Duration
+}
+
+func newLatencyMap() *latencyMap {
+	return &latencyMap{
+		rtts: make(map[string]time.Duration),
+	}
+}
+
+// update smooths the given round trip time measured to the given peer into its round trip time
+func (lm *latencyMap) update(pkiID common.PKIidType, rtt time.Duration) {
+	lm.Lock()
+	defer lm.Unlock()
+	srtt, exists := lm.rtts[string(pkiID)]
+	if !exists {
+		lm.rtts[string(pkiID)] = rtt
+		return
+	}
+	lm.rtts[string(pkiID)] = srtt + time.Duration(rttSmoothingFactor*float64(rtt-srtt))
+}
+
+// retain removes the round trip times of all peers but the given members
+func (lm *latencyMap) retain(members []discovery.NetworkMember) {
+	retained := make(map[string]struct{})
+	for _, member := range members {
+		retained[string(member.PKIid)] = struct{}{}
+	}
+	lm.Lock()
+	defer lm.Unlock()
+	for pkiID := range lm.rtts {
+		if _, exists := retained[pkiID]; !exists {
+			delete(lm.rtts, pkiID)
+		}
+	}
+}
+
+// rttOf returns the round trip time of the given peer,
+// and whether it was measured
+func (lm *latencyMap) rttOf(pkiID common.PKIidType) (time.Duration, bool) {
+	lm.RLock()
+	defer lm.RUnlock()
+	rtt, exists := lm.rtts[string(pkiID)]
+	return rtt, exists
+}
+
+// rttsOf returns the round trip times of the given members by their PKI-IDs,
+// omitting the members whose round trip times weren't measured
+func (lm *latencyMap) rttsOf(members []discovery.NetworkMember) map[string]time.Duration {
+	lm.RLock()
+	defer lm.RUnlock()
+	res := make(map[string]time.Duration)
+	for _, member := range members {
+		if rtt, exists := lm.rtts[string(member.PKIid)]; exists {
+			res[string(member.PKIid)] = rtt
+		}
+	}
+	return res
+}
+
+// PeerLatencies returns the smoothed round trip times measured to the alive peers
+// of the given channel by their PKI-IDs, or to all alive peers if the channel is empty.
+// Peers whose round trip times weren't measured yet are omitted.
+func (g *gossipServiceImpl) PeerLatencies(channel common.ChainID) map[string]time.Duration {
+	if len(channel) == 0 {
+		return g.latencies.rttsOf(g.disc.GetMembership())
+	}
+	return g.latencies.rttsOf(g.PeersOfChannel(channel))
+}
+
+func (g *gossipServiceImpl) periodicalLatencyProbing() {
+	for {
+		select {
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
+			return
+		case <-time.After(g.conf.LatencyProbeInterval):
+			g.probeLatencies()
+		}
+	}
+}
+
+// probeLatencies measures the round trip times to all alive peers in parallel,
+// and forgets the round trip times of the peers that are no longer alive
+func (g *gossipServiceImpl) probeLatencies() {
+	alive := g.disc.GetMembership()
+	g.latencies.retain(alive)
+
+	var wg sync.WaitGroup
+	wg.Add(len(alive))
+	for _, member := range alive {
+		go func(member discovery.NetworkMember) {
+			defer wg.Done()
+			rtt, err := g.comm.MeasureRTT(&comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
+			if err != nil {
+				g.logger.Debugf("Failed measuring round trip time to %s: %v", member.PreferredEndpoint(), err)
+				return
+			}
+			g.latencies.update(member.PKIid, rtt)
+		}(member)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyMap(t *testing.T) {
+	p1, p2, p3 := common.PKIidType("p1"), common.PKIidType("p2"), common.PKIidType("p3")
+	members := []discovery.NetworkMember{{PKIid: p1}, {PKIid: p2}, {PKIid: p3}}
+	lm := newLatencyMap()
+
+	// The first measurement is taken as is
+	lm.update(p1, 80*time.Millisecond)
+	rtt, exists := lm.rttOf(p1)
+	assert.True(t, exists)
+	assert.Equal(t, 80*time.Millisecond, rtt)
+
+	// Subsequent measurements are smoothed into the round trip time
+	lm.update(p1, 160*time.Millisecond)
+	rtt, _ = lm.rttOf(p1)
+	assert.Equal(t, 90*time.Millisecond, rtt)
+
+	// Peers whose round trip times weren't measured are omitted
+	lm.update(p2, 10*time.Millisecond)
+	assert.Equal(t, map[string]time.Duration{
+		string(p1): 90 * time.Millisecond,
+		string(p2): 10 * time.Millisecond,
+	}, lm.rttsOf(members))
+	assert.Equal(t, map[string]time.Duration{
+		string(p2): 10 * time.Millisecond,
+	}, lm.rttsOf(members[1:]))
+
+	// Round trip times of peers that aren't retained are forgotten
+	lm.retain(members[1:])
+	_, exists = lm.rttOf(p1)
+	assert.False(t, exists)
+	_, exists = lm.rttOf(p2)
+	assert.True(t, exists)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// PeerSnapshot describes a peer in a MembershipSnapshot
+type PeerSnapshot struct {
+	PKIid            string              `json:"pki_id"`
+	Organization     string              `json:"organization,omitempty"`
+	Endpoint         string              `json:"endpoint,omitempty"`
+	InternalEndpoint string              `json:"internal_endpoint,omitempty"`
+	Metadata         []byte              `json:"metadata,omitempty"`
+	LastSeen         *time.Time          `json:"last_seen,omitempty"`
+	StartTime        *time.Time          `json:"start_time,omitempty"`
+	SeqNum           uint64              `json:"seq_num,omitempty"`
+	LedgerHeights    map[string]uint64   `json:"ledger_heights,omitempty"`
+	SnapshotHeights  map[string][]uint64 `json:"snapshot_heights,omitempty"`
+	RTTMillis        float64             `json:"rtt_ms,omitempty"`
+}
+
+// MembershipSnapshot is a point in time view of the membership
+// known to the gossip layer, meant for debugging purposes
+type MembershipSnapshot struct {
+	Time  time.Time      `json:"time"`
+	Self  PeerSnapshot   `json:"self"`
+	Alive []PeerSnapshot `json:"alive"`
+	Dead  []PeerSnapshot `json:"dead"`
+}
+
+// MembershipSnapshot returns a snapshot of the alive and dead membership views,
+// along with the ledger and snapshot heights the peers advertised in the channels this peer has joined
+func (g *gossipServiceImpl) MembershipSnapshot() MembershipSnapshot {
+	view := g.disc.MembershipView()
+	props := g.propertiesByPeer()
+
+	self := g.disc.Self()
+	snapshot := MembershipSnapshot{
+		Time: time.Now(),
+		Self: PeerSnapshot{
+			PKIid:            hex.EncodeToString(self.PKIid),
+			Organization:     string(g.selfOrg),
+			Endpoint:         self.Endpoint,
+			InternalEndpoint: g.conf.InternalEndpoint,
+			Metadata:         self.Metadata,
+			LedgerHeights:    props[string(self.PKIid)].ledgerHeights(),
+			SnapshotHeights:  props[string(self.PKIid)].snapshotHeights(),
+		},
+		Alive: g.peerSnapshots(view.Alive, props),
+		Dead:  g.peerSnapshots(view.Dead, props),
+	}
+	return snapshot
+}
+
+func (g *gossipServiceImpl) peerSnapshots(members []discovery.MemberState, props map[string]channelProperties) []PeerSnapshot {
+	res := []PeerSnapshot{}
+	for _, member := range members {
+		lastSeen := member.LastSeen
+		startTime := member.StartTime
+		rtt, _ := g.latencies.rttOf(member.PKIid)
+		res = append(res, PeerSnapshot{
+			PKIid:            hex.EncodeToString(member.PKIid),
+			Organization:     string(g.getOrgOfPeer(member.PKIid)),
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			Metadata:         member.Metadata,
+			LastSeen:         &lastSeen,
+			StartTime:        &startTime,
+			SeqNum:           member.SeqNum,
+			LedgerHeights:    props[string(member.PKIid)].ledgerHeights(),
+			SnapshotHeights:  props[string(member.PKIid)].snapshotHeights(),
+			RTTMillis:        rtt.Seconds() * 1000,
+		})
+	}
+	return res
+}
+
+// channelProperties maps channel names to the properties
+// a peer advertised in them
+type channelProperties map[string]*proto.Properties
+
+func (cp channelProperties) ledgerHeights() map[string]uint64 {
+	if len(cp) == 0 {
+		return nil
+	}
+	res := make(map[string]uint64)
+	for channel, props := range cp {
+		res[channel] = props.LedgerHeight
+	}
+	return res
+}
+
+func (cp channelProperties) snapshotHeights() map[string][]uint64 {
+	var res map[string][]uint64
+	for channel, props := range cp {
+		if len(props.SnapshotHeights) == 0 {
+			continue
+		}
+		if res == nil {
+			res = make(map[string][]uint64)
+		}
+		res[channel] = props.SnapshotHeights
+	}
+	return res
+}
+
+// propertiesByPeer returns a mapping from PKI-IDs of peers
+// to the properties they advertised in each channel
+func (g *gossipServiceImpl) propertiesByPeer() map[string]channelProperties {
+	res := make(map[string]channelProperties)
+	add := func(pkiID common.PKIidType, channel string, props *proto.Properties) {
+		if _, exists := res[string(pkiID)]; !exists {
+			res[string(pkiID)] = make(channelProperties)
+		}
+		res[string(pkiID)][channel] = props
+	}
+
+	for _, channel := range g.chanState.channelNames() {
+		gc := g.chanState.getGossipChannelByChainID(common.ChainID(channel))
+		if gc == nil {
+			continue
+		}
+		for _, member := range gc.GetPeers() {
+			if member.Properties == nil {
+				continue
+			}
+			add(member.PKIid, channel, member.Properties)
+		}
+		if self := gc.Self(); self != nil && self.GetStateInfo().Properties != nil {
+			add(g.comm.GetPKIid(), channel, self.GetStateInfo().Properties)
+		}
+	}
+	return res
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/pkg/errors"
+)
+
+// persistedMember is an alive member as it is persisted to the file system
+type persistedMember struct {
+	PKIid            string `json:"pki_id"`
+	Endpoint         string `json:"endpoint,omitempty"`
+	InternalEndpoint string `json:"internal_endpoint,omitempty"`
+	Identity         []byte `json:"identity"`
+}
+
+// persistedMembership is the last known alive membership view,
+// which is replayed after a restart in order to rediscover the
+// membership faster than by relying only on bootstrap and anchor peers
+type persistedMembership struct {
+	Time    time.Time         `json:"time"`
+	Members []persistedMember `json:"members"`
+}
+
+// persistMembership writes the alive membership view to the membership file.
+// An empty membership view isn't persisted, in order not to overwrite a previously
+// persisted membership view right after a restart
+func (g *gossipServiceImpl) persistMembership() error {
+	pm := persistedMembership{
+		Time: time.Now(),
+	}
+	for _, member := range g.disc.MembershipView().Alive {
+		identity, err := g.idMapper.Get(member.PKIid)
+		if err != nil {
+			g.logger.Debug("Skipping persisting", member, "since its identity isn't known:", err)
+			continue
+		}
+		pm.Members = append(pm.Members, persistedMember{
+			PKIid:            hex.EncodeToString(member.PKIid),
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			Identity:         identity,
+		})
+	}
+	if len(pm.Members) == 0 {
+		return nil
+	}
+
+	rawMembership, err := json.Marshal(pm)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling membership")
+	}
+	if err := os.MkdirAll(filepath.Dir(g.conf.MembershipFile), 0755); err != nil {
+		return errors.Wrapf(err, "failed creating directory of %s", g.conf.MembershipFile)
+	}
+	// Write to a temporary file first, so that a crash
+	// would never leave a partially written membership file
+	tmpFile := g.conf.MembershipFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, rawMembership, 0644); err != nil {
+		return errors.Wrapf(err, "failed writing %s", tmpFile)
+	}
+	if err := os.Rename(tmpFile, g.conf.MembershipFile); err != nil {
+		return errors.Wrapf(err, "failed renaming %s to %s", tmpFile, g.conf.MembershipFile)
+	}
+	return nil
+}
+
+// loadPersistedMembership reads the membership view persisted to the given file.
+// A file that doesn't exist yields an empty membership view
+func loadPersistedMembership(file string) (*persistedMembership, error) {
+	rawMembership, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return &persistedMembership{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading %s", file)
+	}
+	pm := &persistedMembership{}
+	if err := json.Unmarshal(rawMembership, pm); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling %s", file)
+	}
+	return pm, nil
+}
+
+func (g *gossipServiceImpl) persistsMembership() bool {
+	return g.conf.MembershipFile != "" && g.conf.MembershipPersistInterval > 0
+}
+
+func (g *gossipServiceImpl) periodicalMembershipPersistence() {
+	for {
+		select {
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
+			return
+		case <-time.After(g.conf.MembershipPersistInterval):
+			if err := g.persistMembership(); err != nil {
+				g.logger.Warningf("Failed persisting membership: %+v", err)
+			}
+		}
+	}
+}
+
+// connect2PersistedPeers connects to the members of the persisted membership view.
+// These members are only suspected to be alive, hence they are added to the membership
+// only once they are reachable, and still have the identity they were persisted with
+func (g *gossipServiceImpl) connect2PersistedPeers() {
+	pm, err := loadPersistedMembership(g.conf.MembershipFile)
+	if err != nil {
+		g.logger.Warningf("Failed loading persisted membership: %+v", err)
+		return
+	}
+	if len(pm.Members) > 0 {
+		g.logger.Infof("Connecting to %d members persisted at %s", len(pm.Members), pm.Time)
+	}
+	for _, member := range pm.Members {
+		pkiID, err := hex.DecodeString(member.PKIid)
+		if err != nil || len(pkiID) == 0 {
+			g.logger.Warning("Skipping persisted member with invalid PKI-ID", member.PKIid)
+			continue
+		}
+		if bytes.Equal(pkiID, g.comm.GetPKIid()) {
+			continue
+		}
+		// The identity is learned in advance, and identities that are no longer
+		// valid (i.e revoked or expired) are not connected to
+		if err := g.idMapper.Put(common.PKIidType(pkiID), api.PeerIdentityType(member.Identity)); err != nil {
+			g.logger.Warningf("Skipping persisted member %s: %+v", member.PKIid, err)
+			continue
+		}
+		inOurOrg := bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(member.Identity))
+		endpoint := member.Endpoint
+		if inOurOrg && member.InternalEndpoint != "" {
+			endpoint = member.InternalEndpoint
+		}
+		if endpoint == "" || (!inOurOrg && g.selfNetworkMember().Endpoint == "") {
+			continue
+		}
+		g.disc.Connect(discovery.NetworkMember{
+			InternalEndpoint: endpoint,
+			Endpoint:         endpoint,
+		}, g.persistedPeerIdentifier(endpoint, pkiID))
+	}
+}
+
+func (g *gossipServiceImpl) persistedPeerIdentifier(endpoint string, expectedPKIID common.PKIidType) func() (*discovery.PeerIdentification, error) {
+	return func() (*discovery.PeerIdentification, error) {
+		remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		pkiID := g.mcs.GetPKIidOfCert(remotePeerIdentity)
+		if !bytes.Equal(pkiID, expectedPKIID) {
+			return nil, errors.Errorf("%s no longer belongs to the persisted member %s", endpoint, hex.EncodeToString(expectedPKIID))
+		}
+		return &discovery.PeerIdentification{
+			ID:      pkiID,
+			SelfOrg: bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity)),
+		}, nil
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGossipInstanceWithMembershipFile(portPrefix int, id int, membershipFile string, boot ...int) Gossip {
+	port := id + portPrefix
+	conf := &Config{
+		BindPort:                   port,
+		BootstrapPeers:             bootPeers(portPrefix, boot...),
+		ID:                         fmt.Sprintf("p%d", id),
+		MaxBlockCountToStore:       100,
+		MaxPropagationBurstLatency: time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:    20,
+		PropagateIterations:        1,
+		PropagatePeerNum:           3,
+		PullInterval:               time.Duration(2) * time.Second,
+		PullPeerNum:                5,
+		InternalEndpoint:           fmt.Sprintf("localhost:%d", port),
+		ExternalEndpoint:           fmt.Sprintf("1.2.3.4:%d", port),
+		PublishCertPeriod:          time.Duration(4) * time.Second,
+		PublishStateInfoInterval:   time.Duration(1) * time.Second,
+		RequestStateInfoInterval:   time.Duration(1) * time.Second,
+		MembershipFile:             membershipFile,
+		MembershipPersistInterval:  time.Duration(100) * time.Millisecond,
+	}
+	selfID := api.PeerIdentityType(conf.InternalEndpoint)
+	return NewGossipServiceWithServer(conf, &orgCryptoService{}, &naiveCryptoService{}, selfID, nil)
+}
+
+func TestMembershipPersistence(t *testing.T) {
+	// Scenario: p1 bootstraps from p0 and persists its membership.
+	// After p1 is restarted without any bootstrap peers,
+	// it rediscovers p0 out of its persisted membership.
+	portPrefix := 15610
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	membershipFile := filepath.Join(dir, "gossip", "membership.json")
+
+	p0 := newGossipInstance(portPrefix, 0, 100)
+	defer p0.Stop()
+
+	p1 := newGossipInstanceWithMembershipFile(portPrefix, 1, membershipFile, 0)
+	waitUntilOrFail(t, func() bool {
+		return len(p1.Peers()) == 1
+	})
+	waitUntilOrFail(t, func() bool {
+		_, err := os.Stat(membershipFile)
+		return err == nil
+	})
+	p1.Stop()
+
+	pm, err := loadPersistedMembership(membershipFile)
+	assert.NoError(t, err)
+	assert.Len(t, pm.Members, 1)
+	assert.Equal(t, hex.EncodeToString([]byte("localhost:15610")), pm.Members[0].PKIid)
+	assert.Equal(t, "localhost:15610", pm.Members[0].InternalEndpoint)
+	assert.Equal(t, []byte("localhost:15610"), pm.Members[0].Identity)
+
+	p1 = newGossipInstanceWithMembershipFile(portPrefix, 1, membershipFile)
+	defer p1.Stop()
+	waitUntilOrFail(t, func() bool {
+		return len(p1.Peers()) == 1
+	})
+}
+
+func TestLoadPersistedMembership(t *testing.T) {
+	dir, err := ioutil.TempDir("", "membership")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A membership file that doesn't exist yields an empty membership
+	pm, err := loadPersistedMembership(filepath.Join(dir, "membership.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, pm.Members)
+
+	// A corrupted membership file yields an error
+	corrupted := filepath.Join(dir, "corrupted.json")
+	assert.NoError(t, ioutil.WriteFile(corrupted, []byte("{"), 0644))
+	_, err = loadPersistedMembership(corrupted)
+	assert.Contains(t, err.Error(), "failed unmarshaling "+corrupted)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"hash/fnv"
+	"sync"
+
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// msgWorkerQueueSize is the number of messages that may wait for each worker
+// before dispatching messages to it blocks
+const msgWorkerQueueSize = 100
+
+// msgWorkers verifies and handles incoming messages in a bounded number of goroutines.
+// Messages of the same sender are always handled by the same goroutine in the order
+// they were dispatched, hence messages of each sender are handled in order,
+// while messages of different senders are handled in parallel.
+type msgWorkers struct {
+	queues []chan proto.ReceivedMessage
+	handle func(proto.ReceivedMessage)
+	wg     sync.WaitGroup
+}
+
+// newMsgWorkers starts the given number of workers that handle messages with the given function.
+// At least a single worker is started.
+func newMsgWorkers(size int, handle func(proto.ReceivedMessage)) *msgWorkers {
+	if size < 1 {
+		size = 1
+	}
+	w := &msgWorkers{
+		queues: make([]chan proto.ReceivedMessage, size),
+		handle: handle,
+	}
+	w.wg.Add(size)
+	for i := range w.queues {
+		w.queues[i] = make(chan proto.ReceivedMessage, msgWorkerQueueSize)
+		go w.work(w.queues[i])
+	}
+	return w
+}
+
+func (w *msgWorkers) work(queue <-chan proto.ReceivedMessage) {
+	defer w.wg.Done()
+	for m := range queue {
+		w.handle(m)
+	}
+}
+
+// dispatch queues the given message to the worker of its sender,
+// and blocks if the queue of the worker is full
+func (w *msgWorkers) dispatch(m proto.ReceivedMessage) {
+	w.queues[w.workerOf(m)] <- m
+}
+
+// workerOf returns the index of the worker that handles messages of the sender of the given message
+func (w *msgWorkers) workerOf(m proto.ReceivedMessage) int {
+	if len(w.queues) == 1 || m == nil || m.GetConnectionInfo() == nil {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write(m.GetConnectionInfo().ID)
+	return int(h.Sum32() % uint32(len(w.queues)))
+}
+
+// stop stops the workers once they handle the messages already dispatched to them,
+// and waits for them to exit. Messages must not be dispatched after stop is called.
+func (w *msgWorkers) stop() {
+	for _, queue := range w.queues {
+		close(queue)
+	}
+	w.wg.Wait()
+}
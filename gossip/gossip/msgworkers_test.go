@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+type msgFromSender struct {
+	sentMsg
+	sender common.PKIidType
+	seq    int
+}
+
+func (m *msgFromSender) GetConnectionInfo() *proto.ConnectionInfo {
+	return &proto.ConnectionInfo{ID: m.sender}
+}
+
+func TestMsgWorkersOrderPerSender(t *testing.T) {
+	var lock sync.Mutex
+	handled := make(map[string][]int)
+	workers := newMsgWorkers(4, func(m proto.ReceivedMessage) {
+		msg := m.(*msgFromSender)
+		lock.Lock()
+		defer lock.Unlock()
+		handled[string(msg.sender)] = append(handled[string(msg.sender)], msg.seq)
+	})
+
+	senders := 10
+	msgsPerSender := 200
+	for seq := 0; seq < msgsPerSender; seq++ {
+		for i := 0; i < senders; i++ {
+			workers.dispatch(&msgFromSender{sender: common.PKIidType(fmt.Sprintf("p%d", i)), seq: seq})
+		}
+	}
+	// Stopping the workers waits for the messages already dispatched to be handled
+	workers.stop()
+
+	assert.Len(t, handled, senders)
+	for sender, seqs := range handled {
+		assert.Len(t, seqs, msgsPerSender, "sender %s", sender)
+		for i, seq := range seqs {
+			assert.Equal(t, i, seq, "message of sender %s was handled out of order", sender)
+		}
+	}
+}
+
+func TestMsgWorkersParallelism(t *testing.T) {
+	// Find two senders that are handled by different workers
+	workers := newMsgWorkers(2, nil)
+	workers.stop()
+	p1 := &msgFromSender{sender: common.PKIidType("p1")}
+	var p2 *msgFromSender
+	for i := 2; p2 == nil; i++ {
+		m := &msgFromSender{sender: common.PKIidType(fmt.Sprintf("p%d", i))}
+		if workers.workerOf(m) != workers.workerOf(p1) {
+			p2 = m
+		}
+	}
+
+	// A message of p1 that blocks doesn't prevent the message of p2 from being handled
+	release := make(chan struct{})
+	handledP2 := make(chan struct{})
+	workers = newMsgWorkers(2, func(m proto.ReceivedMessage) {
+		if m == p1 {
+			<-release
+			return
+		}
+		close(handledP2)
+	})
+	workers.dispatch(p1)
+	workers.dispatch(p2)
+	select {
+	case <-handledP2:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "message of p2 wasn't handled while the message of p1 was being handled")
+	}
+	close(release)
+	workers.stop()
+}
+
+func TestMsgWorkersSize(t *testing.T) {
+	workers := newMsgWorkers(0, func(proto.ReceivedMessage) {})
+	defer workers.stop()
+	assert.Len(t, workers.queues, 1)
+	assert.Equal(t, 0, workers.workerOf(nil))
+	assert.Equal(t, 0, workers.workerOf(&sentMsg{}))
+}
@@ -50,15 +50,55 @@ type Mapper interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// OnPurge registers a listener that is invoked with a PurgeEvent
+	// for every identity that is purged from the Mapper
+	OnPurge(listener func(PurgeEvent))
+
 	// Stop stops all background computations of the Mapper
 	Stop()
 }
 
 type purgeTrigger func(pkiID common.PKIidType, identity api.PeerIdentityType)
 
+// PurgeReason is the reason an identity was purged from the Mapper
+type PurgeReason int
+
+const (
+	// Unused means the identity wasn't used for longer than the usage threshold
+	Unused PurgeReason = iota
+	// Expired means the certificate of the identity expired
+	Expired
+	// Revoked means the identity was found invalid when it was re-validated,
+	// such as when its certificate was revoked
+	Revoked
+)
+
+// String returns a string representation of this PurgeReason
+func (r PurgeReason) String() string {
+	switch r {
+	case Unused:
+		return "unused"
+	case Expired:
+		return "expired"
+	case Revoked:
+		return "revoked"
+	default:
+		return "unknown"
+	}
+}
+
+// PurgeEvent describes an identity that was purged from the Mapper
+type PurgeEvent struct {
+	PKIID    common.PKIidType
+	Identity api.PeerIdentityType
+	Reason   PurgeReason
+	Time     time.Time
+}
+
 // identityMapperImpl is a struct that implements Mapper
 type identityMapperImpl struct {
 	onPurge    purgeTrigger
+	listeners  []func(PurgeEvent)
 	mcs        api.MessageCryptoService
 	sa         api.SecurityAdvisor
 	pkiID2Cert map[string]*storedIdentity
@@ -139,7 +179,7 @@ func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdent
 		// Identity would be wiped out a millisecond after its expiration date
 		timeToLive := expirationDate.Add(time.Millisecond).Sub(time.Now())
 		expirationTimer = time.AfterFunc(timeToLive, func() {
-			is.delete(pkiID, identity)
+			is.delete(pkiID, identity, Expired)
 		})
 	}
 
@@ -187,29 +227,29 @@ func (is *identityMapperImpl) GetPKIidOfCert(identity api.PeerIdentityType) comm
 
 // SuspectPeers re-validates all peers that match the given predicate
 func (is *identityMapperImpl) SuspectPeers(isSuspected api.PeerSuspector) {
-	for _, identity := range is.validateIdentities(isSuspected) {
+	for identity, reason := range is.validateIdentities(isSuspected) {
 		identity.cancelExpirationTimer()
-		is.delete(identity.pkiID, identity.peerIdentity)
+		is.delete(identity.pkiID, identity.peerIdentity, reason)
 	}
 }
 
-// validateIdentities returns a list of identities that have been revoked, expired or haven't been
-// used for a long time
-func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) []*storedIdentity {
+// validateIdentities returns the identities that have been revoked, expired or haven't been
+// used for a long time, mapped to the reason they should be purged
+func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) map[*storedIdentity]PurgeReason {
 	now := time.Now()
 	is.RLock()
 	defer is.RUnlock()
-	var revokedIdentities []*storedIdentity
+	revokedIdentities := make(map[*storedIdentity]PurgeReason)
 	for pkiID, storedIdentity := range is.pkiID2Cert {
 		if pkiID != is.selfPKIID && storedIdentity.fetchLastAccessTime().Add(usageThreshold).Before(now) {
-			revokedIdentities = append(revokedIdentities, storedIdentity)
+			revokedIdentities[storedIdentity] = Unused
 			continue
 		}
 		if !isSuspected(storedIdentity.peerIdentity) {
 			continue
 		}
 		if err := is.mcs.ValidateIdentity(storedIdentity.fetchIdentity()); err != nil {
-			revokedIdentities = append(revokedIdentities, storedIdentity)
+			revokedIdentities[storedIdentity] = Revoked
 		}
 	}
 	return revokedIdentities
@@ -230,11 +270,30 @@ func (is *identityMapperImpl) IdentityInfo() api.PeerIdentitySet {
 	return res
 }
 
-func (is *identityMapperImpl) delete(pkiID common.PKIidType, identity api.PeerIdentityType) {
+// OnPurge registers a listener that is invoked with a PurgeEvent
+// for every identity that is purged from the Mapper
+func (is *identityMapperImpl) OnPurge(listener func(PurgeEvent)) {
 	is.Lock()
 	defer is.Unlock()
+	is.listeners = append(is.listeners, listener)
+}
+
+func (is *identityMapperImpl) delete(pkiID common.PKIidType, identity api.PeerIdentityType, reason PurgeReason) {
+	is.Lock()
 	is.onPurge(pkiID, identity)
 	delete(is.pkiID2Cert, string(pkiID))
+	listeners := is.listeners
+	is.Unlock()
+
+	event := PurgeEvent{
+		PKIID:    pkiID,
+		Identity: identity,
+		Reason:   reason,
+		Time:     time.Now(),
+	}
+	for _, listener := range listeners {
+		listener(event)
+	}
 }
 
 type storedIdentity struct {
@@ -337,3 +337,63 @@ func TestIdentityInfo(t *testing.T) {
 		assert.Equal(t, strings.ToLower(org), string(pkiID[0]))
 	}
 }
+
+func TestPurgeEvents(t *testing.T) {
+	events := make(chan PurgeEvent, 1)
+	msgCryptoService.On("Expiration", api.PeerIdentityType("revokedIdentity")).Return(time.Now().Add(time.Hour), nil)
+	msgCryptoService.On("Expiration", api.PeerIdentityType("expiringIdentity")).Return(time.Now().Add(time.Second), nil)
+	msgCryptoService.On("Expiration", api.PeerIdentityType("unusedIdentity")).Return(time.Now().Add(time.Hour), nil)
+	SetIdentityUsageThreshold(time.Second * 500)
+	idStore := NewIdentityMapper(msgCryptoService, dummyID, noopPurgeTrigger, msgCryptoService)
+	defer idStore.Stop()
+	idStore.OnPurge(func(event PurgeEvent) {
+		events <- event
+	})
+	assertPurgeEvent := func(expected string, reason PurgeReason) {
+		select {
+		case <-time.After(time.Second * 10):
+			t.Fatalf("Didn't get a purge event, expected %s to be purged", expected)
+		case event := <-events:
+			assert.Equal(t, expected, string(event.Identity))
+			assert.Equal(t, msgCryptoService.GetPKIidOfCert(api.PeerIdentityType(expected)), event.PKIID)
+			assert.Equal(t, reason, event.Reason)
+			assert.False(t, event.Time.IsZero())
+		}
+	}
+
+	// An identity that is revoked is purged when it is suspected
+	revoked := api.PeerIdentityType("revokedIdentity")
+	pkiID := msgCryptoService.GetPKIidOfCert(revoked)
+	assert.NoError(t, idStore.Put(pkiID, revoked))
+	msgCryptoService.revokedIdentities[string(pkiID)] = struct{}{}
+	defer func() {
+		msgCryptoService.revokedIdentities = map[string]struct{}{}
+	}()
+	idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
+		return true
+	})
+	assertPurgeEvent("revokedIdentity", Revoked)
+
+	// An identity that expires is purged when it expires
+	expiring := api.PeerIdentityType("expiringIdentity")
+	assert.NoError(t, idStore.Put(msgCryptoService.GetPKIidOfCert(expiring), expiring))
+	assertPurgeEvent("expiringIdentity", Expired)
+
+	// An identity that isn't used is purged when the usage threshold passes
+	SetIdentityUsageThreshold(time.Millisecond * 500)
+	defer SetIdentityUsageThreshold(time.Hour)
+	unused := api.PeerIdentityType("unusedIdentity")
+	assert.NoError(t, idStore.Put(msgCryptoService.GetPKIidOfCert(unused), unused))
+	time.Sleep(time.Second)
+	idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
+		return false
+	})
+	assertPurgeEvent("unusedIdentity", Unused)
+}
+
+func TestPurgeReasonString(t *testing.T) {
+	assert.Equal(t, "unused", Unused.String())
+	assert.Equal(t, "expired", Expired.String())
+	assert.Equal(t, "revoked", Revoked.String())
+	assert.Equal(t, "unknown", PurgeReason(10).String())
+}
@@ -8,9 +8,11 @@ package integration
 
 import (
 	"net"
+	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/gossip"
@@ -52,6 +54,15 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSCerts:                   certs,
+		IdentitySweepInterval:      viper.GetDuration("peer.gossip.identitySweepInterval"),
+		MembershipPersistInterval:  viper.GetDuration("peer.gossip.membershipPersistence.interval"),
+		VerificationWorkers:        util.GetIntOrDefault("peer.gossip.verificationWorkers", 1),
+		LatencyProbeInterval:       viper.GetDuration("peer.gossip.latencyProbeInterval"),
+	}
+
+	conf.MembershipFile = config.GetPath("peer.gossip.membershipPersistence.file")
+	if conf.MembershipFile == "" && conf.MembershipPersistInterval > 0 {
+		conf.MembershipFile = filepath.Join(config.GetPath("peer.fileSystemPath"), "gossip", "membership.json")
 	}
 
 	return conf, nil
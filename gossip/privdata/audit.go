@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// AccessDecision is the outcome of a private data request for a single digest
+type AccessDecision string
+
+const (
+	// AccessGranted means the private data was sent to the requesting peer
+	AccessGranted AccessDecision = "granted"
+	// AccessDenied means the requesting peer isn't eligible for the collection
+	AccessDenied AccessDecision = "denied"
+	// AccessNoPolicy means no access policy could be found for the collection
+	AccessNoPolicy AccessDecision = "no_policy"
+	// AccessNotFound means the requested private data wasn't found
+	AccessNotFound AccessDecision = "not_found"
+)
+
+// AuditEvent records a request for private data that was served to a remote peer
+type AuditEvent struct {
+	Time       time.Time      `json:"time"`
+	Channel    string         `json:"channel"`
+	Org        string         `json:"org"`
+	Endpoint   string         `json:"endpoint"`
+	PKIID      string         `json:"pki_id"`
+	Namespace  string         `json:"namespace"`
+	Collection string         `json:"collection"`
+	TxID       string         `json:"tx_id"`
+	BlockSeq   uint64         `json:"block_seq"`
+	KeyCount   int            `json:"key_count"`
+	Decision   AccessDecision `json:"decision"`
+}
+
+// AuditSink receives AuditEvents of private data requests served to remote peers
+type AuditSink interface {
+	// Record records the given AuditEvent
+	Record(event AuditEvent)
+}
+
+// fileAuditSink writes AuditEvents as JSON lines to a file,
+// and rotates the file when it exceeds a maximum size
+type fileAuditSink struct {
+	lock       sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// NewFileAuditSink returns an AuditSink that appends events to the file at the given path.
+// Once the file exceeds maxSize bytes it is renamed to <path>.1, previous backups are shifted,
+// and at most maxBackups backups are kept. A non-positive maxSize disables rotation.
+func NewFileAuditSink(path string, maxSize int64, maxBackups int) (AuditSink, error) {
+	s := &fileAuditSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileAuditSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening audit log %s", s.path)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed inspecting audit log %s", s.path)
+	}
+	s.f = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileAuditSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+func (s *fileAuditSink) rotate() error {
+	s.f.Close()
+	if s.maxBackups > 0 {
+		for i := s.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed rotating audit log %s", s.path)
+			}
+		}
+		if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+			return errors.Wrapf(err, "failed rotating audit log %s", s.path)
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return errors.Wrapf(err, "failed rotating audit log %s", s.path)
+	}
+	return s.open()
+}
+
+// Record appends the given AuditEvent to the file
+func (s *fileAuditSink) Record(event AuditEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		logger.Warning("Failed marshaling audit event:", err)
+		return
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			logger.Warning("Failed rotating audit log:", err)
+			return
+		}
+	}
+	n, err := s.f.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		logger.Warning("Failed writing to audit log:", err)
+	}
+}
+
+// AuditSinkFromConfig returns an AuditSink according to the peer.gossip.pvtData.auditLog
+// section of the configuration, or nil if auditing isn't enabled
+func AuditSinkFromConfig() (AuditSink, error) {
+	path := viper.GetString("peer.gossip.pvtData.auditLog.path")
+	if path == "" {
+		return nil, nil
+	}
+	maxSize := int64(viper.GetInt("peer.gossip.pvtData.auditLog.maxSizeMB")) * 1024 * 1024
+	return NewFileAuditSink(path, maxSize, viper.GetInt("peer.gossip.pvtData.auditLog.maxBackups"))
+}
+
+// orgOfIdentity returns the MSP ID of the given serialized identity,
+// or an empty string if it cannot be determined
+func orgOfIdentity(identity []byte) string {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sID); err != nil {
+		return ""
+	}
+	return sID.Mspid
+}
+
+// countKeys returns the number of keys written by the given private write sets
+func countKeys(rwSets []util.PrivateRWSet) int {
+	var count int
+	for _, rws := range rwSets {
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(rws, kvRWSet); err != nil {
+			continue
+		}
+		count += len(kvRWSet.Writes)
+	}
+	return count
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type auditSinkMock struct {
+	sync.Mutex
+	events []AuditEvent
+}
+
+func (s *auditSinkMock) Record(event AuditEvent) {
+	s.Lock()
+	defer s.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *auditSinkMock) recorded() []AuditEvent {
+	s.Lock()
+	defer s.Unlock()
+	return append([]AuditEvent(nil), s.events...)
+}
+
+func readAuditLog(t *testing.T, path string) []AuditEvent {
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	var events []AuditEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event AuditEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestFileAuditSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	event := AuditEvent{Channel: "A", Org: "Org1MSP", Collection: "col1", TxID: "tx1", Decision: AccessGranted}
+	rawEvent, _ := json.Marshal(event)
+	// Each file can hold 2 events
+	sink, err := NewFileAuditSink(path, int64(2*(len(rawEvent)+1)), 2)
+	assert.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		event.BlockSeq = uint64(i)
+		sink.Record(event)
+	}
+
+	// The current file holds the last event, and only 2 backups are kept
+	current := readAuditLog(t, path)
+	assert.Len(t, current, 1)
+	assert.Equal(t, uint64(6), current[0].BlockSeq)
+	assert.Equal(t, AccessGranted, current[0].Decision)
+	backup1 := readAuditLog(t, path+".1")
+	assert.Len(t, backup1, 2)
+	assert.Equal(t, uint64(4), backup1[0].BlockSeq)
+	backup2 := readAuditLog(t, path+".2")
+	assert.Len(t, backup2, 2)
+	assert.Equal(t, uint64(2), backup2[0].BlockSeq)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// Reopening the audit log appends to it
+	sink, err = NewFileAuditSink(path, 0, 0)
+	assert.NoError(t, err)
+	sink.Record(event)
+	assert.Len(t, readAuditLog(t, path), 2)
+
+	_, err = NewFileAuditSink(filepath.Join(dir, "nonexistent", "audit.log"), 0, 0)
+	assert.Contains(t, err.Error(), "failed opening audit log")
+}
+
+func TestAuditSinkFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	defer viper.Reset()
+
+	viper.Set("peer.gossip.pvtData.auditLog.path", "")
+	sink, err := AuditSinkFromConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, sink)
+
+	viper.Set("peer.gossip.pvtData.auditLog.path", filepath.Join(dir, "audit.log"))
+	viper.Set("peer.gossip.pvtData.auditLog.maxSizeMB", 1)
+	viper.Set("peer.gossip.pvtData.auditLog.maxBackups", 3)
+	sink, err = AuditSinkFromConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024*1024), sink.(*fileAuditSink).maxSize)
+	assert.Equal(t, 3, sink.(*fileAuditSink).maxBackups)
+}
+
+func TestAuditHelpers(t *testing.T) {
+	sID, _ := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("p1")})
+	assert.Equal(t, "Org1MSP", orgOfIdentity(sID))
+	assert.Equal(t, "", orgOfIdentity([]byte{1, 2, 3}))
+
+	rws, _ := proto.Marshal(&kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{Key: "k1"}, {Key: "k2"}},
+	})
+	assert.Equal(t, 4, countKeys([]util.PrivateRWSet{rws, rws, {1, 2, 3}}))
+}
@@ -90,6 +90,10 @@ type Coordinator interface {
 	// Get recent block sequence number
 	LedgerHeight() (uint64, error)
 
+	// MissingPvtDataStats returns a summary of the private data that wasn't available
+	// when blocks were committed
+	MissingPvtDataStats() MissingPvtDataStats
+
 	// Close coordinator, shuts down coordinator service
 	Close()
 }
@@ -118,12 +122,16 @@ type Support struct {
 	committer.Committer
 	TransientStore
 	Fetcher
+	// MissingPvtDataPath is the file the private data missing at commit time is recorded in,
+	// so its statistics survive restarts. It is only kept in memory if the path is empty.
+	MissingPvtDataPath string
 }
 
 type coordinator struct {
 	selfSignedData common.SignedData
 	Support
 	transientBlockRetention uint64
+	missingPvtData          *missingPvtDataTracker
 }
 
 // NewCoordinator creates a new instance of coordinator
@@ -133,7 +141,14 @@ func NewCoordinator(support Support, selfSignedData common.SignedData) Coordinat
 		logger.Warning("Configuration key", transientBlockRetentionConfigKey, "isn't set, defaulting to", transientBlockRetentionDefault)
 		transientBlockRetention = transientBlockRetentionDefault
 	}
-	return &coordinator{Support: support, selfSignedData: selfSignedData, transientBlockRetention: transientBlockRetention}
+	return &coordinator{Support: support, selfSignedData: selfSignedData, transientBlockRetention: transientBlockRetention,
+		missingPvtData: newMissingPvtDataTracker(support.MissingPvtDataPath)}
+}
+
+// MissingPvtDataStats returns a summary of the private data that wasn't available
+// when blocks were committed
+func (c *coordinator) MissingPvtDataStats() MissingPvtDataStats {
+	return c.missingPvtData.summary()
 }
 
 // StorePvtData used to persist private date into transient store
@@ -225,6 +240,7 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	if err != nil {
 		return errors.Wrap(err, "commit failed")
 	}
+	c.missingPvtData.record(block.Header.Number, blockAndPvtData.Missing)
 
 	if len(blockAndPvtData.BlockPvtData) > 0 {
 		// Finally, purge all transactions in block - valid or not valid.
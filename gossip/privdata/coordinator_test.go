@@ -396,6 +396,10 @@ func (cap *collectionAccessPolicy) MemberOrgs() []string {
 	return []string{"org0", "org1"}
 }
 
+func (cap *collectionAccessPolicy) MemberAccessFilter() privdata.Filter {
+	return cap.AccessFilter()
+}
+
 func (cap *collectionAccessPolicy) RequiredPeerCount() int {
 	return 1
 }
@@ -1150,10 +1154,16 @@ func TestProceedWithoutPrivateData(t *testing.T) {
 		TransientStore:  store,
 		Validator:       &validatorMock{},
 	}, peerSelfSignedData)
+	assert.Empty(t, coordinator.MissingPvtDataStats().BlockRanges)
 	err := coordinator.StoreBlock(block, pvtData)
 	assert.NoError(t, err)
 	assertCommitHappened()
 	assertPurged("tx1")
+	// The missing private data is reported
+	assert.Equal(t, MissingPvtDataStats{
+		ByChaincode: map[string]map[string]int{"ns3": {"c2": 1}},
+		BlockRanges: []BlockRange{{From: block.Header.Number, To: block.Header.Number}},
+	}, coordinator.MissingPvtDataStats())
 }
 
 func TestCoordinatorGetBlocks(t *testing.T) {
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"sync"
+	"time"
+
+	gossip2 "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/spf13/viper"
+)
+
+const defaultDisseminationLogSize = 1000
+
+// DisseminationRecord describes how the private data a transaction wrote to a collection was
+// disseminated to other peers at endorsement time, meant for debugging private data that
+// isn't available at some of the peers of the collection
+type DisseminationRecord struct {
+	Time          time.Time                `json:"time"`
+	TxID          string                   `json:"tx_id"`
+	BlockHeight   uint64                   `json:"block_height"`
+	Namespace     string                   `json:"namespace"`
+	Collection    string                   `json:"collection"`
+	RequiredPeers int                      `json:"required_peers"`
+	MaxPeers      int                      `json:"max_peers"`
+	EligiblePeers int                      `json:"eligible_peers"`
+	Peers         []gossip2.PeerSendResult `json:"peers"`
+	Acks          int                      `json:"acks"`
+	Error         string                   `json:"error,omitempty"`
+}
+
+// disseminationLog keeps the most recent records of the disseminations of the private data of a channel
+type disseminationLog struct {
+	lock    sync.Mutex
+	records []DisseminationRecord
+	next    int
+	full    bool
+}
+
+// newDisseminationLog creates a disseminationLog that keeps the
+// number of records set by peer.gossip.pvtData.disseminationLogSize
+func newDisseminationLog() *disseminationLog {
+	size := viper.GetInt("peer.gossip.pvtData.disseminationLogSize")
+	if size <= 0 {
+		size = defaultDisseminationLogSize
+	}
+	return &disseminationLog{records: make([]DisseminationRecord, size)}
+}
+
+// add adds a record to the log, evicting the oldest record if the log is full
+func (l *disseminationLog) add(record DisseminationRecord) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.records[l.next] = record
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// lookup returns, oldest first, the records of the transactions simulated at or above
+// the given block height, and only those of the given transaction if txID isn't empty
+func (l *disseminationLog) lookup(startBlock uint64, txID string) []DisseminationRecord {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var ordered []DisseminationRecord
+	if l.full {
+		ordered = append(ordered, l.records[l.next:]...)
+	}
+	ordered = append(ordered, l.records[:l.next]...)
+
+	res := []DisseminationRecord{}
+	for _, record := range ordered {
+		if record.BlockHeight < startBlock || (txID != "" && record.TxID != txID) {
+			continue
+		}
+		res = append(res, record)
+	}
+	return res
+}
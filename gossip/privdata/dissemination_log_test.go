@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisseminationLog(t *testing.T) {
+	viper.Set("peer.gossip.pvtData.disseminationLogSize", 3)
+	defer viper.Set("peer.gossip.pvtData.disseminationLogSize", 0)
+
+	txIDs := func(records []DisseminationRecord) []string {
+		res := []string{}
+		for _, record := range records {
+			res = append(res, record.TxID)
+		}
+		return res
+	}
+
+	log := newDisseminationLog()
+	assert.Empty(t, log.lookup(0, ""))
+	log.add(DisseminationRecord{TxID: "tx1", BlockHeight: 1})
+	log.add(DisseminationRecord{TxID: "tx2", BlockHeight: 2})
+	assert.Equal(t, []string{"tx1", "tx2"}, txIDs(log.lookup(0, "")))
+
+	// The oldest records are evicted once the log is full
+	log.add(DisseminationRecord{TxID: "tx3", BlockHeight: 2})
+	log.add(DisseminationRecord{TxID: "tx4", BlockHeight: 3})
+	log.add(DisseminationRecord{TxID: "tx5", BlockHeight: 4})
+	assert.Equal(t, []string{"tx3", "tx4", "tx5"}, txIDs(log.lookup(0, "")))
+	assert.Equal(t, []string{"tx4", "tx5"}, txIDs(log.lookup(3, "")))
+	assert.Equal(t, []string{"tx4"}, txIDs(log.lookup(0, "tx4")))
+	assert.Empty(t, log.lookup(0, "tx1"))
+
+	viper.Set("peer.gossip.pvtData.disseminationLogSize", 0)
+	assert.Len(t, newDisseminationLog().records, defaultDisseminationLogSize)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -41,6 +42,10 @@ type gossipAdapter interface {
 type PvtDataDistributor interface {
 	// Distribute broadcast reliably private data read write set based on policies
 	Distribute(txID string, privData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error
+
+	// Disseminations returns, oldest first, the records of the most recent disseminations of the private data
+	// of transactions simulated at or above the given block height, and only of the given transaction if txID isn't empty
+	Disseminations(startBlock uint64, txID string) []DisseminationRecord
 }
 
 // IdentityDeserializerFactory is a factory interface to create
@@ -56,6 +61,7 @@ type distributorImpl struct {
 	chainID string
 	gossipAdapter
 	CollectionAccessFactory
+	disseminations *disseminationLog
 }
 
 // CollectionAccessFactory an interface to generate collection access policy
@@ -97,6 +103,7 @@ func NewDistributor(chainID string, gossip gossipAdapter, factory CollectionAcce
 		chainID:                 chainID,
 		gossipAdapter:           gossip,
 		CollectionAccessFactory: factory,
+		disseminations:          newDisseminationLog(),
 	}
 }
 
@@ -211,7 +218,13 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 	for _, dis := range disseminationPlan {
 		go func(dis *dissemination) {
 			defer wg.Done()
-			err := d.SendByCriteria(dis.msg, dis.criteria)
+			var report gossip2.SendReport
+			criteria := dis.criteria
+			criteria.OnSent = func(r gossip2.SendReport) {
+				report = r
+			}
+			err := d.SendByCriteria(dis.msg, criteria)
+			d.recordDissemination(dis, report, err)
 			if err != nil {
 				atomic.AddUint32(&failures, 1)
 				m := dis.msg.GetPrivateData().Payload
@@ -227,6 +240,37 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 	return nil
 }
 
+// recordDissemination records to which peers the private data of a dissemination was sent, and how they responded
+func (d *distributorImpl) recordDissemination(dis *dissemination, report gossip2.SendReport, err error) {
+	m := dis.msg.GetPrivateData().Payload
+	record := DisseminationRecord{
+		Time:          time.Now(),
+		TxID:          m.TxId,
+		BlockHeight:   m.PrivateSimHeight,
+		Namespace:     m.Namespace,
+		Collection:    m.CollectionName,
+		RequiredPeers: dis.criteria.MinAck,
+		MaxPeers:      dis.criteria.MaxPeers,
+		EligiblePeers: report.EligiblePeers,
+		Peers:         report.Peers,
+	}
+	for _, peer := range report.Peers {
+		if peer.Error == "" {
+			record.Acks++
+		}
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	d.disseminations.add(record)
+}
+
+// Disseminations returns, oldest first, the records of the most recent disseminations of the private data
+// of transactions simulated at or above the given block height, and only of the given transaction if txID isn't empty
+func (d *distributorImpl) Disseminations(startBlock uint64, txID string) []DisseminationRecord {
+	return d.disseminations.lookup(startBlock, txID)
+}
+
 func (d *distributorImpl) createPrivateDataMessage(txID, namespace string,
 	collection *rwset.CollectionPvtReadWriteSet,
 	ccp *common.CollectionConfigPackage,
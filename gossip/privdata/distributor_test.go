@@ -41,6 +41,11 @@ func (mock *collectionAccessPolicyMock) AccessFilter() privdata.Filter {
 	return args.Get(0).(privdata.Filter)
 }
 
+func (mock *collectionAccessPolicyMock) MemberAccessFilter() privdata.Filter {
+	args := mock.Called()
+	return args.Get(0).(privdata.Filter)
+}
+
 func (mock *collectionAccessPolicyMock) RequiredPeerCount() int {
 	args := mock.Called()
 	return args.Int(0)
@@ -59,6 +64,7 @@ func (mock *collectionAccessPolicyMock) MemberOrgs() []string {
 func (mock *collectionAccessPolicyMock) Setup(requiredPeerCount int, maxPeerCount int,
 	accessFilter privdata.Filter, orgs []string) {
 	mock.On("AccessFilter").Return(accessFilter)
+	mock.On("MemberAccessFilter").Return(accessFilter)
 	mock.On("RequiredPeerCount").Return(requiredPeerCount)
 	mock.On("MaximumPeerCount").Return(maxPeerCount)
 	mock.On("MemberOrgs").Return(orgs)
@@ -210,3 +216,80 @@ func TestDistributor(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Failed disseminating 2 out of 2 private RWSets")
 }
+
+func TestDistributorRecordsDisseminations(t *testing.T) {
+	g := &gossipMock{
+		Mock: mock.Mock{},
+		PeerSignature: api.PeerSignature{
+			Signature:    []byte{3, 4, 5},
+			Message:      []byte{6, 7, 8},
+			PeerIdentity: []byte{0, 1, 2},
+		},
+	}
+	report := gossip2.SendReport{
+		EligiblePeers: 3,
+		Peers: []gossip2.PeerSendResult{
+			{PKIid: "0a0b", Organization: "org1", Endpoint: "p1:7051"},
+			{PKIid: "0c0d", Organization: "org2", Endpoint: "p2:7051", Error: "timed out"},
+		},
+	}
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(gossip2.SendCriteria).OnSent(report)
+	}).Return(nil).Once()
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(gossip2.SendCriteria).OnSent(gossip2.SendReport{EligiblePeers: 0})
+	}).Return(errors.New("Requested to send to at least 1 peers, but know only of 0 suitable peers")).Once()
+
+	c1ColConfig := &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name:              "c1",
+				RequiredPeerCount: 1,
+				MaximumPeerCount:  2,
+			},
+		},
+	}
+	policyMock := &collectionAccessPolicyMock{}
+	policyMock.Setup(1, 2, func(_ common.SignedData) bool {
+		return true
+	}, []string{"org1", "org2"})
+	accessFactoryMock := &collectionAccessFactoryMock{}
+	accessFactoryMock.On("AccessPolicy", c1ColConfig, "test").Return(policyMock, nil)
+
+	d := NewDistributor("test", g, accessFactoryMock)
+	pdFactory := &pvtDataFactory{}
+	pvtData := pdFactory.addRWSet().addNSRWSet("ns1", "c1").addRWSet().addNSRWSet("ns1", "c1").create()
+	configs := map[string]*common.CollectionConfigPackage{
+		"ns1": {Config: []*common.CollectionConfig{c1ColConfig}},
+	}
+	err := d.Distribute("tx1", &transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: pvtData[0].WriteSet, CollectionConfigs: configs}, 5)
+	assert.NoError(t, err)
+	err = d.Distribute("tx2", &transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: pvtData[1].WriteSet, CollectionConfigs: configs}, 7)
+	assert.Error(t, err)
+
+	records := d.Disseminations(0, "")
+	assert.Len(t, records, 2)
+	assert.Equal(t, "tx1", records[0].TxID)
+	assert.Equal(t, uint64(5), records[0].BlockHeight)
+	assert.Equal(t, "ns1", records[0].Namespace)
+	assert.Equal(t, "c1", records[0].Collection)
+	assert.Equal(t, 1, records[0].RequiredPeers)
+	assert.Equal(t, 2, records[0].MaxPeers)
+	assert.Equal(t, 3, records[0].EligiblePeers)
+	assert.Equal(t, report.Peers, records[0].Peers)
+	assert.Equal(t, 1, records[0].Acks)
+	assert.Empty(t, records[0].Error)
+
+	assert.Equal(t, "tx2", records[1].TxID)
+	assert.Equal(t, 0, records[1].EligiblePeers)
+	assert.Equal(t, 0, records[1].Acks)
+	assert.Equal(t, "Requested to send to at least 1 peers, but know only of 0 suitable peers", records[1].Error)
+
+	records = d.Disseminations(6, "")
+	assert.Len(t, records, 1)
+	assert.Equal(t, "tx2", records[0].TxID)
+	records = d.Disseminations(0, "tx1")
+	assert.Len(t, records, 1)
+	assert.Equal(t, "tx1", records[0].TxID)
+	assert.Empty(t, d.Disseminations(8, ""))
+}
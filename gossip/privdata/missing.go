@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+// maxMissingBlockRanges is the maximum number of block ranges that are kept.
+// Once exceeded, the two oldest ranges are merged into one.
+const maxMissingBlockRanges = 1000
+
+// BlockRange is a range of consecutive blocks, inclusive on both ends
+type BlockRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// MissingPvtDataStats summarizes the private data that wasn't available
+// when blocks were committed
+type MissingPvtDataStats struct {
+	// ByChaincode maps chaincode names to collection names to the number of
+	// transactions that were committed without the private data of the collection
+	ByChaincode map[string]map[string]int `json:"by_chaincode"`
+	// BlockRanges are the ranges of blocks that were committed with missing private data.
+	// Only the most recent ranges are precise, as the oldest ones are merged
+	// once there are too many of them, and may contain blocks that weren't missing private data.
+	BlockRanges []BlockRange `json:"block_ranges"`
+}
+
+// missingPvtDataTracker keeps track of private data that was missing
+// when blocks were committed, and persists it to a file if a path is given
+type missingPvtDataTracker struct {
+	lock  sync.RWMutex
+	path  string
+	stats MissingPvtDataStats
+}
+
+// newMissingPvtDataTracker creates a missingPvtDataTracker that persists the
+// missing private data to the file at the given path, and loads the missing
+// private data that was persisted to it before. If the path is empty,
+// the missing private data is only kept in memory.
+func newMissingPvtDataTracker(path string) *missingPvtDataTracker {
+	t := &missingPvtDataTracker{
+		path: path,
+		stats: MissingPvtDataStats{
+			ByChaincode: make(map[string]map[string]int),
+		},
+	}
+	if path == "" {
+		return t
+	}
+	if err := t.load(); err != nil {
+		logger.Warningf("Failed loading missing private data from %s: %+v", path, err)
+	}
+	return t
+}
+
+// record records the private data that was missing when the given block was committed
+func (t *missingPvtDataTracker) record(blockSeq uint64, missing []ledger.MissingPrivateData) {
+	if len(missing) == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stats.BlockRanges = addToBlockRanges(t.stats.BlockRanges, blockSeq)
+	for _, m := range missing {
+		if _, exists := t.stats.ByChaincode[m.Namespace]; !exists {
+			t.stats.ByChaincode[m.Namespace] = make(map[string]int)
+		}
+		t.stats.ByChaincode[m.Namespace][m.Collection]++
+	}
+	if t.path == "" {
+		return
+	}
+	if err := t.persist(); err != nil {
+		logger.Warningf("Failed persisting missing private data to %s: %+v", t.path, err)
+	}
+}
+
+// summary returns a summary of the recorded missing private data
+func (t *missingPvtDataTracker) summary() MissingPvtDataStats {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	res := MissingPvtDataStats{
+		ByChaincode: make(map[string]map[string]int),
+	}
+	for cc, collections := range t.stats.ByChaincode {
+		res.ByChaincode[cc] = make(map[string]int)
+		for col, count := range collections {
+			res.ByChaincode[cc][col] = count
+		}
+	}
+	if len(t.stats.BlockRanges) > 0 {
+		res.BlockRanges = append([]BlockRange(nil), t.stats.BlockRanges...)
+	}
+	return res
+}
+
+func (t *missingPvtDataTracker) load() error {
+	raw, err := ioutil.ReadFile(t.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	stats := MissingPvtDataStats{}
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return errors.Wrap(err, "failed unmarshaling missing private data")
+	}
+	if stats.ByChaincode != nil {
+		t.stats.ByChaincode = stats.ByChaincode
+	}
+	t.stats.BlockRanges = stats.BlockRanges
+	return nil
+}
+
+// persist writes the missing private data to a temporary file and renames it
+// to the path of the tracker, so a crash never leaves a partially written file behind
+func (t *missingPvtDataTracker) persist() error {
+	raw, err := json.Marshal(t.stats)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling missing private data")
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	tmpPath := t.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, raw, 0644); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmpPath, t.path))
+}
+
+// addToBlockRanges adds the given block to the given sorted block ranges,
+// extending or merging the ranges adjacent to it, and merges the two oldest
+// ranges if there are too many of them
+func addToBlockRanges(ranges []BlockRange, seq uint64) []BlockRange {
+	// i is the index of the first range that starts after the block
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].From > seq
+	})
+	if i > 0 && ranges[i-1].To >= seq {
+		// The block is already within a range
+		return ranges
+	}
+	extendsPrev := i > 0 && ranges[i-1].To+1 == seq
+	extendsNext := i < len(ranges) && ranges[i].From == seq+1
+	switch {
+	case extendsPrev && extendsNext:
+		ranges[i-1].To = ranges[i].To
+		ranges = append(ranges[:i], ranges[i+1:]...)
+	case extendsPrev:
+		ranges[i-1].To = seq
+	case extendsNext:
+		ranges[i].From = seq
+	default:
+		ranges = append(ranges, BlockRange{})
+		copy(ranges[i+1:], ranges[i:])
+		ranges[i] = BlockRange{From: seq, To: seq}
+	}
+	if len(ranges) > maxMissingBlockRanges {
+		ranges[1].From = ranges[0].From
+		ranges = ranges[1:]
+	}
+	return ranges
+}
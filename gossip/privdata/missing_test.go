@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func missingPvtData(txID, ns, col string) ledger.MissingPrivateData {
+	return ledger.MissingPrivateData{TxId: txID, Namespace: ns, Collection: col}
+}
+
+func TestMissingPvtDataTracker(t *testing.T) {
+	tracker := newMissingPvtDataTracker("")
+	assert.Equal(t, MissingPvtDataStats{ByChaincode: map[string]map[string]int{}}, tracker.summary())
+
+	tracker.record(5, []ledger.MissingPrivateData{missingPvtData("tx1", "cc1", "col1"), missingPvtData("tx1", "cc1", "col2")})
+	tracker.record(3, []ledger.MissingPrivateData{missingPvtData("tx2", "cc1", "col1")})
+	tracker.record(4, []ledger.MissingPrivateData{missingPvtData("tx3", "cc2", "col1")})
+	tracker.record(6, nil)
+	tracker.record(10, []ledger.MissingPrivateData{missingPvtData("tx4", "cc1", "col1")})
+
+	stats := tracker.summary()
+	assert.Equal(t, map[string]map[string]int{
+		"cc1": {"col1": 3, "col2": 1},
+		"cc2": {"col1": 1},
+	}, stats.ByChaincode)
+	assert.Equal(t, []BlockRange{{From: 3, To: 5}, {From: 10, To: 10}}, stats.BlockRanges)
+
+	// Ensure the stats are a copy
+	stats.ByChaincode["cc1"]["col1"] = 100
+	stats.BlockRanges[0].To = 100
+	assert.Equal(t, 3, tracker.summary().ByChaincode["cc1"]["col1"])
+	assert.Equal(t, uint64(5), tracker.summary().BlockRanges[0].To)
+}
+
+func TestMissingPvtDataTrackerPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "missingpvtdata")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "channels", "mychannel.json")
+
+	tracker := newMissingPvtDataTracker(path)
+	tracker.record(7, []ledger.MissingPrivateData{missingPvtData("tx1", "cc1", "col1")})
+	tracker.record(8, []ledger.MissingPrivateData{missingPvtData("tx2", "cc1", "col1")})
+
+	// The missing private data survives a restart
+	restarted := newMissingPvtDataTracker(path)
+	assert.Equal(t, tracker.summary(), restarted.summary())
+	restarted.record(9, []ledger.MissingPrivateData{missingPvtData("tx3", "cc1", "col2")})
+	assert.Equal(t, MissingPvtDataStats{
+		ByChaincode: map[string]map[string]int{"cc1": {"col1": 2, "col2": 1}},
+		BlockRanges: []BlockRange{{From: 7, To: 9}},
+	}, newMissingPvtDataTracker(path).summary())
+
+	// A corrupted file is ignored
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{"), 0644))
+	assert.Equal(t, MissingPvtDataStats{ByChaincode: map[string]map[string]int{}}, newMissingPvtDataTracker(path).summary())
+}
+
+func TestAddToBlockRanges(t *testing.T) {
+	var ranges []BlockRange
+	for _, seq := range []uint64{10, 12, 5, 11, 12, 4, 20} {
+		ranges = addToBlockRanges(ranges, seq)
+	}
+	assert.Equal(t, []BlockRange{{From: 4, To: 5}, {From: 10, To: 12}, {From: 20, To: 20}}, ranges)
+
+	// Once there are too many ranges, the oldest ones are merged
+	ranges = nil
+	for i := 0; i < maxMissingBlockRanges+2; i++ {
+		ranges = addToBlockRanges(ranges, uint64(i*2))
+	}
+	assert.Len(t, ranges, maxMissingBlockRanges)
+	assert.Equal(t, BlockRange{From: 0, To: 4}, ranges[0])
+	assert.Equal(t, BlockRange{From: 6, To: 6}, ranges[1])
+	assert.Equal(t, BlockRange{From: uint64(maxMissingBlockRanges+1) * 2, To: uint64(maxMissingBlockRanges+1) * 2}, ranges[len(ranges)-1])
+}
@@ -8,6 +8,7 @@ package privdata
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -65,18 +66,21 @@ type puller struct {
 	msgChan  <-chan proto.ReceivedMessage
 	channel  string
 	cs       privdata.CollectionStore
+	audit    AuditSink
 	gossip
 	PrivateDataRetriever
 	CollectionAccessFactory
 }
 
-// NewPuller creates new private data puller
-func NewPuller(cs privdata.CollectionStore, g gossip, dataRetriever PrivateDataRetriever, factory CollectionAccessFactory, channel string) *puller {
+// NewPuller creates new private data puller.
+// If auditSink isn't nil, every private data request served to remote peers is recorded in it.
+func NewPuller(cs privdata.CollectionStore, g gossip, dataRetriever PrivateDataRetriever, factory CollectionAccessFactory, channel string, auditSink AuditSink) *puller {
 	p := &puller{
 		pubSub:                  util.NewPubSub(),
 		stopChan:                make(chan struct{}),
 		channel:                 channel,
 		cs:                      cs,
+		audit:                   auditSink,
 		gossip:                  g,
 		PrivateDataRetriever:    dataRetriever,
 		CollectionAccessFactory: factory,
@@ -138,17 +142,20 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 		rwSets := p.CollectionRWSet(dig)
 		logger.Debug("Found", len(rwSets.RWSet), "for TxID", dig.TxId, ", collection", dig.Collection, "for", message.GetConnectionInfo().Endpoint)
 		if len(rwSets.RWSet) == 0 {
+			p.auditRequest(message, dig, nil, AccessNotFound)
 			continue
 		}
 
 		colAP, err := p.AccessPolicy(rwSets.CollectionConfig, p.channel)
 		if err != nil {
 			logger.Debug("No policy found for channel", p.channel, ", collection", dig.Collection, "txID", dig.TxId, ":", err, "skipping...")
+			p.auditRequest(message, dig, rwSets.RWSet, AccessNoPolicy)
 			continue
 		}
-		colFilter := colAP.AccessFilter()
+		colFilter := colAP.MemberAccessFilter()
 		if colFilter == nil {
 			logger.Debug("Collection ", dig.Collection, " has no access filter, txID", dig.TxId, "skipping...")
+			p.auditRequest(message, dig, rwSets.RWSet, AccessNoPolicy)
 			continue
 		}
 		eligibleForCollection := colFilter(fcommon.SignedData{
@@ -159,9 +166,11 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 
 		if !eligibleForCollection {
 			logger.Debug("Peer", message.GetConnectionInfo().Endpoint, "isn't eligible for txID", dig.TxId, "at collection", dig.Collection)
+			p.auditRequest(message, dig, rwSets.RWSet, AccessDenied)
 			continue
 		}
 
+		p.auditRequest(message, dig, rwSets.RWSet, AccessGranted)
 		returned = append(returned, &proto.PvtDataElement{
 			Digest:  dig,
 			Payload: util.PrivateRWSets(rwSets.RWSet...),
@@ -170,6 +179,27 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 	return returned
 }
 
+// auditRequest records the decision taken for a digest requested by a remote peer
+func (p *puller) auditRequest(message proto.ReceivedMessage, dig *proto.PvtDataDigest, rwSets []util.PrivateRWSet, decision AccessDecision) {
+	if p.audit == nil {
+		return
+	}
+	connInfo := message.GetConnectionInfo()
+	p.audit.Record(AuditEvent{
+		Time:       time.Now(),
+		Channel:    p.channel,
+		Org:        orgOfIdentity(connInfo.Identity),
+		Endpoint:   connInfo.Endpoint,
+		PKIID:      hex.EncodeToString(connInfo.ID),
+		Namespace:  dig.Namespace,
+		Collection: dig.Collection,
+		TxID:       dig.TxId,
+		BlockSeq:   dig.BlockSeq,
+		KeyCount:   countKeys(rwSets),
+		Decision:   decision,
+	})
+}
+
 func (p *puller) handleResponse(message proto.ReceivedMessage) {
 	msg := message.GetGossipMessage().GetPrivateRes()
 	logger.Debug("Got", msg, "from", message.GetConnectionInfo().Endpoint)
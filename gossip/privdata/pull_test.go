@@ -95,6 +95,10 @@ func (mc *mockCollectionAccess) AccessFilter() privdata.Filter {
 	return policy2Filter[mc]
 }
 
+func (mc *mockCollectionAccess) MemberAccessFilter() privdata.Filter {
+	return mc.AccessFilter()
+}
+
 func (mc *mockCollectionAccess) RequiredPeerCount() int {
 	return 0
 }
@@ -210,7 +214,7 @@ func (gn *gossipNetwork) newPuller(id string, ps privdata.CollectionStore, facto
 	}
 	g.On("PeersOfChannel", mock.Anything).Return(peers)
 
-	p := NewPuller(ps, g, &dataRetrieverMock{}, factory, "A")
+	p := NewPuller(ps, g, &dataRetrieverMock{}, factory, "A", nil)
 	gn.peers = append(gn.peers, g)
 	return p
 }
@@ -276,6 +280,9 @@ func TestPullerFromOnly1Peer(t *testing.T) {
 		t.Fatal("p3 shouldn't have been selected for pull")
 	})
 
+	auditSink := &auditSinkMock{}
+	p2.audit = auditSink
+
 	dasf := &digestsAndSourceFactory{}
 
 	fetchedMessages, err := p1.fetch(dasf.mapDigest(dig).toSources().create())
@@ -284,6 +291,15 @@ func TestPullerFromOnly1Peer(t *testing.T) {
 	fetched := []util.PrivateRWSet{rws1, rws2}
 	assert.NoError(t, err)
 	assert.Equal(t, p2TransientStore.RWSet, fetched)
+
+	// p2 recorded that it served the private data to p1
+	events := auditSink.recorded()
+	assert.Len(t, events, 1)
+	assert.Equal(t, "A", events[0].Channel)
+	assert.Equal(t, "ns1", events[0].Namespace)
+	assert.Equal(t, "col1", events[0].Collection)
+	assert.Equal(t, "txID1", events[0].TxID)
+	assert.Equal(t, AccessGranted, events[0].Decision)
 }
 
 func TestPullerDataNotAvailable(t *testing.T) {
@@ -416,11 +432,76 @@ func TestPullerPeerNotEligible(t *testing.T) {
 			},
 		},
 	})
+	auditSink := &auditSinkMock{}
+	p2.audit = auditSink
+	p3.audit = auditSink
 	dasf := &digestsAndSourceFactory{}
 	d2s := dasf.mapDigest(&proto.PvtDataDigest{Collection: "col1", TxId: "txID1", Namespace: "ns1"}).toSources().create()
 	fetchedMessages, err := p1.fetch(d2s)
 	assert.Empty(t, fetchedMessages)
 	assert.NoError(t, err)
+	// The denied requests were recorded
+	events := auditSink.recorded()
+	assert.NotEmpty(t, events)
+	for _, event := range events {
+		assert.Equal(t, AccessDenied, event.Decision)
+		assert.Equal(t, "col1", event.Collection)
+	}
+}
+
+func TestPullerPeerNotEligibleByMemberAccessPolicy(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 pulls from p2, and it is in an org of the collection,
+	// but it doesn't satisfy the member access policy of the collection
+	gn := &gossipNetwork{}
+	policyStore := newCollectionStore().withPolicy("col1").thatMapsTo("p2")
+	factoryMock1 := &collectionAccessFactoryMock{}
+	policyMock1 := &collectionAccessPolicyMock{}
+	policyMock1.Setup(1, 2, func(data fcommon.SignedData) bool {
+		return bytes.Equal(data.Identity, []byte("p2"))
+	}, []string{"org1", "org2"})
+	factoryMock1.On("AccessPolicy", mock.Anything, mock.Anything).Return(policyMock1, nil)
+	p1 := gn.newPuller("p1", policyStore, factoryMock1, "p2")
+
+	policyStore = newCollectionStore().withPolicy("col1").thatMapsTo("p1")
+	factoryMock2 := &collectionAccessFactoryMock{}
+	policyMock2 := &collectionAccessPolicyMock{}
+	policyMock2.On("AccessFilter").Return(privdata.Filter(func(data fcommon.SignedData) bool {
+		return bytes.Equal(data.Identity, []byte("p1"))
+	}))
+	policyMock2.On("MemberAccessFilter").Return(privdata.Filter(func(data fcommon.SignedData) bool {
+		return false
+	}))
+	factoryMock2.On("AccessPolicy", mock.Anything, mock.Anything).Return(policyMock2, nil)
+
+	p2 := gn.newPuller("p2", policyStore, factoryMock2)
+	dig := &proto.PvtDataDigest{
+		TxId:       "txID1",
+		Collection: "col1",
+		Namespace:  "ns1",
+	}
+	p2.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", dig).Return(&util.PrivateRWSetWithConfig{
+		RWSet: newPRWSet(),
+		CollectionConfig: &fcommon.CollectionConfig{
+			Payload: &fcommon.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &fcommon.StaticCollectionConfig{
+					Name: "col1",
+				},
+			},
+		},
+	})
+	auditSink := &auditSinkMock{}
+	p2.audit = auditSink
+
+	dasf := &digestsAndSourceFactory{}
+	fetchedMessages, err := p1.fetch(dasf.mapDigest(dig).toSources().create())
+	assert.Empty(t, fetchedMessages)
+	assert.NoError(t, err)
+	events := auditSink.recorded()
+	assert.NotEmpty(t, events)
+	for _, event := range events {
+		assert.Equal(t, AccessDenied, event.Decision)
+	}
 }
 
 func TestPullerDifferentPeersDifferentCollections(t *testing.T) {
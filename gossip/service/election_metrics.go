@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// leaderElectionMetrics reports the leadership transitions of a channel
+type leaderElectionMetrics struct {
+	isLeader    metrics.Gauge
+	transitions metrics.Counter
+}
+
+// newLeaderElectionMetrics creates the leader election metrics of the given channel,
+// or returns nil if metrics aren't initialized
+func newLeaderElectionMetrics(chainID string) *leaderElectionMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("gossip_election").Tagged(map[string]string{"channel": chainID})
+	return &leaderElectionMetrics{
+		isLeader:    scope.Gauge("is_leader"),
+		transitions: scope.Counter("leadership_transitions"),
+	}
+}
+
+// report reports a transition to or from being the leader
+func (m *leaderElectionMetrics) report(isLeader bool) {
+	if m == nil {
+		return
+	}
+	m.transitions.Inc(1)
+	if isLeader {
+		m.isLeader.Update(1)
+	} else {
+		m.isLeader.Update(0)
+	}
+}
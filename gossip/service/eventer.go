@@ -8,8 +8,10 @@ package service
 
 import (
 	"reflect"
+	"sort"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/gossip/util"
 
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -27,6 +29,9 @@ type Config interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// OrdererOrgEndpoints returns a map of the MSP IDs of the ordering organizations to the endpoints of their orderers
+	OrdererOrgEndpoints() map[string][]string
 }
 
 // ConfigProcessor receives config updates
@@ -42,7 +47,7 @@ type configStore struct {
 
 type configEventReceiver interface {
 	updateAnchors(config Config)
-	updateEndpoints(chainID string, endpoints []string)
+	updateEndpoints(chainID string, endpoints []string, orgEndpoints map[string][]string)
 }
 
 type configEventer struct {
@@ -81,7 +86,24 @@ func (ce *configEventer) ProcessConfigUpdate(config Config) {
 		logger.Debugf("Calling out because config was updated for channel %s", config.ChainID())
 		ce.receiver.updateAnchors(config)
 	}
-	ce.receiver.updateEndpoints(config.ChainID(), config.OrdererAddresses())
+	ce.receiver.updateEndpoints(config.ChainID(), ordererEndpoints(config), config.OrdererOrgEndpoints())
+}
+
+// ordererEndpoints returns the orderer addresses of the channel followed by
+// the endpoints of the ordering organizations that aren't among them
+func ordererEndpoints(config Config) []string {
+	endpoints := append([]string{}, config.OrdererAddresses()...)
+	var orgEndpoints []string
+	for _, orgEndpoint := range config.OrdererOrgEndpoints() {
+		orgEndpoints = append(orgEndpoints, orgEndpoint...)
+	}
+	sort.Strings(orgEndpoints)
+	for _, endpoint := range orgEndpoints {
+		if !util.Contains(endpoint, endpoints) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
 }
 
 func cloneOrgConfig(src map[string]channelconfig.ApplicationOrg) map[string]channelconfig.ApplicationOrg {
@@ -22,8 +22,9 @@ func init() {
 }
 
 type mockReceiver struct {
-	orgs     map[string]channelconfig.ApplicationOrg
-	sequence uint64
+	orgs        map[string]channelconfig.ApplicationOrg
+	sequence    uint64
+	ordererOrgs map[string][]string
 }
 
 func (mr *mockReceiver) updateAnchors(config Config) {
@@ -32,7 +33,8 @@ func (mr *mockReceiver) updateAnchors(config Config) {
 	mr.sequence = config.Sequence()
 }
 
-func (mr *mockReceiver) updateEndpoints(chainID string, endpoints []string) {
+func (mr *mockReceiver) updateEndpoints(chainID string, endpoints []string, orgEndpoints map[string][]string) {
+	mr.ordererOrgs = orgEndpoints
 }
 
 type mockConfig mockReceiver
@@ -41,6 +43,10 @@ func (mc *mockConfig) OrdererAddresses() []string {
 	return []string{"localhost:7050"}
 }
 
+func (mc *mockConfig) OrdererOrgEndpoints() map[string][]string {
+	return mc.ordererOrgs
+}
+
 func (mc *mockConfig) Sequence() uint64 {
 	return mc.sequence
 }
@@ -155,3 +161,26 @@ func TestUpdatedSeqOnly(t *testing.T) {
 		t.Errorf("Should not have cleared anchor peers when reprocessing newer config with higher sequence")
 	}
 }
+
+func TestOrdererEndpoints(t *testing.T) {
+	mc := &mockConfig{
+		ordererOrgs: map[string][]string{
+			"OrdererOrg1": {"orderer1:7050", "localhost:7050"},
+			"OrdererOrg2": {"orderer2:7050"},
+		},
+	}
+
+	mr := &mockReceiver{}
+
+	ce := newConfigEventer(mr)
+	ce.ProcessConfigUpdate(mc)
+
+	if !reflect.DeepEqual(mr.ordererOrgs, mc.ordererOrgs) {
+		t.Errorf("Should have updated the endpoints of the ordering organizations")
+	}
+
+	expected := []string{"localhost:7050", "orderer1:7050", "orderer2:7050"}
+	if endpoints := ordererEndpoints(mc); !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("Expected orderer endpoints %v but got %v", expected, endpoints)
+	}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"path/filepath"
 	"sync"
 
 	"github.com/hyperledger/fabric/core/committer"
@@ -14,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/election"
@@ -50,6 +52,18 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// LeaderElectionStatus returns the status of the leader election
+	// of each channel the peer uses leader election in
+	LeaderElectionStatus() map[string]election.Status
+	// OverrideLeaderElection overrides the outcome of the leader election of the given channel
+	OverrideLeaderElection(chainID string, override election.Override) error
+	// PvtDataDisseminations returns, oldest first, the records of the recent disseminations of the private data
+	// of the given channel, of transactions simulated at or above the given block height,
+	// and only of the given transaction if txID isn't empty
+	PvtDataDisseminations(chainID string, startBlock uint64, txID string) ([]privdata2.DisseminationRecord, error)
+	// MissingPvtDataStats returns the statistics of the private data that was missing
+	// when the blocks of the given channel were committed
+	MissingPvtDataStats(chainID string) (privdata2.MissingPvtDataStats, error)
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -89,10 +103,15 @@ type gossipServiceImpl struct {
 	leaderElection  map[string]election.LeaderElectionService
 	deliveryService map[string]deliverclient.DeliverService
 	deliveryFactory DeliveryServiceFactory
-	lock            sync.RWMutex
-	mcs             api.MessageCryptoService
-	peerIdentity    []byte
-	secAdv          api.SecurityAdvisor
+	// ordererEndpoints and ordererOrgs are the latest orderer endpoints of each channel,
+	// and the endpoints of each of its ordering organizations
+	ordererEndpoints map[string][]string
+	ordererOrgs      map[string]map[string][]string
+	lock             sync.RWMutex
+	mcs              api.MessageCryptoService
+	peerIdentity     []byte
+	secAdv           api.SecurityAdvisor
+	auditSink        privdata2.AuditSink
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -148,18 +167,27 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 
 		logger.Info("Initialize gossip with endpoint", endpoint, "and bootstrap set", bootPeers)
 
+		var auditSink privdata2.AuditSink
+		auditSink, err = privdata2.AuditSinkFromConfig()
+		if err != nil {
+			return
+		}
+
 		gossip, err = integration.NewGossipComponent(peerIdentity, endpoint, s, secAdv,
 			mcs, secureDialOpts, certs, bootPeers...)
 		gossipServiceInstance = &gossipServiceImpl{
-			mcs:             mcs,
-			gossipSvc:       gossip,
-			privateHandlers: make(map[string]privateHandler),
-			chains:          make(map[string]state.GossipStateProvider),
-			leaderElection:  make(map[string]election.LeaderElectionService),
-			deliveryService: make(map[string]deliverclient.DeliverService),
-			deliveryFactory: factory,
-			peerIdentity:    peerIdentity,
-			secAdv:          secAdv,
+			mcs:              mcs,
+			gossipSvc:        gossip,
+			privateHandlers:  make(map[string]privateHandler),
+			chains:           make(map[string]state.GossipStateProvider),
+			leaderElection:   make(map[string]election.LeaderElectionService),
+			deliveryService:  make(map[string]deliverclient.DeliverService),
+			deliveryFactory:  factory,
+			ordererEndpoints: make(map[string][]string),
+			ordererOrgs:      make(map[string]map[string][]string),
+			peerIdentity:     peerIdentity,
+			secAdv:           secAdv,
+			auditSink:        auditSink,
 		}
 	})
 	return errors.WithStack(err)
@@ -167,6 +195,10 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 
 // GetGossipService returns an instance of gossip service
 func GetGossipService() GossipService {
+	// A nil instance is returned as a nil interface, so callers can tell that it isn't initialized
+	if gossipServiceInstance == nil {
+		return nil
+	}
 	return gossipServiceInstance
 }
 
@@ -232,7 +264,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 	// Initialize private data fetcher
 	dataRetriever := privdata2.NewDataRetriever(storeSupport)
 	collectionAccessFactory := privdata2.NewCollectionAccessFactory(support.IdDeserializeFactory)
-	fetcher := privdata2.NewPuller(support.Cs, g.gossipSvc, dataRetriever, collectionAccessFactory, chainID)
+	fetcher := privdata2.NewPuller(support.Cs, g.gossipSvc, dataRetriever, collectionAccessFactory, chainID, g.auditSink)
 
 	coordinator := privdata2.NewCoordinator(privdata2.Support{
 		CollectionStore: support.Cs,
@@ -240,6 +272,8 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 		TransientStore:  support.Store,
 		Committer:       support.Committer,
 		Fetcher:         fetcher,
+		// Recorded next to the ledgers, as it describes the blocks committed to them
+		MissingPvtDataPath: filepath.Join(ledgerconfig.GetRootPath(), "missingPvtData", chainID+".json"),
 	}, g.createSelfSignedData())
 
 	g.privateHandlers[chainID] = privateHandler{
@@ -249,10 +283,15 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 	}
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator)
 	if g.deliveryService[chainID] == nil {
+		if updated, exists := g.ordererEndpoints[chainID]; exists && len(updated) != 0 {
+			endpoints = updated
+		}
 		var err error
 		g.deliveryService[chainID], err = g.deliveryFactory.Service(g, endpoints, g.mcs)
 		if err != nil {
 			logger.Warningf("Cannot create delivery client, due to %+v", errors.WithStack(err))
+		} else {
+			g.deliveryService[chainID].UpdateOrdererOrgs(chainID, g.ordererOrgs[chainID])
 		}
 	}
 
@@ -325,9 +364,15 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 	g.JoinChan(jcm, gossipCommon.ChainID(config.ChainID()))
 }
 
-func (g *gossipServiceImpl) updateEndpoints(chainID string, endpoints []string) {
-	if ds, ok := g.deliveryService[chainID]; ok {
-		logger.Debugf("Updating endpoints for chainID", chainID)
+func (g *gossipServiceImpl) updateEndpoints(chainID string, endpoints []string, orgEndpoints map[string][]string) {
+	g.lock.Lock()
+	g.ordererEndpoints[chainID] = endpoints
+	g.ordererOrgs[chainID] = orgEndpoints
+	ds, ok := g.deliveryService[chainID]
+	g.lock.Unlock()
+	if ok {
+		logger.Debugf("Updating endpoints for chainID %s", chainID)
+		ds.UpdateOrdererOrgs(chainID, orgEndpoints)
 		if err := ds.UpdateEndpoints(chainID, endpoints); err != nil {
 			// The only reason to fail is because of absence of block provider
 			// for given channel id, hence printing a warning will be enough
@@ -364,6 +409,56 @@ func (g *gossipServiceImpl) Stop() {
 	g.gossipSvc.Stop()
 }
 
+// LeaderElectionStatus returns the status of the leader election
+// of each channel the peer uses leader election in
+func (g *gossipServiceImpl) LeaderElectionStatus() map[string]election.Status {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	res := make(map[string]election.Status, len(g.leaderElection))
+	for chainID, le := range g.leaderElection {
+		res[chainID] = le.Status()
+	}
+	return res
+}
+
+// OverrideLeaderElection overrides the outcome of the leader election of the given channel
+func (g *gossipServiceImpl) OverrideLeaderElection(chainID string, override election.Override) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("leader election isn't used in channel %s", chainID)
+	}
+	logger.Infof("Overriding leader election of channel %s with %s", chainID, override)
+	le.SetOverride(override)
+	return nil
+}
+
+// PvtDataDisseminations returns, oldest first, the records of the recent disseminations of the private data
+// of the given channel, of transactions simulated at or above the given block height,
+// and only of the given transaction if txID isn't empty
+func (g *gossipServiceImpl) PvtDataDisseminations(chainID string, startBlock uint64, txID string) ([]privdata2.DisseminationRecord, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("No private data handler for %s", chainID)
+	}
+	return handler.distributor.Disseminations(startBlock, txID), nil
+}
+
+// MissingPvtDataStats returns the statistics of the private data that was missing
+// when the blocks of the given channel were committed
+func (g *gossipServiceImpl) MissingPvtDataStats(chainID string) (privdata2.MissingPvtDataStats, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return privdata2.MissingPvtDataStats{}, errors.Errorf("No private data handler for %s", chainID)
+	}
+	return handler.coordinator.MissingPvtDataStats(), nil
+}
+
 func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool)) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
 	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID))
@@ -380,7 +475,9 @@ func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 }
 
 func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer blocksprovider.LedgerInfo) func(bool) {
+	leMetrics := newLeaderElectionMetrics(chainID)
 	return func(isLeader bool) {
+		leMetrics.report(isLeader)
 		if isLeader {
 			yield := func() {
 				g.lock.RLock()
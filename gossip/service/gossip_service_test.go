@@ -321,6 +321,9 @@ func (ds *mockDeliverService) UpdateEndpoints(chainID string, endpoints []string
 	panic("implement me")
 }
 
+func (ds *mockDeliverService) UpdateOrdererOrgs(chainID string, orgEndpoints map[string][]string) {
+}
+
 func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	ds.running[chainID] = true
 	return nil
@@ -666,14 +669,16 @@ func newGossipInstance(portPrefix int, id int, maxMsgCount int, boot ...int) Gos
 		selfID, nil)
 
 	gossipService := &gossipServiceImpl{
-		mcs:             cryptoService,
-		gossipSvc:       gossip,
-		chains:          make(map[string]state.GossipStateProvider),
-		leaderElection:  make(map[string]election.LeaderElectionService),
-		privateHandlers: make(map[string]privateHandler),
-		deliveryService: make(map[string]deliverclient.DeliverService),
-		deliveryFactory: &deliveryFactoryImpl{},
-		peerIdentity:    api.PeerIdentityType(conf.InternalEndpoint),
+		mcs:              cryptoService,
+		gossipSvc:        gossip,
+		chains:           make(map[string]state.GossipStateProvider),
+		leaderElection:   make(map[string]election.LeaderElectionService),
+		privateHandlers:  make(map[string]privateHandler),
+		deliveryService:  make(map[string]deliverclient.DeliverService),
+		deliveryFactory:  &deliveryFactoryImpl{},
+		ordererEndpoints: make(map[string][]string),
+		ordererOrgs:      make(map[string]map[string][]string),
+		peerIdentity:     api.PeerIdentityType(conf.InternalEndpoint),
 	}
 
 	return gossipService
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -60,6 +61,10 @@ func (*gossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
 
+func (*gossipMock) OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error) {
+	panic("implement me")
+}
+
 func (*gossipMock) Peers() []discovery.NetworkMember {
 	panic("implement me")
 }
@@ -72,6 +77,10 @@ func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateExternalEndpoint(endpoint string) {
+	panic("implement me")
+}
+
 // UpdateLedgerHeight updates the ledger height the peer
 // publishes to other peers in the channel
 func (*gossipMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
@@ -84,6 +93,12 @@ func (*gossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common
 	panic("implement me")
 }
 
+// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+// the peer publishes to other peers in the channel
+func (*gossipMock) UpdateLedgerSnapshots(heights []uint64, chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
@@ -104,6 +119,18 @@ func (g *gossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("implement me")
 }
 
+func (g *gossipMock) MembershipSnapshot() gossip.MembershipSnapshot {
+	panic("implement me")
+}
+
+func (g *gossipMock) PeerLatencies(channel common.ChainID) map[string]time.Duration {
+	panic("implement me")
+}
+
+func (g *gossipMock) OnIdentityPurge(listener func(identity.PurgeEvent)) {
+	panic("implement me")
+}
+
 func (*gossipMock) Stop() {
 	panic("implement me")
 }
@@ -136,6 +163,10 @@ func (c *configMock) OrdererAddresses() []string {
 	return []string{"localhost:7050"}
 }
 
+func (c *configMock) OrdererOrgEndpoints() map[string][]string {
+	return map[string][]string{}
+}
+
 func (*configMock) ChainID() string {
 	return "A"
 }
@@ -0,0 +1,217 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"io"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	blockStreamEnabledKey = "peer.gossip.state.blockStreamEnabled"
+
+	defMaxConcurrentBlockStreams = 5
+	defBlockStreamRetryInterval  = 5 * time.Minute
+	defBlockStreamBackoff        = 100 * time.Millisecond
+)
+
+// blockStreamEnabled returns whether missing blocks should be pulled
+// over dedicated block streams, which is the default
+func blockStreamEnabled() bool {
+	if !viper.IsSet(blockStreamEnabledKey) {
+		return true
+	}
+	return viper.GetBool(blockStreamEnabledKey)
+}
+
+// serveBlockStream streams the blocks requested over the given block stream,
+// unless too many block streams are already being served
+func (s *GossipStateProviderImpl) serveBlockStream(req comm.BlockStreamRequest) {
+	select {
+	case s.blockStreamSlots <- struct{}{}:
+	default:
+		logger.Warning("Too many block streams are being served, rejecting block stream of", req.GetConnectionInfo().Endpoint)
+		req.Close(errors.New("too many block streams are being served"))
+		return
+	}
+	defer func() {
+		<-s.blockStreamSlots
+	}()
+	req.Close(s.streamBlocks(req))
+}
+
+// streamBlocks sends the blocks in the requested range one by one,
+// each in its own state response. Unlike the state responses sent in gossip messages,
+// the range isn't bounded by the anti-entropy batch size, as the remote peer applies
+// back-pressure by not reading from the stream until it has room for more blocks.
+func (s *GossipStateProviderImpl) streamBlocks(req comm.BlockStreamRequest) error {
+	request := req.GetGossipMessage().GetStateRequest()
+	if request.StartSeqNum > request.EndSeqNum {
+		return errors.Errorf("invalid sequence interval [%d...%d]", request.StartSeqNum, request.EndSeqNum)
+	}
+
+	currentHeight, err := s.ledger.LedgerHeight()
+	if err != nil {
+		logger.Errorf("Cannot access to current ledger height, due to %+v", errors.WithStack(err))
+		return errors.New("cannot access ledger height")
+	}
+	if currentHeight == 0 || currentHeight-1 < request.StartSeqNum {
+		return errors.Errorf("ledger height is %d, cannot stream blocks starting from %d", currentHeight, request.StartSeqNum)
+	}
+	endSeqNum := min(currentHeight-1, request.EndSeqNum)
+
+	logger.Debugf("Streaming blocks [%d...%d] to %s", request.StartSeqNum, endSeqNum, req.GetConnectionInfo().Endpoint)
+	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
+		payload, err := s.payloadOf(seqNum, req.GetConnectionInfo())
+		if err != nil {
+			logger.Errorf("%+v, aborting block stream", err)
+			return errors.Errorf("failed reading block %d", seqNum)
+		}
+		err = req.Send(&proto.GossipMessage{
+			Nonce:   req.GetGossipMessage().Nonce,
+			Tag:     proto.GossipMessage_CHAN_OR_ORG,
+			Channel: []byte(s.chainID),
+			Content: &proto.GossipMessage_StateResponse{
+				StateResponse: &proto.RemoteStateResponse{
+					Payloads: []*proto.Payload{payload},
+				},
+			},
+		})
+		if err != nil {
+			logger.Warningf("Failed streaming block %d to %s: %v", seqNum, req.GetConnectionInfo().Endpoint, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// streamBlocksInRange attempts to pull the blocks in the range [start...end] over a
+// dedicated block stream from a peer that supports block streams.
+// Returns the sequence of the first block that wasn't received, and whether all blocks were received.
+func (s *GossipStateProviderImpl) streamBlocksInRange(start uint64, end uint64) (uint64, bool) {
+	if !s.blockStreamEnabled {
+		return start, false
+	}
+	peers := s.filterPeers(func(member discovery.NetworkMember) bool {
+		return s.hasRequiredHeight(end)(member) && s.supportsBlockStream(member)
+	})
+	if len(peers) == 0 {
+		return start, false
+	}
+	peer := peers[util.RandomInt(len(peers))]
+
+	logger.Debugf("State transfer, with peer %s, streaming blocks in range [%d...%d], "+
+		"for chainID %s", peer.Endpoint, start, end, s.chainID)
+	stream, err := s.mediator.OpenBlockStream(s.stateRequestMessage(start, end), peer)
+	if err != nil {
+		logger.Infof("Failed opening block stream to %s, falling back to gossip messages: %v", peer.Endpoint, err)
+		s.blockStreamFailures[string(peer.PKIID)] = time.Now()
+		return start, false
+	}
+	defer stream.Close()
+
+	next := start
+	for next <= end {
+		msg, err := s.recvFromBlockStream(stream)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.Warningf("Failed receiving blocks from %s, received blocks [%d...%d] out of [%d...%d]: %v",
+				peer.Endpoint, start, next-1, start, end, err)
+			return next, false
+		}
+		for _, payload := range msg.GetStateResponse().GetPayloads() {
+			if payload.SeqNum != next {
+				logger.Warningf("Expected block %d from %s but got block %d", next, peer.Endpoint, payload.SeqNum)
+				return next, false
+			}
+			if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
+				logger.Warningf("Error verifying block with sequence number %d, due to %+v", payload.SeqNum, errors.WithStack(err))
+				return next, false
+			}
+			if !s.waitForBufferSpace(payload.SeqNum) {
+				return next, false
+			}
+			if err := s.addPayload(payload); err != nil {
+				logger.Warningf("Payload with sequence number %d wasn't added to payload buffer: %v", payload.SeqNum, err)
+			}
+			next++
+		}
+	}
+	return next, next > end
+}
+
+// recvFromBlockStream receives the next message from the given block stream,
+// or returns an error if none is received in a timely manner or the state provider is stopped
+func (s *GossipStateProviderImpl) recvFromBlockStream(stream comm.BlockStream) (*proto.SignedGossipMessage, error) {
+	type recvResult struct {
+		msg *proto.SignedGossipMessage
+		err error
+	}
+	resChan := make(chan recvResult, 1)
+	go func() {
+		msg, err := stream.Recv()
+		resChan <- recvResult{msg: msg, err: err}
+	}()
+
+	select {
+	case res := <-resChan:
+		if res.err == nil && res.msg.GetStateResponse() == nil {
+			return nil, errors.Errorf("expected a state response but got %v", res.msg)
+		}
+		return res.msg, res.err
+	case <-time.After(defAntiEntropyStateResponseTimeout):
+		return nil, errors.New("timed out waiting for blocks")
+	case <-s.stopCh:
+		s.stopCh <- struct{}{}
+		return nil, errors.New("state provider is stopping")
+	}
+}
+
+// waitForBufferSpace blocks until the block with the given sequence can be added to the payload buffer.
+// Returns false if the state provider is stopped while waiting.
+func (s *GossipStateProviderImpl) waitForBufferSpace(seqNum uint64) bool {
+	for {
+		height, err := s.ledger.LedgerHeight()
+		if err != nil {
+			logger.Errorf("Cannot obtain ledger height, due to %+v", errors.WithStack(err))
+			return false
+		}
+		if seqNum < height || seqNum-height < defMaxBlockDistance {
+			return true
+		}
+		select {
+		case <-time.After(defBlockStreamBackoff):
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			return false
+		}
+	}
+}
+
+// supportsBlockStream returns whether a block stream may be opened to the given peer,
+// which is the case unless opening a block stream to it recently failed
+func (s *GossipStateProviderImpl) supportsBlockStream(member discovery.NetworkMember) bool {
+	lastFailure, failed := s.blockStreamFailures[string(member.PKIid)]
+	if !failed {
+		return true
+	}
+	if time.Since(lastFailure) < defBlockStreamRetryInterval {
+		return false
+	}
+	delete(s.blockStreamFailures, string(member.PKIid))
+	return true
+}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// commitQueueMetrics reports the number of blocks of a channel that are received and wait to be committed
+type commitQueueMetrics struct {
+	pendingCommits metrics.Gauge
+}
+
+// newCommitQueueMetrics creates the commit queue metrics of the given channel,
+// or returns nil if metrics aren't initialized
+func newCommitQueueMetrics(chainID string) *commitQueueMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("ledger").Tagged(map[string]string{"channel": chainID})
+	return &commitQueueMetrics{
+		pendingCommits: scope.Gauge("pending_commits"),
+	}
+}
+
+// report reports the number of blocks that wait to be committed
+func (m *commitQueueMetrics) report(pendingCommits int) {
+	if m == nil {
+		return
+	}
+	m.pendingCommits.Update(float64(pendingCommits))
+}
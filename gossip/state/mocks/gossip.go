@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package mocks
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -49,6 +53,12 @@ func (g *GossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID comm
 
 }
 
+// UpdateLedgerSnapshots updates the heights of the ledger snapshots
+// the peer publishes to other peers in the channel
+func (g *GossipMock) UpdateLedgerSnapshots(heights []uint64, chainID common.ChainID) {
+
+}
+
 func (g *GossipMock) LeaveChan(_ common.ChainID) {
 	panic("implement me")
 }
@@ -57,6 +67,12 @@ func (g *GossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	g.Called(msg, peers)
 }
 
+// OpenBlockStream returns an error, as block streams aren't supported by the mock,
+// so that state transfer falls back to sending the blocks in gossip messages
+func (g *GossipMock) OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error) {
+	return nil, errors.New("block streams aren't supported")
+}
+
 func (g *GossipMock) Peers() []discovery.NetworkMember {
 	return g.Called().Get(0).([]discovery.NetworkMember)
 }
@@ -70,6 +86,10 @@ func (g *GossipMock) UpdateMetadata(metadata []byte) {
 	g.Called(metadata)
 }
 
+func (g *GossipMock) UpdateExternalEndpoint(endpoint string) {
+	g.Called(endpoint)
+}
+
 func (g *GossipMock) Gossip(msg *proto.GossipMessage) {
 	g.Called(msg)
 }
@@ -90,6 +110,21 @@ func (g *GossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("not implemented")
 }
 
+// MembershipSnapshot returns a snapshot of the alive and dead membership views
+func (g *GossipMock) MembershipSnapshot() gossip.MembershipSnapshot {
+	panic("not implemented")
+}
+
+// PeerLatencies returns the round trip times measured to the alive peers of the given channel
+func (g *GossipMock) PeerLatencies(channel common.ChainID) map[string]time.Duration {
+	panic("not implemented")
+}
+
+// OnIdentityPurge registers a listener that is invoked for every peer identity that is purged
+func (g *GossipMock) OnIdentityPurge(listener func(identity.PurgeEvent)) {
+	panic("not implemented")
+}
+
 func (g *GossipMock) Stop() {
 
 }
@@ -69,6 +69,10 @@ type GossipAdapter interface {
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common2.ChainID) []discovery.NetworkMember
+
+	// OpenBlockStream sends the given state request to a remote peer over a dedicated
+	// block stream, and returns the stream the response messages are received from
+	OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error)
 }
 
 // MCSAdapter adapter of message crypto service interface to bound
@@ -132,6 +136,9 @@ type GossipStateProviderImpl struct {
 	// Queue of payloads which wasn't acquired yet
 	payloads PayloadsBuffer
 
+	// commitQueueMetrics reports the size of the queue of payloads
+	commitQueueMetrics *commitQueueMetrics
+
 	ledger ledgerResources
 
 	stateResponseCh chan proto.ReceivedMessage
@@ -145,6 +152,18 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	// blockStreamEnabled indicates whether missing blocks are
+	// first attempted to be pulled over dedicated block streams
+	blockStreamEnabled bool
+
+	// blockStreamFailures maps PKI-IDs of peers to the last time
+	// a block stream couldn't be opened to them.
+	// It is only accessed by the anti-entropy routine.
+	blockStreamFailures map[string]time.Time
+
+	// blockStreamSlots bounds the amount of block streams served concurrently
+	blockStreamSlots chan struct{}
 }
 
 var logger = util.GetLogger(util.LoggingStateModule, "")
@@ -211,6 +230,8 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		// Create a queue for payload received
 		payloads: NewPayloadsBuffer(height),
 
+		commitQueueMetrics: newCommitQueueMetrics(chainID),
+
 		ledger: ledger,
 
 		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
@@ -222,6 +243,12 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		stateTransferActive: 0,
 
 		once: sync.Once{},
+
+		blockStreamEnabled: blockStreamEnabled(),
+
+		blockStreamFailures: make(map[string]time.Time),
+
+		blockStreamSlots: make(chan struct{}, defMaxConcurrentBlockStreams),
 	}
 
 	nodeMetastate := common2.NewNodeMetastate(height - 1)
@@ -338,7 +365,9 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 
 	incoming := msg.GetGossipMessage()
 
-	if incoming.GetStateRequest() != nil {
+	if streamReq, isBlockStream := msg.(comm.BlockStreamRequest); isBlockStream {
+		s.serveBlockStream(streamReq)
+	} else if incoming.GetStateRequest() != nil {
 		if len(s.stateRequestCh) < defChannelBufferSize {
 			// Forward state request to the channel, if there are too
 			// many message of state request ignore to avoid flooding.
@@ -402,49 +431,14 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 
 	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
-		logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
-		connInfo := msg.GetConnectionInfo()
-		peerAuthInfo := common.SignedData{
-			Data:      connInfo.Auth.SignedData,
-			Signature: connInfo.Auth.Signature,
-			Identity:  connInfo.Identity,
-		}
-		block, pvtData, err := s.ledger.GetPvtDataAndBlockByNum(seqNum, peerAuthInfo)
-
+		payload, err := s.payloadOf(seqNum, msg.GetConnectionInfo())
 		if err != nil {
-			logger.Errorf("Wasn't able to read block with sequence number %d from ledger, "+
-				"due to %+v skipping....", seqNum, errors.WithStack(err))
+			logger.Errorf("%+v, skipping....", err)
 			continue
 		}
 
-		if block == nil {
-			logger.Errorf("Wasn't able to read block with sequence number %d from ledger, skipping....", seqNum)
-			continue
-		}
-
-		blockBytes, err := pb.Marshal(block)
-
-		if err != nil {
-			logger.Errorf("Could not marshal block: %+v", errors.WithStack(err))
-			continue
-		}
-
-		var pvtBytes [][]byte
-		if pvtData != nil {
-			// Marshal private data
-			pvtBytes, err = pvtData.Marshal()
-			if err != nil {
-				logger.Errorf("Failed to marshal private rwset for block %d due to %+v", seqNum, errors.WithStack(err))
-				continue
-			}
-		}
-
 		// Appending result to the response
-		response.Payloads = append(response.Payloads, &proto.Payload{
-			SeqNum:      seqNum,
-			Data:        blockBytes,
-			PrivateData: pvtBytes,
-		})
+		response.Payloads = append(response.Payloads, payload)
 	}
 	// Sending back response with missing blocks
 	msg.Respond(&proto.GossipMessage{
@@ -456,6 +450,45 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	})
 }
 
+// payloadOf reads the block with the given sequence number along with the private data
+// the remote peer of the given connection is eligible for, and returns them as a payload
+func (s *GossipStateProviderImpl) payloadOf(seqNum uint64, connInfo *proto.ConnectionInfo) (*proto.Payload, error) {
+	logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
+	peerAuthInfo := common.SignedData{
+		Data:      connInfo.Auth.SignedData,
+		Signature: connInfo.Auth.Signature,
+		Identity:  connInfo.Identity,
+	}
+	block, pvtData, err := s.ledger.GetPvtDataAndBlockByNum(seqNum, peerAuthInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Wasn't able to read block with sequence number %d from ledger", seqNum)
+	}
+
+	if block == nil {
+		return nil, errors.Errorf("Wasn't able to read block with sequence number %d from ledger", seqNum)
+	}
+
+	blockBytes, err := pb.Marshal(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not marshal block")
+	}
+
+	var pvtBytes [][]byte
+	if pvtData != nil {
+		// Marshal private data
+		pvtBytes, err = pvtData.Marshal()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to marshal private rwset for block %d", seqNum)
+		}
+	}
+
+	return &proto.Payload{
+		SeqNum:      seqNum,
+		Data:        blockBytes,
+		PrivateData: pvtBytes,
+	}, nil
+}
+
 func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage) (uint64, error) {
 	max := uint64(0)
 	// Send signal that response for given nonce has been received
@@ -529,6 +562,7 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 			logger.Debugf("Ready to transfer payloads to the ledger, next sequence number is = [%d]", s.payloads.Next())
 			// Collect all subsequent payloads
 			for payload := s.payloads.Pop(); payload != nil; payload = s.payloads.Pop() {
+				s.commitQueueMetrics.report(s.payloads.Size())
 				rawBlock := &common.Block{}
 				if err := pb.Unmarshal(payload.Data, rawBlock); err != nil {
 					logger.Errorf("Error getting block with seqNum = %d due to (%+v)...dropping block", payload.SeqNum, errors.WithStack(err))
@@ -619,6 +653,13 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 // GetBlocksInRange capable to acquire blocks with sequence
 // numbers in the range [start...end].
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
+	// Prefer pulling the blocks over a dedicated block stream,
+	// and pull whatever wasn't streamed via gossip messages
+	start, done := s.streamBlocksInRange(start, end)
+	if done {
+		return
+	}
+
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
@@ -759,6 +800,7 @@ func (s *GossipStateProviderImpl) addPayload(payload *proto.Payload) error {
 
 
 	s.payloads.Push(payload)
+	s.commitQueueMetrics.report(s.payloads.Size())
 	return nil
 }
 
@@ -1138,7 +1138,10 @@ func TestNewGossipStateProvider_BatchingOfStateRequest(t *testing.T) {
 		}
 	}
 
-	peer := newPeerNode(newGossipConfig(portPrefix, 1, 0), newCommitter(), noopPeerIdentityAcceptor)
+	// Block streams aren't used, so the missing blocks are sent in batches within gossip messages
+	peerConfig := newGossipConfig(portPrefix, 1, 0)
+	g := &blockStreamlessGossip{gossipInstance: newGossipInstance(peerConfig, &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor})}
+	peer := newPeerNodeWithGossip(peerConfig, newCommitter(), noopPeerIdentityAcceptor, g)
 	defer peer.shutdown()
 
 	naiveStateMsgPredicate := func(message interface{}) bool {
@@ -1207,6 +1210,122 @@ func TestNewGossipStateProvider_BatchingOfStateRequest(t *testing.T) {
 	}
 }
 
+func TestNewGossipStateProvider_BlockStream(t *testing.T) {
+	t.Parallel()
+	portPrefix := portStartRange + 800
+	bootPeer := newPeerNode(newGossipConfig(portPrefix, 0), newCommitter(), noopPeerIdentityAcceptor)
+	defer bootPeer.shutdown()
+
+	// More blocks than fit in a single batch of a gossip state response
+	msgCount := defAntiEntropyBatchSize*2 + 5
+	for i := 1; i <= msgCount; i++ {
+		rawblock := pcomm.NewBlock(uint64(i), []byte{})
+		b, err := pb.Marshal(rawblock)
+		assert.NoError(t, err)
+		bootPeer.s.AddPayload(&proto.Payload{
+			SeqNum: uint64(i),
+			Data:   b,
+		})
+	}
+
+	peer := newPeerNode(newGossipConfig(portPrefix, 1, 0), newCommitter(), noopPeerIdentityAcceptor)
+	defer peer.shutdown()
+
+	stateResponsePredicate := func(message interface{}) bool {
+		return message.(proto.ReceivedMessage).GetGossipMessage().GetStateResponse() != nil
+	}
+	_, peerCh := peer.g.Accept(stateResponsePredicate, true)
+	var stateResponsesViaGossip int32
+	go func() {
+		for range peerCh {
+			atomic.AddInt32(&stateResponsesViaGossip, 1)
+		}
+	}()
+
+	waitUntilTrueOrTimeout(t, func() bool {
+		height, err := peer.commit.LedgerHeight()
+		return err == nil && height == uint64(msgCount+1)
+	}, 60*time.Second)
+	// All blocks were transferred over the block stream, and none within gossip messages
+	assert.Equal(t, int32(0), atomic.LoadInt32(&stateResponsesViaGossip))
+}
+
+func TestBlockStreamFallback(t *testing.T) {
+	t.Parallel()
+	// Scenario: the peer that has the missing blocks doesn't support block streams,
+	// so the blocks are pulled from it within gossip messages, and no block stream
+	// is attempted to be opened to it until the retry interval expires
+	mc := &mockCommitter{Mock: &mock.Mock{}}
+	blocksPassedToLedger := make(chan uint64, 10)
+	mc.On("CommitWithPvtData", mock.Anything).Run(func(arg mock.Arguments) {
+		blocksPassedToLedger <- arg.Get(0).(*pcomm.Block).Header.Number
+	})
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	msgsFromPeer := make(chan proto.ReceivedMessage)
+	g := &mocks.GossipMock{}
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{
+		{
+			PKIid:    common.PKIidType("a"),
+			Endpoint: "a",
+			Properties: &proto.Properties{
+				LedgerHeight: 3,
+			},
+		}})
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, msgsFromPeer)
+	g.On("Send", mock.Anything, mock.Anything).Run(func(arguments mock.Arguments) {
+		msg := arguments.Get(0).(*proto.GossipMessage)
+		req := msg.GetStateRequest()
+		res := &proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Channel: []byte(util.GetTestChainID()),
+			Content: &proto.GossipMessage_StateResponse{
+				StateResponse: &proto.RemoteStateResponse{},
+			},
+		}
+		for seq := req.StartSeqNum; seq <= req.EndSeqNum; seq++ {
+			b, _ := pb.Marshal(pcomm.NewBlock(seq, []byte{}))
+			res.GetStateResponse().Payloads = append(res.GetStateResponse().Payloads, &proto.Payload{
+				SeqNum: seq,
+				Data:   b,
+			})
+		}
+		sMsg, _ := res.NoopSign()
+		msgsFromPeer <- &comm.ReceivedMessageImpl{
+			SignedGossipMessage: sMsg,
+		}
+	})
+	portPrefix := portStartRange + 850
+	p := newPeerNodeWithGossip(newGossipConfig(portPrefix, 0), mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+
+	for expectedSequence := 1; expectedSequence < 3; expectedSequence++ {
+		select {
+		case blockSeq := <-blocksPassedToLedger:
+			assert.Equal(t, expectedSequence, int(blockSeq))
+		case <-time.After(defAntiEntropyInterval * 2):
+			t.Fatal("Didn't receive block", expectedSequence, "within a timely manner")
+		}
+	}
+	assert.False(t, p.s.supportsBlockStream(discovery.NetworkMember{PKIid: common.PKIidType("a")}))
+	assert.True(t, p.s.supportsBlockStream(discovery.NetworkMember{PKIid: common.PKIidType("b")}))
+}
+
+// gossipInstance is embedded in blockStreamlessGossip, as gossip.Gossip
+// can't be embedded due to its Gossip method
+type gossipInstance interface {
+	gossip.Gossip
+}
+
+// blockStreamlessGossip is a gossip instance that doesn't support block streams
+type blockStreamlessGossip struct {
+	gossipInstance
+}
+
+func (*blockStreamlessGossip) OpenBlockStream(msg *proto.GossipMessage, peer *comm.RemotePeer) (comm.BlockStream, error) {
+	return nil, errors.New("block streams aren't supported")
+}
+
 // coordinatorMock mocking structure to capture mock interface for
 // coord to simulate coord flow during the test
 type coordinatorMock struct {
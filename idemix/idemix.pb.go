@@ -15,8 +15,12 @@ It has these top-level messages:
 	Credential
 	CredRequest
 	Signature
+	NonRevocationProof
 	NymSignature
 	CredentialRevocationInformation
+	PlainSigRevocationData
+	RevocationHandleSignature
+	PlainSigNonRevocationProof
 */
 package idemix
 
@@ -336,19 +340,23 @@ func (m *CredRequest) GetProofS2() []byte {
 // ProofSRNym - a zero-knowledge proof of knowledge of the
 // user secret inside Nym
 type Signature struct {
-	APrime       *ECP     `protobuf:"bytes,1,opt,name=APrime" json:"APrime,omitempty"`
-	ABar         *ECP     `protobuf:"bytes,2,opt,name=ABar" json:"ABar,omitempty"`
-	BPrime       *ECP     `protobuf:"bytes,3,opt,name=BPrime" json:"BPrime,omitempty"`
-	ProofC       []byte   `protobuf:"bytes,4,opt,name=ProofC,proto3" json:"ProofC,omitempty"`
-	ProofSSk     []byte   `protobuf:"bytes,5,opt,name=ProofSSk,proto3" json:"ProofSSk,omitempty"`
-	ProofSE      []byte   `protobuf:"bytes,6,opt,name=ProofSE,proto3" json:"ProofSE,omitempty"`
-	ProofSR2     []byte   `protobuf:"bytes,7,opt,name=ProofSR2,proto3" json:"ProofSR2,omitempty"`
-	ProofSR3     []byte   `protobuf:"bytes,8,opt,name=ProofSR3,proto3" json:"ProofSR3,omitempty"`
-	ProofSSPrime []byte   `protobuf:"bytes,9,opt,name=ProofSSPrime,proto3" json:"ProofSSPrime,omitempty"`
-	ProofSAttrs  [][]byte `protobuf:"bytes,10,rep,name=ProofSAttrs,proto3" json:"ProofSAttrs,omitempty"`
-	Nonce        []byte   `protobuf:"bytes,11,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
-	Nym          *ECP     `protobuf:"bytes,12,opt,name=Nym" json:"Nym,omitempty"`
-	ProofSRNym   []byte   `protobuf:"bytes,13,opt,name=ProofSRNym,proto3" json:"ProofSRNym,omitempty"`
+	APrime             *ECP                `protobuf:"bytes,1,opt,name=APrime" json:"APrime,omitempty"`
+	ABar               *ECP                `protobuf:"bytes,2,opt,name=ABar" json:"ABar,omitempty"`
+	BPrime             *ECP                `protobuf:"bytes,3,opt,name=BPrime" json:"BPrime,omitempty"`
+	ProofC             []byte              `protobuf:"bytes,4,opt,name=ProofC,proto3" json:"ProofC,omitempty"`
+	ProofSSk           []byte              `protobuf:"bytes,5,opt,name=ProofSSk,proto3" json:"ProofSSk,omitempty"`
+	ProofSE            []byte              `protobuf:"bytes,6,opt,name=ProofSE,proto3" json:"ProofSE,omitempty"`
+	ProofSR2           []byte              `protobuf:"bytes,7,opt,name=ProofSR2,proto3" json:"ProofSR2,omitempty"`
+	ProofSR3           []byte              `protobuf:"bytes,8,opt,name=ProofSR3,proto3" json:"ProofSR3,omitempty"`
+	ProofSSPrime       []byte              `protobuf:"bytes,9,opt,name=ProofSSPrime,proto3" json:"ProofSSPrime,omitempty"`
+	ProofSAttrs        [][]byte            `protobuf:"bytes,10,rep,name=ProofSAttrs,proto3" json:"ProofSAttrs,omitempty"`
+	Nonce              []byte              `protobuf:"bytes,11,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Nym                *ECP                `protobuf:"bytes,12,opt,name=Nym" json:"Nym,omitempty"`
+	ProofSRNym         []byte              `protobuf:"bytes,13,opt,name=ProofSRNym,proto3" json:"ProofSRNym,omitempty"`
+	RevocationEpochPk  *ECP2               `protobuf:"bytes,14,opt,name=RevocationEpochPk" json:"RevocationEpochPk,omitempty"`
+	RevocationPkSig    []byte              `protobuf:"bytes,15,opt,name=RevocationPkSig,proto3" json:"RevocationPkSig,omitempty"`
+	Epoch              int64               `protobuf:"varint,16,opt,name=Epoch" json:"Epoch,omitempty"`
+	NonRevocationProof *NonRevocationProof `protobuf:"bytes,17,opt,name=NonRevocationProof" json:"NonRevocationProof,omitempty"`
 }
 
 func (m *Signature) Reset()                    { *m = Signature{} }
@@ -447,6 +455,59 @@ func (m *Signature) GetProofSRNym() []byte {
 	return nil
 }
 
+func (m *Signature) GetRevocationEpochPk() *ECP2 {
+	if m != nil {
+		return m.RevocationEpochPk
+	}
+	return nil
+}
+
+func (m *Signature) GetRevocationPkSig() []byte {
+	if m != nil {
+		return m.RevocationPkSig
+	}
+	return nil
+}
+
+func (m *Signature) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *Signature) GetNonRevocationProof() *NonRevocationProof {
+	if m != nil {
+		return m.NonRevocationProof
+	}
+	return nil
+}
+
+// NonRevocationProof contains proof that the credential is not revoked
+type NonRevocationProof struct {
+	RevocationAlg      int32  `protobuf:"varint,1,opt,name=RevocationAlg" json:"RevocationAlg,omitempty"`
+	NonRevocationProof []byte `protobuf:"bytes,2,opt,name=NonRevocationProof,proto3" json:"NonRevocationProof,omitempty"`
+}
+
+func (m *NonRevocationProof) Reset()                    { *m = NonRevocationProof{} }
+func (m *NonRevocationProof) String() string            { return proto.CompactTextString(m) }
+func (*NonRevocationProof) ProtoMessage()               {}
+func (*NonRevocationProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *NonRevocationProof) GetRevocationAlg() int32 {
+	if m != nil {
+		return m.RevocationAlg
+	}
+	return 0
+}
+
+func (m *NonRevocationProof) GetNonRevocationProof() []byte {
+	if m != nil {
+		return m.NonRevocationProof
+	}
+	return nil
+}
+
 // NymSignature specifies a signature object that signs a message
 // with respect to a pseudonym. It differs from the standard idemix.signature in the fact that
 // the  standard signature object also proves that the pseudonym is based on a secret certified by
@@ -466,7 +527,7 @@ type NymSignature struct {
 func (m *NymSignature) Reset()                    { *m = NymSignature{} }
 func (m *NymSignature) String() string            { return proto.CompactTextString(m) }
 func (*NymSignature) ProtoMessage()               {}
-func (*NymSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*NymSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *NymSignature) GetProofC() []byte {
 	if m != nil {
@@ -509,10 +570,12 @@ type CredentialRevocationInformation struct {
 	RevocationData []byte `protobuf:"bytes,5,opt,name=RevocationData,proto3" json:"RevocationData,omitempty"`
 }
 
-func (m *CredentialRevocationInformation) Reset()                    { *m = CredentialRevocationInformation{} }
-func (m *CredentialRevocationInformation) String() string            { return proto.CompactTextString(m) }
-func (*CredentialRevocationInformation) ProtoMessage()               {}
-func (*CredentialRevocationInformation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *CredentialRevocationInformation) Reset()         { *m = CredentialRevocationInformation{} }
+func (m *CredentialRevocationInformation) String() string { return proto.CompactTextString(m) }
+func (*CredentialRevocationInformation) ProtoMessage()    {}
+func (*CredentialRevocationInformation) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9}
+}
 
 func (m *CredentialRevocationInformation) GetEpoch() int64 {
 	if m != nil {
@@ -549,6 +612,77 @@ func (m *CredentialRevocationInformation) GetRevocationData() []byte {
 	return nil
 }
 
+// PlainSigRevocationData is the RevocationData of a CRI of the ALG_PLAIN_SIGNATURE revocation algorithm.
+// It contains a weak Boneh-Boyen signature under the EpochPK on the revocation handle of each credential
+// that is not revoked in the epoch
+type PlainSigRevocationData struct {
+	Signatures []*RevocationHandleSignature `protobuf:"bytes,1,rep,name=Signatures" json:"Signatures,omitempty"`
+}
+
+func (m *PlainSigRevocationData) Reset()                    { *m = PlainSigRevocationData{} }
+func (m *PlainSigRevocationData) String() string            { return proto.CompactTextString(m) }
+func (*PlainSigRevocationData) ProtoMessage()               {}
+func (*PlainSigRevocationData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *PlainSigRevocationData) GetSignatures() []*RevocationHandleSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// RevocationHandleSignature is a weak Boneh-Boyen signature on a revocation handle
+type RevocationHandleSignature struct {
+	RevocationHandle []byte `protobuf:"bytes,1,opt,name=RevocationHandle,proto3" json:"RevocationHandle,omitempty"`
+	Signature        *ECP   `protobuf:"bytes,2,opt,name=Signature" json:"Signature,omitempty"`
+}
+
+func (m *RevocationHandleSignature) Reset()                    { *m = RevocationHandleSignature{} }
+func (m *RevocationHandleSignature) String() string            { return proto.CompactTextString(m) }
+func (*RevocationHandleSignature) ProtoMessage()               {}
+func (*RevocationHandleSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RevocationHandleSignature) GetRevocationHandle() []byte {
+	if m != nil {
+		return m.RevocationHandle
+	}
+	return nil
+}
+
+func (m *RevocationHandleSignature) GetSignature() *ECP {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PlainSigNonRevocationProof is the NonRevocationProof of the ALG_PLAIN_SIGNATURE revocation algorithm.
+// It proves in zero-knowledge the knowledge of a signature under the EpochPK on the hidden revocation handle,
+// using a randomized signature SigmaBar
+type PlainSigNonRevocationProof struct {
+	SigmaBar *ECP   `protobuf:"bytes,1,opt,name=SigmaBar" json:"SigmaBar,omitempty"`
+	ProofSR  []byte `protobuf:"bytes,2,opt,name=ProofSR,proto3" json:"ProofSR,omitempty"`
+}
+
+func (m *PlainSigNonRevocationProof) Reset()                    { *m = PlainSigNonRevocationProof{} }
+func (m *PlainSigNonRevocationProof) String() string            { return proto.CompactTextString(m) }
+func (*PlainSigNonRevocationProof) ProtoMessage()               {}
+func (*PlainSigNonRevocationProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *PlainSigNonRevocationProof) GetSigmaBar() *ECP {
+	if m != nil {
+		return m.SigmaBar
+	}
+	return nil
+}
+
+func (m *PlainSigNonRevocationProof) GetProofSR() []byte {
+	if m != nil {
+		return m.ProofSR
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ECP)(nil), "ECP")
 	proto.RegisterType((*ECP2)(nil), "ECP2")
@@ -557,54 +691,68 @@ func init() {
 	proto.RegisterType((*Credential)(nil), "Credential")
 	proto.RegisterType((*CredRequest)(nil), "CredRequest")
 	proto.RegisterType((*Signature)(nil), "Signature")
+	proto.RegisterType((*NonRevocationProof)(nil), "NonRevocationProof")
 	proto.RegisterType((*NymSignature)(nil), "NymSignature")
 	proto.RegisterType((*CredentialRevocationInformation)(nil), "CredentialRevocationInformation")
+	proto.RegisterType((*PlainSigRevocationData)(nil), "PlainSigRevocationData")
+	proto.RegisterType((*RevocationHandleSignature)(nil), "RevocationHandleSignature")
+	proto.RegisterType((*PlainSigNonRevocationProof)(nil), "PlainSigNonRevocationProof")
 }
 
 func init() { proto.RegisterFile("idemix/idemix.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 670 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcd, 0x6e, 0xda, 0x4c,
-	0x14, 0xd5, 0x60, 0x9b, 0x84, 0x0b, 0xc9, 0x17, 0x4d, 0x3e, 0x45, 0xa3, 0xa8, 0x6a, 0xa8, 0x15,
-	0x45, 0x59, 0x11, 0xc5, 0x79, 0x02, 0x9b, 0xba, 0x05, 0x45, 0x42, 0xd6, 0x78, 0x11, 0xe8, 0xce,
-	0xc0, 0x00, 0x56, 0x30, 0x4e, 0x07, 0x53, 0x85, 0xe7, 0xe8, 0xb6, 0xcf, 0xd3, 0x97, 0xea, 0xa6,
-	0x9a, 0x1f, 0xff, 0x4a, 0x5d, 0x31, 0xe7, 0x5c, 0xdf, 0xb9, 0x87, 0x73, 0xae, 0x06, 0x2e, 0xe3,
-	0x25, 0x4b, 0xe2, 0xf7, 0x07, 0xf5, 0x33, 0x78, 0xe3, 0x69, 0x96, 0xda, 0x9f, 0xc0, 0xf0, 0x87,
-	0x01, 0xee, 0x01, 0x9a, 0x12, 0xd4, 0x47, 0xf7, 0x3d, 0x8a, 0xa6, 0x02, 0xcd, 0x48, 0x4b, 0xa1,
-	0x99, 0xfd, 0x05, 0x4c, 0x7f, 0x18, 0x38, 0xf8, 0x1c, 0x5a, 0x53, 0x57, 0x7f, 0xd4, 0x9a, 0xba,
-	0x12, 0x7b, 0xfa, 0xb3, 0xd6, 0xd4, 0x13, 0x78, 0xe6, 0x12, 0x43, 0xe1, 0x99, 0xac, 0xcf, 0x3c,
-	0x62, 0x6a, 0xec, 0xd9, 0xbf, 0x5a, 0xf0, 0xdf, 0x78, 0xbf, 0x3f, 0x30, 0x1e, 0x1c, 0xe6, 0xdb,
-	0x78, 0xf1, 0xcc, 0x8e, 0xf8, 0x0e, 0xce, 0xdd, 0x2c, 0xe3, 0xf1, 0xfc, 0x90, 0xb1, 0x49, 0x94,
-	0xb0, 0x3d, 0x41, 0x7d, 0xe3, 0xbe, 0x43, 0x1b, 0x2c, 0xbe, 0x02, 0x63, 0x14, 0xbe, 0xca, 0x61,
-	0x5d, 0xc7, 0x1c, 0xf8, 0xc3, 0x80, 0x0a, 0x02, 0x5f, 0x83, 0x35, 0xa2, 0xd1, 0x6e, 0x29, 0xc7,
-	0xe6, 0x15, 0x45, 0xe1, 0x0f, 0xd0, 0x1e, 0x89, 0x6b, 0xf6, 0xc4, 0xec, 0x1b, 0x45, 0x51, 0x73,
-	0xf8, 0x12, 0xd0, 0x0b, 0xb1, 0x64, 0x97, 0x25, 0x0a, 0x0e, 0x45, 0x2f, 0xe2, 0x3a, 0x2f, 0xe2,
-	0x5f, 0x1f, 0x49, 0xbb, 0x7a, 0x9d, 0xa4, 0xf2, 0x9a, 0x43, 0x4e, 0x9a, 0x35, 0x07, 0x5f, 0x41,
-	0x3b, 0xe0, 0x69, 0xba, 0x1a, 0x92, 0x53, 0xf9, 0x77, 0x35, 0x2a, 0xf8, 0x90, 0x74, 0x2a, 0x7c,
-	0x88, 0x31, 0x98, 0xa3, 0x68, 0xbf, 0x21, 0x20, 0x59, 0x79, 0xb6, 0x5d, 0xe8, 0x28, 0x77, 0x84,
-	0x2f, 0x17, 0x60, 0x8c, 0xc3, 0x57, 0x6d, 0xb6, 0x38, 0x62, 0x1b, 0x8c, 0x71, 0x90, 0x3b, 0x70,
-	0x31, 0x68, 0x18, 0x49, 0x45, 0xd1, 0x5e, 0x01, 0x0c, 0x39, 0x5b, 0xb2, 0x5d, 0x16, 0x47, 0x5b,
-	0x8c, 0x01, 0xa9, 0xb8, 0x72, 0xb1, 0xc8, 0x15, 0x9c, 0x57, 0x73, 0x11, 0x79, 0x22, 0x6d, 0x5f,
-	0xc7, 0x86, 0x7c, 0x81, 0x42, 0x1d, 0x1a, 0x0a, 0xf1, 0xff, 0x60, 0x29, 0x0b, 0xad, 0xbe, 0x71,
-	0xdf, 0xa3, 0x0a, 0xd8, 0x3f, 0x11, 0x74, 0xc5, 0x20, 0xca, 0xbe, 0x1f, 0xd8, 0x3e, 0x13, 0xe9,
-	0x4c, 0x8e, 0x49, 0x6d, 0x96, 0x20, 0x70, 0x1f, 0xba, 0x4a, 0xe7, 0x24, 0xdd, 0x2d, 0x98, 0x5e,
-	0x95, 0x2a, 0x55, 0x31, 0xce, 0xa8, 0x19, 0x47, 0xe0, 0x44, 0x59, 0xf5, 0xa8, 0xb5, 0xe4, 0xb0,
-	0xac, 0x38, 0x32, 0xbd, 0xa2, 0xe2, 0xd8, 0x7f, 0x5a, 0xd0, 0x09, 0xe3, 0xf5, 0x2e, 0xca, 0x0e,
-	0x9c, 0x89, 0xf4, 0xdd, 0x80, 0xc7, 0x09, 0xab, 0xc9, 0xd2, 0x1c, 0x26, 0x60, 0xba, 0x5e, 0xc4,
-	0x6b, 0x56, 0x48, 0x46, 0xf4, 0x79, 0xaa, 0xaf, 0xba, 0x52, 0x9a, 0xab, 0xe8, 0x35, 0x6b, 0x7a,
-	0xaf, 0xe1, 0x54, 0xc9, 0x08, 0x5f, 0xb5, 0xac, 0x02, 0x97, 0x8a, 0x7d, 0xb9, 0x56, 0x85, 0x62,
-	0xbf, 0xec, 0xa2, 0x6a, 0xab, 0x8a, 0x2e, 0xea, 0x54, 0x6a, 0x4f, 0x7a, 0xa9, 0x0a, 0x8c, 0x6d,
-	0xe8, 0xe9, 0xdb, 0x95, 0x52, 0xb5, 0x5c, 0x35, 0x4e, 0x78, 0xaf, 0xb0, 0xca, 0x0f, 0x64, 0x7e,
-	0x55, 0x4a, 0x64, 0xab, 0x72, 0xe9, 0xca, 0x76, 0x2b, 0x4f, 0x44, 0x66, 0xd9, 0x6b, 0x66, 0xf9,
-	0x11, 0x40, 0xcf, 0x17, 0xe5, 0x33, 0xd9, 0x52, 0x61, 0xec, 0x77, 0xe8, 0x4d, 0x8e, 0x49, 0xe9,
-	0x7f, 0xe9, 0x14, 0xfa, 0xa7, 0x53, 0xad, 0x86, 0x53, 0xf5, 0x19, 0x46, 0x73, 0x46, 0xa9, 0xd8,
-	0xac, 0x28, 0xb6, 0x7f, 0x23, 0xb8, 0x29, 0xd7, 0x9e, 0xb2, 0x1f, 0xe9, 0x22, 0xca, 0xe2, 0x74,
-	0x37, 0xde, 0xad, 0x52, 0x9e, 0xc8, 0xa3, 0xe8, 0xf4, 0xdf, 0xd2, 0xc5, 0x46, 0x8a, 0x31, 0xa8,
-	0x02, 0xf8, 0x06, 0x4e, 0xe4, 0x21, 0x78, 0xd6, 0x8b, 0xa0, 0x5f, 0x82, 0x9c, 0x15, 0x82, 0xf4,
-	0x31, 0x8c, 0xd7, 0xb9, 0xa0, 0x92, 0xc1, 0xb7, 0x70, 0x56, 0xce, 0x73, 0xb7, 0x6b, 0x29, 0xcc,
-	0xa2, 0x75, 0x52, 0x3c, 0x72, 0x25, 0xf1, 0x39, 0xca, 0x22, 0xbd, 0x22, 0x0d, 0xd6, 0xbb, 0xfb,
-	0x76, 0xbb, 0x8e, 0xb3, 0xcd, 0x61, 0x3e, 0x58, 0xa4, 0xc9, 0xc3, 0xe6, 0xf8, 0xc6, 0xf8, 0x96,
-	0x2d, 0xd7, 0x8c, 0x3f, 0xac, 0xa2, 0x39, 0x8f, 0x17, 0xfa, 0xe5, 0x9e, 0xb7, 0xe5, 0xd3, 0xfd,
-	0xf4, 0x37, 0x00, 0x00, 0xff, 0xff, 0x9b, 0x7b, 0xe4, 0xc7, 0xd1, 0x05, 0x00, 0x00,
+	// 831 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdd, 0x6e, 0xe2, 0x46,
+	0x14, 0xd6, 0x60, 0x9b, 0x24, 0x07, 0x36, 0xc9, 0x4e, 0xaa, 0x68, 0x1a, 0x55, 0x5d, 0x6a, 0xad,
+	0x22, 0xd4, 0x0b, 0xa2, 0x75, 0xee, 0x7a, 0x67, 0x53, 0xb7, 0x44, 0x2b, 0x21, 0x6b, 0x5c, 0x69,
+	0xa1, 0x77, 0x06, 0x26, 0xc4, 0x05, 0xdb, 0xa9, 0x31, 0xd5, 0xf2, 0x1c, 0xbd, 0xed, 0x6b, 0xf4,
+	0x15, 0xfa, 0x5c, 0xd5, 0xfc, 0xd8, 0x1e, 0x1b, 0x72, 0xc5, 0x9c, 0xef, 0x9c, 0x33, 0xf3, 0xf1,
+	0x7d, 0xc7, 0x33, 0x70, 0x13, 0xaf, 0x58, 0x12, 0x7f, 0x7d, 0x90, 0x3f, 0xa3, 0xd7, 0x3c, 0x2b,
+	0x32, 0xfb, 0x07, 0x30, 0xfc, 0x71, 0x80, 0xfb, 0x80, 0x66, 0x04, 0x0d, 0xd0, 0xb0, 0x4f, 0xd1,
+	0x8c, 0x47, 0x73, 0xd2, 0x91, 0xd1, 0xdc, 0xfe, 0x05, 0x4c, 0x7f, 0x1c, 0x38, 0xf8, 0x12, 0x3a,
+	0x33, 0x57, 0x15, 0x75, 0x66, 0xae, 0x88, 0x3d, 0x55, 0xd6, 0x99, 0x79, 0x3c, 0x9e, 0xbb, 0xc4,
+	0x90, 0xf1, 0x5c, 0xe4, 0xe7, 0x1e, 0x31, 0x55, 0xec, 0xd9, 0xff, 0x74, 0xe0, 0xea, 0x69, 0xb7,
+	0xdb, 0xb3, 0x3c, 0xd8, 0x2f, 0xb6, 0xf1, 0xf2, 0x33, 0x3b, 0xe0, 0x7b, 0xb8, 0x74, 0x8b, 0x22,
+	0x8f, 0x17, 0xfb, 0x82, 0x4d, 0xa3, 0x84, 0xed, 0x08, 0x1a, 0x18, 0xc3, 0x0b, 0xda, 0x42, 0xf1,
+	0x2d, 0x18, 0x93, 0x70, 0x23, 0x0e, 0xeb, 0x39, 0xe6, 0xc8, 0x1f, 0x07, 0x94, 0x03, 0xf8, 0x0e,
+	0xac, 0x09, 0x8d, 0xd2, 0x15, 0x31, 0xb4, 0x8c, 0x84, 0xf0, 0x77, 0xd0, 0x9d, 0xf0, 0x6d, 0x76,
+	0xc4, 0x1c, 0x18, 0x55, 0x52, 0x61, 0xf8, 0x06, 0xd0, 0x17, 0x62, 0x89, 0x2e, 0x8b, 0x27, 0x1c,
+	0x8a, 0xbe, 0xf0, 0xed, 0xbc, 0x28, 0xff, 0xf5, 0x13, 0xe9, 0xea, 0xdb, 0x09, 0xa8, 0xcc, 0x39,
+	0xe4, 0xac, 0x9d, 0x73, 0xf0, 0x2d, 0x74, 0x83, 0x3c, 0xcb, 0x9e, 0xc7, 0xe4, 0x5c, 0xfc, 0x5d,
+	0x15, 0x55, 0x78, 0x48, 0x2e, 0x34, 0x3c, 0xc4, 0x18, 0xcc, 0x49, 0xb4, 0x7b, 0x21, 0x20, 0x50,
+	0xb1, 0xb6, 0x5d, 0xb8, 0x90, 0xea, 0x70, 0x5d, 0xae, 0xc1, 0x78, 0x0a, 0x37, 0x4a, 0x6c, 0xbe,
+	0xc4, 0x36, 0x18, 0x4f, 0x41, 0xa9, 0xc0, 0xf5, 0xa8, 0x25, 0x24, 0xe5, 0x49, 0xfb, 0x19, 0x60,
+	0x9c, 0xb3, 0x15, 0x4b, 0x8b, 0x38, 0xda, 0x62, 0x0c, 0x48, 0xda, 0x55, 0x92, 0x45, 0x2e, 0xc7,
+	0xbc, 0x86, 0x8a, 0xc8, 0xe3, 0x6e, 0xfb, 0xca, 0x36, 0xe4, 0xf3, 0x28, 0x54, 0xa6, 0xa1, 0x10,
+	0x7f, 0x03, 0x96, 0x94, 0xd0, 0x1a, 0x18, 0xc3, 0x3e, 0x95, 0x81, 0xfd, 0x37, 0x82, 0x1e, 0x3f,
+	0x88, 0xb2, 0x3f, 0xf7, 0x6c, 0x57, 0x70, 0x77, 0xa6, 0x87, 0xa4, 0x71, 0x16, 0x07, 0xf0, 0x00,
+	0x7a, 0x92, 0xe7, 0x34, 0x4b, 0x97, 0x4c, 0x8d, 0x8a, 0x0e, 0x69, 0xc2, 0x19, 0x0d, 0xe1, 0x08,
+	0x9c, 0x89, 0x55, 0xf8, 0x49, 0x71, 0x29, 0xc3, 0x3a, 0xe3, 0x10, 0x4b, 0xcf, 0x38, 0xf6, 0xbf,
+	0x26, 0x5c, 0x84, 0xf1, 0x3a, 0x8d, 0x8a, 0x7d, 0xce, 0xb8, 0xfb, 0x6e, 0x90, 0xc7, 0x09, 0x6b,
+	0xd0, 0x52, 0x18, 0x26, 0x60, 0xba, 0x5e, 0x94, 0x37, 0xa4, 0x10, 0x08, 0xef, 0xf3, 0x64, 0x9f,
+	0x3e, 0x52, 0x0a, 0xd3, 0xf8, 0x9a, 0x0d, 0xbe, 0x77, 0x70, 0x2e, 0x69, 0x84, 0x1b, 0x45, 0xab,
+	0x8a, 0x6b, 0xc6, 0x3e, 0xe9, 0xea, 0x8c, 0xfd, 0xba, 0x8b, 0xca, 0xa9, 0xaa, 0xba, 0xa8, 0xa3,
+	0xe5, 0x1e, 0xd5, 0x50, 0x55, 0x31, 0xb6, 0xa1, 0xaf, 0x76, 0x97, 0x4c, 0xe5, 0x70, 0x35, 0x30,
+	0xae, 0xbd, 0x8c, 0xa5, 0x7f, 0x20, 0xfc, 0xd3, 0x21, 0xee, 0xad, 0xf4, 0xa5, 0x27, 0xda, 0xad,
+	0xd2, 0x11, 0xe1, 0x65, 0xbf, 0xed, 0xe5, 0xf7, 0x00, 0xea, 0x7c, 0x9e, 0x7e, 0x27, 0x5a, 0x34,
+	0x04, 0x3f, 0xc2, 0x7b, 0xca, 0xfe, 0xca, 0x96, 0x51, 0x11, 0x67, 0xa9, 0xff, 0x9a, 0x2d, 0x5f,
+	0x82, 0x0d, 0xb9, 0xd4, 0xbf, 0xaf, 0xe3, 0x3c, 0x1e, 0xc2, 0x55, 0x0d, 0x06, 0x9b, 0x30, 0x5e,
+	0x93, 0x2b, 0xb1, 0x73, 0x1b, 0xe6, 0x64, 0x45, 0x13, 0xb9, 0x1e, 0xa0, 0xa1, 0x41, 0x65, 0x80,
+	0xc7, 0x80, 0xa7, 0x59, 0xaa, 0xd5, 0x72, 0x3e, 0xe4, 0xbd, 0x38, 0xf5, 0x66, 0x74, 0x9c, 0xa2,
+	0x27, 0xca, 0xed, 0x3f, 0x4e, 0x6d, 0x82, 0x3f, 0xc2, 0xbb, 0x1a, 0x72, 0xb7, 0x6b, 0x31, 0x46,
+	0x16, 0x6d, 0x82, 0x78, 0x74, 0x92, 0x80, 0x1c, 0xf4, 0x53, 0x67, 0x7d, 0x85, 0xfe, 0xf4, 0x90,
+	0xd4, 0x53, 0x5a, 0xcf, 0x13, 0x7a, 0x73, 0x9e, 0x3a, 0xad, 0x79, 0x6a, 0x3a, 0x61, 0x1c, 0x39,
+	0x51, 0xf9, 0x6a, 0x6a, 0xbe, 0xda, 0xff, 0x21, 0xf8, 0x50, 0x5f, 0x0e, 0x35, 0xaf, 0xa7, 0xf4,
+	0x39, 0xcb, 0x13, 0xb1, 0xac, 0x45, 0x46, 0xba, 0xc8, 0x1f, 0xe0, 0x4c, 0x2c, 0x82, 0xcf, 0xa4,
+	0xa3, 0xfb, 0x59, 0xa2, 0x9c, 0x90, 0x5a, 0x72, 0x03, 0x15, 0xa1, 0x1a, 0x39, 0x96, 0xd2, 0x3c,
+	0x25, 0xe5, 0x3d, 0x5c, 0xd6, 0xc0, 0xcf, 0x51, 0x11, 0xa9, 0x0f, 0xa9, 0x85, 0xda, 0xbf, 0xc1,
+	0x6d, 0xb0, 0x8d, 0xe2, 0x34, 0x8c, 0xd7, 0xcd, 0x0c, 0xfe, 0x09, 0xa0, 0x52, 0x56, 0x3e, 0x24,
+	0x3d, 0xe7, 0x6e, 0x54, 0x17, 0x4d, 0xa2, 0x74, 0xb5, 0x65, 0x55, 0x09, 0xd5, 0xaa, 0xed, 0x0d,
+	0x7c, 0xfb, 0x66, 0x21, 0xfe, 0x11, 0xae, 0xdb, 0x49, 0xe5, 0xd7, 0x11, 0x8e, 0x6d, 0xed, 0x12,
+	0x6a, 0x5c, 0x2f, 0x35, 0x6c, 0xcf, 0xe0, 0xae, 0xfc, 0x0b, 0x27, 0x26, 0x6f, 0x00, 0xe7, 0x61,
+	0xbc, 0x4e, 0x22, 0x7e, 0x3f, 0xe9, 0x77, 0x57, 0x85, 0xd6, 0x37, 0x0a, 0x55, 0xc3, 0x51, 0x86,
+	0xde, 0xfd, 0xef, 0x1f, 0xd7, 0x71, 0xf1, 0xb2, 0x5f, 0x8c, 0x96, 0x59, 0xf2, 0xf0, 0x72, 0x78,
+	0x65, 0xf9, 0x96, 0xad, 0xd6, 0x2c, 0x7f, 0x78, 0x8e, 0x16, 0x79, 0xbc, 0x54, 0x8f, 0xff, 0xa2,
+	0x2b, 0x5e, 0xff, 0xc7, 0xff, 0x07, 0x00, 0x74, 0x03, 0x81, 0xf2, 0x14, 0x08, 0x00, 0x00,
 }
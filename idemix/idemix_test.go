@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/stretchr/testify/assert"
 )
@@ -132,12 +133,13 @@ func TestIdemix(t *testing.T) {
 	// Test signing no disclosure
 	Nym, RandNym := MakeNym(sk, key.IPk, rng)
 
+	rhindex := 0
 	disclosure := []byte{0, 0, 0, 0, 0}
 	msg := []byte{1, 2, 3, 4, 5}
-	sig, err := NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rng)
+	sig, err := NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
 	assert.NoError(t, err)
 
-	err = sig.Ver(disclosure, key.IPk, msg, nil)
+	err = sig.Ver(disclosure, key.IPk, msg, nil, rhindex, &revocationKey.PublicKey, epoch)
 	if err != nil {
 		t.Fatalf("Signature should be valid but verification returned error: %s", err)
 		return
@@ -145,15 +147,65 @@ func TestIdemix(t *testing.T) {
 
 	// Test signing selective disclosure
 	disclosure = []byte{0, 1, 1, 1, 1}
-	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rng)
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
 	assert.NoError(t, err)
 
-	err = sig.Ver(disclosure, key.IPk, msg, attrs)
+	err = sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch)
 	if err != nil {
 		t.Fatalf("Signature should be valid but verification returned error: %s", err)
 		return
 	}
 
+	// Test that the revocation handle can't be disclosed
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, []byte{1, 1, 1, 1, 1}, msg, rhindex, cri, rng)
+	assert.Error(t, err, "signing with a disclosed revocation handle should fail")
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, 1, &revocationKey.PublicKey, epoch), "verifying with a disclosed revocation handle should fail")
+
+	// Test that the signature is not valid in another epoch, or with another revocation key
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch+1), "signature should not be valid in another epoch")
+	otherRevocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &otherRevocationKey.PublicKey, epoch), "signature should not be valid with another revocation key")
+
+	// Test revocation with plain signatures
+	epoch = 1
+	rh := FP256BN.FromBytes(cred.Attrs[rhindex])
+	cri, err = CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(7), rh}, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	err = VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPK, cri.EpochPKSig, int(cri.Epoch), RevocationAlgorithm(cri.RevocationAlg))
+	assert.NoError(t, err)
+
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch)
+	if err != nil {
+		t.Fatalf("Signature of an unrevoked credential should be valid but verification returned error: %s", err)
+		return
+	}
+	assert.Error(t, sig.Ver(disclosure, key.IPk, []byte{6}, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature should not be valid for another message")
+
+	// Breaking the non-revocation proof should make the signature invalid
+	nonRevocationProof := sig.NonRevocationProof.NonRevocationProof
+	plainSigProof := &PlainSigNonRevocationProof{}
+	assert.NoError(t, proto.Unmarshal(nonRevocationProof, plainSigProof))
+	plainSigProof.ProofSR = BigToBytes(RandModOrder(rng))
+	sig.NonRevocationProof.NonRevocationProof, err = proto.Marshal(plainSigProof)
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature with a broken non-revocation proof should be invalid")
+	sig.NonRevocationProof.NonRevocationProof = nonRevocationProof
+
+	// A signature can't be created in an epoch in which the revocation handle is revoked
+	epoch = 2
+	cri, err = CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(7)}, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
+	assert.Error(t, err, "signing with a revoked credential should fail")
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature of a previous epoch should not be valid")
+
+	// Unknown revocation algorithms are not supported
+	_, err = CreateCRI(revocationKey, []*FP256BN.BIG{}, epoch, RevocationAlgorithm(5), rng)
+	assert.Error(t, err)
+
 	// Test NymSignatures
 	nymsig, err := NewNymSignature(sk, Nym, RandNym, key.IPk, []byte("testing"), rng)
 	assert.NoError(t, err)
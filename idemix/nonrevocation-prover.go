@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// nonRevokedProver is the Prover of the ZK proof system that is used to prove non-revocation
+// The prover proves that the revocation handle of a credential, which remains hidden, is not revoked
+// in the epoch of a certain CRI. The proof is a part of the signature of knowledge of an idemix signature,
+// which is why its first move (the Fiat-Shamir contribution) and its response (given the challenge)
+// are computed separately
+type nonRevokedProver interface {
+	// getFSContribution returns the non-revocation contribution to the Fiat-Shamir hash, forming the challenge of the ZKP,
+	// rRh is the randomness of the revocation handle rh that is used in the rest of the signature of knowledge
+	getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error)
+
+	// getNonRevokedProof returns a proof of non-revocation with the respect to passed challenge
+	getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error)
+}
+
+// nopNonRevokedProver is an empty nonRevokedProver that produces an empty proof of non-revocation
+type nopNonRevokedProver struct{}
+
+func (prover *nopNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	return nil, nil
+}
+
+func (prover *nopNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	ret := &NonRevocationProof{}
+	ret.RevocationAlg = int32(ALG_NO_REVOCATION)
+	return ret, nil
+}
+
+// plainSigNonRevokedProver proves the knowledge of a weak Boneh-Boyen signature sigma under the epoch public key W
+// on the hidden revocation handle rh. To keep the signatures unlinkable, the proof is done on a randomized
+// signature SigmaBar = sigma^rho, for which e(SigmaBar, W) = e(SigmaBar^{-rh} * g1^rho, g2)
+type plainSigNonRevokedProver struct {
+	sigmaBar *FP256BN.ECP
+	rho      *FP256BN.BIG
+	rRho     *FP256BN.BIG
+}
+
+func (prover *plainSigNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	revocationData := &PlainSigRevocationData{}
+	err := proto.Unmarshal(cri.RevocationData, revocationData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the revocation data of the CRI")
+	}
+
+	var sigma *FP256BN.ECP
+	rhBytes := BigToBytes(rh)
+	for _, rhSig := range revocationData.Signatures {
+		if bytes.Equal(rhSig.RevocationHandle, rhBytes) {
+			sigma = EcpFromProto(rhSig.Signature)
+			break
+		}
+	}
+	if sigma == nil {
+		return nil, errors.Errorf("the revocation handle is revoked in epoch %d", cri.Epoch)
+	}
+
+	prover.rho = RandModOrder(rng)
+	prover.rRho = RandModOrder(rng)
+	prover.sigmaBar = sigma.Mul(prover.rho)
+
+	// t = e(SigmaBar^{-rRh} * g1^{rRho}, g2)
+	t := FP256BN.Fexp(FP256BN.Ate(GenG2, prover.sigmaBar.Mul2(FP256BN.Modneg(rRh, GroupOrder), GenG1, prover.rRho)))
+
+	contribution := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(contribution, 0, prover.sigmaBar)
+	t.ToBytes(contribution[index:])
+	return contribution, nil
+}
+
+func (prover *plainSigNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	proofBytes, err := proto.Marshal(&PlainSigNonRevocationProof{
+		SigmaBar: EcpToProto(prover.sigmaBar),
+		ProofSR:  BigToBytes(Modadd(prover.rRho, FP256BN.Modmul(chal, prover.rho, GroupOrder), GroupOrder)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the non-revocation proof")
+	}
+	return &NonRevocationProof{
+		RevocationAlg:      int32(ALG_PLAIN_SIGNATURE),
+		NonRevocationProof: proofBytes,
+	}, nil
+}
+
+// getNonRevocationProver returns the nonRevokedProver bound to the passed revocation algorithm
+func getNonRevocationProver(algorithm RevocationAlgorithm) (nonRevokedProver, error) {
+	switch algorithm {
+	case ALG_NO_REVOCATION:
+		return &nopNonRevokedProver{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevokedProver{}, nil
+	default:
+		// unknown revocation algorithm
+		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// nonRevocationVerifier is the verifier of the ZK proof system that is used to prove non-revocation
+type nonRevocationVerifier interface {
+	// recomputeFSContribution recomputes the contribution of the non-revocation proof to the ZKP challenge,
+	// proofSRh is the response of the revocation handle that is part of the rest of the signature of knowledge
+	recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error)
+}
+
+// nopNonRevocationVerifier is an empty nonRevocationVerifier that produces an empty contribution
+type nopNonRevocationVerifier struct{}
+
+func (verifier *nopNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	return nil, nil
+}
+
+// plainSigNonRevocationVerifier verifies the proofs produced by plainSigNonRevokedProver
+type plainSigNonRevocationVerifier struct{}
+
+func (verifier *plainSigNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	plainSigProof := &PlainSigNonRevocationProof{}
+	err := proto.Unmarshal(proof.NonRevocationProof, plainSigProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the non-revocation proof")
+	}
+	if plainSigProof.SigmaBar == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: SigmaBar is missing")
+	}
+	sigmaBar := EcpFromProto(plainSigProof.SigmaBar)
+	if sigmaBar.Is_infinity() {
+		return nil, errors.Errorf("non-revocation proof invalid: SigmaBar = 1")
+	}
+	proofSRho := FP256BN.FromBytes(plainSigProof.ProofSR)
+
+	// t = e(SigmaBar^{-sRh} * g1^{sRho}, g2) * e(SigmaBar^{-c}, W)
+	t := FP256BN.Fexp(FP256BN.Ate2(
+		GenG2, sigmaBar.Mul2(FP256BN.Modneg(proofSRh, GroupOrder), GenG1, proofSRho),
+		epochPK, sigmaBar.Mul(FP256BN.Modneg(chal, GroupOrder))))
+
+	contribution := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(contribution, 0, sigmaBar)
+	t.ToBytes(contribution[index:])
+	return contribution, nil
+}
+
+// getNonRevocationVerifier returns the nonRevocationVerifier bound to the passed revocation algorithm
+func getNonRevocationVerifier(algorithm RevocationAlgorithm) (nonRevocationVerifier, error) {
+	switch algorithm {
+	case ALG_NO_REVOCATION:
+		return &nopNonRevocationVerifier{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevocationVerifier{}, nil
+	default:
+		// unknown revocation algorithm
+		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
+	}
+}
@@ -26,10 +26,13 @@ type RevocationAlgorithm int32
 
 const (
 	ALG_NO_REVOCATION RevocationAlgorithm = iota
+	ALG_PLAIN_SIGNATURE
 )
 
+// ProofBytes is the length of the contribution of the non-revocation proof of each algorithm to the signature challenge
 var ProofBytes = map[RevocationAlgorithm]int{
-	ALG_NO_REVOCATION: 0,
+	ALG_NO_REVOCATION:   0,
+	ALG_PLAIN_SIGNATURE: 2*FieldBytes + 1 + 12*FieldBytes,
 }
 
 // GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
@@ -41,38 +44,64 @@ func GenerateLongTermRevocationKey() (*ecdsa.PrivateKey, error) {
 // Users can use the CRI to prove that they are not revoked.
 // Note that when not using revocation (i.e., alg = ALG_NO_REVOCATION), the entered unrevokedHandles are not used,
 // and the resulting CRI can be used by any signer.
+// When using ALG_PLAIN_SIGNATURE, the CRI contains a signature under a fresh epoch key on each of the unrevoked handles,
+// so that only the signers holding a credential with one of these handles can prove that they are not revoked.
 func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int, alg RevocationAlgorithm, rng *amcl.RAND) (*CredentialRevocationInformation, error) {
 	if key == nil || rng == nil {
 		return nil, errors.Errorf("CreateCRI received nil input")
 	}
+	if alg != ALG_NO_REVOCATION && alg != ALG_PLAIN_SIGNATURE {
+		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
+	}
 	cri := &CredentialRevocationInformation{}
 	cri.RevocationAlg = int32(alg)
 	cri.Epoch = int64(epoch)
 
+	var epochSk *FP256BN.BIG
 	if alg == ALG_NO_REVOCATION {
 		// put a dummy PK in the proto
 		cri.EpochPK = Ecp2ToProto(GenG2)
 	} else {
 		// create epoch key
-		_, epochPk := WBBKeyGen(rng)
+		var epochPk *FP256BN.ECP2
+		epochSk, epochPk = WBBKeyGen(rng)
 		cri.EpochPK = Ecp2ToProto(epochPk)
 	}
 
 	// sign epoch + epoch key with long term key
 	bytesToSign, err := proto.Marshal(cri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal CRI")
+	}
 	digest := sha256.New().Sum(bytesToSign)
 
 	pkSigR, pkSigS, err := ecdsa.Sign(rand.Reader, key, digest)
 	if err != nil {
 		return nil, err
 	}
-	cri.EpochPKSig = append(pkSigR.Bytes(), pkSigS.Bytes()...)
+	// both halves of the signature are padded to the same length, so that the signature can be split in the middle
+	sigHalfLength := (key.Params().BitSize + 7) / 8
+	cri.EpochPKSig = make([]byte, 2*sigHalfLength)
+	copy(cri.EpochPKSig[sigHalfLength-len(pkSigR.Bytes()):sigHalfLength], pkSigR.Bytes())
+	copy(cri.EpochPKSig[2*sigHalfLength-len(pkSigS.Bytes()):], pkSigS.Bytes())
 
 	if alg == ALG_NO_REVOCATION {
 		return cri, nil
-	} else {
-		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
 	}
+
+	// sign the unrevoked handles with the epoch key
+	revocationData := &PlainSigRevocationData{}
+	for _, rh := range unrevokedHandles {
+		revocationData.Signatures = append(revocationData.Signatures, &RevocationHandleSignature{
+			RevocationHandle: BigToBytes(rh),
+			Signature:        EcpToProto(WBBSign(epochSk, rh)),
+		})
+	}
+	cri.RevocationData, err = proto.Marshal(revocationData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation data")
+	}
+	return cri, nil
 }
 
 // VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
@@ -84,6 +113,9 @@ func VerifyEpochPK(pk *ecdsa.PublicKey, epochPK *ECP2, epochPkSig []byte, epoch
 	if pk == nil || epochPK == nil {
 		return errors.Errorf("EpochPK invalid: received nil input")
 	}
+	if len(epochPkSig) == 0 || len(epochPkSig)%2 != 0 {
+		return errors.Errorf("EpochPKSig invalid: unexpected length %d", len(epochPkSig))
+	}
 	cri := &CredentialRevocationInformation{}
 	cri.RevocationAlg = int32(alg)
 	cri.EpochPK = epochPK
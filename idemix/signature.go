@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"crypto/ecdsa"
+
 	"github.com/hyperledger/fabric-amcl/amcl"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
@@ -39,15 +41,36 @@ func hiddenIndices(Disclosure []byte) []int {
 	return HiddenIndices
 }
 
+// hiddenPosition returns the position of the attribute with the given index among the hidden attributes,
+// or -1 if the attribute is disclosed
+func hiddenPosition(HiddenIndices []int, index int) int {
+	for i, j := range HiddenIndices {
+		if j == index {
+			return i
+		}
+	}
+	return -1
+}
+
 // NewSignature creates a new idemix signature (Schnorr-type signature)
 // The []byte Disclosure steers which attributes are disclosed:
 // if Disclosure[i] == 0 then attribute i remains hidden and otherwise it is disclosed.
+// The attribute with index rhIndex is the revocation handle, which must remain hidden, and the signature
+// proves that it is not revoked in the epoch of the passed CRI.
 // We use the zero-knowledge proof by http://eprint.iacr.org/2016/663.pdf to prove knowledge of a BBS+ signature
-func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP256BN.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rng *amcl.RAND) (*Signature, error) {
-	if cred == nil || sk == nil || Nym == nil || RNym == nil || ipk == nil || rng == nil {
+func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP256BN.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rhIndex int, cri *CredentialRevocationInformation, rng *amcl.RAND) (*Signature, error) {
+	if cred == nil || sk == nil || Nym == nil || RNym == nil || ipk == nil || cri == nil || rng == nil {
 		return nil, errors.Errorf("cannot create idemix signature: received nil input")
 	}
 
+	if rhIndex < 0 || rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) {
+		return nil, errors.Errorf("cannot create idemix signature: received invalid input")
+	}
+
+	if Disclosure[rhIndex] == 1 {
+		return nil, errors.Errorf("Attribute %d is disclosed but also used as revocation handle attribute, which should remain hidden.", rhIndex)
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	// Start sig
@@ -100,13 +123,24 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 
 	t3 := HSk.Mul2(rSk, HRand, rRNym)
 
+	// Compute the non-revocation contribution, which shares the randomness of the hidden revocation handle
+	prover, err := getNonRevocationProver(RevocationAlgorithm(cri.RevocationAlg))
+	if err != nil {
+		return nil, err
+	}
+	nonRevokedProofHashData, err := prover.getFSContribution(FP256BN.FromBytes(cred.Attrs[rhIndex]), rAttrs[hiddenPosition(HiddenIndices, rhIndex)], cri, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to compute non-revoked proof")
+	}
+
 	// proofData is the data being hashed, it consists of:
 	// the signature label
 	// 7 elements of G1 each taking 2*FieldBytes+1 bytes
 	// one bigint (hash of the issuer public key) of length FieldBytes
 	// disclosed attributes
 	// message being signed
-	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg))
+	// the epoch, the epoch public key and the non-revocation contribution
+	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg)+FieldBytes+4*FieldBytes+ProofBytes[RevocationAlgorithm(cri.RevocationAlg)])
 	index := 0
 	index = appendBytesString(proofData, index, signLabel)
 	index = appendBytesG1(proofData, index, t1)
@@ -121,6 +155,10 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 	copy(proofData[index:], Disclosure)
 	index = index + len(Disclosure)
 	copy(proofData[index:], msg)
+	index = index + len(msg)
+	index = appendBytesBig(proofData, index, FP256BN.NewBIGint(int(cri.Epoch)))
+	index = appendBytesG2(proofData, index, Ecp2FromProto(cri.EpochPK))
+	copy(proofData[index:], nonRevokedProofHashData)
 	c := HashModOrder(proofData)
 
 	// add the previous hash and the nonce and hash again to compute a second hash (C value)
@@ -141,27 +179,64 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 		ProofSAttrs[i] = BigToBytes(Modadd(rAttrs[i], FP256BN.Modmul(ProofC, FP256BN.FromBytes(cred.Attrs[j]), GroupOrder), GroupOrder))
 	}
 
+	nonRevokedProof, err := prover.getNonRevokedProof(ProofC)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Signature{
-			EcpToProto(APrime),
-			EcpToProto(ABar),
-			EcpToProto(BPrime),
-			BigToBytes(ProofC),
-			BigToBytes(ProofSSk),
-			BigToBytes(ProofSE),
-			BigToBytes(ProofSR2),
-			BigToBytes(ProofSR3),
-			BigToBytes(ProofSSPrime),
-			ProofSAttrs,
-			BigToBytes(Nonce),
-			EcpToProto(Nym),
-			BigToBytes(ProofSRNym)},
+			APrime:             EcpToProto(APrime),
+			ABar:               EcpToProto(ABar),
+			BPrime:             EcpToProto(BPrime),
+			ProofC:             BigToBytes(ProofC),
+			ProofSSk:           BigToBytes(ProofSSk),
+			ProofSE:            BigToBytes(ProofSE),
+			ProofSR2:           BigToBytes(ProofSR2),
+			ProofSR3:           BigToBytes(ProofSR3),
+			ProofSSPrime:       BigToBytes(ProofSSPrime),
+			ProofSAttrs:        ProofSAttrs,
+			Nonce:              BigToBytes(Nonce),
+			Nym:                EcpToProto(Nym),
+			ProofSRNym:         BigToBytes(ProofSRNym),
+			RevocationEpochPk:  cri.EpochPK,
+			RevocationPkSig:    cri.EpochPKSig,
+			Epoch:              cri.Epoch,
+			NonRevocationProof: nonRevokedProof},
 		nil
 }
 
 // Ver verifies an idemix signature
 // Disclosure steers which attributes it expects to be disclosed
 // attributeValues[i] contains the desired attribute value for the i-th undisclosed attribute in Disclosure
-func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG) error {
+// rhIndex is the index of the revocation handle attribute, and the signature must prove that it is not revoked
+// in the given epoch, with an epoch public key signed by the long term revocation public key revPk
+func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG, rhIndex int, revPk *ecdsa.PublicKey, epoch int) error {
+	if ipk == nil || revPk == nil {
+		return errors.Errorf("cannot verify idemix signature: received nil input")
+	}
+
+	if rhIndex < 0 || rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) {
+		return errors.Errorf("cannot verify idemix signature: received invalid input")
+	}
+
+	if Disclosure[rhIndex] == 1 {
+		return errors.Errorf("Attribute %d is disclosed but is also used as revocation handle, which should remain hidden.", rhIndex)
+	}
+
+	if sig.NonRevocationProof == nil || sig.RevocationEpochPk == nil {
+		return errors.Errorf("signature invalid: non-revocation proof is missing")
+	}
+
+	if sig.Epoch != int64(epoch) {
+		return errors.Errorf("signature invalid: it was created in epoch %d, but the current epoch is %d", sig.Epoch, epoch)
+	}
+
+	revocationAlg := RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg)
+	err := VerifyEpochPK(revPk, sig.RevocationEpochPk, sig.RevocationPkSig, int(sig.Epoch), revocationAlg)
+	if err != nil {
+		return errors.WithMessage(err, "signature invalid: the epoch public key is not valid")
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	APrime := EcpFromProto(sig.GetAPrime())
@@ -230,13 +305,24 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 	t3 := HSk.Mul2(ProofSSk, HRand, ProofSRNym)
 	t3.Sub(Nym.Mul(ProofC))
 
+	// recompute the non-revocation contribution, which shares the response of the hidden revocation handle
+	verifier, err := getNonRevocationVerifier(revocationAlg)
+	if err != nil {
+		return err
+	}
+	nonRevokedProofHashData, err := verifier.recomputeFSContribution(sig.NonRevocationProof, ProofC, Ecp2FromProto(sig.RevocationEpochPk), ProofSAttrs[hiddenPosition(HiddenIndices, rhIndex)])
+	if err != nil {
+		return err
+	}
+
 	// proofData is the data being hashed, it consists of:
 	// the signature label
 	// 7 elements of G1 each taking 2*FieldBytes+1 bytes
 	// one bigint (hash of the issuer public key) of length FieldBytes
 	// disclosed attributes
 	// message that was signed
-	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg))
+	// the epoch, the epoch public key and the non-revocation contribution
+	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg)+FieldBytes+4*FieldBytes+ProofBytes[revocationAlg])
 	index := 0
 	index = appendBytesString(proofData, index, signLabel)
 	index = appendBytesG1(proofData, index, t1)
@@ -251,6 +337,10 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 	copy(proofData[index:], Disclosure)
 	index = index + len(Disclosure)
 	copy(proofData[index:], msg)
+	index = index + len(msg)
+	index = appendBytesBig(proofData, index, FP256BN.NewBIGint(epoch))
+	index = appendBytesG2(proofData, index, Ecp2FromProto(sig.RevocationEpochPk))
+	copy(proofData[index:], nonRevokedProofHashData)
 
 	c := HashModOrder(proofData)
 	index = 0
@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// cacheMetrics reports the hits and misses of a cache
+type cacheMetrics struct {
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+// newCacheMetrics creates the metrics of the cache with the given name,
+// or returns nil if metrics aren't initialized
+func newCacheMetrics(name string) *cacheMetrics {
+	if metrics.RootScope == nil {
+		return nil
+	}
+	scope := metrics.RootScope.SubScope("msp_cache").Tagged(map[string]string{"cache": name})
+	return &cacheMetrics{
+		hits:   scope.Counter("hits"),
+		misses: scope.Counter("misses"),
+	}
+}
+
+func (m *cacheMetrics) report(hit bool) {
+	if m == nil {
+		return
+	}
+	if hit {
+		m.hits.Inc(1)
+	} else {
+		m.misses.Inc(1)
+	}
+}
+
+// entry is a cached value along with the time it expires at
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// boundedCache is a thread safe LRU cache whose entries
+// expire after a time-to-live, if the time-to-live is positive
+type boundedCache struct {
+	lock    sync.Mutex
+	name    string
+	size    int
+	ttl     time.Duration
+	lru     *lru.Cache
+	metrics *cacheMetrics
+	now     func() time.Time
+}
+
+func newBoundedCache(name string, conf Config) *boundedCache {
+	return &boundedCache{
+		name: name,
+		size: conf.Size,
+		ttl:  conf.TTL,
+		lru:  lru.New(conf.Size),
+		now:  time.Now,
+	}
+}
+
+// reportLookup reports a cache hit or miss.
+// The metrics are created upon the first lookup after metrics are initialized,
+// since the local MSP is created before metrics are initialized
+func (c *boundedCache) reportLookup(hit bool) {
+	if c.metrics == nil {
+		c.metrics = newCacheMetrics(c.name)
+	}
+	c.metrics.report(hit)
+}
+
+// Get returns the value of the given key, and whether it was found and hasn't expired
+func (c *boundedCache) Get(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	v, ok := c.lru.Get(key)
+	if ok && c.ttl > 0 && c.now().After(v.(*entry).expiresAt) {
+		c.lru.Remove(key)
+		ok = false
+	}
+	c.reportLookup(ok)
+	if !ok {
+		return nil, false
+	}
+	return v.(*entry).value, true
+}
+
+// Add adds the given value under the given key, evicting the least recently used entry if the cache is full
+func (c *boundedCache) Add(key string, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru.Add(key, &entry{
+		value:     value,
+		expiresAt: c.now().Add(c.ttl),
+	})
+}
+
+// Len returns the number of entries in the cache, including ones that have expired but weren't evicted yet
+func (c *boundedCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// Purge removes all entries from the cache
+func (c *boundedCache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lru = lru.New(c.size)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/msp/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type counter struct {
+	count int64
+}
+
+func (c *counter) Inc(delta int64) {
+	c.count += delta
+}
+
+func TestBoundedCacheTTL(t *testing.T) {
+	c := newBoundedCache("test", Config{Size: 10, TTL: time.Minute})
+	now := time.Now()
+	c.now = func() time.Time {
+		return now
+	}
+	c.Add("a", 1)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// The entry expires once its time-to-live elapses
+	now = now.Add(time.Minute + time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+
+	// Without a time-to-live, entries don't expire
+	c = newBoundedCache("test", Config{Size: 10})
+	c.Add("a", 1)
+	c.now = func() time.Time {
+		return now.Add(time.Hour * 24 * 365)
+	}
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+}
+
+func TestBoundedCacheSize(t *testing.T) {
+	c := newBoundedCache("test", Config{Size: 2})
+	c.Add("a", 1)
+	c.Add("b", 2)
+	// Access "a" so that "b" is the least recently used entry
+	c.Get("a")
+	c.Add("c", 3)
+	assert.Equal(t, 2, c.Len())
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestBoundedCacheMetrics(t *testing.T) {
+	hits, misses := &counter{}, &counter{}
+	c := newBoundedCache("test", Config{Size: 10})
+	c.metrics = &cacheMetrics{hits: hits, misses: misses}
+	c.Get("a")
+	c.Add("a", 1)
+	c.Get("a")
+	c.Get("a")
+	assert.Equal(t, int64(2), hits.count)
+	assert.Equal(t, int64(1), misses.count)
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(&mocks.MockMSP{}, Config{})
+	assert.EqualError(t, err, "Invalid cache size 0. It must be positive.")
+
+	i, err := NewWithConfig(&mocks.MockMSP{}, Config{Size: 5, TTL: time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, i.(*cachedMSP).satisfiesPrincipalCache.ttl)
+	assert.Equal(t, 5, i.(*cachedMSP).deserializeIdentityCache.size)
+
+	// An invalid default configuration is ignored
+	defer SetDefaultConfig(DefaultConfig())
+	SetDefaultConfig(Config{Size: 0})
+	assert.Equal(t, 100, DefaultConfig().Size)
+	SetDefaultConfig(Config{Size: 7})
+	i, err = New(&mocks.MockMSP{})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, i.(*cachedMSP).validateIdentityCache.size)
+}
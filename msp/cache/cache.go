@@ -9,31 +9,68 @@ package cache
 import (
 	"fmt"
 	"sync"
+	"time"
 
-	"github.com/golang/groupcache/lru"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/msp"
 	pmsp "github.com/hyperledger/fabric/protos/msp"
 )
 
-const (
-	deserializeIdentityCacheSize = 100
-	validateIdentityCacheSize    = 100
-	satisfiesPrincipalCacheSize  = 100
+var mspLogger = flogging.MustGetLogger("msp")
+
+// Config bounds the caches of a cached MSP
+type Config struct {
+	// Size is the maximum number of entries of each cache
+	Size int
+	// TTL is the time an entry is cached for. A TTL of 0 means entries don't expire,
+	// and are only evicted when the cache is full or the MSP is set up again
+	TTL time.Duration
+}
+
+var (
+	defaultConfigLock sync.RWMutex
+	defaultConfig     = Config{Size: 100}
 )
 
-var mspLogger = flogging.MustGetLogger("msp")
+// SetDefaultConfig sets the configuration of the cached MSPs that are created by New
+func SetDefaultConfig(conf Config) {
+	if conf.Size <= 0 {
+		mspLogger.Warningf("Invalid MSP cache size %d, keeping %d", conf.Size, DefaultConfig().Size)
+		return
+	}
+	defaultConfigLock.Lock()
+	defer defaultConfigLock.Unlock()
+	defaultConfig = conf
+}
 
+// DefaultConfig returns the configuration of the cached MSPs that are created by New
+func DefaultConfig() Config {
+	defaultConfigLock.RLock()
+	defer defaultConfigLock.RUnlock()
+	return defaultConfig
+}
+
+// New returns a cached MSP that decorates the given MSP,
+// with caches bounded by the default configuration
 func New(o msp.MSP) (msp.MSP, error) {
+	return NewWithConfig(o, DefaultConfig())
+}
+
+// NewWithConfig returns a cached MSP that decorates the given MSP,
+// with caches bounded by the given configuration
+func NewWithConfig(o msp.MSP, conf Config) (msp.MSP, error) {
 	mspLogger.Debugf("Creating Cache-MSP instance")
 	if o == nil {
 		return nil, fmt.Errorf("Invalid passed MSP. It must be different from nil.")
 	}
+	if conf.Size <= 0 {
+		return nil, fmt.Errorf("Invalid cache size %d. It must be positive.", conf.Size)
+	}
 
 	theMsp := &cachedMSP{MSP: o}
-	theMsp.deserializeIdentityCache = lru.New(deserializeIdentityCacheSize)
-	theMsp.satisfiesPrincipalCache = lru.New(satisfiesPrincipalCacheSize)
-	theMsp.validateIdentityCache = lru.New(validateIdentityCacheSize)
+	theMsp.deserializeIdentityCache = newBoundedCache("deserialize_identity", conf)
+	theMsp.satisfiesPrincipalCache = newBoundedCache("satisfies_principal", conf)
+	theMsp.validateIdentityCache = newBoundedCache("validate_identity", conf)
 
 	return theMsp, nil
 }
@@ -42,20 +79,14 @@ type cachedMSP struct {
 	msp.MSP
 
 	// cache for DeserializeIdentity.
-	deserializeIdentityCache *lru.Cache
-
-	dicMutex sync.Mutex // synchronize access to cache
+	deserializeIdentityCache *boundedCache
 
 	// cache for validateIdentity
-	validateIdentityCache *lru.Cache
-
-	vicMutex sync.Mutex // synchronize access to cache
+	validateIdentityCache *boundedCache
 
 	// basically a map of principals=>identities=>stringified to booleans
 	// specifying whether this identity satisfies this principal
-	satisfiesPrincipalCache *lru.Cache
-
-	spcMutex sync.Mutex // synchronize access to cache
+	satisfiesPrincipalCache *boundedCache
 }
 
 type cachedIdentity struct {
@@ -72,9 +103,7 @@ func (id *cachedIdentity) Validate() error {
 }
 
 func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
-	c.dicMutex.Lock()
 	id, ok := c.deserializeIdentityCache.Get(string(serializedIdentity))
-	c.dicMutex.Unlock()
 	if ok {
 		return &cachedIdentity{
 			cache:    c,
@@ -84,8 +113,6 @@ func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity
 
 	id, err := c.MSP.DeserializeIdentity(serializedIdentity)
 	if err == nil {
-		c.dicMutex.Lock()
-		defer c.dicMutex.Unlock()
 		c.deserializeIdentityCache.Add(string(serializedIdentity), id)
 		return &cachedIdentity{
 			cache:    c,
@@ -96,6 +123,7 @@ func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity
 }
 
 func (c *cachedMSP) Setup(config *pmsp.MSPConfig) error {
+	// The cached results may no longer hold under the new configuration
 	c.cleanCash()
 
 	return c.MSP.Setup(config)
@@ -105,9 +133,7 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 	identifier := id.GetIdentifier()
 	key := string(identifier.Mspid + ":" + identifier.Id)
 
-	c.vicMutex.Lock()
 	_, ok := c.validateIdentityCache.Get(key)
-	c.vicMutex.Unlock()
 	if ok {
 		// cache only stores if the identity is valid.
 		return nil
@@ -115,8 +141,6 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 
 	err := c.MSP.Validate(id)
 	if err == nil {
-		c.vicMutex.Lock()
-		defer c.vicMutex.Unlock()
 		c.validateIdentityCache.Add(key, true)
 	}
 
@@ -129,9 +153,7 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	principalKey := string(principal.PrincipalClassification) + string(principal.Principal)
 	key := identityKey + principalKey
 
-	c.spcMutex.Lock()
 	v, ok := c.satisfiesPrincipalCache.Get(key)
-	c.spcMutex.Unlock()
 	if ok {
 		if v == nil {
 			return nil
@@ -142,16 +164,14 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 
 	err := c.MSP.SatisfiesPrincipal(id, principal)
 
-	c.spcMutex.Lock()
-	defer c.spcMutex.Unlock()
 	c.satisfiesPrincipalCache.Add(key, err)
 	return err
 }
 
 func (c *cachedMSP) cleanCash() error {
-	c.deserializeIdentityCache = lru.New(deserializeIdentityCacheSize)
-	c.satisfiesPrincipalCache = lru.New(satisfiesPrincipalCacheSize)
-	c.validateIdentityCache = lru.New(validateIdentityCacheSize)
+	c.deserializeIdentityCache.Purge()
+	c.satisfiesPrincipalCache.Purge()
+	c.validateIdentityCache.Purge()
 
 	return nil
 }
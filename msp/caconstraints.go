@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"fmt"
+	"strings"
+
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+	"github.com/tjfoc/gmsm/sm2"
+)
+
+// caConstraints enforces the CA constraints of the configuration of an MSP
+// on the certification chains of its identities
+type caConstraints struct {
+	// maxPathLength bounds the number of intermediate CA certificates
+	// of a certification chain, unless it is negative
+	maxPathLength int
+	// nameConstraints maps the raw sanitized certificates of the
+	// constrained CAs to their name constraints
+	nameConstraints map[string]*m.FabricCANameConstraints
+}
+
+// check verifies that a certification chain, which starts with the certificate
+// under validation and ends with a root CA certificate, satisfies the constraints
+func (c *caConstraints) check(chain []*sm2.Certificate) error {
+	if c.maxPathLength >= 0 {
+		intermediates := 0
+		for _, cert := range chain[:len(chain)-1] {
+			if cert.IsCA {
+				intermediates++
+			}
+		}
+		if intermediates > c.maxPathLength {
+			return errors.Errorf("the certification chain has %d intermediate CAs, but at most %d are allowed", intermediates, c.maxPathLength)
+		}
+	}
+
+	// the name constraints of a CA apply to all the certificates below it in the chain
+	for i := len(chain) - 1; i > 0; i-- {
+		constraints, exists := c.nameConstraints[string(chain[i].Raw)]
+		if !exists {
+			continue
+		}
+		for _, cert := range chain[:i] {
+			if err := checkNameConstraints(cert, constraints); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("certificate [%s] violates the name constraints of CA [%s]", cert.Subject.CommonName, chain[i].Subject.CommonName))
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkNameConstraints verifies that the names of a certificate are within the given constraints
+func checkNameConstraints(cert *sm2.Certificate, constraints *m.FabricCANameConstraints) error {
+	domains := append([]string{}, cert.DNSNames...)
+	for _, email := range cert.EmailAddresses {
+		// the whole address is matched if it has no domain, so that it can't be permitted
+		domains = append(domains, email[strings.LastIndex(email, "@")+1:])
+	}
+	for _, domain := range domains {
+		for _, excluded := range constraints.ExcludedDnsDomains {
+			if matchDomainConstraint(domain, excluded) {
+				return errors.Errorf("name [%s] is excluded by [%s]", domain, excluded)
+			}
+		}
+		if len(constraints.PermittedDnsDomains) == 0 {
+			continue
+		}
+		permitted := false
+		for _, constraint := range constraints.PermittedDnsDomains {
+			if matchDomainConstraint(domain, constraint) {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return errors.Errorf("name [%s] is not within the permitted domains %v", domain, constraints.PermittedDnsDomains)
+		}
+	}
+
+	if len(constraints.PermittedOrganizationalUnits) == 0 {
+		return nil
+	}
+	// identities can't escape the constraints by leaving out their organizational units
+	if !cert.IsCA && len(cert.Subject.OrganizationalUnit) == 0 {
+		return errors.New("the certificate does not contain an organizational unit")
+	}
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		permitted := false
+		for _, constraint := range constraints.PermittedOrganizationalUnits {
+			if ou == constraint {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return errors.Errorf("organizational unit [%s] is not among the permitted ones %v", ou, constraints.PermittedOrganizationalUnits)
+		}
+	}
+
+	return nil
+}
+
+// matchDomainConstraint tells whether a domain is within the domain of a constraint.
+// As in RFC 5280, a constraint with a leading dot only matches the subdomains of its domain
+func matchDomainConstraint(domain, constraint string) bool {
+	domain = strings.ToLower(domain)
+	constraint = strings.ToLower(constraint)
+	if strings.HasPrefix(constraint, ".") {
+		return len(domain) > len(constraint) && strings.HasSuffix(domain, constraint)
+	}
+	return domain == constraint || strings.HasSuffix(domain, "."+constraint)
+}
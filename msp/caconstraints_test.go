@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
+)
+
+// newCAConstraintsTestChain returns the certification chain of an identity,
+// issued by an intermediate CA that a root CA issued
+func newCAConstraintsTestChain(t *testing.T, identityTemplate *sm2.Certificate) []*sm2.Certificate {
+	caTemplate := func(serial int64, cn string) *sm2.Certificate {
+		return &sm2.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              sm2.KeyUsageCertSign | sm2.KeyUsageCRLSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+	}
+	root, rootKey := newOCSPTestCert(t, caTemplate(1, "ca.example.com"), nil, nil)
+	intermediate, intermediateKey := newOCSPTestCert(t, caTemplate(2, "ica.org1.example.com"), root, rootKey)
+	identityTemplate.SerialNumber = big.NewInt(3)
+	identityTemplate.NotBefore = time.Now().Add(-time.Hour)
+	identityTemplate.NotAfter = time.Now().Add(time.Hour)
+	identityTemplate.KeyUsage = sm2.KeyUsageDigitalSignature
+	cert, _ := newOCSPTestCert(t, identityTemplate, intermediate, intermediateKey)
+	return []*sm2.Certificate{cert, intermediate, root}
+}
+
+func TestCAConstraintsMaxPathLength(t *testing.T) {
+	chain := newCAConstraintsTestChain(t, &sm2.Certificate{Subject: pkix.Name{CommonName: "peer0.org1.example.com"}})
+
+	assert.NoError(t, (&caConstraints{maxPathLength: -1}).check(chain))
+	assert.NoError(t, (&caConstraints{maxPathLength: 1}).check(chain))
+	err := (&caConstraints{maxPathLength: 0}).check(chain)
+	assert.EqualError(t, err, "the certification chain has 1 intermediate CAs, but at most 0 are allowed")
+
+	// the intermediate CA itself is within the limit
+	assert.NoError(t, (&caConstraints{maxPathLength: 1}).check(chain[1:]))
+	assert.Error(t, (&caConstraints{maxPathLength: 0}).check(chain[1:]))
+}
+
+func TestCAConstraintsNameConstraints(t *testing.T) {
+	chain := newCAConstraintsTestChain(t, &sm2.Certificate{
+		Subject:        pkix.Name{CommonName: "peer0.org1.example.com", OrganizationalUnit: []string{"peer", "org1"}},
+		DNSNames:       []string{"peer0.org1.example.com"},
+		EmailAddresses: []string{"admin@org1.example.com"},
+	})
+	constrain := func(ca *sm2.Certificate, constraints *m.FabricCANameConstraints) *caConstraints {
+		return &caConstraints{maxPathLength: -1, nameConstraints: map[string]*m.FabricCANameConstraints{string(ca.Raw): constraints}}
+	}
+	intermediate, root := chain[1], chain[2]
+
+	for _, constraints := range []*m.FabricCANameConstraints{
+		{PermittedDnsDomains: []string{"org1.example.com"}},
+		{PermittedDnsDomains: []string{"org2.example.com", ".example.com"}},
+		{ExcludedDnsDomains: []string{"org2.example.com"}},
+		{PermittedOrganizationalUnits: []string{"peer", "org1", "client"}},
+	} {
+		assert.NoError(t, constrain(intermediate, constraints).check(chain))
+		assert.NoError(t, constrain(root, constraints).check(chain))
+	}
+
+	err := constrain(intermediate, &m.FabricCANameConstraints{PermittedDnsDomains: []string{"org2.example.com"}}).check(chain)
+	assert.EqualError(t, err, "certificate [peer0.org1.example.com] violates the name constraints of CA [ica.org1.example.com]: name [peer0.org1.example.com] is not within the permitted domains [org2.example.com]")
+
+	// a leading dot only matches subdomains
+	err = constrain(intermediate, &m.FabricCANameConstraints{PermittedDnsDomains: []string{".peer0.org1.example.com"}}).check(chain)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "name [peer0.org1.example.com] is not within the permitted domains")
+
+	// the domains of the email addresses are constrained as well
+	err = constrain(intermediate, &m.FabricCANameConstraints{ExcludedDnsDomains: []string{"ORG1.example.com"}}).check(chain)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "name [peer0.org1.example.com] is excluded by [ORG1.example.com]")
+
+	err = constrain(root, &m.FabricCANameConstraints{PermittedOrganizationalUnits: []string{"peer"}}).check(chain)
+	assert.EqualError(t, err, "certificate [peer0.org1.example.com] violates the name constraints of CA [ca.example.com]: organizational unit [org1] is not among the permitted ones [peer]")
+
+	// the constraints of a root CA apply to the intermediate CAs too
+	err = constrain(root, &m.FabricCANameConstraints{ExcludedDnsDomains: []string{"example.com"}}).check(chain[1:])
+	assert.NoError(t, err)
+	err = constrain(root, &m.FabricCANameConstraints{PermittedOrganizationalUnits: []string{"peer"}}).check(chain[1:])
+	assert.NoError(t, err)
+
+	// identities can't leave out their organizational units
+	chain = newCAConstraintsTestChain(t, &sm2.Certificate{Subject: pkix.Name{CommonName: "peer0.org1.example.com"}})
+	err = constrain(chain[1], &m.FabricCANameConstraints{PermittedOrganizationalUnits: []string{"peer"}}).check(chain)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certificate does not contain an organizational unit")
+}
+
+func TestSetupWithCAConstraints(t *testing.T) {
+	// testdata/intermediate has an admin certified by an intermediate CA
+	mspDir, err := filepath.Abs("testdata/intermediate")
+	assert.NoError(t, err)
+	tempDir, err := ioutil.TempDir("", "fabric-msp-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	for _, dir := range []string{cacerts, intermediatecerts, admincerts} {
+		err = os.Symlink(filepath.Join(mspDir, dir), filepath.Join(tempDir, dir))
+		assert.NoError(t, err)
+	}
+
+	setup := func(config string) error {
+		err := ioutil.WriteFile(filepath.Join(tempDir, configfilename), []byte(config), 0644)
+		assert.NoError(t, err)
+		conf, err := GetVerifyingMspConfig(tempDir, "SampleOrg", ProviderTypeToString(FABRIC))
+		assert.NoError(t, err)
+		thisMSP, err := newBccspMsp(MSPv1_0)
+		assert.NoError(t, err)
+		return thisMSP.Setup(conf)
+	}
+
+	assert.NoError(t, setup("CAConstraints:\n  MaxPathLength: 1\n  NameConstraints:\n  - Certificate: cacerts/cacert.pem\n    PermittedDNSDomains: [example.com]\n"))
+
+	// the intermediate CA itself exceeds the path length
+	err = setup("CAConstraints:\n  MaxPathLength: 0\n")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certification chain has 1 intermediate CAs, but at most 0 are allowed")
+
+	// the admin is outside of the namespace of the intermediate CA
+	err = setup("CAConstraints:\n  NameConstraints:\n  - Certificate: intermediatecerts/intermediatecert.pem\n    PermittedOrganizationalUnits: [org1]\n")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "admin 0 is invalid")
+	assert.Contains(t, err.Error(), "certificate [user1] violates the name constraints of CA [ica1]")
+
+	// name constraints can only be set on the CAs of the MSP
+	err = setup("CAConstraints:\n  NameConstraints:\n  - Certificate: admincerts/admin.pem\n")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate of name constraints [user1] is not in root or intermediate certs")
+}
+
+func TestMatchDomainConstraint(t *testing.T) {
+	assert.True(t, matchDomainConstraint("example.com", "example.com"))
+	assert.True(t, matchDomainConstraint("peer0.Example.com", "example.COM"))
+	assert.False(t, matchDomainConstraint("badexample.com", "example.com"))
+	assert.False(t, matchDomainConstraint("example.com", ".example.com"))
+	assert.True(t, matchDomainConstraint("peer0.example.com", ".example.com"))
+	assert.False(t, matchDomainConstraint("com", "example.com"))
+}
@@ -12,10 +12,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -30,10 +32,11 @@ type OrganizationalUnitIdentifiersConfiguration struct {
 	OrganizationalUnitIdentifier string `yaml:"OrganizationalUnitIdentifier,omitempty"`
 }
 
-// NodeOUs contains information on how to tell apart clients, peers and orderers
+// NodeOUs contains information on how to tell apart clients, peers, admins and orderers
 // based on OUs. If the check is enforced, by setting Enabled to true,
-// the MSP will consider an identity valid if it is an identity of a client, a peer or
-// an orderer. An identity should have only one of these special OUs.
+// the MSP will consider an identity valid if it is an identity of a client, a peer,
+// an admin or an orderer. An identity should have only one of these special OUs.
+// Admins and orderers are classified by OU on channels with the V1_3 capability only.
 type NodeOUs struct {
 	// Enable activates the OU enforcement
 	Enable bool `yaml:"Enable,omitempty"`
@@ -41,6 +44,51 @@ type NodeOUs struct {
 	ClientOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"ClientOUIdentifier,omitempty"`
 	// PeerOUIdentifier specifies how to recognize peers by OU
 	PeerOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"PeerOUIdentifier,omitempty"`
+	// AdminOUIdentifier specifies how to recognize admins by OU.
+	// If set, the admincerts folder may be left empty
+	AdminOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"AdminOUIdentifier,omitempty"`
+	// OrdererOUIdentifier specifies how to recognize orderers by OU
+	OrdererOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"OrdererOUIdentifier,omitempty"`
+}
+
+// OCSP configures the checking of the revocation status of certificates against the
+// OCSP responders named in them. Revocations are then picked up without updating the
+// revocation list of the MSP.
+type OCSP struct {
+	// Enable activates the OCSP checking
+	Enable bool `yaml:"Enable,omitempty"`
+	// HardFail makes an identity invalid if the revocation status of its certificate
+	// cannot be determined. By default, such identities are considered valid
+	HardFail bool `yaml:"HardFail,omitempty"`
+	// CacheTTL bounds the time an OCSP response is cached for
+	CacheTTL time.Duration `yaml:"CacheTTL,omitempty"`
+	// Timeout bounds the time an OCSP responder is waited for
+	Timeout time.Duration `yaml:"Timeout,omitempty"`
+}
+
+// CANameConstraints restricts the names of the certificates that a CA certifies,
+// either directly or through intermediate CAs
+type CANameConstraints struct {
+	// Certificate is the path to a root or intermediate certificate
+	Certificate string `yaml:"Certificate,omitempty"`
+	// PermittedDNSDomains, if set, are the domains the DNS names and email
+	// addresses of the certificates must be within
+	PermittedDNSDomains []string `yaml:"PermittedDNSDomains,omitempty"`
+	// ExcludedDNSDomains are the domains the DNS names and email addresses
+	// of the certificates must not be within
+	ExcludedDNSDomains []string `yaml:"ExcludedDNSDomains,omitempty"`
+	// PermittedOrganizationalUnits, if set, are the only OUs the certificates may contain
+	PermittedOrganizationalUnits []string `yaml:"PermittedOrganizationalUnits,omitempty"`
+}
+
+// CAConstraints restricts the certification chains of the identities of the MSP,
+// in addition to the constraints carried by the CA certificates
+type CAConstraints struct {
+	// MaxPathLength, if set, bounds the number of intermediate CAs between
+	// a root CA and an identity
+	MaxPathLength *uint32 `yaml:"MaxPathLength,omitempty"`
+	// NameConstraints restricts the names certified by the CAs
+	NameConstraints []*CANameConstraints `yaml:"NameConstraints,omitempty"`
 }
 
 // Configuration represents the accessory configuration an MSP can be equipped with.
@@ -52,6 +100,12 @@ type Configuration struct {
 	// NodeOUs enables the MSP to tell apart clients, peers and orderers based
 	// on the identity's OU.
 	NodeOUs *NodeOUs `yaml:"NodeOUs,omitempty"`
+	// OCSP enables the MSP to check the revocation status of certificates
+	// against OCSP responders, in addition to its revocation list
+	OCSP *OCSP `yaml:"OCSP,omitempty"`
+	// CAConstraints enables the MSP to restrict the certification chains of its
+	// identities, so that a CA can't issue identities outside of its namespace
+	CAConstraints *CAConstraints `yaml:"CAConstraints,omitempty"`
 }
 
 func readFile(file string) ([]byte, error) {
@@ -134,7 +188,7 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		bccspConfig = factory.GetDefaultOpts()
 	}
 
-	if bccspConfig.ProviderName == "SW" {
+	if bccspConfig.ProviderName == "SW" || bccspConfig.ProviderName == "GM" {
 		if bccspConfig.SwOpts == nil {
 			bccspConfig.SwOpts = factory.GetDefaultOpts().SwOpts
 		}
@@ -216,8 +270,10 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		return nil, errors.WithMessage(err, fmt.Sprintf("could not load a valid ca certificate from directory %s", cacertDir))
 	}
 
+	// admin certificates may be missing if admins are classified by OU,
+	// which is checked once the configuration file is loaded
 	admincert, err := getPemMaterialFromDir(admincertDir)
-	if err != nil || len(admincert) == 0 {
+	if err != nil && !os.IsNotExist(err) {
 		return nil, errors.WithMessage(err, fmt.Sprintf("could not load a valid admin certificate from directory %s", admincertDir))
 	}
 
@@ -257,6 +313,8 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	// otherwise skip it
 	var ouis []*msp.FabricOUIdentifier
 	var nodeOUs *msp.FabricNodeOUs
+	var ocspConfig *msp.FabricOCSPConfig
+	var caConstraints *msp.FabricCAConstraints
 	_, err = os.Stat(configFile)
 	if err == nil {
 		// load the file, if there is a failure in loading it then
@@ -324,11 +382,73 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 			} else {
 				nodeOUs.PeerOUIdentifier.Certificate = raw
 			}
+
+			// AdminOU, if defined
+			if configuration.NodeOUs.AdminOUIdentifier != nil && len(configuration.NodeOUs.AdminOUIdentifier.OrganizationalUnitIdentifier) != 0 {
+				nodeOUs.AdminOUIdentifier = &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: configuration.NodeOUs.AdminOUIdentifier.OrganizationalUnitIdentifier}
+				f = filepath.Join(dir, configuration.NodeOUs.AdminOUIdentifier.Certificate)
+				raw, err = readFile(f)
+				if err != nil {
+					mspLogger.Debugf("Failed loading AdminOU certificate at [%s]: [%s]", f, err)
+				} else {
+					nodeOUs.AdminOUIdentifier.Certificate = raw
+				}
+			}
+
+			// OrdererOU, if defined
+			if configuration.NodeOUs.OrdererOUIdentifier != nil && len(configuration.NodeOUs.OrdererOUIdentifier.OrganizationalUnitIdentifier) != 0 {
+				nodeOUs.OrdererOUIdentifier = &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: configuration.NodeOUs.OrdererOUIdentifier.OrganizationalUnitIdentifier}
+				f = filepath.Join(dir, configuration.NodeOUs.OrdererOUIdentifier.Certificate)
+				raw, err = readFile(f)
+				if err != nil {
+					mspLogger.Debugf("Failed loading OrdererOU certificate at [%s]: [%s]", f, err)
+				} else {
+					nodeOUs.OrdererOUIdentifier.Certificate = raw
+				}
+			}
+		}
+
+		// Prepare OCSP
+		if configuration.OCSP != nil && configuration.OCSP.Enable {
+			mspLogger.Info("Loading OCSP")
+			ocspConfig = &msp.FabricOCSPConfig{
+				HardFail:        configuration.OCSP.HardFail,
+				CacheTtlSeconds: uint32(configuration.OCSP.CacheTTL / time.Second),
+				TimeoutSeconds:  uint32(configuration.OCSP.Timeout / time.Second),
+			}
+		}
+
+		// Prepare CAConstraints
+		if configuration.CAConstraints != nil {
+			mspLogger.Info("Loading CAConstraints")
+			caConstraints = &msp.FabricCAConstraints{}
+			if configuration.CAConstraints.MaxPathLength != nil {
+				caConstraints.EnforceMaxPathLength = true
+				caConstraints.MaxPathLength = *configuration.CAConstraints.MaxPathLength
+			}
+			for _, nameConstraints := range configuration.CAConstraints.NameConstraints {
+				f := filepath.Join(dir, nameConstraints.Certificate)
+				raw, err = readFile(f)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed loading name constraints certificate at [%s]", f)
+				}
+
+				caConstraints.NameConstraints = append(caConstraints.NameConstraints, &msp.FabricCANameConstraints{
+					Certificate:                  raw,
+					PermittedDnsDomains:          nameConstraints.PermittedDNSDomains,
+					ExcludedDnsDomains:           nameConstraints.ExcludedDNSDomains,
+					PermittedOrganizationalUnits: nameConstraints.PermittedOrganizationalUnits,
+				})
+			}
 		}
 	} else {
 		mspLogger.Debugf("MSP configuration file not found at [%s]: [%s]", configFile, err)
 	}
 
+	if len(admincert) == 0 && (nodeOUs == nil || nodeOUs.AdminOUIdentifier == nil) {
+		return nil, errors.Errorf("could not load a valid admin certificate from directory %s", admincertDir)
+	}
+
 	// Set FabricCryptoConfig
 	cryptoConfig := &msp.FabricCryptoConfig{
 		SignatureHashFamily:            bccsp.SHA2,
@@ -348,6 +468,8 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		TlsRootCerts:                  tlsCACerts,
 		TlsIntermediateCerts:          tlsIntermediateCerts,
 		FabricNodeOUs:                 nodeOUs,
+		OcspConfig:                    ocspConfig,
+		CaConstraints:                 caConstraints,
 	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
@@ -363,6 +485,7 @@ const (
 	IdemixConfigFileIssuerPublicKey     = "IssuerPublicKey"
 	IdemixConfigFileRevocationPublicKey = "RevocationPublicKey"
 	IdemixConfigFileSigner              = "SignerConfig"
+	IdemixConfigFileCRI                 = "CredentialRevocationInformation"
 )
 
 // GetIdemixMspConfig returns the configuration for the Idemix MSP
@@ -372,9 +495,27 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 		return nil, errors.Wrapf(err, "failed to read issuer public key file")
 	}
 
+	revocationPkBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationPublicKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read revocation public key file")
+	}
+
 	idemixConfig := &msp.IdemixMSPConfig{
-		Name: ID,
-		IPk:  ipkBytes,
+		Name:         ID,
+		IPk:          ipkBytes,
+		RevocationPk: revocationPkBytes,
+	}
+
+	// the CRI of the current epoch is optional, and it determines the epoch of the MSP
+	criBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileCRI))
+	if err == nil {
+		cri := &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(criBytes, cri)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal credential revocation information")
+		}
+		idemixConfig.CredentialRevocationInformation = criBytes
+		idemixConfig.Epoch = cri.Epoch
 	}
 
 	signerBytes, err := readFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
@@ -12,7 +12,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +25,85 @@ func TestGetLocalMspConfig(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetVerifyingMspConfigWithOCSP(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "fabric-msp-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	for _, dir := range []string{cacerts, admincerts} {
+		err = os.Symlink(filepath.Join(mspDir, dir), filepath.Join(tempDir, dir))
+		assert.NoError(t, err)
+	}
+	config := []byte("OCSP:\n  Enable: true\n  HardFail: true\n  CacheTTL: 2m\n  Timeout: 3s\n")
+	err = ioutil.WriteFile(filepath.Join(tempDir, configfilename), config, 0644)
+	assert.NoError(t, err)
+
+	conf, err := GetVerifyingMspConfig(tempDir, "SampleOrg", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+	fabricConf := &msp.FabricMSPConfig{}
+	err = proto.Unmarshal(conf.Config, fabricConf)
+	assert.NoError(t, err)
+	assert.Equal(t, &msp.FabricOCSPConfig{HardFail: true, CacheTtlSeconds: 120, TimeoutSeconds: 3}, fabricConf.OcspConfig)
+
+	// OCSP is left out unless enabled
+	config = []byte("OCSP:\n  Enable: false\n  HardFail: true\n")
+	err = ioutil.WriteFile(filepath.Join(tempDir, configfilename), config, 0644)
+	assert.NoError(t, err)
+
+	conf, err = GetVerifyingMspConfig(tempDir, "SampleOrg", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+	fabricConf = &msp.FabricMSPConfig{}
+	err = proto.Unmarshal(conf.Config, fabricConf)
+	assert.NoError(t, err)
+	assert.Nil(t, fabricConf.OcspConfig)
+}
+
+func TestGetVerifyingMspConfigWithAdminOU(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "fabric-msp-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// no admincerts folder
+	err = os.Symlink(filepath.Join(mspDir, cacerts), filepath.Join(tempDir, cacerts))
+	assert.NoError(t, err)
+
+	_, err = GetVerifyingMspConfig(tempDir, "SampleOrg", ProviderTypeToString(FABRIC))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not load a valid admin certificate")
+
+	// admins are classified by OU
+	config := []byte(`NodeOUs:
+  Enable: true
+  ClientOUIdentifier:
+    OrganizationalUnitIdentifier: "client"
+  PeerOUIdentifier:
+    OrganizationalUnitIdentifier: "peer"
+  AdminOUIdentifier:
+    Certificate: "cacerts/cacert.pem"
+    OrganizationalUnitIdentifier: "admin"
+  OrdererOUIdentifier:
+    OrganizationalUnitIdentifier: "orderer"
+`)
+	err = ioutil.WriteFile(filepath.Join(tempDir, configfilename), config, 0644)
+	assert.NoError(t, err)
+
+	conf, err := GetVerifyingMspConfig(tempDir, "SampleOrg", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+	fabricConf := &msp.FabricMSPConfig{}
+	err = proto.Unmarshal(conf.Config, fabricConf)
+	assert.NoError(t, err)
+	assert.Empty(t, fabricConf.Admins)
+	assert.Equal(t, "admin", fabricConf.FabricNodeOUs.AdminOUIdentifier.OrganizationalUnitIdentifier)
+	assert.NotEmpty(t, fabricConf.FabricNodeOUs.AdminOUIdentifier.Certificate)
+	assert.Equal(t, "orderer", fabricConf.FabricNodeOUs.OrdererOUIdentifier.OrganizationalUnitIdentifier)
+}
+
 func TestGetLocalMspConfigFails(t *testing.T) {
 	_, err := GetLocalMspConfig("/tmp/", nil, "SampleOrg")
 	assert.Error(t, err)
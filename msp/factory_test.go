@@ -47,6 +47,13 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).internalSetupFunc).Pointer()).Name(), "github.com/hyperledger/fabric/msp.(*bccspmsp).(github.com/hyperledger/fabric/msp.setupV11)-fm")
 	assert.Equal(t, runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).internalValidateIdentityOusFunc).Pointer()).Name(), "github.com/hyperledger/fabric/msp.(*bccspmsp).(github.com/hyperledger/fabric/msp.validateIdentityOUsV11)-fm")
 
+	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_3}})
+	assert.NoError(t, err)
+	assert.NotNil(t, i)
+	assert.Equal(t, MSPVersion(MSPv1_3), i.(*bccspmsp).version)
+	assert.Equal(t, runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).internalSetupFunc).Pointer()).Name(), "github.com/hyperledger/fabric/msp.(*bccspmsp).(github.com/hyperledger/fabric/msp.setupV13)-fm")
+	assert.Equal(t, runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).internalValidateIdentityOusFunc).Pointer()).Name(), "github.com/hyperledger/fabric/msp.(*bccspmsp).(github.com/hyperledger/fabric/msp.validateIdentityOUsV13)-fm")
+
 	i, err = New(&IdemixNewOpts{NewBaseOpts{Version: MSPv1_0}})
 	assert.Error(t, err)
 	assert.Nil(t, i)
@@ -55,4 +62,8 @@ func TestNew(t *testing.T) {
 	i, err = New(&IdemixNewOpts{NewBaseOpts{Version: MSPv1_1}})
 	assert.NoError(t, err)
 	assert.NotNil(t, i)
+
+	i, err = New(&IdemixNewOpts{NewBaseOpts{Version: MSPv1_3}})
+	assert.NoError(t, err)
+	assert.NotNil(t, i)
 }
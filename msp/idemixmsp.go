@@ -8,6 +8,8 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"encoding/hex"
 	"time"
 
@@ -54,10 +56,12 @@ const (
 var discloseFlags = []byte{1, 1, 0, 0}
 
 type idemixmsp struct {
-	ipk    *idemix.IssuerPublicKey
-	rng    *amcl.RAND
-	signer *idemixSigningIdentity
-	name   string
+	ipk          *idemix.IssuerPublicKey
+	rng          *amcl.RAND
+	signer       *idemixSigningIdentity
+	name         string
+	revocationPK *ecdsa.PublicKey
+	epoch        int
 }
 
 // newIdemixMsp creates a new instance of idemixmsp
@@ -112,6 +116,22 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	}
 	msp.ipk = ipk
 
+	x, y := elliptic.Unmarshal(elliptic.P384(), conf.RevocationPk)
+	if x == nil {
+		return errors.Errorf("failed to unmarshal revocation public key from idemix msp config")
+	}
+	msp.revocationPK = &ecdsa.PublicKey{Curve: elliptic.P384(), X: x, Y: y}
+	msp.epoch = int(conf.Epoch)
+
+	// The CRI of the current epoch may be distributed together with the config
+	var cri *idemix.CredentialRevocationInformation
+	if len(conf.CredentialRevocationInformation) != 0 {
+		cri, err = msp.verifiedCRI(conf.CredentialRevocationInformation)
+		if err != nil {
+			return errors.WithMessage(err, "invalid credential revocation information in idemix msp config")
+		}
+	}
+
 	rng, err := idemix.GetRand()
 	if err != nil {
 		return errors.Wrap(err, "error initializing PRNG for idemix msp")
@@ -178,8 +198,19 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.Wrap(err, "Credential is not cryptographically valid")
 	}
 
+	// Use the CRI of the config if there is one, since it is more likely to be up to date
+	if cri == nil {
+		if len(conf.Signer.CredentialRevocationInformation) == 0 {
+			return errors.New("no credential revocation information found for the default signer")
+		}
+		cri, err = msp.verifiedCRI(conf.Signer.CredentialRevocationInformation)
+		if err != nil {
+			return errors.WithMessage(err, "invalid credential revocation information of the default signer")
+		}
+	}
+
 	// Create the cryptographic evidence that this identity is valid
-	proof, err := idemix.NewSignature(cred, sk, Nym, RandNym, ipk, discloseFlags, nil, rng)
+	proof, err := idemix.NewSignature(cred, sk, Nym, RandNym, ipk, discloseFlags, nil, AttributeIndexRevocationHandle, cri, rng)
 	if err != nil {
 		return errors.Wrap(err, "Failed to setup cryptographic proof of identity")
 	}
@@ -190,6 +221,24 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	return nil
 }
 
+// verifiedCRI unmarshals a CredentialRevocationInformation, and checks that it belongs to the current epoch
+// and that its epoch public key was signed with the revocation key of this MSP
+func (msp *idemixmsp) verifiedCRI(criBytes []byte) (*idemix.CredentialRevocationInformation, error) {
+	cri := &idemix.CredentialRevocationInformation{}
+	err := proto.Unmarshal(criBytes, cri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal credential revocation information")
+	}
+	if cri.Epoch != int64(msp.epoch) {
+		return nil, errors.Errorf("credential revocation information is of epoch %d, but the current epoch is %d", cri.Epoch, msp.epoch)
+	}
+	err = idemix.VerifyEpochPK(msp.revocationPK, cri.EpochPK, cri.EpochPKSig, int(cri.Epoch), idemix.RevocationAlgorithm(cri.RevocationAlg))
+	if err != nil {
+		return nil, err
+	}
+	return cri, nil
+}
+
 // GetVersion returns the version of this MSP
 func (msp *idemixmsp) GetVersion() MSPVersion {
 	return MSPv1_1
@@ -285,7 +334,7 @@ func (id *idemixidentity) verifyProof() error {
 	ouBytes := []byte(id.OU.OrganizationalUnitIdentifier)
 	attributeValues := []*FP256BN.BIG{idemix.HashModOrder(ouBytes), FP256BN.NewBIGint(int(id.Role.Role))}
 
-	return id.associationProof.Ver(discloseFlags, id.msp.ipk, nil, attributeValues)
+	return id.associationProof.Ver(discloseFlags, id.msp.ipk, nil, attributeValues, AttributeIndexRevocationHandle, id.msp.revocationPK, id.msp.epoch)
 }
 
 func (msp *idemixmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal) error {
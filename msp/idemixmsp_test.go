@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"crypto/x509"
+	"io/ioutil"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
@@ -118,6 +121,96 @@ func TestSetupBad(t *testing.T) {
 	err = msp1.Setup(conf)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to unmarshal ipk from idemix msp config")
+
+	// Create MSP config with bad revocation public key bytes
+	conf, err = GetIdemixMspConfig("testdata/idemix/MSP1OU1", "IdemixMSP1")
+	assert.NoError(t, err)
+	idemixconfig = &msp.IdemixMSPConfig{}
+	err = proto.Unmarshal(conf.Config, idemixconfig)
+	assert.NoError(t, err)
+	idemixconfig.RevocationPk = []byte("barf")
+
+	idemixConfigBytes, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	conf.Config = idemixConfigBytes
+
+	err = msp1.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unmarshal revocation public key from idemix msp config")
+}
+
+// setupWithCRI sets up the idemix msp of the given config directory
+// with a fresh CRI of the given epoch, distributed through the msp config
+func setupWithCRI(t *testing.T, configPath string, epoch int, unrevokedHandles []*FP256BN.BIG) (MSP, error) {
+	keyBytes, err := ioutil.ReadFile(configPath + "/ca/RevocationKey")
+	assert.NoError(t, err)
+	revocationKey, err := x509.ParseECPrivateKey(keyBytes)
+	assert.NoError(t, err)
+	rng, err := idemix.GetRand()
+	assert.NoError(t, err)
+	cri, err := idemix.CreateCRI(revocationKey, unrevokedHandles, epoch, idemix.ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+
+	conf, err := GetIdemixMspConfig(configPath, "MSP1")
+	assert.NoError(t, err)
+	idemixconfig := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixconfig))
+	idemixconfig.Epoch = int64(epoch)
+	idemixconfig.CredentialRevocationInformation, err = proto.Marshal(cri)
+	assert.NoError(t, err)
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+
+	idemixMSP, err := newIdemixMsp()
+	assert.NoError(t, err)
+	return idemixMSP, idemixMSP.Setup(conf)
+}
+
+func TestEpochRotation(t *testing.T) {
+	msp1, err := setup("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	signer := msp1.(*idemixmsp).signer
+	rh := FP256BN.FromBytes(signer.Cred.Attrs[AttributeIndexRevocationHandle])
+
+	// In an epoch in which the signer isn't revoked, its identity is valid
+	msp1, err = setupWithCRI(t, "testdata/idemix/MSP1OU1", 1, []*FP256BN.BIG{rh})
+	assert.NoError(t, err)
+	id, err := getDefaultSigner(msp1)
+	assert.NoError(t, err)
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+
+	verMsp, err := setupWithCRI(t, "testdata/idemix/MSP1Verifier", 1, nil)
+	assert.NoError(t, err)
+	verID, err := verMsp.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Validate(verID))
+
+	// but it's no longer valid once the verifiers move to the next epoch
+	verMsp, err = setupWithCRI(t, "testdata/idemix/MSP1Verifier", 2, nil)
+	assert.NoError(t, err)
+	verID, err = verMsp.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	err = verMsp.Validate(verID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "signature invalid: it was created in epoch 1, but the current epoch is 2")
+
+	// and the signer can't produce a valid identity in an epoch in which it is revoked
+	_, err = setupWithCRI(t, "testdata/idemix/MSP1OU1", 2, []*FP256BN.BIG{FP256BN.NewBIGint(1234)})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is revoked in epoch 2")
+
+	// The CRI of the signer must be of the current epoch
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+	idemixconfig := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixconfig))
+	idemixconfig.Epoch = 3
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	err = msp1.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "credential revocation information is of epoch 0, but the current epoch is 3")
 }
 
 func TestSigning(t *testing.T) {
@@ -77,6 +77,14 @@ type bccspmsp struct {
 	// list of certificate revocation lists
 	CRL []*pkix.CertificateList
 
+	// checker of the revocation status of certificates against
+	// OCSP responders, nil if OCSP checking is disabled
+	ocsp *ocspChecker
+
+	// constraints on the certification chains of identities,
+	// nil if the configuration does not set any
+	caConstraints *caConstraints
+
 	// list of OUs
 	ouIdentifiers map[string][][]byte
 
@@ -88,6 +96,9 @@ type bccspmsp struct {
 	// These are the OUIdentifiers of the clients, peers and orderers.
 	// They are used to tell apart these entities
 	clientOU, peerOU *OUIdentifier
+
+	// NodeOUs of admins and orderers, nil if they are not classified by OU
+	adminOU, ordererOU *OUIdentifier
 }
 
 // newBccspMsp returns an MSP instance backed up by a BCCSP
@@ -108,6 +119,9 @@ func newBccspMsp(version MSPVersion) (MSP, error) {
 	case MSPv1_1:
 		theMsp.internalSetupFunc = theMsp.setupV11
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
+	case MSPv1_3:
+		theMsp.internalSetupFunc = theMsp.setupV13
+		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV13
 	default:
 		return nil, errors.Errorf("Invalid MSP version [%v]", version)
 	}
@@ -291,7 +305,7 @@ func (msp *bccspmsp) hasOURole(id Identity, mspRole m.MSPRole_MSPRoleType) error
 		return errors.New("NodeOUs not activated. Cannot tell apart identities.")
 	}
 
-	mspLogger.Debugf("MSP %s checking if the identity is a client", msp.name)
+	mspLogger.Debugf("MSP %s checking if the identity is a %s", msp.name, mspRole)
 
 	switch id := id.(type) {
 	// If this identity is of this specific type,
@@ -305,18 +319,25 @@ func (msp *bccspmsp) hasOURole(id Identity, mspRole m.MSPRole_MSPRoleType) error
 }
 
 func (msp *bccspmsp) hasOURoleInternal(id *identity, mspRole m.MSPRole_MSPRoleType) error {
-	var nodeOUValue string
+	var nodeOU *OUIdentifier
 	switch mspRole {
 	case m.MSPRole_CLIENT:
-		nodeOUValue = msp.clientOU.OrganizationalUnitIdentifier
+		nodeOU = msp.clientOU
 	case m.MSPRole_PEER:
-		nodeOUValue = msp.peerOU.OrganizationalUnitIdentifier
+		nodeOU = msp.peerOU
+	case m.MSPRole_ADMIN:
+		nodeOU = msp.adminOU
+	case m.MSPRole_ORDERER:
+		nodeOU = msp.ordererOU
 	default:
-		return fmt.Errorf("Invalid MSPRoleType. It must be CLIENT, PEER or ORDERER")
+		return fmt.Errorf("Invalid MSPRoleType. It must be CLIENT, PEER, ADMIN or ORDERER")
+	}
+	if nodeOU == nil {
+		return fmt.Errorf("NodeOUs do not classify [%s] identities, MSP: [%s]", mspRole, msp.name)
 	}
 
 	for _, OU := range id.GetOrganizationalUnits() {
-		if OU.OrganizationalUnitIdentifier == nodeOUValue {
+		if OU.OrganizationalUnitIdentifier == nodeOU.OrganizationalUnitIdentifier {
 			return nil
 		}
 	}
@@ -408,10 +429,23 @@ func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 					return nil
 				}
 			}
+			// otherwise, if admins are classified by OU,
+			// we check that the id is a valid admin identity
+			if msp.ouEnforcement && msp.adminOU != nil {
+				if err := msp.Validate(id); err != nil {
+					return errors.Wrapf(err, "The identity is not valid under this MSP [%s]", msp.name)
+				}
+				if err := msp.hasOURole(id, m.MSPRole_ADMIN); err != nil {
+					return errors.Wrapf(err, "The identity is not an admin under this MSP [%s]", msp.name)
+				}
+				return nil
+			}
 			return errors.New("This identity is not an admin")
 		case m.MSPRole_CLIENT:
 			fallthrough
 		case m.MSPRole_PEER:
+			fallthrough
+		case m.MSPRole_ORDERER:
 			mspLogger.Debugf("Checking if identity satisfies role [%s] for %s", m.MSPRole_MSPRoleType_name[int32(mspRole.Role)], msp.name)
 			if err := msp.Validate(id); err != nil {
 				return errors.Wrapf(err, "The identity is not valid under this MSP [%s]", msp.name)
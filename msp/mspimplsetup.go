@@ -199,6 +199,63 @@ func (msp *bccspmsp) setupCRLs(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
+func (msp *bccspmsp) setupOCSP(conf *m.FabricMSPConfig) error {
+	// OCSP checking is optional
+	msp.ocsp = nil
+	if conf.OcspConfig == nil {
+		return nil
+	}
+
+	msp.ocsp = newOCSPChecker(conf.OcspConfig)
+	mspLogger.Debugf("OCSP checking enabled for MSP %s, hard fail: %t", conf.Name, conf.OcspConfig.HardFail)
+
+	return nil
+}
+
+func (msp *bccspmsp) setupCAConstraints(conf *m.FabricMSPConfig) error {
+	// CA constraints are optional
+	msp.caConstraints = nil
+	if conf.CaConstraints == nil {
+		return nil
+	}
+
+	constraints := &caConstraints{maxPathLength: -1, nameConstraints: make(map[string]*m.FabricCANameConstraints)}
+	if conf.CaConstraints.EnforceMaxPathLength {
+		constraints.maxPathLength = int(conf.CaConstraints.MaxPathLength)
+	}
+	for _, nameConstraints := range conf.CaConstraints.NameConstraints {
+		cert, err := msp.getCertFromPem(nameConstraints.Certificate)
+		if err != nil {
+			return errors.WithMessage(err, "failed getting certificate of name constraints")
+		}
+		// Sanitize it to ensure like for like comparison with the certification chains
+		cert, err = msp.sanitizeCert(cert)
+		if err != nil {
+			return errors.WithMessage(err, "sanitizeCert failed")
+		}
+
+		found := false
+		for _, id := range append(append([]Identity{}, msp.rootCerts...), msp.intermediateCerts...) {
+			if id.(*identity).cert.Equal(cert) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("certificate of name constraints [%s] is not in root or intermediate certs", cert.Subject.CommonName)
+		}
+		if _, exists := constraints.nameConstraints[string(cert.Raw)]; exists {
+			return errors.Errorf("duplicate name constraints for certificate [%s]", cert.Subject.CommonName)
+		}
+		constraints.nameConstraints[string(cert.Raw)] = nameConstraints
+	}
+
+	msp.caConstraints = constraints
+	mspLogger.Debugf("CA constraints enabled for MSP %s, max path length: %d, name constraints: %d", conf.Name, constraints.maxPathLength, len(constraints.nameConstraints))
+
+	return nil
+}
+
 func (msp *bccspmsp) finalizeSetupCAs(config *m.FabricMSPConfig) error {
 	// ensure that our CAs are properly formed and that they are valid
 	for _, id := range append(append([]Identity{}, msp.rootCerts...), msp.intermediateCerts...) {
@@ -261,6 +318,54 @@ func (msp *bccspmsp) setupNodeOUs(config *m.FabricMSPConfig) error {
 	return nil
 }
 
+func (msp *bccspmsp) setupNodeOUsV13(config *m.FabricMSPConfig) error {
+	// Setup the NodeOUs of clients and peers as per V11
+	if err := msp.setupNodeOUs(config); err != nil {
+		return err
+	}
+
+	msp.adminOU = nil
+	msp.ordererOU = nil
+	if config.FabricNodeOUs == nil {
+		return nil
+	}
+
+	// AdminOU
+	adminOU, err := msp.getNodeOUIdentifier(config.FabricNodeOUs.AdminOUIdentifier)
+	if err != nil {
+		return err
+	}
+	msp.adminOU = adminOU
+
+	// OrdererOU
+	ordererOU, err := msp.getNodeOUIdentifier(config.FabricNodeOUs.OrdererOUIdentifier)
+	if err != nil {
+		return err
+	}
+	msp.ordererOU = ordererOU
+
+	return nil
+}
+
+// getNodeOUIdentifier returns the OUIdentifier of the given NodeOU configuration,
+// or nil if the NodeOU is not configured
+func (msp *bccspmsp) getNodeOUIdentifier(ouConf *m.FabricOUIdentifier) (*OUIdentifier, error) {
+	if ouConf == nil || len(ouConf.OrganizationalUnitIdentifier) == 0 {
+		return nil, nil
+	}
+
+	nodeOU := &OUIdentifier{OrganizationalUnitIdentifier: ouConf.OrganizationalUnitIdentifier}
+	if len(ouConf.Certificate) != 0 {
+		certifiersIdentifier, err := msp.getCertifiersIdentifier(ouConf.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		nodeOU.CertifiersIdentifier = certifiersIdentifier
+	}
+
+	return nodeOU, nil
+}
+
 func (msp *bccspmsp) setupSigningIdentity(conf *m.FabricMSPConfig) error {
 	if conf.SigningIdentity != nil {
 		sid, err := msp.getSigningIdentityFromConf(conf.SigningIdentity)
@@ -400,6 +505,16 @@ func (msp *bccspmsp) preSetupV1(conf *m.FabricMSPConfig) error {
 		return err
 	}
 
+	// Setup OCSP
+	if err := msp.setupOCSP(conf); err != nil {
+		return err
+	}
+
+	// Setup CA constraints
+	if err := msp.setupCAConstraints(conf); err != nil {
+		return err
+	}
+
 	// Finalize setup of the CAs
 	if err := msp.finalizeSetupCAs(conf); err != nil {
 		return err
@@ -480,3 +595,53 @@ func (msp *bccspmsp) postSetupV11(conf *m.FabricMSPConfig) error {
 
 	return nil
 }
+
+func (msp *bccspmsp) setupV13(conf *m.FabricMSPConfig) error {
+	err := msp.preSetupV1(conf)
+	if err != nil {
+		return err
+	}
+
+	// setup NodeOUs, including the ones of admins and orderers
+	if err := msp.setupNodeOUsV13(conf); err != nil {
+		return err
+	}
+
+	err = msp.postSetupV13(conf)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msp *bccspmsp) postSetupV13(conf *m.FabricMSPConfig) error {
+	// Admins are either listed, or classified by OU
+	if len(msp.admins) == 0 && (!msp.ouEnforcement || msp.adminOU == nil) {
+		return errors.New("administrators must be declared when no admin OU classification is set")
+	}
+
+	// Check for OU enforcement
+	if !msp.ouEnforcement {
+		// No enforcement required. Call post setup as per V1
+		return msp.postSetupV1(conf)
+	}
+
+	// Check that the listed admins are clients, or admins by OU
+	for i, admin := range msp.admins {
+		err := admin.Validate()
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("admin %d is invalid", i))
+		}
+
+		err = msp.hasOURole(admin, m.MSPRole_CLIENT)
+		if err != nil && msp.adminOU != nil {
+			err = msp.hasOURole(admin, m.MSPRole_ADMIN)
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("admin %d is invalid", i))
+		}
+	}
+
+	return nil
+}
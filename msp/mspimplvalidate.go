@@ -30,6 +30,14 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 		return errors.WithMessage(err, "could not validate identity against certification chain")
 	}
 
+	if msp.ocsp != nil {
+		// validationChain[1] is the CA that issued the identity's certificate
+		err = msp.ocsp.check(id.cert, validationChain[1])
+		if err != nil {
+			return errors.WithMessage(err, "could not validate identity against OCSP responders")
+		}
+	}
+
 	err = msp.internalValidateIdentityOusFunc(id)
 	if err != nil {
 		return errors.WithMessage(err, "could not validate identity's OUs")
@@ -73,6 +81,13 @@ func (msp *bccspmsp) validateTLSCAIdentity(cert *sm2.Certificate, opts *sm2.Veri
 }
 
 func (msp *bccspmsp) validateIdentityAgainstChain(id *identity, validationChain []*sm2.Certificate) error {
+	if msp.caConstraints != nil {
+		err := msp.caConstraints.check(validationChain)
+		if err != nil {
+			return errors.WithMessage(err, "the certification chain violates the CA constraints")
+		}
+	}
+
 	return msp.validateCertAgainstChain(id.cert, validationChain)
 }
 
@@ -204,6 +219,62 @@ func (msp *bccspmsp) validateIdentityOUsV11(id *identity) error {
 	return nil
 }
 
+func (msp *bccspmsp) validateIdentityOUsV13(id *identity) error {
+	// Run the same checks as per V1
+	err := msp.validateIdentityOUsV1(id)
+	if err != nil {
+		return err
+	}
+
+	// Perform V1_3 additional checks:
+	//
+	// -- Check for OU enforcement
+	if !msp.ouEnforcement {
+		// No enforcement required
+		return nil
+	}
+
+	// The special OUs used to tell apart clients, peers, admins and orderers.
+	// Admins and orderers are classified by OU only if configured to.
+	nodeOUs := []*OUIdentifier{msp.clientOU, msp.peerOU}
+	for _, nodeOU := range []*OUIdentifier{msp.adminOU, msp.ordererOU} {
+		if nodeOU != nil {
+			nodeOUs = append(nodeOUs, nodeOU)
+		}
+	}
+
+	// Make sure that the identity has only one of the special OUs
+	counter := 0
+	for _, OU := range id.GetOrganizationalUnits() {
+		// Is OU.OrganizationalUnitIdentifier one of the special OUs?
+		var nodeOU *OUIdentifier
+		for _, candidate := range nodeOUs {
+			if OU.OrganizationalUnitIdentifier == candidate.OrganizationalUnitIdentifier {
+				nodeOU = candidate
+				break
+			}
+		}
+		if nodeOU == nil {
+			continue
+		}
+
+		// Yes. Then, enforce the certifiers identifier is this is specified.
+		// It is not specified, it means that any certification path is fine.
+		if len(nodeOU.CertifiersIdentifier) != 0 && !bytes.Equal(nodeOU.CertifiersIdentifier, OU.CertifiersIdentifier) {
+			return errors.Errorf("certifiersIdentifier does not match: [%v], MSP: [%s]", id.GetOrganizationalUnits(), msp.name)
+		}
+		counter++
+		if counter > 1 {
+			break
+		}
+	}
+	if counter != 1 {
+		return errors.Errorf("the identity must be a client, a peer, an admin or an orderer identity to be valid, not a combination of them. OUs: [%v], MSP: [%s]", id.GetOrganizationalUnits(), msp.name)
+	}
+
+	return nil
+}
+
 func (msp *bccspmsp) getValidityOptsForCert(cert *sm2.Certificate) sm2.VerifyOptions {
 	// First copy the opts to override the CurrentTime field
 	// in order to make the certificate passing the expiration test
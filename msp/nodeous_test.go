@@ -17,11 +17,16 @@ limitations under the License.
 package msp
 
 import (
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
 )
 
 func TestInvalidAdminNodeOU(t *testing.T) {
@@ -235,3 +240,145 @@ func TestSatisfiesPrincipalClient(t *testing.T) {
 		assert.Contains(t, err.Error(), "The identity is not a [PEER] under this MSP [SampleOrg]")
 	}))
 }
+
+// nodeOUsV13TestSetup issues certificates carrying the given OUs
+// from a freshly generated CA
+type nodeOUsV13TestSetup struct {
+	t      *testing.T
+	ca     *sm2.Certificate
+	caKey  *sm2.PrivateKey
+	serial int64
+}
+
+func newNodeOUsV13TestSetup(t *testing.T) *nodeOUsV13TestSetup {
+	ca, caKey := newOCSPTestCert(t, &sm2.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.org1.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              sm2.KeyUsageCertSign | sm2.KeyUsageCRLSign,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+	return &nodeOUsV13TestSetup{t: t, ca: ca, caKey: caKey, serial: 1}
+}
+
+func (s *nodeOUsV13TestSetup) issue(ous ...string) []byte {
+	s.serial++
+	cert, _ := newOCSPTestCert(s.t, &sm2.Certificate{
+		SerialNumber:   big.NewInt(s.serial),
+		Subject:        pkix.Name{CommonName: "user.org1.example.com", OrganizationalUnit: ous},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       sm2.KeyUsageDigitalSignature,
+		AuthorityKeyId: s.ca.SubjectKeyId,
+	}, s.ca, s.caKey)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func (s *nodeOUsV13TestSetup) newMSP(version MSPVersion, admins [][]byte, adminOU bool) (MSP, error) {
+	nodeOUs := &msp.FabricNodeOUs{
+		Enable:              true,
+		ClientOUIdentifier:  &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "client"},
+		PeerOUIdentifier:    &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "peer"},
+		OrdererOUIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "orderer"},
+	}
+	if adminOU {
+		nodeOUs.AdminOUIdentifier = &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "admin"}
+	}
+	conf, err := proto.Marshal(&msp.FabricMSPConfig{
+		Name:          "Org1MSP",
+		RootCerts:     [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.ca.Raw})},
+		Admins:        admins,
+		FabricNodeOUs: nodeOUs,
+	})
+	assert.NoError(s.t, err)
+
+	thisMSP, err := newBccspMsp(version)
+	assert.NoError(s.t, err)
+	return thisMSP, thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: conf})
+}
+
+func rolePrincipal(t *testing.T, role msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+	principalBytes, err := proto.Marshal(&msp.MSPRole{Role: role, MspIdentifier: "Org1MSP"})
+	assert.NoError(t, err)
+	return &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: principalBytes}
+}
+
+func TestNodeOUsV13AdminsAndOrderers(t *testing.T) {
+	s := newNodeOUsV13TestSetup(t)
+
+	// admins classified by OU need not be listed
+	thisMSP, err := s.newMSP(MSPv1_3, nil, true)
+	assert.NoError(t, err)
+
+	admin, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("admin")))
+	assert.NoError(t, err)
+	orderer, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("orderer")))
+	assert.NoError(t, err)
+	peer, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("peer")))
+	assert.NoError(t, err)
+	client, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("client")))
+	assert.NoError(t, err)
+
+	for _, id := range []Identity{admin, orderer, peer, client} {
+		assert.NoError(t, id.Validate())
+		assert.NoError(t, id.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_MEMBER)))
+	}
+
+	assert.NoError(t, admin.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ADMIN)))
+	assert.Error(t, admin.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_CLIENT)))
+	assert.Error(t, client.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ADMIN)))
+
+	assert.NoError(t, orderer.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ORDERER)))
+	assert.Error(t, orderer.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_PEER)))
+	assert.Error(t, peer.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ORDERER)))
+
+	// an identity can carry only one of the NodeOUs
+	adminAndClient, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("admin", "client")))
+	assert.NoError(t, err)
+	err = adminAndClient.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not a combination of them")
+
+	// listed admins must be clients or admins
+	_, err = s.newMSP(MSPv1_3, [][]byte{s.issue("admin")}, true)
+	assert.NoError(t, err)
+	_, err = s.newMSP(MSPv1_3, [][]byte{s.issue("client")}, true)
+	assert.NoError(t, err)
+	_, err = s.newMSP(MSPv1_3, [][]byte{s.issue("peer")}, true)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "admin 0 is invalid")
+
+	// admins must be listed if they are not classified by OU
+	_, err = s.newMSP(MSPv1_3, nil, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "administrators must be declared")
+}
+
+func TestNodeOUsV11IgnoresAdminsAndOrderers(t *testing.T) {
+	s := newNodeOUsV13TestSetup(t)
+
+	thisMSP, err := s.newMSP(MSPv1_1, [][]byte{s.issue("client")}, true)
+	assert.NoError(t, err)
+	assert.Nil(t, thisMSP.(*bccspmsp).adminOU)
+	assert.Nil(t, thisMSP.(*bccspmsp).ordererOU)
+
+	// admin and orderer OUs are not NodeOUs before V1_3
+	admin, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("admin")))
+	assert.NoError(t, err)
+	assert.Error(t, admin.Validate())
+	assert.Error(t, admin.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ADMIN)))
+
+	orderer, err := thisMSP.DeserializeIdentity(serializeForTest(t, s.issue("orderer", "peer")))
+	assert.NoError(t, err)
+	assert.NoError(t, orderer.Validate())
+	assert.Error(t, orderer.SatisfiesPrincipal(rolePrincipal(t, msp.MSPRole_ORDERER)))
+}
+
+func serializeForTest(t *testing.T, certPEM []byte) []byte {
+	sId, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: certPEM})
+	assert.NoError(t, err)
+	return sId
+}
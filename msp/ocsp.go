@@ -0,0 +1,366 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+	"github.com/tjfoc/gmsm/sm2"
+)
+
+const (
+	// defaultOCSPCacheTTL is the maximum time an OCSP response is cached
+	// for, when the configuration does not set one
+	defaultOCSPCacheTTL = 10 * time.Minute
+	// defaultOCSPTimeout is the maximum time an OCSP responder is waited
+	// for, when the configuration does not set one
+	defaultOCSPTimeout = 5 * time.Second
+	// maxOCSPResponseSize bounds the size of the responses read from
+	// OCSP responders
+	maxOCSPResponseSize = 1 << 20
+	// ocspClockSkew is the tolerance applied to the validity period of
+	// OCSP responses
+	ocspClockSkew = 5 * time.Minute
+)
+
+var (
+	oidSHA1              = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidOCSPBasicResponse = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+)
+
+// The following structures reflect the ASN.1 definitions
+// of RFC 6960 needed to query OCSP responders
+
+type ocspCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type ocspRequest struct {
+	TBSRequest ocspTBSRequest
+}
+
+type ocspTBSRequest struct {
+	Version     int `asn1:"explicit,tag:0,default:0,optional"`
+	RequestList []ocspSingleRequest
+}
+
+type ocspSingleRequest struct {
+	Cert ocspCertID
+}
+
+type ocspResponse struct {
+	Status   asn1.Enumerated
+	Response ocspResponseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type ocspBasicResponse struct {
+	TBSResponseData    ocspResponseData
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+	Certificates       []asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseData struct {
+	Raw            asn1.RawContent
+	Version        int `asn1:"optional,default:0,explicit,tag:0"`
+	RawResponderID asn1.RawValue
+	ProducedAt     time.Time `asn1:"generalized"`
+	Responses      []ocspSingleResponse
+}
+
+type ocspSingleResponse struct {
+	CertID           ocspCertID
+	Good             asn1.Flag        `asn1:"tag:0,optional"`
+	Revoked          ocspRevokedInfo  `asn1:"tag:1,optional"`
+	Unknown          asn1.Flag        `asn1:"tag:2,optional"`
+	ThisUpdate       time.Time        `asn1:"generalized"`
+	NextUpdate       time.Time        `asn1:"generalized,explicit,tag:0,optional"`
+	SingleExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspRevokedInfo struct {
+	RevocationTime time.Time       `asn1:"generalized"`
+	Reason         asn1.Enumerated `asn1:"explicit,tag:0,optional"`
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ocspCacheEntry records the revocation status of a certificate
+// as reported by an OCSP responder
+type ocspCacheEntry struct {
+	revoked bool
+	// expiresAt is the time after which the status must be queried
+	// again. It is zero for revoked certificates, which stay revoked
+	expiresAt time.Time
+}
+
+// ocspChecker checks the revocation status of certificates against
+// the OCSP responders named in their AuthorityInformationAccess
+// extension, caching the answers it gets
+type ocspChecker struct {
+	hardFail bool
+	cacheTTL time.Duration
+	client   *http.Client
+	now      func() time.Time
+
+	lock  sync.Mutex
+	cache map[string]*ocspCacheEntry
+}
+
+func newOCSPChecker(conf *m.FabricOCSPConfig) *ocspChecker {
+	cacheTTL := defaultOCSPCacheTTL
+	if conf.CacheTtlSeconds != 0 {
+		cacheTTL = time.Duration(conf.CacheTtlSeconds) * time.Second
+	}
+	timeout := defaultOCSPTimeout
+	if conf.TimeoutSeconds != 0 {
+		timeout = time.Duration(conf.TimeoutSeconds) * time.Second
+	}
+
+	return &ocspChecker{
+		hardFail: conf.HardFail,
+		cacheTTL: cacheTTL,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+		cache:    make(map[string]*ocspCacheEntry),
+	}
+}
+
+// check returns an error if cert, issued by issuer, has been revoked
+// according to its OCSP responders. If the revocation status cannot be
+// determined, an error is returned only in hard fail mode
+func (o *ocspChecker) check(cert, issuer *sm2.Certificate) error {
+	if len(cert.OCSPServer) == 0 {
+		// nothing to ask
+		return nil
+	}
+
+	certID, err := newOCSPCertID(cert, issuer)
+	if err != nil {
+		return errors.WithMessage(err, "could not build the OCSP request")
+	}
+	req, err := asn1.Marshal(ocspRequest{
+		TBSRequest: ocspTBSRequest{RequestList: []ocspSingleRequest{{Cert: *certID}}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the OCSP request")
+	}
+
+	if revoked, found := o.lookup(string(req)); found {
+		if revoked {
+			return errors.New("The certificate has been revoked")
+		}
+		return nil
+	}
+
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		var resp *ocspSingleResponse
+		resp, lastErr = o.query(server, req, certID, issuer)
+		if lastErr != nil {
+			mspLogger.Debugf("Failed querying OCSP responder %s: %s", server, lastErr)
+			continue
+		}
+
+		switch {
+		case bool(resp.Good):
+			expiresAt := o.now().Add(o.cacheTTL)
+			if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(expiresAt) {
+				expiresAt = resp.NextUpdate
+			}
+			o.store(string(req), &ocspCacheEntry{expiresAt: expiresAt})
+			return nil
+		case bool(resp.Unknown):
+			lastErr = errors.Errorf("OCSP responder %s does not know the certificate", server)
+		default:
+			o.store(string(req), &ocspCacheEntry{revoked: true})
+			return errors.New("The certificate has been revoked")
+		}
+	}
+
+	if o.hardFail {
+		return errors.WithMessage(lastErr, "could not determine the revocation status of the certificate")
+	}
+	mspLogger.Warningf("Could not determine the revocation status of certificate with serial number %s, accepting it: %s", cert.SerialNumber, lastErr)
+	return nil
+}
+
+// lookup returns the cached revocation status for the given request,
+// if a valid one exists
+func (o *ocspChecker) lookup(req string) (revoked bool, found bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	entry, ok := o.cache[req]
+	if !ok {
+		return false, false
+	}
+	if !entry.revoked && !o.now().Before(entry.expiresAt) {
+		delete(o.cache, req)
+		return false, false
+	}
+	return entry.revoked, true
+}
+
+// store caches the revocation status for the given request, purging
+// the entries that expired
+func (o *ocspChecker) store(req string, entry *ocspCacheEntry) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	now := o.now()
+	for k, v := range o.cache {
+		if !v.revoked && !now.Before(v.expiresAt) {
+			delete(o.cache, k)
+		}
+	}
+	o.cache[req] = entry
+}
+
+// query sends req to the given OCSP responder and returns the verified
+// status it reports for the certificate identified by certID
+func (o *ocspChecker) query(server string, req []byte, certID *ocspCertID, issuer *sm2.Certificate) (*ocspSingleResponse, error) {
+	httpResp, err := o.client.Post(server, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected HTTP status %d", httpResp.StatusCode)
+	}
+	raw, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxOCSPResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading the response")
+	}
+
+	return parseOCSPResponse(raw, certID, issuer, o.now())
+}
+
+// parseOCSPResponse parses an OCSP response, checks that it is signed by
+// issuer or by a responder issuer delegated to, and returns the status it
+// reports for the certificate identified by certID
+func parseOCSPResponse(raw []byte, certID *ocspCertID, issuer *sm2.Certificate, now time.Time) (*ocspSingleResponse, error) {
+	var resp ocspResponse
+	rest, err := asn1.Unmarshal(raw, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed response")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after the response")
+	}
+	if resp.Status != 0 {
+		return nil, errors.Errorf("responder returned status %d", resp.Status)
+	}
+	if !resp.Response.ResponseType.Equal(oidOCSPBasicResponse) {
+		return nil, errors.Errorf("unsupported response type %s", resp.Response.ResponseType)
+	}
+
+	var basic ocspBasicResponse
+	rest, err = asn1.Unmarshal(resp.Response.Response, &basic)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed basic response")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after the basic response")
+	}
+
+	signer := issuer
+	if len(basic.Certificates) != 0 {
+		// the response is signed by a delegated responder,
+		// which must have been authorized by the issuer
+		signer, err = sm2.ParseCertificate(basic.Certificates[0].FullBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "malformed responder certificate")
+		}
+		if err = signer.CheckSignatureFrom(issuer); err != nil {
+			return nil, errors.Wrap(err, "responder certificate not signed by the issuer")
+		}
+		if !hasOCSPSigningUsage(signer) {
+			return nil, errors.New("responder certificate not authorized to sign OCSP responses")
+		}
+	}
+	err = signer.CheckCRLSignature(&pkix.CertificateList{
+		TBSCertList:        pkix.TBSCertificateList{Raw: basic.TBSResponseData.Raw},
+		SignatureAlgorithm: basic.SignatureAlgorithm,
+		SignatureValue:     basic.Signature,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid response signature")
+	}
+
+	for i := range basic.TBSResponseData.Responses {
+		single := &basic.TBSResponseData.Responses[i]
+		if !single.CertID.equal(certID) {
+			continue
+		}
+		if single.ThisUpdate.After(now.Add(ocspClockSkew)) {
+			return nil, errors.New("response is not valid yet")
+		}
+		if !single.NextUpdate.IsZero() && single.NextUpdate.Before(now.Add(-ocspClockSkew)) {
+			return nil, errors.New("response has expired")
+		}
+		return single, nil
+	}
+
+	return nil, errors.New("response does not cover the certificate")
+}
+
+func newOCSPCertID(cert, issuer *sm2.Certificate) (*ocspCertID, error) {
+	var spki subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, errors.Wrap(err, "malformed issuer public key")
+	}
+
+	nameHash := sha1.Sum(issuer.RawSubject)
+	keyHash := sha1.Sum(spki.PublicKey.RightAlign())
+
+	return &ocspCertID{
+		HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+		NameHash:      nameHash[:],
+		IssuerKeyHash: keyHash[:],
+		SerialNumber:  cert.SerialNumber,
+	}, nil
+}
+
+func (id *ocspCertID) equal(other *ocspCertID) bool {
+	return id.HashAlgorithm.Algorithm.Equal(other.HashAlgorithm.Algorithm) &&
+		bytes.Equal(id.NameHash, other.NameHash) &&
+		bytes.Equal(id.IssuerKeyHash, other.IssuerKeyHash) &&
+		id.SerialNumber.Cmp(other.SerialNumber) == 0
+}
+
+func hasOCSPSigningUsage(cert *sm2.Certificate) bool {
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == sm2.ExtKeyUsageOCSPSigning {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/sm3"
+)
+
+const (
+	ocspGood = iota
+	ocspRevoked
+	ocspUnknown
+)
+
+var oidSignatureSM2WithSM3 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}
+
+type ocspTestResponder struct {
+	sync.Mutex
+	t          *testing.T
+	status     int
+	httpStatus int
+	nextUpdate time.Time
+	// key signs the responses, cert is included in them if not nil
+	key      *sm2.PrivateKey
+	cert     *sm2.Certificate
+	requests int
+}
+
+func (r *ocspTestResponder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	r.requests++
+
+	if r.httpStatus != 0 {
+		w.WriteHeader(r.httpStatus)
+		return
+	}
+
+	raw, err := ioutil.ReadAll(req.Body)
+	assert.NoError(r.t, err)
+	var ocspReq ocspRequest
+	_, err = asn1.Unmarshal(raw, &ocspReq)
+	assert.NoError(r.t, err)
+
+	single := ocspSingleResponse{
+		CertID:     ocspReq.TBSRequest.RequestList[0].Cert,
+		ThisUpdate: time.Now().Add(-time.Minute).UTC(),
+		NextUpdate: r.nextUpdate,
+	}
+	switch r.status {
+	case ocspGood:
+		single.Good = true
+	case ocspRevoked:
+		single.Revoked = ocspRevokedInfo{RevocationTime: time.Now().Add(-time.Hour).UTC()}
+	case ocspUnknown:
+		single.Unknown = true
+	}
+
+	keyHash, err := asn1.Marshal(single.CertID.IssuerKeyHash)
+	assert.NoError(r.t, err)
+	tbs, err := asn1.Marshal(ocspResponseData{
+		RawResponderID: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: keyHash},
+		ProducedAt:     time.Now().UTC(),
+		Responses:      []ocspSingleResponse{single},
+	})
+	assert.NoError(r.t, err)
+
+	sig, err := r.key.Sign(rand.Reader, sm3.Sm3Sum(tbs), nil)
+	assert.NoError(r.t, err)
+	basic := ocspBasicResponse{
+		TBSResponseData:    ocspResponseData{Raw: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSignatureSM2WithSM3},
+		Signature:          asn1.BitString{Bytes: sig, BitLength: 8 * len(sig)},
+	}
+	if r.cert != nil {
+		basic.Certificates = []asn1.RawValue{{FullBytes: r.cert.Raw}}
+	}
+	basicRaw, err := asn1.Marshal(basic)
+	assert.NoError(r.t, err)
+
+	resp, err := asn1.Marshal(ocspResponse{
+		Response: ocspResponseBytes{ResponseType: oidOCSPBasicResponse, Response: basicRaw},
+	})
+	assert.NoError(r.t, err)
+
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(resp)
+}
+
+func (r *ocspTestResponder) requestCount() int {
+	r.Lock()
+	defer r.Unlock()
+	return r.requests
+}
+
+func newOCSPTestCert(t *testing.T, template, parent *sm2.Certificate, parentKey *sm2.PrivateKey) (*sm2.Certificate, *sm2.PrivateKey) {
+	key, err := sm2.GenerateKey(sm2.P256Sm2(), rand.Reader)
+	assert.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	raw, err := sm2.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := sm2.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return cert, key
+}
+
+// newOCSPTestSetup returns a CA, its key, and a certificate it issued that
+// names the given OCSP responder
+func newOCSPTestSetup(t *testing.T, server string) (*sm2.Certificate, *sm2.PrivateKey, *sm2.Certificate) {
+	ca, caKey := newOCSPTestCert(t, &sm2.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              sm2.KeyUsageCertSign | sm2.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+	cert, _ := newOCSPTestCert(t, &sm2.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "peer0.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     sm2.KeyUsageDigitalSignature,
+		OCSPServer:   []string{server},
+	}, ca, caKey)
+	return ca, caKey, cert
+}
+
+func TestOCSPCheck(t *testing.T) {
+	responder := &ocspTestResponder{t: t}
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	ca, caKey, cert := newOCSPTestSetup(t, server.URL)
+	responder.key = caKey
+
+	checker := newOCSPChecker(&m.FabricOCSPConfig{HardFail: true})
+
+	responder.status = ocspGood
+	assert.NoError(t, checker.check(cert, ca))
+	assert.Equal(t, 1, responder.requestCount())
+
+	// the response is cached
+	responder.status = ocspRevoked
+	assert.NoError(t, checker.check(cert, ca))
+	assert.Equal(t, 1, responder.requestCount())
+
+	// once the cache expires, the revocation is picked up
+	checker.now = func() time.Time { return time.Now().Add(defaultOCSPCacheTTL + time.Second) }
+	err := checker.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The certificate has been revoked")
+	assert.Equal(t, 2, responder.requestCount())
+
+	// and stays cached
+	responder.status = ocspGood
+	assert.Error(t, checker.check(cert, ca))
+	assert.Equal(t, 2, responder.requestCount())
+}
+
+func TestOCSPCheckNextUpdate(t *testing.T) {
+	responder := &ocspTestResponder{t: t, status: ocspGood, nextUpdate: time.Now().Add(time.Minute).UTC()}
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	ca, caKey, cert := newOCSPTestSetup(t, server.URL)
+	responder.key = caKey
+
+	checker := newOCSPChecker(&m.FabricOCSPConfig{CacheTtlSeconds: 3600})
+	assert.NoError(t, checker.check(cert, ca))
+	assert.NoError(t, checker.check(cert, ca))
+	assert.Equal(t, 1, responder.requestCount())
+
+	// responses are not cached past their next update
+	checker.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	assert.NoError(t, checker.check(cert, ca))
+	assert.Equal(t, 2, responder.requestCount())
+}
+
+func TestOCSPCheckFailureModes(t *testing.T) {
+	responder := &ocspTestResponder{t: t}
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	ca, caKey, cert := newOCSPTestSetup(t, server.URL)
+	responder.key = caKey
+
+	softFail := newOCSPChecker(&m.FabricOCSPConfig{})
+	hardFail := newOCSPChecker(&m.FabricOCSPConfig{HardFail: true})
+
+	// unknown certificate
+	responder.status = ocspUnknown
+	assert.NoError(t, softFail.check(cert, ca))
+	err := hardFail.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not know the certificate")
+
+	// responder failure
+	responder.status = ocspGood
+	responder.httpStatus = http.StatusInternalServerError
+	assert.NoError(t, softFail.check(cert, ca))
+	err = hardFail.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected HTTP status 500")
+
+	// unreachable responder
+	unreachable := *cert
+	unreachable.OCSPServer = []string{"http://127.0.0.1:0"}
+	assert.NoError(t, softFail.check(&unreachable, ca))
+	assert.Error(t, hardFail.check(&unreachable, ca))
+
+	// failures are not cached
+	responder.httpStatus = 0
+	assert.NoError(t, hardFail.check(cert, ca))
+
+	// revoked certificates are rejected even in soft fail mode
+	responder.status = ocspRevoked
+	softFail = newOCSPChecker(&m.FabricOCSPConfig{})
+	assert.Error(t, softFail.check(cert, ca))
+
+	// certificates without responders are not checked
+	noResponder := *cert
+	noResponder.OCSPServer = nil
+	assert.NoError(t, hardFail.check(&noResponder, ca))
+}
+
+func TestOCSPCheckSignature(t *testing.T) {
+	responder := &ocspTestResponder{t: t, status: ocspGood}
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	ca, caKey, cert := newOCSPTestSetup(t, server.URL)
+
+	checker := newOCSPChecker(&m.FabricOCSPConfig{HardFail: true})
+
+	// signed by an unrelated key
+	otherKey, err := sm2.GenerateKey(sm2.P256Sm2(), rand.Reader)
+	assert.NoError(t, err)
+	responder.key = otherKey
+	err = checker.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid response signature")
+
+	// signed by a delegated responder lacking the OCSP signing usage
+	template := &sm2.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "ocsp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     sm2.KeyUsageDigitalSignature,
+	}
+	responder.cert, responder.key = newOCSPTestCert(t, template, ca, caKey)
+	err = checker.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorized to sign OCSP responses")
+
+	// signed by a delegated responder not issued by the CA
+	template.ExtKeyUsage = []sm2.ExtKeyUsage{sm2.ExtKeyUsageOCSPSigning}
+	responder.cert, responder.key = newOCSPTestCert(t, template, nil, nil)
+	err = checker.check(cert, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "responder certificate not signed by the issuer")
+
+	// signed by a delegated responder
+	responder.cert, responder.key = newOCSPTestCert(t, template, ca, caKey)
+	assert.NoError(t, checker.check(cert, ca))
+}
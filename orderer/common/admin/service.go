@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+const pkgLogID = "orderer/common/admin"
+
+var logger = flogging.MustGetLogger(pkgLogID)
+
+var accessDenied = errors.New("access denied")
+
+// TLSReloader reloads the TLS credentials of the orderer
+type TLSReloader interface {
+	// ReloadTLSCredentials reloads the TLS credentials of the orderer from their
+	// files, and returns the PEM encoded certificate of the orderer and the number
+	// of certificate authorities client certificates are verified with
+	ReloadTLSCredentials() (certificate []byte, clientRootCAs int, err error)
+}
+
+// AccessControlEvaluator evaluates whether the creator of the given SignedData
+// is eligible of using the admin service
+type AccessControlEvaluator interface {
+	// Evaluate evaluates the eligibility of the creator of the given SignedData
+	// for being serviced by the admin service
+	Evaluate(signatureSet []*cb.SignedData) error
+}
+
+// Service implements the admin service of the orderer
+type Service struct {
+	tls        TLSReloader
+	ace        AccessControlEvaluator
+	timeWindow time.Duration
+}
+
+// NewService creates an admin service which authorizes requests using the
+// given AccessControlEvaluator, and rejects requests whose timestamp is not
+// within the given time window of the orderer's time.
+// The TLSReloader is nil if TLS isn't enabled.
+func NewService(tls TLSReloader, ace AccessControlEvaluator, timeWindow time.Duration) *Service {
+	return &Service{
+		tls:        tls,
+		ace:        ace,
+		timeWindow: timeWindow,
+	}
+}
+
+// ReloadTLSCredentials reloads the TLS credentials of the orderer from their files
+func (s *Service) ReloadTLSCredentials(ctx context.Context, env *cb.Envelope) (*ab.TLSCredentials, error) {
+	if err := s.validate(ctx, env, &ab.ReloadTLSCredentialsRequest{}); err != nil {
+		return nil, err
+	}
+	if s.tls == nil {
+		return nil, errors.New("TLS is not enabled")
+	}
+	certificate, clientRootCAs, err := s.tls.ReloadTLSCredentials()
+	if err != nil {
+		logger.Warningf("Failed reloading TLS credentials: %v", err)
+		return nil, errors.WithMessage(err, "failed reloading TLS credentials")
+	}
+	logger.Infof("Reloaded TLS credentials with %d client root CAs", clientRootCAs)
+	return &ab.TLSCredentials{Certificate: certificate, ClientRootCas: uint32(clientRootCAs)}, nil
+}
+
+// validate unmarshals the request in the given envelope into msg, and checks
+// that the request is recent and that its creator is authorized
+func (s *Service) validate(ctx context.Context, env *cb.Envelope, msg proto.Message) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if env == nil {
+		return errors.New("nil envelope")
+	}
+	addr := util.ExtractRemoteAddress(ctx)
+	ch, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_ORDERER_ADMIN_OPERATION, msg)
+	if err != nil {
+		logger.Warningf("Request from %s is badly formed: %+v", addr, err)
+		return errors.Wrap(err, "bad request")
+	}
+
+	if ch.Timestamp == nil {
+		logger.Warningf("Request from %s has no timestamp", addr)
+		return errors.New("empty timestamp")
+	}
+	ts := ch.Timestamp
+	reqTs := time.Unix(ts.Seconds, int64(ts.Nanos))
+	now := time.Now()
+	if reqTs.Add(s.timeWindow).Before(now) || reqTs.Add(-s.timeWindow).After(now) {
+		logger.Warningf("Request from %s unauthorized due to incorrect time: %s", addr, reqTs.String())
+		return accessDenied
+	}
+
+	sd, err := env.AsSignedData()
+	if err != nil {
+		return errors.Errorf("bad request, cannot extract signed data: %v", err)
+	}
+	if err := s.ace.Evaluate(sd); err != nil {
+		logger.Warningf("Request from %s unauthorized due to authentication: %v", addr, err)
+		return accessDenied
+	}
+	return nil
+}
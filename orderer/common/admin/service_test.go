@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type mockEvaluator struct {
+	err error
+}
+
+func (e *mockEvaluator) Evaluate(signatureSet []*cb.SignedData) error {
+	return e.err
+}
+
+type mockTLSReloader struct {
+	reloaded int
+	err      error
+}
+
+func (r *mockTLSReloader) ReloadTLSCredentials() ([]byte, int, error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	r.reloaded++
+	return []byte("certificate"), 2, nil
+}
+
+func request(t *testing.T, msg proto.Message) *cb.Envelope {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ORDERER_ADMIN_OPERATION, "", nil, msg, 0, 0)
+	assert.NoError(t, err)
+	return env
+}
+
+func requestWithTimestamp(ts *timestamp.Timestamp, msg proto.Message) *cb.Envelope {
+	ch := &cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_ORDERER_ADMIN_OPERATION),
+		Timestamp: ts,
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(ch)},
+			Data:   utils.MarshalOrPanic(msg),
+		}),
+	}
+}
+
+func TestReloadTLSCredentials(t *testing.T) {
+	reloader := &mockTLSReloader{}
+	s := NewService(reloader, &mockEvaluator{}, time.Minute)
+
+	creds, err := s.ReloadTLSCredentials(context.Background(), request(t, &ab.ReloadTLSCredentialsRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &ab.TLSCredentials{Certificate: []byte("certificate"), ClientRootCas: 2}, creds)
+	assert.Equal(t, 1, reloader.reloaded)
+
+	reloader.err = errors.New("invalid TLS certificate or key")
+	_, err = s.ReloadTLSCredentials(context.Background(), request(t, &ab.ReloadTLSCredentialsRequest{}))
+	assert.EqualError(t, err, "failed reloading TLS credentials: invalid TLS certificate or key")
+
+	s = NewService(nil, &mockEvaluator{}, time.Minute)
+	_, err = s.ReloadTLSCredentials(context.Background(), request(t, &ab.ReloadTLSCredentialsRequest{}))
+	assert.EqualError(t, err, "TLS is not enabled")
+}
+
+func TestValidate(t *testing.T) {
+	reloader := &mockTLSReloader{}
+	s := NewService(reloader, &mockEvaluator{}, time.Minute)
+	ctx := context.Background()
+
+	_, err := s.ReloadTLSCredentials(nil, request(t, &ab.ReloadTLSCredentialsRequest{}))
+	assert.EqualError(t, err, "nil context")
+
+	_, err = s.ReloadTLSCredentials(ctx, nil)
+	assert.EqualError(t, err, "nil envelope")
+
+	env, _ := utils.CreateSignedEnvelope(cb.HeaderType_PEER_ADMIN_OPERATION, "", nil, &ab.ReloadTLSCredentialsRequest{}, 0, 0)
+	_, err = s.ReloadTLSCredentials(ctx, env)
+	assert.Contains(t, err.Error(), "bad request")
+
+	_, err = s.ReloadTLSCredentials(ctx, requestWithTimestamp(nil, &ab.ReloadTLSCredentialsRequest{}))
+	assert.EqualError(t, err, "empty timestamp")
+
+	past := time.Now().Add(-time.Hour)
+	_, err = s.ReloadTLSCredentials(ctx, requestWithTimestamp(&timestamp.Timestamp{Seconds: past.Unix()}, &ab.ReloadTLSCredentialsRequest{}))
+	assert.Equal(t, accessDenied, err)
+
+	s.ace = &mockEvaluator{err: errors.New("not an admin")}
+	_, err = s.ReloadTLSCredentials(ctx, request(t, &ab.ReloadTLSCredentialsRequest{}))
+	assert.Equal(t, accessDenied, err)
+
+	assert.Equal(t, 0, reloader.reloaded)
+}
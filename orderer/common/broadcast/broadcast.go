@@ -8,6 +8,7 @@ package broadcast
 
 import (
 	"io"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
@@ -65,7 +66,8 @@ type Consenter interface {
 }
 
 type handlerImpl struct {
-	sm ChannelSupportRegistrar
+	sm    ChannelSupportRegistrar
+	dedup *deduplicator
 }
 
 // NewHandlerImpl constructs a new implementation of the Handler interface
@@ -75,6 +77,16 @@ func NewHandlerImpl(sm ChannelSupportRegistrar) Handler {
 	}
 }
 
+// NewDeduplicatingHandler constructs a new implementation of the Handler interface which
+// rejects transactions whose ID was already broadcast on the channel within the given window.
+// At most maxTransactions transaction IDs are remembered, the oldest ones are forgotten first.
+func NewDeduplicatingHandler(sm ChannelSupportRegistrar, window time.Duration, maxTransactions int) Handler {
+	return &handlerImpl{
+		sm:    sm,
+		dedup: newDeduplicator(window, maxTransactions),
+	}
+}
+
 // Handle starts a service thread for a given gRPC connection and services the broadcast connection
 func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 	addr := util.ExtractRemoteAddress(srv.Context())
@@ -100,46 +112,58 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()})
 		}
 
+		ctx := flogging.WithFields(srv.Context(), flogging.Fields{Channel: chdr.ChannelId, TxID: chdr.TxId})
+		txLogger := flogging.FromContext(ctx, logger)
+
 		if err = processor.WaitReady(); err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
+			txLogger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
 		}
 
 		if !isConfig {
-			logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
+			txLogger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
 			configSeq, err := processor.ProcessNormalMsg(msg)
 			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
+				txLogger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
 				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
 			}
 
+			dedup := bh.dedup != nil && chdr.TxId != ""
+			if dedup && !bh.dedup.add(chdr.ChannelId, chdr.TxId) {
+				txLogger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because txid '%s' was already broadcast", chdr.ChannelId, addr, chdr.TxId)
+				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: ErrDuplicateTransaction.Error()})
+			}
+
 			err = processor.Order(msg, configSeq)
 			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
+				if dedup {
+					bh.dedup.forget(chdr.ChannelId, chdr.TxId)
+				}
+				txLogger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
 				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
 			}
 		} else { // isConfig
-			logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
+			txLogger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
 
 			config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
 			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
+				txLogger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
 				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
 			}
 
 			err = processor.Configure(config, configSeq)
 			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
+				txLogger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
 				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
 			}
 		}
 
-		logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+		txLogger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
 
 		err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
 		if err != nil {
-			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
+			txLogger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return err
 		}
 	}
@@ -312,3 +312,39 @@ func TestMalformedHeader(t *testing.T) {
 		t.Fatalf("Should have terminated the stream")
 	}
 }
+
+func TestDuplicateTransaction(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo", TxId: "tx1"}
+	bh := NewDeduplicatingHandler(mm, time.Minute, 10)
+
+	broadcast := func() *ab.BroadcastResponse {
+		m := newMockB()
+		defer close(m.recvChan)
+		go bh.Handle(m)
+		m.recvChan <- nil
+		return <-m.sendChan
+	}
+
+	assert.Equal(t, cb.Status_SUCCESS, broadcast().Status)
+
+	reply := broadcast()
+	assert.Equal(t, cb.Status_BAD_REQUEST, reply.Status, "Should have rejected the replayed transaction")
+	assert.Equal(t, ErrDuplicateTransaction.Error(), reply.Info)
+
+	// The same transaction ID on another channel isn't a duplicate
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "bar", TxId: "tx1"}
+	assert.Equal(t, cb.Status_SUCCESS, broadcast().Status)
+
+	// A transaction which failed being ordered may be broadcast again
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo", TxId: "tx2"}
+	mm.MsgProcessorVal.rejectEnqueue = true
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, broadcast().Status)
+	mm.MsgProcessorVal.rejectEnqueue = false
+	assert.Equal(t, cb.Status_SUCCESS, broadcast().Status)
+
+	// Messages without a transaction ID aren't deduplicated
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo"}
+	assert.Equal(t, cb.Status_SUCCESS, broadcast().Status)
+	assert.Equal(t, cb.Status_SUCCESS, broadcast().Status)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateTransaction is returned when a transaction is broadcast again
+// within the deduplication window
+var ErrDuplicateTransaction = errors.New("duplicate transaction")
+
+type txKey struct {
+	channelID string
+	txID      string
+}
+
+type txEntry struct {
+	key      txKey
+	received time.Time
+}
+
+// deduplicator remembers the IDs of the transactions broadcast within a time window,
+// up to a maximum amount of transactions
+type deduplicator struct {
+	window          time.Duration
+	maxTransactions int
+	now             func() time.Time
+
+	lock sync.Mutex
+	txs  map[txKey]*list.Element
+	// order holds the txEntries by the time they were received, oldest first
+	order *list.List
+}
+
+func newDeduplicator(window time.Duration, maxTransactions int) *deduplicator {
+	return &deduplicator{
+		window:          window,
+		maxTransactions: maxTransactions,
+		now:             time.Now,
+		txs:             make(map[txKey]*list.Element),
+		order:           list.New(),
+	}
+}
+
+// add records the given transaction, and returns false if it was
+// already recorded within the deduplication window
+func (d *deduplicator) add(channelID, txID string) bool {
+	key := txKey{channelID: channelID, txID: txID}
+	now := d.now()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.expire(now)
+	if _, exists := d.txs[key]; exists {
+		reportDuplicate(channelID)
+		return false
+	}
+	if d.maxTransactions > 0 && d.order.Len() >= d.maxTransactions {
+		d.remove(d.order.Front())
+	}
+	d.txs[key] = d.order.PushBack(&txEntry{key: key, received: now})
+	return true
+}
+
+// forget removes the given transaction, so that it may be broadcast again
+func (d *deduplicator) forget(channelID, txID string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if e, exists := d.txs[txKey{channelID: channelID, txID: txID}]; exists {
+		d.remove(e)
+	}
+}
+
+// expire removes the transactions received before the deduplication window
+func (d *deduplicator) expire(now time.Time) {
+	for e := d.order.Front(); e != nil; e = d.order.Front() {
+		if now.Sub(e.Value.(*txEntry).received) < d.window {
+			return
+		}
+		d.remove(e)
+	}
+}
+
+func (d *deduplicator) remove(e *list.Element) {
+	delete(d.txs, d.order.Remove(e).(*txEntry).key)
+}
+
+// reportDuplicate counts the duplicate transactions dropped of the
+// given channel, if metrics are initialized
+func reportDuplicate(channelID string) {
+	if metrics.RootScope == nil {
+		return
+	}
+	metrics.RootScope.SubScope("broadcast").Tagged(map[string]string{"channel": channelID}).
+		Counter("duplicates_dropped").Inc(1)
+}
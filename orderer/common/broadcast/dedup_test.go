@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicator(t *testing.T) {
+	now := time.Now()
+	d := newDeduplicator(time.Minute, 3)
+	d.now = func() time.Time { return now }
+
+	assert.True(t, d.add("foo", "tx1"))
+	assert.False(t, d.add("foo", "tx1"), "Should have detected the duplicate")
+	assert.True(t, d.add("bar", "tx1"))
+
+	// Transactions are forgotten once the window elapses
+	now = now.Add(30 * time.Second)
+	assert.True(t, d.add("foo", "tx2"))
+	now = now.Add(30 * time.Second)
+	assert.True(t, d.add("foo", "tx1"), "Should have forgotten the expired transaction")
+	assert.False(t, d.add("foo", "tx2"))
+	assert.Equal(t, 2, d.order.Len())
+	assert.Len(t, d.txs, 2)
+
+	// The oldest transactions are forgotten once the cache is full
+	assert.True(t, d.add("foo", "tx3"))
+	assert.True(t, d.add("foo", "tx4"))
+	assert.Equal(t, 3, d.order.Len())
+	assert.True(t, d.add("foo", "tx2"), "Should have evicted the oldest transaction")
+	assert.False(t, d.add("foo", "tx4"))
+
+	// Forgotten transactions may be added again
+	d.forget("foo", "tx4")
+	d.forget("foo", "tx5")
+	assert.True(t, d.add("foo", "tx4"))
+	assert.Equal(t, len(d.txs), d.order.Len())
+}
@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+const pkgLogID = "orderer/common/channelparticipation"
+
+var logger = flogging.MustGetLogger(pkgLogID)
+
+var accessDenied = errors.New("access denied")
+
+// Registrar joins, removes and lists the channels the orderer participates in
+type Registrar interface {
+	// JoinChannel creates the channel of the given genesis block and starts servicing it
+	JoinChannel(configBlock *cb.Block) (*ab.ChannelInfo, error)
+
+	// RemoveChannel stops servicing the given channel and removes its ledger
+	RemoveChannel(channelID string) error
+
+	// ChannelList returns the channels the orderer participates in
+	ChannelList() *ab.ChannelList
+}
+
+// AccessControlEvaluator evaluates whether the creator of the given SignedData
+// is eligible of using the channel participation service
+type AccessControlEvaluator interface {
+	// Evaluate evaluates the eligibility of the creator of the given SignedData
+	// for being serviced by the channel participation service
+	Evaluate(signatureSet []*cb.SignedData) error
+}
+
+// Service implements the channel participation service of the orderer
+type Service struct {
+	registrar  Registrar
+	ace        AccessControlEvaluator
+	timeWindow time.Duration
+}
+
+// NewService creates a channel participation service which authorizes requests
+// using the given AccessControlEvaluator, and rejects requests whose timestamp
+// is not within the given time window of the orderer's time
+func NewService(registrar Registrar, ace AccessControlEvaluator, timeWindow time.Duration) *Service {
+	return &Service{
+		registrar:  registrar,
+		ace:        ace,
+		timeWindow: timeWindow,
+	}
+}
+
+// Join makes the orderer join the channel of the config block of the given JoinChannelRequest
+func (s *Service) Join(ctx context.Context, env *cb.Envelope) (*ab.ChannelInfo, error) {
+	req := &ab.JoinChannelRequest{}
+	if err := s.validate(ctx, env, req); err != nil {
+		return nil, err
+	}
+	if req.ConfigBlock == nil {
+		return nil, errors.New("bad request: missing config block")
+	}
+	info, err := s.registrar.JoinChannel(req.ConfigBlock)
+	if err != nil {
+		logger.Warningf("Failed joining channel: %v", err)
+		return nil, err
+	}
+	logger.Infof("Joined channel %s", info.Name)
+	return info, nil
+}
+
+// Remove makes the orderer leave the channel of the given RemoveChannelRequest
+func (s *Service) Remove(ctx context.Context, env *cb.Envelope) (*empty.Empty, error) {
+	req := &ab.RemoveChannelRequest{}
+	if err := s.validate(ctx, env, req); err != nil {
+		return nil, err
+	}
+	if req.ChannelId == "" {
+		return nil, errors.New("bad request: missing channel ID")
+	}
+	if err := s.registrar.RemoveChannel(req.ChannelId); err != nil {
+		logger.Warningf("Failed removing channel %s: %v", req.ChannelId, err)
+		return nil, err
+	}
+	logger.Infof("Removed channel %s", req.ChannelId)
+	return &empty.Empty{}, nil
+}
+
+// List returns the channels the orderer participates in
+func (s *Service) List(ctx context.Context, env *cb.Envelope) (*ab.ChannelList, error) {
+	if err := s.validate(ctx, env, &ab.ListChannelsRequest{}); err != nil {
+		return nil, err
+	}
+	return s.registrar.ChannelList(), nil
+}
+
+// validate unmarshals the request in the given envelope into msg, and checks
+// that the request is recent and that its creator is authorized
+func (s *Service) validate(ctx context.Context, env *cb.Envelope, msg proto.Message) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if env == nil {
+		return errors.New("nil envelope")
+	}
+	addr := util.ExtractRemoteAddress(ctx)
+	ch, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_ORDERER_ADMIN_OPERATION, msg)
+	if err != nil {
+		logger.Warningf("Request from %s is badly formed: %+v", addr, err)
+		return errors.Wrap(err, "bad request")
+	}
+
+	if ch.Timestamp == nil {
+		logger.Warningf("Request from %s has no timestamp", addr)
+		return errors.New("empty timestamp")
+	}
+	ts := ch.Timestamp
+	reqTs := time.Unix(ts.Seconds, int64(ts.Nanos))
+	now := time.Now()
+	if reqTs.Add(s.timeWindow).Before(now) || reqTs.Add(-s.timeWindow).After(now) {
+		logger.Warningf("Request from %s unauthorized due to incorrect time: %s", addr, reqTs.String())
+		return accessDenied
+	}
+
+	sd, err := env.AsSignedData()
+	if err != nil {
+		return errors.Errorf("bad request, cannot extract signed data: %v", err)
+	}
+	if err := s.ace.Evaluate(sd); err != nil {
+		logger.Warningf("Request from %s unauthorized due to authentication: %v", addr, err)
+		return accessDenied
+	}
+	return nil
+}
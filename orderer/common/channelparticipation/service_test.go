@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type mockEvaluator struct {
+	err error
+}
+
+func (e *mockEvaluator) Evaluate(signatureSet []*cb.SignedData) error {
+	return e.err
+}
+
+type mockRegistrar struct {
+	joined  *cb.Block
+	removed string
+	err     error
+}
+
+func (r *mockRegistrar) JoinChannel(configBlock *cb.Block) (*ab.ChannelInfo, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.joined = configBlock
+	return &ab.ChannelInfo{Name: "foo", Height: 1}, nil
+}
+
+func (r *mockRegistrar) RemoveChannel(channelID string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.removed = channelID
+	return nil
+}
+
+func (r *mockRegistrar) ChannelList() *ab.ChannelList {
+	return &ab.ChannelList{Channels: []*ab.ChannelInfo{{Name: "foo", Height: 1}}}
+}
+
+func request(t *testing.T, msg proto.Message) *cb.Envelope {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ORDERER_ADMIN_OPERATION, "", nil, msg, 0, 0)
+	assert.NoError(t, err)
+	return env
+}
+
+func requestWithTimestamp(ts *timestamp.Timestamp, msg proto.Message) *cb.Envelope {
+	ch := &cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_ORDERER_ADMIN_OPERATION),
+		Timestamp: ts,
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(ch)},
+			Data:   utils.MarshalOrPanic(msg),
+		}),
+	}
+}
+
+func TestJoin(t *testing.T) {
+	registrar := &mockRegistrar{}
+	s := NewService(registrar, &mockEvaluator{}, time.Minute)
+	block := cb.NewBlock(0, nil)
+
+	info, err := s.Join(context.Background(), request(t, &ab.JoinChannelRequest{ConfigBlock: block}))
+	assert.NoError(t, err)
+	assert.Equal(t, &ab.ChannelInfo{Name: "foo", Height: 1}, info)
+	assert.True(t, proto.Equal(block, registrar.joined))
+
+	_, err = s.Join(context.Background(), request(t, &ab.JoinChannelRequest{}))
+	assert.EqualError(t, err, "bad request: missing config block")
+
+	registrar.err = errors.New("channel already exists")
+	_, err = s.Join(context.Background(), request(t, &ab.JoinChannelRequest{ConfigBlock: block}))
+	assert.EqualError(t, err, "channel already exists")
+}
+
+func TestRemove(t *testing.T) {
+	registrar := &mockRegistrar{}
+	s := NewService(registrar, &mockEvaluator{}, time.Minute)
+
+	resp, err := s.Remove(context.Background(), request(t, &ab.RemoveChannelRequest{ChannelId: "foo"}))
+	assert.NoError(t, err)
+	assert.Equal(t, &empty.Empty{}, resp)
+	assert.Equal(t, "foo", registrar.removed)
+
+	_, err = s.Remove(context.Background(), request(t, &ab.RemoveChannelRequest{}))
+	assert.EqualError(t, err, "bad request: missing channel ID")
+
+	registrar.err = errors.New("channel does not exist")
+	_, err = s.Remove(context.Background(), request(t, &ab.RemoveChannelRequest{ChannelId: "bar"}))
+	assert.EqualError(t, err, "channel does not exist")
+}
+
+func TestList(t *testing.T) {
+	s := NewService(&mockRegistrar{}, &mockEvaluator{}, time.Minute)
+
+	list, err := s.List(context.Background(), request(t, &ab.ListChannelsRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &ab.ChannelList{Channels: []*ab.ChannelInfo{{Name: "foo", Height: 1}}}, list)
+}
+
+func TestValidate(t *testing.T) {
+	s := NewService(&mockRegistrar{}, &mockEvaluator{}, time.Minute)
+	ctx := context.Background()
+
+	_, err := s.List(nil, request(t, &ab.ListChannelsRequest{}))
+	assert.EqualError(t, err, "nil context")
+
+	_, err = s.List(ctx, nil)
+	assert.EqualError(t, err, "nil envelope")
+
+	_, err = s.List(ctx, &cb.Envelope{})
+	assert.Contains(t, err.Error(), "bad request")
+
+	env, _ := utils.CreateSignedEnvelope(cb.HeaderType_PEER_ADMIN_OPERATION, "", nil, &ab.ListChannelsRequest{}, 0, 0)
+	_, err = s.List(ctx, env)
+	assert.Contains(t, err.Error(), "bad request")
+
+	_, err = s.List(ctx, requestWithTimestamp(nil, &ab.ListChannelsRequest{}))
+	assert.EqualError(t, err, "empty timestamp")
+
+	past := time.Now().Add(-time.Hour)
+	_, err = s.List(ctx, requestWithTimestamp(&timestamp.Timestamp{Seconds: past.Unix()}, &ab.ListChannelsRequest{}))
+	assert.Equal(t, accessDenied, err)
+
+	future := time.Now().Add(time.Hour)
+	_, err = s.List(ctx, requestWithTimestamp(&timestamp.Timestamp{Seconds: future.Unix()}, &ab.ListChannelsRequest{}))
+	assert.Equal(t, accessDenied, err)
+
+	s.ace = &mockEvaluator{err: errors.New("not an admin")}
+	_, err = s.List(ctx, request(t, &ab.ListChannelsRequest{}))
+	assert.Equal(t, accessDenied, err)
+}
@@ -34,6 +34,7 @@ type TopLevel struct {
 	RAMLedger  RAMLedger
 	Kafka      Kafka
 	Debug      Debug
+	Metrics    Metrics
 }
 
 // General contains config which should be common among all orderer types.
@@ -43,6 +44,7 @@ type General struct {
 	ListenPort     uint16
 	TLS            TLS
 	Keepalive      Keepalive
+	Limits         Limits
 	GenesisMethod  string
 	GenesisProfile string
 	SystemChannel  string
@@ -54,6 +56,9 @@ type General struct {
 	LocalMSPID     string
 	BCCSP          *bccsp.FactoryOpts
 	Authentication Authentication
+	Deduplication  Deduplication
+
+	ChannelParticipation ChannelParticipation
 }
 
 // Keepalive contains configuration for gRPC servers.
@@ -63,6 +68,15 @@ type Keepalive struct {
 	ServerTimeout     time.Duration
 }
 
+// Limits contains configuration for the limits the gRPC server enforces
+// on the connections and requests of its clients.
+type Limits struct {
+	MaxConcurrentStreams    uint32
+	MaxConnectionsPerClient int
+	MaxStreamsPerClient     int
+	DeniedClients           []string
+}
+
 // TLS contains configuration for TLS connections.
 type TLS struct {
 	Enabled            bool
@@ -71,6 +85,9 @@ type TLS struct {
 	RootCAs            []string
 	ClientAuthRequired bool
 	ClientRootCAs      []string
+	// WatchInterval is the interval the files of the TLS credentials of the
+	// GRPC server are checked for changes at, zero disables checking them
+	WatchInterval time.Duration
 }
 
 // Authentication contains configuration parameters related to authenticating
@@ -79,6 +96,19 @@ type Authentication struct {
 	TimeWindow time.Duration
 }
 
+// Deduplication contains configuration for dropping transactions which
+// are broadcast again within a time window. A zero window disables it.
+type Deduplication struct {
+	Window          time.Duration
+	MaxTransactions int
+}
+
+// ChannelParticipation contains configuration for the channel participation
+// service, which orderer administrators join and remove channels with.
+type ChannelParticipation struct {
+	Enabled bool
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -153,6 +183,48 @@ type Debug struct {
 	DeliverTraceDir   string
 }
 
+// Metrics contains configuration for reporting the orderer's metrics.
+type Metrics struct {
+	Enabled        bool
+	Reporter       string
+	Interval       time.Duration
+	StatsdReporter StatsdReporter
+	PromReporter   PromReporter
+	OTLPReporter   OTLPReporter
+}
+
+// StatsdReporter contains configuration for pushing metrics to a statsd server.
+type StatsdReporter struct {
+	Address       string
+	FlushInterval time.Duration
+	FlushBytes    int
+}
+
+// PromReporter contains configuration for serving metrics to Prometheus.
+type PromReporter struct {
+	ListenAddress string
+	TLS           PromReporterTLS
+}
+
+// OTLPReporter contains configuration for pushing metrics to an
+// OpenTelemetry collector over OTLP/HTTP.
+type OTLPReporter struct {
+	Endpoint    string
+	Timeout     time.Duration
+	ServiceName string
+}
+
+// PromReporterTLS contains configuration for mutual TLS on the HTTP server
+// metrics are served from, along with the organizational units client
+// certificates must carry to access each endpoint.
+type PromReporterTLS struct {
+	Enabled       bool
+	PrivateKey    string
+	Certificate   string
+	ClientRootCAs []string
+	Authorization map[string][]string
+}
+
 // Defaults carries the default orderer configuration values.
 var Defaults = TopLevel{
 	General: General{
@@ -175,6 +247,10 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		Deduplication: Deduplication{
+			Window:          0,
+			MaxTransactions: 100000,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -216,6 +292,29 @@ var Defaults = TopLevel{
 		BroadcastTraceDir: "",
 		DeliverTraceDir:   "",
 	},
+	Metrics: Metrics{
+		Enabled:  false,
+		Reporter: "statsd",
+		Interval: time.Second,
+		StatsdReporter: StatsdReporter{
+			Address:       "0.0.0.0:8125",
+			FlushInterval: 2 * time.Second,
+			FlushBytes:    1432,
+		},
+		PromReporter: PromReporter{
+			ListenAddress: "0.0.0.0:8080",
+			TLS: PromReporterTLS{
+				PrivateKey:    "tls/server.key",
+				Certificate:   "tls/server.crt",
+				ClientRootCAs: []string{"tls/ca.crt"},
+			},
+		},
+		OTLPReporter: OTLPReporter{
+			Endpoint:    "http://localhost:4318",
+			Timeout:     10 * time.Second,
+			ServiceName: "orderer",
+		},
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -250,6 +349,11 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		if c.Metrics.PromReporter.TLS.Enabled {
+			c.Metrics.PromReporter.TLS.ClientRootCAs = translateCAs(configDir, c.Metrics.PromReporter.TLS.ClientRootCAs)
+			coreconfig.TranslatePathInPlace(configDir, &c.Metrics.PromReporter.TLS.PrivateKey)
+			coreconfig.TranslatePathInPlace(configDir, &c.Metrics.PromReporter.TLS.Certificate)
+		}
 	}()
 
 	for {
@@ -303,6 +407,23 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.General.Deduplication.Window > 0 && c.General.Deduplication.MaxTransactions == 0:
+			logger.Infof("General.Deduplication.MaxTransactions unset, setting to %d", Defaults.General.Deduplication.MaxTransactions)
+			c.General.Deduplication.MaxTransactions = Defaults.General.Deduplication.MaxTransactions
+
+		case c.Metrics.Enabled && c.Metrics.Reporter == "":
+			logger.Infof("Metrics.Reporter unset, setting to %s", Defaults.Metrics.Reporter)
+			c.Metrics.Reporter = Defaults.Metrics.Reporter
+		case c.Metrics.Enabled && c.Metrics.Interval == 0:
+			logger.Infof("Metrics.Interval unset, setting to %v", Defaults.Metrics.Interval)
+			c.Metrics.Interval = Defaults.Metrics.Interval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushInterval == 0:
+			logger.Infof("Metrics.StatsdReporter.FlushInterval unset, setting to %v", Defaults.Metrics.StatsdReporter.FlushInterval)
+			c.Metrics.StatsdReporter.FlushInterval = Defaults.Metrics.StatsdReporter.FlushInterval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushBytes == 0:
+			logger.Infof("Metrics.StatsdReporter.FlushBytes unset, setting to %d", Defaults.Metrics.StatsdReporter.FlushBytes)
+			c.Metrics.StatsdReporter.FlushBytes = Defaults.Metrics.StatsdReporter.FlushBytes
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
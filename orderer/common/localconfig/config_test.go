@@ -114,3 +114,40 @@ func TestSystemChannel(t *testing.T) {
 	assert.Equal(t, Defaults.General.SystemChannel, conf.General.SystemChannel,
 		"Expected default system channel ID to be '%s', got '%s' instead", Defaults.General.SystemChannel, conf.General.SystemChannel)
 }
+
+func TestDeduplicationConfig(t *testing.T) {
+	uconf := &TopLevel{}
+	uconf.completeInitialization("/dummy/path")
+	assert.Equal(t, Deduplication{}, uconf.General.Deduplication, "Deduplication should be disabled by default")
+
+	uconf = &TopLevel{General: General{Deduplication: Deduplication{Window: time.Minute}}}
+	uconf.completeInitialization("/dummy/path")
+	assert.Equal(t, Defaults.General.Deduplication.MaxTransactions, uconf.General.Deduplication.MaxTransactions)
+
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), conf.General.Deduplication.Window)
+	assert.Equal(t, 100000, conf.General.Deduplication.MaxTransactions)
+	assert.Equal(t, Defaults.Metrics, conf.Metrics)
+}
+
+func TestPromReporterTLSConfig(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf, err := Load()
+	assert.NoError(t, err)
+	assert.False(t, conf.Metrics.PromReporter.TLS.Enabled)
+
+	os.Setenv("ORDERER_METRICS_PROMREPORTER_TLS_ENABLED", "true")
+	defer os.Unsetenv("ORDERER_METRICS_PROMREPORTER_TLS_ENABLED")
+	conf, err = Load()
+	assert.NoError(t, err)
+	tlsConf := conf.Metrics.PromReporter.TLS
+	assert.True(t, tlsConf.Enabled)
+	assert.True(t, filepath.IsAbs(tlsConf.PrivateKey))
+	assert.True(t, filepath.IsAbs(tlsConf.Certificate))
+	assert.Len(t, tlsConf.ClientRootCAs, 1)
+	assert.True(t, filepath.IsAbs(tlsConf.ClientRootCAs[0]))
+}
@@ -11,6 +11,8 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -34,6 +36,16 @@ const (
 	epoch      = 0
 )
 
+var (
+	// ErrChannelAlreadyExists is returned when joining a channel the orderer already participates in
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+	// ErrChannelNotExist is returned when removing a channel the orderer doesn't participate in
+	ErrChannelNotExist = errors.New("channel does not exist")
+	// ErrSystemChannelExists is returned when joining or removing a channel while the
+	// orderer has a system channel, which manages the channels of the orderer
+	ErrSystemChannelExists = errors.New("channels are managed by the system channel")
+)
+
 var logger *logging.Logger
 
 func init() {
@@ -96,6 +108,7 @@ type ledgerResources struct {
 
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
+	lock            sync.RWMutex
 	chains          map[string]*ChainSupport
 	consenters      map[string]consensus.Consenter
 	ledgerFactory   blockledger.Factory
@@ -187,7 +200,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		logger.Infof("No system channel found, channels are joined through the channel participation service")
 	}
 
 	return r
@@ -207,8 +220,13 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 		return nil, false, nil, fmt.Errorf("could not determine channel ID: %s", err)
 	}
 
+	r.lock.RLock()
 	cs, ok := r.chains[chdr.ChannelId]
+	r.lock.RUnlock()
 	if !ok {
+		if r.systemChannel == nil {
+			return nil, false, nil, fmt.Errorf("channel %s does not exist", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -224,42 +242,59 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 
 // GetChain retrieves the chain support for a chain (and whether it exists)
 func (r *Registrar) GetChain(chainID string) (*ChainSupport, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
 	cs, ok := r.chains[chainID]
 	return cs, ok
 }
 
-func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
+// newBundle creates the channelconfig bundle of the config in the given config transaction
+func newBundle(configTx *cb.Envelope) (*channelconfig.Bundle, error) {
 	payload, err := utils.UnmarshalPayload(configTx.Payload)
 	if err != nil {
-		logger.Panicf("Error umarshaling envelope to payload: %s", err)
+		return nil, errors.Wrap(err, "error umarshaling envelope to payload")
 	}
 
 	if payload.Header == nil {
-		logger.Panicf("Missing channel header: %s", err)
+		return nil, errors.New("missing channel header")
 	}
 
 	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 	if err != nil {
-		logger.Panicf("Error unmarshaling channel header: %s", err)
+		return nil, errors.Wrap(err, "error unmarshaling channel header")
 	}
 
 	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
 	if err != nil {
-		logger.Panicf("Error umarshaling config envelope from payload data: %s", err)
+		return nil, errors.Wrap(err, "error umarshaling config envelope from payload data")
 	}
 
 	bundle, err := channelconfig.NewBundle(chdr.ChannelId, configEnvelope.Config)
 	if err != nil {
-		logger.Panicf("Error creating channelconfig bundle: %s", err)
+		return nil, errors.Wrap(err, "error creating channelconfig bundle")
+	}
+	return bundle, nil
+}
+
+func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
+	bundle, err := newBundle(configTx)
+	if err != nil {
+		logger.Panicf("%s", err)
 	}
 
 	checkResourcesOrPanic(bundle)
 
-	ledger, err := r.ledgerFactory.GetOrCreate(chdr.ChannelId)
+	chainID := bundle.ConfigtxValidator().ChainID()
+	ledger, err := r.ledgerFactory.GetOrCreate(chainID)
 	if err != nil {
-		logger.Panicf("Error getting ledger for %s", chdr.ChannelId)
+		logger.Panicf("Error getting ledger for %s", chainID)
 	}
 
+	return r.newLedgerResourcesFromBundle(bundle, ledger)
+}
+
+func (r *Registrar) newLedgerResourcesFromBundle(bundle *channelconfig.Bundle, ledger blockledger.ReadWriter) *ledgerResources {
 	return &ledgerResources{
 		configResources: &configResources{
 			mutableResources: channelconfig.NewBundleSource(bundle, r.callbacks...),
@@ -272,6 +307,9 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	ledgerResources := r.newLedgerResources(configtx)
 	ledgerResources.Append(blockledger.CreateNextBlock(ledgerResources, []*cb.Envelope{configtx}))
 
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	// Copy the map to allow concurrent reads from broadcast/deliver while the new chainSupport is
 	newChains := make(map[string]*ChainSupport)
 	for key, value := range r.chains {
@@ -291,9 +329,123 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 
 // ChannelsCount returns the count of the current total number of channels.
 func (r *Registrar) ChannelsCount() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
 	return len(r.chains)
 }
 
+// JoinChannel creates the ledger of the channel the given genesis block belongs to,
+// and starts servicing the channel. Channels can only be joined by orderers
+// without a system channel.
+func (r *Registrar) JoinChannel(configBlock *cb.Block) (*ab.ChannelInfo, error) {
+	if r.systemChannelID != "" {
+		return nil, ErrSystemChannelExists
+	}
+	if configBlock == nil || configBlock.Header == nil {
+		return nil, errors.New("config block is empty")
+	}
+	if configBlock.Header.Number != 0 {
+		return nil, errors.Errorf("joining a channel requires its genesis block, got block %d", configBlock.Header.Number)
+	}
+	configTx, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed extracting the config transaction")
+	}
+	bundle, err := newBundle(configTx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := bundle.ConsortiumsConfig(); ok {
+		return nil, errors.New("the block is the genesis block of a system channel")
+	}
+	if err := checkResources(bundle); err != nil {
+		return nil, err
+	}
+	oc, _ := bundle.OrdererConfig()
+	if _, ok := r.consenters[oc.ConsensusType()]; !ok {
+		return nil, errors.Errorf("unsupported consensus type %s", oc.ConsensusType())
+	}
+	chainID := bundle.ConfigtxValidator().ChainID()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.chains[chainID]; ok {
+		return nil, ErrChannelAlreadyExists
+	}
+	ledger, err := r.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed creating the ledger of channel %s", chainID)
+	}
+	if err := ledger.Append(configBlock); err != nil {
+		return nil, errors.Wrapf(err, "failed appending the genesis block of channel %s", chainID)
+	}
+
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		newChains[key] = value
+	}
+
+	cs := newChainSupport(r, r.newLedgerResourcesFromBundle(bundle, ledger), r.consenters, r.signer)
+
+	logger.Infof("Joined and starting channel %s", chainID)
+
+	newChains[chainID] = cs
+	cs.start()
+
+	r.chains = newChains
+
+	return &ab.ChannelInfo{Name: chainID, Height: cs.Height()}, nil
+}
+
+// RemoveChannel stops servicing the given channel and removes its ledger.
+// Channels can only be removed by orderers without a system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	if r.systemChannelID != "" {
+		return ErrSystemChannelExists
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cs, ok := r.chains[channelID]
+	if !ok {
+		return ErrChannelNotExist
+	}
+	cs.Halt()
+
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+
+	logger.Infof("Removed channel %s", channelID)
+
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		return errors.Wrapf(err, "failed removing the ledger of channel %s", channelID)
+	}
+	return nil
+}
+
+// ChannelList returns the channels the orderer participates in, sorted by name
+func (r *Registrar) ChannelList() *ab.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := &ab.ChannelList{SystemChannel: r.systemChannelID}
+	for chainID, cs := range r.chains {
+		list.Channels = append(list.Channels, &ab.ChannelInfo{Name: chainID, Height: cs.Height()})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+	return list
+}
+
 // NewChannelConfig produces a new template channel configuration based on the system channel's current config.
 func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfig.Resources, error) {
 	return r.templator.NewChannelConfig(envConfigUpdate)
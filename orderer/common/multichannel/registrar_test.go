@@ -104,14 +104,110 @@ func TestGetConfigTxFailure(t *testing.T) {
 	assert.Panics(t, func() { getConfigTx(rl) }, "Should have panicked because of bad last config metadata")
 }
 
-// This test checks to make sure the orderer refuses to come up if it cannot find a system channel
+// This test checks that the orderer comes up without a system channel, and rejects
+// messages of channels it doesn't participate in
 func TestNoSystemChain(t *testing.T) {
 	lf := ramledger.New(10)
 
 	consenters := make(map[string]consensus.Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	assert.Panics(t, func() { NewRegistrar(lf, consenters, mockCrypto()) }, "Should have panicked when starting without a system chain")
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+	assert.Empty(t, manager.SystemChannelID())
+	assert.Equal(t, 0, manager.ChannelsCount())
+
+	_, _, _, err := manager.BroadcastChannelSupport(makeConfigTx("foo", 1))
+	assert.EqualError(t, err, "channel foo does not exist")
+}
+
+// appChannelGenesisBlock returns the genesis block of an application channel
+func appChannelGenesisBlock(chainID string) *cb.Block {
+	profile := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	profile.Consortiums = nil
+	return encoder.New(profile).GenesisBlockForChannel(chainID)
+}
+
+func TestJoinAndRemoveChannel(t *testing.T) {
+	lf := ramledger.New(10)
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+
+	// Joining channels
+	for _, chainID := range []string{"foo", "bar"} {
+		info, err := manager.JoinChannel(appChannelGenesisBlock(chainID))
+		assert.NoError(t, err)
+		assert.Equal(t, &ab.ChannelInfo{Name: chainID, Height: 1}, info)
+		_, ok := manager.GetChain(chainID)
+		assert.True(t, ok, "Should have gotten the joined chain")
+	}
+	assert.Equal(t, &ab.ChannelList{
+		Channels: []*ab.ChannelInfo{{Name: "bar", Height: 1}, {Name: "foo", Height: 1}},
+	}, manager.ChannelList())
+
+	// The joined channel is serviced
+	chainSupport, _ := manager.GetChain("foo")
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		chainSupport.Order(makeNormalTx("foo", i), 0)
+	}
+	rl, _ := lf.GetOrCreate("foo")
+	it, _ := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	select {
+	case <-it.ReadyChan():
+	case <-time.After(time.Second):
+		t.Fatalf("Block 1 not produced after timeout")
+	}
+	it.Close()
+
+	// Joining a channel twice
+	_, err := manager.JoinChannel(appChannelGenesisBlock("foo"))
+	assert.Equal(t, ErrChannelAlreadyExists, err)
+
+	// Joining with a block which isn't a genesis block
+	block := appChannelGenesisBlock("baz")
+	block.Header.Number = 3
+	_, err = manager.JoinChannel(block)
+	assert.EqualError(t, err, "joining a channel requires its genesis block, got block 3")
+
+	// Joining with a block which isn't a config block
+	_, err = manager.JoinChannel(cb.NewBlock(0, nil))
+	assert.Error(t, err)
+
+	// Joining a system channel
+	_, err = manager.JoinChannel(encoder.New(conf).GenesisBlockForChannel("baz"))
+	assert.EqualError(t, err, "the block is the genesis block of a system channel")
+	assert.Equal(t, 2, manager.ChannelsCount())
+
+	// Removing channels
+	assert.NoError(t, manager.RemoveChannel("foo"))
+	_, ok := manager.GetChain("foo")
+	assert.False(t, ok, "Should not have found the removed chain")
+	assert.Equal(t, []string{"bar"}, lf.ChainIDs())
+	assert.Equal(t, ErrChannelNotExist, manager.RemoveChannel("foo"))
+
+	// A removed channel can be joined again
+	info, err := manager.JoinChannel(appChannelGenesisBlock("foo"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), info.Height)
+}
+
+func TestJoinAndRemoveChannelWithSystemChannel(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+
+	_, err := manager.JoinChannel(appChannelGenesisBlock("foo"))
+	assert.Equal(t, ErrSystemChannelExists, err)
+	assert.Equal(t, ErrSystemChannelExists, manager.RemoveChannel(genesisconfig.TestChainID))
+	assert.Equal(t, &ab.ChannelList{
+		Channels:      []*ab.ChannelInfo{{Name: genesisconfig.TestChainID, Height: 1}},
+		SystemChannel: genesisconfig.TestChainID,
+	}, manager.ChannelList())
 }
 
 // This test checks to make sure that the orderer refuses to come up if there are multiple system channels
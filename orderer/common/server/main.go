@@ -13,15 +13,20 @@ import (
 	"os"
 	"time"
 
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/admin"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
@@ -37,6 +42,7 @@ import (
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/performance"
 	"github.com/op/go-logging"
+	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -81,6 +87,11 @@ func Main() {
 
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
+	// Initialize metrics before the broadcast and consensus services, so that they could report metrics.
+	// If metrics aren't enabled, the metrics reported are discarded.
+	initializeMetrics(conf)
+	defer metrics.Shutdown()
+
 	signer := localmsp.NewSigner()
 	serverConfig := initializeServerConfig(conf)
 	grpcServer := initializeGrpcServer(conf, serverConfig)
@@ -96,16 +107,25 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 			updateTrustedRoots(grpcServer, caSupport, bundle)
 		}
 	}
+	tlsReloader := initializeTLSReloader(conf, grpcServer, caSupport)
+	if tlsReloader != nil {
+		defer tlsReloader.Stop()
+	}
 
 	manager := initializeMultichannelRegistrar(conf, signer, tlsCallback)
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, signer, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	server := NewServer(manager, signer, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, &conf.General.Deduplication)
 
 	switch cmd {
 	case start.FullCommand(): // "start" command
 		logger.Infof("Starting %s", metadata.GetVersionInfo())
 		initializeProfilingService(conf)
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+		if conf.General.ChannelParticipation.Enabled {
+			logger.Info("Channel participation service is enabled")
+			ab.RegisterChannelParticipationServer(grpcServer.Server(), initializeChannelParticipationService(conf, manager))
+		}
+		ab.RegisterAdminServer(grpcServer.Server(), initializeAdminService(conf, tlsReloader))
 		logger.Info("Beginning to serve requests")
 		grpcServer.Start()
 	case benchmark.FullCommand(): // "benchmark" command
@@ -116,6 +136,140 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	}
 }
 
+// initializeMetrics initializes the metrics root scope and starts reporting metrics
+func initializeMetrics(conf *localconfig.TopLevel) {
+	opts := metrics.Opts{
+		Enabled:  conf.Metrics.Enabled,
+		Reporter: conf.Metrics.Reporter,
+		Interval: conf.Metrics.Interval,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conf.Metrics.StatsdReporter.Address,
+			FlushInterval: conf.Metrics.StatsdReporter.FlushInterval,
+			FlushBytes:    conf.Metrics.StatsdReporter.FlushBytes,
+		},
+		PromReporterOpts: metrics.PromReporterOpts{
+			ListenAddress: conf.Metrics.PromReporter.ListenAddress,
+			TLS: metrics.PromReporterTLSOpts{
+				Enabled:       conf.Metrics.PromReporter.TLS.Enabled,
+				CertFile:      conf.Metrics.PromReporter.TLS.Certificate,
+				KeyFile:       conf.Metrics.PromReporter.TLS.PrivateKey,
+				ClientRootCAs: conf.Metrics.PromReporter.TLS.ClientRootCAs,
+				Authorization: conf.Metrics.PromReporter.TLS.Authorization,
+			},
+		},
+		OTLPReporterOpts: metrics.OTLPReporterOpts{
+			Endpoint:    conf.Metrics.OTLPReporter.Endpoint,
+			Timeout:     conf.Metrics.OTLPReporter.Timeout,
+			ServiceName: conf.Metrics.OTLPReporter.ServiceName,
+		},
+	}
+	if err := metrics.Init(opts); err != nil {
+		logger.Panicf("Failed initializing metrics: %s", err)
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Error starting metrics server: %s", err)
+		}
+	}()
+}
+
+// initializeChannelParticipationService creates the channel participation service,
+// which services requests signed by admins of the local MSP
+func initializeChannelParticipationService(conf *localconfig.TopLevel, manager *multichannel.Registrar) *channelparticipation.Service {
+	return channelparticipation.NewService(manager, localAdminPolicy(conf), conf.General.Authentication.TimeWindow)
+}
+
+// localAdminPolicy returns the policy satisfied by the signatures of the admins of the local MSP
+func localAdminPolicy(conf *localconfig.TopLevel) policies.Policy {
+	pp := cauthdsl.NewPolicyProvider(mspmgmt.GetLocalMSP())
+	policy, _, err := pp.NewPolicy(utils.MarshalOrPanic(cauthdsl.SignedByAnyAdmin([]string{conf.General.LocalMSPID})))
+	if err != nil {
+		logger.Panicf("Failed creating the local admin policy: %+v", err)
+	}
+	return policy
+}
+
+// initializeAdminService creates the admin service, whose requests must be signed by an admin of the local MSP
+func initializeAdminService(conf *localconfig.TopLevel, tlsReloader *comm.CredentialsReloader) *admin.Service {
+	var reloader admin.TLSReloader
+	if tlsReloader != nil {
+		reloader = &adminTLSReloader{reloader: tlsReloader}
+	}
+	return admin.NewService(reloader, localAdminPolicy(conf), conf.General.Authentication.TimeWindow)
+}
+
+// adminTLSReloader exposes the reloading of the TLS credentials to the admin service
+type adminTLSReloader struct {
+	reloader *comm.CredentialsReloader
+}
+
+func (r *adminTLSReloader) ReloadTLSCredentials() ([]byte, int, error) {
+	opts, err := r.reloader.Reload()
+	if err != nil {
+		return nil, 0, err
+	}
+	return opts.Certificate, len(opts.ClientRootCAs), nil
+}
+
+// initializeTLSReloader creates the reloader of the TLS credentials of the
+// GRPC server, and starts watching their files if configured to.
+// It returns nil if TLS isn't enabled.
+func initializeTLSReloader(conf *localconfig.TopLevel, srv comm.TLSServer, rootCASupport *comm.CASupport) *comm.CredentialsReloader {
+	if !conf.General.TLS.Enabled {
+		return nil
+	}
+	files := []string{conf.General.TLS.Certificate, conf.General.TLS.PrivateKey}
+	if conf.General.TLS.ClientAuthRequired {
+		files = append(files, conf.General.TLS.ClientRootCAs...)
+	}
+	load := func() (*comm.SecureOptions, error) {
+		return loadTLSCredentials(conf, rootCASupport)
+	}
+	reloader := comm.NewCredentialsReloader(load, files, srv)
+	if conf.General.TLS.WatchInterval > 0 {
+		logger.Infof("Watching TLS credential files for changes every %s", conf.General.TLS.WatchInterval)
+		reloader.Watch(conf.General.TLS.WatchInterval)
+	}
+	return reloader
+}
+
+// loadTLSCredentials reads the TLS credentials of the GRPC server from their
+// files. The client root CAs read replace the statically configured ones, and
+// the client root CAs returned include those of the channels.
+func loadTLSCredentials(conf *localconfig.TopLevel, rootCASupport *comm.CASupport) (*comm.SecureOptions, error) {
+	certificate, err := ioutil.ReadFile(conf.General.TLS.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed loading server certificate")
+	}
+	key, err := ioutil.ReadFile(conf.General.TLS.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed loading private key")
+	}
+	secureOpts := &comm.SecureOptions{
+		UseTLS:            true,
+		RequireClientCert: conf.General.TLS.ClientAuthRequired,
+		Certificate:       certificate,
+		Key:               key,
+	}
+	if !secureOpts.RequireClientCert {
+		return secureOpts, nil
+	}
+
+	var clientRootCAs [][]byte
+	for _, clientRoot := range conf.General.TLS.ClientRootCAs {
+		root, err := ioutil.ReadFile(clientRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed loading client root CAs")
+		}
+		clientRootCAs = append(clientRootCAs, root)
+	}
+	rootCASupport.Lock()
+	defer rootCASupport.Unlock()
+	rootCASupport.ClientRootCAs = clientRootCAs
+	secureOpts.ClientRootCAs = trustedClientRoots(rootCASupport)
+	return secureOpts, nil
+}
+
 // Set the logging level
 func initializeLoggingLevel(conf *localconfig.TopLevel) {
 	flogging.InitBackend(flogging.SetFormat(conf.General.LogFormat), os.Stderr)
@@ -188,7 +342,14 @@ func initializeServerConfig(conf *localconfig.TopLevel) comm.ServerConfig {
 	kaOpts.ServerInterval = conf.General.Keepalive.ServerInterval
 	kaOpts.ServerTimeout = conf.General.Keepalive.ServerTimeout
 
-	return comm.ServerConfig{SecOpts: secureOpts, KaOpts: kaOpts}
+	limits := comm.ConnectionLimits{
+		MaxConcurrentStreams:    conf.General.Limits.MaxConcurrentStreams,
+		MaxConnectionsPerClient: conf.General.Limits.MaxConnectionsPerClient,
+		MaxStreamsPerClient:     conf.General.Limits.MaxStreamsPerClient,
+		DeniedClients:           conf.General.Limits.DeniedClients,
+	}
+
+	return comm.ServerConfig{SecOpts: secureOpts, KaOpts: kaOpts, Limits: limits}
 }
 
 func initializeBootstrapChannel(conf *localconfig.TopLevel, lf blockledger.Factory) {
@@ -200,6 +361,9 @@ func initializeBootstrapChannel(conf *localconfig.TopLevel, lf blockledger.Facto
 		genesisBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		genesisBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		logger.Info("Not bootstrapping a system channel, channels are joined through the channel participation service")
+		return
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -331,21 +495,8 @@ func updateTrustedRoots(srv *comm.GRPCServer, rootCASupport *comm.CASupport,
 		rootCASupport.AppRootCAsByChain[cid] = appRootCAs
 		rootCASupport.OrdererRootCAsByChain[cid] = ordererRootCAs
 
-		// now iterate over all roots for all app and orderer chains
-		trustedRoots := [][]byte{}
-		for _, roots := range rootCASupport.AppRootCAsByChain {
-			trustedRoots = append(trustedRoots, roots...)
-		}
-		for _, roots := range rootCASupport.OrdererRootCAsByChain {
-			trustedRoots = append(trustedRoots, roots...)
-		}
-		// also need to append statically configured root certs
-		if len(rootCASupport.ClientRootCAs) > 0 {
-			trustedRoots = append(trustedRoots, rootCASupport.ClientRootCAs...)
-		}
-
 		// now update the client roots for the gRPC server
-		err := srv.SetClientRootCAs(trustedRoots)
+		err := srv.SetClientRootCAs(trustedClientRoots(rootCASupport))
 		if err != nil {
 			msg := "Failed to update trusted roots for orderer from latest config " +
 				"block.  This orderer may not be able to communicate " +
@@ -355,6 +506,25 @@ func updateTrustedRoots(srv *comm.GRPCServer, rootCASupport *comm.CASupport,
 	}
 }
 
+// trustedClientRoots returns the root CAs of all app and orderer chains,
+// along with the statically configured root CAs.
+// The caller must hold the lock of the CASupport.
+func trustedClientRoots(rootCASupport *comm.CASupport) [][]byte {
+	// now iterate over all roots for all app and orderer chains
+	trustedRoots := [][]byte{}
+	for _, roots := range rootCASupport.AppRootCAsByChain {
+		trustedRoots = append(trustedRoots, roots...)
+	}
+	for _, roots := range rootCASupport.OrdererRootCAsByChain {
+		trustedRoots = append(trustedRoots, roots...)
+	}
+	// also need to append statically configured root certs
+	if len(rootCASupport.ClientRootCAs) > 0 {
+		trustedRoots = append(trustedRoots, rootCASupport.ClientRootCAs...)
+	}
+	return trustedRoots
+}
+
 func prettyPrintStruct(i interface{}) {
 	params := util.Flatten(i)
 	var buffer bytes.Buffer
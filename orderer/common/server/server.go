@@ -67,11 +67,22 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 	return rs.Send(response)
 }
 
+func (rs *responseSender) SendBlockBatchResponse(batch *cb.BlockBatch) error {
+	response := &ab.DeliverResponse{
+		Type: &ab.DeliverResponse_BlockBatch{BlockBatch: batch},
+	}
+	return rs.Send(response)
+}
+
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool, dedup *localconfig.Deduplication) ab.AtomicBroadcastServer {
+	bh := broadcast.NewHandlerImpl(broadcastSupport{Registrar: r})
+	if dedup != nil && dedup.Window > 0 {
+		bh = broadcast.NewDeduplicatingHandler(broadcastSupport{Registrar: r}, dedup.Window, dedup.MaxTransactions)
+	}
 	s := &server{
 		dh:        deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS),
-		bh:        broadcast.NewHandlerImpl(broadcastSupport{Registrar: r}),
+		bh:        bh,
 		debug:     debug,
 		Registrar: r,
 	}
@@ -18,7 +18,7 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list|simulatepolicy|events."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -41,6 +41,8 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
 	chaincodeCmd.AddCommand(listCmd(cf))
+	chaincodeCmd.AddCommand(simulatePolicyCmd(cf))
+	chaincodeCmd.AddCommand(eventsCmd(cf))
 
 	return chaincodeCmd
 }
@@ -68,6 +70,10 @@ var (
 	connectionProfile     string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
+	eventName             string
+	startBlock            string
+	endBlock              string
+	eventsTxID            string
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -120,9 +126,17 @@ func resetFlags() {
 	flags.StringVarP(&connectionProfile, "connectionProfile", "", common.UndefinedParamValue,
 		fmt.Sprint("Connection profile that provides the necessary connection information for the network. Note: currently only supported for providing peer connection information"))
 	flags.BoolVar(&waitForEvent, "waitForEvent", false,
-		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully, and print its validation code and block number. The command fails if a peer invalidates the transaction"))
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
 		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+	flags.StringVar(&eventName, "eventName", "",
+		fmt.Sprint("A regular expression the names of the queried chaincode events match, all the names if empty"))
+	flags.StringVar(&startBlock, "startBlock", "",
+		fmt.Sprint("The number of the first block the chaincode events are queried from (default the genesis block)"))
+	flags.StringVar(&endBlock, "endBlock", "",
+		fmt.Sprint("The number of the last block the chaincode events are queried from (default the last block)"))
+	flags.StringVar(&eventsTxID, "txID", "",
+		fmt.Sprint("The ID of the transaction the queried chaincode event was emitted by"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -10,8 +10,10 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
+	"os"
 	"strings"
 	"sync"
 
@@ -175,11 +177,12 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 }
 
 type collectionConfigJson struct {
-	Name          string `json:"name"`
-	Policy        string `json:"policy"`
-	RequiredCount int32  `json:"requiredPeerCount"`
-	MaxPeerCount  int32  `json:"maxPeerCount"`
-	BlockToLive   uint64 `json:"blockToLive"`
+	Name               string `json:"name"`
+	Policy             string `json:"policy"`
+	RequiredCount      int32  `json:"requiredPeerCount"`
+	MaxPeerCount       int32  `json:"maxPeerCount"`
+	BlockToLive        uint64 `json:"blockToLive"`
+	MemberAccessPolicy string `json:"memberAccessPolicy"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -217,14 +220,29 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		// the member access policy is optional
+		var mapc *pcommon.CollectionPolicyConfig
+		if cconfitem.MemberAccessPolicy != "" {
+			p, err := cauthdsl.FromString(cconfitem.MemberAccessPolicy)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid member access policy %s", cconfitem.MemberAccessPolicy))
+			}
+			mapc = &pcommon.CollectionPolicyConfig{
+				Payload: &pcommon.CollectionPolicyConfig_SignaturePolicy{
+					SignaturePolicy: p,
+				},
+			}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
-					Name:              cconfitem.Name,
-					MemberOrgsPolicy:  cpc,
-					RequiredPeerCount: cconfitem.RequiredCount,
-					MaximumPeerCount:  cconfitem.MaxPeerCount,
-					BlockToLive:       cconfitem.BlockToLive,
+					Name:               cconfitem.Name,
+					MemberOrgsPolicy:   cpc,
+					RequiredPeerCount:  cconfitem.RequiredCount,
+					MaximumPeerCount:   cconfitem.MaxPeerCount,
+					BlockToLive:        cconfitem.BlockToLive,
+					MemberAccessPolicy: mapc,
 				},
 			},
 		}
@@ -537,6 +555,10 @@ func ChaincodeInvokeOrQuery(
 				if err != nil {
 					return nil, err
 				}
+				dg.PrintCommitStatus(commitStatusOutput)
+				if err = dg.CheckCommitStatus(); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -567,8 +589,20 @@ type deliverClient struct {
 	Client     api.DeliverClient
 	Connection api.Deliver
 	Address    string
+	// Status is the commit status of the txid at the peer, once received
+	Status *commitStatus
 }
 
+// commitStatus is the outcome of the validation of a transaction by a peer
+type commitStatus struct {
+	BlockNumber    uint64
+	ValidationCode pb.TxValidationCode
+}
+
+// commitStatusOutput is where the commit status of invoked transactions is
+// printed to
+var commitStatusOutput io.Writer = os.Stdout
+
 func newDeliverGroup(deliverClients []api.DeliverClient, peerAddresses []string, certificate tls.Certificate, channelID string, txid string) *deliverGroup {
 	clients := make([]*deliverClient, len(deliverClients))
 	for i, client := range deliverClients {
@@ -576,6 +610,10 @@ func newDeliverGroup(deliverClients []api.DeliverClient, peerAddresses []string,
 			Client:  client,
 			Address: peerAddresses[i],
 		}
+		if dc.Address == "" {
+			// the peer was taken from the configuration
+			dc.Address = viper.GetString("peer.address")
+		}
 		clients[i] = dc
 	}
 
@@ -683,7 +721,11 @@ func (dg *deliverGroup) ClientWait(dc *deliverClient) {
 			filteredTransactions := r.FilteredBlock.FilteredTransactions
 			for _, tx := range filteredTransactions {
 				if tx.Txid == dg.TxID {
-					logger.Infof("txid [%s] committed with status (%s) at %s", dg.TxID, tx.TxValidationCode, dc.Address)
+					logger.Debugf("txid [%s] committed with status (%s) in block %d at %s", dg.TxID, tx.TxValidationCode, r.FilteredBlock.Number, dc.Address)
+					dc.Status = &commitStatus{
+						BlockNumber:    r.FilteredBlock.Number,
+						ValidationCode: tx.TxValidationCode,
+					}
 					return
 				}
 			}
@@ -695,6 +737,29 @@ func (dg *deliverGroup) ClientWait(dc *deliverClient) {
 	}
 }
 
+// PrintCommitStatus prints the validation code of the txid and the number of
+// the block it was committed in at each peer that received it
+func (dg *deliverGroup) PrintCommitStatus(w io.Writer) {
+	for _, dc := range dg.Clients {
+		if dc.Status == nil {
+			continue
+		}
+		fmt.Fprintf(w, "txid [%s] committed with status (%s) in block %d at %s\n",
+			dg.TxID, dc.Status.ValidationCode, dc.Status.BlockNumber, dc.Address)
+	}
+}
+
+// CheckCommitStatus returns an error if any of the peers invalidated the txid
+func (dg *deliverGroup) CheckCommitStatus() error {
+	for _, dc := range dg.Clients {
+		if dc.Status != nil && dc.Status.ValidationCode != pb.TxValidationCode_VALID {
+			return errors.Errorf("transaction %s was invalidated with status (%s) in block %d at %s",
+				dg.TxID, dc.Status.ValidationCode, dc.Status.BlockNumber, dc.Address)
+		}
+	}
+	return nil
+}
+
 // WaitForWG waits for the deliverGroup's wait group and closes
 // the channel when ready
 func (dg *deliverGroup) WaitForWG(readyCh chan struct{}) {
@@ -8,10 +8,12 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -189,6 +191,26 @@ const sampleCollectionConfigGood = `[
 	}
 ]`
 
+const sampleCollectionConfigMemberAccess = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"memberAccessPolicy": "OR('A.peer', 'B.peer')"
+	}
+]`
+
+const sampleCollectionConfigBadMemberAccess = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"memberAccessPolicy": "barf"
+	}
+]`
+
 const sampleCollectionConfigBad = `[
 	{
 		"name": "foo",
@@ -211,8 +233,22 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Equal(t, "foo", conf.Name)
 	assert.Equal(t, pol, conf.MemberOrgsPolicy.GetSignaturePolicy())
 	assert.Equal(t, 10, int(conf.BlockToLive))
+	assert.Nil(t, conf.MemberAccessPolicy)
 	t.Logf("conf=%s", conf)
 
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigMemberAccess))
+	assert.NoError(t, err)
+	ccp = &common2.CollectionConfigPackage{}
+	proto.Unmarshal(cc, ccp)
+	conf = ccp.Config[0].GetStaticCollectionConfig()
+	pol, _ = cauthdsl.FromString("OR('A.peer', 'B.peer')")
+	assert.Equal(t, pol, conf.MemberAccessPolicy.GetSignaturePolicy())
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBadMemberAccess))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid member access policy barf")
+	assert.Nil(t, cc)
+
 	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBad))
 	assert.Error(t, err)
 	assert.Nil(t, cc)
@@ -533,6 +569,46 @@ func TestDeliverGroupWait(t *testing.T) {
 	resetFlags()
 }
 
+func TestDeliverGroupCommitStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	validBlock := createFilteredBlock("txid0")
+	validBlock.Number = 5
+	invalidBlock := createFilteredBlock("txid0")
+	invalidBlock.Number = 6
+	invalidBlock.FilteredTransactions[0].TxValidationCode = pb.TxValidationCode_MVCC_READ_CONFLICT
+
+	mockConn := &mock.Deliver{}
+	mockConn.RecvReturns(&pb.DeliverResponse{Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: validBlock}}, nil)
+	mockConn2 := &mock.Deliver{}
+	mockConn2.RecvReturns(&pb.DeliverResponse{Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: invalidBlock}}, nil)
+	dg := deliverGroup{
+		Clients: []*deliverClient{
+			{Connection: mockConn, Address: "peer0"},
+			{Connection: mockConn2, Address: "peer1"},
+		},
+		ChannelID: "testchannel",
+		TxID:      "txid0",
+	}
+	err := dg.Wait(context.Background())
+	g.Expect(err).To(BeNil())
+	g.Expect(dg.Clients[0].Status).To(Equal(&commitStatus{BlockNumber: 5, ValidationCode: pb.TxValidationCode_VALID}))
+	g.Expect(dg.Clients[1].Status).To(Equal(&commitStatus{BlockNumber: 6, ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT}))
+
+	buf := &bytes.Buffer{}
+	dg.PrintCommitStatus(buf)
+	g.Expect(buf.String()).To(Equal(
+		"txid [txid0] committed with status (VALID) in block 5 at peer0\n" +
+			"txid [txid0] committed with status (MVCC_READ_CONFLICT) in block 6 at peer1\n"))
+
+	err = dg.CheckCommitStatus()
+	g.Expect(err).To(MatchError("transaction txid0 was invalidated with status (MVCC_READ_CONFLICT) in block 6 at peer1"))
+
+	// the transaction is valid at all the peers
+	dg.Clients = dg.Clients[:1]
+	g.Expect(dg.CheckCommitStatus()).To(Succeed())
+}
+
 func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 	// success - deliver client returns event with expected txid
 	InitMSP()
@@ -621,6 +697,30 @@ func TestChaincodeInvokeOrQuery_waitForEvent(t *testing.T) {
 	assert.Contains(t, err.Error(), "timed out")
 	close(delayChan)
 
+	// failure - one of the peers invalidates the transaction
+	waitForEventTimeout = time.Second
+	invalidBlock := createFilteredBlock("txid0")
+	invalidBlock.FilteredTransactions[0].TxValidationCode = pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+	mockDCInvalid := getMockDeliverClientRespondsWithFilteredBlocks([]*pb.FilteredBlock{invalidBlock})
+	mockDeliverClients = []api.DeliverClient{getMockDeliverClient(), mockDCInvalid}
+	buf := &bytes.Buffer{}
+	commitStatusOutput = buf
+
+	_, err = ChaincodeInvokeOrQuery(
+		&pb.ChaincodeSpec{},
+		channelID,
+		txID,
+		true,
+		mockCF.Signer,
+		mockCF.Certificate,
+		mockCF.EndorserClients,
+		mockDeliverClients,
+		mockCF.BroadcastClient,
+	)
+	assert.EqualError(t, err, "transaction txid0 was invalidated with status (ENDORSEMENT_POLICY_FAILURE) in block 0 at peer1")
+	assert.Contains(t, buf.String(), "txid [txid0] committed with status (VALID) in block 0 at peer0\n")
+
 	// clean-up
+	commitStatusOutput = os.Stdout
 	resetFlags()
 }
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const eventsCmdName = "events"
+
+var chaincodeEventsCmd *cobra.Command
+
+// eventsCmd returns the cobra command for querying the historical events of a chaincode
+func eventsCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeEventsCmd = &cobra.Command{
+		Use:   eventsCmdName,
+		Short: "Query the historical events of a chaincode.",
+		Long:  "Query the events emitted by a chaincode on a channel, as indexed by the ledger of the peer, optionally by event name pattern, block range and transaction ID",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeEvents(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"eventName",
+		"startBlock",
+		"endBlock",
+		"txID",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeEventsCmd, flagList)
+
+	return chaincodeEventsCmd
+}
+
+func chaincodeEvents(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	records, err := queryChaincodeEvents(cf)
+	if err != nil {
+		return err
+	}
+	for _, record := range records.Records {
+		jsonBytes, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonBytes))
+	}
+	return nil
+}
+
+func queryChaincodeEvents(cf *ChaincodeCmdFactory) (*pb.ChaincodeEventRecords, error) {
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input: &pb.ChaincodeInput{Args: [][]byte{
+				[]byte(qscc.GetChaincodeEvents),
+				[]byte(channelID),
+				[]byte(chaincodeName),
+				[]byte(eventName),
+				[]byte(startBlock),
+				[]byte(endBlock),
+				[]byte(eventsTxID),
+			}},
+		},
+	}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error serializing identity for %s", cf.Signer.GetIdentifier()))
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, creator)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+	signedProp, err := utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	// the events are queried from a single peer
+	proposalResp, err := cf.EndorserClients[0].ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+	if proposalResp.Response == nil {
+		return nil, errors.New("proposal response had nil 'response'")
+	}
+	if proposalResp.Response.Status != int32(cb.Status_SUCCESS) {
+		return nil, errors.Errorf("bad response: %d - %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	records := &pb.ChaincodeEventRecords{}
+	if err := proto.Unmarshal(proposalResp.Response.Payload, records); err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+	return records, nil
+}
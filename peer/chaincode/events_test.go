@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaincodeEventsCmd(t *testing.T) {
+	InitMSP()
+	resetFlags()
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	records := &pb.ChaincodeEventRecords{
+		Records: []*pb.ChaincodeEventRecord{
+			{BlockNumber: 3, TxNumber: 1, Event: &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx1", EventName: "transfer"}},
+		},
+	}
+	recordsBytes, err := proto.Marshal(records)
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200, Payload: recordsBytes},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+	}
+
+	cmd := eventsCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc", "--eventName", "trans.*", "--startBlock", "2"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "trans.*", eventName)
+	assert.Equal(t, "2", startBlock)
+
+	received, err := queryChaincodeEvents(mockCF)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(records, received))
+}
+
+func TestChaincodeEventsCmdFailures(t *testing.T) {
+	InitMSP()
+	resetFlags()
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	// reset channelID, it might have been set by previous test
+	channelID = ""
+	cmd := eventsCmd(&ChaincodeCmdFactory{Signer: signer})
+	cmd.SetArgs([]string{"-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	cmd = eventsCmd(&ChaincodeCmdFactory{Signer: signer})
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "must supply value for chaincode name parameter")
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "chaincode event index is not enabled"},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+	}
+	cmd = eventsCmd(mockCF)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "bad response: 500 - chaincode event index is not enabled")
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+const simulatePolicyCmdName = "simulatepolicy"
+
+var chaincodeSimulatePolicyCmd *cobra.Command
+
+// simulatePolicyCmd returns the cobra command for simulating an endorsement policy
+func simulatePolicyCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeSimulatePolicyCmd = &cobra.Command{
+		Use:   simulatePolicyCmdName,
+		Short: "Simulate an endorsement policy against the peers of a channel.",
+		Long:  "Report whether an endorsement policy can be satisfied by the peers of a channel, which organizations it requires, and minimal sets of peers that satisfy it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return simulatePolicy(cmd)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"policy",
+		"peerAddresses",
+		"tlsRootCertFiles",
+	}
+	attachFlags(chaincodeSimulatePolicyCmd, flagList)
+
+	return chaincodeSimulatePolicyCmd
+}
+
+func simulatePolicy(cmd *cobra.Command) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if policy == common.UndefinedParamValue {
+		return errors.New("The required parameter 'policy' is empty. Rerun the command with -P flag")
+	}
+	p, err := cauthdsl.FromString(policy)
+	if err != nil {
+		return errors.Errorf("invalid policy %s", policy)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var address, tlsRootCertFile string
+	if len(peerAddresses) > 0 {
+		address = peerAddresses[0]
+	}
+	if len(tlsRootCertFiles) > 0 {
+		tlsRootCertFile = tlsRootCertFiles[0]
+	}
+	cl, auth, err := common.GetDiscoveryClient(address, tlsRootCertFile)
+	if err != nil {
+		return err
+	}
+
+	req := discovery.NewRequest().OfChannel(channelID).AddPolicySimulationQuery(p)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := cl.Send(ctx, req, auth)
+	if err != nil {
+		return errors.WithMessage(err, "failed sending policy simulation query")
+	}
+	res, err := resp.ForChannel(channelID).PolicySimulation()
+	if err != nil {
+		return errors.WithMessage(err, "failed simulating policy")
+	}
+	printPolicySimulation(os.Stdout, res)
+	return nil
+}
+
+func printPolicySimulation(w io.Writer, res *discovery.PolicySimulation) {
+	fmt.Fprintf(w, "Satisfiable: %t\n", res.Satisfiable)
+	fmt.Fprintf(w, "Required organizations: %s\n", strings.Join(res.RequiredOrgs, ", "))
+	if !res.Satisfiable {
+		return
+	}
+	fmt.Fprintln(w, "Minimal peer sets:")
+	for i, peers := range res.MinimalPeerSets {
+		var endpoints []string
+		for _, p := range peers {
+			endpoint := p.AliveMessage.GetAliveMsg().GetMembership().GetEndpoint()
+			endpoints = append(endpoints, fmt.Sprintf("%s (%s)", endpoint, p.MSPID))
+		}
+		fmt.Fprintf(w, "%d. %s\n", i+1, strings.Join(endpoints, ", "))
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"testing"
+
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatePolicyCmdBadArgs(t *testing.T) {
+	defer resetFlags()
+
+	// reset channelID and policy, they might have been set by previous tests
+	channelID = ""
+	policy = ""
+
+	cmd := simulatePolicyCmd(nil)
+	cmd.SetArgs([]string{"-P", "OR('Org1MSP.member')"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	resetFlags()
+	policy = ""
+	cmd = simulatePolicyCmd(nil)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "The required parameter 'policy' is empty. Rerun the command with -P flag")
+
+	resetFlags()
+	cmd = simulatePolicyCmd(nil)
+	cmd.SetArgs([]string{"-C", "mychannel", "-P", "OR('Org1MSP.member'"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "invalid policy OR('Org1MSP.member'")
+}
+
+func TestPrintPolicySimulation(t *testing.T) {
+	peer := func(endpoint, mspID string) *discovery.Peer {
+		msg := &gossip.GossipMessage{
+			Content: &gossip.GossipMessage_AliveMsg{
+				AliveMsg: &gossip.AliveMessage{
+					Membership: &gossip.Member{Endpoint: endpoint},
+				},
+			},
+		}
+		return &discovery.Peer{
+			MSPID:        mspID,
+			AliveMessage: &gossip.SignedGossipMessage{GossipMessage: msg},
+		}
+	}
+
+	buff := &bytes.Buffer{}
+	printPolicySimulation(buff, &discovery.PolicySimulation{
+		Satisfiable:  true,
+		RequiredOrgs: []string{"Org1MSP"},
+		MinimalPeerSets: []discovery.Endorsers{
+			{peer("p0:7051", "Org1MSP"), peer("p1:7051", "Org2MSP")},
+			{peer("p0:7051", "Org1MSP"), peer("p2:7051", "Org3MSP")},
+		},
+	})
+	assert.Equal(t, "Satisfiable: true\n"+
+		"Required organizations: Org1MSP\n"+
+		"Minimal peer sets:\n"+
+		"1. p0:7051 (Org1MSP), p1:7051 (Org2MSP)\n"+
+		"2. p0:7051 (Org1MSP), p2:7051 (Org3MSP)\n", buff.String())
+
+	buff.Reset()
+	printPolicySimulation(buff, &discovery.PolicySimulation{
+		RequiredOrgs: []string{"Org1MSP", "Org2MSP"},
+	})
+	assert.Equal(t, "Satisfiable: false\nRequired organizations: Org1MSP, Org2MSP\n", buff.String())
+}
@@ -20,7 +20,7 @@ import (
 
 const (
 	channelFuncName = "channel"
-	channelCmdDes   = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo."
+	channelCmdDes   = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|snapshot."
 )
 
 var logger = flogging.MustGetLogger("channelCmd")
@@ -38,6 +38,8 @@ const (
 var (
 	// join related variables.
 	genesisBlockPath string
+	snapshotPath     string
+	snapshotHash     string
 
 	// create related variables
 	channelID     string
@@ -57,6 +59,7 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(snapshotCmd(cf))
 
 	return channelCmd
 }
@@ -81,6 +84,8 @@ func resetFlags() {
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.IntVarP(&timeout, "timeout", "t", 5, "Channel creation timeout")
+	flags.StringVarP(&snapshotPath, "snapshot", "", common.UndefinedParamValue, "Path of a snapshot of the ledger of the channel on the file system of the peer")
+	flags.StringVarP(&snapshotHash, "snapshotHash", "", "", "Hex encoded state hash of the snapshot, as printed by the peer that exported it")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -17,6 +17,7 @@ limitations under the License.
 package channel
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -32,18 +33,25 @@ import (
 
 const commandDescription = "Joins the peer to a channel."
 
+const joinCmdLong = commandDescription + " With '--snapshot', the peer joins the channel from a " +
+	"snapshot of its ledger exported by another peer, instead of committing all of its blocks. " +
+	"The block provided with '-b' is then the config block in effect as of the last block of the " +
+	"snapshot, and '--snapshotHash' is the state hash of the snapshot."
+
 func joinCmd(cf *ChannelCmdFactory) *cobra.Command {
 	// Set the flags on the channel start command.
 	joinCmd := &cobra.Command{
 		Use:   "join",
 		Short: commandDescription,
-		Long:  commandDescription,
+		Long:  joinCmdLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return join(cmd, args, cf)
 		},
 	}
 	flagList := []string{
 		"blockpath",
+		"snapshot",
+		"snapshotHash",
 	}
 	attachFlags(joinCmd, flagList)
 
@@ -75,6 +83,16 @@ func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
 	}
 	// Build the spec
 	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChain), gb}}
+	if snapshotPath != common.UndefinedParamValue {
+		if snapshotHash == "" {
+			return nil, errors.New("Must supply the state hash of the snapshot")
+		}
+		stateHash, err := hex.DecodeString(snapshotHash)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid state hash of the snapshot: %s", err)
+		}
+		input = &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), gb, []byte(snapshotPath), stateHash}}
+	}
 
 	spec := &pb.ChaincodeSpec{
 		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
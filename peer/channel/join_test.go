@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
@@ -73,6 +74,42 @@ func TestJoin(t *testing.T) {
 	assert.NoError(t, cmd.Execute(), "expected join command to succeed")
 }
 
+func TestJoinBySnapshot(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	dir, err := ioutil.TempDir("/tmp", "jointest")
+	assert.NoError(t, err, "Could not create the directory %s", dir)
+	mockblockfile := filepath.Join(dir, "mockjointest.block")
+	err = ioutil.WriteFile(mockblockfile, []byte(""), 0644)
+	assert.NoError(t, err, "Could not write to the file %s", mockblockfile)
+	defer os.RemoveAll(dir)
+
+	args := []string{"-b", mockblockfile, "--snapshot", "/var/hyperledger/snapshots/mychannel"}
+	spec, err := joinSpecWithArgs(args)
+	assert.EqualError(t, err, "Must supply the state hash of the snapshot")
+
+	spec, err = joinSpecWithArgs(append(args, "--snapshotHash", "not-hex"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid state hash of the snapshot")
+
+	spec, err = joinSpecWithArgs(append(args, "--snapshotHash", "0a0b"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(""), []byte("/var/hyperledger/snapshots/mychannel"), {0x0a, 0x0b}},
+		spec.Input.Args)
+}
+
+// joinSpecWithArgs parses the flags of the join command and builds its chaincode spec
+func joinSpecWithArgs(args []string) (*pb.ChaincodeSpec, error) {
+	resetFlags()
+	cmd := joinCmd(nil)
+	AddFlags(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		return nil, err
+	}
+	return getJoinCCSpec()
+}
+
 func TestJoinNonExistentBlock(t *testing.T) {
 	InitMSP()
 	resetFlags()
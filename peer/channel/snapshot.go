@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func snapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export a snapshot of the ledger of a channel.",
+		Long: "Export a snapshot of the ledger of a channel to a new file on the file system of the peer, " +
+			"and print the info of the snapshot. The peers of other organizations join the channel from " +
+			"the snapshot with 'peer channel join --snapshot', provided with its state hash. " +
+			"Requires '-c' and '--snapshot'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshot(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"snapshot",
+	}
+	attachFlags(snapshotCmd, flagList)
+
+	return snapshotCmd
+}
+
+func (cc *endorserClient) exportSnapshot() (*cscc.SnapshotInfo, error) {
+	var err error
+
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.ExportSnapshot), []byte(channelID), []byte(snapshotPath)}},
+		},
+	}
+
+	var prop *pb.Proposal
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err = utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil {
+		return nil, errors.New("received nil response")
+	}
+	if proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	info := &cscc.SnapshotInfo{}
+	if err := json.Unmarshal(proposalResp.Response.Payload, info); err != nil {
+		return nil, errors.Wrap(err, "cannot read cscc response")
+	}
+
+	return info, nil
+}
+
+func snapshot(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if snapshotPath == common.UndefinedParamValue {
+		return errors.New("Must supply the path of the snapshot on the file system of the peer")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	info, err := client.exportSnapshot()
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Snapshot info: %s\n", string(jsonBytes))
+
+	return nil
+}
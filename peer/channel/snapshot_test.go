@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSnapshot(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockPayload, err := json.Marshal(&cscc.SnapshotInfo{
+		ChannelID:     mockChannel,
+		LastBlockNum:  10,
+		LastBlockHash: "0a0b",
+		StateHash:     "0c0d",
+	})
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := snapshotCmd(mockCF)
+	AddFlags(cmd)
+
+	cmd.SetArgs([]string{"-c", mockChannel, "--snapshot", "/var/hyperledger/snapshots/mychannel"})
+	assert.NoError(t, cmd.Execute())
+
+	// the peer fails to export the snapshot
+	resetFlags()
+	mockResponse.Response = &pb.Response{Status: 500, Message: "Unknown chain ID, mychannel"}
+	cmd = snapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--snapshot", "/var/hyperledger/snapshots/mychannel"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "received bad response, status 500: Unknown chain ID, mychannel")
+}
+
+func TestExportSnapshotMissingParams(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	cmd := snapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshot", "/var/hyperledger/snapshots/mychannel"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = snapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "Must supply the path of the snapshot on the file system of the peer")
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/cache"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/peer/chaincode/api"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -118,6 +119,14 @@ func InitCrypto(mspMgrConfigDir, localMSPID, localMSPType string) error {
 		return errors.WithMessage(err, "could not parse YAML config")
 	}
 
+	// Bound the caches of the MSPs, both of the local MSP and of the channel MSPs
+	if size := viper.GetInt("peer.mspCache.size"); size > 0 {
+		cache.SetDefaultConfig(cache.Config{
+			Size: size,
+			TTL:  viper.GetDuration("peer.mspCache.ttl"),
+		})
+	}
+
 	err = mspmgmt.LoadLocalMspWithType(mspMgrConfigDir, bccspConfig, localMSPID, localMSPType)
 	if err != nil {
 		return errors.WithMessage(err, fmt.Sprintf("error when setting up MSP of type %s from directory %s", localMSPType, mspMgrConfigDir))
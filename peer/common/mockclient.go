@@ -17,6 +17,8 @@ limitations under the License.
 package common
 
 import (
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -102,3 +104,78 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, env *cb.Envelop
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetGossipMembership(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.GossipMembershipResponse, error) {
+	return &pb.GossipMembershipResponse{Membership: []byte("{}")}, m.err
+}
+
+func (m *mockAdminClient) GetLeaderElectionStatus(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.LeaderElectionStatusResponse, error) {
+	return &pb.LeaderElectionStatusResponse{Status: []byte("{}")}, m.err
+}
+
+func (m *mockAdminClient) OverrideLeaderElection(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
+
+func (m *mockAdminClient) ReloadGossipEndpoint(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.GossipEndpointResponse, error) {
+	return &pb.GossipEndpointResponse{}, m.err
+}
+
+func (m *mockAdminClient) GetDiscoveryStats(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.DiscoveryStatsResponse, error) {
+	return &pb.DiscoveryStatsResponse{}, m.err
+}
+
+func (m *mockAdminClient) InstallChaincode(ctx context.Context, opts ...grpc.CallOption) (pb.Admin_InstallChaincodeClient, error) {
+	return nil, m.err
+}
+
+func (m *mockAdminClient) PruneHistory(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.HistoryPruningStatusResponse, error) {
+	return &pb.HistoryPruningStatusResponse{}, m.err
+}
+
+func (m *mockAdminClient) GetHistoryPruningStatus(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.HistoryPruningStatusResponse, error) {
+	return &pb.HistoryPruningStatusResponse{}, m.err
+}
+
+func (m *mockAdminClient) DryRunCommit(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.DryRunCommitResponse, error) {
+	return &pb.DryRunCommitResponse{}, m.err
+}
+
+func (m *mockAdminClient) PurgePrivateData(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
+
+func (m *mockAdminClient) GetLoggerLevels(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.LoggerLevelsResponse, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	response := &pb.LoggerLevelsResponse{
+		Loggers: []*pb.LoggerLevel{{Logger: op.GetLoggerLevelsReq().LoggerPattern, Level: "INFO"}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) SetLoggerLevels(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.LoggerLevelsResponse, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	request := op.GetLoggerLevelsReq()
+	response := &pb.LoggerLevelsResponse{
+		Loggers: []*pb.LoggerLevel{{Logger: request.LoggerPattern, Level: strings.ToUpper(request.LogLevel)}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) ReloadTLSCredentials(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.TLSCredentialsResponse, error) {
+	return &pb.TLSCredentialsResponse{}, m.err
+}
+
+func (m *mockAdminClient) GetPvtDataDisseminations(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.PvtDataDisseminationsResponse, error) {
+	return &pb.PvtDataDisseminationsResponse{Disseminations: []byte("[]")}, m.err
+}
+
+func (m *mockAdminClient) GetMissingPvtDataStats(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.MissingPvtDataStatsResponse, error) {
+	return &pb.MissingPvtDataStatsResponse{Stats: []byte("{}")}, m.err
+}
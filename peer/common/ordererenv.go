@@ -27,6 +27,8 @@ func SetOrdererEnv(cmd *cobra.Command, args []string) {
 	// chaining PersistentPreRun functions
 	loggingSpec := viper.GetString("logging.level")
 	flogging.InitFromSpec(loggingSpec)
+	// take the orderer flags that aren't set from the active profile
+	applyOrdererProfile(cmd.Flags())
 	// set the orderer environment from flags
 	viper.Set("orderer.tls.rootcert.file", caFile)
 	viper.Set("orderer.tls.clientKey.file", keyFile)
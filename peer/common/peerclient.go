@@ -12,10 +12,14 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
+	discovery "github.com/hyperledger/fabric/discovery/client"
 	"github.com/hyperledger/fabric/peer/chaincode/api"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 )
 
 // PeerClient represents a client for communicating with a peer
@@ -98,6 +102,17 @@ func (pc *PeerClient) Admin() (pb.AdminClient, error) {
 	return pb.NewAdminClient(conn), nil
 }
 
+// Discovery returns a dialer that connects to the peer for its Discovery service
+func (pc *PeerClient) Discovery() discovery.Dialer {
+	return func() (*grpc.ClientConn, error) {
+		conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("discovery client failed to connect to %s", pc.address))
+		}
+		return conn, nil
+	}
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (pc *PeerClient) Certificate() tls.Certificate {
 	return pc.commonClient.Certificate()
@@ -157,3 +172,37 @@ func GetDeliverClient(address, tlsRootCertFile string) (api.DeliverClient, error
 	}
 	return peerClient.Deliver()
 }
+
+// GetDiscoveryClient returns a new discovery client that signs its requests
+// with the default signer, along with the authentication info that identifies
+// the default signer and the client's TLS certificate. If the both the address
+// and tlsRootCertFile are not provided, the target values for the client are
+// taken from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetDiscoveryClient(address, tlsRootCertFile string) (*discovery.Client, *discprotos.AuthInfo, error) {
+	var peerClient *PeerClient
+	var err error
+	if address != "" {
+		peerClient, err = NewPeerClientForAddress(address, tlsRootCertFile)
+	} else {
+		peerClient, err = NewPeerClientFromEnv()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, err := GetDefaultSigner()
+	if err != nil {
+		return nil, nil, err
+	}
+	identity, err := signer.Serialize()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("failed serializing identity for %s", signer.GetIdentifier()))
+	}
+	auth := &discprotos.AuthInfo{
+		ClientIdentity: identity,
+	}
+	if cert := peerClient.Certificate(); len(cert.Certificate) > 0 {
+		auth.ClientTlsCertHash = util.ComputeSHA256(cert.Certificate[0])
+	}
+	return discovery.NewClient(peerClient.Discovery(), signer.Sign), auth, nil
+}
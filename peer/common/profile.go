@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// ProfileEnvVar is the environment variable selecting the profile the
+	// peer CLI uses, unless one is selected with the --profile flag
+	ProfileEnvVar = "CORE_PEER_PROFILE"
+	// ProfilesFileEnvVar is the environment variable overriding the path of
+	// the file the profiles are stored in
+	ProfilesFileEnvVar = "CORE_PEER_PROFILESFILE"
+	// ProfileFlag is the name of the flag selecting the profile the peer CLI uses
+	ProfileFlag = "profile"
+)
+
+// Profile is a named set of the settings the peer CLI identifies itself and
+// connects to a peer and an orderer with. Relative paths are relative to the
+// directory of the file the profiles are stored in.
+type Profile struct {
+	// ID of the local MSP
+	MSPID string `yaml:"mspId,omitempty"`
+	// Path to the directory of the local MSP
+	MSPConfigPath string `yaml:"mspConfigPath,omitempty"`
+	// Peer is the peer the commands are sent to
+	Peer EndpointProfile `yaml:"peer,omitempty"`
+	// Orderer is the orderer transactions are sent to
+	Orderer EndpointProfile `yaml:"orderer,omitempty"`
+}
+
+// EndpointProfile holds the settings the peer CLI connects to a node with
+type EndpointProfile struct {
+	Address string     `yaml:"address,omitempty"`
+	TLS     TLSProfile `yaml:"tls,omitempty"`
+}
+
+// TLSProfile holds the TLS settings the peer CLI connects to a node with
+type TLSProfile struct {
+	Enabled            bool   `yaml:"enabled,omitempty"`
+	ClientAuthRequired bool   `yaml:"clientAuthRequired,omitempty"`
+	RootCert           string `yaml:"rootcert,omitempty"`
+	ClientCert         string `yaml:"clientCert,omitempty"`
+	ClientKey          string `yaml:"clientKey,omitempty"`
+	ServerHostOverride string `yaml:"serverhostoverride,omitempty"`
+}
+
+// Profiles are the profiles of the peer CLI, as stored in a file
+type Profiles struct {
+	// Current is the name of the profile used when none is selected
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+
+	file string
+}
+
+// activeProfile is the profile the settings of the peer CLI were taken from,
+// whose orderer settings apply to the orderer flags that aren't set
+var activeProfile *Profile
+
+// DefaultProfilesFile returns the path of the file the profiles are stored in
+func DefaultProfilesFile() string {
+	if file := os.Getenv(ProfilesFileEnvVar); file != "" {
+		return file
+	}
+	return filepath.Join(os.Getenv("HOME"), ".fabric", "profiles.yaml")
+}
+
+// LoadProfiles reads the profiles stored in the given file. No profiles are
+// returned if the file doesn't exist.
+func LoadProfiles(file string) (*Profiles, error) {
+	profiles := &Profiles{file: file}
+	raw, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading profiles from %s", file)
+	}
+	if err := yaml.Unmarshal(raw, profiles); err != nil {
+		return nil, errors.Wrapf(err, "failed parsing profiles from %s", file)
+	}
+	return profiles, nil
+}
+
+// Save writes the profiles to the file they were loaded from
+func (p *Profiles) Save() error {
+	raw, err := yaml.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling profiles")
+	}
+	if err := os.MkdirAll(filepath.Dir(p.file), 0700); err != nil {
+		return errors.Wrapf(err, "failed creating the directory of %s", p.file)
+	}
+	// profiles point at the private keys of their identities
+	if err := ioutil.WriteFile(p.file, raw, 0600); err != nil {
+		return errors.Wrapf(err, "failed writing profiles to %s", p.file)
+	}
+	return nil
+}
+
+// Names returns the sorted names of the profiles
+func (p *Profiles) Names() []string {
+	var names []string
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the profile of the given name
+func (p *Profiles) Get(name string) (*Profile, error) {
+	profile, exists := p.Profiles[name]
+	if !exists {
+		return nil, errors.Errorf("profile %s does not exist in %s", name, p.file)
+	}
+	return profile, nil
+}
+
+// Set adds or replaces the profile of the given name
+func (p *Profiles) Set(name string, profile *Profile) {
+	if p.Profiles == nil {
+		p.Profiles = make(map[string]*Profile)
+	}
+	p.Profiles[name] = profile
+}
+
+// Delete removes the profile of the given name, and unselects it if it is
+// the current profile
+func (p *Profiles) Delete(name string) error {
+	if _, err := p.Get(name); err != nil {
+		return err
+	}
+	delete(p.Profiles, name)
+	if p.Current == name {
+		p.Current = ""
+	}
+	return nil
+}
+
+// Apply sets the settings of the profile of the given name in the global
+// Viper instance, over the ones of the config file and of the environment
+func (p *Profiles) Apply(name string) error {
+	profile, err := p.Get(name)
+	if err != nil {
+		return err
+	}
+	resolved := *profile
+	base := filepath.Dir(p.file)
+	for _, path := range []*string{
+		&resolved.MSPConfigPath,
+		&resolved.Peer.TLS.RootCert, &resolved.Peer.TLS.ClientCert, &resolved.Peer.TLS.ClientKey,
+		&resolved.Orderer.TLS.RootCert, &resolved.Orderer.TLS.ClientCert, &resolved.Orderer.TLS.ClientKey,
+	} {
+		if *path != "" {
+			config.TranslatePathInPlace(base, path)
+		}
+	}
+
+	setIfNotEmpty("peer.localMspId", resolved.MSPID)
+	setIfNotEmpty("peer.mspConfigPath", resolved.MSPConfigPath)
+	resolved.Peer.apply("peer")
+	resolved.Orderer.apply("orderer")
+	activeProfile = &resolved
+	return nil
+}
+
+func (e EndpointProfile) apply(prefix string) {
+	setIfNotEmpty(prefix+".address", e.Address)
+	viper.Set(prefix+".tls.enabled", e.TLS.Enabled)
+	viper.Set(prefix+".tls.clientAuthRequired", e.TLS.ClientAuthRequired)
+	setIfNotEmpty(prefix+".tls.rootcert.file", e.TLS.RootCert)
+	setIfNotEmpty(prefix+".tls.clientCert.file", e.TLS.ClientCert)
+	setIfNotEmpty(prefix+".tls.clientKey.file", e.TLS.ClientKey)
+	setIfNotEmpty(prefix+".tls.serverhostoverride", e.TLS.ServerHostOverride)
+}
+
+func setIfNotEmpty(key, value string) {
+	if value != "" {
+		viper.Set(key, value)
+	}
+}
+
+// SelectedProfile returns the name of the profile selected with the --profile
+// flag among the given command line arguments, or with the CORE_PEER_PROFILE
+// environment variable. The profile has to be known before the command line
+// is parsed, since the local MSP is initialized with its settings.
+func SelectedProfile(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--"+ProfileFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, "--"+ProfileFlag+"=") {
+			return strings.TrimPrefix(arg, "--"+ProfileFlag+"=")
+		}
+	}
+	return os.Getenv(ProfileEnvVar)
+}
+
+// InitProfile applies the profile selected among the given command line
+// arguments, or the current profile if none is selected
+func InitProfile(args []string) error {
+	profiles, err := LoadProfiles(DefaultProfilesFile())
+	if err != nil {
+		return err
+	}
+	name := SelectedProfile(args)
+	if name == "" {
+		name = profiles.Current
+	}
+	if name == "" {
+		return nil
+	}
+	return profiles.Apply(name)
+}
+
+// applyOrdererProfile sets the orderer flags that weren't set on the command
+// line from the active profile
+func applyOrdererProfile(flags *pflag.FlagSet) {
+	if activeProfile == nil {
+		return
+	}
+	orderer := activeProfile.Orderer
+	for flag, apply := range map[string]func(){
+		"orderer":                    func() { OrderingEndpoint = orderer.Address },
+		"tls":                        func() { tlsEnabled = orderer.TLS.Enabled },
+		"clientauth":                 func() { clientAuth = orderer.TLS.ClientAuthRequired },
+		"cafile":                     func() { caFile = orderer.TLS.RootCert },
+		"keyfile":                    func() { keyFile = orderer.TLS.ClientKey },
+		"certfile":                   func() { certFile = orderer.TLS.ClientCert },
+		"ordererTLSHostnameOverride": func() { ordererTLSHostnameOverride = orderer.TLS.ServerHostOverride },
+	} {
+		if !flags.Changed(flag) {
+			apply()
+		}
+	}
+}
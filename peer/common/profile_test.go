@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfiles = `
+current: org1
+profiles:
+  org1:
+    mspId: Org1MSP
+    mspConfigPath: org1/msp
+    peer:
+      address: peer0.org1.example.com:7051
+      tls:
+        enabled: true
+        rootcert: /etc/org1/ca.crt
+  org2:
+    mspId: Org2MSP
+    orderer:
+      address: orderer.example.com:7050
+      tls:
+        enabled: true
+        rootcert: orderer/ca.crt
+`
+
+func writeProfiles(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "profiles")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "profiles.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(testProfiles), 0600))
+	return file, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadProfiles(t *testing.T) {
+	file, cleanup := writeProfiles(t)
+	defer cleanup()
+
+	profiles, err := LoadProfiles(file)
+	require.NoError(t, err)
+	assert.Equal(t, "org1", profiles.Current)
+	assert.Equal(t, []string{"org1", "org2"}, profiles.Names())
+	org1, err := profiles.Get("org1")
+	require.NoError(t, err)
+	assert.Equal(t, "Org1MSP", org1.MSPID)
+	assert.Equal(t, "peer0.org1.example.com:7051", org1.Peer.Address)
+	_, err = profiles.Get("org3")
+	assert.EqualError(t, err, "profile org3 does not exist in "+file)
+
+	// changes are saved
+	profiles.Set("org3", &Profile{MSPID: "Org3MSP"})
+	require.NoError(t, profiles.Delete("org1"))
+	assert.Equal(t, "", profiles.Current)
+	require.NoError(t, profiles.Save())
+	profiles, err = LoadProfiles(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org2", "org3"}, profiles.Names())
+	assert.Error(t, profiles.Delete("org1"))
+
+	// a missing file has no profiles
+	profiles, err = LoadProfiles(filepath.Join(filepath.Dir(file), "missing.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, profiles.Names())
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("profiles: [}"), 0600))
+	_, err = LoadProfiles(file)
+	assert.Contains(t, err.Error(), "failed parsing profiles from "+file)
+}
+
+func TestSelectedProfile(t *testing.T) {
+	defer os.Unsetenv(ProfileEnvVar)
+	os.Unsetenv(ProfileEnvVar)
+	assert.Equal(t, "", SelectedProfile([]string{"channel", "list"}))
+	assert.Equal(t, "org1", SelectedProfile([]string{"channel", "list", "--profile", "org1"}))
+	assert.Equal(t, "org2", SelectedProfile([]string{"--profile=org2", "channel", "list"}))
+	assert.Equal(t, "", SelectedProfile([]string{"chaincode", "invoke", "--", "--profile", "org1"}))
+	os.Setenv(ProfileEnvVar, "org3")
+	assert.Equal(t, "org3", SelectedProfile([]string{"channel", "list"}))
+	assert.Equal(t, "org1", SelectedProfile([]string{"channel", "list", "--profile", "org1"}))
+}
+
+func TestInitProfile(t *testing.T) {
+	file, cleanup := writeProfiles(t)
+	defer cleanup()
+	defer os.Unsetenv(ProfilesFileEnvVar)
+	os.Setenv(ProfilesFileEnvVar, file)
+	defer func() { activeProfile = nil }()
+	defer viper.Reset()
+
+	// the current profile is used by default
+	require.NoError(t, InitProfile(nil))
+	assert.Equal(t, "Org1MSP", viper.GetString("peer.localMspId"))
+	assert.Equal(t, filepath.Join(filepath.Dir(file), "org1", "msp"), viper.GetString("peer.mspConfigPath"))
+	assert.Equal(t, "peer0.org1.example.com:7051", viper.GetString("peer.address"))
+	assert.True(t, viper.GetBool("peer.tls.enabled"))
+	assert.Equal(t, "/etc/org1/ca.crt", viper.GetString("peer.tls.rootcert.file"))
+
+	require.NoError(t, InitProfile([]string{"--profile", "org2"}))
+	assert.Equal(t, "Org2MSP", viper.GetString("peer.localMspId"))
+	assert.False(t, viper.GetBool("peer.tls.enabled"))
+
+	assert.EqualError(t, InitProfile([]string{"--profile", "org3"}), "profile org3 does not exist in "+file)
+}
+
+func TestOrdererProfile(t *testing.T) {
+	file, cleanup := writeProfiles(t)
+	defer cleanup()
+	profiles, err := LoadProfiles(file)
+	require.NoError(t, err)
+	require.NoError(t, profiles.Apply("org2"))
+	defer func() { activeProfile = nil }()
+	defer viper.Reset()
+
+	run := func(args ...string) {
+		cmd := &cobra.Command{
+			Use:              "test",
+			Run:              func(cmd *cobra.Command, args []string) {},
+			PersistentPreRun: SetOrdererEnv,
+		}
+		AddOrdererFlags(cmd)
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+	}
+
+	// the orderer settings of the profile apply to the flags that aren't set
+	run()
+	assert.Equal(t, "orderer.example.com:7050", viper.GetString("orderer.address"))
+	assert.True(t, viper.GetBool("orderer.tls.enabled"))
+	assert.Equal(t, filepath.Join(filepath.Dir(file), "orderer", "ca.crt"), viper.GetString("orderer.tls.rootcert.file"))
+
+	run("-o", "orderer2.example.com:7050", "--cafile", "ca.crt")
+	assert.Equal(t, "orderer2.example.com:7050", viper.GetString("orderer.address"))
+	assert.True(t, viper.GetBool("orderer.tls.enabled"))
+	assert.Equal(t, "ca.crt", viper.GetString("orderer.tls.rootcert.file"))
+}
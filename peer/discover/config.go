@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/jsonpb"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func configCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Discover the configuration of a channel.",
+		Long:  "Discover the MSPs and the orderer endpoints of a channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverConfig(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func discoverConfig(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	cf, err := prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.send(discovery.NewRequest().OfChannel(channelID).AddConfigQuery())
+	if err != nil {
+		return err
+	}
+	config, err := resp.Config()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving config")
+	}
+
+	if output == jsonOutput {
+		m := &jsonpb.Marshaler{Indent: "\t"}
+		if err := m.Marshal(cf.Output, config); err != nil {
+			return errors.Wrap(err, "failed marshaling config to JSON")
+		}
+		fmt.Fprintln(cf.Output)
+		return nil
+	}
+
+	var mspIDs []string
+	for mspID := range config.Msps {
+		mspIDs = append(mspIDs, mspID)
+	}
+	for mspID := range config.Orderers {
+		if _, exists := config.Msps[mspID]; !exists {
+			mspIDs = append(mspIDs, mspID)
+		}
+	}
+	sort.Strings(mspIDs)
+
+	tw := tabwriter.NewWriter(cf.Output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MSP ID\tORDERERS")
+	for _, mspID := range mspIDs {
+		var orderers []string
+		for _, ep := range config.Orderers[mspID].GetEndpoint() {
+			orderers = append(orderers, fmt.Sprintf("%s:%d", ep.Host, ep.Port))
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", mspID, strings.Join(orderers, ","))
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/peer/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	discoverFuncName = "discover"
+	discoverCmdDes   = "Query the discovery service of a peer: endorsers|peers|config|versions|impact|orgs."
+
+	tableOutput = "table"
+	jsonOutput  = "json"
+)
+
+var logger = flogging.MustGetLogger("discoverCmd")
+
+var (
+	channelID        string
+	chaincodes       []string
+	collections      []string
+	keys             []string
+	keyPolicies      []string
+	subPolicies      []string
+	output           string
+	peerAddress      string
+	tlsRootCertFile  string
+	timeout          time.Duration
+	maxHeightLag     uint64
+	configUpdateFile string
+)
+
+// Cmd returns the cobra command for Discover
+func Cmd(cf *DiscoverCmdFactory) *cobra.Command {
+	discoverCmd.AddCommand(endorsersCmd(cf))
+	discoverCmd.AddCommand(peersCmd(cf))
+	discoverCmd.AddCommand(configCmd(cf))
+	discoverCmd.AddCommand(versionsCmd(cf))
+	discoverCmd.AddCommand(impactCmd(cf))
+	discoverCmd.AddCommand(orgsCmd(cf))
+
+	return discoverCmd
+}
+
+var discoverCmd = &cobra.Command{
+	Use:   discoverFuncName,
+	Short: fmt.Sprint(discoverCmdDes),
+	Long:  fmt.Sprint(discoverCmdDes),
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channel", "C", common.UndefinedParamValue,
+		"The channel to query the discovery service in the context of")
+	flags.StringArrayVarP(&chaincodes, "chaincode", "n", nil,
+		"The chaincodes to query endorsers or installed versions for")
+	flags.StringArrayVarP(&collections, "collection", "", nil,
+		"The collections a chaincode accesses, in the format of <chaincode>:<collection>[,<collection>...]")
+	flags.StringArrayVarP(&keys, "key", "", nil,
+		"The keys a chaincode writes to, in the format of <chaincode>:<key>[,<key>...]. The peer looks up their key-level endorsement policies")
+	flags.StringArrayVarP(&keyPolicies, "keyPolicy", "", nil,
+		"A key-level endorsement policy of a key a chaincode writes to, in the format of <chaincode>:<policy>, e.g. mycc:\"OR('Org1MSP.peer','Org2MSP.peer')\"")
+	flags.StringArrayVarP(&subPolicies, "subPolicy", "", nil,
+		"The sub-policy of the definition of a chaincode that endorses its invocation, in the format of <chaincode>:<sub-policy>, e.g. mycc:transfer. If the chaincode has no such sub-policy, its endorsement policy applies")
+	flags.StringVarP(&output, "output", "o", tableOutput,
+		fmt.Sprintf("The output format of the results, either %s or %s", tableOutput, jsonOutput))
+	flags.StringVarP(&peerAddress, "peerAddress", "", common.UndefinedParamValue,
+		"The address of the peer to query. Defaults to the peer.address setting")
+	flags.StringVarP(&tlsRootCertFile, "tlsRootCertFile", "", common.UndefinedParamValue,
+		"If TLS is enabled, the path to the TLS root cert file of the peer to query. Defaults to the peer.tls.rootcert.file setting")
+	flags.DurationVarP(&timeout, "timeout", "", 10*time.Second,
+		"The time to wait for the response of the discovery service")
+	flags.Uint64VarP(&maxHeightLag, "maxLedgerHeightLag", "", 0,
+		"Exclude endorsers whose ledger height lags behind the highest ledger height in the channel by more than this number of blocks. 0 means the peer's configuration applies")
+	flags.StringVarP(&configUpdateFile, "configUpdate", "", common.UndefinedParamValue,
+		"The file containing the config update, either a marshaled common.ConfigUpdate or the config update envelope submitted by peer channel update")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
+
+// Sender sends requests to the discovery service
+type Sender interface {
+	Send(ctx context.Context, req *discovery.Request, auth *discprotos.AuthInfo) (discovery.Response, error)
+}
+
+// DiscoverCmdFactory holds the clients used by DiscoverCmd
+type DiscoverCmdFactory struct {
+	Client   Sender
+	AuthInfo *discprotos.AuthInfo
+	Output   io.Writer
+}
+
+// InitCmdFactory init the DiscoverCmdFactory with a discovery client
+// to the peer, using the peer's TLS and MSP configuration
+func InitCmdFactory() (*DiscoverCmdFactory, error) {
+	client, auth, err := common.GetDiscoveryClient(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting discovery client")
+	}
+	return &DiscoverCmdFactory{
+		Client:   client,
+		AuthInfo: auth,
+		Output:   os.Stdout,
+	}, nil
+}
+
+// prepare validates the flags common to all commands,
+// and initializes the given DiscoverCmdFactory if it is nil
+func prepare(cmd *cobra.Command, cf *DiscoverCmdFactory) (*DiscoverCmdFactory, error) {
+	if output != tableOutput && output != jsonOutput {
+		return nil, errors.Errorf("invalid output format %s, must be either %s or %s", output, tableOutput, jsonOutput)
+	}
+	if channelID == common.UndefinedParamValue {
+		return nil, errors.New("The required parameter 'channel' is empty. Rerun the command with -C flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf != nil {
+		return cf, nil
+	}
+	return InitCmdFactory()
+}
+
+// send sends the given request to the discovery service
+// and returns its response in the context of the channel
+func (cf *DiscoverCmdFactory) send(req *discovery.Request) (discovery.ChannelResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := cf.Client.Send(ctx, req, cf.AuthInfo)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending discovery request")
+	}
+	return resp.ForChannel(channelID), nil
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling output to JSON")
+	}
+	fmt.Fprintln(w, string(b))
+	return nil
+}
+
+// peerInfo is the printable form of a peer returned by the discovery service
+type peerInfo struct {
+	MSPID        string   `json:"mspid"`
+	Endpoint     string   `json:"endpoint"`
+	LedgerHeight uint64   `json:"ledger_height"`
+	Chaincodes   []string `json:"chaincodes,omitempty"`
+}
+
+func newPeerInfo(p *discovery.Peer) peerInfo {
+	info := peerInfo{
+		MSPID:    p.MSPID,
+		Endpoint: p.AliveMessage.GetAliveMsg().GetMembership().GetEndpoint(),
+	}
+	if p.StateInfoMessage == nil {
+		return info
+	}
+	properties := p.StateInfoMessage.GetStateInfo().GetProperties()
+	info.LedgerHeight = properties.GetLedgerHeight()
+	for _, cc := range properties.GetChaincodes() {
+		info.Chaincodes = append(info.Chaincodes, fmt.Sprintf("%s:%s", cc.Name, cc.Version))
+	}
+	return info
+}
+
+func newPeerInfos(peers []*discovery.Peer) []peerInfo {
+	res := []peerInfo{}
+	for _, p := range peers {
+		res = append(res, newPeerInfo(p))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].MSPID != res[j].MSPID {
+			return res[i].MSPID < res[j].MSPID
+		}
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
+
+func printPeersTable(w io.Writer, peers []peerInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MSP ID\tENDPOINT\tLEDGER HEIGHT\tCHAINCODES")
+	for _, p := range peers {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", p.MSPID, p.Endpoint, p.LedgerHeight, strings.Join(p.Chaincodes, ","))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,620 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiscoverPeers(t *testing.T) {
+	defer resetFlags()
+
+	resp := &mockResponse{}
+	resp.On("Peers").Return([]*discovery.Peer{
+		newPeer("p1:7051", "Org2MSP", 10, &gossip.Chaincode{Name: "mycc", Version: "1.0"}),
+		newPeer("p0:7051", "Org1MSP", 12),
+	}, nil)
+	cf, buff := newCmdFactory(resp, nil)
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := peersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "MSP ID   ENDPOINT  LEDGER HEIGHT  CHAINCODES\n"+
+		"Org1MSP  p0:7051   12             \n"+
+		"Org2MSP  p1:7051   10             mycc:1.0\n", buff.String())
+
+	// Scenario II: JSON output
+	buff.Reset()
+	resetFlags()
+	cmd = peersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `[
+		{"mspid": "Org1MSP", "endpoint": "p0:7051", "ledger_height": 12},
+		{"mspid": "Org2MSP", "endpoint": "p1:7051", "ledger_height": 10, "chaincodes": ["mycc:1.0"]}
+	]`, buff.String())
+
+	// Scenario III: The discovery service can't be reached
+	resetFlags()
+	cf, _ = newCmdFactory(nil, errors.New("connection refused"))
+	cmd = peersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "failed sending discovery request: connection refused")
+}
+
+func TestDiscoverEndorsers(t *testing.T) {
+	defer resetFlags()
+
+	resp := &mockResponse{}
+	resp.On("Endorsers", "cc1").Return(discovery.Endorsers{newPeer("p0:7051", "Org1MSP", 5)}, nil)
+	resp.On("Endorsers", "cc2").Return(discovery.Endorsers{newPeer("p1:7051", "Org2MSP", 6)}, nil)
+	sender := &mockSender{}
+	sender.On("Send", mock.MatchedBy(func(req *discovery.Request) bool {
+		interests := req.Queries[0].GetCcQuery().Interests
+		return len(interests) == 2 &&
+			interests[0].Chaincodes[0].Name == "cc1" &&
+			assert.ObjectsAreEqual([]string{"col1", "col2"}, interests[0].Chaincodes[0].CollectionNames) &&
+			interests[1].Chaincodes[0].Name == "cc2" &&
+			len(interests[1].Chaincodes[0].CollectionNames) == 0 &&
+			assert.ObjectsAreEqual([]string{"k1", "k2"}, interests[1].Chaincodes[0].KeyNames) &&
+			len(interests[1].Chaincodes[0].KeyPolicies) == 1 &&
+			len(interests[0].Chaincodes[0].KeyNames) == 0 &&
+			interests[0].Chaincodes[0].SubPolicy == "transfer" &&
+			interests[1].Chaincodes[0].SubPolicy == ""
+	})).Return(resp, nil)
+	buff := &bytes.Buffer{}
+	cf := &DiscoverCmdFactory{Client: sender, AuthInfo: &discprotos.AuthInfo{}, Output: buff}
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := endorsersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2", "--collection", "cc1:col1,col2",
+		"--key", "cc2:k1,k2", "--keyPolicy", "cc2:OR('Org1MSP.peer','Org2MSP.peer')", "--subPolicy", "cc1:transfer"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Chaincode: cc1\n"+
+		"MSP ID   ENDPOINT  LEDGER HEIGHT  CHAINCODES\n"+
+		"Org1MSP  p0:7051   5              \n"+
+		"\n"+
+		"Chaincode: cc2\n"+
+		"MSP ID   ENDPOINT  LEDGER HEIGHT  CHAINCODES\n"+
+		"Org2MSP  p1:7051   6              \n", buff.String())
+
+	// Scenario II: JSON output
+	buff.Reset()
+	resetFlags()
+	cmd = endorsersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2", "--collection", "cc1:col1,col2",
+		"--key", "cc2:k1,k2", "--keyPolicy", "cc2:OR('Org1MSP.peer','Org2MSP.peer')", "--subPolicy", "cc1:transfer", "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{
+		"cc1": [{"mspid": "Org1MSP", "endpoint": "p0:7051", "ledger_height": 5}],
+		"cc2": [{"mspid": "Org2MSP", "endpoint": "p1:7051", "ledger_height": 6}]
+	}`, buff.String())
+
+	// Scenario III: Endorsers of a chaincode can't be found
+	resp = &mockResponse{}
+	resp.On("Endorsers", "cc1").Return(nil, errors.New("no endorsement combination can be satisfied"))
+	cf, _ = newCmdFactory(resp, nil)
+	resetFlags()
+	cmd = endorsersCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "failed retrieving endorsers for chaincode cc1: no endorsement combination can be satisfied")
+}
+
+func TestDiscoverConfig(t *testing.T) {
+	defer resetFlags()
+
+	resp := &mockResponse{}
+	resp.On("Config").Return(&discprotos.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{
+			"Org1MSP":    {Name: "Org1MSP"},
+			"OrdererMSP": {Name: "OrdererMSP"},
+			"Org2MSP":    {Name: "Org2MSP"},
+		},
+		Orderers: map[string]*discprotos.Endpoints{
+			"OrdererMSP": {
+				Endpoint: []*discprotos.Endpoint{
+					{Host: "orderer0", Port: 7050},
+					{Host: "orderer1", Port: 7050},
+				},
+			},
+		},
+	}, nil)
+	cf, buff := newCmdFactory(resp, nil)
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := configCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "MSP ID      ORDERERS\n"+
+		"OrdererMSP  orderer0:7050,orderer1:7050\n"+
+		"Org1MSP     \n"+
+		"Org2MSP     \n", buff.String())
+
+	// Scenario II: JSON output
+	buff.Reset()
+	resetFlags()
+	cmd = configCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{
+		"msps": {
+			"Org1MSP": {"name": "Org1MSP"},
+			"Org2MSP": {"name": "Org2MSP"},
+			"OrdererMSP": {"name": "OrdererMSP"}
+		},
+		"orderers": {
+			"OrdererMSP": {"endpoint": [{"host": "orderer0", "port": 7050}, {"host": "orderer1", "port": 7050}]}
+		}
+	}`, buff.String())
+}
+
+func TestDiscoverVersions(t *testing.T) {
+	defer resetFlags()
+
+	resp := &mockResponse{}
+	resp.On("ChaincodeVersions").Return([]*discovery.ChaincodeVersions{
+		{
+			Chaincode:      "cc1",
+			DefinedVersion: "1.0",
+			PeersByInstalledVersion: map[string][]*discovery.Peer{
+				"1.0": {newPeer("p0:7051", "Org1MSP", 5)},
+				"0.9": {newPeer("p1:7051", "Org2MSP", 5)},
+				"":    {newPeer("p2:7051", "Org2MSP", 5)},
+			},
+		},
+		{
+			Chaincode: "cc2",
+			PeersByInstalledVersion: map[string][]*discovery.Peer{
+				"": {newPeer("p0:7051", "Org1MSP", 5)},
+			},
+		},
+	}, nil)
+	sender := &mockSender{}
+	sender.On("Send", mock.MatchedBy(func(req *discovery.Request) bool {
+		return assert.ObjectsAreEqual([]string{"cc1", "cc2"}, req.Queries[0].GetChaincodeVersions().Chaincodes)
+	})).Return(resp, nil)
+	buff := &bytes.Buffer{}
+	cf := &DiscoverCmdFactory{Client: sender, AuthInfo: &discprotos.AuthInfo{}, Output: buff}
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := versionsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Chaincode: cc1, defined version: 1.0\n"+
+		"MSP ID   ENDPOINT  INSTALLED VERSION  STATUS\n"+
+		"Org1MSP  p0:7051   1.0                ok\n"+
+		"Org2MSP  p1:7051   0.9                version mismatch\n"+
+		"Org2MSP  p2:7051                      not installed\n"+
+		"\n"+
+		"Chaincode: cc2, defined version: not defined\n"+
+		"MSP ID   ENDPOINT  INSTALLED VERSION  STATUS\n"+
+		"Org1MSP  p0:7051                      not installed\n", buff.String())
+
+	// Scenario II: JSON output
+	buff.Reset()
+	resetFlags()
+	cmd = versionsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2", "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{
+		"cc1": {
+			"defined_version": "1.0",
+			"peers": [
+				{"mspid": "Org1MSP", "endpoint": "p0:7051", "installed_version": "1.0", "status": "ok"},
+				{"mspid": "Org2MSP", "endpoint": "p1:7051", "installed_version": "0.9", "status": "version mismatch"},
+				{"mspid": "Org2MSP", "endpoint": "p2:7051", "status": "not installed"}
+			]
+		},
+		"cc2": {
+			"peers": [{"mspid": "Org1MSP", "endpoint": "p0:7051", "status": "not installed"}]
+		}
+	}`, buff.String())
+
+	// Scenario III: No chaincode is specified
+	resetFlags()
+	cmd = versionsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'chaincode' is empty. Rerun the command with -n flag")
+
+	// Scenario IV: The query fails
+	resp = &mockResponse{}
+	resp.On("ChaincodeVersions").Return(nil, errors.New("access denied"))
+	cf, _ = newCmdFactory(resp, nil)
+	resetFlags()
+	cmd = versionsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1"})
+	assert.EqualError(t, cmd.Execute(), "failed retrieving chaincode versions: access denied")
+}
+
+func TestDiscoverOrgs(t *testing.T) {
+	defer resetFlags()
+
+	resp := &mockResponse{}
+	resp.On("OrgCombinations").Return([]*discovery.OrgCombinations{
+		{
+			Chaincode: "cc1",
+			Combinations: []map[string]uint32{
+				{"Org2MSP": 1, "Org1MSP": 1},
+				{"Org3MSP": 2},
+			},
+		},
+		{
+			Chaincode:    "cc2",
+			Combinations: []map[string]uint32{{"Org1MSP": 1}},
+		},
+	}, nil)
+	sender := &mockSender{}
+	sender.On("Send", mock.MatchedBy(func(req *discovery.Request) bool {
+		interests := req.Queries[0].GetOrgCombinations().GetInterests()
+		return len(interests) == 2 && interests[0].Chaincodes[0].Name == "cc1" &&
+			assert.ObjectsAreEqual([]string{"col1"}, interests[0].Chaincodes[0].CollectionNames)
+	})).Return(resp, nil)
+	buff := &bytes.Buffer{}
+	cf := &DiscoverCmdFactory{Client: sender, AuthInfo: &discprotos.AuthInfo{}, Output: buff}
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := orgsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2", "--collection", "cc1:col1"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Chaincode: cc1\n"+
+		"COMBINATION  ENDORSEMENTS BY MSP ID\n"+
+		"1            Org1MSP:1, Org2MSP:1\n"+
+		"2            Org3MSP:2\n"+
+		"\n"+
+		"Chaincode: cc2\n"+
+		"COMBINATION  ENDORSEMENTS BY MSP ID\n"+
+		"1            Org1MSP:1\n", buff.String())
+
+	// Scenario II: JSON output
+	buff.Reset()
+	resetFlags()
+	cmd = orgsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1", "-n", "cc2", "--collection", "cc1:col1", "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{
+		"cc1": [{"Org1MSP": 1, "Org2MSP": 1}, {"Org3MSP": 2}],
+		"cc2": [{"Org1MSP": 1}]
+	}`, buff.String())
+
+	// Scenario III: No chaincode is specified
+	resetFlags()
+	cmd = orgsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'chaincode' is empty. Rerun the command with -n flag")
+
+	// Scenario IV: The query fails
+	resp = &mockResponse{}
+	resp.On("OrgCombinations").Return(nil, errors.New("access denied"))
+	cf, _ = newCmdFactory(resp, nil)
+	resetFlags()
+	cmd = orgsCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "cc1"})
+	assert.EqualError(t, cmd.Execute(), "failed retrieving org combinations: access denied")
+}
+
+func TestDiscoverImpact(t *testing.T) {
+	defer resetFlags()
+
+	configUpdate := &common.ConfigUpdate{ChannelId: "mychannel"}
+	dir, err := ioutil.TempDir("", "impact")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configUpdateFile := filepath.Join(dir, "config_update.pb")
+	assert.NoError(t, ioutil.WriteFile(configUpdateFile, utils.MarshalOrPanic(configUpdate), 0644))
+	envelopeFile := filepath.Join(dir, "config_update_envelope.pb")
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, "mychannel", nil, &common.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, 0, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(envelopeFile, utils.MarshalOrPanic(env), 0644))
+
+	resp := &mockResponse{}
+	ejected := &discovery.EjectedPeer{
+		Peer:   newPeer("p1:7051", "Org2MSP", 5),
+		Reason: "Org2MSP is not an application organization of the channel",
+	}
+	resp.On("ConfigUpdateImpact").Return(&discovery.ConfigUpdateImpact{
+		PolicyChanges: []*discprotos.PolicyChange{
+			{Path: "/Channel/Application/Org2/Admins", Type: discprotos.PolicyChange_REMOVED},
+			{Path: "/Channel/Application/Org3/Admins", Type: discprotos.PolicyChange_ADDED},
+		},
+		AddedMSPs:    []string{"Org3MSP"},
+		EjectedPeers: []*discovery.EjectedPeer{ejected},
+	}, nil)
+	sender := &mockSender{}
+	sender.On("Send", mock.MatchedBy(func(req *discovery.Request) bool {
+		return bytes.Equal(utils.MarshalOrPanic(configUpdate), req.Queries[0].GetConfigUpdateImpact().ConfigUpdate)
+	})).Return(resp, nil)
+	buff := &bytes.Buffer{}
+	cf := &DiscoverCmdFactory{Client: sender, AuthInfo: &discprotos.AuthInfo{}, Output: buff}
+
+	// Scenario I: Table output
+	resetFlags()
+	cmd := impactCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "--configUpdate", configUpdateFile})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Policy changes:\n"+
+		"PATH                              CHANGE\n"+
+		"/Channel/Application/Org2/Admins  removed\n"+
+		"/Channel/Application/Org3/Admins  added\n"+
+		"\n"+
+		"Added MSPs: Org3MSP\n"+
+		"Removed MSPs: none\n"+
+		"\n"+
+		"Ejected peers:\n"+
+		"MSP ID   ENDPOINT  REASON\n"+
+		"Org2MSP  p1:7051   Org2MSP is not an application organization of the channel\n", buff.String())
+
+	// Scenario II: JSON output, with the config update in an envelope
+	buff.Reset()
+	resetFlags()
+	cmd = impactCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "--configUpdate", envelopeFile, "-o", "json"})
+	assert.NoError(t, cmd.Execute())
+	assert.JSONEq(t, `{
+		"policy_changes": [
+			{"path": "/Channel/Application/Org2/Admins", "change": "removed"},
+			{"path": "/Channel/Application/Org3/Admins", "change": "added"}
+		],
+		"added_msps": ["Org3MSP"],
+		"removed_msps": [],
+		"ejected_peers": [
+			{"mspid": "Org2MSP", "endpoint": "p1:7051", "reason": "Org2MSP is not an application organization of the channel"}
+		]
+	}`, buff.String())
+
+	// Scenario III: No config update is specified
+	resetFlags()
+	cmd = impactCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "The required parameter 'configUpdate' is empty. Rerun the command with --configUpdate flag")
+
+	// Scenario IV: The config update file doesn't exist
+	resetFlags()
+	cmd = impactCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "--configUpdate", filepath.Join(dir, "missing.pb")})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed reading config update")
+
+	// Scenario V: The query fails
+	resp = &mockResponse{}
+	resp.On("ConfigUpdateImpact").Return(nil, errors.New("failed computing config update impact: delta set was empty"))
+	cf, _ = newCmdFactory(resp, nil)
+	resetFlags()
+	cmd = impactCmd(cf)
+	cmd.SetArgs([]string{"-C", "mychannel", "--configUpdate", configUpdateFile})
+	assert.EqualError(t, cmd.Execute(), "failed retrieving config update impact: failed computing config update impact: delta set was empty")
+}
+
+func TestBadFlags(t *testing.T) {
+	defer resetFlags()
+
+	cf, _ := newCmdFactory(&mockResponse{}, nil)
+	for _, testCase := range []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "missing channel",
+			args:        []string{"-n", "mycc"},
+			expectedErr: "The required parameter 'channel' is empty. Rerun the command with -C flag",
+		},
+		{
+			name:        "bad output format",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "-o", "yaml"},
+			expectedErr: "invalid output format yaml, must be either table or json",
+		},
+		{
+			name:        "missing chaincode",
+			args:        []string{"-C", "mychannel"},
+			expectedErr: "The required parameter 'chaincode' is empty. Rerun the command with -n flag",
+		},
+		{
+			name:        "malformed collection",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--collection", "mycc"},
+			expectedErr: "invalid collection mycc, expected <chaincode>:<collection>[,<collection>...]",
+		},
+		{
+			name:        "collection of unknown chaincode",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--collection", "yourcc:col"},
+			expectedErr: "collection yourcc:col refers to chaincode yourcc which isn't queried",
+		},
+		{
+			name:        "malformed key",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--key", "mycc:"},
+			expectedErr: "invalid key mycc:, expected <chaincode>:<key>[,<key>...]",
+		},
+		{
+			name:        "key of unknown chaincode",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--key", "yourcc:k1"},
+			expectedErr: "key yourcc:k1 refers to chaincode yourcc which isn't queried",
+		},
+		{
+			name:        "malformed key policy",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--keyPolicy", "mycc"},
+			expectedErr: "invalid key policy mycc, expected <chaincode>:<policy>",
+		},
+		{
+			name:        "unparsable key policy",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--keyPolicy", "mycc:OR('Org1MSP.peer',Org2MSPpeer)"},
+			expectedErr: "invalid key policy mycc:OR('Org1MSP.peer',Org2MSPpeer): unrecognized token 'Org2MSPpeer' in policy string",
+		},
+		{
+			name:        "malformed sub-policy",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--subPolicy", "mycc:"},
+			expectedErr: "invalid sub-policy mycc:, expected <chaincode>:<sub-policy>",
+		},
+		{
+			name:        "sub-policy of unknown chaincode",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--subPolicy", "yourcc:transfer"},
+			expectedErr: "sub-policy yourcc:transfer refers to chaincode yourcc which isn't queried",
+		},
+		{
+			name:        "multiple sub-policies",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--subPolicy", "mycc:transfer", "--subPolicy", "mycc:read"},
+			expectedErr: "chaincode mycc has more than one sub-policy",
+		},
+		{
+			name:        "duplicate chaincode",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "-n", "mycc"},
+			expectedErr: "chaincode mycc is specified more than once",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			resetFlags()
+			cmd := endorsersCmd(cf)
+			cmd.SetArgs(testCase.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func newCmdFactory(resp discovery.Response, err error) (*DiscoverCmdFactory, *bytes.Buffer) {
+	sender := &mockSender{}
+	sender.On("Send", mock.Anything).Return(resp, err)
+	buff := &bytes.Buffer{}
+	return &DiscoverCmdFactory{
+		Client:   sender,
+		AuthInfo: &discprotos.AuthInfo{},
+		Output:   buff,
+	}, buff
+}
+
+func newPeer(endpoint, mspID string, height uint64, chaincodes ...*gossip.Chaincode) *discovery.Peer {
+	aliveMsg := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_AliveMsg{
+			AliveMsg: &gossip.AliveMessage{
+				Membership: &gossip.Member{Endpoint: endpoint},
+			},
+		},
+	}
+	stateInfoMsg := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{
+				Properties: &gossip.Properties{
+					LedgerHeight: height,
+					Chaincodes:   chaincodes,
+				},
+			},
+		},
+	}
+	return &discovery.Peer{
+		MSPID:            mspID,
+		AliveMessage:     &gossip.SignedGossipMessage{GossipMessage: aliveMsg},
+		StateInfoMessage: &gossip.SignedGossipMessage{GossipMessage: stateInfoMsg},
+	}
+}
+
+type mockSender struct {
+	mock.Mock
+}
+
+func (ms *mockSender) Send(ctx context.Context, req *discovery.Request, auth *discprotos.AuthInfo) (discovery.Response, error) {
+	args := ms.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(discovery.Response), args.Error(1)
+}
+
+type mockResponse struct {
+	mock.Mock
+}
+
+func (mr *mockResponse) ForChannel(string) discovery.ChannelResponse {
+	return mr
+}
+
+func (mr *mockResponse) ForLocal() discovery.LocalResponse {
+	return mr
+}
+
+func (mr *mockResponse) Config() (*discprotos.ConfigResult, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discprotos.ConfigResult), args.Error(1)
+}
+
+func (mr *mockResponse) Peers() ([]*discovery.Peer, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*discovery.Peer), args.Error(1)
+}
+
+func (mr *mockResponse) SnapshotPeers() ([]*discovery.Peer, error) {
+	return mr.Peers()
+}
+
+func (mr *mockResponse) Endorsers(cc string, _ discovery.PrioritySelector, _ discovery.ExclusionFilter) (discovery.Endorsers, error) {
+	args := mr.Called(cc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(discovery.Endorsers), args.Error(1)
+}
+
+func (mr *mockResponse) PolicySimulation() (*discovery.PolicySimulation, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.PolicySimulation), args.Error(1)
+}
+
+func (mr *mockResponse) ConfigUpdateImpact() (*discovery.ConfigUpdateImpact, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.ConfigUpdateImpact), args.Error(1)
+}
+
+func (mr *mockResponse) ChaincodeVersions() ([]*discovery.ChaincodeVersions, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*discovery.ChaincodeVersions), args.Error(1)
+}
+
+func (mr *mockResponse) OrgCombinations() ([]*discovery.OrgCombinations, error) {
+	args := mr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*discovery.OrgCombinations), args.Error(1)
+}
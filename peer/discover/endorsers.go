@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func endorsersCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "endorsers",
+		Short: "Discover endorsers for chaincodes.",
+		Long:  "Discover a set of peers whose endorsements satisfy the endorsement policy of each of the given chaincodes, of the collections they access, and of the keys they write to.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverEndorsers(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"chaincode",
+		"collection",
+		"key",
+		"keyPolicy",
+		"subPolicy",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+		"maxLedgerHeightLag",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func discoverEndorsers(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	if len(chaincodes) == 0 {
+		return errors.New("The required parameter 'chaincode' is empty. Rerun the command with -n flag")
+	}
+	calls, err := chaincodeCalls(chaincodes, collections, keys, keyPolicies, subPolicies)
+	if err != nil {
+		return err
+	}
+	cf, err = prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	req := discovery.NewRequest().OfChannel(channelID).SetMaxLedgerHeightLag(maxHeightLag).AddEndorsersQueryForCalls(calls...)
+	resp, err := cf.send(req)
+	if err != nil {
+		return err
+	}
+
+	endorsersByChaincode := make(map[string][]peerInfo)
+	for _, cc := range chaincodes {
+		endorsers, err := resp.Endorsers(cc, discovery.NoPriorities, discovery.NoExclusion)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed retrieving endorsers for chaincode %s", cc))
+		}
+		endorsersByChaincode[cc] = newPeerInfos(endorsers)
+	}
+
+	if output == jsonOutput {
+		return printJSON(cf.Output, endorsersByChaincode)
+	}
+	for i, cc := range chaincodes {
+		if i > 0 {
+			fmt.Fprintln(cf.Output)
+		}
+		fmt.Fprintf(cf.Output, "Chaincode: %s\n", cc)
+		printPeersTable(cf.Output, endorsersByChaincode[cc])
+	}
+	return nil
+}
+
+// chaincodeCalls returns the chaincode calls of the given chaincodes,
+// along with the collections each of them accesses according to the given
+// collection flags, which are in the form of <chaincode>:<collection>[,<collection>...],
+// the keys each of them writes to according to the given key flags, which are in the form
+// of <chaincode>:<key>[,<key>...], the key-level endorsement policies of the keys
+// each of them writes to according to the given key policy flags, which are in the form
+// of <chaincode>:<policy>, and the sub-policy each of them selects according to the
+// given sub-policy flags, which are in the form of <chaincode>:<sub-policy>
+func chaincodeCalls(chaincodes, collections, keys, keyPolicies, subPolicies []string) ([]*discprotos.ChaincodeCall, error) {
+	var calls []*discprotos.ChaincodeCall
+	callsByName := make(map[string]*discprotos.ChaincodeCall)
+	for _, cc := range chaincodes {
+		if _, exists := callsByName[cc]; exists {
+			return nil, errors.Errorf("chaincode %s is specified more than once", cc)
+		}
+		call := &discprotos.ChaincodeCall{Name: cc}
+		callsByName[cc] = call
+		calls = append(calls, call)
+	}
+	for _, col := range collections {
+		call, names, err := callOfFlag(callsByName, "collection", col, "<chaincode>:<collection>[,<collection>...]")
+		if err != nil {
+			return nil, err
+		}
+		call.CollectionNames = append(call.CollectionNames, strings.Split(names, ",")...)
+	}
+	for _, key := range keys {
+		call, names, err := callOfFlag(callsByName, "key", key, "<chaincode>:<key>[,<key>...]")
+		if err != nil {
+			return nil, err
+		}
+		call.KeyNames = append(call.KeyNames, strings.Split(names, ",")...)
+	}
+	for _, keyPolicy := range keyPolicies {
+		call, policy, err := callOfFlag(callsByName, "key policy", keyPolicy, "<chaincode>:<policy>")
+		if err != nil {
+			return nil, err
+		}
+		envelope, err := cauthdsl.FromString(policy)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid key policy %s", keyPolicy))
+		}
+		call.KeyPolicies = append(call.KeyPolicies, envelope)
+	}
+	for _, subPolicy := range subPolicies {
+		call, name, err := callOfFlag(callsByName, "sub-policy", subPolicy, "<chaincode>:<sub-policy>")
+		if err != nil {
+			return nil, err
+		}
+		if call.SubPolicy != "" {
+			return nil, errors.Errorf("chaincode %s has more than one sub-policy", call.Name)
+		}
+		call.SubPolicy = name
+	}
+	return calls, nil
+}
+
+// callOfFlag returns the chaincode call that the given flag value, which is in the form
+// of <chaincode>:<value>, refers to, along with the value
+func callOfFlag(callsByName map[string]*discprotos.ChaincodeCall, flag, flagValue, format string) (*discprotos.ChaincodeCall, string, error) {
+	s := strings.SplitN(flagValue, ":", 2)
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return nil, "", errors.Errorf("invalid %s %s, expected %s", flag, flagValue, format)
+	}
+	call, exists := callsByName[s[0]]
+	if !exists {
+		return nil, "", errors.Errorf("%s %s refers to chaincode %s which isn't queried", flag, flagValue, s[0])
+	}
+	return call, s[1], nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/proto"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func impactCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "impact",
+		Short: "Discover the impact of a config update.",
+		Long: "Discover how a config update would change the configuration of a channel: which policies it adds, removes " +
+			"or modifies, which MSPs it adds or removes, and which alive peers of the channel it would eject. " +
+			"The config update doesn't need to be signed.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverImpact(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"configUpdate",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+// policyChangeInfo is the printable form of a policy change
+type policyChangeInfo struct {
+	Path   string `json:"path"`
+	Change string `json:"change"`
+}
+
+// ejectedPeerInfo is the printable form of a peer a config update would eject
+type ejectedPeerInfo struct {
+	MSPID    string `json:"mspid"`
+	Endpoint string `json:"endpoint"`
+	Reason   string `json:"reason"`
+}
+
+// impactInfo is the printable form of the impact of a config update
+type impactInfo struct {
+	PolicyChanges []policyChangeInfo `json:"policy_changes"`
+	AddedMSPs     []string           `json:"added_msps"`
+	RemovedMSPs   []string           `json:"removed_msps"`
+	EjectedPeers  []ejectedPeerInfo  `json:"ejected_peers"`
+}
+
+func discoverImpact(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	if configUpdateFile == common.UndefinedParamValue {
+		return errors.New("The required parameter 'configUpdate' is empty. Rerun the command with --configUpdate flag")
+	}
+	configUpdate, err := readConfigUpdate(configUpdateFile)
+	if err != nil {
+		return err
+	}
+	cf, err = prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.send(discovery.NewRequest().OfChannel(channelID).AddConfigUpdateImpactQuery(configUpdate))
+	if err != nil {
+		return err
+	}
+	impact, err := resp.ConfigUpdateImpact()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving config update impact")
+	}
+
+	info := newImpactInfo(impact)
+	if output == jsonOutput {
+		return printJSON(cf.Output, info)
+	}
+	printImpact(cf.Output, info)
+	return nil
+}
+
+// readConfigUpdate returns the marshaled config update in the given file, which contains either
+// a marshaled common.ConfigUpdate, or an envelope of a config update such as peer channel update submits
+func readConfigUpdate(file string) ([]byte, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading config update")
+	}
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(raw, env); err == nil && len(env.Payload) > 0 {
+		configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+		if _, err := utils.UnmarshalEnvelopeOfTypes(env, []cb.HeaderType{cb.HeaderType_CONFIG_UPDATE}, configUpdateEnv); err == nil {
+			return configUpdateEnv.ConfigUpdate, nil
+		}
+	}
+	return raw, nil
+}
+
+func newImpactInfo(impact *discovery.ConfigUpdateImpact) impactInfo {
+	info := impactInfo{
+		PolicyChanges: []policyChangeInfo{},
+		AddedMSPs:     append([]string{}, impact.AddedMSPs...),
+		RemovedMSPs:   append([]string{}, impact.RemovedMSPs...),
+		EjectedPeers:  []ejectedPeerInfo{},
+	}
+	for _, change := range impact.PolicyChanges {
+		info.PolicyChanges = append(info.PolicyChanges, policyChangeInfo{
+			Path:   change.Path,
+			Change: strings.ToLower(change.Type.String()),
+		})
+	}
+	for _, p := range impact.EjectedPeers {
+		info.EjectedPeers = append(info.EjectedPeers, ejectedPeerInfo{
+			MSPID:    p.MSPID,
+			Endpoint: p.AliveMessage.GetAliveMsg().GetMembership().GetEndpoint(),
+			Reason:   p.Reason,
+		})
+	}
+	sort.Slice(info.EjectedPeers, func(i, j int) bool {
+		if info.EjectedPeers[i].MSPID != info.EjectedPeers[j].MSPID {
+			return info.EjectedPeers[i].MSPID < info.EjectedPeers[j].MSPID
+		}
+		return info.EjectedPeers[i].Endpoint < info.EjectedPeers[j].Endpoint
+	})
+	return info
+}
+
+func printImpact(w io.Writer, info impactInfo) {
+	fmt.Fprintln(w, "Policy changes:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tCHANGE")
+	for _, change := range info.PolicyChanges {
+		fmt.Fprintf(tw, "%s\t%s\n", change.Path, change.Change)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nAdded MSPs: %s\n", listOrNone(info.AddedMSPs))
+	fmt.Fprintf(w, "Removed MSPs: %s\n", listOrNone(info.RemovedMSPs))
+
+	fmt.Fprintln(w, "\nEjected peers:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MSP ID\tENDPOINT\tREASON")
+	for _, p := range info.EjectedPeers {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", p.MSPID, p.Endpoint, p.Reason)
+	}
+	tw.Flush()
+}
+
+func listOrNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func orgsCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orgs",
+		Short: "Discover the organizations required to endorse chaincodes.",
+		Long: "Discover the combinations of organizations whose endorsements satisfy the endorsement policy of each of the given chaincodes, " +
+			"of the collections they access, and of the keys they write to. Unlike endorsers, the peers of the organizations aren't resolved.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverOrgs(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"chaincode",
+		"collection",
+		"key",
+		"keyPolicy",
+		"subPolicy",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func discoverOrgs(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	if len(chaincodes) == 0 {
+		return errors.New("The required parameter 'chaincode' is empty. Rerun the command with -n flag")
+	}
+	calls, err := chaincodeCalls(chaincodes, collections, keys, keyPolicies, subPolicies)
+	if err != nil {
+		return err
+	}
+	cf, err = prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.send(discovery.NewRequest().OfChannel(channelID).AddOrgCombinationsQuery(calls...))
+	if err != nil {
+		return err
+	}
+	combinations, err := resp.OrgCombinations()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving org combinations")
+	}
+
+	combinationsByChaincode := make(map[string][]map[string]uint32)
+	for _, ccCombinations := range combinations {
+		combinationsByChaincode[ccCombinations.Chaincode] = ccCombinations.Combinations
+	}
+
+	if output == jsonOutput {
+		return printJSON(cf.Output, combinationsByChaincode)
+	}
+	for i, cc := range chaincodes {
+		if i > 0 {
+			fmt.Fprintln(cf.Output)
+		}
+		fmt.Fprintf(cf.Output, "Chaincode: %s\n", cc)
+		printOrgCombinationsTable(cf.Output, combinationsByChaincode[cc])
+	}
+	return nil
+}
+
+func printOrgCombinationsTable(w io.Writer, combinations []map[string]uint32) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMBINATION\tENDORSEMENTS BY MSP ID")
+	for i, combination := range combinations {
+		var orgs []string
+		for mspID, quantity := range combination {
+			orgs = append(orgs, fmt.Sprintf("%s:%d", mspID, quantity))
+		}
+		sort.Strings(orgs)
+		fmt.Fprintf(tw, "%d\t%s\n", i+1, strings.Join(orgs, ", "))
+	}
+	tw.Flush()
+}
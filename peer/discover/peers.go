@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func peersCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "peers",
+		Short: "Discover the peers of a channel.",
+		Long:  "Discover the alive peers of a channel, along with their ledger heights and installed chaincodes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverPeers(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func discoverPeers(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	cf, err := prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.send(discovery.NewRequest().OfChannel(channelID).AddPeersQuery())
+	if err != nil {
+		return err
+	}
+	peers, err := resp.Peers()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving peers")
+	}
+
+	infos := newPeerInfos(peers)
+	if output == jsonOutput {
+		return printJSON(cf.Output, infos)
+	}
+	printPeersTable(cf.Output, infos)
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	versionMatches      = "ok"
+	versionMismatch     = "version mismatch"
+	versionNotInstalled = "not installed"
+	versionNotDefined   = "not defined"
+)
+
+func versionsCmd(cf *DiscoverCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions",
+		Short: "Discover the installed versions of chaincodes.",
+		Long: "Discover the versions of chaincodes installed on the alive peers of a channel, compared to the versions " +
+			"of the chaincode definitions in the channel. Only peers that have the defined version installed are endorsers.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return discoverVersions(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channel",
+		"chaincode",
+		"output",
+		"peerAddress",
+		"tlsRootCertFile",
+		"timeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+// installedVersionInfo is the printable form of the version of a chaincode installed on a peer
+type installedVersionInfo struct {
+	MSPID            string `json:"mspid"`
+	Endpoint         string `json:"endpoint"`
+	InstalledVersion string `json:"installed_version,omitempty"`
+	Status           string `json:"status"`
+}
+
+// chaincodeVersionsInfo is the printable form of the versions of a chaincode
+type chaincodeVersionsInfo struct {
+	DefinedVersion string                 `json:"defined_version,omitempty"`
+	Peers          []installedVersionInfo `json:"peers"`
+}
+
+func discoverVersions(cmd *cobra.Command, cf *DiscoverCmdFactory) error {
+	if len(chaincodes) == 0 {
+		return errors.New("The required parameter 'chaincode' is empty. Rerun the command with -n flag")
+	}
+	cf, err := prepare(cmd, cf)
+	if err != nil {
+		return err
+	}
+	resp, err := cf.send(discovery.NewRequest().OfChannel(channelID).AddChaincodeVersionsQuery(chaincodes...))
+	if err != nil {
+		return err
+	}
+	versions, err := resp.ChaincodeVersions()
+	if err != nil {
+		return errors.WithMessage(err, "failed retrieving chaincode versions")
+	}
+
+	versionsByChaincode := make(map[string]chaincodeVersionsInfo)
+	for _, ccVersions := range versions {
+		versionsByChaincode[ccVersions.Chaincode] = newChaincodeVersionsInfo(ccVersions)
+	}
+
+	if output == jsonOutput {
+		return printJSON(cf.Output, versionsByChaincode)
+	}
+	for i, cc := range chaincodes {
+		if i > 0 {
+			fmt.Fprintln(cf.Output)
+		}
+		info := versionsByChaincode[cc]
+		definedVersion := info.DefinedVersion
+		if definedVersion == "" {
+			definedVersion = versionNotDefined
+		}
+		fmt.Fprintf(cf.Output, "Chaincode: %s, defined version: %s\n", cc, definedVersion)
+		printVersionsTable(cf.Output, info.Peers)
+	}
+	return nil
+}
+
+func newChaincodeVersionsInfo(ccVersions *discovery.ChaincodeVersions) chaincodeVersionsInfo {
+	info := chaincodeVersionsInfo{
+		DefinedVersion: ccVersions.DefinedVersion,
+		Peers:          []installedVersionInfo{},
+	}
+	for version, peers := range ccVersions.PeersByInstalledVersion {
+		status := versionMatches
+		switch {
+		case version == "":
+			status = versionNotInstalled
+		case version != ccVersions.DefinedVersion:
+			status = versionMismatch
+		}
+		for _, p := range peers {
+			info.Peers = append(info.Peers, installedVersionInfo{
+				MSPID:            p.MSPID,
+				Endpoint:         p.AliveMessage.GetAliveMsg().GetMembership().GetEndpoint(),
+				InstalledVersion: version,
+				Status:           status,
+			})
+		}
+	}
+	sort.Slice(info.Peers, func(i, j int) bool {
+		if info.Peers[i].MSPID != info.Peers[j].MSPID {
+			return info.Peers[i].MSPID < info.Peers[j].MSPID
+		}
+		return info.Peers[i].Endpoint < info.Peers[j].Endpoint
+	})
+	return info
+}
+
+func printVersionsTable(w io.Writer, peers []installedVersionInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MSP ID\tENDPOINT\tINSTALLED VERSION\tSTATUS")
+	for _, p := range peers {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.MSPID, p.Endpoint, p.InstalledVersion, p.Status)
+	}
+	tw.Flush()
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/fabric/peer/channel"
 	"github.com/hyperledger/fabric/peer/clilogging"
 	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/discover"
 	"github.com/hyperledger/fabric/peer/node"
+	"github.com/hyperledger/fabric/peer/profile"
 	"github.com/hyperledger/fabric/peer/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -49,12 +51,16 @@ func main() {
 
 	mainFlags.String("logging-level", "", "Default logging level and overrides, see core.yaml for full syntax")
 	viper.BindPFlag("logging_level", mainFlags.Lookup("logging-level"))
+	// the profile is applied before the command line is parsed, see common.SelectedProfile
+	mainFlags.String(common.ProfileFlag, "", "Profile of the peer CLI to use, see 'peer profile'")
 
 	mainCmd.AddCommand(version.Cmd())
 	mainCmd.AddCommand(node.Cmd())
 	mainCmd.AddCommand(chaincode.Cmd(nil))
 	mainCmd.AddCommand(clilogging.Cmd(nil))
 	mainCmd.AddCommand(channel.Cmd(nil))
+	mainCmd.AddCommand(discover.Cmd(nil))
+	mainCmd.AddCommand(profile.Cmd())
 
 	err := common.InitConfig(cmdRoot)
 	if err != nil { // Handle errors reading the config file
@@ -62,6 +68,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// profiles configure the peer CLI, they don't apply to the peer node
+	if cmd, _, err := mainCmd.Find(os.Args[1:]); err != nil || !strings.HasPrefix(cmd.CommandPath(), "peer node") {
+		if err := common.InitProfile(os.Args[1:]); err != nil {
+			logger.Errorf("Fatal error when applying the profile of the peer CLI : %s", err)
+			os.Exit(1)
+		}
+	}
+
 	// setup system-wide logging backend based on settings from core.yaml
 	flogging.InitBackend(flogging.SetFormat(viper.GetString("logging.format")), logOutput)
 
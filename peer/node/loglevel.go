@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var expiryMinutes uint32
+
+// getAdminClient returns the client of the admin service of the peer
+var getAdminClient = common.GetAdminClient
+
+func logLevelCmd() *cobra.Command {
+	flags := nodeLogLevelCmd.Flags()
+	flags.Uint32VarP(&expiryMinutes, "expiry", "e", 0,
+		"Minutes after which the loggers revert to their previous levels. The levels are kept if zero")
+
+	return nodeLogLevelCmd
+}
+
+var nodeLogLevelCmd = &cobra.Command{
+	Use:   "loglevel <logger pattern> [<log level>]",
+	Short: "Gets or sets the log levels of loggers.",
+	Long: "Gets the log levels of the loggers whose name matches the pattern, in which '*' matches " +
+		"any sequence of characters, or sets them when a log level is provided. Levels that are set " +
+		"with an expiry revert to the levels the loggers had before once it elapses.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("no logger pattern provided")
+		}
+		if len(args) > 2 {
+			return fmt.Errorf("trailing args detected: %s", args[2:])
+		}
+		if len(args) == 2 {
+			if err := common.CheckLogLevel(args[1]); err != nil {
+				return err
+			}
+		} else if expiryMinutes != 0 {
+			return errors.New("an expiry can only be provided when setting log levels")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return logLevel(args)
+	},
+}
+
+func logLevel(args []string) error {
+	adminClient, err := getAdminClient()
+	if err != nil {
+		return err
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	request := &pb.LoggerLevelsRequest{LoggerPattern: args[0]}
+	if len(args) == 2 {
+		request.LogLevel = args[1]
+		request.ExpiryMinutes = expiryMinutes
+	}
+	env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "",
+		crypto.NewSignatureHeaderCreator(signer), &pb.AdminOperation{
+			Content: &pb.AdminOperation_LoggerLevelsReq{LoggerLevelsReq: request},
+		}, 0, 0)
+	if err != nil {
+		return errors.Errorf("failed signing request: %v", err)
+	}
+
+	var response *pb.LoggerLevelsResponse
+	if len(args) == 2 {
+		response, err = adminClient.SetLoggerLevels(context.Background(), env)
+	} else {
+		response, err = adminClient.GetLoggerLevels(context.Background(), env)
+	}
+	if err != nil {
+		return err
+	}
+	for _, loggerLevel := range response.Loggers {
+		fmt.Printf("%s: %s\n", loggerLevel.Logger, loggerLevel.Level)
+	}
+	return nil
+}
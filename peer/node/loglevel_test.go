@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/mocks"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelCmd(t *testing.T) {
+	common.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return &mocks.Signer{}, nil
+	}
+	defer func() { getAdminClient = common.GetAdminClient }()
+
+	var tests = []struct {
+		name        string
+		args        []string
+		clientErr   error
+		expectedErr string
+	}{
+		{name: "get", args: []string{"gossip/*"}},
+		{name: "set", args: []string{"gossip/*", "debug"}},
+		{name: "set with expiry", args: []string{"gossip/*", "debug", "--expiry", "30"}},
+		{name: "no pattern", args: []string{}, expectedErr: "no logger pattern provided"},
+		{name: "trailing args", args: []string{"gossip/*", "debug", "foo"}, expectedErr: "trailing args detected: [foo]"},
+		{name: "invalid level", args: []string{"gossip/*", "foo"}, expectedErr: "invalid log level provided - foo"},
+		{name: "expiry without level", args: []string{"gossip/*", "--expiry", "30"},
+			expectedErr: "an expiry can only be provided when setting log levels"},
+		{name: "admin service error", args: []string{"gossip/*"}, clientErr: errors.New("access denied"),
+			expectedErr: "access denied"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			expiryMinutes = 0
+			getAdminClient = func() (pb.AdminClient, error) {
+				return common.GetMockAdminClient(test.clientErr), nil
+			}
+			cmd := logLevelCmd()
+			cmd.SetArgs(test.args)
+			err := cmd.Execute()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestLogLevelAdminClientError(t *testing.T) {
+	getAdminClient = func() (pb.AdminClient, error) {
+		return nil, errors.New("failed connecting to peer")
+	}
+	defer func() { getAdminClient = common.GetAdminClient }()
+
+	assert.EqualError(t, logLevel([]string{"gossip/*"}), "failed connecting to peer")
+}
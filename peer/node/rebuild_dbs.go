@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/spf13/cobra"
+)
+
+func rebuildDBsCmd() *cobra.Command {
+	return nodeRebuildDBsCmd
+}
+
+var nodeRebuildDBsCmd = &cobra.Command{
+	Use:   "rebuild-dbs",
+	Short: "Rebuilds databases.",
+	Long: "Drops the state, history and other databases of all channels that are derived from the blocks. " +
+		"When the command is executed, the peer must be offline. When the peer starts after the command, it rebuilds " +
+		"the databases from the blocks of the channels. Use the command when the peer reports at start that the " +
+		"databases of a channel are inconsistent with its blocks.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return ledgermgmt.RebuildDBs()
+	},
+}
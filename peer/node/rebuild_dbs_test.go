@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildDBsCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rebuilddbscmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	fileSystemPath := viper.GetString("peer.fileSystemPath")
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Set("peer.fileSystemPath", fileSystemPath)
+
+	cmd := rebuildDBsCmd()
+
+	cmd.SetArgs([]string{"trailing"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [trailing]")
+
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute())
+
+	// the command doesn't run while the ledger data is locked by a running peer
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	assert.NoError(t, fileLock.Lock())
+	defer fileLock.Unlock()
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the ledger data is in use")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var channelID string
+var blockNumber uint64
+
+func rollbackCmd() *cobra.Command {
+	// Set the flags on the node rollback command.
+	flags := nodeRollbackCmd.Flags()
+	flags.StringVarP(&channelID, "channel", "c", common.UndefinedParamValue, "Channel to rollback")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number to which the channel needs to be rolled back to")
+
+	return nodeRollbackCmd
+}
+
+var nodeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rolls back a channel.",
+	Long: "Rolls back a channel to a specified block number. When the command is executed, the peer must be offline. " +
+		"When the peer starts after the rollback, it rebuilds the state and history databases of all the channels " +
+		"from their blocks, and receives the blocks removed during the rollback, along with their private data, " +
+		"from the orderer or other peers again.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return ledgermgmt.RollbackLedger(channelID, blockNumber)
+	},
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rollbackcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	fileSystemPath := viper.GetString("peer.fileSystemPath")
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Set("peer.fileSystemPath", fileSystemPath)
+
+	cmd := rollbackCmd()
+
+	cmd.SetArgs([]string{"-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd.SetArgs([]string{"-c", "ch1", "-b", "10", "trailing"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [trailing]")
+
+	cmd.SetArgs([]string{"-c", "ch1", "-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "ledger [ch1] does not exist")
+
+	// the command doesn't run while the ledger data is locked by a running peer
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	assert.NoError(t, fileLock.Lock())
+	defer fileLock.Unlock()
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the ledger data is in use")
+}
+
+func TestResetCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "resetcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	fileSystemPath := viper.GetString("peer.fileSystemPath")
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Set("peer.fileSystemPath", fileSystemPath)
+
+	cmd := resetCmd()
+
+	cmd.SetArgs([]string{"trailing"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [trailing]")
+
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute())
+
+	// the command doesn't run while the ledger data is locked by a running peer
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	assert.NoError(t, fileLock.Lock())
+	defer fileLock.Unlock()
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the ledger data is in use")
+}
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,8 +24,12 @@ import (
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/healthz"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/admin"
@@ -32,19 +39,29 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/gateway"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
 	"github.com/hyperledger/fabric/core/handlers/library"
+	principalHandler "github.com/hyperledger/fabric/core/handlers/principal/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
@@ -55,6 +72,9 @@ import (
 	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/election"
+	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -63,9 +83,13 @@ import (
 	"github.com/hyperledger/fabric/peer/version"
 	cb "github.com/hyperledger/fabric/protos/common"
 	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	tokenpb "github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/server"
+	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -77,6 +101,7 @@ const (
 	chaincodeAddrKey       = "peer.chaincodeAddress"
 	chaincodeListenAddrKey = "peer.chaincodeListenAddress"
 	defaultChaincodePort   = 7052
+	healthCheckTimeout     = 10 * time.Second
 )
 
 var chaincodeDevMode bool
@@ -142,8 +167,15 @@ func serve(args []string) error {
 	//Users can pass in their own ACLProvider to RegisterACLProvider (currently unit tests do this)
 	aclProvider := aclmgmt.NewACLProvider() // TODO: provide resource getter / peer.GetStableChannelConfig
 
+	// the ledger data is locked while the peer runs, such that it is not modified offline meanwhile
+	ledgerFileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := ledgerFileLock.Lock(); err != nil {
+		return errors.WithMessage(err, "failed locking the ledger data, a peer node or a command that modifies the ledger data offline may be running")
+	}
+	defer ledgerFileLock.Unlock()
+
 	//initialize resource management exit
-	ledgermgmt.Initialize(peer.ConfigTxProcessors)
+	ledgermgmt.Initialize(customTxProcessors(aclProvider))
 
 	// Parameter overrides must be processed before any parameters are
 	// cached. Failures to cache cause the server to terminate immediately.
@@ -201,6 +233,17 @@ func serve(args []string) error {
 		grpclog.Fatalf("Failed to create ehub server: %v", err)
 	}
 
+	// the TLS credentials of the servers are reloaded without a restart
+	// when their files change, and over the admin service
+	var tlsReloader *comm.CredentialsReloader
+	if serverConfig.SecOpts.UseTLS {
+		tlsReloader = comm.NewCredentialsReloader(peer.LoadTLSCredentials, peer.GetTLSCredentialFiles(), peerServer, ehubGrpcServer)
+		if interval := viper.GetDuration("peer.tls.watchInterval"); interval > 0 {
+			tlsReloader.Watch(interval)
+			defer tlsReloader.Stop()
+		}
+	}
+
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	policyCheckerProvider := func(resourceName string) deliver.PolicyCheckerFunc {
 		return func(env *cb.Envelope, channelID string) error {
@@ -232,7 +275,7 @@ func serve(args []string) error {
 	logger.Debugf("Running peer")
 
 	// Start the Admin server
-	startAdminServer(listenAddr, peerServer.Server())
+	startAdminServer(listenAddr, peerServer.Server(), lscc.New(sccp, aclProvider), tlsReloader)
 
 	privDataDist := func(channel string, txID string, privateData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error {
 		return service.GetGossipService().DistributePrivateData(channel, txID, privateData, blkHt)
@@ -272,6 +315,10 @@ func serve(args []string) error {
 	})
 	endorserSupport.PluginEndorser = pluginEndorser
 	serverEndorser := endorser.NewEndorserServer(privDataDist, endorserSupport)
+	serverEndorser.Limits = endorser.SimulationLimits{
+		MaxRWSetSize:       viper.GetInt("peer.endorser.maxRWSetSize"),
+		MaxPrivateDataSize: viper.GetInt("peer.endorser.maxPrivateDataSize"),
+	}
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
@@ -324,6 +371,33 @@ func serve(args []string) error {
 		certs.TLSClientCert.Store(&clientCert)
 	}
 
+	// The health of the components the peer depends on is served alongside the metrics
+	healthHandler := healthz.NewHealthHandler(healthCheckTimeout)
+	if err := registerHealthCheckers(healthHandler); err != nil {
+		return err
+	}
+	metricsOpts := metrics.NewOpts()
+	metricsOpts.PromReporterOpts.Handlers = map[string]http.Handler{"/healthz": healthHandler}
+
+	// Initialize metrics before the gossip service, so that it could report metrics.
+	// If metrics aren't enabled, the metrics reported are discarded.
+	if err := metrics.Init(metricsOpts); err != nil {
+		return errors.Wrap(err, "failed initializing metrics")
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Error starting metrics server: %s", err)
+		}
+	}()
+	defer metrics.Shutdown()
+
+	// Spans of the transactions endorsed and committed by the peer are recorded
+	// in the traces of the transactions, if tracing is enabled
+	if err := tracing.Init(tracing.NewOpts()); err != nil {
+		return errors.Wrap(err, "failed initializing tracing")
+	}
+	defer tracing.Shutdown()
+
 	err = service.InitGossipService(serializedIdentity, peerEndpoint.Address, peerServer.Server(), certs,
 		messageCryptoService, secAdv, secureDialOpts, bootstrap...)
 	if err != nil {
@@ -360,10 +434,30 @@ func serve(args []string) error {
 			logger.Panicf("Failed subscribing to chaincode lifecycle updates")
 		}
 		cceventmgmt.GetMgr().Register(cid, sub)
+		// Advertise the snapshots the peer exported, for peers joining the channel to discover them
+		peer.PublishSnapshotHeights(cid)
 	}, ccp, sccp, txvalidator.MapBasedPluginMapper(validationPluginsByName))
 
+	// Build, and optionally launch, the chaincodes of the channels in the background,
+	// so that their first invocations after the restart don't wait for them
+	if !chaincode.IsDevMode() {
+		warmUp := chaincode.NewWarmUp(chaincode.GlobalConfig(), chaincodeSupport, &ccprovider.CCInfoFSImpl{})
+		go warmUp.Run(channelIDs(), newQueryExecutor)
+	}
+
 	if viper.GetBool("peer.discovery.enabled") {
-		registerDiscoveryService(peerServer, messageCryptoService, lifecycle)
+		evaluatorFactory, _ := reg.Lookup(library.PrincipalEvaluation).(principalHandler.PluginFactory)
+		registerDiscoveryService(peerServer, messageCryptoService, lifecycle, evaluatorFactory)
+	}
+
+	if viper.GetBool("peer.gateway.enabled") {
+		evaluatorFactory, _ := reg.Lookup(library.PrincipalEvaluation).(principalHandler.PluginFactory)
+		registerGatewayService(peerServer, auth, peerEndpoint.Address, secureDialOpts, aclProvider, messageCryptoService, lifecycle, evaluatorFactory)
+	}
+
+	if viper.GetBool("peer.token.enabled") {
+		logger.Info("Starting token prover service")
+		tokenpb.RegisterProverServer(peerServer.Server(), server.NewProver(aclProvider, newQueryExecutor, localmsp.NewSigner()))
 	}
 
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]",
@@ -381,6 +475,27 @@ func serve(args []string) error {
 		serve <- nil
 	}()
 
+	// SIGHUP reloads the configuration file and publishes the gossip
+	// external endpoint found in it, and reloads the TLS credentials
+	// without a restart
+	reloadSigs := make(chan os.Signal, 1)
+	signal.Notify(reloadSigs, syscall.SIGHUP)
+	go func() {
+		for range reloadSigs {
+			if tlsReloader != nil {
+				if _, err := tlsReloader.Reload(); err != nil {
+					logger.Errorf("Failed reloading TLS credentials: %+v", err)
+				}
+			}
+			endpoint, err := reloadGossipEndpoint()
+			if err != nil {
+				logger.Errorf("Failed reloading gossip endpoint: %+v", err)
+				continue
+			}
+			logger.Infof("Reloaded gossip external endpoint: %s", endpoint)
+		}
+	}()
+
 	go func() {
 		var grpcErr error
 		if grpcErr = peerServer.Start(); grpcErr != nil {
@@ -424,26 +539,214 @@ func localPolicy(policyObject proto.Message) policies.Policy {
 	return policy
 }
 
-func registerDiscoveryService(peerServer *comm.GRPCServer, mcs api.MessageCryptoService, lc *cc.Lifecycle) {
+// principalEvaluator returns the principal evaluator the endorsement analyzer uses,
+// which is the given default evaluator unless a principal evaluation handler replaces it
+func principalEvaluator(defaultEvaluator principalHandler.Evaluator, factory principalHandler.PluginFactory) principalHandler.Evaluator {
+	if factory == nil {
+		return defaultEvaluator
+	}
+	evaluator := factory.New(defaultEvaluator)
+	if evaluator == nil {
+		logger.Warning("Principal evaluation handler returned no evaluator, using the default principal evaluation")
+		return defaultEvaluator
+	}
+	return evaluator
+}
+
+// channelIDs returns the IDs of the channels the peer is joined to
+func channelIDs() []string {
+	var channels []string
+	for _, channel := range peer.GetChannelsInfo() {
+		channels = append(channels, channel.ChannelId)
+	}
+	return channels
+}
+
+// customTxProcessors returns the processors of the transactions that the ledger
+// commits without chaincodes: config transactions and token transactions
+func customTxProcessors(aclProvider aclmgmt.ACLProvider) customtx.Processors {
+	processors := customtx.Processors{}
+	for txType, processor := range peer.ConfigTxProcessors {
+		processors[txType] = processor
+	}
+	processors[cb.HeaderType_TOKEN_TRANSACTION] = &transaction.Processor{ACLProvider: aclProvider}
+	return processors
+}
+
+// newQueryExecutor creates a query executor on the ledger of the given channel
+func newQueryExecutor(channel string) (ledger.QueryExecutor, error) {
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("channel %s doesn't exist", channel)
+	}
+	return l.NewQueryExecutor()
+}
+
+// stateMetadata returns the metadata of the given key of the given chaincode
+// in the ledger of the given channel
+func stateMetadata(channel string, cc string, key string) (map[string][]byte, error) {
+	ledger := peer.GetLedger(channel)
+	if ledger == nil {
+		return nil, errors.Errorf("channel %s doesn't exist", channel)
+	}
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer qe.Done()
+	return qe.GetStateMetadata(cc, key)
+}
+
+// discoveryACLSupport returns the access control support of the discovery service
+func discoveryACLSupport(mcs api.MessageCryptoService) *discacl.DiscoverySupport {
 	mspID := viper.GetString("peer.localMspId")
 	localAccessPolicy := localPolicy(cauthdsl.SignedByAnyAdmin([]string{mspID}))
 	if viper.GetBool("peer.discovery.orgMembersAllowedAccess") {
 		localAccessPolicy = localPolicy(cauthdsl.SignedByAnyMember([]string{mspID}))
 	}
-	acl := discacl.NewDiscoverySupport(mcs, localAccessPolicy, discacl.ChannelConfigGetterFunc(peer.GetChannelConfig))
+	return discacl.NewDiscoverySupport(mcs, localAccessPolicy, discacl.ChannelConfigGetterFunc(peer.GetChannelConfig))
+}
+
+// endorsementAnalyzer returns an analyzer that computes the endorsement descriptors of chaincodes
+func endorsementAnalyzer(gSup *gossip.DiscoverySupport, acl *discacl.DiscoverySupport, lc *cc.Lifecycle, evaluatorFactory principalHandler.PluginFactory) discovery.EndorsementSupport {
+	ccSup := ccsupport.NewDiscoverySupport(lc, ccsupport.StateMetadataRetrieverFunc(stateMetadata))
+	ea := endorsement.NewEndorsementAnalyzer(gSup, ccSup, principalEvaluator(acl, evaluatorFactory), lc)
+	if lag := viper.GetInt("peer.discovery.maxLedgerHeightLag"); lag > 0 {
+		ea.SetMaxLedgerHeightLag(uint64(lag))
+	}
+	return ea
+}
+
+func registerDiscoveryService(peerServer *comm.GRPCServer, mcs api.MessageCryptoService, lc *cc.Lifecycle, evaluatorFactory principalHandler.PluginFactory) {
+	acl := discoveryACLSupport(mcs)
 	gSup := gossip.NewDiscoverySupport(service.GetGossipService())
-	ccSup := ccsupport.NewDiscoverySupport(lc)
-	ea := endorsement.NewEndorsementAnalyzer(gSup, ccSup, acl, lc)
+	ea := endorsementAnalyzer(gSup, acl, lc, evaluatorFactory)
 	confSup := config.NewDiscoverySupport(config.CurrentConfigBlockGetterFunc(peer.GetCurrConfigBlock))
+	overrides, err := ordererEndpointOverrides()
+	if err != nil {
+		logger.Panicf("Failed loading orderer endpoint overrides: %v", err)
+	}
+	if err := confSup.SetOrdererEndpointOverrides(overrides); err != nil {
+		logger.Panicf("Failed setting orderer endpoint overrides: %v", err)
+	}
 	support := discsupport.NewDiscoverySupport(acl, gSup, ea, confSup, acl)
 	svc := discovery.NewService(discovery.Config{
 		TLS:                          peerServer.TLSEnabled(),
 		AuthCacheEnabled:             viper.GetBool("peer.discovery.authCacheEnabled"),
 		AuthCacheMaxSize:             viper.GetInt("peer.discovery.authCacheMaxSize"),
 		AuthCachePurgeRetentionRatio: viper.GetFloat64("peer.discovery.authCachePurgeRetentionRatio"),
+		QueryJournalPath:             viper.GetString("peer.discovery.queryJournalPath"),
+		ResponseSigner:               mgmt.GetLocalSigningIdentityOrPanic(),
+		QueryConcurrency:             viper.GetInt("peer.discovery.queryConcurrency"),
 	}, support)
 	logger.Info("Discovery service activated")
 	discprotos.RegisterDiscoveryServer(peerServer.Server(), svc)
+	registeredDiscoveryService.Lock()
+	registeredDiscoveryService.stats = svc.Stats
+	registeredDiscoveryService.Unlock()
+	if viper.GetBool("peer.discovery.gateway.enabled") {
+		startDiscoveryGateway(svc.HTTPHandler(), peerServer)
+	}
+}
+
+func registerGatewayService(peerServer *comm.GRPCServer, localEndorser pb.EndorserServer, endpoint string, secureDialOpts func() []grpc.DialOption,
+	aclProvider aclmgmt.ACLProvider, mcs api.MessageCryptoService, lc *cc.Lifecycle, evaluatorFactory principalHandler.PluginFactory) {
+	gSup := gossip.NewDiscoverySupport(service.GetGossipService())
+	support := &gatewaySupport{
+		EndorsementSupport: endorsementAnalyzer(gSup, discoveryACLSupport(mcs), lc, evaluatorFactory),
+		ACLProvider:        aclProvider,
+	}
+	endorserClients := gateway.EndorserClients(func(endpoint string) (*grpc.ClientConn, error) {
+		return grpc.Dial(endpoint, secureDialOpts()...)
+	})
+	broadcastClients := gateway.BroadcastClients(func(channel string) gateway.Dialer {
+		return deliverclient.DefaultConnectionFactory(channel)
+	})
+	srv := gateway.NewServer(gateway.Config{
+		Endpoint:              endpoint,
+		EndorsementTimeout:    viper.GetDuration("peer.gateway.endorsementTimeout"),
+		BroadcastTimeout:      viper.GetDuration("peer.gateway.broadcastTimeout"),
+		CommitTimeout:         viper.GetDuration("peer.gateway.commitTimeout"),
+		CommitStatusRetention: uint64(viper.GetInt("peer.gateway.commitStatusRetention")),
+	}, localEndorser, support, endorserClients, broadcastClients)
+	logger.Info("Gateway service activated")
+	gp.RegisterGatewayServer(peerServer.Server(), srv)
+}
+
+// gatewaySupport provides the gateway service with the endorsement descriptors,
+// the orderer endpoints, the ledgers and the access control of channels
+type gatewaySupport struct {
+	discovery.EndorsementSupport
+	aclmgmt.ACLProvider
+}
+
+// OrdererEndpoints returns the orderer endpoints in the configuration of the given channel
+func (*gatewaySupport) OrdererEndpoints(channel string) ([]string, error) {
+	conf := peer.GetChannelConfig(channel)
+	if conf == nil {
+		return nil, errors.Errorf("channel %s doesn't exist", channel)
+	}
+	return conf.ChannelConfig().OrdererAddresses(), nil
+}
+
+// Ledger returns the ledger of the given channel, or nil if the peer hasn't joined it
+func (*gatewaySupport) Ledger(channel string) gateway.Ledger {
+	ledger := peer.GetLedger(channel)
+	if ledger == nil {
+		return nil
+	}
+	return ledger
+}
+
+// startDiscoveryGateway serves the discovery service over HTTP/JSON,
+// using the TLS certificate of the peer if TLS is enabled
+func startDiscoveryGateway(handler http.Handler, peerServer *comm.GRPCServer) {
+	listenAddress := viper.GetString("peer.discovery.gateway.listenAddress")
+	server := &http.Server{Addr: listenAddress, Handler: handler}
+	if peerServer.TLSEnabled() {
+		server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{peerServer.ServerCertificate()},
+			// Clients are authenticated by their signatures, and their TLS certificates
+			// are only matched against the TLS certificate hashes they claim
+			ClientAuth: tls.RequestClientCert,
+		}
+	}
+	go func() {
+		logger.Infof("Starting discovery gateway with listenAddress = %s", listenAddress)
+		var err error
+		if server.TLSConfig != nil {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
+			logger.Errorf("Error starting discovery gateway: %s", err)
+		}
+	}()
+}
+
+// ordererEndpointOverrides returns the orderer endpoint overrides of the discovery service
+// from the peer configuration, keyed by MSP IDs
+func ordererEndpointOverrides() (map[string]config.OrdererEndpointOverride, error) {
+	var overrides []struct {
+		MSPID     string
+		Endpoints []string
+		Replace   bool
+	}
+	if err := viper.UnmarshalKey("peer.discovery.ordererEndpointOverrides", &overrides); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling orderer endpoint overrides")
+	}
+	res := make(map[string]config.OrdererEndpointOverride)
+	for _, override := range overrides {
+		if override.MSPID == "" {
+			return nil, errors.New("orderer endpoint override is missing an MSP ID")
+		}
+		res[override.MSPID] = config.OrdererEndpointOverride{
+			Endpoints: override.Endpoints,
+			Replace:   override.Replace,
+		}
+	}
+	return res, nil
 }
 
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
@@ -590,6 +893,29 @@ func computeChaincodeEndpoint(peerHostname string) (ccEndpoint string, err error
 	return ccEndpoint, nil
 }
 
+// registerHealthCheckers registers the health checkers
+// of the components the peer depends on
+func registerHealthCheckers(healthHandler *healthz.HealthHandler) error {
+	if ledgerconfig.IsCouchDBEnabled() {
+		couchDBDef := couchdb.GetCouchDBDefinition()
+		couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+			couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout)
+		if err != nil {
+			return errors.WithMessage(err, "failed creating CouchDB instance for health checks")
+		}
+		if err := healthHandler.RegisterChecker("couchdb", couchInstance); err != nil {
+			return err
+		}
+	}
+	if !chaincode.IsDevMode() {
+		dockerVM := dockercontroller.NewDockerVM(viper.GetString("peer.id"), viper.GetString("peer.networkId"))
+		if err := healthHandler.RegisterChecker("docker", dockerVM); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 //NOTE - when we implement JOIN we will no longer pass the chainID as param
 //The chaincode support will come up without registering system chaincodes
 //which will be registered only during join phase.
@@ -598,6 +924,24 @@ func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca
 	userRunsCC := chaincode.IsDevMode()
 	tlsEnabled := viper.GetBool("peer.tls.enabled")
 
+	var dockerProvider container.VMProvider = dockercontroller.NewProvider(
+		viper.GetString("peer.id"),
+		viper.GetString("peer.networkId"),
+	)
+	var builders []externalbuilder.Config
+	if err := viperutil.EnhancedExactUnmarshalKey("chaincode.externalBuilders", &builders); err != nil {
+		logger.Panicf("Failed loading external builders: %s", err)
+	}
+	if len(builders) > 0 {
+		// Chaincode that none of the external builders detects is built and launched by Docker
+		dockerProvider = externalbuilder.NewProvider(
+			ccEndpoint,
+			filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "externalbuilds"),
+			builders,
+			dockerProvider,
+		)
+	}
+
 	authenticator := accesscontrol.NewAuthenticator(ca)
 	ipRegistry := inproccontroller.NewRegistry()
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
@@ -610,10 +954,7 @@ func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca
 		&ccprovider.CCInfoFSImpl{},
 		aclProvider,
 		container.NewVMController(map[string]container.VMProvider{
-			dockercontroller.ContainerType: dockercontroller.NewProvider(
-				viper.GetString("peer.id"),
-				viper.GetString("peer.networkId"),
-			),
+			dockercontroller.ContainerType: dockerProvider,
 			inproccontroller.ContainerType: ipRegistry,
 		}),
 		sccp,
@@ -682,7 +1023,7 @@ func adminHasSeparateListener(peerListenAddr string, adminListenAddress string)
 	return adminPort != peerPort
 }
 
-func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
+func startAdminServer(peerListenAddr string, peerServer *grpc.Server, installer admin.ChaincodeInstaller, tlsReloader *comm.CredentialsReloader) {
 	adminListenAddress := viper.GetString("peer.adminService.listenAddress")
 	separateLsnrForAdmin := adminHasSeparateListener(peerListenAddr, adminListenAddress)
 	mspID := viper.GetString("peer.localMspId")
@@ -699,12 +1040,148 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
 			logger.Fatalf("Failed to create admin server (%s)", err)
 		}
 		gRPCService = adminServer.Server()
+		if tlsReloader != nil {
+			tlsReloader.AddServer(adminServer)
+		}
 		defer func() {
 			go adminServer.Start()
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	adminService := admin.NewAdminServer(adminPolicy, &adminGossipSupport{})
+	adminService.SetDiscoverySupport(&adminDiscoverySupport{})
+	adminService.SetLedgerSupport(&adminLedgerSupport{})
+	adminService.SetChaincodeInstaller(installer, filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "chaincodeUploads"))
+	if tlsReloader != nil {
+		adminService.SetTLSSupport(&adminTLSSupport{reloader: tlsReloader})
+	}
+	pb.RegisterAdminServer(gRPCService, adminService)
+}
+
+// adminGossipSupport exposes the gossip service to the admin service.
+// The gossip service is looked up lazily, since the admin service
+// is started before the gossip service is initialized
+type adminGossipSupport struct{}
+
+// initializedGossipService returns the gossip service, or an error if it isn't initialized yet
+func initializedGossipService() (service.GossipService, error) {
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return nil, errors.New("gossip service isn't initialized yet")
+	}
+	return gossipService, nil
+}
+
+func (*adminGossipSupport) MembershipSnapshot() (gossipgossip.MembershipSnapshot, error) {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return gossipgossip.MembershipSnapshot{}, err
+	}
+	return gossipService.MembershipSnapshot(), nil
+}
+
+func (*adminGossipSupport) LeaderElectionStatus() (map[string]election.Status, error) {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return nil, err
+	}
+	return gossipService.LeaderElectionStatus(), nil
+}
+
+func (*adminGossipSupport) OverrideLeaderElection(chainID string, override election.Override) error {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return err
+	}
+	return gossipService.OverrideLeaderElection(chainID, override)
+}
+
+func (*adminGossipSupport) ReloadExternalEndpoint() (string, error) {
+	return reloadGossipEndpoint()
+}
+
+func (*adminGossipSupport) PvtDataDisseminations(chainID string, startBlock uint64, txID string) ([]gossipprivdata.DisseminationRecord, error) {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return nil, err
+	}
+	return gossipService.PvtDataDisseminations(chainID, startBlock, txID)
+}
+
+func (*adminGossipSupport) MissingPvtDataStats(chainID string) (gossipprivdata.MissingPvtDataStats, error) {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return gossipprivdata.MissingPvtDataStats{}, err
+	}
+	return gossipService.MissingPvtDataStats(chainID)
+}
+
+// adminTLSSupport exposes the reloading of the TLS credentials to the admin service
+type adminTLSSupport struct {
+	reloader *comm.CredentialsReloader
+}
+
+func (s *adminTLSSupport) ReloadTLSCredentials() ([]byte, int, error) {
+	opts, err := s.reloader.Reload()
+	if err != nil {
+		return nil, 0, err
+	}
+	return opts.Certificate, len(opts.ClientRootCAs), nil
+}
+
+// reloadGossipEndpoint re-reads the configuration file of the peer, and
+// publishes the gossip external endpoint found in it to other peers
+func reloadGossipEndpoint() (string, error) {
+	gossipService, err := initializedGossipService()
+	if err != nil {
+		return "", err
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		return "", errors.Wrap(err, "failed reading config file")
+	}
+	endpoint := viper.GetString("peer.gossip.externalEndpoint")
+	gossipService.UpdateExternalEndpoint(endpoint)
+	return endpoint, nil
+}
+
+// registeredDiscoveryService provides the statistics of the
+// discovery service, once the discovery service is registered
+var registeredDiscoveryService struct {
+	sync.RWMutex
+	stats func() discovery.Stats
+}
+
+// adminDiscoverySupport exposes the discovery service to the admin service.
+// The discovery service is looked up lazily, since the admin service
+// is started before the discovery service is registered
+type adminDiscoverySupport struct{}
+
+func (*adminDiscoverySupport) DiscoveryStats() (discovery.Stats, error) {
+	registeredDiscoveryService.RLock()
+	defer registeredDiscoveryService.RUnlock()
+	if registeredDiscoveryService.stats == nil {
+		return discovery.Stats{}, errors.New("discovery service isn't enabled")
+	}
+	return registeredDiscoveryService.stats(), nil
+}
+
+// adminLedgerSupport exposes the ledgers of the channels the peer joined to the admin service
+type adminLedgerSupport struct{}
+
+func (*adminLedgerSupport) PruneHistory(channelID string) error {
+	return ledgermgmt.PruneHistory(channelID)
+}
+
+func (*adminLedgerSupport) HistoryPruningStatus(channelID string) (*ledger.HistoryPruningStatus, error) {
+	return ledgermgmt.GetHistoryPruningStatus(channelID)
+}
+
+func (*adminLedgerSupport) DryRunCommit(channelID string, block *cb.Block) ([]pb.TxValidationCode, error) {
+	return peer.DryRunCommit(channelID, block)
+}
+
+func (*adminLedgerSupport) PurgePrivateData(channelID, ccName, collName string, keys []string, blockToLive uint64) error {
+	return ledgermgmt.PurgePrivateDataKeys(channelID, ccName, collName, keys, blockToLive)
 }
 
 func initializeEventsServerConfig(mutualTLS bool) *producer.EventsServerConfig {
@@ -24,7 +24,10 @@ import (
 
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/handlers/library"
+	principalHandler "github.com/hyperledger/fabric/core/handlers/principal/api"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -88,6 +91,9 @@ func TestHandlerMap(t *testing.T) {
           library: /opt/lib/filter1.so
         -
           name: filter2
+      principalEvaluator:
+        name: evaluator1
+        library: /opt/lib/evaluator1.so
   `
 	viper.SetConfigType("yaml")
 	err := viper.ReadConfig(bytes.NewBuffer([]byte(config1)))
@@ -99,6 +105,53 @@ func TestHandlerMap(t *testing.T) {
 	assert.Len(t, libConf.AuthFilters, 2, "expected two filters")
 	assert.Equal(t, "/opt/lib/filter1.so", libConf.AuthFilters[0].Library)
 	assert.Equal(t, "filter2", libConf.AuthFilters[1].Name)
+	assert.Equal(t, "/opt/lib/evaluator1.so", libConf.PrincipalEvaluator.Library)
+}
+
+type principalEvaluatorFunc func(channel string, identity []byte, principal *mspprotos.MSPPrincipal) error
+
+func (f principalEvaluatorFunc) SatisfiesPrincipal(channel string, identity []byte, principal *mspprotos.MSPPrincipal) error {
+	return f(channel, identity, principal)
+}
+
+func (f principalEvaluatorFunc) MSPOfPrincipal(principal *mspprotos.MSPPrincipal) string {
+	return "Org1MSP"
+}
+
+type principalEvaluatorFactory func(defaultEvaluator principalHandler.Evaluator) principalHandler.Evaluator
+
+func (f principalEvaluatorFactory) New(defaultEvaluator principalHandler.Evaluator) principalHandler.Evaluator {
+	return f(defaultEvaluator)
+}
+
+func TestPrincipalEvaluator(t *testing.T) {
+	defaultEvaluator := principalEvaluatorFunc(func(_ string, _ []byte, _ *mspprotos.MSPPrincipal) error {
+		return errors.New("default")
+	})
+	customEvaluator := principalEvaluatorFunc(func(_ string, identity []byte, principal *mspprotos.MSPPrincipal) error {
+		if string(identity) == "token" {
+			return nil
+		}
+		return defaultEvaluator.SatisfiesPrincipal("", identity, principal)
+	})
+
+	// Scenario I: No principal evaluation handler is configured
+	evaluator := principalEvaluator(defaultEvaluator, nil)
+	assert.EqualError(t, evaluator.SatisfiesPrincipal("", []byte("token"), nil), "default")
+
+	// Scenario II: The principal evaluation handler handles custom identities,
+	// and delegates the rest to the default evaluator
+	evaluator = principalEvaluator(defaultEvaluator, principalEvaluatorFactory(func(principalHandler.Evaluator) principalHandler.Evaluator {
+		return customEvaluator
+	}))
+	assert.NoError(t, evaluator.SatisfiesPrincipal("", []byte("token"), nil))
+	assert.EqualError(t, evaluator.SatisfiesPrincipal("", []byte("x509"), nil), "default")
+
+	// Scenario III: The principal evaluation handler returns no evaluator
+	evaluator = principalEvaluator(defaultEvaluator, principalEvaluatorFactory(func(principalHandler.Evaluator) principalHandler.Evaluator {
+		return nil
+	}))
+	assert.EqualError(t, evaluator.SatisfiesPrincipal("", []byte("token"), nil), "default")
 }
 
 func TestComputeChaincodeEndpoint(t *testing.T) {
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	profileFuncName = "profile"
+	profileCmdDes   = "Manage the profiles of the peer CLI: list|show|use|set|delete."
+)
+
+var logger = flogging.MustGetLogger("cli/profile")
+
+// out is where the profiles are printed to
+var out io.Writer = os.Stdout
+
+// Cmd returns the cobra command for Profile
+func Cmd() *cobra.Command {
+	profileCmd.AddCommand(listCmd())
+	profileCmd.AddCommand(showCmd())
+	profileCmd.AddCommand(useCmd())
+	profileCmd.AddCommand(setCmd())
+	profileCmd.AddCommand(deleteCmd())
+
+	return profileCmd
+}
+
+var profileCmd = &cobra.Command{
+	Use:   profileFuncName,
+	Short: fmt.Sprint(profileCmdDes),
+	Long: fmt.Sprint(profileCmdDes + " A profile names the MSP and the TLS settings the " +
+		"peer CLI uses to connect to a peer and an orderer. The profile used is selected with " +
+		"the --profile flag or the " + common.ProfileEnvVar + " environment variable, and " +
+		"defaults to the current profile. Profiles are stored in $HOME/.fabric/profiles.yaml, " +
+		"unless " + common.ProfilesFileEnvVar + " sets another file."),
+}
+
+func loadProfiles() (*common.Profiles, error) {
+	return common.LoadProfiles(common.DefaultProfilesFile())
+}
+
+func checkName(args []string) error {
+	if len(args) == 0 {
+		return errors.New("the profile name must be specified")
+	}
+	if len(args) > 1 {
+		return errors.Errorf("only the profile name must be specified, got %d arguments", len(args))
+	}
+	return nil
+}
+
+func listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the profiles.",
+		Long:  "List the profiles, marking the current profile with an asterisk.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := loadProfiles()
+			if err != nil {
+				return err
+			}
+			for _, name := range profiles.Names() {
+				marker := " "
+				if name == profiles.Current {
+					marker = "*"
+				}
+				fmt.Fprintf(out, "%s %s\n", marker, name)
+			}
+			return nil
+		},
+	}
+}
+
+func showCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show a profile.",
+		Long:  "Show the settings of the given profile, or of the current profile.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := loadProfiles()
+			if err != nil {
+				return err
+			}
+			name := profiles.Current
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if name == "" {
+				return errors.New("no profile is specified, and there is no current profile")
+			}
+			profile, err := profiles.Get(name)
+			if err != nil {
+				return err
+			}
+			raw, err := yaml.Marshal(profile)
+			if err != nil {
+				return errors.Wrap(err, "failed marshaling profile")
+			}
+			fmt.Fprintf(out, "%s:\n%s", name, raw)
+			return nil
+		},
+	}
+}
+
+func useCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a profile the current profile.",
+		Long:  "Make the given profile the profile used when none is selected.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkName(args); err != nil {
+				return err
+			}
+			profiles, err := loadProfiles()
+			if err != nil {
+				return err
+			}
+			if _, err := profiles.Get(args[0]); err != nil {
+				return err
+			}
+			profiles.Current = args[0]
+			if err := profiles.Save(); err != nil {
+				return err
+			}
+			logger.Infof("Switched to profile %s", args[0])
+			return nil
+		},
+	}
+}
+
+func deleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a profile.",
+		Long:  "Delete the given profile.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkName(args); err != nil {
+				return err
+			}
+			profiles, err := loadProfiles()
+			if err != nil {
+				return err
+			}
+			if err := profiles.Delete(args[0]); err != nil {
+				return err
+			}
+			return profiles.Save()
+		},
+	}
+}
+
+func setCmd() *cobra.Command {
+	var p common.Profile
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or update a profile.",
+		Long: "Create the given profile, or update the settings of an existing profile " +
+			"with the flags that are set. Relative paths are relative to the directory of " +
+			"the profiles file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkName(args); err != nil {
+				return err
+			}
+			profiles, err := loadProfiles()
+			if err != nil {
+				return err
+			}
+			profile, err := profiles.Get(args[0])
+			if err != nil {
+				profile = &common.Profile{}
+			}
+			flags := cmd.Flags()
+			for flag, apply := range map[string]func(){
+				"mspid":                      func() { profile.MSPID = p.MSPID },
+				"mspConfigPath":              func() { profile.MSPConfigPath = p.MSPConfigPath },
+				"peerAddress":                func() { profile.Peer.Address = p.Peer.Address },
+				"peerTLS":                    func() { profile.Peer.TLS.Enabled = p.Peer.TLS.Enabled },
+				"peerClientAuth":             func() { profile.Peer.TLS.ClientAuthRequired = p.Peer.TLS.ClientAuthRequired },
+				"peerCAFile":                 func() { profile.Peer.TLS.RootCert = p.Peer.TLS.RootCert },
+				"peerCertFile":               func() { profile.Peer.TLS.ClientCert = p.Peer.TLS.ClientCert },
+				"peerKeyFile":                func() { profile.Peer.TLS.ClientKey = p.Peer.TLS.ClientKey },
+				"peerTLSHostnameOverride":    func() { profile.Peer.TLS.ServerHostOverride = p.Peer.TLS.ServerHostOverride },
+				"orderer":                    func() { profile.Orderer.Address = p.Orderer.Address },
+				"tls":                        func() { profile.Orderer.TLS.Enabled = p.Orderer.TLS.Enabled },
+				"clientauth":                 func() { profile.Orderer.TLS.ClientAuthRequired = p.Orderer.TLS.ClientAuthRequired },
+				"cafile":                     func() { profile.Orderer.TLS.RootCert = p.Orderer.TLS.RootCert },
+				"certfile":                   func() { profile.Orderer.TLS.ClientCert = p.Orderer.TLS.ClientCert },
+				"keyfile":                    func() { profile.Orderer.TLS.ClientKey = p.Orderer.TLS.ClientKey },
+				"ordererTLSHostnameOverride": func() { profile.Orderer.TLS.ServerHostOverride = p.Orderer.TLS.ServerHostOverride },
+			} {
+				if flags.Changed(flag) {
+					apply()
+				}
+			}
+			profiles.Set(args[0], profile)
+			if profiles.Current == "" {
+				profiles.Current = args[0]
+			}
+			return profiles.Save()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&p.MSPID, "mspid", "", "ID of the local MSP")
+	flags.StringVar(&p.MSPConfigPath, "mspConfigPath", "", "Path to the directory of the local MSP")
+	flags.StringVar(&p.Peer.Address, "peerAddress", "", "Peer endpoint")
+	flags.BoolVar(&p.Peer.TLS.Enabled, "peerTLS", false, "Use TLS when communicating with the peer")
+	flags.BoolVar(&p.Peer.TLS.ClientAuthRequired, "peerClientAuth", false,
+		"Use mutual TLS when communicating with the peer")
+	flags.StringVar(&p.Peer.TLS.RootCert, "peerCAFile", "",
+		"Path to file containing PEM-encoded trusted certificate(s) for the peer")
+	flags.StringVar(&p.Peer.TLS.ClientCert, "peerCertFile", "",
+		"Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the peer")
+	flags.StringVar(&p.Peer.TLS.ClientKey, "peerKeyFile", "",
+		"Path to file containing PEM-encoded private key to use for mutual TLS communication with the peer")
+	flags.StringVar(&p.Peer.TLS.ServerHostOverride, "peerTLSHostnameOverride", "",
+		"The hostname override to use when validating the TLS connection to the peer")
+	flags.StringVarP(&p.Orderer.Address, "orderer", "o", "", "Ordering service endpoint")
+	flags.BoolVar(&p.Orderer.TLS.Enabled, "tls", false, "Use TLS when communicating with the orderer endpoint")
+	flags.BoolVar(&p.Orderer.TLS.ClientAuthRequired, "clientauth", false,
+		"Use mutual TLS when communicating with the orderer endpoint")
+	flags.StringVar(&p.Orderer.TLS.RootCert, "cafile", "",
+		"Path to file containing PEM-encoded trusted certificate(s) for the ordering endpoint")
+	flags.StringVar(&p.Orderer.TLS.ClientCert, "certfile", "",
+		"Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the orderer endpoint")
+	flags.StringVar(&p.Orderer.TLS.ClientKey, "keyfile", "",
+		"Path to file containing PEM-encoded private key to use for mutual TLS communication with the orderer endpoint")
+	flags.StringVar(&p.Orderer.TLS.ServerHostOverride, "ordererTLSHostnameOverride", "",
+		"The hostname override to use when validating the TLS connection to the orderer")
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package profile
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runCmd(cmd *cobra.Command, args ...string) (string, error) {
+	buf := &bytes.Buffer{}
+	out = buf
+	defer func() { out = os.Stdout }()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestProfileCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiles")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, ".fabric", "profiles.yaml")
+	os.Setenv(common.ProfilesFileEnvVar, file)
+	defer os.Unsetenv(common.ProfilesFileEnvVar)
+
+	output, err := runCmd(listCmd())
+	assert.NoError(t, err)
+	assert.Equal(t, "", output)
+	_, err = runCmd(showCmd())
+	assert.EqualError(t, err, "no profile is specified, and there is no current profile")
+
+	// the first profile becomes the current one
+	_, err = runCmd(setCmd(), "org1", "--mspid", "Org1MSP", "--peerAddress", "peer0.org1.example.com:7051",
+		"--peerTLS", "--peerCAFile", "org1/ca.crt", "-o", "orderer.example.com:7050", "--tls")
+	require.NoError(t, err)
+	_, err = runCmd(setCmd(), "org2", "--mspid", "Org2MSP")
+	require.NoError(t, err)
+	output, err = runCmd(listCmd())
+	assert.NoError(t, err)
+	assert.Equal(t, "* org1\n  org2\n", output)
+
+	// only the flags that are set are updated
+	_, err = runCmd(setCmd(), "org1", "--peerAddress", "peer1.org1.example.com:7051")
+	require.NoError(t, err)
+	profiles, err := common.LoadProfiles(file)
+	require.NoError(t, err)
+	org1, err := profiles.Get("org1")
+	require.NoError(t, err)
+	assert.Equal(t, &common.Profile{
+		MSPID: "Org1MSP",
+		Peer: common.EndpointProfile{
+			Address: "peer1.org1.example.com:7051",
+			TLS:     common.TLSProfile{Enabled: true, RootCert: "org1/ca.crt"},
+		},
+		Orderer: common.EndpointProfile{
+			Address: "orderer.example.com:7050",
+			TLS:     common.TLSProfile{Enabled: true},
+		},
+	}, org1)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	output, err = runCmd(showCmd())
+	assert.NoError(t, err)
+	assert.Contains(t, output, "org1:\nmspId: Org1MSP\n")
+	output, err = runCmd(showCmd(), "org2")
+	assert.NoError(t, err)
+	assert.Equal(t, "org2:\nmspId: Org2MSP\n", output)
+
+	_, err = runCmd(useCmd(), "org2")
+	assert.NoError(t, err)
+	_, err = runCmd(useCmd(), "org3")
+	assert.EqualError(t, err, "profile org3 does not exist in "+file)
+	_, err = runCmd(useCmd())
+	assert.EqualError(t, err, "the profile name must be specified")
+	output, err = runCmd(listCmd())
+	assert.NoError(t, err)
+	assert.Equal(t, "  org1\n* org2\n", output)
+
+	_, err = runCmd(deleteCmd(), "org2")
+	assert.NoError(t, err)
+	_, err = runCmd(deleteCmd(), "org2")
+	assert.EqualError(t, err, "profile org2 does not exist in "+file)
+	output, err = runCmd(listCmd())
+	assert.NoError(t, err)
+	assert.Equal(t, "  org1\n", output)
+}
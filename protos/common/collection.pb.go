@@ -30,6 +30,7 @@ It has these top-level messages:
 	BlockHeader
 	BlockData
 	BlockMetadata
+	BlockBatch
 	ConfigEnvelope
 	ConfigGroupSchema
 	ConfigValueSchema
@@ -201,6 +202,10 @@ type StaticCollectionConfig struct {
 	// For instance if the value is set to 10, a key last modified by block number 100
 	// will be purged at block number 111. A zero value is treated same as MaxUint64
 	BlockToLive uint64 `protobuf:"varint,5,opt,name=block_to_live,json=blockToLive" json:"block_to_live,omitempty"`
+	// An optional policy the identity of a peer has to satisfy, in addition to
+	// member_orgs_policy, for the peer to be served the private data of the
+	// collection when it pulls it from other peers
+	MemberAccessPolicy *CollectionPolicyConfig `protobuf:"bytes,6,opt,name=member_access_policy,json=memberAccessPolicy" json:"member_access_policy,omitempty"`
 }
 
 func (m *StaticCollectionConfig) Reset()                    { *m = StaticCollectionConfig{} }
@@ -243,6 +248,13 @@ func (m *StaticCollectionConfig) GetBlockToLive() uint64 {
 	return 0
 }
 
+func (m *StaticCollectionConfig) GetMemberAccessPolicy() *CollectionPolicyConfig {
+	if m != nil {
+		return m.MemberAccessPolicy
+	}
+	return nil
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -388,34 +400,35 @@ func init() {
 func init() { proto.RegisterFile("common/collection.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 453 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x41, 0x6b, 0xdb, 0x40,
-	0x10, 0x85, 0xa3, 0xc6, 0x76, 0xd0, 0x98, 0x52, 0x77, 0x43, 0x1d, 0x51, 0x4a, 0x6a, 0x44, 0x0f,
-	0x86, 0x16, 0xa9, 0xa4, 0xff, 0x20, 0xa6, 0x90, 0x52, 0x43, 0x8d, 0xd2, 0x53, 0x2e, 0x62, 0xb5,
-	0x9a, 0xc8, 0x4b, 0x24, 0xad, 0xb2, 0xbb, 0x32, 0xf6, 0xb1, 0xff, 0xbb, 0x87, 0xe0, 0x5d, 0xc9,
-	0x52, 0x8c, 0x6f, 0x9e, 0x79, 0xdf, 0x3c, 0xcf, 0x3c, 0x2d, 0x5c, 0x31, 0x51, 0x14, 0xa2, 0x0c,
-	0x99, 0xc8, 0x73, 0x64, 0x9a, 0x8b, 0x32, 0xa8, 0xa4, 0xd0, 0x82, 0x8c, 0xac, 0xf0, 0xf1, 0x43,
-	0x03, 0x54, 0x22, 0xe7, 0x8c, 0xa3, 0xb2, 0xb2, 0xff, 0x1b, 0xae, 0x16, 0x87, 0x91, 0x85, 0x28,
-	0x1f, 0x79, 0xb6, 0xa2, 0xec, 0x89, 0x66, 0x48, 0xbe, 0xc3, 0x88, 0x99, 0x86, 0xe7, 0xcc, 0xce,
-	0xe7, 0xe3, 0x1b, 0x2f, 0xb0, 0x16, 0xc1, 0xf1, 0x40, 0xd4, 0x70, 0xfe, 0x0e, 0x26, 0xc7, 0x1a,
-	0x79, 0x00, 0x4f, 0x69, 0xaa, 0x39, 0x8b, 0xbb, 0xd5, 0xe2, 0x83, 0xaf, 0x33, 0x1f, 0xdf, 0x5c,
-	0xb7, 0xbe, 0xf7, 0x86, 0x3b, 0x76, 0xb8, 0x3b, 0x8b, 0xa6, 0xea, 0xa4, 0x72, 0xeb, 0xc2, 0x45,
-	0x45, 0x77, 0xb9, 0xa0, 0xa9, 0xff, 0xdf, 0x81, 0xe9, 0xe9, 0x79, 0x42, 0x60, 0x50, 0xd2, 0x02,
-	0xcd, 0xbf, 0xb9, 0x91, 0xf9, 0x4d, 0x96, 0x40, 0x0a, 0x2c, 0x12, 0x94, 0xb1, 0x90, 0x99, 0x8a,
-	0x4d, 0x28, 0x3b, 0xef, 0xcd, 0xeb, 0x7d, 0x3a, 0xa7, 0x95, 0xd1, 0x9b, 0x6b, 0x27, 0x76, 0xf2,
-	0x8f, 0xcc, 0x94, 0xed, 0x93, 0x00, 0x2e, 0x25, 0x3e, 0xd7, 0x5c, 0x62, 0x1a, 0x57, 0x88, 0x32,
-	0x66, 0xa2, 0x2e, 0xb5, 0x77, 0x3e, 0x73, 0xe6, 0xc3, 0xe8, 0x7d, 0x2b, 0xad, 0x10, 0xe5, 0x62,
-	0x2f, 0x90, 0x6f, 0x40, 0x0a, 0xba, 0xe5, 0x45, 0x5d, 0xf4, 0xf1, 0x81, 0xc1, 0x27, 0x8d, 0xd2,
-	0xd1, 0x3e, 0xbc, 0x4d, 0x72, 0xc1, 0x9e, 0x62, 0x2d, 0xe2, 0x9c, 0x6f, 0xd0, 0x1b, 0xce, 0x9c,
-	0xf9, 0x20, 0x1a, 0x9b, 0xe6, 0x5f, 0xb1, 0xe4, 0x1b, 0xf4, 0x9f, 0x61, 0x7a, 0x7a, 0x5b, 0xb2,
-	0x84, 0x89, 0xe2, 0x59, 0x49, 0x75, 0x2d, 0xb1, 0xbd, 0xd3, 0xe6, 0xfe, 0xf9, 0x90, 0x7b, 0xab,
-	0xdb, 0xc1, 0x9f, 0xe5, 0x06, 0x73, 0x51, 0xe1, 0xdd, 0x59, 0xf4, 0x4e, 0xbd, 0x96, 0xfa, 0x89,
-	0xff, 0x73, 0x80, 0xf4, 0xb2, 0x96, 0x5c, 0xa3, 0xe4, 0x94, 0x78, 0x70, 0xc1, 0xd6, 0xb4, 0x2c,
-	0x31, 0x6f, 0x02, 0x6f, 0x4b, 0x72, 0x09, 0x43, 0xbd, 0x8d, 0x79, 0x6a, 0x62, 0x76, 0xa3, 0x81,
-	0xde, 0xfe, 0x4a, 0xc9, 0x35, 0x40, 0xf7, 0x2e, 0x4c, 0x62, 0x6e, 0xd4, 0xeb, 0x90, 0x4f, 0xe0,
-	0xee, 0x3f, 0x98, 0xaa, 0x28, 0x43, 0x93, 0x90, 0x1b, 0x75, 0x8d, 0xdb, 0x7b, 0xf8, 0x22, 0x64,
-	0x16, 0xac, 0x77, 0x15, 0xca, 0x1c, 0xd3, 0x0c, 0x65, 0xf0, 0x48, 0x13, 0xc9, 0x99, 0x7d, 0xdd,
-	0xaa, 0xb9, 0xf0, 0xe1, 0x6b, 0xc6, 0xf5, 0xba, 0x4e, 0xf6, 0x65, 0xd8, 0x83, 0x43, 0x0b, 0x87,
-	0x16, 0x0e, 0x2d, 0x9c, 0x8c, 0x4c, 0xf9, 0xe3, 0x25, 0x00, 0x00, 0xff, 0xff, 0x04, 0x6f, 0x60,
-	0x95, 0x53, 0x03, 0x00, 0x00,
+	// 474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xd1, 0x6a, 0xdb, 0x30,
+	0x14, 0x86, 0x9b, 0x36, 0x49, 0xf1, 0x09, 0x63, 0x99, 0xba, 0xa5, 0x66, 0x8c, 0x2e, 0x98, 0x5d,
+	0x04, 0x36, 0xec, 0xd1, 0x3d, 0xc1, 0x1a, 0x06, 0x1d, 0x0b, 0x2c, 0xb8, 0xbb, 0xea, 0x8d, 0x91,
+	0xe5, 0x53, 0x47, 0xd4, 0xb6, 0x5c, 0x49, 0x0e, 0xc9, 0xe5, 0x5e, 0x70, 0xcf, 0x34, 0x22, 0xd9,
+	0xb1, 0x1b, 0x72, 0xd1, 0x3b, 0xeb, 0xfc, 0xdf, 0x7f, 0xa4, 0xa3, 0x5f, 0x86, 0x4b, 0x26, 0xf2,
+	0x5c, 0x14, 0x01, 0x13, 0x59, 0x86, 0x4c, 0x73, 0x51, 0xf8, 0xa5, 0x14, 0x5a, 0x90, 0xa1, 0x15,
+	0xde, 0xbf, 0xab, 0x81, 0x52, 0x64, 0x9c, 0x71, 0x54, 0x56, 0xf6, 0x7e, 0xc1, 0xe5, 0x7c, 0x6f,
+	0x99, 0x8b, 0xe2, 0x81, 0xa7, 0x4b, 0xca, 0x1e, 0x69, 0x8a, 0xe4, 0x2b, 0x0c, 0x99, 0x29, 0xb8,
+	0xbd, 0xe9, 0xd9, 0x6c, 0x74, 0xed, 0xfa, 0xb6, 0x85, 0x7f, 0x68, 0x08, 0x6b, 0xce, 0xdb, 0xc2,
+	0xf8, 0x50, 0x23, 0xf7, 0xe0, 0x2a, 0x4d, 0x35, 0x67, 0x51, 0x7b, 0xb4, 0x68, 0xdf, 0xb7, 0x37,
+	0x1b, 0x5d, 0x5f, 0x35, 0x7d, 0xef, 0x0c, 0x77, 0xd8, 0xe1, 0xf6, 0x24, 0x9c, 0xa8, 0xa3, 0xca,
+	0x8d, 0x03, 0xe7, 0x25, 0xdd, 0x66, 0x82, 0x26, 0xde, 0xbf, 0x53, 0x98, 0x1c, 0xf7, 0x13, 0x02,
+	0xfd, 0x82, 0xe6, 0x68, 0x76, 0x73, 0x42, 0xf3, 0x4d, 0x16, 0x40, 0x72, 0xcc, 0x63, 0x94, 0x91,
+	0x90, 0xa9, 0x8a, 0xcc, 0xa5, 0x6c, 0xdd, 0xd3, 0xe7, 0xe7, 0x69, 0x3b, 0x2d, 0x8d, 0x5e, 0x4f,
+	0x3b, 0xb6, 0xce, 0xdf, 0x32, 0x55, 0xb6, 0x4e, 0x7c, 0xb8, 0x90, 0xf8, 0x54, 0x71, 0x89, 0x49,
+	0x54, 0x22, 0xca, 0x88, 0x89, 0xaa, 0xd0, 0xee, 0xd9, 0xb4, 0x37, 0x1b, 0x84, 0x6f, 0x1a, 0x69,
+	0x89, 0x28, 0xe7, 0x3b, 0x81, 0x7c, 0x01, 0x92, 0xd3, 0x0d, 0xcf, 0xab, 0xbc, 0x8b, 0xf7, 0x0d,
+	0x3e, 0xae, 0x95, 0x96, 0xf6, 0xe0, 0x55, 0x9c, 0x09, 0xf6, 0x18, 0x69, 0x11, 0x65, 0x7c, 0x8d,
+	0xee, 0x60, 0xda, 0x9b, 0xf5, 0xc3, 0x91, 0x29, 0xfe, 0x11, 0x0b, 0xbe, 0x46, 0xb2, 0x84, 0xb7,
+	0xf5, 0x3c, 0x94, 0x31, 0x54, 0xfb, 0x89, 0x86, 0x2f, 0x9a, 0xa8, 0xbe, 0x8b, 0xef, 0xc6, 0x6a,
+	0x15, 0xef, 0x09, 0x26, 0xc7, 0x69, 0xb2, 0x80, 0xb1, 0xe2, 0x69, 0x41, 0x75, 0x25, 0xb1, 0xd9,
+	0xc7, 0x26, 0xf9, 0x71, 0x9f, 0x64, 0xa3, 0x5b, 0xe3, 0x8f, 0x62, 0x8d, 0x99, 0x28, 0xf1, 0xf6,
+	0x24, 0x7c, 0xad, 0x9e, 0x4b, 0xdd, 0x0c, 0xff, 0xf6, 0x80, 0x74, 0xd2, 0x93, 0x5c, 0xa3, 0xe4,
+	0x94, 0xb8, 0x70, 0xce, 0x56, 0xb4, 0x28, 0x30, 0xab, 0x23, 0x6c, 0x96, 0xe4, 0x02, 0x06, 0x7a,
+	0x13, 0xf1, 0xc4, 0x04, 0xe7, 0x84, 0x7d, 0xbd, 0xf9, 0x99, 0x90, 0x2b, 0x80, 0xf6, 0xa5, 0x99,
+	0x0c, 0x9c, 0xb0, 0x53, 0x21, 0x1f, 0xc0, 0xd9, 0x3d, 0x01, 0x55, 0x52, 0x86, 0xe6, 0xce, 0x9d,
+	0xb0, 0x2d, 0xdc, 0xdc, 0xc1, 0x27, 0x21, 0x53, 0x7f, 0xb5, 0x2d, 0x51, 0x66, 0x98, 0xa4, 0x28,
+	0xfd, 0x07, 0x1a, 0x4b, 0xce, 0xec, 0xff, 0xa2, 0xea, 0x09, 0xef, 0x3f, 0xa7, 0x5c, 0xaf, 0xaa,
+	0x78, 0xb7, 0x0c, 0x3a, 0x70, 0x60, 0xe1, 0xc0, 0xc2, 0x81, 0x85, 0xe3, 0xa1, 0x59, 0x7e, 0xfb,
+	0x3f, 0x00, 0xc4, 0x36, 0x01, 0x1b, 0xa5, 0x03, 0x00, 0x00,
 }
@@ -59,35 +59,41 @@ func (Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0
 type HeaderType int32
 
 const (
-	HeaderType_MESSAGE              HeaderType = 0
-	HeaderType_CONFIG               HeaderType = 1
-	HeaderType_CONFIG_UPDATE        HeaderType = 2
-	HeaderType_ENDORSER_TRANSACTION HeaderType = 3
-	HeaderType_ORDERER_TRANSACTION  HeaderType = 4
-	HeaderType_DELIVER_SEEK_INFO    HeaderType = 5
-	HeaderType_CHAINCODE_PACKAGE    HeaderType = 6
-	HeaderType_PEER_ADMIN_OPERATION HeaderType = 8
+	HeaderType_MESSAGE                 HeaderType = 0
+	HeaderType_CONFIG                  HeaderType = 1
+	HeaderType_CONFIG_UPDATE           HeaderType = 2
+	HeaderType_ENDORSER_TRANSACTION    HeaderType = 3
+	HeaderType_ORDERER_TRANSACTION     HeaderType = 4
+	HeaderType_DELIVER_SEEK_INFO       HeaderType = 5
+	HeaderType_CHAINCODE_PACKAGE       HeaderType = 6
+	HeaderType_PEER_ADMIN_OPERATION    HeaderType = 8
+	HeaderType_ORDERER_ADMIN_OPERATION HeaderType = 9
+	HeaderType_TOKEN_TRANSACTION       HeaderType = 10
 )
 
 var HeaderType_name = map[int32]string{
-	0: "MESSAGE",
-	1: "CONFIG",
-	2: "CONFIG_UPDATE",
-	3: "ENDORSER_TRANSACTION",
-	4: "ORDERER_TRANSACTION",
-	5: "DELIVER_SEEK_INFO",
-	6: "CHAINCODE_PACKAGE",
-	8: "PEER_ADMIN_OPERATION",
+	0:  "MESSAGE",
+	1:  "CONFIG",
+	2:  "CONFIG_UPDATE",
+	3:  "ENDORSER_TRANSACTION",
+	4:  "ORDERER_TRANSACTION",
+	5:  "DELIVER_SEEK_INFO",
+	6:  "CHAINCODE_PACKAGE",
+	8:  "PEER_ADMIN_OPERATION",
+	9:  "ORDERER_ADMIN_OPERATION",
+	10: "TOKEN_TRANSACTION",
 }
 var HeaderType_value = map[string]int32{
-	"MESSAGE":              0,
-	"CONFIG":               1,
-	"CONFIG_UPDATE":        2,
-	"ENDORSER_TRANSACTION": 3,
-	"ORDERER_TRANSACTION":  4,
-	"DELIVER_SEEK_INFO":    5,
-	"CHAINCODE_PACKAGE":    6,
-	"PEER_ADMIN_OPERATION": 8,
+	"MESSAGE":                 0,
+	"CONFIG":                  1,
+	"CONFIG_UPDATE":           2,
+	"ENDORSER_TRANSACTION":    3,
+	"ORDERER_TRANSACTION":     4,
+	"DELIVER_SEEK_INFO":       5,
+	"CHAINCODE_PACKAGE":       6,
+	"PEER_ADMIN_OPERATION":    8,
+	"ORDERER_ADMIN_OPERATION": 9,
+	"TOKEN_TRANSACTION":       10,
 }
 
 func (x HeaderType) String() string {
@@ -123,6 +129,28 @@ func (x BlockMetadataIndex) String() string {
 }
 func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
+// Compression identifies the algorithm compressed data is compressed with
+type Compression int32
+
+const (
+	Compression_UNCOMPRESSED Compression = 0
+	Compression_GZIP         Compression = 1
+)
+
+var Compression_name = map[int32]string{
+	0: "UNCOMPRESSED",
+	1: "GZIP",
+}
+var Compression_value = map[string]int32{
+	"UNCOMPRESSED": 0,
+	"GZIP":         1,
+}
+
+func (x Compression) String() string {
+	return proto.EnumName(Compression_name, int32(x))
+}
+func (Compression) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
 // LastConfig is the encoded value for the Metadata message which is encoded in the LAST_CONFIGURATION block metadata index
 type LastConfig struct {
 	Index uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
@@ -489,6 +517,39 @@ func (m *BlockMetadata) GetMetadata() [][]byte {
 	return nil
 }
 
+// BlockBatch contains consecutive blocks delivered together
+type BlockBatch struct {
+	Blocks           []*Block    `protobuf:"bytes,1,rep,name=blocks" json:"blocks,omitempty"`
+	Compression      Compression `protobuf:"varint,2,opt,name=compression,enum=common.Compression" json:"compression,omitempty"`
+	CompressedBlocks []byte      `protobuf:"bytes,3,opt,name=compressed_blocks,json=compressedBlocks,proto3" json:"compressed_blocks,omitempty"`
+}
+
+func (m *BlockBatch) Reset()                    { *m = BlockBatch{} }
+func (m *BlockBatch) String() string            { return proto.CompactTextString(m) }
+func (*BlockBatch) ProtoMessage()               {}
+func (*BlockBatch) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *BlockBatch) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockBatch) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_UNCOMPRESSED
+}
+
+func (m *BlockBatch) GetCompressedBlocks() []byte {
+	if m != nil {
+		return m.CompressedBlocks
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*LastConfig)(nil), "common.LastConfig")
 	proto.RegisterType((*Metadata)(nil), "common.Metadata")
@@ -502,73 +563,81 @@ func init() {
 	proto.RegisterType((*BlockHeader)(nil), "common.BlockHeader")
 	proto.RegisterType((*BlockData)(nil), "common.BlockData")
 	proto.RegisterType((*BlockMetadata)(nil), "common.BlockMetadata")
+	proto.RegisterType((*BlockBatch)(nil), "common.BlockBatch")
 	proto.RegisterEnum("common.Status", Status_name, Status_value)
 	proto.RegisterEnum("common.HeaderType", HeaderType_name, HeaderType_value)
 	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
+	proto.RegisterEnum("common.Compression", Compression_name, Compression_value)
 }
 
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 952 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x18, 0x5d, 0xc7, 0xf9, 0xf9, 0xb9, 0x69, 0xdd, 0x49, 0xcb, 0x9a, 0xc2, 0x6a, 0x2b, 0xc3, 0xa2,
-	0xd2, 0x4a, 0xa9, 0x28, 0x17, 0x38, 0x3a, 0xf6, 0xb4, 0xb5, 0x9a, 0xda, 0x61, 0xec, 0x2c, 0x62,
-	0x17, 0xc9, 0x72, 0x92, 0x69, 0x12, 0x91, 0xd8, 0x91, 0x3d, 0xa9, 0xda, 0x33, 0x77, 0x84, 0x04,
-	0x57, 0xfe, 0x0a, 0xfe, 0x01, 0x8e, 0xfc, 0x41, 0x20, 0xae, 0x68, 0x3c, 0xb6, 0x37, 0x29, 0x2b,
-	0x71, 0xca, 0xbc, 0x37, 0x2f, 0xdf, 0xf7, 0xe6, 0x7b, 0x63, 0x1b, 0x3a, 0xe3, 0x78, 0xb9, 0x8c,
-	0xa3, 0x73, 0xf1, 0xd3, 0x5d, 0x25, 0x31, 0x8b, 0x51, 0x5d, 0xa0, 0xa3, 0x97, 0xd3, 0x38, 0x9e,
-	0x2e, 0xe8, 0x79, 0xc6, 0x8e, 0xd6, 0x77, 0xe7, 0x6c, 0xbe, 0xa4, 0x29, 0x0b, 0x97, 0x2b, 0x21,
-	0xd4, 0x75, 0x80, 0x7e, 0x98, 0x32, 0x33, 0x8e, 0xee, 0xe6, 0x53, 0x74, 0x00, 0xb5, 0x79, 0x34,
-	0xa1, 0x0f, 0x9a, 0x74, 0x2c, 0x9d, 0x54, 0x89, 0x00, 0xfa, 0x5b, 0x68, 0xde, 0x52, 0x16, 0x4e,
-	0x42, 0x16, 0x72, 0xc5, 0x7d, 0xb8, 0x58, 0xd3, 0x4c, 0xb1, 0x43, 0x04, 0x40, 0x5f, 0x03, 0xa4,
-	0xf3, 0x69, 0x14, 0xb2, 0x75, 0x42, 0x53, 0xad, 0x72, 0x2c, 0x9f, 0x28, 0x17, 0x1f, 0x76, 0x73,
-	0x47, 0xc5, 0x7f, 0xbd, 0x42, 0x41, 0x36, 0xc4, 0xfa, 0xf7, 0xb0, 0xff, 0x1f, 0x01, 0xfa, 0x1c,
-	0xd4, 0x52, 0x12, 0xcc, 0x68, 0x38, 0xa1, 0x49, 0xde, 0x70, 0xaf, 0xe4, 0xaf, 0x33, 0x1a, 0x7d,
-	0x0c, 0xad, 0x92, 0xd2, 0x2a, 0x99, 0xe6, 0x1d, 0xa1, 0xbf, 0x81, 0x7a, 0xae, 0x7b, 0x05, 0xbb,
-	0xe3, 0x59, 0x18, 0x45, 0x74, 0xb1, 0x5d, 0xb0, 0x9d, 0xb3, 0xb9, 0xec, 0x7d, 0x9d, 0x2b, 0xef,
-	0xed, 0xac, 0xff, 0x58, 0x81, 0xb6, 0xb9, 0xf5, 0x67, 0x04, 0x55, 0xf6, 0xb8, 0x12, 0xb3, 0xa9,
-	0x91, 0x6c, 0x8d, 0x34, 0x68, 0xdc, 0xd3, 0x24, 0x9d, 0xc7, 0x51, 0x56, 0xa7, 0x46, 0x0a, 0x88,
-	0xbe, 0x82, 0x56, 0x99, 0x86, 0x26, 0x1f, 0x4b, 0x27, 0xca, 0xc5, 0x51, 0x57, 0xe4, 0xd5, 0x2d,
-	0xf2, 0xea, 0xfa, 0x85, 0x82, 0xbc, 0x13, 0xa3, 0x17, 0x00, 0xc5, 0x59, 0xe6, 0x13, 0xad, 0x7a,
-	0x2c, 0x9d, 0xb4, 0x48, 0x2b, 0x67, 0xec, 0x09, 0xea, 0x40, 0x8d, 0x3d, 0xf0, 0x9d, 0x5a, 0xb6,
-	0x53, 0x65, 0x0f, 0xf6, 0x84, 0x07, 0x47, 0x57, 0xf1, 0x78, 0xa6, 0xd5, 0x45, 0xb4, 0x19, 0xe0,
-	0xd3, 0xa3, 0x0f, 0x8c, 0x46, 0x99, 0xbf, 0x86, 0x98, 0x5e, 0x49, 0x20, 0x1d, 0xda, 0x6c, 0x91,
-	0x06, 0x63, 0x9a, 0xb0, 0x60, 0x16, 0xa6, 0x33, 0xad, 0x99, 0x29, 0x14, 0xb6, 0x48, 0x4d, 0x9a,
-	0xb0, 0xeb, 0x30, 0x9d, 0xe9, 0x06, 0xec, 0x79, 0x4f, 0x22, 0xd1, 0xa0, 0x31, 0x4e, 0x68, 0xc8,
-	0xe2, 0x62, 0xc6, 0x05, 0xe4, 0x26, 0xa2, 0x38, 0x1a, 0x17, 0x41, 0x09, 0xa0, 0x63, 0x68, 0x0c,
-	0xc2, 0xc7, 0x45, 0x1c, 0x4e, 0xd0, 0x67, 0x50, 0xdf, 0x48, 0x47, 0xb9, 0xd8, 0x2d, 0x2e, 0x91,
-	0x28, 0x4d, 0xf2, 0x5d, 0x3e, 0x69, 0x7e, 0x63, 0xf2, 0x3a, 0xd9, 0x5a, 0xef, 0x41, 0x13, 0x47,
-	0xf7, 0x74, 0x11, 0x8b, 0xa9, 0xaf, 0x44, 0xc9, 0xc2, 0x42, 0x0e, 0xff, 0xe7, 0xbe, 0xfc, 0x24,
-	0x41, 0xad, 0xb7, 0x88, 0xc7, 0x3f, 0xa0, 0xb3, 0x27, 0x4e, 0x3a, 0x85, 0x93, 0x6c, 0xfb, 0x89,
-	0x9d, 0x57, 0x1b, 0x76, 0x94, 0x8b, 0xfd, 0x2d, 0xa9, 0x15, 0xb2, 0x50, 0x38, 0x44, 0x5f, 0x40,
-	0x73, 0x99, 0xdf, 0xf5, 0x3c, 0xf0, 0xc3, 0x2d, 0x69, 0xf1, 0x20, 0x90, 0x52, 0xa6, 0x4f, 0x41,
-	0xd9, 0x68, 0x88, 0x3e, 0x80, 0x7a, 0xb4, 0x5e, 0x8e, 0x72, 0x57, 0x55, 0x92, 0x23, 0xf4, 0x09,
-	0xb4, 0x57, 0x09, 0xbd, 0x9f, 0xc7, 0xeb, 0x54, 0x24, 0x25, 0x4e, 0xb6, 0x53, 0x90, 0x3c, 0x2a,
-	0xf4, 0x11, 0xb4, 0x78, 0x4d, 0x21, 0x90, 0x33, 0x41, 0x93, 0x13, 0x59, 0x8e, 0x2f, 0xa1, 0x55,
-	0xda, 0x2d, 0xc7, 0x2b, 0x1d, 0xcb, 0xe5, 0x78, 0xcf, 0xa0, 0xbd, 0x65, 0x12, 0x1d, 0x6d, 0x9c,
-	0x46, 0x08, 0x4b, 0x7c, 0xfa, 0x87, 0x04, 0x75, 0x8f, 0x85, 0x6c, 0x9d, 0x22, 0x05, 0x1a, 0x43,
-	0xe7, 0xc6, 0x71, 0xbf, 0x75, 0xd4, 0x67, 0x68, 0x07, 0x1a, 0xde, 0xd0, 0x34, 0xb1, 0xe7, 0xa9,
-	0x7f, 0x4a, 0x48, 0x05, 0xa5, 0x67, 0x58, 0x01, 0xc1, 0xdf, 0x0c, 0xb1, 0xe7, 0xab, 0x3f, 0xcb,
-	0x68, 0x17, 0x5a, 0x97, 0x2e, 0xe9, 0xd9, 0x96, 0x85, 0x1d, 0xf5, 0x97, 0x0c, 0x3b, 0xae, 0x1f,
-	0x5c, 0xba, 0x43, 0xc7, 0x52, 0x7f, 0x95, 0xd1, 0x0b, 0xd0, 0x72, 0x75, 0x80, 0x1d, 0xdf, 0xf6,
-	0xbf, 0x0b, 0x7c, 0xd7, 0x0d, 0xfa, 0x06, 0xb9, 0xc2, 0xea, 0x6f, 0x32, 0x3a, 0x82, 0x43, 0xdb,
-	0xf1, 0x31, 0x71, 0x8c, 0x7e, 0xe0, 0x61, 0xf2, 0x1a, 0x93, 0x00, 0x13, 0xe2, 0x12, 0xf5, 0x2f,
-	0x19, 0x1d, 0xc0, 0x1e, 0x2f, 0x65, 0xdf, 0x0e, 0xfa, 0xf8, 0x16, 0x3b, 0x3e, 0xb6, 0xd4, 0xbf,
-	0x65, 0xa4, 0x41, 0x87, 0x0b, 0x6d, 0x13, 0x07, 0x43, 0xc7, 0x78, 0x6d, 0xd8, 0x7d, 0xa3, 0xd7,
-	0xc7, 0xea, 0x3f, 0xf2, 0xe9, 0xef, 0x12, 0x80, 0x98, 0xba, 0xcf, 0x9f, 0x63, 0x05, 0x1a, 0xb7,
-	0xd8, 0xf3, 0x8c, 0x2b, 0xac, 0x3e, 0x43, 0x00, 0x75, 0xd3, 0x75, 0x2e, 0xed, 0x2b, 0x55, 0x42,
-	0xfb, 0xd0, 0x16, 0xeb, 0x60, 0x38, 0xb0, 0x0c, 0x1f, 0xab, 0x15, 0xa4, 0xc1, 0x01, 0x76, 0x2c,
-	0x97, 0x78, 0x98, 0x04, 0x3e, 0x31, 0x1c, 0xcf, 0x30, 0x7d, 0xdb, 0x75, 0x54, 0x19, 0x3d, 0x87,
-	0x8e, 0x4b, 0x2c, 0x4c, 0x9e, 0x6c, 0x54, 0xd1, 0x21, 0xec, 0x5b, 0xb8, 0x6f, 0x73, 0xc7, 0x1e,
-	0xc6, 0x37, 0x81, 0xed, 0x5c, 0xba, 0x6a, 0x8d, 0xd3, 0xe6, 0xb5, 0x61, 0x3b, 0xa6, 0x6b, 0xe1,
-	0x60, 0x60, 0x98, 0x37, 0xbc, 0x7f, 0x9d, 0x37, 0x18, 0x60, 0x4c, 0x02, 0xc3, 0xba, 0xb5, 0x9d,
-	0xc0, 0x1d, 0x60, 0x62, 0x64, 0x75, 0x9a, 0xa7, 0x6f, 0x01, 0x6d, 0xa5, 0x64, 0xf3, 0x37, 0x38,
-	0xda, 0x05, 0xf0, 0xec, 0x2b, 0xc7, 0xf0, 0x87, 0x04, 0x7b, 0xea, 0x33, 0xb4, 0x07, 0x4a, 0xdf,
-	0xf0, 0xfc, 0xa0, 0x3c, 0xc4, 0x73, 0xe8, 0x6c, 0xf8, 0xf1, 0x82, 0x4b, 0xbb, 0xef, 0x63, 0xa2,
-	0x56, 0xf8, 0xb1, 0x73, 0xc3, 0xaa, 0xdc, 0xf3, 0xe0, 0xd3, 0x38, 0x99, 0x76, 0x67, 0x8f, 0x2b,
-	0x9a, 0x2c, 0xe8, 0x64, 0x4a, 0x93, 0xee, 0x5d, 0x38, 0x4a, 0xe6, 0x63, 0xf1, 0xbe, 0x4a, 0xf3,
-	0xcb, 0xfc, 0xe6, 0x6c, 0x3a, 0x67, 0xb3, 0xf5, 0x88, 0xc3, 0xf3, 0x0d, 0xf1, 0xb9, 0x10, 0x8b,
-	0x8f, 0x51, 0x9a, 0x7f, 0xb0, 0x46, 0xf5, 0x0c, 0x7e, 0xf9, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x7c, 0xfc, 0x43, 0x77, 0xc8, 0x06, 0x00, 0x00,
+	// 1056 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x95, 0xdf, 0x6f, 0xe3, 0x44,
+	0x10, 0xc7, 0xeb, 0x38, 0x3f, 0xc7, 0x4d, 0xbb, 0xdd, 0xdc, 0x71, 0xa6, 0xc7, 0xe9, 0x2a, 0x43,
+	0x51, 0xaf, 0x95, 0x52, 0x51, 0x84, 0x04, 0x8f, 0x8e, 0xbd, 0x6d, 0xad, 0x26, 0x76, 0x58, 0x3b,
+	0x87, 0xe8, 0x21, 0x59, 0x4e, 0xb2, 0x4d, 0x22, 0x12, 0x3b, 0xb2, 0x9d, 0xaa, 0x7d, 0xe6, 0x1d,
+	0x21, 0x81, 0xc4, 0x13, 0xff, 0x0b, 0x8f, 0xfc, 0x27, 0xfc, 0x03, 0x20, 0x5e, 0x91, 0xbd, 0xb6,
+	0x93, 0x94, 0x93, 0x78, 0x8a, 0x67, 0xe6, 0x93, 0x99, 0xef, 0xce, 0x8c, 0xbd, 0xd0, 0x1a, 0x05,
+	0x8b, 0x45, 0xe0, 0x9f, 0xf3, 0x9f, 0xf6, 0x32, 0x0c, 0xe2, 0x00, 0x57, 0xb9, 0x75, 0xf8, 0x7a,
+	0x12, 0x04, 0x93, 0x39, 0x3b, 0x4f, 0xbd, 0xc3, 0xd5, 0xdd, 0x79, 0x3c, 0x5b, 0xb0, 0x28, 0xf6,
+	0x16, 0x4b, 0x0e, 0x2a, 0x0a, 0x40, 0xd7, 0x8b, 0x62, 0x2d, 0xf0, 0xef, 0x66, 0x13, 0xfc, 0x0c,
+	0x2a, 0x33, 0x7f, 0xcc, 0x1e, 0x64, 0xe1, 0x48, 0x38, 0x29, 0x53, 0x6e, 0x28, 0xef, 0xa0, 0xde,
+	0x63, 0xb1, 0x37, 0xf6, 0x62, 0x2f, 0x21, 0xee, 0xbd, 0xf9, 0x8a, 0xa5, 0xc4, 0x2e, 0xe5, 0x06,
+	0xfe, 0x0a, 0x20, 0x9a, 0x4d, 0x7c, 0x2f, 0x5e, 0x85, 0x2c, 0x92, 0x4b, 0x47, 0xe2, 0x89, 0x74,
+	0xf1, 0x61, 0x3b, 0x53, 0x94, 0xff, 0xd7, 0xce, 0x09, 0xba, 0x01, 0x2b, 0xdf, 0xc1, 0xc1, 0x7f,
+	0x00, 0xfc, 0x06, 0x50, 0x81, 0xb8, 0x53, 0xe6, 0x8d, 0x59, 0x98, 0x15, 0xdc, 0x2f, 0xfc, 0xd7,
+	0xa9, 0x1b, 0x7f, 0x04, 0x8d, 0xc2, 0x25, 0x97, 0x52, 0x66, 0xed, 0x50, 0x6e, 0xa1, 0x9a, 0x71,
+	0xc7, 0xb0, 0x37, 0x9a, 0x7a, 0xbe, 0xcf, 0xe6, 0xdb, 0x09, 0x9b, 0x99, 0x37, 0xc3, 0xde, 0x57,
+	0xb9, 0xf4, 0xde, 0xca, 0xca, 0x0f, 0x25, 0x68, 0x6a, 0x5b, 0x7f, 0xc6, 0x50, 0x8e, 0x1f, 0x97,
+	0xbc, 0x37, 0x15, 0x9a, 0x3e, 0x63, 0x19, 0x6a, 0xf7, 0x2c, 0x8c, 0x66, 0x81, 0x9f, 0xe6, 0xa9,
+	0xd0, 0xdc, 0xc4, 0x5f, 0x42, 0xa3, 0x98, 0x86, 0x2c, 0x1e, 0x09, 0x27, 0xd2, 0xc5, 0x61, 0x9b,
+	0xcf, 0xab, 0x9d, 0xcf, 0xab, 0xed, 0xe4, 0x04, 0x5d, 0xc3, 0xf8, 0x15, 0x40, 0x7e, 0x96, 0xd9,
+	0x58, 0x2e, 0x1f, 0x09, 0x27, 0x0d, 0xda, 0xc8, 0x3c, 0xc6, 0x18, 0xb7, 0xa0, 0x12, 0x3f, 0x24,
+	0x91, 0x4a, 0x1a, 0x29, 0xc7, 0x0f, 0xc6, 0x38, 0x19, 0x1c, 0x5b, 0x06, 0xa3, 0xa9, 0x5c, 0xe5,
+	0xa3, 0x4d, 0x8d, 0xa4, 0x7b, 0xec, 0x21, 0x66, 0x7e, 0xaa, 0xaf, 0xc6, 0xbb, 0x57, 0x38, 0xb0,
+	0x02, 0xcd, 0x78, 0x1e, 0xb9, 0x23, 0x16, 0xc6, 0xee, 0xd4, 0x8b, 0xa6, 0x72, 0x3d, 0x25, 0xa4,
+	0x78, 0x1e, 0x69, 0x2c, 0x8c, 0xaf, 0xbd, 0x68, 0xaa, 0xa8, 0xb0, 0x6f, 0x3f, 0x19, 0x89, 0x0c,
+	0xb5, 0x51, 0xc8, 0xbc, 0x38, 0xc8, 0x7b, 0x9c, 0x9b, 0x89, 0x08, 0x3f, 0xf0, 0x47, 0xf9, 0xa0,
+	0xb8, 0xa1, 0x10, 0xa8, 0xf5, 0xbd, 0xc7, 0x79, 0xe0, 0x8d, 0xf1, 0xa7, 0x50, 0xdd, 0x98, 0x8e,
+	0x74, 0xb1, 0x97, 0x2f, 0x11, 0x4f, 0x4d, 0xab, 0xd3, 0xa2, 0xd3, 0xc9, 0xc6, 0x64, 0x79, 0xd2,
+	0x67, 0xa5, 0x03, 0x75, 0xe2, 0xdf, 0xb3, 0x79, 0xc0, 0xbb, 0xbe, 0xe4, 0x29, 0x73, 0x09, 0x99,
+	0xf9, 0x3f, 0xfb, 0xf2, 0xa3, 0x00, 0x95, 0xce, 0x3c, 0x18, 0x7d, 0x8f, 0xcf, 0x9e, 0x28, 0x69,
+	0xe5, 0x4a, 0xd2, 0xf0, 0x13, 0x39, 0xc7, 0x1b, 0x72, 0xa4, 0x8b, 0x83, 0x2d, 0x54, 0xf7, 0x62,
+	0x8f, 0x2b, 0xc4, 0x9f, 0x41, 0x7d, 0x91, 0xed, 0x7a, 0x36, 0xf0, 0xe7, 0x5b, 0x68, 0xfe, 0x22,
+	0xd0, 0x02, 0x53, 0x26, 0x20, 0x6d, 0x14, 0xc4, 0x1f, 0x40, 0xd5, 0x5f, 0x2d, 0x86, 0x99, 0xaa,
+	0x32, 0xcd, 0x2c, 0xfc, 0x31, 0x34, 0x97, 0x21, 0xbb, 0x9f, 0x05, 0xab, 0x88, 0x4f, 0x8a, 0x9f,
+	0x6c, 0x37, 0x77, 0x26, 0xa3, 0xc2, 0x2f, 0xa1, 0x91, 0xe4, 0xe4, 0x80, 0x98, 0x02, 0xf5, 0xc4,
+	0x91, 0xce, 0xf1, 0x35, 0x34, 0x0a, 0xb9, 0x45, 0x7b, 0x85, 0x23, 0xb1, 0x68, 0xef, 0x19, 0x34,
+	0xb7, 0x44, 0xe2, 0xc3, 0x8d, 0xd3, 0x70, 0x70, 0x2d, 0xfb, 0x57, 0x01, 0x20, 0xa5, 0x3b, 0x5e,
+	0x3c, 0x9a, 0xe2, 0x63, 0xa8, 0x0e, 0x13, 0x2b, 0x4a, 0x41, 0xe9, 0xa2, 0xb9, 0x75, 0x6c, 0x9a,
+	0x05, 0xf1, 0x17, 0x20, 0x8d, 0x82, 0xc5, 0x32, 0x64, 0x51, 0xf1, 0xbe, 0xec, 0xad, 0x1b, 0xaf,
+	0xad, 0x43, 0x74, 0x93, 0xc3, 0x67, 0x70, 0x90, 0x9b, 0x6c, 0xec, 0x66, 0x85, 0xf8, 0xf9, 0xd0,
+	0x3a, 0x90, 0x96, 0x8a, 0x4e, 0x7f, 0x17, 0xa0, 0x6a, 0xc7, 0x5e, 0xbc, 0x8a, 0xb0, 0x04, 0xb5,
+	0x81, 0x79, 0x63, 0x5a, 0xdf, 0x98, 0x68, 0x07, 0xef, 0x42, 0xcd, 0x1e, 0x68, 0x1a, 0xb1, 0x6d,
+	0xf4, 0x87, 0x80, 0x11, 0x48, 0x1d, 0x55, 0x77, 0x29, 0xf9, 0x7a, 0x40, 0x6c, 0x07, 0xfd, 0x24,
+	0xe2, 0x3d, 0x68, 0x5c, 0x5a, 0xb4, 0x63, 0xe8, 0x3a, 0x31, 0xd1, 0xcf, 0xa9, 0x6d, 0x5a, 0x8e,
+	0x7b, 0x69, 0x0d, 0x4c, 0x1d, 0xfd, 0x22, 0xe2, 0x57, 0x20, 0x67, 0xb4, 0x4b, 0x4c, 0xc7, 0x70,
+	0xbe, 0x75, 0x1d, 0xcb, 0x72, 0xbb, 0x2a, 0xbd, 0x22, 0xe8, 0x37, 0x11, 0x1f, 0xc2, 0x73, 0xc3,
+	0x74, 0x08, 0x35, 0xd5, 0xae, 0x6b, 0x13, 0xfa, 0x96, 0x50, 0x97, 0x50, 0x6a, 0x51, 0xf4, 0x97,
+	0x88, 0x9f, 0xc1, 0x7e, 0x92, 0xca, 0xe8, 0xf5, 0xbb, 0xa4, 0x47, 0x4c, 0x87, 0xe8, 0xe8, 0x6f,
+	0x11, 0xcb, 0xd0, 0x4a, 0x40, 0x43, 0x23, 0xee, 0xc0, 0x54, 0xdf, 0xaa, 0x46, 0x57, 0xed, 0x74,
+	0x09, 0xfa, 0x47, 0x3c, 0xfd, 0x53, 0x00, 0xe0, 0xfb, 0xe0, 0x24, 0x5f, 0x18, 0x09, 0x6a, 0x3d,
+	0x62, 0xdb, 0xea, 0x15, 0x41, 0x3b, 0x18, 0xa0, 0xaa, 0x59, 0xe6, 0xa5, 0x71, 0x85, 0x04, 0x7c,
+	0x00, 0x4d, 0xfe, 0xec, 0x0e, 0xfa, 0xba, 0xea, 0x10, 0x54, 0xc2, 0x32, 0x3c, 0x23, 0xa6, 0x6e,
+	0x51, 0x9b, 0x50, 0xd7, 0xa1, 0xaa, 0x69, 0xab, 0x9a, 0x63, 0x58, 0x26, 0x12, 0xf1, 0x0b, 0x68,
+	0x59, 0x54, 0x27, 0xf4, 0x49, 0xa0, 0x8c, 0x9f, 0xc3, 0x81, 0x4e, 0xba, 0x46, 0xa2, 0xd8, 0x26,
+	0xe4, 0xc6, 0x35, 0xcc, 0x4b, 0x0b, 0x55, 0x12, 0xb7, 0x76, 0xad, 0x1a, 0xa6, 0x66, 0xe9, 0xc4,
+	0xed, 0xab, 0xda, 0x4d, 0x52, 0xbf, 0x9a, 0x14, 0xe8, 0x13, 0x42, 0x5d, 0x55, 0xef, 0x19, 0xa6,
+	0x6b, 0xf5, 0x09, 0x55, 0xd3, 0x3c, 0x75, 0xfc, 0x12, 0x5e, 0xe4, 0x05, 0x9e, 0x06, 0x1b, 0x49,
+	0x36, 0xc7, 0xba, 0x21, 0xe6, 0x56, 0x6d, 0x38, 0x7d, 0x07, 0x78, 0x6b, 0xe7, 0x8c, 0xe4, 0x3e,
+	0xc2, 0x7b, 0x00, 0xb6, 0x71, 0x65, 0xaa, 0xce, 0x80, 0x12, 0x1b, 0xed, 0xe0, 0x7d, 0x90, 0xba,
+	0xaa, 0xed, 0xb8, 0xc5, 0xc1, 0x5f, 0x40, 0x6b, 0x23, 0x8f, 0xed, 0x5e, 0x1a, 0x5d, 0x87, 0x50,
+	0x54, 0x4a, 0x5a, 0x95, 0x69, 0x40, 0xe2, 0xe9, 0x1b, 0x90, 0x36, 0x56, 0x0a, 0x23, 0xd8, 0x1d,
+	0x98, 0x9a, 0xd5, 0xeb, 0x53, 0x62, 0xdb, 0x44, 0x47, 0x3b, 0xb8, 0x0e, 0xe5, 0xab, 0x5b, 0xa3,
+	0x8f, 0x84, 0x8e, 0x0d, 0x9f, 0x04, 0xe1, 0xa4, 0x3d, 0x7d, 0x5c, 0xb2, 0x70, 0xce, 0xc6, 0x13,
+	0x16, 0xb6, 0xef, 0xbc, 0x61, 0x38, 0x1b, 0xf1, 0x0f, 0x75, 0x94, 0xad, 0xe8, 0xed, 0xd9, 0x64,
+	0x16, 0x4f, 0x57, 0xc3, 0xc4, 0x3c, 0xdf, 0x80, 0xcf, 0x39, 0xcc, 0x6f, 0xe1, 0x28, 0xbb, 0xa9,
+	0x87, 0xd5, 0xd4, 0xfc, 0xfc, 0xdf, 0x01, 0x00, 0xea, 0xb6, 0x77, 0x71, 0xc1, 0x07, 0x00, 0x00,
 }
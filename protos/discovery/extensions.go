@@ -19,6 +19,11 @@ const (
 	PeerMembershipQueryType
 	ChaincodeQueryType
 	LocalMembershipQueryType
+	SnapshotPeersQueryType
+	PolicySimulationQueryType
+	ChaincodeVersionsQueryType
+	ConfigUpdateImpactQueryType
+	OrgCombinationsQueryType
 )
 
 // GetType returns the type of the request
@@ -35,6 +40,21 @@ func (q *Query) GetType() QueryType {
 	if q.GetLocalPeers() != nil {
 		return LocalMembershipQueryType
 	}
+	if q.GetSnapshotPeers() != nil {
+		return SnapshotPeersQueryType
+	}
+	if q.GetPolicySimulation() != nil {
+		return PolicySimulationQueryType
+	}
+	if q.GetChaincodeVersions() != nil {
+		return ChaincodeVersionsQueryType
+	}
+	if q.GetConfigUpdateImpact() != nil {
+		return ConfigUpdateImpactQueryType
+	}
+	if q.GetOrgCombinations() != nil {
+		return OrgCombinationsQueryType
+	}
 	return InvalidQueryType
 }
 
@@ -60,9 +80,50 @@ func (m *Response) MembershipAt(i int) (*PeerMembershipResult, *Error) {
 	return r.GetMembers(), r.GetError()
 }
 
+// PolicySimulationAt returns the PolicySimulationResult at a given index in the Response,
+// or an Error if present.
+func (m *Response) PolicySimulationAt(i int) (*PolicySimulationResult, *Error) {
+	r := m.Results[i]
+	return r.GetPolicySimulationRes(), r.GetError()
+}
+
+// ChaincodeVersionsAt returns the ChaincodeVersionsResult at a given index in the Response,
+// or an Error if present.
+func (m *Response) ChaincodeVersionsAt(i int) (*ChaincodeVersionsResult, *Error) {
+	r := m.Results[i]
+	return r.GetChaincodeVersionsRes(), r.GetError()
+}
+
+// ConfigUpdateImpactAt returns the ConfigUpdateImpactResult at a given index in the Response,
+// or an Error if present.
+func (m *Response) ConfigUpdateImpactAt(i int) (*ConfigUpdateImpactResult, *Error) {
+	r := m.Results[i]
+	return r.GetConfigUpdateImpactRes(), r.GetError()
+}
+
+// OrgCombinationsAt returns the OrgCombinationsResult at a given index in the Response,
+// or an Error if present.
+func (m *Response) OrgCombinationsAt(i int) (*OrgCombinationsResult, *Error) {
+	r := m.Results[i]
+	return r.GetOrgCombinationsRes(), r.GetError()
+}
+
 // EndorsersAt returns the PeerMembershipResult at a given index in the Response,
 // or an Error if present.
 func (m *Response) EndorsersAt(i int) (*ChaincodeQueryResult, *Error) {
 	r := m.Results[i]
 	return r.GetCcQueryRes(), r.GetError()
 }
+
+// SignedContent returns the message that a ResponseSignature over this Response
+// signs in the context of a request with the given nonce, which is the nonce
+// followed by this Response without its signature in serialized form.
+func (m *Response) SignedContent(nonce []byte) ([]byte, error) {
+	content, err := proto.Marshal(&Response{Results: m.Results})
+	if err != nil {
+		return nil, err
+	}
+	msg := make([]byte, 0, len(nonce)+len(content))
+	msg = append(msg, nonce...)
+	return append(msg, content...), nil
+}
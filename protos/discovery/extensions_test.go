@@ -53,9 +53,68 @@ func TestGetType(t *testing.T) {
 		},
 	}
 	assert.Equal(t, ChaincodeQueryType, q.GetType())
+	q = &Query{
+		Query: &Query_SnapshotPeers{
+			SnapshotPeers: &SnapshotPeersQuery{},
+		},
+	}
+	assert.Equal(t, SnapshotPeersQueryType, q.GetType())
+	q = &Query{
+		Query: &Query_PolicySimulation{
+			PolicySimulation: &PolicySimulationQuery{},
+		},
+	}
+	assert.Equal(t, PolicySimulationQueryType, q.GetType())
+	q = &Query{
+		Query: &Query_ChaincodeVersions{
+			ChaincodeVersions: &ChaincodeVersionsQuery{},
+		},
+	}
+	assert.Equal(t, ChaincodeVersionsQueryType, q.GetType())
+	q = &Query{
+		Query: &Query_ConfigUpdateImpact{
+			ConfigUpdateImpact: &ConfigUpdateImpactQuery{},
+		},
+	}
+	assert.Equal(t, ConfigUpdateImpactQueryType, q.GetType())
+
+	q = &Query{
+		Query: &Query_OrgCombinations{
+			OrgCombinations: &OrgCombinationsQuery{},
+		},
+	}
+	assert.Equal(t, OrgCombinationsQueryType, q.GetType())
 
 	q = &Query{
 		Query: &invalidQuery{},
 	}
 	assert.Equal(t, InvalidQueryType, q.GetType())
 }
+
+func TestSignedContent(t *testing.T) {
+	resp := &Response{
+		Results: []*QueryResult{
+			{
+				Result: &QueryResult_Error{
+					Error: &Error{Content: "foo"},
+				},
+			},
+		},
+	}
+	unsigned, _ := proto.Marshal(resp)
+
+	msg, err := resp.SignedContent([]byte("nonce"))
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("nonce"), unsigned...), msg)
+
+	// The signature of the Response isn't part of the signed content
+	resp.Signature = &ResponseSignature{Identity: []byte("p1"), Signature: []byte("sig")}
+	msg2, err := resp.SignedContent([]byte("nonce"))
+	assert.NoError(t, err)
+	assert.Equal(t, msg, msg2)
+
+	// Different nonces yield different signed content
+	msg3, err := resp.SignedContent([]byte("another nonce"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, msg, msg3)
+}
@@ -11,6 +11,7 @@ It has these top-level messages:
 	SignedRequest
 	Request
 	Response
+	ResponseSignature
 	AuthInfo
 	Query
 	QueryResult
@@ -23,6 +24,20 @@ It has these top-level messages:
 	ChaincodeCall
 	ChaincodeQueryResult
 	LocalPeerQuery
+	SnapshotPeersQuery
+	PolicySimulationQuery
+	PolicySimulationResult
+	ChaincodeVersionsQuery
+	ChaincodeVersionsResult
+	ChaincodeVersions
+	ConfigUpdateImpactQuery
+	ConfigUpdateImpactResult
+	OrgCombinationsQuery
+	OrgCombinationsResult
+	OrgCombinations
+	OrgCombination
+	PolicyChange
+	EjectedPeer
 	EndorsementDescriptor
 	Layout
 	Peers
@@ -39,6 +54,7 @@ import math "math"
 import gossip "github.com/hyperledger/fabric/protos/gossip"
 import msp "github.com/hyperledger/fabric/protos/msp"
 import _ "github.com/hyperledger/fabric/protos/msp"
+import common1 "github.com/hyperledger/fabric/protos/common"
 
 import (
 	context "golang.org/x/net/context"
@@ -56,6 +72,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// DescriptorVersion is the format of an EndorsementDescriptor
+type DescriptorVersion int32
+
+const (
+	// In V1 descriptors, groups are named after the principals
+	// of the endorsement policy
+	DescriptorVersion_V1 DescriptorVersion = 0
+	// In V2 descriptors, groups are keyed by the MSP IDs of the endorsers,
+	// and the layouts reference the MSP IDs directly.
+	// Principals of the same MSP are merged into a single group.
+	DescriptorVersion_V2 DescriptorVersion = 1
+)
+
+var DescriptorVersion_name = map[int32]string{
+	0: "V1",
+	1: "V2",
+}
+var DescriptorVersion_value = map[string]int32{
+	"V1": 0,
+	"V2": 1,
+}
+
+func (x DescriptorVersion) String() string {
+	return proto.EnumName(DescriptorVersion_name, int32(x))
+}
+func (DescriptorVersion) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type PolicyChange_Type int32
+
+const (
+	PolicyChange_ADDED    PolicyChange_Type = 0
+	PolicyChange_REMOVED  PolicyChange_Type = 1
+	PolicyChange_MODIFIED PolicyChange_Type = 2
+)
+
+var PolicyChange_Type_name = map[int32]string{
+	0: "ADDED",
+	1: "REMOVED",
+	2: "MODIFIED",
+}
+var PolicyChange_Type_value = map[string]int32{
+	"ADDED":    0,
+	"REMOVED":  1,
+	"MODIFIED": 2,
+}
+
+func (x PolicyChange_Type) String() string {
+	return proto.EnumName(PolicyChange_Type_name, int32(x))
+}
+func (PolicyChange_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{28, 0} }
+
 // SignedRequest contains a serialized Request in the payload field
 // and a signature.
 // The identity that is used to verify the signature
@@ -93,6 +160,9 @@ type Request struct {
 	Authentication *AuthInfo `protobuf:"bytes,1,opt,name=authentication" json:"authentication,omitempty"`
 	// queries
 	Queries []*Query `protobuf:"bytes,2,rep,name=queries" json:"queries,omitempty"`
+	// nonce, if set, asks the service to sign its response,
+	// and binds the signature of the response to this request.
+	Nonce []byte `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -114,9 +184,19 @@ func (m *Request) GetQueries() []*Query {
 	return nil
 }
 
+func (m *Request) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
 type Response struct {
 	// The results are returned in the same order of the queries
 	Results []*QueryResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	// signature is the signature of the peer over the response,
+	// and is only present if the request contained a nonce.
+	Signature *ResponseSignature `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
@@ -131,6 +211,44 @@ func (m *Response) GetResults() []*QueryResult {
 	return nil
 }
 
+func (m *Response) GetSignature() *ResponseSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ResponseSignature is a signature of a peer over a Response.
+// The signed message is the nonce of the request, followed by
+// the Response without its signature in serialized form.
+// This allows clients to trust responses that were relayed to them
+// by intermediaries, and not only responses received directly from the peer.
+type ResponseSignature struct {
+	// identity is the identity of the peer that signed the response.
+	// It is a msp.SerializedIdentity in bytes form
+	Identity  []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ResponseSignature) Reset()                    { *m = ResponseSignature{} }
+func (m *ResponseSignature) String() string            { return proto.CompactTextString(m) }
+func (*ResponseSignature) ProtoMessage()               {}
+func (*ResponseSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ResponseSignature) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *ResponseSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // AuthInfo aggregates authentication information that the server uses
 // to authenticate the client
 type AuthInfo struct {
@@ -151,7 +269,7 @@ type AuthInfo struct {
 func (m *AuthInfo) Reset()                    { *m = AuthInfo{} }
 func (m *AuthInfo) String() string            { return proto.CompactTextString(m) }
 func (*AuthInfo) ProtoMessage()               {}
-func (*AuthInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*AuthInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *AuthInfo) GetClientIdentity() []byte {
 	if m != nil {
@@ -175,13 +293,18 @@ type Query struct {
 	//	*Query_PeerQuery
 	//	*Query_CcQuery
 	//	*Query_LocalPeers
+	//	*Query_SnapshotPeers
+	//	*Query_PolicySimulation
+	//	*Query_ChaincodeVersions
+	//	*Query_ConfigUpdateImpact
+	//	*Query_OrgCombinations
 	Query isQuery_Query `protobuf_oneof:"query"`
 }
 
 func (m *Query) Reset()                    { *m = Query{} }
 func (m *Query) String() string            { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()               {}
-func (*Query) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*Query) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 type isQuery_Query interface{ isQuery_Query() }
 
@@ -197,11 +320,31 @@ type Query_CcQuery struct {
 type Query_LocalPeers struct {
 	LocalPeers *LocalPeerQuery `protobuf:"bytes,5,opt,name=local_peers,json=localPeers,oneof"`
 }
+type Query_SnapshotPeers struct {
+	SnapshotPeers *SnapshotPeersQuery `protobuf:"bytes,6,opt,name=snapshot_peers,json=snapshotPeers,oneof"`
+}
+type Query_PolicySimulation struct {
+	PolicySimulation *PolicySimulationQuery `protobuf:"bytes,7,opt,name=policy_simulation,json=policySimulation,oneof"`
+}
+type Query_ChaincodeVersions struct {
+	ChaincodeVersions *ChaincodeVersionsQuery `protobuf:"bytes,8,opt,name=chaincode_versions,json=chaincodeVersions,oneof"`
+}
+type Query_ConfigUpdateImpact struct {
+	ConfigUpdateImpact *ConfigUpdateImpactQuery `protobuf:"bytes,9,opt,name=config_update_impact,json=configUpdateImpact,oneof"`
+}
+type Query_OrgCombinations struct {
+	OrgCombinations *OrgCombinationsQuery `protobuf:"bytes,10,opt,name=org_combinations,json=orgCombinations,oneof"`
+}
 
-func (*Query_ConfigQuery) isQuery_Query() {}
-func (*Query_PeerQuery) isQuery_Query()   {}
-func (*Query_CcQuery) isQuery_Query()     {}
-func (*Query_LocalPeers) isQuery_Query()  {}
+func (*Query_ConfigQuery) isQuery_Query()        {}
+func (*Query_PeerQuery) isQuery_Query()          {}
+func (*Query_CcQuery) isQuery_Query()            {}
+func (*Query_LocalPeers) isQuery_Query()         {}
+func (*Query_SnapshotPeers) isQuery_Query()      {}
+func (*Query_PolicySimulation) isQuery_Query()   {}
+func (*Query_ChaincodeVersions) isQuery_Query()  {}
+func (*Query_ConfigUpdateImpact) isQuery_Query() {}
+func (*Query_OrgCombinations) isQuery_Query()    {}
 
 func (m *Query) GetQuery() isQuery_Query {
 	if m != nil {
@@ -245,6 +388,41 @@ func (m *Query) GetLocalPeers() *LocalPeerQuery {
 	return nil
 }
 
+func (m *Query) GetSnapshotPeers() *SnapshotPeersQuery {
+	if x, ok := m.GetQuery().(*Query_SnapshotPeers); ok {
+		return x.SnapshotPeers
+	}
+	return nil
+}
+
+func (m *Query) GetPolicySimulation() *PolicySimulationQuery {
+	if x, ok := m.GetQuery().(*Query_PolicySimulation); ok {
+		return x.PolicySimulation
+	}
+	return nil
+}
+
+func (m *Query) GetChaincodeVersions() *ChaincodeVersionsQuery {
+	if x, ok := m.GetQuery().(*Query_ChaincodeVersions); ok {
+		return x.ChaincodeVersions
+	}
+	return nil
+}
+
+func (m *Query) GetConfigUpdateImpact() *ConfigUpdateImpactQuery {
+	if x, ok := m.GetQuery().(*Query_ConfigUpdateImpact); ok {
+		return x.ConfigUpdateImpact
+	}
+	return nil
+}
+
+func (m *Query) GetOrgCombinations() *OrgCombinationsQuery {
+	if x, ok := m.GetQuery().(*Query_OrgCombinations); ok {
+		return x.OrgCombinations
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Query_OneofMarshaler, _Query_OneofUnmarshaler, _Query_OneofSizer, []interface{}{
@@ -252,6 +430,11 @@ func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Query_PeerQuery)(nil),
 		(*Query_CcQuery)(nil),
 		(*Query_LocalPeers)(nil),
+		(*Query_SnapshotPeers)(nil),
+		(*Query_PolicySimulation)(nil),
+		(*Query_ChaincodeVersions)(nil),
+		(*Query_ConfigUpdateImpact)(nil),
+		(*Query_OrgCombinations)(nil),
 	}
 }
 
@@ -279,6 +462,31 @@ func _Query_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LocalPeers); err != nil {
 			return err
 		}
+	case *Query_SnapshotPeers:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SnapshotPeers); err != nil {
+			return err
+		}
+	case *Query_PolicySimulation:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PolicySimulation); err != nil {
+			return err
+		}
+	case *Query_ChaincodeVersions:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeVersions); err != nil {
+			return err
+		}
+	case *Query_ConfigUpdateImpact:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfigUpdateImpact); err != nil {
+			return err
+		}
+	case *Query_OrgCombinations:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrgCombinations); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Query.Query has unexpected type %T", x)
@@ -321,6 +529,46 @@ func _Query_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Query = &Query_LocalPeers{msg}
 		return true, err
+	case 6: // query.snapshot_peers
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SnapshotPeersQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_SnapshotPeers{msg}
+		return true, err
+	case 7: // query.policy_simulation
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PolicySimulationQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_PolicySimulation{msg}
+		return true, err
+	case 8: // query.chaincode_versions
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeVersionsQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_ChaincodeVersions{msg}
+		return true, err
+	case 9: // query.config_update_impact
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfigUpdateImpactQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_ConfigUpdateImpact{msg}
+		return true, err
+	case 10: // query.org_combinations
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OrgCombinationsQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_OrgCombinations{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -350,6 +598,31 @@ func _Query_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Query_SnapshotPeers:
+		s := proto.Size(x.SnapshotPeers)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Query_PolicySimulation:
+		s := proto.Size(x.PolicySimulation)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Query_ChaincodeVersions:
+		s := proto.Size(x.ChaincodeVersions)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Query_ConfigUpdateImpact:
+		s := proto.Size(x.ConfigUpdateImpact)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Query_OrgCombinations:
+		s := proto.Size(x.OrgCombinations)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -367,13 +640,17 @@ type QueryResult struct {
 	//	*QueryResult_ConfigResult
 	//	*QueryResult_CcQueryRes
 	//	*QueryResult_Members
+	//	*QueryResult_PolicySimulationRes
+	//	*QueryResult_ChaincodeVersionsRes
+	//	*QueryResult_ConfigUpdateImpactRes
+	//	*QueryResult_OrgCombinationsRes
 	Result isQueryResult_Result `protobuf_oneof:"result"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
 func (m *QueryResult) String() string            { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()               {}
-func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 type isQueryResult_Result interface{ isQueryResult_Result() }
 
@@ -389,11 +666,27 @@ type QueryResult_CcQueryRes struct {
 type QueryResult_Members struct {
 	Members *PeerMembershipResult `protobuf:"bytes,4,opt,name=members,oneof"`
 }
+type QueryResult_PolicySimulationRes struct {
+	PolicySimulationRes *PolicySimulationResult `protobuf:"bytes,5,opt,name=policy_simulation_res,json=policySimulationRes,oneof"`
+}
+type QueryResult_ChaincodeVersionsRes struct {
+	ChaincodeVersionsRes *ChaincodeVersionsResult `protobuf:"bytes,6,opt,name=chaincode_versions_res,json=chaincodeVersionsRes,oneof"`
+}
+type QueryResult_ConfigUpdateImpactRes struct {
+	ConfigUpdateImpactRes *ConfigUpdateImpactResult `protobuf:"bytes,7,opt,name=config_update_impact_res,json=configUpdateImpactRes,oneof"`
+}
+type QueryResult_OrgCombinationsRes struct {
+	OrgCombinationsRes *OrgCombinationsResult `protobuf:"bytes,8,opt,name=org_combinations_res,json=orgCombinationsRes,oneof"`
+}
 
-func (*QueryResult_Error) isQueryResult_Result()        {}
-func (*QueryResult_ConfigResult) isQueryResult_Result() {}
-func (*QueryResult_CcQueryRes) isQueryResult_Result()   {}
-func (*QueryResult_Members) isQueryResult_Result()      {}
+func (*QueryResult_Error) isQueryResult_Result()                 {}
+func (*QueryResult_ConfigResult) isQueryResult_Result()          {}
+func (*QueryResult_CcQueryRes) isQueryResult_Result()            {}
+func (*QueryResult_Members) isQueryResult_Result()               {}
+func (*QueryResult_PolicySimulationRes) isQueryResult_Result()   {}
+func (*QueryResult_ChaincodeVersionsRes) isQueryResult_Result()  {}
+func (*QueryResult_ConfigUpdateImpactRes) isQueryResult_Result() {}
+func (*QueryResult_OrgCombinationsRes) isQueryResult_Result()    {}
 
 func (m *QueryResult) GetResult() isQueryResult_Result {
 	if m != nil {
@@ -430,6 +723,34 @@ func (m *QueryResult) GetMembers() *PeerMembershipResult {
 	return nil
 }
 
+func (m *QueryResult) GetPolicySimulationRes() *PolicySimulationResult {
+	if x, ok := m.GetResult().(*QueryResult_PolicySimulationRes); ok {
+		return x.PolicySimulationRes
+	}
+	return nil
+}
+
+func (m *QueryResult) GetChaincodeVersionsRes() *ChaincodeVersionsResult {
+	if x, ok := m.GetResult().(*QueryResult_ChaincodeVersionsRes); ok {
+		return x.ChaincodeVersionsRes
+	}
+	return nil
+}
+
+func (m *QueryResult) GetConfigUpdateImpactRes() *ConfigUpdateImpactResult {
+	if x, ok := m.GetResult().(*QueryResult_ConfigUpdateImpactRes); ok {
+		return x.ConfigUpdateImpactRes
+	}
+	return nil
+}
+
+func (m *QueryResult) GetOrgCombinationsRes() *OrgCombinationsResult {
+	if x, ok := m.GetResult().(*QueryResult_OrgCombinationsRes); ok {
+		return x.OrgCombinationsRes
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*QueryResult) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _QueryResult_OneofMarshaler, _QueryResult_OneofUnmarshaler, _QueryResult_OneofSizer, []interface{}{
@@ -437,6 +758,10 @@ func (*QueryResult) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) e
 		(*QueryResult_ConfigResult)(nil),
 		(*QueryResult_CcQueryRes)(nil),
 		(*QueryResult_Members)(nil),
+		(*QueryResult_PolicySimulationRes)(nil),
+		(*QueryResult_ChaincodeVersionsRes)(nil),
+		(*QueryResult_ConfigUpdateImpactRes)(nil),
+		(*QueryResult_OrgCombinationsRes)(nil),
 	}
 }
 
@@ -464,6 +789,26 @@ func _QueryResult_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Members); err != nil {
 			return err
 		}
+	case *QueryResult_PolicySimulationRes:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PolicySimulationRes); err != nil {
+			return err
+		}
+	case *QueryResult_ChaincodeVersionsRes:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeVersionsRes); err != nil {
+			return err
+		}
+	case *QueryResult_ConfigUpdateImpactRes:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfigUpdateImpactRes); err != nil {
+			return err
+		}
+	case *QueryResult_OrgCombinationsRes:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrgCombinationsRes); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("QueryResult.Result has unexpected type %T", x)
@@ -506,6 +851,38 @@ func _QueryResult_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Bu
 		err := b.DecodeMessage(msg)
 		m.Result = &QueryResult_Members{msg}
 		return true, err
+	case 5: // result.policy_simulation_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PolicySimulationResult)
+		err := b.DecodeMessage(msg)
+		m.Result = &QueryResult_PolicySimulationRes{msg}
+		return true, err
+	case 6: // result.chaincode_versions_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeVersionsResult)
+		err := b.DecodeMessage(msg)
+		m.Result = &QueryResult_ChaincodeVersionsRes{msg}
+		return true, err
+	case 7: // result.config_update_impact_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfigUpdateImpactResult)
+		err := b.DecodeMessage(msg)
+		m.Result = &QueryResult_ConfigUpdateImpactRes{msg}
+		return true, err
+	case 8: // result.org_combinations_res
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OrgCombinationsResult)
+		err := b.DecodeMessage(msg)
+		m.Result = &QueryResult_OrgCombinationsRes{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -535,6 +912,26 @@ func _QueryResult_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(4<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *QueryResult_PolicySimulationRes:
+		s := proto.Size(x.PolicySimulationRes)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *QueryResult_ChaincodeVersionsRes:
+		s := proto.Size(x.ChaincodeVersionsRes)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *QueryResult_ConfigUpdateImpactRes:
+		s := proto.Size(x.ConfigUpdateImpactRes)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *QueryResult_OrgCombinationsRes:
+		s := proto.Size(x.OrgCombinationsRes)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -549,7 +946,7 @@ type ConfigQuery struct {
 func (m *ConfigQuery) Reset()                    { *m = ConfigQuery{} }
 func (m *ConfigQuery) String() string            { return proto.CompactTextString(m) }
 func (*ConfigQuery) ProtoMessage()               {}
-func (*ConfigQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*ConfigQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type ConfigResult struct {
 	// msps is a map from MSP_ID to FabricMSPConfig
@@ -561,7 +958,7 @@ type ConfigResult struct {
 func (m *ConfigResult) Reset()                    { *m = ConfigResult{} }
 func (m *ConfigResult) String() string            { return proto.CompactTextString(m) }
 func (*ConfigResult) ProtoMessage()               {}
-func (*ConfigResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ConfigResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *ConfigResult) GetMsps() map[string]*msp.FabricMSPConfig {
 	if m != nil {
@@ -584,7 +981,7 @@ type PeerMembershipQuery struct {
 func (m *PeerMembershipQuery) Reset()                    { *m = PeerMembershipQuery{} }
 func (m *PeerMembershipQuery) String() string            { return proto.CompactTextString(m) }
 func (*PeerMembershipQuery) ProtoMessage()               {}
-func (*PeerMembershipQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*PeerMembershipQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 // PeerMembershipResult contains peers mapped by their organizations (MSP_ID)
 type PeerMembershipResult struct {
@@ -594,7 +991,7 @@ type PeerMembershipResult struct {
 func (m *PeerMembershipResult) Reset()                    { *m = PeerMembershipResult{} }
 func (m *PeerMembershipResult) String() string            { return proto.CompactTextString(m) }
 func (*PeerMembershipResult) ProtoMessage()               {}
-func (*PeerMembershipResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*PeerMembershipResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PeerMembershipResult) GetPeersByOrg() map[string]*Peers {
 	if m != nil {
@@ -609,12 +1006,17 @@ func (m *PeerMembershipResult) GetPeersByOrg() map[string]*Peers {
 // is evaluated independantly for each given interest.
 type ChaincodeQuery struct {
 	Interests []*ChaincodeInterest `protobuf:"bytes,1,rep,name=interests" json:"interests,omitempty"`
+	// descriptor_version is the format of the endorsement descriptors
+	// the client expects in the result.
+	// Peers that don't support descriptor versions return V1 descriptors,
+	// hence clients should check the version of the descriptors they receive.
+	DescriptorVersion DescriptorVersion `protobuf:"varint,2,opt,name=descriptor_version,json=descriptorVersion,enum=discovery.DescriptorVersion" json:"descriptor_version,omitempty"`
 }
 
 func (m *ChaincodeQuery) Reset()                    { *m = ChaincodeQuery{} }
 func (m *ChaincodeQuery) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeQuery) ProtoMessage()               {}
-func (*ChaincodeQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*ChaincodeQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *ChaincodeQuery) GetInterests() []*ChaincodeInterest {
 	if m != nil {
@@ -623,17 +1025,37 @@ func (m *ChaincodeQuery) GetInterests() []*ChaincodeInterest {
 	return nil
 }
 
+func (m *ChaincodeQuery) GetDescriptorVersion() DescriptorVersion {
+	if m != nil {
+		return m.DescriptorVersion
+	}
+	return DescriptorVersion_V1
+}
+
 // ChaincodeInterest defines an interest about an endorsement
 // for a specific single chaincode invocation.
 // Multiple chaincodes indicate chaincode to chaincode invocations.
 type ChaincodeInterest struct {
 	Chaincodes []*ChaincodeCall `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	// If greater than 0, peers whose ledger height lags behind the highest
+	// ledger height in the channel by more than this number of blocks
+	// are excluded from the endorsement descriptor.
+	// Overrides the exclusion the peer is configured with.
+	MaxLedgerHeightLag uint64 `protobuf:"varint,2,opt,name=max_ledger_height_lag,json=maxLedgerHeightLag" json:"max_ledger_height_lag,omitempty"`
+	// preferred_orgs are MSP IDs of organizations whose peers are preferred as endorsers,
+	// i.e because they are close to the client. Layouts that can be satisfied by
+	// peers of these organizations are listed first, and so are their peers in groups.
+	PreferredOrgs []string `protobuf:"bytes,3,rep,name=preferred_orgs,json=preferredOrgs" json:"preferred_orgs,omitempty"`
+	// If greater than 0, peers whose round trip time from the peer that computes the
+	// endorsement descriptor is at most this number of microseconds are preferred as
+	// endorsers, in addition to the peers of the preferred_orgs.
+	MaxPreferredRttMicros uint64 `protobuf:"varint,4,opt,name=max_preferred_rtt_micros,json=maxPreferredRttMicros" json:"max_preferred_rtt_micros,omitempty"`
 }
 
 func (m *ChaincodeInterest) Reset()                    { *m = ChaincodeInterest{} }
 func (m *ChaincodeInterest) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeInterest) ProtoMessage()               {}
-func (*ChaincodeInterest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ChaincodeInterest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *ChaincodeInterest) GetChaincodes() []*ChaincodeCall {
 	if m != nil {
@@ -642,17 +1064,55 @@ func (m *ChaincodeInterest) GetChaincodes() []*ChaincodeCall {
 	return nil
 }
 
+func (m *ChaincodeInterest) GetMaxLedgerHeightLag() uint64 {
+	if m != nil {
+		return m.MaxLedgerHeightLag
+	}
+	return 0
+}
+
+func (m *ChaincodeInterest) GetPreferredOrgs() []string {
+	if m != nil {
+		return m.PreferredOrgs
+	}
+	return nil
+}
+
+func (m *ChaincodeInterest) GetMaxPreferredRttMicros() uint64 {
+	if m != nil {
+		return m.MaxPreferredRttMicros
+	}
+	return 0
+}
+
 // ChaincodeCall defines a call to a chaincode.
-// It may have collections that are related to the chaincode
+// It may have collections that are related to the chaincode,
+// and key-level (state-based) endorsement policies of the keys it writes
 type ChaincodeCall struct {
 	Name            string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	CollectionNames []string `protobuf:"bytes,2,rep,name=collection_names,json=collectionNames" json:"collection_names,omitempty"`
+	// no_private_reads indicates the call only writes to the collections
+	// and doesn't read from them, hence the endorsers don't need to be
+	// members of the collections
+	NoPrivateReads bool `protobuf:"varint,3,opt,name=no_private_reads,json=noPrivateReads" json:"no_private_reads,omitempty"`
+	// key_policies are the key-level endorsement policies of the keys
+	// the call writes to, which need to be satisfied in addition to the
+	// endorsement policy of the chaincode
+	KeyPolicies []*common1.SignaturePolicyEnvelope `protobuf:"bytes,4,rep,name=key_policies,json=keyPolicies" json:"key_policies,omitempty"`
+	// key_names are the state keys the call writes to, whose key-level
+	// endorsement policies are looked up in the ledger of the peer,
+	// and need to be satisfied just like key_policies
+	KeyNames []string `protobuf:"bytes,5,rep,name=key_names,json=keyNames" json:"key_names,omitempty"`
+	// sub_policy is the name of the sub-policy of the chaincode definition
+	// the call is endorsed according to, such as the function the call invokes.
+	// If the chaincode has no sub-policy of this name, its endorsement policy applies.
+	SubPolicy string `protobuf:"bytes,6,opt,name=sub_policy,json=subPolicy" json:"sub_policy,omitempty"`
 }
 
 func (m *ChaincodeCall) Reset()                    { *m = ChaincodeCall{} }
 func (m *ChaincodeCall) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeCall) ProtoMessage()               {}
-func (*ChaincodeCall) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*ChaincodeCall) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *ChaincodeCall) GetName() string {
 	if m != nil {
@@ -668,6 +1128,34 @@ func (m *ChaincodeCall) GetCollectionNames() []string {
 	return nil
 }
 
+func (m *ChaincodeCall) GetNoPrivateReads() bool {
+	if m != nil {
+		return m.NoPrivateReads
+	}
+	return false
+}
+
+func (m *ChaincodeCall) GetKeyPolicies() []*common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.KeyPolicies
+	}
+	return nil
+}
+
+func (m *ChaincodeCall) GetKeyNames() []string {
+	if m != nil {
+		return m.KeyNames
+	}
+	return nil
+}
+
+func (m *ChaincodeCall) GetSubPolicy() string {
+	if m != nil {
+		return m.SubPolicy
+	}
+	return ""
+}
+
 // ChaincodeQueryResult contains EndorsementDescriptors for
 // chaincodes
 type ChaincodeQueryResult struct {
@@ -677,7 +1165,7 @@ type ChaincodeQueryResult struct {
 func (m *ChaincodeQueryResult) Reset()                    { *m = ChaincodeQueryResult{} }
 func (m *ChaincodeQueryResult) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeQueryResult) ProtoMessage()               {}
-func (*ChaincodeQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*ChaincodeQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ChaincodeQueryResult) GetContent() []*EndorsementDescriptor {
 	if m != nil {
@@ -693,34 +1181,396 @@ type LocalPeerQuery struct {
 func (m *LocalPeerQuery) Reset()                    { *m = LocalPeerQuery{} }
 func (m *LocalPeerQuery) String() string            { return proto.CompactTextString(m) }
 func (*LocalPeerQuery) ProtoMessage()               {}
-func (*LocalPeerQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*LocalPeerQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+// SnapshotPeersQuery queries for peers in a channel context that advertise
+// a ledger snapshot at a height that is at least min_height
+type SnapshotPeersQuery struct {
+	MinHeight uint64 `protobuf:"varint,1,opt,name=min_height,json=minHeight" json:"min_height,omitempty"`
+}
+
+func (m *SnapshotPeersQuery) Reset()                    { *m = SnapshotPeersQuery{} }
+func (m *SnapshotPeersQuery) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotPeersQuery) ProtoMessage()               {}
+func (*SnapshotPeersQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *SnapshotPeersQuery) GetMinHeight() uint64 {
+	if m != nil {
+		return m.MinHeight
+	}
+	return 0
+}
+
+// PolicySimulationQuery requests a PolicySimulationResult for the given
+// endorsement policy, which doesn't need to be the policy of any chaincode.
+// This allows to validate an endorsement policy before defining a chaincode with it.
+type PolicySimulationQuery struct {
+	Policy *common1.SignaturePolicyEnvelope `protobuf:"bytes,1,opt,name=policy" json:"policy,omitempty"`
+}
+
+func (m *PolicySimulationQuery) Reset()                    { *m = PolicySimulationQuery{} }
+func (m *PolicySimulationQuery) String() string            { return proto.CompactTextString(m) }
+func (*PolicySimulationQuery) ProtoMessage()               {}
+func (*PolicySimulationQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *PolicySimulationQuery) GetPolicy() *common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+// PolicySimulationResult reports whether an endorsement policy can be satisfied
+// by the alive peers of a channel
+type PolicySimulationResult struct {
+	// satisfiable is whether the alive peers of the channel can satisfy the policy
+	Satisfiable bool `protobuf:"varint,1,opt,name=satisfiable" json:"satisfiable,omitempty"`
+	// required_orgs are the MSP IDs of the organizations that
+	// every way of satisfying the policy requires endorsements from
+	RequiredOrgs []string `protobuf:"bytes,2,rep,name=required_orgs,json=requiredOrgs" json:"required_orgs,omitempty"`
+	// minimal_peer_sets are sets of alive peers that satisfy the policy,
+	// such that none of them contains another
+	MinimalPeerSets []*Peers `protobuf:"bytes,3,rep,name=minimal_peer_sets,json=minimalPeerSets" json:"minimal_peer_sets,omitempty"`
+}
+
+func (m *PolicySimulationResult) Reset()                    { *m = PolicySimulationResult{} }
+func (m *PolicySimulationResult) String() string            { return proto.CompactTextString(m) }
+func (*PolicySimulationResult) ProtoMessage()               {}
+func (*PolicySimulationResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *PolicySimulationResult) GetSatisfiable() bool {
+	if m != nil {
+		return m.Satisfiable
+	}
+	return false
+}
+
+func (m *PolicySimulationResult) GetRequiredOrgs() []string {
+	if m != nil {
+		return m.RequiredOrgs
+	}
+	return nil
+}
+
+func (m *PolicySimulationResult) GetMinimalPeerSets() []*Peers {
+	if m != nil {
+		return m.MinimalPeerSets
+	}
+	return nil
+}
+
+// ChaincodeVersionsQuery requests a ChaincodeVersionsResult for the given chaincodes.
+// This allows to find the peers that prevent the endorsement policy of a chaincode
+// from being satisfied, since they don't have the version of its definition installed.
+type ChaincodeVersionsQuery struct {
+	Chaincodes []string `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *ChaincodeVersionsQuery) Reset()                    { *m = ChaincodeVersionsQuery{} }
+func (m *ChaincodeVersionsQuery) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeVersionsQuery) ProtoMessage()               {}
+func (*ChaincodeVersionsQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ChaincodeVersionsQuery) GetChaincodes() []string {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+// ChaincodeVersionsResult contains the ChaincodeVersions of each chaincode
+// of the query, in the order of the query
+type ChaincodeVersionsResult struct {
+	Chaincodes []*ChaincodeVersions `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *ChaincodeVersionsResult) Reset()                    { *m = ChaincodeVersionsResult{} }
+func (m *ChaincodeVersionsResult) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeVersionsResult) ProtoMessage()               {}
+func (*ChaincodeVersionsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ChaincodeVersionsResult) GetChaincodes() []*ChaincodeVersions {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+// ChaincodeVersions compares the versions of a chaincode installed on the
+// alive peers of a channel with the version of its definition in the channel
+type ChaincodeVersions struct {
+	Chaincode string `protobuf:"bytes,1,opt,name=chaincode" json:"chaincode,omitempty"`
+	// defined_version is the version of the chaincode definition in the channel,
+	// or empty if the chaincode isn't defined in the channel
+	DefinedVersion string `protobuf:"bytes,2,opt,name=defined_version,json=definedVersion" json:"defined_version,omitempty"`
+	// peers_by_installed_version maps versions of the chaincode to the alive peers
+	// of the channel that have them installed.
+	// Peers that don't have the chaincode installed are mapped to the empty version.
+	PeersByInstalledVersion map[string]*Peers `protobuf:"bytes,3,rep,name=peers_by_installed_version,json=peersByInstalledVersion" json:"peers_by_installed_version,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ChaincodeVersions) Reset()                    { *m = ChaincodeVersions{} }
+func (m *ChaincodeVersions) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeVersions) ProtoMessage()               {}
+func (*ChaincodeVersions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ChaincodeVersions) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ChaincodeVersions) GetDefinedVersion() string {
+	if m != nil {
+		return m.DefinedVersion
+	}
+	return ""
+}
+
+func (m *ChaincodeVersions) GetPeersByInstalledVersion() map[string]*Peers {
+	if m != nil {
+		return m.PeersByInstalledVersion
+	}
+	return nil
+}
+
+// ConfigUpdateImpactQuery requests a ConfigUpdateImpactResult for a config update
+// of the channel. This allows admins to review the consequences of a config update
+// before they sign it.
+type ConfigUpdateImpactQuery struct {
+	// config_update is a marshaled common.ConfigUpdate
+	ConfigUpdate []byte `protobuf:"bytes,1,opt,name=config_update,json=configUpdate,proto3" json:"config_update,omitempty"`
+}
+
+func (m *ConfigUpdateImpactQuery) Reset()                    { *m = ConfigUpdateImpactQuery{} }
+func (m *ConfigUpdateImpactQuery) String() string            { return proto.CompactTextString(m) }
+func (*ConfigUpdateImpactQuery) ProtoMessage()               {}
+func (*ConfigUpdateImpactQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ConfigUpdateImpactQuery) GetConfigUpdate() []byte {
+	if m != nil {
+		return m.ConfigUpdate
+	}
+	return nil
+}
+
+// ConfigUpdateImpactResult reports how a config update would change the
+// configuration of a channel, and which alive peers of the channel it would eject
+type ConfigUpdateImpactResult struct {
+	// policy_changes are the policies the update adds, removes or modifies,
+	// ordered by their paths
+	PolicyChanges []*PolicyChange `protobuf:"bytes,1,rep,name=policy_changes,json=policyChanges" json:"policy_changes,omitempty"`
+	// added_msps are the MSP IDs of the organizations the update adds to the channel
+	AddedMsps []string `protobuf:"bytes,2,rep,name=added_msps,json=addedMsps" json:"added_msps,omitempty"`
+	// removed_msps are the MSP IDs of the organizations the update removes from the channel
+	RemovedMsps []string `protobuf:"bytes,3,rep,name=removed_msps,json=removedMsps" json:"removed_msps,omitempty"`
+	// ejected_peers are the alive peers of the channel which
+	// wouldn't be members of the channel after the update
+	EjectedPeers []*EjectedPeer `protobuf:"bytes,4,rep,name=ejected_peers,json=ejectedPeers" json:"ejected_peers,omitempty"`
+}
+
+func (m *ConfigUpdateImpactResult) Reset()                    { *m = ConfigUpdateImpactResult{} }
+func (m *ConfigUpdateImpactResult) String() string            { return proto.CompactTextString(m) }
+func (*ConfigUpdateImpactResult) ProtoMessage()               {}
+func (*ConfigUpdateImpactResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ConfigUpdateImpactResult) GetPolicyChanges() []*PolicyChange {
+	if m != nil {
+		return m.PolicyChanges
+	}
+	return nil
+}
+
+func (m *ConfigUpdateImpactResult) GetAddedMsps() []string {
+	if m != nil {
+		return m.AddedMsps
+	}
+	return nil
+}
+
+func (m *ConfigUpdateImpactResult) GetRemovedMsps() []string {
+	if m != nil {
+		return m.RemovedMsps
+	}
+	return nil
+}
+
+func (m *ConfigUpdateImpactResult) GetEjectedPeers() []*EjectedPeer {
+	if m != nil {
+		return m.EjectedPeers
+	}
+	return nil
+}
+
+// OrgCombinationsQuery requests an OrgCombinationsResult for the given chaincode interests.
+// Unlike a ChaincodeQuery, the peers of the channel aren't resolved, which makes the query
+// cheaper, and allows clients to pre-compute which organizations to route endorsements to.
+type OrgCombinationsQuery struct {
+	Interests []*ChaincodeInterest `protobuf:"bytes,1,rep,name=interests" json:"interests,omitempty"`
+}
+
+func (m *OrgCombinationsQuery) Reset()                    { *m = OrgCombinationsQuery{} }
+func (m *OrgCombinationsQuery) String() string            { return proto.CompactTextString(m) }
+func (*OrgCombinationsQuery) ProtoMessage()               {}
+func (*OrgCombinationsQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *OrgCombinationsQuery) GetInterests() []*ChaincodeInterest {
+	if m != nil {
+		return m.Interests
+	}
+	return nil
+}
+
+// OrgCombinationsResult contains the OrgCombinations of each chaincode interest
+// of the query, in the order of the query
+type OrgCombinationsResult struct {
+	Content []*OrgCombinations `protobuf:"bytes,1,rep,name=content" json:"content,omitempty"`
+}
+
+func (m *OrgCombinationsResult) Reset()                    { *m = OrgCombinationsResult{} }
+func (m *OrgCombinationsResult) String() string            { return proto.CompactTextString(m) }
+func (*OrgCombinationsResult) ProtoMessage()               {}
+func (*OrgCombinationsResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *OrgCombinationsResult) GetContent() []*OrgCombinations {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+// OrgCombinations are the combinations of organizations whose endorsements
+// satisfy the endorsement policies of a chaincode interest,
+// regardless of whether the organizations have alive peers
+type OrgCombinations struct {
+	// chaincode is the name of the first chaincode of the interest
+	Chaincode string `protobuf:"bytes,1,opt,name=chaincode" json:"chaincode,omitempty"`
+	// combinations are minimal, such that none of them requires all the endorsements
+	// another one requires. They are ordered by the total quantity of endorsements
+	// they require, and then by the MSP IDs of their organizations
+	Combinations []*OrgCombination `protobuf:"bytes,2,rep,name=combinations" json:"combinations,omitempty"`
+}
+
+func (m *OrgCombinations) Reset()                    { *m = OrgCombinations{} }
+func (m *OrgCombinations) String() string            { return proto.CompactTextString(m) }
+func (*OrgCombinations) ProtoMessage()               {}
+func (*OrgCombinations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *OrgCombinations) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *OrgCombinations) GetCombinations() []*OrgCombination {
+	if m != nil {
+		return m.Combinations
+	}
+	return nil
+}
+
+// OrgCombination maps MSP IDs of organizations to the quantity of
+// endorsements required from peers of each organization
+type OrgCombination struct {
+	QuantitiesByOrg map[string]uint32 `protobuf:"bytes,1,rep,name=quantities_by_org,json=quantitiesByOrg" json:"quantities_by_org,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *OrgCombination) Reset()                    { *m = OrgCombination{} }
+func (m *OrgCombination) String() string            { return proto.CompactTextString(m) }
+func (*OrgCombination) ProtoMessage()               {}
+func (*OrgCombination) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *OrgCombination) GetQuantitiesByOrg() map[string]uint32 {
+	if m != nil {
+		return m.QuantitiesByOrg
+	}
+	return nil
+}
+
+// PolicyChange is a policy that a config update adds, removes or modifies
+type PolicyChange struct {
+	// path is the fully qualified path of the policy, such as /Channel/Application/Admins
+	Path string            `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Type PolicyChange_Type `protobuf:"varint,2,opt,name=type,enum=discovery.PolicyChange_Type" json:"type,omitempty"`
+}
+
+func (m *PolicyChange) Reset()                    { *m = PolicyChange{} }
+func (m *PolicyChange) String() string            { return proto.CompactTextString(m) }
+func (*PolicyChange) ProtoMessage()               {}
+func (*PolicyChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *PolicyChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PolicyChange) GetType() PolicyChange_Type {
+	if m != nil {
+		return m.Type
+	}
+	return PolicyChange_ADDED
+}
+
+// EjectedPeer is an alive peer of a channel that a config update would eject
+type EjectedPeer struct {
+	Peer *Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
+	// reason is why the peer wouldn't be a member of the channel after the update
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *EjectedPeer) Reset()                    { *m = EjectedPeer{} }
+func (m *EjectedPeer) String() string            { return proto.CompactTextString(m) }
+func (*EjectedPeer) ProtoMessage()               {}
+func (*EjectedPeer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *EjectedPeer) GetPeer() *Peer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *EjectedPeer) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
 
 // EndorsementDescriptor contains information about which peers can be used
 // to request endorsement from, such that the endorsement policy would be fulfilled.
 // Here is how to compute a set of peers to ask an endorsement from, given an EndorsementDescriptor:
 // Let e: G --> P be the endorsers_by_groups field that maps a group to a set of peers.
 // Note that applying e on a group g yields a set of peers.
-// 1) Select a layout l: G --> N out of the layouts given.
-//    l is the quantities_by_group field of a Layout, and it maps a group to an integer.
-// 2) R = {}  (an empty set of peers)
-// 3) For each group g in the layout l, compute n = l(g)
-//    3.1) Denote P_g as a set of n random peers {p0, p1, ... p_n} selected from e(g)
-//    3.2) R = R U P_g  (add P_g to R)
-// 4) The set of peers R is the peers the client needs to request endorsements from
+//  1. Select a layout l: G --> N out of the layouts given.
+//     l is the quantities_by_group field of a Layout, and it maps a group to an integer.
+//  2. R = {}  (an empty set of peers)
+//  3. For each group g in the layout l, compute n = l(g)
+//     3.1) Denote P_g as a set of n random peers {p0, p1, ... p_n} selected from e(g)
+//     3.2) R = R U P_g  (add P_g to R)
+//  4. The set of peers R is the peers the client needs to request endorsements from
 type EndorsementDescriptor struct {
 	Chaincode string `protobuf:"bytes,1,opt,name=chaincode" json:"chaincode,omitempty"`
 	// Specifies the endorsers, separated to groups.
+	// Group names are derived from the principals of the groups,
+	// and are therefore the same across queries and peers.
 	EndorsersByGroups map[string]*Peers `protobuf:"bytes,2,rep,name=endorsers_by_groups,json=endorsersByGroups" json:"endorsers_by_groups,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Specifies options of fulfulling the endorsement policy.
 	// Each option lists the group names, and the amount of signatures needed
 	// from each group.
 	Layouts []*Layout `protobuf:"bytes,3,rep,name=layouts" json:"layouts,omitempty"`
+	// The format of the descriptor
+	Version DescriptorVersion `protobuf:"varint,4,opt,name=version,enum=discovery.DescriptorVersion" json:"version,omitempty"`
 }
 
 func (m *EndorsementDescriptor) Reset()                    { *m = EndorsementDescriptor{} }
 func (m *EndorsementDescriptor) String() string            { return proto.CompactTextString(m) }
 func (*EndorsementDescriptor) ProtoMessage()               {}
-func (*EndorsementDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*EndorsementDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *EndorsementDescriptor) GetChaincode() string {
 	if m != nil {
@@ -743,6 +1593,13 @@ func (m *EndorsementDescriptor) GetLayouts() []*Layout {
 	return nil
 }
 
+func (m *EndorsementDescriptor) GetVersion() DescriptorVersion {
+	if m != nil {
+		return m.Version
+	}
+	return DescriptorVersion_V1
+}
+
 // Layout contains a mapping from a group name to number of peers
 // that are needed for fulfilling an endorsement policy
 type Layout struct {
@@ -754,7 +1611,7 @@ type Layout struct {
 func (m *Layout) Reset()                    { *m = Layout{} }
 func (m *Layout) String() string            { return proto.CompactTextString(m) }
 func (*Layout) ProtoMessage()               {}
-func (*Layout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*Layout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *Layout) GetQuantitiesByGroup() map[string]uint32 {
 	if m != nil {
@@ -771,7 +1628,7 @@ type Peers struct {
 func (m *Peers) Reset()                    { *m = Peers{} }
 func (m *Peers) String() string            { return proto.CompactTextString(m) }
 func (*Peers) ProtoMessage()               {}
-func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *Peers) GetPeers() []*Peer {
 	if m != nil {
@@ -789,12 +1646,19 @@ type Peer struct {
 	MembershipInfo *gossip.Envelope `protobuf:"bytes,2,opt,name=membership_info,json=membershipInfo" json:"membership_info,omitempty"`
 	// This is the msp.SerializedIdentity of the peer, represented in bytes.
 	Identity []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	// This is the ledger height the peer advertised in its StateInfo message,
+	// set only for peers returned in an EndorsementDescriptor.
+	LedgerHeight uint64 `protobuf:"varint,4,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	// This is the round trip time in microseconds from the peer that computed the
+	// response to the peer, as last measured by the gossip layer. It is set only for
+	// peers returned in an EndorsementDescriptor, and only if it was measured.
+	RttMicros uint64 `protobuf:"varint,5,opt,name=rtt_micros,json=rttMicros" json:"rtt_micros,omitempty"`
 }
 
 func (m *Peer) Reset()                    { *m = Peer{} }
 func (m *Peer) String() string            { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *Peer) GetStateInfo() *gossip.Envelope {
 	if m != nil {
@@ -817,6 +1681,20 @@ func (m *Peer) GetIdentity() []byte {
 	return nil
 }
 
+func (m *Peer) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *Peer) GetRttMicros() uint64 {
+	if m != nil {
+		return m.RttMicros
+	}
+	return 0
+}
+
 // Error denotes that something went wrong and contains the error message
 type Error struct {
 	Content string `protobuf:"bytes,1,opt,name=content" json:"content,omitempty"`
@@ -825,7 +1703,7 @@ type Error struct {
 func (m *Error) Reset()                    { *m = Error{} }
 func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *Error) GetContent() string {
 	if m != nil {
@@ -834,15 +1712,18 @@ func (m *Error) GetContent() string {
 	return ""
 }
 
-// Endpoints is a list of Endpoint(s)
+// Endpoints is a list of Endpoint(s) of the orderers of an organization,
+// along with the TLS CA certificates that the orderers' TLS certificates chain to
 type Endpoints struct {
-	Endpoint []*Endpoint `protobuf:"bytes,1,rep,name=endpoint" json:"endpoint,omitempty"`
+	Endpoint             []*Endpoint `protobuf:"bytes,1,rep,name=endpoint" json:"endpoint,omitempty"`
+	TlsRootCerts         [][]byte    `protobuf:"bytes,2,rep,name=tls_root_certs,json=tlsRootCerts,proto3" json:"tls_root_certs,omitempty"`
+	TlsIntermediateCerts [][]byte    `protobuf:"bytes,3,rep,name=tls_intermediate_certs,json=tlsIntermediateCerts,proto3" json:"tls_intermediate_certs,omitempty"`
 }
 
 func (m *Endpoints) Reset()                    { *m = Endpoints{} }
 func (m *Endpoints) String() string            { return proto.CompactTextString(m) }
 func (*Endpoints) ProtoMessage()               {}
-func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *Endpoints) GetEndpoint() []*Endpoint {
 	if m != nil {
@@ -851,16 +1732,33 @@ func (m *Endpoints) GetEndpoint() []*Endpoint {
 	return nil
 }
 
+func (m *Endpoints) GetTlsRootCerts() [][]byte {
+	if m != nil {
+		return m.TlsRootCerts
+	}
+	return nil
+}
+
+func (m *Endpoints) GetTlsIntermediateCerts() [][]byte {
+	if m != nil {
+		return m.TlsIntermediateCerts
+	}
+	return nil
+}
+
 // Endpoint is a combination of a host and a port
 type Endpoint struct {
 	Host string `protobuf:"bytes,1,opt,name=host" json:"host,omitempty"`
 	Port uint32 `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+	// overridden is true if the endpoint isn't taken from the channel
+	// configuration, but from the local configuration of the peer
+	Overridden bool `protobuf:"varint,3,opt,name=overridden" json:"overridden,omitempty"`
 }
 
 func (m *Endpoint) Reset()                    { *m = Endpoint{} }
 func (m *Endpoint) String() string            { return proto.CompactTextString(m) }
 func (*Endpoint) ProtoMessage()               {}
-func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *Endpoint) GetHost() string {
 	if m != nil {
@@ -876,10 +1774,18 @@ func (m *Endpoint) GetPort() uint32 {
 	return 0
 }
 
+func (m *Endpoint) GetOverridden() bool {
+	if m != nil {
+		return m.Overridden
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*SignedRequest)(nil), "discovery.SignedRequest")
 	proto.RegisterType((*Request)(nil), "discovery.Request")
 	proto.RegisterType((*Response)(nil), "discovery.Response")
+	proto.RegisterType((*ResponseSignature)(nil), "discovery.ResponseSignature")
 	proto.RegisterType((*AuthInfo)(nil), "discovery.AuthInfo")
 	proto.RegisterType((*Query)(nil), "discovery.Query")
 	proto.RegisterType((*QueryResult)(nil), "discovery.QueryResult")
@@ -892,6 +1798,20 @@ func init() {
 	proto.RegisterType((*ChaincodeCall)(nil), "discovery.ChaincodeCall")
 	proto.RegisterType((*ChaincodeQueryResult)(nil), "discovery.ChaincodeQueryResult")
 	proto.RegisterType((*LocalPeerQuery)(nil), "discovery.LocalPeerQuery")
+	proto.RegisterType((*SnapshotPeersQuery)(nil), "discovery.SnapshotPeersQuery")
+	proto.RegisterType((*PolicySimulationQuery)(nil), "discovery.PolicySimulationQuery")
+	proto.RegisterType((*PolicySimulationResult)(nil), "discovery.PolicySimulationResult")
+	proto.RegisterType((*ChaincodeVersionsQuery)(nil), "discovery.ChaincodeVersionsQuery")
+	proto.RegisterType((*ChaincodeVersionsResult)(nil), "discovery.ChaincodeVersionsResult")
+	proto.RegisterType((*ChaincodeVersions)(nil), "discovery.ChaincodeVersions")
+	proto.RegisterType((*ConfigUpdateImpactQuery)(nil), "discovery.ConfigUpdateImpactQuery")
+	proto.RegisterType((*ConfigUpdateImpactResult)(nil), "discovery.ConfigUpdateImpactResult")
+	proto.RegisterType((*OrgCombinationsQuery)(nil), "discovery.OrgCombinationsQuery")
+	proto.RegisterType((*OrgCombinationsResult)(nil), "discovery.OrgCombinationsResult")
+	proto.RegisterType((*OrgCombinations)(nil), "discovery.OrgCombinations")
+	proto.RegisterType((*OrgCombination)(nil), "discovery.OrgCombination")
+	proto.RegisterType((*PolicyChange)(nil), "discovery.PolicyChange")
+	proto.RegisterType((*EjectedPeer)(nil), "discovery.EjectedPeer")
 	proto.RegisterType((*EndorsementDescriptor)(nil), "discovery.EndorsementDescriptor")
 	proto.RegisterType((*Layout)(nil), "discovery.Layout")
 	proto.RegisterType((*Peers)(nil), "discovery.Peers")
@@ -899,6 +1819,8 @@ func init() {
 	proto.RegisterType((*Error)(nil), "discovery.Error")
 	proto.RegisterType((*Endpoints)(nil), "discovery.Endpoints")
 	proto.RegisterType((*Endpoint)(nil), "discovery.Endpoint")
+	proto.RegisterEnum("discovery.DescriptorVersion", DescriptorVersion_name, DescriptorVersion_value)
+	proto.RegisterEnum("discovery.PolicyChange_Type", PolicyChange_Type_name, PolicyChange_Type_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -978,76 +1900,143 @@ var _Discovery_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("discovery/protocol.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1131 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0x1b, 0x45,
-	0x14, 0x8e, 0x9d, 0xb8, 0xb6, 0x8f, 0xe3, 0x5c, 0x26, 0x6e, 0x30, 0x56, 0x45, 0xd3, 0x95, 0x0a,
-	0xa1, 0x48, 0xeb, 0x2a, 0x08, 0x28, 0x49, 0x04, 0x6a, 0x2e, 0xd4, 0x91, 0x9a, 0x26, 0x99, 0x22,
-	0x84, 0x78, 0xb1, 0x36, 0xeb, 0x13, 0x7b, 0xc5, 0x7a, 0x67, 0x33, 0x33, 0x1b, 0x69, 0x9f, 0x79,
-	0xe7, 0x27, 0xf0, 0xc2, 0x0b, 0xe2, 0x27, 0xf0, 0xeb, 0xd0, 0xce, 0x65, 0xbd, 0x76, 0x36, 0x2a,
-	0x12, 0x6f, 0x33, 0xe7, 0x9c, 0xef, 0x3b, 0x97, 0x39, 0x33, 0x73, 0xa0, 0x3b, 0x0a, 0x84, 0xcf,
-	0xee, 0x90, 0xa7, 0xfd, 0x98, 0x33, 0xc9, 0x7c, 0x16, 0xba, 0x6a, 0x41, 0x9a, 0xb9, 0xa6, 0xd7,
-	0x19, 0x33, 0x21, 0x82, 0xb8, 0x3f, 0x45, 0x21, 0xbc, 0x31, 0x6a, 0x83, 0x5e, 0x67, 0x2a, 0xe2,
-	0xfe, 0x54, 0xc4, 0x43, 0x9f, 0x45, 0x37, 0xc1, 0xb8, 0x28, 0x0d, 0x46, 0x18, 0xc9, 0x40, 0x06,
-	0x28, 0xb4, 0xd4, 0x79, 0x03, 0xed, 0xf7, 0xc1, 0x38, 0xc2, 0x11, 0xc5, 0xdb, 0x04, 0x85, 0x24,
-	0x5d, 0xa8, 0xc7, 0x5e, 0x1a, 0x32, 0x6f, 0xd4, 0xad, 0xec, 0x54, 0x76, 0x57, 0xa9, 0xdd, 0x92,
-	0x27, 0xd0, 0x14, 0xc1, 0x38, 0xf2, 0x64, 0xc2, 0xb1, 0x5b, 0x55, 0xba, 0x99, 0xc0, 0xe1, 0x50,
-	0xb7, 0x14, 0x07, 0xb0, 0xe6, 0x25, 0x72, 0x92, 0x79, 0xf2, 0x3d, 0x19, 0xb0, 0x48, 0x31, 0xb5,
-	0xf6, 0xb6, 0xdc, 0x3c, 0x72, 0xf7, 0x75, 0x22, 0x27, 0x67, 0xd1, 0x0d, 0xa3, 0x0b, 0xa6, 0xe4,
-	0x05, 0xd4, 0x6f, 0x13, 0xe4, 0x01, 0x8a, 0x6e, 0x75, 0x67, 0x79, 0xb7, 0xb5, 0xb7, 0x51, 0x40,
-	0x5d, 0x25, 0xc8, 0x53, 0x6a, 0x0d, 0x9c, 0x43, 0x68, 0x50, 0x14, 0x31, 0x8b, 0x04, 0x92, 0x97,
-	0x50, 0xe7, 0x28, 0x92, 0x50, 0x8a, 0x6e, 0x45, 0xe1, 0xb6, 0xef, 0xe1, 0x94, 0x9a, 0x5a, 0x33,
-	0x67, 0x04, 0x0d, 0x1b, 0x05, 0xf9, 0x0c, 0xd6, 0xfd, 0x30, 0xc0, 0x48, 0x0e, 0x4d, 0x85, 0x52,
-	0x93, 0xfd, 0x9a, 0x16, 0x9f, 0x19, 0x29, 0xe9, 0x43, 0xc7, 0x18, 0xca, 0x50, 0x0c, 0x7d, 0xe4,
-	0x72, 0x38, 0xf1, 0xc4, 0xc4, 0xd4, 0x63, 0x53, 0xeb, 0x7e, 0x0c, 0xc5, 0x31, 0x72, 0x39, 0xf0,
-	0xc4, 0xc4, 0xf9, 0xa3, 0x0a, 0x35, 0xe5, 0x3e, 0xab, 0xac, 0x3f, 0xf1, 0xa2, 0x08, 0x43, 0xc5,
-	0xdd, 0xa4, 0x76, 0x4b, 0x0e, 0x60, 0x55, 0x1f, 0xd5, 0x30, 0xcb, 0x2c, 0x55, 0x64, 0xf3, 0x09,
-	0x1c, 0x2b, 0xb5, 0xe2, 0x19, 0x2c, 0xd1, 0x96, 0x3f, 0xdb, 0x92, 0xef, 0x01, 0x62, 0x44, 0x6e,
-	0xa0, 0xcb, 0x0a, 0xfa, 0x49, 0x01, 0x7a, 0x89, 0xc8, 0xcf, 0x71, 0x7a, 0x8d, 0x5c, 0x4c, 0x82,
-	0xd8, 0x52, 0x34, 0x33, 0x8c, 0x26, 0xf8, 0x1a, 0x1a, 0xbe, 0x6f, 0xe0, 0x2b, 0x0a, 0xfe, 0x71,
-	0xd1, 0xf3, 0xc4, 0x0b, 0x22, 0x9f, 0x8d, 0xd0, 0x22, 0xeb, 0xbe, 0xaf, 0x71, 0x87, 0xd0, 0x0a,
-	0x99, 0xef, 0x85, 0xc3, 0x8c, 0x4a, 0x74, 0x6b, 0xf7, 0xa0, 0x6f, 0x33, 0xed, 0xa5, 0xf5, 0x33,
-	0x58, 0xa2, 0x10, 0x5a, 0x89, 0x38, 0xaa, 0x43, 0x4d, 0xb9, 0x74, 0x7e, 0xab, 0x42, 0xab, 0x70,
-	0x3e, 0x64, 0x17, 0x6a, 0xc8, 0x39, 0xe3, 0xa6, 0x69, 0x8a, 0xc7, 0x7f, 0x9a, 0xc9, 0x07, 0x4b,
-	0x54, 0x1b, 0x90, 0xef, 0xa0, 0x6d, 0xca, 0xa6, 0x8f, 0xd4, 0xd4, 0xed, 0xa3, 0x7b, 0x75, 0xd3,
-	0xcc, 0x83, 0x25, 0x6a, 0xca, 0x6c, 0x3c, 0x1d, 0xc3, 0xaa, 0x4d, 0x3c, 0x63, 0x30, 0xb5, 0x7b,
-	0xfa, 0x60, 0xf2, 0x39, 0x0d, 0x98, 0x12, 0x50, 0x14, 0xe4, 0x00, 0xea, 0x53, 0x5d, 0x5d, 0x53,
-	0xbc, 0xa7, 0x0f, 0xd6, 0x3e, 0xc7, 0x5b, 0xc4, 0x51, 0x03, 0x1e, 0xe9, 0xd0, 0x9d, 0x36, 0xb4,
-	0x0a, 0x67, 0xec, 0xfc, 0x5d, 0x85, 0xd5, 0x62, 0xec, 0xe4, 0x2b, 0x58, 0x99, 0x8a, 0xd8, 0xf6,
-	0xf6, 0xb3, 0x07, 0x52, 0x74, 0xcf, 0x45, 0x2c, 0x4e, 0x23, 0xc9, 0x53, 0xaa, 0xcc, 0xc9, 0x6b,
-	0x68, 0x30, 0x3e, 0x42, 0x9e, 0x85, 0xa7, 0xaf, 0xd3, 0xf3, 0x87, 0xa0, 0x17, 0xc6, 0x4e, 0xc3,
-	0x73, 0x58, 0xef, 0x1c, 0x9a, 0x39, 0x2b, 0xd9, 0x80, 0xe5, 0x5f, 0x31, 0x35, 0xfd, 0x9b, 0x2d,
-	0xc9, 0x0b, 0xa8, 0xdd, 0x79, 0x61, 0x82, 0xa6, 0xf8, 0x1d, 0x77, 0x2a, 0x62, 0xf7, 0x07, 0xef,
-	0x9a, 0x07, 0xfe, 0xf9, 0xfb, 0x4b, 0xe3, 0x41, 0x9b, 0xec, 0x57, 0x5f, 0x55, 0x7a, 0x57, 0xd0,
-	0x9e, 0xf3, 0xf4, 0x5f, 0x28, 0x0b, 0x1d, 0x10, 0x8d, 0x62, 0x16, 0x44, 0x52, 0x14, 0x28, 0x9d,
-	0xc7, 0xb0, 0x55, 0xd2, 0xe4, 0xce, 0x3f, 0x15, 0xe8, 0x94, 0x1d, 0x00, 0xb9, 0x82, 0x55, 0xd5,
-	0xb2, 0xc3, 0xeb, 0x74, 0xc8, 0xf8, 0xd8, 0xd4, 0xb4, 0xff, 0x81, 0x73, 0x73, 0x75, 0xdf, 0xa6,
-	0x17, 0x7c, 0xac, 0x4b, 0xa4, 0xae, 0x9d, 0x16, 0xf4, 0x2e, 0x60, 0x7d, 0x41, 0x5d, 0x92, 0xd7,
-	0xa7, 0xf3, 0x79, 0x6d, 0x2c, 0x38, 0x9c, 0xcb, 0xe9, 0x2d, 0xac, 0xcd, 0x37, 0x1f, 0xd9, 0x87,
-	0x66, 0x10, 0x49, 0xe4, 0x28, 0xf2, 0x27, 0xee, 0x49, 0x59, 0xab, 0x9e, 0x19, 0x23, 0x3a, 0x33,
-	0x77, 0xce, 0x61, 0xf3, 0x9e, 0x9e, 0xbc, 0x02, 0xf0, 0xad, 0xd0, 0x32, 0x76, 0xcb, 0x18, 0x8f,
-	0xbd, 0x30, 0xa4, 0x05, 0x5b, 0xe7, 0x1d, 0xb4, 0xe7, 0x94, 0x84, 0xc0, 0x4a, 0xe4, 0x4d, 0xd1,
-	0x24, 0xab, 0xd6, 0xe4, 0x73, 0xd8, 0xf0, 0x59, 0x18, 0xa2, 0x9f, 0x3d, 0xeb, 0xc3, 0x4c, 0xa4,
-	0x5b, 0xb0, 0x49, 0xd7, 0x67, 0xf2, 0x77, 0x99, 0xd8, 0xa1, 0xd0, 0x29, 0xbb, 0x69, 0x64, 0x1f,
-	0xea, 0x3e, 0x8b, 0x24, 0x46, 0xd2, 0x84, 0xb7, 0x33, 0xdf, 0x0a, 0x8c, 0x0b, 0x9c, 0x62, 0x24,
-	0x4f, 0x50, 0xf8, 0x3c, 0x88, 0x25, 0xe3, 0xd4, 0x02, 0x9c, 0x0d, 0x58, 0x9b, 0x7f, 0x7f, 0x9c,
-	0x3f, 0xab, 0xf0, 0xb8, 0x14, 0x94, 0xfd, 0x6c, 0x79, 0x76, 0x26, 0x87, 0x99, 0x80, 0x8c, 0x61,
-	0x0b, 0x35, 0x4c, 0xb7, 0xcc, 0x98, 0xb3, 0x24, 0xb6, 0xd7, 0xe9, 0x9b, 0x0f, 0x45, 0x64, 0xa5,
-	0x59, 0x6f, 0xbc, 0x51, 0x48, 0xdd, 0x3d, 0x9b, 0xb8, 0x28, 0x27, 0x5f, 0x40, 0x3d, 0xf4, 0x52,
-	0x96, 0xc8, 0xec, 0x29, 0xca, 0xc8, 0x37, 0x8b, 0x8f, 0xa9, 0xd2, 0x50, 0x6b, 0xd1, 0xfb, 0x09,
-	0xb6, 0xcb, 0x99, 0xff, 0x67, 0xe3, 0xfd, 0x55, 0x81, 0x47, 0xda, 0x17, 0xf9, 0x19, 0xb6, 0x6e,
-	0x13, 0xcf, 0xcc, 0x0b, 0x79, 0xe6, 0xe6, 0x28, 0x76, 0xef, 0xc5, 0xe6, 0x5e, 0xe5, 0xc6, 0x26,
-	0x20, 0x93, 0xe9, 0xed, 0xa2, 0xbc, 0x77, 0x02, 0xdb, 0xe5, 0xc6, 0x25, 0xc1, 0x77, 0x8a, 0xc1,
-	0xb7, 0x8b, 0xa1, 0xba, 0x50, 0x53, 0xe1, 0x93, 0xe7, 0x50, 0xd3, 0x7f, 0x90, 0x0e, 0x6d, 0x7d,
-	0x21, 0x3f, 0xaa, 0xb5, 0xce, 0xef, 0x15, 0x58, 0xc9, 0xf6, 0xa4, 0x0f, 0x20, 0xa4, 0x27, 0x71,
-	0x18, 0x44, 0x37, 0x2c, 0xff, 0x67, 0xf4, 0x2c, 0xe5, 0x9e, 0x46, 0x77, 0x18, 0xb2, 0x18, 0x69,
-	0x53, 0xd9, 0xa8, 0xf1, 0xe0, 0x5b, 0x58, 0x9f, 0xe6, 0xcf, 0x81, 0x46, 0x55, 0x1f, 0x40, 0xad,
-	0xcd, 0x0c, 0x15, 0xb4, 0x07, 0x8d, 0x7c, 0xa4, 0x58, 0x56, 0x43, 0x42, 0xbe, 0x77, 0x9e, 0x41,
-	0x4d, 0x7d, 0x69, 0x6a, 0x34, 0xc8, 0x1b, 0x5d, 0x8f, 0x06, 0xa6, 0x8d, 0x0f, 0xa1, 0x99, 0xbf,
-	0x79, 0xa4, 0x0f, 0x0d, 0x34, 0x1b, 0x93, 0xea, 0x56, 0xc9, 0xdb, 0x48, 0x73, 0x23, 0x67, 0x0f,
-	0x1a, 0x56, 0x9a, 0xdd, 0xd1, 0x09, 0x13, 0xd6, 0x81, 0x5a, 0x67, 0xb2, 0x98, 0x71, 0x69, 0x4a,
-	0xab, 0xd6, 0x7b, 0x03, 0x68, 0x9e, 0x58, 0x4e, 0x72, 0x00, 0x0d, 0xbb, 0x21, 0xc5, 0xb7, 0x61,
-	0x6e, 0x66, 0xec, 0x15, 0xa3, 0xb0, 0x03, 0x99, 0xb3, 0x74, 0xf4, 0xf2, 0x17, 0x77, 0x1c, 0xc8,
-	0x49, 0x72, 0xed, 0xfa, 0x6c, 0xda, 0x9f, 0xa4, 0x31, 0xf2, 0x10, 0x47, 0x63, 0xe4, 0xfd, 0x1b,
-	0xf5, 0x3f, 0xe8, 0xc1, 0x56, 0xf4, 0x73, 0xf0, 0xf5, 0x23, 0x25, 0xf9, 0xf2, 0xdf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x59, 0x7e, 0x3b, 0x2c, 0xfd, 0x0a, 0x00, 0x00,
+	// 2205 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x73, 0x1b, 0x49,
+	0x15, 0xb7, 0x6c, 0xc9, 0x92, 0x9e, 0x3e, 0x2c, 0xb5, 0x65, 0x47, 0x2b, 0xb2, 0xbb, 0xce, 0x84,
+	0x80, 0x09, 0x94, 0x9c, 0xcd, 0x2e, 0x9b, 0x6c, 0x12, 0x42, 0xc5, 0x1f, 0x59, 0x1b, 0x62, 0xec,
+	0x8c, 0x43, 0x96, 0x4a, 0x51, 0xa8, 0xc6, 0x33, 0x6d, 0x69, 0xc8, 0xcc, 0xf4, 0xb8, 0xbb, 0xe5,
+	0x8a, 0xae, 0x9c, 0xf8, 0x13, 0xe0, 0xc0, 0x9d, 0xe2, 0xb4, 0x5c, 0xf9, 0x33, 0xb8, 0x51, 0x5c,
+	0xf8, 0x0b, 0x38, 0x73, 0xa4, 0xfa, 0x6b, 0x3c, 0xa3, 0x19, 0x27, 0xd9, 0xca, 0x49, 0xea, 0xf7,
+	0xf1, 0x9b, 0xee, 0xd7, 0xbf, 0xf7, 0xfa, 0x75, 0x43, 0xdf, 0xf3, 0x99, 0x4b, 0x2e, 0x30, 0x9d,
+	0x6d, 0xc5, 0x94, 0x70, 0xe2, 0x92, 0x60, 0x28, 0xff, 0xa0, 0x7a, 0xa2, 0x19, 0xf4, 0xc6, 0x84,
+	0x31, 0x3f, 0xde, 0x0a, 0x31, 0x63, 0xce, 0x18, 0x2b, 0x83, 0x41, 0x2f, 0x64, 0xf1, 0x56, 0xc8,
+	0xe2, 0x91, 0x4b, 0xa2, 0x33, 0x7f, 0x9c, 0x96, 0xfa, 0x1e, 0x8e, 0xb8, 0xcf, 0x7d, 0xcc, 0xb4,
+	0x74, 0xcd, 0x25, 0x61, 0x48, 0xa2, 0xad, 0x98, 0x04, 0xbe, 0x9b, 0x88, 0xad, 0xaf, 0xa1, 0x75,
+	0xe2, 0x8f, 0x23, 0xec, 0xd9, 0xf8, 0x7c, 0x8a, 0x19, 0x47, 0x7d, 0xa8, 0xc6, 0xce, 0x2c, 0x20,
+	0x8e, 0xd7, 0x2f, 0x6d, 0x94, 0x36, 0x9b, 0xb6, 0x19, 0xa2, 0xeb, 0x50, 0x67, 0xfe, 0x38, 0x72,
+	0xf8, 0x94, 0xe2, 0xfe, 0xa2, 0xd4, 0x5d, 0x0a, 0xac, 0x3f, 0x96, 0xa0, 0x6a, 0x30, 0x1e, 0x42,
+	0xdb, 0x99, 0xf2, 0x89, 0x98, 0x81, 0xeb, 0x70, 0x9f, 0x44, 0x12, 0xaa, 0x71, 0x77, 0x75, 0x98,
+	0xac, 0x68, 0xf8, 0x64, 0xca, 0x27, 0x07, 0xd1, 0x19, 0xb1, 0xe7, 0x4c, 0xd1, 0x6d, 0xa8, 0x9e,
+	0x4f, 0x31, 0xf5, 0x31, 0xeb, 0x2f, 0x6e, 0x2c, 0x6d, 0x36, 0xee, 0x76, 0x52, 0x5e, 0xcf, 0xa7,
+	0x98, 0xce, 0x6c, 0x63, 0x80, 0x7a, 0x50, 0x89, 0x48, 0xe4, 0xe2, 0xfe, 0x92, 0x9c, 0x8e, 0x1a,
+	0x58, 0x6f, 0xa0, 0x66, 0x63, 0x16, 0x93, 0x88, 0x61, 0x74, 0x07, 0xaa, 0x14, 0xb3, 0x69, 0xc0,
+	0x59, 0xbf, 0x24, 0xd1, 0xd6, 0x73, 0x68, 0x52, 0x6d, 0x1b, 0x33, 0xf4, 0x60, 0x7e, 0x99, 0x8d,
+	0xbb, 0xd7, 0x53, 0x3e, 0x06, 0xf9, 0xc4, 0xd8, 0xa4, 0x83, 0x70, 0x08, 0xdd, 0x9c, 0x1e, 0x0d,
+	0xa0, 0xa6, 0x77, 0x63, 0xa6, 0x43, 0x9a, 0x8c, 0xdf, 0x11, 0x53, 0x0f, 0x6a, 0x26, 0x4c, 0xe8,
+	0x87, 0xb0, 0xe2, 0x06, 0x3e, 0x8e, 0xf8, 0x68, 0x0e, 0xac, 0xad, 0xc4, 0x07, 0x06, 0x72, 0x0b,
+	0x7a, 0xda, 0x90, 0x07, 0x6c, 0xe4, 0x62, 0xca, 0x47, 0x13, 0x87, 0x4d, 0x34, 0x7a, 0x57, 0xe9,
+	0x5e, 0x04, 0x6c, 0x07, 0x53, 0xbe, 0xef, 0xb0, 0x89, 0xf5, 0xf7, 0x0a, 0x54, 0x64, 0x24, 0xc4,
+	0xde, 0xbb, 0x13, 0x27, 0x8a, 0x70, 0x20, 0xb1, 0xeb, 0xb6, 0x19, 0xa2, 0x87, 0xd0, 0x54, 0x1c,
+	0x1b, 0x89, 0xd0, 0xcf, 0x74, 0x5c, 0xd2, 0xb1, 0xdc, 0x91, 0x6a, 0x89, 0xb3, 0xbf, 0x60, 0x37,
+	0xdc, 0xcb, 0x21, 0xfa, 0x39, 0x40, 0x8c, 0x31, 0xd5, 0xae, 0x4b, 0xd2, 0xf5, 0x93, 0x94, 0xeb,
+	0x31, 0xc6, 0xf4, 0x10, 0x87, 0xa7, 0x98, 0xb2, 0x89, 0x1f, 0x1b, 0x88, 0xba, 0xf0, 0x51, 0x00,
+	0x5f, 0x42, 0xcd, 0x75, 0xb5, 0x7b, 0x59, 0xba, 0x7f, 0x94, 0xfe, 0xf2, 0xc4, 0xf1, 0x23, 0x97,
+	0x78, 0xd8, 0x78, 0x56, 0x5d, 0x57, 0xf9, 0x3d, 0x82, 0x46, 0x40, 0x5c, 0x27, 0x18, 0x09, 0x28,
+	0xd6, 0xaf, 0xe4, 0x5c, 0x9f, 0x09, 0xed, 0xb1, 0xf9, 0xce, 0xfe, 0x82, 0x0d, 0x81, 0x91, 0x30,
+	0xf4, 0x14, 0xda, 0x2c, 0x72, 0x62, 0x36, 0x21, 0x5c, 0x03, 0x2c, 0x4b, 0x80, 0x8f, 0x53, 0x00,
+	0x27, 0xda, 0x40, 0x7a, 0x18, 0x90, 0x16, 0x4b, 0x4b, 0xd1, 0x11, 0x74, 0x65, 0xd2, 0xcd, 0x46,
+	0xcc, 0x0f, 0xa7, 0x81, 0x4a, 0x88, 0xaa, 0x84, 0xda, 0x48, 0x47, 0x41, 0xda, 0x9c, 0x24, 0x26,
+	0x06, 0xad, 0x13, 0xcf, 0x29, 0x90, 0x0d, 0xc8, 0x35, 0x6b, 0x1e, 0x5d, 0x60, 0xca, 0x7c, 0x12,
+	0xb1, 0x7e, 0x4d, 0x22, 0xde, 0x28, 0x0a, 0xcc, 0x4b, 0x6d, 0x63, 0x20, 0xbb, 0xee, 0xbc, 0x06,
+	0xbd, 0x84, 0x9e, 0xde, 0xe0, 0x69, 0xec, 0x39, 0x1c, 0x8f, 0xfc, 0x30, 0x76, 0x5c, 0xde, 0xaf,
+	0x4b, 0x54, 0x2b, 0xb7, 0xd1, 0xbf, 0x96, 0x56, 0x07, 0xd2, 0xc8, 0xc0, 0x22, 0x37, 0xa7, 0x42,
+	0xcf, 0xa0, 0x43, 0xe8, 0x78, 0xe4, 0x92, 0xf0, 0xd4, 0x8f, 0xe4, 0xf4, 0x59, 0x1f, 0x24, 0xe6,
+	0xa7, 0x29, 0xcc, 0x23, 0x3a, 0xde, 0x49, 0x59, 0x18, 0xc0, 0x15, 0x92, 0x95, 0x6f, 0x57, 0xa1,
+	0x22, 0x59, 0x60, 0xfd, 0xb7, 0x0c, 0x8d, 0x54, 0xf6, 0xa2, 0x4d, 0xa8, 0x60, 0x4a, 0x09, 0xd5,
+	0x85, 0x26, 0x5d, 0x32, 0xf6, 0x84, 0x7c, 0x7f, 0xc1, 0x56, 0x06, 0xe8, 0x31, 0xb4, 0xf4, 0x42,
+	0x55, 0xc2, 0x6b, 0x2a, 0x5f, 0xcb, 0xad, 0x50, 0x21, 0xef, 0x2f, 0xd8, 0x4d, 0x37, 0x35, 0x46,
+	0x3b, 0xd0, 0x34, 0x5c, 0x14, 0x08, 0xfd, 0xa5, 0xdc, 0x62, 0xb2, 0x7c, 0x4c, 0x60, 0x40, 0xb3,
+	0xd2, 0xc6, 0x0c, 0x3d, 0x84, 0x6a, 0xa8, 0x08, 0xdf, 0x2f, 0xe7, 0xfc, 0xb3, 0xe9, 0x90, 0xf8,
+	0x1b, 0x0f, 0xf4, 0x0d, 0xac, 0xe5, 0xf8, 0x24, 0xa7, 0x52, 0xc9, 0x31, 0x60, 0x9e, 0x53, 0x09,
+	0xd8, 0x6a, 0x9c, 0xd7, 0xa0, 0x57, 0xb0, 0x9e, 0xe7, 0x95, 0x44, 0x5e, 0xce, 0xb3, 0x60, 0x9e,
+	0x41, 0x09, 0x74, 0xcf, 0x2d, 0x50, 0xa1, 0xdf, 0x41, 0xbf, 0x88, 0x5f, 0x12, 0x5d, 0xe5, 0xc2,
+	0xcd, 0xb7, 0x72, 0x2c, 0x81, 0x5f, 0x73, 0x8b, 0x74, 0xe8, 0x05, 0xf4, 0xe6, 0x79, 0x26, 0xb1,
+	0x6b, 0xb9, 0x3c, 0x9b, 0xe3, 0x5a, 0x02, 0x8c, 0x48, 0x4e, 0xb1, 0x5d, 0x83, 0x65, 0xc5, 0x12,
+	0xab, 0x05, 0x8d, 0x54, 0x85, 0xb3, 0xfe, 0xb6, 0x08, 0xcd, 0x34, 0x4d, 0xd0, 0x4f, 0xa1, 0x1c,
+	0xb2, 0xd8, 0x1c, 0x32, 0x37, 0xae, 0x60, 0xd3, 0xf0, 0x90, 0xc5, 0x6c, 0x2f, 0xe2, 0x74, 0x66,
+	0x4b, 0x73, 0xf4, 0x04, 0x6a, 0x84, 0x7a, 0x98, 0x62, 0x6a, 0x4e, 0xbb, 0x5b, 0x57, 0xb9, 0x1e,
+	0x69, 0x3b, 0xe5, 0x9e, 0xb8, 0x0d, 0x0e, 0xa1, 0x9e, 0xa0, 0xa2, 0x0e, 0x2c, 0xbd, 0xc6, 0x33,
+	0x5d, 0xbd, 0xc5, 0x5f, 0x74, 0x1b, 0x2a, 0x17, 0x4e, 0x30, 0x35, 0x47, 0x59, 0x6f, 0x18, 0xb2,
+	0x78, 0xf8, 0xd4, 0x39, 0xa5, 0xbe, 0x7b, 0x78, 0x72, 0xac, 0xbf, 0xa0, 0x4c, 0x1e, 0x2c, 0xde,
+	0x2f, 0x0d, 0x9e, 0x43, 0x2b, 0xf3, 0xa5, 0xf7, 0x81, 0x4c, 0x25, 0x5b, 0xe4, 0xc5, 0xc4, 0x8f,
+	0x38, 0x4b, 0x41, 0x5a, 0x6b, 0xb0, 0x5a, 0x50, 0xe2, 0xad, 0x7f, 0x94, 0xa0, 0x57, 0xc4, 0x75,
+	0xf4, 0x1c, 0x9a, 0xb2, 0xde, 0x8e, 0x4e, 0x67, 0x23, 0x42, 0xc7, 0x3a, 0xa6, 0x5b, 0xef, 0x48,
+	0x11, 0x29, 0x64, 0xdb, 0xb3, 0x23, 0x3a, 0x56, 0x21, 0x82, 0x38, 0x11, 0x0c, 0x8e, 0x60, 0x65,
+	0x4e, 0x5d, 0xb0, 0xae, 0x1f, 0x64, 0xd7, 0xd5, 0x99, 0xfb, 0x60, 0x66, 0x4d, 0x7f, 0x2e, 0x41,
+	0x3b, 0x9b, 0xe8, 0xa2, 0x71, 0xf0, 0x23, 0x8e, 0x29, 0x66, 0x49, 0xb3, 0x71, 0xbd, 0x28, 0x63,
+	0x0e, 0xb4, 0x91, 0x7d, 0x69, 0x8e, 0x7e, 0x09, 0xc8, 0xc3, 0xcc, 0xa5, 0x7e, 0xcc, 0x09, 0x35,
+	0xb9, 0x27, 0xe7, 0xd1, 0xce, 0x80, 0xec, 0x26, 0x46, 0x3a, 0xb9, 0xec, 0xae, 0x37, 0x2f, 0xb2,
+	0xfe, 0x53, 0x82, 0x6e, 0xee, 0x6b, 0xe8, 0x3e, 0x40, 0x92, 0x99, 0x66, 0x7e, 0xfd, 0xa2, 0xf9,
+	0xed, 0x38, 0x41, 0x60, 0xa7, 0x6c, 0xd1, 0x67, 0xb0, 0x16, 0x3a, 0x6f, 0x46, 0x01, 0xf6, 0xc6,
+	0x98, 0x8e, 0x26, 0xd8, 0x1f, 0x4f, 0xf8, 0x28, 0x70, 0xc6, 0x72, 0x7e, 0x65, 0x1b, 0x85, 0xce,
+	0x9b, 0x67, 0x52, 0xb7, 0x2f, 0x55, 0xcf, 0x9c, 0x31, 0xba, 0x05, 0xed, 0x98, 0xe2, 0x33, 0x4c,
+	0x29, 0xf6, 0xc4, 0x1e, 0x8a, 0x3a, 0xb9, 0xb4, 0x59, 0xb7, 0x5b, 0x89, 0xf4, 0x88, 0x8e, 0x19,
+	0xba, 0x07, 0x7d, 0x81, 0x7c, 0x69, 0x4a, 0x39, 0x1f, 0x85, 0xbe, 0x4b, 0x89, 0x2a, 0x8c, 0x65,
+	0x5b, 0x7c, 0xf9, 0xd8, 0xa8, 0x6d, 0xce, 0x0f, 0xa5, 0xd2, 0xfa, 0x5f, 0x09, 0x5a, 0x99, 0x09,
+	0x23, 0x04, 0xe5, 0xc8, 0x09, 0xb1, 0xde, 0x4f, 0xf9, 0x1f, 0xfd, 0x08, 0x3a, 0x2e, 0x09, 0x02,
+	0xec, 0xca, 0x12, 0x29, 0x44, 0x2a, 0xcb, 0xea, 0xf6, 0xca, 0xa5, 0xfc, 0x57, 0x42, 0x8c, 0x36,
+	0xa1, 0x13, 0x91, 0x51, 0x4c, 0xfd, 0x0b, 0x51, 0x9c, 0x28, 0x76, 0x3c, 0x55, 0xda, 0x6b, 0x76,
+	0x3b, 0x22, 0xc7, 0x4a, 0x6c, 0x0b, 0x29, 0xda, 0x86, 0xe6, 0x6b, 0x3c, 0x1b, 0x99, 0x3e, 0xba,
+	0x5f, 0x96, 0x91, 0xfc, 0x74, 0xa8, 0xfa, 0xeb, 0x61, 0xd2, 0xf7, 0xa9, 0xda, 0xbb, 0x17, 0x5d,
+	0xe0, 0x80, 0xc4, 0xd8, 0x6e, 0xbc, 0xc6, 0xb3, 0x63, 0xed, 0x83, 0xbe, 0x07, 0x75, 0x81, 0xa1,
+	0x66, 0x54, 0x91, 0x33, 0xaa, 0xbd, 0xc6, 0x33, 0x35, 0x95, 0x8f, 0x01, 0xd8, 0xf4, 0x54, 0x7d,
+	0x60, 0x26, 0x4b, 0x6f, 0xdd, 0xae, 0xb3, 0xe9, 0xa9, 0x02, 0xb4, 0x6c, 0xe8, 0x15, 0x9d, 0x30,
+	0xe8, 0x01, 0x54, 0x5d, 0x12, 0x71, 0x1c, 0x71, 0xbd, 0xb9, 0x1b, 0xd9, 0xbc, 0x24, 0x94, 0xe1,
+	0x10, 0x47, 0xfc, 0x92, 0x42, 0xb6, 0x71, 0xb0, 0x3a, 0xd0, 0xce, 0xb6, 0x42, 0xd6, 0xe7, 0x80,
+	0xf2, 0xbd, 0x8d, 0x98, 0x5a, 0xe8, 0x47, 0x9a, 0x02, 0x32, 0xd4, 0x65, 0xbb, 0x1e, 0xfa, 0x91,
+	0xda, 0x78, 0xeb, 0x18, 0xd6, 0x0a, 0xbb, 0x18, 0x74, 0x0f, 0x96, 0xf5, 0x72, 0x4a, 0x1b, 0xa5,
+	0xf7, 0x89, 0x96, 0x36, 0xb7, 0xfe, 0x52, 0x82, 0xf5, 0xe2, 0x43, 0x0c, 0x6d, 0x40, 0x83, 0x39,
+	0xdc, 0x67, 0x67, 0xbe, 0x73, 0x1a, 0xa8, 0x7d, 0xaf, 0xd9, 0x69, 0x11, 0xba, 0x09, 0x2d, 0x8a,
+	0xcf, 0xa7, 0x7e, 0xc2, 0x41, 0xb5, 0xf7, 0x4d, 0x23, 0x94, 0x14, 0x7c, 0x04, 0xdd, 0xd0, 0x8f,
+	0xfc, 0x50, 0x77, 0x89, 0x23, 0x86, 0xb9, 0x22, 0x6b, 0x51, 0x01, 0x58, 0xd1, 0xa6, 0x62, 0x74,
+	0x82, 0x39, 0xb3, 0xee, 0xc3, 0x7a, 0x71, 0x97, 0x85, 0x3e, 0xc9, 0xa5, 0x5b, 0x3d, 0x9d, 0x54,
+	0xd6, 0x37, 0x70, 0xed, 0x8a, 0x33, 0x14, 0x3d, 0x2a, 0xc8, 0xd4, 0xeb, 0x6f, 0x3d, 0x7b, 0xd3,
+	0xc0, 0xdf, 0x2e, 0x42, 0x37, 0x67, 0x21, 0x2e, 0x1a, 0x89, 0x8d, 0xce, 0x91, 0x4b, 0x81, 0xb8,
+	0x5c, 0x78, 0xf8, 0xcc, 0x8f, 0xb0, 0x97, 0xa9, 0x3d, 0x75, 0xbb, 0xad, 0xc5, 0x1a, 0x07, 0x5d,
+	0xc0, 0x20, 0x29, 0xcd, 0x7e, 0xc4, 0xb8, 0x13, 0x04, 0x29, 0x1f, 0x15, 0xb6, 0xaf, 0xde, 0x36,
+	0x55, 0x53, 0xa5, 0x0f, 0x8c, 0xb3, 0x56, 0xa8, 0x92, 0x7d, 0x2d, 0x2e, 0xd6, 0x0e, 0x7e, 0x0b,
+	0xd7, 0xdf, 0xe6, 0xf8, 0x81, 0xc5, 0xfc, 0x31, 0x5c, 0xbb, 0xa2, 0xab, 0x15, 0x1c, 0xca, 0xf4,
+	0x2d, 0xfa, 0xd2, 0xd5, 0x4c, 0x77, 0x21, 0xd6, 0xbf, 0x4a, 0xd0, 0xbf, 0xaa, 0x65, 0x41, 0x8f,
+	0xa1, 0xad, 0xdb, 0x35, 0x71, 0x99, 0x1a, 0x27, 0x3b, 0x7a, 0x2d, 0xd7, 0xa7, 0xed, 0x48, 0xbd,
+	0xdd, 0x8a, 0x53, 0x23, 0x59, 0x0e, 0x1c, 0xcf, 0xc3, 0xde, 0x48, 0xf6, 0x17, 0x8a, 0xc2, 0x75,
+	0x29, 0x11, 0xc7, 0x3e, 0xba, 0x01, 0x4d, 0x8a, 0x43, 0x72, 0x61, 0x0c, 0x54, 0x9d, 0x6d, 0x68,
+	0x99, 0x34, 0x79, 0x08, 0x2d, 0xfc, 0x7b, 0xec, 0x72, 0xec, 0xe9, 0x7b, 0x4c, 0x39, 0x77, 0x13,
+	0xde, 0x53, 0x7a, 0x11, 0x19, 0xbb, 0x89, 0x2f, 0x07, 0x4c, 0x94, 0x9b, 0xa2, 0xee, 0xfc, 0x43,
+	0x4e, 0x3b, 0xeb, 0x10, 0xd6, 0x0a, 0xbb, 0x30, 0xf4, 0xc5, 0x7c, 0x0d, 0x1b, 0xbc, 0xa5, 0x71,
+	0x4b, 0xaa, 0x57, 0x04, 0x2b, 0x73, 0xba, 0x77, 0xd0, 0xfd, 0x67, 0xe2, 0x36, 0x7b, 0x69, 0xad,
+	0x3b, 0xaf, 0x8f, 0xae, 0xfc, 0x96, 0x9d, 0x31, 0xb7, 0xbe, 0x2d, 0x41, 0x3b, 0x6b, 0x80, 0x5e,
+	0x41, 0xf7, 0x7c, 0xea, 0xe8, 0x17, 0x97, 0x6c, 0xdf, 0x32, 0xbc, 0x12, 0x76, 0xf8, 0x3c, 0x71,
+	0x49, 0xb5, 0x2d, 0x2b, 0xe7, 0x59, 0xe9, 0x60, 0x1b, 0x7a, 0x45, 0x86, 0x05, 0x9c, 0xef, 0xa5,
+	0x39, 0xdf, 0x4a, 0x33, 0xfc, 0x0f, 0x25, 0x68, 0xa6, 0x49, 0x26, 0x8e, 0xcb, 0xd8, 0xe1, 0x13,
+	0x73, 0x5c, 0x8a, 0xff, 0xe8, 0x0e, 0x94, 0xf9, 0x2c, 0xc6, 0x05, 0x6d, 0x47, 0xda, 0x75, 0xf8,
+	0x62, 0x16, 0x63, 0x5b, 0x5a, 0x5a, 0x3f, 0x81, 0xb2, 0x18, 0xa1, 0x3a, 0x54, 0x9e, 0xec, 0xee,
+	0xee, 0xed, 0x76, 0x16, 0x50, 0x03, 0xaa, 0xf6, 0xde, 0xe1, 0xd1, 0xcb, 0xbd, 0xdd, 0x4e, 0x09,
+	0x35, 0xa1, 0x76, 0x78, 0xb4, 0x7b, 0xf0, 0xf4, 0x60, 0x6f, 0xb7, 0xb3, 0x68, 0xfd, 0x02, 0x1a,
+	0x29, 0x9e, 0xa1, 0x9b, 0x50, 0x16, 0x74, 0xd4, 0x47, 0xc2, 0xca, 0x5c, 0x82, 0xda, 0x52, 0x89,
+	0xd6, 0x45, 0x07, 0xee, 0xb0, 0xa4, 0x20, 0xe9, 0x91, 0xf5, 0xef, 0x45, 0x58, 0x2b, 0x3c, 0xd4,
+	0xde, 0xb1, 0xf5, 0x63, 0x58, 0xc5, 0xca, 0x4d, 0x15, 0xb1, 0x31, 0x25, 0xd3, 0xd8, 0x30, 0xe0,
+	0xde, 0xbb, 0x4e, 0x4c, 0x23, 0x15, 0x85, 0xe8, 0x6b, 0xe9, 0xa9, 0xf6, 0xac, 0x8b, 0xe7, 0xe5,
+	0xe8, 0xc7, 0x50, 0x0d, 0x9c, 0x19, 0x99, 0x26, 0xa7, 0x49, 0x37, 0xfd, 0xee, 0x20, 0x35, 0xb6,
+	0xb1, 0x40, 0x5f, 0x42, 0xd5, 0xd4, 0xd0, 0xf2, 0x7b, 0xf4, 0x7c, 0xc6, 0x78, 0xf0, 0x12, 0xd6,
+	0x8b, 0x67, 0xf4, 0x81, 0x05, 0xf1, 0xaf, 0x25, 0x58, 0x56, 0x73, 0x44, 0xbf, 0x81, 0xd5, 0x2c,
+	0xb3, 0x65, 0xc4, 0x34, 0xb7, 0x37, 0x73, 0x6b, 0xca, 0x70, 0x5a, 0x4e, 0x48, 0x47, 0xe8, 0x7c,
+	0x5e, 0x3e, 0xd8, 0x85, 0xf5, 0x62, 0xe3, 0xef, 0xc4, 0xec, 0x21, 0x54, 0xd4, 0x33, 0xcb, 0x2d,
+	0xa8, 0xa8, 0xea, 0xa6, 0xa6, 0x96, 0xe3, 0x93, 0xd2, 0x5a, 0xff, 0x2c, 0x41, 0x59, 0xd2, 0x6f,
+	0x0b, 0x80, 0x71, 0x79, 0x13, 0x8d, 0xce, 0x48, 0xf2, 0x6e, 0xa0, 0xde, 0x59, 0x87, 0x49, 0x23,
+	0x52, 0x97, 0x36, 0xf2, 0x05, 0xee, 0x2b, 0x58, 0x09, 0x93, 0x3b, 0x87, 0xf2, 0x5a, 0xbc, 0xc2,
+	0xab, 0x7d, 0x69, 0x28, 0x5d, 0xd3, 0x4f, 0x80, 0x4b, 0x73, 0x4f, 0x80, 0x37, 0xa1, 0x95, 0xe9,
+	0xac, 0x75, 0xe3, 0xdb, 0x0c, 0x52, 0x2d, 0xb5, 0x38, 0x04, 0x52, 0xad, 0x71, 0x45, 0x35, 0x5e,
+	0x34, 0x69, 0x87, 0x6f, 0x40, 0x45, 0x3e, 0x73, 0xc8, 0x17, 0xbc, 0xa4, 0x80, 0xaa, 0x17, 0x3c,
+	0x35, 0xb4, 0xfe, 0x54, 0x82, 0x7a, 0x72, 0x3b, 0x43, 0x5b, 0x50, 0xc3, 0x7a, 0xa0, 0xe3, 0xb5,
+	0x5a, 0x70, 0x8b, 0xb3, 0x13, 0x23, 0xf4, 0x7d, 0x68, 0x8b, 0xe7, 0x44, 0x4a, 0x08, 0x97, 0x6f,
+	0x8a, 0x2a, 0x65, 0x9a, 0x76, 0x93, 0x07, 0xcc, 0x26, 0x84, 0x8b, 0xd7, 0x44, 0x86, 0xbe, 0x80,
+	0x75, 0x61, 0x25, 0x2b, 0x7d, 0x88, 0x3d, 0x5f, 0x84, 0x57, 0x59, 0x2f, 0x49, 0xeb, 0x1e, 0x0f,
+	0xd8, 0x41, 0x4a, 0x29, 0xbd, 0x2c, 0x1b, 0x6a, 0xe6, 0x8b, 0xa2, 0x2e, 0x4d, 0x08, 0x33, 0xb3,
+	0x97, 0xff, 0x85, 0x2c, 0x26, 0x94, 0xeb, 0xbd, 0x97, 0xff, 0x45, 0x7b, 0x25, 0xe6, 0x4a, 0x7d,
+	0xcf, 0xc3, 0x91, 0xee, 0xd4, 0x53, 0x92, 0xdb, 0x37, 0xa1, 0x9b, 0xcb, 0x1b, 0xb4, 0x0c, 0x8b,
+	0x2f, 0x3f, 0xeb, 0x2c, 0xc8, 0xdf, 0xbb, 0x9d, 0xd2, 0xdd, 0x7d, 0xa8, 0xef, 0x9a, 0x45, 0xa3,
+	0x87, 0x50, 0x33, 0x03, 0x94, 0xbe, 0x17, 0x65, 0x9e, 0xc7, 0x07, 0xab, 0x05, 0x4f, 0xc1, 0xd6,
+	0xc2, 0xf6, 0x9d, 0x57, 0xc3, 0xb1, 0xcf, 0x27, 0xd3, 0x53, 0xd1, 0xd8, 0x6e, 0x4d, 0x66, 0x31,
+	0xa6, 0x6a, 0xff, 0xb6, 0xce, 0xe4, 0x55, 0x5b, 0x3d, 0xed, 0xb3, 0xad, 0xc4, 0xf9, 0x74, 0x59,
+	0x4a, 0x3e, 0xff, 0xff, 0x00, 0xb1, 0x1d, 0x57, 0xc6, 0xff, 0x17, 0x00, 0x00,
 }
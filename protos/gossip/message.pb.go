@@ -1064,6 +1064,9 @@ type Properties struct {
 	LedgerHeight uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
 	LeftChannel  bool         `protobuf:"varint,2,opt,name=left_channel,json=leftChannel" json:"left_channel,omitempty"`
 	Chaincodes   []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	// snapshot_heights are the heights of the ledger snapshots
+	// the peer can serve to peers joining the channel
+	SnapshotHeights []uint64 `protobuf:"varint,4,rep,packed,name=snapshot_heights,json=snapshotHeights" json:"snapshot_heights,omitempty"`
 }
 
 func (m *Properties) Reset()                    { *m = Properties{} }
@@ -1092,6 +1095,13 @@ func (m *Properties) GetChaincodes() []*Chaincode {
 	return nil
 }
 
+func (m *Properties) GetSnapshotHeights() []uint64 {
+	if m != nil {
+		return m.SnapshotHeights
+	}
+	return nil
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -1937,6 +1947,10 @@ type GossipClient interface {
 	GossipStream(ctx context.Context, opts ...grpc.CallOption) (Gossip_GossipStreamClient, error)
 	// Ping is used to probe a remote peer's aliveness
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// BlockStream is a dedicated gRPC stream used for transferring blocks in bulk.
+	// After the handshake, the initiator sends a single RemoteStateRequest
+	// and the blocks are streamed back in RemoteStateResponses
+	BlockStream(ctx context.Context, opts ...grpc.CallOption) (Gossip_BlockStreamClient, error)
 }
 
 type gossipClient struct {
@@ -1987,6 +2001,37 @@ func (c *gossipClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 	return out, nil
 }
 
+func (c *gossipClient) BlockStream(ctx context.Context, opts ...grpc.CallOption) (Gossip_BlockStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Gossip_serviceDesc.Streams[1], c.cc, "/gossip.Gossip/BlockStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gossipBlockStreamClient{stream}
+	return x, nil
+}
+
+type Gossip_BlockStreamClient interface {
+	Send(*Envelope) error
+	Recv() (*Envelope, error)
+	grpc.ClientStream
+}
+
+type gossipBlockStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *gossipBlockStreamClient) Send(m *Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gossipBlockStreamClient) Recv() (*Envelope, error) {
+	m := new(Envelope)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Gossip service
 
 type GossipServer interface {
@@ -1994,6 +2039,10 @@ type GossipServer interface {
 	GossipStream(Gossip_GossipStreamServer) error
 	// Ping is used to probe a remote peer's aliveness
 	Ping(context.Context, *Empty) (*Empty, error)
+	// BlockStream is a dedicated gRPC stream used for transferring blocks in bulk.
+	// After the handshake, the initiator sends a single RemoteStateRequest
+	// and the blocks are streamed back in RemoteStateResponses
+	BlockStream(Gossip_BlockStreamServer) error
 }
 
 func RegisterGossipServer(s *grpc.Server, srv GossipServer) {
@@ -2044,6 +2093,32 @@ func _Gossip_Ping_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Gossip_BlockStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GossipServer).BlockStream(&gossipBlockStreamServer{stream})
+}
+
+type Gossip_BlockStreamServer interface {
+	Send(*Envelope) error
+	Recv() (*Envelope, error)
+	grpc.ServerStream
+}
+
+type gossipBlockStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *gossipBlockStreamServer) Send(m *Envelope) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gossipBlockStreamServer) Recv() (*Envelope, error) {
+	m := new(Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Gossip_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "gossip.Gossip",
 	HandlerType: (*GossipServer)(nil),
@@ -2060,6 +2135,12 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "BlockStream",
+			Handler:       _Gossip_BlockStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "gossip/message.proto",
 }
@@ -2067,123 +2148,124 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x6f, 0xe3, 0xc6,
-	0x11, 0x17, 0x6d, 0x49, 0x16, 0x47, 0x1f, 0x96, 0xd7, 0xbe, 0x3b, 0xc6, 0x49, 0x13, 0x87, 0xed,
-	0x25, 0xd7, 0xfa, 0x62, 0x5f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x07, 0x5b, 0x52, 0x2c, 0x21, 0x27,
-	0x9d, 0x4b, 0xfb, 0xd0, 0xba, 0x2f, 0xc4, 0x9a, 0x5c, 0x53, 0xac, 0xc9, 0x25, 0xcd, 0x5d, 0x3b,
-	0xf6, 0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0xdf, 0xd0, 0xa7, 0xfe, 0x9b, 0xc5, 0xee, 0xf2, 0x53,
-	0xb2, 0x0f, 0xb8, 0x00, 0x79, 0xd3, 0x7c, 0xef, 0xce, 0xce, 0xfc, 0x66, 0x28, 0xd8, 0xf2, 0x22,
-	0xc6, 0xfc, 0x78, 0x3f, 0x24, 0x8c, 0x61, 0x8f, 0xec, 0xc5, 0x49, 0xc4, 0x23, 0xd4, 0x54, 0xdc,
-	0xed, 0x67, 0x4e, 0x14, 0x86, 0x11, 0xdd, 0x77, 0xa2, 0x20, 0x20, 0x0e, 0xf7, 0x23, 0xaa, 0x14,
-	0xcc, 0x7f, 0x69, 0xd0, 0x1a, 0xd1, 0x5b, 0x12, 0x44, 0x31, 0x41, 0x06, 0xac, 0xc5, 0xf8, 0x3e,
-	0x88, 0xb0, 0x6b, 0x68, 0x3b, 0xda, 0x8b, 0x8e, 0x95, 0x91, 0xe8, 0x13, 0xd0, 0x99, 0xef, 0x51,
-	0xcc, 0x6f, 0x12, 0x62, 0xac, 0x48, 0x59, 0xc1, 0x40, 0xaf, 0x61, 0x9d, 0x11, 0x27, 0x21, 0xdc,
-	0x26, 0xa9, 0x2b, 0x63, 0x75, 0x47, 0x7b, 0xd1, 0x3e, 0x78, 0xba, 0xa7, 0xe2, 0xef, 0x9d, 0x4a,
-	0x71, 0x16, 0xc8, 0xea, 0xb1, 0x0a, 0x6d, 0x8e, 0xa1, 0x57, 0xd5, 0xf8, 0xa9, 0x47, 0x31, 0x0f,
-	0xa1, 0xa9, 0x3c, 0xa1, 0x97, 0xd0, 0xf7, 0x29, 0x27, 0x09, 0xc5, 0xc1, 0x88, 0xba, 0x71, 0xe4,
-	0x53, 0x2e, 0x5d, 0xe9, 0xe3, 0x9a, 0xb5, 0x24, 0x39, 0xd2, 0x61, 0xcd, 0x89, 0x28, 0x27, 0x94,
-	0x9b, 0x3f, 0xb6, 0xa1, 0x7b, 0x2c, 0x8f, 0x3d, 0x55, 0xb9, 0x44, 0x5b, 0xd0, 0xa0, 0x11, 0x75,
-	0x88, 0xb4, 0xaf, 0x5b, 0x8a, 0x10, 0x47, 0x74, 0xe6, 0x98, 0x52, 0x12, 0xa4, 0xc7, 0xc8, 0x48,
-	0xb4, 0x0b, 0xab, 0x1c, 0x7b, 0x32, 0x07, 0xbd, 0x83, 0x8f, 0xb2, 0x1c, 0x54, 0x7c, 0xee, 0x9d,
-	0x61, 0xcf, 0x12, 0x5a, 0xe8, 0x6b, 0xd0, 0x71, 0xe0, 0xdf, 0x12, 0x3b, 0x64, 0x9e, 0xd1, 0x90,
-	0x69, 0xdb, 0xca, 0x4c, 0x0e, 0x85, 0x20, 0xb5, 0x18, 0xd7, 0xac, 0x96, 0x54, 0x9c, 0x32, 0x0f,
-	0xfd, 0x0e, 0xd6, 0x42, 0x12, 0xda, 0x09, 0xb9, 0x36, 0x9a, 0xd2, 0x24, 0x8f, 0x32, 0x25, 0xe1,
-	0x05, 0x49, 0xd8, 0xdc, 0x8f, 0x2d, 0x72, 0x7d, 0x43, 0x18, 0x1f, 0xd7, 0xac, 0x66, 0x48, 0x42,
-	0x8b, 0x5c, 0xa3, 0xdf, 0x67, 0x56, 0xcc, 0x58, 0x93, 0x56, 0xdb, 0x0f, 0x59, 0xb1, 0x38, 0xa2,
-	0x8c, 0xe4, 0x66, 0x0c, 0xbd, 0x82, 0x96, 0x8b, 0x39, 0x96, 0x07, 0x6c, 0x49, 0xbb, 0xcd, 0xcc,
-	0x6e, 0x88, 0x39, 0x2e, 0xce, 0xb7, 0x26, 0xd4, 0xc4, 0xf1, 0x76, 0xa1, 0x31, 0x27, 0x41, 0x10,
-	0x19, 0x7a, 0x55, 0x5d, 0xa5, 0x60, 0x2c, 0x44, 0xe3, 0x9a, 0xa5, 0x74, 0xd0, 0x7e, 0xea, 0xde,
-	0xf5, 0x3d, 0x03, 0xa4, 0x3e, 0x2a, 0xbb, 0x1f, 0xfa, 0x9e, 0xba, 0x85, 0xf4, 0x3e, 0xf4, 0xbd,
-	0xfc, 0x3c, 0xe2, 0xf6, 0xed, 0xe5, 0xf3, 0x14, 0xf7, 0x96, 0x16, 0xea, 0xe2, 0x6d, 0x69, 0x71,
-	0x13, 0xbb, 0x98, 0x13, 0xa3, 0xb3, 0x1c, 0xe5, 0x9d, 0x94, 0x8c, 0x6b, 0x16, 0xb8, 0x39, 0x85,
-	0x9e, 0x43, 0x83, 0x84, 0x31, 0xbf, 0x37, 0xba, 0xd2, 0xa0, 0x9b, 0x19, 0x8c, 0x04, 0x53, 0x5c,
-	0x40, 0x4a, 0xd1, 0x2e, 0xd4, 0x9d, 0x88, 0x52, 0xa3, 0x27, 0xb5, 0x9e, 0x64, 0x5a, 0x83, 0x88,
-	0xd2, 0x11, 0xe3, 0xf8, 0x22, 0xf0, 0xd9, 0x7c, 0x5c, 0xb3, 0xa4, 0x12, 0x3a, 0x00, 0x60, 0x1c,
-	0x73, 0x62, 0xfb, 0xf4, 0x32, 0x32, 0xd6, 0xa5, 0xc9, 0x46, 0xde, 0x26, 0x42, 0x32, 0xa1, 0x97,
-	0x22, 0x3b, 0x3a, 0xcb, 0x08, 0x74, 0x04, 0x3d, 0x65, 0xc3, 0x28, 0x8e, 0xd9, 0x3c, 0xe2, 0x46,
-	0xbf, 0xfa, 0xe8, 0xb9, 0xdd, 0x69, 0xaa, 0x30, 0xae, 0x59, 0x5d, 0x69, 0x92, 0x31, 0xd0, 0x14,
-	0x36, 0x8b, 0xb8, 0x76, 0x7c, 0x13, 0x04, 0x32, 0x7f, 0x1b, 0xd2, 0xd1, 0x27, 0x4b, 0x8e, 0x4e,
-	0x6e, 0x82, 0xa0, 0x48, 0x64, 0x9f, 0x2d, 0xf0, 0xd1, 0x21, 0x28, 0xff, 0xc2, 0x89, 0x50, 0x32,
-	0x50, 0xb5, 0xa0, 0x2c, 0x12, 0x46, 0x9c, 0x48, 0x77, 0x85, 0x9b, 0x0e, 0x2b, 0xd1, 0x68, 0x98,
-	0xdd, 0x2a, 0x49, 0x4b, 0xce, 0xd8, 0x94, 0x3e, 0x3e, 0x7e, 0xd0, 0x47, 0x5e, 0x95, 0x5d, 0x56,
-	0x66, 0x88, 0xdc, 0x04, 0x04, 0xbb, 0xaa, 0x78, 0x65, 0x89, 0x6e, 0x55, 0x73, 0xf3, 0x26, 0x97,
-	0x16, 0x85, 0xda, 0x2d, 0x4c, 0x44, 0xb9, 0x7e, 0x0b, 0xdd, 0x98, 0x90, 0xc4, 0xf6, 0x5d, 0x42,
-	0xb9, 0xcf, 0xef, 0x8d, 0x27, 0xd5, 0x36, 0x3c, 0x21, 0x24, 0x99, 0xa4, 0x32, 0x71, 0x8d, 0xb8,
-	0x44, 0x8b, 0x66, 0xc7, 0xce, 0x95, 0xf1, 0x54, 0x9a, 0x3c, 0xcb, 0x3b, 0xd7, 0xb9, 0xa2, 0xd1,
-	0x0f, 0x01, 0x71, 0x3d, 0x12, 0x12, 0x2a, 0x2e, 0x2f, 0xb4, 0xd0, 0x9f, 0x01, 0xe2, 0xc4, 0xbf,
-	0x55, 0x59, 0x30, 0x9e, 0x55, 0x93, 0xaf, 0xee, 0x7b, 0x72, 0xcb, 0xab, 0x55, 0x5c, 0xb2, 0x40,
-	0xaf, 0x4b, 0xf6, 0xcc, 0x30, 0xa4, 0xfd, 0x2f, 0x1e, 0xb1, 0xcf, 0x33, 0x56, 0x32, 0x41, 0xaf,
-	0xa1, 0x93, 0x52, 0xb6, 0x28, 0x74, 0xe3, 0xa3, 0xea, 0xb3, 0x9d, 0x28, 0x59, 0xb5, 0xad, 0xdb,
-	0x71, 0xc1, 0x35, 0x6d, 0x58, 0x3d, 0xc3, 0x1e, 0xea, 0x82, 0xfe, 0x6e, 0x36, 0x1c, 0x7d, 0x37,
-	0x99, 0x8d, 0x86, 0xfd, 0x1a, 0xd2, 0xa1, 0x31, 0x9a, 0x9e, 0x9c, 0x9d, 0xf7, 0x35, 0xd4, 0x81,
-	0xd6, 0x5b, 0xeb, 0xd8, 0x7e, 0x3b, 0x7b, 0x73, 0xde, 0x5f, 0x11, 0x7a, 0x83, 0xf1, 0xe1, 0x4c,
-	0x91, 0xab, 0xa8, 0x0f, 0x1d, 0x49, 0x1e, 0xce, 0x86, 0xf6, 0x5b, 0xeb, 0xb8, 0x5f, 0x47, 0xeb,
-	0xd0, 0x56, 0x0a, 0x96, 0x64, 0x34, 0xca, 0x48, 0xfc, 0x3f, 0x0d, 0xf4, 0xbc, 0x22, 0xd1, 0x1e,
-	0xe8, 0xdc, 0x0f, 0x09, 0xe3, 0x38, 0x8c, 0x25, 0xe2, 0xb6, 0x0f, 0xfa, 0xe5, 0x17, 0x3a, 0xf3,
-	0x43, 0x62, 0x15, 0x2a, 0xe8, 0x09, 0x34, 0xe3, 0x2b, 0xdf, 0xf6, 0x5d, 0x09, 0xc4, 0x1d, 0xab,
-	0x11, 0x5f, 0xf9, 0x13, 0x17, 0x7d, 0x06, 0xed, 0x14, 0xa7, 0xed, 0xe9, 0xe1, 0xc0, 0xa8, 0x4b,
-	0x19, 0xa4, 0xac, 0xe9, 0xe1, 0x40, 0x74, 0x68, 0x9c, 0x44, 0x31, 0x49, 0xb8, 0x4f, 0x58, 0x8a,
-	0xc8, 0xa8, 0x48, 0x50, 0x26, 0xb1, 0x4a, 0x5a, 0xe6, 0x8f, 0x1a, 0x40, 0x21, 0x42, 0xbf, 0x84,
-	0xae, 0x7c, 0xfa, 0xc4, 0x9e, 0x13, 0xdf, 0x9b, 0xf3, 0x74, 0x70, 0x74, 0x14, 0x73, 0x2c, 0x79,
-	0xe8, 0x73, 0xe8, 0x04, 0xe4, 0x92, 0xdb, 0xe5, 0x21, 0xd2, 0xb2, 0xda, 0x82, 0x37, 0x48, 0x07,
-	0xc9, 0x6f, 0x41, 0x1c, 0xcc, 0xa7, 0x4e, 0xe4, 0x12, 0x66, 0xac, 0xee, 0xac, 0x96, 0xc1, 0x62,
-	0x90, 0x49, 0xac, 0x92, 0x92, 0x79, 0x08, 0x1b, 0x4b, 0x68, 0x80, 0x5e, 0x42, 0x8b, 0x04, 0xb2,
-	0x10, 0x99, 0xa1, 0x49, 0x2f, 0x79, 0xe6, 0xf2, 0x99, 0x9c, 0x6b, 0x98, 0x7f, 0x80, 0xad, 0x87,
-	0x70, 0x60, 0x31, 0x73, 0xda, 0x62, 0xe6, 0xcc, 0x4b, 0xe8, 0x56, 0x40, 0xaf, 0xf4, 0x04, 0x5a,
-	0xf9, 0x09, 0xb6, 0xa1, 0x95, 0xb7, 0x9a, 0x1a, 0x9d, 0x39, 0x8d, 0x4c, 0xe8, 0xf2, 0x80, 0xd9,
-	0x0e, 0x49, 0xb8, 0x3d, 0xc7, 0x6c, 0x9e, 0x3e, 0x5e, 0x9b, 0x07, 0x6c, 0x40, 0x12, 0x3e, 0xc6,
-	0x6c, 0x6e, 0xbe, 0x83, 0x4e, 0xb9, 0x25, 0x1f, 0x0b, 0x83, 0xa0, 0x2e, 0xdc, 0xa4, 0x21, 0xe4,
-	0x6f, 0x11, 0x3a, 0x24, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x73, 0xda, 0x0c, 0xa1, 0x5d, 0xea, 0xbc,
-	0xc7, 0xa7, 0xbe, 0x2b, 0x27, 0x12, 0x33, 0x56, 0x76, 0x56, 0x5f, 0xe8, 0x56, 0x46, 0xa2, 0x3d,
-	0x68, 0x85, 0xcc, 0xb3, 0xf9, 0x7d, 0xba, 0xfe, 0xf4, 0x8a, 0xb1, 0x24, 0xb2, 0x38, 0x65, 0xde,
-	0xd9, 0x7d, 0x4c, 0xac, 0xb5, 0x50, 0xfd, 0x30, 0x23, 0x68, 0x97, 0xe6, 0xe1, 0x23, 0xe1, 0xca,
-	0xe7, 0x5d, 0xa9, 0x9e, 0xf7, 0x83, 0x03, 0xde, 0x01, 0x14, 0xa3, 0xee, 0x91, 0x78, 0xbf, 0x82,
-	0x7a, 0x1a, 0xeb, 0xe1, 0x2a, 0xa9, 0xff, 0xa4, 0xc8, 0x81, 0x8a, 0xac, 0x46, 0xf9, 0xcf, 0x9e,
-	0xd8, 0x6f, 0xd4, 0x3b, 0x66, 0xdb, 0xdb, 0xaf, 0xab, 0xab, 0x64, 0xfb, 0x60, 0x3d, 0xb7, 0x56,
-	0xec, 0x7c, 0xb7, 0x34, 0xbf, 0x03, 0xb4, 0x8c, 0x80, 0xe8, 0xd5, 0xa2, 0x83, 0xa7, 0x0b, 0x70,
-	0xb9, 0xe4, 0xe7, 0x1c, 0xd6, 0x52, 0x1e, 0x7a, 0x06, 0x6b, 0x8c, 0x5c, 0xdb, 0xf4, 0x26, 0x4c,
-	0xaf, 0xdb, 0x64, 0xe4, 0x7a, 0x76, 0x13, 0x8a, 0xea, 0x2c, 0xbd, 0xaa, 0xca, 0xeb, 0xe7, 0x0b,
-	0xe8, 0x2c, 0x3a, 0xbe, 0x53, 0xc5, 0xdf, 0xff, 0xac, 0x40, 0xaf, 0x1a, 0x16, 0x7d, 0x09, 0xeb,
-	0xc5, 0x5e, 0x6f, 0x53, 0x1c, 0xaa, 0xcc, 0xea, 0x56, 0xaf, 0x60, 0xcf, 0x70, 0x48, 0xc4, 0xea,
-	0x2c, 0xa4, 0x2c, 0xc6, 0x8e, 0x5a, 0x9d, 0x75, 0xab, 0x60, 0xa0, 0x4d, 0x68, 0xf0, 0xbb, 0x0c,
-	0x2e, 0x75, 0xab, 0xce, 0xef, 0x26, 0xae, 0x40, 0xb2, 0xec, 0x44, 0xc9, 0x0f, 0x8c, 0xf0, 0x14,
-	0x2f, 0xb3, 0x63, 0x5a, 0x82, 0x87, 0x5e, 0x02, 0xca, 0x94, 0x98, 0x1f, 0x66, 0x98, 0xd7, 0x90,
-	0xd7, 0xed, 0xa7, 0x92, 0x53, 0x3f, 0x4c, 0x71, 0x6f, 0x06, 0xa8, 0x74, 0x5c, 0x27, 0xa2, 0x97,
-	0xbe, 0xc7, 0xd2, 0x35, 0xf6, 0xb3, 0x3d, 0xf5, 0xa1, 0xb2, 0x37, 0xc8, 0x35, 0x06, 0x52, 0xe1,
-	0x04, 0x3b, 0x57, 0xd8, 0x23, 0xd6, 0x86, 0xb3, 0x20, 0x60, 0xe6, 0xbf, 0x35, 0xe8, 0x94, 0x17,
-	0x65, 0xb4, 0x07, 0x10, 0xe6, 0xfb, 0x6c, 0xfa, 0x64, 0xbd, 0xea, 0xa6, 0x6b, 0x95, 0x34, 0x3e,
-	0x78, 0xb0, 0x94, 0xe1, 0xab, 0x5e, 0x85, 0x2f, 0xf3, 0x9f, 0x1a, 0x6c, 0x2c, 0x6d, 0x1c, 0x8f,
-	0x01, 0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x9f, 0xd9, 0x2e, 0x71, 0x02, 0x9c, 0x60, 0x91, 0x02,
-	0xf9, 0x54, 0x2d, 0xab, 0xeb, 0xb3, 0x61, 0xc1, 0x34, 0xff, 0x08, 0xad, 0xcc, 0x5a, 0x94, 0x9f,
-	0x4f, 0x9d, 0x72, 0xf9, 0xf9, 0xd4, 0x11, 0xe5, 0x57, 0xaa, 0xcb, 0x95, 0x72, 0x5d, 0x9a, 0x97,
-	0xb0, 0xb1, 0xf4, 0x0d, 0x81, 0xbe, 0x85, 0x3e, 0x23, 0xc1, 0xa5, 0x5c, 0x1e, 0x93, 0x50, 0xc5,
-	0xd6, 0xaa, 0x07, 0xce, 0x21, 0x62, 0x5d, 0x68, 0x4e, 0x0a, 0x45, 0xd1, 0xef, 0x62, 0x19, 0xa2,
-	0xb2, 0xaf, 0x3b, 0x96, 0x22, 0xcc, 0x0b, 0x40, 0xcb, 0x5f, 0x1d, 0xe8, 0x0b, 0x68, 0xc8, 0x8f,
-	0x9c, 0x47, 0xc7, 0x94, 0x12, 0x4b, 0x9c, 0x22, 0xd8, 0x7d, 0x0f, 0x4e, 0x11, 0xec, 0x9a, 0x7f,
-	0x85, 0xa6, 0x8a, 0x21, 0xde, 0x8c, 0x54, 0xbe, 0x02, 0xad, 0x9c, 0x7e, 0x2f, 0xc6, 0x3e, 0xbc,
-	0x44, 0x98, 0x6b, 0xd0, 0x90, 0x1f, 0x01, 0xe6, 0xdf, 0x00, 0x2d, 0xaf, 0xba, 0x62, 0x88, 0x31,
-	0x8e, 0x13, 0x6e, 0x57, 0x5b, 0xbf, 0x2d, 0x99, 0xa7, 0xaa, 0xff, 0x3f, 0x85, 0x36, 0xa1, 0xae,
-	0x5d, 0x7d, 0x04, 0x9d, 0x50, 0x57, 0xc9, 0xcd, 0x23, 0xd8, 0x7c, 0x60, 0x01, 0x46, 0xbb, 0xd0,
-	0x4a, 0x51, 0x26, 0x1b, 0xe5, 0x4b, 0x70, 0x96, 0x2b, 0x98, 0xc7, 0xb0, 0xf5, 0xd0, 0x52, 0x89,
-	0xf6, 0x0b, 0xac, 0x55, 0x3e, 0xf2, 0x8f, 0x96, 0x54, 0x51, 0x21, 0x75, 0x0e, 0xc1, 0xe6, 0x7f,
-	0x35, 0xe8, 0x56, 0x44, 0x05, 0x5a, 0x68, 0x25, 0xb4, 0x78, 0x3f, 0xc0, 0x7c, 0x0a, 0x50, 0x74,
-	0x6f, 0x8a, 0x32, 0x25, 0x0e, 0xfa, 0x18, 0xf4, 0x8b, 0x20, 0x72, 0xae, 0x44, 0x4e, 0x64, 0x63,
-	0xd5, 0xad, 0x96, 0x64, 0x9c, 0x92, 0x6b, 0xb4, 0x03, 0x1d, 0x91, 0x2a, 0x9f, 0xda, 0x92, 0x95,
-	0xa2, 0x0b, 0x30, 0x72, 0x3d, 0xa1, 0x47, 0x82, 0x63, 0x7e, 0x0f, 0x4f, 0x1e, 0xdc, 0x80, 0xd1,
-	0xc1, 0xd2, 0xf6, 0xf3, 0x74, 0xe1, 0xba, 0x23, 0x25, 0x2e, 0xed, 0x40, 0xe7, 0xd0, 0xab, 0xca,
-	0xd0, 0x57, 0xd0, 0x54, 0xd9, 0x48, 0x0b, 0xff, 0x91, 0x94, 0xa5, 0x4a, 0xe5, 0x3f, 0x30, 0x54,
-	0xd9, 0xe7, 0xc3, 0xe1, 0x2f, 0xb9, 0xeb, 0x0c, 0xc0, 0x9f, 0xc3, 0x3a, 0xbf, 0xb3, 0x2b, 0xd7,
-	0x4b, 0x17, 0x46, 0x7e, 0x77, 0x9a, 0x5f, 0xb0, 0xea, 0xb2, 0xfc, 0x9f, 0x88, 0xf9, 0x25, 0xac,
-	0x2f, 0x7c, 0x70, 0x88, 0xa6, 0x23, 0x49, 0x12, 0x25, 0xe9, 0xfb, 0x28, 0xc2, 0x7c, 0x07, 0x7a,
-	0xbe, 0x36, 0x8a, 0x09, 0x54, 0x1a, 0x16, 0xf2, 0xb7, 0x88, 0x71, 0x4b, 0x12, 0x26, 0x1e, 0x48,
-	0xbd, 0x5f, 0x46, 0xbe, 0x6f, 0x73, 0xfa, 0xcd, 0x9f, 0xa0, 0x5d, 0x9a, 0xc4, 0x8b, 0x1f, 0x07,
-	0x5d, 0xd0, 0x8f, 0xde, 0xbc, 0x1d, 0x7c, 0x6f, 0x4f, 0x4f, 0x8f, 0xfb, 0x9a, 0xf8, 0x06, 0x98,
-	0x0c, 0x47, 0xb3, 0xb3, 0xc9, 0xd9, 0xb9, 0xe4, 0xac, 0x1c, 0xfc, 0x03, 0x9a, 0x6a, 0x13, 0x42,
-	0xdf, 0x40, 0x47, 0xfd, 0x3a, 0xe5, 0x09, 0xc1, 0x21, 0x5a, 0x6a, 0xec, 0xed, 0x25, 0x8e, 0x59,
-	0x7b, 0xa1, 0xbd, 0xd2, 0xd0, 0x17, 0x50, 0x3f, 0xf1, 0xa9, 0x87, 0xaa, 0x1f, 0xe9, 0xdb, 0x55,
-	0xd2, 0xac, 0x1d, 0x7d, 0xf5, 0xf7, 0x5d, 0xcf, 0xe7, 0xf3, 0x9b, 0x0b, 0x31, 0x69, 0xf6, 0xe7,
-	0xf7, 0x31, 0x49, 0xd4, 0x56, 0xbe, 0x7f, 0x89, 0x2f, 0x12, 0xdf, 0xd9, 0x97, 0xff, 0x8b, 0xb1,
-	0x7d, 0x65, 0x76, 0xd1, 0x94, 0xe4, 0xd7, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x22, 0x0e, 0xc2,
-	0x6f, 0x5f, 0x13, 0x00, 0x00,
+	// 1904 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x53, 0xe4, 0xc6,
+	0x15, 0x1f, 0xc1, 0xcc, 0x30, 0x7a, 0x9a, 0x19, 0x86, 0x5e, 0x76, 0x57, 0xc6, 0x8e, 0x4d, 0x94,
+	0xac, 0x8d, 0xc3, 0x1a, 0x36, 0x38, 0x29, 0xbb, 0xca, 0x49, 0xb6, 0x60, 0xc0, 0x0c, 0xe5, 0x85,
+	0x25, 0x82, 0xad, 0x84, 0x5c, 0x54, 0x8d, 0xd4, 0x68, 0x54, 0x48, 0x2d, 0xa1, 0x6e, 0x30, 0x1c,
+	0x53, 0x39, 0xa4, 0x2a, 0x97, 0x7c, 0x86, 0x54, 0x0e, 0xb9, 0xe7, 0x13, 0xa6, 0xba, 0x5b, 0x7f,
+	0x5a, 0x0c, 0x6c, 0xd5, 0xba, 0x2a, 0x37, 0xbd, 0xff, 0xdd, 0xaf, 0xdf, 0xfb, 0xf5, 0x6b, 0xc1,
+	0x72, 0x98, 0x32, 0x16, 0x65, 0x9b, 0x09, 0x61, 0x0c, 0x87, 0x64, 0x23, 0xcb, 0x53, 0x9e, 0xa2,
+	0xae, 0xe2, 0xae, 0x3c, 0xf7, 0xd3, 0x24, 0x49, 0xe9, 0xa6, 0x9f, 0xc6, 0x31, 0xf1, 0x79, 0x94,
+	0x52, 0xa5, 0xe0, 0xfc, 0xcd, 0x80, 0xde, 0x1e, 0xbd, 0x21, 0x71, 0x9a, 0x11, 0x64, 0xc3, 0x42,
+	0x86, 0xef, 0xe2, 0x14, 0x07, 0xb6, 0xb1, 0x6a, 0xac, 0xf5, 0xdd, 0x92, 0x44, 0x9f, 0x80, 0xc9,
+	0xa2, 0x90, 0x62, 0x7e, 0x9d, 0x13, 0x7b, 0x4e, 0xca, 0x6a, 0x06, 0x7a, 0x0d, 0x8b, 0x8c, 0xf8,
+	0x39, 0xe1, 0x1e, 0x29, 0x5c, 0xd9, 0xf3, 0xab, 0xc6, 0x9a, 0xb5, 0xf5, 0x6c, 0x43, 0xc5, 0xdf,
+	0x38, 0x91, 0xe2, 0x32, 0x90, 0x3b, 0x64, 0x0d, 0xda, 0x99, 0xc0, 0xb0, 0xa9, 0xf1, 0x53, 0x97,
+	0xe2, 0x6c, 0x43, 0x57, 0x79, 0x42, 0x2f, 0x61, 0x14, 0x51, 0x4e, 0x72, 0x8a, 0xe3, 0x3d, 0x1a,
+	0x64, 0x69, 0x44, 0xb9, 0x74, 0x65, 0x4e, 0x5a, 0xee, 0x8c, 0x64, 0xc7, 0x84, 0x05, 0x3f, 0xa5,
+	0x9c, 0x50, 0xee, 0xfc, 0xdd, 0x82, 0xc1, 0xbe, 0x5c, 0xf6, 0xa1, 0xca, 0x25, 0x5a, 0x86, 0x0e,
+	0x4d, 0xa9, 0x4f, 0xa4, 0x7d, 0xdb, 0x55, 0x84, 0x58, 0xa2, 0x3f, 0xc5, 0x94, 0x92, 0xb8, 0x58,
+	0x46, 0x49, 0xa2, 0x75, 0x98, 0xe7, 0x38, 0x94, 0x39, 0x18, 0x6e, 0x7d, 0x54, 0xe6, 0xa0, 0xe1,
+	0x73, 0xe3, 0x14, 0x87, 0xae, 0xd0, 0x42, 0x5f, 0x83, 0x89, 0xe3, 0xe8, 0x86, 0x78, 0x09, 0x0b,
+	0xed, 0x8e, 0x4c, 0xdb, 0x72, 0x69, 0xb2, 0x2d, 0x04, 0x85, 0xc5, 0xa4, 0xe5, 0xf6, 0xa4, 0xe2,
+	0x21, 0x0b, 0xd1, 0x6f, 0x60, 0x21, 0x21, 0x89, 0x97, 0x93, 0x2b, 0xbb, 0x2b, 0x4d, 0xaa, 0x28,
+	0x87, 0x24, 0x39, 0x27, 0x39, 0x9b, 0x46, 0x99, 0x4b, 0xae, 0xae, 0x09, 0xe3, 0x93, 0x96, 0xdb,
+	0x4d, 0x48, 0xe2, 0x92, 0x2b, 0xf4, 0xdb, 0xd2, 0x8a, 0xd9, 0x0b, 0xd2, 0x6a, 0xe5, 0x21, 0x2b,
+	0x96, 0xa5, 0x94, 0x91, 0xca, 0x8c, 0xa1, 0x57, 0xd0, 0x0b, 0x30, 0xc7, 0x72, 0x81, 0x3d, 0x69,
+	0xf7, 0xa4, 0xb4, 0xdb, 0xc5, 0x1c, 0xd7, 0xeb, 0x5b, 0x10, 0x6a, 0x62, 0x79, 0xeb, 0xd0, 0x99,
+	0x92, 0x38, 0x4e, 0x6d, 0xb3, 0xa9, 0xae, 0x52, 0x30, 0x11, 0xa2, 0x49, 0xcb, 0x55, 0x3a, 0x68,
+	0xb3, 0x70, 0x1f, 0x44, 0xa1, 0x0d, 0x52, 0x1f, 0xe9, 0xee, 0x77, 0xa3, 0x50, 0xed, 0x42, 0x7a,
+	0xdf, 0x8d, 0xc2, 0x6a, 0x3d, 0x62, 0xf7, 0xd6, 0xec, 0x7a, 0xea, 0x7d, 0x4b, 0x0b, 0xb5, 0x71,
+	0x4b, 0x5a, 0x5c, 0x67, 0x01, 0xe6, 0xc4, 0xee, 0xcf, 0x46, 0x79, 0x27, 0x25, 0x93, 0x96, 0x0b,
+	0x41, 0x45, 0xa1, 0x17, 0xd0, 0x21, 0x49, 0xc6, 0xef, 0xec, 0x81, 0x34, 0x18, 0x94, 0x06, 0x7b,
+	0x82, 0x29, 0x36, 0x20, 0xa5, 0x68, 0x1d, 0xda, 0x7e, 0x4a, 0xa9, 0x3d, 0x94, 0x5a, 0x4f, 0x4b,
+	0xad, 0x71, 0x4a, 0xe9, 0x1e, 0xe3, 0xf8, 0x3c, 0x8e, 0xd8, 0x74, 0xd2, 0x72, 0xa5, 0x12, 0xda,
+	0x02, 0x60, 0x1c, 0x73, 0xe2, 0x45, 0xf4, 0x22, 0xb5, 0x17, 0xa5, 0xc9, 0x52, 0xd5, 0x26, 0x42,
+	0x72, 0x40, 0x2f, 0x44, 0x76, 0x4c, 0x56, 0x12, 0x68, 0x07, 0x86, 0xca, 0x86, 0x51, 0x9c, 0xb1,
+	0x69, 0xca, 0xed, 0x51, 0xf3, 0xd0, 0x2b, 0xbb, 0x93, 0x42, 0x61, 0xd2, 0x72, 0x07, 0xd2, 0xa4,
+	0x64, 0xa0, 0x43, 0x78, 0x52, 0xc7, 0xf5, 0xb2, 0xeb, 0x38, 0x96, 0xf9, 0x5b, 0x92, 0x8e, 0x3e,
+	0x99, 0x71, 0x74, 0x7c, 0x1d, 0xc7, 0x75, 0x22, 0x47, 0xec, 0x1e, 0x1f, 0x6d, 0x83, 0xf2, 0xef,
+	0xe5, 0x4a, 0xc9, 0x46, 0xcd, 0x82, 0x72, 0x49, 0x92, 0x72, 0x22, 0xdd, 0xd5, 0x6e, 0xfa, 0x4c,
+	0xa3, 0xd1, 0x6e, 0xb9, 0xab, 0xbc, 0x28, 0x39, 0xfb, 0x89, 0xf4, 0xf1, 0xf1, 0x83, 0x3e, 0xaa,
+	0xaa, 0x1c, 0x30, 0x9d, 0x21, 0x72, 0x13, 0x13, 0x1c, 0xa8, 0xe2, 0x95, 0x25, 0xba, 0xdc, 0xcc,
+	0xcd, 0x9b, 0x4a, 0x5a, 0x17, 0xea, 0xa0, 0x36, 0x11, 0xe5, 0xfa, 0x1d, 0x0c, 0x32, 0x42, 0x72,
+	0x2f, 0x0a, 0x08, 0xe5, 0x11, 0xbf, 0xb3, 0x9f, 0x36, 0xdb, 0xf0, 0x98, 0x90, 0xfc, 0xa0, 0x90,
+	0x89, 0x6d, 0x64, 0x1a, 0x2d, 0x9a, 0x1d, 0xfb, 0x97, 0xf6, 0x33, 0x69, 0xf2, 0xbc, 0xea, 0x5c,
+	0xff, 0x92, 0xa6, 0x3f, 0xc6, 0x24, 0x08, 0x49, 0x42, 0xa8, 0xd8, 0xbc, 0xd0, 0x42, 0x7f, 0x00,
+	0xc8, 0xf2, 0xe8, 0x46, 0x65, 0xc1, 0x7e, 0xde, 0x4c, 0xbe, 0xda, 0xef, 0xf1, 0x0d, 0x6f, 0x56,
+	0xb1, 0x66, 0x81, 0x5e, 0x6b, 0xf6, 0xcc, 0xb6, 0xa5, 0xfd, 0xcf, 0x1e, 0xb1, 0xaf, 0x32, 0xa6,
+	0x99, 0xa0, 0xd7, 0xd0, 0x2f, 0x28, 0x4f, 0x14, 0xba, 0xfd, 0x51, 0xf3, 0xd8, 0x8e, 0x95, 0xac,
+	0xd9, 0xd6, 0x56, 0x56, 0x73, 0x1d, 0x0f, 0xe6, 0x4f, 0x71, 0x88, 0x06, 0x60, 0xbe, 0x3b, 0xda,
+	0xdd, 0xfb, 0xfe, 0xe0, 0x68, 0x6f, 0x77, 0xd4, 0x42, 0x26, 0x74, 0xf6, 0x0e, 0x8f, 0x4f, 0xcf,
+	0x46, 0x06, 0xea, 0x43, 0xef, 0xad, 0xbb, 0xef, 0xbd, 0x3d, 0x7a, 0x73, 0x36, 0x9a, 0x13, 0x7a,
+	0xe3, 0xc9, 0xf6, 0x91, 0x22, 0xe7, 0xd1, 0x08, 0xfa, 0x92, 0xdc, 0x3e, 0xda, 0xf5, 0xde, 0xba,
+	0xfb, 0xa3, 0x36, 0x5a, 0x04, 0x4b, 0x29, 0xb8, 0x92, 0xd1, 0xd1, 0x91, 0xf8, 0x3f, 0x06, 0x98,
+	0x55, 0x45, 0xa2, 0x0d, 0x30, 0x79, 0x94, 0x10, 0xc6, 0x71, 0x92, 0x49, 0xc4, 0xb5, 0xb6, 0x46,
+	0xfa, 0x09, 0x9d, 0x46, 0x09, 0x71, 0x6b, 0x15, 0xf4, 0x14, 0xba, 0xd9, 0x65, 0xe4, 0x45, 0x81,
+	0x04, 0xe2, 0xbe, 0xdb, 0xc9, 0x2e, 0xa3, 0x83, 0x00, 0x7d, 0x06, 0x56, 0x81, 0xd3, 0xde, 0xe1,
+	0xf6, 0xd8, 0x6e, 0x4b, 0x19, 0x14, 0xac, 0xc3, 0xed, 0xb1, 0xe8, 0xd0, 0x2c, 0x4f, 0x33, 0x92,
+	0xf3, 0x88, 0x30, 0xbb, 0xd3, 0xc4, 0x8a, 0xe3, 0x4a, 0xe2, 0x6a, 0x5a, 0xce, 0x7f, 0x0d, 0x80,
+	0x5a, 0x84, 0x7e, 0x01, 0x03, 0x79, 0xf4, 0xb9, 0x37, 0x25, 0x51, 0x38, 0xe5, 0xc5, 0xc5, 0xd1,
+	0x57, 0xcc, 0x89, 0xe4, 0xa1, 0x9f, 0x43, 0x3f, 0x26, 0x17, 0xdc, 0xd3, 0x2f, 0x91, 0x9e, 0x6b,
+	0x09, 0xde, 0x58, 0xb1, 0xd0, 0xaf, 0x41, 0x2c, 0x2c, 0xa2, 0x7e, 0x1a, 0x10, 0x66, 0xcf, 0xaf,
+	0xce, 0xeb, 0x60, 0x31, 0x2e, 0x25, 0xae, 0xa6, 0x84, 0xbe, 0x84, 0x51, 0x89, 0x12, 0x45, 0x70,
+	0x66, 0xb7, 0x57, 0xe7, 0xd7, 0xda, 0xee, 0x62, 0xc9, 0x57, 0xf1, 0x99, 0xb3, 0x0d, 0x4b, 0x33,
+	0xc0, 0x81, 0x5e, 0x42, 0x8f, 0xc4, 0xb2, 0x66, 0x99, 0x6d, 0xac, 0xce, 0xeb, 0x49, 0xae, 0xae,
+	0xef, 0x4a, 0xc3, 0xf9, 0x06, 0x96, 0x1f, 0x82, 0x8c, 0xfb, 0x49, 0x36, 0xee, 0x27, 0xd9, 0xb9,
+	0x80, 0x41, 0x03, 0x1f, 0xb5, 0xd3, 0x32, 0xf4, 0xd3, 0x5a, 0x81, 0x5e, 0xd5, 0x95, 0xea, 0x96,
+	0xad, 0x68, 0xe4, 0xc0, 0x80, 0xc7, 0xcc, 0xf3, 0x49, 0xce, 0xbd, 0x29, 0x66, 0xd3, 0xe2, 0x9c,
+	0x2d, 0x1e, 0xb3, 0x31, 0xc9, 0xf9, 0x04, 0xb3, 0xa9, 0xf3, 0x0e, 0xfa, 0x7a, 0xf7, 0x3e, 0x16,
+	0x06, 0x41, 0x5b, 0xb8, 0x29, 0x42, 0xc8, 0x6f, 0x11, 0x3a, 0x21, 0x1c, 0xcb, 0x36, 0x51, 0x9e,
+	0x2b, 0xda, 0x49, 0xc0, 0xd2, 0x9a, 0xf4, 0xf1, 0x01, 0x21, 0x90, 0x97, 0x17, 0xb3, 0xe7, 0x56,
+	0xe7, 0xd7, 0x4c, 0xb7, 0x24, 0xd1, 0x06, 0xf4, 0x12, 0x16, 0x7a, 0xfc, 0xae, 0x98, 0x94, 0x86,
+	0xf5, 0x0d, 0x26, 0xb2, 0x78, 0xc8, 0xc2, 0xd3, 0xbb, 0x8c, 0xb8, 0x0b, 0x89, 0xfa, 0x70, 0x52,
+	0xb0, 0xb4, 0xab, 0xf3, 0x91, 0x70, 0xfa, 0x7a, 0xe7, 0x9a, 0xeb, 0xfd, 0xe0, 0x80, 0xb7, 0x00,
+	0xf5, 0xad, 0xf8, 0x48, 0xbc, 0x5f, 0x42, 0xbb, 0x88, 0xf5, 0x70, 0x95, 0xb4, 0x7f, 0x52, 0xe4,
+	0x18, 0xa0, 0xbe, 0xf5, 0xff, 0xef, 0x89, 0xfd, 0x16, 0x2c, 0x0d, 0xeb, 0xd0, 0x97, 0xcd, 0xa9,
+	0xd3, 0xda, 0x5a, 0xac, 0xac, 0x15, 0xbb, 0x1a, 0x43, 0x9d, 0xef, 0x01, 0xcd, 0x82, 0x25, 0x7a,
+	0x75, 0xdf, 0xc1, 0xb3, 0x7b, 0xc8, 0x3a, 0xe3, 0xe7, 0x0c, 0x16, 0x0a, 0x1e, 0x7a, 0x0e, 0x0b,
+	0x8c, 0x5c, 0x79, 0xf4, 0x3a, 0x29, 0xb6, 0xdb, 0x65, 0xe4, 0xea, 0xe8, 0x3a, 0x11, 0xd5, 0xa9,
+	0x9d, 0xaa, 0xfc, 0x16, 0xe8, 0xd1, 0x00, 0x72, 0x01, 0x0e, 0xfd, 0x26, 0x54, 0xff, 0x73, 0x0e,
+	0x86, 0xcd, 0xb0, 0xe8, 0x0b, 0x58, 0xac, 0x9f, 0x00, 0x1e, 0xc5, 0x89, 0xca, 0xac, 0xe9, 0x0e,
+	0x6b, 0xf6, 0x11, 0x4e, 0x88, 0x98, 0xb2, 0x85, 0x94, 0x65, 0xd8, 0x57, 0x53, 0xb6, 0xe9, 0xd6,
+	0x0c, 0xf4, 0x04, 0x3a, 0xfc, 0xb6, 0x44, 0x56, 0xd3, 0x6d, 0xf3, 0xdb, 0x83, 0x40, 0x80, 0x5e,
+	0xb9, 0xa2, 0xfc, 0x47, 0x46, 0x78, 0x01, 0xad, 0xe5, 0x32, 0x5d, 0xc1, 0x43, 0x2f, 0x01, 0x95,
+	0x4a, 0x2c, 0x4a, 0x4a, 0x78, 0xec, 0xc8, 0xed, 0x8e, 0x0a, 0xc9, 0x49, 0x94, 0x14, 0x10, 0x79,
+	0x04, 0x48, 0x5b, 0xae, 0x9f, 0xd2, 0x8b, 0x28, 0x64, 0xc5, 0xc4, 0xfb, 0xd9, 0x86, 0x7a, 0xd3,
+	0x6c, 0x8c, 0x2b, 0x8d, 0xb1, 0x54, 0x38, 0xc6, 0xfe, 0x25, 0x0e, 0x89, 0xbb, 0xe4, 0xdf, 0x13,
+	0x30, 0xe7, 0x1f, 0x06, 0xf4, 0xf5, 0x99, 0x1a, 0x6d, 0x00, 0x24, 0xd5, 0xe8, 0x5b, 0x1c, 0xd9,
+	0xb0, 0x39, 0x14, 0xbb, 0x9a, 0xc6, 0x07, 0xdf, 0x41, 0x3a, 0x7c, 0xb5, 0x9b, 0xf0, 0xe5, 0xfc,
+	0xd5, 0x80, 0xa5, 0x99, 0xe1, 0xe4, 0x31, 0x80, 0xfa, 0xd0, 0xc0, 0x2f, 0x60, 0x18, 0x31, 0x2f,
+	0x20, 0x7e, 0x8c, 0x73, 0x2c, 0x52, 0x20, 0x8f, 0xaa, 0xe7, 0x0e, 0x22, 0xb6, 0x5b, 0x33, 0x9d,
+	0xdf, 0x41, 0xaf, 0xb4, 0x16, 0xe5, 0x17, 0x51, 0x5f, 0x2f, 0xbf, 0x88, 0xfa, 0xa2, 0xfc, 0xb4,
+	0xba, 0x9c, 0xd3, 0xeb, 0xd2, 0xb9, 0x80, 0xa5, 0x99, 0xe7, 0x06, 0xfa, 0x0e, 0x46, 0x8c, 0xc4,
+	0x17, 0x72, 0xce, 0xcc, 0x13, 0x15, 0xdb, 0x58, 0x35, 0x1e, 0x84, 0x88, 0x45, 0xa1, 0x79, 0x50,
+	0x2b, 0x8a, 0x7e, 0x17, 0x73, 0x13, 0x95, 0x7d, 0xdd, 0x77, 0x15, 0xe1, 0x9c, 0x03, 0x9a, 0x7d,
+	0xa0, 0xa0, 0xcf, 0xa1, 0x23, 0xdf, 0x43, 0x8f, 0x5e, 0x53, 0x4a, 0x2c, 0x71, 0x8a, 0xe0, 0xe0,
+	0x3d, 0x38, 0x45, 0x70, 0xe0, 0xfc, 0x09, 0xba, 0x2a, 0x86, 0x38, 0x33, 0xd2, 0x78, 0x30, 0xba,
+	0x15, 0xfd, 0x5e, 0x8c, 0x7d, 0x78, 0xde, 0x70, 0x16, 0xa0, 0x23, 0xdf, 0x0b, 0xce, 0x9f, 0x01,
+	0xcd, 0x4e, 0xc5, 0xe2, 0x12, 0x63, 0x1c, 0xe7, 0xdc, 0x6b, 0xb6, 0xbe, 0x25, 0x99, 0x27, 0xaa,
+	0xff, 0x3f, 0x05, 0x8b, 0xd0, 0xc0, 0x6b, 0x1e, 0x82, 0x49, 0x68, 0xa0, 0xe4, 0xce, 0x0e, 0x3c,
+	0x79, 0x60, 0x56, 0x46, 0xeb, 0xd0, 0x2b, 0x50, 0xa6, 0xbc, 0xca, 0x67, 0xe0, 0xac, 0x52, 0x70,
+	0xf6, 0x61, 0xf9, 0xa1, 0xf9, 0x13, 0x6d, 0xd6, 0x58, 0xab, 0x7c, 0x54, 0xef, 0x9b, 0x42, 0x51,
+	0x21, 0x75, 0x05, 0xc1, 0xce, 0xbf, 0x0c, 0x18, 0x34, 0x44, 0x35, 0x5a, 0x18, 0x1a, 0x5a, 0xbc,
+	0x1f, 0x60, 0x3e, 0x05, 0xa8, 0xbb, 0xb7, 0x40, 0x19, 0x8d, 0x83, 0x3e, 0x06, 0xf3, 0x3c, 0x4e,
+	0xfd, 0x4b, 0x91, 0x13, 0xd9, 0x58, 0x6d, 0xb7, 0x27, 0x19, 0x27, 0xe4, 0x0a, 0xad, 0x42, 0x5f,
+	0xa4, 0x2a, 0xa2, 0x9e, 0x64, 0x15, 0xe8, 0x02, 0x8c, 0x5c, 0x1d, 0xd0, 0x1d, 0xc1, 0x71, 0x7e,
+	0x80, 0xa7, 0x0f, 0x0e, 0xcb, 0x68, 0x6b, 0x66, 0xfa, 0x79, 0x76, 0x6f, 0xbb, 0x7b, 0x4a, 0xac,
+	0xcd, 0x40, 0x67, 0x30, 0x6c, 0xca, 0xd0, 0x57, 0xd0, 0x55, 0xd9, 0x28, 0x0a, 0xff, 0x91, 0x94,
+	0x15, 0x4a, 0xfa, 0xbf, 0x0e, 0x55, 0xf6, 0x25, 0xe9, 0xfc, 0xb1, 0x72, 0x5d, 0x02, 0xf8, 0x0b,
+	0x58, 0xe4, 0xb7, 0x5e, 0x63, 0x7b, 0xc5, 0x6c, 0xc9, 0x6f, 0x4f, 0xaa, 0x0d, 0x36, 0x5d, 0xea,
+	0xbf, 0x4f, 0x9c, 0x2f, 0x60, 0xf1, 0xde, 0xdb, 0x44, 0x34, 0x1d, 0xc9, 0xf3, 0x34, 0x2f, 0xce,
+	0x47, 0x11, 0xce, 0x3b, 0x30, 0xab, 0x09, 0x53, 0xdc, 0x40, 0xda, 0x65, 0x21, 0xbf, 0x45, 0x8c,
+	0x1b, 0x92, 0x33, 0x71, 0x40, 0xea, 0xfc, 0x4a, 0xf2, 0x7d, 0x93, 0xd3, 0xaf, 0x7e, 0x0f, 0x96,
+	0x76, 0x13, 0xdf, 0x7f, 0x47, 0x0c, 0xc0, 0xdc, 0x79, 0xf3, 0x76, 0xfc, 0x83, 0x77, 0x78, 0xb2,
+	0x3f, 0x32, 0xc4, 0x73, 0xe1, 0x60, 0x77, 0xef, 0xe8, 0xf4, 0xe0, 0xf4, 0x4c, 0x72, 0xe6, 0xb6,
+	0xfe, 0x6d, 0x40, 0x57, 0x8d, 0x42, 0xe8, 0x5b, 0xe8, 0xab, 0xaf, 0x13, 0x9e, 0x13, 0x9c, 0xa0,
+	0x99, 0xce, 0x5e, 0x99, 0xe1, 0x38, 0xad, 0x35, 0xe3, 0x95, 0x81, 0x3e, 0x87, 0xf6, 0x71, 0x44,
+	0x43, 0xd4, 0x7c, 0xd0, 0xaf, 0x34, 0x49, 0xa7, 0x85, 0xbe, 0x01, 0x4b, 0xa6, 0xf3, 0x43, 0x03,
+	0xec, 0x7c, 0xf5, 0x97, 0xf5, 0x30, 0xe2, 0xd3, 0xeb, 0x73, 0x71, 0x47, 0x6d, 0x4e, 0xef, 0x32,
+	0x92, 0xab, 0xd1, 0x7f, 0xf3, 0x02, 0x9f, 0xe7, 0x91, 0xbf, 0x29, 0x7f, 0xbe, 0xb1, 0x4d, 0x65,
+	0x7d, 0xde, 0x95, 0xe4, 0xd7, 0xff, 0x1b, 0x00, 0x12, 0x21, 0x87, 0xdf, 0xc4, 0x13, 0x00, 0x00,
 }
@@ -21,6 +21,9 @@ It has these top-level messages:
 	KeyInfo
 	FabricOUIdentifier
 	FabricNodeOUs
+	FabricOCSPConfig
+	FabricCAConstraints
+	FabricCANameConstraints
 	MSPPrincipal
 	OrganizationUnit
 	MSPRole
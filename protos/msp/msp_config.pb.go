@@ -94,6 +94,13 @@ type FabricMSPConfig struct {
 	// FabricNodeOUs contains the configuration to distinguish clients from peers from orderers
 	// based on the OUs.
 	FabricNodeOUs *FabricNodeOUs `protobuf:"bytes,11,opt,name=FabricNodeOUs" json:"FabricNodeOUs,omitempty"`
+	// OcspConfig, if set, enables the checking of the revocation status of
+	// certificates against the OCSP responders they name, in addition to
+	// the revocation list.
+	OcspConfig *FabricOCSPConfig `protobuf:"bytes,12,opt,name=ocsp_config,json=ocspConfig" json:"ocsp_config,omitempty"`
+	// CaConstraints, if set, restricts the certification chains of the
+	// identities of this MSP beyond the constraints of the CA certificates.
+	CaConstraints *FabricCAConstraints `protobuf:"bytes,13,opt,name=ca_constraints,json=caConstraints" json:"ca_constraints,omitempty"`
 }
 
 func (m *FabricMSPConfig) Reset()                    { *m = FabricMSPConfig{} }
@@ -178,6 +185,20 @@ func (m *FabricMSPConfig) GetFabricNodeOUs() *FabricNodeOUs {
 	return nil
 }
 
+func (m *FabricMSPConfig) GetOcspConfig() *FabricOCSPConfig {
+	if m != nil {
+		return m.OcspConfig
+	}
+	return nil
+}
+
+func (m *FabricMSPConfig) GetCaConstraints() *FabricCAConstraints {
+	if m != nil {
+		return m.CaConstraints
+	}
+	return nil
+}
+
 // FabricCryptoConfig contains configuration parameters
 // for the cryptographic algorithms used by the MSP
 // this configuration refers to
@@ -220,6 +241,14 @@ type IdemixMSPConfig struct {
 	IPk []byte `protobuf:"bytes,2,opt,name=IPk,proto3" json:"IPk,omitempty"`
 	// signer may contain crypto material to configure a default signer
 	Signer *IdemixMSPSignerConfig `protobuf:"bytes,3,opt,name=signer" json:"signer,omitempty"`
+	// revocation_pk is the public key used for revocation of credentials
+	RevocationPk []byte `protobuf:"bytes,4,opt,name=revocation_pk,json=revocationPk,proto3" json:"revocation_pk,omitempty"`
+	// epoch represents the current epoch (time interval) used for revocation
+	Epoch int64 `protobuf:"varint,5,opt,name=epoch" json:"epoch,omitempty"`
+	// credential_revocation_information optionally contains the serialized CredentialRevocationInformation
+	// of the current epoch, which the revocation authority distributes through the channel config.
+	// When present, it takes precedence over the one of the default signer
+	CredentialRevocationInformation []byte `protobuf:"bytes,6,opt,name=credential_revocation_information,json=credentialRevocationInformation,proto3" json:"credential_revocation_information,omitempty"`
 }
 
 func (m *IdemixMSPConfig) Reset()                    { *m = IdemixMSPConfig{} }
@@ -248,6 +277,27 @@ func (m *IdemixMSPConfig) GetSigner() *IdemixMSPSignerConfig {
 	return nil
 }
 
+func (m *IdemixMSPConfig) GetRevocationPk() []byte {
+	if m != nil {
+		return m.RevocationPk
+	}
+	return nil
+}
+
+func (m *IdemixMSPConfig) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *IdemixMSPConfig) GetCredentialRevocationInformation() []byte {
+	if m != nil {
+		return m.CredentialRevocationInformation
+	}
+	return nil
+}
+
 // IdemixMSPSIgnerConfig contains the crypto material to set up an idemix signing identity
 type IdemixMSPSignerConfig struct {
 	// Cred represents the serialized idemix credential of the default signer
@@ -411,9 +461,9 @@ func (m *FabricOUIdentifier) GetOrganizationalUnitIdentifier() string {
 	return ""
 }
 
-// FabricNodeOUs contains configuration to tell apart clients from peers from orderers
-// based on OUs. If NodeOUs recognition is enabled then an msp identity
-// that does not contain any of the specified OU will be considered invalid.
+// FabricNodeOUs contains configuration to tell apart clients from peers from orderers,
+// and admins from the other identities, based on OUs. If NodeOUs recognition is enabled
+// then an msp identity that does not contain any of the specified OU will be considered invalid.
 type FabricNodeOUs struct {
 	// If true then an msp identity that does not contain any of the specified OU will be considered invalid.
 	Enable bool `protobuf:"varint,1,opt,name=Enable" json:"Enable,omitempty"`
@@ -421,6 +471,10 @@ type FabricNodeOUs struct {
 	ClientOUIdentifier *FabricOUIdentifier `protobuf:"bytes,2,opt,name=clientOUIdentifier" json:"clientOUIdentifier,omitempty"`
 	// OU Identifier of the peers
 	PeerOUIdentifier *FabricOUIdentifier `protobuf:"bytes,3,opt,name=peerOUIdentifier" json:"peerOUIdentifier,omitempty"`
+	// OU Identifier of the admins
+	AdminOUIdentifier *FabricOUIdentifier `protobuf:"bytes,4,opt,name=adminOUIdentifier" json:"adminOUIdentifier,omitempty"`
+	// OU Identifier of the orderers
+	OrdererOUIdentifier *FabricOUIdentifier `protobuf:"bytes,5,opt,name=ordererOUIdentifier" json:"ordererOUIdentifier,omitempty"`
 }
 
 func (m *FabricNodeOUs) Reset()                    { *m = FabricNodeOUs{} }
@@ -449,6 +503,152 @@ func (m *FabricNodeOUs) GetPeerOUIdentifier() *FabricOUIdentifier {
 	return nil
 }
 
+func (m *FabricNodeOUs) GetAdminOUIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.AdminOUIdentifier
+	}
+	return nil
+}
+
+func (m *FabricNodeOUs) GetOrdererOUIdentifier() *FabricOUIdentifier {
+	if m != nil {
+		return m.OrdererOUIdentifier
+	}
+	return nil
+}
+
+// FabricOCSPConfig contains configuration to check the revocation status of
+// certificates against the OCSP responders named in them. Revocations are then
+// picked up without updating the revocation list of the MSP.
+type FabricOCSPConfig struct {
+	// If true then an msp identity whose revocation status cannot be determined
+	// will be considered invalid. Otherwise it is considered valid.
+	HardFail bool `protobuf:"varint,1,opt,name=hard_fail,json=hardFail" json:"hard_fail,omitempty"`
+	// Maximum number of seconds an OCSP response is cached for, even if it is
+	// valid for longer. Zero selects the default.
+	CacheTtlSeconds uint32 `protobuf:"varint,2,opt,name=cache_ttl_seconds,json=cacheTtlSeconds" json:"cache_ttl_seconds,omitempty"`
+	// Maximum number of seconds an OCSP responder is waited for. Zero selects
+	// the default.
+	TimeoutSeconds uint32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds" json:"timeout_seconds,omitempty"`
+}
+
+func (m *FabricOCSPConfig) Reset()                    { *m = FabricOCSPConfig{} }
+func (m *FabricOCSPConfig) String() string            { return proto.CompactTextString(m) }
+func (*FabricOCSPConfig) ProtoMessage()               {}
+func (*FabricOCSPConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *FabricOCSPConfig) GetHardFail() bool {
+	if m != nil {
+		return m.HardFail
+	}
+	return false
+}
+
+func (m *FabricOCSPConfig) GetCacheTtlSeconds() uint32 {
+	if m != nil {
+		return m.CacheTtlSeconds
+	}
+	return 0
+}
+
+func (m *FabricOCSPConfig) GetTimeoutSeconds() uint32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+// FabricCAConstraints contains constraints on the certification chains of the
+// identities of an MSP. They are enforced in addition to the ones carried by the
+// CA certificates, so that a compromised intermediate CA cannot issue identities
+// outside of its intended namespace.
+type FabricCAConstraints struct {
+	// If true then the number of intermediate CA certificates in the
+	// certification chain of an identity cannot exceed max_path_length.
+	EnforceMaxPathLength bool `protobuf:"varint,1,opt,name=enforce_max_path_length,json=enforceMaxPathLength" json:"enforce_max_path_length,omitempty"`
+	// Maximum number of intermediate CA certificates between a root CA
+	// and an identity.
+	MaxPathLength uint32 `protobuf:"varint,2,opt,name=max_path_length,json=maxPathLength" json:"max_path_length,omitempty"`
+	// Name constraints of the CAs of the MSP.
+	NameConstraints []*FabricCANameConstraints `protobuf:"bytes,3,rep,name=name_constraints,json=nameConstraints" json:"name_constraints,omitempty"`
+}
+
+func (m *FabricCAConstraints) Reset()                    { *m = FabricCAConstraints{} }
+func (m *FabricCAConstraints) String() string            { return proto.CompactTextString(m) }
+func (*FabricCAConstraints) ProtoMessage()               {}
+func (*FabricCAConstraints) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *FabricCAConstraints) GetEnforceMaxPathLength() bool {
+	if m != nil {
+		return m.EnforceMaxPathLength
+	}
+	return false
+}
+
+func (m *FabricCAConstraints) GetMaxPathLength() uint32 {
+	if m != nil {
+		return m.MaxPathLength
+	}
+	return 0
+}
+
+func (m *FabricCAConstraints) GetNameConstraints() []*FabricCANameConstraints {
+	if m != nil {
+		return m.NameConstraints
+	}
+	return nil
+}
+
+// FabricCANameConstraints restricts the names of the certificates that a CA
+// certifies, either directly or through intermediate CAs.
+type FabricCANameConstraints struct {
+	// Certificate of a root or intermediate CA of the MSP.
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// If not empty, the DNS names and the domains of the email addresses of
+	// the certificates must be within one of these domains. A domain with a
+	// leading dot only matches its subdomains.
+	PermittedDnsDomains []string `protobuf:"bytes,2,rep,name=permitted_dns_domains,json=permittedDnsDomains" json:"permitted_dns_domains,omitempty"`
+	// The DNS names and the domains of the email addresses of the
+	// certificates cannot be within any of these domains.
+	ExcludedDnsDomains []string `protobuf:"bytes,3,rep,name=excluded_dns_domains,json=excludedDnsDomains" json:"excluded_dns_domains,omitempty"`
+	// If not empty, the organizational units of the subjects of the
+	// certificates must be among these.
+	PermittedOrganizationalUnits []string `protobuf:"bytes,4,rep,name=permitted_organizational_units,json=permittedOrganizationalUnits" json:"permitted_organizational_units,omitempty"`
+}
+
+func (m *FabricCANameConstraints) Reset()                    { *m = FabricCANameConstraints{} }
+func (m *FabricCANameConstraints) String() string            { return proto.CompactTextString(m) }
+func (*FabricCANameConstraints) ProtoMessage()               {}
+func (*FabricCANameConstraints) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *FabricCANameConstraints) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FabricCANameConstraints) GetPermittedDnsDomains() []string {
+	if m != nil {
+		return m.PermittedDnsDomains
+	}
+	return nil
+}
+
+func (m *FabricCANameConstraints) GetExcludedDnsDomains() []string {
+	if m != nil {
+		return m.ExcludedDnsDomains
+	}
+	return nil
+}
+
+func (m *FabricCANameConstraints) GetPermittedOrganizationalUnits() []string {
+	if m != nil {
+		return m.PermittedOrganizationalUnits
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MSPConfig)(nil), "msp.MSPConfig")
 	proto.RegisterType((*FabricMSPConfig)(nil), "msp.FabricMSPConfig")
@@ -459,61 +659,85 @@ func init() {
 	proto.RegisterType((*KeyInfo)(nil), "msp.KeyInfo")
 	proto.RegisterType((*FabricOUIdentifier)(nil), "msp.FabricOUIdentifier")
 	proto.RegisterType((*FabricNodeOUs)(nil), "msp.FabricNodeOUs")
+	proto.RegisterType((*FabricOCSPConfig)(nil), "msp.FabricOCSPConfig")
+	proto.RegisterType((*FabricCAConstraints)(nil), "msp.FabricCAConstraints")
+	proto.RegisterType((*FabricCANameConstraints)(nil), "msp.FabricCANameConstraints")
 }
 
 func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 811 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x6f, 0x23, 0x45,
-	0x10, 0x95, 0xed, 0xc4, 0x89, 0xcb, 0x63, 0x27, 0xf4, 0xee, 0x86, 0x01, 0xb1, 0xbb, 0xce, 0x00,
-	0xc2, 0x17, 0x1c, 0xc9, 0x8b, 0x04, 0x07, 0x2e, 0xac, 0x61, 0x61, 0x80, 0xb0, 0x51, 0x5b, 0xb9,
-	0x70, 0x19, 0xb5, 0x67, 0xda, 0xe3, 0x96, 0x67, 0xba, 0x47, 0xdd, 0xed, 0x08, 0x23, 0xfe, 0x02,
-	0x27, 0xfe, 0x07, 0x37, 0xfe, 0x1f, 0xea, 0x0f, 0xdb, 0x63, 0x62, 0x0c, 0xb7, 0xee, 0xaa, 0xf7,
-	0x5e, 0x97, 0x5f, 0x55, 0x8d, 0xe1, 0x69, 0xa9, 0xaa, 0x9b, 0x52, 0x55, 0x49, 0x2a, 0xf8, 0x9c,
-	0xe5, 0xa3, 0x4a, 0x0a, 0x2d, 0x50, 0xab, 0x54, 0x55, 0xf4, 0x39, 0x74, 0x6e, 0xa7, 0x77, 0x13,
-	0x1b, 0x47, 0x08, 0x4e, 0xf4, 0xba, 0xa2, 0x61, 0x63, 0xd0, 0x18, 0x9e, 0x62, 0x7b, 0x46, 0x57,
-	0xd0, 0x76, 0xac, 0xb0, 0x39, 0x68, 0x0c, 0x03, 0xec, 0x6f, 0xd1, 0x9f, 0x27, 0x70, 0xf1, 0x86,
-	0xcc, 0x24, 0x4b, 0xf7, 0xf8, 0x9c, 0x94, 0x8e, 0xdf, 0xc1, 0xf6, 0x8c, 0x9e, 0x03, 0x48, 0x21,
-	0x74, 0x92, 0x52, 0xa9, 0x55, 0xd8, 0x1c, 0xb4, 0x86, 0x01, 0xee, 0x98, 0xc8, 0xc4, 0x04, 0xd0,
-	0xa7, 0x80, 0x18, 0xd7, 0x54, 0x96, 0x34, 0x63, 0x44, 0x53, 0x0f, 0x6b, 0x59, 0xd8, 0x3b, 0xf5,
-	0x8c, 0x83, 0x5f, 0x41, 0x9b, 0x64, 0x25, 0xe3, 0x2a, 0x3c, 0xb1, 0x10, 0x7f, 0x43, 0x9f, 0xc0,
-	0x85, 0xa4, 0x0f, 0x22, 0x25, 0x9a, 0x09, 0x9e, 0x14, 0x4c, 0xe9, 0xf0, 0xd4, 0x02, 0xfa, 0xbb,
-	0xf0, 0x8f, 0x4c, 0x69, 0x34, 0x81, 0x4b, 0xc5, 0x72, 0xce, 0x78, 0x9e, 0xb0, 0x8c, 0x72, 0xcd,
-	0xf4, 0x3a, 0x6c, 0x0f, 0x1a, 0xc3, 0xee, 0x38, 0x1c, 0x95, 0xaa, 0x1a, 0x4d, 0x5d, 0x32, 0xf6,
-	0xb9, 0x98, 0xcf, 0x05, 0xbe, 0x50, 0xfb, 0x41, 0x94, 0xc0, 0x4b, 0x21, 0x73, 0xc2, 0xd9, 0xaf,
-	0x56, 0x98, 0x14, 0xc9, 0x8a, 0x33, 0xed, 0x05, 0xe7, 0x8c, 0x4a, 0x15, 0x9e, 0x0d, 0x5a, 0xc3,
-	0xee, 0xf8, 0x5d, 0xab, 0xe9, 0x6c, 0x7a, 0x7b, 0x1f, 0x6f, 0xf3, 0xf8, 0xf9, 0x3e, 0xff, 0x9e,
-	0x33, 0xbd, 0xcb, 0x2a, 0xf4, 0x25, 0xf4, 0x52, 0xb9, 0xae, 0xb4, 0xf0, 0x1d, 0x0b, 0xcf, 0x6d,
-	0x89, 0x75, 0xb9, 0x89, 0xcd, 0x3b, 0xe3, 0x71, 0x90, 0xd6, 0x6e, 0xe8, 0x23, 0xe8, 0xeb, 0x42,
-	0x25, 0x35, 0xdb, 0x3b, 0xd6, 0x8b, 0x40, 0x17, 0x0a, 0x6f, 0x9d, 0xff, 0x0c, 0xae, 0x0c, 0xea,
-	0x80, 0xfb, 0x60, 0xd1, 0x4f, 0x75, 0xa1, 0xe2, 0x47, 0x0d, 0xf8, 0x02, 0x7a, 0xee, 0xfd, 0x9f,
-	0x44, 0x46, 0xdf, 0xde, 0xab, 0xb0, 0x6b, 0x2b, 0x43, 0xb5, 0xca, 0x7c, 0x06, 0xef, 0x03, 0xa3,
-	0x3f, 0x1a, 0x80, 0x1e, 0x97, 0x8e, 0xc6, 0xf0, 0xcc, 0xd8, 0x4b, 0xf4, 0x4a, 0xd2, 0x64, 0x41,
-	0xd4, 0x22, 0x99, 0x93, 0x92, 0x15, 0x6b, 0x3f, 0x44, 0x4f, 0xb6, 0xc9, 0xef, 0x88, 0x5a, 0xbc,
-	0xb1, 0x29, 0x14, 0xc3, 0xf5, 0xa6, 0x79, 0x35, 0xd3, 0x3d, 0x7b, 0xc5, 0x53, 0x63, 0xaa, 0x1d,
-	0xd7, 0x0e, 0x7e, 0xb1, 0x01, 0xee, 0xec, 0xb5, 0x42, 0x1e, 0x15, 0x2d, 0xe1, 0x22, 0xce, 0x68,
-	0xc9, 0x7e, 0x39, 0x3e, 0xc5, 0x97, 0xd0, 0x8a, 0xef, 0x96, 0x7e, 0x05, 0xcc, 0x11, 0x8d, 0xa1,
-	0x6d, 0x4a, 0xa3, 0x32, 0x6c, 0x59, 0x07, 0xde, 0xb7, 0x0e, 0x6c, 0xb5, 0xa6, 0x36, 0xe7, 0xdb,
-	0xe3, 0x91, 0xd1, 0xef, 0x4d, 0x78, 0x76, 0x10, 0x61, 0xde, 0x9c, 0x48, 0x9a, 0xd9, 0x37, 0x03,
-	0x6c, 0xcf, 0xa8, 0x0f, 0xcd, 0xe9, 0xe6, 0xc9, 0xe6, 0x74, 0x89, 0xbe, 0x86, 0x17, 0xc7, 0xa7,
-	0xce, 0x56, 0xd2, 0xc1, 0x1f, 0x1c, 0x9b, 0x2d, 0xf4, 0x1e, 0x9c, 0x33, 0x95, 0xd8, 0xb5, 0x09,
-	0x4f, 0x06, 0x8d, 0xe1, 0x39, 0x3e, 0x63, 0xea, 0x2b, 0x73, 0x45, 0x1f, 0x42, 0x8f, 0x72, 0x29,
-	0x8a, 0xa2, 0xa4, 0xdc, 0xe8, 0x86, 0xa7, 0x56, 0x2f, 0xd8, 0x05, 0xe3, 0x0c, 0x7d, 0x0f, 0xd7,
-	0xa9, 0xa4, 0x56, 0x8f, 0x14, 0x49, 0x6d, 0xe9, 0x18, 0x9f, 0x0b, 0x59, 0xda, 0xb3, 0xdd, 0xa8,
-	0x00, 0xbf, 0xdc, 0x01, 0xf1, 0x16, 0x17, 0xef, 0x60, 0x91, 0x80, 0x27, 0x07, 0xf6, 0xcd, 0xd4,
-	0x51, 0xad, 0x66, 0x05, 0x4b, 0x13, 0xef, 0xb0, 0x73, 0x25, 0x70, 0x41, 0xe7, 0x1b, 0x7a, 0x05,
-	0xfd, 0x4a, 0xb2, 0x07, 0x33, 0xb5, 0x1e, 0xd5, 0xb4, 0x7d, 0x08, 0x6c, 0x1f, 0x7e, 0xa0, 0x6e,
-	0x75, 0x7b, 0x1e, 0xe3, 0x48, 0xd1, 0x14, 0xce, 0x7c, 0x06, 0x7d, 0x0c, 0xfd, 0x25, 0xad, 0x8f,
-	0x8f, 0xef, 0x77, 0x6f, 0x49, 0x6b, 0xb3, 0x82, 0xae, 0x21, 0x30, 0xb0, 0x92, 0x68, 0x2a, 0x19,
-	0x29, 0x7c, 0x3b, 0xba, 0x4b, 0xba, 0xbe, 0xf5, 0xa1, 0xe8, 0xb7, 0xcd, 0x5c, 0xd7, 0x37, 0x1c,
-	0x0d, 0xa0, 0x6b, 0xb6, 0x89, 0xcd, 0x59, 0x4a, 0x34, 0xf5, 0x3f, 0xa1, 0x1e, 0xfa, 0x1f, 0xfd,
-	0x6c, 0xfe, 0x77, 0x3f, 0xa3, 0xbf, 0x1a, 0xff, 0xd8, 0x48, 0xf3, 0x8d, 0xfc, 0x86, 0x93, 0x59,
-	0xe1, 0x1e, 0x3d, 0xc7, 0xfe, 0x86, 0xbe, 0x05, 0x94, 0x16, 0x8c, 0x72, 0x5d, 0xaf, 0xd3, 0xbb,
-	0xf6, 0xaf, 0x1f, 0xaa, 0x03, 0x14, 0xf3, 0x0d, 0xad, 0x28, 0x95, 0x7b, 0x32, 0xad, 0xe3, 0x32,
-	0x8f, 0x08, 0xaf, 0x13, 0xb8, 0x16, 0x32, 0x1f, 0x2d, 0xd6, 0x15, 0x95, 0x05, 0xcd, 0x72, 0x2a,
-	0x47, 0x73, 0xcb, 0x73, 0xff, 0x4e, 0xca, 0x28, 0xbd, 0xbe, 0xbc, 0x55, 0x95, 0xdb, 0x90, 0x3b,
-	0x92, 0x2e, 0x49, 0x4e, 0x7f, 0x1e, 0xe6, 0x4c, 0x2f, 0x56, 0xb3, 0x51, 0x2a, 0xca, 0x9b, 0x1a,
-	0xf7, 0xc6, 0x71, 0x6f, 0x1c, 0xd7, 0xfc, 0xd7, 0xcd, 0xda, 0xf6, 0xfc, 0xea, 0xef, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x84, 0x84, 0x11, 0x5d, 0xfd, 0x06, 0x00, 0x00,
+	// 1137 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcb, 0x6e, 0xe3, 0x36,
+	0x17, 0x86, 0xed, 0x38, 0x13, 0x1f, 0xcb, 0x76, 0xc2, 0xdc, 0xf4, 0xff, 0xcd, 0x64, 0x1c, 0xf7,
+	0x66, 0x14, 0xa8, 0x53, 0x64, 0x7a, 0x5b, 0x14, 0x28, 0x66, 0x9c, 0xc9, 0xd4, 0xed, 0x64, 0x12,
+	0xd0, 0xcd, 0xa6, 0x1b, 0x81, 0x91, 0x68, 0x8b, 0xb0, 0x44, 0x09, 0x24, 0x3d, 0x88, 0x8b, 0x2e,
+	0xbb, 0xed, 0xaa, 0x0f, 0xd1, 0xa7, 0xe8, 0xba, 0x8f, 0xd2, 0x7d, 0x9f, 0xa0, 0x20, 0x45, 0xdb,
+	0xf2, 0xa5, 0xee, 0x2c, 0xba, 0x23, 0xcf, 0xf7, 0x9d, 0x23, 0xf2, 0x3b, 0x17, 0x0a, 0x0e, 0x62,
+	0x99, 0x9e, 0xc7, 0x32, 0xf5, 0xfc, 0x84, 0x0f, 0xd8, 0xb0, 0x93, 0x8a, 0x44, 0x25, 0xa8, 0x14,
+	0xcb, 0xb4, 0xf5, 0x05, 0x54, 0xae, 0xfb, 0xb7, 0x5d, 0x63, 0x47, 0x08, 0xb6, 0xd4, 0x24, 0xa5,
+	0x6e, 0xa1, 0x59, 0x68, 0x97, 0xb1, 0x59, 0xa3, 0x23, 0xd8, 0xce, 0xbc, 0xdc, 0x62, 0xb3, 0xd0,
+	0x76, 0xb0, 0xdd, 0xb5, 0x7e, 0x2b, 0x43, 0xe3, 0x8a, 0xdc, 0x0b, 0xe6, 0x2f, 0xf8, 0x73, 0x12,
+	0x67, 0xfe, 0x15, 0x6c, 0xd6, 0xe8, 0x31, 0x80, 0x48, 0x12, 0xe5, 0xf9, 0x54, 0x28, 0xe9, 0x16,
+	0x9b, 0xa5, 0xb6, 0x83, 0x2b, 0xda, 0xd2, 0xd5, 0x06, 0xf4, 0x31, 0x20, 0xc6, 0x15, 0x15, 0x31,
+	0x0d, 0x18, 0x51, 0xd4, 0xd2, 0x4a, 0x86, 0xb6, 0x97, 0x47, 0x32, 0xfa, 0x11, 0x6c, 0x93, 0x20,
+	0x66, 0x5c, 0xba, 0x5b, 0x86, 0x62, 0x77, 0xe8, 0x43, 0x68, 0x08, 0xfa, 0x26, 0xf1, 0x89, 0x62,
+	0x09, 0xf7, 0x22, 0x26, 0x95, 0x5b, 0x36, 0x84, 0xfa, 0xdc, 0xfc, 0x8a, 0x49, 0x85, 0xba, 0xb0,
+	0x2b, 0xd9, 0x90, 0x33, 0x3e, 0xf4, 0x58, 0x40, 0xb9, 0x62, 0x6a, 0xe2, 0x6e, 0x37, 0x0b, 0xed,
+	0xea, 0x85, 0xdb, 0x89, 0x65, 0xda, 0xe9, 0x67, 0x60, 0xcf, 0x62, 0x3d, 0x3e, 0x48, 0x70, 0x43,
+	0x2e, 0x1a, 0x91, 0x07, 0x4f, 0x12, 0x31, 0x24, 0x9c, 0xfd, 0x68, 0x02, 0x93, 0xc8, 0x1b, 0x73,
+	0xa6, 0x6c, 0xc0, 0x01, 0xa3, 0x42, 0xba, 0x8f, 0x9a, 0xa5, 0x76, 0xf5, 0xe2, 0xd8, 0xc4, 0xcc,
+	0x64, 0xba, 0xb9, 0xeb, 0xcd, 0x70, 0xfc, 0x78, 0xd1, 0xff, 0x8e, 0x33, 0x35, 0x47, 0x25, 0xfa,
+	0x0a, 0x6a, 0xbe, 0x98, 0xa4, 0x2a, 0xb1, 0x19, 0x73, 0x77, 0x9a, 0x85, 0xa5, 0x70, 0x5d, 0x83,
+	0x67, 0xc2, 0x63, 0xc7, 0xcf, 0xed, 0xd0, 0x7b, 0x50, 0x57, 0x91, 0xf4, 0x72, 0xb2, 0x57, 0x8c,
+	0x16, 0x8e, 0x8a, 0x24, 0x9e, 0x29, 0xff, 0x29, 0x1c, 0x69, 0xd6, 0x1a, 0xf5, 0xc1, 0xb0, 0x0f,
+	0x54, 0x24, 0x7b, 0x2b, 0x09, 0xf8, 0x12, 0x6a, 0xd9, 0xf7, 0x5f, 0x27, 0x01, 0xbd, 0xb9, 0x93,
+	0x6e, 0xd5, 0x9c, 0x0c, 0xe5, 0x4e, 0x66, 0x11, 0xbc, 0x48, 0x44, 0x9f, 0x43, 0x35, 0xf1, 0x67,
+	0x35, 0xe8, 0x3a, 0xc6, 0xef, 0x30, 0x2f, 0x50, 0x77, 0x5a, 0x48, 0x18, 0x34, 0xd3, 0xde, 0xe6,
+	0x6b, 0xa8, 0xfb, 0x44, 0x7b, 0x49, 0x25, 0x08, 0xe3, 0x4a, 0xba, 0xb5, 0x5c, 0xbe, 0xac, 0x18,
+	0xcf, 0xba, 0x73, 0x1c, 0xd7, 0x7c, 0x92, 0xdb, 0xb6, 0x7e, 0x2d, 0x00, 0x5a, 0xd5, 0x0c, 0x5d,
+	0xc0, 0xa1, 0xce, 0x2b, 0x51, 0x63, 0x41, 0xbd, 0x90, 0xc8, 0xd0, 0x1b, 0x90, 0x98, 0x45, 0x13,
+	0x5b, 0xbd, 0xfb, 0x33, 0xf0, 0x1b, 0x22, 0xc3, 0x2b, 0x03, 0xa1, 0x1e, 0x9c, 0x4d, 0xab, 0x26,
+	0x97, 0x6d, 0xeb, 0x3d, 0xe6, 0xbe, 0xce, 0xa6, 0xe9, 0x93, 0x0a, 0x3e, 0x9d, 0x12, 0xe7, 0x79,
+	0x35, 0x81, 0x2c, 0xab, 0xf5, 0x57, 0x01, 0x1a, 0xbd, 0x80, 0xc6, 0xec, 0x61, 0x73, 0xff, 0xec,
+	0x42, 0xa9, 0x77, 0x3b, 0xb2, 0xcd, 0xa7, 0x97, 0xe8, 0x02, 0xb6, 0xf5, 0xd9, 0xa8, 0x70, 0x4b,
+	0x46, 0x88, 0xff, 0x1b, 0x21, 0x66, 0xb1, 0xfa, 0x06, 0xb3, 0x42, 0x5a, 0x26, 0x7a, 0x17, 0x6a,
+	0xb9, 0xfe, 0x48, 0x47, 0xee, 0x96, 0x89, 0xe7, 0xcc, 0x8d, 0xb7, 0x23, 0x74, 0x00, 0x65, 0x9a,
+	0x26, 0x7e, 0xe8, 0x96, 0x9b, 0x85, 0x76, 0x09, 0x67, 0x1b, 0xf4, 0x2d, 0x9c, 0xf9, 0x82, 0x9a,
+	0x4b, 0x90, 0xc8, 0xcb, 0x45, 0x61, 0x7c, 0x90, 0x88, 0xd8, 0xac, 0x4d, 0x0b, 0x39, 0xf8, 0xc9,
+	0x9c, 0x88, 0x67, 0xbc, 0xde, 0x9c, 0xd6, 0xfa, 0xa5, 0x08, 0x87, 0x6b, 0x0f, 0xaa, 0xaf, 0xde,
+	0x15, 0x34, 0x30, 0x57, 0x77, 0xb0, 0x59, 0xa3, 0x3a, 0x14, 0xfb, 0xd3, 0x9b, 0x17, 0xfb, 0x23,
+	0x74, 0x09, 0xa7, 0x9b, 0xdb, 0xce, 0x08, 0x52, 0xc1, 0x27, 0x9b, 0x9a, 0x0b, 0xfd, 0x0f, 0x76,
+	0x98, 0xf4, 0xcc, 0xdc, 0x30, 0x2a, 0xec, 0xe0, 0x47, 0x4c, 0x3e, 0xd3, 0x5b, 0xad, 0x12, 0xe5,
+	0x22, 0x89, 0xa2, 0x98, 0x72, 0x1d, 0xd7, 0x08, 0x51, 0xc1, 0xce, 0xdc, 0xd8, 0x0b, 0xfe, 0x53,
+	0x3d, 0x12, 0xd8, 0x5f, 0x33, 0x70, 0xf4, 0x39, 0xd2, 0xf1, 0x7d, 0xc4, 0x7c, 0xcf, 0x26, 0x3a,
+	0x53, 0xc5, 0xc9, 0x8c, 0x99, 0x6e, 0xe8, 0x29, 0xd4, 0x53, 0xc1, 0xde, 0xe8, 0xb6, 0xb5, 0xac,
+	0xa2, 0x29, 0x07, 0xc7, 0x94, 0xc3, 0x77, 0x34, 0x9b, 0x5d, 0x35, 0xcb, 0xc9, 0x9c, 0x5a, 0x7d,
+	0x78, 0x64, 0x11, 0xf4, 0x3e, 0xd4, 0x47, 0x34, 0x5f, 0xc6, 0xb6, 0xec, 0x6a, 0x23, 0x9a, 0xab,
+	0x59, 0x74, 0x06, 0x8e, 0xa6, 0xc5, 0x44, 0x51, 0xc1, 0x48, 0x64, 0xd3, 0x51, 0x1d, 0xd1, 0xc9,
+	0xb5, 0x35, 0xb5, 0x7e, 0x02, 0xb4, 0x3a, 0xe2, 0x50, 0x13, 0xaa, 0x7a, 0x9c, 0xb0, 0x01, 0xf3,
+	0x89, 0xa2, 0xf6, 0x0a, 0x79, 0xd3, 0x5b, 0xe4, 0xb3, 0xf8, 0xef, 0xf9, 0x6c, 0xfd, 0x51, 0x5c,
+	0x1a, 0x49, 0xfa, 0x91, 0x78, 0xc1, 0xc9, 0x7d, 0x94, 0x7d, 0x74, 0x07, 0xdb, 0x1d, 0x7a, 0x09,
+	0xc8, 0x8f, 0x18, 0xe5, 0x2a, 0x7f, 0x4e, 0xb7, 0xb8, 0x32, 0x5a, 0xf3, 0x30, 0x5e, 0xe3, 0xa2,
+	0x1f, 0x91, 0x94, 0x52, 0xb1, 0x10, 0xa6, 0xb4, 0x39, 0xcc, 0x8a, 0x03, 0x7a, 0x01, 0x7b, 0xa6,
+	0x08, 0x17, 0xa2, 0x6c, 0x6d, 0x8e, 0xb2, 0xea, 0x81, 0x7a, 0xb0, 0x9f, 0x88, 0x80, 0x8a, 0xa5,
+	0xe3, 0x94, 0x37, 0x07, 0x5a, 0xe7, 0xd3, 0xfa, 0xb9, 0x00, 0xbb, 0xcb, 0xa3, 0x18, 0xbd, 0x03,
+	0x95, 0x90, 0x88, 0xc0, 0x1b, 0x10, 0x16, 0x59, 0x3d, 0x77, 0xb4, 0xe1, 0x8a, 0xb0, 0x08, 0x7d,
+	0x04, 0x7b, 0x3e, 0xf1, 0x43, 0xea, 0x29, 0x15, 0x79, 0x92, 0xfa, 0x09, 0x0f, 0xa4, 0x11, 0xb4,
+	0x86, 0x1b, 0x06, 0xf8, 0x5e, 0x45, 0xfd, 0xcc, 0xac, 0x9f, 0x68, 0xc5, 0x62, 0x9a, 0x8c, 0xd5,
+	0x8c, 0x59, 0x32, 0xcc, 0xba, 0x35, 0x5b, 0x62, 0xeb, 0xf7, 0x02, 0xec, 0xaf, 0x19, 0xeb, 0xe8,
+	0x33, 0x38, 0xa6, 0xba, 0x77, 0x7c, 0xea, 0xc5, 0xe4, 0xc1, 0x4b, 0x89, 0x0a, 0xbd, 0x88, 0xf2,
+	0xa1, 0x0a, 0xed, 0xb9, 0x0e, 0x2c, 0x7c, 0x4d, 0x1e, 0x6e, 0x89, 0x0a, 0x5f, 0x19, 0x0c, 0x7d,
+	0x00, 0x8d, 0x65, 0x7a, 0x76, 0xc2, 0x5a, 0xbc, 0xc0, 0x7b, 0x09, 0xbb, 0x7a, 0xe0, 0x2e, 0xbc,
+	0x34, 0x25, 0xf3, 0x8a, 0x9f, 0x2c, 0xbc, 0x34, 0xaf, 0x49, 0x4c, 0xf3, 0xaf, 0x4d, 0x83, 0x2f,
+	0x1a, 0x5a, 0x7f, 0x16, 0xe0, 0xf8, 0x1f, 0xc8, 0x6f, 0xd1, 0x14, 0x17, 0x70, 0x98, 0x52, 0x11,
+	0x33, 0xa5, 0x68, 0xe0, 0x05, 0x5c, 0x7a, 0x41, 0x12, 0x13, 0xc6, 0xb3, 0x5f, 0xa7, 0x0a, 0xde,
+	0x9f, 0x81, 0x97, 0x5c, 0x5e, 0x66, 0x10, 0xfa, 0x04, 0x0e, 0xe8, 0x83, 0x1f, 0x8d, 0x83, 0x25,
+	0x97, 0x92, 0x71, 0x41, 0x53, 0x2c, 0xe7, 0x71, 0x09, 0xa7, 0xf3, 0xaf, 0xac, 0x69, 0xc2, 0xec,
+	0xff, 0xaa, 0x82, 0x4f, 0x66, 0xac, 0x9b, 0x95, 0x1e, 0x94, 0xcf, 0x3d, 0x38, 0x4b, 0xc4, 0xb0,
+	0x13, 0x4e, 0x52, 0x2a, 0x22, 0x1a, 0x0c, 0xa9, 0xe8, 0x0c, 0xcc, 0xc5, 0xb3, 0x3f, 0x4c, 0xa9,
+	0x75, 0x7b, 0xbe, 0x7b, 0x3d, 0x7d, 0xca, 0x6f, 0x89, 0x3f, 0x22, 0x43, 0xfa, 0x43, 0x7b, 0xc8,
+	0x54, 0x38, 0xbe, 0xef, 0xf8, 0x49, 0x7c, 0x9e, 0xf3, 0x3d, 0xcf, 0x7c, 0xcf, 0x33, 0x5f, 0xfd,
+	0xbf, 0x7a, 0xbf, 0x6d, 0xd6, 0x4f, 0xff, 0x1e, 0x00, 0x3e, 0x53, 0x92, 0xcc, 0xc1, 0x0a, 0x00,
+	0x00,
 }
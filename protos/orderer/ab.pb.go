@@ -16,7 +16,16 @@ It has these top-level messages:
 	SeekSpecified
 	SeekPosition
 	SeekInfo
+	DeliverCheckpoint
 	DeliverResponse
+	BlockStreamOptions
+	JoinChannelRequest
+	RemoveChannelRequest
+	ListChannelsRequest
+	ChannelInfo
+	ChannelList
+	ReloadTLSCredentialsRequest
+	TLSCredentials
 	ConsensusType
 	BatchSize
 	BatchTimeout
@@ -34,6 +43,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import common "github.com/hyperledger/fabric/protos/common"
+import google_protobuf "github.com/golang/protobuf/ptypes/empty"
 
 import (
 	context "golang.org/x/net/context"
@@ -289,9 +299,11 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
 type SeekInfo struct {
-	Start    *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
-	Stop     *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
-	Behavior SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	Start         *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	Stop          *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
+	Behavior      SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	StreamOptions *BlockStreamOptions   `protobuf:"bytes,4,opt,name=stream_options,json=streamOptions" json:"stream_options,omitempty"`
+	Checkpoint    []byte                `protobuf:"bytes,5,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 }
 
 func (m *SeekInfo) Reset()                    { *m = SeekInfo{} }
@@ -320,17 +332,68 @@ func (m *SeekInfo) GetBehavior() SeekInfo_SeekBehavior {
 	return SeekInfo_BLOCK_UNTIL_READY
 }
 
+func (m *SeekInfo) GetStreamOptions() *BlockStreamOptions {
+	if m != nil {
+		return m.StreamOptions
+	}
+	return nil
+}
+
+func (m *SeekInfo) GetCheckpoint() []byte {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// DeliverCheckpoint marks the position right after a delivered transaction. Peers issue checkpoints
+// marshaled into opaque tokens along the transactions they deliver, such that consumers can resume the
+// deliver right after the last transaction they processed. The transactions of the block of the checkpoint
+// up to the transaction of the checkpoint are omitted from the block, by leaving their data empty
+type DeliverCheckpoint struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TxIndex     uint32 `protobuf:"varint,2,opt,name=tx_index,json=txIndex" json:"tx_index,omitempty"`
+	TxId        string `protobuf:"bytes,3,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+}
+
+func (m *DeliverCheckpoint) Reset()                    { *m = DeliverCheckpoint{} }
+func (m *DeliverCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*DeliverCheckpoint) ProtoMessage()               {}
+func (*DeliverCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DeliverCheckpoint) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *DeliverCheckpoint) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *DeliverCheckpoint) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
+	//	*DeliverResponse_BlockBatch
 	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()               {}
-func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type isDeliverResponse_Type interface{ isDeliverResponse_Type() }
 
@@ -340,9 +403,13 @@ type DeliverResponse_Status struct {
 type DeliverResponse_Block struct {
 	Block *common.Block `protobuf:"bytes,2,opt,name=block,oneof"`
 }
+type DeliverResponse_BlockBatch struct {
+	BlockBatch *common.BlockBatch `protobuf:"bytes,3,opt,name=block_batch,json=blockBatch,oneof"`
+}
 
-func (*DeliverResponse_Status) isDeliverResponse_Type() {}
-func (*DeliverResponse_Block) isDeliverResponse_Type()  {}
+func (*DeliverResponse_Status) isDeliverResponse_Type()     {}
+func (*DeliverResponse_Block) isDeliverResponse_Type()      {}
+func (*DeliverResponse_BlockBatch) isDeliverResponse_Type() {}
 
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
@@ -365,11 +432,19 @@ func (m *DeliverResponse) GetBlock() *common.Block {
 	return nil
 }
 
+func (m *DeliverResponse) GetBlockBatch() *common.BlockBatch {
+	if x, ok := m.GetType().(*DeliverResponse_BlockBatch); ok {
+		return x.BlockBatch
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
+		(*DeliverResponse_BlockBatch)(nil),
 	}
 }
 
@@ -385,6 +460,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Block); err != nil {
 			return err
 		}
+	case *DeliverResponse_BlockBatch:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockBatch); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -410,6 +490,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_Block{msg}
 		return true, err
+	case 3: // Type.block_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common.BlockBatch)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_BlockBatch{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -427,6 +515,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_BlockBatch:
+		s := proto.Size(x.BlockBatch)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -434,6 +527,167 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// BlockStreamOptions requests the delivered blocks to be batched and compressed,
+// servers that don't support batching deliver the blocks one by one, uncompressed
+type BlockStreamOptions struct {
+	Compression    common.Compression `protobuf:"varint,1,opt,name=compression,enum=common.Compression" json:"compression,omitempty"`
+	MaxBatchBlocks uint32             `protobuf:"varint,2,opt,name=max_batch_blocks,json=maxBatchBlocks" json:"max_batch_blocks,omitempty"`
+	MaxBatchBytes  uint32             `protobuf:"varint,3,opt,name=max_batch_bytes,json=maxBatchBytes" json:"max_batch_bytes,omitempty"`
+}
+
+func (m *BlockStreamOptions) Reset()                    { *m = BlockStreamOptions{} }
+func (m *BlockStreamOptions) String() string            { return proto.CompactTextString(m) }
+func (*BlockStreamOptions) ProtoMessage()               {}
+func (*BlockStreamOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *BlockStreamOptions) GetCompression() common.Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return common.Compression_UNCOMPRESSED
+}
+
+func (m *BlockStreamOptions) GetMaxBatchBlocks() uint32 {
+	if m != nil {
+		return m.MaxBatchBlocks
+	}
+	return 0
+}
+
+func (m *BlockStreamOptions) GetMaxBatchBytes() uint32 {
+	if m != nil {
+		return m.MaxBatchBytes
+	}
+	return 0
+}
+
+// JoinChannelRequest asks the orderer to join the channel the config block belongs to
+type JoinChannelRequest struct {
+	ConfigBlock *common.Block `protobuf:"bytes,1,opt,name=config_block,json=configBlock" json:"config_block,omitempty"`
+}
+
+func (m *JoinChannelRequest) Reset()                    { *m = JoinChannelRequest{} }
+func (m *JoinChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*JoinChannelRequest) ProtoMessage()               {}
+func (*JoinChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *JoinChannelRequest) GetConfigBlock() *common.Block {
+	if m != nil {
+		return m.ConfigBlock
+	}
+	return nil
+}
+
+// RemoveChannelRequest asks the orderer to stop servicing a channel and to remove its ledger
+type RemoveChannelRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *RemoveChannelRequest) Reset()                    { *m = RemoveChannelRequest{} }
+func (m *RemoveChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveChannelRequest) ProtoMessage()               {}
+func (*RemoveChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RemoveChannelRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// ListChannelsRequest asks the orderer for the channels it participates in
+type ListChannelsRequest struct {
+}
+
+func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
+func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListChannelsRequest) ProtoMessage()               {}
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+// ChannelInfo describes a channel the orderer participates in
+type ChannelInfo struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *ChannelInfo) Reset()                    { *m = ChannelInfo{} }
+func (m *ChannelInfo) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInfo) ProtoMessage()               {}
+func (*ChannelInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ChannelInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChannelInfo) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// ChannelList contains the channels the orderer participates in
+type ChannelList struct {
+	Channels      []*ChannelInfo `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+	SystemChannel string         `protobuf:"bytes,2,opt,name=system_channel,json=systemChannel" json:"system_channel,omitempty"`
+}
+
+func (m *ChannelList) Reset()                    { *m = ChannelList{} }
+func (m *ChannelList) String() string            { return proto.CompactTextString(m) }
+func (*ChannelList) ProtoMessage()               {}
+func (*ChannelList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *ChannelList) GetChannels() []*ChannelInfo {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *ChannelList) GetSystemChannel() string {
+	if m != nil {
+		return m.SystemChannel
+	}
+	return ""
+}
+
+// ReloadTLSCredentialsRequest asks the orderer to reload its TLS credentials from their files
+type ReloadTLSCredentialsRequest struct {
+}
+
+func (m *ReloadTLSCredentialsRequest) Reset()                    { *m = ReloadTLSCredentialsRequest{} }
+func (m *ReloadTLSCredentialsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReloadTLSCredentialsRequest) ProtoMessage()               {}
+func (*ReloadTLSCredentialsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+// TLSCredentials describes the TLS credentials the orderer uses
+type TLSCredentials struct {
+	Certificate   []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	ClientRootCas uint32 `protobuf:"varint,2,opt,name=client_root_cas,json=clientRootCas" json:"client_root_cas,omitempty"`
+}
+
+func (m *TLSCredentials) Reset()                    { *m = TLSCredentials{} }
+func (m *TLSCredentials) String() string            { return proto.CompactTextString(m) }
+func (*TLSCredentials) ProtoMessage()               {}
+func (*TLSCredentials) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *TLSCredentials) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *TLSCredentials) GetClientRootCas() uint32 {
+	if m != nil {
+		return m.ClientRootCas
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BroadcastResponse)(nil), "orderer.BroadcastResponse")
 	proto.RegisterType((*SeekNewest)(nil), "orderer.SeekNewest")
@@ -441,7 +695,16 @@ func init() {
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*DeliverCheckpoint)(nil), "orderer.DeliverCheckpoint")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
+	proto.RegisterType((*BlockStreamOptions)(nil), "orderer.BlockStreamOptions")
+	proto.RegisterType((*JoinChannelRequest)(nil), "orderer.JoinChannelRequest")
+	proto.RegisterType((*RemoveChannelRequest)(nil), "orderer.RemoveChannelRequest")
+	proto.RegisterType((*ListChannelsRequest)(nil), "orderer.ListChannelsRequest")
+	proto.RegisterType((*ChannelInfo)(nil), "orderer.ChannelInfo")
+	proto.RegisterType((*ChannelList)(nil), "orderer.ChannelList")
+	proto.RegisterType((*ReloadTLSCredentialsRequest)(nil), "orderer.ReloadTLSCredentialsRequest")
+	proto.RegisterType((*TLSCredentials)(nil), "orderer.TLSCredentials")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 }
 
@@ -618,40 +881,272 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
+// Client API for ChannelParticipation service
+
+type ChannelParticipationClient interface {
+	// Join requires a JoinChannelRequest and returns the channel joined
+	Join(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelInfo, error)
+	// Remove requires a RemoveChannelRequest
+	Remove(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// List requires a ListChannelsRequest and returns the channels the orderer participates in
+	List(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelList, error)
+}
+
+type channelParticipationClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChannelParticipationClient(cc *grpc.ClientConn) ChannelParticipationClient {
+	return &channelParticipationClient{cc}
+}
+
+func (c *channelParticipationClient) Join(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelInfo, error) {
+	out := new(ChannelInfo)
+	err := grpc.Invoke(ctx, "/orderer.ChannelParticipation/Join", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelParticipationClient) Remove(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/orderer.ChannelParticipation/Remove", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *channelParticipationClient) List(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelList, error) {
+	out := new(ChannelList)
+	err := grpc.Invoke(ctx, "/orderer.ChannelParticipation/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ChannelParticipation service
+
+type ChannelParticipationServer interface {
+	// Join requires a JoinChannelRequest and returns the channel joined
+	Join(context.Context, *common.Envelope) (*ChannelInfo, error)
+	// Remove requires a RemoveChannelRequest
+	Remove(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	// List requires a ListChannelsRequest and returns the channels the orderer participates in
+	List(context.Context, *common.Envelope) (*ChannelList, error)
+}
+
+func RegisterChannelParticipationServer(s *grpc.Server, srv ChannelParticipationServer) {
+	s.RegisterService(&_ChannelParticipation_serviceDesc, srv)
+}
+
+func _ChannelParticipation_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelParticipationServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.ChannelParticipation/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelParticipationServer).Join(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelParticipation_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelParticipationServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.ChannelParticipation/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelParticipationServer).Remove(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChannelParticipation_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelParticipationServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.ChannelParticipation/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelParticipationServer).List(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ChannelParticipation_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "orderer.ChannelParticipation",
+	HandlerType: (*ChannelParticipationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _ChannelParticipation_Join_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _ChannelParticipation_Remove_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ChannelParticipation_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "orderer/ab.proto",
+}
+
+// Client API for Admin service
+
+type AdminClient interface {
+	// ReloadTLSCredentials requires a ReloadTLSCredentialsRequest and returns the TLS credentials reloaded
+	ReloadTLSCredentials(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TLSCredentials, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ReloadTLSCredentials(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TLSCredentials, error) {
+	out := new(TLSCredentials)
+	err := grpc.Invoke(ctx, "/orderer.Admin/ReloadTLSCredentials", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Admin service
+
+type AdminServer interface {
+	// ReloadTLSCredentials requires a ReloadTLSCredentialsRequest and returns the TLS credentials reloaded
+	ReloadTLSCredentials(context.Context, *common.Envelope) (*TLSCredentials, error)
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_ReloadTLSCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReloadTLSCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderer.Admin/ReloadTLSCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReloadTLSCredentials(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "orderer.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReloadTLSCredentials",
+			Handler:    _Admin_ReloadTLSCredentials_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "orderer/ab.proto",
+}
+
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+	// 987 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdb, 0x6e, 0xe3, 0x36,
+	0x10, 0x95, 0xb2, 0x8e, 0x37, 0x19, 0x5f, 0x92, 0x30, 0x97, 0xba, 0x09, 0x76, 0x91, 0x0a, 0xc8,
+	0xd6, 0x45, 0x5b, 0x3b, 0x70, 0xbb, 0x05, 0x7a, 0x01, 0x8a, 0xd8, 0x9b, 0x20, 0x6e, 0x8d, 0x64,
+	0xc1, 0x64, 0x1f, 0xba, 0x2f, 0x02, 0x25, 0xd1, 0x36, 0x11, 0x4b, 0x54, 0x45, 0x3a, 0xb5, 0xbf,
+	0xa2, 0x5f, 0xd0, 0x87, 0xbe, 0xf7, 0xa1, 0x9f, 0xd0, 0x4f, 0x2b, 0x78, 0x91, 0x6c, 0xa7, 0xc6,
+	0xa2, 0x4f, 0xd2, 0x9c, 0x39, 0x33, 0x67, 0xc8, 0x19, 0x92, 0xb0, 0xcb, 0xb3, 0x88, 0x66, 0x34,
+	0x6b, 0x93, 0xa0, 0x95, 0x66, 0x5c, 0x72, 0xf4, 0xdc, 0x22, 0xc7, 0xfb, 0x21, 0x8f, 0x63, 0x9e,
+	0xb4, 0xcd, 0xc7, 0x78, 0x8f, 0x4f, 0x46, 0x9c, 0x8f, 0x26, 0xb4, 0xad, 0xad, 0x60, 0x3a, 0x6c,
+	0xd3, 0x38, 0x95, 0x73, 0xe3, 0xf4, 0x6e, 0x61, 0xaf, 0x9b, 0x71, 0x12, 0x85, 0x44, 0x48, 0x4c,
+	0x45, 0xca, 0x13, 0x41, 0xd1, 0x2b, 0x28, 0x0b, 0x49, 0xe4, 0x54, 0x34, 0xdc, 0x53, 0xb7, 0x59,
+	0xef, 0xd4, 0x5b, 0x36, 0xe1, 0x9d, 0x46, 0xb1, 0xf5, 0x22, 0x04, 0x25, 0x96, 0x0c, 0x79, 0x63,
+	0xe3, 0xd4, 0x6d, 0x6e, 0x63, 0xfd, 0xef, 0x55, 0x01, 0xee, 0x28, 0x7d, 0xb8, 0xa1, 0xbf, 0x51,
+	0x21, 0x73, 0xeb, 0x76, 0x12, 0x29, 0xeb, 0x53, 0xa8, 0x29, 0xeb, 0x2e, 0xa5, 0x21, 0x1b, 0x32,
+	0x1a, 0xa1, 0x23, 0x28, 0x27, 0xd3, 0x38, 0xa0, 0x99, 0x16, 0x2a, 0x61, 0x6b, 0x79, 0x7f, 0xb9,
+	0x50, 0x55, 0xcc, 0xb7, 0x5c, 0x30, 0xc9, 0x78, 0x82, 0xbe, 0x84, 0x72, 0xa2, 0x33, 0x6a, 0x62,
+	0xa5, 0xb3, 0xdf, 0xb2, 0x4b, 0x6e, 0x2d, 0xc4, 0xae, 0x1d, 0x6c, 0x49, 0x8a, 0xce, 0xb5, 0x64,
+	0x63, 0x63, 0x0d, 0xdd, 0x54, 0xa3, 0xe8, 0x86, 0x84, 0xbe, 0x81, 0x6d, 0x91, 0xd7, 0xd4, 0x78,
+	0xa6, 0x23, 0x8e, 0x56, 0x22, 0x8a, 0x8a, 0xaf, 0x1d, 0xbc, 0xa0, 0x76, 0xcb, 0x50, 0xba, 0x9f,
+	0xa7, 0xd4, 0xfb, 0x67, 0x03, 0xb6, 0x14, 0xad, 0x9f, 0x0c, 0x39, 0xfa, 0x1c, 0x36, 0x85, 0x24,
+	0x59, 0x5e, 0xe9, 0xe1, 0x4a, 0xa2, 0x7c, 0x41, 0xd8, 0x70, 0xd0, 0x67, 0x50, 0x12, 0x92, 0xa7,
+	0x8d, 0x8d, 0x0f, 0x71, 0x35, 0x05, 0x7d, 0x07, 0x5b, 0x01, 0x1d, 0x93, 0x47, 0xc6, 0x33, 0x5d,
+	0x63, 0xbd, 0xf3, 0x72, 0x85, 0xae, 0xc4, 0xf5, 0x4f, 0xd7, 0xb2, 0x70, 0xc1, 0x47, 0x5d, 0xa8,
+	0x0b, 0x99, 0x51, 0x12, 0xfb, 0x3c, 0x55, 0x29, 0x45, 0xa3, 0xa4, 0x05, 0x4f, 0x8a, 0x0c, 0xdd,
+	0x09, 0x0f, 0x1f, 0xee, 0x34, 0xe7, 0xd6, 0x50, 0x70, 0x4d, 0x2c, 0x9b, 0xe8, 0x25, 0x40, 0x38,
+	0xa6, 0xe1, 0x43, 0xca, 0x59, 0x22, 0x1b, 0x9b, 0xa7, 0x6e, 0xb3, 0x8a, 0x97, 0x10, 0xef, 0x07,
+	0xa8, 0x2e, 0xab, 0xa3, 0x43, 0xd8, 0xeb, 0x0e, 0x6e, 0x7b, 0x3f, 0xfb, 0xef, 0x6e, 0xee, 0xfb,
+	0x03, 0x1f, 0x5f, 0x5e, 0xbc, 0xf9, 0x65, 0xd7, 0x51, 0xf0, 0xd5, 0x45, 0x7f, 0xe0, 0xf7, 0xaf,
+	0xfc, 0x9b, 0xdb, 0x7b, 0x0b, 0xbb, 0xde, 0x10, 0xf6, 0xde, 0xd0, 0x09, 0x7b, 0xa4, 0x59, 0xaf,
+	0x48, 0x89, 0x3e, 0x81, 0x6a, 0xa0, 0xea, 0xf2, 0x57, 0x86, 0xa4, 0xa2, 0xb1, 0x1b, 0x0d, 0xa1,
+	0x8f, 0x61, 0x4b, 0xce, 0x7c, 0x96, 0x44, 0x74, 0xa6, 0x37, 0xb1, 0x86, 0x9f, 0xcb, 0x59, 0x5f,
+	0x99, 0x68, 0x1f, 0x36, 0x95, 0xcb, 0x74, 0x74, 0x1b, 0x97, 0xe4, 0xac, 0x1f, 0x79, 0x7f, 0xba,
+	0xb0, 0x63, 0x85, 0x8a, 0x71, 0x6f, 0x7e, 0x78, 0xdc, 0xd5, 0xa0, 0xd8, 0x81, 0x3f, 0x83, 0x4d,
+	0x2d, 0x6e, 0xfb, 0x55, 0xcb, 0x89, 0x7a, 0xf7, 0xae, 0x1d, 0x6c, 0xbc, 0xe8, 0x35, 0x98, 0x1a,
+	0xfd, 0x80, 0xc8, 0x70, 0x6c, 0x27, 0x0a, 0xad, 0x90, 0xbb, 0xca, 0x73, 0xed, 0x60, 0x08, 0x0a,
+	0xab, 0x18, 0xa7, 0x3f, 0x5c, 0x40, 0xff, 0xed, 0x87, 0xca, 0x1a, 0xf2, 0x38, 0xcd, 0xa8, 0x10,
+	0x8c, 0x27, 0xb6, 0xd6, 0xfd, 0x3c, 0x6b, 0x6f, 0xe1, 0xc2, 0xcb, 0x3c, 0xd4, 0x84, 0xdd, 0x98,
+	0xcc, 0x4c, 0x29, 0xbe, 0x56, 0x13, 0x76, 0xa7, 0xea, 0x31, 0x99, 0x19, 0x65, 0x8d, 0xa2, 0x57,
+	0xb0, 0xb3, 0xc4, 0x9c, 0x4b, 0x2a, 0x74, 0xe9, 0x35, 0x5c, 0x2b, 0x88, 0x0a, 0xf4, 0xae, 0x00,
+	0xfd, 0xc4, 0x59, 0xd2, 0x1b, 0x93, 0x24, 0xa1, 0x13, 0x4c, 0x7f, 0x9d, 0xaa, 0x43, 0x74, 0x0e,
+	0xd5, 0x90, 0x27, 0x43, 0x36, 0x32, 0x22, 0x0d, 0x77, 0xcd, 0x16, 0xe1, 0x8a, 0xa1, 0x68, 0xc3,
+	0x7b, 0x0d, 0x07, 0x98, 0xc6, 0xfc, 0x91, 0x3e, 0xc9, 0xf4, 0x42, 0x4d, 0x9a, 0x46, 0x54, 0xf7,
+	0x5c, 0xdd, 0xbd, 0x6d, 0x8b, 0xf4, 0x23, 0xef, 0x10, 0xf6, 0x07, 0x4c, 0x48, 0x1b, 0x24, 0x6c,
+	0x94, 0xf7, 0x2d, 0x54, 0x2c, 0xa4, 0x8f, 0x21, 0x82, 0x52, 0x42, 0x62, 0x6a, 0xc3, 0xf5, 0xbf,
+	0xba, 0x6e, 0xc6, 0x94, 0x8d, 0xc6, 0xe6, 0x5a, 0x28, 0x61, 0x6b, 0x79, 0xc3, 0x22, 0x54, 0x25,
+	0x46, 0xe7, 0xb0, 0x65, 0xd5, 0xd4, 0x44, 0x3c, 0x6b, 0x56, 0x3a, 0x07, 0xc5, 0x39, 0x59, 0x92,
+	0xc0, 0x05, 0x0b, 0x9d, 0x41, 0x5d, 0xcc, 0x85, 0xa4, 0xb1, 0x6f, 0x21, 0x7b, 0x25, 0xd6, 0x0c,
+	0x6a, 0x83, 0xbc, 0x17, 0x70, 0x82, 0xe9, 0x84, 0x93, 0xe8, 0x7e, 0x70, 0xd7, 0xcb, 0x68, 0x44,
+	0x13, 0xc9, 0xc8, 0x62, 0x05, 0xef, 0xa1, 0xbe, 0xea, 0x40, 0xa7, 0x50, 0x09, 0x69, 0x26, 0xd9,
+	0x90, 0x85, 0x44, 0x9a, 0xb5, 0x54, 0xf1, 0x32, 0xa4, 0x7a, 0x16, 0x4e, 0x18, 0x4d, 0xa4, 0x9f,
+	0x71, 0x2e, 0xfd, 0x90, 0xe4, 0xcd, 0xad, 0x19, 0x18, 0x73, 0x2e, 0x7b, 0x44, 0x74, 0x7e, 0x77,
+	0x61, 0xe7, 0x42, 0xf2, 0x98, 0x85, 0xc5, 0x75, 0x8f, 0x7e, 0x84, 0xed, 0x85, 0xb1, 0x9b, 0x37,
+	0xea, 0x32, 0x79, 0xa4, 0x13, 0x9e, 0xd2, 0xe3, 0xe3, 0xc5, 0xe5, 0xf0, 0xf4, 0x85, 0xf0, 0x9c,
+	0xa6, 0x7b, 0xee, 0xa2, 0xef, 0xe1, 0xb9, 0x3d, 0x4b, 0x6b, 0xc2, 0x1b, 0x45, 0xf8, 0x93, 0xf3,
+	0x66, 0x82, 0x3b, 0x7f, 0xbb, 0x70, 0x60, 0x37, 0xe6, 0x2d, 0xc9, 0x24, 0x0b, 0x59, 0x4a, 0xf4,
+	0x5d, 0x7f, 0x0e, 0x25, 0x35, 0x5e, 0x6b, 0x52, 0xae, 0x6d, 0x83, 0xe7, 0xa0, 0xaf, 0xa1, 0x6c,
+	0x06, 0x69, 0x4d, 0xcc, 0x51, 0xcb, 0x3c, 0x7f, 0xad, 0xfc, 0xf9, 0x6b, 0x5d, 0xaa, 0xe7, 0xcf,
+	0x73, 0x94, 0x8e, 0x6e, 0xf7, 0xff, 0xd0, 0x51, 0x3c, 0xcf, 0xe9, 0x0c, 0x60, 0xf3, 0x22, 0x8a,
+	0x59, 0x82, 0x7a, 0x70, 0xb0, 0xae, 0x91, 0x6b, 0x52, 0x7d, 0x54, 0xa4, 0x5a, 0xa5, 0x7a, 0x4e,
+	0xf7, 0x1d, 0x9c, 0xf1, 0x6c, 0xd4, 0x1a, 0xcf, 0x53, 0x9a, 0x4d, 0x68, 0x34, 0xa2, 0x59, 0x6b,
+	0x48, 0x82, 0x8c, 0x85, 0xa6, 0x54, 0x91, 0x47, 0xbe, 0xff, 0x62, 0xc4, 0xe4, 0x78, 0x1a, 0xa8,
+	0xdc, 0xed, 0x25, 0x76, 0xdb, 0xb0, 0xcd, 0xbb, 0x2e, 0xda, 0x96, 0x1d, 0x94, 0xb5, 0xfd, 0xd5,
+	0xbf, 0x03, 0x00, 0xa3, 0x3b, 0x12, 0x5c, 0x27, 0x08, 0x00, 0x00,
 }
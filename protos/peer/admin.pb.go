@@ -24,6 +24,28 @@ It has these top-level messages:
 	LogLevelRequest
 	LogLevelResponse
 	AdminOperation
+	GossipMembershipResponse
+	LeaderElectionStatusResponse
+	LeaderElectionOverrideRequest
+	GossipEndpointResponse
+	DiscoveryStatsResponse
+	InstallChaincodeMessage
+	InstallChaincodeRequest
+	InstallChaincodeProgress
+	HistoryPruningRequest
+	HistoryPruningStatusResponse
+	DryRunCommitRequest
+	DryRunCommitResponse
+	DryRunTransactionResult
+	PrivateDataPurgeRequest
+	PvtDataDisseminationsRequest
+	PvtDataDisseminationsResponse
+	MissingPvtDataStatsRequest
+	MissingPvtDataStatsResponse
+	LoggerLevelsRequest
+	LoggerLevelsResponse
+	LoggerLevel
+	TLSCredentialsResponse
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -31,6 +53,8 @@ It has these top-level messages:
 	ChaincodeInvocationSpec
 	LifecycleEvent
 	ChaincodeEvent
+	ChaincodeEventRecord
+	ChaincodeEventRecords
 	ChaincodeMessage
 	GetState
 	PutState
@@ -42,6 +66,8 @@ It has these top-level messages:
 	QueryStateClose
 	QueryResultBytes
 	QueryResponse
+	QueryMetadata
+	QueryResponseMetadata
 	AnchorPeers
 	AnchorPeer
 	APIResource
@@ -58,6 +84,7 @@ It has these top-level messages:
 	SignedEvent
 	Event
 	DeliverResponse
+	CollectionPvtDataHash
 	PeerID
 	PeerEndpoint
 	SignedProposal
@@ -143,6 +170,32 @@ func (x ServerStatus_StatusCode) String() string {
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
 
+type LeaderElectionOverrideRequest_Override int32
+
+const (
+	LeaderElectionOverrideRequest_NONE       LeaderElectionOverrideRequest_Override = 0
+	LeaderElectionOverrideRequest_CLAIM      LeaderElectionOverrideRequest_Override = 1
+	LeaderElectionOverrideRequest_RELINQUISH LeaderElectionOverrideRequest_Override = 2
+)
+
+var LeaderElectionOverrideRequest_Override_name = map[int32]string{
+	0: "NONE",
+	1: "CLAIM",
+	2: "RELINQUISH",
+}
+var LeaderElectionOverrideRequest_Override_value = map[string]int32{
+	"NONE":       0,
+	"CLAIM":      1,
+	"RELINQUISH": 2,
+}
+
+func (x LeaderElectionOverrideRequest_Override) String() string {
+	return proto.EnumName(LeaderElectionOverrideRequest_Override_name, int32(x))
+}
+func (LeaderElectionOverrideRequest_Override) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0}
+}
+
 type ServerStatus struct {
 	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
 }
@@ -210,6 +263,14 @@ func (m *LogLevelResponse) GetLogLevel() string {
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
+	//	*AdminOperation_LeaderElectionOverrideReq
+	//	*AdminOperation_InstallChaincodeReq
+	//	*AdminOperation_HistoryPruningReq
+	//	*AdminOperation_DryRunCommitReq
+	//	*AdminOperation_PrivateDataPurgeReq
+	//	*AdminOperation_PvtDataDisseminationsReq
+	//	*AdminOperation_LoggerLevelsReq
+	//	*AdminOperation_MissingPvtDataStatsReq
 	Content isAdminOperation_Content `protobuf_oneof:"content"`
 }
 
@@ -223,8 +284,40 @@ type isAdminOperation_Content interface{ isAdminOperation_Content() }
 type AdminOperation_LogReq struct {
 	LogReq *LogLevelRequest `protobuf:"bytes,1,opt,name=logReq,oneof"`
 }
+type AdminOperation_LeaderElectionOverrideReq struct {
+	LeaderElectionOverrideReq *LeaderElectionOverrideRequest `protobuf:"bytes,2,opt,name=leaderElectionOverrideReq,oneof"`
+}
+type AdminOperation_InstallChaincodeReq struct {
+	InstallChaincodeReq *InstallChaincodeRequest `protobuf:"bytes,3,opt,name=installChaincodeReq,oneof"`
+}
+type AdminOperation_HistoryPruningReq struct {
+	HistoryPruningReq *HistoryPruningRequest `protobuf:"bytes,4,opt,name=historyPruningReq,oneof"`
+}
+type AdminOperation_DryRunCommitReq struct {
+	DryRunCommitReq *DryRunCommitRequest `protobuf:"bytes,5,opt,name=dryRunCommitReq,oneof"`
+}
+type AdminOperation_PrivateDataPurgeReq struct {
+	PrivateDataPurgeReq *PrivateDataPurgeRequest `protobuf:"bytes,6,opt,name=privateDataPurgeReq,oneof"`
+}
+type AdminOperation_PvtDataDisseminationsReq struct {
+	PvtDataDisseminationsReq *PvtDataDisseminationsRequest `protobuf:"bytes,7,opt,name=pvtDataDisseminationsReq,oneof"`
+}
+type AdminOperation_LoggerLevelsReq struct {
+	LoggerLevelsReq *LoggerLevelsRequest `protobuf:"bytes,8,opt,name=loggerLevelsReq,oneof"`
+}
+type AdminOperation_MissingPvtDataStatsReq struct {
+	MissingPvtDataStatsReq *MissingPvtDataStatsRequest `protobuf:"bytes,9,opt,name=missingPvtDataStatsReq,oneof"`
+}
 
-func (*AdminOperation_LogReq) isAdminOperation_Content() {}
+func (*AdminOperation_LogReq) isAdminOperation_Content()                    {}
+func (*AdminOperation_LeaderElectionOverrideReq) isAdminOperation_Content() {}
+func (*AdminOperation_InstallChaincodeReq) isAdminOperation_Content()       {}
+func (*AdminOperation_HistoryPruningReq) isAdminOperation_Content()         {}
+func (*AdminOperation_DryRunCommitReq) isAdminOperation_Content()           {}
+func (*AdminOperation_PrivateDataPurgeReq) isAdminOperation_Content()       {}
+func (*AdminOperation_PvtDataDisseminationsReq) isAdminOperation_Content()  {}
+func (*AdminOperation_LoggerLevelsReq) isAdminOperation_Content()           {}
+func (*AdminOperation_MissingPvtDataStatsReq) isAdminOperation_Content()    {}
 
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
@@ -240,10 +333,74 @@ func (m *AdminOperation) GetLogReq() *LogLevelRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetLeaderElectionOverrideReq() *LeaderElectionOverrideRequest {
+	if x, ok := m.GetContent().(*AdminOperation_LeaderElectionOverrideReq); ok {
+		return x.LeaderElectionOverrideReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetInstallChaincodeReq() *InstallChaincodeRequest {
+	if x, ok := m.GetContent().(*AdminOperation_InstallChaincodeReq); ok {
+		return x.InstallChaincodeReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetHistoryPruningReq() *HistoryPruningRequest {
+	if x, ok := m.GetContent().(*AdminOperation_HistoryPruningReq); ok {
+		return x.HistoryPruningReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetDryRunCommitReq() *DryRunCommitRequest {
+	if x, ok := m.GetContent().(*AdminOperation_DryRunCommitReq); ok {
+		return x.DryRunCommitReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetPrivateDataPurgeReq() *PrivateDataPurgeRequest {
+	if x, ok := m.GetContent().(*AdminOperation_PrivateDataPurgeReq); ok {
+		return x.PrivateDataPurgeReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetPvtDataDisseminationsReq() *PvtDataDisseminationsRequest {
+	if x, ok := m.GetContent().(*AdminOperation_PvtDataDisseminationsReq); ok {
+		return x.PvtDataDisseminationsReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetLoggerLevelsReq() *LoggerLevelsRequest {
+	if x, ok := m.GetContent().(*AdminOperation_LoggerLevelsReq); ok {
+		return x.LoggerLevelsReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetMissingPvtDataStatsReq() *MissingPvtDataStatsRequest {
+	if x, ok := m.GetContent().(*AdminOperation_MissingPvtDataStatsReq); ok {
+		return x.MissingPvtDataStatsReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
+		(*AdminOperation_LeaderElectionOverrideReq)(nil),
+		(*AdminOperation_InstallChaincodeReq)(nil),
+		(*AdminOperation_HistoryPruningReq)(nil),
+		(*AdminOperation_DryRunCommitReq)(nil),
+		(*AdminOperation_PrivateDataPurgeReq)(nil),
+		(*AdminOperation_PvtDataDisseminationsReq)(nil),
+		(*AdminOperation_LoggerLevelsReq)(nil),
+		(*AdminOperation_MissingPvtDataStatsReq)(nil),
 	}
 }
 
@@ -256,6 +413,46 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogReq); err != nil {
 			return err
 		}
+	case *AdminOperation_LeaderElectionOverrideReq:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeaderElectionOverrideReq); err != nil {
+			return err
+		}
+	case *AdminOperation_InstallChaincodeReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.InstallChaincodeReq); err != nil {
+			return err
+		}
+	case *AdminOperation_HistoryPruningReq:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HistoryPruningReq); err != nil {
+			return err
+		}
+	case *AdminOperation_DryRunCommitReq:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DryRunCommitReq); err != nil {
+			return err
+		}
+	case *AdminOperation_PrivateDataPurgeReq:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrivateDataPurgeReq); err != nil {
+			return err
+		}
+	case *AdminOperation_PvtDataDisseminationsReq:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PvtDataDisseminationsReq); err != nil {
+			return err
+		}
+	case *AdminOperation_LoggerLevelsReq:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoggerLevelsReq); err != nil {
+			return err
+		}
+	case *AdminOperation_MissingPvtDataStatsReq:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MissingPvtDataStatsReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -274,6 +471,70 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogReq{msg}
 		return true, err
+	case 2: // content.leaderElectionOverrideReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LeaderElectionOverrideRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_LeaderElectionOverrideReq{msg}
+		return true, err
+	case 3: // content.installChaincodeReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(InstallChaincodeRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_InstallChaincodeReq{msg}
+		return true, err
+	case 4: // content.historyPruningReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HistoryPruningRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_HistoryPruningReq{msg}
+		return true, err
+	case 5: // content.dryRunCommitReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DryRunCommitRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_DryRunCommitReq{msg}
+		return true, err
+	case 6: // content.privateDataPurgeReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PrivateDataPurgeRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_PrivateDataPurgeReq{msg}
+		return true, err
+	case 7: // content.pvtDataDisseminationsReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PvtDataDisseminationsRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_PvtDataDisseminationsReq{msg}
+		return true, err
+	case 8: // content.loggerLevelsReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LoggerLevelsRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_LoggerLevelsReq{msg}
+		return true, err
+	case 9: // content.missingPvtDataStatsReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MissingPvtDataStatsRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_MissingPvtDataStatsReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -288,6 +549,46 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(1<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_LeaderElectionOverrideReq:
+		s := proto.Size(x.LeaderElectionOverrideReq)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_InstallChaincodeReq:
+		s := proto.Size(x.InstallChaincodeReq)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_HistoryPruningReq:
+		s := proto.Size(x.HistoryPruningReq)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_DryRunCommitReq:
+		s := proto.Size(x.DryRunCommitReq)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_PrivateDataPurgeReq:
+		s := proto.Size(x.PrivateDataPurgeReq)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_PvtDataDisseminationsReq:
+		s := proto.Size(x.PvtDataDisseminationsReq)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_LoggerLevelsReq:
+		s := proto.Size(x.LoggerLevelsReq)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_MissingPvtDataStatsReq:
+		s := proto.Size(x.MissingPvtDataStatsReq)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -295,142 +596,1003 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-func init() {
-	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
-	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
-	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
-	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
-	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
+// GossipMembershipResponse contains a JSON encoded snapshot
+// of the gossip membership view of the peer
+type GossipMembershipResponse struct {
+	Membership []byte `protobuf:"bytes,1,opt,name=membership,proto3" json:"membership,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *GossipMembershipResponse) Reset()                    { *m = GossipMembershipResponse{} }
+func (m *GossipMembershipResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipMembershipResponse) ProtoMessage()               {}
+func (*GossipMembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-// Client API for Admin service
+func (m *GossipMembershipResponse) GetMembership() []byte {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
 
-type AdminClient interface {
-	GetStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error)
-	StartServer(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error)
-	GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
-	SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
-	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+// LeaderElectionStatusResponse contains a JSON encoded status of the
+// leader election of each channel the peer uses leader election in
+type LeaderElectionStatusResponse struct {
+	Status []byte `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-type adminClient struct {
-	cc *grpc.ClientConn
+func (m *LeaderElectionStatusResponse) Reset()                    { *m = LeaderElectionStatusResponse{} }
+func (m *LeaderElectionStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaderElectionStatusResponse) ProtoMessage()               {}
+func (*LeaderElectionStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *LeaderElectionStatusResponse) GetStatus() []byte {
+	if m != nil {
+		return m.Status
+	}
+	return nil
 }
 
-func NewAdminClient(cc *grpc.ClientConn) AdminClient {
-	return &adminClient{cc}
+// LeaderElectionOverrideRequest overrides the outcome of
+// the leader election of a channel
+type LeaderElectionOverrideRequest struct {
+	ChannelId string                                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Override  LeaderElectionOverrideRequest_Override `protobuf:"varint,2,opt,name=override,enum=protos.LeaderElectionOverrideRequest_Override" json:"override,omitempty"`
 }
 
-func (c *adminClient) GetStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error) {
-	out := new(ServerStatus)
-	err := grpc.Invoke(ctx, "/protos.Admin/GetStatus", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *LeaderElectionOverrideRequest) Reset()                    { *m = LeaderElectionOverrideRequest{} }
+func (m *LeaderElectionOverrideRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaderElectionOverrideRequest) ProtoMessage()               {}
+func (*LeaderElectionOverrideRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *LeaderElectionOverrideRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *adminClient) StartServer(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error) {
-	out := new(ServerStatus)
-	err := grpc.Invoke(ctx, "/protos.Admin/StartServer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *LeaderElectionOverrideRequest) GetOverride() LeaderElectionOverrideRequest_Override {
+	if m != nil {
+		return m.Override
 	}
-	return out, nil
+	return LeaderElectionOverrideRequest_NONE
 }
 
-func (c *adminClient) GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error) {
-	out := new(LogLevelResponse)
-	err := grpc.Invoke(ctx, "/protos.Admin/GetModuleLogLevel", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// GossipEndpointResponse contains the external endpoint the peer
+// publishes to other peers after its configuration was reloaded
+type GossipEndpointResponse struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
 }
 
-func (c *adminClient) SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error) {
-	out := new(LogLevelResponse)
-	err := grpc.Invoke(ctx, "/protos.Admin/SetModuleLogLevel", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GossipEndpointResponse) Reset()                    { *m = GossipEndpointResponse{} }
+func (m *GossipEndpointResponse) String() string            { return proto.CompactTextString(m) }
+func (*GossipEndpointResponse) ProtoMessage()               {}
+func (*GossipEndpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *GossipEndpointResponse) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
 	}
-	return out, nil
+	return ""
 }
 
-func (c *adminClient) RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
-	out := new(google_protobuf.Empty)
-	err := grpc.Invoke(ctx, "/protos.Admin/RevertLogLevels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// DiscoveryStatsResponse contains JSON encoded statistics
+// of the discovery service of the peer
+type DiscoveryStatsResponse struct {
+	Stats []byte `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
 }
 
-// Server API for Admin service
+func (m *DiscoveryStatsResponse) Reset()                    { *m = DiscoveryStatsResponse{} }
+func (m *DiscoveryStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiscoveryStatsResponse) ProtoMessage()               {}
+func (*DiscoveryStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-type AdminServer interface {
-	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
-	StartServer(context.Context, *common.Envelope) (*ServerStatus, error)
-	GetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
-	SetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
-	RevertLogLevels(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+func (m *DiscoveryStatsResponse) GetStats() []byte {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
 }
 
-func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
-	s.RegisterService(&_Admin_serviceDesc, srv)
+// InstallChaincodeMessage is sent by the client over an InstallChaincode stream.
+// The first message contains a signed AdminOperation with an InstallChaincodeRequest,
+// and the following messages contain consecutive chunks of the chaincode package,
+// starting at the offset the peer acknowledges in its first progress message.
+type InstallChaincodeMessage struct {
+	// Types that are valid to be assigned to Content:
+	//	*InstallChaincodeMessage_Request
+	//	*InstallChaincodeMessage_Chunk
+	Content isInstallChaincodeMessage_Content `protobuf_oneof:"content"`
 }
 
-func _Admin_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Envelope)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AdminServer).GetStatus(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.Admin/GetStatus",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServer).GetStatus(ctx, req.(*common.Envelope))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *InstallChaincodeMessage) Reset()                    { *m = InstallChaincodeMessage{} }
+func (m *InstallChaincodeMessage) String() string            { return proto.CompactTextString(m) }
+func (*InstallChaincodeMessage) ProtoMessage()               {}
+func (*InstallChaincodeMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type isInstallChaincodeMessage_Content interface{ isInstallChaincodeMessage_Content() }
+
+type InstallChaincodeMessage_Request struct {
+	Request *common.Envelope `protobuf:"bytes,1,opt,name=request,oneof"`
+}
+type InstallChaincodeMessage_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
 }
 
-func _Admin_StartServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Envelope)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AdminServer).StartServer(ctx, in)
+func (*InstallChaincodeMessage_Request) isInstallChaincodeMessage_Content() {}
+func (*InstallChaincodeMessage_Chunk) isInstallChaincodeMessage_Content()   {}
+
+func (m *InstallChaincodeMessage) GetContent() isInstallChaincodeMessage_Content {
+	if m != nil {
+		return m.Content
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/protos.Admin/StartServer",
+	return nil
+}
+
+func (m *InstallChaincodeMessage) GetRequest() *common.Envelope {
+	if x, ok := m.GetContent().(*InstallChaincodeMessage_Request); ok {
+		return x.Request
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServer).StartServer(ctx, req.(*common.Envelope))
+	return nil
+}
+
+func (m *InstallChaincodeMessage) GetChunk() []byte {
+	if x, ok := m.GetContent().(*InstallChaincodeMessage_Chunk); ok {
+		return x.Chunk
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Admin_GetModuleLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Envelope)
-	if err := dec(in); err != nil {
-		return nil, err
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*InstallChaincodeMessage) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _InstallChaincodeMessage_OneofMarshaler, _InstallChaincodeMessage_OneofUnmarshaler, _InstallChaincodeMessage_OneofSizer, []interface{}{
+		(*InstallChaincodeMessage_Request)(nil),
+		(*InstallChaincodeMessage_Chunk)(nil),
 	}
-	if interceptor == nil {
-		return srv.(AdminServer).GetModuleLogLevel(ctx, in)
+}
+
+func _InstallChaincodeMessage_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*InstallChaincodeMessage)
+	// content
+	switch x := m.Content.(type) {
+	case *InstallChaincodeMessage_Request:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Request); err != nil {
+			return err
+		}
+	case *InstallChaincodeMessage_Chunk:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.Chunk)
+	case nil:
+	default:
+		return fmt.Errorf("InstallChaincodeMessage.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _InstallChaincodeMessage_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*InstallChaincodeMessage)
+	switch tag {
+	case 1: // content.request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common.Envelope)
+		err := b.DecodeMessage(msg)
+		m.Content = &InstallChaincodeMessage_Request{msg}
+		return true, err
+	case 2: // content.chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Content = &InstallChaincodeMessage_Chunk{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _InstallChaincodeMessage_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*InstallChaincodeMessage)
+	// content
+	switch x := m.Content.(type) {
+	case *InstallChaincodeMessage_Request:
+		s := proto.Size(x.Request)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *InstallChaincodeMessage_Chunk:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Chunk)))
+		n += len(x.Chunk)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// InstallChaincodeRequest starts the upload of a chaincode package,
+// or resumes a previous upload of the same package that was interrupted
+type InstallChaincodeRequest struct {
+	// package_hash is the SHA256 hash of the chaincode package,
+	// which identifies the upload
+	PackageHash []byte `protobuf:"bytes,1,opt,name=package_hash,json=packageHash,proto3" json:"package_hash,omitempty"`
+	PackageSize uint64 `protobuf:"varint,2,opt,name=package_size,json=packageSize" json:"package_size,omitempty"`
+}
+
+func (m *InstallChaincodeRequest) Reset()                    { *m = InstallChaincodeRequest{} }
+func (m *InstallChaincodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*InstallChaincodeRequest) ProtoMessage()               {}
+func (*InstallChaincodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *InstallChaincodeRequest) GetPackageHash() []byte {
+	if m != nil {
+		return m.PackageHash
+	}
+	return nil
+}
+
+func (m *InstallChaincodeRequest) GetPackageSize() uint64 {
+	if m != nil {
+		return m.PackageSize
+	}
+	return 0
+}
+
+// InstallChaincodeProgress acknowledges the bytes of a chaincode package
+// the peer has received so far, and whether the chaincode was installed
+type InstallChaincodeProgress struct {
+	Received uint64 `protobuf:"varint,1,opt,name=received" json:"received,omitempty"`
+	Total    uint64 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
+	// installed is set once the whole package was received and installed
+	Installed        bool   `protobuf:"varint,3,opt,name=installed" json:"installed,omitempty"`
+	ChaincodeName    string `protobuf:"bytes,4,opt,name=chaincode_name,json=chaincodeName" json:"chaincode_name,omitempty"`
+	ChaincodeVersion string `protobuf:"bytes,5,opt,name=chaincode_version,json=chaincodeVersion" json:"chaincode_version,omitempty"`
+}
+
+func (m *InstallChaincodeProgress) Reset()                    { *m = InstallChaincodeProgress{} }
+func (m *InstallChaincodeProgress) String() string            { return proto.CompactTextString(m) }
+func (*InstallChaincodeProgress) ProtoMessage()               {}
+func (*InstallChaincodeProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *InstallChaincodeProgress) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *InstallChaincodeProgress) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *InstallChaincodeProgress) GetInstalled() bool {
+	if m != nil {
+		return m.Installed
+	}
+	return false
+}
+
+func (m *InstallChaincodeProgress) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *InstallChaincodeProgress) GetChaincodeVersion() string {
+	if m != nil {
+		return m.ChaincodeVersion
+	}
+	return ""
+}
+
+// HistoryPruningRequest identifies the channel whose history database
+// is pruned, or whose pruning status is requested
+type HistoryPruningRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *HistoryPruningRequest) Reset()                    { *m = HistoryPruningRequest{} }
+func (m *HistoryPruningRequest) String() string            { return proto.CompactTextString(m) }
+func (*HistoryPruningRequest) ProtoMessage()               {}
+func (*HistoryPruningRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *HistoryPruningRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// HistoryPruningStatusResponse contains the JSON encoded status of the
+// pruning of the history database of a channel
+type HistoryPruningStatusResponse struct {
+	Status []byte `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (m *HistoryPruningStatusResponse) Reset()                    { *m = HistoryPruningStatusResponse{} }
+func (m *HistoryPruningStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*HistoryPruningStatusResponse) ProtoMessage()               {}
+func (*HistoryPruningStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *HistoryPruningStatusResponse) GetStatus() []byte {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// DryRunCommitRequest contains a block of a channel, which the peer validates
+// against the current state of the channel without committing it
+type DryRunCommitRequest struct {
+	ChannelId string        `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Block     *common.Block `protobuf:"bytes,2,opt,name=block" json:"block,omitempty"`
+}
+
+func (m *DryRunCommitRequest) Reset()                    { *m = DryRunCommitRequest{} }
+func (m *DryRunCommitRequest) String() string            { return proto.CompactTextString(m) }
+func (*DryRunCommitRequest) ProtoMessage()               {}
+func (*DryRunCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *DryRunCommitRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *DryRunCommitRequest) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// DryRunCommitResponse contains the outcome of the validation
+// of each transaction of the block, in the order of the block
+type DryRunCommitResponse struct {
+	Transactions []*DryRunTransactionResult `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+}
+
+func (m *DryRunCommitResponse) Reset()                    { *m = DryRunCommitResponse{} }
+func (m *DryRunCommitResponse) String() string            { return proto.CompactTextString(m) }
+func (*DryRunCommitResponse) ProtoMessage()               {}
+func (*DryRunCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *DryRunCommitResponse) GetTransactions() []*DryRunTransactionResult {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// DryRunTransactionResult is the outcome of the validation of a transaction
+type DryRunTransactionResult struct {
+	TxId           string           `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,2,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+}
+
+func (m *DryRunTransactionResult) Reset()                    { *m = DryRunTransactionResult{} }
+func (m *DryRunTransactionResult) String() string            { return proto.CompactTextString(m) }
+func (*DryRunTransactionResult) ProtoMessage()               {}
+func (*DryRunTransactionResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *DryRunTransactionResult) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *DryRunTransactionResult) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+// PrivateDataPurgeRequest purges the private values of keys of a collection
+// from the ledger of the peer. The hashes of the keys and values remain
+type PrivateDataPurgeRequest struct {
+	ChannelId      string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	ChaincodeName  string   `protobuf:"bytes,2,opt,name=chaincode_name,json=chaincodeName" json:"chaincode_name,omitempty"`
+	CollectionName string   `protobuf:"bytes,3,opt,name=collection_name,json=collectionName" json:"collection_name,omitempty"`
+	Keys           []string `protobuf:"bytes,4,rep,name=keys" json:"keys,omitempty"`
+	// block_to_live overrides the blockToLive of the collection for the keys,
+	// such that they are purged this many blocks after the last committed block.
+	// The keys are purged right away when it is zero
+	BlockToLive uint64 `protobuf:"varint,5,opt,name=block_to_live,json=blockToLive" json:"block_to_live,omitempty"`
+}
+
+func (m *PrivateDataPurgeRequest) Reset()                    { *m = PrivateDataPurgeRequest{} }
+func (m *PrivateDataPurgeRequest) String() string            { return proto.CompactTextString(m) }
+func (*PrivateDataPurgeRequest) ProtoMessage()               {}
+func (*PrivateDataPurgeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *PrivateDataPurgeRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PrivateDataPurgeRequest) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *PrivateDataPurgeRequest) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *PrivateDataPurgeRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *PrivateDataPurgeRequest) GetBlockToLive() uint64 {
+	if m != nil {
+		return m.BlockToLive
+	}
+	return 0
+}
+
+// PvtDataDisseminationsRequest requests the records of the disseminations of the private data
+// written by the transactions the peer endorsed in a channel, which were simulated at or above
+// start_block, and only those of the transaction tx_id if it is set
+type PvtDataDisseminationsRequest struct {
+	ChannelId  string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	StartBlock uint64 `protobuf:"varint,2,opt,name=start_block,json=startBlock" json:"start_block,omitempty"`
+	TxId       string `protobuf:"bytes,3,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+}
+
+func (m *PvtDataDisseminationsRequest) Reset()                    { *m = PvtDataDisseminationsRequest{} }
+func (m *PvtDataDisseminationsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PvtDataDisseminationsRequest) ProtoMessage()               {}
+func (*PvtDataDisseminationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *PvtDataDisseminationsRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PvtDataDisseminationsRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataDisseminationsRequest) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+// PvtDataDisseminationsResponse contains the JSON encoded records of the disseminations
+// of private data, each describing the peers selected to receive the private data
+// of a collection and whether they acknowledged it
+type PvtDataDisseminationsResponse struct {
+	Disseminations []byte `protobuf:"bytes,1,opt,name=disseminations,proto3" json:"disseminations,omitempty"`
+}
+
+func (m *PvtDataDisseminationsResponse) Reset()                    { *m = PvtDataDisseminationsResponse{} }
+func (m *PvtDataDisseminationsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PvtDataDisseminationsResponse) ProtoMessage()               {}
+func (*PvtDataDisseminationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *PvtDataDisseminationsResponse) GetDisseminations() []byte {
+	if m != nil {
+		return m.Disseminations
+	}
+	return nil
+}
+
+// MissingPvtDataStatsRequest requests the statistics of the private data
+// that was missing when the blocks of a channel were committed
+type MissingPvtDataStatsRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *MissingPvtDataStatsRequest) Reset()                    { *m = MissingPvtDataStatsRequest{} }
+func (m *MissingPvtDataStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*MissingPvtDataStatsRequest) ProtoMessage()               {}
+func (*MissingPvtDataStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *MissingPvtDataStatsRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// MissingPvtDataStatsResponse contains the JSON encoded statistics of the missing
+// private data, per chaincode and collection, and the ranges of blocks missing it
+type MissingPvtDataStatsResponse struct {
+	Stats []byte `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (m *MissingPvtDataStatsResponse) Reset()                    { *m = MissingPvtDataStatsResponse{} }
+func (m *MissingPvtDataStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*MissingPvtDataStatsResponse) ProtoMessage()               {}
+func (*MissingPvtDataStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *MissingPvtDataStatsResponse) GetStats() []byte {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// LoggerLevelsRequest selects the loggers whose level is requested or set
+// by a pattern matching their names, in which '*' matches any sequence of characters
+type LoggerLevelsRequest struct {
+	LoggerPattern string `protobuf:"bytes,1,opt,name=logger_pattern,json=loggerPattern" json:"logger_pattern,omitempty"`
+	// log_level is the level the loggers are set to
+	LogLevel string `protobuf:"bytes,2,opt,name=log_level,json=logLevel" json:"log_level,omitempty"`
+	// expiry_minutes reverts the loggers to the levels they had before after
+	// this many minutes. The levels are kept until changed again when it is zero
+	ExpiryMinutes uint32 `protobuf:"varint,3,opt,name=expiry_minutes,json=expiryMinutes" json:"expiry_minutes,omitempty"`
+}
+
+func (m *LoggerLevelsRequest) Reset()                    { *m = LoggerLevelsRequest{} }
+func (m *LoggerLevelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*LoggerLevelsRequest) ProtoMessage()               {}
+func (*LoggerLevelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *LoggerLevelsRequest) GetLoggerPattern() string {
+	if m != nil {
+		return m.LoggerPattern
+	}
+	return ""
+}
+
+func (m *LoggerLevelsRequest) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
+}
+
+func (m *LoggerLevelsRequest) GetExpiryMinutes() uint32 {
+	if m != nil {
+		return m.ExpiryMinutes
+	}
+	return 0
+}
+
+// LoggerLevelsResponse contains the levels of the loggers matching the pattern of the request
+type LoggerLevelsResponse struct {
+	Loggers []*LoggerLevel `protobuf:"bytes,1,rep,name=loggers" json:"loggers,omitempty"`
+}
+
+func (m *LoggerLevelsResponse) Reset()                    { *m = LoggerLevelsResponse{} }
+func (m *LoggerLevelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*LoggerLevelsResponse) ProtoMessage()               {}
+func (*LoggerLevelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *LoggerLevelsResponse) GetLoggers() []*LoggerLevel {
+	if m != nil {
+		return m.Loggers
+	}
+	return nil
+}
+
+type LoggerLevel struct {
+	Logger string `protobuf:"bytes,1,opt,name=logger" json:"logger,omitempty"`
+	Level  string `protobuf:"bytes,2,opt,name=level" json:"level,omitempty"`
+}
+
+func (m *LoggerLevel) Reset()                    { *m = LoggerLevel{} }
+func (m *LoggerLevel) String() string            { return proto.CompactTextString(m) }
+func (*LoggerLevel) ProtoMessage()               {}
+func (*LoggerLevel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *LoggerLevel) GetLogger() string {
+	if m != nil {
+		return m.Logger
+	}
+	return ""
+}
+
+func (m *LoggerLevel) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+// TLSCredentialsResponse describes the TLS credentials the gRPC servers
+// of the peer use after they were reloaded from their files
+type TLSCredentialsResponse struct {
+	// certificate is the PEM encoded TLS certificate of the peer
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// client_root_cas is the number of certificate authorities
+	// the certificates of clients are verified with
+	ClientRootCas uint32 `protobuf:"varint,2,opt,name=client_root_cas,json=clientRootCas" json:"client_root_cas,omitempty"`
+}
+
+func (m *TLSCredentialsResponse) Reset()                    { *m = TLSCredentialsResponse{} }
+func (m *TLSCredentialsResponse) String() string            { return proto.CompactTextString(m) }
+func (*TLSCredentialsResponse) ProtoMessage()               {}
+func (*TLSCredentialsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *TLSCredentialsResponse) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *TLSCredentialsResponse) GetClientRootCas() uint32 {
+	if m != nil {
+		return m.ClientRootCas
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
+	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
+	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
+	proto.RegisterType((*GossipMembershipResponse)(nil), "protos.GossipMembershipResponse")
+	proto.RegisterType((*LeaderElectionStatusResponse)(nil), "protos.LeaderElectionStatusResponse")
+	proto.RegisterType((*LeaderElectionOverrideRequest)(nil), "protos.LeaderElectionOverrideRequest")
+	proto.RegisterType((*GossipEndpointResponse)(nil), "protos.GossipEndpointResponse")
+	proto.RegisterType((*DiscoveryStatsResponse)(nil), "protos.DiscoveryStatsResponse")
+	proto.RegisterType((*InstallChaincodeMessage)(nil), "protos.InstallChaincodeMessage")
+	proto.RegisterType((*InstallChaincodeRequest)(nil), "protos.InstallChaincodeRequest")
+	proto.RegisterType((*InstallChaincodeProgress)(nil), "protos.InstallChaincodeProgress")
+	proto.RegisterType((*HistoryPruningRequest)(nil), "protos.HistoryPruningRequest")
+	proto.RegisterType((*HistoryPruningStatusResponse)(nil), "protos.HistoryPruningStatusResponse")
+	proto.RegisterType((*DryRunCommitRequest)(nil), "protos.DryRunCommitRequest")
+	proto.RegisterType((*DryRunCommitResponse)(nil), "protos.DryRunCommitResponse")
+	proto.RegisterType((*DryRunTransactionResult)(nil), "protos.DryRunTransactionResult")
+	proto.RegisterType((*PrivateDataPurgeRequest)(nil), "protos.PrivateDataPurgeRequest")
+	proto.RegisterType((*PvtDataDisseminationsRequest)(nil), "protos.PvtDataDisseminationsRequest")
+	proto.RegisterType((*PvtDataDisseminationsResponse)(nil), "protos.PvtDataDisseminationsResponse")
+	proto.RegisterType((*MissingPvtDataStatsRequest)(nil), "protos.MissingPvtDataStatsRequest")
+	proto.RegisterType((*MissingPvtDataStatsResponse)(nil), "protos.MissingPvtDataStatsResponse")
+	proto.RegisterType((*LoggerLevelsRequest)(nil), "protos.LoggerLevelsRequest")
+	proto.RegisterType((*LoggerLevelsResponse)(nil), "protos.LoggerLevelsResponse")
+	proto.RegisterType((*LoggerLevel)(nil), "protos.LoggerLevel")
+	proto.RegisterType((*TLSCredentialsResponse)(nil), "protos.TLSCredentialsResponse")
+	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
+	proto.RegisterEnum("protos.LeaderElectionOverrideRequest_Override", LeaderElectionOverrideRequest_Override_name, LeaderElectionOverrideRequest_Override_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Admin service
+
+type AdminClient interface {
+	GetStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error)
+	StartServer(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error)
+	GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
+	SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
+	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	GetGossipMembership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipMembershipResponse, error)
+	GetLeaderElectionStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LeaderElectionStatusResponse, error)
+	OverrideLeaderElection(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	ReloadGossipEndpoint(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipEndpointResponse, error)
+	GetDiscoveryStats(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DiscoveryStatsResponse, error)
+	InstallChaincode(ctx context.Context, opts ...grpc.CallOption) (Admin_InstallChaincodeClient, error)
+	PruneHistory(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*HistoryPruningStatusResponse, error)
+	GetHistoryPruningStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*HistoryPruningStatusResponse, error)
+	DryRunCommit(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DryRunCommitResponse, error)
+	PurgePrivateData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	GetPvtDataDisseminations(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataDisseminationsResponse, error)
+	GetMissingPvtDataStats(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*MissingPvtDataStatsResponse, error)
+	GetLoggerLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LoggerLevelsResponse, error)
+	SetLoggerLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LoggerLevelsResponse, error)
+	ReloadTLSCredentials(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TLSCredentialsResponse, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) GetStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StartServer(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/StartServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error) {
+	out := new(LogLevelResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetModuleLogLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error) {
+	out := new(LogLevelResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/SetModuleLogLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/RevertLogLevels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipMembership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipMembershipResponse, error) {
+	out := new(GossipMembershipResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipMembership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetLeaderElectionStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LeaderElectionStatusResponse, error) {
+	out := new(LeaderElectionStatusResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetLeaderElectionStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) OverrideLeaderElection(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/OverrideLeaderElection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReloadGossipEndpoint(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipEndpointResponse, error) {
+	out := new(GossipEndpointResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/ReloadGossipEndpoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetDiscoveryStats(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DiscoveryStatsResponse, error) {
+	out := new(DiscoveryStatsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetDiscoveryStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) InstallChaincode(ctx context.Context, opts ...grpc.CallOption) (Admin_InstallChaincodeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Admin_serviceDesc.Streams[0], c.cc, "/protos.Admin/InstallChaincode", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminInstallChaincodeClient{stream}
+	return x, nil
+}
+
+type Admin_InstallChaincodeClient interface {
+	Send(*InstallChaincodeMessage) error
+	Recv() (*InstallChaincodeProgress, error)
+	grpc.ClientStream
+}
+
+type adminInstallChaincodeClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminInstallChaincodeClient) Send(m *InstallChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminInstallChaincodeClient) Recv() (*InstallChaincodeProgress, error) {
+	m := new(InstallChaincodeProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) PruneHistory(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*HistoryPruningStatusResponse, error) {
+	out := new(HistoryPruningStatusResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/PruneHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetHistoryPruningStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*HistoryPruningStatusResponse, error) {
+	out := new(HistoryPruningStatusResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetHistoryPruningStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DryRunCommit(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DryRunCommitResponse, error) {
+	out := new(DryRunCommitResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/DryRunCommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) PurgePrivateData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/PurgePrivateData", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetPvtDataDisseminations(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataDisseminationsResponse, error) {
+	out := new(PvtDataDisseminationsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetPvtDataDisseminations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetMissingPvtDataStats(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*MissingPvtDataStatsResponse, error) {
+	out := new(MissingPvtDataStatsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetMissingPvtDataStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetLoggerLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LoggerLevelsResponse, error) {
+	out := new(LoggerLevelsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetLoggerLevels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetLoggerLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LoggerLevelsResponse, error) {
+	out := new(LoggerLevelsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/SetLoggerLevels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReloadTLSCredentials(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*TLSCredentialsResponse, error) {
+	out := new(TLSCredentialsResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/ReloadTLSCredentials", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Admin service
+
+type AdminServer interface {
+	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
+	StartServer(context.Context, *common.Envelope) (*ServerStatus, error)
+	GetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
+	SetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
+	RevertLogLevels(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	GetGossipMembership(context.Context, *common.Envelope) (*GossipMembershipResponse, error)
+	GetLeaderElectionStatus(context.Context, *common.Envelope) (*LeaderElectionStatusResponse, error)
+	OverrideLeaderElection(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	ReloadGossipEndpoint(context.Context, *common.Envelope) (*GossipEndpointResponse, error)
+	GetDiscoveryStats(context.Context, *common.Envelope) (*DiscoveryStatsResponse, error)
+	InstallChaincode(Admin_InstallChaincodeServer) error
+	PruneHistory(context.Context, *common.Envelope) (*HistoryPruningStatusResponse, error)
+	GetHistoryPruningStatus(context.Context, *common.Envelope) (*HistoryPruningStatusResponse, error)
+	DryRunCommit(context.Context, *common.Envelope) (*DryRunCommitResponse, error)
+	PurgePrivateData(context.Context, *common.Envelope) (*google_protobuf.Empty, error)
+	GetPvtDataDisseminations(context.Context, *common.Envelope) (*PvtDataDisseminationsResponse, error)
+	GetMissingPvtDataStats(context.Context, *common.Envelope) (*MissingPvtDataStatsResponse, error)
+	GetLoggerLevels(context.Context, *common.Envelope) (*LoggerLevelsResponse, error)
+	SetLoggerLevels(context.Context, *common.Envelope) (*LoggerLevelsResponse, error)
+	ReloadTLSCredentials(context.Context, *common.Envelope) (*TLSCredentialsResponse, error)
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetStatus(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StartServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).StartServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/StartServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).StartServer(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetModuleLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetModuleLogLevel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -478,6 +1640,284 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetGossipMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipMembership(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetLeaderElectionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetLeaderElectionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetLeaderElectionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetLeaderElectionStatus(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_OverrideLeaderElection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).OverrideLeaderElection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/OverrideLeaderElection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).OverrideLeaderElection(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReloadGossipEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReloadGossipEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ReloadGossipEndpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReloadGossipEndpoint(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetDiscoveryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetDiscoveryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetDiscoveryStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetDiscoveryStats(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_InstallChaincode_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServer).InstallChaincode(&adminInstallChaincodeServer{stream})
+}
+
+type Admin_InstallChaincodeServer interface {
+	Send(*InstallChaincodeProgress) error
+	Recv() (*InstallChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type adminInstallChaincodeServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminInstallChaincodeServer) Send(m *InstallChaincodeProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminInstallChaincodeServer) Recv() (*InstallChaincodeMessage, error) {
+	m := new(InstallChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Admin_PruneHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PruneHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/PruneHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PruneHistory(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetHistoryPruningStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetHistoryPruningStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetHistoryPruningStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetHistoryPruningStatus(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DryRunCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DryRunCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/DryRunCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DryRunCommit(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_PurgePrivateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PurgePrivateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/PurgePrivateData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PurgePrivateData(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetPvtDataDisseminations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetPvtDataDisseminations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetPvtDataDisseminations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetPvtDataDisseminations(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetMissingPvtDataStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetMissingPvtDataStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetMissingPvtDataStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetMissingPvtDataStats(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetLoggerLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetLoggerLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetLoggerLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetLoggerLevels(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetLoggerLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetLoggerLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/SetLoggerLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetLoggerLevels(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReloadTLSCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReloadTLSCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ReloadTLSCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReloadTLSCredentials(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -502,43 +1942,179 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetGossipMembership",
+			Handler:    _Admin_GetGossipMembership_Handler,
+		},
+		{
+			MethodName: "GetLeaderElectionStatus",
+			Handler:    _Admin_GetLeaderElectionStatus_Handler,
+		},
+		{
+			MethodName: "OverrideLeaderElection",
+			Handler:    _Admin_OverrideLeaderElection_Handler,
+		},
+		{
+			MethodName: "ReloadGossipEndpoint",
+			Handler:    _Admin_ReloadGossipEndpoint_Handler,
+		},
+		{
+			MethodName: "GetDiscoveryStats",
+			Handler:    _Admin_GetDiscoveryStats_Handler,
+		},
+		{
+			MethodName: "PruneHistory",
+			Handler:    _Admin_PruneHistory_Handler,
+		},
+		{
+			MethodName: "GetHistoryPruningStatus",
+			Handler:    _Admin_GetHistoryPruningStatus_Handler,
+		},
+		{
+			MethodName: "DryRunCommit",
+			Handler:    _Admin_DryRunCommit_Handler,
+		},
+		{
+			MethodName: "PurgePrivateData",
+			Handler:    _Admin_PurgePrivateData_Handler,
+		},
+		{
+			MethodName: "GetPvtDataDisseminations",
+			Handler:    _Admin_GetPvtDataDisseminations_Handler,
+		},
+		{
+			MethodName: "GetMissingPvtDataStats",
+			Handler:    _Admin_GetMissingPvtDataStats_Handler,
+		},
+		{
+			MethodName: "GetLoggerLevels",
+			Handler:    _Admin_GetLoggerLevels_Handler,
+		},
+		{
+			MethodName: "SetLoggerLevels",
+			Handler:    _Admin_SetLoggerLevels_Handler,
+		},
+		{
+			MethodName: "ReloadTLSCredentials",
+			Handler:    _Admin_ReloadTLSCredentials_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InstallChaincode",
+			Handler:       _Admin_InstallChaincode_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 466 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x0b, 0xed, 0xc8, 0xe9, 0xd8, 0x82, 0x41, 0x50, 0x75, 0x42, 0xa0, 0x5c, 0xc1, 0x8d,
-	0x23, 0x8a, 0xd0, 0xae, 0xb8, 0x68, 0x49, 0x18, 0x88, 0x2d, 0xad, 0x9c, 0x55, 0x08, 0x24, 0x34,
-	0xa5, 0xed, 0x99, 0x57, 0xe1, 0xc4, 0x99, 0xe3, 0x54, 0xda, 0xeb, 0xf0, 0x1c, 0x3c, 0x1c, 0x8a,
-	0x9d, 0x68, 0x13, 0xf4, 0x06, 0xf5, 0xea, 0xf8, 0x1c, 0x7f, 0xdf, 0x67, 0x9f, 0x3f, 0x70, 0x73,
-	0x44, 0xe5, 0x27, 0xab, 0x74, 0x9d, 0xd1, 0x5c, 0x49, 0x2d, 0x49, 0xcf, 0x98, 0x62, 0x78, 0xc4,
-	0xa5, 0xe4, 0x02, 0x7d, 0xe3, 0x2e, 0xca, 0x4b, 0x1f, 0xd3, 0x5c, 0xdf, 0x58, 0xd0, 0xf0, 0xf1,
-	0x52, 0xa6, 0xa9, 0xcc, 0x7c, 0x6b, 0x6c, 0xd0, 0xfb, 0xd5, 0x86, 0xfd, 0x18, 0xd5, 0x06, 0x55,
-	0xac, 0x13, 0x5d, 0x16, 0xe4, 0x18, 0x7a, 0x85, 0x39, 0x0d, 0xda, 0x2f, 0xdb, 0xaf, 0x0e, 0x46,
-	0x2f, 0x2c, 0xb0, 0xa0, 0x77, 0x51, 0xd4, 0x9a, 0x0f, 0x72, 0x85, 0xac, 0x86, 0x7b, 0xdf, 0x00,
-	0x6e, 0xa3, 0xe4, 0x21, 0x38, 0xf3, 0x28, 0x08, 0x3f, 0x7e, 0x8e, 0xc2, 0xc0, 0x6d, 0x91, 0x3e,
-	0xec, 0xc5, 0xe7, 0x63, 0x76, 0x1e, 0x06, 0x6e, 0xdb, 0x3a, 0xd3, 0xd9, 0x2c, 0x0c, 0xdc, 0x0e,
-	0x01, 0xe8, 0xcd, 0xc6, 0xf3, 0x38, 0x0c, 0xdc, 0x7b, 0xc4, 0x81, 0x6e, 0xc8, 0xd8, 0x94, 0xb9,
-	0xf7, 0x2b, 0xcc, 0x3c, 0xfa, 0x12, 0x4d, 0xbf, 0x46, 0x6e, 0xd7, 0x3b, 0x83, 0xc3, 0x53, 0xc9,
-	0x4f, 0x71, 0x83, 0x82, 0xe1, 0x75, 0x89, 0x85, 0x26, 0xcf, 0x01, 0x84, 0xe4, 0x17, 0xa9, 0x5c,
-	0x95, 0x02, 0xcd, 0x57, 0x1d, 0xe6, 0x08, 0xc9, 0xcf, 0x4c, 0x80, 0x1c, 0x41, 0xe5, 0x5c, 0x88,
-	0x8a, 0x32, 0xe8, 0x98, 0xdb, 0x07, 0xa2, 0x96, 0xf0, 0x22, 0x70, 0x6f, 0xe5, 0x8a, 0x5c, 0x66,
-	0x05, 0xee, 0xa8, 0x77, 0x30, 0xae, 0x9a, 0x31, 0xcd, 0x51, 0x25, 0x7a, 0x2d, 0x33, 0xf2, 0x06,
-	0x7a, 0x42, 0x72, 0x86, 0xd7, 0x46, 0xa9, 0x3f, 0x7a, 0xd6, 0x14, 0xf1, 0xaf, 0x34, 0x3e, 0xb5,
-	0x58, 0x0d, 0x9c, 0x38, 0xb0, 0xb7, 0x94, 0x99, 0xc6, 0x4c, 0x8f, 0x7e, 0x77, 0xa0, 0x6b, 0x04,
-	0xc9, 0x3b, 0x70, 0x4e, 0x50, 0xd7, 0x9d, 0x71, 0x69, 0xdd, 0xb9, 0x30, 0xdb, 0xa0, 0x90, 0x39,
-	0x0e, 0x9f, 0x6c, 0xeb, 0x8d, 0xd7, 0x22, 0xc7, 0xd0, 0x8f, 0x75, 0xa2, 0xb4, 0x0d, 0xff, 0x07,
-	0x71, 0x0c, 0x8f, 0x4e, 0x50, 0xdb, 0x9c, 0x9b, 0xaf, 0x6e, 0xa1, 0x0f, 0xfe, 0x4d, 0xc7, 0x96,
-	0xd1, 0x4a, 0xc4, 0x3b, 0x4a, 0xbc, 0x87, 0x43, 0x86, 0x1b, 0x54, 0xba, 0xb9, 0xdb, 0x96, 0xfb,
-	0x53, 0x6a, 0x67, 0x9d, 0x36, 0xb3, 0x4e, 0xc3, 0x6a, 0xd6, 0xbd, 0xd6, 0xe4, 0x07, 0x78, 0x52,
-	0x71, 0x7a, 0x75, 0x93, 0xa3, 0x12, 0xb8, 0xe2, 0xa8, 0xe8, 0x65, 0xb2, 0x50, 0xeb, 0x65, 0xf3,
-	0x64, 0xb5, 0x3e, 0x93, 0x7d, 0x53, 0xe1, 0x59, 0xb2, 0xfc, 0x99, 0x70, 0xfc, 0xfe, 0x9a, 0xaf,
-	0xf5, 0x55, 0xb9, 0xa8, 0x5e, 0xf1, 0xef, 0x10, 0x7d, 0x4b, 0xb4, 0xfb, 0x54, 0xf8, 0x15, 0x71,
-	0x61, 0x77, 0xed, 0xed, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x20, 0x26, 0x0b, 0x86, 0x03,
-	0x00, 0x00,
+	// 1639 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x6d, 0x73, 0x22, 0xc7,
+	0x11, 0x06, 0x09, 0xbd, 0xd0, 0x80, 0xc4, 0x8d, 0x14, 0x44, 0x74, 0x77, 0xb6, 0xb2, 0xf6, 0x39,
+	0x4e, 0x25, 0x41, 0xc9, 0x39, 0xb1, 0xab, 0xe2, 0x4a, 0x2a, 0x3a, 0x41, 0x90, 0x6c, 0x09, 0xe1,
+	0x45, 0x72, 0xca, 0x71, 0x52, 0xd4, 0x6a, 0xe9, 0x83, 0xc9, 0x2d, 0x3b, 0x7b, 0x33, 0x03, 0x75,
+	0xf2, 0xa7, 0x54, 0x7e, 0x4a, 0xfe, 0x44, 0x3e, 0xa4, 0x2a, 0x95, 0x9f, 0x96, 0x9a, 0x97, 0x5d,
+	0x58, 0xb4, 0xa0, 0xbb, 0xba, 0x4f, 0xcb, 0x3c, 0xd3, 0xfd, 0xcc, 0x4c, 0xf7, 0xf4, 0xcb, 0x00,
+	0xd5, 0x08, 0x91, 0x1f, 0x7b, 0x83, 0x31, 0x0d, 0x1b, 0x11, 0x67, 0x92, 0x91, 0x4d, 0xfd, 0x11,
+	0x87, 0x8f, 0x87, 0x8c, 0x0d, 0x03, 0x3c, 0xd6, 0xc3, 0xdb, 0xc9, 0xcb, 0x63, 0x1c, 0x47, 0xf2,
+	0xce, 0x08, 0x1d, 0xee, 0xf9, 0x6c, 0x3c, 0x66, 0xe1, 0xb1, 0xf9, 0x58, 0xb0, 0xa6, 0xb9, 0x24,
+	0xf7, 0x42, 0xe1, 0xf9, 0x92, 0xc6, 0xb8, 0xf3, 0xaf, 0x3c, 0x94, 0x7b, 0xc8, 0xa7, 0xc8, 0x7b,
+	0xd2, 0x93, 0x13, 0x41, 0xbe, 0x80, 0x4d, 0xa1, 0x7f, 0xd5, 0xf3, 0x47, 0xf9, 0x4f, 0x77, 0x9e,
+	0x7f, 0x68, 0x04, 0x45, 0x63, 0x5e, 0xaa, 0x61, 0x3e, 0xa7, 0x6c, 0x80, 0xae, 0x15, 0x77, 0xbe,
+	0x03, 0x98, 0xa1, 0xa4, 0x02, 0xc5, 0x9b, 0x4e, 0xb3, 0xf5, 0xa7, 0xf3, 0x4e, 0xab, 0x59, 0xcd,
+	0x91, 0x12, 0x6c, 0xf5, 0xae, 0x4f, 0xdc, 0xeb, 0x56, 0xb3, 0x9a, 0x37, 0x83, 0xab, 0x6e, 0xb7,
+	0xd5, 0xac, 0xae, 0x11, 0x80, 0xcd, 0xee, 0xc9, 0x4d, 0xaf, 0xd5, 0xac, 0xae, 0x93, 0x22, 0x6c,
+	0xb4, 0x5c, 0xf7, 0xca, 0xad, 0x16, 0x94, 0xcc, 0x4d, 0xe7, 0xeb, 0xce, 0xd5, 0x9f, 0x3b, 0xd5,
+	0x0d, 0xe7, 0x12, 0x76, 0x2f, 0xd8, 0xf0, 0x02, 0xa7, 0x18, 0xb8, 0xf8, 0x7a, 0x82, 0x42, 0x92,
+	0xa7, 0x00, 0x01, 0x1b, 0xf6, 0xc7, 0x6c, 0x30, 0x09, 0x50, 0x6f, 0xb5, 0xe8, 0x16, 0x03, 0x36,
+	0xbc, 0xd4, 0x00, 0x79, 0x0c, 0x6a, 0xd0, 0x0f, 0x94, 0x4a, 0x7d, 0x4d, 0xcf, 0x6e, 0x07, 0x96,
+	0xc2, 0xe9, 0x40, 0x75, 0x46, 0x27, 0x22, 0x16, 0x0a, 0x7c, 0x2f, 0xbe, 0x7f, 0x6e, 0xc2, 0xce,
+	0x89, 0xf2, 0xd2, 0x55, 0x84, 0xdc, 0x53, 0xc6, 0x25, 0xbf, 0x86, 0xcd, 0x80, 0x0d, 0x5d, 0x7c,
+	0xad, 0xa9, 0x4a, 0xcf, 0x0f, 0x62, 0x2b, 0x2e, 0x9c, 0xe3, 0x2c, 0xe7, 0x5a, 0x41, 0x82, 0xf0,
+	0xe3, 0x00, 0xbd, 0x01, 0xf2, 0x56, 0x80, 0xda, 0x43, 0x57, 0x53, 0xe4, 0x9c, 0x0e, 0x50, 0xb1,
+	0xac, 0x69, 0x96, 0x67, 0x09, 0xcb, 0x32, 0x41, 0xcb, 0xb9, 0x9c, 0x89, 0xf4, 0x60, 0x8f, 0x86,
+	0x42, 0x7a, 0x41, 0x70, 0x3a, 0xf2, 0x68, 0xe8, 0x33, 0xb3, 0xc0, 0xba, 0x5e, 0x20, 0x71, 0xf6,
+	0xf9, 0x7d, 0x11, 0x4b, 0x9d, 0xa5, 0x4d, 0x2e, 0xe1, 0xd1, 0x88, 0x0a, 0xc9, 0xf8, 0x5d, 0x97,
+	0x4f, 0x42, 0x1a, 0xea, 0x93, 0x17, 0x34, 0xe5, 0xd3, 0x98, 0xf2, 0x6c, 0x51, 0xc0, 0x12, 0xde,
+	0xd7, 0x24, 0x6d, 0xd8, 0x1d, 0xf0, 0x3b, 0x77, 0x12, 0x9e, 0xb2, 0xf1, 0x98, 0x4a, 0x45, 0xb6,
+	0xa1, 0xc9, 0x1e, 0xc7, 0x64, 0xcd, 0xf4, 0xb4, 0xa5, 0x5a, 0xd4, 0x52, 0x87, 0x8d, 0x38, 0x9d,
+	0x7a, 0x12, 0x9b, 0x9e, 0xf4, 0xba, 0x13, 0x3e, 0xd4, 0x87, 0xdd, 0x4c, 0x1f, 0xb6, 0x7b, 0x5f,
+	0x24, 0x3e, 0x6c, 0x86, 0x36, 0xb9, 0x85, 0x7a, 0x34, 0x95, 0x0a, 0x6a, 0x52, 0x21, 0x70, 0x4c,
+	0x43, 0xed, 0x73, 0xa1, 0x98, 0xb7, 0x34, 0xf3, 0xc7, 0x09, 0xf3, 0x12, 0x39, 0x4b, 0xbf, 0x94,
+	0x47, 0x59, 0x20, 0x60, 0xc3, 0x21, 0x72, 0x7d, 0x59, 0x34, 0xf5, 0x76, 0xda, 0x02, 0x17, 0xe9,
+	0xe9, 0xd8, 0x02, 0x0b, 0x5a, 0xe4, 0xaf, 0x50, 0x1b, 0x53, 0x21, 0x68, 0x38, 0xb4, 0x7b, 0x51,
+	0x31, 0xaa, 0xf9, 0x8a, 0x9a, 0xcf, 0x89, 0xf9, 0x2e, 0x33, 0xa5, 0x2c, 0xed, 0x12, 0x8e, 0x17,
+	0x45, 0xd8, 0xf2, 0x59, 0x28, 0x31, 0x94, 0xce, 0xef, 0xa0, 0xde, 0x66, 0x42, 0xd0, 0xe8, 0x12,
+	0xc7, 0xb7, 0xc8, 0xc5, 0x88, 0x46, 0x49, 0x70, 0x7d, 0x00, 0x30, 0x4e, 0x50, 0x1d, 0x11, 0x65,
+	0x77, 0x0e, 0x71, 0x3e, 0x87, 0x27, 0xe9, 0x1b, 0x6d, 0x12, 0x49, 0xa2, 0x5f, 0x4b, 0xe5, 0xa4,
+	0x72, 0x92, 0x72, 0xfe, 0x93, 0x87, 0xa7, 0x2b, 0x43, 0x41, 0x85, 0xb5, 0x3f, 0xf2, 0xc2, 0x10,
+	0x83, 0x3e, 0x1d, 0xc4, 0x61, 0x6d, 0x91, 0xf3, 0x01, 0xf9, 0x0a, 0xb6, 0x99, 0xd5, 0xd0, 0x21,
+	0xb6, 0xf3, 0xbc, 0xf1, 0x56, 0x21, 0xd6, 0x48, 0xc6, 0x89, 0xbe, 0x73, 0x0c, 0xdb, 0x31, 0x4a,
+	0xb6, 0xa1, 0xd0, 0xb9, 0xea, 0xb4, 0xaa, 0x39, 0x95, 0xd2, 0x4e, 0x2f, 0x4e, 0xce, 0x2f, 0xab,
+	0x79, 0xb2, 0x03, 0xe0, 0xb6, 0x2e, 0xce, 0x3b, 0xdf, 0xdc, 0x9c, 0xf7, 0xce, 0xaa, 0x6b, 0xce,
+	0x6f, 0xa0, 0x66, 0x2c, 0xd6, 0x0a, 0x07, 0x11, 0xa3, 0xa1, 0x4c, 0xce, 0x7b, 0x08, 0xdb, 0x68,
+	0x31, 0xbb, 0xe7, 0x64, 0xec, 0x34, 0xa0, 0xd6, 0xa4, 0xc2, 0x57, 0xcb, 0xde, 0x59, 0x3f, 0x58,
+	0xad, 0x7d, 0xd8, 0x50, 0x76, 0x89, 0x8d, 0x64, 0x06, 0xce, 0xdf, 0xe1, 0x60, 0x31, 0x98, 0x2f,
+	0x51, 0x08, 0x6f, 0x88, 0xe4, 0x17, 0xb0, 0xc5, 0xcd, 0x79, 0x6c, 0x96, 0xaa, 0x36, 0x6c, 0xcd,
+	0x68, 0x85, 0x53, 0x0c, 0x58, 0x84, 0x67, 0x39, 0x37, 0x16, 0x21, 0x35, 0xd8, 0xf0, 0x47, 0x93,
+	0xf0, 0x95, 0x36, 0x54, 0xf9, 0x2c, 0xe7, 0x9a, 0xe1, 0xfc, 0x1d, 0xe8, 0xdf, 0x5f, 0x2b, 0x76,
+	0xc4, 0x4f, 0xa0, 0x1c, 0x79, 0xfe, 0x2b, 0x6f, 0x88, 0xfd, 0x91, 0x27, 0x46, 0x76, 0x8f, 0x25,
+	0x8b, 0x9d, 0x79, 0x62, 0x34, 0x2f, 0x22, 0xe8, 0x0f, 0xc6, 0x21, 0x85, 0x44, 0xa4, 0x47, 0x7f,
+	0x40, 0xe7, 0xbf, 0x79, 0xa8, 0x2f, 0xae, 0xd0, 0xe5, 0x6c, 0xc8, 0x51, 0x08, 0x65, 0x35, 0x8e,
+	0x3e, 0xd2, 0x29, 0x1a, 0x4f, 0x17, 0xdc, 0x64, 0xac, 0x6c, 0x23, 0x99, 0xf4, 0x02, 0x4b, 0x6a,
+	0x06, 0xe4, 0x09, 0x14, 0x6d, 0x36, 0xc3, 0x81, 0xce, 0x80, 0xdb, 0xee, 0x0c, 0x20, 0xcf, 0x60,
+	0xc7, 0x8f, 0x17, 0xe9, 0x87, 0xde, 0x18, 0x75, 0x46, 0x2b, 0xba, 0x95, 0x04, 0xed, 0x78, 0x63,
+	0x24, 0x3f, 0x87, 0x47, 0x33, 0xb1, 0x29, 0x72, 0x41, 0x59, 0xa8, 0xd3, 0x55, 0xd1, 0xad, 0x26,
+	0x13, 0xdf, 0x1a, 0xdc, 0xf9, 0x1c, 0x7e, 0x94, 0x99, 0x07, 0x1f, 0xb8, 0xa8, 0x2a, 0x42, 0xd2,
+	0x7a, 0x6f, 0x19, 0x21, 0xdf, 0xc1, 0x5e, 0x46, 0xaa, 0x7c, 0x28, 0x2c, 0x3e, 0x82, 0x8d, 0xdb,
+	0x80, 0xf9, 0xaf, 0x6c, 0xd9, 0xa9, 0xc4, 0xd7, 0xe2, 0x85, 0x02, 0x5d, 0x33, 0xe7, 0x7c, 0x0f,
+	0xfb, 0x69, 0x6a, 0xbb, 0x95, 0x53, 0x28, 0xcf, 0xb5, 0x19, 0x6a, 0x43, 0xeb, 0xf3, 0xc9, 0xd6,
+	0xe8, 0x5c, 0xcf, 0x24, 0x5c, 0x14, 0x93, 0x40, 0xba, 0x29, 0x25, 0xe7, 0x35, 0x1c, 0x2c, 0x11,
+	0x24, 0x7b, 0xb0, 0x21, 0xdf, 0xcc, 0xb6, 0x5d, 0x90, 0x6f, 0xce, 0x07, 0xe4, 0x04, 0x76, 0xa7,
+	0x5e, 0x40, 0x07, 0x3a, 0x83, 0xf6, 0x95, 0xc5, 0x6d, 0x3c, 0xd7, 0xe3, 0x75, 0xaf, 0xdf, 0x7c,
+	0x9b, 0x08, 0xe8, 0xbe, 0x65, 0x67, 0x9a, 0x1a, 0x3b, 0xff, 0xcb, 0xc3, 0xc1, 0x92, 0x4a, 0xf0,
+	0x90, 0xbd, 0xee, 0xdf, 0x94, 0xb5, 0xac, 0x9b, 0xf2, 0x53, 0xd8, 0xf5, 0x59, 0x60, 0x33, 0x8a,
+	0x91, 0x5b, 0xd7, 0x72, 0x3b, 0x33, 0x58, 0x0b, 0x12, 0x28, 0xbc, 0xc2, 0x3b, 0x51, 0x2f, 0x1c,
+	0xad, 0xab, 0x13, 0xaa, 0xdf, 0xc4, 0x81, 0x8a, 0xb6, 0x7b, 0x5f, 0xb2, 0x7e, 0x40, 0xa7, 0xa8,
+	0xaf, 0x58, 0xc1, 0x2d, 0x69, 0xf0, 0x9a, 0x5d, 0xd0, 0x29, 0x3a, 0x02, 0x9e, 0xac, 0xaa, 0x38,
+	0x0f, 0x1d, 0xe3, 0x43, 0x28, 0x09, 0xe9, 0x71, 0xd9, 0x9f, 0x39, 0xbf, 0xe0, 0x82, 0x86, 0xb4,
+	0xe7, 0x67, 0xa6, 0x5f, 0x9f, 0x99, 0xde, 0x69, 0xc3, 0xd3, 0x25, 0x8b, 0xda, 0x0b, 0xf1, 0x09,
+	0xec, 0x0c, 0x52, 0x33, 0xf6, 0x8e, 0x2e, 0xa0, 0xce, 0x97, 0x70, 0xb8, 0xbc, 0x08, 0x3d, 0x14,
+	0x20, 0x9f, 0xc1, 0xe3, 0x4c, 0xe5, 0x95, 0xb9, 0xf1, 0x1f, 0x79, 0xd8, 0xcb, 0xa8, 0xa3, 0xca,
+	0x9f, 0xa6, 0x8e, 0xf6, 0x23, 0x4f, 0x4a, 0xe4, 0xa1, 0x5d, 0xaf, 0x62, 0xd0, 0xae, 0x01, 0x57,
+	0x36, 0x85, 0x8a, 0x03, 0xdf, 0x44, 0x94, 0xdf, 0xf5, 0xc7, 0x34, 0x9c, 0x48, 0x14, 0xda, 0x68,
+	0x15, 0xb7, 0x62, 0xd0, 0x4b, 0x03, 0x3a, 0x2d, 0xd8, 0x4f, 0xef, 0xc0, 0x6e, 0xf8, 0x97, 0xb0,
+	0x65, 0x16, 0x8b, 0x03, 0x68, 0x2f, 0xa3, 0xf0, 0xbb, 0xb1, 0x8c, 0xf3, 0x25, 0x94, 0xe6, 0x70,
+	0x95, 0x0e, 0xcc, 0x8c, 0xdd, 0xb8, 0x1d, 0x29, 0x33, 0xcc, 0xef, 0xd6, 0x0c, 0x9c, 0x5b, 0xa8,
+	0x5d, 0x5f, 0xf4, 0x4e, 0x39, 0x0e, 0x30, 0x94, 0xd4, 0x9b, 0xdb, 0xc5, 0x11, 0x94, 0x7c, 0xe4,
+	0x92, 0xbe, 0xa4, 0xbe, 0x27, 0x31, 0x4e, 0xda, 0x73, 0x10, 0xf9, 0x04, 0x76, 0xfd, 0x80, 0x62,
+	0x28, 0xfb, 0x9c, 0x31, 0xd9, 0xf7, 0x3d, 0xa1, 0xb9, 0x2b, 0x6e, 0xc5, 0xc0, 0x2e, 0x63, 0xf2,
+	0xd4, 0x13, 0xcf, 0xff, 0x5d, 0x82, 0x0d, 0xdd, 0x23, 0x93, 0xdf, 0x42, 0xb1, 0x8d, 0xd2, 0xbe,
+	0x36, 0xee, 0x55, 0x9c, 0xc3, 0xfd, 0xac, 0xf7, 0x86, 0x93, 0x23, 0x5f, 0x40, 0xa9, 0xa7, 0x6e,
+	0xa2, 0x81, 0xdf, 0x41, 0xf1, 0x04, 0x1e, 0xb5, 0x51, 0x9a, 0x3e, 0x3e, 0xee, 0xbe, 0x33, 0xd4,
+	0xeb, 0xf7, 0x3b, 0x74, 0x63, 0x04, 0x43, 0xd1, 0x7b, 0x4f, 0x8a, 0xdf, 0xc3, 0xae, 0x8b, 0x53,
+	0xe4, 0x32, 0x9e, 0xcb, 0x3a, 0x7b, 0xad, 0x61, 0xde, 0x75, 0x8d, 0xf8, 0x5d, 0xd7, 0x68, 0xa9,
+	0x77, 0x9d, 0x93, 0x23, 0x5f, 0xc3, 0x5e, 0x1b, 0xe5, 0x62, 0x83, 0x95, 0x41, 0x71, 0x14, 0xef,
+	0x61, 0x59, 0x33, 0xe6, 0xe4, 0x48, 0x0f, 0x0e, 0xda, 0x28, 0xb3, 0x3a, 0xae, 0x0c, 0xc2, 0x8f,
+	0xb3, 0x1b, 0xa2, 0x74, 0xfd, 0x71, 0x72, 0xa4, 0x09, 0xb5, 0xb8, 0xfd, 0x49, 0x4b, 0xbe, 0xd3,
+	0x39, 0xbf, 0x82, 0x7d, 0x17, 0x03, 0xe6, 0x0d, 0xd2, 0x9d, 0x51, 0x06, 0xc7, 0x07, 0xe9, 0x83,
+	0x2e, 0xf6, 0x50, 0x4e, 0x8e, 0xb4, 0xb5, 0xe3, 0xd3, 0xcd, 0xd2, 0x2a, 0xa2, 0xec, 0xb6, 0xca,
+	0xc9, 0x91, 0xef, 0xa1, 0xba, 0xd8, 0x74, 0x90, 0xa5, 0x2f, 0x25, 0xdb, 0x5c, 0x1d, 0x1e, 0x2d,
+	0x13, 0x88, 0xfb, 0x15, 0x27, 0xf7, 0x69, 0xfe, 0x57, 0x79, 0x72, 0x06, 0x65, 0x55, 0xd2, 0xd1,
+	0x96, 0xf7, 0x55, 0x1e, 0x58, 0xd5, 0x01, 0x24, 0x6e, 0xcd, 0x12, 0x7a, 0x0f, 0xd2, 0x3f, 0x42,
+	0x79, 0xbe, 0xca, 0x67, 0x30, 0x3d, 0xc9, 0x7e, 0x93, 0x25, 0x0c, 0x7f, 0x80, 0xaa, 0xae, 0xa5,
+	0x73, 0xb5, 0xf5, 0x9d, 0xae, 0xc4, 0x0d, 0xd4, 0xdb, 0x28, 0x33, 0x4b, 0x4c, 0x06, 0xcf, 0xb3,
+	0x07, 0x9e, 0x5e, 0xc9, 0xb6, 0xbe, 0x81, 0x9a, 0x4a, 0x0b, 0xf7, 0x6b, 0x46, 0x06, 0xe9, 0x47,
+	0x2b, 0x1f, 0x49, 0x09, 0xe5, 0x29, 0xec, 0xaa, 0xb8, 0x9a, 0x4b, 0xe7, 0xab, 0xcc, 0x95, 0x95,
+	0xf6, 0x0d, 0x49, 0xef, 0xbd, 0x49, 0x92, 0x30, 0x4a, 0xe7, 0xf5, 0x55, 0xb7, 0x3f, 0xbb, 0x02,
+	0x38, 0xb9, 0x17, 0x7f, 0x03, 0x87, 0xf1, 0x61, 0x63, 0x74, 0x17, 0x21, 0x0f, 0x70, 0x30, 0x44,
+	0xde, 0x78, 0xe9, 0xdd, 0x72, 0xea, 0xc7, 0x9a, 0x11, 0x22, 0x7f, 0x51, 0xd6, 0xc9, 0xbd, 0x6b,
+	0x7a, 0xf5, 0xbf, 0xfc, 0x6c, 0x48, 0xe5, 0x68, 0x72, 0xab, 0x56, 0x3b, 0x9e, 0x53, 0x3c, 0x36,
+	0x8a, 0xe6, 0x6f, 0x2b, 0x71, 0xac, 0x14, 0x6f, 0xcd, 0x5f, 0x5a, 0x9f, 0xfd, 0x7f, 0x00, 0xc6,
+	0x5b, 0x54, 0xc3, 0xed, 0x12, 0x00, 0x00,
 }
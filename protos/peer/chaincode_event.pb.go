@@ -54,26 +54,84 @@ func (m *ChaincodeEvent) GetPayload() []byte {
 	return nil
 }
 
+// ChaincodeEventRecord is a chaincode event indexed by the ledger, along with
+// the position of the transaction that emitted it
+type ChaincodeEventRecord struct {
+	BlockNumber uint64          `protobuf:"varint,1,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TxNumber    uint64          `protobuf:"varint,2,opt,name=tx_number,json=txNumber" json:"tx_number,omitempty"`
+	Event       *ChaincodeEvent `protobuf:"bytes,3,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *ChaincodeEventRecord) Reset()                    { *m = ChaincodeEventRecord{} }
+func (m *ChaincodeEventRecord) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventRecord) ProtoMessage()               {}
+func (*ChaincodeEventRecord) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
+
+func (m *ChaincodeEventRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventRecord) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventRecord) GetEvent() *ChaincodeEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+// ChaincodeEventRecords are the chaincode events that match a query of the events indexed by the ledger
+type ChaincodeEventRecords struct {
+	Records []*ChaincodeEventRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ChaincodeEventRecords) Reset()                    { *m = ChaincodeEventRecords{} }
+func (m *ChaincodeEventRecords) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventRecords) ProtoMessage()               {}
+func (*ChaincodeEventRecords) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+
+func (m *ChaincodeEventRecords) GetRecords() []*ChaincodeEventRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeEvent)(nil), "protos.ChaincodeEvent")
+	proto.RegisterType((*ChaincodeEventRecord)(nil), "protos.ChaincodeEventRecord")
+	proto.RegisterType((*ChaincodeEventRecords)(nil), "protos.ChaincodeEventRecords")
 }
 
 func init() { proto.RegisterFile("peer/chaincode_event.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 218 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2a, 0x48, 0x4d, 0x2d,
-	0xd2, 0x4f, 0xce, 0x48, 0xcc, 0xcc, 0x4b, 0xce, 0x4f, 0x49, 0x8d, 0x4f, 0x2d, 0x4b, 0xcd, 0x2b,
-	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03, 0x53, 0xc5, 0x4a, 0x8d, 0x8c, 0x5c, 0x7c,
-	0xce, 0x30, 0x15, 0xae, 0x20, 0x05, 0x42, 0x8a, 0x5c, 0x3c, 0x08, 0x3d, 0x99, 0x29, 0x12, 0x8c,
-	0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0xdc, 0x70, 0x31, 0xcf, 0x14, 0x21, 0x61, 0x2e, 0xd6, 0x92, 0x0a,
-	0x90, 0x1c, 0x13, 0x58, 0x8e, 0xa5, 0xa4, 0xc2, 0x33, 0x45, 0x48, 0x96, 0x8b, 0x0b, 0x6c, 0x43,
-	0x7c, 0x5e, 0x62, 0x6e, 0xaa, 0x04, 0x33, 0x58, 0x86, 0x13, 0x2c, 0xe2, 0x97, 0x98, 0x9b, 0x2a,
-	0x24, 0xc1, 0xc5, 0x5e, 0x90, 0x58, 0x99, 0x93, 0x9f, 0x98, 0x22, 0xc1, 0xa2, 0xc0, 0xa8, 0xc1,
-	0x13, 0x04, 0xe3, 0x3a, 0xa5, 0x71, 0x29, 0xe5, 0x17, 0xa5, 0xeb, 0x65, 0x54, 0x16, 0xa4, 0x16,
-	0xe5, 0xa4, 0xa6, 0xa4, 0xa7, 0x16, 0xe9, 0xa5, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0xdc, 0x5a,
-	0xac, 0x07, 0xf2, 0x87, 0x93, 0x28, 0xaa, 0x33, 0x03, 0x12, 0x93, 0xb3, 0x13, 0xd3, 0x53, 0xa3,
-	0x34, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x91, 0x4c, 0xd0, 0x87,
-	0x98, 0xa0, 0x0f, 0x31, 0x41, 0x1f, 0x64, 0x42, 0x12, 0xc4, 0xcf, 0xc6, 0x80, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x4a, 0x9d, 0xa8, 0x17, 0x18, 0x01, 0x00, 0x00,
+	// 302 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0xcb, 0x6a, 0xc3, 0x30,
+	0x10, 0x44, 0x79, 0x34, 0xcd, 0x3a, 0xf4, 0xa0, 0x3e, 0x30, 0x7d, 0x40, 0xea, 0x53, 0x0a, 0xc5,
+	0x86, 0x14, 0xfa, 0x01, 0x29, 0x3d, 0xe4, 0x92, 0x16, 0x1f, 0x7b, 0x09, 0xb2, 0xb4, 0x71, 0x4c,
+	0x62, 0xcb, 0x28, 0x4a, 0x71, 0x8e, 0x85, 0x7e, 0x78, 0xf1, 0xaa, 0xee, 0x23, 0xb4, 0x27, 0x49,
+	0x33, 0xb3, 0x33, 0x23, 0x16, 0xce, 0x4b, 0x44, 0x13, 0xc9, 0xa5, 0xc8, 0x0a, 0xa9, 0x15, 0xce,
+	0xf1, 0x15, 0x0b, 0x1b, 0x96, 0x46, 0x5b, 0xcd, 0x0f, 0xe8, 0xd8, 0x04, 0x6f, 0x0c, 0x8e, 0x1e,
+	0x1a, 0xc5, 0x63, 0x2d, 0xe0, 0xd7, 0x30, 0xf8, 0x9e, 0xc9, 0x94, 0xcf, 0x86, 0x6c, 0xd4, 0x8f,
+	0xbd, 0x2f, 0x6c, 0xaa, 0xf8, 0x31, 0x74, 0x6d, 0x55, 0x73, 0x2d, 0xe2, 0x3a, 0xb6, 0x9a, 0x2a,
+	0x7e, 0x05, 0x40, 0x09, 0xf3, 0x42, 0xe4, 0xe8, 0xb7, 0x89, 0xe9, 0x13, 0x32, 0x13, 0x39, 0x72,
+	0x1f, 0x7a, 0xa5, 0xd8, 0xad, 0xb5, 0x50, 0x7e, 0x67, 0xc8, 0x46, 0x83, 0xb8, 0x79, 0x06, 0xef,
+	0x0c, 0x4e, 0x7e, 0x77, 0x88, 0x51, 0x6a, 0xa3, 0xea, 0x26, 0xc9, 0x5a, 0xcb, 0xd5, 0xbc, 0xd8,
+	0xe6, 0x09, 0x1a, 0x6a, 0xd2, 0x89, 0x3d, 0xc2, 0x66, 0x04, 0xf1, 0x0b, 0xe8, 0xdb, 0xaa, 0xe1,
+	0x5b, 0xc4, 0x1f, 0xda, 0xea, 0x93, 0xbc, 0x85, 0x2e, 0xe5, 0x53, 0x19, 0x6f, 0x7c, 0xe6, 0xfe,
+	0xbe, 0x09, 0xf7, 0xc2, 0x9c, 0x28, 0x78, 0x82, 0xd3, 0xbf, 0x5a, 0x6c, 0xf8, 0x3d, 0xf4, 0x8c,
+	0xbb, 0xfa, 0x6c, 0xd8, 0x1e, 0x79, 0xe3, 0xcb, 0x7f, 0x8c, 0x48, 0x14, 0x37, 0xe2, 0xc9, 0x02,
+	0x02, 0x6d, 0xd2, 0x70, 0xb9, 0x2b, 0xd1, 0xac, 0x51, 0xa5, 0x68, 0xc2, 0x85, 0x48, 0x4c, 0x26,
+	0x9b, 0xf1, 0x7a, 0x3f, 0x93, 0xbd, 0xd0, 0x67, 0x21, 0x57, 0x22, 0xc5, 0x97, 0x9b, 0x34, 0xb3,
+	0xcb, 0x6d, 0x12, 0x4a, 0x9d, 0x47, 0x3f, 0x1c, 0x22, 0xe7, 0x10, 0x39, 0x87, 0xa8, 0x76, 0x48,
+	0xdc, 0x2e, 0xef, 0x3e, 0x06, 0x00, 0xc2, 0xca, 0x53, 0xf2, 0xf0, 0x01, 0x00, 0x00,
 }
@@ -242,6 +242,8 @@ type GetStateByRange struct {
 	StartKey   string `protobuf:"bytes,1,opt,name=startKey" json:"startKey,omitempty"`
 	EndKey     string `protobuf:"bytes,2,opt,name=endKey" json:"endKey,omitempty"`
 	Collection string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	// metadata is a marshaled QueryMetadata, which is set for paginated queries
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *GetStateByRange) Reset()                    { *m = GetStateByRange{} }
@@ -270,6 +272,13 @@ func (m *GetStateByRange) GetCollection() string {
 	return ""
 }
 
+func (m *GetStateByRange) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type GetQueryResult struct {
 	Query      string `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
 	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -362,6 +371,8 @@ type QueryResponse struct {
 	Results []*QueryResultBytes `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
 	HasMore bool                `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
 	Id      string              `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	// metadata is a marshaled QueryResponseMetadata, which is set for paginated queries
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
@@ -390,6 +401,67 @@ func (m *QueryResponse) GetId() string {
 	return ""
 }
 
+func (m *QueryResponse) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// QueryMetadata is the metadata of a paginated query, which is sent by the
+// chaincode. The page starts at the bookmark, or at the start key of the
+// query if the bookmark is empty, and contains at most page_size results.
+type QueryMetadata struct {
+	PageSize int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	Bookmark string `protobuf:"bytes,2,opt,name=bookmark" json:"bookmark,omitempty"`
+}
+
+func (m *QueryMetadata) Reset()                    { *m = QueryMetadata{} }
+func (m *QueryMetadata) String() string            { return proto.CompactTextString(m) }
+func (*QueryMetadata) ProtoMessage()               {}
+func (*QueryMetadata) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+
+func (m *QueryMetadata) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *QueryMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+// QueryResponseMetadata is the metadata of the response to a paginated query.
+// The bookmark is the key the next page starts at, and is empty if there are
+// no more results.
+type QueryResponseMetadata struct {
+	FetchedRecordsCount int32  `protobuf:"varint,1,opt,name=fetched_records_count,json=fetchedRecordsCount" json:"fetched_records_count,omitempty"`
+	Bookmark            string `protobuf:"bytes,2,opt,name=bookmark" json:"bookmark,omitempty"`
+}
+
+func (m *QueryResponseMetadata) Reset()                    { *m = QueryResponseMetadata{} }
+func (m *QueryResponseMetadata) String() string            { return proto.CompactTextString(m) }
+func (*QueryResponseMetadata) ProtoMessage()               {}
+func (*QueryResponseMetadata) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *QueryResponseMetadata) GetFetchedRecordsCount() int32 {
+	if m != nil {
+		return m.FetchedRecordsCount
+	}
+	return 0
+}
+
+func (m *QueryResponseMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*GetState)(nil), "protos.GetState")
@@ -402,6 +474,8 @@ func init() {
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
 	proto.RegisterType((*QueryResponse)(nil), "protos.QueryResponse")
+	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
+	proto.RegisterType((*QueryResponseMetadata)(nil), "protos.QueryResponseMetadata")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
 }
 
@@ -509,60 +583,163 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// Client API for Chaincode service
+
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Chaincode_serviceDesc.Streams[0], c.cc, "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Chaincode service
+
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0xc7, 0x8f, 0x00, 0xc1, 0x0c, 0x09, 0xec, 0x6d, 0xae, 0xa9, 0x0f, 0xe9, 0x5a, 0x8a, 0xfa,
-	0x40, 0x5f, 0xa0, 0xa5, 0x7d, 0xe8, 0xc3, 0x49, 0x15, 0xc1, 0x1b, 0x62, 0x85, 0xd8, 0xdc, 0xda,
-	0x39, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x67, 0xac, 0x1a, 0xaf, 0x6b, 0x2f, 0xa7, 0xf3, 0x67, 0xe8,
-	0x07, 0xeb, 0xd7, 0xaa, 0xd6, 0xc6, 0x84, 0x23, 0x8a, 0x4e, 0xea, 0x13, 0xfe, 0xcf, 0xfc, 0x66,
-	0xe6, 0x3f, 0xd6, 0xb2, 0x86, 0xd7, 0x31, 0x63, 0xc9, 0x68, 0xb5, 0x76, 0x83, 0x68, 0xc5, 0x3d,
-	0xe6, 0xa4, 0xeb, 0x60, 0x33, 0x8c, 0x13, 0x2e, 0x38, 0x3e, 0xcd, 0x7f, 0xd2, 0x6e, 0xf7, 0x08,
-	0x61, 0x9f, 0x58, 0x24, 0x0a, 0xa6, 0x7b, 0x91, 0xe7, 0xe2, 0x84, 0xc7, 0x3c, 0x75, 0xc3, 0x5d,
-	0xf0, 0x7b, 0x9f, 0x73, 0x3f, 0x64, 0xa3, 0x5c, 0x3d, 0x6c, 0x3f, 0x8e, 0x44, 0xb0, 0x61, 0xa9,
-	0x70, 0x37, 0x71, 0x01, 0xf4, 0xff, 0xa9, 0x03, 0x9a, 0x96, 0xfd, 0xee, 0x58, 0x9a, 0xba, 0x3e,
-	0xc3, 0xbf, 0x40, 0x4d, 0x64, 0x31, 0x53, 0x2b, 0xbd, 0xca, 0xa0, 0x3d, 0x7e, 0x53, 0xa0, 0xe9,
-	0xf0, 0x98, 0x1b, 0xda, 0x59, 0xcc, 0x68, 0x8e, 0xe2, 0xdf, 0xa1, 0xb9, 0x6f, 0xad, 0x9e, 0xf4,
-	0x2a, 0x83, 0xd6, 0xb8, 0x3b, 0x2c, 0x86, 0x0f, 0xcb, 0xe1, 0x43, 0xbb, 0x24, 0xe8, 0x23, 0x8c,
-	0x55, 0x68, 0xc4, 0x6e, 0x16, 0x72, 0xd7, 0x53, 0xab, 0xbd, 0xca, 0xe0, 0x8c, 0x96, 0x12, 0x63,
-	0xa8, 0x89, 0xcf, 0x81, 0xa7, 0xd6, 0x7a, 0x95, 0x41, 0x93, 0xe6, 0xcf, 0x78, 0x0c, 0x4a, 0xb9,
-	0xa2, 0x5a, 0xcf, 0xc7, 0x5c, 0x96, 0xf6, 0xac, 0xc0, 0x8f, 0x98, 0xb7, 0xd8, 0x65, 0xe9, 0x9e,
-	0xc3, 0x7f, 0x40, 0xe7, 0xe8, 0x95, 0xa9, 0xa7, 0x5f, 0x96, 0xee, 0x37, 0x23, 0x32, 0x4b, 0xdb,
-	0xab, 0x2f, 0x34, 0x7e, 0x03, 0xb0, 0x5a, 0xbb, 0x51, 0xc4, 0x42, 0x27, 0xf0, 0xd4, 0x46, 0x6e,
-	0xa7, 0xb9, 0x8b, 0xe8, 0x5e, 0xff, 0xdf, 0x13, 0xa8, 0xc9, 0x57, 0x81, 0xcf, 0xa1, 0x79, 0x6f,
-	0x68, 0xe4, 0x5a, 0x37, 0x88, 0x86, 0x5e, 0xe0, 0x33, 0x50, 0x28, 0x99, 0xe9, 0x96, 0x4d, 0x28,
-	0xaa, 0xe0, 0x36, 0x40, 0xa9, 0x88, 0x86, 0x4e, 0xb0, 0x02, 0x35, 0xdd, 0xd0, 0x6d, 0x54, 0xc5,
-	0x4d, 0xa8, 0x53, 0x32, 0xd1, 0x96, 0xa8, 0x86, 0x3b, 0xd0, 0xb2, 0xe9, 0xc4, 0xb0, 0x26, 0x53,
-	0x5b, 0x37, 0x0d, 0x54, 0x97, 0x2d, 0xa7, 0xe6, 0xdd, 0x62, 0x4e, 0x6c, 0xa2, 0xa1, 0x53, 0x89,
-	0x12, 0x4a, 0x4d, 0x8a, 0x1a, 0x32, 0x33, 0x23, 0xb6, 0x63, 0xd9, 0x13, 0x9b, 0x20, 0x45, 0xca,
-	0xc5, 0x7d, 0x29, 0x9b, 0x52, 0x6a, 0x64, 0xbe, 0x93, 0x80, 0x5f, 0x01, 0xd2, 0x8d, 0xf7, 0xe6,
-	0x2d, 0x71, 0xa6, 0x37, 0x13, 0xdd, 0x98, 0x9a, 0x1a, 0x41, 0xad, 0xc2, 0xa0, 0xb5, 0x30, 0x0d,
-	0x8b, 0xa0, 0x73, 0x7c, 0x09, 0x78, 0xdf, 0xd0, 0xb9, 0x5a, 0x3a, 0x74, 0x62, 0xcc, 0x08, 0x6a,
-	0xcb, 0x5a, 0x19, 0x7f, 0x77, 0x4f, 0xe8, 0xd2, 0xa1, 0xc4, 0xba, 0x9f, 0xdb, 0xa8, 0x23, 0xa3,
-	0x45, 0xa4, 0xe0, 0x0d, 0xf2, 0xc1, 0x46, 0x08, 0x7f, 0x03, 0x2f, 0x0f, 0xa3, 0xd3, 0xb9, 0x69,
-	0x11, 0xf4, 0x52, 0xba, 0xb9, 0x25, 0x64, 0x31, 0x99, 0xeb, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8,
-	0x90, 0x1d, 0x6f, 0x74, 0xcb, 0x36, 0xe9, 0xd2, 0xb9, 0x36, 0xa9, 0x73, 0x4b, 0x96, 0xe8, 0xa2,
-	0xff, 0x16, 0x94, 0x19, 0x13, 0x96, 0x70, 0x05, 0xc3, 0x08, 0xaa, 0x7f, 0xb1, 0x2c, 0x3f, 0x83,
-	0x4d, 0x2a, 0x1f, 0xf1, 0x77, 0x00, 0x2b, 0x1e, 0x86, 0x6c, 0x25, 0x02, 0x1e, 0xe5, 0x87, 0xac,
-	0x49, 0x0f, 0x22, 0x7d, 0x0a, 0xca, 0x62, 0xfb, 0x6c, 0xf5, 0x2b, 0xa8, 0x7f, 0x72, 0xc3, 0x2d,
-	0xcb, 0x0b, 0xcf, 0x68, 0x21, 0x8e, 0x7a, 0x56, 0x9f, 0xf4, 0x7c, 0x0b, 0x8a, 0xc6, 0xc2, 0xff,
-	0xeb, 0x88, 0x41, 0xa7, 0xdc, 0xe7, 0x2a, 0xa3, 0x6e, 0xe4, 0x33, 0xdc, 0x05, 0x25, 0x15, 0x6e,
-	0x22, 0x6e, 0xf7, 0x9d, 0xf6, 0x1a, 0x5f, 0xc2, 0x29, 0x8b, 0x3c, 0x99, 0x29, 0x5a, 0xed, 0xd4,
-	0x57, 0x4d, 0x5e, 0x43, 0x7b, 0xc6, 0xc4, 0xbb, 0x2d, 0x4b, 0x32, 0xca, 0xd2, 0x6d, 0x28, 0xe4,
-	0xb2, 0x7f, 0x4b, 0xb9, 0x1b, 0x51, 0x88, 0xaf, 0xda, 0xfd, 0x11, 0xd0, 0x8c, 0x89, 0x9b, 0x20,
-	0x15, 0x3c, 0xc9, 0xae, 0x79, 0x22, 0x67, 0x3f, 0x59, 0xba, 0xdf, 0x83, 0x76, 0x3e, 0x2a, 0x5f,
-	0xcb, 0x60, 0x9f, 0x05, 0x6e, 0xc3, 0x49, 0xe0, 0xed, 0x90, 0x93, 0xc0, 0xeb, 0xff, 0x00, 0x9d,
-	0x47, 0x62, 0x1a, 0xf2, 0x94, 0x3d, 0x41, 0x7e, 0x03, 0x74, 0xe0, 0xf7, 0x2a, 0x13, 0x2c, 0xc5,
-	0x3d, 0x68, 0x25, 0x8f, 0x32, 0x87, 0xcf, 0xe8, 0x61, 0xa8, 0x1f, 0xc1, 0x79, 0x59, 0x15, 0xf3,
-	0x28, 0x65, 0x78, 0x0c, 0x8d, 0x22, 0x2f, 0xf1, 0xea, 0xa0, 0x35, 0x56, 0xcb, 0xbf, 0xf4, 0x71,
-	0x77, 0x5a, 0x82, 0xf8, 0x35, 0x28, 0x6b, 0x37, 0x75, 0x36, 0x3c, 0x29, 0xce, 0x82, 0x42, 0x1b,
-	0x6b, 0x37, 0xbd, 0xe3, 0x49, 0xe9, 0xb2, 0x5a, 0xba, 0x1c, 0x7f, 0x38, 0xb8, 0x1c, 0xad, 0x6d,
-	0x1c, 0xf3, 0x44, 0x60, 0x0d, 0x14, 0xca, 0xfc, 0x20, 0x15, 0x2c, 0xc1, 0xea, 0x73, 0x57, 0x63,
-	0xf7, 0xd9, 0x4c, 0xff, 0xc5, 0xa0, 0xf2, 0x73, 0xe5, 0xca, 0x84, 0x3e, 0x4f, 0xfc, 0xe1, 0x3a,
-	0x8b, 0x59, 0x12, 0x32, 0xcf, 0x67, 0xc9, 0xf0, 0xa3, 0xfb, 0x90, 0x04, 0xab, 0xb2, 0x4e, 0xde,
-	0xe6, 0x7f, 0xfe, 0xe4, 0x07, 0x62, 0xbd, 0x7d, 0x18, 0xae, 0xf8, 0x66, 0x74, 0x80, 0x8e, 0x0a,
-	0xb4, 0xb8, 0xd5, 0xd3, 0x91, 0x44, 0x1f, 0x8a, 0x4f, 0xc4, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x18, 0x6a, 0x79, 0xe7, 0x46, 0x06, 0x00, 0x00,
+	// 934 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4d, 0x6f, 0xdb, 0x46,
+	0x10, 0x8d, 0x2c, 0xc9, 0xa2, 0xc6, 0x8e, 0xbc, 0x59, 0xc7, 0xae, 0xa2, 0x22, 0xad, 0x4a, 0xf4,
+	0xa0, 0x5e, 0xa4, 0x56, 0xed, 0xa1, 0x87, 0x00, 0x85, 0x2c, 0xad, 0x65, 0xc1, 0x36, 0xa9, 0x2c,
+	0xe9, 0x20, 0xee, 0x85, 0xa0, 0xc9, 0x35, 0x45, 0x98, 0xe2, 0xb2, 0xe4, 0x2a, 0x88, 0x72, 0xeb,
+	0xb5, 0xfd, 0x61, 0xfd, 0x5b, 0xc5, 0xf2, 0xcb, 0xb2, 0x0c, 0x27, 0x40, 0x4e, 0xe2, 0x9b, 0x79,
+	0xf3, 0xe6, 0xcd, 0x60, 0x57, 0x0b, 0xaf, 0x22, 0xc6, 0xe2, 0x81, 0xb3, 0xb0, 0xfd, 0xd0, 0xe1,
+	0x2e, 0xb3, 0x92, 0x85, 0xbf, 0xec, 0x47, 0x31, 0x17, 0x1c, 0xef, 0xa6, 0x3f, 0x49, 0xa7, 0xb3,
+	0x45, 0x61, 0x1f, 0x58, 0x28, 0x32, 0x4e, 0xe7, 0x30, 0xcd, 0x45, 0x31, 0x8f, 0x78, 0x62, 0x07,
+	0x79, 0xf0, 0x7b, 0x8f, 0x73, 0x2f, 0x60, 0x83, 0x14, 0xdd, 0xac, 0x6e, 0x07, 0xc2, 0x5f, 0xb2,
+	0x44, 0xd8, 0xcb, 0x28, 0x23, 0xa8, 0xff, 0xd6, 0x01, 0x8d, 0x0b, 0xbd, 0x4b, 0x96, 0x24, 0xb6,
+	0xc7, 0xf0, 0x2f, 0x50, 0x13, 0xeb, 0x88, 0xb5, 0x2b, 0xdd, 0x4a, 0xaf, 0x35, 0x7c, 0x9d, 0x51,
+	0x93, 0xfe, 0x36, 0xaf, 0x6f, 0xae, 0x23, 0x46, 0x53, 0x2a, 0xfe, 0x1d, 0x9a, 0xa5, 0x74, 0x7b,
+	0xa7, 0x5b, 0xe9, 0xed, 0x0d, 0x3b, 0xfd, 0xac, 0x79, 0xbf, 0x68, 0xde, 0x37, 0x0b, 0x06, 0xbd,
+	0x27, 0xe3, 0x36, 0x34, 0x22, 0x7b, 0x1d, 0x70, 0xdb, 0x6d, 0x57, 0xbb, 0x95, 0xde, 0x3e, 0x2d,
+	0x20, 0xc6, 0x50, 0x13, 0x1f, 0x7d, 0xb7, 0x5d, 0xeb, 0x56, 0x7a, 0x4d, 0x9a, 0x7e, 0xe3, 0x21,
+	0x28, 0xc5, 0x88, 0xed, 0x7a, 0xda, 0xe6, 0xb8, 0xb0, 0x67, 0xf8, 0x5e, 0xc8, 0xdc, 0x79, 0x9e,
+	0xa5, 0x25, 0x0f, 0xff, 0x01, 0x07, 0x5b, 0x2b, 0x6b, 0xef, 0x3e, 0x2c, 0x2d, 0x27, 0x23, 0x32,
+	0x4b, 0x5b, 0xce, 0x03, 0x8c, 0x5f, 0x03, 0x38, 0x0b, 0x3b, 0x0c, 0x59, 0x60, 0xf9, 0x6e, 0xbb,
+	0x91, 0xda, 0x69, 0xe6, 0x91, 0x99, 0xab, 0xfe, 0xb7, 0x03, 0x35, 0xb9, 0x0a, 0xfc, 0x1c, 0x9a,
+	0x57, 0xda, 0x84, 0x9c, 0xce, 0x34, 0x32, 0x41, 0xcf, 0xf0, 0x3e, 0x28, 0x94, 0x4c, 0x67, 0x86,
+	0x49, 0x28, 0xaa, 0xe0, 0x16, 0x40, 0x81, 0xc8, 0x04, 0xed, 0x60, 0x05, 0x6a, 0x33, 0x6d, 0x66,
+	0xa2, 0x2a, 0x6e, 0x42, 0x9d, 0x92, 0xd1, 0xe4, 0x1a, 0xd5, 0xf0, 0x01, 0xec, 0x99, 0x74, 0xa4,
+	0x19, 0xa3, 0xb1, 0x39, 0xd3, 0x35, 0x54, 0x97, 0x92, 0x63, 0xfd, 0x72, 0x7e, 0x41, 0x4c, 0x32,
+	0x41, 0xbb, 0x92, 0x4a, 0x28, 0xd5, 0x29, 0x6a, 0xc8, 0xcc, 0x94, 0x98, 0x96, 0x61, 0x8e, 0x4c,
+	0x82, 0x14, 0x09, 0xe7, 0x57, 0x05, 0x6c, 0x4a, 0x38, 0x21, 0x17, 0x39, 0x04, 0xfc, 0x12, 0xd0,
+	0x4c, 0x7b, 0xa7, 0x9f, 0x13, 0x6b, 0x7c, 0x36, 0x9a, 0x69, 0x63, 0x7d, 0x42, 0xd0, 0x5e, 0x66,
+	0xd0, 0x98, 0xeb, 0x9a, 0x41, 0xd0, 0x73, 0x7c, 0x0c, 0xb8, 0x14, 0xb4, 0x4e, 0xae, 0x2d, 0x3a,
+	0xd2, 0xa6, 0x04, 0xb5, 0x64, 0xad, 0x8c, 0xbf, 0xbd, 0x22, 0xf4, 0xda, 0xa2, 0xc4, 0xb8, 0xba,
+	0x30, 0xd1, 0x81, 0x8c, 0x66, 0x91, 0x8c, 0xaf, 0x91, 0xf7, 0x26, 0x42, 0xf8, 0x08, 0x5e, 0x6c,
+	0x46, 0xc7, 0x17, 0xba, 0x41, 0xd0, 0x0b, 0xe9, 0xe6, 0x9c, 0x90, 0xf9, 0xe8, 0x62, 0xf6, 0x8e,
+	0x20, 0x8c, 0xbf, 0x81, 0x43, 0xa9, 0x78, 0x36, 0x33, 0x4c, 0x9d, 0x5e, 0x5b, 0xa7, 0x3a, 0xb5,
+	0xce, 0xc9, 0x35, 0x3a, 0x54, 0xdf, 0x80, 0x32, 0x65, 0xc2, 0x10, 0xb6, 0x60, 0x18, 0x41, 0xf5,
+	0x8e, 0xad, 0xd3, 0x33, 0xd8, 0xa4, 0xf2, 0x13, 0x7f, 0x07, 0xe0, 0xf0, 0x20, 0x60, 0x8e, 0xf0,
+	0x79, 0x98, 0x1e, 0xb2, 0x26, 0xdd, 0x88, 0xa8, 0x14, 0x94, 0xf9, 0xea, 0xc9, 0xea, 0x97, 0x50,
+	0xff, 0x60, 0x07, 0x2b, 0x96, 0x16, 0xee, 0xd3, 0x0c, 0x6c, 0x69, 0x56, 0x1f, 0x69, 0xbe, 0x01,
+	0x65, 0xc2, 0x82, 0xaf, 0x75, 0xf4, 0x77, 0x05, 0x0e, 0x8a, 0x81, 0x4e, 0xd6, 0xd4, 0x0e, 0x3d,
+	0x86, 0x3b, 0xa0, 0x24, 0xc2, 0x8e, 0xc5, 0x79, 0x29, 0x55, 0x62, 0x7c, 0x0c, 0xbb, 0x2c, 0x74,
+	0x65, 0x26, 0xd3, 0xca, 0xd1, 0x97, 0x5c, 0x4a, 0xcd, 0x25, 0x13, 0xb6, 0x6b, 0x0b, 0x3b, 0xbd,
+	0x2d, 0xfb, 0xb4, 0xc4, 0xea, 0x29, 0xb4, 0xa6, 0x4c, 0xbc, 0x5d, 0xb1, 0x78, 0x4d, 0x59, 0xb2,
+	0x0a, 0x84, 0xdc, 0xc4, 0x5f, 0x12, 0xe6, 0xed, 0x33, 0xf0, 0xc5, 0x59, 0x7e, 0x04, 0x34, 0x65,
+	0xe2, 0xcc, 0x4f, 0x04, 0x8f, 0xd7, 0xa7, 0x3c, 0x96, 0xbe, 0x1e, 0x6d, 0x44, 0xed, 0x42, 0x2b,
+	0x6d, 0x95, 0x8e, 0xac, 0xb1, 0x8f, 0x02, 0xb7, 0x60, 0xc7, 0x77, 0x73, 0xca, 0x8e, 0xef, 0xaa,
+	0x3f, 0xc0, 0xc1, 0x3d, 0x63, 0x1c, 0xf0, 0x84, 0x3d, 0xa2, 0xfc, 0x06, 0x68, 0xc3, 0xef, 0xc9,
+	0x5a, 0xb0, 0x04, 0x77, 0x61, 0x2f, 0xbe, 0x87, 0x29, 0x79, 0x9f, 0x6e, 0x86, 0xd4, 0x7f, 0x2a,
+	0xf0, 0xbc, 0x28, 0x8b, 0x78, 0x98, 0x30, 0x3c, 0x84, 0x46, 0x46, 0x90, 0xfc, 0x6a, 0x6f, 0x6f,
+	0xd8, 0x2e, 0x2e, 0xfc, 0xb6, 0x3c, 0x2d, 0x88, 0xf8, 0x15, 0x28, 0x0b, 0x3b, 0xb1, 0x96, 0x3c,
+	0xce, 0x4e, 0x8a, 0x42, 0x1b, 0x0b, 0x3b, 0xb9, 0xe4, 0x71, 0x61, 0xb3, 0x5a, 0xd8, 0xfc, 0xec,
+	0xd6, 0xcf, 0x72, 0x2f, 0x97, 0x79, 0x00, 0x7f, 0x0b, 0xcd, 0xc8, 0xf6, 0x98, 0x95, 0xf8, 0x9f,
+	0xb2, 0x3f, 0xd6, 0x3a, 0x55, 0x64, 0xc0, 0xf0, 0x3f, 0xa5, 0x67, 0xe2, 0x86, 0xf3, 0xbb, 0xa5,
+	0x1d, 0xdf, 0xe5, 0x9b, 0x2f, 0xb1, 0xea, 0xc1, 0xd1, 0x83, 0xa9, 0x4a, 0xc5, 0x21, 0x1c, 0xdd,
+	0x32, 0xe1, 0x2c, 0x98, 0x6b, 0xc5, 0xcc, 0xe1, 0xb1, 0x9b, 0x58, 0x0e, 0x5f, 0x85, 0x22, 0x57,
+	0x3f, 0xcc, 0x93, 0x34, 0xcb, 0x8d, 0x65, 0xea, 0x73, 0x8d, 0x86, 0xef, 0x37, 0x5e, 0x02, 0x63,
+	0x15, 0x45, 0x3c, 0x16, 0x78, 0x02, 0x0a, 0x65, 0x9e, 0x9f, 0x08, 0x16, 0xe3, 0xf6, 0x53, 0xef,
+	0x40, 0xe7, 0xc9, 0x8c, 0xfa, 0xac, 0x57, 0xf9, 0xb9, 0x32, 0x9c, 0x43, 0xb3, 0xcc, 0xe0, 0x31,
+	0x34, 0xc6, 0x3c, 0x0c, 0x99, 0x23, 0xbe, 0x5e, 0xf1, 0x44, 0x07, 0x95, 0xc7, 0x5e, 0x7f, 0xb1,
+	0x8e, 0x58, 0x1c, 0x30, 0xd7, 0x63, 0x71, 0xff, 0xd6, 0xbe, 0x89, 0x7d, 0xa7, 0xa8, 0x93, 0x8f,
+	0xe1, 0x9f, 0x3f, 0x79, 0xbe, 0x58, 0xac, 0x6e, 0xfa, 0x0e, 0x5f, 0x0e, 0x36, 0xa8, 0x83, 0x8c,
+	0x9a, 0x3d, 0x8a, 0xc9, 0x40, 0x52, 0x6f, 0xb2, 0x17, 0xf6, 0xd7, 0xff, 0x07, 0x00, 0xca, 0x0b,
+	0x94, 0xf5, 0x85, 0x07, 0x00, 0x00,
 }
@@ -289,6 +289,11 @@ type FilteredTransaction struct {
 	// Types that are valid to be assigned to Data:
 	//	*FilteredTransaction_TransactionActions
 	Data isFilteredTransaction_Data `protobuf_oneof:"Data"`
+	// The hashes of the private data written by the transaction,
+	// only populated by DeliverFilteredWithPvtDataHashes
+	PvtDataHashes []*CollectionPvtDataHash `protobuf:"bytes,5,rep,name=pvt_data_hashes,json=pvtDataHashes" json:"pvt_data_hashes,omitempty"`
+	// The checkpoint token to resume the deliver right after the transaction
+	Checkpoint []byte `protobuf:"bytes,6,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 }
 
 func (m *FilteredTransaction) Reset()                    { *m = FilteredTransaction{} }
@@ -339,6 +344,20 @@ func (m *FilteredTransaction) GetTransactionActions() *FilteredTransactionAction
 	return nil
 }
 
+func (m *FilteredTransaction) GetPvtDataHashes() []*CollectionPvtDataHash {
+	if m != nil {
+		return m.PvtDataHashes
+	}
+	return nil
+}
+
+func (m *FilteredTransaction) GetCheckpoint() []byte {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*FilteredTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _FilteredTransaction_OneofMarshaler, _FilteredTransaction_OneofUnmarshaler, _FilteredTransaction_OneofSizer, []interface{}{
@@ -737,7 +756,11 @@ type DeliverResponse struct {
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_BlockBatch
 	Type isDeliverResponse_Type `protobuf_oneof:"Type"`
+	// The checkpoint token to resume the deliver right after the last transaction
+	// of the delivered block, set for block and filtered block responses
+	Checkpoint []byte `protobuf:"bytes,5,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 }
 
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
@@ -756,10 +779,14 @@ type DeliverResponse_Block struct {
 type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,oneof"`
 }
+type DeliverResponse_BlockBatch struct {
+	BlockBatch *common.BlockBatch `protobuf:"bytes,4,opt,name=block_batch,json=blockBatch,oneof"`
+}
 
 func (*DeliverResponse_Status) isDeliverResponse_Type()        {}
 func (*DeliverResponse_Block) isDeliverResponse_Type()         {}
 func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
+func (*DeliverResponse_BlockBatch) isDeliverResponse_Type()    {}
 
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
@@ -789,12 +816,27 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetBlockBatch() *common.BlockBatch {
+	if x, ok := m.GetType().(*DeliverResponse_BlockBatch); ok {
+		return x.BlockBatch
+	}
+	return nil
+}
+
+func (m *DeliverResponse) GetCheckpoint() []byte {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_BlockBatch)(nil),
 	}
 }
 
@@ -815,6 +857,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
 			return err
 		}
+	case *DeliverResponse_BlockBatch:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockBatch); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -848,6 +895,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_FilteredBlock{msg}
 		return true, err
+	case 4: // Type.block_batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common.BlockBatch)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_BlockBatch{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -870,6 +925,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_BlockBatch:
+		s := proto.Size(x.BlockBatch)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -877,6 +937,40 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// CollectionPvtDataHash is the hash of the private data a transaction
+// wrote to a collection of a chaincode
+type CollectionPvtDataHash struct {
+	Namespace      string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collection_name,json=collectionName" json:"collection_name,omitempty"`
+	PvtRwsetHash   []byte `protobuf:"bytes,3,opt,name=pvt_rwset_hash,json=pvtRwsetHash,proto3" json:"pvt_rwset_hash,omitempty"`
+}
+
+func (m *CollectionPvtDataHash) Reset()                    { *m = CollectionPvtDataHash{} }
+func (m *CollectionPvtDataHash) String() string            { return proto.CompactTextString(m) }
+func (*CollectionPvtDataHash) ProtoMessage()               {}
+func (*CollectionPvtDataHash) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{12} }
+
+func (m *CollectionPvtDataHash) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CollectionPvtDataHash) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *CollectionPvtDataHash) GetPvtRwsetHash() []byte {
+	if m != nil {
+		return m.PvtRwsetHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
@@ -890,6 +984,7 @@ func init() {
 	proto.RegisterType((*SignedEvent)(nil), "protos.SignedEvent")
 	proto.RegisterType((*Event)(nil), "protos.Event")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
+	proto.RegisterType((*CollectionPvtDataHash)(nil), "protos.CollectionPvtDataHash")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -1008,6 +1103,9 @@ type DeliverClient interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received.
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of **filtered** block replies, whose transactions carry the hashes of the private data they wrote, is received.
+	DeliverFilteredWithPvtDataHashes(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredWithPvtDataHashesClient, error)
 }
 
 type deliverClient struct {
@@ -1080,6 +1178,37 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverFilteredWithPvtDataHashes(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredWithPvtDataHashesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deliver_serviceDesc.Streams[2], c.cc, "/protos.Deliver/DeliverFilteredWithPvtDataHashes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverFilteredWithPvtDataHashesClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverFilteredWithPvtDataHashesClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverFilteredWithPvtDataHashesClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverFilteredWithPvtDataHashesClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverFilteredWithPvtDataHashesClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Deliver service
 
 type DeliverServer interface {
@@ -1089,6 +1218,9 @@ type DeliverServer interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received.
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of **filtered** block replies, whose transactions carry the hashes of the private data they wrote, is received.
+	DeliverFilteredWithPvtDataHashes(Deliver_DeliverFilteredWithPvtDataHashesServer) error
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -1147,6 +1279,32 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverFilteredWithPvtDataHashes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverFilteredWithPvtDataHashes(&deliverDeliverFilteredWithPvtDataHashesServer{stream})
+}
+
+type Deliver_DeliverFilteredWithPvtDataHashesServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverFilteredWithPvtDataHashesServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverFilteredWithPvtDataHashesServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverFilteredWithPvtDataHashesServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
@@ -1164,6 +1322,12 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverFilteredWithPvtDataHashes",
+			Handler:       _Deliver_DeliverFilteredWithPvtDataHashes_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
@@ -1171,69 +1335,77 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 1009 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xb6, 0x62, 0xc7, 0xb1, 0x8e, 0xe3, 0xd4, 0xd9, 0xb4, 0xa9, 0xc6, 0x05, 0x5a, 0xc4, 0xc0,
-	0x04, 0x2e, 0xec, 0x60, 0x3a, 0x0c, 0xd3, 0x0b, 0x98, 0xf8, 0x27, 0xc8, 0x34, 0x4d, 0x32, 0x1b,
-	0x87, 0x8b, 0x5e, 0xa0, 0x59, 0xcb, 0x6b, 0x59, 0xad, 0x2c, 0x79, 0x76, 0xd7, 0x99, 0xe4, 0x11,
-	0x78, 0x03, 0xde, 0x80, 0x19, 0x5e, 0x85, 0x17, 0xe2, 0x92, 0xd1, 0x6a, 0x57, 0x52, 0x6c, 0xda,
-	0x21, 0x57, 0xf6, 0xf9, 0xfb, 0xf6, 0xfc, 0x7c, 0x67, 0x57, 0xb0, 0xbf, 0xa4, 0x94, 0x75, 0xe8,
-	0x0d, 0x8d, 0x04, 0x6f, 0x2f, 0x59, 0x2c, 0x62, 0x54, 0x95, 0x3f, 0xbc, 0x75, 0xe0, 0xc5, 0x8b,
-	0x45, 0x1c, 0x75, 0xd2, 0x9f, 0xd4, 0xd8, 0x7a, 0xee, 0xc7, 0xb1, 0x1f, 0xd2, 0x8e, 0x94, 0x26,
-	0xab, 0x59, 0x47, 0x04, 0x0b, 0xca, 0x05, 0x59, 0x2c, 0x95, 0x43, 0x4b, 0x02, 0x7a, 0x73, 0x12,
-	0x44, 0x5e, 0x3c, 0xa5, 0xae, 0x84, 0x56, 0xb6, 0x43, 0x69, 0x13, 0x8c, 0x44, 0x9c, 0x78, 0x22,
-	0xd0, 0xa0, 0xf6, 0x25, 0xec, 0xf6, 0x75, 0x00, 0xa6, 0x3e, 0xfa, 0x1c, 0x76, 0x73, 0x80, 0x60,
-	0x6a, 0x19, 0x2f, 0x8c, 0x23, 0x13, 0xd7, 0x33, 0xdd, 0x68, 0x8a, 0x3e, 0x05, 0x90, 0xc8, 0x6e,
-	0x44, 0x16, 0xd4, 0xda, 0x92, 0x0e, 0xa6, 0xd4, 0x9c, 0x93, 0x05, 0xb5, 0xff, 0x34, 0xa0, 0x36,
-	0x8a, 0x04, 0x65, 0x94, 0x0b, 0x74, 0xac, 0x7d, 0xc5, 0xdd, 0x92, 0x4a, 0xb0, 0xbd, 0xee, 0x7e,
-	0x7a, 0x34, 0x6f, 0x0f, 0x13, 0xcb, 0xf8, 0x6e, 0x49, 0x55, 0x78, 0xf2, 0x17, 0x0d, 0x00, 0xe5,
-	0x09, 0x30, 0xea, 0xbb, 0x41, 0x34, 0x8b, 0xe5, 0x29, 0xf5, 0xee, 0x63, 0x1d, 0x59, 0x4c, 0xd9,
-	0x29, 0xe1, 0xa6, 0x57, 0x90, 0x47, 0xd1, 0x2c, 0x46, 0x16, 0xec, 0x48, 0xdd, 0x68, 0x60, 0x95,
-	0x65, 0x82, 0x5a, 0xec, 0x99, 0xb0, 0xa3, 0x9c, 0xec, 0x97, 0x50, 0xc3, 0xd4, 0x0f, 0xb8, 0xa0,
-	0x0c, 0x1d, 0x41, 0x35, 0x9d, 0x84, 0x65, 0xbc, 0x28, 0x1f, 0xd5, 0xbb, 0x4d, 0x7d, 0x94, 0x2e,
-	0x05, 0x2b, 0xbb, 0xfd, 0x06, 0x4c, 0x4c, 0xdf, 0x51, 0xd9, 0x44, 0xf4, 0x05, 0x6c, 0x89, 0x5b,
-	0x59, 0x57, 0xbd, 0x7b, 0xa0, 0x43, 0xc6, 0x79, 0x97, 0xf1, 0x96, 0xb8, 0x45, 0xcf, 0xc0, 0xa4,
-	0x8c, 0xc5, 0xcc, 0x5d, 0x70, 0x5f, 0xf5, 0xab, 0x26, 0x15, 0x6f, 0xb8, 0x6f, 0x7f, 0x0f, 0x70,
-	0x1d, 0xb1, 0x87, 0xa7, 0xf1, 0x87, 0x01, 0x8d, 0xd3, 0x20, 0x4c, 0xb4, 0xd3, 0x5e, 0x18, 0x7b,
-	0xef, 0x93, 0xb9, 0x78, 0x73, 0x12, 0x45, 0x34, 0xcc, 0x07, 0x67, 0x2a, 0xcd, 0x68, 0x8a, 0x0e,
-	0xa1, 0x1a, 0xad, 0x16, 0x13, 0xca, 0x64, 0x0a, 0x15, 0xac, 0x24, 0x74, 0x09, 0x4f, 0x66, 0x0a,
-	0xc7, 0x2d, 0xf0, 0x83, 0x5b, 0x15, 0x99, 0xc1, 0x33, 0x9d, 0x81, 0x3e, 0xac, 0x58, 0xdd, 0xe3,
-	0xd9, 0xa6, 0x92, 0xdb, 0xff, 0x18, 0x70, 0xf0, 0x1f, 0xde, 0x08, 0x41, 0x45, 0xdc, 0x66, 0xa9,
-	0xc9, 0xff, 0xe8, 0x2b, 0xa8, 0x48, 0x6a, 0x6c, 0x49, 0x6a, 0xa0, 0xb6, 0x62, 0xbc, 0x43, 0xc9,
-	0x94, 0x32, 0xc9, 0x0d, 0x69, 0x47, 0xa7, 0x80, 0xc4, 0xad, 0x7b, 0x43, 0xc2, 0x60, 0x4a, 0x12,
-	0x30, 0x37, 0x99, 0xb6, 0x9c, 0xed, 0x5e, 0xd7, 0xca, 0x1a, 0x7f, 0xfb, 0x6b, 0xe6, 0xd0, 0x4f,
-	0xd8, 0xd0, 0x14, 0x6b, 0x1a, 0x74, 0x0d, 0x07, 0x85, 0x22, 0xdd, 0xbc, 0xd6, 0x64, 0x82, 0xf6,
-	0x47, 0x6a, 0x3d, 0x49, 0x3d, 0x9d, 0x12, 0x46, 0x62, 0x43, 0xdb, 0xab, 0x42, 0x65, 0x40, 0x04,
-	0xb1, 0xdf, 0x41, 0xeb, 0xc3, 0xb1, 0xe8, 0x0c, 0xf6, 0x73, 0x6e, 0xeb, 0xa3, 0xd3, 0x41, 0x3f,
-	0x5f, 0x3f, 0x3a, 0xa3, 0x78, 0x1a, 0x5c, 0xe0, 0xb8, 0x42, 0xb3, 0xdf, 0xc2, 0xd3, 0x0f, 0x38,
-	0xa3, 0x9f, 0xe0, 0xd1, 0xda, 0x35, 0xa0, 0x38, 0x7a, 0xb8, 0xb1, 0x41, 0x72, 0x09, 0xf1, 0x9e,
-	0x77, 0x4f, 0xb6, 0x5f, 0x43, 0xfd, 0x2a, 0xf0, 0x23, 0x3a, 0x95, 0x22, 0xfa, 0x04, 0x4c, 0x1e,
-	0xf8, 0x11, 0x11, 0x2b, 0x96, 0x6e, 0xf1, 0x2e, 0xce, 0x15, 0xe8, 0x33, 0xb5, 0xe4, 0xbd, 0x3b,
-	0x41, 0xb9, 0x9c, 0xe4, 0x2e, 0x2e, 0x68, 0xec, 0xbf, 0xcb, 0xb0, 0x9d, 0xe2, 0xb4, 0xa1, 0xa6,
-	0xa9, 0xae, 0x12, 0xca, 0x08, 0xae, 0x37, 0xd1, 0x29, 0xe1, 0xcc, 0x07, 0x7d, 0x09, 0xdb, 0x93,
-	0x84, 0xdb, 0x6a, 0xff, 0x1b, 0x9a, 0x1e, 0x92, 0xf0, 0x4e, 0x09, 0xa7, 0x56, 0x74, 0xb2, 0x59,
-	0x6e, 0xf9, 0x63, 0xe5, 0x3a, 0xa5, 0xf5, 0x82, 0xd1, 0xb7, 0x60, 0x32, 0xbd, 0xd5, 0x8a, 0x0d,
-	0xfb, 0x79, 0x6a, 0xca, 0xe0, 0x94, 0x70, 0xee, 0x85, 0x5e, 0x02, 0xac, 0xb2, 0xcd, 0xb5, 0xb6,
-	0x65, 0x0c, 0xd2, 0x31, 0xf9, 0x4e, 0x3b, 0x25, 0x5c, 0xf0, 0x43, 0x3f, 0xc2, 0x5e, 0xb6, 0x6e,
-	0x69, 0x6d, 0x3b, 0x32, 0xf2, 0xc9, 0x3a, 0x01, 0x74, 0x8d, 0x8d, 0xd9, 0xbd, 0x2d, 0x4f, 0x6e,
-	0x36, 0x46, 0x89, 0x88, 0x99, 0x55, 0x95, 0x9d, 0xd6, 0x22, 0xfa, 0x01, 0xcc, 0xec, 0x45, 0xb0,
-	0x6a, 0x12, 0xb4, 0xd5, 0x4e, 0xdf, 0x8c, 0xb6, 0x7e, 0x33, 0xda, 0x63, 0xed, 0x81, 0x73, 0x67,
-	0x64, 0x43, 0x43, 0x84, 0xdc, 0xf5, 0x28, 0x13, 0xee, 0x9c, 0xf0, 0xb9, 0x65, 0x4a, 0xe4, 0xba,
-	0x08, 0x79, 0x9f, 0x32, 0xe1, 0x10, 0x3e, 0xef, 0xed, 0xa8, 0x19, 0xda, 0x7f, 0x19, 0xf0, 0x68,
-	0x40, 0xc3, 0xe0, 0x86, 0x32, 0x4c, 0xf9, 0x32, 0x8e, 0x38, 0x4d, 0xae, 0x2d, 0x2e, 0x88, 0x58,
-	0x71, 0x75, 0xc5, 0xef, 0xe9, 0x41, 0x5d, 0x49, 0xad, 0x53, 0xc2, 0xca, 0xfe, 0x7f, 0x27, 0xba,
-	0xd9, 0xa5, 0xf2, 0x43, 0xba, 0x94, 0xec, 0x63, 0x72, 0x79, 0x7c, 0x73, 0x0d, 0x66, 0xf6, 0xca,
-	0xa0, 0x5d, 0xa8, 0xe1, 0xe1, 0xcf, 0xa3, 0xab, 0xf1, 0x10, 0x37, 0x4b, 0xc8, 0x84, 0xed, 0xde,
-	0xd9, 0x45, 0xff, 0x75, 0xd3, 0x40, 0x0d, 0x30, 0xfb, 0xce, 0xc9, 0xe8, 0xbc, 0x7f, 0x31, 0x18,
-	0x36, 0xb7, 0x12, 0x11, 0x0f, 0x7f, 0x19, 0xf6, 0xc7, 0xa3, 0x8b, 0xf3, 0x66, 0x19, 0xed, 0x43,
-	0xe3, 0x74, 0x74, 0x36, 0x1e, 0xe2, 0xe1, 0x20, 0x0d, 0xa8, 0x74, 0x5f, 0x41, 0x55, 0xc2, 0x72,
-	0x74, 0x0c, 0x95, 0xfe, 0x9c, 0x08, 0x94, 0x5d, 0xfe, 0x85, 0xb5, 0x69, 0x35, 0xee, 0xbd, 0x74,
-	0x76, 0xe9, 0xc8, 0x38, 0x36, 0xba, 0xbf, 0x1b, 0xb0, 0xa3, 0xfa, 0x87, 0x5e, 0xe5, 0x7f, 0x9b,
-	0xba, 0x13, 0xc3, 0xe8, 0x86, 0x86, 0xf1, 0x92, 0xb6, 0x9e, 0xea, 0xe8, 0xb5, 0x6e, 0xa7, 0x38,
-	0xa8, 0x97, 0x8d, 0x41, 0xf7, 0xe2, 0xc1, 0x18, 0xbd, 0xdf, 0xc0, 0x8e, 0x99, 0xdf, 0x9e, 0xdf,
-	0x2d, 0x29, 0x0b, 0xe9, 0xd4, 0xa7, 0xac, 0x3d, 0x23, 0x13, 0x16, 0x78, 0x3a, 0x2c, 0xf9, 0x6a,
-	0xe8, 0x35, 0xd2, 0x5a, 0x2f, 0x89, 0xf7, 0x9e, 0xf8, 0xf4, 0xed, 0xd7, 0x7e, 0x20, 0xe6, 0xab,
-	0x49, 0x72, 0x56, 0xa7, 0x10, 0xd9, 0x49, 0x23, 0xd3, 0xcf, 0x13, 0xde, 0x49, 0x22, 0x27, 0xe9,
-	0xf7, 0xcc, 0x77, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xfc, 0x9e, 0x06, 0xeb, 0x08, 0x00,
-	0x00,
+	// 1151 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdb, 0x72, 0xdb, 0x44,
+	0x18, 0xf6, 0x29, 0x8e, 0xf5, 0xfb, 0x10, 0x67, 0xd3, 0x83, 0xc6, 0xa5, 0x34, 0x88, 0x53, 0xe0,
+	0xc2, 0x2e, 0xa6, 0x30, 0x4c, 0x2f, 0x60, 0xea, 0x43, 0xb1, 0xe9, 0x29, 0xb3, 0x71, 0x61, 0xa6,
+	0x17, 0x68, 0xd6, 0xf2, 0x5a, 0x56, 0x2b, 0x4b, 0x9a, 0xdd, 0xb5, 0x49, 0x2e, 0x99, 0xe1, 0x21,
+	0x78, 0x03, 0x1e, 0x86, 0x77, 0x80, 0x57, 0x61, 0x76, 0xa5, 0x95, 0x14, 0xa7, 0xed, 0x90, 0x2b,
+	0xfb, 0x3f, 0xee, 0x7f, 0xfa, 0xfe, 0x5f, 0x70, 0x18, 0x51, 0xca, 0x7a, 0x74, 0x4b, 0x03, 0xc1,
+	0xbb, 0x11, 0x0b, 0x45, 0x88, 0xaa, 0xea, 0x87, 0x77, 0x8e, 0x9c, 0x70, 0xbd, 0x0e, 0x83, 0x5e,
+	0xfc, 0x13, 0x0b, 0x3b, 0xf7, 0xdc, 0x30, 0x74, 0x7d, 0xda, 0x53, 0xd4, 0x7c, 0xb3, 0xec, 0x09,
+	0x6f, 0x4d, 0xb9, 0x20, 0xeb, 0x28, 0x51, 0xe8, 0x28, 0x87, 0xce, 0x8a, 0x78, 0x81, 0x13, 0x2e,
+	0xa8, 0xad, 0x5c, 0x27, 0xb2, 0x5b, 0x4a, 0x26, 0x18, 0x09, 0x38, 0x71, 0x84, 0xa7, 0x9d, 0x5a,
+	0xa7, 0xd0, 0x18, 0x6a, 0x03, 0x4c, 0x5d, 0xf4, 0x11, 0x34, 0x32, 0x07, 0xde, 0xc2, 0x2c, 0x1e,
+	0x17, 0x4f, 0x0c, 0x5c, 0x4f, 0x79, 0xd3, 0x05, 0xba, 0x0b, 0xa0, 0x3c, 0xdb, 0x01, 0x59, 0x53,
+	0xb3, 0xa4, 0x14, 0x0c, 0xc5, 0x79, 0x4e, 0xd6, 0xd4, 0xfa, 0xab, 0x08, 0xb5, 0x69, 0x20, 0x28,
+	0xa3, 0x5c, 0xa0, 0xfb, 0x5a, 0x57, 0x5c, 0x44, 0x54, 0x39, 0x6b, 0xf5, 0x0f, 0xe3, 0xa7, 0x79,
+	0x77, 0x2c, 0x25, 0xb3, 0x8b, 0x88, 0x26, 0xe6, 0xf2, 0x2f, 0x1a, 0x01, 0xca, 0x02, 0x60, 0xd4,
+	0xb5, 0xbd, 0x60, 0x19, 0xaa, 0x57, 0xea, 0xfd, 0x1b, 0xda, 0x32, 0x1f, 0xf2, 0xa4, 0x80, 0xdb,
+	0x4e, 0x8e, 0x9e, 0x06, 0xcb, 0x10, 0x99, 0xb0, 0xaf, 0x78, 0xd3, 0x91, 0x59, 0x56, 0x01, 0x6a,
+	0x72, 0x60, 0xc0, 0x7e, 0xa2, 0x64, 0x3d, 0x80, 0x1a, 0xa6, 0xae, 0xc7, 0x05, 0x65, 0xe8, 0x04,
+	0xaa, 0x71, 0x27, 0xcc, 0xe2, 0x71, 0xf9, 0xa4, 0xde, 0x6f, 0xeb, 0xa7, 0x74, 0x2a, 0x38, 0x91,
+	0x5b, 0xcf, 0xc0, 0xc0, 0xf4, 0x35, 0x55, 0x45, 0x44, 0x1f, 0x43, 0x49, 0x9c, 0xab, 0xbc, 0xea,
+	0xfd, 0x23, 0x6d, 0x32, 0xcb, 0xaa, 0x8c, 0x4b, 0xe2, 0x1c, 0xdd, 0x01, 0x83, 0x32, 0x16, 0x32,
+	0x7b, 0xcd, 0xdd, 0xa4, 0x5e, 0x35, 0xc5, 0x78, 0xc6, 0x5d, 0xeb, 0x5b, 0x80, 0x97, 0x01, 0xbb,
+	0x7e, 0x18, 0x7f, 0x16, 0xa1, 0xf9, 0xd8, 0xf3, 0x25, 0x77, 0x31, 0xf0, 0x43, 0xe7, 0x8d, 0xec,
+	0x8b, 0xb3, 0x22, 0x41, 0x40, 0xfd, 0xac, 0x71, 0x46, 0xc2, 0x99, 0x2e, 0xd0, 0x2d, 0xa8, 0x06,
+	0x9b, 0xf5, 0x9c, 0x32, 0x15, 0x42, 0x05, 0x27, 0x14, 0x3a, 0x85, 0x9b, 0xcb, 0xc4, 0x8f, 0x9d,
+	0x9b, 0x0f, 0x6e, 0x56, 0x54, 0x04, 0x77, 0x74, 0x04, 0xfa, 0xb1, 0x7c, 0x76, 0x37, 0x96, 0x57,
+	0x99, 0xdc, 0xfa, 0xb7, 0x04, 0x47, 0x6f, 0xd1, 0x46, 0x08, 0x2a, 0xe2, 0x3c, 0x0d, 0x4d, 0xfd,
+	0x47, 0x9f, 0x41, 0x45, 0x8d, 0x46, 0x49, 0x8d, 0x06, 0xea, 0x26, 0x13, 0x3f, 0xa1, 0x64, 0x41,
+	0x99, 0x9a, 0x0d, 0x25, 0x47, 0x8f, 0x01, 0x89, 0x73, 0x7b, 0x4b, 0x7c, 0x6f, 0x41, 0xa4, 0x33,
+	0x5b, 0x76, 0x5b, 0xf5, 0xb6, 0xd5, 0x37, 0xd3, 0xc2, 0x9f, 0xff, 0x9c, 0x2a, 0x0c, 0xe5, 0x34,
+	0xb4, 0xc5, 0x0e, 0x07, 0xbd, 0x84, 0xa3, 0x5c, 0x92, 0x76, 0x96, 0xab, 0xec, 0xa0, 0xf5, 0x9e,
+	0x5c, 0x1f, 0xc5, 0x9a, 0x93, 0x02, 0x46, 0xe2, 0x0a, 0x17, 0x8d, 0xe1, 0x20, 0xda, 0x0a, 0x7b,
+	0x41, 0x04, 0xb1, 0x57, 0x84, 0xaf, 0x28, 0x37, 0xf7, 0x54, 0xf9, 0xee, 0xa6, 0x23, 0x1b, 0xfa,
+	0x7e, 0x3c, 0x34, 0xa7, 0x5b, 0x31, 0x22, 0x82, 0x4c, 0x08, 0x5f, 0xe1, 0x66, 0x94, 0x11, 0x94,
+	0xa3, 0x0f, 0x65, 0x0b, 0xa9, 0xf3, 0x26, 0x0a, 0xbd, 0x40, 0x98, 0xd5, 0xe3, 0xe2, 0x49, 0x03,
+	0xe7, 0x38, 0x83, 0x2a, 0x54, 0xa4, 0xb6, 0xf5, 0x1a, 0x3a, 0xef, 0x0e, 0x11, 0x3d, 0x85, 0xc3,
+	0x0c, 0x42, 0x3a, 0xc3, 0x78, 0x9e, 0xee, 0xed, 0x66, 0x98, 0x22, 0x29, 0x36, 0xce, 0x41, 0x29,
+	0xf1, 0x66, 0xbd, 0x82, 0xdb, 0xef, 0x50, 0x46, 0x3f, 0xc0, 0xc1, 0xce, 0xb6, 0x49, 0xa0, 0x70,
+	0xeb, 0x0a, 0x50, 0x15, 0xd6, 0x71, 0xcb, 0xb9, 0x44, 0x5b, 0x4f, 0xa0, 0x7e, 0xe6, 0xb9, 0x01,
+	0x5d, 0x28, 0x12, 0x7d, 0x00, 0x06, 0xf7, 0xdc, 0x80, 0x88, 0x0d, 0x8b, 0x97, 0x45, 0x03, 0x67,
+	0x0c, 0x59, 0x1c, 0xf5, 0xc6, 0xe0, 0x42, 0x50, 0xae, 0x06, 0xa6, 0x81, 0x73, 0x1c, 0xeb, 0xef,
+	0x32, 0xec, 0xc5, 0x7e, 0xba, 0x50, 0xd3, 0x88, 0x4a, 0x02, 0x4a, 0x71, 0xa4, 0x01, 0x3f, 0x29,
+	0xe0, 0x54, 0x07, 0x7d, 0x0a, 0x7b, 0x73, 0x09, 0xa1, 0x64, 0xcd, 0x34, 0xf5, 0x14, 0x2a, 0x5c,
+	0x4d, 0x0a, 0x38, 0x96, 0xa2, 0x47, 0x57, 0xd3, 0x2d, 0xbf, 0x2f, 0xdd, 0x49, 0x61, 0x37, 0x61,
+	0xf4, 0x15, 0x18, 0x4c, 0x2f, 0x8f, 0x64, 0xe8, 0x0e, 0xb3, 0xd0, 0x12, 0xc1, 0xa4, 0x80, 0x33,
+	0x2d, 0xf4, 0x00, 0x60, 0x93, 0x2e, 0x08, 0x73, 0x4f, 0xd9, 0x20, 0x6d, 0x93, 0xad, 0x8e, 0x49,
+	0x01, 0xe7, 0xf4, 0xd0, 0xf7, 0xd0, 0x4a, 0x51, 0x1d, 0xe7, 0xb6, 0xaf, 0x2c, 0x6f, 0xee, 0x0e,
+	0x80, 0xce, 0xb1, 0xb9, 0xcc, 0x33, 0xd4, 0x02, 0x65, 0x94, 0x88, 0x90, 0x25, 0x63, 0xa8, 0x49,
+	0xf4, 0x1d, 0x18, 0xe9, 0xe1, 0x31, 0x6b, 0xca, 0x69, 0xa7, 0x1b, 0x9f, 0xa6, 0xae, 0x3e, 0x4d,
+	0xdd, 0x99, 0xd6, 0xc0, 0x99, 0x32, 0xb2, 0xa0, 0x29, 0x7c, 0x6e, 0x3b, 0x94, 0x09, 0x05, 0x12,
+	0xd3, 0x50, 0x9e, 0xeb, 0xc2, 0xe7, 0x43, 0xca, 0x84, 0xc4, 0xc0, 0x60, 0x3f, 0xe9, 0xa1, 0xf5,
+	0x7b, 0x09, 0x0e, 0x46, 0xd4, 0xf7, 0xb6, 0x94, 0x61, 0xca, 0xa3, 0x30, 0xe0, 0x54, 0x6e, 0x47,
+	0x2e, 0x88, 0xd8, 0xf0, 0xe4, 0x92, 0xb4, 0x74, 0xa3, 0xce, 0x14, 0x77, 0x52, 0xc0, 0x89, 0xfc,
+	0xff, 0x76, 0xf4, 0x6a, 0x95, 0xca, 0xd7, 0xaa, 0xd2, 0x37, 0x50, 0x57, 0x66, 0xf6, 0x9c, 0x08,
+	0x67, 0x95, 0x34, 0x14, 0x5d, 0x7a, 0x6c, 0x20, 0x25, 0xb2, 0x39, 0xf3, 0x94, 0xda, 0x81, 0xf9,
+	0xde, 0xdb, 0x60, 0x2e, 0x57, 0x9f, 0xf5, 0x47, 0x11, 0x6e, 0xbe, 0x75, 0x6f, 0x48, 0xa4, 0xc8,
+	0xeb, 0xcb, 0x23, 0xe2, 0x50, 0xbd, 0xea, 0x53, 0x06, 0xfa, 0x1c, 0x0e, 0x9c, 0xd4, 0x2c, 0x7f,
+	0xa6, 0x5b, 0x19, 0x5b, 0xde, 0x6a, 0xf4, 0x09, 0xb4, 0xe4, 0xda, 0x62, 0xbf, 0x71, 0x9a, 0xb4,
+	0xa4, 0xac, 0x82, 0x69, 0x44, 0x5b, 0x81, 0x25, 0x53, 0x3e, 0xf6, 0xe5, 0x4b, 0x30, 0xd2, 0x53,
+	0x8d, 0x1a, 0x50, 0xc3, 0xe3, 0x1f, 0xa7, 0x67, 0xb3, 0x31, 0x6e, 0x17, 0x90, 0x01, 0x7b, 0x83,
+	0xa7, 0x2f, 0x86, 0x4f, 0xda, 0x45, 0xd4, 0x04, 0x63, 0x38, 0x79, 0x34, 0x7d, 0x3e, 0x7c, 0x31,
+	0x1a, 0xb7, 0x4b, 0x92, 0xc4, 0xe3, 0x9f, 0xc6, 0xc3, 0xd9, 0xf4, 0xc5, 0xf3, 0x76, 0x19, 0x1d,
+	0x42, 0xf3, 0xf1, 0xf4, 0xe9, 0x6c, 0x8c, 0xc7, 0xa3, 0xd8, 0xa0, 0xd2, 0x7f, 0x08, 0x55, 0xe5,
+	0x96, 0xa3, 0xfb, 0x50, 0x19, 0xae, 0x88, 0x40, 0xe9, 0x05, 0xcd, 0x2d, 0x85, 0x4e, 0xf3, 0xd2,
+	0xe7, 0x82, 0x55, 0x38, 0x29, 0xde, 0x2f, 0xf6, 0xff, 0x29, 0xc2, 0x7e, 0x32, 0x1d, 0xe8, 0x61,
+	0xf6, 0xb7, 0xad, 0x4b, 0x3f, 0x0e, 0xb6, 0xd4, 0x0f, 0x23, 0xda, 0xb9, 0xad, 0xad, 0x77, 0x66,
+	0x29, 0xf6, 0x83, 0x06, 0xe9, 0x90, 0xe9, 0x4e, 0x5f, 0xdf, 0xc7, 0x19, 0x1c, 0xef, 0xf8, 0xf8,
+	0xc5, 0x13, 0xab, 0xd3, 0x4b, 0x8b, 0xfd, 0xba, 0x4e, 0x07, 0xbf, 0x82, 0x15, 0x32, 0xb7, 0xbb,
+	0xba, 0x88, 0x28, 0xf3, 0xe9, 0xc2, 0xa5, 0xac, 0xbb, 0x24, 0x73, 0xe6, 0x39, 0xda, 0x2c, 0xa2,
+	0x94, 0x0d, 0x9a, 0x71, 0x01, 0x4f, 0x89, 0xf3, 0x86, 0xb8, 0xf4, 0xd5, 0x17, 0xae, 0x27, 0x56,
+	0x9b, 0xb9, 0x7c, 0xab, 0x97, 0xb3, 0xec, 0xc5, 0x96, 0xf1, 0x87, 0x23, 0xef, 0x49, 0xcb, 0x79,
+	0xfc, 0xa5, 0xf9, 0xf5, 0x7f, 0x03, 0x00, 0x94, 0x0c, 0x55, 0x4f, 0x85, 0x0a, 0x00, 0x00,
 }
@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/lifecycle/chaincode_definition.proto
+
+/*
+Package lifecycle is a generated protocol buffer package.
+
+It is generated from these files:
+	peer/lifecycle/chaincode_definition.proto
+	peer/lifecycle/db.proto
+
+It has these top-level messages:
+	ChaincodeEndorsementInfo
+	ChaincodeValidationInfo
+	ApplicationPolicy
+	StateMetadata
+	StateData
+*/
+package lifecycle
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common1 "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ChaincodeEndorsementInfo is (most) everything the peer needs to know in order
+// to execute a chaincode
+type ChaincodeEndorsementInfo struct {
+	Version           string `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	InitRequired      bool   `protobuf:"varint,2,opt,name=init_required,json=initRequired" json:"init_required,omitempty"`
+	EndorsementPlugin string `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin" json:"endorsement_plugin,omitempty"`
+}
+
+func (m *ChaincodeEndorsementInfo) Reset()                    { *m = ChaincodeEndorsementInfo{} }
+func (m *ChaincodeEndorsementInfo) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEndorsementInfo) ProtoMessage()               {}
+func (*ChaincodeEndorsementInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ChaincodeEndorsementInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeEndorsementInfo) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func (m *ChaincodeEndorsementInfo) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+// ChaincodeValidationInfo is (most) everything the peer needs to know in order
+// to validate a transaction
+type ChaincodeValidationInfo struct {
+	ValidationPlugin string `protobuf:"bytes,1,opt,name=validation_plugin,json=validationPlugin" json:"validation_plugin,omitempty"`
+	// validation_parameter is a marshaled ApplicationPolicy
+	ValidationParameter []byte `protobuf:"bytes,2,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	// sub_policies maps names, such as the functions of the chaincode,
+	// to marshaled ApplicationPolicies. Invocations that select a sub-policy
+	// are endorsed according to it instead of validation_parameter
+	SubPolicies map[string][]byte `protobuf:"bytes,3,rep,name=sub_policies,json=subPolicies" json:"sub_policies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ChaincodeValidationInfo) Reset()                    { *m = ChaincodeValidationInfo{} }
+func (m *ChaincodeValidationInfo) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeValidationInfo) ProtoMessage()               {}
+func (*ChaincodeValidationInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ChaincodeValidationInfo) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeValidationInfo) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *ChaincodeValidationInfo) GetSubPolicies() map[string][]byte {
+	if m != nil {
+		return m.SubPolicies
+	}
+	return nil
+}
+
+// ApplicationPolicy captures the diffenent policy types that
+// are set and evaluted at the application level.
+type ApplicationPolicy struct {
+	// Types that are valid to be assigned to Type:
+	//	*ApplicationPolicy_SignaturePolicy
+	//	*ApplicationPolicy_ChannelConfigPolicyReference
+	Type isApplicationPolicy_Type `protobuf_oneof:"Type"`
+}
+
+func (m *ApplicationPolicy) Reset()                    { *m = ApplicationPolicy{} }
+func (m *ApplicationPolicy) String() string            { return proto.CompactTextString(m) }
+func (*ApplicationPolicy) ProtoMessage()               {}
+func (*ApplicationPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type isApplicationPolicy_Type interface{ isApplicationPolicy_Type() }
+
+type ApplicationPolicy_SignaturePolicy struct {
+	SignaturePolicy *common1.SignaturePolicyEnvelope `protobuf:"bytes,1,opt,name=signature_policy,json=signaturePolicy,oneof"`
+}
+type ApplicationPolicy_ChannelConfigPolicyReference struct {
+	ChannelConfigPolicyReference string `protobuf:"bytes,2,opt,name=channel_config_policy_reference,json=channelConfigPolicyReference,oneof"`
+}
+
+func (*ApplicationPolicy_SignaturePolicy) isApplicationPolicy_Type()              {}
+func (*ApplicationPolicy_ChannelConfigPolicyReference) isApplicationPolicy_Type() {}
+
+func (m *ApplicationPolicy) GetType() isApplicationPolicy_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ApplicationPolicy) GetSignaturePolicy() *common1.SignaturePolicyEnvelope {
+	if x, ok := m.GetType().(*ApplicationPolicy_SignaturePolicy); ok {
+		return x.SignaturePolicy
+	}
+	return nil
+}
+
+func (m *ApplicationPolicy) GetChannelConfigPolicyReference() string {
+	if x, ok := m.GetType().(*ApplicationPolicy_ChannelConfigPolicyReference); ok {
+		return x.ChannelConfigPolicyReference
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ApplicationPolicy) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ApplicationPolicy_OneofMarshaler, _ApplicationPolicy_OneofUnmarshaler, _ApplicationPolicy_OneofSizer, []interface{}{
+		(*ApplicationPolicy_SignaturePolicy)(nil),
+		(*ApplicationPolicy_ChannelConfigPolicyReference)(nil),
+	}
+}
+
+func _ApplicationPolicy_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ApplicationPolicy)
+	// Type
+	switch x := m.Type.(type) {
+	case *ApplicationPolicy_SignaturePolicy:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SignaturePolicy); err != nil {
+			return err
+		}
+	case *ApplicationPolicy_ChannelConfigPolicyReference:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.ChannelConfigPolicyReference)
+	case nil:
+	default:
+		return fmt.Errorf("ApplicationPolicy.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ApplicationPolicy_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ApplicationPolicy)
+	switch tag {
+	case 1: // Type.signature_policy
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common1.SignaturePolicyEnvelope)
+		err := b.DecodeMessage(msg)
+		m.Type = &ApplicationPolicy_SignaturePolicy{msg}
+		return true, err
+	case 2: // Type.channel_config_policy_reference
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Type = &ApplicationPolicy_ChannelConfigPolicyReference{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ApplicationPolicy_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ApplicationPolicy)
+	// Type
+	switch x := m.Type.(type) {
+	case *ApplicationPolicy_SignaturePolicy:
+		s := proto.Size(x.SignaturePolicy)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ApplicationPolicy_ChannelConfigPolicyReference:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.ChannelConfigPolicyReference)))
+		n += len(x.ChannelConfigPolicyReference)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeEndorsementInfo)(nil), "lifecycle.ChaincodeEndorsementInfo")
+	proto.RegisterType((*ChaincodeValidationInfo)(nil), "lifecycle.ChaincodeValidationInfo")
+	proto.RegisterType((*ApplicationPolicy)(nil), "lifecycle.ApplicationPolicy")
+}
+
+func init() { proto.RegisterFile("peer/lifecycle/chaincode_definition.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 451 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x97, 0x16, 0x06, 0x7d, 0x2d, 0xa2, 0x35, 0x43, 0x44, 0x13, 0xd2, 0xaa, 0x72, 0x29,
+	0x02, 0x1c, 0xb1, 0x5e, 0x10, 0x07, 0x24, 0x36, 0x55, 0x0c, 0x89, 0xc3, 0x94, 0xa1, 0x1d, 0xb8,
+	0x44, 0x8e, 0xf3, 0x92, 0x5a, 0xb8, 0xb6, 0x71, 0x92, 0x4a, 0xf9, 0x0f, 0xb8, 0xf2, 0xaf, 0xf0,
+	0x17, 0xa2, 0xc4, 0x49, 0x9b, 0x21, 0xed, 0x16, 0xbf, 0xef, 0x8f, 0x7c, 0xe2, 0x3c, 0x78, 0x6d,
+	0x10, 0x6d, 0x20, 0x45, 0x8a, 0xbc, 0xe2, 0x12, 0x03, 0xbe, 0x61, 0x42, 0x71, 0x9d, 0x60, 0x94,
+	0x60, 0x2a, 0x94, 0x28, 0x84, 0x56, 0xd4, 0x58, 0x5d, 0x68, 0x32, 0xda, 0xbb, 0x4e, 0x9f, 0x73,
+	0xbd, 0xdd, 0x6a, 0x15, 0x18, 0x2d, 0x05, 0x17, 0x98, 0x3b, 0xc7, 0xe2, 0xb7, 0x07, 0xfe, 0x65,
+	0x57, 0xb0, 0x56, 0x89, 0xb6, 0x39, 0x6e, 0x51, 0x15, 0x5f, 0x55, 0xaa, 0x89, 0x0f, 0x8f, 0x76,
+	0x68, 0x73, 0xa1, 0x95, 0xef, 0xcd, 0xbd, 0xe5, 0x28, 0xec, 0x8e, 0xe4, 0x15, 0x3c, 0xa9, 0xdf,
+	0x14, 0x59, 0xfc, 0x55, 0x0a, 0x8b, 0x89, 0x3f, 0x98, 0x7b, 0xcb, 0xc7, 0xe1, 0xa4, 0x1e, 0x86,
+	0xed, 0x8c, 0xbc, 0x03, 0x82, 0x87, 0xc6, 0xc8, 0xc8, 0x32, 0x13, 0xca, 0x1f, 0x36, 0x4d, 0xb3,
+	0x9e, 0x72, 0xdd, 0x08, 0x8b, 0x3f, 0x03, 0x78, 0xb1, 0x47, 0xb9, 0x65, 0x52, 0x24, 0xac, 0xfe,
+	0x94, 0x86, 0xe4, 0x0d, 0xcc, 0x76, 0xfb, 0x49, 0xd7, 0xe4, 0x98, 0xa6, 0x07, 0xc1, 0x15, 0x91,
+	0xf7, 0x70, 0xd2, 0x37, 0x33, 0xcb, 0xb6, 0x58, 0xa0, 0x6d, 0x18, 0x27, 0xe1, 0xb3, 0x9e, 0xbf,
+	0x93, 0xc8, 0x2d, 0x4c, 0xf2, 0x32, 0x8e, 0xba, 0xcb, 0xf1, 0x87, 0xf3, 0xe1, 0x72, 0x7c, 0xbe,
+	0xa2, 0xfb, 0xfb, 0xa3, 0xf7, 0x90, 0xd1, 0x9b, 0x32, 0xbe, 0x6e, 0x53, 0x6b, 0x55, 0xd8, 0x2a,
+	0x1c, 0xe7, 0x87, 0xc9, 0xe9, 0x27, 0x98, 0xfe, 0x6f, 0x20, 0x53, 0x18, 0xfe, 0xc4, 0xaa, 0xa5,
+	0xaf, 0x1f, 0xc9, 0x09, 0x3c, 0xdc, 0x31, 0x59, 0x62, 0x4b, 0xe8, 0x0e, 0x1f, 0x07, 0x1f, 0xbc,
+	0xc5, 0x5f, 0x0f, 0x66, 0x9f, 0x8d, 0x91, 0x82, 0x3b, 0xe0, 0xba, 0xa8, 0x22, 0xdf, 0x60, 0x9a,
+	0x8b, 0x4c, 0xb1, 0xa2, 0xb4, 0xe8, 0x98, 0x5d, 0xdd, 0xf8, 0xfc, 0x8c, 0xba, 0xdf, 0x4c, 0x6f,
+	0x3a, 0xdd, 0x45, 0xd6, 0x6a, 0x87, 0x52, 0x1b, 0xbc, 0x3a, 0x0a, 0x9f, 0xe6, 0x77, 0x25, 0xf2,
+	0x05, 0xce, 0xf8, 0x86, 0x29, 0x85, 0x32, 0xe2, 0x5a, 0xa5, 0x22, 0x6b, 0x2b, 0x23, 0x8b, 0x29,
+	0x5a, 0x54, 0xdc, 0x71, 0x8d, 0xae, 0x8e, 0xc2, 0x97, 0xad, 0xf1, 0xb2, 0xf1, 0xb9, 0x7c, 0xd8,
+	0xb9, 0x2e, 0x8e, 0xe1, 0xc1, 0xf7, 0xca, 0xe0, 0x05, 0x87, 0xb7, 0xda, 0x66, 0x74, 0x53, 0x19,
+	0xb4, 0x12, 0x93, 0x0c, 0x2d, 0x4d, 0x59, 0x6c, 0x05, 0x77, 0x3b, 0x97, 0xd3, 0x7a, 0x81, 0x0f,
+	0x57, 0xfb, 0x63, 0x95, 0x89, 0x62, 0x53, 0xc6, 0x35, 0x7a, 0xd0, 0x0b, 0x05, 0x2e, 0x14, 0xb8,
+	0x50, 0x70, 0x77, 0xeb, 0xe3, 0xe3, 0x66, 0xbc, 0xfa, 0x37, 0x00, 0x87, 0x7e, 0x70, 0xbd, 0x0e,
+	0x03, 0x00, 0x00,
+}
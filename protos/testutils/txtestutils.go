@@ -101,7 +101,7 @@ func ConstructSingedTxEnv(chainID string, ccid *pb.ChaincodeID, pResponse *pb.Re
 		return nil, "", err
 	}
 
-	presp, err := putils.CreateProposalResponse(prop.Header, prop.Payload, pResponse, simulationResults, nil, ccid, nil, signer)
+	presp, err := putils.CreateProposalResponse(prop.Header, prop.Payload, pResponse, simulationResults, events, ccid, nil, signer)
 	if err != nil {
 		return nil, "", err
 	}
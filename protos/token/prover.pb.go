@@ -0,0 +1,860 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: token/prover.proto
+
+package token
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// Header is the header of the commands sent to the prover
+type Header struct {
+	// The time the command was created by the client
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// The channel the command applies to
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	// A unique value, to prevent replays of the command
+	Nonce []byte `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// The serialized identity of the client, which signs the command
+	Creator []byte `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+}
+
+func (m *Header) Reset()                    { *m = Header{} }
+func (m *Header) String() string            { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()               {}
+func (*Header) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
+func (m *Header) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *Header) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *Header) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *Header) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
+// Command is a request to the prover
+type Command struct {
+	Header *Header `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//	*Command_IssueRequest
+	//	*Command_TransferRequest
+	//	*Command_RedeemRequest
+	//	*Command_ListRequest
+	Payload isCommand_Payload `protobuf_oneof:"payload"`
+}
+
+func (m *Command) Reset()                    { *m = Command{} }
+func (m *Command) String() string            { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()               {}
+func (*Command) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+type isCommand_Payload interface{ isCommand_Payload() }
+
+type Command_IssueRequest struct {
+	IssueRequest *IssueRequest `protobuf:"bytes,2,opt,name=issue_request,json=issueRequest,oneof"`
+}
+type Command_TransferRequest struct {
+	TransferRequest *TransferRequest `protobuf:"bytes,3,opt,name=transfer_request,json=transferRequest,oneof"`
+}
+type Command_RedeemRequest struct {
+	RedeemRequest *RedeemRequest `protobuf:"bytes,4,opt,name=redeem_request,json=redeemRequest,oneof"`
+}
+type Command_ListRequest struct {
+	ListRequest *ListRequest `protobuf:"bytes,5,opt,name=list_request,json=listRequest,oneof"`
+}
+
+func (*Command_IssueRequest) isCommand_Payload()    {}
+func (*Command_TransferRequest) isCommand_Payload() {}
+func (*Command_RedeemRequest) isCommand_Payload()   {}
+func (*Command_ListRequest) isCommand_Payload()     {}
+
+func (m *Command) GetPayload() isCommand_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Command) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *Command) GetIssueRequest() *IssueRequest {
+	if x, ok := m.GetPayload().(*Command_IssueRequest); ok {
+		return x.IssueRequest
+	}
+	return nil
+}
+
+func (m *Command) GetTransferRequest() *TransferRequest {
+	if x, ok := m.GetPayload().(*Command_TransferRequest); ok {
+		return x.TransferRequest
+	}
+	return nil
+}
+
+func (m *Command) GetRedeemRequest() *RedeemRequest {
+	if x, ok := m.GetPayload().(*Command_RedeemRequest); ok {
+		return x.RedeemRequest
+	}
+	return nil
+}
+
+func (m *Command) GetListRequest() *ListRequest {
+	if x, ok := m.GetPayload().(*Command_ListRequest); ok {
+		return x.ListRequest
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
+		(*Command_IssueRequest)(nil),
+		(*Command_TransferRequest)(nil),
+		(*Command_RedeemRequest)(nil),
+		(*Command_ListRequest)(nil),
+	}
+}
+
+func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Command)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Command_IssueRequest:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.IssueRequest); err != nil {
+			return err
+		}
+	case *Command_TransferRequest:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TransferRequest); err != nil {
+			return err
+		}
+	case *Command_RedeemRequest:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RedeemRequest); err != nil {
+			return err
+		}
+	case *Command_ListRequest:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListRequest); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Command.Payload has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Command)
+	switch tag {
+	case 2: // payload.issue_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(IssueRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_IssueRequest{msg}
+		return true, err
+	case 3: // payload.transfer_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TransferRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_TransferRequest{msg}
+		return true, err
+	case 4: // payload.redeem_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RedeemRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_RedeemRequest{msg}
+		return true, err
+	case 5: // payload.list_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ListRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_ListRequest{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Command_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Command)
+	// payload
+	switch x := m.Payload.(type) {
+	case *Command_IssueRequest:
+		s := proto.Size(x.IssueRequest)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_TransferRequest:
+		s := proto.Size(x.TransferRequest)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_RedeemRequest:
+		s := proto.Size(x.RedeemRequest)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_ListRequest:
+		s := proto.Size(x.ListRequest)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// SignedCommand is a command along with the signature of its creator
+type SignedCommand struct {
+	// The serialized Command
+	Command []byte `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// The signature of the creator of the command over the command bytes
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedCommand) Reset()                    { *m = SignedCommand{} }
+func (m *SignedCommand) String() string            { return proto.CompactTextString(m) }
+func (*SignedCommand) ProtoMessage()               {}
+func (*SignedCommand) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+func (m *SignedCommand) GetCommand() []byte {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *SignedCommand) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TokenToIssue specifies a token to create
+type TokenToIssue struct {
+	// The serialized identity of the owner of the token
+	Recipient []byte `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Type      string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Quantity  uint64 `protobuf:"varint,3,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *TokenToIssue) Reset()                    { *m = TokenToIssue{} }
+func (m *TokenToIssue) String() string            { return proto.CompactTextString(m) }
+func (*TokenToIssue) ProtoMessage()               {}
+func (*TokenToIssue) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+func (m *TokenToIssue) GetRecipient() []byte {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *TokenToIssue) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenToIssue) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// IssueRequest requests a transaction that creates the given tokens
+type IssueRequest struct {
+	TokensToIssue []*TokenToIssue `protobuf:"bytes,1,rep,name=tokens_to_issue,json=tokensToIssue" json:"tokens_to_issue,omitempty"`
+}
+
+func (m *IssueRequest) Reset()                    { *m = IssueRequest{} }
+func (m *IssueRequest) String() string            { return proto.CompactTextString(m) }
+func (*IssueRequest) ProtoMessage()               {}
+func (*IssueRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+func (m *IssueRequest) GetTokensToIssue() []*TokenToIssue {
+	if m != nil {
+		return m.TokensToIssue
+	}
+	return nil
+}
+
+// RecipientTransferShare specifies the quantity that a transfer gives to a recipient
+type RecipientTransferShare struct {
+	// The serialized identity of the recipient
+	Recipient []byte `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Quantity  uint64 `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *RecipientTransferShare) Reset()                    { *m = RecipientTransferShare{} }
+func (m *RecipientTransferShare) String() string            { return proto.CompactTextString(m) }
+func (*RecipientTransferShare) ProtoMessage()               {}
+func (*RecipientTransferShare) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *RecipientTransferShare) GetRecipient() []byte {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *RecipientTransferShare) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// TransferRequest requests a transaction that spends the given tokens of the creator
+// of the command, and gives their quantity to the recipients
+type TransferRequest struct {
+	TokenIds []*InputId                `protobuf:"bytes,1,rep,name=token_ids,json=tokenIds" json:"token_ids,omitempty"`
+	Shares   []*RecipientTransferShare `protobuf:"bytes,2,rep,name=shares" json:"shares,omitempty"`
+}
+
+func (m *TransferRequest) Reset()                    { *m = TransferRequest{} }
+func (m *TransferRequest) String() string            { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()               {}
+func (*TransferRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *TransferRequest) GetTokenIds() []*InputId {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetShares() []*RecipientTransferShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+// RedeemRequest requests a transaction that spends the given tokens of the creator
+// of the command, redeems the given quantity, and returns the rest to the creator
+type RedeemRequest struct {
+	TokenIds         []*InputId `protobuf:"bytes,1,rep,name=token_ids,json=tokenIds" json:"token_ids,omitempty"`
+	QuantityToRedeem uint64     `protobuf:"varint,2,opt,name=quantity_to_redeem,json=quantityToRedeem" json:"quantity_to_redeem,omitempty"`
+}
+
+func (m *RedeemRequest) Reset()                    { *m = RedeemRequest{} }
+func (m *RedeemRequest) String() string            { return proto.CompactTextString(m) }
+func (*RedeemRequest) ProtoMessage()               {}
+func (*RedeemRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *RedeemRequest) GetTokenIds() []*InputId {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *RedeemRequest) GetQuantityToRedeem() uint64 {
+	if m != nil {
+		return m.QuantityToRedeem
+	}
+	return 0
+}
+
+// ListRequest requests the unspent tokens of the creator of the command
+type ListRequest struct {
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+// TokenOutput is an unspent token
+type TokenOutput struct {
+	Id       *InputId `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Type     string   `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Quantity uint64   `protobuf:"varint,3,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *TokenOutput) Reset()                    { *m = TokenOutput{} }
+func (m *TokenOutput) String() string            { return proto.CompactTextString(m) }
+func (*TokenOutput) ProtoMessage()               {}
+func (*TokenOutput) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *TokenOutput) GetId() *InputId {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *TokenOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenOutput) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// UnspentTokens are the unspent tokens of the creator of a list command
+type UnspentTokens struct {
+	Tokens []*TokenOutput `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+}
+
+func (m *UnspentTokens) Reset()                    { *m = UnspentTokens{} }
+func (m *UnspentTokens) String() string            { return proto.CompactTextString(m) }
+func (*UnspentTokens) ProtoMessage()               {}
+func (*UnspentTokens) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *UnspentTokens) GetTokens() []*TokenOutput {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+// Error is the reason the prover failed processing a command
+type Error struct {
+	Message string `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *Error) Reset()                    { *m = Error{} }
+func (m *Error) String() string            { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()               {}
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// CommandResponseHeader is the header of the responses of the prover
+type CommandResponseHeader struct {
+	// The time the response was created by the prover
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// The SHA256 hash of the command bytes the response is for
+	CommandHash []byte `protobuf:"bytes,2,opt,name=command_hash,json=commandHash,proto3" json:"command_hash,omitempty"`
+	// The serialized identity of the peer, which signs the response
+	Creator []byte `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+}
+
+func (m *CommandResponseHeader) Reset()                    { *m = CommandResponseHeader{} }
+func (m *CommandResponseHeader) String() string            { return proto.CompactTextString(m) }
+func (*CommandResponseHeader) ProtoMessage()               {}
+func (*CommandResponseHeader) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *CommandResponseHeader) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *CommandResponseHeader) GetCommandHash() []byte {
+	if m != nil {
+		return m.CommandHash
+	}
+	return nil
+}
+
+func (m *CommandResponseHeader) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
+// CommandResponse is the response of the prover to a command
+type CommandResponse struct {
+	Header *CommandResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//	*CommandResponse_Err
+	//	*CommandResponse_TokenTransaction
+	//	*CommandResponse_UnspentTokens
+	Payload isCommandResponse_Payload `protobuf_oneof:"payload"`
+}
+
+func (m *CommandResponse) Reset()                    { *m = CommandResponse{} }
+func (m *CommandResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommandResponse) ProtoMessage()               {}
+func (*CommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+
+type isCommandResponse_Payload interface{ isCommandResponse_Payload() }
+
+type CommandResponse_Err struct {
+	Err *Error `protobuf:"bytes,2,opt,name=err,oneof"`
+}
+type CommandResponse_TokenTransaction struct {
+	TokenTransaction *TokenTransaction `protobuf:"bytes,3,opt,name=token_transaction,json=tokenTransaction,oneof"`
+}
+type CommandResponse_UnspentTokens struct {
+	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,oneof"`
+}
+
+func (*CommandResponse_Err) isCommandResponse_Payload()              {}
+func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
+func (*CommandResponse_UnspentTokens) isCommandResponse_Payload()    {}
+
+func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetHeader() *CommandResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetErr() *Error {
+	if x, ok := m.GetPayload().(*CommandResponse_Err); ok {
+		return x.Err
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetTokenTransaction() *TokenTransaction {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenTransaction); ok {
+		return x.TokenTransaction
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
+	if x, ok := m.GetPayload().(*CommandResponse_UnspentTokens); ok {
+		return x.UnspentTokens
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
+		(*CommandResponse_Err)(nil),
+		(*CommandResponse_TokenTransaction)(nil),
+		(*CommandResponse_UnspentTokens)(nil),
+	}
+}
+
+func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CommandResponse)
+	// payload
+	switch x := m.Payload.(type) {
+	case *CommandResponse_Err:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Err); err != nil {
+			return err
+		}
+	case *CommandResponse_TokenTransaction:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenTransaction); err != nil {
+			return err
+		}
+	case *CommandResponse_UnspentTokens:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CommandResponse)
+	switch tag {
+	case 2: // payload.err
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Error)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_Err{msg}
+		return true, err
+	case 3: // payload.token_transaction
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenTransaction)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenTransaction{msg}
+		return true, err
+	case 4: // payload.unspent_tokens
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(UnspentTokens)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_UnspentTokens{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CommandResponse)
+	// payload
+	switch x := m.Payload.(type) {
+	case *CommandResponse_Err:
+		s := proto.Size(x.Err)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_TokenTransaction:
+		s := proto.Size(x.TokenTransaction)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_UnspentTokens:
+		s := proto.Size(x.UnspentTokens)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// SignedCommandResponse is a response along with the signature of the prover
+type SignedCommandResponse struct {
+	// The serialized CommandResponse
+	Response []byte `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// The signature of the prover over the response bytes
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignedCommandResponse) Reset()                    { *m = SignedCommandResponse{} }
+func (m *SignedCommandResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignedCommandResponse) ProtoMessage()               {}
+func (*SignedCommandResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *SignedCommandResponse) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SignedCommandResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Header)(nil), "token.Header")
+	proto.RegisterType((*Command)(nil), "token.Command")
+	proto.RegisterType((*SignedCommand)(nil), "token.SignedCommand")
+	proto.RegisterType((*TokenToIssue)(nil), "token.TokenToIssue")
+	proto.RegisterType((*IssueRequest)(nil), "token.IssueRequest")
+	proto.RegisterType((*RecipientTransferShare)(nil), "token.RecipientTransferShare")
+	proto.RegisterType((*TransferRequest)(nil), "token.TransferRequest")
+	proto.RegisterType((*RedeemRequest)(nil), "token.RedeemRequest")
+	proto.RegisterType((*ListRequest)(nil), "token.ListRequest")
+	proto.RegisterType((*TokenOutput)(nil), "token.TokenOutput")
+	proto.RegisterType((*UnspentTokens)(nil), "token.UnspentTokens")
+	proto.RegisterType((*Error)(nil), "token.Error")
+	proto.RegisterType((*CommandResponseHeader)(nil), "token.CommandResponseHeader")
+	proto.RegisterType((*CommandResponse)(nil), "token.CommandResponse")
+	proto.RegisterType((*SignedCommandResponse)(nil), "token.SignedCommandResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Prover service
+
+type ProverClient interface {
+	// ProcessCommand returns the token transaction of an issue, transfer or redeem command,
+	// or the unspent tokens of the creator of a list command
+	ProcessCommand(ctx context.Context, in *SignedCommand, opts ...grpc.CallOption) (*SignedCommandResponse, error)
+}
+
+type proverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProverClient(cc *grpc.ClientConn) ProverClient {
+	return &proverClient{cc}
+}
+
+func (c *proverClient) ProcessCommand(ctx context.Context, in *SignedCommand, opts ...grpc.CallOption) (*SignedCommandResponse, error) {
+	out := new(SignedCommandResponse)
+	err := grpc.Invoke(ctx, "/token.Prover/ProcessCommand", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Prover service
+
+type ProverServer interface {
+	// ProcessCommand returns the token transaction of an issue, transfer or redeem command,
+	// or the unspent tokens of the creator of a list command
+	ProcessCommand(context.Context, *SignedCommand) (*SignedCommandResponse, error)
+}
+
+func RegisterProverServer(s *grpc.Server, srv ProverServer) {
+	s.RegisterService(&_Prover_serviceDesc, srv)
+}
+
+func _Prover_ProcessCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProverServer).ProcessCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/token.Prover/ProcessCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProverServer).ProcessCommand(ctx, req.(*SignedCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Prover_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "token.Prover",
+	HandlerType: (*ProverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessCommand",
+			Handler:    _Prover_ProcessCommand_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "token/prover.proto",
+}
+
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor1) }
+
+var fileDescriptor1 = []byte{
+	// 817 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5b, 0x8f, 0xe3, 0x34,
+	0x14, 0x9e, 0xf4, 0x36, 0xd3, 0xd3, 0x64, 0x3a, 0x98, 0xbd, 0x44, 0xd5, 0x2c, 0x74, 0x83, 0x90,
+	0x46, 0x0b, 0x4a, 0xa5, 0x01, 0x04, 0x62, 0xc5, 0xcb, 0xae, 0x58, 0x5a, 0x81, 0xc4, 0xac, 0xb7,
+	0xbc, 0x20, 0x50, 0xe5, 0x26, 0x9e, 0xc4, 0xd0, 0xc6, 0x59, 0xdb, 0x41, 0xea, 0x9f, 0x40, 0xe2,
+	0xbf, 0xf0, 0xef, 0x78, 0x41, 0xb1, 0x9d, 0x5b, 0x35, 0x02, 0x8d, 0xf6, 0x2d, 0xe7, 0xea, 0xcf,
+	0xe7, 0x3b, 0xfe, 0x02, 0x48, 0xf1, 0xdf, 0x69, 0xb6, 0xc8, 0x05, 0xff, 0x83, 0x8a, 0x30, 0x17,
+	0x5c, 0x71, 0x34, 0xd4, 0xbe, 0xd9, 0x87, 0x09, 0xe7, 0xc9, 0x8e, 0x2e, 0xb4, 0x73, 0x5b, 0xdc,
+	0x2e, 0x14, 0xdb, 0x53, 0xa9, 0xc8, 0x3e, 0x37, 0x79, 0xb3, 0xc7, 0xa6, 0x56, 0x09, 0x92, 0x49,
+	0x12, 0x29, 0xc6, 0x33, 0x13, 0x08, 0xfe, 0x72, 0x60, 0xb4, 0xa4, 0x24, 0xa6, 0x02, 0x7d, 0x05,
+	0xe3, 0xba, 0xcc, 0x77, 0xe6, 0xce, 0xd5, 0xe4, 0x7a, 0x16, 0x9a, 0xc6, 0x61, 0xd5, 0x38, 0x5c,
+	0x57, 0x19, 0xb8, 0x49, 0x46, 0x4f, 0x00, 0xa2, 0x94, 0x64, 0x19, 0xdd, 0x6d, 0x58, 0xec, 0xf7,
+	0xe6, 0xce, 0xd5, 0x18, 0x8f, 0xad, 0x67, 0x15, 0xa3, 0x07, 0x30, 0xcc, 0x78, 0x16, 0x51, 0xbf,
+	0x3f, 0x77, 0xae, 0x5c, 0x6c, 0x0c, 0xe4, 0xc3, 0x69, 0x24, 0x28, 0x51, 0x5c, 0xf8, 0x03, 0xed,
+	0xaf, 0xcc, 0xe0, 0xef, 0x1e, 0x9c, 0xbe, 0xe4, 0xfb, 0x3d, 0xc9, 0x62, 0xf4, 0x31, 0x8c, 0x52,
+	0x0d, 0xcf, 0x22, 0xf2, 0x42, 0x7d, 0x93, 0xd0, 0x60, 0xc6, 0x36, 0x88, 0xbe, 0x06, 0x8f, 0x49,
+	0x59, 0xd0, 0x8d, 0xa0, 0x6f, 0x0b, 0x2a, 0x95, 0x06, 0x31, 0xb9, 0x7e, 0xdf, 0x66, 0xaf, 0xca,
+	0x18, 0x36, 0xa1, 0xe5, 0x09, 0x76, 0x59, 0xcb, 0x46, 0x2f, 0xe1, 0x42, 0xcf, 0xe5, 0x96, 0x8a,
+	0xba, 0xbc, 0xaf, 0xcb, 0x1f, 0xd9, 0xf2, 0xb5, 0x0d, 0x37, 0x1d, 0xa6, 0xaa, 0xeb, 0x42, 0xdf,
+	0xc0, 0xb9, 0xa0, 0x31, 0xa5, 0xfb, 0xba, 0xc5, 0x40, 0xb7, 0x78, 0x60, 0x5b, 0x60, 0x1d, 0x6c,
+	0x1a, 0x78, 0xa2, 0xed, 0x40, 0x5f, 0x82, 0xbb, 0x63, 0x52, 0xd5, 0xc5, 0x43, 0x5d, 0x8c, 0x6c,
+	0xf1, 0x0f, 0x4c, 0xaa, 0xa6, 0x74, 0xb2, 0x6b, 0xcc, 0x17, 0x63, 0x38, 0xcd, 0xc9, 0x61, 0xc7,
+	0x49, 0x1c, 0x7c, 0x07, 0xde, 0x1b, 0x96, 0x64, 0x34, 0xae, 0x66, 0x57, 0x4e, 0xd8, 0x7c, 0xfa,
+	0x8e, 0x9d, 0xb0, 0x8d, 0x5c, 0xc2, 0x58, 0xb2, 0x24, 0x23, 0xaa, 0x10, 0x54, 0x8f, 0xca, 0xc5,
+	0x8d, 0x23, 0xf8, 0x05, 0xdc, 0x75, 0x79, 0xee, 0x9a, 0xeb, 0xb9, 0x95, 0xd9, 0x82, 0x46, 0x2c,
+	0x67, 0x34, 0x53, 0xb6, 0x53, 0xe3, 0x40, 0x08, 0x06, 0xea, 0x90, 0x53, 0x4b, 0xbb, 0xfe, 0x46,
+	0x33, 0x38, 0x7b, 0x5b, 0x90, 0x4c, 0x31, 0x75, 0xd0, 0xa3, 0x1c, 0xe0, 0xda, 0x0e, 0xbe, 0x07,
+	0xb7, 0x4d, 0x07, 0x7a, 0x0e, 0x53, 0x7d, 0x4b, 0xb9, 0x51, 0x7c, 0xa3, 0x89, 0xf1, 0x9d, 0x79,
+	0xbf, 0x45, 0x5e, 0x1b, 0x0b, 0xf6, 0x4c, 0xae, 0x35, 0x03, 0x0c, 0x8f, 0x70, 0x85, 0xa4, 0x62,
+	0xe9, 0x4d, 0x4a, 0xc4, 0xff, 0x81, 0x6e, 0x03, 0xec, 0x1d, 0x01, 0x2c, 0x60, 0x7a, 0x44, 0x38,
+	0xfa, 0x04, 0xc6, 0xfa, 0xdc, 0x0d, 0x8b, 0xa5, 0x45, 0x77, 0x5e, 0xad, 0x56, 0x96, 0x17, 0x6a,
+	0x15, 0xe3, 0x33, 0x6d, 0xae, 0x62, 0x89, 0xbe, 0x80, 0x91, 0x2c, 0x21, 0x48, 0xbf, 0xa7, 0x33,
+	0x9f, 0xd4, 0x2b, 0x70, 0x17, 0x50, 0x6c, 0x93, 0x83, 0xdf, 0xc0, 0xeb, 0x2c, 0xc9, 0xfd, 0x0e,
+	0xfd, 0x14, 0x50, 0x75, 0x81, 0x72, 0x8e, 0x66, 0xbb, 0xec, 0xd5, 0x2e, 0xaa, 0xc8, 0x9a, 0x9b,
+	0x13, 0x02, 0x0f, 0x26, 0xad, 0x9d, 0x0a, 0x7e, 0x85, 0x89, 0x1e, 0xf2, 0x8f, 0x85, 0xca, 0x0b,
+	0x85, 0x3e, 0x80, 0x1e, 0x8b, 0xed, 0x7b, 0x3b, 0x3e, 0xb1, 0xc7, 0xe2, 0x7b, 0x33, 0xfe, 0x1c,
+	0xbc, 0x9f, 0x32, 0x99, 0x97, 0x37, 0xd7, 0xe4, 0xa1, 0x67, 0x30, 0x32, 0x34, 0xda, 0x6b, 0xa1,
+	0x36, 0xd3, 0x06, 0x04, 0xb6, 0x19, 0xc1, 0x53, 0x18, 0x7e, 0x2b, 0x04, 0x17, 0xe5, 0x36, 0xef,
+	0xa9, 0x94, 0x24, 0xa1, 0x1a, 0xda, 0x18, 0x57, 0x66, 0xf0, 0xa7, 0x03, 0x0f, 0xed, 0xce, 0x63,
+	0x2a, 0x73, 0x9e, 0x49, 0xfa, 0xce, 0x92, 0xf6, 0x14, 0x5c, 0xfb, 0x58, 0x36, 0x29, 0x91, 0xa9,
+	0x7d, 0x24, 0x13, 0xeb, 0x5b, 0x12, 0x99, 0xb6, 0x05, 0xac, 0xdf, 0x15, 0xb0, 0x7f, 0x1c, 0x98,
+	0x1e, 0x01, 0x42, 0x9f, 0x1f, 0x09, 0xd9, 0xa5, 0xbd, 0xf3, 0x9d, 0xc0, 0x6b, 0x5d, 0x9b, 0x43,
+	0x9f, 0x0a, 0x61, 0xd5, 0xcc, 0xb5, 0x25, 0x7a, 0x1e, 0xcb, 0x13, 0x5c, 0x86, 0xd0, 0x2b, 0x78,
+	0xcf, 0x6c, 0x49, 0x4b, 0xdb, 0xad, 0x7c, 0x3d, 0xee, 0x3c, 0xa0, 0x26, 0xbc, 0x3c, 0xc1, 0x17,
+	0xea, 0xc8, 0x57, 0x0a, 0x58, 0x61, 0x48, 0xda, 0x58, 0x6e, 0xba, 0x02, 0xd6, 0x61, 0xb0, 0x14,
+	0xb0, 0xa2, 0xed, 0x68, 0xeb, 0xd0, 0x6b, 0x78, 0xd8, 0xd1, 0xa1, 0x7a, 0x04, 0x33, 0x38, 0x13,
+	0xf6, 0xdb, 0xbe, 0xc8, 0xda, 0xfe, 0x6f, 0x45, 0xba, 0xbe, 0x81, 0xd1, 0x8d, 0xfe, 0xed, 0xa1,
+	0x57, 0x70, 0x7e, 0x23, 0x78, 0x44, 0xa5, 0xac, 0x54, 0xae, 0x02, 0xd8, 0x39, 0x73, 0x76, 0x79,
+	0x97, 0xb7, 0x42, 0x12, 0x9c, 0xbc, 0x78, 0x0d, 0x1f, 0x71, 0x91, 0x84, 0xe9, 0x21, 0xa7, 0x62,
+	0x47, 0xe3, 0x84, 0x8a, 0xf0, 0x96, 0x6c, 0x05, 0x8b, 0xcc, 0x5e, 0x48, 0x53, 0xfe, 0xf3, 0xb3,
+	0x84, 0xa9, 0xb4, 0xd8, 0x86, 0x11, 0xdf, 0x2f, 0x5a, 0xb9, 0x0b, 0x93, 0x6b, 0xfe, 0xb7, 0x72,
+	0xa1, 0x73, 0xb7, 0x23, 0x6d, 0x7d, 0xf6, 0xef, 0x00, 0x87, 0xdf, 0xd6, 0x44, 0xa8, 0x07, 0x00,
+	0x00,
+}
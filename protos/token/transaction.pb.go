@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: token/transaction.proto
+
+/*
+Package token is a generated protocol buffer package.
+
+It is generated from these files:
+	token/transaction.proto
+	token/prover.proto
+
+It has these top-level messages:
+	TokenTransaction
+	PlainTokenAction
+	PlainIssue
+	PlainTransfer
+	PlainRedeem
+	PlainOutput
+	InputId
+	Header
+	Command
+	SignedCommand
+	TokenToIssue
+	IssueRequest
+	RecipientTransferShare
+	TransferRequest
+	RedeemRequest
+	ListRequest
+	TokenOutput
+	UnspentTokens
+	Error
+	CommandResponseHeader
+	CommandResponse
+	SignedCommandResponse
+*/
+package token
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// TokenTransaction is the payload of the transactions of type TOKEN_TRANSACTION, which
+// the peers validate and commit natively, without invoking chaincodes
+type TokenTransaction struct {
+	// Types that are valid to be assigned to Action:
+	//	*TokenTransaction_PlainAction
+	Action isTokenTransaction_Action `protobuf_oneof:"action"`
+}
+
+func (m *TokenTransaction) Reset()                    { *m = TokenTransaction{} }
+func (m *TokenTransaction) String() string            { return proto.CompactTextString(m) }
+func (*TokenTransaction) ProtoMessage()               {}
+func (*TokenTransaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type isTokenTransaction_Action interface{ isTokenTransaction_Action() }
+
+type TokenTransaction_PlainAction struct {
+	PlainAction *PlainTokenAction `protobuf:"bytes,1,opt,name=plain_action,json=plainAction,oneof"`
+}
+
+func (*TokenTransaction_PlainAction) isTokenTransaction_Action() {}
+
+func (m *TokenTransaction) GetAction() isTokenTransaction_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *TokenTransaction) GetPlainAction() *PlainTokenAction {
+	if x, ok := m.GetAction().(*TokenTransaction_PlainAction); ok {
+		return x.PlainAction
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*TokenTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _TokenTransaction_OneofMarshaler, _TokenTransaction_OneofUnmarshaler, _TokenTransaction_OneofSizer, []interface{}{
+		(*TokenTransaction_PlainAction)(nil),
+	}
+}
+
+func _TokenTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*TokenTransaction)
+	// action
+	switch x := m.Action.(type) {
+	case *TokenTransaction_PlainAction:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainAction); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("TokenTransaction.Action has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _TokenTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*TokenTransaction)
+	switch tag {
+	case 1: // action.plain_action
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainTokenAction)
+		err := b.DecodeMessage(msg)
+		m.Action = &TokenTransaction_PlainAction{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*TokenTransaction)
+	// action
+	switch x := m.Action.(type) {
+	case *TokenTransaction_PlainAction:
+		s := proto.Size(x.PlainAction)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// PlainTokenAction governs the structure of the token transactions whose
+// outputs are in the clear
+type PlainTokenAction struct {
+	// Types that are valid to be assigned to Data:
+	//	*PlainTokenAction_PlainIssue
+	//	*PlainTokenAction_PlainTransfer
+	//	*PlainTokenAction_PlainRedeem
+	Data isPlainTokenAction_Data `protobuf_oneof:"data"`
+}
+
+func (m *PlainTokenAction) Reset()                    { *m = PlainTokenAction{} }
+func (m *PlainTokenAction) String() string            { return proto.CompactTextString(m) }
+func (*PlainTokenAction) ProtoMessage()               {}
+func (*PlainTokenAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type isPlainTokenAction_Data interface{ isPlainTokenAction_Data() }
+
+type PlainTokenAction_PlainIssue struct {
+	PlainIssue *PlainIssue `protobuf:"bytes,1,opt,name=plain_issue,json=plainIssue,oneof"`
+}
+type PlainTokenAction_PlainTransfer struct {
+	PlainTransfer *PlainTransfer `protobuf:"bytes,2,opt,name=plain_transfer,json=plainTransfer,oneof"`
+}
+type PlainTokenAction_PlainRedeem struct {
+	PlainRedeem *PlainRedeem `protobuf:"bytes,3,opt,name=plain_redeem,json=plainRedeem,oneof"`
+}
+
+func (*PlainTokenAction_PlainIssue) isPlainTokenAction_Data()    {}
+func (*PlainTokenAction_PlainTransfer) isPlainTokenAction_Data() {}
+func (*PlainTokenAction_PlainRedeem) isPlainTokenAction_Data()   {}
+
+func (m *PlainTokenAction) GetData() isPlainTokenAction_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainIssue() *PlainIssue {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainIssue); ok {
+		return x.PlainIssue
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainTransfer() *PlainTransfer {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainTransfer); ok {
+		return x.PlainTransfer
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainRedeem() *PlainRedeem {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainRedeem); ok {
+		return x.PlainRedeem
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _PlainTokenAction_OneofMarshaler, _PlainTokenAction_OneofUnmarshaler, _PlainTokenAction_OneofSizer, []interface{}{
+		(*PlainTokenAction_PlainIssue)(nil),
+		(*PlainTokenAction_PlainTransfer)(nil),
+		(*PlainTokenAction_PlainRedeem)(nil),
+	}
+}
+
+func _PlainTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*PlainTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *PlainTokenAction_PlainIssue:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainIssue); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainTransfer:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainTransfer); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainRedeem:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainRedeem); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("PlainTokenAction.Data has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _PlainTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*PlainTokenAction)
+	switch tag {
+	case 1: // data.plain_issue
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainIssue)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainIssue{msg}
+		return true, err
+	case 2: // data.plain_transfer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainTransfer{msg}
+		return true, err
+	case 3: // data.plain_redeem
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainRedeem)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainRedeem{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*PlainTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *PlainTokenAction_PlainIssue:
+		s := proto.Size(x.PlainIssue)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainTransfer:
+		s := proto.Size(x.PlainTransfer)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainRedeem:
+		s := proto.Size(x.PlainRedeem)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// PlainIssue specifies the tokens that an issue action creates
+type PlainIssue struct {
+	Outputs []*PlainOutput `protobuf:"bytes,1,rep,name=outputs" json:"outputs,omitempty"`
+}
+
+func (m *PlainIssue) Reset()                    { *m = PlainIssue{} }
+func (m *PlainIssue) String() string            { return proto.CompactTextString(m) }
+func (*PlainIssue) ProtoMessage()               {}
+func (*PlainIssue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PlainIssue) GetOutputs() []*PlainOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// PlainTransfer specifies the tokens that a transfer action spends and creates
+type PlainTransfer struct {
+	// The tokens that are spent, which have the same type and are owned by the creator of the transaction
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs" json:"inputs,omitempty"`
+	// The tokens that are created, whose quantities add up to the quantities of the inputs
+	Outputs []*PlainOutput `protobuf:"bytes,2,rep,name=outputs" json:"outputs,omitempty"`
+}
+
+func (m *PlainTransfer) Reset()                    { *m = PlainTransfer{} }
+func (m *PlainTransfer) String() string            { return proto.CompactTextString(m) }
+func (*PlainTransfer) ProtoMessage()               {}
+func (*PlainTransfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *PlainTransfer) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *PlainTransfer) GetOutputs() []*PlainOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// PlainRedeem specifies the tokens that a redeem action spends, and the quantity that it redeems
+type PlainRedeem struct {
+	// The tokens that are spent, which have the same type and are owned by the creator of the transaction
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs" json:"inputs,omitempty"`
+	// The quantity of the inputs that is taken out of circulation
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity" json:"quantity,omitempty"`
+	// The tokens that return the rest of the quantity of the inputs to the creator of the transaction
+	Outputs []*PlainOutput `protobuf:"bytes,3,rep,name=outputs" json:"outputs,omitempty"`
+}
+
+func (m *PlainRedeem) Reset()                    { *m = PlainRedeem{} }
+func (m *PlainRedeem) String() string            { return proto.CompactTextString(m) }
+func (*PlainRedeem) ProtoMessage()               {}
+func (*PlainRedeem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *PlainRedeem) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *PlainRedeem) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *PlainRedeem) GetOutputs() []*PlainOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// PlainOutput is a token, which is in the clear
+type PlainOutput struct {
+	// The serialized identity of the owner of the token
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The type of the token
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// The quantity of the token
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *PlainOutput) Reset()                    { *m = PlainOutput{} }
+func (m *PlainOutput) String() string            { return proto.CompactTextString(m) }
+func (*PlainOutput) ProtoMessage()               {}
+func (*PlainOutput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PlainOutput) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *PlainOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PlainOutput) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// InputId identifies a token by the transaction that created it,
+// and its index among the outputs of the transaction
+type InputId struct {
+	TxId  string `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *InputId) Reset()                    { *m = InputId{} }
+func (m *InputId) String() string            { return proto.CompactTextString(m) }
+func (*InputId) ProtoMessage()               {}
+func (*InputId) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *InputId) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *InputId) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*TokenTransaction)(nil), "token.TokenTransaction")
+	proto.RegisterType((*PlainTokenAction)(nil), "token.PlainTokenAction")
+	proto.RegisterType((*PlainIssue)(nil), "token.PlainIssue")
+	proto.RegisterType((*PlainTransfer)(nil), "token.PlainTransfer")
+	proto.RegisterType((*PlainRedeem)(nil), "token.PlainRedeem")
+	proto.RegisterType((*PlainOutput)(nil), "token.PlainOutput")
+	proto.RegisterType((*InputId)(nil), "token.InputId")
+}
+
+func init() { proto.RegisterFile("token/transaction.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xc1, 0xae, 0xd2, 0x40,
+	0x14, 0xa5, 0xaf, 0xd0, 0xf7, 0xbc, 0x7d, 0x10, 0x1c, 0x49, 0x68, 0x5c, 0x91, 0x9a, 0x18, 0x63,
+	0x4c, 0x9b, 0x28, 0x89, 0x89, 0xd1, 0x85, 0xac, 0xe8, 0x4a, 0x1d, 0x59, 0xb1, 0x21, 0x85, 0x0e,
+	0x30, 0x11, 0x66, 0xea, 0x74, 0x1a, 0x61, 0xe5, 0xef, 0xf9, 0x59, 0xa6, 0x77, 0x0a, 0x4c, 0xd9,
+	0xf0, 0x76, 0x73, 0xee, 0x39, 0xe7, 0xde, 0x73, 0xa0, 0x30, 0xd4, 0xf2, 0x17, 0x13, 0xb1, 0x56,
+	0xa9, 0x28, 0xd2, 0x95, 0xe6, 0x52, 0x44, 0xb9, 0x92, 0x5a, 0x92, 0x0e, 0x12, 0xe1, 0x1c, 0xfa,
+	0xb3, 0xea, 0x31, 0xbb, 0x08, 0xc8, 0x67, 0x78, 0xcc, 0x77, 0x29, 0x17, 0x0b, 0x83, 0x03, 0x67,
+	0xe4, 0xbc, 0xf1, 0xdf, 0x0f, 0x23, 0x74, 0x44, 0xdf, 0x2b, 0x0a, 0x3d, 0x5f, 0x91, 0x9e, 0xb6,
+	0xa8, 0x8f, 0x72, 0x03, 0x27, 0x0f, 0xe0, 0x19, 0x5f, 0xf8, 0xcf, 0x81, 0xfe, 0xb5, 0x9a, 0x8c,
+	0xc1, 0xa8, 0x17, 0xbc, 0x28, 0x4a, 0x56, 0xef, 0x7e, 0x6e, 0xef, 0x4e, 0x2a, 0x62, 0xda, 0xa2,
+	0x90, 0x9f, 0x11, 0xf9, 0x02, 0x3d, 0xe3, 0xc2, 0x22, 0x6b, 0xa6, 0x82, 0x3b, 0x34, 0x0e, 0x1a,
+	0xa1, 0x6a, 0x6e, 0xda, 0xa2, 0xdd, 0xdc, 0x1e, 0x90, 0x8f, 0xa7, 0x46, 0x8a, 0x65, 0x8c, 0xed,
+	0x03, 0x17, 0xcd, 0xc4, 0x36, 0x53, 0x64, 0xce, 0x65, 0x0c, 0x9c, 0x78, 0xd0, 0xce, 0x52, 0x9d,
+	0x86, 0x9f, 0x00, 0x2e, 0xd9, 0xc8, 0x3b, 0xb8, 0x97, 0xa5, 0xce, 0x4b, 0x5d, 0x04, 0xce, 0xc8,
+	0xbd, 0xde, 0xf4, 0x0d, 0x29, 0x7a, 0x92, 0x84, 0x0c, 0xba, 0x8d, 0x78, 0xe4, 0x35, 0x78, 0x5c,
+	0x58, 0xee, 0x5e, 0xed, 0x4e, 0xaa, 0x61, 0x92, 0xd1, 0x9a, 0xb5, 0xcf, 0xdc, 0xdd, 0x3e, 0xf3,
+	0x17, 0x7c, 0xab, 0xc8, 0x93, 0x8f, 0xbc, 0x84, 0x87, 0xdf, 0x65, 0x2a, 0x34, 0xd7, 0x47, 0xfc,
+	0x4d, 0xdb, 0xf4, 0x8c, 0xed, 0x00, 0xee, 0xed, 0x00, 0x3f, 0xc1, 0xb7, 0xe6, 0x64, 0x00, 0x1d,
+	0xf9, 0x47, 0x30, 0x85, 0x7f, 0xf1, 0x23, 0x35, 0x80, 0x10, 0x68, 0xeb, 0x63, 0xce, 0xf0, 0xd4,
+	0x33, 0x8a, 0xef, 0x46, 0x04, 0xb7, 0x19, 0x21, 0x1c, 0xc3, 0x7d, 0x9d, 0x98, 0xbc, 0x80, 0x8e,
+	0x3e, 0x2c, 0x78, 0x16, 0x38, 0xb5, 0xf7, 0x90, 0x64, 0xd5, 0x15, 0x2e, 0x32, 0x76, 0xc0, 0x85,
+	0x5d, 0x6a, 0xc0, 0xe4, 0x07, 0xbc, 0x92, 0x6a, 0x13, 0x6d, 0x8f, 0x39, 0x53, 0x3b, 0x96, 0x6d,
+	0x98, 0x8a, 0xd6, 0xe9, 0x52, 0xf1, 0x95, 0xf9, 0xf8, 0x0b, 0x53, 0x63, 0xfe, 0x76, 0xc3, 0xf5,
+	0xb6, 0x5c, 0x46, 0x2b, 0xb9, 0x8f, 0x2d, 0x6d, 0x6c, 0xb4, 0xb1, 0xd1, 0xc6, 0xa8, 0x5d, 0x7a,
+	0x88, 0x3e, 0xfc, 0x1f, 0x00, 0x0c, 0xf2, 0xb6, 0xfd, 0x51, 0x03, 0x00, 0x00,
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -116,6 +119,57 @@ func CopyBlockMetadata(src *cb.Block, dst *cb.Block) {
 	InitBlockMetadata(dst)
 }
 
+// NewBlockBatch packs the given blocks into a batch, compressing them
+// with the given algorithm unless compression is UNCOMPRESSED
+func NewBlockBatch(blocks []*cb.Block, compression cb.Compression) (*cb.BlockBatch, error) {
+	switch compression {
+	case cb.Compression_UNCOMPRESSED:
+		return &cb.BlockBatch{Blocks: blocks}, nil
+	case cb.Compression_GZIP:
+		raw, err := proto.Marshal(&cb.BlockBatch{Blocks: blocks})
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling blocks(%s)", err)
+		}
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(raw); err != nil {
+			return nil, fmt.Errorf("error compressing blocks(%s)", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("error compressing blocks(%s)", err)
+		}
+		return &cb.BlockBatch{Compression: compression, CompressedBlocks: buf.Bytes()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compression)
+	}
+}
+
+// GetBlocksFromBlockBatch returns the blocks carried by the batch,
+// decompressing them if needed
+func GetBlocksFromBlockBatch(batch *cb.BlockBatch) ([]*cb.Block, error) {
+	switch batch.Compression {
+	case cb.Compression_UNCOMPRESSED:
+		return batch.Blocks, nil
+	case cb.Compression_GZIP:
+		r, err := gzip.NewReader(bytes.NewReader(batch.CompressedBlocks))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing blocks(%s)", err)
+		}
+		defer r.Close()
+		raw, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing blocks(%s)", err)
+		}
+		uncompressed := &cb.BlockBatch{}
+		if err := proto.Unmarshal(raw, uncompressed); err != nil {
+			return nil, fmt.Errorf("error reconstructing blocks(%s)", err)
+		}
+		return uncompressed.Blocks, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", batch.Compression)
+	}
+}
+
 // InitBlockMetadata copies metadata from one block into another
 func InitBlockMetadata(block *cb.Block) {
 	if block.Metadata == nil {
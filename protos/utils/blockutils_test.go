@@ -215,3 +215,40 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestBlockBatch(t *testing.T) {
+	blocks := []*cb.Block{cb.NewBlock(0, nil), cb.NewBlock(1, []byte("prev"))}
+	blocks[1].Data.Data = [][]byte{[]byte("tx1"), []byte("tx2")}
+
+	for _, compression := range []cb.Compression{cb.Compression_UNCOMPRESSED, cb.Compression_GZIP} {
+		batch, err := utils.NewBlockBatch(blocks, compression)
+		assert.NoError(t, err)
+		assert.Equal(t, compression, batch.Compression)
+
+		// round trip through the wire format
+		bytes, err := proto.Marshal(batch)
+		assert.NoError(t, err)
+		received := &cb.BlockBatch{}
+		assert.NoError(t, proto.Unmarshal(bytes, received))
+
+		result, err := utils.GetBlocksFromBlockBatch(received)
+		assert.NoError(t, err)
+		assert.Len(t, result, len(blocks))
+		for i := range blocks {
+			assert.True(t, proto.Equal(blocks[i], result[i]), "Unexpected block %d in batch", i)
+		}
+	}
+
+	gzipped, _ := utils.NewBlockBatch(blocks, cb.Compression_GZIP)
+	assert.Empty(t, gzipped.Blocks)
+
+	// unknown compression
+	_, err := utils.NewBlockBatch(blocks, cb.Compression(42))
+	assert.Error(t, err)
+	_, err = utils.GetBlocksFromBlockBatch(&cb.BlockBatch{Compression: cb.Compression(42)})
+	assert.Error(t, err)
+
+	// malformed compressed data
+	_, err = utils.GetBlocksFromBlockBatch(&cb.BlockBatch{Compression: cb.Compression_GZIP, CompressedBlocks: []byte("not gzip")})
+	assert.Error(t, err)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 )
 
 // GetPayloads get's the underlying payload objects in a TransactionAction
@@ -114,6 +115,43 @@ func CreateSignedEnvelopeWithTLSBinding(txType common.HeaderType, channelID stri
 	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
 }
 
+// CreateSignedTokenTxEnvelope creates a signed envelope of a token transaction for the given channel,
+// and returns it along with the ID of the transaction, which is computed as for proposals
+func CreateSignedTokenTxEnvelope(channelID string, ttx *token.TokenTransaction, signer crypto.LocalSigner) (*common.Envelope, string, error) {
+	shdr, err := signer.NewSignatureHeader()
+	if err != nil {
+		return nil, "", err
+	}
+
+	txID, err := ComputeProposalTxID(shdr.Nonce, shdr.Creator)
+	if err != nil {
+		return nil, "", err
+	}
+
+	chdr := MakeChannelHeader(common.HeaderType_TOKEN_TRANSACTION, 0, channelID, 0)
+	chdr.TxId = txID
+
+	data, err := proto.Marshal(ttx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	paylBytes, err := proto.Marshal(&common.Payload{
+		Header: MakePayloadHeader(chdr, shdr),
+		Data:   data,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	sig, err := signer.Sign(paylBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &common.Envelope{Payload: paylBytes, Signature: sig}, txID, nil
+}
+
 // CreateSignedTx assembles an Envelope message from proposal, endorsements, and a signer.
 // This function should be called by a client when it has collected enough endorsements
 // for a proposal to create a transaction and submit it to peers for ordering
@@ -129,8 +167,7 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 	}
 
 	// the original payload
-	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
-	if err != nil {
+	if _, err := GetChaincodeProposalPayload(proposal.Payload); err != nil {
 		return nil, fmt.Errorf("Could not unmarshal the proposal payload")
 	}
 
@@ -150,6 +187,41 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 		return nil, fmt.Errorf("The signer needs to be the same as the one referenced in the header")
 	}
 
+	env, err := CreateUnsignedTx(proposal, resps...)
+	if err != nil {
+		return nil, err
+	}
+
+	// sign the payload
+	sig, err := signer.Sign(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// here's the envelope
+	env.Signature = sig
+	return env, nil
+}
+
+// CreateUnsignedTx assembles an Envelope message from proposal, endorsements, which is not signed yet.
+// The payload of the envelope is to be signed by the creator of the proposal, referenced in its header
+func CreateUnsignedTx(proposal *peer.Proposal, resps ...*peer.ProposalResponse) (*common.Envelope, error) {
+	if len(resps) == 0 {
+		return nil, fmt.Errorf("At least one proposal response is necessary")
+	}
+
+	// the original header
+	hdr, err := GetHeader(proposal.Header)
+	if err != nil {
+		return nil, fmt.Errorf("Could not unmarshal the proposal header")
+	}
+
+	// the original payload
+	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("Could not unmarshal the proposal payload")
+	}
+
 	// get header extensions so we have the visibility field
 	hdrExt, err := GetChaincodeHeaderExtension(hdr)
 	if err != nil {
@@ -213,14 +285,7 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 		return nil, err
 	}
 
-	// sign the payload
-	sig, err := signer.Sign(paylBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	// here's the envelope
-	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
+	return &common.Envelope{Payload: paylBytes}, nil
 }
 
 // CreateProposalResponse creates a proposal response.
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -232,6 +233,47 @@ func TestCreateSignedTx(t *testing.T) {
 
 }
 
+func TestCreateUnsignedTx(t *testing.T) {
+	signID, err := mockmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	assert.NoError(t, err, "Unexpected error getting signing identity")
+	signerBytes, err := signID.Serialize()
+	assert.NoError(t, err, "Unexpected error serializing signing identity")
+
+	ccHeaderExtensionBytes, _ := proto.Marshal(&pb.ChaincodeHeaderExtension{})
+	chdrBytes, _ := proto.Marshal(&cb.ChannelHeader{
+		Extension: ccHeaderExtensionBytes,
+	})
+	shdrBytes, _ := proto.Marshal(&cb.SignatureHeader{
+		Creator: signerBytes,
+	})
+	headerBytes, _ := proto.Marshal(&cb.Header{
+		ChannelHeader:   chdrBytes,
+		SignatureHeader: shdrBytes,
+	})
+	prop := &pb.Proposal{Header: headerBytes}
+	responses := []*pb.ProposalResponse{{
+		Payload:     []byte("payload"),
+		Endorsement: &pb.Endorsement{},
+		Response: &pb.Response{
+			Status: int32(200),
+		},
+	}}
+
+	// no proposal responses
+	_, err = utils.CreateUnsignedTx(prop)
+	assert.Error(t, err, "Expected error with no proposal responses")
+
+	// success
+	env, err := utils.CreateUnsignedTx(prop, responses...)
+	assert.NoError(t, err, "Unexpected error creating unsigned transaction")
+	assert.Nil(t, env.Signature)
+
+	// signing the unsigned transaction yields the signed transaction
+	signedEnv, err := utils.CreateSignedTx(prop, signID, responses...)
+	assert.NoError(t, err, "Unexpected error creating signed transaction")
+	assert.Equal(t, signedEnv.Payload, env.Payload)
+}
+
 func TestCreateSignedEnvelope(t *testing.T) {
 	var env *cb.Envelope
 	channelID := "mychannelID"
@@ -275,6 +317,42 @@ func TestCreateSignedEnvelopeNilSigner(t *testing.T) {
 	assert.Equal(t, msg, data, "Payload data does not match expected value")
 }
 
+func TestCreateSignedTokenTxEnvelope(t *testing.T) {
+	ttx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainIssue{
+					PlainIssue: &token.PlainIssue{
+						Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "TOK", Quantity: 100}},
+					},
+				},
+			},
+		},
+	}
+
+	env, txID, err := utils.CreateSignedTokenTxEnvelope("mychannelID", ttx, goodSigner)
+	assert.NoError(t, err, "Unexpected error creating signed token transaction envelope")
+	// mock sign returns the bytes to be signed
+	assert.Equal(t, env.Payload, env.Signature, "Unexpected signature returned")
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err, "Failed to unmarshal payload")
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err, "Failed to unmarshal channel header")
+	assert.Equal(t, cb.HeaderType_TOKEN_TRANSACTION, cb.HeaderType(chdr.Type))
+	assert.Equal(t, "mychannelID", chdr.ChannelId)
+	assert.Equal(t, txID, chdr.TxId)
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	assert.NoError(t, err, "Failed to unmarshal signature header")
+	assert.NoError(t, utils.CheckProposalTxID(txID, shdr.Nonce, shdr.Creator))
+	data := &token.TokenTransaction{}
+	err = proto.Unmarshal(payload.Data, data)
+	assert.NoError(t, err, "Expected payload data to be a token transaction")
+	assert.True(t, proto.Equal(ttx, data), "Payload data does not match expected value")
+
+	_, _, err = utils.CreateSignedTokenTxEnvelope("mychannelID", ttx, badSigner)
+	assert.EqualError(t, err, "signature header error")
+}
+
 func TestGetSignedProposal(t *testing.T) {
 	var signedProp *pb.SignedProposal
 	var err error
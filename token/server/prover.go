@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("token/server")
+
+// ACLProvider checks the access control of the resources of a channel
+type ACLProvider interface {
+	// CheckACL checks the access control of the given resource of the given channel
+	// for the given identity information
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// QueryExecutorCreator creates query executors on the state of a channel
+type QueryExecutorCreator func(channel string) (ledger.QueryExecutor, error)
+
+// Prover is the gRPC service through which clients have the peer prepare token
+// transactions, which they submit to the ordering service, and list their tokens
+type Prover struct {
+	ACLProvider      ACLProvider
+	NewQueryExecutor QueryExecutorCreator
+	Signer           crypto.LocalSigner
+	Issuer           plain.Issuer
+}
+
+// NewProver creates a Prover that checks commands with the given ACL provider,
+// reads the state of the channels with the query executors created by the given
+// function, and signs its responses with the given signer
+func NewProver(aclProvider ACLProvider, newQueryExecutor QueryExecutorCreator, signer crypto.LocalSigner) *Prover {
+	return &Prover{
+		ACLProvider:      aclProvider,
+		NewQueryExecutor: newQueryExecutor,
+		Signer:           signer,
+	}
+}
+
+// ProcessCommand processes the given signed command and returns a signed response
+// holding either the result of the command or the error that made it fail.
+// It returns an error only if it fails to create the response.
+func (p *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*token.SignedCommandResponse, error) {
+	response, err := p.processCommand(sc)
+	if err != nil {
+		logger.Warningf("Failed processing command: %s", err)
+		response = &token.CommandResponse{
+			Payload: &token.CommandResponse_Err{Err: &token.Error{Message: err.Error()}},
+		}
+	}
+	return p.signResponse(sc.Command, response)
+}
+
+func (p *Prover) processCommand(sc *token.SignedCommand) (*token.CommandResponse, error) {
+	command := &token.Command{}
+	if err := proto.Unmarshal(sc.Command, command); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling command")
+	}
+	header := command.Header
+	if err := validateHeader(header); err != nil {
+		return nil, err
+	}
+
+	signedData := []*common.SignedData{{Data: sc.Command, Identity: header.Creator, Signature: sc.Signature}}
+	if err := p.ACLProvider.CheckACL(resources.Token_ProcessCommand, header.ChannelId, signedData); err != nil {
+		return nil, errors.WithMessage(err, "access denied")
+	}
+
+	switch payload := command.Payload.(type) {
+	case *token.Command_IssueRequest:
+		// the transaction would be invalid if its creator isn't allowed to issue tokens
+		if err := p.ACLProvider.CheckACL(resources.Token_Issue, header.ChannelId, signedData); err != nil {
+			return nil, errors.WithMessage(err, "access denied")
+		}
+		ttx, err := p.Issuer.RequestIssue(payload.IssueRequest.TokensToIssue)
+		if err != nil {
+			return nil, err
+		}
+		return tokenTransactionResponse(ttx), nil
+
+	case *token.Command_TransferRequest:
+		var ttx *token.TokenTransaction
+		err := p.withTransactor(header, func(transactor *plain.Transactor) (err error) {
+			ttx, err = transactor.RequestTransfer(payload.TransferRequest)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tokenTransactionResponse(ttx), nil
+
+	case *token.Command_RedeemRequest:
+		var ttx *token.TokenTransaction
+		err := p.withTransactor(header, func(transactor *plain.Transactor) (err error) {
+			ttx, err = transactor.RequestRedeem(payload.RedeemRequest)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tokenTransactionResponse(ttx), nil
+
+	case *token.Command_ListRequest:
+		var unspent *token.UnspentTokens
+		err := p.withTransactor(header, func(transactor *plain.Transactor) (err error) {
+			unspent, err = transactor.ListTokens()
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &token.CommandResponse{
+			Payload: &token.CommandResponse_UnspentTokens{UnspentTokens: unspent},
+		}, nil
+
+	default:
+		return nil, errors.Errorf("command type not recognized: %T", payload)
+	}
+}
+
+// withTransactor calls the given function with a transactor of the tokens of the
+// creator of the command, which reads the state of the channel of the command
+func (p *Prover) withTransactor(header *token.Header, f func(*plain.Transactor) error) error {
+	qe, err := p.NewQueryExecutor(header.ChannelId)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating query executor")
+	}
+	defer qe.Done()
+
+	return f(&plain.Transactor{PublicCredential: header.Creator, Ledger: qe})
+}
+
+func (p *Prover) signResponse(command []byte, response *token.CommandResponse) (*token.SignedCommandResponse, error) {
+	shdr, err := p.Signer.NewSignatureHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating signature header")
+	}
+	response.Header = &token.CommandResponseHeader{
+		Timestamp:   util.CreateUtcTimestamp(),
+		CommandHash: util.ComputeSHA256(command),
+		Creator:     shdr.Creator,
+	}
+
+	responseBytes, err := proto.Marshal(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling response")
+	}
+	signature, err := p.Signer.Sign(responseBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed signing response")
+	}
+	return &token.SignedCommandResponse{Response: responseBytes, Signature: signature}, nil
+}
+
+func validateHeader(header *token.Header) error {
+	switch {
+	case header == nil:
+		return errors.New("command has no header")
+	case header.ChannelId == "":
+		return errors.New("command has no channel")
+	case len(header.Creator) == 0:
+		return errors.New("command has no creator")
+	case len(header.Nonce) == 0:
+		return errors.New("command has no nonce")
+	}
+	return nil
+}
+
+func tokenTransactionResponse(ttx *token.TokenTransaction) *token.CommandResponse {
+	return &token.CommandResponse{
+		Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: ttx},
+	}
+}
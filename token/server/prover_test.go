@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type mockACLProvider struct {
+	denied    map[string]bool
+	resources []string
+}
+
+func (m *mockACLProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	m.resources = append(m.resources, resName)
+	if m.denied[resName] {
+		return errors.Errorf("%s denied", resName)
+	}
+	return nil
+}
+
+// mockQueryExecutor is an in-memory state of the tokens namespace
+type mockQueryExecutor struct {
+	ledger.QueryExecutor
+	state map[string][]byte
+	done  int
+}
+
+func (m *mockQueryExecutor) SetState(namespace string, key string, value []byte) error {
+	m.state[key] = value
+	return nil
+}
+
+func (m *mockQueryExecutor) DeleteState(namespace string, key string) error {
+	delete(m.state, key)
+	return nil
+}
+
+func (m *mockQueryExecutor) GetState(namespace string, key string) ([]byte, error) {
+	return m.state[key], nil
+}
+
+func (m *mockQueryExecutor) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	itr := &mockResultsIterator{}
+	for key, value := range m.state {
+		if key >= startKey && key < endKey {
+			itr.kvs = append(itr.kvs, &queryresult.KV{Namespace: namespace, Key: key, Value: value})
+		}
+	}
+	sort.Slice(itr.kvs, func(i, j int) bool { return itr.kvs[i].Key < itr.kvs[j].Key })
+	return itr, nil
+}
+
+func (m *mockQueryExecutor) Done() {
+	m.done++
+}
+
+type mockResultsIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (itr *mockResultsIterator) Next() (commonledger.QueryResult, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *mockResultsIterator) Close() {}
+
+func signedCommand(t *testing.T, command *token.Command) *token.SignedCommand {
+	commandBytes, err := proto.Marshal(command)
+	assert.NoError(t, err)
+	return &token.SignedCommand{Command: commandBytes, Signature: []byte("signature")}
+}
+
+func commandHeader() *token.Header {
+	return &token.Header{
+		Timestamp: util.CreateUtcTimestamp(),
+		ChannelId: "testchannel",
+		Nonce:     []byte("nonce"),
+		Creator:   []byte("alice"),
+	}
+}
+
+func processCommand(t *testing.T, prover *Prover, sc *token.SignedCommand) *token.CommandResponse {
+	scr, err := prover.ProcessCommand(context.Background(), sc)
+	assert.NoError(t, err)
+	// the mock signer signs messages with themselves
+	assert.Equal(t, scr.Response, scr.Signature)
+
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(scr.Response, response)
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256(sc.Command), response.Header.CommandHash)
+	assert.Equal(t, []byte("peer"), response.Header.Creator)
+	return response
+}
+
+func newTestProver() (*Prover, *mockACLProvider, *mockQueryExecutor) {
+	aclProvider := &mockACLProvider{denied: map[string]bool{}}
+	qe := &mockQueryExecutor{state: map[string][]byte{}}
+	newQueryExecutor := func(channel string) (ledger.QueryExecutor, error) {
+		if channel != "testchannel" {
+			return nil, errors.Errorf("channel %s doesn't exist", channel)
+		}
+		return qe, nil
+	}
+	return NewProver(aclProvider, newQueryExecutor, &mockcrypto.LocalSigner{Identity: []byte("peer")}), aclProvider, qe
+}
+
+func TestProverIssue(t *testing.T) {
+	prover, aclProvider, _ := newTestProver()
+	sc := signedCommand(t, &token.Command{
+		Header: commandHeader(),
+		Payload: &token.Command_IssueRequest{IssueRequest: &token.IssueRequest{
+			TokensToIssue: []*token.TokenToIssue{{Recipient: []byte("bob"), Type: "coin", Quantity: 10}},
+		}},
+	})
+
+	response := processCommand(t, prover, sc)
+	assert.Equal(t, []string{resources.Token_ProcessCommand, resources.Token_Issue}, aclProvider.resources)
+	outputs := response.GetTokenTransaction().GetPlainAction().GetPlainIssue().GetOutputs()
+	assert.Equal(t, []*token.PlainOutput{{Owner: []byte("bob"), Type: "coin", Quantity: 10}}, outputs)
+
+	aclProvider.denied[resources.Token_Issue] = true
+	response = processCommand(t, prover, sc)
+	assert.Equal(t, "access denied: token/Issue denied", response.GetErr().GetMessage())
+}
+
+func TestProverTransferRedeemAndList(t *testing.T) {
+	prover, _, qe := newTestProver()
+	issue := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{
+			Data: &token.PlainTokenAction_PlainIssue{PlainIssue: &token.PlainIssue{
+				Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "coin", Quantity: 10}},
+			}},
+		}},
+	}
+	err := (&plain.Verifier{}).ProcessTx("issue", []byte("alice"), issue, qe)
+	assert.NoError(t, err)
+	ids := []*token.InputId{{TxId: "issue", Index: 0}}
+
+	response := processCommand(t, prover, signedCommand(t, &token.Command{
+		Header:  commandHeader(),
+		Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+	}))
+	assert.Equal(t, []*token.TokenOutput{{Id: ids[0], Type: "coin", Quantity: 10}}, response.GetUnspentTokens().GetTokens())
+
+	response = processCommand(t, prover, signedCommand(t, &token.Command{
+		Header: commandHeader(),
+		Payload: &token.Command_TransferRequest{TransferRequest: &token.TransferRequest{
+			TokenIds: ids,
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 10}},
+		}},
+	}))
+	transfer := response.GetTokenTransaction().GetPlainAction().GetPlainTransfer()
+	assert.Equal(t, []*token.PlainOutput{{Owner: []byte("bob"), Type: "coin", Quantity: 10}}, transfer.GetOutputs())
+
+	response = processCommand(t, prover, signedCommand(t, &token.Command{
+		Header: commandHeader(),
+		Payload: &token.Command_RedeemRequest{RedeemRequest: &token.RedeemRequest{
+			TokenIds:         ids,
+			QuantityToRedeem: 20,
+		}},
+	}))
+	assert.Equal(t, "the quantity to redeem (20) exceeds the quantity of the tokens (10)", response.GetErr().GetMessage())
+	assert.Equal(t, 3, qe.done)
+}
+
+func TestProverBadCommand(t *testing.T) {
+	prover, aclProvider, _ := newTestProver()
+
+	response := processCommand(t, prover, &token.SignedCommand{Command: []byte("garbage")})
+	assert.Contains(t, response.GetErr().GetMessage(), "failed unmarshaling command")
+
+	header := commandHeader()
+	header.Nonce = nil
+	response = processCommand(t, prover, signedCommand(t, &token.Command{Header: header}))
+	assert.Equal(t, "command has no nonce", response.GetErr().GetMessage())
+
+	header = commandHeader()
+	header.ChannelId = "otherchannel"
+	response = processCommand(t, prover, signedCommand(t, &token.Command{
+		Header:  header,
+		Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+	}))
+	assert.Equal(t, "failed creating query executor: channel otherchannel doesn't exist", response.GetErr().GetMessage())
+
+	response = processCommand(t, prover, signedCommand(t, &token.Command{Header: commandHeader()}))
+	assert.Equal(t, "command type not recognized: <nil>", response.GetErr().GetMessage())
+
+	aclProvider.denied[resources.Token_ProcessCommand] = true
+	response = processCommand(t, prover, signedCommand(t, &token.Command{
+		Header:  commandHeader(),
+		Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+	}))
+	assert.Equal(t, "access denied: token/ProcessCommand denied", response.GetErr().GetMessage())
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// StateReader reads the state of the ledger
+type StateReader interface {
+	// GetState gets the value for given namespace and key
+	GetState(namespace string, key string) ([]byte, error)
+}
+
+// invalidInputsError is returned when the creator of a token transaction can't spend its inputs
+type invalidInputsError struct {
+	msg string
+}
+
+func (e *invalidInputsError) Error() string {
+	return e.msg
+}
+
+// checkInputs checks that the tokens with the given IDs are distinct, unspent, of the same
+// type and owned by the given creator, and returns their type and total quantity.
+// It returns an invalidInputsError if they aren't, and the errors of the ledger as they are.
+func checkInputs(ledger StateReader, creator []byte, ids []*token.InputId) (string, uint64, error) {
+	if len(ids) == 0 {
+		return "", 0, &invalidInputsError{msg: "no inputs"}
+	}
+
+	var tokenType string
+	var quantity uint64
+	spent := make(map[string]struct{})
+	for _, id := range ids {
+		key := outputKey(id.TxId, id.Index)
+		if _, exists := spent[key]; exists {
+			return "", 0, &invalidInputsError{msg: fmt.Sprintf("input %s is spent more than once", inputString(id))}
+		}
+		spent[key] = struct{}{}
+
+		output, err := unspentOutput(ledger, id)
+		if err != nil {
+			return "", 0, err
+		}
+		if output == nil {
+			return "", 0, &invalidInputsError{msg: fmt.Sprintf("input %s doesn't exist or is spent", inputString(id))}
+		}
+		if !bytes.Equal(output.Owner, creator) {
+			return "", 0, &invalidInputsError{msg: fmt.Sprintf("input %s isn't owned by the creator", inputString(id))}
+		}
+		if tokenType == "" {
+			tokenType = output.Type
+		} else if output.Type != tokenType {
+			return "", 0, &invalidInputsError{msg: fmt.Sprintf("input %s has type %s, while the other inputs have type %s", inputString(id), output.Type, tokenType)}
+		}
+		if quantity+output.Quantity < quantity {
+			return "", 0, &invalidInputsError{msg: "the quantity of the inputs overflows"}
+		}
+		quantity += output.Quantity
+	}
+	return tokenType, quantity, nil
+}
+
+// unspentOutput returns the unspent token with the given ID, or nil if it doesn't exist or is spent
+func unspentOutput(ledger StateReader, id *token.InputId) (*token.PlainOutput, error) {
+	value, err := ledger.GetState(Namespace, outputKey(id.TxId, id.Index))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed retrieving token %s", inputString(id)))
+	}
+	if value == nil {
+		return nil, nil
+	}
+	output := &token.PlainOutput{}
+	if err := proto.Unmarshal(value, output); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling token %s", inputString(id))
+	}
+	return output, nil
+}
+
+func inputString(id *token.InputId) string {
+	return fmt.Sprintf("%s:%d", id.TxId, id.Index)
+}
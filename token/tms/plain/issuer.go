@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// Issuer creates token transactions that issue plain tokens
+type Issuer struct{}
+
+// RequestIssue creates a token transaction that issues the given tokens
+func (i *Issuer) RequestIssue(tokensToIssue []*token.TokenToIssue) (*token.TokenTransaction, error) {
+	if len(tokensToIssue) == 0 {
+		return nil, errors.New("no tokens to issue")
+	}
+
+	var outputs []*token.PlainOutput
+	for _, tti := range tokensToIssue {
+		output := &token.PlainOutput{Owner: tti.Recipient, Type: tti.Type, Quantity: tti.Quantity}
+		if err := checkOutput(output); err != nil {
+			return nil, errors.Errorf("token to issue %s", err.msg)
+		}
+		outputs = append(outputs, output)
+	}
+
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainIssue{
+			PlainIssue: &token.PlainIssue{Outputs: outputs},
+		},
+	}), nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Namespace is the namespace of the state of the ledger that holds the unspent tokens
+	Namespace = "tms"
+
+	tokenOutput           = "tokenOutput"
+	compositeKeyNamespace = "\x00"
+	compositeKeySeparator = "\x00"
+	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
+)
+
+// outputKey returns the key of the state that holds the token created by the output
+// with the given index of the given transaction, as long as the token is unspent.
+// The keys are composite keys, as chaincodes create them.
+func outputKey(txID string, index uint32) string {
+	return createCompositeKey(tokenOutput, txID, strconv.FormatUint(uint64(index), 10))
+}
+
+// outputsRange returns the start and end keys of the range of the keys of all the unspent tokens
+func outputsRange() (string, string) {
+	prefix := createCompositeKey(tokenOutput)
+	return prefix, prefix + string(maxUnicodeRuneValue)
+}
+
+// parseOutputKey returns the ID of the token whose state has the given key
+func parseOutputKey(key string) (*token.InputId, error) {
+	components := strings.Split(strings.TrimPrefix(key, compositeKeyNamespace), compositeKeySeparator)
+	// the composite key ends with a separator, hence its last component is empty
+	if len(components) != 4 || components[0] != tokenOutput {
+		return nil, errors.Errorf("key %q isn't the key of a token", key)
+	}
+	index, err := strconv.ParseUint(components[2], 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "key %q has an invalid output index", key)
+	}
+	return &token.InputId{TxId: components[1], Index: uint32(index)}, nil
+}
+
+func createCompositeKey(objectType string, attributes ...string) string {
+	ck := compositeKeyNamespace + objectType + compositeKeySeparator
+	for _, att := range attributes {
+		ck += att + compositeKeySeparator
+	}
+	return ck
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"sort"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+
+// memoryLedger is an in-memory state of a single namespace
+type memoryLedger struct {
+	state map[string][]byte
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{state: map[string][]byte{}}
+}
+
+func (l *memoryLedger) GetState(namespace string, key string) ([]byte, error) {
+	return l.state[key], nil
+}
+
+func (l *memoryLedger) SetState(namespace string, key string, value []byte) error {
+	l.state[key] = value
+	return nil
+}
+
+func (l *memoryLedger) DeleteState(namespace string, key string) error {
+	delete(l.state, key)
+	return nil
+}
+
+func (l *memoryLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	var keys []string
+	for key := range l.state {
+		if key >= startKey && key < endKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	itr := &memoryIterator{}
+	for _, key := range keys {
+		itr.kvs = append(itr.kvs, &queryresult.KV{Namespace: namespace, Key: key, Value: l.state[key]})
+	}
+	return itr, nil
+}
+
+type memoryIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (itr *memoryIterator) Next() (commonledger.QueryResult, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *memoryIterator) Close() {}
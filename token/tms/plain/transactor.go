@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// LedgerReader reads the state of the ledger
+type LedgerReader interface {
+	StateReader
+	// GetStateRangeScanIterator returns an iterator over the keys of the given namespace between the given keys
+	GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error)
+}
+
+// Transactor creates token transactions that transfer and redeem the plain tokens
+// owned by PublicCredential, and lists them
+type Transactor struct {
+	PublicCredential []byte
+	Ledger           LedgerReader
+}
+
+// RequestTransfer creates a token transaction that transfers the tokens with the given IDs
+// to the recipients of the given shares
+func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
+	tokenType, quantity, err := checkInputs(t.Ledger, t.PublicCredential, request.TokenIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.Shares) == 0 {
+		return nil, errors.New("no shares to transfer")
+	}
+
+	var outputs []*token.PlainOutput
+	for _, share := range request.Shares {
+		outputs = append(outputs, &token.PlainOutput{Owner: share.Recipient, Type: tokenType, Quantity: share.Quantity})
+	}
+	outputQuantity, err := sumOutputs(tokenType, outputs)
+	if err != nil {
+		return nil, err
+	}
+	if outputQuantity != quantity {
+		return nil, errors.Errorf("the shares add up to %d, while the tokens to transfer add up to %d", outputQuantity, quantity)
+	}
+
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{
+			PlainTransfer: &token.PlainTransfer{Inputs: request.TokenIds, Outputs: outputs},
+		},
+	}), nil
+}
+
+// RequestRedeem creates a token transaction that redeems the given quantity of the tokens
+// with the given IDs, and returns the remainder to their owner
+func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTransaction, error) {
+	tokenType, quantity, err := checkInputs(t.Ledger, t.PublicCredential, request.TokenIds)
+	if err != nil {
+		return nil, err
+	}
+	if request.QuantityToRedeem == 0 {
+		return nil, errors.New("the quantity to redeem is zero")
+	}
+	if request.QuantityToRedeem > quantity {
+		return nil, errors.Errorf("the quantity to redeem (%d) exceeds the quantity of the tokens (%d)", request.QuantityToRedeem, quantity)
+	}
+
+	var outputs []*token.PlainOutput
+	if remainder := quantity - request.QuantityToRedeem; remainder > 0 {
+		outputs = append(outputs, &token.PlainOutput{Owner: t.PublicCredential, Type: tokenType, Quantity: remainder})
+	}
+
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainRedeem{
+			PlainRedeem: &token.PlainRedeem{Inputs: request.TokenIds, Quantity: request.QuantityToRedeem, Outputs: outputs},
+		},
+	}), nil
+}
+
+// ListTokens returns the unspent tokens owned by PublicCredential
+func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
+	startKey, endKey := outputsRange()
+	itr, err := t.Ledger.GetStateRangeScanIterator(Namespace, startKey, endKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed querying tokens")
+	}
+	defer itr.Close()
+
+	unspent := &token.UnspentTokens{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed querying tokens")
+		}
+		if res == nil {
+			return unspent, nil
+		}
+		kv := res.(*queryresult.KV)
+		output := &token.PlainOutput{}
+		if err := proto.Unmarshal(kv.Value, output); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling token with key %q", kv.Key)
+		}
+		if !bytes.Equal(output.Owner, t.PublicCredential) {
+			continue
+		}
+		id, err := parseOutputKey(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		unspent.Tokens = append(unspent.Tokens, &token.TokenOutput{Id: id, Type: output.Type, Quantity: output.Quantity})
+	}
+}
+
+func plainTokenTransaction(action *token.PlainTokenAction) *token.TokenTransaction {
+	return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+}
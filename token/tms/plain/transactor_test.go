@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuer(t *testing.T) {
+	issuer := &Issuer{}
+
+	ttx, err := issuer.RequestIssue([]*token.TokenToIssue{{Recipient: alice, Type: "coin", Quantity: 10}})
+	assert.NoError(t, err)
+	assert.Equal(t, issueTx(&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10}), ttx)
+
+	_, err = issuer.RequestIssue(nil)
+	assert.EqualError(t, err, "no tokens to issue")
+
+	_, err = issuer.RequestIssue([]*token.TokenToIssue{{Type: "coin", Quantity: 10}})
+	assert.EqualError(t, err, "token to issue has no owner")
+}
+
+func TestTransactor(t *testing.T) {
+	ledger := newMemoryLedger()
+	err := (&Verifier{}).ProcessTx("issue", alice, issueTx(
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10},
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 5},
+		&token.PlainOutput{Owner: alice, Type: "bill", Quantity: 1},
+	), ledger)
+	assert.NoError(t, err)
+	transactor := &Transactor{PublicCredential: alice, Ledger: ledger}
+	coins := []*token.InputId{{TxId: "issue", Index: 0}}
+
+	unspent, err := transactor.ListTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, []*token.TokenOutput{
+		{Id: &token.InputId{TxId: "issue", Index: 0}, Type: "coin", Quantity: 10},
+		{Id: &token.InputId{TxId: "issue", Index: 2}, Type: "bill", Quantity: 1},
+	}, unspent.Tokens)
+
+	ttx, err := transactor.RequestTransfer(&token.TransferRequest{
+		TokenIds: coins,
+		Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 7}, {Recipient: alice, Quantity: 3}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, transferTx(coins,
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 7},
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 3},
+	), ttx)
+
+	_, err = transactor.RequestTransfer(&token.TransferRequest{
+		TokenIds: coins,
+		Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 7}},
+	})
+	assert.EqualError(t, err, "the shares add up to 7, while the tokens to transfer add up to 10")
+
+	_, err = transactor.RequestTransfer(&token.TransferRequest{
+		TokenIds: []*token.InputId{{TxId: "issue", Index: 1}},
+		Shares:   []*token.RecipientTransferShare{{Recipient: alice, Quantity: 5}},
+	})
+	assert.EqualError(t, err, "input issue:1 isn't owned by the creator")
+
+	ttx, err = transactor.RequestRedeem(&token.RedeemRequest{TokenIds: coins, QuantityToRedeem: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, redeemTx(coins, 4, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 6}), ttx)
+
+	ttx, err = transactor.RequestRedeem(&token.RedeemRequest{TokenIds: coins, QuantityToRedeem: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, redeemTx(coins, 10), ttx)
+
+	_, err = transactor.RequestRedeem(&token.RedeemRequest{TokenIds: coins, QuantityToRedeem: 11})
+	assert.EqualError(t, err, "the quantity to redeem (11) exceeds the quantity of the tokens (10)")
+}
+
+func TestParseOutputKey(t *testing.T) {
+	id, err := parseOutputKey(outputKey("tx1", 42))
+	assert.NoError(t, err)
+	assert.Equal(t, &token.InputId{TxId: "tx1", Index: 42}, id)
+
+	_, err = parseOutputKey("tx1")
+	assert.Error(t, err)
+}
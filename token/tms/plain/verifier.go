@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+var verifierLogger = flogging.MustGetLogger("token/tms/plain")
+
+// LedgerWriter reads and writes the state of the ledger
+type LedgerWriter interface {
+	StateReader
+	// SetState sets the given value for the given namespace and key
+	SetState(namespace string, key string, value []byte) error
+	// DeleteState deletes the given namespace and key
+	DeleteState(namespace string, key string) error
+}
+
+// Verifier checks plain token transactions and applies them to the state of the ledger
+type Verifier struct{}
+
+// ProcessTx checks that the given token transaction, created by the given creator, is valid,
+// and if it is, spends its inputs and creates its outputs in the state of the ledger.
+// It returns a customtx.InvalidTxError if the transaction is invalid, and the errors of
+// the ledger as they are.
+func (v *Verifier) ProcessTx(txID string, creator []byte, ttx *token.TokenTransaction, ledger LedgerWriter) error {
+	action := ttx.GetPlainAction()
+	if action == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown action in token transaction %s", txID)}
+	}
+
+	var err error
+	switch data := action.Data.(type) {
+	case *token.PlainTokenAction_PlainIssue:
+		err = v.issue(txID, data.PlainIssue, ledger)
+	case *token.PlainTokenAction_PlainTransfer:
+		err = v.transfer(txID, creator, data.PlainTransfer, ledger)
+	case *token.PlainTokenAction_PlainRedeem:
+		err = v.redeem(txID, creator, data.PlainRedeem, ledger)
+	default:
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown plain token action in token transaction %s", txID)}
+	}
+	if invalidErr, ok := err.(*invalidInputsError); ok {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid token transaction %s: %s", txID, invalidErr.msg)}
+	}
+	return err
+}
+
+func (v *Verifier) issue(txID string, issue *token.PlainIssue, ledger LedgerWriter) error {
+	if len(issue.Outputs) == 0 {
+		return &invalidInputsError{msg: "no outputs"}
+	}
+	for i, output := range issue.Outputs {
+		if err := checkOutput(output); err != nil {
+			return &invalidInputsError{msg: fmt.Sprintf("output %d %s", i, err.msg)}
+		}
+	}
+	return v.createOutputs(txID, issue.Outputs, ledger)
+}
+
+func (v *Verifier) transfer(txID string, creator []byte, transfer *token.PlainTransfer, ledger LedgerWriter) error {
+	tokenType, inputQuantity, err := checkInputs(ledger, creator, transfer.Inputs)
+	if err != nil {
+		return err
+	}
+	if len(transfer.Outputs) == 0 {
+		return &invalidInputsError{msg: "no outputs"}
+	}
+	outputQuantity, err := sumOutputs(tokenType, transfer.Outputs)
+	if err != nil {
+		return err
+	}
+	if outputQuantity != inputQuantity {
+		return &invalidInputsError{msg: fmt.Sprintf("the quantity of the outputs (%d) doesn't match the quantity of the inputs (%d)", outputQuantity, inputQuantity)}
+	}
+
+	if err := v.spendInputs(transfer.Inputs, ledger); err != nil {
+		return err
+	}
+	return v.createOutputs(txID, transfer.Outputs, ledger)
+}
+
+func (v *Verifier) redeem(txID string, creator []byte, redeem *token.PlainRedeem, ledger LedgerWriter) error {
+	tokenType, inputQuantity, err := checkInputs(ledger, creator, redeem.Inputs)
+	if err != nil {
+		return err
+	}
+	if redeem.Quantity == 0 {
+		return &invalidInputsError{msg: "the redeemed quantity is zero"}
+	}
+	// the outputs of a redemption return the remainder of the inputs to the creator
+	for i, output := range redeem.Outputs {
+		if output == nil || !bytes.Equal(output.Owner, creator) {
+			return &invalidInputsError{msg: fmt.Sprintf("output %d isn't owned by the creator", i)}
+		}
+	}
+	outputQuantity, err := sumOutputs(tokenType, redeem.Outputs)
+	if err != nil {
+		return err
+	}
+	if outputQuantity+redeem.Quantity < outputQuantity || outputQuantity+redeem.Quantity != inputQuantity {
+		return &invalidInputsError{msg: fmt.Sprintf("the redeemed quantity (%d) and the quantity of the outputs (%d) don't add up to the quantity of the inputs (%d)", redeem.Quantity, outputQuantity, inputQuantity)}
+	}
+
+	if err := v.spendInputs(redeem.Inputs, ledger); err != nil {
+		return err
+	}
+	return v.createOutputs(txID, redeem.Outputs, ledger)
+}
+
+func (v *Verifier) spendInputs(ids []*token.InputId, ledger LedgerWriter) error {
+	for _, id := range ids {
+		if err := ledger.DeleteState(Namespace, outputKey(id.TxId, id.Index)); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed spending token %s", inputString(id)))
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) createOutputs(txID string, outputs []*token.PlainOutput, ledger LedgerWriter) error {
+	for i, output := range outputs {
+		value, err := proto.Marshal(output)
+		if err != nil {
+			return errors.Wrapf(err, "failed marshaling output %d of token transaction %s", i, txID)
+		}
+		if err := ledger.SetState(Namespace, outputKey(txID, uint32(i)), value); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed creating output %d of token transaction %s", i, txID))
+		}
+	}
+	verifierLogger.Debugf("Created %d outputs of token transaction %s", len(outputs), txID)
+	return nil
+}
+
+// sumOutputs checks the given outputs of a transaction spending tokens of the given type,
+// and returns their total quantity
+func sumOutputs(tokenType string, outputs []*token.PlainOutput) (uint64, error) {
+	var quantity uint64
+	for i, output := range outputs {
+		if err := checkOutput(output); err != nil {
+			return 0, &invalidInputsError{msg: fmt.Sprintf("output %d %s", i, err.msg)}
+		}
+		if output.Type != tokenType {
+			return 0, &invalidInputsError{msg: fmt.Sprintf("output %d has type %s, while the inputs have type %s", i, output.Type, tokenType)}
+		}
+		if quantity+output.Quantity < quantity {
+			return 0, &invalidInputsError{msg: "the quantity of the outputs overflows"}
+		}
+		quantity += output.Quantity
+	}
+	return quantity, nil
+}
+
+func checkOutput(output *token.PlainOutput) *invalidInputsError {
+	switch {
+	case output == nil:
+		return &invalidInputsError{msg: "is missing"}
+	case len(output.Owner) == 0:
+		return &invalidInputsError{msg: "has no owner"}
+	case output.Type == "":
+		return &invalidInputsError{msg: "has no type"}
+	case output.Quantity == 0:
+		return &invalidInputsError{msg: "has zero quantity"}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	alice = []byte("alice")
+	bob   = []byte("bob")
+)
+
+func issueTx(outputs ...*token.PlainOutput) *token.TokenTransaction {
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainIssue{PlainIssue: &token.PlainIssue{Outputs: outputs}},
+	})
+}
+
+func transferTx(inputs []*token.InputId, outputs ...*token.PlainOutput) *token.TokenTransaction {
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{Inputs: inputs, Outputs: outputs}},
+	})
+}
+
+func redeemTx(inputs []*token.InputId, quantity uint64, outputs ...*token.PlainOutput) *token.TokenTransaction {
+	return plainTokenTransaction(&token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: &token.PlainRedeem{Inputs: inputs, Quantity: quantity, Outputs: outputs}},
+	})
+}
+
+func assertInvalid(t *testing.T, err error, msg string) {
+	if assert.IsType(t, &customtx.InvalidTxError{}, err) {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestVerifierIssue(t *testing.T) {
+	v := &Verifier{}
+	ledger := newMemoryLedger()
+
+	err := v.ProcessTx("issue", alice, issueTx(
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10},
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 5},
+	), ledger)
+	assert.NoError(t, err)
+	assert.Len(t, ledger.state, 2)
+
+	output, err := unspentOutput(ledger, &token.InputId{TxId: "issue", Index: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 5}, output)
+
+	err = v.ProcessTx("empty", alice, issueTx(), ledger)
+	assertInvalid(t, err, "no outputs")
+
+	err = v.ProcessTx("zero", alice, issueTx(&token.PlainOutput{Owner: alice, Type: "coin"}), ledger)
+	assertInvalid(t, err, "output 0 has zero quantity")
+
+	err = v.ProcessTx("untyped", alice, issueTx(&token.PlainOutput{Owner: alice, Quantity: 1}), ledger)
+	assertInvalid(t, err, "output 0 has no type")
+
+	err = v.ProcessTx("unowned", alice, issueTx(&token.PlainOutput{Type: "coin", Quantity: 1}), ledger)
+	assertInvalid(t, err, "output 0 has no owner")
+
+	err = v.ProcessTx("unknown", alice, &token.TokenTransaction{}, ledger)
+	assertInvalid(t, err, "unknown action")
+	assert.Len(t, ledger.state, 2)
+}
+
+func TestVerifierTransfer(t *testing.T) {
+	v := &Verifier{}
+	ledger := newMemoryLedger()
+	err := v.ProcessTx("issue", alice, issueTx(
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10},
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 5},
+		&token.PlainOutput{Owner: alice, Type: "bill", Quantity: 1},
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 1},
+	), ledger)
+	assert.NoError(t, err)
+
+	coins := []*token.InputId{{TxId: "issue", Index: 0}, {TxId: "issue", Index: 1}}
+
+	tests := []struct {
+		name   string
+		inputs []*token.InputId
+		output *token.PlainOutput
+		errMsg string
+	}{
+		{"no inputs", nil, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 1}, "no inputs"},
+		{"missing input", []*token.InputId{{TxId: "issue", Index: 7}}, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 1}, "input issue:7 doesn't exist or is spent"},
+		{"repeated input", []*token.InputId{coins[0], coins[0]}, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 20}, "input issue:0 is spent more than once"},
+		{"input not owned", []*token.InputId{{TxId: "issue", Index: 3}}, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 1}, "input issue:3 isn't owned by the creator"},
+		{"mixed types", []*token.InputId{coins[0], {TxId: "issue", Index: 2}}, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 11}, "input issue:2 has type bill"},
+		{"wrong output type", coins, &token.PlainOutput{Owner: bob, Type: "bill", Quantity: 15}, "output 0 has type bill"},
+		{"unbalanced", coins, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 16}, "the quantity of the outputs (16) doesn't match the quantity of the inputs (15)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.ProcessTx("transfer", alice, transferTx(test.inputs, test.output), ledger)
+			assertInvalid(t, err, test.errMsg)
+		})
+	}
+	assert.Len(t, ledger.state, 4)
+
+	err = v.ProcessTx("transfer", alice, transferTx(coins,
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 12},
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 3},
+	), ledger)
+	assert.NoError(t, err)
+	assert.Len(t, ledger.state, 4)
+	output, err := unspentOutput(ledger, &token.InputId{TxId: "transfer", Index: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 12}, output)
+
+	// the inputs are spent
+	err = v.ProcessTx("double-spend", alice, transferTx(coins, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 15}), ledger)
+	assertInvalid(t, err, "input issue:0 doesn't exist or is spent")
+}
+
+func TestVerifierTransferOverflow(t *testing.T) {
+	v := &Verifier{}
+	ledger := newMemoryLedger()
+	err := v.ProcessTx("issue", alice, issueTx(
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: math.MaxUint64},
+		&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 1},
+	), ledger)
+	assert.NoError(t, err)
+
+	err = v.ProcessTx("transfer", alice, transferTx(
+		[]*token.InputId{{TxId: "issue", Index: 0}, {TxId: "issue", Index: 1}},
+		&token.PlainOutput{Owner: bob, Type: "coin", Quantity: 1},
+	), ledger)
+	assertInvalid(t, err, "the quantity of the inputs overflows")
+}
+
+func TestVerifierRedeem(t *testing.T) {
+	v := &Verifier{}
+	ledger := newMemoryLedger()
+	err := v.ProcessTx("issue", alice, issueTx(&token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10}), ledger)
+	assert.NoError(t, err)
+	inputs := []*token.InputId{{TxId: "issue", Index: 0}}
+
+	err = v.ProcessTx("redeem", alice, redeemTx(inputs, 0, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 10}), ledger)
+	assertInvalid(t, err, "the redeemed quantity is zero")
+
+	err = v.ProcessTx("redeem", alice, redeemTx(inputs, 4, &token.PlainOutput{Owner: bob, Type: "coin", Quantity: 6}), ledger)
+	assertInvalid(t, err, "output 0 isn't owned by the creator")
+
+	err = v.ProcessTx("redeem", alice, redeemTx(inputs, 4, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 5}), ledger)
+	assertInvalid(t, err, "the redeemed quantity (4) and the quantity of the outputs (5) don't add up to the quantity of the inputs (10)")
+
+	err = v.ProcessTx("redeem", bob, redeemTx(inputs, 10), ledger)
+	assertInvalid(t, err, "input issue:0 isn't owned by the creator")
+
+	err = v.ProcessTx("redeem", alice, redeemTx(inputs, 4, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 6}), ledger)
+	assert.NoError(t, err)
+	assert.Len(t, ledger.state, 1)
+	output, err := unspentOutput(ledger, &token.InputId{TxId: "redeem", Index: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, &token.PlainOutput{Owner: alice, Type: "coin", Quantity: 6}, output)
+
+	err = v.ProcessTx("redeem-all", alice, redeemTx([]*token.InputId{{TxId: "redeem", Index: 0}}, 6), ledger)
+	assert.NoError(t, err)
+	assert.Empty(t, ledger.state)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/tms/plain"
+)
+
+var logger = flogging.MustGetLogger("token/transaction")
+
+// ACLProvider checks the access control of the resources of a channel
+type ACLProvider interface {
+	// CheckACL checks the access control of the given resource of the given channel
+	// for the given identity information
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// Processor is the custom transaction processor of token transactions.
+// It applies valid token transactions to the state of the ledger when
+// their block is committed, and marks invalid ones as such.
+type Processor struct {
+	// ACLProvider checks that the creators of token transactions that issue
+	// tokens are allowed to issue tokens on the channel
+	ACLProvider ACLProvider
+	Verifier    plain.Verifier
+}
+
+// GenerateSimulationResults implements customtx.Processor
+func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	payload, err := utils.UnmarshalPayload(txEnv.Payload)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("failed unmarshaling payload: %s", err)}
+	}
+	if payload.Header == nil {
+		return &customtx.InvalidTxError{Msg: "payload has no header"}
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("failed unmarshaling channel header: %s", err)}
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("failed unmarshaling signature header: %s", err)}
+	}
+	ttx := &token.TokenTransaction{}
+	if err := proto.Unmarshal(payload.Data, ttx); err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("failed unmarshaling token transaction %s: %s", chdr.TxId, err)}
+	}
+
+	// The transactions that are reprocessed when the ledger is initialized were valid
+	// when they were committed, regardless of the current configuration of the channel
+	if !initializingLedger && ttx.GetPlainAction().GetPlainIssue() != nil {
+		if err := p.ACLProvider.CheckACL(resources.Token_Issue, chdr.ChannelId, txEnv); err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("creator of token transaction %s isn't allowed to issue tokens: %s", chdr.TxId, err)}
+		}
+	}
+
+	if err := p.Verifier.ProcessTx(chdr.TxId, shdr.Creator, ttx, simulator); err != nil {
+		return err
+	}
+	logger.Debugf("Processed token transaction %s of channel %s", chdr.TxId, chdr.ChannelId)
+	return nil
+}
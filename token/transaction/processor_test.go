@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockACLProvider struct {
+	err       error
+	resources []string
+}
+
+func (m *mockACLProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	m.resources = append(m.resources, resName)
+	return m.err
+}
+
+// mockTxSimulator records the state written by the processor
+type mockTxSimulator struct {
+	ledger.TxSimulator
+	state map[string][]byte
+}
+
+func (m *mockTxSimulator) GetState(namespace string, key string) ([]byte, error) {
+	return m.state[namespace+"/"+key], nil
+}
+
+func (m *mockTxSimulator) SetState(namespace string, key string, value []byte) error {
+	m.state[namespace+"/"+key] = value
+	return nil
+}
+
+func (m *mockTxSimulator) DeleteState(namespace string, key string) error {
+	delete(m.state, namespace+"/"+key)
+	return nil
+}
+
+func tokenTxEnvelope(t *testing.T, txID string, data []byte) *common.Envelope {
+	chdr := utils.MakeChannelHeader(common.HeaderType_TOKEN_TRANSACTION, 0, "testchannel", 0)
+	chdr.TxId = txID
+	shdr := &common.SignatureHeader{Creator: []byte("alice"), Nonce: []byte("nonce")}
+	payload := &common.Payload{
+		Header: utils.MakePayloadHeader(chdr, shdr),
+		Data:   data,
+	}
+	payloadBytes, err := proto.Marshal(payload)
+	assert.NoError(t, err)
+	return &common.Envelope{Payload: payloadBytes}
+}
+
+func issueTxEnvelope(t *testing.T, txID string) *common.Envelope {
+	ttx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainIssue{
+					PlainIssue: &token.PlainIssue{
+						Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "coin", Quantity: 10}},
+					},
+				},
+			},
+		},
+	}
+	data, err := proto.Marshal(ttx)
+	assert.NoError(t, err)
+	return tokenTxEnvelope(t, txID, data)
+}
+
+func TestProcessorIssue(t *testing.T) {
+	aclProvider := &mockACLProvider{}
+	processor := &Processor{ACLProvider: aclProvider}
+	simulator := &mockTxSimulator{state: map[string][]byte{}}
+
+	err := processor.GenerateSimulationResults(issueTxEnvelope(t, "tx1"), simulator, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{resources.Token_Issue}, aclProvider.resources)
+	assert.Len(t, simulator.state, 1)
+}
+
+func TestProcessorIssueNotAllowed(t *testing.T) {
+	aclProvider := &mockACLProvider{err: errors.New("access denied")}
+	processor := &Processor{ACLProvider: aclProvider}
+	simulator := &mockTxSimulator{state: map[string][]byte{}}
+
+	err := processor.GenerateSimulationResults(issueTxEnvelope(t, "tx1"), simulator, false)
+	assert.IsType(t, &customtx.InvalidTxError{}, err)
+	assert.EqualError(t, err, "creator of token transaction tx1 isn't allowed to issue tokens: access denied")
+	assert.Empty(t, simulator.state)
+
+	// the access control isn't checked again when the ledger is initialized
+	err = processor.GenerateSimulationResults(issueTxEnvelope(t, "tx1"), simulator, true)
+	assert.NoError(t, err)
+	assert.Len(t, aclProvider.resources, 1)
+	assert.Len(t, simulator.state, 1)
+}
+
+func TestProcessorInvalidTransaction(t *testing.T) {
+	processor := &Processor{ACLProvider: &mockACLProvider{}}
+	simulator := &mockTxSimulator{state: map[string][]byte{}}
+
+	err := processor.GenerateSimulationResults(&common.Envelope{Payload: []byte("garbage")}, simulator, false)
+	assert.IsType(t, &customtx.InvalidTxError{}, err)
+
+	err = processor.GenerateSimulationResults(tokenTxEnvelope(t, "tx1", []byte("garbage")), simulator, false)
+	assert.IsType(t, &customtx.InvalidTxError{}, err)
+
+	err = processor.GenerateSimulationResults(tokenTxEnvelope(t, "tx1", nil), simulator, false)
+	assert.IsType(t, &customtx.InvalidTxError{}, err)
+	assert.Contains(t, err.Error(), "unknown action in token transaction tx1")
+	assert.Empty(t, simulator.state)
+}